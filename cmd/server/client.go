@@ -0,0 +1,7 @@
+package main
+
+import "github.com/bear-san/haproxy-configurator/internal/cli"
+
+func init() {
+	rootCmd.AddCommand(cli.NewClientCommand())
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initConfigOutput          string
+	initConfigProbeInterfaces bool
+	initConfigForce           bool
+)
+
+var initConfigCmd = &cobra.Command{
+	Use:   "init-config",
+	Short: "Generate a commented example configuration file",
+	Long: `Generate a fully commented unified configuration file with HAProxy and
+Netplan sections to use as a starting point.
+
+With --probe-interfaces the Netplan interface mappings are pre-filled from the
+subnets currently configured on this host's network interfaces.`,
+	Args: cobra.NoArgs,
+	RunE: runInitConfig,
+}
+
+func init() {
+	initConfigCmd.Flags().StringVarP(&initConfigOutput, "output", "o", "", "File to write the configuration to (default: stdout)")
+	initConfigCmd.Flags().BoolVar(&initConfigProbeInterfaces, "probe-interfaces", false, "Pre-fill interface mappings from the local network interfaces")
+	initConfigCmd.Flags().BoolVar(&initConfigForce, "force", false, "Overwrite the output file if it already exists")
+
+	rootCmd.AddCommand(initConfigCmd)
+}
+
+// runInitConfig renders the example configuration to stdout or the output file
+func runInitConfig(cmd *cobra.Command, _ []string) error {
+	data := initConfigData{
+		InterfaceMappings: exampleInterfaceMappings,
+	}
+
+	if initConfigProbeInterfaces {
+		mappings, err := config.DetectInterfaceMappings()
+		if err != nil {
+			return err
+		}
+		if len(mappings) == 0 {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No configured interfaces found, using example interface mappings")
+		} else {
+			data.InterfaceMappings = mappings
+			data.Probed = true
+		}
+	}
+
+	if initConfigOutput == "" {
+		return renderInitConfig(cmd.OutOrStdout(), data)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if initConfigForce {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	// The file may end up holding credentials, so keep it private
+	file, err := os.OpenFile(initConfigOutput, flags, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", initConfigOutput)
+		}
+		return fmt.Errorf("failed to create %s: %w", initConfigOutput, err)
+	}
+
+	if err := renderInitConfig(file, data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", initConfigOutput, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote configuration to %s\n", initConfigOutput)
+	return nil
+}
+
+// initConfigData is the input of the configuration template
+type initConfigData struct {
+	InterfaceMappings []config.InterfaceMapping
+	Probed            bool
+}
+
+// exampleInterfaceMappings are used when interfaces are not probed
+var exampleInterfaceMappings = []config.InterfaceMapping{
+	{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}},
+	{Interface: "vlan100@eth0", Subnets: []string{"10.100.0.0/24"}},
+}
+
+// renderInitConfig writes the commented configuration template
+func renderInitConfig(w io.Writer, data initConfigData) error {
+	if err := initConfigTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render configuration: %w", err)
+	}
+	return nil
+}
+
+var initConfigTemplate = template.Must(template.New("config").Parse(`# HAProxy Configurator unified configuration file
+# Generated by "haproxy-configurator init-config"
+#
+# Values may reference environment variables as ${VAR} or ${VAR:-default},
+# e.g. password: "${DATAPLANE_PASSWORD}"
+
+# HAProxy Data Plane API configuration
+haproxy:
+  # Base URL of the Data Plane API
+  api_url: "http://localhost:5555"
+
+  # Basic auth credentials of the Data Plane API user
+  username: "admin"
+  password: "admin"
+  # Alternatively read them from files, e.g. mounted Kubernetes secrets
+  # username_file: "/var/run/secrets/dataplane/username"
+  # password_file: "/var/run/secrets/dataplane/password"
+
+  # Fail fast with UNAVAILABLE while the Data Plane API is persistently down
+  circuit_breaker:
+    # Consecutive connection/5xx failures before the circuit opens
+    failure_threshold: 5
+    # Seconds the circuit stays open before a probe request is let through
+    open_seconds: 30
+
+  # Seconds allowed for a single Data Plane API request
+  request_timeout_seconds: 30
+
+  # Retry idempotent requests failing with connection errors or 502/503/504
+  retry:
+    max_retries: 2
+    initial_backoff_ms: 200
+    max_backoff_ms: 2000
+    # Overrides for reads (GET) and writes (PUT, DELETE)
+    # writes:
+    #   max_retries: 1
+
+# Additional HAProxy instances, selected per call with the "x-haproxy-instance"
+# gRPC metadata key; the haproxy section above is the "default" instance
+# haproxy_instances:
+#   - name: "edge-2"
+#     api_url: "http://10.0.0.12:5555"
+#     username: "admin"
+#     password: "admin"
+
+# Netplan integration: bind addresses are added to and removed from the
+# interface whose subnet contains them. Remove this section to disable it.
+netplan:
+  interface_mappings:
+{{- if .Probed}}
+    # Detected from the interfaces configured on this host; review before use
+{{- end}}
+{{- range .InterfaceMappings}}
+    - interface: "{{.Interface}}"
+      subnets:
+{{- range .Subnets}}
+        - "{{.}}"
+{{- end}}
+{{- end}}
+
+  # Netplan file managed by the configurator
+  netplan_config_path: "/etc/netplan/99-haproxy-configurator.yaml"
+
+  # Back up the Netplan file before every change
+  backup_enabled: true
+
+  # Write changes without checking them with netplan generate first
+  # skip_generate: true
+
+  # Directory holding pending Netplan transactions
+  transaction_dir: "/var/lib/haproxy-configurator/netplan-transactions"
+
+  # Reject binds whose address is in none of the subnets above
+  # strict_address_validation: true
+
+  # warn: log Netplan errors of the bind and commit RPCs; fail: also return them to the caller
+  # failure_policy: "warn"
+
+  # Check with arping that no other host uses the VIP of a new bind
+  # arp_probe: true
+
+  # Report VIPs left without a bind every interval, and remove them if requested
+  # orphan_cleanup:
+  #   interval_seconds: 3600
+  #   remove: false
+
+# Log output
+logging:
+  # debug, info, warn or error
+  level: "info"
+  # json or console
+  format: "json"
+  # "stdout", "stderr" or file paths
+  output_paths:
+    - "stdout"
+  error_output_paths:
+    - "stderr"
+  # Leave request bodies and Netplan files out of the log
+  # disable_payloads: true
+
+# Record every configuration change for ListEvents and auditing
+# journal:
+#   path: "/var/lib/haproxy-configurator/journal.db"
+#   retention_days: 90
+
+# Forward every configuration change to syslog or journald
+# audit:
+#   target: "journald"
+
+# Notify HTTP endpoints or Slack about transaction events (transaction_committed,
+# transaction_failed, rollback, netplan_failed, replication_failed, drift_detected)
+# webhooks:
+#   - url: "https://hooks.example.com/haproxy"
+#     events: ["transaction_failed", "netplan_failed"]
+#   - type: "slack"
+#     url: "https://hooks.slack.com/services/T000/B000/XXXX"
+#     events: ["transaction_failed", "rollback"]
+
+# Fetch credentials and the gRPC TLS certificate from HashiCorp Vault
+# vault:
+#   address: "https://vault.example.com:8200"
+#   auth:
+#     method: "kubernetes"
+#     role: "haproxy-configurator"
+#   haproxy_credentials:
+#     path: "secret/data/haproxy/dataplane"
+`))
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestRenderInitConfigIsValid(t *testing.T) {
+	probed := initConfigData{
+		InterfaceMappings: []config.InterfaceMapping{
+			{Interface: "ens3", Subnets: []string{"203.0.113.0/24", "2001:db8::/64"}},
+		},
+		Probed: true,
+	}
+
+	for name, data := range map[string]initConfigData{
+		"example": {InterfaceMappings: exampleInterfaceMappings},
+		"probed":  probed,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderInitConfig(&buf, data); err != nil {
+				t.Fatalf("renderInitConfig failed: %v", err)
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(path)
+			if err != nil {
+				t.Fatalf("Generated config does not load: %v\n%s", err, buf.String())
+			}
+			if err := cfg.ValidateConfig(); err != nil {
+				t.Fatalf("Generated config is invalid: %v", err)
+			}
+
+			if len(cfg.Netplan.InterfaceMappings) != len(data.InterfaceMappings) {
+				t.Errorf("Expected %d interface mappings, got %d", len(data.InterfaceMappings), len(cfg.Netplan.InterfaceMappings))
+			}
+		})
+	}
+}
@@ -1,26 +1,55 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/bear-san/haproxy-configurator/internal/bgp"
 	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/debug"
+	"github.com/bear-san/haproxy-configurator/internal/discovery"
+	"github.com/bear-san/haproxy-configurator/internal/drift"
+	"github.com/bear-san/haproxy-configurator/internal/gateway"
+	"github.com/bear-san/haproxy-configurator/internal/gitops"
+	"github.com/bear-san/haproxy-configurator/internal/kubernetes"
+	"github.com/bear-san/haproxy-configurator/internal/leader"
 	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"github.com/bear-san/haproxy-configurator/internal/peersync"
 	"github.com/bear-san/haproxy-configurator/internal/server"
+	"github.com/bear-san/haproxy-configurator/internal/systemd"
+	"github.com/bear-san/haproxy-configurator/internal/vault"
 	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
 var (
-	port        int
-	listenAddr  string
-	configFile  string
-	development bool
+	port          int
+	listenAddr    string
+	configFile    string
+	development   bool
+	metricsListen string
+	debugListen   string
+	watchConfig   bool
+	httpListen    string
+	grpcWeb       bool
+	httpOrigins   []string
+	dryRun        bool
+	recordDir     string
+	replayDir     string
 )
 
 var rootCmd = &cobra.Command{
@@ -41,6 +70,16 @@ func init() {
 	rootCmd.Flags().StringVarP(&listenAddr, "listen", "l", "0.0.0.0", "The server listen address")
 	rootCmd.Flags().StringVarP(&configFile, "config", "f", "", "Path to the unified configuration file (required)")
 	rootCmd.Flags().BoolVarP(&development, "development", "d", false, "Enable development mode logging")
+	rootCmd.Flags().StringVar(&metricsListen, "metrics-listen", "", "Address to serve Prometheus metrics on (e.g. :9100); disabled if empty")
+	rootCmd.Flags().BoolVar(&watchConfig, "watch-config", false, "Reload the configuration file automatically when it changes (SIGHUP always triggers a reload)")
+	rootCmd.Flags().StringVar(&httpListen, "http-listen", "", "Address to serve the REST gateway and /openapi.json on (e.g. :8080); disabled if empty")
+	rootCmd.Flags().BoolVar(&grpcWeb, "grpc-web", false, "Also accept gRPC-Web calls from browsers on the --http-listen address")
+	rootCmd.Flags().StringSliceVar(&httpOrigins, "http-allowed-origins", nil, "Origins browsers may call the --http-listen address from (CORS); \"*\" allows any origin")
+	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Accept and validate all requests but only log the Data Plane API and Netplan writes")
+	rootCmd.Flags().StringVar(&recordDir, "record-dataplane", "", "Record all Data Plane API requests and responses to one <instance>.jsonl file per instance in this directory")
+	rootCmd.Flags().StringVar(&replayDir, "replay-dataplane", "", "Answer the Data Plane API requests from the recordings in this directory instead of sending them")
+	rootCmd.MarkFlagsMutuallyExclusive("record-dataplane", "replay-dataplane")
+	rootCmd.Flags().StringVar(&debugListen, "debug-listen", "", "Address to serve pprof, expvar and state dumps on (e.g. 127.0.0.1:6060); disabled if empty")
 
 	// Make config flag required
 	if err := rootCmd.MarkFlagRequired("config"); err != nil {
@@ -64,7 +103,7 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	// Construct the listen address
 	listenAddress := fmt.Sprintf("%s:%d", listenAddr, port)
-	
+
 	logger.GetLogger().Info("Starting HAProxy Configurator gRPC server",
 		zap.String("listen_address", listenAddress),
 		zap.String("config_file", configFile),
@@ -78,9 +117,6 @@ func runServer(cmd *cobra.Command, args []string) {
 			zap.Error(err))
 	}
 
-	// Create a new gRPC server
-	s := grpc.NewServer()
-
 	// Load unified configuration file
 	cfg, err := config.LoadConfig(configFile)
 	if err != nil {
@@ -89,6 +125,8 @@ func runServer(cmd *cobra.Command, args []string) {
 			zap.Error(err))
 	}
 
+	applyRecordingFlags(cfg)
+
 	// Validate configuration
 	if err := cfg.ValidateConfig(); err != nil {
 		logger.GetLogger().Fatal("Invalid configuration",
@@ -96,27 +134,548 @@ func runServer(cmd *cobra.Command, args []string) {
 			zap.Error(err))
 	}
 
+	cfg.DryRun = cfg.DryRun || dryRun
+
+	// Re-initialize logger with the output settings from the configuration file
+	if err := logger.InitLoggerWithConfig(development, cfg.Logging); err != nil {
+		logger.GetLogger().Fatal("Failed to initialize logger from configuration",
+			zap.String("config_file", configFile),
+			zap.Error(err))
+	}
+
+	// Fetch secrets from Vault before anything uses them
+	var secrets *vault.Watcher
+	if cfg.HasVault() {
+		secrets = startVault(cfg)
+	}
+
 	logger.GetLogger().Info("Loaded unified configuration",
 		zap.String("config_file", configFile),
 		zap.String("haproxy_url", cfg.HAProxy.APIURL),
-		zap.String("haproxy_username", cfg.HAProxy.Username),
-		zap.Bool("netplan_enabled", cfg.HasNetplanIntegration()))
+		zap.Bool("netplan_enabled", cfg.HasNetplanIntegration()),
+		zap.Int("haproxy_instances", len(cfg.Instances)+1),
+		zap.Bool("vault_enabled", cfg.HasVault()),
+		zap.Bool("dry_run", cfg.DryRun),
+		zap.String("dataplane_recording", cfg.DataplaneRecording.Mode))
 
-	// Create and register the HAProxy manager service
+	// Create a new gRPC server, serving TLS with the certificate from Vault if configured
+	var tlsConfig *tls.Config
+	var serverOptions []grpc.ServerOption
+	if secrets != nil && cfg.Vault.GRPCTLS.Path != "" {
+		tlsConfig = &tls.Config{
+			GetCertificate: secrets.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	// Create and register the HAProxy manager service, routing calls to the HAProxy instance they select
 	haproxyService := server.NewHAProxyManagerServerWithConfig(cfg)
+	interceptors := grpc.ChainUnaryInterceptor(haproxyService.UnaryLoggingInterceptor(), haproxyService.UnaryLeaderInterceptor(),
+		haproxyService.UnaryFreezeInterceptor(), haproxyService.UnaryInstanceInterceptor(), haproxyService.UnaryIdempotencyInterceptor())
+	streamInterceptors := grpc.ChainStreamInterceptor(haproxyService.StreamLoggingInterceptor(), haproxyService.StreamLeaderInterceptor(),
+		haproxyService.StreamFreezeInterceptor())
+	serverOptions = append(serverOptions, interceptors, streamInterceptors)
+	s := grpc.NewServer(serverOptions...)
+
+	// Apply rotated Data Plane API credentials without a restart
+	if secrets != nil && cfg.Vault.HAProxyCredentials.Path != "" {
+		secrets.OnCredentialsChange(func(c vault.Credentials) {
+			haproxyService.SetDataplaneCredentials(c.Username, c.Password)
+		})
+	}
 
 	pb.RegisterHAProxyManagerServiceServer(s, haproxyService)
 
+	// Reload the configuration on SIGHUP, ReloadConfig and, if requested, when the file changes
+	startConfigReloader(haproxyService, secrets)
+
+	// Only make changes while elected leader if redundant instances are configured
+	if cfg.HasLeaderElection() {
+		startLeaderElection(cfg.LeaderElection, haproxyService)
+	}
+
+	// Copy the tracked VIPs and the journal of the leader while on standby if configured
+	if cfg.HasPeerSync() {
+		startPeerSync(cfg.PeerSync, haproxyService)
+	}
+
+	// Announce the VIPs over BGP if configured
+	if cfg.HasBGP() {
+		startBGP(cfg.BGP, haproxyService)
+	}
+
+	// Import the open transactions, tracked VIPs and bind index from the live configuration before anything makes
+	// changes; if HAProxy is not up yet, the import is retried in the background
+	startupErr := verifyStartup(haproxyService, 10*time.Second)
+
+	// Reconcile from GitOps manifests if configured
+	if cfg.HasGitOps() {
+		startGitOps(cfg.GitOps, haproxyService)
+	}
+
+	// Sync backend servers from service discovery if configured
+	if cfg.HasDiscovery() {
+		startDiscovery(cfg.Discovery, haproxyService)
+	}
+
+	// Compare the live configuration with the desired state if configured
+	if cfg.HasDriftDetection() {
+		startDriftDetection(cfg.DriftDetection, haproxyService)
+	}
+
+	// Search for VIPs left without a bind if configured
+	if cfg.HasNetplanIntegration() && cfg.Netplan.OrphanCleanup.IntervalSeconds > 0 {
+		startOrphanCleanup(cfg.Netplan.OrphanCleanup, haproxyService)
+	}
+
+	// Reconcile the Kubernetes custom resources and Services of type LoadBalancer if configured
+	if cfg.HasKubernetesOperator() || cfg.HasKubernetesLoadBalancer() {
+		startKubernetesController(cfg.Kubernetes, haproxyService)
+	}
+
 	// Enable reflection for development/debugging
 	reflection.Register(s)
 
+	// Serve Prometheus metrics if requested
+	if metricsListen != "" {
+		startMetricsServer(metricsListen)
+	}
+
+	// Serve the REST gateway and gRPC-Web if requested
+	if httpListen != "" {
+		startGatewayServer(httpListen, haproxyService, tlsConfig, interceptors, streamInterceptors)
+	} else if grpcWeb {
+		logger.GetLogger().Warn("--grpc-web has no effect without --http-listen")
+	}
+
+	// Serve runtime diagnostics if requested
+	if debugListen != "" {
+		startDebugServer(debugListen, haproxyService)
+	}
+
 	logger.GetLogger().Info("HAProxy Configurator gRPC server ready",
 		zap.String("listen_address", listenAddress),
 		zap.String("example_command", fmt.Sprintf("grpcurl -plaintext localhost:%d list", port)))
 
+	// Tell systemd once the Data Plane API answers and the Netplan state is reconciled
+	go notifyReady(haproxyService, startupErr)
+
 	// Start serving
 	if err := s.Serve(lis); err != nil {
 		logger.GetLogger().Fatal("Failed to serve",
 			zap.Error(err))
 	}
-}
\ No newline at end of file
+}
+
+// applyRecordingFlags lets --record-dataplane and --replay-dataplane take precedence over dataplane_recording
+func applyRecordingFlags(cfg *config.Config) {
+	switch {
+	case recordDir != "":
+		cfg.DataplaneRecording = config.DataplaneRecordingSettings{Mode: "record", Dir: recordDir}
+	case replayDir != "":
+		cfg.DataplaneRecording = config.DataplaneRecordingSettings{Mode: "replay", Dir: replayDir}
+	}
+}
+
+// verifyStartup runs the startup checks once, giving up after timeout
+func verifyStartup(haproxyService *server.HAProxyManagerServer, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return haproxyService.VerifyStartup(ctx)
+}
+
+// notifyReady retries the startup checks until they pass, starting from the outcome of the first run, then
+// reports readiness to systemd and starts the watchdog pings if the unit sets WatchdogSec. Without systemd, only
+// the checks run.
+func notifyReady(haproxyService *server.HAProxyManagerServer, err error) {
+	backoff := time.Second
+	for err != nil {
+		logger.GetLogger().Warn("Startup checks failed, retrying",
+			zap.Duration("retry_in", backoff),
+			zap.Error(err))
+		if _, err := systemd.Notify(systemd.Status("Waiting for startup checks: " + err.Error())); err != nil {
+			logger.GetLogger().Warn("Failed to notify systemd", zap.Error(err))
+		}
+		time.Sleep(backoff)
+		backoff = min(backoff*2, 30*time.Second)
+		err = verifyStartup(haproxyService, 30*time.Second)
+	}
+
+	notified, err := systemd.Notify(systemd.Ready + "\n" + systemd.Status("Serving"))
+	if err != nil {
+		logger.GetLogger().Error("Failed to notify systemd of readiness", zap.Error(err))
+		return
+	}
+	if !notified {
+		return
+	}
+	logger.GetLogger().Info("Notified systemd of readiness")
+
+	timeout, err := systemd.WatchdogTimeout()
+	if err != nil {
+		logger.GetLogger().Error("Invalid systemd watchdog settings, watchdog disabled", zap.Error(err))
+		return
+	}
+	if timeout > 0 {
+		logger.GetLogger().Info("Pinging systemd watchdog",
+			zap.Duration("watchdog_timeout", timeout))
+		go systemd.RunWatchdog(context.Background(), timeout, haproxyService.Alive)
+	}
+}
+
+// startVault fetches the configured secrets from Vault, applies the Data Plane API
+// credentials to cfg and keeps refreshing all secrets in the background
+func startVault(cfg *config.Config) *vault.Watcher {
+	client, err := vault.NewClient(cfg.Vault)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create vault client",
+			zap.String("address", cfg.Vault.Address),
+			zap.Error(err))
+	}
+
+	watcher := vault.NewWatcher(client, cfg.Vault)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := watcher.Refresh(ctx); err != nil {
+		logger.GetLogger().Fatal("Failed to fetch secrets from vault",
+			zap.String("address", cfg.Vault.Address),
+			zap.Error(err))
+	}
+
+	if cfg.Vault.HAProxyCredentials.Path != "" {
+		credentials := watcher.Credentials()
+		cfg.HAProxy.Username = credentials.Username
+		cfg.HAProxy.Password = credentials.Password
+	}
+
+	logger.GetLogger().Info("Loaded secrets from vault",
+		zap.String("address", cfg.Vault.Address),
+		zap.String("auth_method", cfg.Vault.Auth.Method),
+		zap.String("haproxy_credentials_path", cfg.Vault.HAProxyCredentials.Path),
+		zap.String("grpc_tls_path", cfg.Vault.GRPCTLS.Path),
+		zap.Int("refresh_interval_seconds", cfg.Vault.RefreshIntervalSeconds))
+
+	go watcher.Run(context.Background())
+
+	return watcher
+}
+
+// startConfigReloader reloads the configuration file on SIGHUP, on ReloadConfig and, with --watch-config,
+// whenever it changes
+func startConfigReloader(haproxyService *server.HAProxyManagerServer, secrets *vault.Watcher) {
+	var mutex sync.Mutex
+	reload := func(trigger string) ([]string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return reloadConfig(haproxyService, secrets, trigger)
+	}
+	haproxyService.SetConfigReloader(func() ([]string, error) { return reload("rpc") })
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			reload("sighup")
+		}
+	}()
+
+	if watchConfig {
+		if err := config.WatchFile(context.Background(), configFile, func() { reload("file_change") }); err != nil {
+			logger.GetLogger().Error("Failed to watch configuration file, only SIGHUP will trigger reloads",
+				zap.String("config_file", configFile),
+				zap.Error(err))
+		} else {
+			logger.GetLogger().Info("Watching configuration file for changes",
+				zap.String("config_file", configFile))
+		}
+	}
+}
+
+// reloadConfig loads and validates the configuration file and swaps it into the running server, returning the
+// changed sections that only take effect after a restart. An invalid file is logged and returned, and the active
+// configuration is kept.
+func reloadConfig(haproxyService *server.HAProxyManagerServer, secrets *vault.Watcher, trigger string) ([]string, error) {
+	logger.GetLogger().Info("Reloading configuration",
+		zap.String("config_file", configFile),
+		zap.String("trigger", trigger))
+
+	cfg, err := config.LoadConfig(configFile)
+	if err == nil {
+		applyRecordingFlags(cfg)
+		err = cfg.ValidateConfig()
+	}
+	if err != nil {
+		logger.GetLogger().Error("Failed to reload configuration, keeping the active configuration",
+			zap.String("config_file", configFile),
+			zap.Error(err))
+		return nil, err
+	}
+
+	cfg.DryRun = cfg.DryRun || dryRun
+
+	// Credentials from Vault take precedence over the file
+	if secrets != nil && cfg.Vault.HAProxyCredentials.Path != "" {
+		credentials := secrets.Credentials()
+		cfg.HAProxy.Username = credentials.Username
+		cfg.HAProxy.Password = credentials.Password
+	}
+
+	restartRequired := haproxyService.Reload(cfg)
+
+	logger.GetLogger().Info("Configuration reloaded",
+		zap.String("config_file", configFile),
+		zap.String("haproxy_url", cfg.HAProxy.APIURL),
+		zap.Bool("netplan_enabled", cfg.HasNetplanIntegration()))
+	return restartRequired, nil
+}
+
+// startLeaderElection competes for leadership in the background. On SIGINT or SIGTERM the leadership is
+// released before exiting, so a standby takes over right away. The settings are only read at startup.
+func startLeaderElection(settings config.LeaderElectionSettings, haproxyService *server.HAProxyManagerServer) {
+	election, err := leader.New(settings)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create leader election",
+			zap.Error(err))
+	}
+	haproxyService.SetLeaderElection(election)
+
+	logger.GetLogger().Info("Leader election enabled, serving reads only until elected",
+		zap.String("backend", settings.Backend),
+		zap.String("identity", settings.Identity))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		defer stop()
+		if err := election.Run(ctx); err != nil {
+			logger.GetLogger().Fatal("Leader election failed",
+				zap.Error(err))
+		}
+		logger.GetLogger().Info("Released leadership, shutting down")
+		logger.Sync()
+		os.Exit(0)
+	}()
+}
+
+// startPeerSync copies the state of the leader in the background. The peer sync settings are only read at
+// startup.
+func startPeerSync(settings config.PeerSyncSettings, haproxyService *server.HAProxyManagerServer) {
+	syncer, err := peersync.NewSyncer(settings, haproxyService)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create peer sync",
+			zap.Error(err))
+	}
+	haproxyService.SetPeerSync(syncer)
+
+	logger.GetLogger().Info("Peer sync enabled",
+		zap.Strings("peers", settings.Peers),
+		zap.Int("interval_seconds", settings.IntervalSeconds))
+
+	go syncer.Run(context.Background())
+}
+
+// startBGP runs the BGP announcer in the background. The BGP settings are only read at startup.
+func startBGP(settings config.BGPSettings, haproxyService *server.HAProxyManagerServer) {
+	addresses := haproxyService.BindAddresses
+	if settings.HealthGated {
+		addresses = haproxyService.HealthyBindAddresses
+	}
+	controller, err := bgp.NewController(settings, addresses)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create BGP announcer",
+			zap.Error(err))
+	}
+	haproxyService.SetBGP(controller)
+
+	logger.GetLogger().Info("BGP announcement enabled",
+		zap.Uint32("asn", settings.ASN),
+		zap.Strings("prefixes", settings.Prefixes),
+		zap.Int("interval_seconds", settings.IntervalSeconds),
+		zap.String("method", settings.Method),
+		zap.Bool("health_gated", settings.HealthGated))
+
+	go controller.Run(context.Background())
+}
+
+// startGitOps runs the GitOps controller in the background. The GitOps settings are only read at startup.
+func startGitOps(settings config.GitOpsSettings, haproxyService *server.HAProxyManagerServer) {
+	controller := gitops.NewController(settings, func(ctx context.Context, desired *pb.State) ([]*pb.StateChange, error) {
+		return haproxyService.ApplyState(ctx, settings.Instance, desired, "")
+	})
+	haproxyService.SetGitOps(controller)
+
+	logger.GetLogger().Info("GitOps reconciliation enabled",
+		zap.String("path", settings.Path),
+		zap.String("repository", settings.Repository),
+		zap.String("branch", settings.Branch),
+		zap.String("instance", settings.Instance),
+		zap.Int("interval_seconds", settings.IntervalSeconds))
+
+	go controller.Run(context.Background())
+}
+
+// startDiscovery syncs the servers of backends from service discovery in the background. The discovery settings
+// are only read at startup.
+func startDiscovery(settings config.DiscoverySettings, haproxyService *server.HAProxyManagerServer) {
+	sources := append(discovery.NewConsulSources(settings.Consul), discovery.NewFileSources(settings.Files)...)
+	if len(settings.Kubernetes.Backends) > 0 {
+		client, err := kubernetes.NewDynamicClient(settings.Kubernetes.Kubeconfig)
+		if err != nil {
+			logger.GetLogger().Fatal("Failed to connect to Kubernetes",
+				zap.Error(err))
+		}
+		sources = append(sources, discovery.NewEndpointSliceSources(client, settings.Kubernetes)...)
+	}
+
+	manager := discovery.NewManager(sources, time.Duration(settings.ResyncIntervalSeconds)*time.Second,
+		func(ctx context.Context, backend string, servers []*pb.Server, unhealthy []string) ([]*pb.StateChange, error) {
+			return haproxyService.SyncServers(ctx, settings.Instance, backend, servers, unhealthy)
+		})
+	haproxyService.SetDiscovery(manager)
+
+	logger.GetLogger().Info("Service discovery enabled",
+		zap.Int("consul_services", len(settings.Consul.Services)),
+		zap.Int("kubernetes_backends", len(settings.Kubernetes.Backends)),
+		zap.Int("files", len(settings.Files)),
+		zap.String("instance", settings.Instance),
+		zap.Int("resync_interval_seconds", settings.ResyncIntervalSeconds))
+
+	go manager.Run(context.Background())
+}
+
+// startDriftDetection compares the live configuration with the desired state in the background. The drift
+// detection settings are only read at startup.
+func startDriftDetection(settings config.DriftDetectionSettings, haproxyService *server.HAProxyManagerServer) {
+	detector := drift.NewDetector(settings, haproxyService)
+	haproxyService.SetDrift(detector)
+
+	logger.GetLogger().Info("Drift detection enabled",
+		zap.String("desired_state", settings.DesiredState),
+		zap.Bool("record", settings.Record),
+		zap.Bool("remediate", settings.Remediate),
+		zap.String("instance", settings.Instance),
+		zap.Int("interval_seconds", settings.IntervalSeconds))
+
+	go detector.Run(context.Background())
+}
+
+// startOrphanCleanup searches for orphaned VIPs in the background. The interval is only read at startup.
+func startOrphanCleanup(settings config.OrphanCleanupSettings, haproxyService *server.HAProxyManagerServer) {
+	logger.GetLogger().Info("Orphaned VIP cleanup enabled",
+		zap.Bool("remove", settings.Remove),
+		zap.Int("interval_seconds", settings.IntervalSeconds))
+
+	go haproxyService.RunOrphanCleanup(context.Background(), time.Duration(settings.IntervalSeconds)*time.Second)
+}
+
+// startKubernetesController runs the Kubernetes operator and/or LoadBalancer controller in the background.
+// The settings are only read at startup.
+func startKubernetesController(settings config.KubernetesSettings, haproxyService *server.HAProxyManagerServer) {
+	client, err := kubernetes.NewDynamicClient(settings.Kubeconfig)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to connect to Kubernetes",
+			zap.Error(err))
+	}
+
+	controller, err := kubernetes.NewController(client, settings,
+		func(ctx context.Context, desired *pb.State, prefix string) ([]*pb.StateChange, error) {
+			return haproxyService.ApplyState(ctx, settings.Instance, desired, prefix)
+		})
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create Kubernetes controller",
+			zap.Error(err))
+	}
+
+	logger.GetLogger().Info("Kubernetes integration enabled",
+		zap.String("namespace", settings.Namespace),
+		zap.String("instance", settings.Instance),
+		zap.Bool("operator", settings.Operator),
+		zap.Bool("load_balancer", settings.LoadBalancer.Enabled),
+		zap.Int("resync_interval_seconds", settings.ResyncIntervalSeconds))
+
+	go func() {
+		if err := controller.Run(context.Background()); err != nil {
+			logger.GetLogger().Error("Kubernetes controller stopped",
+				zap.Error(err))
+		}
+	}()
+}
+
+// startMetricsServer serves the Prometheus metrics endpoint in the background
+func startMetricsServer(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	logger.GetLogger().Info("Serving Prometheus metrics",
+		zap.String("listen_address", address),
+		zap.String("path", "/metrics"))
+
+	go func() {
+		if err := http.ListenAndServe(address, mux); err != nil {
+			logger.GetLogger().Error("Metrics server stopped",
+				zap.String("listen_address", address),
+				zap.Error(err))
+		}
+	}()
+}
+
+// startGatewayServer serves the REST gateway, its OpenAPI document and optionally gRPC-Web
+// in the background, using the gRPC server's TLS configuration if there is one
+func startGatewayServer(address string, haproxyService *server.HAProxyManagerServer, tlsConfig *tls.Config, opts ...grpc.ServerOption) {
+	handler, err := gateway.New(haproxyService, gateway.Options{
+		ForwardHeaders: []string{server.InstanceMetadataKey, server.IdempotencyKeyMetadataKey, server.RequestIDMetadataKey},
+		GRPCWeb:        grpcWeb,
+		AllowedOrigins: httpOrigins,
+	}, opts...)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to create REST gateway",
+			zap.Error(err))
+	}
+
+	logger.GetLogger().Info("Serving REST gateway",
+		zap.String("listen_address", address),
+		zap.String("openapi_path", gateway.OpenAPIPath),
+		zap.Bool("grpc_web", grpcWeb),
+		zap.Strings("allowed_origins", httpOrigins),
+		zap.Bool("tls", tlsConfig != nil))
+
+	httpServer := &http.Server{
+		Addr:      address,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+	}
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil {
+			logger.GetLogger().Error("REST gateway stopped",
+				zap.String("listen_address", address),
+				zap.Error(err))
+		}
+	}()
+}
+
+// startDebugServer serves pprof, expvar and the state dump endpoint in the background
+func startDebugServer(address string, haproxyService *server.HAProxyManagerServer) {
+	debugServer, err := debug.Start(address, haproxyService.DebugState)
+	if err != nil {
+		logger.GetLogger().Fatal("Failed to start debug server",
+			zap.Error(err))
+	}
+	logger.GetLogger().Warn("Serving debug endpoints; do not expose this address publicly",
+		zap.String("listen_address", debugServer.Addr().String()),
+		zap.Strings("paths", []string{"/debug/pprof/", "/debug/vars", "/debug/dump"}))
+
+	go func() {
+		if err := <-debugServer.Done(); err != nil {
+			logger.GetLogger().Error("Debug server stopped",
+				zap.String("listen_address", address),
+				zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bear-san/haproxy-configurator/internal/gateway"
+	"github.com/spf13/cobra"
+)
+
+var openAPIOutput string
+
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI v3 document of the REST gateway",
+	Long: `Print the OpenAPI v3 document describing the REST gateway, e.g. to generate
+client SDKs without a running server. A running server with --http-listen serves
+the same document at /openapi.json.`,
+	Args: cobra.NoArgs,
+	RunE: runOpenAPI,
+}
+
+func init() {
+	openAPICmd.Flags().StringVarP(&openAPIOutput, "output", "o", "", "File to write the document to (default: stdout)")
+
+	rootCmd.AddCommand(openAPICmd)
+}
+
+// runOpenAPI writes the OpenAPI document to stdout or the output file
+func runOpenAPI(cmd *cobra.Command, _ []string) error {
+	document, err := gateway.OpenAPIDocument()
+	if err != nil {
+		return err
+	}
+	document = append(document, '\n')
+
+	if openAPIOutput == "" {
+		_, err := cmd.OutOrStdout().Write(document)
+		return err
+	}
+	if err := os.WriteFile(openAPIOutput, document, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", openAPIOutput, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/buildinfo"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the build version of the binary",
+	Long: `Print the version, commit, build date and Go version of this binary. Use
+"client info show" to ask a running server, including the version of the Data
+Plane API it is connected to.`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+// runVersion prints the build information of the binary
+func runVersion(cmd *cobra.Command, _ []string) error {
+	info := buildinfo.Get()
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Version:    %s\n", info.Version)
+	if info.Commit != "" {
+		fmt.Fprintf(out, "Commit:     %s\n", info.Commit)
+	}
+	if info.Date != "" {
+		fmt.Fprintf(out, "Built:      %s\n", info.Date)
+	}
+	fmt.Fprintf(out, "Go version: %s\n", info.GoVersion)
+	return nil
+}
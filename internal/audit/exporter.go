@@ -0,0 +1,129 @@
+// Package audit forwards the configuration changes recorded in the event journal to syslog or journald, with
+// the resource, action and transaction of each change as structured fields
+package audit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// defaultTag names the application in syslog messages and journal entries
+const defaultTag = "haproxy-configurator"
+
+// queueSize is the number of events buffered while the target is slow or unreachable
+const queueSize = 1024
+
+// sink writes events to an audit target
+type sink interface {
+	write(event journal.Event) error
+	close() error
+}
+
+// Exporter forwards events to syslog or journald in the background, in the order they are exported
+type Exporter struct {
+	target string
+	sink   sink
+	queue  chan journal.Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewExporter creates an exporter for the configured target and starts forwarding
+func NewExporter(settings config.AuditSettings) (*Exporter, error) {
+	tag := settings.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	var s sink
+	switch settings.Target {
+	case "syslog":
+		facility, ok := facilities[settings.Facility]
+		if !ok {
+			return nil, fmt.Errorf("unknown syslog facility %q", settings.Facility)
+		}
+		s = &syslogSink{network: settings.Network, address: settings.Address, facility: facility, tag: tag}
+	case "journald":
+		address := settings.Address
+		if address == "" {
+			address = journaldSocket
+		}
+		s = &journaldSink{address: address, tag: tag}
+	default:
+		return nil, fmt.Errorf("unknown audit target %q", settings.Target)
+	}
+
+	exporter := &Exporter{
+		target: settings.Target,
+		sink:   s,
+		queue:  make(chan journal.Event, queueSize),
+		done:   make(chan struct{}),
+	}
+	go exporter.run()
+	return exporter, nil
+}
+
+// Export queues an event for forwarding. It never blocks: while the queue is full, events are dropped and
+// counted.
+func (e *Exporter) Export(event journal.Event) {
+	if e == nil {
+		return
+	}
+	select {
+	case e.queue <- event:
+	default:
+		metrics.AuditEventsDropped.WithLabelValues(e.target).Inc()
+		logger.GetLogger().Warn("Audit export queue is full, dropping event",
+			zap.String("target", e.target),
+			zap.String("resource_type", event.ResourceType),
+			zap.String("resource_name", event.ResourceName),
+			zap.String("action", event.Action))
+	}
+}
+
+// Close forwards the queued events and closes the connection to the target
+func (e *Exporter) Close() {
+	if e == nil {
+		return
+	}
+	e.once.Do(func() {
+		close(e.queue)
+		<-e.done
+		_ = e.sink.close()
+	})
+}
+
+// run forwards queued events until the exporter is closed
+func (e *Exporter) run() {
+	defer close(e.done)
+	for event := range e.queue {
+		if err := e.sink.write(event); err != nil {
+			metrics.AuditEventsDropped.WithLabelValues(e.target).Inc()
+			logger.GetLogger().Warn("Failed to export audit event",
+				zap.String("target", e.target),
+				zap.Uint64("event_id", event.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+// summary describes an event in one line, e.g. "create backend app (transaction 0a1b)"
+func summary(event journal.Event) string {
+	text := event.Action + " " + event.ResourceType
+	if event.ResourceName != "" {
+		text += " " + event.ResourceName
+	}
+	if event.ParentName != "" {
+		text += " of " + event.ParentName
+	}
+	if event.TransactionID != "" && event.ResourceType != "transaction" {
+		text += " (transaction " + event.TransactionID + ")"
+	}
+	return text
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+)
+
+// testEvent is a change with a value spanning lines
+var testEvent = journal.Event{
+	ID:            7,
+	Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	ResourceType:  "server",
+	ResourceName:  "web1",
+	ParentName:    "app",
+	Action:        "update",
+	TransactionID: "txn-1",
+	NewValue:      json.RawMessage("{\"name\":\"web1\",\n\"comment\":\"say \\\"hi\\\" ]\"}"),
+}
+
+func TestSyslogExport(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	exporter, err := NewExporter(config.AuditSettings{Target: "syslog", Network: "udp", Address: listener.LocalAddr().String(), Facility: "auth"})
+	if err != nil {
+		t.Fatalf("NewExporter failed: %v", err)
+	}
+	exporter.Export(testEvent)
+	exporter.Close()
+
+	buf := make([]byte, 4096)
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to receive message: %v", err)
+	}
+	message := string(buf[:n])
+
+	// Facility auth (4) and severity notice (5)
+	if !strings.HasPrefix(message, "<37>1 2026-01-02T03:04:05Z ") {
+		t.Errorf("Unexpected header in %q", message)
+	}
+	expected := ` haproxy-configurator `
+	if !strings.Contains(message, expected) {
+		t.Errorf("Expected the tag in %q", message)
+	}
+	expected = ` server [change@32473 event_id="7" resource_type="server" resource_name="web1" parent_name="app" action="update" transaction_id="txn-1" new_value="{\"name\":\"web1\",` + "\n" + `\"comment\":\"say \\\"hi\\\" \]\"}"] update server web1 of app (transaction txn-1)`
+	if !strings.HasSuffix(message, expected) {
+		t.Errorf("Expected message to end with %q, got %q", expected, message)
+	}
+}
+
+func TestSyslogExportTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	exporter, err := NewExporter(config.AuditSettings{Target: "syslog", Network: "tcp", Address: listener.Addr().String(), Tag: "lb"})
+	if err != nil {
+		t.Fatalf("NewExporter failed: %v", err)
+	}
+	exporter.Export(journal.Event{ResourceType: "backend", ResourceName: "app", Action: "create"})
+	exporter.Export(journal.Event{ResourceType: "transaction", ResourceName: "txn-1", Action: "commit", TransactionID: "txn-1"})
+	exporter.Close()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+
+	// Messages are framed by octet counting
+	for _, suffix := range []string{
+		` backend [change@32473 resource_type="backend" resource_name="app" action="create"] create backend app`,
+		` transaction [change@32473 resource_type="transaction" resource_name="txn-1" action="commit" transaction_id="txn-1"] commit transaction txn-1`,
+	} {
+		var length int
+		if _, err := fmt.Fscanf(reader, "%d ", &length); err != nil {
+			t.Fatalf("Failed to read frame length: %v", err)
+		}
+		message := make([]byte, length)
+		if _, err := io.ReadFull(reader, message); err != nil {
+			t.Fatalf("Failed to read frame: %v", err)
+		}
+		if !strings.HasPrefix(string(message), "<133>1 ") || !strings.HasSuffix(string(message), suffix) {
+			t.Errorf("Expected a local0 notice ending with %q, got %q", suffix, message)
+		}
+	}
+}
+
+func TestJournaldExport(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	exporter, err := NewExporter(config.AuditSettings{Target: "journald", Address: socket})
+	if err != nil {
+		t.Fatalf("NewExporter failed: %v", err)
+	}
+	exporter.Export(testEvent)
+	exporter.Close()
+
+	buf := make([]byte, 4096)
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to receive entry: %v", err)
+	}
+
+	var expected bytes.Buffer
+	expected.WriteString("MESSAGE=update server web1 of app (transaction txn-1)\nPRIORITY=5\nSYSLOG_IDENTIFIER=haproxy-configurator\n" +
+		"HAPROXY_EVENT_ID=7\nHAPROXY_RESOURCE_TYPE=server\nHAPROXY_RESOURCE_NAME=web1\nHAPROXY_PARENT_NAME=app\n" +
+		"HAPROXY_ACTION=update\nHAPROXY_TRANSACTION_ID=txn-1\nHAPROXY_NEW_VALUE\n")
+	_ = binary.Write(&expected, binary.LittleEndian, uint64(len(testEvent.NewValue)))
+	expected.WriteString(string(testEvent.NewValue) + "\n")
+	if !bytes.Equal(buf[:n], expected.Bytes()) {
+		t.Errorf("Expected entry %q, got %q", expected.String(), buf[:n])
+	}
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+)
+
+// journaldSocket is the socket of the native journal protocol
+const journaldSocket = "/run/systemd/journal/socket"
+
+// journaldSink sends events to journald over its native protocol, with the fields of the event as journal
+// fields prefixed with HAPROXY_, e.g. HAPROXY_RESOURCE_TYPE
+type journaldSink struct {
+	address string
+	tag     string
+	conn    *net.UnixConn
+}
+
+// write sends an event as a single datagram
+func (s *journaldSink) write(event journal.Event) error {
+	if s.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: s.address, Net: "unixgram"})
+		if err != nil {
+			return fmt.Errorf("failed to connect to journald: %w", err)
+		}
+		s.conn = conn
+	}
+
+	if _, err := s.conn.Write(s.format(event)); err != nil {
+		_ = s.close()
+		return fmt.Errorf("failed to write to journald: %w", err)
+	}
+	return nil
+}
+
+// close closes the connection, if any
+func (s *journaldSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format encodes an event in the native journal protocol
+func (s *journaldSink) format(event journal.Event) []byte {
+	var b bytes.Buffer
+	writeJournalField(&b, "MESSAGE", summary(event))
+	writeJournalField(&b, "PRIORITY", strconv.Itoa(severityNotice))
+	writeJournalField(&b, "SYSLOG_IDENTIFIER", s.tag)
+	for _, f := range eventFields(event) {
+		if f.value != "" {
+			writeJournalField(&b, "HAPROXY_"+strings.ToUpper(f.name), f.value)
+		}
+	}
+	return b.Bytes()
+}
+
+// writeJournalField appends a field, using the binary encoding for values spanning lines
+func writeJournalField(b *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		b.WriteString(name + "=" + value + "\n")
+		return
+	}
+	b.WriteString(name + "\n")
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(value)))
+	b.WriteString(value + "\n")
+}
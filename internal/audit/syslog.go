@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+)
+
+// facilities maps the syslog facility names to their codes; the empty name selects local0
+var facilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7, "uucp": 8,
+	"cron": 9, "authpriv": 10, "ftp": 11, "local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23, "": 16,
+}
+
+// severityNotice is the severity of audit messages: a normal but significant condition
+const severityNotice = 5
+
+// structuredDataID names the structured data element of audit messages. 32473 is the private enterprise
+// number reserved for documentation and examples (RFC 5612).
+const structuredDataID = "change@32473"
+
+// localSockets are the sockets of the local syslog daemon, tried in order
+var localSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// dialTimeout bounds connecting to a remote syslog server
+const dialTimeout = 10 * time.Second
+
+// syslogSink sends events to a syslog server in the RFC 5424 format, with the fields of the event as
+// structured data. Events for the local daemon use the traditional format it expects, with the structured
+// data at the start of the message.
+type syslogSink struct {
+	network  string
+	address  string
+	facility int
+	tag      string
+	conn     net.Conn
+}
+
+// write sends an event, reconnecting once if the connection was lost
+func (s *syslogSink) write(event journal.Event) error {
+	message := s.format(event)
+	if s.conn != nil {
+		if _, err := s.conn.Write(message); err == nil {
+			return nil
+		}
+		_ = s.close()
+	}
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(message); err != nil {
+		_ = s.close()
+		return fmt.Errorf("failed to write to syslog: %w", err)
+	}
+	return nil
+}
+
+// connect dials the syslog server, or the first local socket that accepts a connection
+func (s *syslogSink) connect() error {
+	if s.network != "" {
+		conn, err := net.DialTimeout(s.network, s.address, dialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog at %s: %w", s.address, err)
+		}
+		s.conn = conn
+		return nil
+	}
+
+	var errs []error
+	for _, socket := range localSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.Dial(network, socket)
+			if err == nil {
+				s.conn = conn
+				return nil
+			}
+			errs = append(errs, err)
+		}
+	}
+	return fmt.Errorf("failed to connect to the local syslog daemon: %w", errors.Join(errs...))
+}
+
+// close closes the connection, if any
+func (s *syslogSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format encodes an event as a syslog message for the configured transport
+func (s *syslogSink) format(event journal.Event) []byte {
+	priority := s.facility*8 + severityNotice
+	data := structuredData(event)
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	if s.network == "" {
+		return []byte(fmt.Sprintf("<%d>%s %s[%d]: %s %s\n", priority, timestamp.Format(time.Stamp), s.tag, os.Getpid(), data, summary(event)))
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	messageID := event.ResourceType
+	if messageID == "" {
+		messageID = "-"
+	}
+	message := fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s", priority, timestamp.Format(time.RFC3339Nano), hostname, s.tag,
+		os.Getpid(), messageID, data, summary(event))
+	if s.network == "tcp" {
+		// Octet counting framing (RFC 6587)
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	return []byte(message)
+}
+
+// structuredData encodes the fields of an event as an RFC 5424 structured data element, leaving out empty ones
+func structuredData(event journal.Event) string {
+	var b strings.Builder
+	b.WriteString("[" + structuredDataID)
+	for _, param := range eventFields(event) {
+		if param.value == "" {
+			continue
+		}
+		b.WriteString(" " + param.name + `="` + escapeParam(param.value) + `"`)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// escapeParam escapes the characters RFC 5424 requires escaped in parameter values
+func escapeParam(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// field is a named field of an audit event
+type field struct {
+	name  string
+	value string
+}
+
+// eventFields returns the fields of an event in a fixed order. The event ID is empty without a journal.
+func eventFields(event journal.Event) []field {
+	var id string
+	if event.ID != 0 {
+		id = strconv.FormatUint(event.ID, 10)
+	}
+	return []field{
+		{"event_id", id},
+		{"resource_type", event.ResourceType},
+		{"resource_name", event.ResourceName},
+		{"parent_name", event.ParentName},
+		{"action", event.Action},
+		{"transaction_id", event.TransactionID},
+		{"old_value", string(event.OldValue)},
+		{"new_value", string(event.NewValue)},
+	}
+}
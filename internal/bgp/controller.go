@@ -0,0 +1,189 @@
+// Package bgp announces the VIPs of HAProxy binds as host routes over BGP, for L3 networks where the VIPs are
+// routed to the HAProxy host instead of being assigned to an interface by Netplan
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// AddressFunc returns the bind addresses of the local HAProxy instance that are to be announced
+type AddressFunc func(ctx context.Context) ([]string, error)
+
+// Announcer advertises and withdraws routes
+type Announcer interface {
+	Announced(ctx context.Context) ([]netip.Prefix, error)
+	Announce(ctx context.Context, prefixes []netip.Prefix) error
+	Withdraw(ctx context.Context, prefixes []netip.Prefix) error
+	Describe() string
+}
+
+// Controller keeps the announced host routes in line with the bind addresses. Only routes within the configured
+// prefixes are announced and withdrawn, so other announcements of the router are left alone.
+type Controller struct {
+	announcer Announcer
+	prefixes  []netip.Prefix
+	interval  time.Duration
+	addresses AddressFunc
+	trigger   chan struct{}
+
+	mutex     sync.Mutex // Serializes syncs
+	announced []netip.Prefix
+}
+
+// NewController creates a controller announcing through FRR
+func NewController(settings config.BGPSettings, addresses AddressFunc) (*Controller, error) {
+	var prefixes []netip.Prefix
+	for _, value := range settings.Prefixes {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bgp prefix %q: %w", value, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	var announcer Announcer = NewFRR(settings.VtyshPath, settings.ASN)
+	if settings.Method == "static" {
+		announcer = NewFRRStatic(settings.VtyshPath, settings.StaticNextHop)
+	}
+	return newController(announcer, prefixes, time.Duration(settings.IntervalSeconds)*time.Second, addresses), nil
+}
+
+// newController creates a controller for an arbitrary announcer
+func newController(announcer Announcer, prefixes []netip.Prefix, interval time.Duration, addresses AddressFunc) *Controller {
+	return &Controller{
+		announcer: announcer,
+		prefixes:  prefixes,
+		interval:  interval,
+		addresses: addresses,
+		trigger:   make(chan struct{}, 1),
+	}
+}
+
+// Run syncs immediately and then on every interval or trigger, until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Sync(ctx); err != nil {
+			logger.GetLogger().Error("Failed to sync BGP announcements",
+				zap.String("announcer", c.announcer.Describe()),
+				zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.trigger:
+		}
+	}
+}
+
+// Trigger requests a sync without waiting for the next interval, e.g. after a transaction was committed
+func (c *Controller) Trigger() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Sync announces a host route for every bind address within the prefixes and withdraws the routes of
+// addresses that are no longer bound. If the bind addresses cannot be read, the announcements are kept.
+func (c *Controller) Sync(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	addresses, err := c.addresses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read bind addresses: %w", err)
+	}
+	desired := make(map[netip.Prefix]bool)
+	for _, value := range addresses {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			continue // Wildcards and unix sockets
+		}
+		addr = addr.Unmap()
+		if c.manages(addr) {
+			desired[netip.PrefixFrom(addr, addr.BitLen())] = true
+		}
+	}
+
+	current, err := c.announcer.Announced(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read announced routes: %w", err)
+	}
+	announced := make(map[netip.Prefix]bool)
+	var withdraw []netip.Prefix
+	for _, prefix := range current {
+		if !prefix.IsSingleIP() || !c.manages(prefix.Addr()) {
+			continue
+		}
+		announced[prefix] = true
+		if !desired[prefix] {
+			withdraw = append(withdraw, prefix)
+		}
+	}
+	var announce []netip.Prefix
+	for prefix := range desired {
+		if !announced[prefix] {
+			announce = append(announce, prefix)
+		}
+	}
+	sortPrefixes(announce)
+	sortPrefixes(withdraw)
+
+	if err := c.announcer.Announce(ctx, announce); err != nil {
+		return fmt.Errorf("failed to announce routes: %w", err)
+	}
+	if err := c.announcer.Withdraw(ctx, withdraw); err != nil {
+		return fmt.Errorf("failed to withdraw routes: %w", err)
+	}
+
+	if len(announce) > 0 || len(withdraw) > 0 {
+		logger.GetLogger().Info("Updated BGP announcements",
+			zap.String("announcer", c.announcer.Describe()),
+			zap.Stringers("announced", announce),
+			zap.Stringers("withdrawn", withdraw))
+	}
+
+	c.announced = c.announced[:0]
+	for prefix := range desired {
+		c.announced = append(c.announced, prefix)
+	}
+	sortPrefixes(c.announced)
+	return nil
+}
+
+// Announced returns the routes announced by the last successful sync
+func (c *Controller) Announced() []netip.Prefix {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]netip.Prefix(nil), c.announced...)
+}
+
+// manages reports whether addr lies within the configured prefixes
+func (c *Controller) manages(addr netip.Addr) bool {
+	for _, prefix := range c.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortPrefixes orders prefixes by address
+func sortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		return prefixes[i].Addr().Less(prefixes[j].Addr())
+	})
+}
@@ -0,0 +1,226 @@
+package bgp
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAnnouncer keeps announced routes in memory
+type fakeAnnouncer struct {
+	routes map[netip.Prefix]bool
+}
+
+func (f *fakeAnnouncer) Announced(context.Context) ([]netip.Prefix, error) {
+	var result []netip.Prefix
+	for prefix := range f.routes {
+		result = append(result, prefix)
+	}
+	return result, nil
+}
+
+func (f *fakeAnnouncer) Announce(_ context.Context, prefixes []netip.Prefix) error {
+	for _, prefix := range prefixes {
+		f.routes[prefix] = true
+	}
+	return nil
+}
+
+func (f *fakeAnnouncer) Withdraw(_ context.Context, prefixes []netip.Prefix) error {
+	for _, prefix := range prefixes {
+		delete(f.routes, prefix)
+	}
+	return nil
+}
+
+func (f *fakeAnnouncer) Describe() string {
+	return "fake"
+}
+
+func TestSync(t *testing.T) {
+	announcer := &fakeAnnouncer{routes: map[netip.Prefix]bool{
+		netip.MustParsePrefix("192.168.100.9/32"): true, // Stale VIP
+		netip.MustParsePrefix("10.0.0.0/8"):       true, // Not managed
+		netip.MustParsePrefix("172.16.0.1/32"):    true, // Not managed
+	}}
+	addresses := []string{"192.168.100.10", "192.168.100.10", "2001:db8::10", "172.16.0.2", "*", ""}
+	controller := newController(announcer,
+		[]netip.Prefix{netip.MustParsePrefix("192.168.100.0/24"), netip.MustParsePrefix("2001:db8::/64")},
+		time.Minute,
+		func(context.Context) ([]string, error) { return addresses, nil })
+
+	if err := controller.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	expected := map[netip.Prefix]bool{
+		netip.MustParsePrefix("192.168.100.10/32"): true,
+		netip.MustParsePrefix("2001:db8::10/128"):  true,
+		netip.MustParsePrefix("10.0.0.0/8"):        true,
+		netip.MustParsePrefix("172.16.0.1/32"):     true,
+	}
+	if !reflect.DeepEqual(announcer.routes, expected) {
+		t.Errorf("Expected routes %v, got %v", expected, announcer.routes)
+	}
+	if announced := controller.Announced(); len(announced) != 2 || announced[0].String() != "192.168.100.10/32" {
+		t.Errorf("Unexpected announced routes %v", announced)
+	}
+}
+
+func TestSyncKeepsRoutesWhenAddressesAreUnavailable(t *testing.T) {
+	vip := netip.MustParsePrefix("192.168.100.10/32")
+	announcer := &fakeAnnouncer{routes: map[netip.Prefix]bool{vip: true}}
+	controller := newController(announcer, []netip.Prefix{netip.MustParsePrefix("192.168.100.0/24")}, time.Minute,
+		func(context.Context) ([]string, error) { return nil, errors.New("connection refused") })
+
+	if err := controller.Sync(context.Background()); err == nil {
+		t.Fatal("Expected Sync to fail")
+	}
+	if !announcer.routes[vip] {
+		t.Error("Expected the route to stay announced")
+	}
+}
+
+// fakeVtysh installs a vtysh stand-in that logs its arguments and prints a running configuration
+func fakeVtysh(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	config := filepath.Join(dir, "running-config")
+	calls := filepath.Join(dir, "calls")
+	err := os.WriteFile(config, []byte(`frr version 9.1
+hostname lb1
+ip route 10.0.0.0/8 192.168.1.1
+ip route 192.168.100.20/32 Null0
+ipv6 route 2001:db8::20/128 Null0
+!
+vrf blue
+ ip route 192.168.100.21/32 Null0
+exit-vrf
+!
+router bgp 65001
+ no bgp network import-check
+ neighbor 10.0.0.254 remote-as 65000
+ !
+ address-family ipv4 unicast
+  network 192.168.100.10/32
+  network 192.168.100.11/32
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  network 2001:db8::10/128
+ exit-address-family
+exit
+!
+router bgp 65002 vrf blue
+ address-family ipv4 unicast
+  network 192.168.100.12/32
+ exit-address-family
+exit
+!
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := filepath.Join(dir, "vtysh")
+	err = os.WriteFile(script, []byte(`#!/bin/sh
+echo "$@" >> `+calls+`
+if [ "$2" = "show running-config" ]; then cat `+config+`; fi
+`), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script, calls
+}
+
+func TestFRR(t *testing.T) {
+	vtysh, calls := fakeVtysh(t)
+	frr := NewFRR(vtysh, 65001)
+
+	announced, err := frr.Announced(context.Background())
+	if err != nil {
+		t.Fatalf("Announced failed: %v", err)
+	}
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("192.168.100.10/32"),
+		netip.MustParsePrefix("192.168.100.11/32"),
+		netip.MustParsePrefix("2001:db8::10/128"),
+	}
+	if !reflect.DeepEqual(announced, expected) {
+		t.Errorf("Expected %v, got %v", expected, announced)
+	}
+
+	err = frr.Withdraw(context.Background(), []netip.Prefix{
+		netip.MustParsePrefix("192.168.100.11/32"),
+		netip.MustParsePrefix("2001:db8::10/128"),
+	})
+	if err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if err := frr.Announce(context.Background(), nil); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 vtysh calls, got %q", lines)
+	}
+	withdraw := "-c configure terminal -c router bgp 65001" +
+		" -c address-family ipv4 unicast -c no network 192.168.100.11/32 -c exit-address-family" +
+		" -c address-family ipv6 unicast -c no network 2001:db8::10/128 -c exit-address-family -c end"
+	if lines[1] != withdraw {
+		t.Errorf("Unexpected vtysh call %q", lines[1])
+	}
+}
+
+func TestFRRStatic(t *testing.T) {
+	vtysh, calls := fakeVtysh(t)
+	frr := NewFRRStatic(vtysh, "Null0")
+
+	announced, err := frr.Announced(context.Background())
+	if err != nil {
+		t.Fatalf("Announced failed: %v", err)
+	}
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("192.168.100.20/32"),
+		netip.MustParsePrefix("2001:db8::20/128"),
+	}
+	if !reflect.DeepEqual(announced, expected) {
+		t.Errorf("Expected %v, got %v", expected, announced)
+	}
+
+	err = frr.Announce(context.Background(), []netip.Prefix{
+		netip.MustParsePrefix("192.168.100.22/32"),
+		netip.MustParsePrefix("2001:db8::22/128"),
+	})
+	if err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if err := frr.Withdraw(context.Background(), []netip.Prefix{netip.MustParsePrefix("192.168.100.20/32")}); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	expectedCalls := []string{
+		"-c show running-config",
+		"-c configure terminal -c ip route 192.168.100.22/32 Null0 -c ipv6 route 2001:db8::22/128 Null0 -c end",
+		"-c configure terminal -c no ip route 192.168.100.20/32 Null0 -c end",
+	}
+	if !reflect.DeepEqual(lines, expectedCalls) {
+		t.Errorf("Expected vtysh calls %q, got %q", expectedCalls, lines)
+	}
+}
@@ -0,0 +1,193 @@
+package bgp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/netip"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// FRR announces routes through the network statements of a "router bgp" instance of the FRR routing daemon,
+// configured with vtysh. The statements are not saved to the startup configuration; the controller
+// re-announces the VIPs after FRR restarts.
+type FRR struct {
+	vtysh string
+	asn   uint32
+}
+
+// NewFRR creates an announcer for the BGP instance with the given AS
+func NewFRR(vtysh string, asn uint32) *FRR {
+	return &FRR{vtysh: vtysh, asn: asn}
+}
+
+// Describe identifies the BGP instance in logs
+func (f *FRR) Describe() string {
+	return "frr router bgp " + strconv.FormatUint(uint64(f.asn), 10)
+}
+
+// Announced returns the prefixes of the network statements of the BGP instance
+func (f *FRR) Announced(ctx context.Context) ([]netip.Prefix, error) {
+	output, err := run(ctx, f.vtysh, "show running-config")
+	if err != nil {
+		return nil, err
+	}
+	return parseNetworks(output, f.asn), nil
+}
+
+// Announce adds network statements for the prefixes
+func (f *FRR) Announce(ctx context.Context, prefixes []netip.Prefix) error {
+	return f.configure(ctx, prefixes, "network")
+}
+
+// Withdraw removes the network statements of the prefixes
+func (f *FRR) Withdraw(ctx context.Context, prefixes []netip.Prefix) error {
+	return f.configure(ctx, prefixes, "no network")
+}
+
+// configure runs a network command for every prefix in a single vtysh session
+func (f *FRR) configure(ctx context.Context, prefixes []netip.Prefix, command string) error {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	commands := []string{"configure terminal", fmt.Sprintf("router bgp %d", f.asn)}
+	for _, family := range []string{"ipv4", "ipv6"} {
+		var statements []string
+		for _, prefix := range prefixes {
+			if prefix.Addr().Is4() == (family == "ipv4") {
+				statements = append(statements, command+" "+prefix.String())
+			}
+		}
+		if len(statements) > 0 {
+			commands = append(commands, "address-family "+family+" unicast")
+			commands = append(commands, statements...)
+			commands = append(commands, "exit-address-family")
+		}
+	}
+	commands = append(commands, "end")
+
+	_, err := run(ctx, f.vtysh, commands...)
+	return err
+}
+
+// run executes commands with the vtysh binary and returns the output
+func run(ctx context.Context, vtysh string, commands ...string) (string, error) {
+	var args []string
+	for _, command := range commands {
+		args = append(args, "-c", command)
+	}
+	output, err := exec.CommandContext(ctx, vtysh, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("vtysh failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// parseNetworks extracts the network statements of "router bgp <asn>" from a running configuration
+func parseNetworks(config string, asn uint32) []netip.Prefix {
+	header := fmt.Sprintf("router bgp %d", asn)
+
+	var result []netip.Prefix
+	inside := false
+	scanner := bufio.NewScanner(strings.NewReader(config))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Blocks end at the next unindented line, e.g. "exit" or "!"
+		if line != "" && line[0] != ' ' {
+			inside = strings.TrimSpace(line) == header
+			continue
+		}
+		if !inside {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "network" {
+			if prefix, err := netip.ParsePrefix(fields[1]); err == nil {
+				result = append(result, prefix)
+			}
+		}
+	}
+	return result
+}
+
+// FRRStatic announces routes as static routes of the FRR routing daemon, configured with vtysh, which a routing
+// protocol redistributes, e.g. with "redistribute static" in the "router bgp" instance. Only routes to the next hop
+// are considered, so other static routes are left alone. Like the network statements of FRR, the routes are not
+// saved to the startup configuration.
+type FRRStatic struct {
+	vtysh   string
+	nextHop string
+}
+
+// NewFRRStatic creates an announcer adding static routes to nextHop, e.g. "Null0" or an interface
+func NewFRRStatic(vtysh, nextHop string) *FRRStatic {
+	return &FRRStatic{vtysh: vtysh, nextHop: nextHop}
+}
+
+// Describe identifies the next hop of the routes in logs
+func (f *FRRStatic) Describe() string {
+	return "frr static routes to " + f.nextHop
+}
+
+// Announced returns the prefixes of the static routes to the next hop
+func (f *FRRStatic) Announced(ctx context.Context) ([]netip.Prefix, error) {
+	output, err := run(ctx, f.vtysh, "show running-config")
+	if err != nil {
+		return nil, err
+	}
+	return parseStaticRoutes(output, f.nextHop), nil
+}
+
+// Announce adds static routes for the prefixes
+func (f *FRRStatic) Announce(ctx context.Context, prefixes []netip.Prefix) error {
+	return f.configure(ctx, prefixes, "")
+}
+
+// Withdraw removes the static routes of the prefixes
+func (f *FRRStatic) Withdraw(ctx context.Context, prefixes []netip.Prefix) error {
+	return f.configure(ctx, prefixes, "no ")
+}
+
+// configure adds or, with the "no " prefix, removes the static route of every prefix in a single vtysh session
+func (f *FRRStatic) configure(ctx context.Context, prefixes []netip.Prefix, prefix string) error {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	commands := []string{"configure terminal"}
+	for _, route := range prefixes {
+		family := "ip"
+		if !route.Addr().Is4() {
+			family = "ipv6"
+		}
+		commands = append(commands, fmt.Sprintf("%s%s route %s %s", prefix, family, route, f.nextHop))
+	}
+	commands = append(commands, "end")
+
+	_, err := run(ctx, f.vtysh, commands...)
+	return err
+}
+
+// parseStaticRoutes extracts the static routes of the default VRF to nextHop from a running configuration
+func parseStaticRoutes(config, nextHop string) []netip.Prefix {
+	var result []netip.Prefix
+	scanner := bufio.NewScanner(strings.NewReader(config))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Routes of other VRFs are indented within their vrf block
+		if line == "" || line[0] == ' ' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || (fields[0] != "ip" && fields[0] != "ipv6") || fields[1] != "route" || fields[3] != nextHop {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(fields[2]); err == nil {
+			result = append(result, prefix)
+		}
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"github.com/spf13/cobra"
+)
+
+// newApplyCommand creates the command applying manifests in one transaction
+func newApplyCommand(options *clientOptions) *cobra.Command {
+	var files []string
+	var dryRun, prune bool
+
+	cmd := &cobra.Command{
+		Use:   "apply -f FILE...",
+		Short: "Apply manifests of frontends, binds, backends and servers in one transaction",
+		Long: `Apply manifests of frontends, binds, backends and servers in one transaction.
+
+Manifests are state documents as written by "state export", in YAML or JSON. Resources may be
+split across files and directories freely, but each may only be declared once. Resources in the
+manifests are created or replaced; with --prune, everything else is deleted. --dry-run prints
+the planned changes without writing anything. The changes are printed one per line, or as the
+ImportState response with --output json or yaml.
+
+Examples:
+  haproxy-configurator client apply -f haproxy/ --dry-run
+  haproxy-configurator client apply -f backends.yaml -f frontends.yaml
+  haproxy-configurator client state export | haproxy-configurator client apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var out *printer
+			if options.output != "" && options.output != outputTable {
+				var err error
+				if out, err = newPrinter(cmd.OutOrStdout(), options.output); err != nil {
+					return err
+				}
+			}
+			desired, err := readManifests(files, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			conn, err := options.dial()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close() }()
+
+			ctx, cancel := options.callContext(cmd.Context(), false)
+			defer cancel()
+			response, err := pb.NewHAProxyManagerServiceClient(conn).ImportState(ctx, &pb.ImportStateRequest{
+				State:  desired,
+				Prune:  prune,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return err
+			}
+			if out != nil {
+				return out.print(response)
+			}
+			return printPlan(cmd.OutOrStdout(), response, dryRun)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&files, "filename", "f", nil, "Manifest file or directory to apply; - reads stdin. May be repeated")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the planned changes without applying them")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete frontends, binds, backends and servers that are not in the manifests")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+// readManifests reads and merges the manifest files and directories, "-" being stdin
+func readManifests(paths []string, stdin io.Reader) (*pb.State, error) {
+	var documents []*pb.State
+	for _, path := range paths {
+		if path == "-" {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read stdin: %w", err)
+			}
+			document, err := state.DecodeManifest("stdin", data)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+			continue
+		}
+
+		files, err := state.ManifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifest %s: %w", file, err)
+			}
+			document, err := state.DecodeManifest(file, data)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	}
+	return state.Merge(documents...)
+}
+
+// printPlan lists the changes of an apply, one per line, followed by a summary
+func printPlan(w io.Writer, response *pb.ImportStateResponse, dryRun bool) error {
+	if len(response.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes, the configuration already matches the manifests")
+		return err
+	}
+
+	for _, change := range response.Changes {
+		name := change.Name
+		if change.ParentName != "" {
+			name = change.ParentName + "/" + change.Name
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %s\n", change.Action, change.ResourceType, name); err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		_, err := fmt.Fprintf(w, "Dry run: %d to create, %d to update, %d to delete\n", response.Created, response.Updated, response.Deleted)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Applied in transaction %s: %d created, %d updated, %d deleted\n",
+		response.Transaction.GetId(), response.Created, response.Updated, response.Deleted)
+	return err
+}
@@ -0,0 +1,117 @@
+// Package cli implements the client subcommands, which call the gRPC API of a running server
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// instanceMetadataKey selects the HAProxy instance a call is routed to, see server.InstanceMetadataKey
+const instanceMetadataKey = "x-haproxy-instance"
+
+// idempotencyKeyMetadataKey makes a retried call return the original response, see server.IdempotencyKeyMetadataKey
+const idempotencyKeyMetadataKey = "x-idempotency-key"
+
+// clientOptions are the flags shared by all client commands
+type clientOptions struct {
+	server             string
+	instance           string
+	idempotencyKey     string
+	tls                bool
+	caFile             string
+	insecureSkipVerify bool
+	timeout            time.Duration
+	output             string
+}
+
+// NewClientCommand creates the client command group with a subcommand for every RPC
+func NewClientCommand() *cobra.Command {
+	options := &clientOptions{}
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "Call the gRPC API of a running server",
+		Long: `Call the gRPC API of a running server. Request fields are set with flags and
+positional arguments; --data sets the whole request as JSON.
+
+Examples:
+  haproxy-configurator client backend list --server lb1:50051 -o table
+  haproxy-configurator client transaction create --version 42
+  haproxy-configurator client backend create app --mode http --balance roundrobin --transaction-id $TXN
+  haproxy-configurator client server create app app1 --address 10.0.0.1 --port 8080 --transaction-id $TXN
+  haproxy-configurator client transaction commit $TXN
+  haproxy-configurator client apply -f haproxy/ --dry-run`,
+	}
+
+	defaultServer := os.Getenv("HAPROXY_CONFIGURATOR_SERVER")
+	if defaultServer == "" {
+		defaultServer = "localhost:50051"
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&options.server, "server", defaultServer, "Address of the server (env HAPROXY_CONFIGURATOR_SERVER)")
+	flags.StringVar(&options.instance, "instance", os.Getenv("HAPROXY_CONFIGURATOR_INSTANCE"), "HAProxy instance to manage (env HAPROXY_CONFIGURATOR_INSTANCE; default: the server's default instance)")
+	flags.StringVar(&options.idempotencyKey, "idempotency-key", "", "Return the response of an earlier call with the same key instead of applying a change twice")
+	flags.BoolVar(&options.tls, "tls", false, "Connect with TLS")
+	flags.StringVar(&options.caFile, "ca-file", "", "CA certificate to verify the server with (implies --tls)")
+	flags.BoolVar(&options.insecureSkipVerify, "insecure-skip-verify", false, "Do not verify the server certificate (implies --tls)")
+	flags.DurationVar(&options.timeout, "timeout", 30*time.Second, "Timeout of a call; streaming calls are not limited")
+	flags.StringVarP(&options.output, "output", "o", "", "Output format: json (default), yaml or table")
+
+	addRPCCommands(cmd, options)
+	cmd.AddCommand(newApplyCommand(options))
+	cmd.AddCommand(newTUICommand(options))
+	return cmd
+}
+
+// dial connects to the server
+func (o *clientOptions) dial() (*grpc.ClientConn, error) {
+	transport := insecure.NewCredentials()
+	if o.tls || o.caFile != "" || o.insecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: o.insecureSkipVerify,
+		}
+		if o.caFile != "" {
+			pem, err := os.ReadFile(o.caFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %s", o.caFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(o.server, grpc.WithTransportCredentials(transport))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", o.server, err)
+	}
+	return conn, nil
+}
+
+// callContext returns the context of a call, carrying the instance, the idempotency key and, unless streaming, the timeout
+func (o *clientOptions) callContext(parent context.Context, streaming bool) (context.Context, context.CancelFunc) {
+	ctx := parent
+	if o.instance != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, instanceMetadataKey, o.instance)
+	}
+	if o.idempotencyKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, idempotencyKeyMetadataKey, o.idempotencyKey)
+	}
+	if streaming || o.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeService records the last request and instance
+type fakeService struct {
+	pb.UnimplementedHAProxyManagerServiceServer
+	request  proto.Message
+	instance string
+}
+
+func (f *fakeService) record(ctx context.Context, req proto.Message) {
+	f.request = req
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(instanceMetadataKey)) > 0 {
+		f.instance = md.Get(instanceMetadataKey)[0]
+	}
+}
+
+func (f *fakeService) CreateBackend(ctx context.Context, req *pb.CreateBackendRequest) (*pb.CreateBackendResponse, error) {
+	f.record(ctx, req)
+	return &pb.CreateBackendResponse{Backend: req.Backend}, nil
+}
+
+func (f *fakeService) UpdateServer(ctx context.Context, req *pb.UpdateServerRequest) (*pb.UpdateServerResponse, error) {
+	f.record(ctx, req)
+	return &pb.UpdateServerResponse{Server: req.Server}, nil
+}
+
+func (f *fakeService) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	f.record(ctx, req)
+	return &pb.ListEventsResponse{}, nil
+}
+
+func (f *fakeService) ImportState(ctx context.Context, req *pb.ImportStateRequest) (*pb.ImportStateResponse, error) {
+	f.record(ctx, req)
+	response := &pb.ImportStateResponse{Created: 2}
+	for _, backend := range req.State.Backends {
+		response.Changes = append(response.Changes, &pb.StateChange{ResourceType: "backend", Action: "create", Name: backend.Backend.Name})
+		for _, server := range backend.Servers {
+			response.Changes = append(response.Changes, &pb.StateChange{ResourceType: "server", Action: "create", ParentName: backend.Backend.Name, Name: server.Name})
+		}
+	}
+	if !req.DryRun {
+		response.Transaction = &pb.Transaction{Id: "txn-1", Status: "success"}
+	}
+	return response, nil
+}
+
+func (f *fakeService) WatchChanges(req *pb.WatchChangesRequest, stream grpc.ServerStreamingServer[pb.WatchChangesResponse]) error {
+	for _, resourceType := range req.ResourceTypes {
+		if err := stream.Send(&pb.WatchChangesResponse{Event: &pb.Event{ResourceType: resourceType}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startServer serves the fake service on a local port
+func startServer(t *testing.T) (*fakeService, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	service := &fakeService{}
+	server := grpc.NewServer()
+	pb.RegisterHAProxyManagerServiceServer(server, service)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return service, listener.Addr().String()
+}
+
+// run executes a client command and returns its output
+func run(t *testing.T, address string, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewClientCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append(args, "--server", address))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestClientCoversService(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, rpc := range rpcCommands {
+		covered[rpc.method] = true
+	}
+	methods := pb.File_haproxy_proto.Services().ByName("HAProxyManagerService").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		if name := string(methods.Get(i).Name()); !covered[name] {
+			t.Errorf("RPC %s has no client command", name)
+		}
+	}
+}
+
+func TestClientGroupsHaveDescriptions(t *testing.T) {
+	for _, rpc := range rpcCommands {
+		if rpcGroups[rpc.group].short == "" {
+			t.Errorf("Command group %s of RPC %s has no description", rpc.group, rpc.method)
+		}
+	}
+}
+
+func TestClientBuildsRequests(t *testing.T) {
+	service, address := startServer(t)
+
+	out, err := run(t, address, "backend", "create", "app", "--mode", "http", "--balance", "roundrobin",
+		"--labels", "team=payments,env=prod", "--labels", "tier=web", "--transaction-id", "txn-1", "--instance", "edge-2")
+	if err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	expected := &pb.CreateBackendRequest{
+		TransactionId: "txn-1",
+		Backend: &pb.Backend{
+			Name:    "app",
+			Mode:    pb.ProxyMode_PROXY_MODE_HTTP,
+			Balance: &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN},
+			Labels:  map[string]string{"team": "payments", "env": "prod", "tier": "web"},
+		},
+	}
+	if !proto.Equal(service.request, expected) {
+		t.Errorf("Expected request %v, got %v", expected, service.request)
+	}
+	if service.instance != "edge-2" {
+		t.Errorf("Expected the instance to be sent, got %q", service.instance)
+	}
+	if !strings.Contains(out, `"name": "app"`) {
+		t.Errorf("Unexpected output %s", out)
+	}
+
+	// Positional arguments fill both the request name and the resource name; flags override --data
+	out, err = run(t, address, "server", "update", "app", "app1", "--port", "9090",
+		"--data", `{"server": {"address": "10.0.0.1", "port": 8080}}`)
+	if err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	expectedServer := &pb.UpdateServerRequest{
+		BackendName: "app",
+		Name:        "app1",
+		Server:      &pb.Server{Name: "app1", Address: "10.0.0.1", Port: 9090},
+	}
+	if !proto.Equal(service.request, expectedServer) {
+		t.Errorf("Expected request %v, got %v", expectedServer, service.request)
+	}
+
+	if out, err = run(t, address, "event", "list", "--since", "1h", "--limit", "5"); err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	events := service.request.(*pb.ListEventsRequest)
+	if events.Since == nil || events.Limit != 5 {
+		t.Errorf("Unexpected request %v", events)
+	}
+}
+
+func TestClientStreams(t *testing.T) {
+	_, address := startServer(t)
+
+	out, err := run(t, address, "event", "watch", "--resource-types", "backend,server")
+	if err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	if strings.Count(out, `"resource_type"`) != 2 {
+		t.Errorf("Expected 2 streamed events, got %s", out)
+	}
+}
+
+func TestClientRejectsInvalidValues(t *testing.T) {
+	_, address := startServer(t)
+
+	_, err := run(t, address, "backend", "create", "app", "--mode", "udp")
+	if err == nil || !strings.Contains(err.Error(), "tcp, http") {
+		t.Errorf("Expected the valid modes in the error, got %v", err)
+	}
+}
+
+func TestClientApply(t *testing.T) {
+	service, address := startServer(t)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(manifest, []byte("backends:\n  - backend:\n      name: app\n    servers:\n      - name: app1\n        address: 10.0.0.1\n        port: 8080\n"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	out, err := run(t, address, "apply", "-f", dir, "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	request := service.request.(*pb.ImportStateRequest)
+	if !request.DryRun || request.Prune || len(request.State.Backends) != 1 {
+		t.Errorf("Unexpected request %v", request)
+	}
+	expected := "create backend app\ncreate server app/app1\nDry run: 2 to create, 0 to update, 0 to delete\n"
+	if out != expected {
+		t.Errorf("Expected output %q, got %q", expected, out)
+	}
+
+	out, err = run(t, address, "apply", "-f", manifest, "--prune")
+	if err != nil {
+		t.Fatalf("Command failed: %v: %s", err, out)
+	}
+	if !service.request.(*pb.ImportStateRequest).Prune || !strings.Contains(out, "Applied in transaction txn-1: 2 created") {
+		t.Errorf("Unexpected output %s", out)
+	}
+
+	out, err = run(t, address, "apply", "-f", manifest, "-o", "yaml")
+	if err != nil || !strings.Contains(out, "created: 2\n") {
+		t.Errorf("Expected the response as YAML, got %v: %s", err, out)
+	}
+
+	// The same resource in two manifests is rejected before anything is sent
+	if _, err := run(t, address, "apply", "-f", dir, "-f", manifest); err == nil || !strings.Contains(err.Error(), "duplicate backend app") {
+		t.Errorf("Expected a duplicate backend error, got %v", err)
+	}
+}
+
+func TestRequestFlagNames(t *testing.T) {
+	input := (&pb.UpdateBindRequest{}).ProtoReflect().Descriptor()
+	var names []string
+	for _, flag := range requestFlags(input, map[string]bool{"frontend_name": true, "bind.name": true}) {
+		names = append(names, flag.name)
+	}
+	expected := "accept-proxy,address,annotations,expected-version,labels,port,ssl,ssl-certificate,transaction-id,v4v6,v6only"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Expected flags %s, got %v", expected, names)
+	}
+}
@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Output formats selected with --output
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+// column is a table column showing a field of a row, given as a dotted path such as "balance.algorithm"
+type column struct {
+	header string
+	field  string
+}
+
+// tableColumns are the columns of the messages printed as table rows
+var tableColumns = map[protoreflect.FullName][]column{
+	"haproxy.v1.Backend": {
+		{"NAME", "name"}, {"MODE", "mode"}, {"BALANCE", "balance.algorithm"}, {"LABELS", "labels"},
+	},
+	"haproxy.v1.Frontend": {
+		{"NAME", "name"}, {"MODE", "mode"}, {"DEFAULT BACKEND", "default_backend"}, {"DISABLED", "disabled"}, {"DESCRIPTION", "description"}, {"LABELS", "labels"},
+	},
+	"haproxy.v1.Bind": {
+		{"NAME", "name"}, {"ADDRESS", "address"}, {"PORT", "port"}, {"V4V6", "v4v6"}, {"V6ONLY", "v6only"}, {"LABELS", "labels"},
+	},
+	"haproxy.v1.Server": {
+		{"NAME", "name"}, {"ADDRESS", "address"}, {"PORT", "port"}, {"LABELS", "labels"},
+	},
+	"haproxy.v1.Transaction": {
+		{"ID", "id"}, {"STATUS", "status"},
+	},
+	"haproxy.v1.Event": {
+		{"ID", "id"}, {"TIME", "timestamp"}, {"ACTION", "action"}, {"TYPE", "resource_type"}, {"PARENT", "parent_name"}, {"NAME", "resource_name"}, {"TRANSACTION", "transaction_id"},
+	},
+	"haproxy.v1.StateChange": {
+		{"ACTION", "action"}, {"TYPE", "resource_type"}, {"PARENT", "parent_name"}, {"NAME", "name"},
+	},
+	"haproxy.v1.NetplanChange": {
+		{"OPERATION", "operation"}, {"ADDRESS", "ip_address"}, {"INTERFACE", "interface"}, {"SUBNET MASK", "subnet_mask"},
+	},
+	"haproxy.v1.ProxyStats": {
+		{"TYPE", "type"}, {"BACKEND", "backend_name"}, {"NAME", "name"}, {"STATUS", "status"}, {"SESSIONS", "current_sessions"},
+		{"RATE", "session_rate"}, {"TOTAL", "total_sessions"}, {"CHECK", "check_status"},
+	},
+	"haproxy.v1.SetServerStateResponse": {
+		{"ADMIN STATE", "admin_state"}, {"OPERATIONAL STATE", "operational_state"},
+	},
+	"haproxy.v1.TrackedAddress": {
+		{"ADDRESS", "ip_address"}, {"INTERFACE", "interface"}, {"CONFIGURED", "configured"}, {"HOST INTERFACE", "host_interface"},
+	},
+	"haproxy.v1.NetplanTransaction": {
+		{"TRANSACTION", "transaction_id"}, {"STATUS", "status"}, {"CREATED", "created_at"}, {"CHANGES", "changes"}, {"ERROR", "error"},
+	},
+	"haproxy.v1.ReplicaStatus": {
+		{"INSTANCE", "instance"}, {"SYNCED", "synced"}, {"VERSION", "version"}, {"CHANGES", "changes"}, {"LAST SYNC", "last_sync_time"}, {"ERROR", "error"},
+	},
+	"haproxy.v1.PeerStatus": {
+		{"ADDRESS", "address"}, {"IDENTITY", "identity"}, {"LEADER", "leader"}, {"LAST SYNC", "last_sync_time"}, {"LAST EVENT", "last_event_id"}, {"ERROR", "last_error"},
+	},
+	"haproxy.v1.DiscoveredBackend": {
+		{"BACKEND", "backend_name"}, {"SOURCE", "source"}, {"SERVERS", "servers"}, {"HEALTHY", "healthy_servers"}, {"LAST SYNC", "last_sync_time"}, {"ERROR", "last_error"},
+	},
+	"haproxy.v1.GetVersionResponse": {
+		{"VERSION", "version"},
+	},
+	"haproxy.v1.GetStatusResponse": {
+		{"UPTIME", "uptime"}, {"DATAPLANE API", "dataplane_api_reachable"}, {"LATENCY", "dataplane_api_latency"},
+		{"TRANSACTIONS", "open_transactions"}, {"VIPS", "tracked_vips"}, {"LAST COMMIT", "last_commit_time"},
+		{"FROZEN", "freeze.frozen"},
+	},
+	"haproxy.v1.FreezeChangesResponse": {
+		{"FROZEN", "freeze.frozen"}, {"REASON", "freeze.reason"}, {"SINCE", "freeze.since"}, {"UNTIL", "freeze.expire_time"},
+	},
+	"haproxy.v1.GetServerInfoResponse": {
+		{"VERSION", "version"}, {"COMMIT", "commit"}, {"GO", "go_version"}, {"FEATURES", "features"}, {"DATAPLANE API", "dataplane_api_version"},
+	},
+	"haproxy.v1.ExportStateResponse": {
+		{"VERSION", "version"}, {"FRONTENDS", "state.frontends"}, {"BACKENDS", "state.backends"},
+	},
+	"haproxy.v1.GetGitOpsStatusResponse": {
+		{"ENABLED", "enabled"}, {"SOURCE", "source"}, {"REVISION", "revision"}, {"LAST SYNC", "last_sync_time"}, {"LAST ERROR", "last_error"},
+	},
+	"haproxy.v1.GetDriftStatusResponse": {
+		{"ENABLED", "enabled"}, {"INSTANCE", "instance"}, {"DRIFTED", "drifted"}, {"CHANGES", "changes"}, {"LAST CHECK", "last_check_time"}, {"LAST ERROR", "last_error"},
+	},
+	"haproxy.v1.CheckDriftResponse": {
+		{"INSTANCE", "status.instance"}, {"DRIFTED", "status.drifted"}, {"CHANGES", "status.changes"}, {"LAST ERROR", "status.last_error"},
+	},
+}
+
+// printer writes responses in the selected output format
+type printer struct {
+	w       io.Writer
+	format  string
+	printed bool    // Whether a message was printed before, for the separators and headers of streams
+	widths  [][]int // Widths of the columns of each table so far
+}
+
+// columnPadding is the space between table columns
+const columnPadding = 3
+
+// newPrinter creates a printer, rejecting unknown formats; an empty format prints JSON
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "":
+		format = outputJSON
+	case outputJSON, outputYAML, outputTable:
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected %s, %s or %s", format, outputJSON, outputYAML, outputTable)
+	}
+	return &printer{w: w, format: format}, nil
+}
+
+// print writes a message; streams call it once per message
+func (p *printer) print(message proto.Message) error {
+	defer func() { p.printed = true }()
+
+	switch p.format {
+	case outputTable:
+		return p.printTable(message.ProtoReflect())
+	case outputYAML:
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+		if err != nil {
+			return err
+		}
+		document, err := state.JSONToYAML(data)
+		if err != nil {
+			return fmt.Errorf("failed to convert response to YAML: %w", err)
+		}
+		if p.printed {
+			if _, err := fmt.Fprintln(p.w, "---"); err != nil {
+				return err
+			}
+		}
+		_, err = p.w.Write(document)
+		return err
+	default:
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+		if err != nil {
+			return err
+		}
+		// protojson randomizes its whitespace on purpose; indent again for stable output
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, out.String())
+		return err
+	}
+}
+
+// table is the columns and rows of a message printed as a table
+type table struct {
+	columns []column
+	rows    []protoreflect.Message
+}
+
+// printTable writes the tables of a message, separated by blank lines. The headers and the column widths carry
+// over between the messages of a stream, so that its rows line up as long as their values fit.
+func (p *printer) printTable(message protoreflect.Message) error {
+	tables := messageTables(message)
+	if len(p.widths) != len(tables) {
+		p.widths = make([][]int, len(tables))
+	}
+	empty := true
+	for _, t := range tables {
+		empty = empty && len(t.rows) == 0
+	}
+	if empty {
+		// Only the header of the first table is printed, so that an empty list still shows its columns
+		if p.printed || len(tables) == 0 {
+			return nil
+		}
+		tables = tables[:1]
+	}
+
+	separate := false
+	for i, t := range tables {
+		if len(t.rows) == 0 && len(tables) > 1 {
+			continue
+		}
+		if separate {
+			if _, err := fmt.Fprintln(p.w); err != nil {
+				return err
+			}
+		}
+		separate = true
+
+		var lines [][]string
+		if !p.printed {
+			headers := make([]string, len(t.columns))
+			for j, column := range t.columns {
+				headers[j] = column.header
+			}
+			lines = append(lines, headers)
+		}
+		for _, row := range t.rows {
+			cells := make([]string, len(t.columns))
+			for j, column := range t.columns {
+				cells[j] = formatField(row, column.field)
+			}
+			lines = append(lines, cells)
+		}
+		if err := p.writeLines(lines, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLines writes the lines of a table in columns at least as wide as the ones written before
+func (p *printer) writeLines(lines [][]string, index int) error {
+	p.widths[index] = columnWidths(lines, p.widths[index])
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(p.w, alignRow(line, p.widths[index])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnWidths widens the column widths to fit the cells of the lines
+func columnWidths(lines [][]string, widths []int) []int {
+	for _, line := range lines {
+		if len(widths) < len(line) {
+			widths = append(widths, make([]int, len(line)-len(widths))...)
+		}
+		for i, cell := range line {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	return widths
+}
+
+// alignRow pads the cells of a line to the column widths, leaving the last cell unpadded
+func alignRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+		b.WriteString(cell + strings.Repeat(" ", widths[i]-len(cell)+columnPadding))
+	}
+	return b.String()
+}
+
+// messageTables finds the tables of a message: the message itself if it has columns, otherwise a table for
+// every list of such messages or, without lists, its first such message. Messages without any, such as
+// delete responses, have no table.
+func messageTables(message protoreflect.Message) []table {
+	if columns, ok := tableColumns[message.Descriptor().FullName()]; ok {
+		return []table{{columns, []protoreflect.Message{message}}}
+	}
+
+	var tables []table
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !field.IsList() || field.Message() == nil {
+			continue
+		}
+		if columns, ok := tableColumns[field.Message().FullName()]; ok {
+			list := message.Get(field).List()
+			rows := make([]protoreflect.Message, list.Len())
+			for j := range rows {
+				rows[j] = list.Get(j).Message()
+			}
+			tables = append(tables, table{columns, rows})
+		}
+	}
+	if tables != nil {
+		return tables
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsList() || field.Message() == nil {
+			continue
+		}
+		if columns, ok := tableColumns[field.Message().FullName()]; ok {
+			if !message.Has(field) {
+				return []table{{columns: columns}}
+			}
+			return []table{{columns, []protoreflect.Message{message.Get(field).Message()}}}
+		}
+	}
+	return nil
+}
+
+// formatField renders a field of a row for a table cell: enums by their short name, timestamps in local time,
+// lists by their length and other unset fields, except numbers, as "-"
+func formatField(message protoreflect.Message, path string) string {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		field := message.Descriptor().Fields().ByName(protoreflect.Name(part))
+		if !message.Has(field) {
+			return "-"
+		}
+		message = message.Get(field).Message()
+	}
+	field := message.Descriptor().Fields().ByName(protoreflect.Name(parts[len(parts)-1]))
+	value := message.Get(field)
+
+	switch {
+	case field.IsList():
+		return strconv.Itoa(value.List().Len())
+	case field.IsMap():
+		return formatMap(value.Map())
+	case field.Kind() == protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case isNumber(field.Kind()):
+		return fmt.Sprint(value.Interface())
+	case !message.Has(field):
+		return "-"
+	case field.Kind() == protoreflect.EnumKind:
+		enumValue := field.Enum().Values().ByNumber(value.Enum())
+		if enumValue == nil {
+			return strconv.Itoa(int(value.Enum()))
+		}
+		return enumShortName(field.Enum(), string(enumValue.Name()))
+	case field.Kind() == protoreflect.MessageKind && field.Message().FullName() == timestampName:
+		return value.Message().Interface().(*timestamppb.Timestamp).AsTime().Local().Format(time.RFC3339)
+	case field.Kind() == protoreflect.MessageKind && field.Message().FullName() == durationName:
+		return value.Message().Interface().(*durationpb.Duration).AsDuration().String()
+	default:
+		return value.String()
+	}
+}
+
+// formatMap formats the entries of a map as sorted key=value pairs, like labels in kubectl
+func formatMap(entries protoreflect.Map) string {
+	if entries.Len() == 0 {
+		return "-"
+	}
+	var pairs []string
+	entries.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		pairs = append(pairs, key.String()+"="+value.String())
+		return true
+	})
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// isNumber reports whether a field kind is an integer or floating point number
+func isNumber(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// render prints messages with a new printer
+func render(t *testing.T, format string, messages ...proto.Message) string {
+	t.Helper()
+	var out bytes.Buffer
+	p, err := newPrinter(&out, format)
+	if err != nil {
+		t.Fatalf("newPrinter failed: %v", err)
+	}
+	for _, message := range messages {
+		if err := p.print(message); err != nil {
+			t.Fatalf("print failed: %v", err)
+		}
+	}
+	return out.String()
+}
+
+func TestPrintTable(t *testing.T) {
+	out := render(t, outputTable, &pb.ListBackendsResponse{Backends: []*pb.Backend{
+		{Name: "app", Mode: pb.ProxyMode_PROXY_MODE_HTTP, Balance: &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN}},
+		{Name: "db", Mode: pb.ProxyMode_PROXY_MODE_TCP, Labels: map[string]string{"tier": "db", "team": "payments"}},
+	}})
+	expected := "NAME   MODE   BALANCE      LABELS\n" +
+		"app    http   roundrobin   -\n" +
+		"db     tcp    -            team=payments,tier=db\n"
+	if out != expected {
+		t.Errorf("Expected table\n%s\ngot\n%s", expected, out)
+	}
+
+	// Single resources are one row, responses listing changes show the changes
+	if out := render(t, outputTable, &pb.GetServerResponse{Server: &pb.Server{Name: "app1", Address: "10.0.0.1", Port: 8080}}); !strings.Contains(out, "app1   10.0.0.1   8080") {
+		t.Errorf("Unexpected server table\n%s", out)
+	}
+	out = render(t, outputTable, &pb.ImportStateResponse{
+		Transaction: &pb.Transaction{Id: "txn-1"},
+		Changes:     []*pb.StateChange{{ResourceType: "server", Action: "create", ParentName: "app", Name: "app1"}},
+	})
+	if !strings.HasPrefix(out, "ACTION") || !strings.Contains(out, "create   server   app      app1") {
+		t.Errorf("Unexpected change table\n%s", out)
+	}
+
+	// Streams print the header once
+	out = render(t, outputTable, &pb.WatchChangesResponse{Event: &pb.Event{Id: 1}}, &pb.WatchChangesResponse{Event: &pb.Event{Id: 2}})
+	if strings.Count(out, "TIME") != 1 || strings.Count(out, "\n") != 3 {
+		t.Errorf("Unexpected stream table\n%s", out)
+	}
+
+	// Every list of a response is a table of its own; empty lists show their header only if all are empty
+	out = render(t, outputTable, &pb.DiffTransactionResponse{
+		Changes:        []*pb.StateChange{{ResourceType: "bind", Action: "create", ParentName: "web", Name: "vip"}},
+		NetplanChanges: []*pb.NetplanChange{{Operation: "add", IpAddress: "192.168.1.100", Interface: "eth0", SubnetMask: "/24"}},
+	})
+	expected = "ACTION   TYPE   PARENT   NAME\n" +
+		"create   bind   web      vip\n" +
+		"\n" +
+		"OPERATION   ADDRESS         INTERFACE   SUBNET MASK\n" +
+		"add         192.168.1.100   eth0        /24\n"
+	if out != expected {
+		t.Errorf("Expected tables\n%s\ngot\n%s", expected, out)
+	}
+	if out := render(t, outputTable, &pb.DiffTransactionResponse{}); out != "ACTION   TYPE   PARENT   NAME\n" {
+		t.Errorf("Expected the header of the first table, got %q", out)
+	}
+
+	if out := render(t, outputTable, &pb.DeleteBackendResponse{}); out != "" {
+		t.Errorf("Expected no table for an empty response, got %q", out)
+	}
+}
+
+func TestPrintYAML(t *testing.T) {
+	out := render(t, outputYAML, &pb.GetBindResponse{Bind: &pb.Bind{Name: "vip", Address: "192.168.1.100", Port: 443}},
+		&pb.GetVersionResponse{Version: 3})
+	expected := "bind:\n  name: vip\n  address: 192.168.1.100\n  port: 443\n---\nversion: 3\n"
+	if out != expected {
+		t.Errorf("Expected YAML\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestPrinterRejectsUnknownFormat(t *testing.T) {
+	if _, err := newPrinter(&bytes.Buffer{}, "xml"); err == nil || !strings.Contains(err.Error(), "json, yaml or table") {
+		t.Errorf("Expected an error listing the formats, got %v", err)
+	}
+}
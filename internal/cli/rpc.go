@@ -0,0 +1,690 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rpcCommand maps an RPC to "client <group> <verb>"
+type rpcCommand struct {
+	method string
+	group  string
+	verb   string
+	args   []string // Request fields set by the positional arguments, in order; "a,b" sets both fields
+	short  string
+}
+
+// rpcCommands covers every RPC of the HAProxy manager service
+var rpcCommands = []rpcCommand{
+	{"GetVersion", "config", "version", nil, "Show the configuration version"},
+	{"ReloadConfig", "config", "reload", nil, "Reload the configuration file of the server, like SIGHUP"},
+
+	{"FreezeChanges", "changes", "freeze", nil, "Reject all changes until unfrozen or the freeze expires"},
+	{"UnfreezeChanges", "changes", "unfreeze", nil, "Lift the change freeze"},
+
+	{"GetServerInfo", "info", "show", nil, "Show the server build, enabled features and Data Plane API version"},
+	{"GetStatus", "status", "show", nil, "Show uptime, Data Plane API connectivity, open transactions and Netplan state"},
+
+	{"CreateTransaction", "transaction", "create", nil, "Start a transaction"},
+	{"GetTransaction", "transaction", "get", []string{"transaction_id"}, "Show a transaction"},
+	{"ListTransactions", "transaction", "list", nil, "List the open transactions"},
+	{"DiffTransaction", "transaction", "diff", []string{"transaction_id"}, "Show the changes committing a transaction makes, including its Netplan changes"},
+	{"CommitTransaction", "transaction", "commit", []string{"transaction_id"}, "Commit a transaction, including its Netplan changes"},
+	{"CloseTransaction", "transaction", "close", []string{"transaction_id"}, "Discard a transaction"},
+
+	{"CreateBackend", "backend", "create", []string{"backend.name"}, "Create a backend"},
+	{"GetBackend", "backend", "get", []string{"name"}, "Show a backend"},
+	{"ListBackends", "backend", "list", nil, "List backends"},
+	{"StreamBackends", "backend", "stream", nil, "Stream the backends one at a time"},
+	{"UpdateBackend", "backend", "update", []string{"name,backend.name"}, "Replace a backend"},
+	{"DeleteBackend", "backend", "delete", []string{"name"}, "Delete a backend"},
+	{"ShiftTraffic", "backend", "shift-traffic", []string{"backend_name"}, "Move the load of a backend to canary servers in steps, rolling back on errors"},
+	{"ApplyBackend", "backend", "apply", []string{"backend.name"}, "Create or replace a backend"},
+
+	{"CreateFrontend", "frontend", "create", []string{"frontend.name"}, "Create a frontend"},
+	{"GetFrontend", "frontend", "get", []string{"name"}, "Show a frontend"},
+	{"ListFrontends", "frontend", "list", nil, "List frontends"},
+	{"UpdateFrontend", "frontend", "update", []string{"name,frontend.name"}, "Replace a frontend"},
+	{"DeleteFrontend", "frontend", "delete", []string{"name"}, "Delete a frontend"},
+	{"ApplyFrontend", "frontend", "apply", []string{"frontend.name"}, "Create or replace a frontend"},
+	{"CreateHTTPSFrontend", "frontend", "create-https", []string{"name"}, "Create a TLS terminating frontend with its certificate and an optional HTTP redirect"},
+	{"SwapBackends", "frontend", "swap-backends", []string{"frontend_name", "blue", "green"}, "Exchange two backends in the traffic of a frontend for blue/green releases"},
+
+	{"CreateBind", "bind", "create", []string{"frontend_name", "bind.name"}, "Create a bind, assigning its VIP with Netplan"},
+	{"GetBind", "bind", "get", []string{"frontend_name", "name"}, "Show a bind"},
+	{"ListBinds", "bind", "list", []string{"frontend_name"}, "List the binds of a frontend"},
+	{"UpdateBind", "bind", "update", []string{"frontend_name", "bind.name"}, "Replace a bind"},
+	{"DeleteBind", "bind", "delete", []string{"frontend_name", "name"}, "Delete a bind, removing its VIP with Netplan"},
+	{"ApplyBind", "bind", "apply", []string{"frontend_name", "bind.name"}, "Create or replace a bind"},
+
+	{"CreateRoute", "route", "create", []string{"frontend_name", "route.name"}, "Route hostnames of a frontend to a backend by SNI or Host header"},
+	{"GetRoute", "route", "get", []string{"frontend_name", "name"}, "Show a route"},
+	{"ListRoutes", "route", "list", []string{"frontend_name"}, "List the routes of a frontend"},
+	{"UpdateRoute", "route", "update", []string{"frontend_name", "route.name"}, "Replace a route"},
+	{"DeleteRoute", "route", "delete", []string{"frontend_name", "name"}, "Delete a route"},
+	{"CreateRateLimitPolicy", "rate-limit", "create", []string{"frontend_name", "policy.name"}, "Limit the requests of a frontend per client"},
+	{"GetRateLimitPolicy", "rate-limit", "get", []string{"frontend_name", "name"}, "Show a rate limit policy"},
+	{"ListRateLimitPolicies", "rate-limit", "list", []string{"frontend_name"}, "List the rate limit policies of a frontend"},
+	{"UpdateRateLimitPolicy", "rate-limit", "update", []string{"frontend_name", "policy.name"}, "Replace a rate limit policy"},
+	{"DeleteRateLimitPolicy", "rate-limit", "delete", []string{"frontend_name", "name"}, "Delete a rate limit policy"},
+	{"CreateLuaAction", "lua-action", "create", []string{"frontend_name", "action.name"}, "Run an action of a loaded Lua script in a frontend"},
+	{"ListLuaActions", "lua-action", "list", []string{"frontend_name"}, "List the Lua actions of a frontend"},
+	{"DeleteLuaAction", "lua-action", "delete", []string{"frontend_name", "name"}, "Delete a Lua action"},
+
+	{"CreateRing", "ring", "create", []string{"ring.name"}, "Create a ring buffer for logs and traces"},
+	{"GetRing", "ring", "get", []string{"name"}, "Show a ring"},
+	{"ListRings", "ring", "list", nil, "List the rings"},
+	{"UpdateRing", "ring", "update", []string{"ring.name"}, "Replace a ring"},
+	{"DeleteRing", "ring", "delete", []string{"name"}, "Delete a ring no frontend or backend sends logs to"},
+	{"AttachRingLogTarget", "ring", "attach", []string{"target.ring"}, "Send the logs of a frontend or backend to a ring"},
+	{"ListRingLogTargets", "ring", "targets", nil, "List the rings a frontend or backend sends logs to"},
+	{"DetachRingLogTarget", "ring", "detach", []string{"ring"}, "Stop sending the logs of a frontend or backend to a ring"},
+	{"StreamRing", "ring", "stream", []string{"name"}, "Stream the events of a ring, and new ones with --follow"},
+
+	{"CreateHTTPCheck", "http-check", "create", []string{"backend_name"}, "Add an http-check send or expect rule to a backend, enabling HTTP health checks"},
+	{"ListHTTPChecks", "http-check", "list", []string{"backend_name"}, "List the http-check rules of a backend in the order they run"},
+	{"UpdateHTTPCheck", "http-check", "update", []string{"backend_name", "index"}, "Replace the http-check rule of a backend at a position"},
+	{"DeleteHTTPCheck", "http-check", "delete", []string{"backend_name", "index"}, "Delete the http-check rule of a backend at a position"},
+
+	{"CreateProgram", "program", "create", []string{"program.name"}, "Create a program run by the HAProxy master, given with --command"},
+	{"GetProgram", "program", "get", []string{"name"}, "Show a program"},
+	{"ListPrograms", "program", "list", nil, "List the programs"},
+	{"UpdateProgram", "program", "update", []string{"program.name"}, "Replace a program"},
+	{"DeleteProgram", "program", "delete", []string{"name"}, "Delete a program"},
+
+	{"UploadLuaScript", "lua", "upload", []string{"name"}, "Validate and store a new version of a Lua script, given with --content"},
+	{"GetLuaScript", "lua", "get", []string{"name"}, "Show a Lua script with its versions and the content of one of them"},
+	{"ListLuaScripts", "lua", "list", nil, "List the Lua scripts with their versions"},
+	{"LoadLuaScript", "lua", "load", []string{"name"}, "Load a version of a Lua script, the latest by default"},
+	{"RollbackLuaScript", "lua", "rollback", []string{"name"}, "Load an earlier version of a Lua script, the one before the loaded version by default"},
+	{"UnloadLuaScript", "lua", "unload", []string{"name"}, "Stop loading a Lua script"},
+	{"DeleteLuaScript", "lua", "delete", []string{"name"}, "Delete a version of a Lua script, or all of them"},
+
+	{"CreateServer", "server", "create", []string{"backend_name", "server.name"}, "Create a server"},
+	{"GetServer", "server", "get", []string{"backend_name", "name"}, "Show a server"},
+	{"ListServers", "server", "list", []string{"backend_name"}, "List the servers of a backend"},
+	{"StreamServers", "server", "stream", []string{"backend_name"}, "Stream the servers of a backend one at a time"},
+	{"UpdateServer", "server", "update", []string{"backend_name", "name,server.name"}, "Replace a server"},
+	{"DeleteServer", "server", "delete", []string{"backend_name", "name"}, "Delete a server"},
+	{"ApplyServer", "server", "apply", []string{"backend_name", "server.name"}, "Create or replace a server"},
+	{"CreateServers", "server", "create-many", []string{"backend_name"}, "Create several servers, given with --data"},
+	{"DeleteServers", "server", "delete-many", []string{"backend_name", "names"}, "Delete several servers, given as a comma separated list"},
+	{"SetMetadata", "metadata", "set", []string{"resource_type", "name"}, "Replace the labels and annotations of a backend, frontend, bind or server"},
+	{"SetServerState", "server", "state", []string{"backend_name", "name"}, "Set the runtime state of a server to ready, drain or maint"},
+	{"DrainServer", "server", "drain", []string{"backend_name", "name"}, "Drain a server and report its sessions until they end"},
+	{"EnterMaintenance", "maintenance", "enter", []string{"backend_name"}, "Put a backend or one of its servers into maintenance"},
+	{"ExitMaintenance", "maintenance", "exit", []string{"backend_name"}, "Take a backend or one of its servers out of maintenance"},
+	{"ListMaintenance", "maintenance", "list", nil, "List the backends and servers in maintenance"},
+
+	{"GetStats", "stats", "show", nil, "Show the live statistics of frontends, backends and servers"},
+
+	{"ExportState", "state", "export", nil, "Export the whole configuration"},
+	{"ImportState", "state", "import", nil, "Import a state document"},
+	{"ApplyDesiredState", "state", "apply", nil, "Make the configuration match a desired state"},
+	{"RenderPreview", "state", "preview", nil, "Render the haproxy.cfg sections of a transaction or state document without applying anything"},
+
+	{"GetNetplanStatus", "netplan", "status", nil, "Show the VIPs managed through Netplan, whether they are configured on the host, and pending Netplan transactions"},
+	{"GetNetplanTransaction", "netplan", "txn", []string{"transaction_id"}, "Show the Netplan changes of a transaction and why its commit failed, if it did"},
+	{"CleanupOrphanedAddresses", "netplan", "cleanup-orphans", nil, "List the VIPs in the Netplan configuration that no bind listens on, and remove them with --remove"},
+
+	{"GetClusterStatus", "cluster", "status", nil, "Show the outcome of the last replication to every cluster node"},
+	{"SyncCluster", "cluster", "sync", nil, "Replicate the configuration of the default instance to every cluster node now"},
+
+	{"GetPeerState", "peer", "state", nil, "Show the tracked VIPs, pending Netplan transactions and journal events offered to standbys"},
+	{"GetPeerSyncStatus", "peer", "status", nil, "Show the outcome of the last sync with every peer"},
+
+	{"GetGitOpsStatus", "gitops", "status", nil, "Show the progress of GitOps reconciliation"},
+
+	{"GetDiscoveryStatus", "discovery", "status", nil, "Show the backends synced from service discovery"},
+
+	{"GetDriftStatus", "drift", "status", nil, "Show whether the live configuration differs from the desired state"},
+	{"CheckDrift", "drift", "check", nil, "Compare the live configuration with the desired state now"},
+
+	{"ListEvents", "event", "list", nil, "Query the event journal"},
+	{"WatchChanges", "event", "watch", nil, "Stream configuration changes as they happen"},
+}
+
+// rpcGroups describes the command groups
+var rpcGroups = map[string]struct {
+	short   string
+	aliases []string
+}{
+	"config":      {"Show the HAProxy configuration version and reload the configuration file", nil},
+	"info":        {"Show information about the server", nil},
+	"changes":     {"Freeze and unfreeze configuration changes", nil},
+	"status":      {"Show the health of the server", nil},
+	"transaction": {"Manage transactions", []string{"txn", "transactions"}},
+	"backend":     {"Manage backends", []string{"backends"}},
+	"frontend":    {"Manage frontends", []string{"frontends"}},
+	"bind":        {"Manage the binds of frontends", []string{"binds"}},
+	"server":      {"Manage the servers of backends", []string{"servers"}},
+	"route":       {"Manage the hostname routes of frontends", []string{"routes"}},
+	"rate-limit":  {"Manage the rate limit policies of frontends", []string{"rate-limits"}},
+	"http-check":  {"Manage the HTTP health checks of backends", []string{"http-checks"}},
+	"lua":         {"Manage versioned Lua scripts and load them into HAProxy", nil},
+	"lua-action":  {"Manage the Lua actions of frontends", []string{"lua-actions"}},
+	"ring":        {"Manage rings and the logs sent to them", []string{"rings"}},
+	"program":     {"Manage the programs run by the HAProxy master", []string{"programs"}},
+	"maintenance": {"Put backends and servers into and out of maintenance", []string{"maint"}},
+	"metadata":    {"Manage the labels and annotations of resources", nil},
+	"stats":       {"Show live statistics", nil},
+	"state":       {"Export, import and apply the whole configuration", nil},
+	"netplan":     {"Inspect and clean up Netplan address management", nil},
+	"cluster":     {"Inspect and trigger replication to the cluster nodes", nil},
+	"peer":        {"Inspect synchronization between redundant configurators", []string{"peers"}},
+	"gitops":      {"Inspect GitOps reconciliation", nil},
+	"discovery":   {"Inspect backends synced from service discovery", nil},
+	"drift":       {"Inspect and check drift from the desired state", nil},
+	"event":       {"Query and watch configuration changes", []string{"events"}},
+}
+
+// addRPCCommands adds the command groups and a command per RPC to parent
+func addRPCCommands(parent *cobra.Command, options *clientOptions) {
+	service := pb.File_haproxy_proto.Services().ByName("HAProxyManagerService")
+
+	groups := make(map[string]*cobra.Command)
+	for _, rpc := range rpcCommands {
+		method := service.Methods().ByName(protoreflect.Name(rpc.method))
+		if method == nil {
+			panic(fmt.Sprintf("unknown RPC %s", rpc.method))
+		}
+
+		group, ok := groups[rpc.group]
+		if !ok {
+			group = &cobra.Command{
+				Use:     rpc.group,
+				Short:   rpcGroups[rpc.group].short,
+				Aliases: rpcGroups[rpc.group].aliases,
+			}
+			groups[rpc.group] = group
+			parent.AddCommand(group)
+		}
+		group.AddCommand(newRPCCommand(rpc, method, options))
+	}
+}
+
+// newRPCCommand creates the command calling one RPC
+func newRPCCommand(rpc rpcCommand, method protoreflect.MethodDescriptor, options *clientOptions) *cobra.Command {
+	input := method.Input()
+
+	var argPaths [][]fieldPath
+	var argNames []string
+	bound := make(map[string]bool)
+	for _, arg := range rpc.args {
+		var paths []fieldPath
+		for _, name := range strings.Split(arg, ",") {
+			path, err := resolvePath(input, name)
+			if err != nil {
+				panic(fmt.Sprintf("%s: %v", rpc.method, err))
+			}
+			paths = append(paths, path)
+			bound[name] = true
+		}
+		argPaths = append(argPaths, paths)
+		argNames = append(argNames, strings.ToUpper(string(paths[0][len(paths[0])-1].Name())))
+	}
+	if len(argNames) > 0 && argNames[len(argNames)-1] == "NAME" && len(rpc.args) > 1 {
+		argNames[len(argNames)-1] = strings.ToUpper(rpc.group) + "_NAME"
+	}
+
+	var data string
+	fields := requestFlags(input, bound)
+
+	cmd := &cobra.Command{
+		Use:   strings.Join(append([]string{rpc.verb}, argNames...), " "),
+		Short: rpc.short,
+		Long:  rpc.short + ".\n\nCalls the " + string(method.Name()) + " RPC.",
+		Args:  cobra.ExactArgs(len(argNames)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := newMessage(input)
+			if data != "" {
+				content, err := readValue(data, cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				if err := protojson.Unmarshal(content, request); err != nil {
+					return fmt.Errorf("invalid --data: %w", err)
+				}
+			}
+			for i, paths := range argPaths {
+				for _, path := range paths {
+					if err := path.set(request.ProtoReflect(), args[i]); err != nil {
+						return err
+					}
+				}
+			}
+			for _, field := range fields {
+				if err := field.apply(request.ProtoReflect(), cmd.InOrStdin()); err != nil {
+					return err
+				}
+			}
+			return invoke(cmd, options, method, request)
+		},
+	}
+	cmd.Flags().StringVarP(&data, "data", "d", "", "Request as JSON; @file reads it from a file, @- from stdin. Flags and arguments override its fields")
+	for _, field := range fields {
+		flag := cmd.Flags().VarPF(field, field.name, "", field.usage())
+		if field.isBool() {
+			flag.NoOptDefVal = "true"
+		}
+	}
+	return cmd
+}
+
+// invoke calls the RPC and prints the responses
+func invoke(cmd *cobra.Command, options *clientOptions, method protoreflect.MethodDescriptor, request proto.Message) error {
+	out, err := newPrinter(cmd.OutOrStdout(), options.output)
+	if err != nil {
+		return err
+	}
+
+	conn, err := options.dial()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	ctx, cancel := options.callContext(cmd.Context(), method.IsStreamingServer())
+	defer cancel()
+
+	if !method.IsStreamingServer() {
+		response := newMessage(method.Output())
+		if err := conn.Invoke(ctx, fullMethod, request, response); err != nil {
+			return err
+		}
+		return out.print(response)
+	}
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(request); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		response := newMessage(method.Output())
+		if err := stream.RecvMsg(response); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := out.print(response); err != nil {
+			return err
+		}
+	}
+}
+
+// newMessage creates an empty message of the generated Go type
+func newMessage(desc protoreflect.MessageDescriptor) proto.Message {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		panic(fmt.Sprintf("message type %s is not registered", desc.FullName()))
+	}
+	return messageType.New().Interface()
+}
+
+// readValue returns value, or the content of a file for "@file" and of stdin for "@-"
+func readValue(value string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case value == "@-":
+		return io.ReadAll(stdin)
+	case strings.HasPrefix(value, "@"):
+		content, err := os.ReadFile(value[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", value[1:], err)
+		}
+		return content, nil
+	default:
+		return []byte(value), nil
+	}
+}
+
+// fieldPath is a field of a request, possibly nested in message fields
+type fieldPath []protoreflect.FieldDescriptor
+
+// resolvePath looks up a dotted field name such as "backend.name"
+func resolvePath(message protoreflect.MessageDescriptor, name string) (fieldPath, error) {
+	var path fieldPath
+	for _, part := range strings.Split(name, ".") {
+		if message == nil {
+			return nil, fmt.Errorf("field %s is not a message", name)
+		}
+		field := message.Fields().ByName(protoreflect.Name(part))
+		if field == nil {
+			return nil, fmt.Errorf("unknown field %s in %s", name, message.FullName())
+		}
+		path = append(path, field)
+		message = field.Message()
+	}
+	return path, nil
+}
+
+// set parses value into the field, creating the messages along the path
+func (p fieldPath) set(message protoreflect.Message, value string) error {
+	for _, field := range p[:len(p)-1] {
+		message = message.Mutable(field).Message()
+	}
+	field := p[len(p)-1]
+	if field.IsMap() {
+		entries := message.Mutable(field).Map()
+		for _, item := range strings.Split(value, ",") {
+			key, entry, ok := strings.Cut(strings.TrimSpace(item), "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid value %q for %s: expected key=value", item, field.Name())
+			}
+			entries.Set(protoreflect.ValueOfString(key).MapKey(), protoreflect.ValueOfString(entry))
+		}
+		return nil
+	}
+	if field.IsList() {
+		list := message.Mutable(field).List()
+		for _, item := range strings.Split(value, ",") {
+			parsed, err := parseValue(field, strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+			list.Append(parsed)
+		}
+		return nil
+	}
+	parsed, err := parseValue(field, value)
+	if err != nil {
+		return err
+	}
+	message.Set(field, parsed)
+	return nil
+}
+
+// parseValue converts a command line value for a field
+func parseValue(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("invalid value %q for %s: %w", value, field.Name(), err)
+	}
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(v), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(v)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(v), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(v)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(v), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		if field.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(v)), nil
+		}
+		return protoreflect.ValueOfFloat64(v), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			name := string(values.Get(i).Name())
+			if strings.EqualFold(name, value) || strings.EqualFold(enumShortName(field.Enum(), name), value) {
+				return protoreflect.ValueOfEnum(values.Get(i).Number()), nil
+			}
+		}
+		return invalid(fmt.Errorf("expected one of %s", strings.Join(enumChoices(field.Enum()), ", ")))
+	case protoreflect.MessageKind:
+		switch field.Message().FullName() {
+		case timestampName:
+			t, err := parseTime(value)
+			if err != nil {
+				return invalid(err)
+			}
+			return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), nil
+		case durationName:
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return invalid(err)
+			}
+			return protoreflect.ValueOfMessage(durationpb.New(d).ProtoReflect()), nil
+		}
+	}
+	return invalid(fmt.Errorf("%s fields cannot be set from the command line, use --data", field.Kind()))
+}
+
+// Full names of the well-known messages set from a single flag value
+const (
+	timestampName = "google.protobuf.Timestamp"
+	durationName  = "google.protobuf.Duration"
+)
+
+// isStringMap reports whether a field maps strings to strings, set from key=value flag values
+func isStringMap(field protoreflect.FieldDescriptor) bool {
+	return field.IsMap() && field.MapKey().Kind() == protoreflect.StringKind && field.MapValue().Kind() == protoreflect.StringKind
+}
+
+// isSingleValue reports whether a message field is set from a single flag value
+func isSingleValue(field protoreflect.FieldDescriptor) bool {
+	name := field.Message().FullName()
+	return name == timestampName || name == durationName
+}
+
+// parseTime accepts RFC 3339 times and durations, which are counted back from now
+func parseTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// enumShortName strips the prefix shared by the values of an enum, e.g. PROXY_MODE_HTTP becomes http
+func enumShortName(enum protoreflect.EnumDescriptor, name string) string {
+	prefix := ""
+	if first := string(enum.Values().Get(0).Name()); strings.HasSuffix(first, "_UNSPECIFIED") {
+		prefix = strings.TrimSuffix(first, "UNSPECIFIED")
+	}
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// enumChoices lists the short names of the values of an enum, except the unspecified one
+func enumChoices(enum protoreflect.EnumDescriptor) []string {
+	var choices []string
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		if values.Get(i).Number() != 0 {
+			choices = append(choices, enumShortName(enum, string(values.Get(i).Name())))
+		}
+	}
+	return choices
+}
+
+// fieldFlag is a flag setting a request field. It implements pflag.Value.
+type fieldFlag struct {
+	name   string
+	path   fieldPath
+	values []string
+}
+
+var _ pflag.Value = (*fieldFlag)(nil)
+
+// requestFlags creates a flag for every scalar request field not set by a positional argument. Fields of
+// nested messages are flattened, e.g. backend.mode becomes --mode, and a message with a single field takes
+// the name of its parent, e.g. backend.balance.algorithm becomes --balance. Names that would collide keep the
+// path of their parents.
+func requestFlags(input protoreflect.MessageDescriptor, bound map[string]bool) []*fieldFlag {
+	type candidate struct {
+		short, long string
+		path        fieldPath
+	}
+	var candidates []candidate
+
+	var walk func(message protoreflect.MessageDescriptor, path fieldPath, dotted, alias string)
+	walk = func(message protoreflect.MessageDescriptor, path fieldPath, dotted, alias string) {
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			fieldPath := append(append(fieldPath{}, path...), field)
+			name := string(field.Name())
+			full := strings.TrimPrefix(dotted+"."+name, ".")
+			// IDs and versions of resources are assigned by the server
+			if bound[full] || (field.IsMap() && !isStringMap(field)) || (len(path) > 0 && (name == "id" || name == "resource_version")) {
+				continue
+			}
+
+			short := name
+			if alias != "" && fields.Len() == 1 {
+				short = alias
+			}
+			if field.Kind() == protoreflect.MessageKind && !isSingleValue(field) && !field.IsMap() {
+				if !field.IsList() {
+					walk(field.Message(), fieldPath, full, name)
+				}
+				continue
+			}
+			candidates = append(candidates, candidate{short: short, long: full, path: fieldPath})
+		}
+	}
+	walk(input, nil, "", "")
+
+	counts := make(map[string]int)
+	for _, c := range candidates {
+		counts[c.short]++
+	}
+	var flags []*fieldFlag
+	for _, c := range candidates {
+		name := c.short
+		if counts[name] > 1 {
+			name = c.long
+		}
+		flags = append(flags, &fieldFlag{
+			name: strings.NewReplacer("_", "-", ".", "-").Replace(name),
+			path: c.path,
+		})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+	return flags
+}
+
+// apply sets the field if the flag was given
+func (f *fieldFlag) apply(message protoreflect.Message, stdin io.Reader) error {
+	for _, value := range f.values {
+		if f.readsFile() {
+			content, err := readValue(value, stdin)
+			if err != nil {
+				return err
+			}
+			value = string(content)
+		}
+		if err := f.path.set(message, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readsFile reports whether the flag accepts @file, for fields usually kept in files
+func (f *fieldFlag) readsFile() bool {
+	name := f.path[len(f.path)-1].Name()
+	return name == "document" || name == "certificate_pem"
+}
+
+// isBool reports whether the flag may be given without a value
+func (f *fieldFlag) isBool() bool {
+	field := f.path[len(f.path)-1]
+	return field.Kind() == protoreflect.BoolKind && !field.IsList()
+}
+
+// usage describes the flag in the help output
+func (f *fieldFlag) usage() string {
+	field := f.path[len(f.path)-1]
+	var parts []string
+	for _, p := range f.path {
+		parts = append(parts, string(p.Name()))
+	}
+	usage := "Sets " + strings.Join(parts, ".")
+	switch {
+	case field.IsMap():
+		return usage + " (repeatable, comma separated key=value pairs)"
+	case field.Kind() == protoreflect.EnumKind:
+		usage += ": " + strings.Join(enumChoices(field.Enum()), ", ")
+	case field.Kind() == protoreflect.MessageKind && field.Message().FullName() == durationName:
+		usage += ": duration such as 30s"
+	case field.Kind() == protoreflect.MessageKind:
+		usage += ": RFC 3339 time, or a duration before now such as 1h"
+	case f.readsFile():
+		usage += "; @file reads it from a file, @- from stdin"
+	}
+	if field.IsList() {
+		usage += " (repeatable, comma separated)"
+	}
+	return usage
+}
+
+// String returns the values given for the flag
+func (f *fieldFlag) String() string {
+	return strings.Join(f.values, ",")
+}
+
+// Set records a value given for the flag; it is parsed when the request is built
+func (f *fieldFlag) Set(value string) error {
+	if field := f.path[len(f.path)-1]; len(f.values) > 0 && !field.IsList() && !field.IsMap() {
+		f.values = f.values[:0]
+	}
+	f.values = append(f.values, value)
+	return nil
+}
+
+// Type names the value type in the help output
+func (f *fieldFlag) Type() string {
+	field := f.path[len(f.path)-1]
+	switch field.Kind() {
+	case protoreflect.EnumKind, protoreflect.MessageKind:
+		return "string"
+	case protoreflect.BoolKind:
+		return "bool"
+	}
+	return field.Kind().String()
+}
@@ -0,0 +1,410 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+// newTUICommand creates the command starting the terminal UI
+func newTUICommand(options *clientOptions) *cobra.Command {
+	var refresh time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse the configuration with live statistics and drain servers in a terminal UI",
+		Long: `Browse frontends, backends and servers with live statistics, drain and enable servers, and
+see open transactions with their pending Netplan changes in a terminal UI.
+
+Keys:
+  tab, 1-3      switch between backends, frontends and transactions
+  up/down, j/k  select a row
+  d, e, m       drain, enable (ready) or put the selected server into maintenance
+  r             refresh now
+  q             quit
+
+Server states are changed through the runtime API: they take effect immediately, without a
+transaction, and are lost when HAProxy restarts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			conn, err := options.dial()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close() }()
+
+			model := newTUIModel(pb.NewHAProxyManagerServiceClient(conn), options, refresh)
+			_, err = tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run()
+			return err
+		},
+	}
+	cmd.Flags().DurationVar(&refresh, "refresh", 2*time.Second, "Interval between updates of the configuration and statistics")
+	return cmd
+}
+
+// tuiView is a tab of the terminal UI
+type tuiView int
+
+const (
+	viewBackends tuiView = iota
+	viewFrontends
+	viewTransactions
+)
+
+// tuiViewNames are the titles of the tabs, in order
+var tuiViewNames = []string{"Backends", "Frontends", "Transactions"}
+
+var (
+	tuiTabStyle       = lipgloss.NewStyle().Padding(0, 1)
+	tuiActiveTabStyle = tuiTabStyle.Bold(true).Reverse(true)
+	tuiHeaderStyle    = lipgloss.NewStyle().Bold(true)
+	tuiSelectedStyle  = lipgloss.NewStyle().Reverse(true)
+	tuiFaintStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+// tuiSnapshot is the data shown by the terminal UI, loaded on every refresh
+type tuiSnapshot struct {
+	state        *pb.State
+	stats        map[statsKey]*pb.ProxyStats
+	transactions []*pb.Transaction
+	netplan      *pb.GetNetplanStatusResponse
+	warnings     []string // Parts that could not be loaded
+}
+
+// statsKey identifies the statistics of a frontend, backend or server
+type statsKey struct {
+	kind    string
+	backend string // Backend of a server
+	name    string
+}
+
+// tuiRow is a line of a view; server rows carry the server so that its state can be changed
+type tuiRow struct {
+	cells   []string
+	backend string
+	server  string
+}
+
+// Messages of the terminal UI
+type (
+	snapshotMsg struct {
+		snapshot *tuiSnapshot
+		err      error
+	}
+	tickMsg   struct{}
+	actionMsg struct {
+		status string
+		err    error
+	}
+)
+
+// tuiModel is the bubbletea model of the terminal UI
+type tuiModel struct {
+	client   pb.HAProxyManagerServiceClient
+	options  *clientOptions
+	refresh  time.Duration
+	view     tuiView
+	cursor   int
+	snapshot *tuiSnapshot
+	err      error  // Error of the last refresh; the previous snapshot stays visible
+	status   string // Outcome of the last action
+	height   int
+}
+
+// newTUIModel creates the model of the terminal UI
+func newTUIModel(client pb.HAProxyManagerServiceClient, options *clientOptions, refresh time.Duration) tuiModel {
+	return tuiModel{client: client, options: options, refresh: refresh}
+}
+
+// Init loads the first snapshot and starts the refresh timer
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.load(), m.tick())
+}
+
+// tick schedules the next refresh
+func (m tuiModel) tick() tea.Cmd {
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+// load reads the configuration, statistics and transactions. Only the configuration is required;
+// parts that fail, e.g. statistics of an older server, are reported as warnings.
+func (m tuiModel) load() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.options.callContext(context.Background(), false)
+		defer cancel()
+
+		exported, err := m.client.ExportState(ctx, &pb.ExportStateRequest{})
+		if err != nil {
+			return snapshotMsg{err: err}
+		}
+		snapshot := &tuiSnapshot{state: exported.State, stats: make(map[statsKey]*pb.ProxyStats)}
+
+		if stats, err := m.client.GetStats(ctx, &pb.GetStatsRequest{}); err != nil {
+			snapshot.warnings = append(snapshot.warnings, "statistics unavailable: "+status.Convert(err).Message())
+		} else {
+			for _, entry := range stats.Stats {
+				key := statsKey{kind: entry.Type, name: entry.Name}
+				if entry.Type == "server" {
+					key.backend = entry.BackendName
+				}
+				snapshot.stats[key] = entry
+			}
+		}
+		if transactions, err := m.client.ListTransactions(ctx, &pb.ListTransactionsRequest{}); err != nil {
+			snapshot.warnings = append(snapshot.warnings, "transactions unavailable: "+status.Convert(err).Message())
+		} else {
+			snapshot.transactions = transactions.Transactions
+		}
+		if netplan, err := m.client.GetNetplanStatus(ctx, &pb.GetNetplanStatusRequest{}); err != nil {
+			snapshot.warnings = append(snapshot.warnings, "Netplan status unavailable: "+status.Convert(err).Message())
+		} else {
+			snapshot.netplan = netplan
+		}
+		return snapshotMsg{snapshot: snapshot}
+	}
+}
+
+// setServerState changes the state of a server and reports the outcome
+func (m tuiModel) setServerState(row tuiRow, state pb.ServerAdminState) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.options.callContext(context.Background(), false)
+		defer cancel()
+
+		_, err := m.client.SetServerState(ctx, &pb.SetServerStateRequest{BackendName: row.backend, Name: row.server, AdminState: state})
+		if err != nil {
+			return actionMsg{err: err}
+		}
+		return actionMsg{status: fmt.Sprintf("Server %s/%s set to %s", row.backend, row.server, enumShortName(state.Descriptor(), state.String()))}
+	}
+}
+
+// Update handles keys, refreshes and the outcome of actions
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tickMsg:
+		return m, tea.Batch(m.load(), m.tick())
+	case snapshotMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.snapshot = msg.snapshot
+			m.cursor = min(m.cursor, max(len(m.rows())-1, 0))
+		}
+	case actionMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = "Failed: " + status.Convert(msg.err).Message()
+		}
+		return m, m.load()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey navigates between views and rows and changes server states
+func (m tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "tab", "right", "l":
+		m.view, m.cursor = (m.view+1)%tuiView(len(tuiViewNames)), 0
+	case "shift+tab", "left", "h":
+		m.view, m.cursor = (m.view+tuiView(len(tuiViewNames))-1)%tuiView(len(tuiViewNames)), 0
+	case "1", "2", "3":
+		m.view, m.cursor = tuiView(key[0]-'1'), 0
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.rows())-1, 0))
+	case "r":
+		return m, m.load()
+	case "d", "e", "m":
+		rows := m.rows()
+		if m.cursor >= len(rows) || rows[m.cursor].server == "" {
+			m.status = "Select a server to change its state"
+			return m, nil
+		}
+		state := map[string]pb.ServerAdminState{
+			"d": pb.ServerAdminState_SERVER_ADMIN_STATE_DRAIN,
+			"e": pb.ServerAdminState_SERVER_ADMIN_STATE_READY,
+			"m": pb.ServerAdminState_SERVER_ADMIN_STATE_MAINT,
+		}[key]
+		return m, m.setServerState(rows[m.cursor], state)
+	}
+	return m, nil
+}
+
+// View renders the tabs, the table of the current view and the status lines
+func (m tuiModel) View() string {
+	var b strings.Builder
+	for i, name := range tuiViewNames {
+		style := tuiTabStyle
+		if tuiView(i) == m.view {
+			style = tuiActiveTabStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%d %s", i+1, name)))
+	}
+	b.WriteString("\n\n")
+
+	if m.snapshot == nil {
+		if m.err != nil {
+			b.WriteString("Failed to load the configuration: " + status.Convert(m.err).Message() + "\n")
+		} else {
+			b.WriteString("Loading...\n")
+		}
+		return b.String()
+	}
+
+	header := m.header()
+	rows := m.rows()
+	lines := [][]string{header}
+	for _, row := range rows {
+		lines = append(lines, row.cells)
+	}
+	widths := columnWidths(lines, nil)
+
+	b.WriteString(tuiHeaderStyle.Render(alignRow(header, widths)) + "\n")
+	first, last := 0, len(rows)
+	if visible := m.height - 8; m.height > 0 && visible > 0 && len(rows) > visible {
+		first = max(m.cursor-visible+1, 0)
+		last = first + visible
+	}
+	for i := first; i < last; i++ {
+		line := alignRow(rows[i].cells, widths)
+		if i == m.cursor {
+			line = tuiSelectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(rows) == 0 {
+		b.WriteString(tuiFaintStyle.Render("(none)") + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.err != nil {
+		b.WriteString("Refresh failed: " + status.Convert(m.err).Message() + "\n")
+	}
+	for _, warning := range m.snapshot.warnings {
+		b.WriteString(tuiFaintStyle.Render(warning) + "\n")
+	}
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+	b.WriteString(tuiFaintStyle.Render("tab switch view • ↑/↓ select • d drain • e enable • m maint • r refresh • q quit"))
+	return b.String()
+}
+
+// header returns the column titles of the current view
+func (m tuiModel) header() []string {
+	switch m.view {
+	case viewTransactions:
+		return []string{"TRANSACTION", "STATUS", "NETPLAN", "CHANGE"}
+	default:
+		return []string{"NAME", "DETAIL", "STATUS", "SESSIONS", "RATE", "TOTAL", "CHECK"}
+	}
+}
+
+// rows returns the lines of the current view
+func (m tuiModel) rows() []tuiRow {
+	if m.snapshot == nil {
+		return nil
+	}
+
+	var rows []tuiRow
+	switch m.view {
+	case viewBackends:
+		for _, backend := range m.snapshot.state.GetBackends() {
+			name := backend.Backend.GetName()
+			rows = append(rows, tuiRow{cells: append([]string{name, proxyMode(backend.Backend.GetMode())},
+				m.statsCells(statsKey{kind: "backend", name: name})...)})
+			for _, server := range backend.Servers {
+				rows = append(rows, tuiRow{
+					cells: append([]string{"  " + server.Name, hostPort(server.Address, server.Port)},
+						m.statsCells(statsKey{kind: "server", backend: name, name: server.Name})...),
+					backend: name,
+					server:  server.Name,
+				})
+			}
+		}
+	case viewFrontends:
+		for _, frontend := range m.snapshot.state.GetFrontends() {
+			name := frontend.Frontend.GetName()
+			rows = append(rows, tuiRow{cells: append([]string{name, proxyMode(frontend.Frontend.GetMode())},
+				m.statsCells(statsKey{kind: "frontend", name: name})...)})
+			for _, bind := range frontend.Binds {
+				rows = append(rows, tuiRow{cells: []string{"  " + bind.Name, hostPort(bind.Address, bind.Port), "", "", "", "", ""}})
+			}
+		}
+	case viewTransactions:
+		pending := make(map[string]*pb.NetplanTransaction)
+		for _, transaction := range m.snapshot.netplan.GetTransactions() {
+			pending[transaction.TransactionId] = transaction
+		}
+		for _, transaction := range m.snapshot.transactions {
+			rows = append(rows, transactionRows(transaction.Id, transaction.Status, pending[transaction.Id])...)
+			delete(pending, transaction.Id)
+		}
+		// Netplan transactions whose HAProxy transaction is gone, e.g. failed commits
+		for _, transaction := range m.snapshot.netplan.GetTransactions() {
+			if _, ok := pending[transaction.TransactionId]; ok {
+				rows = append(rows, transactionRows(transaction.TransactionId, "-", transaction)...)
+			}
+		}
+	}
+	return rows
+}
+
+// transactionRows returns the line of a transaction followed by a line per pending Netplan change
+func transactionRows(id, haproxyStatus string, netplan *pb.NetplanTransaction) []tuiRow {
+	netplanStatus := "-"
+	if netplan != nil {
+		netplanStatus = netplan.Status
+	}
+	rows := []tuiRow{{cells: []string{id, haproxyStatus, netplanStatus, ""}}}
+	for _, change := range netplan.GetChanges() {
+		rows = append(rows, tuiRow{cells: []string{"", "", "", fmt.Sprintf("%s %s%s on %s", change.Operation, change.IpAddress, change.SubnetMask, change.Interface)}})
+	}
+	return rows
+}
+
+// statsCells returns the statistics columns of a frontend, backend or server
+func (m tuiModel) statsCells(key statsKey) []string {
+	stats, ok := m.snapshot.stats[key]
+	if !ok {
+		return []string{"-", "-", "-", "-", "-"}
+	}
+	check := stats.CheckStatus
+	if check == "" {
+		check = "-"
+	}
+	return []string{
+		stats.Status,
+		strconv.FormatInt(stats.CurrentSessions, 10),
+		strconv.FormatInt(stats.SessionRate, 10),
+		strconv.FormatInt(stats.TotalSessions, 10),
+		check,
+	}
+}
+
+// proxyMode returns the short name of a proxy mode
+func proxyMode(mode pb.ProxyMode) string {
+	return enumShortName(mode.Descriptor(), mode.String())
+}
+
+// hostPort formats an address and port, bracketing IPv6 addresses
+func hostPort(address string, port int32) string {
+	if strings.Contains(address, ":") {
+		return fmt.Sprintf("[%s]:%d", address, port)
+	}
+	return fmt.Sprintf("%s:%d", address, port)
+}
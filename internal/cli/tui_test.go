@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTUIClient answers the calls of the terminal UI and records server state changes
+type fakeTUIClient struct {
+	pb.HAProxyManagerServiceClient
+	stateRequest *pb.SetServerStateRequest
+}
+
+func (f *fakeTUIClient) ExportState(context.Context, *pb.ExportStateRequest, ...grpc.CallOption) (*pb.ExportStateResponse, error) {
+	return &pb.ExportStateResponse{State: &pb.State{
+		Frontends: []*pb.FrontendState{{
+			Frontend: &pb.Frontend{Name: "www", Mode: pb.ProxyMode_PROXY_MODE_HTTP},
+			Binds:    []*pb.Bind{{Name: "vip", Address: "192.168.1.100", Port: 443}},
+		}},
+		Backends: []*pb.BackendState{{
+			Backend: &pb.Backend{Name: "app", Mode: pb.ProxyMode_PROXY_MODE_HTTP},
+			Servers: []*pb.Server{{Name: "app1", Address: "10.0.0.1", Port: 8080}},
+		}},
+	}}, nil
+}
+
+func (f *fakeTUIClient) GetStats(context.Context, *pb.GetStatsRequest, ...grpc.CallOption) (*pb.GetStatsResponse, error) {
+	return &pb.GetStatsResponse{Stats: []*pb.ProxyStats{
+		{Type: "backend", Name: "app", Status: "UP", CurrentSessions: 3},
+		{Type: "server", BackendName: "app", Name: "app1", Status: "UP", CurrentSessions: 42, CheckStatus: "L7OK"},
+	}}, nil
+}
+
+func (f *fakeTUIClient) ListTransactions(context.Context, *pb.ListTransactionsRequest, ...grpc.CallOption) (*pb.ListTransactionsResponse, error) {
+	return &pb.ListTransactionsResponse{Transactions: []*pb.Transaction{{Id: "txn-1", Status: "in_progress"}}}, nil
+}
+
+func (f *fakeTUIClient) GetNetplanStatus(context.Context, *pb.GetNetplanStatusRequest, ...grpc.CallOption) (*pb.GetNetplanStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not supported")
+}
+
+func (f *fakeTUIClient) SetServerState(_ context.Context, req *pb.SetServerStateRequest, _ ...grpc.CallOption) (*pb.SetServerStateResponse, error) {
+	f.stateRequest = req
+	return &pb.SetServerStateResponse{AdminState: req.AdminState}, nil
+}
+
+// update feeds a message to the model
+func update(t *testing.T, m tuiModel, msg tea.Msg) (tuiModel, tea.Cmd) {
+	t.Helper()
+	model, cmd := m.Update(msg)
+	return model.(tuiModel), cmd
+}
+
+// key returns the message of a key press
+func key(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestTUIShowsStatsAndDrainsServers(t *testing.T) {
+	client := &fakeTUIClient{}
+	m := newTUIModel(client, &clientOptions{timeout: time.Second}, time.Hour)
+	m, _ = update(t, m, m.load()())
+
+	view := m.View()
+	for _, want := range []string{"app1", "10.0.0.1:8080", "42", "L7OK", "Netplan status unavailable: not supported"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in view:\n%s", want, view)
+		}
+	}
+
+	// The first row is the backend, which has no state to change
+	m, cmd := update(t, m, key('d'))
+	if cmd != nil || m.status != "Select a server to change its state" {
+		t.Fatalf("Expected a hint on the backend row, got %q", m.status)
+	}
+
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd = update(t, m, key('d'))
+	if cmd == nil {
+		t.Fatal("Expected a command to drain the server")
+	}
+	m, _ = update(t, m, cmd())
+	if req := client.stateRequest; req == nil || req.BackendName != "app" || req.Name != "app1" || req.AdminState != pb.ServerAdminState_SERVER_ADMIN_STATE_DRAIN {
+		t.Errorf("Unexpected request %v", client.stateRequest)
+	}
+	if m.status != "Server app/app1 set to drain" {
+		t.Errorf("Unexpected status %q", m.status)
+	}
+}
+
+func TestTUISwitchesViews(t *testing.T) {
+	m := newTUIModel(&fakeTUIClient{}, &clientOptions{timeout: time.Second}, time.Hour)
+	m, _ = update(t, m, m.load()())
+
+	m, _ = update(t, m, key('2'))
+	if view := m.View(); !strings.Contains(view, "192.168.1.100:443") {
+		t.Errorf("Expected the binds in the frontends view:\n%s", view)
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if view := m.View(); !strings.Contains(view, "txn-1") || !strings.Contains(view, "in_progress") {
+		t.Errorf("Expected the transactions view:\n%s", view)
+	}
+	if _, cmd := update(t, m, key('q')); cmd == nil {
+		t.Error("Expected q to quit")
+	}
+}
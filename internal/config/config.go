@@ -3,22 +3,209 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the unified configuration for the HAProxy Configurator
 type Config struct {
-	HAProxy HAProxySettings `yaml:"haproxy"`
-	Netplan NetplanSettings `yaml:"netplan,omitempty"`
+	Path string `yaml:"-"` // File the configuration was loaded from, empty if it was not loaded from a file
+
+	HAProxy   HAProxySettings   `yaml:"haproxy"`
+	Instances []HAProxyInstance `yaml:"haproxy_instances,omitempty"`
+	Netplan   NetplanSettings   `yaml:"netplan,omitempty"`
+	Logging   LoggingSettings   `yaml:"logging,omitempty"`
+	Journal   JournalSettings   `yaml:"journal,omitempty"`
+	// Labels and annotations of backends, frontends, binds and servers, which HAProxy has no place for
+	Metadata MetadataSettings `yaml:"metadata,omitempty"`
+	// Forward the mutation events of the journal to syslog or journald
+	Audit AuditSettings `yaml:"audit,omitempty"`
+	// Remember the responses of calls carrying an idempotency key, so retries are not applied twice
+	Idempotency IdempotencySettings `yaml:"idempotency,omitempty"`
+	Webhooks    []WebhookSettings   `yaml:"webhooks,omitempty"`
+	Vault       VaultSettings       `yaml:"vault,omitempty"`
+	GitOps      GitOpsSettings      `yaml:"gitops,omitempty"`
+	Kubernetes  KubernetesSettings  `yaml:"kubernetes,omitempty"`
+	BGP         BGPSettings         `yaml:"bgp,omitempty"`
+	Cluster     ClusterSettings     `yaml:"cluster,omitempty"`
+	// Keep the servers of backends in sync with service discovery sources such as Consul
+	Discovery DiscoverySettings `yaml:"discovery,omitempty"`
+	// Compare the live configuration with a desired state and report or revert differences
+	DriftDetection DriftDetectionSettings `yaml:"drift_detection,omitempty"`
+	// Elect one active instance among redundant configurators; standbys only serve reads
+	LeaderElection LeaderElectionSettings `yaml:"leader_election,omitempty"`
+	// Copy the tracked VIPs, pending Netplan transactions and journal of the leader to standbys
+	PeerSync PeerSyncSettings `yaml:"peer_sync,omitempty"`
+	// Validate and log every operation but write nothing to the Data Plane API or Netplan
+	DryRun bool `yaml:"dry_run,omitempty"`
+	// Record the Data Plane API traffic to disk, or answer the requests from such a recording to reproduce issues offline
+	DataplaneRecording DataplaneRecordingSettings `yaml:"dataplane_recording,omitempty"`
 }
 
 // HAProxySettings contains the HAProxy Data Plane API settings
 type HAProxySettings struct {
-	APIURL   string `yaml:"api_url"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	APIURL         string                 `yaml:"api_url"`
+	APIURLFile     string                 `yaml:"api_url_file,omitempty"`
+	Username       string                 `yaml:"username"`
+	UsernameFile   string                 `yaml:"username_file,omitempty"`
+	Password       string                 `yaml:"password"`
+	PasswordFile   string                 `yaml:"password_file,omitempty"` // e.g. a mounted Kubernetes secret
+	CircuitBreaker CircuitBreakerSettings `yaml:"circuit_breaker,omitempty"`
+	TLS            DataplaneTLSSettings   `yaml:"tls,omitempty"`
+	// Time allowed for a single HTTP request to the Data Plane API, including reading the response
+	RequestTimeoutSeconds int           `yaml:"request_timeout_seconds,omitempty"`
+	Retry                 RetrySettings `yaml:"retry,omitempty"`
+	// Data Plane API URLs tried in order while api_url is unreachable, e.g. of a standby HAProxy node
+	FallbackAPIURLs []string         `yaml:"fallback_api_urls,omitempty"`
+	Failover        FailoverSettings `yaml:"failover,omitempty"`
+	// How long the read RPCs may answer from a cache of the committed configuration; 0 disables the cache
+	ReadCacheTTLMs int                    `yaml:"read_cache_ttl_ms,omitempty"`
+	ConnectionPool ConnectionPoolSettings `yaml:"connection_pool,omitempty"`
+	// Independent changes of batch and declarative applies sent to the Data Plane API at once; 1 applies them one by one
+	ApplyConcurrency int `yaml:"apply_concurrency,omitempty"`
+	// Open transactions above which creating another fails with RESOURCE_EXHAUSTED; 0 means unlimited
+	MaxOpenTransactions int `yaml:"max_open_transactions,omitempty"`
+	// Stats socket of HAProxy with admin level, a unix socket path or host:port, from which StreamRing reads
+	// rings; the Data Plane API does not serve their contents
+	RuntimeSocket string `yaml:"runtime_socket,omitempty"`
+	// Manage HAProxy without the Data Plane API by writing haproxy.cfg and reloading HAProxy directly
+	Standalone StandaloneSettings `yaml:"standalone,omitempty"`
+}
+
+// StandaloneSettings configure the standalone mode of an instance: the managed frontends and backends are
+// rendered into haproxy.cfg on every commit, which is checked with haproxy -c and activated by a reload
+type StandaloneSettings struct {
+	Enabled    bool   `yaml:"enabled,omitempty"`
+	ConfigFile string `yaml:"config_file,omitempty"` // Written on commit (default: /etc/haproxy/haproxy.cfg)
+	// Global and defaults sections the managed sections are appended to; without it minimal ones are written
+	BaseFile string `yaml:"base_file,omitempty"`
+	// Managed resources, from which the configuration is rendered again after a restart
+	// (default: /var/lib/haproxy-configurator/standalone/<instance>.json)
+	StateFile      string `yaml:"state_file,omitempty"`
+	CertificateDir string `yaml:"certificate_dir,omitempty"` // Where stored certificates are written (default: /etc/haproxy/ssl)
+	StorageDir     string `yaml:"storage_dir,omitempty"`     // Where stored files such as Lua scripts are written (default: /etc/haproxy/general)
+	HAProxyBinary  string `yaml:"haproxy_binary,omitempty"`  // Used to check the configuration (default: haproxy)
+	Reload         string `yaml:"reload,omitempty"`          // "systemd" (default) or "master_socket"
+	SystemdUnit    string `yaml:"systemd_unit,omitempty"`    // Reloaded with systemctl (default: haproxy.service)
+	// Master CLI socket of HAProxy (-S), used to reload with "reload" and to read statistics and change server
+	// states at runtime; without it statistics and runtime changes are not available
+	MasterSocket string `yaml:"master_socket,omitempty"`
+}
+
+// setDefaults fills in unset standalone settings
+func (s *StandaloneSettings) setDefaults() {
+	if s.ConfigFile == "" {
+		s.ConfigFile = "/etc/haproxy/haproxy.cfg"
+	}
+	if s.CertificateDir == "" {
+		s.CertificateDir = "/etc/haproxy/ssl"
+	}
+	if s.StorageDir == "" {
+		s.StorageDir = "/etc/haproxy/general"
+	}
+	if s.HAProxyBinary == "" {
+		s.HAProxyBinary = "haproxy"
+	}
+	if s.Reload == "" {
+		s.Reload = "systemd"
+	}
+	if s.SystemdUnit == "" {
+		s.SystemdUnit = "haproxy.service"
+	}
+}
+
+// validate checks the reload method of an enabled standalone mode
+func (s *StandaloneSettings) validate() error {
+	if !s.Enabled {
+		return nil
+	}
+	switch s.Reload {
+	case "", "systemd":
+	case "master_socket":
+		if s.MasterSocket == "" {
+			return fmt.Errorf("standalone master_socket is required to reload through the master socket")
+		}
+	default:
+		return fmt.Errorf("invalid standalone reload %q: must be systemd or master_socket", s.Reload)
+	}
+	return nil
+}
+
+// DefaultInstance is the name of the HAProxy instance configured in the haproxy section
+const DefaultInstance = "default"
+
+// HAProxyInstance is an additional named HAProxy Data Plane API target
+type HAProxyInstance struct {
+	Name            string `yaml:"name"`
+	HAProxySettings `yaml:",inline"`
+}
+
+// CircuitBreakerSettings controls when calls to the Data Plane API start failing fast
+type CircuitBreakerSettings struct {
+	Disabled         bool `yaml:"disabled,omitempty"`
+	FailureThreshold int  `yaml:"failure_threshold,omitempty"` // Consecutive failures before the circuit opens
+	OpenSeconds      int  `yaml:"open_seconds,omitempty"`      // Time the circuit stays open before a probe call
+}
+
+// RetrySettings controls how failed idempotent Data Plane API requests are retried
+type RetrySettings struct {
+	Disabled         bool `yaml:"disabled,omitempty"`
+	MaxRetries       int  `yaml:"max_retries,omitempty"`        // Retries after the first attempt
+	InitialBackoffMs int  `yaml:"initial_backoff_ms,omitempty"` // Delay before the first retry, doubled for each further retry
+	MaxBackoffMs     int  `yaml:"max_backoff_ms,omitempty"`     // Upper bound of the delay between retries
+	// Wait exactly the backoff instead of a random duration between half and all of it
+	DisableJitter bool `yaml:"disable_jitter,omitempty"`
+	// Overrides for reads (GET) and writes (PUT, DELETE); unset fields keep the settings above
+	Reads  OperationRetrySettings `yaml:"reads,omitempty"`
+	Writes OperationRetrySettings `yaml:"writes,omitempty"`
+}
+
+// OperationRetrySettings overrides the retry settings for one type of request
+type OperationRetrySettings struct {
+	Disabled         bool `yaml:"disabled,omitempty"`
+	MaxRetries       int  `yaml:"max_retries,omitempty"`
+	InitialBackoffMs int  `yaml:"initial_backoff_ms,omitempty"`
+	MaxBackoffMs     int  `yaml:"max_backoff_ms,omitempty"`
+}
+
+// For returns the retry settings of a type of request: its overrides applied to the common settings
+func (r RetrySettings) For(overrides OperationRetrySettings) OperationRetrySettings {
+	result := OperationRetrySettings{
+		Disabled:         r.Disabled || overrides.Disabled,
+		MaxRetries:       r.MaxRetries,
+		InitialBackoffMs: r.InitialBackoffMs,
+		MaxBackoffMs:     r.MaxBackoffMs,
+	}
+	if overrides.MaxRetries != 0 {
+		result.MaxRetries = overrides.MaxRetries
+	}
+	if overrides.InitialBackoffMs != 0 {
+		result.InitialBackoffMs = overrides.InitialBackoffMs
+	}
+	if overrides.MaxBackoffMs != 0 {
+		result.MaxBackoffMs = overrides.MaxBackoffMs
+	}
+	return result
+}
+
+// FailoverSettings controls switching between api_url and the fallback_api_urls
+type FailoverSettings struct {
+	FailureThreshold     int `yaml:"failure_threshold,omitempty"`      // Consecutive unreachable responses before switching
+	ProbeIntervalSeconds int `yaml:"probe_interval_seconds,omitempty"` // How often preceding URLs are probed to fall back
+}
+
+// ConnectionPoolSettings controls how connections to the Data Plane API are kept open and reused
+type ConnectionPoolSettings struct {
+	MaxIdleConnsPerHost int  `yaml:"max_idle_conns_per_host,omitempty"` // Idle connections kept open for later requests
+	MaxConnsPerHost     int  `yaml:"max_conns_per_host,omitempty"`      // Upper bound of open connections; 0 means unlimited
+	IdleTimeoutSeconds  int  `yaml:"idle_timeout_seconds,omitempty"`    // Time an unused connection is kept open
+	KeepAliveSeconds    int  `yaml:"keep_alive_seconds,omitempty"`      // Interval of TCP keep-alive probes
+	DisableHTTP2        bool `yaml:"disable_http2,omitempty"`           // Stay on HTTP/1.1 even if a TLS endpoint offers HTTP/2
 }
 
 // NetplanSettings contains the Netplan-specific settings
@@ -27,12 +214,360 @@ type NetplanSettings struct {
 	ConfigPath        string             `yaml:"netplan_config_path"`
 	BackupEnabled     bool               `yaml:"backup_enabled"`
 	TransactionDir    string             `yaml:"transaction_dir,omitempty"`
+
+	// StrictAddressValidation rejects binds whose address is in none of the mapped subnets instead of creating
+	// them without a VIP
+	StrictAddressValidation bool `yaml:"strict_address_validation,omitempty"`
+
+	// FailurePolicy decides whether Netplan errors of the bind and commit RPCs are only logged ("warn", the
+	// default) or returned to the caller ("fail")
+	FailurePolicy string `yaml:"failure_policy,omitempty"`
+
+	// ARPProbe checks with arping whether another host already uses the address of a new bind
+	ARPProbe bool `yaml:"arp_probe,omitempty"`
+
+	OrphanCleanup OrphanCleanupSettings `yaml:"orphan_cleanup,omitempty"`
+
+	// AutoDiscover adds interface mappings for the subnets of the static addresses in the Netplan files of
+	// DiscoveryDir when the configuration is loaded. Manual mappings take precedence.
+	AutoDiscover bool   `yaml:"auto_discover,omitempty"`
+	DiscoveryDir string `yaml:"discovery_dir,omitempty"` // Directory of the Netplan files (default: /etc/netplan)
+
+	// Backend applies the VIPs with "netplan" apply (the default) or to the connections of "networkmanager" with
+	// nmcli, for hosts without Netplan. The file at ConfigPath is the record of the VIPs with either backend.
+	Backend string `yaml:"backend,omitempty"`
+
+	// SkipGenerate writes the Netplan configuration without checking it with netplan generate first, for hosts
+	// whose netplan lacks --root-dir
+	SkipGenerate bool `yaml:"skip_generate,omitempty"`
+}
+
+// OrphanCleanupSettings configures the periodic search for VIPs in the Netplan configuration that no bind
+// listens on anymore
+type OrphanCleanupSettings struct {
+	IntervalSeconds int  `yaml:"interval_seconds,omitempty"` // How often to search; zero disables the job
+	Remove          bool `yaml:"remove,omitempty"`           // Remove the orphans; otherwise they are only reported
+}
+
+// UsesNetworkManager reports whether the VIPs are applied to NetworkManager instead of with netplan apply
+func (n NetplanSettings) UsesNetworkManager() bool {
+	return n.Backend == "networkmanager"
+}
+
+// FailOnError reports whether Netplan errors are returned to the caller rather than only logged
+func (n NetplanSettings) FailOnError() bool {
+	return n.FailurePolicy == "fail"
+}
+
+// LoggingSettings contains the log output settings
+type LoggingSettings struct {
+	Level            string           `yaml:"level,omitempty"`  // "debug", "info", "warn" or "error"
+	Format           string           `yaml:"format,omitempty"` // "json" or "console"
+	OutputPaths      []string         `yaml:"output_paths,omitempty"`
+	ErrorOutputPaths []string         `yaml:"error_output_paths,omitempty"`
+	Rotation         RotationSettings `yaml:"rotation,omitempty"`
+	// Secure logging mode: leave payloads, such as dry-run request bodies and Netplan files, out of the log
+	DisablePayloads bool `yaml:"disable_payloads,omitempty"`
+}
+
+// RotationSettings controls size and age based rotation of file log outputs
+type RotationSettings struct {
+	Enabled    bool `yaml:"enabled"`
+	MaxSizeMB  int  `yaml:"max_size_mb,omitempty"`
+	MaxAgeDays int  `yaml:"max_age_days,omitempty"`
+	MaxBackups int  `yaml:"max_backups,omitempty"`
+	Compress   bool `yaml:"compress,omitempty"`
+}
+
+// JournalSettings contains the mutation event journal settings
+type JournalSettings struct {
+	Path          string `yaml:"path"`                     // Database file; empty disables the journal
+	RetentionDays int    `yaml:"retention_days,omitempty"` // Events older than this are pruned at startup (0 = keep forever)
+}
+
+// MetadataSettings contains the settings of the metadata store
+type MetadataSettings struct {
+	Path string `yaml:"path"` // Database file; empty disables labels and annotations
+}
+
+// AuditSettings forwards every configuration change, as recorded in the journal, to syslog or journald
+type AuditSettings struct {
+	Target   string `yaml:"target"`             // "syslog" or "journald"; empty disables the export
+	Network  string `yaml:"network,omitempty"`  // syslog: "udp", "tcp" or "unixgram"; empty uses the local syslog daemon
+	Address  string `yaml:"address,omitempty"`  // syslog: host:port or socket path; journald: socket path
+	Facility string `yaml:"facility,omitempty"` // syslog facility, e.g. "local0" (default) or "auth"
+	Tag      string `yaml:"tag,omitempty"`      // Application name of the entries (default: "haproxy-configurator")
+}
+
+// DataplaneRecordingSettings records the requests to and responses from the Data Plane API of every instance, or
+// replays a recording instead of reaching the Data Plane API
+type DataplaneRecordingSettings struct {
+	Mode string `yaml:"mode,omitempty"` // "record" or "replay"; empty disables both
+	Dir  string `yaml:"dir,omitempty"`  // Holds one <instance>.jsonl file per HAProxy instance
+}
+
+// validate checks the recording mode and, for replays, that the recording exists
+func (r *DataplaneRecordingSettings) validate() error {
+	switch r.Mode {
+	case "":
+		return nil
+	case "record", "replay":
+	default:
+		return fmt.Errorf("invalid dataplane_recording mode %q: must be record or replay", r.Mode)
+	}
+	if r.Dir == "" {
+		return fmt.Errorf("dataplane_recording dir is required")
+	}
+	if r.Mode == "replay" {
+		if info, err := os.Stat(r.Dir); err != nil || !info.IsDir() {
+			return fmt.Errorf("dataplane_recording dir %s is not a directory", r.Dir)
+		}
+	}
+	return nil
+}
+
+// IdempotencySettings bounds how long and how many responses to idempotent calls are remembered
+type IdempotencySettings struct {
+	TTLSeconds int `yaml:"ttl_seconds,omitempty"` // How long a key is remembered after its call succeeded (default: 600)
+	MaxKeys    int `yaml:"max_keys,omitempty"`    // Oldest keys are forgotten beyond this number (default: 10000)
+}
+
+// WebhookSettings defines an HTTP endpoint notified about transaction events
+type WebhookSettings struct {
+	Type           string            `yaml:"type,omitempty"` // "http" (default) for a generic JSON POST, or "slack"
+	URL            string            `yaml:"url"`
+	Events         []string          `yaml:"events,omitempty"`   // Event types to deliver; empty delivers all
+	Headers        map[string]string `yaml:"headers,omitempty"`  // Extra request headers, e.g. Authorization
+	Template       string            `yaml:"template,omitempty"` // Go text/template for the request body; defaults to the JSON event
+	TimeoutSeconds int               `yaml:"timeout_seconds,omitempty"`
+	Channel        string            `yaml:"channel,omitempty"`  // slack: overrides the channel of the webhook
+	Username       string            `yaml:"username,omitempty"` // slack: overrides the name messages are posted as
+
+	// MaxRetries is the number of further attempts after a failed delivery, with exponential backoff. Each
+	// attempt is cancelled after TimeoutSeconds.
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// Secret signs the body of generic webhooks with HMAC-SHA256, sent in the X-Webhook-Signature header
+	Secret string `yaml:"secret,omitempty"`
+}
+
+// VaultSettings configures HashiCorp Vault as a source of secrets
+type VaultSettings struct {
+	Address                string               `yaml:"address"` // Empty disables Vault
+	Namespace              string               `yaml:"namespace,omitempty"`
+	CACert                 string               `yaml:"ca_cert,omitempty"`
+	TLSSkipVerify          bool                 `yaml:"tls_skip_verify,omitempty"`
+	Auth                   VaultAuthSettings    `yaml:"auth"`
+	RefreshIntervalSeconds int                  `yaml:"refresh_interval_seconds,omitempty"` // How often secrets are re-read
+	HAProxyCredentials     VaultSecretSettings  `yaml:"haproxy_credentials,omitempty"`
+	GRPCTLS                VaultGRPCTLSSettings `yaml:"grpc_tls,omitempty"`
+}
+
+// VaultAuthSettings selects how the server authenticates to Vault
+type VaultAuthSettings struct {
+	Method    string `yaml:"method"` // "token", "approle" or "kubernetes"
+	MountPath string `yaml:"mount_path,omitempty"`
+
+	// token
+	Token     string `yaml:"token,omitempty"`
+	TokenFile string `yaml:"token_file,omitempty"`
+
+	// approle
+	RoleID       string `yaml:"role_id,omitempty"`
+	SecretID     string `yaml:"secret_id,omitempty"`
+	SecretIDFile string `yaml:"secret_id_file,omitempty"`
+
+	// kubernetes
+	Role                    string `yaml:"role,omitempty"`
+	ServiceAccountTokenFile string `yaml:"service_account_token_file,omitempty"`
+}
+
+// VaultSecretSettings locates the Data Plane API credentials in Vault
+type VaultSecretSettings struct {
+	Path        string `yaml:"path"` // e.g. "secret/data/haproxy/dataplane" for KV v2
+	UsernameKey string `yaml:"username_key,omitempty"`
+	PasswordKey string `yaml:"password_key,omitempty"`
+}
+
+// VaultGRPCTLSSettings locates the gRPC server certificate in Vault.
+// When CommonName is set the path is treated as a PKI issue endpoint, otherwise it is read as a secret.
+type VaultGRPCTLSSettings struct {
+	Path           string `yaml:"path"`
+	CommonName     string `yaml:"common_name,omitempty"`
+	TTL            string `yaml:"ttl,omitempty"` // PKI certificate TTL, e.g. "72h"
+	CertificateKey string `yaml:"certificate_key,omitempty"`
+	PrivateKeyKey  string `yaml:"private_key_key,omitempty"`
+}
+
+// GitOpsSettings configures continuous reconciliation from a directory or git repository of state manifests
+type GitOpsSettings struct {
+	Path            string `yaml:"path,omitempty"`             // Manifest directory; relative to the repository root if repository is set
+	Repository      string `yaml:"repository,omitempty"`       // Git repository URL to clone and poll
+	Branch          string `yaml:"branch,omitempty"`           // Branch to follow (default: main)
+	CheckoutDir     string `yaml:"checkout_dir,omitempty"`     // Where the repository is cloned
+	IntervalSeconds int    `yaml:"interval_seconds,omitempty"` // How often to poll and reconcile
+	Instance        string `yaml:"instance,omitempty"`         // HAProxy instance to reconcile (default: the haproxy section)
+}
+
+// DiscoverySettings configures the sources that keep the servers of backends in sync with discovered services
+type DiscoverySettings struct {
+	Instance              string                      `yaml:"instance,omitempty"`                // HAProxy instance whose backends are synced (default: the haproxy section)
+	ResyncIntervalSeconds int                         `yaml:"resync_interval_seconds,omitempty"` // How often backends are synced without changes, e.g. to retry failures
+	Consul                ConsulSettings              `yaml:"consul,omitempty"`
+	Kubernetes            KubernetesDiscoverySettings `yaml:"kubernetes,omitempty"`
+	Files                 []DiscoveryFile             `yaml:"files,omitempty"`
+}
+
+// ConsulSettings configures backends populated from the Consul catalog
+type ConsulSettings struct {
+	Address     string          `yaml:"address,omitempty"` // HTTP API of a Consul agent (default: http://127.0.0.1:8500)
+	Token       string          `yaml:"token,omitempty"`
+	TokenFile   string          `yaml:"token_file,omitempty"`
+	Datacenter  string          `yaml:"datacenter,omitempty"`   // Empty uses the datacenter of the agent
+	WaitSeconds int             `yaml:"wait_seconds,omitempty"` // How long a watch waits for changes before asking again
+	Services    []ConsulService `yaml:"services,omitempty"`
+}
+
+// ConsulService maps a Consul service to the backend holding its instances as servers
+type ConsulService struct {
+	Service string   `yaml:"service"`
+	Tags    []string `yaml:"tags,omitempty"` // Only instances with all of these tags
+	Backend string   `yaml:"backend"`
+}
+
+// KubernetesDiscoverySettings configures backends populated from the EndpointSlices of Kubernetes Services
+type KubernetesDiscoverySettings struct {
+	Kubeconfig string              `yaml:"kubeconfig,omitempty"` // Empty uses the in-cluster service account
+	Backends   []KubernetesBackend `yaml:"backends,omitempty"`
+}
+
+// KubernetesBackend references the Kubernetes Service whose endpoints are the servers of a backend
+type KubernetesBackend struct {
+	Backend     string `yaml:"backend"`
+	Service     string `yaml:"service"`                // "namespace/name"
+	Port        string `yaml:"port,omitempty"`         // Name of the Service port; may be omitted if the Service has one port
+	AddressType string `yaml:"address_type,omitempty"` // "IPv4" (default) or "IPv6"
+}
+
+// DiscoveryFile is a JSON or YAML file listing the servers of a backend, e.g. written by configuration management
+type DiscoveryFile struct {
+	Backend string `yaml:"backend"`
+	Path    string `yaml:"path"`
+}
+
+// KubernetesSettings configures the Kubernetes controllers
+type KubernetesSettings struct {
+	Kubeconfig            string               `yaml:"kubeconfig,omitempty"`              // Empty uses the in-cluster service account
+	Namespace             string               `yaml:"namespace,omitempty"`               // Namespace to watch; empty watches all namespaces
+	Instance              string               `yaml:"instance,omitempty"`                // HAProxy instance to reconcile (default: the haproxy section)
+	ResyncIntervalSeconds int                  `yaml:"resync_interval_seconds,omitempty"` // How often to reconcile without resource changes
+	Operator              bool                 `yaml:"operator,omitempty"`                // Reconcile HAProxyFrontend, HAProxyBackend and HAProxyBind resources
+	LoadBalancer          LoadBalancerSettings `yaml:"load_balancer,omitempty"`
+}
+
+// BGPSettings configures the announcement of VIPs as host routes through the FRR routing daemon
+type BGPSettings struct {
+	ASN             uint32   `yaml:"asn,omitempty"`              // AS of the FRR "router bgp" instance announcing the VIPs
+	Prefixes        []string `yaml:"prefixes,omitempty"`         // Networks whose VIPs are announced; other announcements are left alone
+	VtyshPath       string   `yaml:"vtysh_path,omitempty"`       // Path of the vtysh binary (default: vtysh)
+	IntervalSeconds int      `yaml:"interval_seconds,omitempty"` // How often the announcements are re-synced
+
+	// Method announces the VIPs as "network" statements of the BGP instance (the default) or as "static" routes
+	// that FRR redistributes
+	Method        string `yaml:"method,omitempty"`
+	StaticNextHop string `yaml:"static_next_hop,omitempty"` // Next hop of the static routes (default: Null0)
+
+	// HealthGated announces a VIP only while the default backend of a frontend bound to it has a server up, and
+	// withdraws it when the health checks of all servers fail
+	HealthGated bool `yaml:"health_gated,omitempty"`
+}
+
+// DriftDetectionSettings configures the periodic comparison of the live configuration with a desired state
+type DriftDetectionSettings struct {
+	DesiredState    string `yaml:"desired_state,omitempty"`    // State document or manifest directory
+	Record          bool   `yaml:"record,omitempty"`           // Write the live state to desired_state after every commit through the configurator
+	Remediate       bool   `yaml:"remediate,omitempty"`        // Apply the desired state when drift is detected
+	IntervalSeconds int    `yaml:"interval_seconds,omitempty"` // How often to compare
+	Instance        string `yaml:"instance,omitempty"`         // HAProxy instance to compare (default: the haproxy section)
+}
+
+// ClusterSettings configures the replication of the default instance to other HAProxy nodes
+type ClusterSettings struct {
+	// Names of haproxy_instances made to mirror the default instance after every committed transaction
+	Replicas []string `yaml:"replicas,omitempty"`
+}
+
+// LeaderElectionSettings configures how redundant configurator instances elect the one allowed to make changes
+type LeaderElectionSettings struct {
+	Backend  string `yaml:"backend,omitempty"`  // "file" or "kubernetes"; empty disables leader election
+	Identity string `yaml:"identity,omitempty"` // Name of this instance (default: the hostname)
+
+	// file: lock held by the leader, on a filesystem shared by the instances
+	LockFile string `yaml:"lock_file,omitempty"`
+
+	// kubernetes: Lease object held by the leader
+	Kubeconfig string `yaml:"kubeconfig,omitempty"` // Empty uses the in-cluster service account
+	Namespace  string `yaml:"namespace,omitempty"`
+	LeaseName  string `yaml:"lease_name,omitempty"`
+
+	LeaseDurationSeconds int `yaml:"lease_duration_seconds,omitempty"` // How long standbys wait before taking over a lease
+	RenewDeadlineSeconds int `yaml:"renew_deadline_seconds,omitempty"` // How long the leader retries renewing before it steps down
+	RetryPeriodSeconds   int `yaml:"retry_period_seconds,omitempty"`   // How often standbys try to acquire the lock or lease
+}
+
+// PeerSyncSettings configures how standbys copy the state of the leader from the other configurator instances
+type PeerSyncSettings struct {
+	Peers              []string `yaml:"peers,omitempty"`                // gRPC addresses of the other instances
+	IntervalSeconds    int      `yaml:"interval_seconds,omitempty"`     // How often standbys copy the state
+	TLS                bool     `yaml:"tls,omitempty"`                  // Connect to the peers over TLS
+	CAFile             string   `yaml:"ca_file,omitempty"`              // CA bundle verifying the peers (default: system roots)
+	InsecureSkipVerify bool     `yaml:"insecure_skip_verify,omitempty"` // Do not verify the peer certificates
+}
+
+// LoadBalancerSettings configures the controller for Services of type LoadBalancer
+type LoadBalancerSettings struct {
+	Enabled bool          `yaml:"enabled,omitempty"`
+	Class   string        `yaml:"class,omitempty"` // spec.loadBalancerClass to serve; empty serves Services without a class
+	Pools   []AddressPool `yaml:"pools,omitempty"` // The first pool is the default
+}
+
+// AddressPool is a named set of VIPs allocated to Services
+type AddressPool struct {
+	Name      string   `yaml:"name"`
+	Addresses []string `yaml:"addresses"` // CIDRs such as "192.168.1.224/28" or ranges such as "192.168.1.200-192.168.1.250"
 }
 
 // InterfaceMapping defines which subnets can be assigned to which interface
 type InterfaceMapping struct {
 	Interface string   `yaml:"interface"`
 	Subnets   []string `yaml:"subnets"`
+
+	// Routes are added to the interface while at least one VIP of their subnet is assigned to it, and removed
+	// with the last one
+	Routes []RouteTemplate `yaml:"routes,omitempty"`
+
+	// RoutingPolicy adds a rule for each VIP of a subnet that looks up the routes of its replies in a table of
+	// their own, so that VIPs of a secondary uplink answer through it
+	RoutingPolicy []RoutingPolicyTemplate `yaml:"routing_policy,omitempty"`
+}
+
+// RouteTemplate is a route that a VIP subnet of an interface mapping needs, e.g. an on-link route or a route
+// through a gateway only reachable from that subnet
+type RouteTemplate struct {
+	Subnet string `yaml:"subnet"`            // One of the subnets of the mapping whose VIPs need the route
+	To     string `yaml:"to"`                // Destination CIDR or "default"
+	Via    string `yaml:"via,omitempty"`     // Gateway; omitted for routes directly on the link
+	OnLink bool   `yaml:"on_link,omitempty"` // The gateway is reachable on the link though outside its subnets
+	Metric int    `yaml:"metric,omitempty"`  // Route metric
+	Table  int    `yaml:"table,omitempty"`   // Routing table; zero is the main table
+	Scope  string `yaml:"scope,omitempty"`   // "global", "link" or "host"
+}
+
+// RoutingPolicyTemplate is the routing policy rule added for each VIP of a subnet of an interface mapping, e.g.
+// "from 203.0.113.5/32 table 100"
+type RoutingPolicyTemplate struct {
+	Subnet   string `yaml:"subnet"`             // One of the subnets of the mapping whose VIPs get the rule
+	Table    int    `yaml:"table"`              // Routing table looked up for traffic from the VIPs
+	Priority int    `yaml:"priority,omitempty"` // Priority of the rules; lower values are looked at first
 }
 
 // LoadConfig loads the unified configuration from a file
@@ -46,11 +581,56 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Expand ${ENV_VAR} references so secrets can be injected via the environment
+	if err := expandEnvInNode(&document); err != nil {
+		return nil, fmt.Errorf("failed to expand environment variables in config file: %w", err)
+	}
+
+	var config Config
+	if len(document.Content) > 0 {
+		if err := document.Decode(&config); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	config.Path = configPath
+	if path, err := filepath.Abs(configPath); err == nil {
+		config.Path = path
+	}
+
+	// Read values provided through *_file settings
+	if err := config.HAProxy.loadFileSettings("haproxy"); err != nil {
+		return nil, err
+	}
+	for i := range config.Instances {
+		section := fmt.Sprintf("haproxy_instances[%s]", config.Instances[i].Name)
+		if err := config.Instances[i].loadFileSettings(section); err != nil {
+			return nil, err
+		}
+	}
+	if consul := &config.Discovery.Consul; consul.TokenFile != "" {
+		if consul.Token != "" {
+			return nil, fmt.Errorf("discovery.consul.token and discovery.consul.token_file are mutually exclusive")
+		}
+		token, err := readSecretFile(consul.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read discovery.consul.token_file: %w", err)
+		}
+		consul.Token = token
+	}
+
+	// Derive interface mappings from the Netplan files of the host
+	if config.Netplan.AutoDiscover {
+		if err := config.Netplan.discoverInterfaceMappings(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set defaults for HAProxy settings if not specified
 	if config.HAProxy.APIURL == "" {
 		config.HAProxy.APIURL = getEnvWithDefault("HAPROXY_API_URL", "http://localhost:5555")
@@ -61,10 +641,329 @@ func LoadConfig(configPath string) (*Config, error) {
 	if config.HAProxy.Password == "" {
 		config.HAProxy.Password = getEnvWithDefault("HAPROXY_API_PASSWORD", "admin")
 	}
+	config.HAProxy.setDefaults()
+	for i := range config.Instances {
+		config.Instances[i].setDefaults()
+	}
+
+	// Set defaults for Vault settings
+	if config.Vault.Address == "" {
+		config.Vault.Address = os.Getenv("VAULT_ADDR")
+	}
+	if config.Vault.RefreshIntervalSeconds == 0 {
+		config.Vault.RefreshIntervalSeconds = 300
+	}
+	if config.Vault.HAProxyCredentials.UsernameKey == "" {
+		config.Vault.HAProxyCredentials.UsernameKey = "username"
+	}
+	if config.Vault.HAProxyCredentials.PasswordKey == "" {
+		config.Vault.HAProxyCredentials.PasswordKey = "password"
+	}
+	if config.Vault.GRPCTLS.CertificateKey == "" {
+		config.Vault.GRPCTLS.CertificateKey = "certificate"
+	}
+	if config.Vault.GRPCTLS.PrivateKeyKey == "" {
+		config.Vault.GRPCTLS.PrivateKeyKey = "private_key"
+	}
+
+	if config.Idempotency.TTLSeconds == 0 {
+		config.Idempotency.TTLSeconds = 600
+	}
+	if config.Idempotency.MaxKeys == 0 {
+		config.Idempotency.MaxKeys = 10000
+	}
+
+	if config.HasGitOps() {
+		config.GitOps.setDefaults()
+	}
+	if config.HasDiscovery() {
+		config.Discovery.setDefaults()
+	}
+	if config.HasDriftDetection() {
+		if config.DriftDetection.IntervalSeconds == 0 {
+			config.DriftDetection.IntervalSeconds = 60
+		}
+		if config.DriftDetection.Instance == "" {
+			config.DriftDetection.Instance = DefaultInstance
+		}
+	}
+	if config.Kubernetes.Instance == "" {
+		config.Kubernetes.Instance = DefaultInstance
+	}
+	if config.Kubernetes.ResyncIntervalSeconds == 0 {
+		config.Kubernetes.ResyncIntervalSeconds = 300
+	}
+	if config.HasLeaderElection() {
+		config.LeaderElection.setDefaults()
+	}
+	if config.HasPeerSync() && config.PeerSync.IntervalSeconds == 0 {
+		config.PeerSync.IntervalSeconds = 10
+	}
+	if config.HasBGP() {
+		if config.BGP.VtyshPath == "" {
+			config.BGP.VtyshPath = "vtysh"
+		}
+		if config.BGP.IntervalSeconds == 0 {
+			config.BGP.IntervalSeconds = 30
+		}
+		if config.BGP.Method == "" {
+			config.BGP.Method = "network"
+		}
+		if config.BGP.Method == "static" && config.BGP.StaticNextHop == "" {
+			config.BGP.StaticNextHop = "Null0"
+		}
+	}
 
 	return &config, nil
 }
 
+// setDefaults fills in unset GitOps settings
+func (g *GitOpsSettings) setDefaults() {
+	if g.Repository != "" {
+		if g.Branch == "" {
+			g.Branch = "main"
+		}
+		if g.CheckoutDir == "" {
+			g.CheckoutDir = "/var/lib/haproxy-configurator/gitops"
+		}
+		if g.Path == "" {
+			g.Path = "."
+		}
+	}
+	if g.IntervalSeconds == 0 {
+		g.IntervalSeconds = 60
+	}
+	if g.Instance == "" {
+		g.Instance = DefaultInstance
+	}
+}
+
+// setDefaults fills in unset discovery settings
+func (d *DiscoverySettings) setDefaults() {
+	if d.Instance == "" {
+		d.Instance = DefaultInstance
+	}
+	if d.ResyncIntervalSeconds == 0 {
+		d.ResyncIntervalSeconds = 30
+	}
+	if d.Consul.Address == "" {
+		d.Consul.Address = getEnvWithDefault("CONSUL_HTTP_ADDR", "http://127.0.0.1:8500")
+	}
+	if d.Consul.Token == "" && d.Consul.TokenFile == "" {
+		d.Consul.Token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+	if d.Consul.WaitSeconds == 0 {
+		d.Consul.WaitSeconds = 300
+	}
+	for i := range d.Kubernetes.Backends {
+		if d.Kubernetes.Backends[i].AddressType == "" {
+			d.Kubernetes.Backends[i].AddressType = "IPv4"
+		}
+	}
+}
+
+// validate checks the discovery settings; instanceNames are the configured HAProxy instances
+func (d *DiscoverySettings) validate(instanceNames map[string]bool) error {
+	if d.ResyncIntervalSeconds < 0 || d.Consul.WaitSeconds < 0 {
+		return fmt.Errorf("discovery resync_interval_seconds and consul wait_seconds must not be negative")
+	}
+	if !instanceNames[d.Instance] {
+		return fmt.Errorf("unknown HAProxy instance %q for discovery", d.Instance)
+	}
+	// Two sources filling the same backend would keep removing each other's servers
+	backends := make(map[string]bool)
+	for i, service := range d.Consul.Services {
+		if service.Service == "" || service.Backend == "" {
+			return fmt.Errorf("service and backend are required for consul service %d", i)
+		}
+		if backends[service.Backend] {
+			return fmt.Errorf("backend %s is synced by more than one discovery source", service.Backend)
+		}
+		backends[service.Backend] = true
+	}
+	for i, backend := range d.Kubernetes.Backends {
+		if backend.Backend == "" || backend.Service == "" {
+			return fmt.Errorf("backend and service are required for kubernetes backend %d", i)
+		}
+		if namespace, name, ok := strings.Cut(backend.Service, "/"); !ok || namespace == "" || name == "" {
+			return fmt.Errorf("service of kubernetes backend %s must be given as namespace/name, got %q", backend.Backend, backend.Service)
+		}
+		if backend.AddressType != "IPv4" && backend.AddressType != "IPv6" {
+			return fmt.Errorf("address_type of kubernetes backend %s must be IPv4 or IPv6, got %q", backend.Backend, backend.AddressType)
+		}
+		if backends[backend.Backend] {
+			return fmt.Errorf("backend %s is synced by more than one discovery source", backend.Backend)
+		}
+		backends[backend.Backend] = true
+	}
+	for i, file := range d.Files {
+		if file.Backend == "" || file.Path == "" {
+			return fmt.Errorf("backend and path are required for discovery file %d", i)
+		}
+		if backends[file.Backend] {
+			return fmt.Errorf("backend %s is synced by more than one discovery source", file.Backend)
+		}
+		backends[file.Backend] = true
+	}
+	return nil
+}
+
+// setDefaults fills in unset leader election settings
+func (l *LeaderElectionSettings) setDefaults() {
+	if l.Identity == "" {
+		l.Identity, _ = os.Hostname()
+	}
+	if l.LeaseName == "" {
+		l.LeaseName = "haproxy-configurator"
+	}
+	if l.LeaseDurationSeconds == 0 {
+		l.LeaseDurationSeconds = 15
+	}
+	if l.RenewDeadlineSeconds == 0 {
+		l.RenewDeadlineSeconds = 10
+	}
+	if l.RetryPeriodSeconds == 0 {
+		l.RetryPeriodSeconds = 2
+	}
+}
+
+// setDefaults fills in unset circuit breaker, timeout, retry, connection pool, concurrency and standalone settings
+func (h *HAProxySettings) setDefaults() {
+	h.CircuitBreaker.setDefaults()
+	h.Standalone.setDefaults()
+	if h.RequestTimeoutSeconds == 0 {
+		h.RequestTimeoutSeconds = 30
+	}
+	if h.Retry.MaxRetries == 0 {
+		h.Retry.MaxRetries = 2
+	}
+	if h.Retry.InitialBackoffMs == 0 {
+		h.Retry.InitialBackoffMs = 200
+	}
+	if h.Retry.MaxBackoffMs == 0 {
+		h.Retry.MaxBackoffMs = 2000
+	}
+	if h.Failover.FailureThreshold == 0 {
+		h.Failover.FailureThreshold = 3
+	}
+	if h.Failover.ProbeIntervalSeconds == 0 {
+		h.Failover.ProbeIntervalSeconds = 10
+	}
+	if h.ApplyConcurrency == 0 {
+		h.ApplyConcurrency = 4
+	}
+	if h.ConnectionPool.MaxIdleConnsPerHost == 0 {
+		h.ConnectionPool.MaxIdleConnsPerHost = 32
+	}
+	if h.ConnectionPool.IdleTimeoutSeconds == 0 {
+		h.ConnectionPool.IdleTimeoutSeconds = 90
+	}
+	if h.ConnectionPool.KeepAliveSeconds == 0 {
+		h.ConnectionPool.KeepAliveSeconds = 30
+	}
+}
+
+// validateRequestSettings checks the circuit breaker, timeout, retry, failover, connection pool, concurrency and
+// transaction settings
+func (h *HAProxySettings) validateRequestSettings() error {
+	if h.CircuitBreaker.FailureThreshold < 0 || h.CircuitBreaker.OpenSeconds < 0 {
+		return fmt.Errorf("circuit breaker settings must not be negative")
+	}
+	if h.RequestTimeoutSeconds < 0 {
+		return fmt.Errorf("request_timeout_seconds must not be negative")
+	}
+	if h.Retry.MaxRetries < 0 || h.Retry.InitialBackoffMs < 0 || h.Retry.MaxBackoffMs < 0 {
+		return fmt.Errorf("retry settings must not be negative")
+	}
+	if h.Retry.MaxBackoffMs < h.Retry.InitialBackoffMs {
+		return fmt.Errorf("retry max_backoff_ms must not be less than initial_backoff_ms")
+	}
+	for name, overrides := range map[string]OperationRetrySettings{"reads": h.Retry.Reads, "writes": h.Retry.Writes} {
+		if overrides.MaxRetries < 0 || overrides.InitialBackoffMs < 0 || overrides.MaxBackoffMs < 0 {
+			return fmt.Errorf("retry %s settings must not be negative", name)
+		}
+		if settings := h.Retry.For(overrides); settings.MaxBackoffMs < settings.InitialBackoffMs {
+			return fmt.Errorf("retry %s max_backoff_ms must not be less than initial_backoff_ms", name)
+		}
+	}
+	if h.Failover.FailureThreshold < 0 || h.Failover.ProbeIntervalSeconds < 0 {
+		return fmt.Errorf("failover settings must not be negative")
+	}
+	if h.ReadCacheTTLMs < 0 {
+		return fmt.Errorf("read_cache_ttl_ms must not be negative")
+	}
+	pool := h.ConnectionPool
+	if pool.MaxIdleConnsPerHost < 0 || pool.MaxConnsPerHost < 0 || pool.IdleTimeoutSeconds < 0 || pool.KeepAliveSeconds < 0 {
+		return fmt.Errorf("connection pool settings must not be negative")
+	}
+	if h.ApplyConcurrency < 0 {
+		return fmt.Errorf("apply_concurrency must not be negative")
+	}
+	if h.MaxOpenTransactions < 0 {
+		return fmt.Errorf("max_open_transactions must not be negative")
+	}
+	urls := map[string]bool{h.APIURL: true}
+	for _, url := range h.FallbackAPIURLs {
+		if url == "" {
+			return fmt.Errorf("fallback_api_urls must not contain empty URLs")
+		}
+		if urls[url] {
+			return fmt.Errorf("duplicate Data Plane API URL %s in fallback_api_urls", url)
+		}
+		urls[url] = true
+	}
+	return nil
+}
+
+// setDefaults fills in unset circuit breaker settings
+func (c *CircuitBreakerSettings) setDefaults() {
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = 5
+	}
+	if c.OpenSeconds == 0 {
+		c.OpenSeconds = 30
+	}
+}
+
+// loadFileSettings reads settings whose value is supplied in a separate file, such as mounted secrets
+func (h *HAProxySettings) loadFileSettings(section string) error {
+	fileSettings := []struct {
+		name  string
+		value *string
+		path  string
+	}{
+		{"api_url", &h.APIURL, h.APIURLFile},
+		{"username", &h.Username, h.UsernameFile},
+		{"password", &h.Password, h.PasswordFile},
+	}
+
+	for _, setting := range fileSettings {
+		if setting.path == "" {
+			continue
+		}
+		if *setting.value != "" {
+			return fmt.Errorf("%s.%s and %s.%s_file are mutually exclusive", section, setting.name, section, setting.name)
+		}
+
+		value, err := readSecretFile(setting.path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s.%s_file: %w", section, setting.name, err)
+		}
+		*setting.value = value
+	}
+
+	return nil
+}
+
+// readSecretFile reads a single-value file, stripping the trailing newline most tools append
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
 // getEnvWithDefault returns the environment variable value or a default if not set
 func getEnvWithDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -97,27 +996,275 @@ func (c *Config) FindInterfaceForIP(ipAddr string) (string, error) {
 
 // ValidateConfig validates the configuration
 func (c *Config) ValidateConfig() error {
-	// Validate HAProxy settings
-	if c.HAProxy.APIURL == "" {
-		return fmt.Errorf("HAProxy API URL is required")
+	// Validate HAProxy settings; standalone instances have no Data Plane API
+	if !c.HAProxy.Standalone.Enabled {
+		if c.HAProxy.APIURL == "" {
+			return fmt.Errorf("HAProxy API URL is required")
+		}
+		if c.HAProxy.Username == "" {
+			return fmt.Errorf("HAProxy API username is required")
+		}
+		if c.HAProxy.Password == "" {
+			return fmt.Errorf("HAProxy API password is required")
+		}
+	}
+	if err := c.HAProxy.Standalone.validate(); err != nil {
+		return err
+	}
+	if err := c.HAProxy.validateRequestSettings(); err != nil {
+		return fmt.Errorf("invalid HAProxy settings: %w", err)
+	}
+	if _, err := c.HAProxy.TLS.ClientTLSConfig(); err != nil {
+		return fmt.Errorf("invalid HAProxy TLS settings: %w", err)
+	}
+
+	// Validate additional HAProxy instances
+	instanceNames := map[string]bool{DefaultInstance: true}
+	for i, instance := range c.Instances {
+		if instance.Name == "" {
+			return fmt.Errorf("name is required for HAProxy instance %d", i)
+		}
+		if instanceNames[instance.Name] {
+			return fmt.Errorf("duplicate HAProxy instance name %q (%q is reserved for the haproxy section)", instance.Name, DefaultInstance)
+		}
+		instanceNames[instance.Name] = true
+
+		if !instance.Standalone.Enabled && (instance.APIURL == "" || instance.Username == "" || instance.Password == "") {
+			return fmt.Errorf("api_url, username and password are required for HAProxy instance %s", instance.Name)
+		}
+		if err := instance.Standalone.validate(); err != nil {
+			return fmt.Errorf("invalid settings for HAProxy instance %s: %w", instance.Name, err)
+		}
+		if err := instance.validateRequestSettings(); err != nil {
+			return fmt.Errorf("invalid settings for HAProxy instance %s: %w", instance.Name, err)
+		}
+		if _, err := instance.TLS.ClientTLSConfig(); err != nil {
+			return fmt.Errorf("invalid TLS settings for HAProxy instance %s: %w", instance.Name, err)
+		}
+	}
+
+	// Validate logging settings
+	if err := c.Logging.validate(); err != nil {
+		return err
+	}
+
+	if c.Journal.RetentionDays < 0 {
+		return fmt.Errorf("journal retention_days must not be negative")
+	}
+	if c.Idempotency.TTLSeconds < 0 || c.Idempotency.MaxKeys < 0 {
+		return fmt.Errorf("idempotency ttl_seconds and max_keys must not be negative")
+	}
+
+	if err := c.Audit.validate(); err != nil {
+		return err
+	}
+
+	if err := c.DataplaneRecording.validate(); err != nil {
+		return err
+	}
+
+	for i, webhook := range c.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("url is required for webhook %d", i)
+		}
+		switch webhook.Type {
+		case "", "http":
+		case "slack":
+			if len(webhook.Headers) > 0 {
+				return fmt.Errorf("headers are not supported for Slack webhook %d", i)
+			}
+			if webhook.Secret != "" {
+				return fmt.Errorf("secret is not supported for Slack webhook %d", i)
+			}
+		default:
+			return fmt.Errorf("invalid type %q for webhook %d: must be http or slack", webhook.Type, i)
+		}
+		if webhook.MaxRetries < 0 || webhook.MaxRetries > 10 {
+			return fmt.Errorf("max_retries of webhook %d must be between 0 and 10", i)
+		}
+		for _, event := range webhook.Events {
+			switch event {
+			case "transaction_committed", "transaction_failed", "rollback", "netplan_failed", "replication_failed", "drift_detected":
+			default:
+				return fmt.Errorf("unknown event type %q for webhook %s", event, webhook.URL)
+			}
+		}
+	}
+
+	if err := c.Vault.validate(); err != nil {
+		return err
+	}
+
+	if c.HasGitOps() {
+		if c.GitOps.IntervalSeconds < 0 {
+			return fmt.Errorf("gitops interval_seconds must not be negative")
+		}
+		if c.GitOps.Repository != "" && filepath.IsAbs(c.GitOps.Path) {
+			return fmt.Errorf("gitops path must be relative to the repository root")
+		}
+		if !instanceNames[c.GitOps.Instance] {
+			return fmt.Errorf("unknown HAProxy instance %q for gitops", c.GitOps.Instance)
+		}
+	}
+
+	if c.HasDiscovery() {
+		if err := c.Discovery.validate(instanceNames); err != nil {
+			return err
+		}
+		// GitOps owns the complete configuration and would keep removing the discovered servers
+		if c.HasGitOps() && c.GitOps.Instance == c.Discovery.Instance {
+			return fmt.Errorf("gitops and discovery cannot manage the same HAProxy instance %q", c.GitOps.Instance)
+		}
+		if c.HasKubernetesOperator() && c.Kubernetes.Instance == c.Discovery.Instance {
+			return fmt.Errorf("the kubernetes operator and discovery cannot manage the same HAProxy instance %q", c.Kubernetes.Instance)
+		}
+	}
+
+	if c.HasDriftDetection() {
+		if c.DriftDetection.IntervalSeconds < 0 {
+			return fmt.Errorf("drift_detection interval_seconds must not be negative")
+		}
+		if !instanceNames[c.DriftDetection.Instance] {
+			return fmt.Errorf("unknown HAProxy instance %q for drift_detection", c.DriftDetection.Instance)
+		}
+		if c.DriftDetection.Record {
+			if info, err := os.Stat(c.DriftDetection.DesiredState); err == nil && info.IsDir() {
+				return fmt.Errorf("drift_detection desired_state must be a file to record into")
+			}
+		}
+	}
+
+	if c.HasKubernetesOperator() || c.HasKubernetesLoadBalancer() {
+		if c.Kubernetes.ResyncIntervalSeconds < 0 {
+			return fmt.Errorf("kubernetes resync_interval_seconds must not be negative")
+		}
+		if !instanceNames[c.Kubernetes.Instance] {
+			return fmt.Errorf("unknown HAProxy instance %q for kubernetes", c.Kubernetes.Instance)
+		}
+		// GitOps owns the complete configuration and would keep deleting the resources created for Kubernetes
+		if c.HasGitOps() && c.GitOps.Instance == c.Kubernetes.Instance {
+			return fmt.Errorf("gitops and kubernetes cannot manage the same HAProxy instance %q", c.GitOps.Instance)
+		}
+	}
+
+	if c.HasKubernetesLoadBalancer() {
+		if len(c.Kubernetes.LoadBalancer.Pools) == 0 {
+			return fmt.Errorf("at least one address pool is required for the kubernetes load balancer")
+		}
+		poolNames := make(map[string]bool)
+		for i, pool := range c.Kubernetes.LoadBalancer.Pools {
+			if pool.Name == "" {
+				return fmt.Errorf("name is required for address pool %d", i)
+			}
+			if poolNames[pool.Name] {
+				return fmt.Errorf("duplicate address pool %s", pool.Name)
+			}
+			poolNames[pool.Name] = true
+			if len(pool.Addresses) == 0 {
+				return fmt.Errorf("at least one address is required for address pool %s", pool.Name)
+			}
+			for _, addresses := range pool.Addresses {
+				if _, _, err := ParseAddressRange(addresses); err != nil {
+					return fmt.Errorf("invalid addresses in pool %s: %w", pool.Name, err)
+				}
+			}
+		}
+	}
+
+	if c.HasBGP() {
+		if len(c.BGP.Prefixes) == 0 {
+			return fmt.Errorf("at least one prefix is required for bgp")
+		}
+		for _, prefix := range c.BGP.Prefixes {
+			if _, err := netip.ParsePrefix(prefix); err != nil {
+				return fmt.Errorf("invalid bgp prefix %q: %w", prefix, err)
+			}
+		}
+		if c.BGP.IntervalSeconds < 0 {
+			return fmt.Errorf("bgp interval_seconds must not be negative")
+		}
+		switch c.BGP.Method {
+		case "", "network", "static":
+		default:
+			return fmt.Errorf("invalid bgp method %q: must be network or static", c.BGP.Method)
+		}
+		if c.BGP.StaticNextHop != "" && c.BGP.Method != "static" {
+			return fmt.Errorf("bgp static_next_hop requires the static method")
+		}
+	}
+
+	if c.HasLeaderElection() {
+		if err := c.LeaderElection.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.HasPeerSync() {
+		// Leadership decides in which direction the state is copied
+		if !c.HasLeaderElection() {
+			return fmt.Errorf("peer_sync requires leader_election")
+		}
+		if c.PeerSync.IntervalSeconds < 0 {
+			return fmt.Errorf("peer_sync interval_seconds must not be negative")
+		}
+		for i, peer := range c.PeerSync.Peers {
+			if _, _, err := net.SplitHostPort(peer); err != nil {
+				return fmt.Errorf("invalid peer_sync peer %d: %w", i, err)
+			}
+		}
+	}
+
+	replicas := make(map[string]bool)
+	for _, replica := range c.Cluster.Replicas {
+		if replica == DefaultInstance || !instanceNames[replica] {
+			return fmt.Errorf("cluster replica %q must be one of haproxy_instances", replica)
+		}
+		if replicas[replica] {
+			return fmt.Errorf("duplicate cluster replica %q", replica)
+		}
+		replicas[replica] = true
 	}
-	if c.HAProxy.Username == "" {
-		return fmt.Errorf("HAProxy API username is required")
+	// Replicas are overwritten with the configuration of the default instance on every commit
+	if c.HasGitOps() && replicas[c.GitOps.Instance] {
+		return fmt.Errorf("gitops cannot manage cluster replica %q", c.GitOps.Instance)
 	}
-	if c.HAProxy.Password == "" {
-		return fmt.Errorf("HAProxy API password is required")
+	if c.HasDiscovery() && replicas[c.Discovery.Instance] {
+		return fmt.Errorf("discovery cannot manage cluster replica %q", c.Discovery.Instance)
+	}
+	if (c.HasKubernetesOperator() || c.HasKubernetesLoadBalancer()) && replicas[c.Kubernetes.Instance] {
+		return fmt.Errorf("kubernetes cannot manage cluster replica %q", c.Kubernetes.Instance)
 	}
 
 	// Validate Netplan settings (only if Netplan integration is enabled)
 	if len(c.Netplan.InterfaceMappings) > 0 {
+		switch c.Netplan.Backend {
+		case "", "netplan", "networkmanager":
+		default:
+			return fmt.Errorf("invalid Netplan backend %q: must be netplan or networkmanager", c.Netplan.Backend)
+		}
+
 		if c.Netplan.ConfigPath == "" {
-			c.Netplan.ConfigPath = "/etc/netplan/99-haproxy-configurator.yaml"
+			c.Netplan.ConfigPath = defaultNetplanConfigPath
+			if c.Netplan.UsesNetworkManager() {
+				// Not a Netplan directory, in case Netplan is installed as well
+				c.Netplan.ConfigPath = "/var/lib/haproxy-configurator/addresses.yaml"
+			}
 		}
 
 		if c.Netplan.TransactionDir == "" {
 			c.Netplan.TransactionDir = "/tmp/haproxy-netplan-transactions"
 		}
 
+		if c.Netplan.OrphanCleanup.IntervalSeconds < 0 {
+			return fmt.Errorf("netplan orphan_cleanup interval_seconds must not be negative")
+		}
+
+		switch c.Netplan.FailurePolicy {
+		case "", "warn", "fail":
+		default:
+			return fmt.Errorf("invalid Netplan failure policy %q: must be warn or fail", c.Netplan.FailurePolicy)
+		}
+
 		for i, mapping := range c.Netplan.InterfaceMappings {
 			if mapping.Interface == "" {
 				return fmt.Errorf("interface name is required for mapping %d", i)
@@ -130,13 +1277,301 @@ func (c *Config) ValidateConfig() error {
 					return fmt.Errorf("invalid CIDR %s for interface %s at index %d: %w", subnet, mapping.Interface, j, err)
 				}
 			}
+			if c.Netplan.UsesNetworkManager() && (len(mapping.Routes) > 0 || len(mapping.RoutingPolicy) > 0) {
+				return fmt.Errorf("routes and routing_policy of interface %s require the netplan backend", mapping.Interface)
+			}
+			for j, route := range mapping.Routes {
+				if err := validateRouteTemplate(route, mapping.Subnets); err != nil {
+					return fmt.Errorf("invalid route %d for interface %s: %w", j, mapping.Interface, err)
+				}
+			}
+			for j, policy := range mapping.RoutingPolicy {
+				if err := validateRoutingPolicyTemplate(policy, mapping.Subnets); err != nil {
+					return fmt.Errorf("invalid routing policy %d for interface %s: %w", j, mapping.Interface, err)
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// validateRouteTemplate checks a route template of an interface mapping with the given subnets
+func validateRouteTemplate(route RouteTemplate, subnets []string) error {
+	if err := validateTemplateSubnet(route.Subnet, subnets); err != nil {
+		return err
+	}
+	if route.To != "default" {
+		if _, _, err := net.ParseCIDR(route.To); err != nil {
+			return fmt.Errorf("invalid destination %q: must be a CIDR or default", route.To)
+		}
+	}
+	if route.Via != "" && net.ParseIP(route.Via) == nil {
+		return fmt.Errorf("invalid gateway %q", route.Via)
+	}
+	if route.OnLink && route.Via == "" {
+		return fmt.Errorf("on_link requires a gateway")
+	}
+	if route.Metric < 0 || route.Table < 0 {
+		return fmt.Errorf("metric and table must not be negative")
+	}
+	switch route.Scope {
+	case "", "global", "link", "host":
+	default:
+		return fmt.Errorf("invalid scope %q: must be global, link or host", route.Scope)
+	}
+	return nil
+}
+
+// validateRoutingPolicyTemplate checks a routing policy template of an interface mapping with the given subnets
+func validateRoutingPolicyTemplate(policy RoutingPolicyTemplate, subnets []string) error {
+	if err := validateTemplateSubnet(policy.Subnet, subnets); err != nil {
+		return err
+	}
+	if policy.Table <= 0 {
+		return fmt.Errorf("table must be positive")
+	}
+	if policy.Priority < 0 {
+		return fmt.Errorf("priority must not be negative")
+	}
+	return nil
+}
+
+// validateTemplateSubnet checks that the subnet of a route or routing policy template is one of subnets
+func validateTemplateSubnet(subnet string, subnets []string) error {
+	_, cidr, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %q: %w", subnet, err)
+	}
+	for _, s := range subnets {
+		if _, mapped, err := net.ParseCIDR(s); err == nil && mapped.String() == cidr.String() {
+			return nil
+		}
+	}
+	return fmt.Errorf("subnet %s is not one of the subnets of the mapping", subnet)
+}
+
 // HasNetplanIntegration returns true if Netplan integration is configured
 func (c *Config) HasNetplanIntegration() bool {
 	return len(c.Netplan.InterfaceMappings) > 0
 }
+
+// HasStandalone returns true if any HAProxy instance is managed without the Data Plane API
+func (c *Config) HasStandalone() bool {
+	if c.HAProxy.Standalone.Enabled {
+		return true
+	}
+	for _, instance := range c.Instances {
+		if instance.Standalone.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// HasJournal returns true if the mutation event journal is configured
+func (c *Config) HasJournal() bool {
+	return c.Journal.Path != ""
+}
+
+// HasMetadata returns true if labels and annotations of resources are stored
+func (c *Config) HasMetadata() bool {
+	return c.Metadata.Path != ""
+}
+
+// HasAudit returns true if configuration changes are forwarded to syslog or journald
+func (c *Config) HasAudit() bool {
+	return c.Audit.Target != ""
+}
+
+// HasGitOps returns true if reconciliation from manifests is configured
+func (c *Config) HasGitOps() bool {
+	return c.GitOps.Path != "" || c.GitOps.Repository != ""
+}
+
+// HasDiscovery returns true if the servers of backends are synced from a discovery source
+func (c *Config) HasDiscovery() bool {
+	return len(c.Discovery.Consul.Services) > 0 || len(c.Discovery.Kubernetes.Backends) > 0 || len(c.Discovery.Files) > 0
+}
+
+// HasKubernetesOperator returns true if the Kubernetes custom resources are reconciled
+func (c *Config) HasKubernetesOperator() bool {
+	return c.Kubernetes.Operator
+}
+
+// HasKubernetesLoadBalancer returns true if Services of type LoadBalancer are served
+func (c *Config) HasKubernetesLoadBalancer() bool {
+	return c.Kubernetes.LoadBalancer.Enabled
+}
+
+// HasCluster returns true if the default instance is replicated to other nodes
+func (c *Config) HasCluster() bool {
+	return len(c.Cluster.Replicas) > 0
+}
+
+// HasDriftDetection returns true if the live configuration is compared with a desired state
+func (c *Config) HasDriftDetection() bool {
+	return c.DriftDetection.DesiredState != ""
+}
+
+// HasLeaderElection returns true if the instance has to be elected leader before making changes
+func (c *Config) HasLeaderElection() bool {
+	return c.LeaderElection.Backend != ""
+}
+
+// HasPeerSync returns true if standbys copy the state of the leader
+func (c *Config) HasPeerSync() bool {
+	return len(c.PeerSync.Peers) > 0
+}
+
+// HasBGP returns true if VIPs are announced over BGP
+func (c *Config) HasBGP() bool {
+	return c.BGP.ASN != 0
+}
+
+// ParseAddressRange parses a CIDR or an "first-last" address range into its first and last usable address.
+// The network and broadcast addresses of IPv4 CIDRs larger than /31 are excluded.
+func ParseAddressRange(value string) (netip.Addr, netip.Addr, error) {
+	if first, last, ok := strings.Cut(value, "-"); ok {
+		from, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid address range %q: %w", value, err)
+		}
+		to, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid address range %q: %w", value, err)
+		}
+		if from.Is4() != to.Is4() || to.Less(from) {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid address range %q", value)
+		}
+		return from, to, nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid CIDR %q: %w", value, err)
+	}
+	prefix = prefix.Masked()
+	first := prefix.Addr()
+	bytes := first.AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	if first.Is4() && prefix.Bits() < 31 {
+		first = first.Next()
+		last = last.Prev()
+	}
+	return first, last, nil
+}
+
+// HasVault returns true if any secret is sourced from Vault
+func (c *Config) HasVault() bool {
+	return c.Vault.HAProxyCredentials.Path != "" || c.Vault.GRPCTLS.Path != ""
+}
+
+// validate checks the Vault settings when a secret is sourced from Vault
+func (v *VaultSettings) validate() error {
+	if v.HAProxyCredentials.Path == "" && v.GRPCTLS.Path == "" {
+		return nil
+	}
+	if v.Address == "" {
+		return fmt.Errorf("vault address is required (set vault.address or VAULT_ADDR)")
+	}
+	if v.RefreshIntervalSeconds < 0 {
+		return fmt.Errorf("vault refresh_interval_seconds must not be negative")
+	}
+
+	switch v.Auth.Method {
+	case "token":
+		if v.Auth.Token == "" && v.Auth.TokenFile == "" && os.Getenv("VAULT_TOKEN") == "" {
+			return fmt.Errorf("vault token auth requires token, token_file or VAULT_TOKEN")
+		}
+	case "approle":
+		if v.Auth.RoleID == "" {
+			return fmt.Errorf("vault approle auth requires role_id")
+		}
+		if v.Auth.SecretID == "" && v.Auth.SecretIDFile == "" {
+			return fmt.Errorf("vault approle auth requires secret_id or secret_id_file")
+		}
+	case "kubernetes":
+		if v.Auth.Role == "" {
+			return fmt.Errorf("vault kubernetes auth requires role")
+		}
+	default:
+		return fmt.Errorf("invalid vault auth method %q: must be one of token, approle, kubernetes", v.Auth.Method)
+	}
+
+	return nil
+}
+
+// validate checks the logging settings for unsupported values
+func (l *LoggingSettings) validate() error {
+	switch l.Level {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("invalid log level %q: must be one of debug, info, warn, error", l.Level)
+	}
+
+	switch l.Format {
+	case "", "json", "console":
+	default:
+		return fmt.Errorf("invalid log format %q: must be json or console", l.Format)
+	}
+
+	if l.Rotation.MaxSizeMB < 0 || l.Rotation.MaxAgeDays < 0 || l.Rotation.MaxBackups < 0 {
+		return fmt.Errorf("log rotation limits must not be negative")
+	}
+
+	return nil
+}
+
+// syslogFacilities are the facility names accepted for the audit export
+var syslogFacilities = []string{"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron",
+	"authpriv", "ftp", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7"}
+
+// validate checks the audit export target and its syslog transport
+func (a *AuditSettings) validate() error {
+	switch a.Target {
+	case "", "journald":
+	case "syslog":
+		switch a.Network {
+		case "":
+		case "udp", "tcp", "unixgram":
+			if a.Address == "" {
+				return fmt.Errorf("audit address is required for syslog over %s", a.Network)
+			}
+		default:
+			return fmt.Errorf("invalid audit network %q: must be udp, tcp or unixgram", a.Network)
+		}
+		if a.Facility != "" && !slices.Contains(syslogFacilities, a.Facility) {
+			return fmt.Errorf("invalid audit facility %q", a.Facility)
+		}
+	default:
+		return fmt.Errorf("invalid audit target %q: must be syslog or journald", a.Target)
+	}
+	return nil
+}
+
+// validate checks the leader election backend and timings
+func (l *LeaderElectionSettings) validate() error {
+	switch l.Backend {
+	case "file":
+		if l.LockFile == "" {
+			return fmt.Errorf("lock_file is required for file leader election")
+		}
+	case "kubernetes":
+		if l.Namespace == "" {
+			return fmt.Errorf("namespace is required for kubernetes leader election")
+		}
+	default:
+		return fmt.Errorf("unknown leader_election backend %q (supported: file, kubernetes)", l.Backend)
+	}
+	if l.Identity == "" {
+		return fmt.Errorf("leader_election identity is required when the hostname is unknown")
+	}
+	if l.RetryPeriodSeconds <= 0 || l.RenewDeadlineSeconds <= l.RetryPeriodSeconds || l.LeaseDurationSeconds <= l.RenewDeadlineSeconds {
+		return fmt.Errorf("leader_election requires lease_duration_seconds > renew_deadline_seconds > retry_period_seconds > 0")
+	}
+	return nil
+}
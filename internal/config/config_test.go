@@ -0,0 +1,578 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadConfigFileSettings(t *testing.T) {
+	dir := t.TempDir()
+	usernameFile := writeFile(t, dir, "username", "dataplane\n")
+	passwordFile := writeFile(t, dir, "password", "from-secret\r\n")
+	configPath := writeFile(t, dir, "config.yaml", `haproxy:
+  api_url: "http://127.0.0.1:5555"
+  username_file: "`+usernameFile+`"
+  password_file: "`+passwordFile+`"
+`)
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.HAProxy.Username != "dataplane" {
+		t.Errorf("Expected username from file, got %q", cfg.HAProxy.Username)
+	}
+	if cfg.HAProxy.Password != "from-secret" {
+		t.Errorf("Expected password from file without trailing newline, got %q", cfg.HAProxy.Password)
+	}
+}
+
+func TestLoadConfigFileSettingsConflict(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := writeFile(t, dir, "password", "from-secret")
+	configPath := writeFile(t, dir, "config.yaml", `haproxy:
+  password: "inline"
+  password_file: "`+passwordFile+`"
+`)
+
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected error when both password and password_file are set")
+	}
+}
+
+func TestLoadConfigFileSettingsMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := writeFile(t, dir, "config.yaml", `haproxy:
+  password_file: "`+filepath.Join(dir, "missing")+`"
+`)
+
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected error for missing password_file")
+	}
+}
+
+func TestLoadConfigDiscoversInterfaceMappings(t *testing.T) {
+	dir := t.TempDir()
+	netplanDir := filepath.Join(dir, "netplan")
+	if err := os.Mkdir(netplanDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, netplanDir, "50-cloud-init.yaml", `network:
+  version: 2
+  ethernets:
+    eth0:
+      addresses:
+        - 192.168.1.10/24
+        - 192.168.1.11/24
+        - fe80::1/64
+    eth1:
+      dhcp4: true
+  vlans:
+    vlan100:
+      id: 100
+      link: eth0
+      addresses:
+        - 10.100.0.2/24
+`)
+	writeFile(t, netplanDir, "60-uplink.yaml", `network:
+  ethernets:
+    eth1:
+      addresses:
+        - "203.0.113.2/28":
+            label: eth1:uplink
+        - 198.51.100.7/32
+`)
+	// The file the configurator writes the VIPs to is not read
+	ownPath := writeFile(t, netplanDir, "99-haproxy-configurator.yaml", `network:
+  ethernets:
+    eth2:
+      addresses: [172.16.0.10/16]
+`)
+	configPath := writeFile(t, dir, "config.yaml", `netplan:
+  auto_discover: true
+  discovery_dir: "`+netplanDir+`"
+  netplan_config_path: "`+ownPath+`"
+  interface_mappings:
+    - interface: "bond0"
+      subnets: ["10.100.0.0/16"]
+`)
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	expected := []InterfaceMapping{
+		{Interface: "bond0", Subnets: []string{"10.100.0.0/16"}},
+		{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}},
+		{Interface: "eth1", Subnets: []string{"203.0.113.0/28"}},
+	}
+	if !reflect.DeepEqual(cfg.Netplan.InterfaceMappings, expected) {
+		t.Errorf("Expected mappings %+v, got %+v", expected, cfg.Netplan.InterfaceMappings)
+	}
+
+	configPath = writeFile(t, dir, "missing.yaml", `netplan:
+  auto_discover: true
+  discovery_dir: "`+filepath.Join(dir, "missing")+`"
+`)
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected a missing discovery directory to fail")
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := writeFile(t, dir, "config.yaml", "haproxy: {}\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	if err := WatchFile(ctx, configPath, func() { changes <- struct{}{} }); err != nil {
+		t.Fatalf("WatchFile failed: %v", err)
+	}
+
+	// Unrelated files in the same directory must not trigger a reload
+	writeFile(t, dir, "other.yaml", "x")
+	select {
+	case <-changes:
+		t.Fatal("Unexpected change notification for unrelated file")
+	case <-time.After(2 * watchDebounce):
+	}
+
+	// Atomic replace, as done by most editors
+	tmp := writeFile(t, dir, ".config.yaml.tmp", "haproxy:\n  api_url: \"http://example\"\n")
+	if err := os.Rename(tmp, configPath); err != nil {
+		t.Fatalf("Failed to replace config file: %v", err)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected change notification after replacing the config file")
+	}
+}
+
+func TestParseAddressRange(t *testing.T) {
+	tests := []struct {
+		value       string
+		first, last string
+	}{
+		{"192.168.1.200-192.168.1.250", "192.168.1.200", "192.168.1.250"},
+		{"192.168.1.224/28", "192.168.1.225", "192.168.1.238"},
+		{"192.168.1.10/32", "192.168.1.10", "192.168.1.10"},
+		{"fd00::/64", "fd00::", "fd00::ffff:ffff:ffff:ffff"},
+	}
+	for _, tt := range tests {
+		first, last, err := ParseAddressRange(tt.value)
+		if err != nil {
+			t.Fatalf("ParseAddressRange(%q) failed: %v", tt.value, err)
+		}
+		if first.String() != tt.first || last.String() != tt.last {
+			t.Errorf("ParseAddressRange(%q) = %s-%s, expected %s-%s", tt.value, first, last, tt.first, tt.last)
+		}
+	}
+
+	for _, value := range []string{"192.168.1.250-192.168.1.200", "192.168.1.1-fd00::1", "192.168.1.0/33", "vip"} {
+		if _, _, err := ParseAddressRange(value); err == nil {
+			t.Errorf("Expected ParseAddressRange(%q) to fail", value)
+		}
+	}
+}
+
+func TestValidateClusterReplicas(t *testing.T) {
+	newConfig := func(replicas ...string) *Config {
+		return &Config{
+			HAProxy:   HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Instances: []HAProxyInstance{{Name: "lb2", HAProxySettings: HAProxySettings{APIURL: "http://lb2:5555", Username: "admin", Password: "admin"}}},
+			Cluster:   ClusterSettings{Replicas: replicas},
+		}
+	}
+
+	if err := newConfig("lb2").ValidateConfig(); err != nil {
+		t.Errorf("Expected a valid cluster, got %v", err)
+	}
+	for _, replicas := range [][]string{{"default"}, {"lb3"}, {"lb2", "lb2"}} {
+		if err := newConfig(replicas...).ValidateConfig(); err == nil {
+			t.Errorf("Expected replicas %v to be rejected", replicas)
+		}
+	}
+
+	cfg := newConfig("lb2")
+	cfg.GitOps = GitOpsSettings{Path: "/srv/manifests", Instance: "lb2"}
+	if err := cfg.ValidateConfig(); err == nil {
+		t.Error("Expected gitops on a replica to be rejected")
+	}
+}
+
+func TestValidateFallbackAPIURLs(t *testing.T) {
+	newConfig := func(fallbacks ...string) *Config {
+		return &Config{HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin", FallbackAPIURLs: fallbacks}}
+	}
+
+	if err := newConfig("http://lb2:5555", "http://lb3:5555").ValidateConfig(); err != nil {
+		t.Errorf("Expected valid fallback URLs, got %v", err)
+	}
+	for _, fallbacks := range [][]string{{""}, {"http://lb1:5555"}, {"http://lb2:5555", "http://lb2:5555"}} {
+		if err := newConfig(fallbacks...).ValidateConfig(); err == nil {
+			t.Errorf("Expected fallback URLs %v to be rejected", fallbacks)
+		}
+	}
+}
+
+func TestRetrySettingsFor(t *testing.T) {
+	retry := RetrySettings{MaxRetries: 2, InitialBackoffMs: 200, MaxBackoffMs: 2000, Writes: OperationRetrySettings{MaxRetries: 5, MaxBackoffMs: 500}}
+	if reads := retry.For(retry.Reads); reads != (OperationRetrySettings{MaxRetries: 2, InitialBackoffMs: 200, MaxBackoffMs: 2000}) {
+		t.Errorf("Expected reads to keep the common settings, got %+v", reads)
+	}
+	if writes := retry.For(retry.Writes); writes != (OperationRetrySettings{MaxRetries: 5, InitialBackoffMs: 200, MaxBackoffMs: 500}) {
+		t.Errorf("Expected writes to override the common settings, got %+v", writes)
+	}
+
+	cfg := &Config{HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin", Retry: retry}}
+	if err := cfg.ValidateConfig(); err != nil {
+		t.Errorf("Expected valid retry overrides, got %v", err)
+	}
+	cfg.HAProxy.Retry.Reads.MaxBackoffMs = 100 // Less than the common initial backoff
+	if err := cfg.ValidateConfig(); err == nil {
+		t.Error("Expected a read backoff below the initial backoff to be rejected")
+	}
+}
+
+func TestValidateNetplanFailurePolicy(t *testing.T) {
+	newConfig := func(policy string) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Netplan: NetplanSettings{
+				InterfaceMappings: []InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+				FailurePolicy:     policy,
+			},
+		}
+	}
+
+	for _, policy := range []string{"", "warn", "fail"} {
+		if err := newConfig(policy).ValidateConfig(); err != nil {
+			t.Errorf("Expected failure policy %q to be valid, got %v", policy, err)
+		}
+	}
+	if err := newConfig("ignore").ValidateConfig(); err == nil {
+		t.Error("Expected an unknown failure policy to be rejected")
+	}
+	if !newConfig("fail").Netplan.FailOnError() || newConfig("").Netplan.FailOnError() {
+		t.Error("Expected only the fail policy to return Netplan errors")
+	}
+}
+
+func TestValidateNetplanBackend(t *testing.T) {
+	newConfig := func(backend string) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Netplan: NetplanSettings{
+				InterfaceMappings: []InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+				Backend:           backend,
+			},
+		}
+	}
+
+	cfg := newConfig("networkmanager")
+	if err := cfg.ValidateConfig(); err != nil {
+		t.Fatalf("Expected the networkmanager backend to be valid, got %v", err)
+	}
+	if !cfg.Netplan.UsesNetworkManager() || strings.HasPrefix(cfg.Netplan.ConfigPath, "/etc/netplan/") {
+		t.Errorf("Expected the VIPs of NetworkManager to be recorded outside /etc/netplan, got %q", cfg.Netplan.ConfigPath)
+	}
+	if err := newConfig("ifupdown").ValidateConfig(); err == nil {
+		t.Error("Expected an unknown backend to be rejected")
+	}
+
+	cfg = newConfig("networkmanager")
+	cfg.Netplan.InterfaceMappings[0].Routes = []RouteTemplate{{Subnet: "192.168.1.0/24", To: "default", Via: "192.168.1.1"}}
+	if err := cfg.ValidateConfig(); err == nil {
+		t.Error("Expected route templates to be rejected with the networkmanager backend")
+	}
+}
+
+func TestValidateNetplanRoutes(t *testing.T) {
+	newConfig := func(route RouteTemplate) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Netplan: NetplanSettings{
+				InterfaceMappings: []InterfaceMapping{{
+					Interface: "eth0",
+					Subnets:   []string{"192.168.1.0/24", "10.0.0.0/8"},
+					Routes:    []RouteTemplate{route},
+				}},
+			},
+		}
+	}
+
+	valid := []RouteTemplate{
+		{Subnet: "192.168.1.0/24", To: "default", Via: "192.168.1.1", Table: 100},
+		{Subnet: "10.0.0.0/8", To: "172.16.0.0/12", Via: "10.255.0.1", OnLink: true, Metric: 50},
+		{Subnet: "10.0.0.0/8", To: "10.0.0.0/8", Scope: "link"},
+	}
+	for _, route := range valid {
+		if err := newConfig(route).ValidateConfig(); err != nil {
+			t.Errorf("Expected route %+v to be valid, got %v", route, err)
+		}
+	}
+
+	invalid := []RouteTemplate{
+		{Subnet: "172.16.0.0/12", To: "default", Via: "172.16.0.1"}, // Not a subnet of the mapping
+		{Subnet: "192.168.1.0/24", To: "somewhere", Via: "192.168.1.1"},
+		{Subnet: "192.168.1.0/24", To: "default", Via: "gateway"},
+		{Subnet: "192.168.1.0/24", To: "default", OnLink: true},
+		{Subnet: "192.168.1.0/24", To: "default", Via: "192.168.1.1", Scope: "site"},
+	}
+	for _, route := range invalid {
+		if err := newConfig(route).ValidateConfig(); err == nil {
+			t.Errorf("Expected route %+v to be rejected", route)
+		}
+	}
+}
+
+func TestValidateNetplanRoutingPolicy(t *testing.T) {
+	newConfig := func(policy RoutingPolicyTemplate) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Netplan: NetplanSettings{
+				InterfaceMappings: []InterfaceMapping{{
+					Interface:     "eth1",
+					Subnets:       []string{"203.0.113.0/28"},
+					RoutingPolicy: []RoutingPolicyTemplate{policy},
+				}},
+			},
+		}
+	}
+
+	if err := newConfig(RoutingPolicyTemplate{Subnet: "203.0.113.0/28", Table: 100, Priority: 1000}).ValidateConfig(); err != nil {
+		t.Errorf("Expected the routing policy to be valid, got %v", err)
+	}
+	invalid := []RoutingPolicyTemplate{
+		{Subnet: "198.51.100.0/24", Table: 100},
+		{Subnet: "203.0.113.0/28"},
+		{Subnet: "203.0.113.0/28", Table: 100, Priority: -1},
+	}
+	for _, policy := range invalid {
+		if err := newConfig(policy).ValidateConfig(); err == nil {
+			t.Errorf("Expected routing policy %+v to be rejected", policy)
+		}
+	}
+}
+
+func TestValidateBGPMethod(t *testing.T) {
+	newConfig := func(method, nextHop string) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			BGP:     BGPSettings{ASN: 65001, Prefixes: []string{"192.168.100.0/24"}, Method: method, StaticNextHop: nextHop, HealthGated: true},
+		}
+	}
+
+	for _, method := range []string{"", "network", "static"} {
+		if err := newConfig(method, "").ValidateConfig(); err != nil {
+			t.Errorf("Expected method %q to be valid, got %v", method, err)
+		}
+	}
+	if err := newConfig("static", "lo").ValidateConfig(); err != nil {
+		t.Errorf("Expected a static next hop to be valid, got %v", err)
+	}
+	if err := newConfig("ospf", "").ValidateConfig(); err == nil {
+		t.Error("Expected an unknown method to be rejected")
+	}
+	if err := newConfig("network", "Null0").ValidateConfig(); err == nil {
+		t.Error("Expected a static next hop without the static method to be rejected")
+	}
+}
+
+func TestValidateLogging(t *testing.T) {
+	newConfig := func(logging LoggingSettings) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Logging: logging,
+		}
+	}
+
+	for _, logging := range []LoggingSettings{
+		{},
+		{Level: "debug", Format: "console"},
+		{Level: "error", Format: "json", OutputPaths: []string{"stdout", "/var/log/haproxy-configurator.log"}},
+		{Rotation: RotationSettings{Enabled: true, MaxSizeMB: 100, MaxAgeDays: 7, MaxBackups: 3, Compress: true}},
+	} {
+		if err := newConfig(logging).ValidateConfig(); err != nil {
+			t.Errorf("Expected logging settings %+v to be valid, got %v", logging, err)
+		}
+	}
+	for _, logging := range []LoggingSettings{
+		{Level: "verbose"},
+		{Level: "INFO"},
+		{Format: "text"},
+		{Rotation: RotationSettings{Enabled: true, MaxSizeMB: -1}},
+		{Rotation: RotationSettings{MaxAgeDays: -1}},
+		{Rotation: RotationSettings{MaxBackups: -1}},
+	} {
+		if err := newConfig(logging).ValidateConfig(); err == nil {
+			t.Errorf("Expected logging settings %+v to be rejected", logging)
+		}
+	}
+}
+
+func TestValidateAudit(t *testing.T) {
+	newConfig := func(audit AuditSettings) *Config {
+		return &Config{
+			HAProxy: HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Audit:   audit,
+		}
+	}
+
+	for _, audit := range []AuditSettings{
+		{},
+		{Target: "journald"},
+		{Target: "syslog"},
+		{Target: "syslog", Network: "tcp", Address: "siem.example.com:601", Facility: "auth"},
+	} {
+		if err := newConfig(audit).ValidateConfig(); err != nil {
+			t.Errorf("Expected audit settings %+v to be valid, got %v", audit, err)
+		}
+	}
+	for _, audit := range []AuditSettings{
+		{Target: "splunk"},
+		{Target: "syslog", Network: "udp"},
+		{Target: "syslog", Network: "http", Address: "siem.example.com:80"},
+		{Target: "syslog", Facility: "local9"},
+	} {
+		if err := newConfig(audit).ValidateConfig(); err == nil {
+			t.Errorf("Expected audit settings %+v to be rejected", audit)
+		}
+	}
+}
+
+func TestValidateDataplaneRecording(t *testing.T) {
+	dir := t.TempDir()
+	newConfig := func(recording DataplaneRecordingSettings) *Config {
+		return &Config{
+			HAProxy:            HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			DataplaneRecording: recording,
+		}
+	}
+
+	for _, recording := range []DataplaneRecordingSettings{
+		{},
+		{Mode: "record", Dir: filepath.Join(dir, "new")},
+		{Mode: "replay", Dir: dir},
+	} {
+		if err := newConfig(recording).ValidateConfig(); err != nil {
+			t.Errorf("Expected recording settings %+v to be valid, got %v", recording, err)
+		}
+	}
+	for _, recording := range []DataplaneRecordingSettings{
+		{Mode: "capture", Dir: dir},
+		{Mode: "record"},
+		{Mode: "replay", Dir: filepath.Join(dir, "missing")},
+	} {
+		if err := newConfig(recording).ValidateConfig(); err == nil {
+			t.Errorf("Expected recording settings %+v to be rejected", recording)
+		}
+	}
+}
+
+func TestValidateWebhooks(t *testing.T) {
+	newConfig := func(webhook WebhookSettings) *Config {
+		return &Config{
+			HAProxy:  HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Webhooks: []WebhookSettings{webhook},
+		}
+	}
+
+	for _, webhook := range []WebhookSettings{
+		{URL: "https://hooks.example.com/haproxy", Events: []string{"rollback", "drift_detected"}},
+		{Type: "http", URL: "https://hooks.example.com/haproxy", Headers: map[string]string{"Authorization": "Bearer token"}},
+		{URL: "https://hooks.example.com/haproxy", Secret: "change-me", MaxRetries: 3},
+		{Type: "slack", URL: "https://hooks.slack.com/services/T000/B000/XXXX", Channel: "#lb"},
+	} {
+		if err := newConfig(webhook).ValidateConfig(); err != nil {
+			t.Errorf("Expected webhook %+v to be valid, got %v", webhook, err)
+		}
+	}
+	for _, webhook := range []WebhookSettings{
+		{Type: "slack"},
+		{Type: "teams", URL: "https://example.webhook.office.com"},
+		{Type: "slack", URL: "https://hooks.slack.com/services/T000/B000/XXXX", Headers: map[string]string{"X-Test": "1"}},
+		{URL: "https://hooks.example.com/haproxy", Events: []string{"committed"}},
+		{Type: "slack", URL: "https://hooks.slack.com/services/T000/B000/XXXX", Secret: "change-me"},
+		{URL: "https://hooks.example.com/haproxy", MaxRetries: -1},
+	} {
+		if err := newConfig(webhook).ValidateConfig(); err == nil {
+			t.Errorf("Expected webhook %+v to be rejected", webhook)
+		}
+	}
+}
+
+func TestValidateDiscovery(t *testing.T) {
+	newConfig := func(services ...ConsulService) *Config {
+		cfg := &Config{
+			HAProxy:   HAProxySettings{APIURL: "http://lb1:5555", Username: "admin", Password: "admin"},
+			Discovery: DiscoverySettings{Consul: ConsulSettings{Services: services}},
+		}
+		cfg.Discovery.setDefaults()
+		return cfg
+	}
+
+	if err := newConfig(ConsulService{Service: "web", Tags: []string{"v2"}, Backend: "web"}, ConsulService{Service: "api", Backend: "api"}).ValidateConfig(); err != nil {
+		t.Errorf("Expected valid discovery settings, got %v", err)
+	}
+	for _, services := range [][]ConsulService{{{Backend: "web"}}, {{Service: "web"}}, {{Service: "web", Backend: "app"}, {Service: "api", Backend: "app"}}} {
+		if err := newConfig(services...).ValidateConfig(); err == nil {
+			t.Errorf("Expected services %v to be rejected", services)
+		}
+	}
+
+	cfg := newConfig(ConsulService{Service: "web", Backend: "web"})
+	cfg.GitOps = GitOpsSettings{Path: "/srv/manifests", Instance: DefaultInstance}
+	if err := cfg.ValidateConfig(); err == nil {
+		t.Error("Expected discovery on an instance reconciled by gitops to be rejected")
+	}
+
+	withKubernetes := func(backends ...KubernetesBackend) *Config {
+		cfg := newConfig(ConsulService{Service: "web", Backend: "web"})
+		cfg.Discovery.Kubernetes.Backends = backends
+		cfg.Discovery.setDefaults()
+		return cfg
+	}
+	if err := withKubernetes(KubernetesBackend{Backend: "api", Service: "shop/api", Port: "http"}).ValidateConfig(); err != nil {
+		t.Errorf("Expected valid kubernetes backends, got %v", err)
+	}
+	for _, backend := range []KubernetesBackend{
+		{Backend: "api", Service: "api"},
+		{Backend: "api", Service: "shop/api", AddressType: "ipv4"},
+		{Backend: "web", Service: "shop/web"},
+	} {
+		if err := withKubernetes(backend).ValidateConfig(); err == nil {
+			t.Errorf("Expected kubernetes backend %+v to be rejected", backend)
+		}
+	}
+
+	cfg = newConfig(ConsulService{Service: "web", Backend: "web"})
+	cfg.Discovery.Files = []DiscoveryFile{{Backend: "static", Path: "/etc/servers/static.yaml"}}
+	if err := cfg.ValidateConfig(); err != nil {
+		t.Errorf("Expected a valid discovery file, got %v", err)
+	}
+	for _, file := range []DiscoveryFile{{Backend: "static"}, {Path: "/etc/servers/static.yaml"}, {Backend: "web", Path: "/etc/servers/web.yaml"}} {
+		cfg.Discovery.Files = []DiscoveryFile{file}
+		if err := cfg.ValidateConfig(); err == nil {
+			t.Errorf("Expected discovery file %+v to be rejected", file)
+		}
+	}
+}
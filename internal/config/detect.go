@@ -0,0 +1,223 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectInterfaceMappings derives interface mappings from the subnets currently
+// configured on the host's interfaces. Loopback, down and address-less
+// interfaces are skipped, as are link-local subnets.
+func DetectInterfaceMappings() ([]InterfaceMapping, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	var mappings []InterfaceMapping
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses of %s: %w", iface.Name, err)
+		}
+
+		subnets := subnetsOf(addrs)
+		if len(subnets) == 0 {
+			continue
+		}
+
+		mappings = append(mappings, InterfaceMapping{
+			Interface: netplanInterfaceName(iface),
+			Subnets:   subnets,
+		})
+	}
+
+	return mappings, nil
+}
+
+// subnetsOf returns the unique, non link-local networks of the given interface addresses
+func subnetsOf(addrs []net.Addr) []string {
+	seen := make(map[string]bool)
+	var subnets []string
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+
+		network := &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask}
+		cidr := network.String()
+		if !seen[cidr] {
+			seen[cidr] = true
+			subnets = append(subnets, cidr)
+		}
+	}
+
+	return subnets
+}
+
+// netplanInterfaceName converts Linux VLAN device names of the form "<parent>.<id>"
+// into the "vlan<id>@<parent>" notation used by interface mappings
+func netplanInterfaceName(iface net.Interface) string {
+	parent, id, ok := strings.Cut(iface.Name, ".")
+	if !ok || parent == "" || id == "" {
+		return iface.Name
+	}
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return iface.Name
+		}
+	}
+	return fmt.Sprintf("vlan%s@%s", id, parent)
+}
+
+// defaultNetplanConfigPath is the Netplan file the configurator writes the VIPs to unless configured otherwise
+const defaultNetplanConfigPath = "/etc/netplan/99-haproxy-configurator.yaml"
+
+// netplanDocument is the part of a Netplan file that interface mappings are derived from
+type netplanDocument struct {
+	Network struct {
+		Ethernets map[string]struct {
+			Addresses []interface{} `yaml:"addresses"`
+		} `yaml:"ethernets"`
+		Vlans map[string]struct {
+			Link      string        `yaml:"link"`
+			Addresses []interface{} `yaml:"addresses"`
+		} `yaml:"vlans"`
+	} `yaml:"network"`
+}
+
+// discoverInterfaceMappings adds the mappings found in the Netplan files of DiscoveryDir after the manual ones,
+// without the subnets that overlap a subnet of a manual mapping
+func (n *NetplanSettings) discoverInterfaceMappings() error {
+	dir := n.DiscoveryDir
+	if dir == "" {
+		dir = "/etc/netplan"
+	}
+	exclude := n.ConfigPath
+	if exclude == "" {
+		exclude = defaultNetplanConfigPath
+	}
+
+	discovered, err := ReadNetplanInterfaceMappings(dir, exclude)
+	if err != nil {
+		return fmt.Errorf("failed to discover netplan interface mappings: %w", err)
+	}
+	n.InterfaceMappings = mergeInterfaceMappings(n.InterfaceMappings, discovered)
+	return nil
+}
+
+// ReadNetplanInterfaceMappings derives interface mappings from the static addresses of the ethernets and VLANs in
+// the Netplan files of dir, leaving out the file at exclude, i.e. the one the configurator writes the VIPs to.
+// The files are read in the order Netplan merges them. Host addresses (/32 and /128) and link-local subnets are
+// skipped.
+func ReadNetplanInterfaceMappings(dir, exclude string) ([]InterfaceMapping, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	subnets := make(map[string][]string) // Interface mapping name -> subnets
+	for _, file := range files {
+		if filepath.Clean(file) == filepath.Clean(exclude) {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var document netplanDocument
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		for name, ethernet := range document.Network.Ethernets {
+			subnets[name] = appendSubnets(subnets[name], ethernet.Addresses)
+		}
+		for name, vlan := range document.Network.Vlans {
+			if vlan.Link == "" {
+				continue
+			}
+			subnets[name+"@"+vlan.Link] = appendSubnets(subnets[name+"@"+vlan.Link], vlan.Addresses)
+		}
+	}
+
+	var mappings []InterfaceMapping
+	for name, list := range subnets {
+		if len(list) > 0 {
+			mappings = append(mappings, InterfaceMapping{Interface: name, Subnets: list})
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].Interface < mappings[j].Interface })
+	return mappings, nil
+}
+
+// appendSubnets appends the networks of Netplan address entries that subnets does not have yet. Entries are
+// strings such as "192.168.1.10/24" or maps from such a string to the options of the address.
+func appendSubnets(subnets []string, addresses []interface{}) []string {
+	for _, entry := range addresses {
+		var value string
+		switch address := entry.(type) {
+		case string:
+			value = address
+		case map[string]interface{}:
+			for key := range address {
+				value = key
+			}
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil || prefix.IsSingleIP() || prefix.Addr().IsLinkLocalUnicast() {
+			continue
+		}
+		subnet := prefix.Masked().String()
+		if !slices.Contains(subnets, subnet) {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// mergeInterfaceMappings returns the manual mappings followed by the discovered ones, without the discovered
+// subnets that overlap a subnet of a manual mapping, so that the manual mappings take precedence
+func mergeInterfaceMappings(manual, discovered []InterfaceMapping) []InterfaceMapping {
+	var taken []netip.Prefix
+	for _, mapping := range manual {
+		for _, subnet := range mapping.Subnets {
+			if prefix, err := netip.ParsePrefix(subnet); err == nil {
+				taken = append(taken, prefix)
+			}
+		}
+	}
+
+	result := append([]InterfaceMapping(nil), manual...)
+	for _, mapping := range discovered {
+		var subnets []string
+		for _, subnet := range mapping.Subnets {
+			prefix, err := netip.ParsePrefix(subnet)
+			if err != nil || slices.ContainsFunc(taken, prefix.Overlaps) {
+				continue
+			}
+			subnets = append(subnets, subnet)
+		}
+		if len(subnets) > 0 {
+			result = append(result, InterfaceMapping{Interface: mapping.Interface, Subnets: subnets})
+		}
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references in s with environment variable values.
+// "$${" produces a literal "${". A bare "$" not followed by "{" is left untouched so that values
+// such as passwords may contain dollar signs. Referencing an unset variable without a default is an error.
+func expandEnv(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var result strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			result.WriteByte(s[i])
+			continue
+		}
+
+		// Escaped reference: $${ -> ${
+		if strings.HasPrefix(s[i:], "$${") {
+			result.WriteString("${")
+			i += 2
+			continue
+		}
+
+		if !strings.HasPrefix(s[i:], "${") {
+			result.WriteByte(s[i])
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference in %q", s)
+		}
+
+		expr := s[i+2 : i+end]
+		name, defaultValue, hasDefault := strings.Cut(expr, ":-")
+		if name == "" {
+			return "", fmt.Errorf("empty variable reference in %q", s)
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok || (value == "" && hasDefault) {
+			if !hasDefault {
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			value = defaultValue
+		}
+
+		result.WriteString(value)
+		i += end
+	}
+
+	return result.String(), nil
+}
+
+// expandEnvInNode expands environment variable references in every scalar value of a YAML document.
+// Mapping keys are left as-is.
+func expandEnvInNode(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := expandEnvInNode(child); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		// Content alternates key, value
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := expandEnvInNode(node.Content[i]); err != nil {
+				return fmt.Errorf("%s: %w", node.Content[i-1].Value, err)
+			}
+		}
+	case yaml.ScalarNode:
+		expanded, err := expandEnv(node.Value)
+		if err != nil {
+			return err
+		}
+		if expanded != node.Value {
+			node.Value = expanded
+			// Let the expanded value be re-resolved (e.g. "${PORT}" -> int)
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("HC_TEST_HOST", "dataplane.local")
+	t.Setenv("HC_TEST_EMPTY", "")
+
+	testCases := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{"plain value", "plain value", false},
+		{"http://${HC_TEST_HOST}:5555", "http://dataplane.local:5555", false},
+		{"${HC_TEST_UNSET:-fallback}", "fallback", false},
+		{"${HC_TEST_EMPTY:-fallback}", "fallback", false},
+		{"${HC_TEST_EMPTY}", "", false},
+		{"pa$$word", "pa$$word", false},
+		{"$${HC_TEST_HOST}", "${HC_TEST_HOST}", false},
+		{"${HC_TEST_UNSET}", "", true},
+		{"${HC_TEST_HOST", "", true},
+		{"${}", "", true},
+	}
+
+	for _, tc := range testCases {
+		result, err := expandEnv(tc.input)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("Expected error for %q, got %q", tc.input, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("Expected %q for %q, got %q", tc.expected, tc.input, result)
+		}
+	}
+}
+
+func TestLoadConfigExpandsEnvironment(t *testing.T) {
+	t.Setenv("HC_TEST_PASSWORD", "s3cr$t")
+	t.Setenv("HC_TEST_RETENTION", "30")
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `haproxy:
+  api_url: "${HC_TEST_URL:-http://127.0.0.1:5555}"
+  username: admin
+  password: ${HC_TEST_PASSWORD}
+journal:
+  path: /var/lib/${HC_TEST_DIR:-haproxy-configurator}/journal.db
+  retention_days: ${HC_TEST_RETENTION}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.HAProxy.APIURL != "http://127.0.0.1:5555" {
+		t.Errorf("Unexpected API URL: %s", cfg.HAProxy.APIURL)
+	}
+	if cfg.HAProxy.Password != "s3cr$t" {
+		t.Errorf("Unexpected password: %s", cfg.HAProxy.Password)
+	}
+	if cfg.Journal.Path != "/var/lib/haproxy-configurator/journal.db" {
+		t.Errorf("Unexpected journal path: %s", cfg.Journal.Path)
+	}
+	if cfg.Journal.RetentionDays != 30 {
+		t.Errorf("Expected numeric value to be expanded, got %d", cfg.Journal.RetentionDays)
+	}
+}
+
+func TestLoadConfigUnsetVariable(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "haproxy:\n  password: ${HC_TEST_DEFINITELY_UNSET}\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected error for unset environment variable")
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// DataplaneTLSSettings configures HTTPS connections to the Data Plane API
+type DataplaneTLSSettings struct {
+	CACert             string `yaml:"ca_cert,omitempty"`     // PEM bundle used instead of the system roots
+	ClientCert         string `yaml:"client_cert,omitempty"` // PEM certificate for mutual TLS
+	ClientKey          string `yaml:"client_key,omitempty"`  // PEM private key for mutual TLS
+	ServerName         string `yaml:"server_name,omitempty"` // Overrides the name verified in the server certificate
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// ClientTLSConfig builds the TLS configuration for Data Plane API connections.
+// It returns nil when no TLS option is set, so the default configuration is used.
+func (t *DataplaneTLSSettings) ClientTLSConfig() (*tls.Config, error) {
+	if *t == (DataplaneTLSSettings{}) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if t.CACert != "" {
+		pem, err := os.ReadFile(t.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA certificate %s", t.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	if t.ClientCert != "" {
+		certificate, err := tls.LoadX509KeyPair(t.ClientCert, t.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
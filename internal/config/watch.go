@@ -0,0 +1,77 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events editors and ConfigMap updates produce for a single change
+const watchDebounce = 500 * time.Millisecond
+
+// WatchFile calls onChange whenever the file at path is written, created or replaced,
+// until ctx is cancelled. The parent directory is watched so that atomic renames and
+// Kubernetes ConfigMap symlink swaps are detected as well.
+func WatchFile(ctx context.Context, path string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	dir := filepath.Dir(absPath)
+
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		resolved, _ := filepath.EvalSymlinks(absPath)
+
+		var debounce *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+
+				// React to the file itself, or to its symlink target changing
+				current, _ := filepath.EvalSymlinks(absPath)
+				if filepath.Clean(event.Name) != absPath && current == resolved {
+					continue
+				}
+				resolved = current
+
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(watchDebounce, onChange)
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,440 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// configurationPath is the prefix of all configuration endpoints of the Data Plane API v3
+const configurationPath = "/v3/services/haproxy/configuration"
+
+// api performs Data Plane API v3 requests. It mirrors the v3 client of haproxy-go and
+// returns its types and errors, but sends requests through a configurable http.Client
+// so that TLS and connection settings can be controlled.
+type api struct {
+	baseURL    string
+	credential string
+	httpClient *http.Client
+	timeout    time.Duration // per attempt; zero means no timeout beyond the caller's context
+	retry      RetryPolicies
+	dryRun     bool
+}
+
+// withoutRetries returns a copy of the API client that sends every request only once
+func (a api) withoutRetries() api {
+	a.retry = RetryPolicies{}
+	return a
+}
+
+// request sends a request and returns the response body, mapping error statuses to v3 errors.
+// Idempotent requests failing with a transport error or a temporarily unavailable upstream
+// are retried according to the retry policy of their method for as long as ctx allows.
+func (a api) request(ctx context.Context, method, path string, transactionID string, body interface{}) ([]byte, error) {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, &v3.InternalError{Message: err.Error()}
+		}
+		payload = data
+	}
+
+	if a.dryRun {
+		if data, ok := simulate(method, path, transactionID, payload); ok {
+			return data, nil
+		}
+		// Simulated transactions do not exist upstream; reading them shows the live configuration
+		if strings.HasPrefix(transactionID, dryRunTransactionPrefix) {
+			transactionID = ""
+		}
+	}
+
+	requestURL := strings.TrimRight(a.baseURL, "/") + path
+	if transactionID != "" {
+		requestURL += "?transaction_id=" + url.QueryEscape(transactionID)
+	}
+	return a.send(ctx, method, requestURL, "application/json", payload)
+}
+
+// send sends a payload of the given content type, retrying it like request
+func (a api) send(ctx context.Context, method, requestURL, contentType string, payload []byte) ([]byte, error) {
+	policy := a.retry.forMethod(method)
+	for retry := 0; ; retry++ {
+		data, err := a.attempt(ctx, method, requestURL, contentType, payload)
+		if err == nil || retry >= policy.MaxRetries || !isUnreachable(err) || ctx.Err() != nil {
+			return data, err
+		}
+		if err := sleep(ctx, policy.backoff(retry)); err != nil {
+			return nil, &transportError{err: err}
+		}
+	}
+}
+
+// attempt sends a single request bounded by the per-attempt timeout
+func (a api) attempt(ctx context.Context, method, requestURL, contentType string, payload []byte) ([]byte, error) {
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	res, err := a.open(ctx, method, requestURL, contentType, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, readError(ctx, err)
+	}
+	return data, nil
+}
+
+// stream sends a GET request and passes the body of the response to fn while it is read, so that a large
+// response is never held as a whole. The request is retried like request until a response arrives; the
+// per-attempt timeout bounds the wait for the response but not the reading of the body, whose pace fn sets.
+func (a api) stream(ctx context.Context, path string, transactionID string, fn func(body io.Reader) error) error {
+	// Simulated transactions do not exist upstream; reading them shows the live configuration
+	if a.dryRun && strings.HasPrefix(transactionID, dryRunTransactionPrefix) {
+		transactionID = ""
+	}
+	requestURL := strings.TrimRight(a.baseURL, "/") + path
+	if transactionID != "" {
+		requestURL += "?transaction_id=" + url.QueryEscape(transactionID)
+	}
+
+	policy := a.retry.forMethod(http.MethodGet)
+	for retry := 0; ; retry++ {
+		res, err := a.openStream(ctx, requestURL)
+		if err == nil {
+			defer res.Body.Close()
+			return fn(res.Body)
+		}
+		if retry >= policy.MaxRetries || !isUnreachable(err) || ctx.Err() != nil {
+			return err
+		}
+		if err := sleep(ctx, policy.backoff(retry)); err != nil {
+			return &transportError{err: err}
+		}
+	}
+}
+
+// openStream sends a single GET request for stream, cancelling it if no response arrives within the per-attempt
+// timeout
+func (a api) openStream(ctx context.Context, requestURL string) (*http.Response, error) {
+	if a.timeout <= 0 {
+		return a.open(ctx, http.MethodGet, requestURL, "application/json", nil)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(a.timeout, cancel)
+	res, err := a.open(ctx, http.MethodGet, requestURL, "application/json", nil)
+	if !timer.Stop() {
+		if err == nil {
+			res.Body.Close()
+		}
+		return nil, &transportError{err: context.DeadlineExceeded}
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnClose is a response body that releases the context of its request once it is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the context of the request
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// open sends a single request and returns the response if it succeeded, mapping error statuses to v3 errors.
+// The caller closes the body of the response.
+func (a api) open(ctx context.Context, method, requestURL, contentType string, payload []byte) (*http.Response, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return nil, &v3.InternalError{Message: err.Error()}
+	}
+	req.Header.Set("Authorization", "Basic "+a.credential)
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	if res.StatusCode/100 == 2 {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, readError(ctx, err)
+	}
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, &v3.UnauthorizedError{Message: string(data)}
+	case http.StatusBadRequest:
+		return nil, &v3.BadRequestError{Message: string(data)}
+	case http.StatusNotFound:
+		return nil, &v3.NotFoundError{Message: string(data)}
+	case http.StatusConflict:
+		return nil, &v3.ConflictError{Message: string(data)}
+	}
+	return nil, &v3.UnknownError{Message: string(data), StatusCode: res.StatusCode}
+}
+
+// readError maps a failure to read a response body: a transport error if ctx ended, an invalid response otherwise
+func readError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return &transportError{err: err}
+	}
+	return &v3.InvalidResponseError{Message: err.Error()}
+}
+
+// requestObject sends a request and decodes a single object response; an empty body yields nil
+func requestObject[T any](ctx context.Context, a api, method, path, transactionID string, body interface{}) (*T, error) {
+	data, err := a.request(ctx, method, path, transactionID, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &result, nil
+}
+
+// requestList sends a request and decodes a list response; an empty body yields nil
+func requestList[T any](ctx context.Context, a api, path, transactionID string) ([]T, error) {
+	data, err := a.request(ctx, http.MethodGet, path, transactionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var result []T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return result, nil
+}
+
+// resourcePath joins escaped path segments below the configuration prefix
+func resourcePath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return configurationPath + "/" + strings.Join(escaped, "/")
+}
+
+// Transaction operations
+
+// GetVersion returns the current configuration version
+func (a api) GetVersion(ctx context.Context) (*int, error) {
+	data, err := a.request(ctx, http.MethodGet, configurationPath+"/version", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &version, nil
+}
+
+// Info describes the Data Plane API build
+type Info struct {
+	API struct {
+		Version   string `json:"version,omitempty"`
+		BuildDate string `json:"build_date,omitempty"`
+	} `json:"api"`
+}
+
+// GetInfo returns the version of the Data Plane API
+func (a api) GetInfo(ctx context.Context) (*Info, error) {
+	return requestObject[Info](ctx, a, http.MethodGet, "/v3/info", "", nil)
+}
+
+// CreateTransaction starts a transaction based on the given configuration version
+func (a api) CreateTransaction(ctx context.Context, version int) (*v3.Transaction, error) {
+	path := fmt.Sprintf("/v3/services/haproxy/transactions?version=%d", version)
+	return requestObject[v3.Transaction](ctx, a, http.MethodPost, path, "", nil)
+}
+
+// GetTransaction retrieves a transaction by ID
+func (a api) GetTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	return requestObject[v3.Transaction](ctx, a, http.MethodGet, "/v3/services/haproxy/transactions/"+url.PathEscape(id), "", nil)
+}
+
+// ListTransactions lists the transactions that have not been committed or closed
+func (a api) ListTransactions(ctx context.Context) ([]v3.Transaction, error) {
+	return requestList[v3.Transaction](ctx, a, "/v3/services/haproxy/transactions?status=in_progress", "")
+}
+
+// CommitTransaction commits a transaction. It is never retried, as a commit whose
+// response was lost may already have been applied.
+func (a api) CommitTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	return requestObject[v3.Transaction](ctx, a.withoutRetries(), http.MethodPut, "/v3/services/haproxy/transactions/"+url.PathEscape(id), "", nil)
+}
+
+// CloseTransaction deletes a transaction without committing it, returning the response text
+func (a api) CloseTransaction(ctx context.Context, id string) (*string, error) {
+	data, err := a.request(ctx, http.MethodDelete, "/v3/services/haproxy/transactions/"+url.PathEscape(id), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	message := string(data)
+	return &message, nil
+}
+
+// Backend operations
+
+// AddBackend creates a backend
+func (a api) AddBackend(ctx context.Context, backend Backend, transactionID string) (*Backend, error) {
+	return requestObject[Backend](ctx, a, http.MethodPost, resourcePath("backends"), transactionID, backend)
+}
+
+// GetBackend retrieves a backend by name
+func (a api) GetBackend(ctx context.Context, name string, transactionID string) (*Backend, error) {
+	return requestObject[Backend](ctx, a, http.MethodGet, resourcePath("backends", name), transactionID, nil)
+}
+
+// ListBackends lists all backends
+func (a api) ListBackends(ctx context.Context, transactionID string) ([]Backend, error) {
+	return requestList[Backend](ctx, a, resourcePath("backends"), transactionID)
+}
+
+// ReplaceBackend replaces an existing backend
+func (a api) ReplaceBackend(ctx context.Context, name string, backend Backend, transactionID string) (*Backend, error) {
+	return requestObject[Backend](ctx, a, http.MethodPut, resourcePath("backends", name), transactionID, backend)
+}
+
+// DeleteBackend deletes a backend
+func (a api) DeleteBackend(ctx context.Context, name string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("backends", name), transactionID, nil)
+	return err
+}
+
+// Frontend operations
+
+// AddFrontend creates a frontend
+func (a api) AddFrontend(ctx context.Context, frontend Frontend, transactionID string) (*Frontend, error) {
+	return requestObject[Frontend](ctx, a, http.MethodPost, resourcePath("frontends"), transactionID, frontend)
+}
+
+// GetFrontend retrieves a frontend by name
+func (a api) GetFrontend(ctx context.Context, name string, transactionID string) (*Frontend, error) {
+	return requestObject[Frontend](ctx, a, http.MethodGet, resourcePath("frontends", name), transactionID, nil)
+}
+
+// ListFrontends lists all frontends
+func (a api) ListFrontends(ctx context.Context, transactionID string) ([]Frontend, error) {
+	return requestList[Frontend](ctx, a, resourcePath("frontends"), transactionID)
+}
+
+// ReplaceFrontend replaces an existing frontend
+func (a api) ReplaceFrontend(ctx context.Context, name string, frontend Frontend, transactionID string) (*Frontend, error) {
+	return requestObject[Frontend](ctx, a, http.MethodPut, resourcePath("frontends", name), transactionID, frontend)
+}
+
+// DeleteFrontend deletes a frontend
+func (a api) DeleteFrontend(ctx context.Context, name string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("frontends", name), transactionID, nil)
+	return err
+}
+
+// Bind operations
+
+// AddBind creates a bind on a frontend
+func (a api) AddBind(ctx context.Context, frontend string, transactionID string, bind Bind) (*Bind, error) {
+	return requestObject[Bind](ctx, a, http.MethodPost, resourcePath("frontends", frontend, "binds"), transactionID, bind)
+}
+
+// GetBind retrieves a bind of a frontend by name
+func (a api) GetBind(ctx context.Context, name string, frontend string, transactionID string) (*Bind, error) {
+	return requestObject[Bind](ctx, a, http.MethodGet, resourcePath("frontends", frontend, "binds", name), transactionID, nil)
+}
+
+// ListBinds lists all binds of a frontend
+func (a api) ListBinds(ctx context.Context, frontend string, transactionID string) ([]Bind, error) {
+	return requestList[Bind](ctx, a, resourcePath("frontends", frontend, "binds"), transactionID)
+}
+
+// ReplaceBind replaces an existing bind of a frontend
+func (a api) ReplaceBind(ctx context.Context, frontend string, transactionID string, bind Bind) (*Bind, error) {
+	return requestObject[Bind](ctx, a, http.MethodPut, resourcePath("frontends", frontend, "binds", derefName(bind.Name)), transactionID, bind)
+}
+
+// DeleteBind deletes a bind from a frontend
+func (a api) DeleteBind(ctx context.Context, name string, frontend string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("frontends", frontend, "binds", name), transactionID, nil)
+	return err
+}
+
+// Server operations
+
+// AddServer creates a server in a backend
+func (a api) AddServer(ctx context.Context, backend string, transactionID string, server Server) (*Server, error) {
+	return requestObject[Server](ctx, a, http.MethodPost, resourcePath("backends", backend, "servers"), transactionID, server)
+}
+
+// GetServer retrieves a server of a backend by name
+func (a api) GetServer(ctx context.Context, name string, backend string, transactionID string) (*Server, error) {
+	return requestObject[Server](ctx, a, http.MethodGet, resourcePath("backends", backend, "servers", name), transactionID, nil)
+}
+
+// ListServers lists all servers of a backend
+func (a api) ListServers(ctx context.Context, backend string, transactionID string) ([]Server, error) {
+	return requestList[Server](ctx, a, resourcePath("backends", backend, "servers"), transactionID)
+}
+
+// ReplaceServer replaces an existing server of a backend
+func (a api) ReplaceServer(ctx context.Context, backend string, transactionID string, server Server) (*Server, error) {
+	return requestObject[Server](ctx, a, http.MethodPut, resourcePath("backends", backend, "servers", derefName(server.Name)), transactionID, server)
+}
+
+// DeleteServer deletes a server from a backend
+func (a api) DeleteServer(ctx context.Context, name string, backend string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("backends", backend, "servers", name), transactionID, nil)
+	return err
+}
+
+// derefName returns the name of a resource or an empty string if unset
+func derefName(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
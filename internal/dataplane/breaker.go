@@ -0,0 +1,176 @@
+package dataplane
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned without contacting the Data Plane API while the circuit is open
+var ErrCircuitOpen = errors.New("data plane API circuit breaker is open")
+
+// CircuitState represents the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+// String returns the lower-case name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops calls to the Data Plane API after consecutive failures.
+// After the open duration has elapsed a single probe call is let through (half-open);
+// its success closes the circuit again, its failure re-opens it.
+type CircuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	instance         string // HAProxy instance label, set by NewClient
+
+	mutex     sync.Mutex
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	clockFunc func() time.Time
+}
+
+// NewCircuitBreaker creates a circuit breaker. A failureThreshold of zero or less disables it.
+func NewCircuitBreaker(failureThreshold int, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		clockFunc:        time.Now,
+	}
+}
+
+// Allow reports whether a call may proceed
+func (b *CircuitBreaker) Allow() bool {
+	if b == nil || b.failureThreshold <= 0 {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.clockFunc().Sub(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		// Only one probe at a time while half-open
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess resets the failure count and closes the circuit
+func (b *CircuitBreaker) RecordSuccess() {
+	if b == nil || b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != CircuitClosed {
+		logger.GetLogger().Info("Data Plane API recovered, closing circuit breaker",
+			zap.String("instance", b.instance))
+		b.setState(CircuitClosed)
+	}
+}
+
+// RecordFailure counts a failed call and opens the circuit once the threshold is reached
+func (b *CircuitBreaker) RecordFailure() {
+	if b == nil || b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.failureThreshold) {
+		logger.GetLogger().Warn("Data Plane API is failing, opening circuit breaker",
+			zap.String("instance", b.instance),
+			zap.Int("consecutive_failures", b.failures),
+			zap.Duration("open_duration", b.openDuration))
+		b.openedAt = b.clockFunc()
+		b.setState(CircuitOpen)
+	}
+}
+
+// Release ends a call without recording its outcome, e.g. when the caller cancelled it,
+// so that a pending half-open probe does not block further calls
+func (b *CircuitBreaker) Release() {
+	if b == nil || b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+}
+
+// Reset closes the circuit and forgets past failures without logging a recovery, e.g. after the client
+// switched to another Data Plane API URL
+func (b *CircuitBreaker) Reset() {
+	if b == nil || b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures = 0
+	b.probing = false
+	if b.state != CircuitClosed {
+		b.setState(CircuitClosed)
+	}
+}
+
+// State returns the current circuit state
+func (b *CircuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// setState updates the state and the exported gauge; the caller must hold the mutex
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	metrics.DataplaneCircuitState.WithLabelValues(b.instance).Set(float64(state))
+}
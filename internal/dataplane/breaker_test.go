@@ -0,0 +1,76 @@
+package dataplane
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(3, 30*time.Second)
+	breaker.clockFunc = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if !breaker.Allow() {
+			t.Fatalf("Expected call %d to be allowed", i)
+		}
+		breaker.RecordFailure()
+	}
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("Expected circuit to stay closed below threshold, got %s", breaker.State())
+	}
+
+	breaker.RecordFailure()
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected circuit to open at threshold, got %s", breaker.State())
+	}
+	if breaker.Allow() {
+		t.Error("Expected calls to be rejected while open")
+	}
+
+	// After the open duration a single probe is allowed
+	now = now.Add(31 * time.Second)
+	if !breaker.Allow() {
+		t.Fatal("Expected probe call after open duration")
+	}
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected half-open state, got %s", breaker.State())
+	}
+	if breaker.Allow() {
+		t.Error("Expected only one concurrent probe while half-open")
+	}
+
+	// A failed probe re-opens the circuit
+	breaker.RecordFailure()
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected failed probe to re-open circuit, got %s", breaker.State())
+	}
+
+	// A successful probe closes it
+	now = now.Add(31 * time.Second)
+	if !breaker.Allow() {
+		t.Fatal("Expected probe call after open duration")
+	}
+	breaker.RecordSuccess()
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("Expected successful probe to close circuit, got %s", breaker.State())
+	}
+	if !breaker.Allow() {
+		t.Error("Expected calls to be allowed once closed")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := NewCircuitBreaker(0, time.Second)
+	for i := 0; i < 10; i++ {
+		breaker.RecordFailure()
+	}
+	if !breaker.Allow() {
+		t.Error("Expected disabled breaker to always allow calls")
+	}
+
+	var nilBreaker *CircuitBreaker
+	if !nilBreaker.Allow() {
+		t.Error("Expected nil breaker to always allow calls")
+	}
+}
@@ -0,0 +1,132 @@
+package dataplane
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+)
+
+// readCacheContextKey marks contexts whose reads may be answered from the read cache
+type readCacheContextKey struct{}
+
+// WithReadCache allows reads of the committed configuration made with ctx to be answered from the read cache
+// of the client. The values returned from the cache are shared, so callers must not modify them.
+func WithReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheContextKey{}, true)
+}
+
+// readCache remembers reads of the committed configuration for a short time. Entries belong to the
+// configuration the client last saw: they are dropped whenever the client sends anything but a read, e.g. a
+// commit, when GetVersion reports another version, and when the client switches to another Data Plane API.
+// Only changes made to HAProxy around the configurator can therefore be missed, for up to the TTL.
+type readCache struct {
+	mutex      sync.Mutex
+	ttl        time.Duration // Zero disables the cache
+	version    int           // Configuration version last reported by GetVersion
+	generation uint64        // Incremented on every invalidation, so reads racing with a write are not stored
+	entries    map[string]cacheEntry
+}
+
+// cacheEntry is a remembered read
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// setTTL replaces the TTL, dropping all entries
+func (r *readCache) setTTL(ttl time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.ttl = ttl
+	r.clear()
+}
+
+// get returns the remembered value of key, if any. Without a hit it returns the generation to store the
+// value read instead with.
+func (r *readCache) get(key string) (any, uint64, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	entry, ok := r.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, r.generation, false
+	}
+	return entry.value, r.generation, true
+}
+
+// put remembers value for key unless the cache was invalidated since generation
+func (r *readCache) put(key string, generation uint64, value any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.ttl == 0 || generation != r.generation {
+		return
+	}
+	now := time.Now()
+	if r.entries == nil {
+		r.entries = make(map[string]cacheEntry)
+	}
+	// Expired entries are dropped here rather than by a background sweep
+	for k, entry := range r.entries {
+		if now.After(entry.expires) {
+			delete(r.entries, k)
+		}
+	}
+	r.entries[key] = cacheEntry{value: value, expires: now.Add(r.ttl)}
+}
+
+// invalidate drops all entries
+func (r *readCache) invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clear()
+}
+
+// observeVersion drops all entries if the configuration version changed since it was last seen
+func (r *readCache) observeVersion(version int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if version != r.version {
+		r.version = version
+		r.clear()
+	}
+}
+
+// clear drops all entries; the caller holds the mutex
+func (r *readCache) clear() {
+	r.generation++
+	r.entries = nil
+}
+
+// SetReadCacheTTL replaces how long reads made with WithReadCache are remembered; zero disables the cache
+func (c *Client) SetReadCacheTTL(ttl time.Duration) {
+	c.cache.setTTL(ttl)
+}
+
+// cachedRead answers a read of the committed configuration from the read cache if ctx allows it. Reads in
+// transactions see uncommitted changes and are never cached.
+func cachedRead[T any](ctx context.Context, c *Client, transactionID string, key []string, read func() (T, error)) (T, error) {
+	if transactionID != "" || ctx.Value(readCacheContextKey{}) == nil {
+		return read()
+	}
+
+	cacheKey := strings.Join(key, "\x00")
+	value, generation, ok := c.cache.get(cacheKey)
+	if ok {
+		metrics.DataplaneCacheRequests.WithLabelValues(c.instance, "hit").Inc()
+		return value.(T), nil
+	}
+	metrics.DataplaneCacheRequests.WithLabelValues(c.instance, "miss").Inc()
+
+	result, err := read()
+	if err == nil {
+		c.cache.put(cacheKey, generation, result)
+	}
+	return result, err
+}
+
+// isRead reports whether a Data Plane API endpoint only reads, so calling it leaves the read cache intact
+func isRead(endpoint string) bool {
+	return strings.HasSuffix(endpoint, ".get") || strings.HasSuffix(endpoint, ".list")
+}
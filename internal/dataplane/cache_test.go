@@ -0,0 +1,108 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadCache(t *testing.T) {
+	var lists, version atomic.Int32
+	version.Store(1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/services/haproxy/configuration/version":
+			_, _ = w.Write([]byte(strconv.Itoa(int(version.Load()))))
+		case r.URL.Path == "/v3/services/haproxy/configuration/backends" && r.Method == http.MethodGet:
+			lists.Add(1)
+			_, _ = w.Write([]byte(`[{"name": "app"}]`))
+		case r.URL.Path == "/v3/services/haproxy/transactions/txn-1":
+			_, _ = w.Write([]byte(`{"id": "txn-1", "status": "success"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL, ReadCacheTTL: time.Minute}, nil)
+	ctx := WithReadCache(context.Background())
+	list := func(ctx context.Context, transactionID string) {
+		t.Helper()
+		if backends, err := client.ListBackends(ctx, transactionID); err != nil || len(backends) != 1 {
+			t.Fatalf("ListBackends = %v, %v", backends, err)
+		}
+	}
+	expectLists := func(want int32, reason string) {
+		t.Helper()
+		if got := lists.Load(); got != want {
+			t.Errorf("Expected %d list requests %s, got %d", want, reason, got)
+		}
+	}
+
+	list(ctx, "")
+	list(ctx, "")
+	expectLists(1, "after a cached read")
+
+	list(context.Background(), "")
+	list(ctx, "txn-1")
+	expectLists(3, "without the cache and in a transaction")
+
+	if _, err := client.CommitTransaction(context.Background(), "txn-1"); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	list(ctx, "")
+	list(ctx, "")
+	expectLists(4, "after a commit")
+
+	// The version is seen the first time, then changes outside the client
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("GetVersion failed: %v", err)
+	}
+	list(ctx, "")
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("GetVersion failed: %v", err)
+	}
+	list(ctx, "")
+	expectLists(5, "while the version is unchanged")
+	version.Store(2)
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("GetVersion failed: %v", err)
+	}
+	list(ctx, "")
+	expectLists(6, "after the version changed")
+
+	client.SetReadCacheTTL(0)
+	list(ctx, "")
+	list(ctx, "")
+	expectLists(8, "with the cache disabled")
+}
+
+func TestReadCacheExpiry(t *testing.T) {
+	var cache readCache
+	cache.setTTL(10 * time.Millisecond)
+
+	_, generation, ok := cache.get("backends.list")
+	if ok {
+		t.Fatal("Expected an empty cache")
+	}
+	cache.put("backends.list", generation, "app")
+	if value, _, ok := cache.get("backends.list"); !ok || value != "app" {
+		t.Errorf("Expected a hit, got %v, %t", value, ok)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, _, ok := cache.get("backends.list"); ok {
+		t.Error("Expected the entry to expire")
+	}
+
+	// A read that started before an invalidation is not stored
+	_, generation, _ = cache.get("backends.list")
+	cache.invalidate()
+	cache.put("backends.list", generation, "stale")
+	if _, _, ok := cache.get("backends.list"); ok {
+		t.Error("Expected the stale read not to be stored")
+	}
+}
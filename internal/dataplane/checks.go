@@ -0,0 +1,85 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// HTTPCheck is an http-check rule of a backend. Health checks run the rules in order once option httpchk is
+// set: send rules write the request and expect rules test the response.
+type HTTPCheck struct {
+	Type            string            `json:"type"`             // "send" or "expect"
+	Method          string            `json:"method,omitempty"` // Of send rules
+	URI             string            `json:"uri,omitempty"`
+	Version         string            `json:"version,omitempty"` // e.g. "HTTP/1.1"
+	Headers         []HTTPCheckHeader `json:"headers,omitempty"`
+	Body            string            `json:"body,omitempty"`
+	Match           string            `json:"match,omitempty"` // Of expect rules: "status", "rstatus", "string" or "rstring"
+	Pattern         string            `json:"pattern,omitempty"`
+	ExclamationMark bool              `json:"exclamation_mark,omitempty"` // Negates the match
+}
+
+// HTTPCheckHeader is a header sent by an http-check send rule
+type HTTPCheckHeader struct {
+	Name string `json:"name"`
+	Fmt  string `json:"fmt"` // Value, a log format string
+}
+
+// httpCheckPath returns the path of the http-check rules of a backend, or of one of them at index
+func httpCheckPath(backend string, index ...int) string {
+	segments := []string{"backends", backend, "http_checks"}
+	for _, i := range index {
+		segments = append(segments, strconv.Itoa(i))
+	}
+	return resourcePath(segments...)
+}
+
+// ListHTTPChecks lists the http-check rules of a backend in order
+func (a api) ListHTTPChecks(ctx context.Context, backend string, transactionID string) ([]HTTPCheck, error) {
+	return requestList[HTTPCheck](ctx, a, httpCheckPath(backend), transactionID)
+}
+
+// AddHTTPCheck inserts an http-check rule into a backend at the given position
+func (a api) AddHTTPCheck(ctx context.Context, backend string, transactionID string, index int, check HTTPCheck) (*HTTPCheck, error) {
+	return requestObject[HTTPCheck](ctx, a, http.MethodPost, httpCheckPath(backend, index), transactionID, check)
+}
+
+// ReplaceHTTPCheck replaces the http-check rule of a backend at the given position
+func (a api) ReplaceHTTPCheck(ctx context.Context, backend string, transactionID string, index int, check HTTPCheck) (*HTTPCheck, error) {
+	return requestObject[HTTPCheck](ctx, a, http.MethodPut, httpCheckPath(backend, index), transactionID, check)
+}
+
+// DeleteHTTPCheck deletes the http-check rule of a backend at the given position
+func (a api) DeleteHTTPCheck(ctx context.Context, backend string, transactionID string, index int) error {
+	_, err := a.request(ctx, http.MethodDelete, httpCheckPath(backend, index), transactionID, nil)
+	return err
+}
+
+// ListHTTPChecks lists the http-check rules of a backend in order
+func (c *Client) ListHTTPChecks(ctx context.Context, backend string, transactionId string) ([]HTTPCheck, error) {
+	return call(ctx, c, "http_checks.list", func(a api) ([]HTTPCheck, error) {
+		return a.ListHTTPChecks(ctx, backend, transactionId)
+	})
+}
+
+// AddHTTPCheck inserts an http-check rule into a backend at the given position
+func (c *Client) AddHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check HTTPCheck) (*HTTPCheck, error) {
+	return call(ctx, c, "http_checks.add", func(a api) (*HTTPCheck, error) {
+		return a.AddHTTPCheck(ctx, backend, transactionId, index, check)
+	})
+}
+
+// ReplaceHTTPCheck replaces the http-check rule of a backend at the given position
+func (c *Client) ReplaceHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check HTTPCheck) (*HTTPCheck, error) {
+	return call(ctx, c, "http_checks.replace", func(a api) (*HTTPCheck, error) {
+		return a.ReplaceHTTPCheck(ctx, backend, transactionId, index, check)
+	})
+}
+
+// DeleteHTTPCheck deletes the http-check rule of a backend at the given position
+func (c *Client) DeleteHTTPCheck(ctx context.Context, backend string, transactionId string, index int) error {
+	return callErr(ctx, c, "http_checks.delete", func(a api) error {
+		return a.DeleteHTTPCheck(ctx, backend, transactionId, index)
+	})
+}
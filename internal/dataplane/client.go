@@ -0,0 +1,475 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// Endpoint describes how to reach a Data Plane API
+type Endpoint struct {
+	BaseURL    string
+	Credential string        // Base64 encoded basic auth credential
+	HTTPClient *http.Client  // nil uses http.DefaultClient
+	Timeout    time.Duration // Per request attempt; zero relies on the caller's context only
+	Retry      RetryPolicies
+	Failover   FailoverPolicy
+	DryRun     bool // Log writes instead of sending them, see simulate
+	// How long reads made with WithReadCache are remembered; zero disables the read cache
+	ReadCacheTTL time.Duration
+	// Open transactions above which CreateTransaction fails; zero means unlimited
+	MaxOpenTransactions int
+	// Wraps the transport of HTTPClient and of clients passed to SetHTTPClient, e.g. Recorder.Wrap; nil sends
+	// requests through the transport as is
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+	// Stats socket of HAProxy, a unix socket path or TCP address, from which rings are read; empty if unavailable
+	RuntimeSocket string
+}
+
+// Client wraps the HAProxy Data Plane API, recording per-endpoint
+// latency and error metrics and failing fast through a circuit breaker
+// while the upstream API is persistently unavailable. With fallback URLs
+// it fails over to the next reachable Data Plane API, see failover.
+type Client struct {
+	instance string
+	mutex    sync.RWMutex
+	api      api
+	breaker  *CircuitBreaker
+
+	// Failover state, protected by mutex. urls[0] is the primary URL and api.baseURL is urls[active].
+	urls     []string
+	active   int
+	failures int // Consecutive failures of the active URL
+	failover FailoverPolicy
+	probing  bool // Whether probeEndpoints is running
+
+	cache         readCache
+	transactions  *transactionLimits
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+	runtimeSocket string
+}
+
+// NewClient creates a Client for the named HAProxy instance reachable at endpoint,
+// guarded by the given circuit breaker (which may be nil)
+func NewClient(instance string, endpoint Endpoint, breaker *CircuitBreaker) *Client {
+	if breaker != nil {
+		breaker.instance = instance
+	}
+	httpClient := endpoint.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := &Client{
+		instance: instance,
+		api: api{
+			baseURL:    endpoint.BaseURL,
+			credential: endpoint.Credential,
+			httpClient: wrapHTTPClient(httpClient, endpoint.WrapTransport),
+			timeout:    endpoint.Timeout,
+			retry:      endpoint.Retry,
+			dryRun:     endpoint.DryRun,
+		},
+		breaker:       breaker,
+		urls:          append([]string{endpoint.BaseURL}, endpoint.Failover.FallbackURLs...),
+		failover:      endpoint.Failover,
+		cache:         readCache{ttl: endpoint.ReadCacheTTL},
+		transactions:  newTransactionLimits(endpoint.MaxOpenTransactions),
+		wrapTransport: endpoint.WrapTransport,
+		runtimeSocket: endpoint.RuntimeSocket,
+	}
+	client.reportActiveURL(nil)
+	return client
+}
+
+// Instance returns the name of the HAProxy instance the client talks to
+func (c *Client) Instance() string {
+	return c.instance
+}
+
+// Breaker returns the circuit breaker guarding the client
+func (c *Client) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// SetCredential replaces the base64 encoded basic auth credential used for subsequent calls
+func (c *Client) SetCredential(credential string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.api.credential = credential
+}
+
+// SetEndpoint replaces the primary Data Plane API base URL and the credential used for subsequent calls.
+// If the primary URL changed, the client returns to it.
+func (c *Client) SetEndpoint(baseURL, credential string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.api.credential = credential
+
+	if baseURL != c.urls[0] {
+		previous := c.urls
+		c.urls = append([]string{baseURL}, c.urls[1:]...)
+		c.switchTo(0)
+		c.reportActiveURL(previous)
+	}
+}
+
+// SetHTTPClient replaces the HTTP client used for subsequent calls, e.g. after TLS settings changed.
+// A nil client restores http.DefaultClient. Idle connections of the previous client are closed.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpClient = wrapHTTPClient(httpClient, c.wrapTransport)
+
+	c.mutex.Lock()
+	previous := c.api.httpClient
+	c.api.httpClient = httpClient
+	c.mutex.Unlock()
+
+	if previous != httpClient && previous != http.DefaultClient {
+		previous.CloseIdleConnections()
+	}
+}
+
+// wrapHTTPClient returns a copy of httpClient whose transport is wrapped by wrap, or httpClient if wrap is nil
+func wrapHTTPClient(httpClient *http.Client, wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	if wrap == nil {
+		return httpClient
+	}
+	wrapped := *httpClient
+	wrapped.Transport = wrap(httpClient.Transport)
+	return &wrapped
+}
+
+// SetRequestPolicy replaces the per-attempt timeout and retry policies used for subsequent calls
+func (c *Client) SetRequestPolicy(timeout time.Duration, retry RetryPolicies) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.api.timeout = timeout
+	c.api.retry = retry
+}
+
+// current returns a snapshot of the underlying API client
+func (c *Client) current() api {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.api
+}
+
+// call runs a single Data Plane API call through the circuit breaker and records its metrics
+func call[T any](ctx context.Context, c *Client, endpoint string, fn func(a api) (T, error)) (T, error) {
+	if !c.breaker.Allow() {
+		var zero T
+		metrics.DataplaneRequests.WithLabelValues(c.instance, endpoint, "rejected").Inc()
+		return zero, ErrCircuitOpen
+	}
+
+	a := c.current()
+	start := time.Now()
+	result, err := fn(a)
+	metrics.DataplaneRequestDuration.WithLabelValues(c.instance, endpoint).Observe(time.Since(start).Seconds())
+	// Even a failed write may have been applied
+	if !isRead(endpoint) {
+		c.cache.invalidate()
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up; that says nothing about the API's health either
+		c.breaker.Release()
+		metrics.DataplaneRequests.WithLabelValues(c.instance, endpoint, "canceled").Inc()
+	case err == nil:
+		c.breaker.RecordSuccess()
+		c.recordReachable(a.baseURL)
+		metrics.DataplaneRequests.WithLabelValues(c.instance, endpoint, "success").Inc()
+	case isClientError(err):
+		// The API answered; a rejected request says nothing about its health
+		c.breaker.RecordSuccess()
+		c.recordReachable(a.baseURL)
+		metrics.DataplaneRequests.WithLabelValues(c.instance, endpoint, "client_error").Inc()
+	default:
+		c.breaker.RecordFailure()
+		if isUnreachable(err) {
+			c.recordUnreachable(a.baseURL, err)
+		}
+		metrics.DataplaneRequests.WithLabelValues(c.instance, endpoint, "error").Inc()
+	}
+
+	return result, err
+}
+
+// callErr adapts calls returning only an error to call
+func callErr(ctx context.Context, c *Client, endpoint string, fn func(a api) error) error {
+	_, err := call(ctx, c, endpoint, func(a api) (struct{}, error) {
+		return struct{}{}, fn(a)
+	})
+	return err
+}
+
+// isClientError reports whether err is a 4xx response from the Data Plane API
+func isClientError(err error) bool {
+	if v3.IsNotFound(err) || v3.IsBadRequest(err) || v3.IsConflict(err) || v3.IsUnauthorized(err) {
+		return true
+	}
+	code := v3.GetHTTPStatusCode(err)
+	return code >= 400 && code < 500
+}
+
+// Transaction operations
+
+// GetVersion returns the current HAProxy configuration version
+func (c *Client) GetVersion(ctx context.Context) (*int, error) {
+	version, err := call(ctx, c, "version.get", func(a api) (*int, error) {
+		return a.GetVersion(ctx)
+	})
+	if err == nil && version != nil {
+		c.cache.observeVersion(*version)
+	}
+	return version, err
+}
+
+// GetInfo returns the version of the Data Plane API
+func (c *Client) GetInfo(ctx context.Context) (*Info, error) {
+	return call(ctx, c, "info.get", func(a api) (*Info, error) {
+		return a.GetInfo(ctx)
+	})
+}
+
+// CreateTransaction starts a new transaction based on the given configuration version. With a limit of open
+// transactions, it fails with ErrTooManyTransactions once the limit is reached.
+func (c *Client) CreateTransaction(ctx context.Context, version int) (*v3.Transaction, error) {
+	if maxOpen := int(c.transactions.maxOpen.Load()); maxOpen > 0 {
+		c.transactions.createMutex.Lock()
+		defer c.transactions.createMutex.Unlock()
+		if err := c.checkOpenTransactions(ctx, maxOpen); err != nil {
+			return nil, err
+		}
+	}
+	return call(ctx, c, "transactions.create", func(a api) (*v3.Transaction, error) {
+		return a.CreateTransaction(ctx, version)
+	})
+}
+
+// GetTransaction retrieves a transaction by ID
+func (c *Client) GetTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	return call(ctx, c, "transactions.get", func(a api) (*v3.Transaction, error) {
+		return a.GetTransaction(ctx, id)
+	})
+}
+
+// ListTransactions lists the open transactions
+func (c *Client) ListTransactions(ctx context.Context) ([]v3.Transaction, error) {
+	return call(ctx, c, "transactions.list", func(a api) ([]v3.Transaction, error) {
+		return a.ListTransactions(ctx)
+	})
+}
+
+// CommitTransaction commits a transaction. Commits through the same client are sent one at a time.
+func (c *Client) CommitTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	release, err := c.acquireCommit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return call(ctx, c, "transactions.commit", func(a api) (*v3.Transaction, error) {
+		return a.CommitTransaction(ctx, id)
+	})
+}
+
+// CloseTransaction closes a transaction without committing it
+func (c *Client) CloseTransaction(ctx context.Context, id string) (*string, error) {
+	return call(ctx, c, "transactions.close", func(a api) (*string, error) {
+		return a.CloseTransaction(ctx, id)
+	})
+}
+
+// Backend operations
+
+// AddBackend creates a backend
+func (c *Client) AddBackend(ctx context.Context, backend Backend, transactionId string) (*Backend, error) {
+	return call(ctx, c, "backends.add", func(a api) (*Backend, error) {
+		return a.AddBackend(ctx, backend, transactionId)
+	})
+}
+
+// GetBackend retrieves a backend by name
+func (c *Client) GetBackend(ctx context.Context, name string, transactionId string) (*Backend, error) {
+	return cachedRead(ctx, c, transactionId, []string{"backends.get", name}, func() (*Backend, error) {
+		return call(ctx, c, "backends.get", func(a api) (*Backend, error) {
+			return a.GetBackend(ctx, name, transactionId)
+		})
+	})
+}
+
+// ListBackends lists all backends
+func (c *Client) ListBackends(ctx context.Context, transactionId string) ([]Backend, error) {
+	return cachedRead(ctx, c, transactionId, []string{"backends.list"}, func() ([]Backend, error) {
+		return call(ctx, c, "backends.list", func(a api) ([]Backend, error) {
+			return a.ListBackends(ctx, transactionId)
+		})
+	})
+}
+
+// ReplaceBackend replaces an existing backend
+func (c *Client) ReplaceBackend(ctx context.Context, name string, backend Backend, transactionId string) (*Backend, error) {
+	return call(ctx, c, "backends.replace", func(a api) (*Backend, error) {
+		return a.ReplaceBackend(ctx, name, backend, transactionId)
+	})
+}
+
+// DeleteBackend deletes a backend
+func (c *Client) DeleteBackend(ctx context.Context, name string, transactionId string) error {
+	return callErr(ctx, c, "backends.delete", func(a api) error {
+		return a.DeleteBackend(ctx, name, transactionId)
+	})
+}
+
+// Frontend operations
+
+// AddFrontend creates a frontend
+func (c *Client) AddFrontend(ctx context.Context, frontend Frontend, transactionId string) (*Frontend, error) {
+	return call(ctx, c, "frontends.add", func(a api) (*Frontend, error) {
+		return a.AddFrontend(ctx, frontend, transactionId)
+	})
+}
+
+// GetFrontend retrieves a frontend by name
+func (c *Client) GetFrontend(ctx context.Context, name string, transactionId string) (*Frontend, error) {
+	return cachedRead(ctx, c, transactionId, []string{"frontends.get", name}, func() (*Frontend, error) {
+		return call(ctx, c, "frontends.get", func(a api) (*Frontend, error) {
+			return a.GetFrontend(ctx, name, transactionId)
+		})
+	})
+}
+
+// ListFrontends lists all frontends
+func (c *Client) ListFrontends(ctx context.Context, transactionId string) ([]Frontend, error) {
+	return cachedRead(ctx, c, transactionId, []string{"frontends.list"}, func() ([]Frontend, error) {
+		return call(ctx, c, "frontends.list", func(a api) ([]Frontend, error) {
+			return a.ListFrontends(ctx, transactionId)
+		})
+	})
+}
+
+// ReplaceFrontend replaces an existing frontend
+func (c *Client) ReplaceFrontend(ctx context.Context, name string, frontend Frontend, transactionId string) (*Frontend, error) {
+	return call(ctx, c, "frontends.replace", func(a api) (*Frontend, error) {
+		return a.ReplaceFrontend(ctx, name, frontend, transactionId)
+	})
+}
+
+// DeleteFrontend deletes a frontend
+func (c *Client) DeleteFrontend(ctx context.Context, name string, transactionId string) error {
+	return callErr(ctx, c, "frontends.delete", func(a api) error {
+		return a.DeleteFrontend(ctx, name, transactionId)
+	})
+}
+
+// Bind operations
+
+// AddBind creates a bind on a frontend
+func (c *Client) AddBind(ctx context.Context, frontend string, transactionId string, bind Bind) (*Bind, error) {
+	return call(ctx, c, "binds.add", func(a api) (*Bind, error) {
+		return a.AddBind(ctx, frontend, transactionId, bind)
+	})
+}
+
+// GetBind retrieves a bind of a frontend by name
+func (c *Client) GetBind(ctx context.Context, name string, frontend string, transactionId string) (*Bind, error) {
+	return cachedRead(ctx, c, transactionId, []string{"binds.get", frontend, name}, func() (*Bind, error) {
+		return call(ctx, c, "binds.get", func(a api) (*Bind, error) {
+			return a.GetBind(ctx, name, frontend, transactionId)
+		})
+	})
+}
+
+// ListBinds lists all binds of a frontend
+func (c *Client) ListBinds(ctx context.Context, frontend string, transactionId string) ([]Bind, error) {
+	return cachedRead(ctx, c, transactionId, []string{"binds.list", frontend}, func() ([]Bind, error) {
+		return call(ctx, c, "binds.list", func(a api) ([]Bind, error) {
+			return a.ListBinds(ctx, frontend, transactionId)
+		})
+	})
+}
+
+// ReplaceBind replaces an existing bind of a frontend
+func (c *Client) ReplaceBind(ctx context.Context, frontend string, transactionId string, bind Bind) (*Bind, error) {
+	return call(ctx, c, "binds.replace", func(a api) (*Bind, error) {
+		return a.ReplaceBind(ctx, frontend, transactionId, bind)
+	})
+}
+
+// DeleteBind deletes a bind from a frontend
+func (c *Client) DeleteBind(ctx context.Context, name string, frontend string, transactionId string) error {
+	return callErr(ctx, c, "binds.delete", func(a api) error {
+		return a.DeleteBind(ctx, name, frontend, transactionId)
+	})
+}
+
+// Server operations
+
+// AddServer creates a server in a backend
+func (c *Client) AddServer(ctx context.Context, backend string, transactionId string, server Server) (*Server, error) {
+	return call(ctx, c, "servers.add", func(a api) (*Server, error) {
+		return a.AddServer(ctx, backend, transactionId, server)
+	})
+}
+
+// GetServer retrieves a server of a backend by name
+func (c *Client) GetServer(ctx context.Context, name string, backend string, transactionId string) (*Server, error) {
+	return cachedRead(ctx, c, transactionId, []string{"servers.get", backend, name}, func() (*Server, error) {
+		return call(ctx, c, "servers.get", func(a api) (*Server, error) {
+			return a.GetServer(ctx, name, backend, transactionId)
+		})
+	})
+}
+
+// ListServers lists all servers of a backend
+func (c *Client) ListServers(ctx context.Context, backend string, transactionId string) ([]Server, error) {
+	return cachedRead(ctx, c, transactionId, []string{"servers.list", backend}, func() ([]Server, error) {
+		return call(ctx, c, "servers.list", func(a api) ([]Server, error) {
+			return a.ListServers(ctx, backend, transactionId)
+		})
+	})
+}
+
+// ReplaceServer replaces an existing server of a backend
+func (c *Client) ReplaceServer(ctx context.Context, backend string, transactionId string, server Server) (*Server, error) {
+	return call(ctx, c, "servers.replace", func(a api) (*Server, error) {
+		return a.ReplaceServer(ctx, backend, transactionId, server)
+	})
+}
+
+// DeleteServer deletes a server from a backend
+func (c *Client) DeleteServer(ctx context.Context, name string, backend string, transactionId string) error {
+	return callErr(ctx, c, "servers.delete", func(a api) error {
+		return a.DeleteServer(ctx, name, backend, transactionId)
+	})
+}
+
+// Runtime operations
+
+// GetStats returns the statistics of all frontends, backends and servers
+func (c *Client) GetStats(ctx context.Context) ([]ProxyStats, error) {
+	return call(ctx, c, "stats.get", func(a api) ([]ProxyStats, error) {
+		return a.GetStats(ctx)
+	})
+}
+
+// SetServerAdminState changes the administrative state of a server in the running process
+func (c *Client) SetServerAdminState(ctx context.Context, backend, name, state string) (*RuntimeServer, error) {
+	return call(ctx, c, "runtime.servers.replace", func(a api) (*RuntimeServer, error) {
+		return a.SetServerAdminState(ctx, backend, name, state)
+	})
+}
+
+// ListRuntimeServers returns the state of the servers of a backend in the running process
+func (c *Client) ListRuntimeServers(ctx context.Context, backend string) ([]RuntimeServer, error) {
+	return call(ctx, c, "runtime.servers.list", func(a api) ([]RuntimeServer, error) {
+		return a.ListRuntimeServers(ctx, backend)
+	})
+}
@@ -0,0 +1,257 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+func TestClientRequests(t *testing.T) {
+	var gotMethod, gotPath, gotQuery, gotAuth, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotQuery, gotAuth, gotBody = r.Method, r.URL.EscapedPath(), r.URL.RawQuery, r.Header.Get("Authorization"), string(body)
+
+		switch r.URL.Path {
+		case "/v3/services/haproxy/configuration/version":
+			_, _ = w.Write([]byte("42\n"))
+		case "/v3/info":
+			_, _ = w.Write([]byte(`{"api":{"version":"v3.1.0 abcdef","build_date":"2025-01-01T00:00:00Z"}}`))
+		case "/v3/services/haproxy/configuration/backends/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"missing"}`))
+		case "/v3/services/haproxy/configuration/backends/broken":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL + "/", Credential: "Y3JlZA=="}, nil)
+
+	version, err := client.GetVersion(context.Background())
+	if err != nil || version == nil || *version != 42 {
+		t.Fatalf("GetVersion = %v, %v; want 42", version, err)
+	}
+	if gotAuth != "Basic Y3JlZA==" {
+		t.Errorf("Expected basic auth header, got %q", gotAuth)
+	}
+
+	info, err := client.GetInfo(context.Background())
+	if err != nil || info.API.Version != "v3.1.0 abcdef" || info.API.BuildDate != "2025-01-01T00:00:00Z" {
+		t.Fatalf("GetInfo = %+v, %v", info, err)
+	}
+
+	name := "web/api"
+	created, err := client.AddServer(context.Background(), "pool a", "txn-1", Server{Server: v3.Server{Name: &name}})
+	if err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	if created == nil || created.Name == nil || *created.Name != name {
+		t.Errorf("Unexpected server returned: %+v", created)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/v3/services/haproxy/configuration/backends/pool%20a/servers" {
+		t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+	}
+	if gotQuery != "transaction_id=txn-1" {
+		t.Errorf("Unexpected query %q", gotQuery)
+	}
+	if gotBody == "" {
+		t.Error("Expected request body")
+	}
+
+	if _, err := client.GetTransaction(context.Background(), "txn-1"); err != nil {
+		t.Fatalf("GetTransaction failed: %v", err)
+	}
+	if gotMethod != http.MethodGet {
+		t.Errorf("GetTransaction should use GET, got %s", gotMethod)
+	}
+
+	if _, err := client.GetBackend(context.Background(), "missing", ""); !v3.IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := client.GetBackend(context.Background(), "broken", ""); v3.GetHTTPStatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 error, got %v", err)
+	}
+}
+
+func TestNewHTTPClientCustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("7"))
+	}))
+	defer srv.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+
+	// Without the CA the self-signed server certificate is rejected
+	untrusted := NewClient("test", Endpoint{BaseURL: srv.URL}, nil)
+	if _, err := untrusted.GetVersion(context.Background()); err == nil {
+		t.Error("Expected certificate verification to fail without the CA")
+	}
+
+	httpClient, err := NewHTTPClient(config.HAProxySettings{
+		TLS: config.DataplaneTLSSettings{CACert: caPath},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient failed: %v", err)
+	}
+
+	trusted := NewClient("test", Endpoint{BaseURL: srv.URL, HTTPClient: httpClient}, nil)
+	if version, err := trusted.GetVersion(context.Background()); err != nil || *version != 7 {
+		t.Errorf("GetVersion = %v, %v; want 7", version, err)
+	}
+}
+
+func TestNewHTTPClientConnectionPool(t *testing.T) {
+	for _, disableHTTP2 := range []bool{false, true} {
+		var connections, protoMajor atomic.Int32
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			protoMajor.Store(int32(r.ProtoMajor))
+			_, _ = w.Write([]byte("7"))
+		}))
+		srv.EnableHTTP2 = true
+		srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connections.Add(1)
+			}
+		}
+		srv.StartTLS()
+
+		caPath := filepath.Join(t.TempDir(), "ca.pem")
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		if err := os.WriteFile(caPath, caPEM, 0600); err != nil {
+			t.Fatalf("Failed to write CA: %v", err)
+		}
+		httpClient, err := NewHTTPClient(config.HAProxySettings{
+			TLS:            config.DataplaneTLSSettings{CACert: caPath},
+			ConnectionPool: config.ConnectionPoolSettings{MaxIdleConnsPerHost: 4, KeepAliveSeconds: 30, DisableHTTP2: disableHTTP2},
+		})
+		if err != nil {
+			t.Fatalf("NewHTTPClient failed: %v", err)
+		}
+
+		client := NewClient("test", Endpoint{BaseURL: srv.URL, HTTPClient: httpClient}, nil)
+		for i := 0; i < 10; i++ {
+			if _, err := client.GetVersion(context.Background()); err != nil {
+				t.Fatalf("GetVersion failed: %v", err)
+			}
+		}
+		if got := connections.Load(); got != 1 {
+			t.Errorf("Expected sequential requests to share 1 connection (disable_http2 %t), got %d", disableHTTP2, got)
+		}
+		want := int32(2)
+		if disableHTTP2 {
+			want = 1
+		}
+		if got := protoMajor.Load(); got != want {
+			t.Errorf("Expected HTTP/%d (disable_http2 %t), got HTTP/%d", want, disableHTTP2, got)
+		}
+		srv.Close()
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("5"))
+	}))
+	defer srv.Close()
+
+	retry := RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	client := NewClient("test", Endpoint{BaseURL: srv.URL, Retry: RetryPolicies{Read: retry, Write: retry}}, nil)
+
+	if version, err := client.GetVersion(context.Background()); err != nil || *version != 5 {
+		t.Fatalf("GetVersion = %v, %v; want 5", version, err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+
+	// Non-idempotent requests are sent only once
+	attempts.Store(0)
+	if _, err := client.CreateTransaction(context.Background(), 1); err == nil {
+		t.Error("Expected CreateTransaction to fail")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+
+	// Writes follow a policy of their own
+	client.SetRequestPolicy(0, RetryPolicies{Read: retry})
+	attempts.Store(0)
+	if err := client.DeleteBackend(context.Background(), "app", "tx"); err == nil || !IsTransient(err) {
+		t.Errorf("Expected DeleteBackend to fail with a transient error, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt without write retries, got %d", got)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for retry, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond} {
+		if got := policy.backoff(retry); got != want {
+			t.Errorf("backoff(%d) = %v; want %v", retry, got, want)
+		}
+	}
+
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("Expected a jittered backoff between 100ms and 200ms, got %v", got)
+		}
+	}
+}
+
+func TestClientTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL, Timeout: 50 * time.Millisecond}, NewCircuitBreaker(1, time.Minute))
+	if _, err := client.GetVersion(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected per-request timeout, got %v", err)
+	}
+	if client.Breaker().State() != CircuitOpen {
+		t.Error("Expected a timed out request to count as a failure")
+	}
+
+	// The caller's deadline applies even without a per-request timeout
+	client = NewClient("test", Endpoint{BaseURL: srv.URL}, NewCircuitBreaker(1, time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetVersion(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected caller deadline to be exceeded, got %v", err)
+	}
+	if client.Breaker().State() != CircuitClosed {
+		t.Error("Expected an abandoned request not to count as a failure")
+	}
+}
@@ -0,0 +1,80 @@
+package dataplane
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+	"go.uber.org/zap"
+)
+
+// dryRunTransactionPrefix marks the IDs of the transactions simulated in dry-run mode
+const dryRunTransactionPrefix = "dry-run-"
+
+// transactionsPath is the collection of configuration transactions
+const transactionsPath = "/v3/services/haproxy/transactions"
+
+// dryRunTransactions numbers the simulated transactions
+var dryRunTransactions atomic.Int64
+
+// simulate answers a request in dry-run mode without sending it. Writes are logged and answered as the Data
+// Plane API would: a new or committed transaction, or the written object. Reads are only answered for
+// simulated transactions; ok is false for everything that should still be read from the Data Plane API.
+func simulate(method, path, transactionID string, payload []byte) (data []byte, ok bool) {
+	if method == http.MethodGet {
+		id, found := strings.CutPrefix(path, transactionsPath+"/")
+		if !found || !strings.HasPrefix(id, dryRunTransactionPrefix) {
+			return nil, false
+		}
+		return marshalTransaction(id, "in_progress"), true
+	}
+
+	logger.GetLogger().Info("Dry run: skipped Data Plane API request",
+		zap.String("method", method),
+		zap.String("path", path),
+		zap.String("transaction_id", transactionID),
+		logger.Payload("body", payload))
+
+	switch {
+	case method == http.MethodPost && strings.HasPrefix(path, transactionsPath+"?"):
+		id := fmt.Sprintf("%s%d-%d", dryRunTransactionPrefix, time.Now().Unix(), dryRunTransactions.Add(1))
+		return marshalTransaction(id, "in_progress"), true
+	case method == http.MethodPut && strings.HasPrefix(path, transactionsPath+"/"):
+		return marshalTransaction(strings.TrimPrefix(path, transactionsPath+"/"), "success"), true
+	case method == http.MethodDelete:
+		return nil, true
+	default:
+		// Creates and replaces return the object as written
+		return payload, true
+	}
+}
+
+// marshalTransaction encodes a transaction as returned by the Data Plane API
+func marshalTransaction(id, status string) []byte {
+	data, _ := json.Marshal(v3.Transaction{Id: &id, Status: &status})
+	return data
+}
+
+// simulateCertificate answers a certificate upload in dry-run mode. Only the name is logged, as certificates
+// contain private keys.
+func simulateCertificate(method, name string) *Certificate {
+	logger.GetLogger().Info("Dry run: skipped Data Plane API request",
+		zap.String("method", method),
+		zap.String("path", certificatesPath),
+		zap.String("certificate", name))
+	return &Certificate{StorageName: name, File: name}
+}
+
+// simulateStorageFile answers the upload of a general file in dry-run mode, logging only its name
+func simulateStorageFile(method, name string) *StorageFile {
+	logger.GetLogger().Info("Dry run: skipped Data Plane API request",
+		zap.String("method", method),
+		zap.String("path", storagePath),
+		zap.String("file", name))
+	return &StorageFile{StorageName: name, File: name}
+}
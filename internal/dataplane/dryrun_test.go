@@ -0,0 +1,62 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+func TestDryRunOnlySendsReads(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		_, _ = w.Write([]byte(`[{"name": "live"}]`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL, DryRun: true}, nil)
+	ctx := context.Background()
+
+	transaction, err := client.CreateTransaction(ctx, 3)
+	if err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+	id := *transaction.Id
+	if !strings.HasPrefix(id, dryRunTransactionPrefix) || *transaction.Status != "in_progress" {
+		t.Fatalf("Unexpected transaction %s %s", id, *transaction.Status)
+	}
+
+	name := "app"
+	backend, err := client.AddBackend(ctx, Backend{Backend: v3.Backend{Name: &name}}, id)
+	if err != nil || *backend.Name != "app" {
+		t.Fatalf("Expected AddBackend to return the backend, got %v, %v", backend, err)
+	}
+	if err := client.DeleteBackend(ctx, "old", id); err != nil {
+		t.Fatalf("DeleteBackend failed: %v", err)
+	}
+	if certificate, err := client.AddCertificate(ctx, "example.com.pem", "key material"); err != nil || certificate.StorageName != "example.com.pem" {
+		t.Fatalf("Expected AddCertificate to return the certificate, got %v, %v", certificate, err)
+	}
+	if got, err := client.GetTransaction(ctx, id); err != nil || *got.Id != id {
+		t.Fatalf("Expected the simulated transaction, got %v, %v", got, err)
+	}
+
+	// Reads within a simulated transaction see the live configuration
+	backends, err := client.ListBackends(ctx, id)
+	if err != nil || len(backends) != 1 || *backends[0].Name != "live" {
+		t.Fatalf("Unexpected backends %v, %v", backends, err)
+	}
+
+	committed, err := client.CommitTransaction(ctx, id)
+	if err != nil || *committed.Id != id || *committed.Status != "success" {
+		t.Fatalf("Unexpected commit %v, %v", committed, err)
+	}
+
+	if len(requests) != 1 || requests[0] != "GET "+configurationPath+"/backends" {
+		t.Errorf("Expected only the read to be sent, got %v", requests)
+	}
+}
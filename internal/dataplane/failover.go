@@ -0,0 +1,174 @@
+package dataplane
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// defaultProbeInterval is used when a FailoverPolicy has no probe interval
+const defaultProbeInterval = 10 * time.Second
+
+// FailoverPolicy controls switching to fallback Data Plane API URLs. The zero value disables failover.
+type FailoverPolicy struct {
+	FallbackURLs     []string      // Tried in order after the primary URL
+	FailureThreshold int           // Consecutive failures of the active URL before switching to the next
+	ProbeInterval    time.Duration // How often the URLs preceding the active one are probed while failed over
+}
+
+// ActiveURL returns the Data Plane API URL calls are currently sent to
+func (c *Client) ActiveURL() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.api.baseURL
+}
+
+// SetFailover replaces the fallback URLs and failover settings. If the fallback URLs changed, the client
+// returns to the primary URL.
+func (c *Client) SetFailover(policy FailoverPolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous := c.urls
+	c.failover = policy
+	c.urls = append([]string{c.urls[0]}, policy.FallbackURLs...)
+	if !slices.Equal(previous, c.urls) {
+		c.switchTo(0)
+	}
+	c.reportActiveURL(previous)
+}
+
+// recordReachable resets the failure count after the active URL answered
+func (c *Client) recordReachable(url string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.api.baseURL == url {
+		c.failures = 0
+	}
+}
+
+// recordUnreachable counts a failure of the given URL and switches to the next URL once the threshold is
+// reached. While a fallback URL is active, the preceding URLs are probed to fall back to them.
+func (c *Client) recordUnreachable(url string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Failures of a URL the client already switched away from are stale
+	if len(c.urls) < 2 || c.api.baseURL != url {
+		return
+	}
+	c.failures++
+	if c.failures < max(c.failover.FailureThreshold, 1) {
+		return
+	}
+
+	next := (c.active + 1) % len(c.urls)
+	logger.GetLogger().Warn("Data Plane API is unreachable, failing over",
+		zap.String("instance", c.instance),
+		zap.String("from", url),
+		zap.String("to", c.urls[next]),
+		zap.Int("consecutive_failures", c.failures),
+		zap.Error(err))
+	c.switchTo(next)
+	c.reportActiveURL(nil)
+
+	if next != 0 && !c.probing {
+		c.probing = true
+		go c.probeEndpoints()
+	}
+}
+
+// probeEndpoints periodically checks the URLs preceding the active one and switches back to the first
+// reachable one. It returns once the primary URL is active again.
+func (c *Client) probeEndpoints() {
+	interval := c.probeInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mutex.Lock()
+		if c.active == 0 {
+			c.probing = false
+			c.mutex.Unlock()
+			return
+		}
+		candidates := append([]string(nil), c.urls[:c.active]...)
+		a := c.api.withoutRetries()
+		c.mutex.Unlock()
+
+		for _, url := range candidates {
+			a.baseURL = url
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			_, err := a.GetInfo(ctx)
+			cancel()
+			if err != nil {
+				continue
+			}
+			if c.fallBackTo(url) {
+				break
+			}
+		}
+	}
+}
+
+// fallBackTo switches to a URL preceding the active one after it was found reachable
+func (c *Client) fallBackTo(url string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := 0; i < c.active; i++ {
+		if c.urls[i] != url {
+			continue
+		}
+		logger.GetLogger().Info("Data Plane API is reachable again, falling back",
+			zap.String("instance", c.instance),
+			zap.String("from", c.api.baseURL),
+			zap.String("to", url))
+		c.switchTo(i)
+		c.reportActiveURL(nil)
+		return true
+	}
+	return false
+}
+
+// probeInterval returns the configured probe interval or its default
+func (c *Client) probeInterval() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.failover.ProbeInterval > 0 {
+		return c.failover.ProbeInterval
+	}
+	return defaultProbeInterval
+}
+
+// switchTo makes the URL at the given index active. Failures counted against the previous URL no longer
+// apply, so the circuit breaker is reset as well. The caller must hold the mutex.
+func (c *Client) switchTo(index int) {
+	if c.active != index {
+		c.breaker.Reset()
+	}
+	c.active = index
+	c.failures = 0
+	c.api.baseURL = c.urls[index]
+	// Another Data Plane API may serve another configuration
+	c.cache.invalidate()
+}
+
+// reportActiveURL updates the active endpoint gauge, removing URLs no longer configured. The caller must hold
+// the mutex.
+func (c *Client) reportActiveURL(previous []string) {
+	for _, url := range previous {
+		metrics.DataplaneActiveEndpoint.DeleteLabelValues(c.instance, url)
+	}
+	for i, url := range c.urls {
+		value := 0.0
+		if i == c.active {
+			value = 1
+		}
+		metrics.DataplaneActiveEndpoint.WithLabelValues(c.instance, url).Set(value)
+	}
+}
@@ -0,0 +1,86 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newVersionServer serves configuration version 1, or 503 while down is set
+func newVersionServer(t *testing.T, down *atomic.Bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/info":
+			_, _ = w.Write([]byte(`{"api":{"version":"v3"}}`))
+		default:
+			_, _ = w.Write([]byte("1"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientFailover(t *testing.T) {
+	var primaryDown, fallbackDown atomic.Bool
+	primary, fallback := newVersionServer(t, &primaryDown), newVersionServer(t, &fallbackDown)
+	primaryDown.Store(true)
+
+	client := NewClient("failover", Endpoint{
+		BaseURL: primary.URL,
+		Failover: FailoverPolicy{
+			FallbackURLs:     []string{fallback.URL},
+			FailureThreshold: 2,
+			ProbeInterval:    20 * time.Millisecond,
+		},
+	}, NewCircuitBreaker(2, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetVersion(context.Background()); err == nil {
+			t.Fatalf("Expected call %d to fail while the primary is down", i)
+		}
+	}
+	if client.ActiveURL() != fallback.URL {
+		t.Fatalf("Expected failover to %s, got %s", fallback.URL, client.ActiveURL())
+	}
+	// The circuit opened on the primary's failures must not block the fallback
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("Expected the fallback to answer, got %v", err)
+	}
+
+	// Once the primary is reachable again the probe switches back to it
+	primaryDown.Store(false)
+	deadline := time.Now().Add(5 * time.Second)
+	for client.ActiveURL() != primary.URL {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting to fall back to the primary, active %s", client.ActiveURL())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientFailoverIgnoresClientErrors(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer primary.Close()
+
+	client := NewClient("failover-4xx", Endpoint{
+		BaseURL:  primary.URL,
+		Failover: FailoverPolicy{FallbackURLs: []string{"http://127.0.0.1:1"}, FailureThreshold: 1},
+	}, nil)
+
+	if _, err := client.GetBackend(context.Background(), "missing", ""); err == nil {
+		t.Fatal("Expected not found")
+	}
+	if client.ActiveURL() != primary.URL {
+		t.Errorf("Expected a reachable primary to stay active, got %s", client.ActiveURL())
+	}
+}
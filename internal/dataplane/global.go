@@ -0,0 +1,104 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Global is the global section. Only the files loaded with lua-load are interpreted; every other setting is
+// kept as read, so that replacing the section leaves them unchanged.
+type Global struct {
+	LuaLoads []string // Files loaded with lua-load, in order
+	settings map[string]json.RawMessage
+}
+
+// luaLoad is an entry of the lua-load list of the global section
+type luaLoad struct {
+	File string `json:"file"`
+}
+
+// UnmarshalJSON reads the global section, keeping the settings that are not interpreted
+func (g *Global) UnmarshalJSON(data []byte) error {
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	var options struct {
+		Loads []luaLoad `json:"loads"`
+	}
+	if raw, ok := settings["lua_options"]; ok {
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return err
+		}
+	}
+
+	g.settings = settings
+	g.LuaLoads = nil
+	for _, load := range options.Loads {
+		g.LuaLoads = append(g.LuaLoads, load.File)
+	}
+	return nil
+}
+
+// MarshalJSON writes the global section with the Lua loads merged into the other Lua options
+func (g Global) MarshalJSON() ([]byte, error) {
+	settings := make(map[string]json.RawMessage, len(g.settings)+1)
+	for key, value := range g.settings {
+		settings[key] = value
+	}
+
+	options := map[string]json.RawMessage{}
+	if raw, ok := settings["lua_options"]; ok {
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return nil, err
+		}
+	}
+	delete(options, "loads")
+	if len(g.LuaLoads) > 0 {
+		loads := make([]luaLoad, 0, len(g.LuaLoads))
+		for _, file := range g.LuaLoads {
+			loads = append(loads, luaLoad{File: file})
+		}
+		raw, err := json.Marshal(loads)
+		if err != nil {
+			return nil, err
+		}
+		options["loads"] = raw
+	}
+
+	delete(settings, "lua_options")
+	if len(options) > 0 {
+		raw, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+		settings["lua_options"] = raw
+	}
+	return json.Marshal(settings)
+}
+
+// GetGlobal retrieves the global section
+func (a api) GetGlobal(ctx context.Context, transactionID string) (*Global, error) {
+	return requestObject[Global](ctx, a, http.MethodGet, resourcePath("global"), transactionID, nil)
+}
+
+// ReplaceGlobal replaces the global section
+func (a api) ReplaceGlobal(ctx context.Context, global Global, transactionID string) (*Global, error) {
+	return requestObject[Global](ctx, a, http.MethodPut, resourcePath("global"), transactionID, global)
+}
+
+// GetGlobal retrieves the global section
+func (c *Client) GetGlobal(ctx context.Context, transactionId string) (*Global, error) {
+	return call(ctx, c, "global.get", func(a api) (*Global, error) {
+		return a.GetGlobal(ctx, transactionId)
+	})
+}
+
+// ReplaceGlobal replaces the global section
+func (c *Client) ReplaceGlobal(ctx context.Context, global Global, transactionId string) (*Global, error) {
+	return call(ctx, c, "global.replace", func(a api) (*Global, error) {
+		return a.ReplaceGlobal(ctx, global, transactionId)
+	})
+}
@@ -0,0 +1,65 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGlobalKeepsSettings(t *testing.T) {
+	var written map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != configurationPath+"/global" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPut {
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &written); err != nil {
+				t.Errorf("Invalid body %s: %v", data, err)
+			}
+			_, _ = w.Write(data)
+			return
+		}
+		_, _ = w.Write([]byte(`{"daemon": true, "maxconn": 4096, "lua_options": {"prepend_path": [{"path": "/usr/share/lua/?.lua"}], "loads": [{"file": "/etc/haproxy/general/a.v1.lua"}]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL}, nil)
+	ctx := context.Background()
+
+	global, err := client.GetGlobal(ctx, "")
+	if err != nil {
+		t.Fatalf("GetGlobal failed: %v", err)
+	}
+	if len(global.LuaLoads) != 1 || global.LuaLoads[0] != "/etc/haproxy/general/a.v1.lua" {
+		t.Fatalf("Unexpected Lua loads %v", global.LuaLoads)
+	}
+
+	global.LuaLoads = append(global.LuaLoads, "/etc/haproxy/general/b.v2.lua")
+	replaced, err := client.ReplaceGlobal(ctx, *global, "txn")
+	if err != nil {
+		t.Fatalf("ReplaceGlobal failed: %v", err)
+	}
+	if len(replaced.LuaLoads) != 2 {
+		t.Errorf("Unexpected Lua loads after replacing %v", replaced.LuaLoads)
+	}
+	options, _ := written["lua_options"].(map[string]interface{})
+	if written["daemon"] != true || written["maxconn"] != float64(4096) || options["prepend_path"] == nil {
+		t.Errorf("Expected the other settings to be kept, got %v", written)
+	}
+	if loads, _ := options["loads"].([]interface{}); len(loads) != 2 {
+		t.Errorf("Unexpected loads %v", options["loads"])
+	}
+
+	// Without loads and other Lua options, lua_options is left out
+	global.LuaLoads = nil
+	global.settings["lua_options"] = json.RawMessage(`{"loads": [{"file": "x"}]}`)
+	data, err := json.Marshal(global)
+	if err != nil || string(data) != `{"daemon":true,"maxconn":4096}` {
+		t.Errorf("Unexpected global section %s: %v", data, err)
+	}
+}
@@ -0,0 +1,77 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+)
+
+// Program is a program section: an external process, such as an agent or exporter, run and restarted by the
+// HAProxy master
+type Program struct {
+	Name          *string `json:"name,omitempty"`
+	Command       string  `json:"command"`
+	User          string  `json:"user,omitempty"`
+	Group         string  `json:"group,omitempty"`
+	StartOnReload string  `json:"start-on-reload,omitempty"` // "enabled" or "disabled"; HAProxy defaults to enabled
+}
+
+// AddProgram creates a program section
+func (a api) AddProgram(ctx context.Context, program Program, transactionID string) (*Program, error) {
+	return requestObject[Program](ctx, a, http.MethodPost, resourcePath("programs"), transactionID, program)
+}
+
+// GetProgram retrieves a program section by name
+func (a api) GetProgram(ctx context.Context, name string, transactionID string) (*Program, error) {
+	return requestObject[Program](ctx, a, http.MethodGet, resourcePath("programs", name), transactionID, nil)
+}
+
+// ListPrograms lists the program sections
+func (a api) ListPrograms(ctx context.Context, transactionID string) ([]Program, error) {
+	return requestList[Program](ctx, a, resourcePath("programs"), transactionID)
+}
+
+// ReplaceProgram replaces a program section
+func (a api) ReplaceProgram(ctx context.Context, name string, program Program, transactionID string) (*Program, error) {
+	return requestObject[Program](ctx, a, http.MethodPut, resourcePath("programs", name), transactionID, program)
+}
+
+// DeleteProgram deletes a program section
+func (a api) DeleteProgram(ctx context.Context, name string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("programs", name), transactionID, nil)
+	return err
+}
+
+// AddProgram creates a program section
+func (c *Client) AddProgram(ctx context.Context, program Program, transactionId string) (*Program, error) {
+	return call(ctx, c, "programs.add", func(a api) (*Program, error) {
+		return a.AddProgram(ctx, program, transactionId)
+	})
+}
+
+// GetProgram retrieves a program section by name
+func (c *Client) GetProgram(ctx context.Context, name string, transactionId string) (*Program, error) {
+	return call(ctx, c, "programs.get", func(a api) (*Program, error) {
+		return a.GetProgram(ctx, name, transactionId)
+	})
+}
+
+// ListPrograms lists the program sections
+func (c *Client) ListPrograms(ctx context.Context, transactionId string) ([]Program, error) {
+	return call(ctx, c, "programs.list", func(a api) ([]Program, error) {
+		return a.ListPrograms(ctx, transactionId)
+	})
+}
+
+// ReplaceProgram replaces a program section
+func (c *Client) ReplaceProgram(ctx context.Context, name string, program Program, transactionId string) (*Program, error) {
+	return call(ctx, c, "programs.replace", func(a api) (*Program, error) {
+		return a.ReplaceProgram(ctx, name, program, transactionId)
+	})
+}
+
+// DeleteProgram deletes a program section
+func (c *Client) DeleteProgram(ctx context.Context, name string, transactionId string) error {
+	return callErr(ctx, c, "programs.delete", func(a api) error {
+		return a.DeleteProgram(ctx, name, transactionId)
+	})
+}
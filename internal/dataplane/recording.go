@@ -0,0 +1,279 @@
+package dataplane
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Interaction is a Data Plane API request and its response as recorded. Bodies are stored with credentials masked,
+// see logger.RedactJSON; request headers, including the authorization, are not stored.
+type Interaction struct {
+	Time        time.Time `json:"time"`
+	DurationMs  int64     `json:"duration_ms"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"` // Path and query, e.g. /v3/services/haproxy/configuration/backends?transaction_id=...
+	RequestBody string    `json:"request_body,omitempty"`
+	Status      int       `json:"status,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	Error       string    `json:"error,omitempty"` // Transport error instead of a response, e.g. connection refused
+}
+
+// RecordingPath returns the file the interactions of an instance are recorded to in dir
+func RecordingPath(dir, instance string) string {
+	return filepath.Join(dir, instance+".jsonl")
+}
+
+// Recorder appends every Data Plane API request and its response to a JSON lines file. Recordings of successive
+// runs are appended to each other, so a restart, e.g. after a crash, does not lose the requests leading up to it.
+type Recorder struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewRecorder opens the recording file at path, creating it and its directory if needed
+func NewRecorder(path string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	return &Recorder{file: file}, nil
+}
+
+// Wrap returns a transport sending requests through next and recording them
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, next: next}
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+// record appends an interaction to the file
+func (r *Recorder) record(interaction Interaction) {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, err := r.file.Write(append(data, '\n')); err != nil {
+		logger.GetLogger().Warn("Failed to record Data Plane API request",
+			zap.String("path", interaction.Path),
+			zap.Error(err))
+	}
+}
+
+// recordingTransport records the requests it sends
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// RoundTrip sends the request and records it with its response or transport error
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		_ = req.Body.Close()
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(payload))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(payload)), nil }
+	}
+
+	interaction := Interaction{
+		Time:        time.Now(),
+		Method:      req.Method,
+		Path:        req.URL.RequestURI(),
+		RequestBody: string(logger.RedactJSON(payload)),
+	}
+	res, err := t.next.RoundTrip(req)
+	interaction.DurationMs = time.Since(interaction.Time).Milliseconds()
+	if err != nil {
+		interaction.Error = err.Error()
+		t.recorder.record(interaction)
+		return nil, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		interaction.Error = err.Error()
+		t.recorder.record(interaction)
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	interaction.Status = res.StatusCode
+	interaction.ContentType = res.Header.Get("Content-Type")
+	interaction.Body = string(logger.RedactJSON(data))
+	t.recorder.record(interaction)
+	return res, nil
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (t *recordingTransport) CloseIdleConnections() {
+	if closer, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// Replayer answers Data Plane API requests from a recording instead of sending them. A request is answered with
+// the first interaction not yet replayed that has the same method, path and body, so a session replays in the
+// order it was recorded. Reads without such an interaction are answered like the last matching one; other
+// requests without a match fail.
+type Replayer struct {
+	mutex        sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewReplayer loads the recording at path
+func NewReplayer(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	var interactions []Interaction
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("invalid interaction on line %d of %s: %w", line, path, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return &Replayer{interactions: interactions, replayed: make([]bool, len(interactions))}, nil
+}
+
+// Wrap returns a transport answering from the recording; next is never used
+func (r *Replayer) Wrap(http.RoundTripper) http.RoundTripper {
+	return r
+}
+
+// Remaining returns the number of recorded interactions not replayed yet
+func (r *Replayer) Remaining() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	remaining := 0
+	for _, replayed := range r.replayed {
+		if !replayed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// RoundTrip answers the request with its recorded response or transport error
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := readBody(req)
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	path := req.URL.RequestURI()
+	body := string(logger.RedactJSON(payload))
+
+	interaction, ok := r.next(req.Method, path, body)
+	if !ok {
+		logger.GetLogger().Warn("No recorded Data Plane API response for request",
+			zap.String("method", req.Method),
+			zap.String("path", path))
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, path)
+	}
+	if interaction.Error != "" {
+		return nil, fmt.Errorf("recorded: %s", interaction.Error)
+	}
+
+	header := make(http.Header)
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(interaction.Status) + " " + http.StatusText(interaction.Status),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Body))),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
+
+// next returns the interaction answering a request and marks it replayed
+func (r *Replayer) next(method, path, body string) (Interaction, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	last := -1
+	for i, interaction := range r.interactions {
+		if interaction.Method != method || interaction.Path != path || interaction.RequestBody != body {
+			continue
+		}
+		if !r.replayed[i] {
+			r.replayed[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+	if last >= 0 && method == http.MethodGet {
+		return r.interactions[last], true
+	}
+	return Interaction{}, false
+}
+
+// readBody returns the body of a request, reading a copy if the request can provide one. Otherwise the body
+// itself is consumed and the caller has to send a new one.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body := req.Body
+	if req.GetBody != nil {
+		copied, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer copied.Close()
+		body = copied
+	}
+	return io.ReadAll(body)
+}
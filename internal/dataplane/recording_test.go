@@ -0,0 +1,94 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == transactionsPath:
+			_, _ = w.Write([]byte(`{"id": "txn-1", "status": "in_progress"}`))
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "app"}`))
+		case r.URL.Path == configurationPath+"/backends/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": 404, "message": "missing not found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"name": "app", "password": "secret"}`))
+		}
+	}))
+	path := RecordingPath(t.TempDir(), "default")
+
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	session := func(client *Client) {
+		t.Helper()
+		ctx := context.Background()
+		transaction, err := client.CreateTransaction(ctx, 1)
+		if err != nil || *transaction.Id != "txn-1" {
+			t.Fatalf("Unexpected transaction %v: %v", transaction, err)
+		}
+		name := "app"
+		if _, err := client.AddBackend(ctx, Backend{Backend: v3.Backend{Name: &name}}, *transaction.Id); err != nil {
+			t.Fatalf("AddBackend failed: %v", err)
+		}
+		if _, err := client.GetBackend(ctx, "missing", ""); !v3.IsNotFound(err) {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+		if backend, err := client.GetBackend(ctx, "app", ""); err != nil || *backend.Name != "app" {
+			t.Errorf("Unexpected backend %v: %v", backend, err)
+		}
+	}
+	session(NewClient("default", Endpoint{BaseURL: srv.URL, Credential: "YWRtaW46c2VjcmV0", WrapTransport: recorder.Wrap}, nil))
+	srv.Close()
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the recording: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Errorf("Expected 4 recorded interactions, got %d", lines)
+	}
+	if strings.Contains(string(data), "YWRtaW46c2VjcmV0") || strings.Contains(string(data), "secret") {
+		t.Errorf("Expected credentials to be left out of the recording, got %s", data)
+	}
+
+	// The session replays without the Data Plane API, from another base URL
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	client := NewClient("default", Endpoint{BaseURL: "http://replay.invalid:5555", WrapTransport: replayer.Wrap}, nil)
+	session(client)
+	if remaining := replayer.Remaining(); remaining != 0 {
+		t.Errorf("Expected the whole recording to be replayed, %d interactions remain", remaining)
+	}
+
+	// Reads can be repeated; writes that were not recorded fail
+	if _, err := client.GetBackend(context.Background(), "app", ""); err != nil {
+		t.Errorf("Expected a repeated read to be answered, got %v", err)
+	}
+	if err := client.DeleteBackend(context.Background(), "app", "txn-1"); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("Expected an unrecorded write to fail, got %v", err)
+	}
+
+	if _, err := NewReplayer(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("Expected a missing recording to fail")
+	}
+}
@@ -0,0 +1,106 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// RetryPolicy controls how a type of failed idempotent Data Plane API requests is retried.
+// The zero value disables retries.
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt
+	InitialBackoff time.Duration // Delay before the first retry, doubled for each further retry
+	MaxBackoff     time.Duration // Upper bound of the delay between retries
+	// Wait a random duration between half and all of the backoff, so that clients failing together do not
+	// retry in lockstep, e.g. while HAProxy reloads
+	Jitter bool
+}
+
+// RetryPolicies holds the retry policy of each type of idempotent request. Other requests, such as creating
+// transactions and resources with POST, and transaction commits are never retried.
+type RetryPolicies struct {
+	Read  RetryPolicy // GET requests
+	Write RetryPolicy // PUT and DELETE requests
+}
+
+// forMethod returns the retry policy of requests with the given method
+func (p RetryPolicies) forMethod(method string) RetryPolicy {
+	switch method {
+	case http.MethodGet:
+		return p.Read
+	case http.MethodPut, http.MethodDelete:
+		return p.Write
+	default:
+		return RetryPolicy{}
+	}
+}
+
+// backoff returns the delay before the given retry, counted from zero
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 0; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter && delay > 1 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+	return delay
+}
+
+// IsTransient reports whether err means that the Data Plane API was temporarily unavailable, e.g. a 502 or a
+// timed out attempt while HAProxy reloads, so that the call may succeed if made again later
+func IsTransient(err error) bool {
+	return isUnreachable(err)
+}
+
+// isUnreachable reports whether err means that no usable response was received: a transport error, including
+// a timed out attempt, or a response signalling a temporarily unavailable upstream. Only these are retried.
+func isUnreachable(err error) bool {
+	var unknown *v3.UnknownError
+	if errors.As(err, &unknown) {
+		switch unknown.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var transport *transportError
+	return errors.As(err, &transport)
+}
+
+// transportError is returned when no response was received from the Data Plane API
+type transportError struct {
+	err error
+}
+
+// Error returns the message of the underlying error
+func (e *transportError) Error() string {
+	return "failed to call Data Plane API: " + e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// sleep waits for the given duration or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
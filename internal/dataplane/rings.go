@@ -0,0 +1,214 @@
+package dataplane
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// ErrRuntimeSocketUnavailable is returned for reading rings if no runtime socket is configured
+var ErrRuntimeSocketUnavailable = errors.New("ring contents unavailable without runtime_socket")
+
+// Ring is a ring section: a buffer in memory keeping the latest log lines or traces sent to it
+type Ring struct {
+	Name        *string `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Format      string  `json:"format,omitempty"` // e.g. "raw", "rfc3164", "rfc5424", "iso" or "timed"
+	Maxlen      *int    `json:"maxlen,omitempty"` // Longest line kept, in bytes
+	Size        *int    `json:"size,omitempty"`   // Size of the buffer, in bytes
+}
+
+// LogTarget is a log line of a frontend or backend, addressed by position like rules
+type LogTarget struct {
+	Address  string `json:"address"` // e.g. "ring@debug" or "/dev/log"
+	Facility string `json:"facility,omitempty"`
+	Level    string `json:"level,omitempty"`
+	Minlevel string `json:"minlevel,omitempty"`
+	Format   string `json:"format,omitempty"`
+	Length   *int   `json:"length,omitempty"`
+}
+
+// logTargetPath returns the path of the log targets of a frontend or backend, or of one of them at index
+func logTargetPath(parentType, parent string, index ...int) string {
+	segments := []string{parentType + "s", parent, "log_targets"}
+	for _, i := range index {
+		segments = append(segments, strconv.Itoa(i))
+	}
+	return resourcePath(segments...)
+}
+
+// AddRing creates a ring section
+func (a api) AddRing(ctx context.Context, ring Ring, transactionID string) (*Ring, error) {
+	return requestObject[Ring](ctx, a, http.MethodPost, resourcePath("rings"), transactionID, ring)
+}
+
+// GetRing retrieves a ring section by name
+func (a api) GetRing(ctx context.Context, name string, transactionID string) (*Ring, error) {
+	return requestObject[Ring](ctx, a, http.MethodGet, resourcePath("rings", name), transactionID, nil)
+}
+
+// ListRings lists the ring sections
+func (a api) ListRings(ctx context.Context, transactionID string) ([]Ring, error) {
+	return requestList[Ring](ctx, a, resourcePath("rings"), transactionID)
+}
+
+// ReplaceRing replaces a ring section
+func (a api) ReplaceRing(ctx context.Context, name string, ring Ring, transactionID string) (*Ring, error) {
+	return requestObject[Ring](ctx, a, http.MethodPut, resourcePath("rings", name), transactionID, ring)
+}
+
+// DeleteRing deletes a ring section
+func (a api) DeleteRing(ctx context.Context, name string, transactionID string) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("rings", name), transactionID, nil)
+	return err
+}
+
+// ListLogTargets lists the log targets of a frontend or backend in order
+func (a api) ListLogTargets(ctx context.Context, parentType, parent string, transactionID string) ([]LogTarget, error) {
+	return requestList[LogTarget](ctx, a, logTargetPath(parentType, parent), transactionID)
+}
+
+// AddLogTarget inserts a log target into a frontend or backend at the given position
+func (a api) AddLogTarget(ctx context.Context, parentType, parent string, transactionID string, index int, target LogTarget) (*LogTarget, error) {
+	return requestObject[LogTarget](ctx, a, http.MethodPost, logTargetPath(parentType, parent, index), transactionID, target)
+}
+
+// DeleteLogTarget deletes the log target of a frontend or backend at the given position
+func (a api) DeleteLogTarget(ctx context.Context, parentType, parent string, transactionID string, index int) error {
+	_, err := a.request(ctx, http.MethodDelete, logTargetPath(parentType, parent, index), transactionID, nil)
+	return err
+}
+
+// AddRing creates a ring section
+func (c *Client) AddRing(ctx context.Context, ring Ring, transactionId string) (*Ring, error) {
+	return call(ctx, c, "rings.add", func(a api) (*Ring, error) {
+		return a.AddRing(ctx, ring, transactionId)
+	})
+}
+
+// GetRing retrieves a ring section by name
+func (c *Client) GetRing(ctx context.Context, name string, transactionId string) (*Ring, error) {
+	return call(ctx, c, "rings.get", func(a api) (*Ring, error) {
+		return a.GetRing(ctx, name, transactionId)
+	})
+}
+
+// ListRings lists the ring sections
+func (c *Client) ListRings(ctx context.Context, transactionId string) ([]Ring, error) {
+	return call(ctx, c, "rings.list", func(a api) ([]Ring, error) {
+		return a.ListRings(ctx, transactionId)
+	})
+}
+
+// ReplaceRing replaces a ring section
+func (c *Client) ReplaceRing(ctx context.Context, name string, ring Ring, transactionId string) (*Ring, error) {
+	return call(ctx, c, "rings.replace", func(a api) (*Ring, error) {
+		return a.ReplaceRing(ctx, name, ring, transactionId)
+	})
+}
+
+// DeleteRing deletes a ring section
+func (c *Client) DeleteRing(ctx context.Context, name string, transactionId string) error {
+	return callErr(ctx, c, "rings.delete", func(a api) error {
+		return a.DeleteRing(ctx, name, transactionId)
+	})
+}
+
+// ListLogTargets lists the log targets of a frontend or backend in order
+func (c *Client) ListLogTargets(ctx context.Context, parentType, parent string, transactionId string) ([]LogTarget, error) {
+	return call(ctx, c, "log_targets.list", func(a api) ([]LogTarget, error) {
+		return a.ListLogTargets(ctx, parentType, parent, transactionId)
+	})
+}
+
+// AddLogTarget inserts a log target into a frontend or backend at the given position
+func (c *Client) AddLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int, target LogTarget) (*LogTarget, error) {
+	return call(ctx, c, "log_targets.add", func(a api) (*LogTarget, error) {
+		return a.AddLogTarget(ctx, parentType, parent, transactionId, index, target)
+	})
+}
+
+// DeleteLogTarget deletes the log target of a frontend or backend at the given position
+func (c *Client) DeleteLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int) error {
+	return callErr(ctx, c, "log_targets.delete", func(a api) error {
+		return a.DeleteLogTarget(ctx, parentType, parent, transactionId, index)
+	})
+}
+
+// WatchRing calls fn for every event in a ring of the running process, read through the runtime socket, since
+// the Data Plane API does not serve ring contents. With follow it waits for further events until ctx is done.
+func (c *Client) WatchRing(ctx context.Context, name string, follow bool, fn func(event string) error) error {
+	if c.runtimeSocket == "" {
+		return ErrRuntimeSocketUnavailable
+	}
+	return ReadRing(ctx, c.runtimeSocket, "", name, follow, fn)
+}
+
+// ReadRing sends "show events" for a ring to a HAProxy CLI socket and calls fn for every event. The socket is a
+// path of a unix socket or a TCP address; prefix is put before the command, e.g. "@1 " for the worker behind a
+// master CLI. Without follow the events in the ring are read and ReadRing returns; with follow it waits for
+// further events until ctx is done.
+func ReadRing(ctx context.Context, socket, prefix, name string, follow bool, fn func(event string) error) error {
+	network := "tcp"
+	if filepath.IsAbs(socket) {
+		network = "unix"
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, socket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to runtime socket: %w", err)
+	}
+	defer conn.Close()
+
+	// Closing the connection ends a blocked read once the caller is gone
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	command := prefix + "show events " + name
+	if follow {
+		command += " -w"
+	}
+	if _, err := fmt.Fprintf(conn, "%s\n", command); err != nil {
+		return fmt.Errorf("failed to send %q to runtime socket: %w", command, err)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	first := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first && strings.HasPrefix(line, "No such event sink") {
+			return &v3.NotFoundError{Message: fmt.Sprintf("ring %s not found", name)}
+		}
+		first = false
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ring %s: %w", name, err)
+	}
+	return nil
+}
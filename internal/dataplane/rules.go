@@ -0,0 +1,233 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// ACL is a named condition of a frontend. Several ACLs with the same name match if any of them does.
+type ACL struct {
+	Name      string `json:"acl_name"`
+	Criterion string `json:"criterion"` // Sample fetch, e.g. "req.ssl_sni"
+	Value     string `json:"value,omitempty"`
+}
+
+// BackendSwitchingRule is a use_backend rule of a frontend
+type BackendSwitchingRule struct {
+	Name     string `json:"name"` // Backend to use
+	Cond     string `json:"cond,omitempty"`
+	CondTest string `json:"cond_test,omitempty"`
+}
+
+// HTTPRequestRule is an http-request rule of a frontend
+type HTTPRequestRule struct {
+	Type                string `json:"type"` // e.g. "redirect", "track-sc", "deny", "tarpit" or "lua"
+	RedirType           string `json:"redir_type,omitempty"`
+	RedirValue          string `json:"redir_value,omitempty"`
+	RedirCode           *int   `json:"redir_code,omitempty"`
+	DenyStatus          *int   `json:"deny_status,omitempty"`
+	TrackScKey          string `json:"track_sc_key,omitempty"`
+	TrackScTable        string `json:"track_sc_table,omitempty"`
+	TrackScStickCounter *int   `json:"track_sc_stick_counter,omitempty"`
+	LuaAction           string `json:"lua_action,omitempty"` // Action registered by a Lua script, without "lua."
+	LuaParams           string `json:"lua_params,omitempty"`
+	Cond                string `json:"cond,omitempty"` // "if" or "unless"
+	CondTest            string `json:"cond_test,omitempty"`
+}
+
+// TCPRequestRule is a tcp-request rule of a frontend
+type TCPRequestRule struct {
+	Type              string `json:"type"`              // "inspect-delay", "content" or "connection"
+	Action            string `json:"action,omitempty"`  // e.g. "accept", "reject", "track-sc" or "lua"
+	Timeout           *int   `json:"timeout,omitempty"` // Milliseconds, for inspect-delay
+	TrackKey          string `json:"track_key,omitempty"`
+	TrackTable        string `json:"track_table,omitempty"`
+	TrackStickCounter *int   `json:"track_stick_counter,omitempty"`
+	LuaAction         string `json:"lua_action,omitempty"` // Action registered by a Lua script, without "lua."
+	LuaParams         string `json:"lua_params,omitempty"`
+	Cond              string `json:"cond,omitempty"`
+	CondTest          string `json:"cond_test,omitempty"`
+}
+
+// Rules are lists within a frontend addressed by position. Inserting or deleting one shifts the positions of
+// those after it.
+
+// listRules lists the rules of a frontend in order
+func listRules[T any](ctx context.Context, a api, frontend, collection, transactionID string) ([]T, error) {
+	return requestList[T](ctx, a, resourcePath("frontends", frontend, collection), transactionID)
+}
+
+// addRule inserts a rule into a frontend at the given position
+func addRule[T any](ctx context.Context, a api, frontend, collection, transactionID string, index int, rule T) (*T, error) {
+	path := resourcePath("frontends", frontend, collection, strconv.Itoa(index))
+	return requestObject[T](ctx, a, http.MethodPost, path, transactionID, rule)
+}
+
+// replaceRule replaces the rule of a frontend at the given position
+func replaceRule[T any](ctx context.Context, a api, frontend, collection, transactionID string, index int, rule T) (*T, error) {
+	path := resourcePath("frontends", frontend, collection, strconv.Itoa(index))
+	return requestObject[T](ctx, a, http.MethodPut, path, transactionID, rule)
+}
+
+// deleteRule deletes the rule of a frontend at the given position
+func (a api) deleteRule(ctx context.Context, frontend, collection, transactionID string, index int) error {
+	_, err := a.request(ctx, http.MethodDelete, resourcePath("frontends", frontend, collection, strconv.Itoa(index)), transactionID, nil)
+	return err
+}
+
+// ListACLs lists the ACLs of a frontend in order
+func (a api) ListACLs(ctx context.Context, frontend string, transactionID string) ([]ACL, error) {
+	return listRules[ACL](ctx, a, frontend, "acls", transactionID)
+}
+
+// AddACL inserts an ACL into a frontend at the given position
+func (a api) AddACL(ctx context.Context, frontend string, transactionID string, index int, acl ACL) (*ACL, error) {
+	return addRule(ctx, a, frontend, "acls", transactionID, index, acl)
+}
+
+// DeleteACL deletes the ACL of a frontend at the given position
+func (a api) DeleteACL(ctx context.Context, frontend string, transactionID string, index int) error {
+	return a.deleteRule(ctx, frontend, "acls", transactionID, index)
+}
+
+// ListBackendSwitchingRules lists the use_backend rules of a frontend in order
+func (a api) ListBackendSwitchingRules(ctx context.Context, frontend string, transactionID string) ([]BackendSwitchingRule, error) {
+	return listRules[BackendSwitchingRule](ctx, a, frontend, "backend_switching_rules", transactionID)
+}
+
+// AddBackendSwitchingRule inserts a use_backend rule into a frontend at the given position
+func (a api) AddBackendSwitchingRule(ctx context.Context, frontend string, transactionID string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	return addRule(ctx, a, frontend, "backend_switching_rules", transactionID, index, rule)
+}
+
+// ReplaceBackendSwitchingRule replaces the use_backend rule of a frontend at the given position
+func (a api) ReplaceBackendSwitchingRule(ctx context.Context, frontend string, transactionID string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	return replaceRule(ctx, a, frontend, "backend_switching_rules", transactionID, index, rule)
+}
+
+// DeleteBackendSwitchingRule deletes the use_backend rule of a frontend at the given position
+func (a api) DeleteBackendSwitchingRule(ctx context.Context, frontend string, transactionID string, index int) error {
+	return a.deleteRule(ctx, frontend, "backend_switching_rules", transactionID, index)
+}
+
+// ListHTTPRequestRules lists the http-request rules of a frontend in order
+func (a api) ListHTTPRequestRules(ctx context.Context, frontend string, transactionID string) ([]HTTPRequestRule, error) {
+	return listRules[HTTPRequestRule](ctx, a, frontend, "http_request_rules", transactionID)
+}
+
+// AddHTTPRequestRule inserts an http-request rule into a frontend at the given position
+func (a api) AddHTTPRequestRule(ctx context.Context, frontend string, transactionID string, index int, rule HTTPRequestRule) (*HTTPRequestRule, error) {
+	return addRule(ctx, a, frontend, "http_request_rules", transactionID, index, rule)
+}
+
+// DeleteHTTPRequestRule deletes the http-request rule of a frontend at the given position
+func (a api) DeleteHTTPRequestRule(ctx context.Context, frontend string, transactionID string, index int) error {
+	return a.deleteRule(ctx, frontend, "http_request_rules", transactionID, index)
+}
+
+// ListTCPRequestRules lists the tcp-request rules of a frontend in order
+func (a api) ListTCPRequestRules(ctx context.Context, frontend string, transactionID string) ([]TCPRequestRule, error) {
+	return listRules[TCPRequestRule](ctx, a, frontend, "tcp_request_rules", transactionID)
+}
+
+// AddTCPRequestRule inserts a tcp-request rule into a frontend at the given position
+func (a api) AddTCPRequestRule(ctx context.Context, frontend string, transactionID string, index int, rule TCPRequestRule) (*TCPRequestRule, error) {
+	return addRule(ctx, a, frontend, "tcp_request_rules", transactionID, index, rule)
+}
+
+// DeleteTCPRequestRule deletes the tcp-request rule of a frontend at the given position
+func (a api) DeleteTCPRequestRule(ctx context.Context, frontend string, transactionID string, index int) error {
+	return a.deleteRule(ctx, frontend, "tcp_request_rules", transactionID, index)
+}
+
+// ListACLs lists the ACLs of a frontend in order
+func (c *Client) ListACLs(ctx context.Context, frontend string, transactionId string) ([]ACL, error) {
+	return call(ctx, c, "acls.list", func(a api) ([]ACL, error) {
+		return a.ListACLs(ctx, frontend, transactionId)
+	})
+}
+
+// AddACL inserts an ACL into a frontend at the given position
+func (c *Client) AddACL(ctx context.Context, frontend string, transactionId string, index int, acl ACL) (*ACL, error) {
+	return call(ctx, c, "acls.add", func(a api) (*ACL, error) {
+		return a.AddACL(ctx, frontend, transactionId, index, acl)
+	})
+}
+
+// DeleteACL deletes the ACL of a frontend at the given position
+func (c *Client) DeleteACL(ctx context.Context, frontend string, transactionId string, index int) error {
+	return callErr(ctx, c, "acls.delete", func(a api) error {
+		return a.DeleteACL(ctx, frontend, transactionId, index)
+	})
+}
+
+// ListBackendSwitchingRules lists the use_backend rules of a frontend in order
+func (c *Client) ListBackendSwitchingRules(ctx context.Context, frontend string, transactionId string) ([]BackendSwitchingRule, error) {
+	return call(ctx, c, "backend_switching_rules.list", func(a api) ([]BackendSwitchingRule, error) {
+		return a.ListBackendSwitchingRules(ctx, frontend, transactionId)
+	})
+}
+
+// AddBackendSwitchingRule inserts a use_backend rule into a frontend at the given position
+func (c *Client) AddBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	return call(ctx, c, "backend_switching_rules.add", func(a api) (*BackendSwitchingRule, error) {
+		return a.AddBackendSwitchingRule(ctx, frontend, transactionId, index, rule)
+	})
+}
+
+// ReplaceBackendSwitchingRule replaces the use_backend rule of a frontend at the given position
+func (c *Client) ReplaceBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule BackendSwitchingRule) (*BackendSwitchingRule, error) {
+	return call(ctx, c, "backend_switching_rules.replace", func(a api) (*BackendSwitchingRule, error) {
+		return a.ReplaceBackendSwitchingRule(ctx, frontend, transactionId, index, rule)
+	})
+}
+
+// DeleteBackendSwitchingRule deletes the use_backend rule of a frontend at the given position
+func (c *Client) DeleteBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return callErr(ctx, c, "backend_switching_rules.delete", func(a api) error {
+		return a.DeleteBackendSwitchingRule(ctx, frontend, transactionId, index)
+	})
+}
+
+// ListHTTPRequestRules lists the http-request rules of a frontend in order
+func (c *Client) ListHTTPRequestRules(ctx context.Context, frontend string, transactionId string) ([]HTTPRequestRule, error) {
+	return call(ctx, c, "http_request_rules.list", func(a api) ([]HTTPRequestRule, error) {
+		return a.ListHTTPRequestRules(ctx, frontend, transactionId)
+	})
+}
+
+// AddHTTPRequestRule inserts an http-request rule into a frontend at the given position
+func (c *Client) AddHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule HTTPRequestRule) (*HTTPRequestRule, error) {
+	return call(ctx, c, "http_request_rules.add", func(a api) (*HTTPRequestRule, error) {
+		return a.AddHTTPRequestRule(ctx, frontend, transactionId, index, rule)
+	})
+}
+
+// DeleteHTTPRequestRule deletes the http-request rule of a frontend at the given position
+func (c *Client) DeleteHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return callErr(ctx, c, "http_request_rules.delete", func(a api) error {
+		return a.DeleteHTTPRequestRule(ctx, frontend, transactionId, index)
+	})
+}
+
+// ListTCPRequestRules lists the tcp-request rules of a frontend in order
+func (c *Client) ListTCPRequestRules(ctx context.Context, frontend string, transactionId string) ([]TCPRequestRule, error) {
+	return call(ctx, c, "tcp_request_rules.list", func(a api) ([]TCPRequestRule, error) {
+		return a.ListTCPRequestRules(ctx, frontend, transactionId)
+	})
+}
+
+// AddTCPRequestRule inserts a tcp-request rule into a frontend at the given position
+func (c *Client) AddTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule TCPRequestRule) (*TCPRequestRule, error) {
+	return call(ctx, c, "tcp_request_rules.add", func(a api) (*TCPRequestRule, error) {
+		return a.AddTCPRequestRule(ctx, frontend, transactionId, index, rule)
+	})
+}
+
+// DeleteTCPRequestRule deletes the tcp-request rule of a frontend at the given position
+func (c *Client) DeleteTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return callErr(ctx, c, "tcp_request_rules.delete", func(a api) error {
+		return a.DeleteTCPRequestRule(ctx, frontend, transactionId, index)
+	})
+}
@@ -0,0 +1,86 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// runtimePath is the prefix of the runtime endpoints, which change the running process without a reload
+const runtimePath = "/v3/services/haproxy/runtime"
+
+// Administrative states of a server in the running process
+const (
+	AdminStateReady = "ready"
+	AdminStateDrain = "drain"
+	AdminStateMaint = "maint"
+)
+
+// RuntimeServer is the state of a server in the running process
+type RuntimeServer struct {
+	Name             string `json:"name,omitempty"`
+	Address          string `json:"address,omitempty"`
+	Port             *int   `json:"port,omitempty"`
+	AdminState       string `json:"admin_state,omitempty"`       // "ready", "drain" or "maint"
+	OperationalState string `json:"operational_state,omitempty"` // "up", "down" or "stopping"
+}
+
+// ProxyStats are the statistics of a frontend, backend or server
+type ProxyStats struct {
+	Type        string      `json:"type"` // "frontend", "backend" or "server"
+	Name        string      `json:"name"`
+	BackendName string      `json:"backend_name,omitempty"` // Backend of a server
+	Stats       StatsValues `json:"stats"`
+}
+
+// StatsValues are the counters of the HAProxy stats socket used by the configurator
+type StatsValues struct {
+	Status      string `json:"status,omitempty"`
+	Scur        int64  `json:"scur,omitempty"` // Current sessions
+	Smax        int64  `json:"smax,omitempty"` // Maximum sessions
+	Stot        int64  `json:"stot,omitempty"` // Total sessions
+	Rate        int64  `json:"rate,omitempty"` // Sessions per second over the last second
+	Bin         int64  `json:"bin,omitempty"`
+	Bout        int64  `json:"bout,omitempty"`
+	ReqTot      int64  `json:"req_tot,omitempty"`  // HTTP requests
+	Hrsp5xx     int64  `json:"hrsp_5xx,omitempty"` // HTTP responses with a 5xx status
+	Econ        int64  `json:"econ,omitempty"`     // Failed connection attempts to a server
+	Eresp       int64  `json:"eresp,omitempty"`    // Failed responses, e.g. closed connections
+	CheckStatus string `json:"check_status,omitempty"`
+}
+
+// nativeStats is the stats response of one HAProxy process
+type nativeStats struct {
+	RuntimeAPI string       `json:"runtimeAPI"`
+	Stats      []ProxyStats `json:"stats"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// GetStats returns the statistics of all frontends, backends and servers
+func (a api) GetStats(ctx context.Context) ([]ProxyStats, error) {
+	processes, err := requestList[nativeStats](ctx, a, "/v3/services/haproxy/stats/native", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []ProxyStats
+	for _, process := range processes {
+		if process.Error != "" {
+			return nil, &v3.UnknownError{Message: process.Error, StatusCode: http.StatusInternalServerError}
+		}
+		stats = append(stats, process.Stats...)
+	}
+	return stats, nil
+}
+
+// SetServerAdminState changes the administrative state of a server in the running process
+func (a api) SetServerAdminState(ctx context.Context, backend, name, state string) (*RuntimeServer, error) {
+	return requestObject[RuntimeServer](ctx, a, http.MethodPut, runtimePath+"/backends/"+url.PathEscape(backend)+"/servers/"+url.PathEscape(name), "", RuntimeServer{AdminState: state})
+}
+
+// ListRuntimeServers returns the state of the servers of a backend in the running process
+func (a api) ListRuntimeServers(ctx context.Context, backend string) ([]RuntimeServer, error) {
+	return requestList[RuntimeServer](ctx, a, runtimePath+"/backends/"+url.PathEscape(backend)+"/servers", "")
+}
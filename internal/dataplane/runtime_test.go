@@ -0,0 +1,59 @@
+package dataplane
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRuntimeRequests(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.EscapedPath(), string(body)
+
+		switch r.URL.Path {
+		case "/v3/services/haproxy/stats/native":
+			_, _ = w.Write([]byte(`[{"runtimeAPI": "/var/run/haproxy.sock", "stats": [
+				{"type": "frontend", "name": "web", "stats": {"status": "OPEN", "scur": 3}},
+				{"type": "server", "name": "app1", "backend_name": "app", "stats": {"status": "UP", "scur": 2, "stot": 40, "check_status": "L4OK"}}
+			]}]`))
+		case "/v3/services/haproxy/runtime/backends/app/servers":
+			_, _ = w.Write([]byte(`[{"name": "app1", "admin_state": "maint", "operational_state": "down"}, {"name": "app2", "admin_state": "ready"}]`))
+		default:
+			_, _ = w.Write([]byte(`{"name": "app1", "admin_state": "drain", "operational_state": "up"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL}, nil)
+
+	stats, err := client.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if len(stats) != 2 || stats[1].BackendName != "app" || stats[1].Stats.Scur != 2 || stats[1].Stats.CheckStatus != "L4OK" {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	server, err := client.SetServerAdminState(context.Background(), "app pool", "app1", AdminStateDrain)
+	if err != nil {
+		t.Fatalf("SetServerAdminState failed: %v", err)
+	}
+	if gotMethod != http.MethodPut || gotPath != "/v3/services/haproxy/runtime/backends/app%20pool/servers/app1" || gotBody != `{"admin_state":"drain"}` {
+		t.Errorf("Unexpected request %s %s %s", gotMethod, gotPath, gotBody)
+	}
+	if server.AdminState != AdminStateDrain || server.OperationalState != "up" {
+		t.Errorf("Unexpected server %+v", server)
+	}
+
+	servers, err := client.ListRuntimeServers(context.Background(), "app")
+	if err != nil {
+		t.Fatalf("ListRuntimeServers failed: %v", err)
+	}
+	if len(servers) != 2 || servers[0].AdminState != AdminStateMaint || servers[1].Name != "app2" {
+		t.Errorf("Unexpected runtime servers %+v", servers)
+	}
+}
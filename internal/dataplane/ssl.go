@@ -0,0 +1,99 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// certificatesPath is the collection of SSL certificates in the storage of the Data Plane API. Certificates
+// are stored immediately; they are not part of transactions.
+const certificatesPath = "/v3/services/haproxy/storage/ssl_certificates"
+
+// Certificate is an SSL certificate in the storage of the Data Plane API
+type Certificate struct {
+	StorageName string `json:"storage_name,omitempty"`
+	File        string `json:"file,omitempty"` // Path on the HAProxy host, as referenced by binds
+	Description string `json:"description,omitempty"`
+}
+
+// AddCertificate stores a certificate, failing with a conflict if one with the same name exists
+func (a api) AddCertificate(ctx context.Context, name, pem string) (*Certificate, error) {
+	if a.dryRun {
+		return simulateCertificate(http.MethodPost, name), nil
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file_upload", name)
+	if err == nil {
+		_, err = file.Write([]byte(pem))
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		return nil, &v3.InternalError{Message: err.Error()}
+	}
+
+	data, err := a.send(ctx, http.MethodPost, strings.TrimRight(a.baseURL, "/")+certificatesPath, form.FormDataContentType(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertificate(data)
+}
+
+// ReplaceCertificate replaces the content of a stored certificate
+func (a api) ReplaceCertificate(ctx context.Context, name, pem string) (*Certificate, error) {
+	if a.dryRun {
+		return simulateCertificate(http.MethodPut, name), nil
+	}
+
+	requestURL := strings.TrimRight(a.baseURL, "/") + certificatesPath + "/" + url.PathEscape(name)
+	data, err := a.send(ctx, http.MethodPut, requestURL, "text/plain", []byte(pem))
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertificate(data)
+}
+
+// GetCertificate retrieves a stored certificate by name
+func (a api) GetCertificate(ctx context.Context, name string) (*Certificate, error) {
+	return requestObject[Certificate](ctx, a, http.MethodGet, certificatesPath+"/"+url.PathEscape(name), "", nil)
+}
+
+// decodeCertificate decodes a certificate response
+func decodeCertificate(data []byte) (*Certificate, error) {
+	var certificate Certificate
+	if err := json.Unmarshal(data, &certificate); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &certificate, nil
+}
+
+// AddCertificate stores a certificate, failing with a conflict if one with the same name exists
+func (c *Client) AddCertificate(ctx context.Context, name, pem string) (*Certificate, error) {
+	return call(ctx, c, "ssl_certificates.add", func(a api) (*Certificate, error) {
+		return a.AddCertificate(ctx, name, pem)
+	})
+}
+
+// ReplaceCertificate replaces the content of a stored certificate
+func (c *Client) ReplaceCertificate(ctx context.Context, name, pem string) (*Certificate, error) {
+	return call(ctx, c, "ssl_certificates.replace", func(a api) (*Certificate, error) {
+		return a.ReplaceCertificate(ctx, name, pem)
+	})
+}
+
+// GetCertificate retrieves a stored certificate by name
+func (c *Client) GetCertificate(ctx context.Context, name string) (*Certificate, error) {
+	return call(ctx, c, "ssl_certificates.get", func(a api) (*Certificate, error) {
+		return a.GetCertificate(ctx, name)
+	})
+}
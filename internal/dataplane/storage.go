@@ -0,0 +1,103 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// storagePath is the collection of general files, such as Lua scripts, in the storage of the Data Plane API.
+// Files are stored immediately; they are not part of transactions.
+const storagePath = "/v3/services/haproxy/storage/general"
+
+// StorageFile is a general file in the storage of the Data Plane API
+type StorageFile struct {
+	StorageName string `json:"storage_name,omitempty"`
+	File        string `json:"file,omitempty"` // Path on the HAProxy host, as referenced by lua-load
+	Description string `json:"description,omitempty"`
+	Size        *int   `json:"size,omitempty"`
+}
+
+// AddStorageFile stores a general file, failing with a conflict if one with the same name exists
+func (a api) AddStorageFile(ctx context.Context, name, content string) (*StorageFile, error) {
+	if a.dryRun {
+		return simulateStorageFile(http.MethodPost, name), nil
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file_upload", name)
+	if err == nil {
+		_, err = file.Write([]byte(content))
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		return nil, &v3.InternalError{Message: err.Error()}
+	}
+
+	data, err := a.send(ctx, http.MethodPost, strings.TrimRight(a.baseURL, "/")+storagePath, form.FormDataContentType(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	var stored StorageFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &stored, nil
+}
+
+// GetStorageFile returns the content of a general file
+func (a api) GetStorageFile(ctx context.Context, name string) (string, error) {
+	data, err := a.request(ctx, http.MethodGet, storagePath+"/"+url.PathEscape(name), "", nil)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ListStorageFiles lists the general files
+func (a api) ListStorageFiles(ctx context.Context) ([]StorageFile, error) {
+	return requestList[StorageFile](ctx, a, storagePath, "")
+}
+
+// DeleteStorageFile deletes a general file
+func (a api) DeleteStorageFile(ctx context.Context, name string) error {
+	_, err := a.request(ctx, http.MethodDelete, storagePath+"/"+url.PathEscape(name), "", nil)
+	return err
+}
+
+// AddStorageFile stores a general file, failing with a conflict if one with the same name exists
+func (c *Client) AddStorageFile(ctx context.Context, name, content string) (*StorageFile, error) {
+	return call(ctx, c, "storage.add", func(a api) (*StorageFile, error) {
+		return a.AddStorageFile(ctx, name, content)
+	})
+}
+
+// GetStorageFile returns the content of a general file
+func (c *Client) GetStorageFile(ctx context.Context, name string) (string, error) {
+	return call(ctx, c, "storage.get", func(a api) (string, error) {
+		return a.GetStorageFile(ctx, name)
+	})
+}
+
+// ListStorageFiles lists the general files
+func (c *Client) ListStorageFiles(ctx context.Context) ([]StorageFile, error) {
+	return call(ctx, c, "storage.list", func(a api) ([]StorageFile, error) {
+		return a.ListStorageFiles(ctx)
+	})
+}
+
+// DeleteStorageFile deletes a general file
+func (c *Client) DeleteStorageFile(ctx context.Context, name string) error {
+	return callErr(ctx, c, "storage.delete", func(a api) error {
+		return a.DeleteStorageFile(ctx, name)
+	})
+}
@@ -0,0 +1,72 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// EachBackend calls fn for every backend, decoding the list response one backend at a time while it is read, so
+// that large configurations are never held as a whole
+func (c *Client) EachBackend(ctx context.Context, transactionId string, fn func(Backend) error) error {
+	return each(ctx, c, "backends.list", resourcePath("backends"), transactionId, fn)
+}
+
+// EachServer calls fn for every server of a backend, decoding the list response one server at a time while it
+// is read
+func (c *Client) EachServer(ctx context.Context, backend string, transactionId string, fn func(Server) error) error {
+	return each(ctx, c, "servers.list", resourcePath("backends", backend, "servers"), transactionId, fn)
+}
+
+// each streams the list at path and calls fn for each of its items. An error of fn stops the iteration and is
+// returned as is; it says nothing about the health of the Data Plane API.
+func each[T any](ctx context.Context, c *Client, endpoint, path, transactionID string, fn func(T) error) error {
+	var stopped error
+	err := callErr(ctx, c, endpoint, func(a api) error {
+		err := a.stream(ctx, path, transactionID, func(body io.Reader) error {
+			return eachItem(body, func(item T) error {
+				stopped = fn(item)
+				return stopped
+			})
+		})
+		if stopped != nil {
+			return nil
+		}
+		return err
+	})
+	if stopped != nil {
+		return stopped
+	}
+	return err
+}
+
+// eachItem decodes the items of a JSON array one at a time and calls fn for each, stopping at the first error.
+// An empty body has no items.
+func eachItem[T any](body io.Reader, fn func(T) error) error {
+	decoder := json.NewDecoder(body)
+	if token, err := decoder.Token(); errors.Is(err, io.EOF) {
+		return nil
+	} else if err != nil {
+		return &v3.InvalidResponseError{Message: err.Error()}
+	} else if token == nil {
+		return nil
+	} else if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return &v3.InvalidResponseError{Message: "expected a list"}
+	}
+	for decoder.More() {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return &v3.InvalidResponseError{Message: err.Error()}
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+func TestEachItem(t *testing.T) {
+	var names []string
+	collect := func(server Server) error {
+		names = append(names, derefName(server.Name))
+		return nil
+	}
+
+	if err := eachItem(strings.NewReader(`[{"name": "a"}, {"name": "b"}]`), collect); err != nil {
+		t.Fatalf("eachItem failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("Expected a and b, got %v", names)
+	}
+
+	for _, empty := range []string{"", "null", "[]"} {
+		names = nil
+		if err := eachItem(strings.NewReader(empty), collect); err != nil || len(names) != 0 {
+			t.Errorf("Expected no items for %q, got %v: %v", empty, names, err)
+		}
+	}
+
+	var invalid *v3.InvalidResponseError
+	if err := eachItem(strings.NewReader(`{"name": "a"}`), collect); !errors.As(err, &invalid) {
+		t.Errorf("Expected an invalid response error for an object, got %v", err)
+	}
+
+	// The first error stops the iteration
+	stop := errors.New("stop")
+	calls := 0
+	err := eachItem(strings.NewReader(`[{"name": "a"}, {"name": "b"}]`), func(Server) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected to stop after the first item, got %v after %d calls", err, calls)
+	}
+}
+
+func TestEachServerDecodesWhileReading(t *testing.T) {
+	first := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "a", "check": "enabled"},`))
+		w.(http.Flusher).Flush()
+		// The rest of the list is only sent once the first server was handled
+		select {
+		case <-first:
+		case <-time.After(2 * time.Second):
+			t.Error("Expected the first server to be handled before the list was complete")
+		}
+		_, _ = w.Write([]byte(`{"name": "b"}]`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL}, nil)
+	var names []string
+	err := client.EachServer(context.Background(), "app", "", func(server Server) error {
+		if len(names) == 0 {
+			close(first)
+		}
+		names = append(names, derefName(server.Name))
+		return nil
+	})
+	if err != nil || len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("Expected a and b, got %v: %v", names, err)
+	}
+
+	// A list cut short is an invalid response, and an error of the callback is returned as is
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "a"}, {"name"`))
+	})
+	var invalid *v3.InvalidResponseError
+	if err := client.EachServer(context.Background(), "app", "", func(Server) error { return nil }); !errors.As(err, &invalid) {
+		t.Errorf("Expected an invalid response error for a truncated list, got %v", err)
+	}
+	stop := errors.New("stop")
+	if err := client.EachServer(context.Background(), "app", "", func(Server) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("Expected the error of the callback, got %v", err)
+	}
+}
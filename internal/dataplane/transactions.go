@@ -0,0 +1,61 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+)
+
+// ErrTooManyTransactions is returned by CreateTransaction while the limit of open transactions is reached
+var ErrTooManyTransactions = errors.New("too many open transactions")
+
+// transactionLimits caps the open transactions of a Data Plane API and serializes the commits sent to it
+type transactionLimits struct {
+	maxOpen     atomic.Int32  // Zero means unlimited
+	createMutex sync.Mutex    // Held while counting and creating, so concurrent creates cannot exceed maxOpen
+	commitSlot  chan struct{} // Holds a token while a commit is in flight
+}
+
+// newTransactionLimits creates limits with at most maxOpen open transactions, zero meaning unlimited
+func newTransactionLimits(maxOpen int) *transactionLimits {
+	limits := &transactionLimits{commitSlot: make(chan struct{}, 1)}
+	limits.maxOpen.Store(int32(maxOpen))
+	return limits
+}
+
+// SetMaxOpenTransactions replaces the number of open transactions above which CreateTransaction fails;
+// zero removes the limit
+func (c *Client) SetMaxOpenTransactions(maxOpen int) {
+	c.transactions.maxOpen.Store(int32(maxOpen))
+}
+
+// checkOpenTransactions fails with ErrTooManyTransactions if the limit of open transactions is reached.
+// Transactions opened by other clients of the Data Plane API count as well.
+func (c *Client) checkOpenTransactions(ctx context.Context, maxOpen int) error {
+	open, err := c.ListTransactions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(open) >= maxOpen {
+		return fmt.Errorf("%w: %d of at most %d transactions are open", ErrTooManyTransactions, len(open), maxOpen)
+	}
+	return nil
+}
+
+// acquireCommit waits until no other commit is in flight. Commits queue up rather than reaching the Data Plane
+// API at once, where they would compete for the configuration file and reloads.
+func (c *Client) acquireCommit(ctx context.Context) (func(), error) {
+	start := time.Now()
+	select {
+	case c.transactions.commitSlot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	metrics.DataplaneCommitWait.WithLabelValues(c.instance).Observe(time.Since(start).Seconds())
+	return func() { <-c.transactions.commitSlot }, nil
+}
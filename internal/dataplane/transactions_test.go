@@ -0,0 +1,98 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxOpenTransactions(t *testing.T) {
+	var open atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			var list []string
+			for i := range open.Load() {
+				list = append(list, fmt.Sprintf(`{"id": "txn-%d"}`, i))
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(list, ",") + "]"))
+		case http.MethodPost:
+			id := open.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id": "txn-%d", "status": "in_progress"}`, id)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL, MaxOpenTransactions: 2}, nil)
+	var wg sync.WaitGroup
+	var rejected atomic.Int32
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.CreateTransaction(context.Background(), 1); errors.Is(err, ErrTooManyTransactions) {
+				rejected.Add(1)
+			} else if err != nil {
+				t.Errorf("CreateTransaction failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if open.Load() != 2 || rejected.Load() != 3 {
+		t.Errorf("Expected 2 open and 3 rejected transactions, got %d open and %d rejected", open.Load(), rejected.Load())
+	}
+
+	client.SetMaxOpenTransactions(0)
+	if _, err := client.CreateTransaction(context.Background(), 1); err != nil {
+		t.Errorf("Expected no limit after removing it, got %v", err)
+	}
+}
+
+func TestCommitsAreSerialized(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for previous := peak.Load(); current > previous && !peak.CompareAndSwap(previous, current); previous = peak.Load() {
+		}
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id": "txn", "status": "success"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", Endpoint{BaseURL: srv.URL}, nil)
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.CommitTransaction(context.Background(), fmt.Sprintf("txn-%d", i)); err != nil {
+				t.Errorf("CommitTransaction failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := peak.Load(); got != 1 {
+		t.Errorf("Expected commits one at a time, got %d at once", got)
+	}
+
+	// A commit waiting for its turn gives up with its context
+	release, err := client.acquireCommit(context.Background())
+	if err != nil {
+		t.Fatalf("acquireCommit failed: %v", err)
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.CommitTransaction(ctx, "txn"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the waiting commit to time out, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package dataplane
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// NewHTTPClient creates the HTTP client for a Data Plane API endpoint, applying its TLS and connection pool
+// settings. Connections are kept open between requests, so the many sequential requests of a transaction do
+// not each pay for a TCP and TLS handshake.
+func NewHTTPClient(settings config.HAProxySettings) (*http.Client, error) {
+	tlsConfig, err := settings.TLS.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pool := settings.ConnectionPool
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: time.Duration(pool.KeepAliveSeconds) * time.Second,
+	}).DialContext
+	if pool.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+		// The limit across hosts must not undercut the limit per host of the primary and fallback URLs
+		transport.MaxIdleConns = max(transport.MaxIdleConns, pool.MaxIdleConnsPerHost*(1+len(settings.FallbackAPIURLs)))
+	}
+	transport.MaxConnsPerHost = pool.MaxConnsPerHost
+	if pool.IdleTimeoutSeconds > 0 {
+		transport.IdleConnTimeout = time.Duration(pool.IdleTimeoutSeconds) * time.Second
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if pool.DisableHTTP2 {
+		// A non-nil empty map turns off the HTTP/2 upgrade negotiated through TLS
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,159 @@
+package dataplane
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// The resources of haproxy-go carry only some fields of the Data Plane API. The types below extend them with
+// the fields the configurator manages as well. Frontends and servers also keep the fields they do not model as
+// read, so that replacing one after changing a single setting leaves health checks, timeouts and the like alone.
+
+// Backend is a backend section
+type Backend struct {
+	v3.Backend
+	Fullconn      *int           `json:"fullconn,omitempty"` // Load at which servers accept up to their maxconn
+	DefaultServer *DefaultServer `json:"default_server,omitempty"`
+	StickTable    *StickTable    `json:"stick_table,omitempty"`
+	Disabled      *bool          `json:"disabled,omitempty"`
+	AdvCheck      string         `json:"adv_check,omitempty"` // Health check protocol, e.g. "httpchk" for option httpchk
+}
+
+// DefaultServer holds the settings the servers of a backend inherit unless they set their own
+type DefaultServer struct {
+	ConnectionLimits
+}
+
+// ConnectionLimits bound the connections a server handles and queues
+type ConnectionLimits struct {
+	Maxconn  *int `json:"maxconn,omitempty"`  // Concurrent connections; those beyond are queued
+	Minconn  *int `json:"minconn,omitempty"`  // Dynamic limit while the backend is below fullconn
+	Maxqueue *int `json:"maxqueue,omitempty"` // Queued connections; those beyond are redispatched
+}
+
+// StickTable is the stick table declared in a backend or frontend
+type StickTable struct {
+	Type   string `json:"type,omitempty"`   // "ip", "ipv6", "integer", "string" or "binary"
+	Size   *int   `json:"size,omitempty"`   // Maximum number of entries
+	Expire *int   `json:"expire,omitempty"` // Milliseconds an entry is kept after its last update
+	Keylen *int   `json:"keylen,omitempty"` // Key length of string tables
+	Store  string `json:"store,omitempty"`  // Comma separated data types, e.g. "http_req_rate(10s)"
+}
+
+// Frontend is a frontend section
+type Frontend struct {
+	v3.Frontend
+	settings settings
+}
+
+// UnmarshalJSON reads the frontend, keeping the settings that are not modeled
+func (f *Frontend) UnmarshalJSON(data []byte) error {
+	read, err := unmarshalWithSettings(data, &f.Frontend)
+	f.settings = read
+	return err
+}
+
+// MarshalJSON writes the frontend over the settings it was read with
+func (f Frontend) MarshalJSON() ([]byte, error) {
+	return marshalWithSettings(f.settings, f.Frontend)
+}
+
+// Server is a server of a backend
+type Server struct {
+	v3.Server
+	ConnectionLimits
+	Weight      *int   `json:"weight,omitempty"`        // Share of the load relative to the other servers, 0 to 256
+	SendProxy   string `json:"send-proxy,omitempty"`    // "enabled" or "disabled"
+	SendProxyV2 string `json:"send-proxy-v2,omitempty"` // "enabled" or "disabled"
+	Maintenance string `json:"maintenance,omitempty"`   // "enabled" or "disabled"
+	settings    settings
+}
+
+// serverFields are the modeled fields of a server, without its JSON methods
+type serverFields Server
+
+// UnmarshalJSON reads the server, keeping the settings that are not modeled
+func (s *Server) UnmarshalJSON(data []byte) error {
+	read, err := unmarshalWithSettings(data, (*serverFields)(s))
+	s.settings = read
+	return err
+}
+
+// MarshalJSON writes the server over the settings it was read with
+func (s Server) MarshalJSON() ([]byte, error) {
+	return marshalWithSettings(s.settings, serverFields(s))
+}
+
+// Bind is a bind of a frontend
+type Bind struct {
+	v3.Bind
+	SSL            *bool   `json:"ssl,omitempty"`
+	SSLCertificate *string `json:"ssl_certificate,omitempty"` // Certificate file on the HAProxy host
+	AcceptProxy    *bool   `json:"accept_proxy,omitempty"`
+}
+
+// settings are the fields of a resource as read from the Data Plane API
+type settings map[string]json.RawMessage
+
+// unmarshalWithSettings decodes data into the modeled fields and returns all of its fields
+func unmarshalWithSettings(data []byte, fields interface{}) (settings, error) {
+	if err := json.Unmarshal(data, fields); err != nil {
+		return nil, err
+	}
+	var read settings
+	if err := json.Unmarshal(data, &read); err != nil {
+		return nil, err
+	}
+	return read, nil
+}
+
+// marshalWithSettings encodes the modeled fields over the settings a resource was read with. A modeled field
+// that is unset is dropped from the settings as well, so that clearing it clears it in the Data Plane API.
+func marshalWithSettings(read settings, fields interface{}) ([]byte, error) {
+	if len(read) == 0 {
+		return json.Marshal(fields)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var modeled settings
+	if err := json.Unmarshal(data, &modeled); err != nil {
+		return nil, err
+	}
+
+	merged := make(settings, len(read)+len(modeled))
+	for key, value := range read {
+		merged[key] = value
+	}
+	for _, key := range jsonKeys(reflect.TypeOf(fields)) {
+		delete(merged, key)
+	}
+	for key, value := range modeled {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// jsonKeys returns the JSON keys of the fields of a struct type, including those of embedded structs
+func jsonKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct:
+			keys = append(keys, jsonKeys(field.Type)...)
+		case !field.IsExported():
+		case name == "":
+			keys = append(keys, field.Name)
+		default:
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
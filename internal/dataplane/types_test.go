@@ -0,0 +1,47 @@
+package dataplane
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestServerKeepsSettings(t *testing.T) {
+	var server Server
+	if err := json.Unmarshal([]byte(`{"name": "app1", "address": "10.0.0.1", "port": 8080, "weight": 100, "maintenance": "enabled", "check": "enabled", "inter": 2000}`), &server); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if server.Name == nil || *server.Name != "app1" || server.Weight == nil || *server.Weight != 100 || server.Maintenance != "enabled" {
+		t.Fatalf("Unexpected server %+v", server)
+	}
+
+	// Changed fields are written, cleared ones are dropped and the others are kept as read
+	weight := 10
+	server.Weight = &weight
+	server.Maintenance = ""
+	data, err := json.Marshal(server)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"address":"10.0.0.1","check":"enabled","inter":2000,"name":"app1","port":8080,"weight":10}` {
+		t.Errorf("Unexpected server %s", data)
+	}
+
+	// A server that was not read is written with its modeled fields only
+	data, err = json.Marshal(Server{Server: server.Server, Weight: &weight})
+	if err != nil || string(data) != `{"name":"app1","address":"10.0.0.1","port":8080,"weight":10}` {
+		t.Errorf("Unexpected server %s: %v", data, err)
+	}
+}
+
+func TestFrontendKeepsSettings(t *testing.T) {
+	var frontend Frontend
+	if err := json.Unmarshal([]byte(`{"name": "www", "mode": "http", "default_backend": "blue", "maxconn": 5000}`), &frontend); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	green := "green"
+	frontend.DefaultBackend = &green
+	data, err := json.Marshal(frontend)
+	if err != nil || string(data) != `{"default_backend":"green","maxconn":5000,"mode":"http","name":"www"}` {
+		t.Errorf("Unexpected frontend %s: %v", data, err)
+	}
+}
@@ -0,0 +1,135 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// StateFunc returns a snapshot of application state to include in the dump endpoint
+type StateFunc func() map[string]interface{}
+
+var startTime = time.Now()
+
+// NewHandler returns an HTTP handler exposing runtime diagnostics:
+//
+//	/debug/pprof/  - pprof profiles (heap, goroutine, profile, trace, ...)
+//	/debug/vars    - expvar variables including memstats
+//	/debug/dump    - JSON snapshot of runtime statistics and application state
+func NewHandler(state StateFunc) http.Handler {
+	mux := http.NewServeMux()
+
+	// Register pprof explicitly instead of relying on http.DefaultServeMux
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	mux.HandleFunc("/debug/dump", func(w http.ResponseWriter, r *http.Request) {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+
+		dump := map[string]interface{}{
+			"uptime_seconds": int64(time.Since(startTime).Seconds()),
+			"goroutines":     runtime.NumGoroutine(),
+			"memory": map[string]interface{}{
+				"heap_alloc_bytes":   memStats.HeapAlloc,
+				"heap_inuse_bytes":   memStats.HeapInuse,
+				"heap_objects":       memStats.HeapObjects,
+				"sys_bytes":          memStats.Sys,
+				"num_gc":             memStats.NumGC,
+				"last_gc":            time.Unix(0, int64(memStats.LastGC)),
+				"gc_pause_total_sec": time.Duration(memStats.PauseTotalNs).Seconds(),
+			},
+		}
+		if state != nil {
+			dump["state"] = state()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(dump)
+	})
+
+	return mux
+}
+
+// Server is the debug listener serving the handler of NewHandler
+type Server struct {
+	httpServer *http.Server
+	listener   net.Listener
+	done       chan error
+}
+
+// ValidateAddress checks the address of the debug listener. The host must be given, as the endpoints have no
+// authentication: an address such as ":6060" would expose them on every interface by accident, so listening on
+// all interfaces requires "0.0.0.0:6060" or "[::]:6060".
+func ValidateAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid debug listen address %q: %w", address, err)
+	}
+	if host == "" {
+		return fmt.Errorf("invalid debug listen address %q: the host is required, e.g. 127.0.0.1%s", address, address)
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+		return fmt.Errorf("invalid debug listen address %q: invalid port %q", address, port)
+	}
+	return nil
+}
+
+// Start listens on address and serves the debug endpoints in the background. The listener is disabled by
+// default: without an address, Start returns nil and nothing is served.
+func Start(address string, state StateFunc) (*Server, error) {
+	if address == "" {
+		return nil, nil
+	}
+	if err := ValidateAddress(address); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	server := &Server{
+		httpServer: &http.Server{Handler: NewHandler(state), ReadHeaderTimeout: 10 * time.Second},
+		listener:   listener,
+		done:       make(chan error, 1),
+	}
+	go func() {
+		err := server.httpServer.Serve(listener)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		server.done <- err
+	}()
+	return server, nil
+}
+
+// Addr returns the address the server listens on, e.g. to find the port chosen for "127.0.0.1:0"
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Done returns a channel receiving the error the server stopped with, or nil once it is shut down
+func (s *Server) Done() <-chan error {
+	return s.done
+}
+
+// Shutdown stops listening and waits for running requests, such as CPU profiles, to finish until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
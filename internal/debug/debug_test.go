@@ -0,0 +1,100 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewHandlerServesEndpoints(t *testing.T) {
+	server := httptest.NewServer(NewHandler(func() map[string]interface{} {
+		return map[string]interface{}{"leader": "node-a"}
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/vars"} {
+		response, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Expected 200 for %s, got %d", path, response.StatusCode)
+		}
+	}
+
+	response, err := http.Get(server.URL + "/debug/dump")
+	if err != nil {
+		t.Fatalf("GET /debug/dump failed: %v", err)
+	}
+	defer response.Body.Close()
+	var dump map[string]interface{}
+	if err := json.NewDecoder(response.Body).Decode(&dump); err != nil {
+		t.Fatalf("Failed to decode dump: %v", err)
+	}
+	state, ok := dump["state"].(map[string]interface{})
+	if !ok || state["leader"] != "node-a" {
+		t.Errorf("Expected the state in the dump, got %v", dump)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:6060", "localhost:6060", "0.0.0.0:6060", "[::1]:6060", "127.0.0.1:0"} {
+		if err := ValidateAddress(address); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", address, err)
+		}
+	}
+	for _, address := range []string{":6060", "6060", "127.0.0.1", "127.0.0.1:http", "127.0.0.1:70000", "::1:6060"} {
+		if err := ValidateAddress(address); err == nil {
+			t.Errorf("Expected %q to be rejected", address)
+		}
+	}
+}
+
+func TestStartDisabledByDefault(t *testing.T) {
+	server, err := Start("", nil)
+	if err != nil || server != nil {
+		t.Errorf("Expected no server without an address, got %v, %v", server, err)
+	}
+	if _, err := Start(":6060", nil); err == nil {
+		t.Error("Expected an address without a host to be rejected")
+	}
+}
+
+func TestStartAndShutdown(t *testing.T) {
+	server, err := Start("127.0.0.1:0", func() map[string]interface{} { return nil })
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	url := "http://" + server.Addr().String() + "/debug/dump"
+
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET /debug/dump failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", response.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	select {
+	case err := <-server.Done():
+		if err != nil {
+			t.Errorf("Expected a clean stop, got %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Server did not stop")
+	}
+
+	if _, err := http.Get(url); err == nil {
+		t.Error("Expected the listener to be closed after Shutdown")
+	}
+}
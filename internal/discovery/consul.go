@@ -0,0 +1,158 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// maxConsulBackoff bounds the wait between failed Consul queries
+const maxConsulBackoff = time.Minute
+
+// consulEntry is an instance of a service as returned by the health endpoint of the Consul HTTP API
+type consulEntry struct {
+	Node struct {
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		Address string `json:"Address"`
+		Port    int32  `json:"Port"`
+	} `json:"Service"`
+	Checks []struct {
+		Status string `json:"Status"` // "passing", "warning" or "critical"
+	} `json:"Checks"`
+}
+
+// ConsulSource watches the instances of a Consul service with blocking queries. Instances with a critical
+// check, including Consul's node and service maintenance, are unhealthy.
+type ConsulSource struct {
+	settings   config.ConsulSettings
+	service    config.ConsulService
+	httpClient *http.Client
+}
+
+// NewConsulSources creates a source per configured Consul service
+func NewConsulSources(settings config.ConsulSettings) []Source {
+	// Blocking queries take up to the wait time plus the jitter Consul adds, wait/16
+	httpClient := &http.Client{Timeout: time.Duration(settings.WaitSeconds)*time.Second*17/16 + 30*time.Second}
+
+	var sources []Source
+	for _, service := range settings.Services {
+		sources = append(sources, &ConsulSource{settings: settings, service: service, httpClient: httpClient})
+	}
+	return sources
+}
+
+// Backend names the backend whose servers are the instances
+func (c *ConsulSource) Backend() string {
+	return c.service.Backend
+}
+
+// Describe names the source, e.g. "consul:web" or "consul:web[v2]" with a tag filter
+func (c *ConsulSource) Describe() string {
+	if len(c.service.Tags) > 0 {
+		return "consul:" + c.service.Service + "[" + strings.Join(c.service.Tags, ",") + "]"
+	}
+	return "consul:" + c.service.Service
+}
+
+// Watch queries the instances of the service and, once it got an answer, asks again for the next change
+func (c *ConsulSource) Watch(ctx context.Context, update func([]Instance)) {
+	var index uint64
+	backoff := time.Second
+	for {
+		instances, next, err := c.query(ctx, index)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.GetLogger().Warn("Failed to query Consul, retrying",
+				zap.String("source", c.Describe()),
+				zap.Duration("retry_in", backoff),
+				zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxConsulBackoff)
+			continue
+		}
+		backoff = time.Second
+
+		// An index going backwards means the Consul state was reset, so the watch starts over
+		if next < index {
+			index = 0
+		} else {
+			index = next
+		}
+		update(instances)
+	}
+}
+
+// query returns the instances of the service once the index of the Consul state passes index, or after the
+// wait time, together with the new index
+func (c *ConsulSource) query(ctx context.Context, index uint64) ([]Instance, uint64, error) {
+	query := url.Values{}
+	for _, tag := range c.service.Tags {
+		query.Add("tag", tag)
+	}
+	if c.settings.Datacenter != "" {
+		query.Set("dc", c.settings.Datacenter)
+	}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", fmt.Sprintf("%ds", c.settings.WaitSeconds))
+	}
+	requestURL := strings.TrimRight(c.settings.Address, "/") + "/v1/health/service/" + url.PathEscape(c.service.Service) + "?" + query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if c.settings.Token != "" {
+		request.Header.Set("X-Consul-Token", c.settings.Token)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("consul returned HTTP %d", response.StatusCode)
+	}
+
+	var entries []consulEntry
+	if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+		return nil, 0, fmt.Errorf("invalid consul response: %w", err)
+	}
+	next, err := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid X-Consul-Index header: %w", err)
+	}
+
+	instances := make([]Instance, 0, len(entries))
+	for _, entry := range entries {
+		instance := Instance{Address: entry.Service.Address, Port: entry.Service.Port, Healthy: true}
+		// Services registered without an address are reached on the address of their node
+		if instance.Address == "" {
+			instance.Address = entry.Node.Address
+		}
+		for _, check := range entry.Checks {
+			if check.Status == "critical" {
+				instance.Healthy = false
+			}
+		}
+		instances = append(instances, instance)
+	}
+	return instances, next, nil
+}
@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+)
+
+// consulResponses are the answers of a fake Consul health endpoint, in order. Once they are used up, queries
+// block until the client gives up.
+var consulResponses = []struct {
+	index string
+	body  string
+}{
+	{"5", `[{"Node": {"Address": "10.0.0.1"}, "Service": {"Address": "", "Port": 8080}, "Checks": [{"Status": "passing"}]}]`},
+	{"7", `[{"Node": {"Address": "10.0.0.1"}, "Service": {"Address": "", "Port": 8080}, "Checks": [{"Status": "passing"}]},
+		{"Node": {"Address": "10.0.0.9"}, "Service": {"Address": "10.0.0.2", "Port": 8080}, "Checks": [{"Status": "warning"}, {"Status": "critical"}]}]`},
+}
+
+func TestConsulSourceWatch(t *testing.T) {
+	var mutex sync.Mutex
+	var queries []string
+	blocked := make(chan struct{})
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		n := len(queries)
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery+" token="+r.Header.Get("X-Consul-Token"))
+		mutex.Unlock()
+
+		if n >= len(consulResponses) {
+			if n == len(consulResponses) {
+				close(blocked)
+			}
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("X-Consul-Index", consulResponses[n].index)
+		_, _ = w.Write([]byte(consulResponses[n].body))
+	}))
+	defer consul.Close()
+
+	sources := NewConsulSources(config.ConsulSettings{
+		Address:     consul.URL,
+		Token:       "secret",
+		Datacenter:  "dc1",
+		WaitSeconds: 60,
+		Services:    []config.ConsulService{{Service: "web", Tags: []string{"v2"}, Backend: "app"}},
+	})
+	if len(sources) != 1 || sources[0].Backend() != "app" || sources[0].Describe() != "consul:web[v2]" {
+		t.Fatalf("Unexpected sources %v", sources)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan []Instance)
+	done := make(chan struct{})
+	go func() {
+		sources[0].Watch(ctx, func(instances []Instance) { updates <- instances })
+		close(done)
+	}()
+
+	first := <-updates
+	if len(first) != 1 || first[0] != (Instance{Address: "10.0.0.1", Port: 8080, Healthy: true}) {
+		t.Errorf("Expected the instance on the node address, got %v", first)
+	}
+	second := <-updates
+	if len(second) != 2 || second[1] != (Instance{Address: "10.0.0.2", Port: 8080, Healthy: false}) {
+		t.Errorf("Expected an unhealthy second instance, got %v", second)
+	}
+	<-blocked
+	cancel()
+	<-done
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(queries) != 3 ||
+		queries[0] != "/v1/health/service/web?dc=dc1&tag=v2 token=secret" ||
+		queries[1] != "/v1/health/service/web?dc=dc1&index=5&tag=v2&wait=60s token=secret" ||
+		!strings.Contains(queries[2], "index=7") {
+		t.Errorf("Unexpected queries %v", queries)
+	}
+}
+
+// staticSource reports fixed instances once
+type staticSource struct {
+	instances []Instance
+}
+
+func (s staticSource) Backend() string  { return "app" }
+func (s staticSource) Describe() string { return "static" }
+func (s staticSource) Watch(_ context.Context, update func([]Instance)) {
+	update(s.instances)
+}
+
+func TestManagerSyncs(t *testing.T) {
+	type call struct {
+		backend   string
+		servers   []*pb.Server
+		unhealthy []string
+	}
+	calls := make(chan call, 10)
+	fail := true
+	manager := NewManager([]Source{staticSource{instances: []Instance{
+		{Address: "10.0.0.2", Port: 80, Healthy: false},
+		{Address: "10.0.0.1", Port: 80, Healthy: true},
+		{Address: "10.0.0.2", Port: 80, Healthy: true},
+		{Address: "fd00::3", Port: 80, Healthy: false},
+	}}}, 10*time.Millisecond, func(_ context.Context, backend string, servers []*pb.Server, unhealthy []string) ([]*pb.StateChange, error) {
+		calls <- call{backend, servers, unhealthy}
+		if fail {
+			fail = false
+			return nil, errors.New("dataplane unavailable")
+		}
+		return []*pb.StateChange{{ResourceType: "server", Action: "create", ParentName: backend, Name: servers[0].Name}}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go manager.Run(ctx)
+
+	first := <-calls
+	if first.backend != "app" || len(first.servers) != 3 || first.servers[0].Name != "10-0-0-1-80" || first.servers[2].Name != "fd00--3-80" {
+		t.Errorf("Unexpected servers %v", first.servers)
+	}
+	if len(first.unhealthy) != 1 || first.unhealthy[0] != "fd00--3-80" {
+		t.Errorf("Expected only the server without a healthy report to be unhealthy, got %v", first.unhealthy)
+	}
+
+	// The failed sync is retried on the resync interval
+	<-calls
+	for deadline := time.Now().Add(5 * time.Second); manager.Status()[0].LastError != ""; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the retry to succeed, got %v", manager.Status()[0])
+		}
+	}
+	status := manager.Status()[0]
+	if status.Source != "static" || status.Servers != 3 || status.HealthyServers != 2 || len(status.LastChanges) != 1 {
+		t.Errorf("Unexpected status %+v", status)
+	}
+}
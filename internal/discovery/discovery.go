@@ -0,0 +1,187 @@
+// Package discovery keeps the servers of backends in sync with service discovery sources: Consul, Kubernetes
+// EndpointSlices and server list files
+package discovery
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+)
+
+// SyncFunc makes the servers of a backend match the discovered ones, putting the servers named in unhealthy into
+// maintenance, and returns the operations performed
+type SyncFunc func(ctx context.Context, backend string, servers []*pb.Server, unhealthy []string) ([]*pb.StateChange, error)
+
+// Instance is a discovered instance of a service
+type Instance struct {
+	Address string
+	Port    int32
+	Healthy bool
+}
+
+// Source watches the instances of the service behind one backend
+type Source interface {
+	// Backend names the backend whose servers are the instances
+	Backend() string
+	// Describe names the source in logs and the status, e.g. "consul:web"
+	Describe() string
+	// Watch calls update with the complete list of instances initially and after every change, until ctx is
+	// cancelled
+	Watch(ctx context.Context, update func([]Instance))
+}
+
+// Status is the sync state of a backend
+type Status struct {
+	Backend        string
+	Source         string
+	Servers        int
+	HealthyServers int
+	LastSync       time.Time
+	LastError      string
+	LastChanges    []*pb.StateChange // Operations of the last sync that changed anything
+}
+
+// backendState is what a source discovered for its backend and how the last sync went
+type backendState struct {
+	source    Source
+	instances []Instance
+	watched   bool // Set once the source reported its instances
+	status    Status
+}
+
+// Manager runs the sources and syncs their backends. Syncs are serialized, so the transactions of different
+// backends do not conflict, and every resync interval all backends are synced again to retry failures and to
+// restore the maintenance state of unhealthy servers after HAProxy reloads.
+type Manager struct {
+	apply    SyncFunc
+	interval time.Duration
+	syncing  sync.Mutex // Serializes syncs
+
+	mutex    sync.RWMutex
+	backends []*backendState
+}
+
+// NewManager creates a manager syncing the backends of the given sources
+func NewManager(sources []Source, interval time.Duration, apply SyncFunc) *Manager {
+	m := &Manager{apply: apply, interval: interval}
+	for _, source := range sources {
+		m.backends = append(m.backends, &backendState{
+			source: source,
+			status: Status{Backend: source.Backend(), Source: source.Describe()},
+		})
+	}
+	return m
+}
+
+// Run watches the sources and syncs their backends until ctx is cancelled
+func (m *Manager) Run(ctx context.Context) {
+	for _, backend := range m.backends {
+		go backend.source.Watch(ctx, func(instances []Instance) {
+			m.mutex.Lock()
+			backend.instances = instances
+			backend.watched = true
+			m.mutex.Unlock()
+			m.syncBackend(ctx, backend)
+		})
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, backend := range m.backends {
+				m.syncBackend(ctx, backend)
+			}
+		}
+	}
+}
+
+// syncBackend applies the last discovered instances of a backend, unless its source has not reported yet
+func (m *Manager) syncBackend(ctx context.Context, backend *backendState) {
+	m.syncing.Lock()
+	defer m.syncing.Unlock()
+
+	m.mutex.RLock()
+	instances, watched := backend.instances, backend.watched
+	m.mutex.RUnlock()
+	if !watched {
+		return
+	}
+
+	servers, unhealthy := Servers(instances)
+	changes, err := m.apply(ctx, backend.source.Backend(), servers, unhealthy)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	backend.status.LastSync = time.Now()
+	backend.status.Servers = len(servers)
+	backend.status.HealthyServers = len(servers) - len(unhealthy)
+	if err != nil {
+		backend.status.LastError = err.Error()
+		logger.GetLogger().Error("Failed to sync discovered servers",
+			zap.String("backend", backend.source.Backend()),
+			zap.String("source", backend.source.Describe()),
+			zap.Error(err))
+		return
+	}
+	backend.status.LastError = ""
+	if len(changes) > 0 {
+		backend.status.LastChanges = changes
+		logger.GetLogger().Info("Synced discovered servers",
+			zap.String("backend", backend.source.Backend()),
+			zap.String("source", backend.source.Describe()),
+			zap.Int("servers", len(servers)),
+			zap.Int("changes", len(changes)))
+	}
+}
+
+// Status returns the sync state of every backend
+func (m *Manager) Status() []Status {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := make([]Status, 0, len(m.backends))
+	for _, backend := range m.backends {
+		result = append(result, backend.status)
+	}
+	return result
+}
+
+// Servers converts discovered instances to servers named after their address and port, and returns the names
+// of the unhealthy ones. An address and port reported more than once is one server, healthy if any report is.
+func Servers(instances []Instance) ([]*pb.Server, []string) {
+	servers := make(map[string]*pb.Server)
+	healthy := make(map[string]bool)
+	for _, instance := range instances {
+		name := ServerName(instance.Address, instance.Port)
+		servers[name] = &pb.Server{Name: name, Address: instance.Address, Port: instance.Port}
+		healthy[name] = healthy[name] || instance.Healthy
+	}
+
+	result := make([]*pb.Server, 0, len(servers))
+	var unhealthy []string
+	for name, server := range servers {
+		result = append(result, server)
+		if !healthy[name] {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	sort.Strings(unhealthy)
+	return result, unhealthy
+}
+
+// ServerName names the server of an instance, e.g. "10-0-0-1-8080" for 10.0.0.1:8080
+func ServerName(address string, port int32) string {
+	return strings.NewReplacer(".", "-", ":", "-").Replace(address) + "-" + strconv.Itoa(int(port))
+}
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// fileWatchRetry is the wait before watching a file again after the watch could not be set up, e.g. because its
+// directory does not exist yet
+const fileWatchRetry = 10 * time.Second
+
+// serverFile is the content of a discovery file
+type serverFile struct {
+	Servers []struct {
+		Address     string `yaml:"address"`
+		Port        int32  `yaml:"port"`
+		Maintenance bool   `yaml:"maintenance,omitempty"` // Keep the server, but send it no traffic
+	} `yaml:"servers"`
+}
+
+// FileSource reads the servers of a backend from a JSON or YAML file and again whenever the file changes.
+// Files that cannot be read or are invalid are skipped, keeping the servers of the last valid version.
+type FileSource struct {
+	file config.DiscoveryFile
+}
+
+// NewFileSources creates a source per configured file
+func NewFileSources(files []config.DiscoveryFile) []Source {
+	var sources []Source
+	for _, file := range files {
+		sources = append(sources, &FileSource{file: file})
+	}
+	return sources
+}
+
+// Backend names the backend whose servers are listed in the file
+func (f *FileSource) Backend() string {
+	return f.file.Backend
+}
+
+// Describe names the source, e.g. "file:/etc/haproxy-configurator/servers/web.yaml"
+func (f *FileSource) Describe() string {
+	return "file:" + f.file.Path
+}
+
+// Watch reads the file initially and whenever it is written, created or replaced
+func (f *FileSource) Watch(ctx context.Context, update func([]Instance)) {
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	for {
+		err := config.WatchFile(ctx, f.file.Path, notify)
+		if err == nil {
+			break
+		}
+		logger.GetLogger().Warn("Failed to watch discovery file, retrying",
+			zap.String("source", f.Describe()),
+			zap.Duration("retry_in", fileWatchRetry),
+			zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(fileWatchRetry):
+		}
+	}
+
+	notify()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+
+		instances, err := f.read()
+		if err != nil {
+			logger.GetLogger().Error("Failed to read discovery file, keeping the previous servers",
+				zap.String("source", f.Describe()),
+				zap.Error(err))
+			continue
+		}
+		update(instances)
+	}
+}
+
+// read parses the file. An empty file is rejected, as it is more likely a write in progress than a backend
+// without servers, which is written as "servers: []".
+func (f *FileSource) read() ([]Instance, error) {
+	data, err := os.ReadFile(f.file.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	var content serverFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&content); err != nil {
+		return nil, fmt.Errorf("invalid discovery file: %w", err)
+	}
+
+	instances := make([]Instance, 0, len(content.Servers))
+	for i, server := range content.Servers {
+		if server.Address == "" {
+			return nil, fmt.Errorf("server %d: address is required", i)
+		}
+		if server.Port < 1 || server.Port > 65535 {
+			return nil, fmt.Errorf("server %d: port must be between 1 and 65535, got %d", i, server.Port)
+		}
+		instances = append(instances, Instance{Address: server.Address, Port: server.Port, Healthy: !server.Maintenance})
+	}
+	return instances, nil
+}
@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestFileSourceWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "web.yaml")
+	write := func(content string) {
+		t.Helper()
+		// Replaced atomically, as configuration management tools do
+		if err := os.WriteFile(path+".tmp", []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatalf("Failed to replace file: %v", err)
+		}
+	}
+	write("servers:\n  - address: 10.0.0.1\n    port: 8080\n  - address: app2.internal\n    port: 8080\n    maintenance: true\n")
+
+	sources := NewFileSources([]config.DiscoveryFile{{Backend: "web", Path: path}})
+	if len(sources) != 1 || sources[0].Backend() != "web" || sources[0].Describe() != "file:"+path {
+		t.Fatalf("Unexpected sources %v", sources)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan []Instance, 10)
+	go sources[0].Watch(ctx, func(instances []Instance) { updates <- instances })
+
+	next := func() []Instance {
+		t.Helper()
+		select {
+		case instances := <-updates:
+			return instances
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for an update")
+			return nil
+		}
+	}
+
+	instances := next()
+	if len(instances) != 2 || instances[0] != (Instance{Address: "10.0.0.1", Port: 8080, Healthy: true}) ||
+		instances[1] != (Instance{Address: "app2.internal", Port: 8080, Healthy: false}) {
+		t.Errorf("Unexpected instances %v", instances)
+	}
+
+	// Invalid versions are skipped; JSON is read as well
+	write(`{"servers": [{"address": "10.0.0.1", "port": 0}]}`)
+	write(`{"servers": [{"address": "10.0.0.3", "port": 9090}]}`)
+	if instances := next(); len(instances) != 1 || instances[0] != (Instance{Address: "10.0.0.3", Port: 9090, Healthy: true}) {
+		t.Errorf("Expected only the valid version to be reported, got %v", instances)
+	}
+
+	write("servers: []\n")
+	if instances := next(); len(instances) != 0 {
+		t.Errorf("Expected no instances, got %v", instances)
+	}
+}
+
+func TestFileSourceRead(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty":   "\n",
+		"unknown": "servers:\n  - address: 10.0.0.1\n    port: 80\n    weight: 10\n",
+		"address": "servers:\n  - port: 80\n",
+		"syntax":  "servers: [",
+	} {
+		path := filepath.Join(dir, name+".yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := (&FileSource{file: config.DiscoveryFile{Backend: "web", Path: path}}).read(); err == nil {
+			t.Errorf("Expected the %s file to be rejected", name)
+		}
+	}
+	if _, err := (&FileSource{file: config.DiscoveryFile{Backend: "web", Path: filepath.Join(dir, "missing.yaml")}}).read(); err == nil {
+		t.Error("Expected a missing file to be rejected")
+	}
+}
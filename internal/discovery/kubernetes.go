@@ -0,0 +1,152 @@
+package discovery
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// endpointSliceResource is the resource watched for the endpoints of Services
+var endpointSliceResource = schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+
+// EndpointSliceSource watches the EndpointSlices of a Kubernetes Service. Endpoints that are not ready, such as
+// pods failing their readiness probe or terminating, are unhealthy.
+type EndpointSliceSource struct {
+	client    dynamic.Interface
+	backend   config.KubernetesBackend
+	namespace string
+	service   string
+}
+
+// NewEndpointSliceSources creates a source per configured backend
+func NewEndpointSliceSources(client dynamic.Interface, settings config.KubernetesDiscoverySettings) []Source {
+	var sources []Source
+	for _, backend := range settings.Backends {
+		namespace, service, _ := strings.Cut(backend.Service, "/")
+		sources = append(sources, &EndpointSliceSource{client: client, backend: backend, namespace: namespace, service: service})
+	}
+	return sources
+}
+
+// Backend names the backend whose servers are the endpoints
+func (e *EndpointSliceSource) Backend() string {
+	return e.backend.Backend
+}
+
+// Describe names the source, e.g. "kubernetes:shop/web" or "kubernetes:shop/web:http" with a port name
+func (e *EndpointSliceSource) Describe() string {
+	if e.backend.Port != "" {
+		return "kubernetes:" + e.backend.Service + ":" + e.backend.Port
+	}
+	return "kubernetes:" + e.backend.Service
+}
+
+// Watch lists the EndpointSlices of the Service and reports its endpoints again whenever a slice changes
+func (e *EndpointSliceSource) Watch(ctx context.Context, update func([]Instance)) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(e.client, 0, e.namespace, func(options *metav1.ListOptions) {
+		options.LabelSelector = discoveryv1.LabelServiceName + "=" + e.service
+	})
+	informer := factory.ForResource(endpointSliceResource)
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		return
+	}
+	// A Service without slices has no add events but is reported as well
+	notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+
+		objects, err := informer.Lister().List(labels.Everything())
+		if err != nil {
+			logger.GetLogger().Error("Failed to list cached EndpointSlices",
+				zap.String("source", e.Describe()),
+				zap.Error(err))
+			continue
+		}
+		update(e.instances(objects))
+	}
+}
+
+// instances returns an instance per endpoint address of the Service serving the selected port
+func (e *EndpointSliceSource) instances(objects []runtime.Object) []Instance {
+	var instances []Instance
+	for _, object := range objects {
+		obj, ok := object.(*unstructured.Unstructured)
+		if !ok || obj.GetLabels()[discoveryv1.LabelServiceName] != e.service {
+			continue
+		}
+		var slice discoveryv1.EndpointSlice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &slice); err != nil {
+			logger.GetLogger().Warn("Failed to decode EndpointSlice",
+				zap.String("namespace", obj.GetNamespace()),
+				zap.String("name", obj.GetName()),
+				zap.Error(err))
+			continue
+		}
+		if string(slice.AddressType) != e.backend.AddressType {
+			continue
+		}
+		port, ok := e.port(&slice)
+		if !ok {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			healthy := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			for _, address := range endpoint.Addresses {
+				instances = append(instances, Instance{Address: address, Port: port, Healthy: healthy})
+			}
+		}
+	}
+	return instances
+}
+
+// port returns the TCP port of a slice with the configured name, or its only port if no name is configured
+func (e *EndpointSliceSource) port(slice *discoveryv1.EndpointSlice) (int32, bool) {
+	if e.backend.Port == "" && len(slice.Ports) != 1 {
+		logger.GetLogger().Warn("Service has several ports, but no port is configured for its backend",
+			zap.String("source", e.Describe()))
+		return 0, false
+	}
+	for _, port := range slice.Ports {
+		if port.Port == nil || (port.Protocol != nil && *port.Protocol != corev1.ProtocolTCP) {
+			continue
+		}
+		if e.backend.Port == "" || (port.Name != nil && *port.Name == e.backend.Port) {
+			return *port.Port, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,114 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newEndpointSlice creates an IPv4 slice of a Service in the shop namespace with a ready and a not ready endpoint
+func newEndpointSlice(t *testing.T, name, service string, port int32, ready, notReady string) *unstructured.Unstructured {
+	t.Helper()
+	portName, yes, no := "http", true, false
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop",
+			Name:      name,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{ready}, Conditions: discoveryv1.EndpointConditions{Ready: &yes}},
+			{Addresses: []string{notReady}, Conditions: discoveryv1.EndpointConditions{Ready: &no}},
+		},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(slice)
+	if err != nil {
+		t.Fatalf("Failed to convert EndpointSlice: %v", err)
+	}
+	result := &unstructured.Unstructured{Object: content}
+	result.SetAPIVersion("discovery.k8s.io/v1")
+	result.SetKind("EndpointSlice")
+	return result
+}
+
+func TestEndpointSliceSourceWatch(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		endpointSliceResource: "EndpointSliceList",
+	}, newEndpointSlice(t, "web-abcde", "web", 8080, "10.0.0.1", "10.0.0.2"), newEndpointSlice(t, "api-abcde", "api", 9090, "10.0.1.1", "10.0.1.2"))
+
+	sources := NewEndpointSliceSources(client, config.KubernetesDiscoverySettings{Backends: []config.KubernetesBackend{
+		{Backend: "web", Service: "shop/web", Port: "http", AddressType: "IPv4"},
+	}})
+	if len(sources) != 1 || sources[0].Backend() != "web" || sources[0].Describe() != "kubernetes:shop/web:http" {
+		t.Fatalf("Unexpected sources %v", sources)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan []Instance, 10)
+	go sources[0].Watch(ctx, func(instances []Instance) { updates <- instances })
+
+	// waitForInstances returns the first update with the given number of instances
+	waitForInstances := func(count int) []Instance {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case instances := <-updates:
+				if len(instances) == count {
+					return instances
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for %d instances", count)
+			}
+		}
+	}
+
+	instances := waitForInstances(2)
+	if instances[0] != (Instance{Address: "10.0.0.1", Port: 8080, Healthy: true}) || instances[1] != (Instance{Address: "10.0.0.2", Port: 8080, Healthy: false}) {
+		t.Errorf("Expected the endpoints of web with their readiness, got %v", instances)
+	}
+
+	added := newEndpointSlice(t, "web-fghij", "web", 8080, "10.0.0.3", "10.0.0.4")
+	if _, err := client.Resource(endpointSliceResource).Namespace("shop").Create(ctx, added, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create EndpointSlice: %v", err)
+	}
+	waitForInstances(4)
+
+	if err := client.Resource(endpointSliceResource).Namespace("shop").Delete(ctx, "web-abcde", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete EndpointSlice: %v", err)
+	}
+	instances = waitForInstances(2)
+	if instances[0].Address != "10.0.0.3" || instances[1].Address != "10.0.0.4" {
+		t.Errorf("Expected the endpoints of the remaining slice, got %v", instances)
+	}
+}
+
+func TestEndpointSliceSourcePort(t *testing.T) {
+	slice := newEndpointSlice(t, "web-abcde", "web", 8080, "10.0.0.1", "10.0.0.2")
+	for _, backend := range []config.KubernetesBackend{
+		{Backend: "web", Service: "shop/web", Port: "metrics", AddressType: "IPv4"},
+		{Backend: "web", Service: "shop/web", Port: "http", AddressType: "IPv6"},
+	} {
+		source := &EndpointSliceSource{backend: backend, namespace: "shop", service: "web"}
+		if instances := source.instances([]runtime.Object{slice}); len(instances) != 0 {
+			t.Errorf("Expected no instances for %+v, got %v", backend, instances)
+		}
+	}
+
+	// The only port of a Service is used without a configured name
+	source := &EndpointSliceSource{backend: config.KubernetesBackend{Backend: "web", Service: "shop/web", AddressType: "IPv4"}, namespace: "shop", service: "web"}
+	if instances := source.instances([]runtime.Object{slice}); len(instances) != 2 || instances[0].Port != 8080 {
+		t.Errorf("Expected the only port to be used, got %v", instances)
+	}
+}
@@ -0,0 +1,279 @@
+// Package drift detects changes made to HAProxy outside of the configurator by comparing the live
+// configuration with a desired state, and optionally reverts them
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Target is the configurator whose live configuration is compared
+type Target interface {
+	IsLeader() bool
+	LiveState(ctx context.Context, instance string) (*pb.State, error)
+	ApplyState(ctx context.Context, instance string, desired *pb.State, prefix string) ([]*pb.StateChange, error)
+	NotifyDrift(instance string, changes []*pb.StateChange)
+}
+
+// Status describes the outcome of the last comparison
+type Status struct {
+	DesiredState    string
+	Instance        string
+	Record          bool
+	Remediate       bool
+	Changes         []*pb.StateChange // Operations that would make the live configuration match the desired state
+	LastCheck       time.Time
+	Detected        time.Time // When the current drift was first detected; zero without drift
+	LastRemediation time.Time
+	LastError       string
+}
+
+// Detector periodically compares the live configuration of an instance with the desired state. In record
+// mode, the desired state is the live state after the last commit through the configurator, so only changes
+// made by other means are drift.
+type Detector struct {
+	settings config.DriftDetectionSettings
+	target   Target
+	trigger  chan struct{}
+
+	// Held shared while the configurator commits and exclusively while comparing, so that a commit in
+	// progress is neither reported as drift nor recorded half way
+	commits sync.RWMutex
+	// Serializes recording after concurrent commits, so the state read last is written last
+	recordMutex sync.Mutex
+
+	mutex  sync.RWMutex
+	status Status
+}
+
+// NewDetector creates a detector for the configured instance
+func NewDetector(settings config.DriftDetectionSettings, target Target) *Detector {
+	return &Detector{
+		settings: settings,
+		target:   target,
+		trigger:  make(chan struct{}, 1),
+		status: Status{
+			DesiredState: settings.DesiredState,
+			Instance:     settings.Instance,
+			Record:       settings.Record,
+			Remediate:    settings.Remediate,
+		},
+	}
+}
+
+// Instance returns the HAProxy instance the detector compares
+func (d *Detector) Instance() string {
+	return d.settings.Instance
+}
+
+// Run compares immediately and then every interval or when triggered, until ctx is cancelled
+func (d *Detector) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(d.settings.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		d.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.trigger:
+		}
+	}
+}
+
+// Trigger requests a comparison without waiting for the next interval
+func (d *Detector) Trigger() {
+	select {
+	case d.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Check compares the live configuration with the desired state once and remediates drift if configured.
+// Standbys compare nothing, as they make no commits to record.
+func (d *Detector) Check(ctx context.Context) {
+	if !d.target.IsLeader() {
+		return
+	}
+
+	d.commits.Lock()
+	live, desired, err := d.load(ctx)
+	d.commits.Unlock()
+	if err != nil {
+		d.fail(err)
+		return
+	}
+
+	var changes []*pb.StateChange
+	for _, change := range state.Diff(live, desired, true) {
+		changes = append(changes, change.Proto())
+	}
+	d.report(changes)
+
+	if len(changes) == 0 || !d.settings.Remediate {
+		return
+	}
+	if _, err := d.target.ApplyState(ctx, d.settings.Instance, desired, ""); err != nil {
+		d.fail(fmt.Errorf("failed to remediate drift: %w", err))
+		return
+	}
+	logger.GetLogger().Info("Remediated configuration drift",
+		zap.String("instance", d.settings.Instance),
+		zap.Int("changes", len(changes)))
+
+	d.mutex.Lock()
+	d.status.LastRemediation = time.Now()
+	d.status.Changes = nil
+	d.status.Detected = time.Time{}
+	d.mutex.Unlock()
+	metrics.DriftChanges.WithLabelValues(d.settings.Instance).Set(0)
+}
+
+// load reads the live and the desired state. In record mode, a missing desired state is recorded from the
+// live one first.
+func (d *Detector) load(ctx context.Context) (*pb.State, *pb.State, error) {
+	live, err := d.target.LiveState(ctx, d.settings.Instance)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read live state: %w", err)
+	}
+	state.Normalize(live)
+
+	if d.settings.Record {
+		if _, err := os.Stat(d.settings.DesiredState); errors.Is(err, os.ErrNotExist) {
+			if err := d.write(live); err != nil {
+				return nil, nil, err
+			}
+			return live, live, nil
+		}
+	}
+
+	desired, err := state.LoadManifests(d.settings.DesiredState)
+	if err != nil {
+		return nil, nil, err
+	}
+	state.Normalize(desired)
+	return live, desired, nil
+}
+
+// report records the outcome of a comparison, notifying when drift is first detected or changes
+func (d *Detector) report(changes []*pb.StateChange) {
+	metrics.DriftChanges.WithLabelValues(d.settings.Instance).Set(float64(len(changes)))
+
+	d.mutex.Lock()
+	previous := d.status.Changes
+	now := time.Now()
+	d.status.LastCheck = now
+	d.status.LastError = ""
+	d.status.Changes = changes
+	switch {
+	case len(changes) == 0:
+		d.status.Detected = time.Time{}
+	case len(previous) == 0:
+		d.status.Detected = now
+	}
+	d.mutex.Unlock()
+
+	if len(changes) == 0 || sameChanges(previous, changes) {
+		return
+	}
+	logger.GetLogger().Warn("Live configuration drifted from the desired state",
+		zap.String("instance", d.settings.Instance),
+		zap.String("desired_state", d.settings.DesiredState),
+		zap.Int("changes", len(changes)))
+	d.target.NotifyDrift(d.settings.Instance, changes)
+}
+
+// fail records a failed comparison or remediation
+func (d *Detector) fail(err error) {
+	logger.GetLogger().Error("Drift detection failed",
+		zap.String("instance", d.settings.Instance),
+		zap.Error(err))
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.status.LastCheck = time.Now()
+	d.status.LastError = err.Error()
+}
+
+// BeginCommit must be called before the configurator commits to the instance, and EndCommit afterwards
+func (d *Detector) BeginCommit() {
+	d.commits.RLock()
+}
+
+// EndCommit ends a commit started with BeginCommit. In record mode the live state after a successful commit
+// becomes the desired state.
+func (d *Detector) EndCommit(ctx context.Context, committed bool) {
+	defer d.commits.RUnlock()
+	if !d.settings.Record || !committed {
+		return
+	}
+
+	d.recordMutex.Lock()
+	defer d.recordMutex.Unlock()
+	live, err := d.target.LiveState(ctx, d.settings.Instance)
+	if err == nil {
+		state.Normalize(live)
+		err = d.write(live)
+	}
+	if err != nil {
+		logger.GetLogger().Error("Failed to record desired state after commit",
+			zap.String("instance", d.settings.Instance),
+			zap.Error(err))
+	}
+}
+
+// write stores a state as the desired state document, replacing the previous one atomically
+func (d *Detector) write(desired *pb.State) error {
+	document, err := state.Encode(desired, pb.StateFormat_STATE_FORMAT_YAML)
+	if err != nil {
+		return fmt.Errorf("failed to encode desired state: %w", err)
+	}
+
+	path := d.settings.DesiredState
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for desired state: %w", err)
+	}
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, []byte(document), 0644); err != nil {
+		return fmt.Errorf("failed to write desired state: %w", err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return fmt.Errorf("failed to replace desired state: %w", err)
+	}
+	return nil
+}
+
+// Status returns a snapshot of the detector status
+func (d *Detector) Status() Status {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.status
+}
+
+// sameChanges reports whether two comparisons found the same changes
+func sameChanges(a, b []*pb.StateChange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package drift
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+)
+
+// fakeTarget serves a fixed live state and counts notifications
+type fakeTarget struct {
+	standby       bool
+	live          *pb.State
+	notifications int
+}
+
+func (f *fakeTarget) IsLeader() bool { return !f.standby }
+
+func (f *fakeTarget) LiveState(context.Context, string) (*pb.State, error) { return f.live, nil }
+
+func (f *fakeTarget) ApplyState(_ context.Context, _ string, desired *pb.State, _ string) ([]*pb.StateChange, error) {
+	f.live = desired
+	return nil, nil
+}
+
+func (f *fakeTarget) NotifyDrift(string, []*pb.StateChange) { f.notifications++ }
+
+func TestDetectorNotifiesOncePerDrift(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desired.yaml")
+	document, err := state.Encode(&pb.State{Backends: []*pb.BackendState{{Backend: &pb.Backend{Name: "app"}}}}, pb.StateFormat_STATE_FORMAT_YAML)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	writeDocument(t, path, document)
+
+	target := &fakeTarget{live: &pb.State{}}
+	detector := NewDetector(config.DriftDetectionSettings{DesiredState: path, Instance: config.DefaultInstance}, target)
+
+	detector.Check(context.Background())
+	detector.Check(context.Background())
+	status := detector.Status()
+	if len(status.Changes) != 1 || status.Changes[0].Action != state.ActionCreate || status.Detected.IsZero() {
+		t.Fatalf("Expected the missing backend to be reported, got %+v", status)
+	}
+	if target.notifications != 1 {
+		t.Errorf("Expected one notification for unchanged drift, got %d", target.notifications)
+	}
+
+	// Standbys compare nothing
+	target.standby = true
+	target.live = &pb.State{Backends: []*pb.BackendState{{Backend: &pb.Backend{Name: "app"}}}}
+	detector.Check(context.Background())
+	if len(detector.Status().Changes) != 1 {
+		t.Error("Expected a standby to keep the last status")
+	}
+
+	target.standby = false
+	detector.Check(context.Background())
+	if status := detector.Status(); len(status.Changes) != 0 || !status.Detected.IsZero() {
+		t.Errorf("Expected the drift to be gone, got %+v", status)
+	}
+}
+
+// writeDocument writes a desired state document
+func writeDocument(t *testing.T, path, document string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
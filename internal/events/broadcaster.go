@@ -0,0 +1,110 @@
+package events
+
+import (
+	"sync"
+)
+
+// DefaultBufferSize is the number of events buffered per subscriber before it is considered too slow
+const DefaultBufferSize = 256
+
+// Subscription receives events published to a Broadcaster
+type Subscription[T any] struct {
+	// C delivers events in publish order. It is closed when the subscription is
+	// cancelled or when the subscriber falls too far behind (see Overflowed).
+	C <-chan T
+
+	ch          chan T
+	broadcaster *Broadcaster[T]
+	overflowed  bool
+}
+
+// Overflowed reports whether the subscription was closed because its buffer filled up.
+// It is only meaningful after C has been closed.
+func (s *Subscription[T]) Overflowed() bool {
+	s.broadcaster.mutex.RLock()
+	defer s.broadcaster.mutex.RUnlock()
+	return s.overflowed
+}
+
+// Cancel stops delivery and closes C. It is safe to call more than once.
+func (s *Subscription[T]) Cancel() {
+	s.broadcaster.remove(s, false)
+}
+
+// Broadcaster fans out published events to all current subscribers.
+// Publish never blocks: subscribers that cannot keep up are disconnected.
+type Broadcaster[T any] struct {
+	mutex       sync.RWMutex
+	subscribers map[*Subscription[T]]struct{}
+	bufferSize  int
+}
+
+// NewBroadcaster creates a Broadcaster whose subscribers buffer up to bufferSize events
+func NewBroadcaster[T any](bufferSize int) *Broadcaster[T] {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Broadcaster[T]{
+		subscribers: make(map[*Subscription[T]]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a new subscriber
+func (b *Broadcaster[T]) Subscribe() *Subscription[T] {
+	ch := make(chan T, b.bufferSize)
+	sub := &Subscription[T]{
+		C:           ch,
+		ch:          ch,
+		broadcaster: b,
+	}
+
+	b.mutex.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mutex.Unlock()
+
+	return sub
+}
+
+// Publish delivers the event to every subscriber
+func (b *Broadcaster[T]) Publish(event T) {
+	if b == nil {
+		return
+	}
+
+	var slow []*Subscription[T]
+
+	b.mutex.RLock()
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	b.mutex.RUnlock()
+
+	for _, sub := range slow {
+		b.remove(sub, true)
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *Broadcaster[T]) SubscriberCount() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return len(b.subscribers)
+}
+
+// remove unregisters a subscriber and closes its channel
+func (b *Broadcaster[T]) remove(sub *Subscription[T], overflowed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	sub.overflowed = overflowed
+	close(sub.ch)
+}
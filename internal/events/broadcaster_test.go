@@ -0,0 +1,62 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestBroadcasterDeliversToAllSubscribers(t *testing.T) {
+	broadcaster := NewBroadcaster[int](4)
+
+	first := broadcaster.Subscribe()
+	second := broadcaster.Subscribe()
+	if broadcaster.SubscriberCount() != 2 {
+		t.Fatalf("Expected 2 subscribers, got %d", broadcaster.SubscriberCount())
+	}
+
+	broadcaster.Publish(1)
+	broadcaster.Publish(2)
+
+	for _, sub := range []*Subscription[int]{first, second} {
+		for _, expected := range []int{1, 2} {
+			if got := <-sub.C; got != expected {
+				t.Errorf("Expected %d, got %d", expected, got)
+			}
+		}
+	}
+
+	first.Cancel()
+	first.Cancel() // Cancelling twice must be safe
+	if _, ok := <-first.C; ok {
+		t.Error("Expected channel to be closed after Cancel")
+	}
+	if first.Overflowed() {
+		t.Error("Cancelled subscription should not report overflow")
+	}
+	if broadcaster.SubscriberCount() != 1 {
+		t.Errorf("Expected 1 subscriber after cancel, got %d", broadcaster.SubscriberCount())
+	}
+}
+
+func TestBroadcasterDisconnectsSlowSubscribers(t *testing.T) {
+	broadcaster := NewBroadcaster[int](2)
+	slow := broadcaster.Subscribe()
+
+	// The third publish overflows the buffer and must not block
+	for i := 0; i < 3; i++ {
+		broadcaster.Publish(i)
+	}
+
+	received := 0
+	for range slow.C {
+		received++
+	}
+	if received != 2 {
+		t.Errorf("Expected the 2 buffered events before close, got %d", received)
+	}
+	if !slow.Overflowed() {
+		t.Error("Expected subscription to report overflow")
+	}
+	if broadcaster.SubscriberCount() != 0 {
+		t.Errorf("Expected slow subscriber to be removed, got %d subscribers", broadcaster.SubscriberCount())
+	}
+}
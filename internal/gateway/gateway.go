@@ -0,0 +1,157 @@
+// Package gateway exposes the gRPC API as REST/JSON over HTTP and describes it with an OpenAPI document
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// OpenAPIPath is the path the OpenAPI document is served at
+const OpenAPIPath = "/openapi.json"
+
+// bufferSize is the size of the in-memory connection buffer between the gateway and the gRPC server
+const bufferSize = 1024 * 1024
+
+// Options configures a Gateway
+type Options struct {
+	ForwardHeaders []string // HTTP headers passed on as gRPC metadata
+	GRPCWeb        bool     // Also accept gRPC-Web calls from browsers
+	AllowedOrigins []string // Origins browsers may call the gateway from; "*" allows any origin
+}
+
+// Gateway translates REST/JSON and optionally gRPC-Web requests into gRPC calls. Calls are passed
+// through an in-process gRPC server so that they run through the same interceptors as calls from
+// gRPC clients.
+type Gateway struct {
+	grpcServer     *grpc.Server
+	conn           *grpc.ClientConn
+	handler        http.Handler
+	grpcWeb        *grpcWebHandler
+	forwardHeaders []string
+	allowedOrigins []string
+}
+
+// New creates a gateway for the service. The server options (e.g. interceptors) are applied to the
+// in-process gRPC server.
+func New(service pb.HAProxyManagerServiceServer, options Options, opts ...grpc.ServerOption) (*Gateway, error) {
+	listener := bufconn.Listen(bufferSize)
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterHAProxyManagerServiceServer(grpcServer, service)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		grpcServer.Stop()
+		return nil, fmt.Errorf("failed to connect gateway to gRPC server: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher(options.ForwardHeaders)),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	if err := pb.RegisterHAProxyManagerServiceHandler(context.Background(), mux, conn); err != nil {
+		_ = conn.Close()
+		grpcServer.Stop()
+		return nil, fmt.Errorf("failed to register gateway handlers: %w", err)
+	}
+
+	document, err := OpenAPIDocument()
+	if err != nil {
+		_ = conn.Close()
+		grpcServer.Stop()
+		return nil, err
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc(OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(document)
+	})
+	handler.Handle("/", mux)
+
+	gateway := &Gateway{
+		grpcServer:     grpcServer,
+		conn:           conn,
+		handler:        handler,
+		forwardHeaders: options.ForwardHeaders,
+		allowedOrigins: options.AllowedOrigins,
+	}
+	if options.GRPCWeb {
+		gateway.grpcWeb = newGRPCWebHandler(conn, options.ForwardHeaders)
+	}
+	return gateway, nil
+}
+
+// ServeHTTP handles a REST or gRPC-Web request, or serves the OpenAPI document
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && g.originAllowed(origin) {
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Add("Vary", "Origin")
+		header.Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message")
+
+		// Answer CORS preflight requests directly
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			allowed := append([]string{"Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}, g.forwardHeaders...)
+			header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
+			header.Set("Access-Control-Allow-Headers", strings.Join(allowed, ", "))
+			header.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	if g.grpcWeb != nil && isGRPCWebRequest(r) {
+		g.grpcWeb.ServeHTTP(w, r)
+		return
+	}
+	g.handler.ServeHTTP(w, r)
+}
+
+// originAllowed reports whether browsers may call the gateway from origin
+func (g *Gateway) originAllowed(origin string) bool {
+	for _, allowed := range g.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close stops the in-process gRPC server
+func (g *Gateway) Close() {
+	_ = g.conn.Close()
+	g.grpcServer.Stop()
+}
+
+// headerMatcher forwards the given headers as lower-case metadata keys, and other
+// headers according to the grpc-gateway defaults
+func headerMatcher(forwardHeaders []string) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		for _, header := range forwardHeaders {
+			if strings.EqualFold(key, header) {
+				return strings.ToLower(header), true
+			}
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
@@ -0,0 +1,163 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeService answers ListBinds and GetBackend and records the instance metadata
+type fakeService struct {
+	pb.UnimplementedHAProxyManagerServiceServer
+	instance string
+}
+
+func (f *fakeService) ListBinds(ctx context.Context, req *pb.ListBindsRequest) (*pb.ListBindsResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-haproxy-instance")) > 0 {
+		f.instance = md.Get("x-haproxy-instance")[0]
+	}
+	return &pb.ListBindsResponse{Binds: []*pb.Bind{{Name: req.FrontendName + "-bind", Port: 443}}}, nil
+}
+
+func (f *fakeService) GetBackend(_ context.Context, req *pb.GetBackendRequest) (*pb.GetBackendResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "backend %s not found", req.Name)
+}
+
+func (f *fakeService) ApplyBackend(_ context.Context, req *pb.ApplyBackendRequest) (*pb.ApplyBackendResponse, error) {
+	return &pb.ApplyBackendResponse{Backend: req.Backend, Changed: true, Created: req.TransactionId == "txn-1"}, nil
+}
+
+func TestGatewayTranslatesRequests(t *testing.T) {
+	service := &fakeService{}
+	var intercepted bool
+	interceptor := grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		intercepted = true
+		return handler(ctx, req)
+	})
+
+	gw, err := New(service, Options{ForwardHeaders: []string{"x-haproxy-instance"}}, interceptor)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer gw.Close()
+
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/frontends/web/binds?transaction_id=txn-1", nil)
+	req.Header.Set("X-HAProxy-Instance", "edge-2")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", res.StatusCode, body)
+	}
+	if !strings.Contains(string(body), `"name":"web-bind"`) {
+		t.Errorf("Unexpected body: %s", body)
+	}
+	if !intercepted {
+		t.Error("Expected the call to pass through the interceptor")
+	}
+	if service.instance != "edge-2" {
+		t.Errorf("Expected instance header to be forwarded, got %q", service.instance)
+	}
+
+	res, err = http.Get(srv.URL + "/v1/backends/missing")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", res.StatusCode)
+	}
+}
+
+func TestGatewayApplyRoute(t *testing.T) {
+	gw, err := New(&fakeService{}, Options{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer gw.Close()
+
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	// The :apply verb must not be routed to UpdateBackend on the same path
+	req, _ := http.NewRequest(http.MethodPut, srv.URL+"/v1/backends/app:apply?transaction_id=txn-1", strings.NewReader(`{"mode":"PROXY_MODE_HTTP"}`))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", res.StatusCode, body)
+	}
+	for _, expected := range []string{`"name":"app"`, `"mode":"PROXY_MODE_HTTP"`, `"changed":true`, `"created":true`} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected %s in body: %s", expected, body)
+		}
+	}
+}
+
+func TestOpenAPIDocumentCoversService(t *testing.T) {
+	data, err := OpenAPIDocument()
+	if err != nil {
+		t.Fatalf("OpenAPIDocument failed: %v", err)
+	}
+
+	var document struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if document.OpenAPI != "3.0.3" {
+		t.Errorf("Unexpected OpenAPI version %q", document.OpenAPI)
+	}
+
+	operations := map[string]bool{}
+	for _, methods := range document.Paths {
+		for _, op := range methods {
+			operations[op.OperationID] = true
+		}
+	}
+	methods := pb.File_haproxy_proto.Services().ByName("HAProxyManagerService").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		if name := string(methods.Get(i).Name()); !operations[name] {
+			t.Errorf("Operation %s is missing from the document", name)
+		}
+	}
+
+	for _, name := range []string{"Backend", "Frontend", "Bind", "Server", "Status"} {
+		if _, ok := document.Components.Schemas[name]; !ok {
+			t.Errorf("Schema %s is missing from the document", name)
+		}
+	}
+
+	// Update and Get of a bind share one documented path
+	if _, ok := document.Paths["/v1/frontends/{frontend_name}/binds/{name}"]["put"]; !ok {
+		t.Error("Expected UpdateBind to be documented on the bind path")
+	}
+}
@@ -0,0 +1,305 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxGRPCWebRequestSize bounds the body of a gRPC-Web request
+const maxGRPCWebRequestSize = 4 * 1024 * 1024
+
+// gRPC-Web frame flags
+const (
+	dataFrame    byte = 0x00
+	trailerFrame byte = 0x80
+)
+
+// grpcWebHandler translates gRPC-Web requests, as sent by browser clients, into calls on the
+// in-process gRPC connection. Unary and server-streaming methods are supported, in binary
+// (application/grpc-web) and base64 text (application/grpc-web-text) encoding.
+type grpcWebHandler struct {
+	conn           *grpc.ClientConn
+	streaming      map[string]bool // full method name -> server streaming
+	forwardHeaders []string
+}
+
+// newGRPCWebHandler creates the translator for the methods of the HAProxy manager service
+func newGRPCWebHandler(conn *grpc.ClientConn, forwardHeaders []string) *grpcWebHandler {
+	desc := pb.HAProxyManagerService_ServiceDesc
+	streaming := make(map[string]bool)
+	for _, method := range desc.Methods {
+		streaming["/"+desc.ServiceName+"/"+method.MethodName] = false
+	}
+	for _, stream := range desc.Streams {
+		// Client streaming cannot be expressed over gRPC-Web
+		if !stream.ClientStreams {
+			streaming["/"+desc.ServiceName+"/"+stream.StreamName] = true
+		}
+	}
+
+	return &grpcWebHandler{
+		conn:           conn,
+		streaming:      streaming,
+		forwardHeaders: forwardHeaders,
+	}
+}
+
+// isGRPCWebRequest reports whether r is a gRPC-Web call
+func isGRPCWebRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web")
+}
+
+// ServeHTTP handles a single gRPC-Web call
+func (h *grpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, "application/grpc-web-text")
+	if text {
+		w.Header().Set("Content-Type", "application/grpc-web-text+proto")
+	} else {
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+	}
+
+	streaming, ok := h.streaming[r.URL.Path]
+	if !ok {
+		writeTrailers(w, text, status.Newf(codes.Unimplemented, "unknown method %s", r.URL.Path), nil)
+		return
+	}
+
+	message, err := readGRPCWebRequest(r.Body, text)
+	if err != nil {
+		writeTrailers(w, text, status.New(codes.InvalidArgument, err.Error()), nil)
+		return
+	}
+
+	ctx, cancel, err := h.callContext(r)
+	if err != nil {
+		writeTrailers(w, text, status.New(codes.InvalidArgument, err.Error()), nil)
+		return
+	}
+	defer cancel()
+
+	if streaming {
+		h.serveStream(ctx, w, r.URL.Path, message, text)
+		return
+	}
+
+	var response []byte
+	var header, trailer metadata.MD
+	err = h.conn.Invoke(ctx, r.URL.Path, &message, &response,
+		grpc.ForceCodec(rawCodec{}), grpc.Header(&header), grpc.Trailer(&trailer))
+
+	writeMetadata(w.Header(), header)
+	w.WriteHeader(http.StatusOK)
+	if err == nil {
+		writeFrame(w, text, dataFrame, response)
+	}
+	writeTrailers(w, text, status.Convert(err), trailer)
+}
+
+// serveStream relays a server-streaming call, flushing each message as it arrives
+func (h *grpcWebHandler) serveStream(ctx context.Context, w http.ResponseWriter, method string, message []byte, text bool) {
+	stream, err := h.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method, grpc.ForceCodec(rawCodec{}))
+	if err == nil {
+		err = stream.SendMsg(&message)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeTrailers(w, text, status.Convert(err), nil)
+		return
+	}
+
+	header, _ := stream.Header()
+	writeMetadata(w.Header(), header)
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	for {
+		var response []byte
+		if err := stream.RecvMsg(&response); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			writeTrailers(w, text, status.Convert(err), stream.Trailer())
+			return
+		}
+		writeFrame(w, text, dataFrame, response)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// callContext derives the context of the gRPC call from the request headers
+func (h *grpcWebHandler) callContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	md := metadata.MD{}
+	for _, header := range h.forwardHeaders {
+		if values := r.Header.Values(header); len(values) > 0 {
+			md.Set(strings.ToLower(header), values...)
+		}
+	}
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+	if value := r.Header.Get("grpc-timeout"); value != "" {
+		timeout, err := parseGRPCTimeout(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, cancel, nil
+}
+
+// writeTrailers writes the call status and trailer metadata as the final gRPC-Web frame
+func writeTrailers(w http.ResponseWriter, text bool, st *status.Status, trailer metadata.MD) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "grpc-status: %d\r\n", st.Code())
+	if st.Message() != "" {
+		fmt.Fprintf(&buf, "grpc-message: %s\r\n", encodeGRPCMessage(st.Message()))
+	}
+	for key, values := range trailer {
+		for _, value := range values {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+	writeFrame(w, text, trailerFrame, buf.Bytes())
+}
+
+// readGRPCWebRequest reads the single request message of a unary or server-streaming call
+func readGRPCWebRequest(body io.Reader, text bool) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxGRPCWebRequestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	if len(data) > maxGRPCWebRequestSize {
+		return nil, fmt.Errorf("request exceeds %d bytes", maxGRPCWebRequestSize)
+	}
+	if text {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 request body: %w", err)
+		}
+		data = decoded
+	}
+
+	if len(data) < 5 {
+		return nil, fmt.Errorf("request frame is truncated")
+	}
+	if data[0] != dataFrame {
+		return nil, fmt.Errorf("compressed requests are not supported")
+	}
+	length := binary.BigEndian.Uint32(data[1:5])
+	if uint32(len(data)-5) < length {
+		return nil, fmt.Errorf("request frame is truncated")
+	}
+	return data[5 : 5+length], nil
+}
+
+// writeFrame writes a length-prefixed gRPC-Web frame, base64 encoded in text mode
+func writeFrame(w io.Writer, text bool, flag byte, payload []byte) {
+	frame := make([]byte, 5+len(payload))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(payload)))
+	copy(frame[5:], payload)
+
+	if text {
+		_, _ = io.WriteString(w, base64.StdEncoding.EncodeToString(frame))
+		return
+	}
+	_, _ = w.Write(frame)
+}
+
+// writeMetadata copies response header metadata to the HTTP response headers
+func writeMetadata(header http.Header, md metadata.MD) {
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+}
+
+// parseGRPCTimeout parses a grpc-timeout header value such as "10S" or "500m"
+func parseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout unit in %q", value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// encodeGRPCMessage percent-encodes a status message as required for the grpc-message trailer
+func encodeGRPCMessage(message string) string {
+	var buf strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= 0x20 && c <= 0x7e && c != '%' {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
+
+// rawCodec passes already encoded protobuf messages through unchanged
+type rawCodec struct{}
+
+// Marshal returns the encoded message
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	data, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return *data, nil
+}
+
+// Unmarshal stores a copy of the encoded message
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	target, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*target = append([]byte(nil), data...)
+	return nil
+}
+
+// Name identifies the codec as protobuf on the wire
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func (f *fakeService) WatchChanges(req *pb.WatchChangesRequest, stream grpc.ServerStreamingServer[pb.WatchChangesResponse]) error {
+	for _, resourceType := range req.ResourceTypes {
+		if err := stream.Send(&pb.WatchChangesResponse{Event: &pb.Event{ResourceType: resourceType}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grpcWebFrame encodes a message as a gRPC-Web data frame
+func grpcWebFrame(t *testing.T, message proto.Message) []byte {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	frame := make([]byte, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(data)))
+	copy(frame[5:], data)
+	return frame
+}
+
+// readGRPCWebFrames splits a gRPC-Web response body into data frames and the trailer frame
+func readGRPCWebFrames(t *testing.T, body []byte) ([][]byte, string) {
+	var messages [][]byte
+	for len(body) >= 5 {
+		length := binary.BigEndian.Uint32(body[1:5])
+		payload := body[5 : 5+length]
+		if body[0] == trailerFrame {
+			return messages, string(payload)
+		}
+		messages = append(messages, payload)
+		body = body[5+length:]
+	}
+	t.Fatal("Response has no trailer frame")
+	return nil, ""
+}
+
+func newGRPCWebTestServer(t *testing.T) *httptest.Server {
+	gw, err := New(&fakeService{}, Options{
+		ForwardHeaders: []string{"x-haproxy-instance"},
+		GRPCWeb:        true,
+		AllowedOrigins: []string{"https://dashboard.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(gw.Close)
+
+	srv := httptest.NewServer(gw)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	srv := newGRPCWebTestServer(t)
+
+	body := grpcWebFrame(t, &pb.ListBindsRequest{FrontendName: "web"})
+	res, err := http.Post(srv.URL+"/haproxy.v1.HAProxyManagerService/ListBinds", "application/grpc-web+proto", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	data, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	messages, trailers := readGRPCWebFrames(t, data)
+	if !strings.Contains(trailers, "grpc-status: 0") {
+		t.Fatalf("Expected OK status, got %q", trailers)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var response pb.ListBindsResponse
+	if err := proto.Unmarshal(messages[0], &response); err != nil {
+		t.Fatalf("Invalid response message: %v", err)
+	}
+	if len(response.Binds) != 1 || response.Binds[0].Name != "web-bind" {
+		t.Errorf("Unexpected response: %v", &response)
+	}
+
+	// Errors are reported in the trailer frame
+	body = grpcWebFrame(t, &pb.GetBackendRequest{Name: "missing"})
+	res, err = http.Post(srv.URL+"/haproxy.v1.HAProxyManagerService/GetBackend", "application/grpc-web+proto", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	data, _ = io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if _, trailers := readGRPCWebFrames(t, data); !strings.Contains(trailers, "grpc-status: 5") {
+		t.Errorf("Expected NOT_FOUND status, got %q", trailers)
+	}
+}
+
+func TestGRPCWebTextStreaming(t *testing.T) {
+	srv := newGRPCWebTestServer(t)
+
+	body := base64.StdEncoding.EncodeToString(grpcWebFrame(t, &pb.WatchChangesRequest{ResourceTypes: []string{"backend", "server"}}))
+	res, err := http.Post(srv.URL+"/haproxy.v1.HAProxyManagerService/WatchChanges", "application/grpc-web-text", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	encoded, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	// Every frame is base64 encoded separately, so decode quantum by quantum
+	var data []byte
+	for i := 0; i+4 <= len(encoded); i += 4 {
+		decoded, err := base64.StdEncoding.DecodeString(string(encoded[i : i+4]))
+		if err != nil {
+			t.Fatalf("Invalid base64 response: %v", err)
+		}
+		data = append(data, decoded...)
+	}
+
+	messages, trailers := readGRPCWebFrames(t, data)
+	if !strings.Contains(trailers, "grpc-status: 0") {
+		t.Fatalf("Expected OK status, got %q", trailers)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 streamed messages, got %d", len(messages))
+	}
+}
+
+func TestGatewayCORS(t *testing.T) {
+	srv := newGRPCWebTestServer(t)
+
+	req, _ := http.NewRequest(http.MethodOptions, srv.URL+"/haproxy.v1.HAProxyManagerService/ListFrontends", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", res.StatusCode)
+	}
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "https://dashboard.example.com" {
+		t.Errorf("Unexpected Access-Control-Allow-Origin %q", got)
+	}
+
+	req.Header.Set("Origin", "https://evil.example.com")
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_ = res.Body.Close()
+	if res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Expected no CORS headers for an unknown origin")
+	}
+}
@@ -0,0 +1,403 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPI v3 document types, limited to what the generator emits
+
+type openAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openAPIInfo                      `json:"info"`
+	Tags       []openAPITag                     `json:"tags"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components openAPIComponents                `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPITag struct {
+	Name string `json:"name"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*schema `json:"schemas"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags"`
+	Description string               `json:"description,omitempty"`
+	Parameters  []parameter          `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+}
+
+// statusSchemaName is the component describing error responses (google.rpc.Status)
+const statusSchemaName = "Status"
+
+// pathParameterPattern matches the variables of a google.api.http path template
+var pathParameterPattern = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// OpenAPIDocument generates the OpenAPI v3 document of the REST gateway from the
+// google.api.http annotations and message descriptors of the service
+func OpenAPIDocument() ([]byte, error) {
+	service := pb.File_haproxy_proto.Services().ByName("HAProxyManagerService")
+	if service == nil {
+		return nil, fmt.Errorf("HAProxyManagerService descriptor not found")
+	}
+
+	g := &generator{
+		pkg:     service.ParentFile().Package(),
+		schemas: map[string]*schema{},
+	}
+	document := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "HAProxy Configurator",
+			Description: "REST gateway of the HAProxy Configurator gRPC API. Errors are returned as google.rpc.Status objects.",
+			Version:     "v1",
+		},
+		Paths: map[string]map[string]*operation{},
+	}
+
+	tags := map[string]bool{}
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		httpMethod, pathTemplate := httpPattern(rule)
+		if httpMethod == "" {
+			continue
+		}
+
+		path, op := g.operation(method, pathTemplate, rule.GetBody())
+		if !tags[op.Tags[0]] {
+			tags[op.Tags[0]] = true
+			document.Tags = append(document.Tags, openAPITag{Name: op.Tags[0]})
+		}
+		if document.Paths[path] == nil {
+			document.Paths[path] = map[string]*operation{}
+		}
+		document.Paths[path][strings.ToLower(httpMethod)] = op
+	}
+
+	g.schemas[statusSchemaName] = &schema{
+		Type: "object",
+		Properties: map[string]*schema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+			"details": {Type: "array", Items: &schema{Type: "object"}},
+		},
+	}
+	document.Components.Schemas = g.schemas
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return data, nil
+}
+
+// httpPattern returns the HTTP method and path template of a rule
+func httpPattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+}
+
+// generator collects the schemas of all messages referenced by the operations
+type generator struct {
+	pkg     protoreflect.FullName
+	schemas map[string]*schema
+}
+
+// operation builds the OpenAPI operation of a method mapped to pathTemplate
+func (g *generator) operation(method protoreflect.MethodDescriptor, pathTemplate, body string) (string, *operation) {
+	input := method.Input()
+	op := &operation{
+		OperationID: string(method.Name()),
+		Tags:        []string{resourceTag(input)},
+		Responses:   map[string]*response{},
+	}
+
+	// Path variables
+	bound := map[string]bool{}
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(pathTemplate, -1) {
+		name := match[1]
+		bound[name] = true
+		op.Parameters = append(op.Parameters, parameter{
+			Name:     pathParameterName(name),
+			In:       "path",
+			Required: true,
+			Schema:   g.fieldSchema(lookupField(input, name)),
+		})
+	}
+	path := pathParameterPattern.ReplaceAllStringFunc(pathTemplate, func(variable string) string {
+		return "{" + pathParameterName(pathParameterPattern.FindStringSubmatch(variable)[1]) + "}"
+	})
+
+	// Request body
+	switch body {
+	case "":
+	case "*":
+		op.RequestBody = &requestBody{
+			Required: true,
+			Content:  jsonContent(g.messageSchema(input)),
+		}
+	default:
+		bound[body] = true
+		op.RequestBody = &requestBody{
+			Required: true,
+			Content:  jsonContent(g.fieldSchema(input.Fields().ByName(protoreflect.Name(body)))),
+		}
+	}
+
+	// Remaining scalar fields are accepted as query parameters
+	if body != "*" {
+		fields := input.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			name := string(field.Name())
+			if bound[name] || isPathPrefix(bound, name) {
+				continue
+			}
+			if field.Kind() == protoreflect.MessageKind && !isScalarMessage(field.Message()) {
+				continue
+			}
+			if field.IsMap() {
+				continue
+			}
+			op.Parameters = append(op.Parameters, parameter{
+				Name:   name,
+				In:     "query",
+				Schema: g.fieldSchema(field),
+			})
+		}
+	}
+
+	result := g.messageSchema(method.Output())
+	description := "OK"
+	if method.IsStreamingServer() {
+		// Streamed responses are newline-delimited JSON objects wrapping each message in "result"
+		result = &schema{
+			Type:       "object",
+			Properties: map[string]*schema{"result": result, "error": {Ref: schemaRef(statusSchemaName)}},
+		}
+		description = "Stream of newline-delimited JSON objects, one per message"
+		op.Description = "Server-streaming call; the connection stays open and each message is written as it is produced."
+	}
+	op.Responses["200"] = &response{Description: description, Content: jsonContent(result)}
+	op.Responses["default"] = &response{
+		Description: "Error",
+		Content:     jsonContent(&schema{Ref: schemaRef(statusSchemaName)}),
+	}
+
+	return path, op
+}
+
+// messageSchema returns a reference to the schema of a message, registering it on first use
+func (g *generator) messageSchema(message protoreflect.MessageDescriptor) *schema {
+	if s := wellKnownSchema(message); s != nil {
+		return s
+	}
+
+	name := g.schemaName(message)
+	if _, ok := g.schemas[name]; !ok {
+		s := &schema{Type: "object", Properties: map[string]*schema{}}
+		g.schemas[name] = s
+
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			s.Properties[string(field.Name())] = g.fieldSchema(field)
+		}
+	}
+	return &schema{Ref: schemaRef(name)}
+}
+
+// fieldSchema returns the schema of a field, including repeated and map fields
+func (g *generator) fieldSchema(field protoreflect.FieldDescriptor) *schema {
+	if field == nil {
+		return &schema{Type: "string"}
+	}
+	if field.IsMap() {
+		return &schema{Type: "object", AdditionalProperties: g.singularSchema(field.MapValue())}
+	}
+	if field.IsList() {
+		return &schema{Type: "array", Items: g.singularSchema(field)}
+	}
+	return g.singularSchema(field)
+}
+
+// singularSchema returns the schema of a single field value, following protojson encoding
+func (g *generator) singularSchema(field protoreflect.FieldDescriptor) *schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		return &schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind:
+		return &schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		s := &schema{Type: "string"}
+		for i := 0; i < values.Len(); i++ {
+			s.Enum = append(s.Enum, string(values.Get(i).Name()))
+		}
+		return s
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageSchema(field.Message())
+	default:
+		return &schema{Type: "string"}
+	}
+}
+
+// schemaName returns the component name of a message, relative to the service package
+func (g *generator) schemaName(message protoreflect.MessageDescriptor) string {
+	return strings.TrimPrefix(string(message.FullName()), string(g.pkg)+".")
+}
+
+// wellKnownSchema returns the JSON representation of well-known types, or nil for other messages
+func wellKnownSchema(message protoreflect.MessageDescriptor) *schema {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp":
+		return &schema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration":
+		return &schema{Type: "string"}
+	case "google.protobuf.Struct":
+		return &schema{Type: "object"}
+	case "google.protobuf.Empty":
+		return &schema{Type: "object"}
+	default:
+		return nil
+	}
+}
+
+// isScalarMessage reports whether a message is encoded as a JSON scalar and can be a query parameter
+func isScalarMessage(message protoreflect.MessageDescriptor) bool {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return true
+	default:
+		return false
+	}
+}
+
+// isPathPrefix reports whether name is the message field of a nested path variable such as bind.name
+func isPathPrefix(bound map[string]bool, name string) bool {
+	for variable := range bound {
+		if strings.HasPrefix(variable, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// pathParameterName names a path variable after its last field, so that /binds/{bind.name} and
+// /binds/{name} are documented as the same path
+func pathParameterName(fieldPath string) string {
+	return fieldPath[strings.LastIndex(fieldPath, ".")+1:]
+}
+
+// lookupField resolves a possibly nested field path such as bind.name
+func lookupField(message protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var field protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if message == nil {
+			return nil
+		}
+		field = message.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil
+		}
+		message = field.Message()
+	}
+	return field
+}
+
+// resourceTag groups operations by the proto file declaring their request, e.g. "backend"
+func resourceTag(input protoreflect.MessageDescriptor) string {
+	return strings.TrimSuffix(input.ParentFile().Path(), ".proto")
+}
+
+// schemaRef returns the reference to a component schema
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// jsonContent wraps a schema as an application/json media type
+func jsonContent(s *schema) map[string]*mediaType {
+	return map[string]*mediaType{"application/json": {Schema: s}}
+}
@@ -0,0 +1,166 @@
+// Package gitops continuously reconciles HAProxy and Netplan with state manifests from a directory or git repository
+package gitops
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+)
+
+// ApplyFunc makes the live configuration match the desired state and returns the operations performed
+type ApplyFunc func(ctx context.Context, desired *pb.State) ([]*pb.StateChange, error)
+
+// Status describes the progress of the controller
+type Status struct {
+	Source          string
+	Revision        string // Last revision applied successfully
+	FetchedRevision string // Last revision read from the source
+	LastSync        time.Time
+	LastApplied     time.Time
+	LastError       string
+	LastChanges     []*pb.StateChange // Operations of the last apply that changed anything
+}
+
+// watchable is implemented by sources that can report changes without polling
+type watchable interface {
+	Watch(ctx context.Context, onChange func()) error
+}
+
+// Controller periodically syncs the source and applies its manifests. Every interval the manifests are
+// applied even if the revision is unchanged, so that changes made outside of GitOps are reverted.
+type Controller struct {
+	source   Source
+	apply    ApplyFunc
+	interval time.Duration
+	trigger  chan struct{}
+
+	mutex  sync.RWMutex
+	status Status
+}
+
+// NewController creates a controller for the configured source
+func NewController(settings config.GitOpsSettings, apply ApplyFunc) *Controller {
+	var source Source
+	if settings.Repository != "" {
+		source = NewGitSource(settings.Repository, settings.Branch, settings.CheckoutDir, settings.Path)
+	} else {
+		source = NewDirectorySource(settings.Path)
+	}
+	return newController(source, time.Duration(settings.IntervalSeconds)*time.Second, apply)
+}
+
+// newController creates a controller for an arbitrary source
+func newController(source Source, interval time.Duration, apply ApplyFunc) *Controller {
+	return &Controller{
+		source:   source,
+		apply:    apply,
+		interval: interval,
+		trigger:  make(chan struct{}, 1),
+		status:   Status{Source: source.Describe()},
+	}
+}
+
+// Run reconciles immediately and then on every interval or source change, until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) {
+	if w, ok := c.source.(watchable); ok {
+		if err := w.Watch(ctx, c.Trigger); err != nil {
+			logger.GetLogger().Warn("Failed to watch GitOps manifests, relying on polling",
+				zap.String("source", c.source.Describe()),
+				zap.Error(err))
+		}
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.trigger:
+		}
+	}
+}
+
+// Trigger requests a reconciliation without waiting for the next interval
+func (c *Controller) Trigger() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Reconcile syncs the source once and applies its manifests
+func (c *Controller) Reconcile(ctx context.Context) {
+	dir, revision, err := c.source.Sync(ctx)
+	if err != nil {
+		c.fail("", err)
+		return
+	}
+
+	desired, err := state.LoadManifests(dir)
+	if err != nil {
+		c.fail(revision, err)
+		return
+	}
+
+	changes, err := c.apply(ctx, desired)
+	if err != nil {
+		c.fail(revision, err)
+		return
+	}
+
+	c.mutex.Lock()
+	previous := c.status.Revision
+	now := time.Now()
+	c.status.FetchedRevision = revision
+	c.status.Revision = revision
+	c.status.LastSync = now
+	c.status.LastError = ""
+	if len(changes) > 0 || previous != revision {
+		c.status.LastApplied = now
+	}
+	if len(changes) > 0 {
+		c.status.LastChanges = changes
+	}
+	c.mutex.Unlock()
+
+	if len(changes) > 0 || previous != revision {
+		logger.GetLogger().Info("Applied GitOps manifests",
+			zap.String("source", c.source.Describe()),
+			zap.String("revision", revision),
+			zap.Int("changes", len(changes)))
+	}
+}
+
+// fail records a failed reconciliation; the last applied revision stays in place
+func (c *Controller) fail(revision string, err error) {
+	logger.GetLogger().Error("GitOps reconciliation failed",
+		zap.String("source", c.source.Describe()),
+		zap.String("revision", revision),
+		zap.Error(err))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if revision != "" {
+		c.status.FetchedRevision = revision
+	}
+	c.status.LastSync = time.Now()
+	c.status.LastError = err.Error()
+}
+
+// Status returns a snapshot of the controller status
+func (c *Controller) Status() Status {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.status
+}
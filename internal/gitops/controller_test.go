@@ -0,0 +1,166 @@
+package gitops
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+)
+
+const backendManifest = `backends:
+  - backend:
+      name: app
+    servers:
+      - name: app1
+        address: 10.0.0.1
+        port: 8080
+`
+
+const frontendManifest = `{"frontends": [{"frontend": {"name": "web", "default_backend": "app"}, "binds": [{"name": "vip", "address": "192.168.1.100", "port": 443}]}]}`
+
+// recordingApply captures the desired states it is called with
+type recordingApply struct {
+	calls []*pb.State
+	err   error
+}
+
+func (r *recordingApply) apply(_ context.Context, desired *pb.State) ([]*pb.StateChange, error) {
+	r.calls = append(r.calls, desired)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return []*pb.StateChange{{ResourceType: "backend", Action: "create", Name: "app"}}, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestControllerReconcile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "app.yaml"), backendManifest)
+
+	recorder := &recordingApply{}
+	controller := newController(NewDirectorySource(dir), time.Hour, recorder.apply)
+	controller.Reconcile(context.Background())
+
+	status := controller.Status()
+	if len(recorder.calls) != 1 || status.LastError != "" {
+		t.Fatalf("Expected one successful apply, got %d calls and error %q", len(recorder.calls), status.LastError)
+	}
+	if !strings.HasPrefix(status.Revision, "sha256:") || status.Revision != status.FetchedRevision {
+		t.Errorf("Unexpected revisions %q / %q", status.Revision, status.FetchedRevision)
+	}
+	if len(status.LastChanges) != 1 {
+		t.Errorf("Expected the changes of the apply, got %v", status.LastChanges)
+	}
+
+	// A failed apply keeps the last applied revision
+	applied := status.Revision
+	writeFile(t, filepath.Join(dir, "app.yaml"), strings.Replace(backendManifest, "8080", "9090", 1))
+	recorder.err = errors.New("commit failed")
+	controller.Reconcile(context.Background())
+
+	status = controller.Status()
+	if status.Revision != applied || status.FetchedRevision == applied {
+		t.Errorf("Expected revision %s to stay applied while %s failed", applied, status.FetchedRevision)
+	}
+	if status.LastError == "" {
+		t.Error("Expected the error to be reported")
+	}
+
+	// Invalid manifests never reach the apply function
+	writeFile(t, filepath.Join(dir, "broken.yaml"), "frontends: [")
+	controller.Reconcile(context.Background())
+	if len(recorder.calls) != 2 {
+		t.Errorf("Expected invalid manifests not to be applied, got %d calls", len(recorder.calls))
+	}
+}
+
+func TestControllerWatchesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "app.yaml"), backendManifest)
+
+	applied := make(chan struct{}, 10)
+	controller := newController(NewDirectorySource(dir), time.Hour, func(context.Context, *pb.State) ([]*pb.StateChange, error) {
+		applied <- struct{}{}
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go controller.Run(ctx)
+
+	<-applied
+	writeFile(t, filepath.Join(dir, "web.json"), frontendManifest)
+
+	select {
+	case <-applied:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a file change to trigger a reconciliation")
+	}
+}
+
+func TestGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	run("init", "--quiet", "--initial-branch=main")
+	writeFile(t, filepath.Join(repo, "haproxy", "app.yaml"), backendManifest)
+	run("add", ".")
+	run("commit", "--quiet", "-m", "Add app backend")
+	first := run("rev-parse", "HEAD")
+
+	source := NewGitSource(repo, "main", filepath.Join(t.TempDir(), "checkout"), "haproxy")
+	dir, revision, err := source.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if revision != first {
+		t.Errorf("Expected revision %s, got %s", first, revision)
+	}
+	if _, err := state.LoadManifests(dir); err != nil {
+		t.Errorf("LoadManifests failed on the checkout: %v", err)
+	}
+
+	writeFile(t, filepath.Join(repo, "haproxy", "web.json"), frontendManifest)
+	run("add", ".")
+	run("commit", "--quiet", "-m", "Add web frontend")
+	second := run("rev-parse", "HEAD")
+
+	if _, revision, err = source.Sync(context.Background()); err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if revision != second {
+		t.Errorf("Expected revision %s after fetch, got %s", second, revision)
+	}
+}
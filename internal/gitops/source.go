@@ -0,0 +1,191 @@
+package gitops
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events a checkout or editor save produces
+const watchDebounce = 500 * time.Millisecond
+
+// Source provides the manifest directory and the revision it is at
+type Source interface {
+	// Sync brings the manifests up to date and returns their directory and revision
+	Sync(ctx context.Context) (dir, revision string, err error)
+	// Describe identifies the source in logs and status
+	Describe() string
+}
+
+// directorySource reads manifests from a local directory
+// The revision is a hash of the manifest contents, as a directory has no history
+type directorySource struct {
+	path string
+}
+
+// NewDirectorySource creates a source for a local manifest directory
+func NewDirectorySource(path string) Source {
+	return &directorySource{path: path}
+}
+
+// Sync hashes the manifests in the directory
+func (d *directorySource) Sync(_ context.Context) (string, string, error) {
+	files, err := state.ManifestFiles(d.path)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read manifest %s: %w", file, err)
+		}
+		rel, _ := filepath.Rel(d.path, file)
+		_, _ = io.WriteString(hash, rel+"\x00")
+		_, err = io.Copy(hash, f)
+		_ = f.Close()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read manifest %s: %w", file, err)
+		}
+	}
+	return d.path, "sha256:" + hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
+
+// Describe returns the directory path
+func (d *directorySource) Describe() string {
+	return d.path
+}
+
+// Watch calls onChange when a file in the directory tree changes, until ctx is cancelled
+func (d *directorySource) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	err = filepath.WalkDir(d.path, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != d.path && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", d.path, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+				// Pick up directories created after the watch started
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watcher.Add(event.Name)
+				}
+
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(watchDebounce, onChange)
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// gitSource clones a git repository and follows a branch
+type gitSource struct {
+	repository  string
+	branch      string
+	checkoutDir string
+	path        string // Manifest directory relative to the repository root
+}
+
+// NewGitSource creates a source that clones repository into checkoutDir and reads manifests from path within it
+func NewGitSource(repository, branch, checkoutDir, path string) Source {
+	return &gitSource{
+		repository:  repository,
+		branch:      branch,
+		checkoutDir: checkoutDir,
+		path:        path,
+	}
+}
+
+// Sync clones the repository on first use and afterwards fetches the branch and resets the checkout to it
+func (g *gitSource) Sync(ctx context.Context) (string, string, error) {
+	if _, err := os.Stat(filepath.Join(g.checkoutDir, ".git")); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(g.checkoutDir), 0755); err != nil {
+			return "", "", fmt.Errorf("failed to create checkout directory: %w", err)
+		}
+		if _, err := g.git(ctx, "", "clone", "--quiet", "--branch", g.branch, "--single-branch", g.repository, g.checkoutDir); err != nil {
+			return "", "", err
+		}
+	} else {
+		if _, err := g.git(ctx, g.checkoutDir, "fetch", "--quiet", "origin", g.branch); err != nil {
+			return "", "", err
+		}
+		if _, err := g.git(ctx, g.checkoutDir, "reset", "--quiet", "--hard", "FETCH_HEAD"); err != nil {
+			return "", "", err
+		}
+	}
+
+	revision, err := g.git(ctx, g.checkoutDir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(g.checkoutDir, g.path), revision, nil
+}
+
+// Describe returns the repository and branch
+func (g *gitSource) Describe() string {
+	return g.repository + "@" + g.branch
+}
+
+// git runs a git command and returns its trimmed output
+func (g *gitSource) git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Never prompt for credentials; they must come from the URL, a credential helper or SSH keys
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
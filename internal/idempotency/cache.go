@@ -0,0 +1,150 @@
+// Package idempotency remembers the responses of calls carrying an idempotency key, so that a client retrying
+// a call whose response it did not receive gets the original response instead of applying the change twice
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Defaults used when a Cache is created without a TTL or key limit
+const (
+	defaultTTL     = 10 * time.Minute
+	defaultMaxKeys = 10000
+)
+
+// ErrKeyReused is returned when a key is sent again with a different method or request
+var ErrKeyReused = errors.New("idempotency key was already used for a different request")
+
+// entry is a call started with a key; done is closed once it finished
+type entry struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	response    proto.Message // Set if the call succeeded
+	expires     time.Time
+}
+
+// expired reports whether a successful call is no longer remembered
+func (e *entry) expired(now time.Time) bool {
+	return e.response != nil && now.After(e.expires)
+}
+
+// queued is a key in the order its call started
+type queued struct {
+	key   string
+	entry *entry
+}
+
+// Cache remembers the responses of successful calls by key. Failed calls are forgotten, so they can be retried.
+type Cache struct {
+	ttl     time.Duration
+	maxKeys int
+
+	mutex   sync.Mutex
+	entries map[string]*entry
+	order   []queued // For evicting the oldest keys first
+}
+
+// NewCache creates a cache remembering up to maxKeys responses for ttl each
+func NewCache(ttl time.Duration, maxKeys int) *Cache {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	if maxKeys <= 0 {
+		maxKeys = defaultMaxKeys
+	}
+	return &Cache{ttl: ttl, maxKeys: maxKeys, entries: make(map[string]*entry)}
+}
+
+// Do calls fn unless a call with the same key succeeded before, in which case its response is returned and
+// replayed is true. A call with the same key still in progress is waited for. The method and request must
+// match those of the first call with the key.
+func (c *Cache) Do(ctx context.Context, key, method string, request proto.Message, fn func() (proto.Message, error)) (response proto.Message, replayed bool, err error) {
+	fingerprint, err := fingerprintOf(method, request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for {
+		c.mutex.Lock()
+		now := time.Now()
+		c.evict(now)
+		existing, ok := c.entries[key]
+		if !ok || existing.expired(now) {
+			current := &entry{fingerprint: fingerprint, done: make(chan struct{})}
+			c.entries[key] = current
+			c.order = append(c.order, queued{key: key, entry: current})
+			c.mutex.Unlock()
+			return c.run(key, current, fn)
+		}
+		c.mutex.Unlock()
+
+		if existing.fingerprint != fingerprint {
+			return nil, false, ErrKeyReused
+		}
+		select {
+		case <-existing.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if existing.response != nil {
+			return proto.Clone(existing.response), true, nil
+		}
+		// The first call failed and was forgotten, so this one applies the change
+	}
+}
+
+// run calls fn for a new entry and records its outcome
+func (c *Cache) run(key string, current *entry, fn func() (proto.Message, error)) (proto.Message, bool, error) {
+	response, err := fn()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err != nil || response == nil {
+		if c.entries[key] == current {
+			delete(c.entries, key)
+		}
+	} else {
+		current.response = proto.Clone(response)
+		current.expires = time.Now().Add(c.ttl)
+	}
+	close(current.done)
+	return response, false, err
+}
+
+// evict forgets the oldest keys while they are expired or beyond the limit. Keys expiring out of order are
+// replaced when reused. The caller must hold the mutex.
+func (c *Cache) evict(now time.Time) {
+	for len(c.order) > 0 {
+		oldest := c.order[0]
+		current := c.entries[oldest.key] == oldest.entry
+		if current && !oldest.entry.expired(now) && len(c.entries) < c.maxKeys {
+			return
+		}
+		if current {
+			delete(c.entries, oldest.key)
+		}
+		c.order = c.order[1:]
+	}
+}
+
+// Len returns the number of remembered keys, including calls in progress
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
+
+// fingerprintOf identifies a call by its method and request
+func fingerprintOf(method string, request proto.Message) ([sha256.Size]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(append([]byte(method+"\x00"), data...)), nil
+}
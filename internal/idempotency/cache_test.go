@@ -0,0 +1,106 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDoReplaysSuccessfulCalls(t *testing.T) {
+	cache := NewCache(time.Minute, 10)
+	request := &pb.CreateBackendRequest{TransactionId: "txn", Backend: &pb.Backend{Name: "app"}}
+	calls := 0
+	fn := func() (proto.Message, error) {
+		calls++
+		return &pb.CreateBackendResponse{Backend: &pb.Backend{Id: int32(calls), Name: "app"}}, nil
+	}
+
+	first, replayed, err := cache.Do(context.Background(), "key", "CreateBackend", request, fn)
+	if err != nil || replayed {
+		t.Fatalf("Expected the first call to run, got replayed=%v err=%v", replayed, err)
+	}
+	second, replayed, err := cache.Do(context.Background(), "key", "CreateBackend", request, fn)
+	if err != nil || !replayed || calls != 1 || !proto.Equal(first, second) {
+		t.Errorf("Expected the first response to be replayed, got %v replayed=%v calls=%d err=%v", second, replayed, calls, err)
+	}
+
+	other := &pb.CreateBackendRequest{TransactionId: "txn", Backend: &pb.Backend{Name: "other"}}
+	if _, _, err := cache.Do(context.Background(), "key", "CreateBackend", other, fn); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Expected ErrKeyReused for a different request, got %v", err)
+	}
+	if _, _, err := cache.Do(context.Background(), "key", "UpdateBackend", request, fn); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Expected ErrKeyReused for a different method, got %v", err)
+	}
+}
+
+func TestDoForgetsFailedCalls(t *testing.T) {
+	cache := NewCache(time.Minute, 10)
+	request := &pb.DeleteBackendRequest{Name: "app"}
+
+	failure := errors.New("unavailable")
+	if _, _, err := cache.Do(context.Background(), "key", "DeleteBackend", request, func() (proto.Message, error) {
+		return nil, failure
+	}); !errors.Is(err, failure) {
+		t.Fatalf("Expected the failure, got %v", err)
+	}
+	if _, replayed, err := cache.Do(context.Background(), "key", "DeleteBackend", request, func() (proto.Message, error) {
+		return &pb.DeleteBackendResponse{}, nil
+	}); err != nil || replayed {
+		t.Errorf("Expected a failed call to be retried, got replayed=%v err=%v", replayed, err)
+	}
+}
+
+func TestDoWaitsForCallInProgress(t *testing.T) {
+	cache := NewCache(time.Minute, 10)
+	request := &pb.DeleteBackendRequest{Name: "app"}
+	started, release := make(chan struct{}), make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _, _ = cache.Do(context.Background(), "key", "DeleteBackend", request, func() (proto.Message, error) {
+			close(started)
+			<-release
+			return &pb.DeleteBackendResponse{}, nil
+		})
+	}()
+	<-started
+
+	go close(release)
+	_, replayed, err := cache.Do(context.Background(), "key", "DeleteBackend", request, func() (proto.Message, error) {
+		t.Error("Expected the duplicate call not to run")
+		return nil, nil
+	})
+	wg.Wait()
+	if err != nil || !replayed {
+		t.Errorf("Expected the duplicate to get the first response, got replayed=%v err=%v", replayed, err)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	cache := NewCache(time.Millisecond, 2)
+	fn := func() (proto.Message, error) { return &pb.DeleteBackendResponse{}, nil }
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, _, err := cache.Do(context.Background(), key, "DeleteBackend", &pb.DeleteBackendRequest{Name: key}, fn); err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected the oldest key to be evicted beyond the limit, got %d keys", cache.Len())
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if _, replayed, _ := cache.Do(context.Background(), "c", "DeleteBackend", &pb.DeleteBackendRequest{Name: "c"}, fn); replayed {
+		t.Error("Expected an expired key to run again")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected expired keys to be evicted, got %d keys", cache.Len())
+	}
+}
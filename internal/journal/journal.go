@@ -0,0 +1,263 @@
+package journal
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultListLimit is the number of events returned by List when no limit is given
+const DefaultListLimit = 100
+
+var eventsBucket = []byte("events")
+
+// Event represents a single configuration change recorded in the journal
+type Event struct {
+	ID            uint64          `json:"id"`
+	Timestamp     time.Time       `json:"timestamp"`
+	ResourceType  string          `json:"resource_type"` // "backend", "frontend", "bind", "server", "route", "rate_limit_policy", "lua_script", "lua_action", "ring", "ring_log_target", "program", "http_check" or "transaction"
+	ResourceName  string          `json:"resource_name"`
+	ParentName    string          `json:"parent_name,omitempty"` // Frontend for binds, backend for servers
+	Action        string          `json:"action"`                // "create", "update", "delete", "commit" or "close"
+	TransactionID string          `json:"transaction_id,omitempty"`
+	OldValue      json.RawMessage `json:"old_value,omitempty"`
+	NewValue      json.RawMessage `json:"new_value,omitempty"`
+}
+
+// Filter restricts the events returned by List. Zero values match everything.
+type Filter struct {
+	ResourceType  string
+	ResourceName  string
+	TransactionID string
+	Since         time.Time
+	Until         time.Time
+	Limit         int
+}
+
+// matches reports whether the event satisfies every filter condition
+func (f Filter) matches(e *Event) bool {
+	if f.ResourceType != "" && e.ResourceType != f.ResourceType {
+		return false
+	}
+	if f.ResourceName != "" && e.ResourceName != f.ResourceName {
+		return false
+	}
+	if f.TransactionID != "" && e.TransactionID != f.TransactionID {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Store persists events in an embedded bbolt database
+type Store struct {
+	db        *bolt.DB
+	retention time.Duration
+}
+
+// Open opens (or creates) the journal database at path.
+// Events older than retention are pruned on open; a zero retention keeps events forever.
+func Open(path string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal database: %w", err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(eventsBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize journal database: %w", err)
+	}
+
+	store := &Store{db: db, retention: retention}
+	if retention > 0 {
+		if _, err := store.Prune(time.Now().Add(-retention)); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// Append records an event, assigning its ID and (if unset) its timestamp
+func (s *Store) Append(event *Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return fmt.Errorf("failed to allocate event ID: %w", err)
+		}
+		event.ID = id
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		return bucket.Put(encodeID(id), data)
+	})
+}
+
+// List returns events matching the filter, newest first
+func (s *Store) List(filter Filter) ([]Event, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	var events []Event
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(eventsBucket).Cursor()
+
+		for k, v := cursor.Last(); k != nil && len(events) < limit; k, v = cursor.Prev() {
+			var event Event
+			if err := json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("failed to parse event %d: %w", decodeID(k), err)
+			}
+
+			// Events are stored in insertion order, so nothing older can match
+			if !filter.Since.IsZero() && event.Timestamp.Before(filter.Since) {
+				break
+			}
+
+			if filter.matches(&event) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// After returns up to limit events with an ID larger than id, oldest first
+func (s *Store) After(id uint64, limit int) ([]Event, error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	var events []Event
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(eventsBucket).Cursor()
+
+		for k, v := cursor.Seek(encodeID(id + 1)); k != nil && len(events) < limit; k, v = cursor.Next() {
+			var event Event
+			if err := json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("failed to parse event %d: %w", decodeID(k), err)
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LastID returns the ID of the newest event, or 0 if the journal is empty
+func (s *Store) LastID() (uint64, error) {
+	var id uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if k, _ := tx.Bucket(eventsBucket).Cursor().Last(); k != nil {
+			id = decodeID(k)
+		}
+		return nil
+	})
+	return id, err
+}
+
+// Import records events copied from another journal, keeping their IDs so that both journals agree.
+// An event with the ID of an existing one replaces it; later appends continue after the largest ID.
+func (s *Store) Import(events []Event) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		for i := range events {
+			data, err := json.Marshal(&events[i])
+			if err != nil {
+				return fmt.Errorf("failed to marshal event: %w", err)
+			}
+			if err := bucket.Put(encodeID(events[i].ID), data); err != nil {
+				return err
+			}
+			if events[i].ID > bucket.Sequence() {
+				if err := bucket.SetSequence(events[i].ID); err != nil {
+					return fmt.Errorf("failed to advance event ID: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Prune deletes all events recorded before cutoff and returns how many were removed
+func (s *Store) Prune(cutoff time.Time) (int, error) {
+	removed := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(eventsBucket).Cursor()
+
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			var event Event
+			if err := json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("failed to parse event %d: %w", decodeID(k), err)
+			}
+			if !event.Timestamp.Before(cutoff) {
+				break
+			}
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune journal: %w", err)
+	}
+
+	return removed, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// encodeID converts an event ID to a big-endian key so keys sort in insertion order
+func encodeID(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
+
+// decodeID converts a big-endian key back into an event ID
+func decodeID(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key)
+}
@@ -0,0 +1,146 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "journal.db"), 0)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestAppendAndList(t *testing.T) {
+	store := openTestStore(t)
+
+	events := []*Event{
+		{ResourceType: "backend", ResourceName: "web", Action: "create", TransactionID: "tx-1"},
+		{ResourceType: "server", ResourceName: "web-1", ParentName: "web", Action: "create", TransactionID: "tx-1"},
+		{ResourceType: "backend", ResourceName: "api", Action: "create", TransactionID: "tx-2"},
+		{ResourceType: "backend", ResourceName: "web", Action: "update", TransactionID: "tx-2"},
+	}
+	for _, e := range events {
+		if err := store.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	// IDs are assigned sequentially
+	for i, e := range events {
+		if e.ID != uint64(i+1) {
+			t.Errorf("Expected event %d to have ID %d, got %d", i, i+1, e.ID)
+		}
+	}
+
+	all, err := store.List(Filter{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(all))
+	}
+	if all[0].ID != 4 || all[3].ID != 1 {
+		t.Errorf("Expected newest first, got IDs %d..%d", all[0].ID, all[3].ID)
+	}
+
+	web, err := store.List(Filter{ResourceType: "backend", ResourceName: "web"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(web) != 2 || web[0].Action != "update" || web[1].Action != "create" {
+		t.Errorf("Unexpected events for backend web: %+v", web)
+	}
+
+	tx, err := store.List(Filter{TransactionID: "tx-1"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tx) != 2 {
+		t.Errorf("Expected 2 events for tx-1, got %d", len(tx))
+	}
+
+	limited, err := store.List(Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(limited) != 1 || limited[0].ID != 4 {
+		t.Errorf("Expected only the newest event, got %+v", limited)
+	}
+}
+
+func TestListTimeRangeAndPrune(t *testing.T) {
+	store := openTestStore(t)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		e := &Event{ResourceType: "frontend", ResourceName: "fe", Action: "update", Timestamp: base.Add(time.Duration(i) * time.Hour)}
+		if err := store.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	ranged, err := store.List(Filter{Since: base.Add(1 * time.Hour), Until: base.Add(3 * time.Hour)})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(ranged) != 3 {
+		t.Errorf("Expected 3 events in range, got %d", len(ranged))
+	}
+
+	removed, err := store.Prune(base.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 events pruned, got %d", removed)
+	}
+
+	remaining, err := store.List(Filter{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(remaining) != 3 {
+		t.Errorf("Expected 3 remaining events, got %d", len(remaining))
+	}
+}
+
+func TestImportAndAfter(t *testing.T) {
+	source, target := openTestStore(t), openTestStore(t)
+
+	for _, name := range []string{"web", "api", "db"} {
+		if err := source.Append(&Event{ResourceType: "backend", ResourceName: name, Action: "create"}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	events, err := source.After(1, 0)
+	if err != nil {
+		t.Fatalf("After failed: %v", err)
+	}
+	if len(events) != 2 || events[0].ResourceName != "api" || events[1].ResourceName != "db" {
+		t.Fatalf("Expected the events after ID 1 oldest first, got %+v", events)
+	}
+
+	if err := target.Import(events); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if id, err := target.LastID(); err != nil || id != 3 {
+		t.Errorf("Expected the imported IDs to be kept, got %d, %v", id, err)
+	}
+
+	// Appends continue after the imported events
+	event := &Event{ResourceType: "backend", ResourceName: "cache", Action: "create"}
+	if err := target.Append(event); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if event.ID != 4 {
+		t.Errorf("Expected ID 4 after the import, got %d", event.ID)
+	}
+}
@@ -0,0 +1,41 @@
+// Package kubernetes contains the Kubernetes controllers that reconcile HAProxy from cluster resources
+package kubernetes
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// NewRESTConfig loads the client configuration from the kubeconfig at path, or from the in-cluster service
+// account if path is empty
+func NewRESTConfig(path string) (*rest.Config, error) {
+	var restConfig *rest.Config
+	var err error
+	if path != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", path)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Kubernetes client configuration: %w", err)
+	}
+	return restConfig, nil
+}
+
+// NewDynamicClient connects to the API server with the kubeconfig at path, or with the
+// in-cluster service account if path is empty
+func NewDynamicClient(path string) (dynamic.Interface, error) {
+	restConfig, err := NewRESTConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return client, nil
+}
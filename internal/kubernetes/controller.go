@@ -0,0 +1,208 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// settleDelay batches the events of several resources applied together, e.g. by kubectl apply -f dir/
+const settleDelay = time.Second
+
+// ApplyFunc makes the live configuration match the desired state and returns the operations performed.
+// With a prefix, only frontends and backends named with it are managed.
+type ApplyFunc func(ctx context.Context, desired *pb.State, prefix string) ([]*pb.StateChange, error)
+
+// Controller reconciles an HAProxy instance with the cluster. In operator mode the HAProxyFrontend,
+// HAProxyBackend and HAProxyBind resources describe the complete configuration of the instance; Services of
+// type LoadBalancer add frontends and backends named with LoadBalancerPrefix. Both are applied together.
+type Controller struct {
+	client   dynamic.Interface
+	settings config.KubernetesSettings
+	pools    []addressPool
+	factory  dynamicinformer.DynamicSharedInformerFactory
+	listers  map[schema.GroupVersionResource]cache.GenericLister
+	apply    ApplyFunc
+	trigger  chan struct{}
+}
+
+// NewController creates a controller for the enabled features, watching the configured namespace or all
+// namespaces. Besides reacting to changes it reconciles every resync interval to revert changes made outside
+// of Kubernetes.
+func NewController(client dynamic.Interface, settings config.KubernetesSettings, apply ApplyFunc) (*Controller, error) {
+	c := &Controller{
+		client:   client,
+		settings: settings,
+		factory:  dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, settings.Namespace, nil),
+		listers:  make(map[schema.GroupVersionResource]cache.GenericLister),
+		apply:    apply,
+		trigger:  make(chan struct{}, 1),
+	}
+
+	var resources []schema.GroupVersionResource
+	if settings.Operator {
+		resources = append(resources, FrontendResource, BackendResource, BindResource)
+	}
+	if settings.LoadBalancer.Enabled {
+		pools, err := parsePools(settings.LoadBalancer.Pools)
+		if err != nil {
+			return nil, err
+		}
+		c.pools = pools
+		resources = append(resources, serviceResource, endpointSliceResource)
+	}
+
+	for _, resource := range resources {
+		informer := c.factory.ForResource(resource)
+		_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { c.Trigger() },
+			UpdateFunc: func(interface{}, interface{}) { c.Trigger() },
+			DeleteFunc: func(interface{}) { c.Trigger() },
+		})
+		c.listers[resource] = informer.Lister()
+	}
+	return c, nil
+}
+
+// Trigger requests a reconciliation
+func (c *Controller) Trigger() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run watches the cluster and reconciles until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) error {
+	c.factory.Start(ctx.Done())
+	for resource, synced := range c.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync %s from the API server", resource.Resource)
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(c.settings.ResyncIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-c.trigger:
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(settleDelay):
+			}
+			// Events that arrived while settling are covered by this reconciliation
+			select {
+			case <-c.trigger:
+			default:
+			}
+		}
+
+		c.Reconcile(ctx)
+	}
+}
+
+// Reconcile builds the desired state from the cached resources, applies it and reports the result
+func (c *Controller) Reconcile(ctx context.Context) {
+	desired := &pb.State{}
+
+	var resources *customResources
+	if c.settings.Operator {
+		resources = c.collectCustomResources(desired)
+		// Applying without the invalid resources would delete their live counterparts, so nothing is applied
+		if len(resources.invalid) > 0 {
+			logger.GetLogger().Warn("Kubernetes resources are invalid, skipping reconciliation",
+				zap.Int("invalid_resources", len(resources.invalid)))
+			c.reportInvalid(ctx, resources)
+			return
+		}
+	}
+
+	var balancers []loadBalancer
+	prefix := ""
+	if c.settings.LoadBalancer.Enabled {
+		balancers = c.collectLoadBalancers(desired)
+		if !c.settings.Operator {
+			prefix = LoadBalancerPrefix
+		}
+	}
+
+	message := ""
+	if err := state.Validate(desired); err != nil {
+		message = fmt.Sprintf("invalid configuration: %v", err)
+	} else if changes, err := c.apply(ctx, desired, prefix); err != nil {
+		message = fmt.Sprintf("failed to apply configuration: %v", err)
+	} else if len(changes) > 0 {
+		logger.GetLogger().Info("Applied Kubernetes resources",
+			zap.Int("frontends", len(desired.Frontends)),
+			zap.Int("backends", len(desired.Backends)),
+			zap.Int("changes", len(changes)))
+	}
+	if message != "" {
+		logger.GetLogger().Error("Kubernetes reconciliation failed",
+			zap.String("error", message))
+	}
+
+	if resources != nil {
+		c.reportStatus(ctx, resources, message)
+	}
+	// Addresses are only published once they are configured
+	if message == "" {
+		c.publishLoadBalancers(ctx, balancers)
+	}
+}
+
+// list returns the cached resources of a type, ordered by namespace and name
+func (c *Controller) list(resource schema.GroupVersionResource) []*unstructured.Unstructured {
+	objects, err := c.listers[resource].List(labels.Everything())
+	if err != nil {
+		logger.GetLogger().Error("Failed to list cached Kubernetes resources",
+			zap.String("resource", resource.Resource),
+			zap.Error(err))
+		return nil
+	}
+
+	var result []*unstructured.Unstructured
+	for _, object := range objects {
+		if obj, ok := object.(*unstructured.Unstructured); ok && obj.GetDeletionTimestamp() == nil {
+			result = append(result, obj)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetNamespace() != result[j].GetNamespace() {
+			return result[i].GetNamespace() < result[j].GetNamespace()
+		}
+		return result[i].GetName() < result[j].GetName()
+	})
+	return result
+}
+
+// decodeSpec decodes the spec of obj into spec
+func decodeSpec(obj *unstructured.Unstructured, spec interface{}) error {
+	content, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err == nil {
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(content, spec)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
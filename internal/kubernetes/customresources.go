@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// customResources are the HAProxy custom resources seen by one reconciliation
+type customResources struct {
+	objects map[schema.GroupVersionResource][]*unstructured.Unstructured
+	invalid map[*unstructured.Unstructured]string // Resource -> reason it cannot be applied
+}
+
+// collectCustomResources adds the frontends, backends and binds declared by custom resources to desired
+func (c *Controller) collectCustomResources(desired *pb.State) *customResources {
+	frontends := c.list(FrontendResource)
+	backends := c.list(BackendResource)
+	binds := c.list(BindResource)
+
+	resources := &customResources{
+		objects: map[schema.GroupVersionResource][]*unstructured.Unstructured{
+			FrontendResource: frontends,
+			BackendResource:  backends,
+			BindResource:     binds,
+		},
+		invalid: make(map[*unstructured.Unstructured]string),
+	}
+	frontendIndex := make(map[string]*pb.FrontendState)
+
+	for _, obj := range frontends {
+		var spec FrontendSpec
+		if err := decodeSpec(obj, &spec); err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		frontend, err := spec.toProto(resourceName(obj, spec.Name))
+		if err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		entry := &pb.FrontendState{Frontend: frontend}
+		desired.Frontends = append(desired.Frontends, entry)
+		frontendIndex[frontend.Name] = entry
+	}
+	for _, obj := range backends {
+		var spec BackendSpec
+		if err := decodeSpec(obj, &spec); err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		backend, err := spec.toProto(resourceName(obj, spec.Name))
+		if err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		desired.Backends = append(desired.Backends, backend)
+	}
+	for _, obj := range binds {
+		var spec BindSpec
+		if err := decodeSpec(obj, &spec); err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		bind, err := spec.toProto(resourceName(obj, spec.Name))
+		if err != nil {
+			resources.invalid[obj] = err.Error()
+			continue
+		}
+		frontend, ok := frontendIndex[spec.Frontend]
+		if !ok {
+			resources.invalid[obj] = fmt.Sprintf("frontend %s does not exist", spec.Frontend)
+			continue
+		}
+		frontend.Binds = append(frontend.Binds, bind)
+	}
+
+	return resources
+}
+
+// reportInvalid marks the invalid custom resources as not ready with the reason
+func (c *Controller) reportInvalid(ctx context.Context, resources *customResources) {
+	for resource, objects := range resources.objects {
+		for _, obj := range objects {
+			if message, ok := resources.invalid[obj]; ok {
+				c.updateResourceStatus(ctx, resource, obj, ResourceStatus{ObservedGeneration: obj.GetGeneration(), Message: message})
+			}
+		}
+	}
+}
+
+// reportStatus reports the outcome of an apply on every custom resource
+func (c *Controller) reportStatus(ctx context.Context, resources *customResources, message string) {
+	for resource, objects := range resources.objects {
+		for _, obj := range objects {
+			c.updateResourceStatus(ctx, resource, obj, ResourceStatus{
+				ObservedGeneration: obj.GetGeneration(),
+				Ready:              message == "",
+				Message:            message,
+			})
+		}
+	}
+}
+
+// updateResourceStatus writes the status of a custom resource unless it is already up to date
+func (c *Controller) updateResourceStatus(ctx context.Context, resource schema.GroupVersionResource, obj *unstructured.Unstructured, status ResourceStatus) {
+	if current, ok, _ := unstructured.NestedMap(obj.Object, "status"); ok {
+		var previous ResourceStatus
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current, &previous); err == nil && previous == status {
+			return
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err == nil {
+		updated := obj.DeepCopy()
+		if err = unstructured.SetNestedMap(updated.Object, content, "status"); err == nil {
+			_, err = c.client.Resource(resource).Namespace(obj.GetNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		logger.GetLogger().Warn("Failed to update Kubernetes resource status",
+			zap.String("resource", resource.Resource),
+			zap.String("namespace", obj.GetNamespace()),
+			zap.String("name", obj.GetName()),
+			zap.Error(err))
+	}
+}
+
+// resourceName returns the HAProxy name of a resource: the name in its spec, or else the resource name
+func resourceName(obj *unstructured.Unstructured, specName string) string {
+	if specName != "" {
+		return specName
+	}
+	return obj.GetName()
+}
@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newResource builds a custom resource with the given spec
+func newResource(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(Group + "/" + Version)
+	obj.SetKind(kind)
+	obj.SetNamespace("lb")
+	obj.SetName(name)
+	obj.SetGeneration(1)
+	return obj
+}
+
+func newFakeClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		FrontendResource:      "HAProxyFrontendList",
+		BackendResource:       "HAProxyBackendList",
+		BindResource:          "HAProxyBindList",
+		serviceResource:       "ServiceList",
+		endpointSliceResource: "EndpointSliceList",
+	}, objects...)
+}
+
+// resourceStatus reads the status the operator wrote to a resource
+func resourceStatus(t *testing.T, client *dynamicfake.FakeDynamicClient, resource schema.GroupVersionResource, name string) ResourceStatus {
+	t.Helper()
+	obj, err := client.Resource(resource).Namespace("lb").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get %s: %v", name, err)
+	}
+	var status ResourceStatus
+	content, _, _ := unstructured.NestedMap(obj.Object, "status")
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &status); err != nil {
+		t.Fatalf("Invalid status on %s: %v", name, err)
+	}
+	return status
+}
+
+// startController runs a controller until the test ends and waits for its caches to sync
+func startController(t *testing.T, client *dynamicfake.FakeDynamicClient, settings config.KubernetesSettings, apply ApplyFunc) *Controller {
+	t.Helper()
+	settings.Namespace = "lb"
+	settings.ResyncIntervalSeconds = 3600
+	controller, err := NewController(client, settings, apply)
+	if err != nil {
+		t.Fatalf("NewController failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	controller.factory.Start(ctx.Done())
+	controller.factory.WaitForCacheSync(ctx.Done())
+	return controller
+}
+
+func TestOperatorReconcile(t *testing.T) {
+	client := newFakeClient(
+		newResource("HAProxyBackend", "app", map[string]interface{}{
+			"mode":    "http",
+			"balance": "roundrobin",
+			"servers": []interface{}{map[string]interface{}{"name": "app1", "address": "10.0.0.1", "port": int64(8080)}},
+		}),
+		newResource("HAProxyFrontend", "web", map[string]interface{}{"name": "www", "defaultBackend": "app"}),
+		newResource("HAProxyBind", "web-vip", map[string]interface{}{"frontend": "www", "address": "192.168.1.100", "port": int64(443)}),
+	)
+
+	var desired *pb.State
+	operator := startController(t, client, config.KubernetesSettings{Operator: true}, func(_ context.Context, state *pb.State, _ string) ([]*pb.StateChange, error) {
+		desired = state
+		return nil, nil
+	})
+	operator.Reconcile(context.Background())
+
+	if desired == nil {
+		t.Fatal("Expected the resources to be applied")
+	}
+	if len(desired.Frontends) != 1 || desired.Frontends[0].Frontend.Name != "www" || len(desired.Frontends[0].Binds) != 1 {
+		t.Errorf("Unexpected frontends %v", desired.Frontends)
+	}
+	backend := desired.Backends[0]
+	if backend.Backend.Mode != pb.ProxyMode_PROXY_MODE_HTTP ||
+		backend.Backend.Balance.GetAlgorithm() != pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN ||
+		len(backend.Servers) != 1 || backend.Servers[0].Port != 8080 {
+		t.Errorf("Unexpected backend %v", backend)
+	}
+
+	status := resourceStatus(t, client, BindResource, "web-vip")
+	if !status.Ready || status.ObservedGeneration != 1 {
+		t.Errorf("Expected the bind to be ready, got %+v", status)
+	}
+}
+
+func TestOperatorInvalidResourceBlocksApply(t *testing.T) {
+	client := newFakeClient(
+		newResource("HAProxyFrontend", "web", map[string]interface{}{"mode": "udp"}),
+		newResource("HAProxyBind", "orphan", map[string]interface{}{"frontend": "missing", "address": "192.168.1.100", "port": int64(80)}),
+	)
+
+	applied := false
+	operator := startController(t, client, config.KubernetesSettings{Operator: true}, func(context.Context, *pb.State, string) ([]*pb.StateChange, error) {
+		applied = true
+		return nil, nil
+	})
+	operator.Reconcile(context.Background())
+
+	if applied {
+		t.Error("Expected nothing to be applied while a resource is invalid")
+	}
+	if status := resourceStatus(t, client, FrontendResource, "web"); status.Ready || status.Message != `unknown mode "udp"` {
+		t.Errorf("Unexpected frontend status %+v", status)
+	}
+	if status := resourceStatus(t, client, BindResource, "orphan"); status.Ready || status.Message == "" {
+		t.Errorf("Unexpected bind status %+v", status)
+	}
+}
+
+func TestOperatorReportsApplyErrors(t *testing.T) {
+	client := newFakeClient(newResource("HAProxyBackend", "app", map[string]interface{}{}))
+
+	operator := startController(t, client, config.KubernetesSettings{Operator: true}, func(context.Context, *pb.State, string) ([]*pb.StateChange, error) {
+		return nil, errors.New("commit failed")
+	})
+	operator.Reconcile(context.Background())
+
+	status := resourceStatus(t, client, BackendResource, "app")
+	if status.Ready || status.Message != "failed to apply configuration: commit failed" {
+		t.Errorf("Unexpected backend status %+v", status)
+	}
+}
@@ -0,0 +1,325 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// LoadBalancerPrefix starts the names of the frontends and backends created for Services of type LoadBalancer
+const LoadBalancerPrefix = "k8s-"
+
+// PoolAnnotation selects the address pool of a Service; without it the first pool is used
+const PoolAnnotation = Group + "/address-pool"
+
+// Resources watched for Services of type LoadBalancer
+var (
+	serviceResource       = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	endpointSliceResource = schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+)
+
+// addressRange is an inclusive range of VIPs
+type addressRange struct {
+	first netip.Addr
+	last  netip.Addr
+}
+
+// addressPool is a named set of VIPs allocated to Services
+type addressPool struct {
+	name   string
+	ranges []addressRange
+}
+
+// parsePools parses the configured address pools
+func parsePools(pools []config.AddressPool) ([]addressPool, error) {
+	var result []addressPool
+	for _, pool := range pools {
+		parsed := addressPool{name: pool.Name}
+		for _, value := range pool.Addresses {
+			first, last, err := config.ParseAddressRange(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address pool %s: %w", pool.Name, err)
+			}
+			parsed.ranges = append(parsed.ranges, addressRange{first: first, last: last})
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
+// contains reports whether addr belongs to the pool
+func (p *addressPool) contains(addr netip.Addr) bool {
+	for _, r := range p.ranges {
+		if addr.BitLen() == r.first.BitLen() && r.first.Compare(addr) <= 0 && addr.Compare(r.last) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the lowest address of the pool that is not used
+func (p *addressPool) next(used map[netip.Addr]bool) (netip.Addr, bool) {
+	for _, r := range p.ranges {
+		for addr := r.first; addr.IsValid() && addr.Compare(r.last) <= 0; addr = addr.Next() {
+			if !used[addr] {
+				return addr, true
+			}
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// loadBalancer is a Service of type LoadBalancer with its allocated VIP
+type loadBalancer struct {
+	object  *unstructured.Unstructured
+	service *corev1.Service
+	address netip.Addr
+}
+
+// collectLoadBalancers allocates VIPs to the Services of type LoadBalancer and adds a frontend and backend per
+// TCP port to desired
+func (c *Controller) collectLoadBalancers(desired *pb.State) []loadBalancer {
+	var candidates []loadBalancer
+	for _, obj := range c.list(serviceResource) {
+		var service corev1.Service
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &service); err != nil {
+			logger.GetLogger().Warn("Failed to decode Service",
+				zap.String("namespace", obj.GetNamespace()),
+				zap.String("name", obj.GetName()),
+				zap.Error(err))
+			continue
+		}
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer || !c.servesClass(service.Spec.LoadBalancerClass) {
+			continue
+		}
+		candidates = append(candidates, loadBalancer{object: obj, service: &service})
+	}
+
+	balancers := c.allocate(candidates)
+	endpoints := c.endpointSlices()
+	for _, lb := range balancers {
+		addLoadBalancer(desired, lb, endpoints[lb.service.Namespace+"/"+lb.service.Name])
+	}
+	return balancers
+}
+
+// servesClass reports whether Services with the given load balancer class are served
+func (c *Controller) servesClass(class *string) bool {
+	if class == nil {
+		return c.settings.LoadBalancer.Class == ""
+	}
+	return *class == c.settings.LoadBalancer.Class
+}
+
+// allocate assigns a VIP to each Service. Addresses already published in the status or requested in
+// spec.loadBalancerIP are kept when they are free and in the Service's pool, so VIPs stay stable across
+// restarts; the remaining Services get the lowest free address of their pool.
+func (c *Controller) allocate(candidates []loadBalancer) []loadBalancer {
+	used := make(map[netip.Addr]bool)
+	pools := make([]*addressPool, len(candidates))
+
+	for i := range candidates {
+		lb := &candidates[i]
+		pool := c.poolFor(lb.service)
+		if pool == nil {
+			continue
+		}
+		pools[i] = pool
+		for _, requested := range requestedAddresses(lb.service) {
+			if pool.contains(requested) && !used[requested] {
+				lb.address = requested
+				used[requested] = true
+				break
+			}
+		}
+	}
+
+	var result []loadBalancer
+	for i, lb := range candidates {
+		if pools[i] == nil {
+			continue
+		}
+		if !lb.address.IsValid() {
+			addr, ok := pools[i].next(used)
+			if !ok {
+				logger.GetLogger().Warn("Address pool is exhausted, Service is not served",
+					zap.String("pool", pools[i].name),
+					zap.String("namespace", lb.service.Namespace),
+					zap.String("name", lb.service.Name))
+				continue
+			}
+			lb.address = addr
+			used[addr] = true
+		}
+		result = append(result, lb)
+	}
+	return result
+}
+
+// poolFor returns the address pool selected by a Service, or nil if it names an unknown pool
+func (c *Controller) poolFor(service *corev1.Service) *addressPool {
+	name, ok := service.Annotations[PoolAnnotation]
+	if !ok {
+		return &c.pools[0]
+	}
+	for i := range c.pools {
+		if c.pools[i].name == name {
+			return &c.pools[i]
+		}
+	}
+	logger.GetLogger().Warn("Service selects an unknown address pool",
+		zap.String("pool", name),
+		zap.String("namespace", service.Namespace),
+		zap.String("name", service.Name))
+	return nil
+}
+
+// requestedAddresses returns the addresses a Service should preferably keep: the one in
+// spec.loadBalancerIP, then the ones already published in its status
+func requestedAddresses(service *corev1.Service) []netip.Addr {
+	var result []netip.Addr
+	if addr, err := netip.ParseAddr(service.Spec.LoadBalancerIP); err == nil {
+		result = append(result, addr)
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if addr, err := netip.ParseAddr(ingress.IP); err == nil {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// endpointSlices returns the cached EndpointSlices by namespace/service name
+func (c *Controller) endpointSlices() map[string][]*discoveryv1.EndpointSlice {
+	result := make(map[string][]*discoveryv1.EndpointSlice)
+	for _, obj := range c.list(endpointSliceResource) {
+		service := obj.GetLabels()[discoveryv1.LabelServiceName]
+		if service == "" {
+			continue
+		}
+		var slice discoveryv1.EndpointSlice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &slice); err != nil {
+			logger.GetLogger().Warn("Failed to decode EndpointSlice",
+				zap.String("namespace", obj.GetNamespace()),
+				zap.String("name", obj.GetName()),
+				zap.Error(err))
+			continue
+		}
+		key := obj.GetNamespace() + "/" + service
+		result[key] = append(result[key], &slice)
+	}
+	return result
+}
+
+// addLoadBalancer adds a frontend with a bind on the VIP and a backend with the ready endpoints as servers
+// for every TCP port of the Service
+func addLoadBalancer(desired *pb.State, lb loadBalancer, slices []*discoveryv1.EndpointSlice) {
+	for _, port := range lb.service.Spec.Ports {
+		if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		name := fmt.Sprintf("%s%s-%s-%d", LoadBalancerPrefix, lb.service.Namespace, lb.service.Name, port.Port)
+
+		desired.Frontends = append(desired.Frontends, &pb.FrontendState{
+			Frontend: &pb.Frontend{Name: name, Mode: pb.ProxyMode_PROXY_MODE_TCP, DefaultBackend: name},
+			Binds:    []*pb.Bind{{Name: "vip", Address: lb.address.String(), Port: port.Port}},
+		})
+		desired.Backends = append(desired.Backends, &pb.BackendState{
+			Backend: &pb.Backend{Name: name, Mode: pb.ProxyMode_PROXY_MODE_TCP, Balance: &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN}},
+			Servers: endpointServers(slices, port.Name, lb.address.Is4()),
+		})
+	}
+}
+
+// endpointServers returns a server per ready endpoint address serving the named Service port. Slices of the
+// VIP's address family are used, since HAProxy reaches the endpoints from the VIP's network.
+func endpointServers(slices []*discoveryv1.EndpointSlice, portName string, ipv4 bool) []*pb.Server {
+	addressType := discoveryv1.AddressTypeIPv6
+	if ipv4 {
+		addressType = discoveryv1.AddressTypeIPv4
+	}
+
+	servers := make(map[string]*pb.Server)
+	for _, slice := range slices {
+		if slice.AddressType != addressType {
+			continue
+		}
+		var target int32
+		for _, port := range slice.Ports {
+			if port.Port != nil && derefString(port.Name) == portName && (port.Protocol == nil || *port.Protocol == corev1.ProtocolTCP) {
+				target = *port.Port
+				break
+			}
+		}
+		if target == 0 {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				name := strings.NewReplacer(".", "-", ":", "-").Replace(address)
+				servers[name] = &pb.Server{Name: name, Address: address, Port: target}
+			}
+		}
+	}
+
+	result := make([]*pb.Server, 0, len(servers))
+	for _, server := range servers {
+		result = append(result, server)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// publishLoadBalancers writes the allocated VIPs to the status of the Services
+func (c *Controller) publishLoadBalancers(ctx context.Context, balancers []loadBalancer) {
+	for _, lb := range balancers {
+		ingress := lb.service.Status.LoadBalancer.Ingress
+		if len(ingress) == 1 && ingress[0].IP == lb.address.String() && ingress[0].Hostname == "" {
+			continue
+		}
+
+		updated := lb.object.DeepCopy()
+		err := unstructured.SetNestedSlice(updated.Object, []interface{}{
+			map[string]interface{}{"ip": lb.address.String()},
+		}, "status", "loadBalancer", "ingress")
+		if err == nil {
+			_, err = c.client.Resource(serviceResource).Namespace(lb.service.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			logger.GetLogger().Warn("Failed to publish the load balancer address of a Service",
+				zap.String("namespace", lb.service.Namespace),
+				zap.String("name", lb.service.Name),
+				zap.String("address", lb.address.String()),
+				zap.Error(err))
+			continue
+		}
+		logger.GetLogger().Info("Published load balancer address",
+			zap.String("namespace", lb.service.Namespace),
+			zap.String("name", lb.service.Name),
+			zap.String("address", lb.address.String()))
+	}
+}
+
+// derefString returns the value of s, or "" if it is nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
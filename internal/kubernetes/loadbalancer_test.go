@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var loadBalancerSettings = config.KubernetesSettings{
+	LoadBalancer: config.LoadBalancerSettings{
+		Enabled: true,
+		Pools: []config.AddressPool{
+			{Name: "default", Addresses: []string{"192.168.1.200-192.168.1.201"}},
+			{Name: "internal", Addresses: []string{"10.10.0.0/31"}},
+		},
+	},
+}
+
+// toUnstructured converts a typed Kubernetes object for the fake client
+func toUnstructured(t *testing.T, obj runtime.Object, apiVersion, kind string) *unstructured.Unstructured {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("Failed to convert %T: %v", obj, err)
+	}
+	result := &unstructured.Unstructured{Object: content}
+	result.SetAPIVersion(apiVersion)
+	result.SetKind(kind)
+	return result
+}
+
+func newService(t *testing.T, name string, mutate func(*corev1.Service)) *unstructured.Unstructured {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "lb", Name: name},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	if mutate != nil {
+		mutate(service)
+	}
+	return toUnstructured(t, service, "v1", "Service")
+}
+
+func newEndpointSlice(t *testing.T, service string, ready bool, addresses ...string) *unstructured.Unstructured {
+	name, port := "http", int32(8080)
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "lb",
+			Name:      service + "-abcde",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       []discoveryv1.EndpointPort{{Name: &name, Port: &port}},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: addresses, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+		},
+	}
+	return toUnstructured(t, slice, "discovery.k8s.io/v1", "EndpointSlice")
+}
+
+// serviceIngress reads the load balancer addresses published on a Service
+func serviceIngress(t *testing.T, client *dynamicfake.FakeDynamicClient, name string) []string {
+	t.Helper()
+	obj, err := client.Resource(serviceResource).Namespace("lb").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get Service %s: %v", name, err)
+	}
+	var service corev1.Service
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &service); err != nil {
+		t.Fatalf("Invalid Service %s: %v", name, err)
+	}
+	var result []string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		result = append(result, ingress.IP)
+	}
+	return result
+}
+
+func TestLoadBalancerReconcile(t *testing.T) {
+	client := newFakeClient(
+		newService(t, "web", nil),
+		newEndpointSlice(t, "web", true, "10.0.0.1", "10.0.0.2"),
+		newEndpointSlice(t, "other", true, "10.0.0.9"),
+		newService(t, "cluster-ip", func(s *corev1.Service) { s.Spec.Type = corev1.ServiceTypeClusterIP }),
+	)
+
+	var desired *pb.State
+	var prefix string
+	controller := startController(t, client, loadBalancerSettings, func(_ context.Context, state *pb.State, p string) ([]*pb.StateChange, error) {
+		desired, prefix = state, p
+		return nil, nil
+	})
+	controller.Reconcile(context.Background())
+
+	if desired == nil {
+		t.Fatal("Expected the load balancers to be applied")
+	}
+	if prefix != LoadBalancerPrefix {
+		t.Errorf("Expected the apply to be scoped to %q, got %q", LoadBalancerPrefix, prefix)
+	}
+	if len(desired.Frontends) != 1 || len(desired.Backends) != 1 {
+		t.Fatalf("Expected one frontend and backend, got %v", desired)
+	}
+	frontend := desired.Frontends[0]
+	if frontend.Frontend.Name != "k8s-lb-web-80" || frontend.Frontend.DefaultBackend != "k8s-lb-web-80" {
+		t.Errorf("Unexpected frontend %v", frontend.Frontend)
+	}
+	if len(frontend.Binds) != 1 || frontend.Binds[0].Address != "192.168.1.200" || frontend.Binds[0].Port != 80 {
+		t.Errorf("Unexpected binds %v", frontend.Binds)
+	}
+	servers := desired.Backends[0].Servers
+	if len(servers) != 2 || servers[0].Name != "10-0-0-1" || servers[0].Port != 8080 || servers[1].Address != "10.0.0.2" {
+		t.Errorf("Unexpected servers %v", servers)
+	}
+
+	if ingress := serviceIngress(t, client, "web"); len(ingress) != 1 || ingress[0] != "192.168.1.200" {
+		t.Errorf("Expected the VIP to be published, got %v", ingress)
+	}
+}
+
+func TestLoadBalancerAllocation(t *testing.T) {
+	client := newFakeClient(
+		// Keeps its published address although it sorts after "b"
+		newService(t, "c", func(s *corev1.Service) {
+			s.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "192.168.1.200"}}
+		}),
+		newService(t, "b", nil),
+		// The pool is exhausted by b and c
+		newService(t, "d", nil),
+		newService(t, "a", func(s *corev1.Service) { s.Annotations = map[string]string{PoolAnnotation: "internal"} }),
+		newService(t, "unknown-pool", func(s *corev1.Service) { s.Annotations = map[string]string{PoolAnnotation: "missing"} }),
+		newService(t, "other-class", func(s *corev1.Service) {
+			class := "example.com/other"
+			s.Spec.LoadBalancerClass = &class
+		}),
+	)
+
+	controller := startController(t, client, loadBalancerSettings, func(context.Context, *pb.State, string) ([]*pb.StateChange, error) {
+		return nil, nil
+	})
+	controller.Reconcile(context.Background())
+
+	expected := map[string]string{
+		"a":            "10.10.0.0",
+		"b":            "192.168.1.201",
+		"c":            "192.168.1.200",
+		"d":            "",
+		"unknown-pool": "",
+		"other-class":  "",
+	}
+	for name, address := range expected {
+		ingress := serviceIngress(t, client, name)
+		if address == "" && len(ingress) != 0 {
+			t.Errorf("Expected no address for %s, got %v", name, ingress)
+		}
+		if address != "" && (len(ingress) != 1 || ingress[0] != address) {
+			t.Errorf("Expected %s for %s, got %v", address, name, ingress)
+		}
+	}
+}
+
+func TestLoadBalancerSkipsUnreadyEndpoints(t *testing.T) {
+	client := newFakeClient(
+		newService(t, "web", nil),
+		newEndpointSlice(t, "web", false, "10.0.0.1"),
+	)
+
+	var desired *pb.State
+	controller := startController(t, client, loadBalancerSettings, func(_ context.Context, state *pb.State, _ string) ([]*pb.StateChange, error) {
+		desired = state
+		return nil, nil
+	})
+	controller.Reconcile(context.Background())
+
+	if len(desired.Backends) != 1 || len(desired.Backends[0].Servers) != 0 {
+		t.Errorf("Expected a backend without servers, got %v", desired.Backends)
+	}
+}
@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// API group and version of the custom resources
+const (
+	Group   = "haproxy.bear-san.github.io"
+	Version = "v1alpha1"
+)
+
+// Custom resources reconciled by the operator
+var (
+	FrontendResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "haproxyfrontends"}
+	BackendResource  = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "haproxybackends"}
+	BindResource     = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "haproxybinds"}
+)
+
+// FrontendSpec is the spec of an HAProxyFrontend
+type FrontendSpec struct {
+	Name           string `json:"name,omitempty"` // HAProxy name; defaults to the resource name
+	Mode           string `json:"mode,omitempty"` // "tcp" or "http"
+	DefaultBackend string `json:"defaultBackend,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Disabled       bool   `json:"disabled,omitempty"`
+}
+
+// BackendSpec is the spec of an HAProxyBackend
+type BackendSpec struct {
+	Name    string       `json:"name,omitempty"`    // HAProxy name; defaults to the resource name
+	Mode    string       `json:"mode,omitempty"`    // "tcp" or "http"
+	Balance string       `json:"balance,omitempty"` // "roundrobin", "first", "hash" or "random"
+	Servers []ServerSpec `json:"servers,omitempty"`
+}
+
+// ServerSpec is a server of an HAProxyBackend
+type ServerSpec struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Port    int32  `json:"port"`
+}
+
+// BindSpec is the spec of an HAProxyBind
+type BindSpec struct {
+	Name     string `json:"name,omitempty"` // HAProxy name; defaults to the resource name
+	Frontend string `json:"frontend"`       // HAProxy name of the frontend
+	Address  string `json:"address"`
+	Port     int32  `json:"port"`
+	V4V6     bool   `json:"v4v6,omitempty"`
+	V6Only   bool   `json:"v6only,omitempty"`
+}
+
+// ResourceStatus is the status the operator reports on every resource
+type ResourceStatus struct {
+	ObservedGeneration int64  `json:"observedGeneration"`
+	Ready              bool   `json:"ready"`
+	Message            string `json:"message,omitempty"`
+}
+
+// toProto converts the spec to a frontend named name
+func (f FrontendSpec) toProto(name string) (*pb.Frontend, error) {
+	mode, err := parseMode(f.Mode)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Frontend{
+		Name:           name,
+		Mode:           mode,
+		DefaultBackend: f.DefaultBackend,
+		Description:    f.Description,
+		Disabled:       f.Disabled,
+	}, nil
+}
+
+// toProto converts the spec to a backend named name together with its servers
+func (b BackendSpec) toProto(name string) (*pb.BackendState, error) {
+	mode, err := parseMode(b.Mode)
+	if err != nil {
+		return nil, err
+	}
+	backend := &pb.Backend{Name: name, Mode: mode}
+	if b.Balance != "" {
+		algorithm, ok := pb.BalanceAlgorithm_value["BALANCE_ALGORITHM_"+strings.ToUpper(b.Balance)]
+		if !ok || algorithm == 0 {
+			return nil, fmt.Errorf("unknown balance algorithm %q", b.Balance)
+		}
+		backend.Balance = &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm(algorithm)}
+	}
+
+	result := &pb.BackendState{Backend: backend}
+	for _, server := range b.Servers {
+		result.Servers = append(result.Servers, &pb.Server{
+			Name:    server.Name,
+			Address: server.Address,
+			Port:    server.Port,
+		})
+	}
+	return result, nil
+}
+
+// toProto converts the spec to a bind named name
+func (b BindSpec) toProto(name string) (*pb.Bind, error) {
+	if b.Frontend == "" {
+		return nil, fmt.Errorf("frontend is required")
+	}
+	return &pb.Bind{
+		Name:    name,
+		Address: b.Address,
+		Port:    b.Port,
+		V4V6:    b.V4V6,
+		V6Only:  b.V6Only,
+	}, nil
+}
+
+// parseMode converts a lower-case proxy mode to its enum value
+func parseMode(mode string) (pb.ProxyMode, error) {
+	if mode == "" {
+		return pb.ProxyMode_PROXY_MODE_UNSPECIFIED, nil
+	}
+	value, ok := pb.ProxyMode_value["PROXY_MODE_"+strings.ToUpper(mode)]
+	if !ok || value == 0 {
+		return 0, fmt.Errorf("unknown mode %q", mode)
+	}
+	return pb.ProxyMode(value), nil
+}
@@ -0,0 +1,62 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// fileCampaign elects the instance holding an exclusive lock on path. The leader writes its identity into
+// the file so that standbys can report it. The lock is released when ctx is done or the process exits.
+func fileCampaign(path string, retryPeriod time.Duration) func(ctx context.Context, e *Election) error {
+	return func(ctx context.Context, e *Election) error {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open leader lock file %s: %w", path, err)
+		}
+		defer file.Close()
+
+		for {
+			locked, err := tryLock(file)
+			if err != nil {
+				return fmt.Errorf("failed to lock %s: %w", path, err)
+			}
+			if locked {
+				break
+			}
+
+			holder, err := os.ReadFile(path)
+			if err != nil {
+				logger.GetLogger().Warn("Failed to read the leader from the lock file",
+					zap.String("path", path),
+					zap.Error(err))
+			}
+			e.set(false, strings.TrimSpace(string(holder)))
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryPeriod):
+			}
+		}
+		defer unlock(file)
+
+		if err := file.Truncate(0); err == nil {
+			_, err = file.WriteAt([]byte(e.identity+"\n"), 0)
+		}
+		if err != nil {
+			logger.GetLogger().Warn("Failed to record the leader in the lock file",
+				zap.String("path", path),
+				zap.Error(err))
+		}
+		e.set(true, e.identity)
+
+		<-ctx.Done()
+		return nil
+	}
+}
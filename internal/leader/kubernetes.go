@@ -0,0 +1,65 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// kubernetesCampaign elects the holder of a coordination.k8s.io Lease
+func kubernetesCampaign(settings config.LeaderElectionSettings) (func(ctx context.Context, e *Election) error, error) {
+	restConfig, err := kubernetes.NewRESTConfig(settings.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return leaseCampaign(client.CoordinationV1(), settings), nil
+}
+
+// leaseCampaign competes for the Lease through the given client. The Lease is released when ctx is done,
+// so a standby can take over without waiting for it to expire.
+func leaseCampaign(client coordinationv1.LeasesGetter, settings config.LeaderElectionSettings) func(ctx context.Context, e *Election) error {
+	return func(ctx context.Context, e *Election) error {
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta:  metav1.ObjectMeta{Name: settings.LeaseName, Namespace: settings.Namespace},
+				Client:     client,
+				LockConfig: resourcelock.ResourceLockConfig{Identity: e.identity},
+			},
+			LeaseDuration:   time.Duration(settings.LeaseDurationSeconds) * time.Second,
+			RenewDeadline:   time.Duration(settings.RenewDeadlineSeconds) * time.Second,
+			RetryPeriod:     time.Duration(settings.RetryPeriodSeconds) * time.Second,
+			ReleaseOnCancel: true,
+			Name:            settings.LeaseName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) { e.set(true, e.identity) },
+				OnStoppedLeading: func() { e.set(false, "") },
+				OnNewLeader: func(identity string) {
+					if identity != e.identity {
+						e.set(false, identity)
+					}
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("invalid leader election settings: %w", err)
+		}
+
+		// Run returns when the Lease could not be renewed; compete again until ctx is done
+		for ctx.Err() == nil {
+			elector.Run(ctx)
+		}
+		return nil
+	}
+}
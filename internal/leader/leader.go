@@ -0,0 +1,101 @@
+// Package leader elects the one configurator instance allowed to make changes among redundant instances
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// Election tracks whether this instance currently leads its group of configurators
+type Election struct {
+	identity string
+	backend  string
+	campaign func(ctx context.Context, e *Election) error // Competes for leadership until ctx is done
+
+	mutex   sync.RWMutex
+	leading bool
+	leader  string
+}
+
+// New creates the election for the configured backend. Run starts competing.
+func New(settings config.LeaderElectionSettings) (*Election, error) {
+	election := &Election{identity: settings.Identity, backend: settings.Backend}
+	switch settings.Backend {
+	case "file":
+		election.campaign = fileCampaign(settings.LockFile, time.Duration(settings.RetryPeriodSeconds)*time.Second)
+	case "kubernetes":
+		campaign, err := kubernetesCampaign(settings)
+		if err != nil {
+			return nil, err
+		}
+		election.campaign = campaign
+	default:
+		return nil, fmt.Errorf("unknown leader election backend %q", settings.Backend)
+	}
+	return election, nil
+}
+
+// Run competes for leadership until ctx is done, then gives it up
+func (e *Election) Run(ctx context.Context) error {
+	defer e.set(false, "")
+	return e.campaign(ctx, e)
+}
+
+// Identity returns the name this instance competes under
+func (e *Election) Identity() string {
+	return e.identity
+}
+
+// Backend returns the name of the election backend
+func (e *Election) Backend() string {
+	return e.backend
+}
+
+// IsLeader reports whether this instance currently holds the leadership
+func (e *Election) IsLeader() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.leading
+}
+
+// Leader returns the identity of the current leader, or an empty string if it is unknown
+func (e *Election) Leader() string {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.leader
+}
+
+// set records the outcome of the last election round and logs changes of leadership
+func (e *Election) set(leading bool, leader string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if leading != e.leading {
+		if leading {
+			logger.GetLogger().Info("Elected leader, accepting changes",
+				zap.String("identity", e.identity))
+		} else {
+			logger.GetLogger().Warn("Lost leadership, serving reads only",
+				zap.String("identity", e.identity))
+		}
+	} else if !leading && leader != e.leader && leader != "" {
+		logger.GetLogger().Info("Following leader",
+			zap.String("identity", e.identity),
+			zap.String("leader", leader))
+	}
+
+	e.leading = leading
+	e.leader = leader
+	if leading {
+		metrics.Leader.Set(1)
+	} else {
+		metrics.Leader.Set(0)
+	}
+}
@@ -0,0 +1,85 @@
+package leader
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// waitFor polls condition until it holds or the test times out
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFileElection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leader.lock")
+	newElection := func(identity string) *Election {
+		election, err := New(config.LeaderElectionSettings{Backend: "file", Identity: identity, LockFile: path, RetryPeriodSeconds: 1})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		return election
+	}
+
+	first, second := newElection("lb1"), newElection("lb2")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = first.Run(ctx)
+		close(done)
+	}()
+	waitFor(t, "lb1 to lead", first.IsLeader)
+
+	secondCtx, secondCancel := context.WithCancel(context.Background())
+	defer secondCancel()
+	go func() { _ = second.Run(secondCtx) }()
+	waitFor(t, "lb2 to follow lb1", func() bool { return second.Leader() == "lb1" })
+	if second.IsLeader() {
+		t.Fatal("Expected only one leader")
+	}
+
+	// The standby takes over once the leader steps down
+	cancel()
+	<-done
+	if first.IsLeader() {
+		t.Error("Expected lb1 to give up leadership")
+	}
+	waitFor(t, "lb2 to lead", second.IsLeader)
+	if second.Leader() != "lb2" {
+		t.Errorf("Expected lb2 as leader, got %q", second.Leader())
+	}
+}
+
+func TestLeaseElection(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	settings := config.LeaderElectionSettings{
+		Backend: "kubernetes", Namespace: "lb", LeaseName: "haproxy-configurator",
+		LeaseDurationSeconds: 3, RenewDeadlineSeconds: 2, RetryPeriodSeconds: 1,
+	}
+	newElection := func(identity string) *Election {
+		return &Election{identity: identity, backend: "kubernetes", campaign: leaseCampaign(client.CoordinationV1(), settings)}
+	}
+
+	first, second := newElection("lb1"), newElection("lb2")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = first.Run(ctx) }()
+	waitFor(t, "lb1 to lead", first.IsLeader)
+
+	go func() { _ = second.Run(ctx) }()
+	waitFor(t, "lb2 to follow lb1", func() bool { return second.Leader() == "lb1" })
+	if second.IsLeader() {
+		t.Error("Expected only one leader")
+	}
+}
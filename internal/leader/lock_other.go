@@ -0,0 +1,16 @@
+//go:build !unix
+
+package leader
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLock is not supported without flock(2)
+func tryLock(*os.File) (bool, error) {
+	return false, errors.New("file leader election is not supported on this platform")
+}
+
+// unlock does nothing without flock(2)
+func unlock(*os.File) {}
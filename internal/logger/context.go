@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Correlation fields attached to the log entries of a request, so that a single grep on one of them
+// reconstructs everything logged while servicing it
+const (
+	FieldRequestID    = "request_id"
+	FieldClient       = "client"
+	FieldMethod       = "method"
+	FieldHAProxyTxnID = "haproxy_txn_id"
+	FieldNetplanTxnID = "netplan_txn_id"
+)
+
+// fieldsContextKey carries the log fields attached to a context
+type fieldsContextKey struct{}
+
+// WithFields returns a copy of ctx whose log entries carry the given fields in addition to those already
+// attached. A field replaces an attached field with the same key.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	attached := Fields(ctx)
+	merged := make([]zap.Field, 0, len(attached)+len(fields))
+	for _, field := range attached {
+		if !hasKey(fields, field.Key) {
+			merged = append(merged, field)
+		}
+	}
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey{}, merged)
+}
+
+// Fields returns the log fields attached to ctx
+func Fields(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(fieldsContextKey{}).([]zap.Field)
+	return fields
+}
+
+// FromContext returns the global logger with the fields attached to ctx
+func FromContext(ctx context.Context) *zap.Logger {
+	fields := Fields(ctx)
+	if len(fields) == 0 {
+		return GetLogger()
+	}
+	return GetLogger().With(fields...)
+}
+
+// hasKey reports whether one of the fields has the given key
+func hasKey(fields []zap.Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	previous := Logger
+	Logger = zap.New(core)
+	defer func() { Logger = previous }()
+
+	ctx := WithFields(context.Background(), zap.String(FieldRequestID, "request-1"), zap.String(FieldHAProxyTxnID, "old"))
+	ctx = WithFields(ctx, zap.String(FieldHAProxyTxnID, "txn-1"), zap.String(FieldNetplanTxnID, "txn-1"))
+	FromContext(ctx).Info("Committing")
+	FromContext(context.Background()).Info("Unrelated")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	expected := map[string]interface{}{FieldRequestID: "request-1", FieldHAProxyTxnID: "txn-1", FieldNetplanTxnID: "txn-1"}
+	if fields := entries[0].ContextMap(); len(fields) != len(expected) || len(entries[0].Context) != len(expected) {
+		t.Errorf("Expected fields %v, got %v", expected, entries[0].Context)
+	} else {
+		for key, value := range expected {
+			if fields[key] != value {
+				t.Errorf("Expected %s=%v, got %v", key, value, fields[key])
+			}
+		}
+	}
+	if len(entries[1].Context) != 0 {
+		t.Errorf("Expected no fields without a request, got %v", entries[1].Context)
+	}
+}
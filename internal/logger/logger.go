@@ -1,8 +1,14 @@
 package logger
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Logger *zap.Logger
@@ -25,7 +31,7 @@ func InitLogger(development bool) error {
 	config.OutputPaths = []string{"stdout"}
 	config.ErrorOutputPaths = []string{"stderr"}
 	
-	logger, err := config.Build()
+	logger, err := config.Build(zap.WrapCore(redact))
 	if err != nil {
 		return err
 	}
@@ -36,6 +42,158 @@ func InitLogger(development bool) error {
 	return nil
 }
 
+// InitLoggerWithConfig initializes the global logger instance from the logging section of the unified config.
+// Settings left empty fall back to the same defaults used by InitLogger.
+func InitLoggerWithConfig(development bool, settings config.LoggingSettings) error {
+	level := zap.InfoLevel
+	if settings.Level != "" {
+		if err := level.Set(settings.Level); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", settings.Level, err)
+		}
+	}
+
+	var encoderConfig zapcore.EncoderConfig
+	if development {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	} else {
+		encoderConfig = zap.NewProductionEncoderConfig()
+	}
+
+	format := settings.Format
+	if format == "" {
+		format = "json"
+		if development {
+			format = "console"
+		}
+	}
+
+	var encoder zapcore.Encoder
+	switch format {
+	case "json":
+		// Color codes would end up verbatim in JSON output
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+
+	outputPaths := settings.OutputPaths
+	if len(outputPaths) == 0 {
+		outputPaths = []string{"stdout"}
+	}
+	errorOutputPaths := settings.ErrorOutputPaths
+	if len(errorOutputPaths) == 0 {
+		errorOutputPaths = []string{"stderr"}
+	}
+
+	sinks := newSinks(settings.Rotation)
+	output, err := sinks.open(outputPaths)
+	if err != nil {
+		return err
+	}
+	errorOutput, err := sinks.open(errorOutputPaths)
+	if err != nil {
+		return err
+	}
+
+	options := []zap.Option{
+		zap.ErrorOutput(errorOutput),
+		zap.AddCaller(),
+		zap.AddStacktrace(zap.ErrorLevel),
+	}
+	if development {
+		options = append(options, zap.Development(), zap.AddStacktrace(zap.WarnLevel))
+	}
+
+	logger := zap.New(redact(zapcore.NewCore(encoder, output, zap.NewAtomicLevelAt(level))), options...)
+	payloadsDisabled.Store(settings.DisablePayloads)
+
+	Logger = logger
+	zap.ReplaceGlobals(logger)
+
+	return nil
+}
+
+// redact wraps a core so that credentials are masked in every entry
+func redact(core zapcore.Core) zapcore.Core {
+	return redactingCore{core}
+}
+
+// sinks opens the output paths of a logger, each path once: a file listed in both output_paths and
+// error_output_paths is written, and rotated, by a single writer
+type sinks struct {
+	rotation config.RotationSettings
+	opened   map[string]zapcore.WriteSyncer
+}
+
+// newSinks creates the sinks of a logger, rotating log files with the given settings
+func newSinks(rotation config.RotationSettings) *sinks {
+	return &sinks{rotation: rotation, opened: make(map[string]zapcore.WriteSyncer)}
+}
+
+// open combines the writers of paths into a single WriteSyncer, reusing those opened for other outputs
+func (s *sinks) open(paths []string) (zapcore.WriteSyncer, error) {
+	syncers := make([]zapcore.WriteSyncer, 0, len(paths))
+	included := make(map[string]bool)
+
+	for _, path := range paths {
+		if path != "stdout" && path != "stderr" {
+			path = filepath.Clean(path)
+		}
+		if included[path] {
+			continue
+		}
+		included[path] = true
+
+		syncer, ok := s.opened[path]
+		if !ok {
+			var err error
+			if syncer, err = s.openPath(path); err != nil {
+				return nil, err
+			}
+			s.opened[path] = syncer
+		}
+		syncers = append(syncers, syncer)
+	}
+
+	return zapcore.NewMultiWriteSyncer(syncers...), nil
+}
+
+// openPath opens a single output path. "stdout" and "stderr" are treated specially; any other value is a file
+// path, which is wrapped in a rotating writer when rotation is enabled.
+func (s *sinks) openPath(path string) (zapcore.WriteSyncer, error) {
+	switch path {
+	case "stdout":
+		return zapcore.Lock(os.Stdout), nil
+	case "stderr":
+		return zapcore.Lock(os.Stderr), nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory for %s: %w", path, err)
+	}
+
+	if s.rotation.Enabled {
+		return zapcore.AddSync(&lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    s.rotation.MaxSizeMB,
+			MaxAge:     s.rotation.MaxAgeDays,
+			MaxBackups: s.rotation.MaxBackups,
+			Compress:   s.rotation.Compress,
+		}), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %s: %w", path, err)
+	}
+	return zapcore.Lock(file), nil
+}
+
 // GetLogger returns the global logger instance
 func GetLogger() *zap.Logger {
 	if Logger == nil {
@@ -50,4 +208,4 @@ func Sync() {
 	if Logger != nil {
 		_ = Logger.Sync()
 	}
-}
\ No newline at end of file
+}
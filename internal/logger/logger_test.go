@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"go.uber.org/zap"
+)
+
+// initTestLogger initializes the global logger from settings and restores the previous one after the test
+func initTestLogger(t *testing.T, development bool, settings config.LoggingSettings) error {
+	t.Helper()
+
+	previous, previousGlobal := Logger, zap.L()
+	t.Cleanup(func() {
+		Logger = previous
+		zap.ReplaceGlobals(previousGlobal)
+		payloadsDisabled.Store(false)
+	})
+	return InitLoggerWithConfig(development, settings)
+}
+
+// readLines returns the non-empty lines of a log file
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	return strings.FieldsFunc(string(content), func(r rune) bool { return r == '\n' })
+}
+
+func TestInitLoggerWithConfigLevelAndFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "configurator.log")
+	if err := initTestLogger(t, false, config.LoggingSettings{Level: "warn", OutputPaths: []string{path}}); err != nil {
+		t.Fatalf("InitLoggerWithConfig failed: %v", err)
+	}
+	GetLogger().Info("Below the level")
+	GetLogger().Warn("Committed", zap.String("password", "secret"))
+	Sync()
+
+	lines := readLines(t, path)
+	if len(lines) != 1 {
+		t.Fatalf("Expected only the warning, got %v", lines)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Expected JSON by default, got %s", lines[0])
+	}
+	if entry["level"] != "warn" || entry["msg"] != "Committed" || entry["password"] != "[REDACTED]" {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+
+	// The console format separates the fields with tabs
+	path = filepath.Join(t.TempDir(), "console.log")
+	if err := initTestLogger(t, false, config.LoggingSettings{Format: "console", OutputPaths: []string{path}}); err != nil {
+		t.Fatalf("InitLoggerWithConfig failed: %v", err)
+	}
+	GetLogger().Info("Started")
+	Sync()
+	if lines := readLines(t, path); len(lines) != 1 || strings.HasPrefix(lines[0], "{") || !strings.Contains(lines[0], "\tinfo\t") {
+		t.Errorf("Expected a console entry, got %v", lines)
+	}
+}
+
+func TestInitLoggerWithConfigRejectsInvalidSettings(t *testing.T) {
+	for _, settings := range []config.LoggingSettings{
+		{Level: "verbose"},
+		{Format: "text"},
+		{OutputPaths: []string{filepath.Join(os.DevNull, "configurator.log")}},
+	} {
+		if err := initTestLogger(t, false, settings); err == nil {
+			t.Errorf("Expected settings %+v to be rejected", settings)
+		}
+	}
+}
+
+func TestSinksOpenEachPathOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "configurator.log")
+
+	for _, rotation := range []config.RotationSettings{{}, {Enabled: true, MaxSizeMB: 1}} {
+		sinks := newSinks(rotation)
+		output, err := sinks.open([]string{path, filepath.Join(dir, "access.log"), path})
+		if err != nil {
+			t.Fatalf("open failed: %v", err)
+		}
+		errorOutput, err := sinks.open([]string{filepath.Join(dir, ".", "configurator.log"), filepath.Join(dir, "error.log")})
+		if err != nil {
+			t.Fatalf("open failed: %v", err)
+		}
+		if len(sinks.opened) != 3 {
+			t.Errorf("Expected the file to be opened once for both outputs, got %d writers", len(sinks.opened))
+		}
+
+		if err := os.Truncate(path, 0); err != nil {
+			t.Fatalf("Failed to truncate log file: %v", err)
+		}
+		if _, err := output.Write([]byte("output\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if _, err := errorOutput.Write([]byte("error\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if lines := readLines(t, path); strings.Join(lines, ",") != "output,error" {
+			t.Errorf("Expected each entry once, got %v", lines)
+		}
+	}
+}
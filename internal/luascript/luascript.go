@@ -0,0 +1,279 @@
+// Package luascript validates Lua scripts deployed to HAProxy and names their versions in the storage of the
+// Data Plane API. Every upload of a script is stored as a new file "<name>.v<version>.lua", so that earlier
+// versions stay available for rollbacks:
+//
+//	if err := luascript.Validate(content); err != nil {
+//		return err
+//	}
+//	file := luascript.FileName("rewrite", 3) // "rewrite.v3.lua"
+//
+// Validation is lexical: it finds unterminated strings and comments and unbalanced blocks and brackets.
+// Everything else is left to HAProxy, which runs the script when checking the configuration.
+package luascript
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSize is the largest script accepted, in bytes
+const MaxSize = 1 << 20
+
+var (
+	namePattern     = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+	filePattern     = regexp.MustCompile(`^([A-Za-z0-9_-]{1,64})\.v([1-9][0-9]*)\.lua$`)
+	registerPattern = regexp.MustCompile(`core\.register_action\s*\(\s*["']([^"']+)["']`)
+)
+
+// ValidateName checks the name of a script: letters, digits, "-" and "_"
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return errors.New("script name must consist of up to 64 letters, digits, \"-\" and \"_\"")
+	}
+	return nil
+}
+
+// FileName returns the storage name of a version of a script
+func FileName(name string, version int) string {
+	return fmt.Sprintf("%s.v%d.lua", name, version)
+}
+
+// ParseFileName returns the script and version of a storage name; ok is false for other files
+func ParseFileName(file string) (name string, version int, ok bool) {
+	match := filePattern.FindStringSubmatch(file)
+	if match == nil {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", 0, false
+	}
+	return match[1], version, true
+}
+
+// Actions returns the names of the actions a script registers with core.register_action, in order
+func Actions(content string) []string {
+	var actions []string
+	for _, match := range registerPattern.FindAllStringSubmatch(content, -1) {
+		actions = append(actions, match[1])
+	}
+	return actions
+}
+
+// Validate checks that a script is Lua source of an acceptable size whose strings, comments, blocks and
+// brackets are terminated and balanced. Errors name the line of the problem.
+func Validate(content string) error {
+	switch {
+	case strings.TrimSpace(content) == "":
+		return errors.New("script is empty")
+	case len(content) > MaxSize:
+		return fmt.Errorf("script exceeds %d bytes", MaxSize)
+	case strings.HasPrefix(content, "\x1bLua"):
+		return errors.New("precompiled scripts are not accepted; upload the source")
+	case !utf8.ValidString(content):
+		return errors.New("script is not valid UTF-8")
+	}
+	return newScanner(content).check()
+}
+
+// opener is a block or bracket that has to be closed, with the line it was opened on
+type opener struct {
+	token string
+	line  int
+}
+
+// closers maps the tokens closing blocks and brackets to the openers they close
+var closers = map[string][]string{
+	"end":   {"function", "if", "do"},
+	"until": {"repeat"},
+	")":     {"("},
+	"]":     {"["},
+	"}":     {"{"},
+}
+
+// scanner walks a script token by token, skipping strings, comments and numbers
+type scanner struct {
+	src  string
+	pos  int
+	line int
+}
+
+func newScanner(src string) *scanner {
+	s := &scanner{src: src, line: 1}
+	// A first line starting with # is skipped by Lua, e.g. a shebang
+	if strings.HasPrefix(src, "#") {
+		s.skipLine()
+	}
+	return s
+}
+
+// check reports the first unterminated string or comment and the first unbalanced block or bracket
+func (s *scanner) check() error {
+	var stack []opener
+	for {
+		token, line, err := s.next()
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			break
+		}
+
+		switch token {
+		case "function", "if", "do", "repeat", "(", "[", "{":
+			stack = append(stack, opener{token, line})
+		case "end", "until", ")", "]", "}":
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: unexpected %q", line, token)
+			}
+			top := stack[len(stack)-1]
+			if !slices.Contains(closers[token], top.token) {
+				return fmt.Errorf("line %d: unexpected %q closing %q opened on line %d", line, token, top.token, top.line)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return fmt.Errorf("line %d: %q is never closed", top.line, top.token)
+	}
+	return nil
+}
+
+// next returns the next keyword, identifier or punctuation character and its line, or an empty token at the
+// end of the script. Loop bodies are opened by their do, so while and for are not reported as openers.
+func (s *scanner) next() (string, int, error) {
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		line := s.line
+		switch {
+		case c == '\n':
+			s.line++
+			s.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			s.pos++
+		case strings.HasPrefix(s.src[s.pos:], "--"):
+			s.pos += 2
+			if level, ok := s.longBracket(); ok {
+				if !s.skipLong(level) {
+					return "", 0, fmt.Errorf("line %d: unterminated comment", line)
+				}
+			} else {
+				s.skipLine()
+			}
+		case c == '"' || c == '\'':
+			if !s.skipQuoted(c) {
+				return "", 0, fmt.Errorf("line %d: unterminated string", line)
+			}
+		case c == '[':
+			if level, ok := s.longBracket(); ok {
+				if !s.skipLong(level) {
+					return "", 0, fmt.Errorf("line %d: unterminated string", line)
+				}
+				continue
+			}
+			s.pos++
+			return "[", line, nil
+		case isLetter(c):
+			start := s.pos
+			for s.pos < len(s.src) && (isLetter(s.src[s.pos]) || isDigit(s.src[s.pos])) {
+				s.pos++
+			}
+			return s.src[start:s.pos], line, nil
+		case isDigit(c):
+			// Numbers, including hexadecimals and exponents such as 1e-3
+			for s.pos < len(s.src) && (isLetter(s.src[s.pos]) || isDigit(s.src[s.pos]) || s.src[s.pos] == '.' ||
+				((s.src[s.pos] == '-' || s.src[s.pos] == '+') && strings.ContainsRune("eEpP", rune(s.src[s.pos-1])))) {
+				s.pos++
+			}
+		default:
+			s.pos++
+			return string(c), line, nil
+		}
+	}
+	return "", s.line, nil
+}
+
+// longBracket checks for the opening long bracket [[, [=[, [==[, ... at the position and returns its level
+func (s *scanner) longBracket() (int, bool) {
+	if s.pos >= len(s.src) || s.src[s.pos] != '[' {
+		return 0, false
+	}
+	level := 0
+	for s.pos+1+level < len(s.src) && s.src[s.pos+1+level] == '=' {
+		level++
+	}
+	if s.pos+1+level >= len(s.src) || s.src[s.pos+1+level] != '[' {
+		return 0, false
+	}
+	return level, true
+}
+
+// skipLong skips a long string or comment opened at the position; false if it is not closed
+func (s *scanner) skipLong(level int) bool {
+	closing := "]" + strings.Repeat("=", level) + "]"
+	body := s.pos + level + 2
+	end := strings.Index(s.src[body:], closing)
+	if end < 0 {
+		return false
+	}
+	s.line += strings.Count(s.src[s.pos:body+end], "\n")
+	s.pos = body + end + len(closing)
+	return true
+}
+
+// skipQuoted skips a quoted string; false if it ends before its closing quote. Escaped line breaks and \z
+// continue the string on the next line.
+func (s *scanner) skipQuoted(quote byte) bool {
+	s.pos++
+	for s.pos < len(s.src) {
+		switch c := s.src[s.pos]; c {
+		case quote:
+			s.pos++
+			return true
+		case '\n':
+			return false
+		case '\\':
+			s.pos++
+			if s.pos < len(s.src) && s.src[s.pos] == 'z' {
+				s.pos++
+				for s.pos < len(s.src) && strings.IndexByte(" \t\r\n\f\v", s.src[s.pos]) >= 0 {
+					if s.src[s.pos] == '\n' {
+						s.line++
+					}
+					s.pos++
+				}
+				continue
+			}
+			if s.pos < len(s.src) && s.src[s.pos] == '\n' {
+				s.line++
+			}
+			s.pos++
+		default:
+			s.pos++
+		}
+	}
+	return false
+}
+
+// skipLine skips to the end of the line, leaving the line break
+func (s *scanner) skipLine() {
+	if end := strings.IndexByte(s.src[s.pos:], '\n'); end >= 0 {
+		s.pos += end
+	} else {
+		s.pos = len(s.src)
+	}
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,83 @@
+package luascript
+
+import (
+	"strings"
+	"testing"
+)
+
+const script = `#!/usr/bin/env lua
+-- Adds a request ID header
+local function id(txn)
+  return string.format("%x", math.random(0, 0xffffff))
+end
+
+--[==[ Long comments may contain ]] and "quotes ]==]
+core.register_action("request-id", { "http-req" }, function(txn)
+  local s = [[
+multi-line ) string ]]
+  if txn.sf:req_hdr("x-request-id") == "" then
+    txn.http:req_set_header("X-Request-ID", id(txn) .. 'it\'s' .. "a \z
+      continued string")
+  elseif false then
+    for i = 1, 1e3 do end
+  else
+    repeat local x = {1, 2, [3] = 4} until true
+  end
+end)
+core.register_action('ban', { "http-req", "tcp-req" }, function(txn) end, 0)
+`
+
+func TestValidate(t *testing.T) {
+	if err := Validate(script); err != nil {
+		t.Fatalf("Expected a valid script, got %v", err)
+	}
+
+	for _, test := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"empty", " \n", "script is empty"},
+		{"too large", strings.Repeat("-", MaxSize+1), "exceeds"},
+		{"bytecode", "\x1bLuaS\x00", "precompiled"},
+		{"invalid UTF-8", "x = '\xff'", "UTF-8"},
+		{"unterminated string", "x = \"abc\ny = 1", "line 1: unterminated string"},
+		{"unterminated long string", "x = 1\ny = [==[ abc ]]", "line 2: unterminated string"},
+		{"unterminated comment", "--[[ abc", "line 1: unterminated comment"},
+		{"missing end", "function f()\n  if x then\n  end\n", "line 1: \"function\" is never closed"},
+		{"extra end", "x = 1\nend", "line 2: unexpected \"end\""},
+		{"mismatched", "f(function()\nend]", "line 2: unexpected \"]\" closing \"(\" opened on line 1"},
+		{"until without repeat", "do\nuntil x", "line 2: unexpected \"until\" closing \"do\""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.content)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestActions(t *testing.T) {
+	if actions := Actions(script); strings.Join(actions, ",") != "request-id,ban" {
+		t.Errorf("Unexpected actions %v", actions)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	file := FileName("rewrite", 12)
+	if file != "rewrite.v12.lua" {
+		t.Errorf("Unexpected file name %s", file)
+	}
+	if name, version, ok := ParseFileName(file); !ok || name != "rewrite" || version != 12 {
+		t.Errorf("Unexpected parse of %s: %s %d %v", file, name, version, ok)
+	}
+	for _, other := range []string{"rewrite.lua", "rewrite.v0.lua", "rewrite.v1.lua.bak", "a/b.v1.lua", "map.txt"} {
+		if _, _, ok := ParseFileName(other); ok {
+			t.Errorf("Expected %s not to be a script version", other)
+		}
+	}
+	if ValidateName("rewrite_v2-beta") != nil || ValidateName("a.b") == nil || ValidateName("") == nil {
+		t.Error("Unexpected name validation")
+	}
+}
@@ -0,0 +1,254 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Kinds of the resources that carry metadata
+const (
+	KindBackend  = "backend"
+	KindFrontend = "frontend"
+	KindBind     = "bind"   // Belongs to a frontend
+	KindServer   = "server" // Belongs to a backend
+)
+
+// childKinds are the kinds of the resources that are deleted with a resource of each kind
+var childKinds = map[string]string{KindBackend: KindServer, KindFrontend: KindBind}
+
+var metadataBucket = []byte("metadata")
+
+// Key identifies a resource of an HAProxy instance
+type Key struct {
+	Instance string
+	Kind     string
+	Parent   string // Frontend of a bind, backend of a server
+	Name     string
+}
+
+// encode converts the key to its database key. Keys of the children of a resource share a prefix, see
+// childPrefix.
+func (k Key) encode() []byte {
+	return []byte(strings.Join([]string{k.Instance, k.Kind, k.Parent, k.Name}, "\x00"))
+}
+
+// childPrefix returns the prefix of the database keys of the binds or servers of the resource, or nil if
+// resources of its kind have none
+func (k Key) childPrefix() []byte {
+	kind, ok := childKinds[k.Kind]
+	if !ok {
+		return nil
+	}
+	return []byte(strings.Join([]string{k.Instance, kind, k.Name, ""}, "\x00"))
+}
+
+// Metadata are the labels and annotations of a resource
+type Metadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IsEmpty reports whether the resource has neither labels nor annotations
+func (m Metadata) IsEmpty() bool {
+	return len(m.Labels) == 0 && len(m.Annotations) == 0
+}
+
+// Validate checks labels and annotations against the syntax of Kubernetes, so that label selectors can match
+// them: keys are qualified names with an optional DNS prefix, and label values are at most 63 characters of
+// alphanumerics, '-', '_' and '.'. Annotation values are free-form.
+func Validate(labels, annotations map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q of label %s: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+	for key := range annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// change is a metadata change recorded in a transaction
+type change struct {
+	key      Key
+	metadata *Metadata // Nil deletes the resource with its binds or servers
+}
+
+// affects reports whether the change replaces or deletes the metadata of key
+func (c change) affects(key Key) bool {
+	if c.key == key {
+		return true
+	}
+	prefix := c.key.childPrefix()
+	return c.metadata == nil && prefix != nil && bytes.HasPrefix(key.encode(), prefix)
+}
+
+// Store persists the labels and annotations of resources in an embedded bbolt database. Changes made within a
+// transaction are kept in memory and written when the transaction is committed, like the changes to HAProxy.
+type Store struct {
+	db *bolt.DB
+
+	mutex   sync.Mutex
+	pending map[string][]change // Changes by transaction ID, in the order they were made
+}
+
+// Open opens (or creates) the metadata database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata database: %w", err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(metadataBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize metadata database: %w", err)
+	}
+
+	return &Store{db: db, pending: make(map[string][]change)}, nil
+}
+
+// Get returns the metadata of the resources as seen by a transaction: the stored metadata with the changes of
+// the transaction applied. Resources without metadata get an empty one.
+func (s *Store) Get(transactionID string, keys ...Key) ([]Metadata, error) {
+	result := make([]Metadata, len(keys))
+	resolved := make([]bool, len(keys))
+
+	s.mutex.Lock()
+	changes := s.pending[transactionID]
+	for i, key := range keys {
+		for j := len(changes) - 1; j >= 0; j-- {
+			if changes[j].affects(key) {
+				if changes[j].metadata != nil && changes[j].key == key {
+					result[i] = *changes[j].metadata
+				}
+				resolved[i] = true
+				break
+			}
+		}
+	}
+	s.mutex.Unlock()
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metadataBucket)
+		for i, key := range keys {
+			if resolved[i] {
+				continue
+			}
+			data := bucket.Get(key.encode())
+			if data == nil {
+				continue
+			}
+			if err := json.Unmarshal(data, &result[i]); err != nil {
+				return fmt.Errorf("failed to parse metadata of %s %s: %w", key.Kind, key.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Set replaces the metadata of a resource; an empty metadata removes it. Without a transaction ID the change is
+// written immediately.
+func (s *Store) Set(transactionID string, key Key, metadata Metadata) error {
+	return s.record(transactionID, change{key: key, metadata: &metadata})
+}
+
+// Delete removes the metadata of a deleted resource, and of its binds or servers, which are deleted with it.
+// Without a transaction ID the change is written immediately.
+func (s *Store) Delete(transactionID string, key Key) error {
+	return s.record(transactionID, change{key: key})
+}
+
+// record writes a change, or keeps it until its transaction is committed
+func (s *Store) record(transactionID string, c change) error {
+	if transactionID == "" {
+		return s.write([]change{c})
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pending[transactionID] = append(s.pending[transactionID], c)
+	return nil
+}
+
+// Commit writes the changes of a committed transaction
+func (s *Store) Commit(transactionID string) error {
+	s.mutex.Lock()
+	changes := s.pending[transactionID]
+	delete(s.pending, transactionID)
+	s.mutex.Unlock()
+
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.write(changes)
+}
+
+// Discard drops the changes of a closed transaction
+func (s *Store) Discard(transactionID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.pending, transactionID)
+}
+
+// write applies changes to the database in one database transaction
+func (s *Store) write(changes []change) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metadataBucket)
+		for _, c := range changes {
+			key := c.key.encode()
+			if c.metadata == nil || c.metadata.IsEmpty() {
+				if err := bucket.Delete(key); err != nil {
+					return fmt.Errorf("failed to delete metadata of %s %s: %w", c.key.Kind, c.key.Name, err)
+				}
+			} else {
+				data, err := json.Marshal(c.metadata)
+				if err != nil {
+					return fmt.Errorf("failed to marshal metadata: %w", err)
+				}
+				if err := bucket.Put(key, data); err != nil {
+					return fmt.Errorf("failed to store metadata of %s %s: %w", c.key.Kind, c.key.Name, err)
+				}
+			}
+
+			if prefix := c.key.childPrefix(); c.metadata == nil && prefix != nil {
+				cursor := bucket.Cursor()
+				for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Seek(prefix) {
+					if err := cursor.Delete(); err != nil {
+						return fmt.Errorf("failed to delete metadata of the children of %s %s: %w", c.key.Kind, c.key.Name, err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,155 @@
+package metadata
+
+import (
+	"maps"
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "metadata.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func get(t *testing.T, store *Store, transactionID string, key Key) Metadata {
+	t.Helper()
+
+	result, err := store.Get(transactionID, key)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	return result[0]
+}
+
+func TestSetAndGet(t *testing.T) {
+	store := openTestStore(t)
+	web := Key{Instance: "default", Kind: KindBackend, Name: "web"}
+	api := Key{Instance: "default", Kind: KindBackend, Name: "api"}
+
+	metadata := Metadata{Labels: map[string]string{"team": "payments"}, Annotations: map[string]string{"owner": "alice@example.com"}}
+	if err := store.Set("", web, metadata); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	result, err := store.Get("", web, api)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !maps.Equal(result[0].Labels, metadata.Labels) || !maps.Equal(result[0].Annotations, metadata.Annotations) {
+		t.Errorf("Expected %v, got %v", metadata, result[0])
+	}
+	if !result[1].IsEmpty() {
+		t.Errorf("Expected no metadata for api, got %v", result[1])
+	}
+
+	// The same name in another instance or of another kind is another resource
+	if other := get(t, store, "", Key{Instance: "edge", Kind: KindBackend, Name: "web"}); !other.IsEmpty() {
+		t.Errorf("Expected no metadata in another instance, got %v", other)
+	}
+	if other := get(t, store, "", Key{Instance: "default", Kind: KindFrontend, Name: "web"}); !other.IsEmpty() {
+		t.Errorf("Expected no metadata for a frontend of the same name, got %v", other)
+	}
+
+	if err := store.Set("", web, Metadata{}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if cleared := get(t, store, "", web); !cleared.IsEmpty() {
+		t.Errorf("Expected empty metadata to remove it, got %v", cleared)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	store := openTestStore(t)
+	web := Key{Instance: "default", Kind: KindBackend, Name: "web"}
+	labels := map[string]string{"env": "prod"}
+
+	if err := store.Set("tx-1", web, Metadata{Labels: labels}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if inside := get(t, store, "tx-1", web); !maps.Equal(inside.Labels, labels) {
+		t.Errorf("Expected the transaction to see its change, got %v", inside)
+	}
+	if outside := get(t, store, "", web); !outside.IsEmpty() {
+		t.Errorf("Expected the change to be invisible outside the transaction, got %v", outside)
+	}
+
+	store.Discard("tx-1")
+	if discarded := get(t, store, "tx-1", web); !discarded.IsEmpty() {
+		t.Errorf("Expected a closed transaction to leave nothing, got %v", discarded)
+	}
+
+	if err := store.Set("tx-2", web, Metadata{Labels: labels}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Commit("tx-2"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if committed := get(t, store, "", web); !maps.Equal(committed.Labels, labels) {
+		t.Errorf("Expected the committed change to be stored, got %v", committed)
+	}
+}
+
+func TestDeleteRemovesChildren(t *testing.T) {
+	store := openTestStore(t)
+	backend := Key{Instance: "default", Kind: KindBackend, Name: "web"}
+	server := Key{Instance: "default", Kind: KindServer, Parent: "web", Name: "web-1"}
+	bind := Key{Instance: "default", Kind: KindBind, Parent: "web", Name: "http"}
+	otherServer := Key{Instance: "default", Kind: KindServer, Parent: "web-canary", Name: "web-1"}
+	labels := Metadata{Labels: map[string]string{"team": "payments"}}
+	for _, key := range []Key{backend, server, bind, otherServer} {
+		if err := store.Set("", key, labels); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	if err := store.Delete("tx-1", backend); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if inside := get(t, store, "tx-1", server); !inside.IsEmpty() {
+		t.Errorf("Expected the servers to be deleted in the transaction, got %v", inside)
+	}
+	if outside := get(t, store, "", server); outside.IsEmpty() {
+		t.Error("Expected the servers to be kept until the transaction is committed")
+	}
+	if err := store.Commit("tx-1"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	result, err := store.Get("", backend, server, bind, otherServer)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !result[0].IsEmpty() || !result[1].IsEmpty() {
+		t.Errorf("Expected the backend and its server to be deleted, got %v", result[:2])
+	}
+	if result[2].IsEmpty() || result[3].IsEmpty() {
+		t.Errorf("Expected the bind of the frontend of the same name and the servers of other backends to be kept, got %v", result[2:])
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		valid       bool
+	}{
+		{"valid", map[string]string{"example.com/team": "payments", "tier": ""}, map[string]string{"note": "any text, even with spaces"}, true},
+		{"label key with spaces", map[string]string{"my team": "payments"}, nil, false},
+		{"label value with spaces", map[string]string{"team": "a b"}, nil, false},
+		{"invalid annotation key", nil, map[string]string{"-note": "x"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.labels, tt.annotations); (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "haproxy_configurator"
+
+// Registry holds every metric exported by the configurator
+var Registry = prometheus.NewRegistry()
+
+var (
+	// DataplaneRequestDuration observes the latency of Data Plane API calls per instance and endpoint
+	DataplaneRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HAProxy Data Plane API requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"instance", "endpoint"})
+
+	// DataplaneRequests counts Data Plane API calls per instance, endpoint and result
+	DataplaneRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "requests_total",
+		Help:      "HAProxy Data Plane API requests by endpoint and result (success, client_error, error, rejected).",
+	}, []string{"instance", "endpoint", "result"})
+
+	// DataplaneCommitWait observes how long transaction commits wait for the commits before them per instance
+	DataplaneCommitWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "commit_wait_seconds",
+		Help:      "Time transaction commits waited for earlier commits to the same HAProxy Data Plane API.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"instance"})
+
+	// DataplaneCacheRequests counts the reads that could be answered from the read cache per instance and result
+	DataplaneCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "cache_requests_total",
+		Help:      "Reads of the HAProxy configuration that could be answered from the read cache by result (hit, miss).",
+	}, []string{"instance", "result"})
+
+	// DataplaneCircuitState reports the circuit breaker state per instance (0 = closed, 1 = open, 2 = half-open)
+	DataplaneCircuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "circuit_state",
+		Help:      "State of the Data Plane API circuit breaker (0 = closed, 1 = open, 2 = half-open).",
+	}, []string{"instance"})
+
+	// DataplaneActiveEndpoint reports which of the configured Data Plane API URLs of an instance is in use
+	DataplaneActiveEndpoint = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "dataplane",
+		Name:      "active_endpoint",
+		Help:      "Whether a configured Data Plane API URL is the one in use (1) or not (0).",
+	}, []string{"instance", "url"})
+
+	// NetplanConfigCacheRequests counts the loads of the Netplan configuration file by whether the parsed file was reused
+	NetplanConfigCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "netplan",
+		Name:      "config_cache_requests_total",
+		Help:      "Loads of the Netplan configuration file that could reuse the parsed file by result (hit, miss).",
+	}, []string{"result"})
+
+	// NetplanOrphanedAddresses reports how many VIPs in the Netplan configuration the last search found without a bind
+	NetplanOrphanedAddresses = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "netplan",
+		Name:      "orphaned_addresses",
+		Help:      "Number of VIPs in the Netplan configuration that no bind listens on, as of the last search.",
+	})
+
+	// DriftChanges reports how many changes separate the live configuration of an instance from its desired state
+	DriftChanges = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "drift",
+		Name:      "changes",
+		Help:      "Number of changes needed to make the live configuration match the desired state (0 = no drift).",
+	}, []string{"instance"})
+
+	// IdempotentReplays counts the calls answered with the response of an earlier call with the same idempotency key
+	IdempotentReplays = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "idempotent_replays_total",
+		Help:      "Number of calls answered with the response of an earlier call with the same idempotency key.",
+	}, []string{"method"})
+
+	// Leader reports whether this instance holds the leadership among redundant configurators
+	Leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "leader_election",
+		Name:      "leader",
+		Help:      "Whether this instance is the elected leader (1) or a standby (0).",
+	})
+
+	// AuditEventsDropped counts the changes that could not be forwarded to syslog or journald per target
+	AuditEventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "audit",
+		Name:      "events_dropped_total",
+		Help:      "Configuration changes that could not be forwarded to the audit target (syslog or journald).",
+	}, []string{"target"})
+
+	// ClusterReplicaSynced reports whether the last replication to a cluster node succeeded (1) or failed (0)
+	ClusterReplicaSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "cluster",
+		Name:      "replica_synced",
+		Help:      "Whether the last replication to a cluster node succeeded (1) or failed (0).",
+	}, []string{"instance"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		DataplaneRequestDuration,
+		DataplaneRequests,
+		DataplaneCacheRequests,
+		DataplaneCommitWait,
+		DataplaneCircuitState,
+		DataplaneActiveEndpoint,
+		NetplanConfigCacheRequests,
+		NetplanOrphanedAddresses,
+		ClusterReplicaSynced,
+		AuditEventsDropped,
+		DriftChanges,
+		IdempotentReplays,
+		Leader,
+	)
+}
+
+// Handler returns an HTTP handler serving all registered metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
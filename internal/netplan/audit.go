@@ -0,0 +1,61 @@
+package netplan
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+)
+
+// AddressStatus is a tracked VIP together with the interface it is actually configured on
+type AddressStatus struct {
+	Address       string
+	Interface     string // Interface the VIP was assigned to through Netplan
+	HostInterface string // Interface the VIP is configured on, empty if it is missing on the host
+}
+
+// hostAddresses maps every address configured on the host to its interface; tests replace it
+var hostAddresses = func() (map[netip.Addr]string, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	addresses := make(map[netip.Addr]string)
+	for _, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses of %s: %w", iface.Name, err)
+		}
+		for _, addr := range addrs {
+			prefix, err := netip.ParsePrefix(addr.String())
+			if err != nil {
+				continue
+			}
+			addresses[prefix.Addr().Unmap()] = iface.Name
+		}
+	}
+	return addresses, nil
+}
+
+// AuditAddresses checks which of the tracked VIPs are actually configured on the host, ordered by address
+func (m *Manager) AuditAddresses() ([]AddressStatus, error) {
+	configured, err := hostAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	statuses := make([]AddressStatus, 0, len(m.addresses))
+	for address, iface := range m.addresses {
+		status := AddressStatus{Address: address, Interface: iface}
+		if ip, err := netip.ParseAddr(address); err == nil {
+			status.HostInterface = configured[ip.Unmap()]
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Address < statuses[j].Address })
+	return statuses, nil
+}
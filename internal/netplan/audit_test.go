@@ -0,0 +1,41 @@
+package netplan
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAuditAddresses(t *testing.T) {
+	original := hostAddresses
+	t.Cleanup(func() { hostAddresses = original })
+	hostAddresses = func() (map[netip.Addr]string, error) {
+		return map[netip.Addr]string{
+			netip.MustParseAddr("192.168.1.100"): "eth0",
+			netip.MustParseAddr("2001:db8::10"):  "eth1",
+		}, nil
+	}
+
+	manager := &Manager{addresses: map[string]string{
+		"192.168.1.101": "eth0",
+		"192.168.1.100": "eth0",
+		"2001:db8::10":  "eth1",
+	}}
+	statuses, err := manager.AuditAddresses()
+	if err != nil {
+		t.Fatalf("AuditAddresses failed: %v", err)
+	}
+
+	expected := []AddressStatus{
+		{Address: "192.168.1.100", Interface: "eth0", HostInterface: "eth0"},
+		{Address: "192.168.1.101", Interface: "eth0"},
+		{Address: "2001:db8::10", Interface: "eth1", HostInterface: "eth1"},
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d statuses, got %+v", len(expected), statuses)
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], statuses[i])
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package netplan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// bindIndexFile is the file in the transaction directory that the bind index is persisted to
+const bindIndexFile = "bind-index.json"
+
+// BindEntry is the address and port of a bind
+type BindEntry struct {
+	Address string `json:"address"`
+	Port    int    `json:"port,omitempty"`
+}
+
+// bindIndex remembers the address of every bind written through the configurator, so that deleting a bind
+// does not need a request to learn which VIP to release. Changes made in a transaction are kept apart until
+// it is committed; a nil pending entry marks a bind deleted in the transaction.
+type bindIndex struct {
+	Committed map[string]BindEntry             `json:"committed"`
+	Pending   map[string]map[string]*BindEntry `json:"pending,omitempty"` // By transaction ID
+}
+
+// BindKey returns the key of a bind in the bind index
+func BindKey(frontend, name string) string {
+	return frontend + "/" + name
+}
+
+// loadBindIndex reads the persisted bind index. A missing or unreadable index starts out empty, as lookups
+// that miss fall back to the Data Plane API.
+func loadBindIndex(fsys FS, path string) bindIndex {
+	index := bindIndex{Committed: make(map[string]BindEntry), Pending: make(map[string]map[string]*BindEntry)}
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.GetLogger().Warn("Failed to read bind index, starting with an empty one",
+				zap.String("path", path),
+				zap.Error(err))
+		}
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		logger.GetLogger().Warn("Failed to parse bind index, starting with an empty one",
+			zap.String("path", path),
+			zap.Error(err))
+		return bindIndex{Committed: make(map[string]BindEntry), Pending: make(map[string]map[string]*BindEntry)}
+	}
+	if index.Committed == nil {
+		index.Committed = make(map[string]BindEntry)
+	}
+	if index.Pending == nil {
+		index.Pending = make(map[string]map[string]*BindEntry)
+	}
+	return index
+}
+
+// saveBinds persists the bind index; the caller holds bindsMutex. A failure only costs lookups after a
+// restart, so it is logged rather than returned.
+func (m *Manager) saveBinds() {
+	path := filepath.Join(m.transactionDir, bindIndexFile)
+	if err := writeFileAtomic(m.fs, path, m.binds); err != nil {
+		logger.GetLogger().Warn("Failed to persist bind index",
+			zap.String("path", path),
+			zap.Error(err))
+	}
+}
+
+// writeFileAtomic writes value as JSON through a temporary file, so readers never see a partial file
+func writeFileAtomic(fsys FS, path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal: %w", err)
+	}
+	temp := path + ".tmp"
+	if err := fsys.WriteFile(temp, data, 0644); err != nil {
+		return err
+	}
+	return fsys.Rename(temp, path)
+}
+
+// RecordBind remembers the address of a bind created or updated in a transaction
+func (m *Manager) RecordBind(transactionID, frontend, name, address string, port int) {
+	m.setPendingBind(transactionID, BindKey(frontend, name), &BindEntry{Address: address, Port: port})
+}
+
+// ForgetBind marks a bind deleted in a transaction
+func (m *Manager) ForgetBind(transactionID, frontend, name string) {
+	m.setPendingBind(transactionID, BindKey(frontend, name), nil)
+}
+
+// setPendingBind records a change of a bind in a transaction
+func (m *Manager) setPendingBind(transactionID, key string, entry *BindEntry) {
+	m.bindsMutex.Lock()
+	defer m.bindsMutex.Unlock()
+
+	if m.binds.Pending[transactionID] == nil {
+		m.binds.Pending[transactionID] = make(map[string]*BindEntry)
+	}
+	m.binds.Pending[transactionID][key] = entry
+	m.saveBinds()
+}
+
+// LookupBind returns the address of a bind as seen in a transaction: its changes in the transaction if any,
+// otherwise the committed entry. It reports false if the bind is unknown or deleted in the transaction.
+func (m *Manager) LookupBind(transactionID, frontend, name string) (BindEntry, bool) {
+	m.bindsMutex.Lock()
+	defer m.bindsMutex.Unlock()
+
+	key := BindKey(frontend, name)
+	if entry, ok := m.binds.Pending[transactionID][key]; ok {
+		if entry == nil {
+			return BindEntry{}, false
+		}
+		return *entry, true
+	}
+	entry, ok := m.binds.Committed[key]
+	return entry, ok
+}
+
+// CommitBinds applies the bind changes of a committed transaction to the index
+func (m *Manager) CommitBinds(transactionID string) {
+	m.bindsMutex.Lock()
+	defer m.bindsMutex.Unlock()
+
+	pending, ok := m.binds.Pending[transactionID]
+	if !ok {
+		return
+	}
+	for key, entry := range pending {
+		if entry == nil {
+			delete(m.binds.Committed, key)
+		} else {
+			m.binds.Committed[key] = *entry
+		}
+	}
+	delete(m.binds.Pending, transactionID)
+	m.saveBinds()
+}
+
+// DiscardBinds drops the bind changes of a closed transaction
+func (m *Manager) DiscardBinds(transactionID string) {
+	m.bindsMutex.Lock()
+	defer m.bindsMutex.Unlock()
+
+	if _, ok := m.binds.Pending[transactionID]; !ok {
+		return
+	}
+	delete(m.binds.Pending, transactionID)
+	m.saveBinds()
+}
+
+// ReplaceBinds replaces the committed entries with the live binds, keyed by BindKey, e.g. on startup to pick
+// up changes made while the configurator was not running. Pending changes are kept.
+func (m *Manager) ReplaceBinds(binds map[string]BindEntry) {
+	m.bindsMutex.Lock()
+	defer m.bindsMutex.Unlock()
+
+	if len(binds) == 0 && len(m.binds.Committed) == 0 {
+		return
+	}
+	m.binds.Committed = make(map[string]BindEntry, len(binds))
+	for key, entry := range binds {
+		m.binds.Committed[key] = entry
+	}
+	m.saveBinds()
+}
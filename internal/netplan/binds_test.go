@@ -0,0 +1,46 @@
+package netplan
+
+import (
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestBindIndex(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{Netplan: config.NetplanSettings{TransactionDir: "/var/lib/haproxy-configurator/transactions"}}
+	manager, fsys, runner := newMemoryManager(cfg)
+
+	manager.ReplaceBinds(map[string]BindEntry{BindKey("web", "vip"): {Address: "192.168.1.100", Port: 443}})
+	manager.RecordBind("txn-1", "web", "vip2", "192.168.1.101", 80)
+	manager.ForgetBind("txn-1", "web", "vip")
+
+	expect := func(manager *Manager, transactionID, name, address string) {
+		t.Helper()
+		entry, ok := manager.LookupBind(transactionID, "web", name)
+		if address == "" && ok {
+			t.Errorf("Expected %s to be unknown in %q, got %+v", name, transactionID, entry)
+		}
+		if address != "" && (!ok || entry.Address != address) {
+			t.Errorf("Expected %s at %s in %q, got %+v, %t", name, address, transactionID, entry, ok)
+		}
+	}
+
+	// Changes in a transaction are only seen in it
+	expect(manager, "txn-1", "vip", "")
+	expect(manager, "txn-1", "vip2", "192.168.1.101")
+	expect(manager, "", "vip", "192.168.1.100")
+	expect(manager, "", "vip2", "")
+
+	// The index survives a restart
+	restarted := NewManagerWithFS(cfg, fsys, runner)
+	expect(restarted, "txn-1", "vip2", "192.168.1.101")
+
+	restarted.CommitBinds("txn-1")
+	expect(restarted, "", "vip", "")
+	expect(restarted, "", "vip2", "192.168.1.101")
+
+	restarted.RecordBind("txn-2", "web", "vip2", "192.168.1.102", 80)
+	restarted.DiscardBinds("txn-2")
+	expect(restarted, "txn-2", "vip2", "192.168.1.101")
+}
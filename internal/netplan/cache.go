@@ -0,0 +1,161 @@
+package netplan
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// fileStamp identifies a version of the Netplan configuration file; the zero value stands for a missing file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// stampOf returns the stamp of the file described by info, which is nil for a missing file
+func stampOf(info os.FileInfo) fileStamp {
+	if info == nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// parsedConfig remembers the parsed Netplan configuration file while the file is watched, so that operations
+// do not read and parse it again. The file watch drops it when the file is changed around the configurator;
+// writes of the manager itself replace it.
+type parsedConfig struct {
+	mutex      sync.Mutex
+	watching   bool                  // The cache is only used while the file is watched
+	cancel     context.CancelFunc    // Stops the file watch
+	config     *NetplanConfiguration // Nil when nothing is cached
+	stamp      fileStamp             // Stamp of the file config was read from or written to
+	generation uint64                // Incremented on every invalidation, so reads racing with a change are not stored
+}
+
+// get returns a copy of the cached configuration, if any. Without a hit it returns the generation to store the
+// configuration read instead with.
+func (p *parsedConfig) get() (*NetplanConfiguration, uint64, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.watching {
+		return nil, p.generation, false
+	}
+	if p.config == nil {
+		metrics.NetplanConfigCacheRequests.WithLabelValues("miss").Inc()
+		return nil, p.generation, false
+	}
+	metrics.NetplanConfigCacheRequests.WithLabelValues("hit").Inc()
+	return p.config.clone(), p.generation, true
+}
+
+// put remembers a copy of netplanConfig as the content of the file with the given stamp, unless the cache was
+// invalidated since generation
+func (p *parsedConfig) put(netplanConfig *NetplanConfiguration, stamp fileStamp, generation uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.watching || generation != p.generation {
+		return
+	}
+	p.config = netplanConfig.clone()
+	p.stamp = stamp
+}
+
+// currentGeneration returns the generation to store a configuration written now with
+func (p *parsedConfig) currentGeneration() uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.generation
+}
+
+// changed drops the cached configuration unless it was read from or written to the file with the given stamp,
+// so the watch does not drop it for the writes of the manager itself
+func (p *parsedConfig) changed(stamp fileStamp) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.config != nil && stamp == p.stamp {
+		return
+	}
+	p.clear()
+}
+
+// invalidate drops the cached configuration
+func (p *parsedConfig) invalidate() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.clear()
+}
+
+// clear drops the cached configuration; the caller holds the mutex
+func (p *parsedConfig) clear() {
+	p.generation++
+	p.config = nil
+}
+
+// watchConfigFile starts caching the parsed configuration file and watching it for changes until ctx is
+// cancelled or Close is called. If the file cannot be watched, every operation reads it as before.
+func (m *Manager) watchConfigFile(ctx context.Context) {
+	configPath := m.currentConfig().Netplan.ConfigPath
+	if configPath == "" {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	err := config.WatchFile(ctx, configPath, func() {
+		info, err := m.fs.Stat(configPath)
+		if err != nil {
+			info = nil
+		}
+		m.parsed.changed(stampOf(info))
+	})
+	if err != nil {
+		cancel()
+		logger.GetLogger().Warn("Failed to watch Netplan config file, reading it on every operation",
+			zap.String("config_path", configPath),
+			zap.Error(err))
+		return
+	}
+
+	m.parsed.mutex.Lock()
+	defer m.parsed.mutex.Unlock()
+	m.parsed.watching = true
+	m.parsed.cancel = cancel
+}
+
+// Close stops watching the Netplan configuration file, e.g. when the manager is replaced on reload
+func (m *Manager) Close() {
+	m.parsed.mutex.Lock()
+	defer m.parsed.mutex.Unlock()
+	if m.parsed.cancel != nil {
+		m.parsed.cancel()
+		m.parsed.cancel = nil
+	}
+	m.parsed.watching = false
+	m.parsed.clear()
+}
+
+// clone copies the parts of the configuration the manager modifies: the interface maps and their address lists.
+// The remaining fields are shared with the original.
+func (c *NetplanConfiguration) clone() *NetplanConfiguration {
+	result := *c
+	if c.Network.Ethernets != nil {
+		result.Network.Ethernets = make(map[string]NetplanInterface, len(c.Network.Ethernets))
+		for name, iface := range c.Network.Ethernets {
+			iface.Addresses = append([]string(nil), iface.Addresses...)
+			result.Network.Ethernets[name] = iface
+		}
+	}
+	if c.Network.Vlans != nil {
+		result.Network.Vlans = make(map[string]NetplanVLAN, len(c.Network.Vlans))
+		for name, vlan := range c.Network.Vlans {
+			vlan.Addresses = append([]string(nil), vlan.Addresses...)
+			result.Network.Vlans[name] = vlan
+		}
+	}
+	return &result
+}
@@ -0,0 +1,86 @@
+package netplan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestParsedConfigCache(t *testing.T) {
+	setupTest()
+
+	configPath := filepath.Join(t.TempDir(), "netplan.yaml")
+	writeConfig := func(address string) {
+		t.Helper()
+		content := "network:\n  version: 2\n  ethernets:\n    eth0:\n      addresses: [\"" + address + "\"]\n"
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write Netplan config: %v", err)
+		}
+	}
+	writeConfig("192.168.1.10/24")
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        configPath,
+			TransactionDir:    t.TempDir(),
+		},
+	}
+	manager := NewManagerWithMock(cfg, &MockNetplanApplier{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.watchConfigFile(ctx)
+	defer manager.Close()
+
+	addresses := func() []string {
+		t.Helper()
+		netplanConfig, err := manager.loadNetplanConfig()
+		if err != nil {
+			t.Fatalf("loadNetplanConfig failed: %v", err)
+		}
+		return netplanConfig.Network.Ethernets["eth0"].Addresses
+	}
+
+	// Callers modify their own copy
+	first := addresses()
+	first[0] = "modified"
+	if got := addresses(); !slices.Equal(got, []string{"192.168.1.10/24"}) {
+		t.Fatalf("Expected the cached configuration to be unchanged, got %v", got)
+	}
+	if _, _, ok := manager.parsed.get(); !ok {
+		t.Fatal("Expected the parsed configuration to be cached")
+	}
+
+	// Writes of the manager replace the cached configuration
+	if err := manager.AddIPAddressToTransaction("txn-1", "192.168.1.100", 80); err != nil {
+		t.Fatalf("AddIPAddressToTransaction failed: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "txn-1"); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	if got := addresses(); !slices.Equal(got, []string{"192.168.1.10/24", "192.168.1.100/24"}) {
+		t.Fatalf("Expected the committed address, got %v", got)
+	}
+
+	// Changes around the manager are picked up once the watch reports them
+	writeConfig("192.168.1.20/24")
+	deadline := time.Now().Add(5 * time.Second)
+	for !slices.Equal(addresses(), []string{"192.168.1.20/24"}) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the external change, got %v", addresses())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Without the watch every load reads the file
+	manager.Close()
+	writeConfig("192.168.1.30/24")
+	if got := addresses(); !slices.Equal(got, []string{"192.168.1.30/24"}) {
+		t.Errorf("Expected the file to be read after Close, got %v", got)
+	}
+}
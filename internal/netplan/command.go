@@ -0,0 +1,49 @@
+package netplan
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// CommandRunner runs external commands such as netplan apply
+type CommandRunner interface {
+	// Run runs a command until it exits or ctx is done, returning its combined output
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// ExecRunner runs commands on the host
+type ExecRunner struct{}
+
+// Run executes the command, killing it if ctx is done first
+func (ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// MockCommandRunner records commands instead of running them, for testing
+type MockCommandRunner struct {
+	mutex    sync.Mutex
+	commands []string
+	Output   []byte
+	Err      error // Returned by every command while set
+}
+
+// Run records the command line and returns the configured output and error
+func (m *MockCommandRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.commands = append(m.commands, strings.Join(append([]string{name}, args...), " "))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.Output, m.Err
+}
+
+// Commands returns the command lines run so far, e.g. "netplan apply"
+func (m *MockCommandRunner) Commands() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.commands...)
+}
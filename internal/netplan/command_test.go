@@ -0,0 +1,27 @@
+package netplan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRealNetplanApplier(t *testing.T) {
+	runner := &MockCommandRunner{}
+	applier := &RealNetplanApplier{Runner: runner}
+
+	if err := applier.Apply(context.Background()); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if commands := runner.Commands(); len(commands) != 1 || commands[0] != "netplan apply" {
+		t.Errorf("Expected netplan apply to be run, got %v", commands)
+	}
+
+	runner.Output = []byte("Invalid YAML")
+	runner.Err = errors.New("exit status 1")
+	err := applier.Apply(context.Background())
+	if err == nil || !errors.Is(err, runner.Err) || !strings.Contains(err.Error(), "Invalid YAML") {
+		t.Errorf("Expected the error to wrap the exit status and include the output, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package netplan
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS is the file system the manager keeps the Netplan configuration, its backups and the transaction files on.
+// Errors for missing files match fs.ErrNotExist.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+}
+
+// OSFS is the file system of the host
+type OSFS struct{}
+
+// ReadFile reads a file, see os.ReadFile
+func (OSFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+// WriteFile writes a file, see os.WriteFile
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// Stat describes a file, see os.Stat
+func (OSFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+// ReadDir lists a directory sorted by name, see os.ReadDir
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+// MkdirAll creates a directory and its parents, see os.MkdirAll
+func (OSFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+// Rename moves a file, see os.Rename
+func (OSFS) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+// Remove deletes a file or an empty directory, see os.Remove
+func (OSFS) Remove(name string) error { return os.Remove(name) }
+
+// MemFS is an in-memory file system for tests. Like the host file system, files can only be written to
+// existing directories.
+type MemFS struct {
+	mutex sync.Mutex
+	files map[string]*memFile // By cleaned path
+}
+
+// memFile is a file or directory of a MemFS
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFS creates an empty in-memory file system
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string]*memFile)}
+}
+
+// ReadFile returns a copy of the content of a file
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	file, err := m.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if file.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte(nil), file.data...), nil
+}
+
+// WriteFile creates or replaces a file in an existing directory
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := filepath.Clean(name)
+	if err := m.checkParent("open", key); err != nil {
+		return err
+	}
+	if file, ok := m.files[key]; ok && file.mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+	m.files[key] = &memFile{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+// Stat describes a file or directory
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if isRoot(filepath.Clean(name)) {
+		return memFileInfo{name: filepath.Base(name), file: &memFile{mode: fs.ModeDir | 0755}}, nil
+	}
+	file, err := m.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return memFileInfo{name: filepath.Base(name), file: file}, nil
+}
+
+// ReadDir lists the entries of a directory sorted by name
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	dir := filepath.Clean(name)
+	if !isRoot(dir) {
+		file, err := m.lookup("open", name)
+		if err != nil {
+			return nil, err
+		}
+		if !file.mode.IsDir() {
+			return nil, &fs.PathError{Op: "readdirent", Path: name, Err: errors.New("not a directory")}
+		}
+	}
+
+	var entries []fs.DirEntry
+	for key, file := range m.files {
+		if filepath.Dir(key) == dir && key != dir {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: filepath.Base(key), file: file}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// MkdirAll creates a directory and any missing parents
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := filepath.Clean(path)
+	var missing []string
+	for dir := key; !isRoot(dir); dir = filepath.Dir(dir) {
+		if file, ok := m.files[dir]; ok {
+			if !file.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: errors.New("not a directory")}
+			}
+			break
+		}
+		missing = append(missing, dir)
+	}
+	for _, dir := range missing {
+		m.files[dir] = &memFile{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+// Rename moves a file or directory, replacing an existing file at newpath
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	from, to := filepath.Clean(oldpath), filepath.Clean(newpath)
+	file, err := m.lookup("rename", oldpath)
+	if err != nil {
+		return err
+	}
+	if err := m.checkParent("rename", to); err != nil {
+		return err
+	}
+	if target, ok := m.files[to]; ok && target.mode.IsDir() {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: errors.New("file exists")}
+	}
+
+	delete(m.files, from)
+	m.files[to] = file
+	if file.mode.IsDir() {
+		for key, child := range m.files {
+			if strings.HasPrefix(key, from+string(filepath.Separator)) {
+				delete(m.files, key)
+				m.files[to+strings.TrimPrefix(key, from)] = child
+			}
+		}
+	}
+	return nil
+}
+
+// Remove deletes a file or an empty directory
+func (m *MemFS) Remove(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := filepath.Clean(name)
+	file, err := m.lookup("remove", name)
+	if err != nil {
+		return err
+	}
+	if file.mode.IsDir() {
+		for child := range m.files {
+			if filepath.Dir(child) == key {
+				return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+			}
+		}
+	}
+	delete(m.files, key)
+	return nil
+}
+
+// lookup returns an existing file or directory; the caller holds the mutex
+func (m *MemFS) lookup(op, name string) (*memFile, error) {
+	file, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return file, nil
+}
+
+// checkParent fails unless the directory a file is created in exists; the caller holds the mutex
+func (m *MemFS) checkParent(op, key string) error {
+	parent := filepath.Dir(key)
+	if isRoot(parent) {
+		return nil
+	}
+	if file, ok := m.files[parent]; !ok || !file.mode.IsDir() {
+		return &fs.PathError{Op: op, Path: key, Err: fs.ErrNotExist}
+	}
+	return nil
+}
+
+// isRoot reports whether a cleaned path is the root or current directory, which always exist
+func isRoot(path string) bool {
+	return path == "." || path == string(filepath.Separator)
+}
+
+// memFileInfo describes a file of a MemFS
+type memFileInfo struct {
+	name string
+	file *memFile
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return int64(len(i.file.data)) }
+func (i memFileInfo) Mode() fs.FileMode  { return i.file.mode }
+func (i memFileInfo) ModTime() time.Time { return i.file.modTime }
+func (i memFileInfo) IsDir() bool        { return i.file.mode.IsDir() }
+func (i memFileInfo) Sys() any           { return nil }
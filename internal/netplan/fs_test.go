@@ -0,0 +1,51 @@
+package netplan
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+)
+
+func TestMemFS(t *testing.T) {
+	fsys := NewMemFS()
+
+	if _, err := fsys.ReadFile("/etc/netplan/netplan.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a missing file, got %v", err)
+	}
+	if err := fsys.WriteFile("/etc/netplan/netplan.yaml", []byte("network: {}"), 0600); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected writes to a missing directory to fail, got %v", err)
+	}
+
+	if err := fsys.MkdirAll("/etc/netplan/committed", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	for _, name := range []string{"/etc/netplan/b.yaml", "/etc/netplan/a.yaml"} {
+		if err := fsys.WriteFile(name, []byte(name), 0600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	entries, err := fsys.ReadDir("/etc/netplan")
+	if err != nil || len(entries) != 3 || entries[0].Name() != "a.yaml" || entries[2].Name() != "committed" || !entries[2].IsDir() {
+		t.Fatalf("Expected a.yaml, b.yaml and committed, got %v: %v", entries, err)
+	}
+
+	if err := fsys.Rename("/etc/netplan/a.yaml", "/etc/netplan/committed/a.yaml"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if data, err := fsys.ReadFile("/etc/netplan/committed/a.yaml"); err != nil || string(data) != "/etc/netplan/a.yaml" {
+		t.Errorf("Expected the renamed file, got %q: %v", data, err)
+	}
+	if _, err := fsys.Stat("/etc/netplan/a.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the old name to be gone, got %v", err)
+	}
+
+	if err := fsys.Remove("/etc/netplan/committed"); err == nil {
+		t.Error("Expected removing a non-empty directory to fail")
+	}
+	if err := fsys.Remove("/etc/netplan/committed/a.yaml"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := fsys.Remove("/etc/netplan/committed"); err != nil {
+		t.Errorf("Expected removing an empty directory to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package netplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// generateRootDir is the directory in the transaction directory that configurations are staged in for netplan
+// generate
+const generateRootDir = "netplan-generate"
+
+// validateNetplanConfig checks data, the content about to be written to the Netplan config file, with
+// netplan generate before it goes live. The content is staged in a root directory of its own next to copies of
+// the other Netplan files in the directory of the config file, so that conflicts with them are found as well. It
+// returns an error with the output of netplan generate if the configuration is rejected. Nothing is checked with
+// the networkmanager backend, skip_generate set or without a command runner. The caller holds configMutex.
+func (m *Manager) validateNetplanConfig(ctx context.Context, data []byte) error {
+	settings := m.currentConfig().Netplan
+	if m.runner == nil || settings.UsesNetworkManager() || settings.SkipGenerate {
+		return nil
+	}
+
+	configPath := settings.ConfigPath
+	rootDir := filepath.Join(m.transactionDir, generateRootDir)
+	stagingDir := filepath.Join(rootDir, "etc", "netplan")
+	if err := m.fs.MkdirAll(stagingDir, 0755); err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	// Files staged for the previous configuration may be gone from the host by now
+	staged, err := m.fs.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to read staging directory: %w", err)
+	}
+	for _, entry := range staged {
+		if err := m.fs.Remove(filepath.Join(stagingDir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to clear staging directory: %w", err)
+		}
+	}
+
+	siblings, err := m.fs.ReadDir(filepath.Dir(configPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read Netplan directory: %w", err)
+	}
+	for _, entry := range siblings {
+		name := entry.Name()
+		if entry.IsDir() || name == filepath.Base(configPath) || !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+		content, err := m.fs.ReadFile(filepath.Join(filepath.Dir(configPath), name))
+		if err != nil {
+			return fmt.Errorf("failed to read Netplan file %s: %w", name, err)
+		}
+		if err := m.fs.WriteFile(filepath.Join(stagingDir, name), content, 0600); err != nil {
+			return fmt.Errorf("failed to stage Netplan file %s: %w", name, err)
+		}
+	}
+	if err := m.fs.WriteFile(filepath.Join(stagingDir, filepath.Base(configPath)), data, 0600); err != nil {
+		return fmt.Errorf("failed to stage Netplan config: %w", err)
+	}
+
+	if output, err := m.runner.Run(ctx, "netplan", "generate", "--root-dir", rootDir); err != nil {
+		return fmt.Errorf("netplan generate rejected the configuration: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
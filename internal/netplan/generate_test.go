@@ -0,0 +1,83 @@
+package netplan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestGenerateRejectsTransaction(t *testing.T) {
+	setupTest()
+	configPath := "/etc/netplan/99-haproxy.yaml"
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        configPath,
+		},
+	}
+	manager, fsys, runner := newMemoryManager(cfg)
+	live := []byte("network:\n  version: 2\n")
+	if err := fsys.MkdirAll("/etc/netplan", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile(configPath, live, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile("/etc/netplan/01-base.yaml", []byte("network:\n  version: 2\n  ethernets:\n    eth0:\n      dhcp4: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.AddIPAddressToTransaction("tx-invalid", "192.168.1.100", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	runner.Output = []byte("Error in network definition: eth0: conflicting definitions")
+	runner.Err = errors.New("exit status 1")
+	err := manager.CommitTransaction(context.Background(), "tx-invalid")
+	if err == nil || !strings.Contains(err.Error(), "conflicting definitions") {
+		t.Fatalf("Expected netplan generate to reject the commit, got %v", err)
+	}
+
+	rootDir := filepath.Join(manager.transactionDir, generateRootDir)
+	if commands := runner.Commands(); !slices.Equal(commands, []string{"netplan generate --root-dir " + rootDir}) {
+		t.Errorf("Expected only netplan generate to be run, got %v", commands)
+	}
+	if data, _ := fsys.ReadFile(configPath); string(data) != string(live) {
+		t.Errorf("Expected the live Netplan config to stay unchanged, got %s", data)
+	}
+	if staged, err := fsys.ReadFile(filepath.Join(rootDir, "etc", "netplan", "99-haproxy.yaml")); err != nil || !strings.Contains(string(staged), "192.168.1.100/24") {
+		t.Errorf("Expected the new config to be staged, got %s, %v", staged, err)
+	}
+	if _, err := fsys.Stat(filepath.Join(rootDir, "etc", "netplan", "01-base.yaml")); err != nil {
+		t.Errorf("Expected the other Netplan files to be staged: %v", err)
+	}
+	if transaction, _ := manager.FindTransaction("tx-invalid"); transaction == nil || transaction.Status != "failed" {
+		t.Errorf("Expected the transaction to be marked failed, got %+v", transaction)
+	}
+	if len(manager.GetTrackedAddresses()) != 0 {
+		t.Error("Expected a rejected commit to track no address")
+	}
+}
+
+func TestSkipGenerate(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/99-haproxy.yaml",
+			SkipGenerate:      true,
+		},
+	}
+	manager, _, runner := newMemoryManager(cfg)
+
+	if err := manager.AddIPAddress("192.168.1.100", 80); err != nil {
+		t.Fatalf("AddIPAddress failed: %v", err)
+	}
+	if commands := runner.Commands(); len(commands) != 0 {
+		t.Errorf("Expected netplan generate to be skipped, got %v", commands)
+	}
+}
@@ -0,0 +1,43 @@
+package netplan
+
+import "sync"
+
+// fileLocks hands out a mutex per file name, so that operations on different transaction files do not wait for
+// each other. Unused mutexes are dropped.
+type fileLocks struct {
+	mutex sync.Mutex
+	locks map[string]*fileLock
+}
+
+// fileLock is the mutex of a single file and the number of callers holding or waiting for it
+type fileLock struct {
+	sync.Mutex
+	users int
+}
+
+// lock locks the file with the given name and returns the function unlocking it
+func (l *fileLocks) lock(name string) func() {
+	l.mutex.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*fileLock)
+	}
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &fileLock{}
+		l.locks[name] = lock
+	}
+	lock.users++
+	l.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(l.locks, name)
+		}
+	}
+}
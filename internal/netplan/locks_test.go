@@ -0,0 +1,122 @@
+package netplan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestFileLocks(t *testing.T) {
+	var locks fileLocks
+	counter := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer locks.lock("transaction-1")()
+			counter++
+		}()
+	}
+	wg.Wait()
+
+	if counter != 50 {
+		t.Errorf("Expected 50 serialized increments, got %d", counter)
+	}
+	if len(locks.locks) != 0 {
+		t.Errorf("Expected unused locks to be dropped, got %d", len(locks.locks))
+	}
+}
+
+// TestConcurrentTransactions is meant to be run with the race detector
+func TestConcurrentTransactions(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{
+				{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}},
+				{Interface: "vlan100@eth1", Subnets: []string{"10.100.0.0/24"}},
+			},
+			ConfigPath:     "/etc/netplan/netplan.yaml",
+			TransactionDir: "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, _, runner := newMemoryManager(cfg)
+
+	const transactions = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*transactions)
+	for i := 0; i < transactions; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			transactionID := fmt.Sprintf("tx-%d", i)
+			for _, address := range []string{fmt.Sprintf("192.168.1.%d", 110+i), fmt.Sprintf("10.100.0.%d", 110+i)} {
+				if err := manager.AddIPAddressToTransaction(transactionID, address, 80); err != nil {
+					errs <- err
+					return
+				}
+			}
+			if err := manager.CommitTransaction(context.Background(), transactionID); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			address := fmt.Sprintf("192.168.1.%d", 200+i)
+			if err := manager.AddIPAddress(address, 80); err != nil {
+				errs <- err
+				return
+			}
+			if err := manager.RemoveIPAddress(address); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = manager.GetTrackedAddresses()
+			_, _ = manager.AuditAddresses()
+			if _, err := manager.ListTransactions(); err != nil {
+				errs <- err
+			}
+			if _, err := manager.FindTransaction(fmt.Sprintf("tx-%d", i)); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Concurrent operation failed: %v", err)
+	}
+
+	// No commit may have overwritten the changes of another
+	tracked := manager.GetTrackedAddresses()
+	manager.configMutex.Lock()
+	netplanConfig, err := manager.loadNetplanConfig()
+	manager.configMutex.Unlock()
+	if err != nil {
+		t.Fatalf("Failed to load Netplan config: %v", err)
+	}
+	configured := strings.Join(append(netplanConfig.Network.Ethernets["eth0"].Addresses, netplanConfig.Network.Vlans["vlan100"].Addresses...), " ")
+	for i := 0; i < transactions; i++ {
+		for _, address := range []string{fmt.Sprintf("192.168.1.%d", 110+i), fmt.Sprintf("10.100.0.%d", 110+i)} {
+			if _, ok := tracked[address]; !ok {
+				t.Errorf("Expected %s to be tracked", address)
+			}
+			if !strings.Contains(configured+" ", address+"/24 ") {
+				t.Errorf("Expected %s in the Netplan config, got %s", address, configured)
+			}
+		}
+		if address := fmt.Sprintf("192.168.1.%d", 200+i); tracked[address] != "" || strings.Contains(configured+" ", address+"/24 ") {
+			t.Errorf("Expected %s to be removed", address)
+		}
+	}
+	if applies := len(appliesOf(runner)); applies != transactions {
+		t.Errorf("Expected %d applies, got %d", transactions, applies)
+	}
+}
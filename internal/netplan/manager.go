@@ -1,15 +1,17 @@
 package netplan
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"io/fs"
 	"net"
-	"os"
-	"os/exec"
+	"net/netip"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bear-san/haproxy-configurator/internal/config"
@@ -33,20 +35,35 @@ type Transaction struct {
 	CreatedAt     time.Time           `json:"created_at"`
 	Status        string              `json:"status"` // "pending", "committed", "failed"
 	Changes       []TransactionChange `json:"changes"`
+	Error         string              `json:"error,omitempty"`         // Why the commit failed
+	FailedChange  *int                `json:"failed_change,omitempty"` // Index of the change that could not be applied, if the commit failed on one
+	FailedAt      *time.Time          `json:"failed_at,omitempty"`
+	CommittedAt   *time.Time          `json:"committed_at,omitempty"`
+}
+
+// ApplyResult is the outcome of applying the Netplan configuration to the host
+type ApplyResult struct {
+	Time time.Time
+	Err  error // Nil if the configuration was applied
 }
 
 // NetplanApplier interface for applying netplan configurations
 type NetplanApplier interface {
-	Apply() error
+	Apply(ctx context.Context) error
 }
 
 // RealNetplanApplier implements NetplanApplier using actual netplan command
-type RealNetplanApplier struct{}
+type RealNetplanApplier struct {
+	Runner CommandRunner // Runs netplan; nil runs it on the host
+}
 
-// Apply executes the actual netplan apply command
-func (r *RealNetplanApplier) Apply() error {
-	cmd := exec.Command("netplan", "apply")
-	output, err := cmd.CombinedOutput()
+// Apply executes the actual netplan apply command, killing it if ctx is done first
+func (r *RealNetplanApplier) Apply(ctx context.Context) error {
+	runner := r.Runner
+	if runner == nil {
+		runner = ExecRunner{}
+	}
+	output, err := runner.Run(ctx, "netplan", "apply")
 	if err != nil {
 		return fmt.Errorf("failed to apply Netplan configuration: %w, output: %s", err, string(output))
 	}
@@ -60,18 +77,34 @@ type MockNetplanApplier struct {
 }
 
 // Apply mocks the netplan apply command for testing
-func (m *MockNetplanApplier) Apply() error {
+func (m *MockNetplanApplier) Apply(ctx context.Context) error {
 	m.ApplyCallCount++
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return m.ApplyError
 }
 
-// Manager handles Netplan configuration operations
+// Manager handles Netplan configuration operations. Its locks are taken in the order configMutex,
+// transactionsMutex, transactionLocks, mutex, and never the other way round.
 type Manager struct {
-	config         *config.Config
-	addresses      map[string]string // IP -> Interface mapping for tracking
-	transactionDir string            // Directory for transaction files
-	mutex          sync.RWMutex      // Protects addresses map
-	applier        NetplanApplier    // Netplan applier (real or mock)
+	config         atomic.Pointer[config.Config] // Swapped by UpdateSettings
+	transactionDir string                        // Directory for transaction files
+	applier        NetplanApplier                // Netplan applier (real or mock)
+	runner         CommandRunner                 // Runs netplan generate on configurations before they are written; nil skips the check
+	fs             FS                            // Holds the Netplan config file, its backups and the transaction files
+
+	configMutex       sync.Mutex        // Held for whole read-modify-write cycles of the Netplan config file and netplan apply
+	transactionsMutex sync.RWMutex      // Held for reading while a transaction file is changed, for writing to replace them all
+	transactionLocks  fileLocks         // Serializes changes to each transaction file
+	mutex             sync.RWMutex      // Protects addresses and lastApply
+	addresses         map[string]string // IP -> Interface mapping for tracking
+	lastApply         *ApplyResult      // Outcome of the last netplan apply, nil before the first
+
+	bindsMutex sync.Mutex
+	binds      bindIndex // Addresses of the binds, see RecordBind
+
+	parsed parsedConfig // Parsed Netplan configuration file, see loadNetplanConfig
 }
 
 // NetplanConfiguration represents the structure of a Netplan YAML file
@@ -88,39 +121,41 @@ type NetplanNetwork struct {
 
 // NetplanInterface represents a network interface configuration
 type NetplanInterface struct {
-	Addresses   []string               `yaml:"addresses,omitempty"`
-	DHCP4       bool                   `yaml:"dhcp4,omitempty"`
-	DHCP6       bool                   `yaml:"dhcp6,omitempty"`
-	Gateway4    string                 `yaml:"gateway4,omitempty"`
-	Gateway6    string                 `yaml:"gateway6,omitempty"`
-	MTU         int                    `yaml:"mtu,omitempty"`
-	MACAddress  string                 `yaml:"macaddress,omitempty"`
-	Critical    bool                   `yaml:"critical,omitempty"`
-	Optional    bool                   `yaml:"optional,omitempty"`
-	Routes      []NetplanRoute         `yaml:"routes,omitempty"`
-	Nameservers *NetplanNameservers    `yaml:"nameservers,omitempty"`
-	Renderer    string                 `yaml:"renderer,omitempty"`
-	Match       *NetplanMatch          `yaml:"match,omitempty"`
-	SetName     string                 `yaml:"set-name,omitempty"`
-	Additional  map[string]interface{} `yaml:",inline"` // Preserve unknown fields
+	Addresses     []string               `yaml:"addresses,omitempty"`
+	DHCP4         bool                   `yaml:"dhcp4,omitempty"`
+	DHCP6         bool                   `yaml:"dhcp6,omitempty"`
+	Gateway4      string                 `yaml:"gateway4,omitempty"`
+	Gateway6      string                 `yaml:"gateway6,omitempty"`
+	MTU           int                    `yaml:"mtu,omitempty"`
+	MACAddress    string                 `yaml:"macaddress,omitempty"`
+	Critical      bool                   `yaml:"critical,omitempty"`
+	Optional      bool                   `yaml:"optional,omitempty"`
+	Routes        []NetplanRoute         `yaml:"routes,omitempty"`
+	RoutingPolicy []NetplanRoutingPolicy `yaml:"routing-policy,omitempty"`
+	Nameservers   *NetplanNameservers    `yaml:"nameservers,omitempty"`
+	Renderer      string                 `yaml:"renderer,omitempty"`
+	Match         *NetplanMatch          `yaml:"match,omitempty"`
+	SetName       string                 `yaml:"set-name,omitempty"`
+	Additional    map[string]interface{} `yaml:",inline"` // Preserve unknown fields
 }
 
 // NetplanVLAN represents a VLAN interface configuration
 type NetplanVLAN struct {
-	ID          int                    `yaml:"id"`
-	Link        string                 `yaml:"link"`
-	Optional    bool                   `yaml:"optional,omitempty"`
-	Addresses   []string               `yaml:"addresses,omitempty"`
-	DHCP4       bool                   `yaml:"dhcp4,omitempty"`
-	DHCP6       bool                   `yaml:"dhcp6,omitempty"`
-	Gateway4    string                 `yaml:"gateway4,omitempty"`
-	Gateway6    string                 `yaml:"gateway6,omitempty"`
-	MTU         int                    `yaml:"mtu,omitempty"`
-	Critical    bool                   `yaml:"critical,omitempty"`
-	Routes      []NetplanRoute         `yaml:"routes,omitempty"`
-	Nameservers *NetplanNameservers    `yaml:"nameservers,omitempty"`
-	Renderer    string                 `yaml:"renderer,omitempty"`
-	Additional  map[string]interface{} `yaml:",inline"` // Preserve unknown fields
+	ID            int                    `yaml:"id"`
+	Link          string                 `yaml:"link"`
+	Optional      bool                   `yaml:"optional,omitempty"`
+	Addresses     []string               `yaml:"addresses,omitempty"`
+	DHCP4         bool                   `yaml:"dhcp4,omitempty"`
+	DHCP6         bool                   `yaml:"dhcp6,omitempty"`
+	Gateway4      string                 `yaml:"gateway4,omitempty"`
+	Gateway6      string                 `yaml:"gateway6,omitempty"`
+	MTU           int                    `yaml:"mtu,omitempty"`
+	Critical      bool                   `yaml:"critical,omitempty"`
+	Routes        []NetplanRoute         `yaml:"routes,omitempty"`
+	RoutingPolicy []NetplanRoutingPolicy `yaml:"routing-policy,omitempty"`
+	Nameservers   *NetplanNameservers    `yaml:"nameservers,omitempty"`
+	Renderer      string                 `yaml:"renderer,omitempty"`
+	Additional    map[string]interface{} `yaml:",inline"` // Preserve unknown fields
 }
 
 // NetplanNameservers represents DNS configuration
@@ -141,6 +176,16 @@ type NetplanRoute struct {
 	Table  int    `yaml:"table,omitempty"`
 }
 
+// NetplanRoutingPolicy represents a routing policy rule, i.e. an ip rule
+type NetplanRoutingPolicy struct {
+	From          string `yaml:"from,omitempty"`
+	To            string `yaml:"to,omitempty"`
+	Table         int    `yaml:"table"`
+	Priority      int    `yaml:"priority,omitempty"`
+	Mark          int    `yaml:"mark,omitempty"`
+	TypeOfService int    `yaml:"type-of-service,omitempty"`
+}
+
 // NetplanMatch represents match conditions for interface selection
 type NetplanMatch struct {
 	Name       string `yaml:"name,omitempty"`
@@ -150,31 +195,39 @@ type NetplanMatch struct {
 
 // NewManagerWithConfig creates a new Netplan manager using unified config
 func NewManagerWithConfig(cfg *config.Config) *Manager {
-	// Use configured transaction directory or default
-	transactionDir := cfg.Netplan.TransactionDir
-	if transactionDir == "" {
-		transactionDir = "/tmp/haproxy-netplan-transactions"
+	return NewManagerWithFS(cfg, OSFS{}, ExecRunner{})
+}
+
+// NewManagerWithFS creates a new Netplan manager keeping its files on fsys and running netplan apply, or nmcli
+// with the networkmanager backend, with runner, e.g. a MemFS and a MockCommandRunner in tests. The Netplan config file is only watched on the host
+// file system.
+func NewManagerWithFS(cfg *config.Config, fsys FS, runner CommandRunner) *Manager {
+	manager := newManager(cfg, fsys, &RealNetplanApplier{Runner: runner})
+	manager.runner = runner
+	if cfg.Netplan.UsesNetworkManager() {
+		manager.applier = &NetworkManagerApplier{manager: manager, runner: runner}
 	}
 
 	logger.GetLogger().Info("Initializing Netplan manager",
-		zap.String("transaction_dir", transactionDir),
+		zap.String("transaction_dir", manager.transactionDir),
 		zap.String("netplan_config_path", cfg.Netplan.ConfigPath),
-		zap.Bool("backup_enabled", cfg.Netplan.BackupEnabled))
+		zap.String("backend", cfg.Netplan.Backend),
+		zap.Bool("backup_enabled", cfg.Netplan.BackupEnabled),
+		zap.Bool("skip_generate", cfg.Netplan.SkipGenerate))
 
-	// Ensure transaction directory exists
-	_ = os.MkdirAll(transactionDir, 0755)
-	_ = os.MkdirAll(filepath.Join(transactionDir, "committed"), 0755)
-
-	return &Manager{
-		config:         cfg,
-		addresses:      make(map[string]string),
-		transactionDir: transactionDir,
-		applier:        &RealNetplanApplier{}, // Use real applier by default
+	if _, ok := fsys.(OSFS); ok {
+		manager.watchConfigFile(context.Background())
 	}
+	return manager
 }
 
 // NewManagerWithMock creates a new Netplan manager with a mock applier for testing
 func NewManagerWithMock(cfg *config.Config, mockApplier *MockNetplanApplier) *Manager {
+	return newManager(cfg, OSFS{}, mockApplier)
+}
+
+// newManager creates a manager and its transaction directory
+func newManager(cfg *config.Config, fsys FS, applier NetplanApplier) *Manager {
 	// Use configured transaction directory or default
 	transactionDir := cfg.Netplan.TransactionDir
 	if transactionDir == "" {
@@ -182,15 +235,41 @@ func NewManagerWithMock(cfg *config.Config, mockApplier *MockNetplanApplier) *Ma
 	}
 
 	// Ensure transaction directory exists
-	_ = os.MkdirAll(transactionDir, 0755)
-	_ = os.MkdirAll(filepath.Join(transactionDir, "committed"), 0755)
+	_ = fsys.MkdirAll(transactionDir, 0755)
+	_ = fsys.MkdirAll(filepath.Join(transactionDir, "committed"), 0755)
 
-	return &Manager{
-		config:         cfg,
+	manager := &Manager{
 		addresses:      make(map[string]string),
 		transactionDir: transactionDir,
-		applier:        mockApplier, // Use mock applier for testing
+		applier:        applier,
+		fs:             fsys,
+		binds:          loadBindIndex(fsys, filepath.Join(transactionDir, bindIndexFile)),
 	}
+	manager.config.Store(cfg)
+	return manager
+}
+
+// currentConfig returns the configuration the manager runs with
+func (m *Manager) currentConfig() *config.Config {
+	return m.config.Load()
+}
+
+// UpdateSettings swaps in the Netplan settings of a reloaded configuration, e.g. added or removed interface
+// mappings, without losing the tracked addresses, the bind index or the transactions in progress. Operations
+// started before keep the settings they started with. Moving the files of the manager or changing its backend
+// needs a new manager, so UpdateSettings fails if netplan_config_path, transaction_dir or backend differ.
+func (m *Manager) UpdateSettings(cfg *config.Config) error {
+	current := m.currentConfig().Netplan
+	switch {
+	case cfg.Netplan.ConfigPath != current.ConfigPath:
+		return fmt.Errorf("netplan_config_path changed from %s to %s", current.ConfigPath, cfg.Netplan.ConfigPath)
+	case cfg.Netplan.TransactionDir != current.TransactionDir:
+		return fmt.Errorf("transaction_dir changed from %s to %s", current.TransactionDir, cfg.Netplan.TransactionDir)
+	case cfg.Netplan.UsesNetworkManager() != current.UsesNetworkManager():
+		return fmt.Errorf("backend changed from %q to %q", current.Backend, cfg.Netplan.Backend)
+	}
+	m.config.Store(cfg)
+	return nil
 }
 
 // parseInterfaceName parses an interface name that might be in VLAN format (vlan@nic)
@@ -221,6 +300,9 @@ func (m *Manager) AddIPAddress(ipAddr string, _ int) error {
 		return fmt.Errorf("failed to find interface for IP %s: %w", ipAddr, err)
 	}
 
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+
 	// Load current Netplan configuration
 	netplanConfig, err := m.loadNetplanConfig()
 	if err != nil {
@@ -252,15 +334,16 @@ func (m *Manager) AddIPAddress(ipAddr string, _ int) error {
 
 		// Check if IP already exists
 		for _, addr := range vlan.Addresses {
-			if strings.HasPrefix(addr, ipAddr) {
+			if sameAddress(addr, ipAddr) {
 				// IP already exists, no need to add
-				m.addresses[ipAddr] = interfaceName
+				m.track(ipAddr, interfaceName)
 				return nil
 			}
 		}
 
 		// Add the new IP address
 		vlan.Addresses = append(vlan.Addresses, fullAddr)
+		vlan.Routes, vlan.RoutingPolicy = m.syncRoutes(interfaceName, vlan.Addresses, vlan.Routes, vlan.RoutingPolicy)
 
 		// Ensure link is set to the correct NIC
 		if vlan.Link == "" {
@@ -278,25 +361,26 @@ func (m *Manager) AddIPAddress(ipAddr string, _ int) error {
 
 		// Check if IP already exists
 		for _, addr := range iface.Addresses {
-			if strings.HasPrefix(addr, ipAddr) {
+			if sameAddress(addr, ipAddr) {
 				// IP already exists, no need to add
-				m.addresses[ipAddr] = interfaceName
+				m.track(ipAddr, interfaceName)
 				return nil
 			}
 		}
 
 		// Add the new IP address
 		iface.Addresses = append(iface.Addresses, fullAddr)
+		iface.Routes, iface.RoutingPolicy = m.syncRoutes(interfaceName, iface.Addresses, iface.Routes, iface.RoutingPolicy)
 		netplanConfig.Network.Ethernets[interfaceName] = iface
 	}
 
 	// Save the configuration
-	if err := m.saveNetplanConfig(netplanConfig); err != nil {
+	if err := m.saveNetplanConfig(context.Background(), netplanConfig); err != nil {
 		return fmt.Errorf("failed to save Netplan config: %w", err)
 	}
 
 	// Track the IP address
-	m.addresses[ipAddr] = interfaceName
+	m.track(ipAddr, interfaceName)
 
 	return nil
 }
@@ -305,12 +389,17 @@ func (m *Manager) AddIPAddress(ipAddr string, _ int) error {
 // It first checks the tracking map, then falls back to finding the interface via subnet mappings.
 // Returns an error if the IP address is not found or cannot be removed.
 func (m *Manager) RemoveIPAddress(ipAddr string) error {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+
 	// Find which interface this IP was assigned to
+	m.mutex.RLock()
 	interfaceName, exists := m.addresses[ipAddr]
+	m.mutex.RUnlock()
 	if !exists {
 		// Try to find it in the current config
 		var err error
-		interfaceName, err = m.config.FindInterfaceForIP(ipAddr)
+		interfaceName, err = m.currentConfig().FindInterfaceForIP(ipAddr)
 		if err != nil {
 			return fmt.Errorf("IP address %s not found in tracking or config: %w", ipAddr, err)
 		}
@@ -339,12 +428,13 @@ func (m *Manager) RemoveIPAddress(ipAddr string) error {
 		// Filter out the IP address
 		var newAddresses []string
 		for _, addr := range vlan.Addresses {
-			if !strings.HasPrefix(addr, ipAddr) {
+			if !sameAddress(addr, ipAddr) {
 				newAddresses = append(newAddresses, addr)
 			}
 		}
 
 		vlan.Addresses = newAddresses
+		vlan.Routes, vlan.RoutingPolicy = m.syncRoutes(interfaceName, vlan.Addresses, vlan.Routes, vlan.RoutingPolicy)
 		netplanConfig.Network.Vlans[vlanName] = vlan
 
 		// If no addresses left, remove the VLAN from config
@@ -365,12 +455,13 @@ func (m *Manager) RemoveIPAddress(ipAddr string) error {
 		// Filter out the IP address
 		var newAddresses []string
 		for _, addr := range iface.Addresses {
-			if !strings.HasPrefix(addr, ipAddr) {
+			if !sameAddress(addr, ipAddr) {
 				newAddresses = append(newAddresses, addr)
 			}
 		}
 
 		iface.Addresses = newAddresses
+		iface.Routes, iface.RoutingPolicy = m.syncRoutes(interfaceName, iface.Addresses, iface.Routes, iface.RoutingPolicy)
 		netplanConfig.Network.Ethernets[interfaceName] = iface
 
 		// If no addresses left, remove the interface from config
@@ -380,44 +471,122 @@ func (m *Manager) RemoveIPAddress(ipAddr string) error {
 	}
 
 	// Save the configuration
-	if err := m.saveNetplanConfig(netplanConfig); err != nil {
+	if err := m.saveNetplanConfig(context.Background(), netplanConfig); err != nil {
 		return fmt.Errorf("failed to save Netplan config: %w", err)
 	}
 
 	// Remove from tracking
-	delete(m.addresses, ipAddr)
+	m.untrack(ipAddr)
 
 	return nil
 }
 
+// entryAddress returns the address a Netplan address entry assigns, e.g. 192.168.1.100 for "192.168.1.100/24"
+func entryAddress(entry string) (netip.Addr, bool) {
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		return prefix.Addr(), true
+	}
+	ip, err := netip.ParseAddr(entry)
+	return ip, err == nil
+}
+
+// sameAddress reports whether a Netplan address entry assigns ipAddr. Both are parsed rather than compared as
+// text, so that "10.0.0.1" does not match "10.0.0.10/24" and differently written IPv6 addresses match.
+func sameAddress(entry, ipAddr string) bool {
+	ip, err := netip.ParseAddr(ipAddr)
+	if err != nil {
+		return false
+	}
+	assigned, ok := entryAddress(entry)
+	return ok && assigned.Unmap() == ip.Unmap()
+}
+
+// track records that ipAddr is assigned to interfaceName
+func (m *Manager) track(ipAddr, interfaceName string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.addresses[ipAddr] = interfaceName
+}
+
+// untrack forgets the assignment of ipAddr
+func (m *Manager) untrack(ipAddr string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.addresses, ipAddr)
+}
+
 // ApplyNetplan applies the Netplan configuration to the system.
 // It uses the configured applier (real or mock) to apply the configuration.
-// Returns an error if the apply fails.
-func (m *Manager) ApplyNetplan() error {
+// Returns an error if the apply fails or ctx is done before it completes.
+func (m *Manager) ApplyNetplan(ctx context.Context) error {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+	return m.applyNetplan(ctx)
+}
+
+// applyNetplan applies the Netplan configuration; the caller holds configMutex
+func (m *Manager) applyNetplan(ctx context.Context) error {
+	if m.currentConfig().DryRun {
+		logger.FromContext(ctx).Info("Dry run: skipped netplan apply")
+		return nil
+	}
 	if m.applier == nil {
 		// Fallback to real applier if not set
 		m.applier = &RealNetplanApplier{}
 	}
-	return m.applier.Apply()
+	err := m.applier.Apply(ctx)
+
+	m.mutex.Lock()
+	m.lastApply = &ApplyResult{Time: time.Now(), Err: err}
+	m.mutex.Unlock()
+	return err
+}
+
+// LastApply returns the outcome of the last netplan apply, or nil if the configuration was not applied yet
+func (m *Manager) LastApply() *ApplyResult {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.lastApply == nil {
+		return nil
+	}
+	result := *m.lastApply
+	return &result
 }
 
-// loadNetplanConfig loads the current Netplan configuration directly from the specified yaml file
+// RestoreLastApply sets the outcome of the last netplan apply, e.g. from the manager this one replaces
+func (m *Manager) RestoreLastApply(result *ApplyResult) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastApply = result
+}
+
+// loadNetplanConfig loads the current Netplan configuration directly from the specified yaml file. While the file
+// is watched, the parsed configuration is reused until the file changes; callers get their own copy to modify.
+// The caller holds configMutex.
 func (m *Manager) loadNetplanConfig() (*NetplanConfiguration, error) {
-	configPath := m.config.Netplan.ConfigPath
+	configPath := m.currentConfig().Netplan.ConfigPath
+
+	cached, generation, ok := m.parsed.get()
+	if ok {
+		return cached, nil
+	}
 
 	// Check if file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	info, err := m.fs.Stat(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
 		// Create a new empty configuration
-		return &NetplanConfiguration{
+		netplanConfig := &NetplanConfiguration{
 			Network: NetplanNetwork{
 				Version:   2,
 				Ethernets: make(map[string]NetplanInterface),
 			},
-		}, nil
+		}
+		m.parsed.put(netplanConfig, stampOf(nil), generation)
+		return netplanConfig, nil
 	}
 
 	// Read existing configuration
-	data, err := os.ReadFile(configPath)
+	data, err := m.fs.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Netplan config file: %w", err)
 	}
@@ -432,63 +601,111 @@ func (m *Manager) loadNetplanConfig() (*NetplanConfiguration, error) {
 		netplanConfig.Network.Ethernets = make(map[string]NetplanInterface)
 	}
 
+	// A change between the stat and the read leaves a stamp the watch does not match, dropping the entry
+	m.parsed.put(&netplanConfig, stampOf(info), generation)
 	return &netplanConfig, nil
 }
 
-// saveNetplanConfig saves the Netplan configuration to file
-func (m *Manager) saveNetplanConfig(netplanConfig *NetplanConfiguration) error {
-	configPath := m.config.Netplan.ConfigPath
+// saveNetplanConfig saves the Netplan configuration to file once netplan generate accepts it; the caller holds
+// configMutex
+func (m *Manager) saveNetplanConfig(ctx context.Context, netplanConfig *NetplanConfiguration) error {
+	configPath := m.currentConfig().Netplan.ConfigPath
+
+	if m.currentConfig().DryRun {
+		data, err := yaml.Marshal(netplanConfig)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Netplan config: %w", err)
+		}
+		logger.GetLogger().Info("Dry run: skipped writing Netplan config",
+			zap.String("config_path", configPath),
+			logger.Payload("config", data))
+		return nil
+	}
+
+	// Marshal to YAML
+	data, err := yaml.Marshal(netplanConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Netplan config: %w", err)
+	}
+
+	// A configuration netplan cannot render would take the interfaces down on the next apply
+	if err := m.validateNetplanConfig(ctx, data); err != nil {
+		return err
+	}
 
 	// Create backup if enabled
-	if m.config.Netplan.BackupEnabled {
+	if m.currentConfig().Netplan.BackupEnabled {
 		if err := m.createBackup(configPath); err != nil {
 			return fmt.Errorf("failed to create backup: %w", err)
 		}
 	}
 
 	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+	if err := m.fs.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Marshal to YAML
-	data, err := yaml.Marshal(netplanConfig)
+	// Write to file
+	generation := m.parsed.currentGeneration()
+	if err := m.fs.WriteFile(configPath, data, 0644); err != nil {
+		m.parsed.invalidate()
+		return fmt.Errorf("failed to write Netplan config file: %w", err)
+	}
+
+	// Remember what was written, so the next operation does not read it back
+	info, err := m.fs.Stat(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to marshal Netplan config: %w", err)
+		m.parsed.invalidate()
+		return nil
 	}
+	m.parsed.put(netplanConfig, stampOf(info), generation)
 
-	// Write to file
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write Netplan config file: %w", err)
+	return nil
+}
+
+// restoreNetplanConfig writes back the content the Netplan config file had before a commit whose netplan apply
+// failed, or removes the file if it did not exist, and applies it again even if ctx is done, so that the host is
+// not left with a half-applied configuration. LastApply keeps reporting the failed apply of the commit rather than
+// the outcome of the restore. The caller holds configMutex.
+func (m *Manager) restoreNetplanConfig(ctx context.Context, previous []byte, existed bool) error {
+	if m.currentConfig().DryRun {
+		return nil
+	}
+	defer m.RestoreLastApply(m.LastApply())
+	configPath := m.currentConfig().Netplan.ConfigPath
+	m.parsed.invalidate()
+	if existed {
+		if err := m.fs.WriteFile(configPath, previous, 0644); err != nil {
+			return fmt.Errorf("failed to write Netplan config file: %w", err)
+		}
+	} else if err := m.fs.Remove(configPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove Netplan config file: %w", err)
 	}
 
+	if err := m.applyNetplan(context.WithoutCancel(ctx)); err != nil {
+		return fmt.Errorf("failed to apply the previous Netplan configuration: %w", err)
+	}
+	logger.FromContext(ctx).Warn("Restored the Netplan configuration after netplan apply failed",
+		zap.String("config_path", configPath))
 	return nil
 }
 
 // createBackup creates a backup of the existing Netplan configuration
 func (m *Manager) createBackup(configPath string) error {
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	data, err := m.fs.ReadFile(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
 		// No existing file to backup
 		return nil
 	}
-
-	timestamp := time.Now().Format("20060102-150405")
-	backupPath := fmt.Sprintf("%s.backup-%s", configPath, timestamp)
-
-	src, err := os.Open(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
+		return fmt.Errorf("failed to read source file: %w", err)
 	}
-	defer func() { _ = src.Close() }()
 
-	dst, err := os.Create(backupPath)
-	if err != nil {
-		return fmt.Errorf("failed to create backup file: %w", err)
-	}
-	defer func() { _ = dst.Close() }()
+	timestamp := time.Now().Format("20060102-150405")
+	backupPath := fmt.Sprintf("%s.backup-%s", configPath, timestamp)
 
-	if _, err := io.Copy(dst, src); err != nil {
-		return fmt.Errorf("failed to copy backup file: %w", err)
+	if err := m.fs.WriteFile(backupPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
 	}
 
 	return nil
@@ -497,6 +714,9 @@ func (m *Manager) createBackup(configPath string) error {
 // GetTrackedAddresses returns a copy of the currently tracked IP addresses.
 // The returned map contains IP addresses as keys and their assigned interfaces as values.
 func (m *Manager) GetTrackedAddresses() map[string]string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	result := make(map[string]string)
 	for ip, iface := range m.addresses {
 		result[ip] = iface
@@ -504,6 +724,63 @@ func (m *Manager) GetTrackedAddresses() map[string]string {
 	return result
 }
 
+// RestoreTrackedAddresses seeds the tracked addresses, e.g. from the manager this one replaces on reload
+func (m *Manager) RestoreTrackedAddresses(addresses map[string]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for ip, iface := range addresses {
+		m.addresses[ip] = iface
+	}
+}
+
+// ReplaceTrackedAddresses replaces the tracked addresses, e.g. with those of the leader a standby follows
+func (m *Manager) ReplaceTrackedAddresses(addresses map[string]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.addresses = make(map[string]string, len(addresses))
+	for ip, iface := range addresses {
+		m.addresses[ip] = iface
+	}
+}
+
+// ReconcileAddresses tracks the bind addresses that the Netplan configuration already assigns to their mapped
+// interface, rebuilding the tracking that is lost on restart. It returns the number of tracked addresses.
+func (m *Manager) ReconcileAddresses(bindAddresses []string) (int, error) {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+
+	netplanConfig, err := m.loadNetplanConfig()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load Netplan config: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, ipAddr := range bindAddresses {
+		interfaceName, err := m.findInterfaceForIP(ipAddr)
+		if err != nil {
+			continue // Not a Netplan-managed VIP
+		}
+
+		var configured []string
+		if vlanName, _, isVLAN := parseInterfaceName(interfaceName); isVLAN {
+			configured = netplanConfig.Network.Vlans[vlanName].Addresses
+		} else {
+			configured = netplanConfig.Network.Ethernets[interfaceName].Addresses
+		}
+		for _, addr := range configured {
+			if sameAddress(addr, ipAddr) {
+				m.addresses[ipAddr] = interfaceName
+				break
+			}
+		}
+	}
+	return len(m.addresses), nil
+}
+
 // getSubnetMaskForIP finds the appropriate subnet mask for the given IP address
 // based on the configured subnet mappings
 func (m *Manager) getSubnetMaskForIP(ipAddr string) (string, error) {
@@ -512,7 +789,7 @@ func (m *Manager) getSubnetMaskForIP(ipAddr string) (string, error) {
 		return "", fmt.Errorf("invalid IP address: %s", ipAddr)
 	}
 
-	for _, mapping := range m.config.Netplan.InterfaceMappings {
+	for _, mapping := range m.currentConfig().Netplan.InterfaceMappings {
 		for _, subnet := range mapping.Subnets {
 			_, cidr, err := net.ParseCIDR(subnet)
 			if err != nil {
@@ -581,10 +858,15 @@ func (m *Manager) RemoveIPAddressFromTransaction(transactionID, ipAddr string) e
 		zap.String("transaction_id", transactionID),
 		zap.String("ip_address", ipAddr))
 
-	// Find the appropriate interface for this IP
-	interfaceName, err := m.findInterfaceForIP(ipAddr)
-	if err != nil {
-		return fmt.Errorf("failed to find interface for IP %s: %w", ipAddr, err)
+	// A tracked VIP is removed from the interface it was assigned to, even if its mapping was removed since
+	m.mutex.RLock()
+	interfaceName, tracked := m.addresses[ipAddr]
+	m.mutex.RUnlock()
+	if !tracked {
+		var err error
+		if interfaceName, err = m.findInterfaceForIP(ipAddr); err != nil {
+			return fmt.Errorf("failed to find interface for IP %s: %w", ipAddr, err)
+		}
 	}
 
 	// Add to transaction
@@ -598,12 +880,18 @@ func (m *Manager) RemoveIPAddressFromTransaction(transactionID, ipAddr string) e
 // CommitTransaction applies all pending changes in a transaction to the Netplan configuration.
 // It loads the transaction, applies all changes to the actual netplan yaml file, updates tracking,
 // and runs netplan apply to activate changes.
-// Returns an error if the transaction cannot be loaded, applied, or if any changes fail.
-func (m *Manager) CommitTransaction(transactionID string) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	logger.GetLogger().Info("Committing Netplan transaction",
+// Returns an error if the transaction cannot be loaded, applied, or if any changes fail. A transaction whose
+// commit is cancelled through ctx, before or during netplan apply, is marked failed. If netplan apply fails, the
+// configuration before the commit is restored and applied again.
+func (m *Manager) CommitTransaction(ctx context.Context, transactionID string) error {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+	m.transactionsMutex.RLock()
+	defer m.transactionsMutex.RUnlock()
+	defer m.transactionLocks.lock(transactionID)()
+	ctx = logger.WithFields(ctx, zap.String(logger.FieldNetplanTxnID, transactionID))
+
+	logger.FromContext(ctx).Info("Committing Netplan transaction",
 		zap.String("transaction_id", transactionID))
 
 	// Load the transaction
@@ -615,6 +903,10 @@ func (m *Manager) CommitTransaction(transactionID string) error {
 	if transaction.Status != "pending" {
 		return fmt.Errorf("transaction %s is not in pending status: %s", transactionID, transaction.Status)
 	}
+	if err := ctx.Err(); err != nil {
+		m.markTransactionFailed(transactionID, -1, err)
+		return fmt.Errorf("transaction %s was not committed: %w", transactionID, err)
+	}
 
 	// Load current Netplan configuration from actual netplan yaml file
 	netplanConfig, err := m.loadNetplanConfig()
@@ -623,31 +915,46 @@ func (m *Manager) CommitTransaction(transactionID string) error {
 	}
 
 	// Apply all changes in the transaction to the netplan configuration
-	for _, change := range transaction.Changes {
+	for i, change := range transaction.Changes {
 		if err := m.applyChange(netplanConfig, change); err != nil {
 			// Mark transaction as failed
-			logger.GetLogger().Error("Failed to apply transaction change",
+			logger.FromContext(ctx).Error("Failed to apply transaction change",
 				zap.String("transaction_id", transactionID),
 				zap.Any("change", change),
 				zap.Error(err))
-			m.markTransactionFailed(transactionID, err)
+			m.markTransactionFailed(transactionID, i, err)
 			return fmt.Errorf("failed to apply change %+v: %w", change, err)
 		}
 	}
 
+	// Remember the configuration before the commit, to restore it if netplan apply fails
+	previous, err := m.fs.ReadFile(m.currentConfig().Netplan.ConfigPath)
+	existed := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		m.markTransactionFailed(transactionID, -1, fmt.Errorf("failed to read Netplan config: %w", err))
+		return fmt.Errorf("failed to read Netplan config: %w", err)
+	}
+
 	// Save the updated configuration to the actual netplan yaml file
-	if err := m.saveNetplanConfig(netplanConfig); err != nil {
-		m.markTransactionFailed(transactionID, err)
+	if err := m.saveNetplanConfig(ctx, netplanConfig); err != nil {
+		m.markTransactionFailed(transactionID, -1, fmt.Errorf("failed to save Netplan config: %w", err))
 		return fmt.Errorf("failed to save Netplan config: %w", err)
 	}
 
 	// Apply the netplan configuration to the system
-	if err := m.ApplyNetplan(); err != nil {
-		m.markTransactionFailed(transactionID, err)
-		return fmt.Errorf("failed to apply Netplan configuration: %w", err)
+	if err := m.applyNetplan(ctx); err != nil {
+		err = fmt.Errorf("failed to apply Netplan configuration: %w", err)
+		if restoreErr := m.restoreNetplanConfig(ctx, previous, existed); restoreErr != nil {
+			err = fmt.Errorf("%w; restoring the previous configuration failed: %v", err, restoreErr)
+		} else {
+			err = fmt.Errorf("%w; the previous configuration was restored", err)
+		}
+		m.markTransactionFailed(transactionID, -1, err)
+		return err
 	}
 
 	// Update tracking state
+	m.mutex.Lock()
 	for _, change := range transaction.Changes {
 		switch change.Operation {
 		case "add":
@@ -656,9 +963,12 @@ func (m *Manager) CommitTransaction(transactionID string) error {
 			delete(m.addresses, change.IPAddress)
 		}
 	}
+	m.mutex.Unlock()
 
 	// Mark transaction as committed
+	committedAt := time.Now()
 	transaction.Status = "committed"
+	transaction.CommittedAt = &committedAt
 	if err := m.saveTransaction(transaction); err != nil {
 		return fmt.Errorf("failed to update transaction status: %w", err)
 	}
@@ -668,7 +978,7 @@ func (m *Manager) CommitTransaction(transactionID string) error {
 		return fmt.Errorf("failed to move transaction to committed: %w", err)
 	}
 
-	logger.GetLogger().Info("Successfully committed Netplan transaction and applied to system",
+	logger.FromContext(ctx).Info("Successfully committed Netplan transaction and applied to system",
 		zap.String("transaction_id", transactionID),
 		zap.Int("changes_applied", len(transaction.Changes)))
 
@@ -677,8 +987,9 @@ func (m *Manager) CommitTransaction(transactionID string) error {
 
 // addChangeToTransaction adds a change to an existing transaction or creates a new one
 func (m *Manager) addChangeToTransaction(transactionID string, change TransactionChange) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	m.transactionsMutex.RLock()
+	defer m.transactionsMutex.RUnlock()
+	defer m.transactionLocks.lock(transactionID)()
 
 	var transaction *Transaction
 	var err error
@@ -708,9 +1019,12 @@ func (m *Manager) addChangeToTransaction(transactionID string, change Transactio
 
 // loadTransaction loads a transaction from file
 func (m *Manager) loadTransaction(transactionID string) (*Transaction, error) {
-	filePath := filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transactionID))
+	return m.loadTransactionFile(filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transactionID)))
+}
 
-	data, err := os.ReadFile(filePath)
+// loadTransactionFile loads a transaction from the file at filePath
+func (m *Manager) loadTransactionFile(filePath string) (*Transaction, error) {
+	data, err := m.fs.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -723,16 +1037,12 @@ func (m *Manager) loadTransaction(transactionID string) (*Transaction, error) {
 	return &transaction, nil
 }
 
-// saveTransaction saves a transaction to file
+// saveTransaction saves a transaction to file, replacing it at once so that readers without the lock of the
+// file never see it partially written
 func (m *Manager) saveTransaction(transaction *Transaction) error {
 	filePath := filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transaction.TransactionID))
 
-	data, err := json.MarshalIndent(transaction, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal transaction: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := writeFileAtomic(m.fs, filePath, transaction); err != nil {
 		return fmt.Errorf("failed to write transaction file: %w", err)
 	}
 
@@ -758,7 +1068,7 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 
 			// Check if IP already exists
 			for _, addr := range vlan.Addresses {
-				if strings.HasPrefix(addr, change.IPAddress) {
+				if sameAddress(addr, change.IPAddress) {
 					// IP already exists, no need to add
 					return nil
 				}
@@ -766,6 +1076,7 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 
 			// Add the new IP address
 			vlan.Addresses = append(vlan.Addresses, fullAddr)
+			vlan.Routes, vlan.RoutingPolicy = m.syncRoutes(change.Interface, vlan.Addresses, vlan.Routes, vlan.RoutingPolicy)
 
 			// Ensure link is set to the correct NIC
 			if vlan.Link == "" {
@@ -778,12 +1089,13 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 			// Filter out the IP address
 			var newAddresses []string
 			for _, addr := range vlan.Addresses {
-				if !strings.HasPrefix(addr, change.IPAddress) {
+				if !sameAddress(addr, change.IPAddress) {
 					newAddresses = append(newAddresses, addr)
 				}
 			}
 
 			vlan.Addresses = newAddresses
+			vlan.Routes, vlan.RoutingPolicy = m.syncRoutes(change.Interface, vlan.Addresses, vlan.Routes, vlan.RoutingPolicy)
 			netplanConfig.Network.Vlans[vlanName] = vlan
 
 			// If no addresses left, remove the VLAN from config
@@ -808,7 +1120,7 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 
 			// Check if IP already exists
 			for _, addr := range iface.Addresses {
-				if strings.HasPrefix(addr, change.IPAddress) {
+				if sameAddress(addr, change.IPAddress) {
 					// IP already exists, no need to add
 					return nil
 				}
@@ -816,18 +1128,20 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 
 			// Add the new IP address
 			iface.Addresses = append(iface.Addresses, fullAddr)
+			iface.Routes, iface.RoutingPolicy = m.syncRoutes(change.Interface, iface.Addresses, iface.Routes, iface.RoutingPolicy)
 			netplanConfig.Network.Ethernets[change.Interface] = iface
 
 		case "remove":
 			// Filter out the IP address
 			var newAddresses []string
 			for _, addr := range iface.Addresses {
-				if !strings.HasPrefix(addr, change.IPAddress) {
+				if !sameAddress(addr, change.IPAddress) {
 					newAddresses = append(newAddresses, addr)
 				}
 			}
 
 			iface.Addresses = newAddresses
+			iface.Routes, iface.RoutingPolicy = m.syncRoutes(change.Interface, iface.Addresses, iface.Routes, iface.RoutingPolicy)
 			netplanConfig.Network.Ethernets[change.Interface] = iface
 
 			// If no addresses left, remove the interface from config
@@ -843,24 +1157,143 @@ func (m *Manager) applyChange(netplanConfig *NetplanConfiguration, change Transa
 	return nil
 }
 
-// markTransactionFailed marks a transaction as failed
-func (m *Manager) markTransactionFailed(transactionID string, _ error) {
+// ListTransactions returns all transactions that have not been committed yet,
+// including pending and failed ones. It takes no lock, as transaction files are replaced at once.
+func (m *Manager) ListTransactions() ([]*Transaction, error) {
+	entries, err := m.fs.ReadDir(m.transactionDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction directory: %w", err)
+	}
+
+	var transactions []*Transaction
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "transaction-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		transactionID := strings.TrimSuffix(strings.TrimPrefix(name, "transaction-"), ".json")
+		transaction, err := m.loadTransaction(transactionID)
+		if err != nil {
+			logger.GetLogger().Warn("Skipping unreadable transaction file",
+				zap.String("file", name),
+				zap.Error(err))
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// ReplaceTransactions makes the uncommitted transactions match the given ones, e.g. those of the leader a
+// standby follows. Transactions missing from the list are deleted.
+func (m *Manager) ReplaceTransactions(transactions []*Transaction) error {
+	m.transactionsMutex.Lock()
+	defer m.transactionsMutex.Unlock()
+
+	current, err := m.ListTransactions()
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool)
+	for _, transaction := range transactions {
+		if err := m.saveTransaction(transaction); err != nil {
+			return fmt.Errorf("failed to save transaction %s: %w", transaction.TransactionID, err)
+		}
+		keep[transaction.TransactionID] = true
+	}
+	for _, transaction := range current {
+		if keep[transaction.TransactionID] {
+			continue
+		}
+		filePath := filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transaction.TransactionID))
+		if err := m.fs.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete transaction %s: %w", transaction.TransactionID, err)
+		}
+	}
+	return nil
+}
+
+// GetTransaction returns a transaction that has not been committed yet, or nil if there is none,
+// i.e. the HAProxy transaction of the same ID has no Netplan changes
+func (m *Manager) GetTransaction(transactionID string) (*Transaction, error) {
+	m.transactionsMutex.RLock()
+	defer m.transactionsMutex.RUnlock()
+	defer m.transactionLocks.lock(transactionID)()
+
+	transaction, err := m.loadTransaction(transactionID)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return transaction, err
+}
+
+// DiscardTransaction deletes a transaction that has not been committed yet, e.g. when the HAProxy transaction of
+// the same ID is closed. A transaction that does not exist is not an error.
+func (m *Manager) DiscardTransaction(transactionID string) error {
+	if transactionID == "" || strings.ContainsAny(transactionID, `/\`) {
+		return nil // Not a transaction ID, and no path out of the transaction directory
+	}
+	m.transactionsMutex.RLock()
+	defer m.transactionsMutex.RUnlock()
+	defer m.transactionLocks.lock(transactionID)()
+
+	filePath := filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transactionID))
+	if err := m.fs.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete transaction %s: %w", transactionID, err)
+	}
+	return nil
+}
+
+// FindTransaction returns a transaction whether or not it has been committed, or nil if there is none
+func (m *Manager) FindTransaction(transactionID string) (*Transaction, error) {
+	if transactionID == "" || strings.ContainsAny(transactionID, `/\`) {
+		return nil, nil // Not a transaction ID, and no path out of the transaction directory
+	}
+	m.transactionsMutex.RLock()
+	defer m.transactionsMutex.RUnlock()
+	defer m.transactionLocks.lock(transactionID)() // Not to miss a transaction being moved to committed
+	transaction, err := m.loadTransaction(transactionID)
+	if errors.Is(err, fs.ErrNotExist) {
+		transaction, err = m.loadTransactionFile(filepath.Join(m.transactionDir, "committed", fmt.Sprintf("transaction-%s.json", transactionID)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	return transaction, err
+}
+
+// markTransactionFailed marks a transaction as failed and records why; changeIndex is the index of the change
+// that could not be applied, or -1 if the commit failed as a whole. The caller holds the lock of the transaction.
+func (m *Manager) markTransactionFailed(transactionID string, changeIndex int, err error) {
 	transaction, loadErr := m.loadTransaction(transactionID)
 	if loadErr != nil {
 		return
 	}
 
+	failedAt := time.Now()
 	transaction.Status = "failed"
-	// Could add error details to transaction if needed
-	_ = m.saveTransaction(transaction)
+	transaction.Error = err.Error()
+	transaction.FailedAt = &failedAt
+	if changeIndex >= 0 {
+		transaction.FailedChange = &changeIndex
+	}
+	if saveErr := m.saveTransaction(transaction); saveErr != nil {
+		logger.GetLogger().Warn("Failed to record the failure of a Netplan transaction",
+			zap.String("transaction_id", transactionID),
+			zap.Error(saveErr))
+	}
 }
 
-// moveTransactionToCommitted moves a transaction file to the committed directory
+// moveTransactionToCommitted moves a transaction file to the committed directory; the caller holds the lock of
+// the transaction
 func (m *Manager) moveTransactionToCommitted(transactionID string) error {
 	srcPath := filepath.Join(m.transactionDir, fmt.Sprintf("transaction-%s.json", transactionID))
 	dstPath := filepath.Join(m.transactionDir, "committed", fmt.Sprintf("transaction-%s.json", transactionID))
 
-	return os.Rename(srcPath, dstPath)
+	return m.fs.Rename(srcPath, dstPath)
 }
 
 // findInterfaceForIP finds the appropriate interface for the given IP address
@@ -870,7 +1303,7 @@ func (m *Manager) findInterfaceForIP(ipAddr string) (string, error) {
 		return "", fmt.Errorf("invalid IP address: %s", ipAddr)
 	}
 
-	for _, mapping := range m.config.Netplan.InterfaceMappings {
+	for _, mapping := range m.currentConfig().Netplan.InterfaceMappings {
 		for _, subnet := range mapping.Subnets {
 			_, cidr, err := net.ParseCIDR(subnet)
 			if err != nil {
@@ -885,6 +1318,42 @@ func (m *Manager) findInterfaceForIP(ipAddr string) (string, error) {
 	return "", fmt.Errorf("no interface mapping found for IP %s", ipAddr)
 }
 
+// CheckBindAddress rejects a bind address that no interface mapping covers if strict_address_validation is
+// enabled. Wildcard addresses are accepted, as they listen on the addresses already configured on the host.
+func (m *Manager) CheckBindAddress(ipAddr string) error {
+	if !m.currentConfig().Netplan.StrictAddressValidation || isWildcardAddress(ipAddr) {
+		return nil
+	}
+	_, err := m.findInterfaceForIP(ipAddr)
+	return err
+}
+
+// ManagesAddress reports whether an interface mapping covers ipAddr, so that it is assigned through Netplan
+func (m *Manager) ManagesAddress(ipAddr string) bool {
+	if isWildcardAddress(ipAddr) {
+		return false
+	}
+	_, err := m.findInterfaceForIP(ipAddr)
+	return err == nil
+}
+
+// TracksAddress reports whether ipAddr was assigned through Netplan, even if no interface mapping covers it anymore
+func (m *Manager) TracksAddress(ipAddr string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	_, ok := m.addresses[ipAddr]
+	return ok
+}
+
+// isWildcardAddress reports whether a bind address listens on all addresses of the host
+func isWildcardAddress(ipAddr string) bool {
+	if ipAddr == "*" {
+		return true
+	}
+	ip := net.ParseIP(ipAddr)
+	return ip != nil && ip.IsUnspecified()
+}
+
 // UnmarshalYAML implements custom YAML unmarshaling to preserve unknown fields
 func (n *NetplanInterface) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// First unmarshal into a generic map
@@ -983,6 +1452,21 @@ func (n *NetplanInterface) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		delete(raw, "routes")
 	}
 
+	if v, ok := raw["routing-policy"]; ok {
+		if policies, ok := v.([]interface{}); ok {
+			n.RoutingPolicy = make([]NetplanRoutingPolicy, 0, len(policies))
+			for _, policy := range policies {
+				var p NetplanRoutingPolicy
+				if policyData, err := yaml.Marshal(policy); err == nil {
+					if err := yaml.Unmarshal(policyData, &p); err == nil {
+						n.RoutingPolicy = append(n.RoutingPolicy, p)
+					}
+				}
+			}
+		}
+		delete(raw, "routing-policy")
+	}
+
 	if v, ok := raw["nameservers"]; ok {
 		var ns NetplanNameservers
 		if nsData, err := yaml.Marshal(v); err == nil {
@@ -1064,6 +1548,9 @@ func (n NetplanInterface) MarshalYAML() (interface{}, error) {
 	if len(n.Routes) > 0 {
 		result["routes"] = n.Routes
 	}
+	if len(n.RoutingPolicy) > 0 {
+		result["routing-policy"] = n.RoutingPolicy
+	}
 	if n.Nameservers != nil {
 		result["nameservers"] = n.Nameservers
 	}
@@ -1188,6 +1675,21 @@ func (n *NetplanVLAN) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		delete(raw, "routes")
 	}
 
+	if v, ok := raw["routing-policy"]; ok {
+		if policies, ok := v.([]interface{}); ok {
+			n.RoutingPolicy = make([]NetplanRoutingPolicy, 0, len(policies))
+			for _, policy := range policies {
+				var p NetplanRoutingPolicy
+				if policyData, err := yaml.Marshal(policy); err == nil {
+					if err := yaml.Unmarshal(policyData, &p); err == nil {
+						n.RoutingPolicy = append(n.RoutingPolicy, p)
+					}
+				}
+			}
+		}
+		delete(raw, "routing-policy")
+	}
+
 	if v, ok := raw["nameservers"]; ok {
 		var ns NetplanNameservers
 		if nsData, err := yaml.Marshal(v); err == nil {
@@ -1253,6 +1755,9 @@ func (n NetplanVLAN) MarshalYAML() (interface{}, error) {
 	if len(n.Routes) > 0 {
 		result["routes"] = n.Routes
 	}
+	if len(n.RoutingPolicy) > 0 {
+		result["routing-policy"] = n.RoutingPolicy
+	}
 	if n.Nameservers != nil {
 		result["nameservers"] = n.Nameservers
 	}
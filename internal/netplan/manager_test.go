@@ -1,9 +1,12 @@
 package netplan
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -16,6 +19,24 @@ func setupTest() {
 	_ = logger.InitLogger(true)
 }
 
+// newMemoryManager creates a manager on an in-memory file system that records netplan apply instead of running it
+func newMemoryManager(cfg *config.Config) (*Manager, *MemFS, *MockCommandRunner) {
+	fsys := NewMemFS()
+	runner := &MockCommandRunner{}
+	return NewManagerWithFS(cfg, fsys, runner), fsys, runner
+}
+
+// appliesOf returns the netplan apply commands the runner recorded, leaving out the netplan generate checks
+func appliesOf(runner *MockCommandRunner) []string {
+	var applies []string
+	for _, command := range runner.Commands() {
+		if command == "netplan apply" {
+			applies = append(applies, command)
+		}
+	}
+	return applies
+}
+
 func TestGetSubnetMaskForIP(t *testing.T) {
 	setupTest()
 
@@ -34,7 +55,7 @@ func TestGetSubnetMaskForIP(t *testing.T) {
 		},
 	}
 
-	manager := NewManagerWithConfig(cfg)
+	manager, _, _ := newMemoryManager(cfg)
 
 	testCases := []struct {
 		ip           string
@@ -80,6 +101,7 @@ func TestNewManagerWithConfig(t *testing.T) {
 					Subnets:   []string{"192.168.1.0/24"},
 				},
 			},
+			TransactionDir: t.TempDir(),
 		},
 	}
 
@@ -88,7 +110,7 @@ func TestNewManagerWithConfig(t *testing.T) {
 		t.Error("NewManager returned nil")
 		return
 	}
-	if manager.config != cfg {
+	if manager.currentConfig() != cfg {
 		t.Error("Manager config not set correctly")
 	}
 	if manager.addresses == nil {
@@ -99,9 +121,7 @@ func TestNewManagerWithConfig(t *testing.T) {
 func TestAddIPAddressWithoutNetplanCommand(t *testing.T) {
 	setupTest()
 
-	// Create temporary directory for test config
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "test-netplan.yaml")
+	configPath := "/etc/netplan/test-netplan.yaml"
 
 	cfg := &config.Config{
 		Netplan: config.NetplanSettings{
@@ -116,9 +136,8 @@ func TestAddIPAddressWithoutNetplanCommand(t *testing.T) {
 		},
 	}
 
-	// Use mock applier to avoid calling actual netplan command
-	mockApplier := &MockNetplanApplier{}
-	manager := NewManagerWithMock(cfg, mockApplier)
+	// Use an in-memory file system and mock runner to avoid calling actual netplan command
+	manager, fsys, _ := newMemoryManager(cfg)
 
 	// Test adding IP address
 	err := manager.AddIPAddress("192.168.1.100", 80)
@@ -136,8 +155,8 @@ func TestAddIPAddressWithoutNetplanCommand(t *testing.T) {
 	}
 
 	// Verify config file was created
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		t.Error("Netplan config file was not created")
+	if _, err := fsys.Stat(configPath); err != nil {
+		t.Errorf("Netplan config file was not created: %v", err)
 	}
 
 	// Test adding duplicate IP (should not error)
@@ -156,9 +175,7 @@ func TestAddIPAddressWithoutNetplanCommand(t *testing.T) {
 func TestRemoveIPAddressWithoutNetplanCommand(t *testing.T) {
 	setupTest()
 
-	// Create temporary directory for test config
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "test-netplan.yaml")
+	configPath := "/etc/netplan/test-netplan.yaml"
 
 	cfg := &config.Config{
 		Netplan: config.NetplanSettings{
@@ -173,9 +190,8 @@ func TestRemoveIPAddressWithoutNetplanCommand(t *testing.T) {
 		},
 	}
 
-	// Use mock applier to avoid calling actual netplan command
-	mockApplier := &MockNetplanApplier{}
-	manager := NewManagerWithMock(cfg, mockApplier)
+	// Use an in-memory file system and mock runner to avoid calling actual netplan command
+	manager, _, _ := newMemoryManager(cfg)
 
 	// First add an IP
 	err := manager.AddIPAddress("192.168.1.100", 80)
@@ -218,11 +234,11 @@ func TestAddIPAddressValidation(t *testing.T) {
 					Subnets:   []string{"192.168.1.0/24"},
 				},
 			},
-			ConfigPath: "/tmp/test-netplan.yaml",
+			ConfigPath: "/etc/netplan/test-netplan.yaml",
 		},
 	}
 
-	manager := NewManagerWithConfig(cfg)
+	manager, _, _ := newMemoryManager(cfg)
 
 	// Test empty IP address
 	err := manager.AddIPAddress("", 80)
@@ -254,7 +270,7 @@ func TestTrackingMechanism(t *testing.T) {
 		},
 	}
 
-	manager := NewManagerWithConfig(cfg)
+	manager, _, _ := newMemoryManager(cfg)
 
 	// Test tracking state is initially empty
 	tracked := manager.GetTrackedAddresses()
@@ -282,14 +298,8 @@ func TestTrackingMechanism(t *testing.T) {
 func TestBackupFileCreation(t *testing.T) {
 	setupTest()
 	// This test verifies the backup logic without executing netplan commands
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "test-netplan.yaml")
-
-	// Create an existing config file
-	existingContent := []byte("network:\n  version: 2\n")
-	if err := os.WriteFile(configPath, existingContent, 0644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
+	netplanDir := "/etc/netplan"
+	configPath := filepath.Join(netplanDir, "test-netplan.yaml")
 
 	cfg := &config.Config{
 		Netplan: config.NetplanSettings{
@@ -304,7 +314,16 @@ func TestBackupFileCreation(t *testing.T) {
 		},
 	}
 
-	manager := NewManagerWithConfig(cfg)
+	manager, fsys, _ := newMemoryManager(cfg)
+
+	// Create an existing config file
+	existingContent := []byte("network:\n  version: 2\n")
+	if err := fsys.MkdirAll(netplanDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	if err := fsys.WriteFile(configPath, existingContent, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
 
 	// Call createBackup directly
 	err := manager.createBackup(configPath)
@@ -313,7 +332,7 @@ func TestBackupFileCreation(t *testing.T) {
 	}
 
 	// Check if backup file was created
-	files, err := os.ReadDir(tmpDir)
+	files, err := fsys.ReadDir(netplanDir)
 	if err != nil {
 		t.Fatalf("Failed to read directory: %v", err)
 	}
@@ -346,11 +365,11 @@ func TestTransactionBasicFlow(t *testing.T) {
 					Subnets:   []string{"192.168.1.0/24"},
 				},
 			},
-			ConfigPath: "/tmp/test-netplan-transaction.yaml",
+			ConfigPath: "/etc/netplan/test-netplan-transaction.yaml",
 		},
 	}
 
-	manager := NewManagerWithConfig(cfg)
+	manager, _, _ := newMemoryManager(cfg)
 	transactionID := "test-tx-123"
 
 	// Test adding IP address to transaction
@@ -366,9 +385,9 @@ func TestTransactionBasicFlow(t *testing.T) {
 	}
 
 	// Load transaction and verify changes
-	transaction, err := manager.loadTransaction(transactionID)
-	if err != nil {
-		t.Errorf("Failed to load transaction: %v", err)
+	transaction, err := manager.GetTransaction(transactionID)
+	if err != nil || transaction == nil {
+		t.Fatalf("Failed to load transaction: %v", err)
 	}
 
 	if len(transaction.Changes) != 2 {
@@ -383,15 +402,15 @@ func TestTransactionBasicFlow(t *testing.T) {
 		t.Errorf("Second change incorrect: %+v", transaction.Changes[1])
 	}
 
-	// Clean up transaction file
-	transactionFile := filepath.Join(manager.transactionDir, fmt.Sprintf("transaction-%s.json", transactionID))
-	_ = os.Remove(transactionFile)
+	// A transaction without Netplan changes has no file
+	if transaction, err := manager.GetTransaction("test-tx-unknown"); err != nil || transaction != nil {
+		t.Errorf("Expected no transaction, got %+v, %v", transaction, err)
+	}
 }
 
 func TestTransactionCommit(t *testing.T) {
 	setupTest()
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "test-netplan.yaml")
+	configPath := "/etc/netplan/test-netplan.yaml"
 
 	cfg := &config.Config{
 		Netplan: config.NetplanSettings{
@@ -406,9 +425,8 @@ func TestTransactionCommit(t *testing.T) {
 		},
 	}
 
-	// Use mock applier to avoid calling actual netplan command
-	mockApplier := &MockNetplanApplier{}
-	manager := NewManagerWithMock(cfg, mockApplier)
+	// Use an in-memory file system and mock runner to avoid calling actual netplan command
+	manager, fsys, runner := newMemoryManager(cfg)
 	transactionID := "test-commit-tx-456"
 
 	// Add changes to transaction
@@ -423,14 +441,14 @@ func TestTransactionCommit(t *testing.T) {
 	}
 
 	// Commit transaction
-	err = manager.CommitTransaction(transactionID)
+	err = manager.CommitTransaction(context.Background(), transactionID)
 	if err != nil {
 		t.Errorf("Failed to commit transaction: %v", err)
 	}
 
 	// Verify Netplan config was updated
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		t.Error("Netplan config file was not created")
+	if _, err := fsys.Stat(configPath); err != nil {
+		t.Errorf("Netplan config file was not created: %v", err)
 	}
 
 	// Verify tracking was updated
@@ -449,13 +467,328 @@ func TestTransactionCommit(t *testing.T) {
 
 	// Verify transaction was moved to committed directory
 	committedFile := filepath.Join(manager.transactionDir, "committed", fmt.Sprintf("transaction-%s.json", transactionID))
-	if _, err := os.Stat(committedFile); os.IsNotExist(err) {
-		t.Error("Transaction was not moved to committed directory")
+	if _, err := fsys.Stat(committedFile); err != nil {
+		t.Errorf("Transaction was not moved to committed directory: %v", err)
 	}
 
 	// Verify netplan apply was called
-	if mockApplier.ApplyCallCount != 1 {
-		t.Errorf("Expected netplan apply to be called once, got %d calls", mockApplier.ApplyCallCount)
+	if commands := appliesOf(runner); len(commands) != 1 {
+		t.Errorf("Expected netplan apply to be run once, got %v", runner.Commands())
+	}
+}
+
+func TestTransactionFailureReason(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/netplan.yaml",
+			TransactionDir:    "/var/lib/haproxy-configurator/transactions",
+			SkipGenerate:      true,
+		},
+	}
+	manager, _, runner := newMemoryManager(cfg)
+
+	broken := &Transaction{TransactionID: "broken-tx", Status: "pending", Changes: []TransactionChange{
+		{Operation: "add", IPAddress: "192.168.1.100", Interface: "eth0", SubnetMask: "/24"},
+		{Operation: "move", IPAddress: "192.168.1.101", Interface: "eth0", SubnetMask: "/24"},
+	}}
+	if err := manager.ReplaceTransactions([]*Transaction{broken}); err != nil {
+		t.Fatalf("ReplaceTransactions failed: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "broken-tx"); err == nil {
+		t.Fatal("Expected the commit of an unknown operation to fail")
+	}
+	transaction, err := manager.FindTransaction("broken-tx")
+	if err != nil || transaction == nil {
+		t.Fatalf("Expected the failed transaction, got %v and %v", transaction, err)
+	}
+	if transaction.Status != "failed" || transaction.FailedChange == nil || *transaction.FailedChange != 1 ||
+		!strings.Contains(transaction.Error, "unknown operation: move") || transaction.FailedAt == nil {
+		t.Errorf("Expected the failure of change 1 to be recorded, got %+v", transaction)
+	}
+
+	// A failed netplan apply is not attributed to a change
+	runner.Err = errors.New("apply failed")
+	if err := manager.AddIPAddressToTransaction("apply-tx", "192.168.1.102", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "apply-tx"); err == nil {
+		t.Fatal("Expected the commit to fail")
+	}
+	transaction, _ = manager.FindTransaction("apply-tx")
+	if transaction == nil || transaction.FailedChange != nil || !strings.Contains(transaction.Error, "apply failed") {
+		t.Errorf("Expected the apply failure to be recorded, got %+v", transaction)
+	}
+
+	runner.Err = nil
+	if err := manager.AddIPAddressToTransaction("good-tx", "192.168.1.103", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "good-tx"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	transaction, _ = manager.FindTransaction("good-tx")
+	if transaction == nil || transaction.Status != "committed" || transaction.CommittedAt == nil || transaction.Error != "" {
+		t.Errorf("Expected the committed transaction, got %+v", transaction)
+	}
+
+	for _, id := range []string{"missing-tx", "../committed/transaction-good-tx", ""} {
+		if transaction, err := manager.FindTransaction(id); transaction != nil || err != nil {
+			t.Errorf("Expected no transaction for %q, got %+v and %v", id, transaction, err)
+		}
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/99-haproxy.yaml",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+	if err := manager.AddIPAddressToTransaction("tx-1", "192.168.1.100", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-1"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+
+	// The mapping of eth0 is replaced by one of eth1 while a transaction is in progress
+	if err := manager.AddIPAddressToTransaction("tx-2", "192.168.1.101", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	reloaded := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth1", Subnets: []string{"10.0.0.0/24"}}},
+			ConfigPath:        "/etc/netplan/99-haproxy.yaml",
+		},
+	}
+	if err := manager.UpdateSettings(reloaded); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if manager.ManagesAddress("192.168.1.102") || !manager.ManagesAddress("10.0.0.5") {
+		t.Error("Expected the new interface mappings to be used")
+	}
+	if err := manager.AddIPAddressToTransaction("tx-2", "10.0.0.5", 80); err != nil {
+		t.Fatalf("Failed to add IP of the new mapping: %v", err)
+	}
+	if !manager.TracksAddress("192.168.1.100") {
+		t.Fatal("Expected the tracked addresses to be kept")
+	}
+	if err := manager.RemoveIPAddressFromTransaction("tx-2", "192.168.1.100"); err != nil {
+		t.Fatalf("Failed to remove the VIP of the removed mapping: %v", err)
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-2"); err != nil {
+		t.Fatalf("Failed to commit the transaction started before the update: %v", err)
+	}
+
+	netplanConfig, err := manager.loadNetplanConfig()
+	if err != nil {
+		t.Fatalf("Failed to load Netplan config: %v", err)
+	}
+	if eth0, eth1 := netplanConfig.Network.Ethernets["eth0"].Addresses, netplanConfig.Network.Ethernets["eth1"].Addresses; !slices.Equal(eth0, []string{"192.168.1.101/24"}) || !slices.Equal(eth1, []string{"10.0.0.5/24"}) {
+		t.Errorf("Unexpected addresses eth0 %v, eth1 %v", eth0, eth1)
+	}
+
+	moved := *reloaded
+	moved.Netplan.TransactionDir = "/var/lib/haproxy-configurator/transactions"
+	if err := manager.UpdateSettings(&moved); err == nil || manager.currentConfig() != reloaded {
+		t.Errorf("Expected a changed transaction_dir to be rejected, got %v", err)
+	}
+}
+
+// failingApplier fails the first failures applies and records the configuration each apply saw
+type failingApplier struct {
+	manager  *Manager
+	failures int
+	applied  []string
+}
+
+func (a *failingApplier) Apply(context.Context) error {
+	data, _ := a.manager.fs.ReadFile(a.manager.currentConfig().Netplan.ConfigPath)
+	a.applied = append(a.applied, string(data))
+	if len(a.applied) <= a.failures {
+		return errors.New("apply failed")
+	}
+	return nil
+}
+
+func TestCommitRestoresConfigOnApplyFailure(t *testing.T) {
+	setupTest()
+	configPath := "/etc/netplan/99-haproxy.yaml"
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        configPath,
+		},
+	}
+	fsys := NewMemFS()
+	applier := &failingApplier{failures: 1}
+	manager := newManager(cfg, fsys, applier)
+	applier.manager = manager
+	if err := manager.AddIPAddress("192.168.1.100", 80); err != nil {
+		t.Fatalf("AddIPAddress failed: %v", err)
+	}
+	before, _ := fsys.ReadFile(configPath)
+
+	if err := manager.AddIPAddressToTransaction("tx-1", "192.168.1.101", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	err := manager.CommitTransaction(context.Background(), "tx-1")
+	if err == nil || !strings.Contains(err.Error(), "previous configuration was restored") {
+		t.Fatalf("Expected the commit to fail with the configuration restored, got %v", err)
+	}
+	if after, _ := fsys.ReadFile(configPath); string(after) != string(before) {
+		t.Errorf("Expected the previous config to be restored, got %s", after)
+	}
+	if len(applier.applied) != 2 || !strings.Contains(applier.applied[0], "192.168.1.101") || applier.applied[1] != string(before) {
+		t.Errorf("Expected the new and then the previous config to be applied, got %q", applier.applied)
+	}
+	if transaction, _ := manager.FindTransaction("tx-1"); transaction == nil || transaction.Status != "failed" || !strings.Contains(transaction.Error, "restored") {
+		t.Errorf("Expected the transaction to be marked failed, got %+v", transaction)
+	}
+	if _, ok := manager.GetTrackedAddresses()["192.168.1.101"]; ok {
+		t.Error("Expected the address of the failed commit not to be tracked")
+	}
+	if result := manager.LastApply(); result == nil || result.Err == nil {
+		t.Errorf("Expected the last apply to report the failed commit rather than the restore, got %+v", result)
+	}
+
+	// Without a configuration before the commit, the file is removed again
+	manager.currentConfig().Netplan.ConfigPath = "/etc/netplan/98-haproxy.yaml"
+	applier.failures, applier.applied = 2, nil
+	if err := manager.AddIPAddressToTransaction("tx-2", "192.168.1.102", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	err = manager.CommitTransaction(context.Background(), "tx-2")
+	if err == nil || !strings.Contains(err.Error(), "restoring the previous configuration failed") {
+		t.Fatalf("Expected the failed restore to be reported, got %v", err)
+	}
+	if _, err := fsys.Stat("/etc/netplan/98-haproxy.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the new config file to be removed, got %v", err)
+	}
+	if result := manager.LastApply(); result == nil || result.Err == nil {
+		t.Errorf("Expected the last apply to have failed, got %+v", result)
+	}
+}
+
+func TestCommitTransactionCancelled(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/netplan.yaml",
+			TransactionDir:    "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, fsys, runner := newMemoryManager(cfg)
+
+	if err := manager.AddIPAddressToTransaction("cancelled-tx", "192.168.1.100", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := manager.CommitTransaction(ctx, "cancelled-tx"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the commit to be cancelled, got %v", err)
+	}
+	if len(runner.Commands()) != 0 || len(manager.GetTrackedAddresses()) != 0 {
+		t.Error("Expected a cancelled commit to change nothing")
+	}
+	if transaction, _ := manager.FindTransaction("cancelled-tx"); transaction == nil || transaction.Status != "failed" {
+		t.Errorf("Expected the cancelled transaction to be marked failed, got %+v", transaction)
+	}
+	if _, err := fsys.Stat(cfg.Netplan.ConfigPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the Netplan config not to be written, got %v", err)
+	}
+}
+
+func TestSameAddress(t *testing.T) {
+	tests := []struct {
+		entry, ipAddr string
+		want          bool
+	}{
+		{"10.0.0.1/24", "10.0.0.1", true},
+		{"10.0.0.10/24", "10.0.0.1", false},
+		{"10.0.0.1/24", "10.0.0.10", false},
+		{"10.0.0.1", "10.0.0.1", true},
+		{"2001:db8::1/64", "2001:0db8:0::1", true},
+		{"2001:db8::10/64", "2001:db8::1", false},
+		{"10.0.0.1/24", "::ffff:10.0.0.1", true},
+		{"invalid", "10.0.0.1", false},
+		{"10.0.0.1/24", "", false},
+	}
+	for _, tt := range tests {
+		if got := sameAddress(tt.entry, tt.ipAddr); got != tt.want {
+			t.Errorf("sameAddress(%q, %q) = %t; want %t", tt.entry, tt.ipAddr, got, tt.want)
+		}
+	}
+}
+
+func TestExactAddressMatching(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{
+				{Interface: "eth0", Subnets: []string{"10.0.0.0/24"}},
+				{Interface: "vlan100@eth1", Subnets: []string{"10.100.0.0/24"}},
+			},
+			ConfigPath:     "/etc/netplan/netplan.yaml",
+			TransactionDir: "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+	addresses := func() []string {
+		manager.configMutex.Lock()
+		defer manager.configMutex.Unlock()
+		netplanConfig, err := manager.loadNetplanConfig()
+		if err != nil {
+			t.Fatalf("Failed to load Netplan config: %v", err)
+		}
+		return append(netplanConfig.Network.Ethernets["eth0"].Addresses, netplanConfig.Network.Vlans["vlan100"].Addresses...)
+	}
+
+	for _, address := range []string{"10.0.0.10", "10.0.0.1", "10.100.0.10", "10.100.0.1"} {
+		if err := manager.AddIPAddress(address, 80); err != nil {
+			t.Fatalf("Failed to add %s: %v", address, err)
+		}
+	}
+	if got := addresses(); len(got) != 4 {
+		t.Fatalf("Expected an address that is a textual prefix of another to be added, got %v", got)
+	}
+	for _, address := range []string{"10.0.0.1", "10.100.0.1"} {
+		if err := manager.RemoveIPAddress(address); err != nil {
+			t.Fatalf("Failed to remove %s: %v", address, err)
+		}
+	}
+	if got := addresses(); len(got) != 2 || got[0] != "10.0.0.10/24" || got[1] != "10.100.0.10/24" {
+		t.Fatalf("Expected only the removed addresses to be gone, got %v", got)
+	}
+
+	// Transactions apply their changes the same way
+	for _, address := range []string{"10.0.0.1", "10.100.0.1"} {
+		if err := manager.AddIPAddressToTransaction("add-tx", address, 80); err != nil {
+			t.Fatalf("Failed to add IP to transaction: %v", err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "add-tx"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	if got := addresses(); len(got) != 4 {
+		t.Fatalf("Expected the committed addresses to be added, got %v", got)
+	}
+	for _, address := range []string{"10.0.0.10", "10.100.0.10"} {
+		if err := manager.RemoveIPAddressFromTransaction("remove-tx", address); err != nil {
+			t.Fatalf("Failed to add IP removal to transaction: %v", err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "remove-tx"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	if got := addresses(); len(got) != 2 || got[0] != "10.0.0.1/24" || got[1] != "10.100.0.1/24" {
+		t.Errorf("Expected only the removed addresses to be gone, got %v", got)
 	}
 }
 
@@ -464,7 +797,7 @@ func TestMockNetplanApplier(t *testing.T) {
 
 	// Test mock applier success
 	mockApplier := &MockNetplanApplier{}
-	err := mockApplier.Apply()
+	err := mockApplier.Apply(context.Background())
 	if err != nil {
 		t.Errorf("Mock applier should not error by default: %v", err)
 	}
@@ -475,7 +808,7 @@ func TestMockNetplanApplier(t *testing.T) {
 	// Test mock applier with error
 	expectedError := fmt.Errorf("mock netplan error")
 	mockApplier = &MockNetplanApplier{ApplyError: expectedError}
-	err = mockApplier.Apply()
+	err = mockApplier.Apply(context.Background())
 	if err != expectedError {
 		t.Errorf("Expected mock error %v, got %v", expectedError, err)
 	}
@@ -483,3 +816,155 @@ func TestMockNetplanApplier(t *testing.T) {
 		t.Errorf("Expected 1 call to Apply(), got %d", mockApplier.ApplyCallCount)
 	}
 }
+
+func TestReconcileAddresses(t *testing.T) {
+	setupTest()
+
+	configPath := "/etc/netplan/netplan.yaml"
+	netplanConfig := `network:
+  version: 2
+  ethernets:
+    eth0:
+      addresses: ["192.168.1.10/24", "192.168.1.100/24"]
+  vlans:
+    vlan100:
+      id: 100
+      link: eth1
+      addresses: ["10.100.0.5/24"]
+`
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{
+				{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}},
+				{Interface: "vlan100@eth1", Subnets: []string{"10.100.0.0/24"}},
+			},
+			ConfigPath:     configPath,
+			TransactionDir: "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, fsys, _ := newMemoryManager(cfg)
+	if err := fsys.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("Failed to create Netplan directory: %v", err)
+	}
+	if err := fsys.WriteFile(configPath, []byte(netplanConfig), 0644); err != nil {
+		t.Fatalf("Failed to write Netplan config: %v", err)
+	}
+
+	// 192.168.1.200 is mapped but not configured, 203.0.113.1 is not managed by Netplan
+	tracked, err := manager.ReconcileAddresses([]string{"192.168.1.100", "10.100.0.5", "192.168.1.200", "203.0.113.1"})
+	if err != nil {
+		t.Fatalf("ReconcileAddresses failed: %v", err)
+	}
+	if tracked != 2 {
+		t.Errorf("Expected 2 tracked addresses, got %d", tracked)
+	}
+	addresses := manager.GetTrackedAddresses()
+	if addresses["192.168.1.100"] != "eth0" || addresses["10.100.0.5"] != "vlan100@eth1" {
+		t.Errorf("Unexpected tracked addresses %v", addresses)
+	}
+}
+
+func TestReplaceTransactions(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/netplan.yaml",
+			TransactionDir:    "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	if err := manager.AddIPAddressToTransaction("stale", "192.168.1.100", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	leader := &Transaction{TransactionID: "leader-tx", Status: "pending", Changes: []TransactionChange{
+		{Operation: "add", IPAddress: "192.168.1.101", Interface: "eth0", SubnetMask: "/24"},
+	}}
+	if err := manager.ReplaceTransactions([]*Transaction{leader}); err != nil {
+		t.Fatalf("ReplaceTransactions failed: %v", err)
+	}
+
+	transactions, err := manager.ListTransactions()
+	if err != nil {
+		t.Fatalf("ListTransactions failed: %v", err)
+	}
+	if len(transactions) != 1 || transactions[0].TransactionID != "leader-tx" || transactions[0].Changes[0].IPAddress != "192.168.1.101" {
+		t.Errorf("Expected only the replacing transaction, got %+v", transactions)
+	}
+
+	manager.RestoreTrackedAddresses(map[string]string{"192.168.1.50": "eth0"})
+	manager.ReplaceTrackedAddresses(map[string]string{"192.168.1.60": "eth0"})
+	if tracked := manager.GetTrackedAddresses(); len(tracked) != 1 || tracked["192.168.1.60"] != "eth0" {
+		t.Errorf("Expected the tracked addresses to be replaced, got %v", tracked)
+	}
+}
+
+func TestDiscardTransaction(t *testing.T) {
+	setupTest()
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        "/etc/netplan/netplan.yaml",
+			TransactionDir:    "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	if err := manager.AddIPAddressToTransaction("closed", "192.168.1.100", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.AddIPAddressToTransaction("open", "192.168.1.101", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.DiscardTransaction("closed"); err != nil {
+		t.Fatalf("DiscardTransaction failed: %v", err)
+	}
+
+	if transaction, err := manager.GetTransaction("closed"); err != nil || transaction != nil {
+		t.Errorf("Expected the transaction to be discarded, got %+v, %v", transaction, err)
+	}
+	if transaction, err := manager.GetTransaction("open"); err != nil || transaction == nil {
+		t.Errorf("Expected the other transaction to be kept, got %+v, %v", transaction, err)
+	}
+
+	// A transaction without Netplan changes has nothing to discard
+	if err := manager.DiscardTransaction("closed"); err != nil {
+		t.Errorf("Expected no error for a missing transaction, got %v", err)
+	}
+}
+
+func TestCheckBindAddress(t *testing.T) {
+	setupTest()
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+	if err := manager.CheckBindAddress("203.0.113.1"); err != nil {
+		t.Errorf("Expected unmapped addresses to be accepted without strict validation, got %v", err)
+	}
+
+	cfg.Netplan.StrictAddressValidation = true
+	for address, valid := range map[string]bool{
+		"192.168.1.100": true,
+		"0.0.0.0":       true,
+		"::":            true,
+		"*":             true,
+		"203.0.113.1":   false,
+		"not-an-ip":     false,
+	} {
+		if err := manager.CheckBindAddress(address); (err == nil) != valid {
+			t.Errorf("CheckBindAddress(%q) = %v, expected valid: %t", address, err, valid)
+		}
+	}
+
+	// Only mapped addresses are assigned through Netplan
+	for address, managed := range map[string]bool{"192.168.1.100": true, "0.0.0.0": false, "203.0.113.1": false} {
+		if got := manager.ManagesAddress(address); got != managed {
+			t.Errorf("ManagesAddress(%q) = %t, expected %t", address, got, managed)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package netplan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/netip"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// networkManagerStateFile is the file in the transaction directory remembering the addresses the NetworkManager
+// applier added to each device
+const networkManagerStateFile = "networkmanager-addresses.json"
+
+// NetworkManagerApplier applies the addresses of the Netplan configuration of a manager to the connection
+// profiles of NetworkManager with nmcli, for hosts that do not use Netplan. The configuration file stays the
+// record of the VIPs; on apply, the addresses of each interface are added to the active connection of its
+// device, and the addresses added before but no longer in the file are removed. Addresses the applier did not
+// add, e.g. the primary address of the host, are never removed.
+type NetworkManagerApplier struct {
+	manager *Manager
+	runner  CommandRunner
+}
+
+// Apply syncs the addresses of the Netplan configuration to NetworkManager and reapplies the changed devices.
+// The caller holds configMutex of the manager.
+func (a *NetworkManagerApplier) Apply(ctx context.Context) error {
+	netplanConfig, err := a.manager.loadNetplanConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load Netplan config: %w", err)
+	}
+
+	desired := make(map[string][]string) // Device -> addresses
+	for name, iface := range netplanConfig.Network.Ethernets {
+		desired[name] = iface.Addresses
+	}
+	for name, vlan := range netplanConfig.Network.Vlans {
+		desired[name] = vlan.Addresses
+	}
+
+	applied, err := a.loadState()
+	if err != nil {
+		return err
+	}
+
+	devices := make([]string, 0, len(desired)+len(applied))
+	for device := range desired {
+		devices = append(devices, device)
+	}
+	for device := range applied {
+		if _, ok := desired[device]; !ok {
+			devices = append(devices, device)
+		}
+	}
+	sort.Strings(devices)
+
+	for _, device := range devices {
+		if err := a.syncDevice(ctx, device, desired[device], applied[device]); err != nil {
+			// Devices synced before stay recorded, so that their addresses are removed later on
+			_ = a.saveState(applied)
+			return err
+		}
+		if len(desired[device]) > 0 {
+			applied[device] = append([]string(nil), desired[device]...)
+		} else {
+			delete(applied, device)
+		}
+	}
+	return a.saveState(applied)
+}
+
+// syncDevice adds the desired addresses missing on the connection of device and removes the previously applied
+// ones that are no longer desired, then reapplies the connection to the device
+func (a *NetworkManagerApplier) syncDevice(ctx context.Context, device string, desired, applied []string) error {
+	connection, err := a.connectionOf(ctx, device)
+	if err != nil {
+		return err
+	}
+	current, err := a.addressesOf(ctx, connection)
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	for _, family := range []string{"ipv4", "ipv6"} {
+		var add, remove []string
+		for _, address := range desired {
+			if addressFamily(address) == family && !containsAddress(current, address) {
+				add = append(add, address)
+			}
+		}
+		for _, address := range applied {
+			if addressFamily(address) == family && !containsAddress(desired, address) && containsAddress(current, address) {
+				remove = append(remove, address)
+			}
+		}
+		if len(add) > 0 {
+			args = append(args, "+"+family+".addresses", strings.Join(add, ","))
+		}
+		if len(remove) > 0 {
+			args = append(args, "-"+family+".addresses", strings.Join(remove, ","))
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	if output, err := a.runner.Run(ctx, "nmcli", append([]string{"connection", "modify", connection}, args...)...); err != nil {
+		return fmt.Errorf("failed to modify NetworkManager connection %s: %w, output: %s", connection, err, strings.TrimSpace(string(output)))
+	}
+	if output, err := a.runner.Run(ctx, "nmcli", "device", "reapply", device); err != nil {
+		return fmt.Errorf("failed to reapply NetworkManager connection to %s: %w, output: %s", device, err, strings.TrimSpace(string(output)))
+	}
+
+	logger.FromContext(ctx).Info("Updated NetworkManager addresses",
+		zap.String("device", device),
+		zap.String("connection", connection),
+		zap.Strings("changes", args))
+	return nil
+}
+
+// connectionOf returns the name of the active connection of device
+func (a *NetworkManagerApplier) connectionOf(ctx context.Context, device string) (string, error) {
+	output, err := a.runner.Run(ctx, "nmcli", "-g", "GENERAL.CONNECTION", "device", "show", device)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the NetworkManager connection of %s: %w, output: %s", device, err, strings.TrimSpace(string(output)))
+	}
+	connection := strings.TrimSpace(string(output))
+	if connection == "" {
+		return "", fmt.Errorf("device %s has no active NetworkManager connection", device)
+	}
+	return connection, nil
+}
+
+// addressesOf returns the static addresses of a connection profile
+func (a *NetworkManagerApplier) addressesOf(ctx context.Context, connection string) ([]string, error) {
+	output, err := a.runner.Run(ctx, "nmcli", "-g", "ipv4.addresses,ipv6.addresses", "connection", "show", connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the addresses of NetworkManager connection %s: %w, output: %s", connection, err, strings.TrimSpace(string(output)))
+	}
+	var addresses []string
+	for _, line := range strings.Split(string(output), "\n") {
+		for _, address := range strings.Split(line, ",") {
+			// Colons of IPv6 addresses are escaped in the output of -g
+			if address = strings.ReplaceAll(strings.TrimSpace(address), `\:`, ":"); address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// loadState returns the addresses applied to each device before
+func (a *NetworkManagerApplier) loadState() (map[string][]string, error) {
+	state := make(map[string][]string)
+	data, err := a.manager.fs.ReadFile(filepath.Join(a.manager.transactionDir, networkManagerStateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied NetworkManager addresses: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse applied NetworkManager addresses: %w", err)
+	}
+	return state, nil
+}
+
+// saveState remembers the addresses applied to each device
+func (a *NetworkManagerApplier) saveState(state map[string][]string) error {
+	if err := writeFileAtomic(a.manager.fs, filepath.Join(a.manager.transactionDir, networkManagerStateFile), state); err != nil {
+		return fmt.Errorf("failed to save applied NetworkManager addresses: %w", err)
+	}
+	return nil
+}
+
+// addressFamily returns the nmcli setting of an address entry, "ipv4" or "ipv6"
+func addressFamily(entry string) string {
+	if ip, ok := entryAddress(entry); ok && !ip.Unmap().Is4() {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// containsAddress reports whether entries has an entry with the same address and prefix length as entry
+func containsAddress(entries []string, entry string) bool {
+	prefix, err := netip.ParsePrefix(entry)
+	if err != nil {
+		return slices.Contains(entries, entry)
+	}
+	return slices.ContainsFunc(entries, func(e string) bool {
+		other, err := netip.ParsePrefix(e)
+		return err == nil && other.Addr().Unmap() == prefix.Addr().Unmap() && other.Bits() == prefix.Bits()
+	})
+}
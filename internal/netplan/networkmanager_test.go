@@ -0,0 +1,99 @@
+package netplan
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// scriptedRunner records commands and answers them with the output configured for the command line
+type scriptedRunner struct {
+	mutex    sync.Mutex
+	outputs  map[string]string
+	commands []string
+}
+
+func (r *scriptedRunner) Run(_ context.Context, name string, args ...string) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	line := strings.Join(append([]string{name}, args...), " ")
+	r.commands = append(r.commands, line)
+	return []byte(r.outputs[line]), nil
+}
+
+// changes returns the commands that modify NetworkManager, and forgets all commands run so far
+func (r *scriptedRunner) changes() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var result []string
+	for _, command := range r.commands {
+		if !strings.HasPrefix(command, "nmcli -g ") {
+			result = append(result, command)
+		}
+	}
+	r.commands = nil
+	return result
+}
+
+func TestNetworkManagerBackend(t *testing.T) {
+	setupTest()
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{
+				{Interface: "eth0", Subnets: []string{"192.168.1.0/24", "2001:db8::/64"}},
+				{Interface: "vlan100@eth0", Subnets: []string{"10.100.0.0/24"}},
+			},
+			ConfigPath:     "/var/lib/haproxy-configurator/addresses.yaml",
+			TransactionDir: "/var/lib/haproxy-configurator/transactions",
+			Backend:        "networkmanager",
+		},
+	}
+	runner := &scriptedRunner{outputs: map[string]string{
+		"nmcli -g GENERAL.CONNECTION device show eth0":                              "Wired connection 1\n",
+		"nmcli -g ipv4.addresses,ipv6.addresses connection show Wired connection 1": "192.168.1.5/24\n\n",
+		"nmcli -g GENERAL.CONNECTION device show vlan100":                           "vlan100\n",
+	}}
+	manager := NewManagerWithFS(cfg, NewMemFS(), runner)
+
+	for _, ip := range []string{"192.168.1.100", "2001:db8::100", "10.100.0.10"} {
+		if err := manager.AddIPAddressToTransaction("tx-add", ip, 80); err != nil {
+			t.Fatalf("Failed to add %s to transaction: %v", ip, err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-add"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	expected := []string{
+		"nmcli connection modify Wired connection 1 +ipv4.addresses 192.168.1.100/24 +ipv6.addresses 2001:db8::100/64",
+		"nmcli device reapply eth0",
+		"nmcli connection modify vlan100 +ipv4.addresses 10.100.0.10/24",
+		"nmcli device reapply vlan100",
+	}
+	if changes := runner.changes(); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected commands %q, got %q", expected, changes)
+	}
+
+	// Only the addresses added by the configurator are removed, never the primary address of the host
+	runner.outputs["nmcli -g ipv4.addresses,ipv6.addresses connection show Wired connection 1"] = "192.168.1.5/24, 192.168.1.100/24\n2001\\:db8::100/64\n"
+	runner.outputs["nmcli -g ipv4.addresses,ipv6.addresses connection show vlan100"] = "10.100.0.10/24\n\n"
+	for _, ip := range []string{"192.168.1.100", "2001:db8::100"} {
+		if err := manager.RemoveIPAddressFromTransaction("tx-remove", ip); err != nil {
+			t.Fatalf("Failed to remove %s in transaction: %v", ip, err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-remove"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	expected = []string{
+		"nmcli connection modify Wired connection 1 -ipv4.addresses 192.168.1.100/24 -ipv6.addresses 2001:db8::100/64",
+		"nmcli device reapply eth0",
+	}
+	if changes := runner.changes(); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected commands %q, got %q", expected, changes)
+	}
+}
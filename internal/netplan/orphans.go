@@ -0,0 +1,133 @@
+package netplan
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// OrphanedAddress is a VIP assigned through Netplan that no bind listens on anymore, e.g. because the bind was
+// deleted around the configurator or a transaction failed halfway
+type OrphanedAddress struct {
+	Address   string
+	Interface string // Interface mapping the address is assigned to, e.g. "eth0" or "vlan100@eth0"
+}
+
+// FindOrphans returns the VIPs that none of the addresses returned by listBindAddresses listens on, ordered by
+// address. VIPs are the tracked addresses and the addresses in the Netplan configuration that fall into a mapped
+// subnet; addresses changed by a pending transaction are left out, as their bind is being created or deleted.
+// The binds are listed after the VIPs, so that a bind committed in between is not mistaken for an orphan.
+func (m *Manager) FindOrphans(listBindAddresses func() ([]string, error)) ([]OrphanedAddress, error) {
+	candidates, inUse, err := m.orphanCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	bindAddresses, err := listBindAddresses()
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range bindAddresses {
+		if ip, err := netip.ParseAddr(address); err == nil {
+			inUse[ip.Unmap()] = true
+		}
+	}
+
+	var orphans []OrphanedAddress
+	for address, iface := range candidates {
+		ip, err := netip.ParseAddr(address)
+		if err != nil || inUse[ip.Unmap()] {
+			continue
+		}
+		orphans = append(orphans, OrphanedAddress{Address: address, Interface: iface})
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Address < orphans[j].Address })
+	return orphans, nil
+}
+
+// orphanCandidates returns the VIPs, mapped to their interface, and the addresses of pending transactions, as of
+// a single point in time: no commit changes the Netplan configuration in between
+func (m *Manager) orphanCandidates() (map[string]string, map[netip.Addr]bool, error) {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+
+	netplanConfig, err := m.loadNetplanConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load Netplan config: %w", err)
+	}
+	transactions, err := m.ListTransactions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inUse := make(map[netip.Addr]bool)
+	for _, transaction := range transactions {
+		if transaction.Status != "pending" {
+			continue
+		}
+		for _, change := range transaction.Changes {
+			if ip, err := netip.ParseAddr(change.IPAddress); err == nil {
+				inUse[ip.Unmap()] = true
+			}
+		}
+	}
+
+	candidates := make(map[string]string) // Address -> interface mapping
+	for name, iface := range netplanConfig.Network.Ethernets {
+		m.addOrphanCandidates(candidates, name, iface.Addresses)
+	}
+	for name, vlan := range netplanConfig.Network.Vlans {
+		m.addOrphanCandidates(candidates, name+"@"+vlan.Link, vlan.Addresses)
+	}
+	for address, iface := range m.GetTrackedAddresses() {
+		candidates[address] = iface
+	}
+	return candidates, inUse, nil
+}
+
+// addOrphanCandidates adds the addresses of an interface that fall into a subnet mapped to it. Addresses
+// outside the mappings, such as the primary address of the host, are never considered VIPs.
+func (m *Manager) addOrphanCandidates(candidates map[string]string, interfaceName string, addresses []string) {
+	for _, address := range addresses {
+		ip, ok := entryAddress(address)
+		if !ok {
+			continue
+		}
+		ipAddr := ip.String()
+		if mapped, err := m.findInterfaceForIP(ipAddr); err == nil && mapped == interfaceName {
+			candidates[ipAddr] = interfaceName
+		}
+	}
+}
+
+// RemoveOrphans removes orphaned VIPs from the Netplan configuration and applies it, through a Netplan
+// transaction of its own
+func (m *Manager) RemoveOrphans(ctx context.Context, orphans []OrphanedAddress) error {
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	transactionID := fmt.Sprintf("orphan-cleanup-%d", time.Now().UnixNano())
+	for _, orphan := range orphans {
+		if err := m.addChangeToTransaction(transactionID, TransactionChange{
+			Operation: "remove",
+			IPAddress: orphan.Address,
+			Interface: orphan.Interface,
+		}); err != nil {
+			return fmt.Errorf("failed to add removal of %s: %w", orphan.Address, err)
+		}
+	}
+	if err := m.CommitTransaction(ctx, transactionID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Removed orphaned VIPs from Netplan",
+		zap.String("transaction_id", transactionID),
+		zap.Int("addresses", len(orphans)))
+	return nil
+}
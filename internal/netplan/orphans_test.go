@@ -0,0 +1,82 @@
+package netplan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestFindAndRemoveOrphans(t *testing.T) {
+	setupTest()
+	configPath := "/etc/netplan/netplan.yaml"
+	initial := `network:
+  version: 2
+  ethernets:
+    eth0:
+      addresses:
+        - 192.168.1.5/24
+        - 192.168.1.100/24
+        - 192.168.1.101/24
+        - 10.0.0.1/8
+`
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}}},
+			ConfigPath:        configPath,
+			TransactionDir:    "/var/lib/haproxy-configurator/transactions",
+		},
+	}
+	manager, fsys, runner := newMemoryManager(cfg)
+	if err := fsys.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("Failed to create Netplan directory: %v", err)
+	}
+	if err := fsys.WriteFile(configPath, []byte(initial), 0600); err != nil {
+		t.Fatalf("Failed to write Netplan config: %v", err)
+	}
+	manager.RestoreTrackedAddresses(map[string]string{"192.168.1.102": "eth0"})
+
+	// An address being added by a pending transaction is not an orphan yet
+	if err := manager.AddIPAddressToTransaction("pending-tx", "192.168.1.103", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+	if err := manager.AddIPAddressToTransaction("pending-tx", "192.168.1.5", 80); err != nil {
+		t.Fatalf("Failed to add IP to transaction: %v", err)
+	}
+
+	orphans, err := manager.FindOrphans(func() ([]string, error) {
+		return []string{"192.168.1.100", "0.0.0.0"}, nil
+	})
+	if err != nil {
+		t.Fatalf("FindOrphans failed: %v", err)
+	}
+	// 10.0.0.1 is outside the mappings, 192.168.1.5 is pending and 192.168.1.100 is bound
+	expected := []OrphanedAddress{{Address: "192.168.1.101", Interface: "eth0"}, {Address: "192.168.1.102", Interface: "eth0"}}
+	if len(orphans) != len(expected) || orphans[0] != expected[0] || orphans[1] != expected[1] {
+		t.Fatalf("Expected orphans %v, got %v", expected, orphans)
+	}
+
+	if err := manager.RemoveOrphans(context.Background(), orphans); err != nil {
+		t.Fatalf("RemoveOrphans failed: %v", err)
+	}
+	if applies := len(appliesOf(runner)); applies != 1 {
+		t.Errorf("Expected netplan apply to be called once, got %d calls", applies)
+	}
+	netplanConfig, err := manager.loadNetplanConfig()
+	if err != nil {
+		t.Fatalf("Failed to load Netplan config: %v", err)
+	}
+	addresses := netplanConfig.Network.Ethernets["eth0"].Addresses
+	if len(addresses) != 3 || addresses[0] != "192.168.1.5/24" || addresses[1] != "192.168.1.100/24" || addresses[2] != "10.0.0.1/8" {
+		t.Errorf("Expected only the orphans to be removed, got %v", addresses)
+	}
+	if _, tracked := manager.GetTrackedAddresses()["192.168.1.102"]; tracked {
+		t.Error("Expected the removed orphan to be untracked")
+	}
+
+	orphans, err = manager.FindOrphans(func() ([]string, error) { return []string{"192.168.1.100"}, nil })
+	if err != nil || len(orphans) != 0 {
+		t.Errorf("Expected no orphans after the cleanup, got %v and %v", orphans, err)
+	}
+}
@@ -0,0 +1,88 @@
+package netplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os/exec"
+	"regexp"
+)
+
+// AddressInUseError reports a VIP that another host on the network already answers ARP requests for
+type AddressInUseError struct {
+	Address   string
+	Interface string
+	MAC       string // Hardware address of the host that answered, empty if it could not be read
+}
+
+func (e *AddressInUseError) Error() string {
+	if e.MAC == "" {
+		return fmt.Sprintf("address %s is already in use on %s", e.Address, e.Interface)
+	}
+	return fmt.Sprintf("address %s is already in use on %s by %s", e.Address, e.Interface, e.MAC)
+}
+
+// arpReplyMAC matches the hardware address in a reply line of arping, e.g. "Unicast reply from 192.168.1.10 [00:11:22:33:44:55]"
+var arpReplyMAC = regexp.MustCompile(`reply from \S+ \[([0-9A-Fa-f:]+)\]`)
+
+// arpProbe sends ARP duplicate address detection probes for ip on iface with arping. It returns whether another
+// host answered and its hardware address; tests replace it.
+var arpProbe = func(ctx context.Context, iface, ip string) (bool, string, error) {
+	output, err := exec.CommandContext(ctx, "arping", "-D", "-c", "2", "-w", "2", "-I", iface, ip).CombinedOutput()
+	if err == nil {
+		return false, "", nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		mac := ""
+		if match := arpReplyMAC.FindSubmatch(output); match != nil {
+			mac = string(match[1])
+		}
+		return true, mac, nil
+	}
+	return false, "", fmt.Errorf("arping failed: %w, output: %s", err, string(output))
+}
+
+// ProbeAddress checks with an ARP probe on its mapped interface whether another host already uses ipAddr, if
+// arp_probe is enabled. Addresses assigned to this host and IPv6 addresses, which ARP does not cover, are not
+// probed. It returns an *AddressInUseError if another host answered.
+func (m *Manager) ProbeAddress(ctx context.Context, ipAddr string) error {
+	if !m.currentConfig().Netplan.ARPProbe {
+		return nil
+	}
+	ip, err := netip.ParseAddr(ipAddr)
+	if err != nil || !ip.Unmap().Is4() {
+		return nil
+	}
+
+	m.mutex.RLock()
+	_, tracked := m.addresses[ipAddr]
+	m.mutex.RUnlock()
+	if tracked {
+		return nil
+	}
+	if local, err := hostAddresses(); err == nil {
+		if _, ok := local[ip.Unmap()]; ok {
+			return nil
+		}
+	}
+
+	interfaceName, err := m.findInterfaceForIP(ipAddr)
+	if err != nil {
+		return nil // Not a Netplan-managed VIP
+	}
+	iface := interfaceName
+	if vlanName, _, isVLAN := parseInterfaceName(interfaceName); isVLAN {
+		iface = vlanName
+	}
+
+	inUse, mac, err := arpProbe(ctx, iface, ip.Unmap().String())
+	if err != nil {
+		return fmt.Errorf("failed to probe %s on %s: %w", ipAddr, iface, err)
+	}
+	if inUse {
+		return &AddressInUseError{Address: ipAddr, Interface: iface, MAC: mac}
+	}
+	return nil
+}
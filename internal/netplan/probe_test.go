@@ -0,0 +1,58 @@
+package netplan
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+func TestProbeAddress(t *testing.T) {
+	originalProbe, originalHost := arpProbe, hostAddresses
+	t.Cleanup(func() { arpProbe, hostAddresses = originalProbe, originalHost })
+	hostAddresses = func() (map[netip.Addr]string, error) {
+		return map[netip.Addr]string{netip.MustParseAddr("192.168.1.10"): "eth0"}, nil
+	}
+	var probed []string
+	arpProbe = func(_ context.Context, iface, ip string) (bool, string, error) {
+		probed = append(probed, iface+" "+ip)
+		return ip == "10.100.0.5", "00:11:22:33:44:55", nil
+	}
+
+	manager := &Manager{addresses: map[string]string{"192.168.1.20": "eth0"}}
+	manager.config.Store(&config.Config{Netplan: config.NetplanSettings{
+		InterfaceMappings: []config.InterfaceMapping{
+			{Interface: "eth0", Subnets: []string{"192.168.1.0/24"}},
+			{Interface: "vlan100@eth1", Subnets: []string{"10.100.0.0/24"}},
+		},
+	}})
+	if err := manager.ProbeAddress(context.Background(), "192.168.1.100"); err != nil || len(probed) != 0 {
+		t.Fatalf("Expected no probe while disabled, got %v and %v", err, probed)
+	}
+
+	manager.currentConfig().Netplan.ARPProbe = true
+	for _, address := range []string{"192.168.1.10", "192.168.1.20", "203.0.113.1", "2001:db8::1", "192.168.1.100"} {
+		if err := manager.ProbeAddress(context.Background(), address); err != nil {
+			t.Errorf("Expected %s to be free, got %v", address, err)
+		}
+	}
+	if len(probed) != 1 || probed[0] != "eth0 192.168.1.100" {
+		t.Errorf("Expected only the unassigned mapped IPv4 address to be probed, got %v", probed)
+	}
+
+	// VLAN addresses are probed on the VLAN interface
+	var inUse *AddressInUseError
+	err := manager.ProbeAddress(context.Background(), "10.100.0.5")
+	if !errors.As(err, &inUse) || inUse.Interface != "vlan100" || inUse.MAC != "00:11:22:33:44:55" {
+		t.Errorf("Expected the address to be in use on vlan100, got %v", err)
+	}
+}
+
+func TestARPReplyMAC(t *testing.T) {
+	output := "ARPING 192.168.1.100 from 0.0.0.0 eth0\nUnicast reply from 192.168.1.100 [52:54:00:AB:CD:EF]  0.712ms\nSent 1 probes (1 broadcast(s))\n"
+	if match := arpReplyMAC.FindStringSubmatch(output); match == nil || match[1] != "52:54:00:AB:CD:EF" {
+		t.Errorf("Expected the hardware address of the reply, got %v", match)
+	}
+}
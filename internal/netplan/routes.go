@@ -0,0 +1,165 @@
+package netplan
+
+import (
+	"net/netip"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// routeTemplates returns the route and routing policy templates of the mappings of interfaceName
+func (m *Manager) routeTemplates(interfaceName string) ([]config.RouteTemplate, []config.RoutingPolicyTemplate) {
+	var routes []config.RouteTemplate
+	var policy []config.RoutingPolicyTemplate
+	for _, mapping := range m.currentConfig().Netplan.InterfaceMappings {
+		if mapping.Interface == interfaceName {
+			routes = append(routes, mapping.Routes...)
+			policy = append(policy, mapping.RoutingPolicy...)
+		}
+	}
+	return routes, policy
+}
+
+// syncRoutes returns the routes and routing policy of interfaceName updated for its addresses. Routes of the
+// templates whose subnet has an address in addresses are added, and those whose subnet has none left removed; the
+// addresses of a subnet are the reference count of its routes, so that the count survives restarts with the
+// Netplan configuration. Each address of a subnet with a routing policy template gets a rule of its own. Routes and
+// rules not created from a template are left alone.
+func (m *Manager) syncRoutes(interfaceName string, addresses []string, routes []NetplanRoute, policy []NetplanRoutingPolicy) ([]NetplanRoute, []NetplanRoutingPolicy) {
+	routeTemplates, policyTemplates := m.routeTemplates(interfaceName)
+	if len(routeTemplates) > 0 {
+		routes = syncTemplateRoutes(routeTemplates, addresses, routes)
+	}
+	if len(policyTemplates) > 0 {
+		policy = syncRoutingPolicy(policyTemplates, addresses, policy)
+	}
+	return routes, policy
+}
+
+// syncTemplateRoutes returns routes with the routes of the templates added or removed by whether their subnet
+// has an address in addresses
+func syncTemplateRoutes(templates []config.RouteTemplate, addresses []string, routes []NetplanRoute) []NetplanRoute {
+	var wanted, unwanted []NetplanRoute
+	for _, template := range templates {
+		if subnetReferences(template.Subnet, addresses) > 0 {
+			wanted = append(wanted, templateRoute(template))
+		} else {
+			unwanted = append(unwanted, templateRoute(template))
+		}
+	}
+
+	var synced []NetplanRoute
+	for _, route := range routes {
+		// A route that two templates share stays while either of their subnets has addresses
+		if containsRoute(unwanted, route) && !containsRoute(wanted, route) {
+			continue
+		}
+		synced = append(synced, route)
+	}
+	for _, route := range wanted {
+		if !containsRoute(synced, route) {
+			synced = append(synced, route)
+		}
+	}
+	return synced
+}
+
+// syncRoutingPolicy returns policy with a rule from each address in addresses within the subnet of a template,
+// and without the rules of the templates whose address is gone
+func syncRoutingPolicy(templates []config.RoutingPolicyTemplate, addresses []string, policy []NetplanRoutingPolicy) []NetplanRoutingPolicy {
+	var wanted []NetplanRoutingPolicy
+	for _, template := range templates {
+		prefix, err := netip.ParsePrefix(template.Subnet)
+		if err != nil {
+			continue
+		}
+		for _, entry := range addresses {
+			if ip, ok := entryAddress(entry); ok && prefix.Contains(ip.Unmap()) {
+				ip = ip.Unmap()
+				wanted = append(wanted, NetplanRoutingPolicy{
+					From:     netip.PrefixFrom(ip, ip.BitLen()).String(),
+					Table:    template.Table,
+					Priority: template.Priority,
+				})
+			}
+		}
+	}
+
+	var synced []NetplanRoutingPolicy
+	for _, rule := range policy {
+		if isTemplateRule(templates, rule) && !containsRule(wanted, rule) {
+			continue
+		}
+		synced = append(synced, rule)
+	}
+	for _, rule := range wanted {
+		if !containsRule(synced, rule) {
+			synced = append(synced, rule)
+		}
+	}
+	return synced
+}
+
+// isTemplateRule reports whether a rule is one that a template creates: a rule from a single address of its subnet
+// to its table
+func isTemplateRule(templates []config.RoutingPolicyTemplate, rule NetplanRoutingPolicy) bool {
+	from, err := netip.ParsePrefix(rule.From)
+	if err != nil || !from.IsSingleIP() || rule.To != "" || rule.Mark != 0 || rule.TypeOfService != 0 {
+		return false
+	}
+	for _, template := range templates {
+		prefix, err := netip.ParsePrefix(template.Subnet)
+		if err == nil && rule.Table == template.Table && rule.Priority == template.Priority && prefix.Contains(from.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsRule reports whether policy has a rule from the same source to the same table with the same priority
+// as rule
+func containsRule(policy []NetplanRoutingPolicy, rule NetplanRoutingPolicy) bool {
+	for _, r := range policy {
+		if r.From == rule.From && r.To == rule.To && r.Table == rule.Table && r.Priority == rule.Priority {
+			return true
+		}
+	}
+	return false
+}
+
+// subnetReferences returns how many of the Netplan address entries assign an address of subnet
+func subnetReferences(subnet string, addresses []string) int {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range addresses {
+		if ip, ok := entryAddress(entry); ok && prefix.Contains(ip.Unmap()) {
+			count++
+		}
+	}
+	return count
+}
+
+// templateRoute returns the Netplan route of a route template
+func templateRoute(template config.RouteTemplate) NetplanRoute {
+	return NetplanRoute{
+		To:     template.To,
+		Via:    template.Via,
+		Metric: template.Metric,
+		OnLink: template.OnLink,
+		Table:  template.Table,
+		Scope:  template.Scope,
+	}
+}
+
+// containsRoute reports whether routes has a route to the same destination through the same gateway in the
+// same table with the same metric as route
+func containsRoute(routes []NetplanRoute, route NetplanRoute) bool {
+	for _, r := range routes {
+		if r.To == route.To && r.Via == route.Via && r.Table == route.Table && r.Metric == route.Metric {
+			return true
+		}
+	}
+	return false
+}
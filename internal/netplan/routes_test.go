@@ -0,0 +1,231 @@
+package netplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// routesOf returns the routes of an interface in the Netplan configuration, or fails if it has none
+func routesOf(t *testing.T, manager *Manager, interfaceName string) []NetplanRoute {
+	t.Helper()
+	netplanConfig, err := manager.loadNetplanConfig()
+	if err != nil {
+		t.Fatalf("Failed to load Netplan config: %v", err)
+	}
+	if vlanName, _, isVLAN := parseInterfaceName(interfaceName); isVLAN {
+		return netplanConfig.Network.Vlans[vlanName].Routes
+	}
+	return netplanConfig.Network.Ethernets[interfaceName].Routes
+}
+
+func TestRouteReferenceCounting(t *testing.T) {
+	setupTest()
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{
+				Interface: "eth0",
+				Subnets:   []string{"192.168.1.0/24", "203.0.113.0/28"},
+				Routes: []config.RouteTemplate{
+					{Subnet: "203.0.113.0/28", To: "default", Via: "192.168.1.1", OnLink: true, Table: 100},
+				},
+			}},
+			ConfigPath: "/etc/netplan/test-netplan.yaml",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	// Addresses of other subnets do not need the route
+	if err := manager.AddIPAddress("192.168.1.100", 80); err != nil {
+		t.Fatalf("AddIPAddress failed: %v", err)
+	}
+	if routes := routesOf(t, manager, "eth0"); len(routes) != 0 {
+		t.Errorf("Expected no routes without an address of the subnet, got %+v", routes)
+	}
+
+	// The first address of the subnet adds the route, the second one does not add it again
+	for _, ip := range []string{"203.0.113.1", "203.0.113.2"} {
+		if err := manager.AddIPAddress(ip, 80); err != nil {
+			t.Fatalf("AddIPAddress(%s) failed: %v", ip, err)
+		}
+	}
+	want := NetplanRoute{To: "default", Via: "192.168.1.1", OnLink: true, Table: 100}
+	if routes := routesOf(t, manager, "eth0"); len(routes) != 1 || routes[0] != want {
+		t.Fatalf("Expected route %+v, got %+v", want, routes)
+	}
+
+	// The route stays until the last address of the subnet is removed
+	if err := manager.RemoveIPAddress("203.0.113.1"); err != nil {
+		t.Fatalf("RemoveIPAddress failed: %v", err)
+	}
+	if routes := routesOf(t, manager, "eth0"); len(routes) != 1 {
+		t.Errorf("Expected the route to stay while an address of the subnet is left, got %+v", routes)
+	}
+	if err := manager.RemoveIPAddress("203.0.113.2"); err != nil {
+		t.Fatalf("RemoveIPAddress failed: %v", err)
+	}
+	if routes := routesOf(t, manager, "eth0"); len(routes) != 0 {
+		t.Errorf("Expected the route to be removed with the last address of the subnet, got %+v", routes)
+	}
+}
+
+func TestRouteReferenceCountingInTransaction(t *testing.T) {
+	setupTest()
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{
+				Interface: "vlan100@eth0",
+				Subnets:   []string{"10.100.0.0/24"},
+				Routes: []config.RouteTemplate{
+					{Subnet: "10.100.0.0/24", To: "10.200.0.0/16", Via: "10.100.0.254", Metric: 10},
+				},
+			}},
+			ConfigPath: "/etc/netplan/test-netplan.yaml",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	for _, ip := range []string{"10.100.0.10", "10.100.0.11"} {
+		if err := manager.AddIPAddressToTransaction("tx-add", ip, 80); err != nil {
+			t.Fatalf("Failed to add IP to transaction: %v", err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-add"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	want := NetplanRoute{To: "10.200.0.0/16", Via: "10.100.0.254", Metric: 10}
+	if routes := routesOf(t, manager, "vlan100@eth0"); len(routes) != 1 || routes[0] != want {
+		t.Fatalf("Expected route %+v, got %+v", want, routes)
+	}
+
+	for _, ip := range []string{"10.100.0.10", "10.100.0.11"} {
+		if err := manager.RemoveIPAddressFromTransaction("tx-remove", ip); err != nil {
+			t.Fatalf("Failed to remove IP in transaction: %v", err)
+		}
+	}
+	if err := manager.CommitTransaction(context.Background(), "tx-remove"); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	if routes := routesOf(t, manager, "vlan100@eth0"); len(routes) != 0 {
+		t.Errorf("Expected the route to be removed with the last address, got %+v", routes)
+	}
+}
+
+func TestSyncRoutesKeepsOtherRoutes(t *testing.T) {
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{
+				Interface: "eth0",
+				Subnets:   []string{"192.168.1.0/24", "192.168.2.0/24"},
+				Routes: []config.RouteTemplate{
+					{Subnet: "192.168.1.0/24", To: "10.0.0.0/8", Via: "192.168.1.1"},
+					{Subnet: "192.168.2.0/24", To: "10.0.0.0/8", Via: "192.168.1.1"},
+				},
+			}},
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	unmanaged := NetplanRoute{To: "172.16.0.0/12", Via: "192.168.1.254"}
+	shared := NetplanRoute{To: "10.0.0.0/8", Via: "192.168.1.1"}
+
+	// A route shared by two templates stays while either subnet has an address
+	routes, _ := manager.syncRoutes("eth0", []string{"192.168.2.5/24"}, []NetplanRoute{unmanaged, shared}, nil)
+	if len(routes) != 2 || routes[0] != unmanaged || routes[1] != shared {
+		t.Errorf("Expected the unmanaged and the shared route, got %+v", routes)
+	}
+
+	// Routes not created from a template are left alone when the templated ones are removed
+	routes, _ = manager.syncRoutes("eth0", nil, routes, nil)
+	if len(routes) != 1 || routes[0] != unmanaged {
+		t.Errorf("Expected only the unmanaged route, got %+v", routes)
+	}
+}
+
+func TestRoutingPolicyPerAddress(t *testing.T) {
+	setupTest()
+
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{
+				Interface: "eth1",
+				Subnets:   []string{"203.0.113.0/28", "2001:db8::/64"},
+				Routes: []config.RouteTemplate{
+					{Subnet: "203.0.113.0/28", To: "default", Via: "203.0.113.14", Table: 100},
+				},
+				RoutingPolicy: []config.RoutingPolicyTemplate{
+					{Subnet: "203.0.113.0/28", Table: 100, Priority: 1000},
+					{Subnet: "2001:db8::/64", Table: 200},
+				},
+			}},
+			ConfigPath: "/etc/netplan/test-netplan.yaml",
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	for _, ip := range []string{"203.0.113.1", "203.0.113.2", "2001:db8::10"} {
+		if err := manager.AddIPAddress(ip, 80); err != nil {
+			t.Fatalf("AddIPAddress(%s) failed: %v", ip, err)
+		}
+	}
+	if err := manager.RemoveIPAddress("203.0.113.1"); err != nil {
+		t.Fatalf("RemoveIPAddress failed: %v", err)
+	}
+
+	netplanConfig, err := manager.loadNetplanConfig()
+	if err != nil {
+		t.Fatalf("Failed to load Netplan config: %v", err)
+	}
+	want := []NetplanRoutingPolicy{
+		{From: "203.0.113.2/32", Table: 100, Priority: 1000},
+		{From: "2001:db8::10/128", Table: 200},
+	}
+	policy := netplanConfig.Network.Ethernets["eth1"].RoutingPolicy
+	if len(policy) != len(want) {
+		t.Fatalf("Expected routing policy %+v, got %+v", want, policy)
+	}
+	for i := range want {
+		if policy[i] != want[i] {
+			t.Errorf("Expected rule %+v at %d, got %+v", want[i], i, policy[i])
+		}
+	}
+	if routes := routesOf(t, manager, "eth1"); len(routes) != 1 || routes[0].Table != 100 {
+		t.Errorf("Expected the default route of table 100, got %+v", routes)
+	}
+}
+
+func TestSyncRoutingPolicyKeepsOtherRules(t *testing.T) {
+	cfg := &config.Config{
+		Netplan: config.NetplanSettings{
+			InterfaceMappings: []config.InterfaceMapping{{
+				Interface: "eth1",
+				Subnets:   []string{"203.0.113.0/28"},
+				RoutingPolicy: []config.RoutingPolicyTemplate{
+					{Subnet: "203.0.113.0/28", Table: 100},
+				},
+			}},
+		},
+	}
+	manager, _, _ := newMemoryManager(cfg)
+
+	unmanaged := []NetplanRoutingPolicy{
+		{From: "203.0.113.0/28", Table: 100},          // Not a single address
+		{From: "203.0.113.5/32", Table: 100, Mark: 1}, // Matches a mark as well
+		{From: "198.51.100.1/32", Table: 100},         // Outside the subnet
+		{To: "203.0.113.0/28", Table: 300},
+	}
+	stale := NetplanRoutingPolicy{From: "203.0.113.9/32", Table: 100}
+
+	_, policy := manager.syncRoutes("eth1", nil, nil, append(append([]NetplanRoutingPolicy(nil), unmanaged...), stale))
+	if len(policy) != len(unmanaged) {
+		t.Fatalf("Expected only the unmanaged rules to stay, got %+v", policy)
+	}
+	for i := range unmanaged {
+		if policy[i] != unmanaged[i] {
+			t.Errorf("Expected rule %+v at %d, got %+v", unmanaged[i], i, policy[i])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package peersync copies the state of the leading configurator to standbys, so that a standby taking over
+// knows which VIPs it owns and continues the event journal
+package peersync
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// requestTimeout bounds a single request to a peer
+const requestTimeout = 10 * time.Second
+
+// maxRounds bounds the requests per peer and sync when the journal has many events to catch up on
+const maxRounds = 100
+
+// Local is the configurator instance the state is copied to
+type Local interface {
+	IsLeader() bool
+	PeerCursor() (uint64, error) // ID of the last journal event, from which the copy continues
+	ImportPeerState(state *pb.GetPeerStateResponse) error
+}
+
+// peer is another configurator instance and the outcome of the last sync with it
+type peer struct {
+	client pb.HAProxyManagerServiceClient
+	conn   *grpc.ClientConn
+
+	mutex  sync.Mutex
+	status *pb.PeerStatus
+}
+
+// Syncer periodically copies the state of the leader among the peers while the local instance is a standby
+type Syncer struct {
+	local    Local
+	interval time.Duration
+	peers    []*peer
+}
+
+// NewSyncer creates a syncer for the configured peers. Connections are established on first use.
+func NewSyncer(settings config.PeerSyncSettings, local Local) (*Syncer, error) {
+	creds, err := transportCredentials(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	syncer := &Syncer{local: local, interval: time.Duration(settings.IntervalSeconds) * time.Second}
+	for _, address := range settings.Peers {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			syncer.Close()
+			return nil, fmt.Errorf("failed to create client for peer %s: %w", address, err)
+		}
+		syncer.peers = append(syncer.peers, &peer{
+			client: pb.NewHAProxyManagerServiceClient(conn),
+			conn:   conn,
+			status: &pb.PeerStatus{Address: address},
+		})
+	}
+	return syncer, nil
+}
+
+// transportCredentials returns the credentials for connecting to the peers
+func transportCredentials(settings config.PeerSyncSettings) (credentials.TransportCredentials, error) {
+	if !settings.TLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: settings.InsecureSkipVerify}
+	if settings.CAFile != "" {
+		data, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read peer CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in peer CA file %s", settings.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Run syncs every interval until ctx is done
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync asks every peer for its state and imports the state of the leader. The leader itself copies nothing.
+func (s *Syncer) Sync(ctx context.Context) {
+	for _, p := range s.peers {
+		if s.local.IsLeader() {
+			return
+		}
+
+		err := s.syncPeer(ctx, p)
+		p.mutex.Lock()
+		if err != nil {
+			p.status.LastError = err.Error()
+			logger.GetLogger().Warn("Failed to sync with peer",
+				zap.String("peer", p.status.Address),
+				zap.Error(err))
+		} else {
+			p.status.LastError = ""
+			p.status.LastSyncTime = timestamppb.Now()
+		}
+		p.mutex.Unlock()
+	}
+}
+
+// syncPeer copies the state of a peer if it is the leader, requesting further journal events as long as
+// the peer has more
+func (s *Syncer) syncPeer(ctx context.Context, p *peer) error {
+	for range maxRounds {
+		cursor, err := s.local.PeerCursor()
+		if err != nil {
+			return fmt.Errorf("failed to read the journal position: %w", err)
+		}
+
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		state, err := p.client.GetPeerState(requestCtx, &pb.GetPeerStateRequest{AfterEventId: cursor})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get peer state: %w", err)
+		}
+
+		p.mutex.Lock()
+		p.status.Identity = state.Identity
+		p.status.Leader = state.Leader
+		p.mutex.Unlock()
+		if !state.Leader {
+			return nil
+		}
+
+		if err := s.local.ImportPeerState(state); err != nil {
+			return fmt.Errorf("failed to import peer state: %w", err)
+		}
+		if n := len(state.Events); n > 0 {
+			p.mutex.Lock()
+			p.status.LastEventId = state.Events[n-1].Id
+			p.mutex.Unlock()
+			logger.GetLogger().Debug("Imported journal events from the leader",
+				zap.String("peer", p.status.Address),
+				zap.Int("events", n))
+		}
+		if !state.MoreEvents {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Status returns the outcome of the last sync with every peer
+func (s *Syncer) Status() []*pb.PeerStatus {
+	var statuses []*pb.PeerStatus
+	for _, p := range s.peers {
+		p.mutex.Lock()
+		statuses = append(statuses, proto.Clone(p.status).(*pb.PeerStatus))
+		p.mutex.Unlock()
+	}
+	return statuses
+}
+
+// Close closes the connections to the peers
+func (s *Syncer) Close() {
+	for _, p := range s.peers {
+		_ = p.conn.Close()
+	}
+}
@@ -0,0 +1,140 @@
+package peersync
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+)
+
+// fakePeer serves a fixed journal, returning at most two events per call
+type fakePeer struct {
+	pb.UnimplementedHAProxyManagerServiceServer
+	leader bool
+	events []*pb.Event
+}
+
+func (p *fakePeer) GetPeerState(_ context.Context, req *pb.GetPeerStateRequest) (*pb.GetPeerStateResponse, error) {
+	response := &pb.GetPeerStateResponse{Identity: "lb1", Leader: p.leader, NetplanEnabled: true, TrackedAddresses: map[string]string{"192.168.1.100": "eth0"}}
+	for _, event := range p.events {
+		if event.Id <= req.AfterEventId {
+			continue
+		}
+		if len(response.Events) == 2 {
+			response.MoreEvents = true
+			break
+		}
+		response.Events = append(response.Events, event)
+	}
+	return response, nil
+}
+
+// fakeLocal records the imported states
+type fakeLocal struct {
+	leader   bool
+	cursor   uint64
+	imported []*pb.GetPeerStateResponse
+}
+
+func (l *fakeLocal) IsLeader() bool { return l.leader }
+
+func (l *fakeLocal) PeerCursor() (uint64, error) { return l.cursor, nil }
+
+func (l *fakeLocal) ImportPeerState(state *pb.GetPeerStateResponse) error {
+	l.imported = append(l.imported, state)
+	if n := len(state.Events); n > 0 {
+		l.cursor = state.Events[n-1].Id
+	}
+	return nil
+}
+
+// startPeer serves the fake peer on a local port and returns its address
+func startPeer(t *testing.T, peer *fakePeer) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterHAProxyManagerServiceServer(server, peer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestSyncImportsFromLeader(t *testing.T) {
+	peer := &fakePeer{leader: true}
+	for id := uint64(1); id <= 5; id++ {
+		peer.events = append(peer.events, &pb.Event{Id: id, ResourceType: "backend", Action: "create"})
+	}
+	local := &fakeLocal{cursor: 1}
+
+	syncer, err := NewSyncer(config.PeerSyncSettings{Peers: []string{startPeer(t, peer)}, IntervalSeconds: 1}, local)
+	if err != nil {
+		t.Fatalf("NewSyncer failed: %v", err)
+	}
+	defer syncer.Close()
+	syncer.Sync(context.Background())
+
+	// Events after the local cursor are fetched in batches until the leader has no more
+	if len(local.imported) != 2 {
+		t.Fatalf("Expected 2 imports, got %d", len(local.imported))
+	}
+	if local.cursor != 5 {
+		t.Errorf("Expected cursor 5, got %d", local.cursor)
+	}
+	if local.imported[0].TrackedAddresses["192.168.1.100"] != "eth0" {
+		t.Errorf("Expected tracked addresses to be imported, got %v", local.imported[0].TrackedAddresses)
+	}
+
+	statuses := syncer.Status()
+	if len(statuses) != 1 || statuses[0].Identity != "lb1" || !statuses[0].Leader || statuses[0].LastEventId != 5 ||
+		statuses[0].LastSyncTime == nil || statuses[0].LastError != "" {
+		t.Errorf("Unexpected status: %v", statuses)
+	}
+}
+
+func TestSyncSkipsStandbyPeerAndLocalLeader(t *testing.T) {
+	standby := &fakePeer{events: []*pb.Event{{Id: 1}}}
+	local := &fakeLocal{}
+	syncer, err := NewSyncer(config.PeerSyncSettings{Peers: []string{startPeer(t, standby)}, IntervalSeconds: 1}, local)
+	if err != nil {
+		t.Fatalf("NewSyncer failed: %v", err)
+	}
+	defer syncer.Close()
+
+	syncer.Sync(context.Background())
+	if len(local.imported) != 0 {
+		t.Errorf("Expected nothing imported from a standby, got %d imports", len(local.imported))
+	}
+
+	standby.leader = true
+	local.leader = true
+	syncer.Sync(context.Background())
+	if len(local.imported) != 0 {
+		t.Errorf("Expected the leader to import nothing, got %d imports", len(local.imported))
+	}
+}
+
+func TestSyncRecordsErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	syncer, err := NewSyncer(config.PeerSyncSettings{Peers: []string{address}, IntervalSeconds: 1}, &fakeLocal{})
+	if err != nil {
+		t.Fatalf("NewSyncer failed: %v", err)
+	}
+	defer syncer.Close()
+
+	syncer.Sync(context.Background())
+	if statuses := syncer.Status(); statuses[0].LastError == "" || statuses[0].LastSyncTime != nil {
+		t.Errorf("Expected an error for an unreachable peer, got %v", statuses[0])
+	}
+}
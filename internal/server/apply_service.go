@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ApplyBackend creates the backend if it does not exist and replaces it if it differs from the live one
+func (s *HAProxyManagerServer) ApplyBackend(ctx context.Context, req *pb.ApplyBackendRequest) (*pb.ApplyBackendResponse, error) {
+	if req.Backend == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "backend is required")
+	}
+	if req.Backend.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	desired := proto.Clone(req.Backend).(*pb.Backend)
+	state.NormalizeBackend(desired)
+
+	current, err := s.GetBackend(ctx, &pb.GetBackendRequest{TransactionId: req.TransactionId, Name: desired.Name, NoCache: true})
+	if status.Code(err) == codes.NotFound {
+		created, err := s.CreateBackend(ctx, &pb.CreateBackendRequest{TransactionId: req.TransactionId, Backend: desired})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ApplyBackendResponse{Backend: created.Backend, Changed: true, Created: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.SameResource(current.Backend, inheritMetadata(current.Backend, desired)) {
+		return &pb.ApplyBackendResponse{Backend: current.Backend}, nil
+	}
+
+	updated, err := s.UpdateBackend(ctx, &pb.UpdateBackendRequest{TransactionId: req.TransactionId, Name: desired.Name, Backend: desired})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApplyBackendResponse{Backend: updated.Backend, Changed: true}, nil
+}
+
+// ApplyFrontend creates the frontend if it does not exist and replaces it if it differs from the live one
+func (s *HAProxyManagerServer) ApplyFrontend(ctx context.Context, req *pb.ApplyFrontendRequest) (*pb.ApplyFrontendResponse, error) {
+	if req.Frontend == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend is required")
+	}
+	if req.Frontend.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	desired := proto.Clone(req.Frontend).(*pb.Frontend)
+	state.NormalizeFrontend(desired)
+
+	current, err := s.GetFrontend(ctx, &pb.GetFrontendRequest{TransactionId: req.TransactionId, Name: desired.Name, NoCache: true})
+	if status.Code(err) == codes.NotFound {
+		created, err := s.CreateFrontend(ctx, &pb.CreateFrontendRequest{TransactionId: req.TransactionId, Frontend: desired})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ApplyFrontendResponse{Frontend: created.Frontend, Changed: true, Created: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.SameResource(current.Frontend, inheritMetadata(current.Frontend, desired)) {
+		return &pb.ApplyFrontendResponse{Frontend: current.Frontend}, nil
+	}
+
+	updated, err := s.UpdateFrontend(ctx, &pb.UpdateFrontendRequest{TransactionId: req.TransactionId, Name: desired.Name, Frontend: desired})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApplyFrontendResponse{Frontend: updated.Frontend, Changed: true}, nil
+}
+
+// ApplyBind creates the bind if it does not exist and replaces it if it differs from the live one.
+// Binds go through the Netplan integration; a bind whose address changes is recreated so its VIP moves with it.
+func (s *HAProxyManagerServer) ApplyBind(ctx context.Context, req *pb.ApplyBindRequest) (*pb.ApplyBindResponse, error) {
+	if req.Bind == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bind is required")
+	}
+	if req.FrontendName == "" || req.Bind.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name and bind name are required")
+	}
+
+	current, err := s.GetBind(ctx, &pb.GetBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Name: req.Bind.Name, NoCache: true})
+	if status.Code(err) == codes.NotFound {
+		created, err := s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Bind: req.Bind})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ApplyBindResponse{Bind: created.Bind, Changed: true, Created: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.SameResource(current.Bind, inheritMetadata(current.Bind, req.Bind)) {
+		return &pb.ApplyBindResponse{Bind: current.Bind}, nil
+	}
+
+	if current.Bind.Address != req.Bind.Address {
+		_, err := s.DeleteBindWithNetplan(ctx, &pb.DeleteBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Name: req.Bind.Name})
+		if err != nil {
+			return nil, err
+		}
+		// The recreated bind keeps the labels and annotations the request leaves unset, as an update would
+		created, err := s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Bind: inheritMetadata(current.Bind, req.Bind)})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ApplyBindResponse{Bind: created.Bind, Changed: true}, nil
+	}
+
+	updated, err := s.UpdateBind(ctx, &pb.UpdateBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Bind: req.Bind})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApplyBindResponse{Bind: updated.Bind, Changed: true}, nil
+}
+
+// ApplyServer creates the server if it does not exist and replaces it if it differs from the live one
+func (s *HAProxyManagerServer) ApplyServer(ctx context.Context, req *pb.ApplyServerRequest) (*pb.ApplyServerResponse, error) {
+	if req.Server == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "server is required")
+	}
+	if req.BackendName == "" || req.Server.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name and server name are required")
+	}
+
+	current, err := s.GetServer(ctx, &pb.GetServerRequest{TransactionId: req.TransactionId, BackendName: req.BackendName, Name: req.Server.Name, NoCache: true})
+	if status.Code(err) == codes.NotFound {
+		created, err := s.CreateServer(ctx, &pb.CreateServerRequest{TransactionId: req.TransactionId, BackendName: req.BackendName, Server: req.Server})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ApplyServerResponse{Server: created.Server, Changed: true, Created: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.SameResource(current.Server, inheritMetadata(current.Server, req.Server)) {
+		return &pb.ApplyServerResponse{Server: current.Server}, nil
+	}
+
+	updated, err := s.UpdateServer(ctx, &pb.UpdateServerRequest{TransactionId: req.TransactionId, BackendName: req.BackendName, Name: req.Server.Name, Server: req.Server})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApplyServerResponse{Server: updated.Server, Changed: true}, nil
+}
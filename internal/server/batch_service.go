@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateServers adds several servers to a backend within a transaction. The whole batch is validated against
+// the servers of the backend with a single request before the first server is added, then the servers are
+// added concurrently, see applyConcurrently. If adding a server fails nonetheless, the servers added by then
+// remain in the transaction, which should then be closed.
+func (s *HAProxyManagerServer) CreateServers(ctx context.Context, req *pb.CreateServersRequest) (*pb.CreateServersResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	if len(req.Servers) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one server is required")
+	}
+	names := make(map[string]bool, len(req.Servers))
+	for _, server := range req.Servers {
+		if server == nil || server.Name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "server name is required")
+		}
+		if names[server.Name] {
+			return nil, status.Errorf(codes.InvalidArgument, "server %s is given more than once", server.Name)
+		}
+		if err := validateServerSettings(server); err != nil {
+			return nil, err
+		}
+		if err := s.checkMetadata(server); err != nil {
+			return nil, err
+		}
+		names[server.Name] = true
+	}
+
+	existing, err := client.ListServers(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, server := range existing {
+		if name := derefString(server.Name); names[name] {
+			return nil, status.Errorf(codes.AlreadyExists, "server %s already exists in backend %s", name, req.BackendName)
+		}
+	}
+
+	response := &pb.CreateServersResponse{Servers: make([]*pb.Server, len(req.Servers))}
+	err = applyConcurrently(s.applyConcurrency(ctx), req.Servers, func(i int, server *pb.Server) error {
+		created, err := client.AddServer(ctx, req.BackendName, req.TransactionId, *convertServerFromProto(server))
+		if err != nil {
+			return batchError(err, "create", server.Name, i, len(req.Servers))
+		}
+		s.recordChange(resourceServer, actionCreate, req.BackendName, server.Name, req.TransactionId, nil, created)
+		response.Servers[i] = convertServerToProto(created)
+		key := metadataKey(client, metadata.KindServer, req.BackendName, server.Name)
+		return s.storeMetadata(ctx, req.TransactionId, key, server, response.Servers[i], true)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteServers removes several servers from a backend within a transaction. Every server must exist, which is
+// checked with a single request before the servers are deleted concurrently. If deleting a server fails
+// nonetheless, the deletions made by then remain in the transaction, which should then be closed.
+func (s *HAProxyManagerServer) DeleteServers(ctx context.Context, req *pb.DeleteServersRequest) (*pb.DeleteServersResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	if len(req.Names) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one server name is required")
+	}
+
+	existing, err := client.ListServers(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	previous := make(map[string]*dataplane.Server, len(existing))
+	for i := range existing {
+		previous[derefString(existing[i].Name)] = &existing[i]
+	}
+	seen := make(map[string]bool, len(req.Names))
+	for _, name := range req.Names {
+		if name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "server name is required")
+		}
+		if seen[name] {
+			return nil, status.Errorf(codes.InvalidArgument, "server %s is given more than once", name)
+		}
+		seen[name] = true
+		if previous[name] == nil {
+			return nil, status.Errorf(codes.NotFound, "server %s not found in backend %s", name, req.BackendName)
+		}
+	}
+
+	err = applyConcurrently(s.applyConcurrency(ctx), req.Names, func(i int, name string) error {
+		if err := client.DeleteServer(ctx, name, req.BackendName, req.TransactionId); err != nil {
+			return batchError(err, "delete", name, i, len(req.Names))
+		}
+		s.recordChange(resourceServer, actionDelete, req.BackendName, name, req.TransactionId, previous[name], nil)
+		s.deleteMetadata(ctx, req.TransactionId, metadataKey(client, metadata.KindServer, req.BackendName, name))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteServersResponse{}, nil
+}
+
+// batchError reports a Data Plane API error in the middle of a batch, keeping its status code
+func batchError(err error, action, name string, index, total int) error {
+	converted := status.Convert(handleHAProxyError(err))
+	return status.Errorf(converted.Code(), "failed to %s server %s (%d of %d, other changes of the batch remain in the transaction): %s",
+		action, name, index+1, total, converted.Message())
+}
+
+// applyConcurrently calls apply for every item, with up to workers calls in flight, so that the round trips
+// of independent Data Plane API requests overlap. Once a call fails, no further items are started and the
+// error of the first failed item in the given order is returned after the running calls finished.
+func applyConcurrently[T any](workers int, items []T, apply func(index int, item T) error) error {
+	errs := make([]error, len(items))
+	var failed atomic.Bool
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(workers, 1), len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if failed.Load() {
+					continue
+				}
+				if err := apply(i, items[i]); err != nil {
+					errs[i] = err
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	for i := range items {
+		if failed.Load() {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
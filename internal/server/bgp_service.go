@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/bgp"
+)
+
+// SetBGP registers the BGP controller that is synced after every commit on the local instance
+func (s *HAProxyManagerServer) SetBGP(controller *bgp.Controller) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bgp = controller
+}
+
+// BindAddresses returns the addresses of all binds of the local (default) instance
+func (s *HAProxyManagerServer) BindAddresses(ctx context.Context) ([]string, error) {
+	current, err := s.readState(ctx, s.client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	for _, frontend := range current.Frontends {
+		for _, bind := range frontend.Binds {
+			addresses = append(addresses, bind.Address)
+		}
+	}
+	return addresses, nil
+}
+
+// HealthyBindAddresses returns the addresses of the binds of the local (default) instance whose frontend can serve
+// traffic: its default backend has a server up, or it has no default backend to check. If the statistics cannot be
+// read, an error is returned, so that the current announcements are kept rather than withdrawn.
+func (s *HAProxyManagerServer) HealthyBindAddresses(ctx context.Context) ([]string, error) {
+	current, err := s.readState(ctx, s.client, "")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.client.GetStats(ctx)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	up := make(map[string]bool)
+	for _, entry := range stats {
+		if entry.Type == "backend" && strings.HasPrefix(entry.Stats.Status, "UP") {
+			up[entry.Name] = true
+		}
+	}
+
+	var addresses []string
+	for _, frontend := range current.Frontends {
+		if backend := frontend.Frontend.DefaultBackend; backend != "" && !up[backend] {
+			continue
+		}
+		for _, bind := range frontend.Binds {
+			addresses = append(addresses, bind.Address)
+		}
+	}
+	return addresses, nil
+}
+
+// triggerBGP requests a BGP sync if the client targets the local instance, whose VIPs are announced
+func (s *HAProxyManagerServer) triggerBGP(client DataplaneClient) {
+	s.mutex.RLock()
+	controller := s.bgp
+	s.mutex.RUnlock()
+
+	if controller != nil && client == s.client {
+		controller.Trigger()
+	}
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBindAddresses limits the binds CreateBind creates from an address list, so that a mistyped range does not
+// create thousands of binds
+const maxBindAddresses = 256
+
+// createBinds creates a bind named "<bind.name>-<address>" for every address of the address list of req, each with
+// its VIP assigned through Netplan like a single bind, all in the transaction of the request. It stops at the
+// first bind that cannot be created; the binds created before stay in the transaction, which should then be closed.
+func (s *HAProxyManagerServer) createBinds(ctx context.Context, req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
+	if req.Bind.Address != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bind address and addresses are mutually exclusive")
+	}
+	addresses, err := expandBindAddresses(req.Addresses)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid addresses: %v", err)
+	}
+
+	response := &pb.CreateBindResponse{}
+	for _, address := range addresses {
+		bind := proto.Clone(req.Bind).(*pb.Bind)
+		bind.Name = req.Bind.Name + "-" + address
+		bind.Address = address
+		created, err := s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{
+			TransactionId: req.TransactionId,
+			FrontendName:  req.FrontendName,
+			Bind:          bind,
+		})
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "failed to create bind %s: %s", bind.Name, status.Convert(err).Message())
+		}
+		response.Binds = append(response.Binds, created.Bind)
+	}
+	response.Bind = response.Binds[0]
+	return response, nil
+}
+
+// expandBindAddresses returns the addresses of an address list, whose entries are addresses, CIDRs or
+// "first-last" ranges, in order and without duplicates
+func expandBindAddresses(entries []string) ([]string, error) {
+	seen := make(map[netip.Addr]bool)
+	var addresses []string
+	for _, entry := range entries {
+		first, err := netip.ParseAddr(entry)
+		last := first
+		if err != nil {
+			if first, last, err = config.ParseAddressRange(entry); err != nil {
+				return nil, err
+			}
+		}
+		for address := first; ; address = address.Next() {
+			if !seen[address] {
+				if len(addresses) == maxBindAddresses {
+					return nil, fmt.Errorf("more than %d addresses", maxBindAddresses)
+				}
+				seen[address] = true
+				addresses = append(addresses, address.String())
+			}
+			if address == last {
+				break
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// createDualStackBinds creates an IPv4 and an IPv6 bind named "<bind.name>-ipv4" and "<bind.name>-ipv6" for the
+// addresses of req or those its hostname resolves to. If the second bind cannot be created, the first one is
+// deleted from the transaction again, VIP included, so that either both are created or none.
+func (s *HAProxyManagerServer) createDualStackBinds(ctx context.Context, req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
+	if req.Bind.Address != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bind address cannot be combined with dual_stack")
+	}
+	ipv4, ipv6, err := dualStackAddresses(ctx, req.Addresses, req.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.CreateBindResponse{}
+	for _, family := range []struct{ name, address string }{{"ipv4", ipv4.String()}, {"ipv6", ipv6.String()}} {
+		bind := proto.Clone(req.Bind).(*pb.Bind)
+		bind.Name = req.Bind.Name + "-" + family.name
+		bind.Address = family.address
+		created, err := s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{
+			TransactionId: req.TransactionId,
+			FrontendName:  req.FrontendName,
+			Bind:          bind,
+		})
+		if err != nil {
+			for _, done := range response.Binds {
+				if _, deleteErr := s.DeleteBindWithNetplan(ctx, &pb.DeleteBindRequest{TransactionId: req.TransactionId, FrontendName: req.FrontendName, Name: done.Name}); deleteErr != nil {
+					logger.FromContext(ctx).Warn("Failed to delete the bind of a dual-stack pair that could not be completed",
+						zap.String("bind_name", done.Name),
+						zap.String("transaction_id", req.TransactionId),
+						zap.Error(deleteErr))
+				}
+			}
+			return nil, status.Errorf(status.Code(err), "failed to create bind %s: %s", bind.Name, status.Convert(err).Message())
+		}
+		response.Binds = append(response.Binds, created.Bind)
+	}
+	response.Bind = response.Binds[0]
+	return response, nil
+}
+
+// lookupAddresses resolves a hostname to its addresses; tests replace it
+var lookupAddresses = net.DefaultResolver.LookupNetIP
+
+// dualStackAddresses returns the IPv4 and IPv6 address of a dual-stack bind, given as two addresses or as a
+// hostname resolving to one address of each family
+func dualStackAddresses(ctx context.Context, addresses []string, hostname string) (netip.Addr, netip.Addr, error) {
+	var candidates []netip.Addr
+	switch {
+	case hostname != "" && len(addresses) > 0:
+		return netip.Addr{}, netip.Addr{}, status.Errorf(codes.InvalidArgument, "addresses and hostname are mutually exclusive")
+	case hostname != "":
+		resolved, err := lookupAddresses(ctx, "ip", hostname)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, status.Errorf(codes.InvalidArgument, "failed to resolve %s: %v", hostname, err)
+		}
+		candidates = resolved
+	default:
+		for _, address := range addresses {
+			addr, err := netip.ParseAddr(address)
+			if err != nil {
+				return netip.Addr{}, netip.Addr{}, status.Errorf(codes.InvalidArgument, "invalid address %q: a dual-stack bind takes single addresses", address)
+			}
+			candidates = append(candidates, addr)
+		}
+	}
+
+	var ipv4, ipv6 []netip.Addr
+	for _, addr := range candidates {
+		if addr = addr.Unmap(); addr.Is4() {
+			ipv4 = append(ipv4, addr)
+		} else {
+			ipv6 = append(ipv6, addr)
+		}
+	}
+	if len(ipv4) != 1 || len(ipv6) != 1 {
+		return netip.Addr{}, netip.Addr{}, status.Errorf(codes.InvalidArgument,
+			"a dual-stack bind needs exactly one IPv4 and one IPv6 address, got %d and %d", len(ipv4), len(ipv6))
+	}
+	return ipv4[0], ipv6[0], nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkBindConflicts rejects a new bind with ALREADY_EXISTS if another bind of any frontend, as seen in the
+// transaction, listens on the same address and port, or if the ARP probe finds its VIP on another host
+func (s *HAProxyManagerServer) checkBindConflicts(ctx context.Context, client DataplaneClient, netplanMgr *netplan.Manager, transactionID, frontendName string, bind *pb.Bind) error {
+	if err := checkBoundAddress(ctx, client, transactionID, frontendName, bind); err != nil {
+		return err
+	}
+
+	if netplanMgr != nil && bind.Address != "" {
+		if err := netplanMgr.ProbeAddress(ctx, bind.Address); err != nil {
+			var inUse *netplan.AddressInUseError
+			if errors.As(err, &inUse) {
+				return status.Errorf(codes.AlreadyExists, "VIP of bind %s: %v", bind.Name, inUse)
+			}
+			if s.failOnNetplanError() {
+				return status.Errorf(codes.Internal, "failed to probe VIP of bind %s: %v", bind.Name, err)
+			}
+			logger.FromContext(ctx).Warn("Failed to probe bind address, creating the bind without the check",
+				zap.String("ip_address", bind.Address),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// checkBoundAddress rejects a new bind if another bind of any frontend listens on the same address and port
+func checkBoundAddress(ctx context.Context, client DataplaneClient, transactionID, frontendName string, bind *pb.Bind) error {
+	if bind.Port == 0 {
+		return nil // Sockets are not compared
+	}
+
+	address := bindListenAddress(bind.Address)
+	frontends, err := client.ListFrontends(ctx, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	for _, frontend := range frontends {
+		name := derefString(frontend.Name)
+		binds, err := client.ListBinds(ctx, name, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		for _, existing := range binds {
+			if name == frontendName && derefString(existing.Name) == bind.Name {
+				continue // Reported as a duplicate name by the Data Plane API
+			}
+			if existing.Port == nil || *existing.Port != int(bind.Port) || bindListenAddress(derefString(existing.Address)) != address {
+				continue
+			}
+			return status.Errorf(codes.AlreadyExists, "%s:%d is already bound by bind %s of frontend %s",
+				bind.Address, bind.Port, derefString(existing.Name), name)
+		}
+	}
+	return nil
+}
+
+// bindListenAddress normalizes a bind address for comparison, e.g. so that "*", "" and "0.0.0.0" all stand for
+// the IPv4 wildcard
+func bindListenAddress(address string) string {
+	if address == "" || address == "*" {
+		return "0.0.0.0"
+	}
+	if ip, err := netip.ParseAddr(address); err == nil {
+		return ip.Unmap().String()
+	}
+	return address
+}
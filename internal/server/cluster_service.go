@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	"github.com/bear-san/haproxy-configurator/internal/webhook"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetClusterStatus reports the outcome of the last replication to every cluster node
+func (s *HAProxyManagerServer) GetClusterStatus(_ context.Context, _ *pb.GetClusterStatusRequest) (*pb.GetClusterStatusResponse, error) {
+	replicas := s.currentConfig().Cluster.Replicas
+	response := &pb.GetClusterStatusResponse{Enabled: len(replicas) > 0, Consistent: true}
+
+	s.replicasMutex.Lock()
+	defer s.replicasMutex.Unlock()
+	for _, name := range replicas {
+		replica, ok := s.replicas[name]
+		if !ok {
+			// Not replicated since startup
+			replica = &pb.ReplicaStatus{Instance: name}
+		}
+		response.Replicas = append(response.Replicas, proto.Clone(replica).(*pb.ReplicaStatus))
+		response.Consistent = response.Consistent && replica.Synced
+	}
+	return response, nil
+}
+
+// SyncCluster replicates the current configuration of the default instance to every cluster node, e.g. to
+// catch up a node that was unreachable during earlier commits
+func (s *HAProxyManagerServer) SyncCluster(ctx context.Context, _ *pb.SyncClusterRequest) (*pb.SyncClusterResponse, error) {
+	replicas := s.replicate(ctx, s.client, "")
+	return &pb.SyncClusterResponse{Replicas: replicas, PartialFailure: partialFailure(replicas)}, nil
+}
+
+// replicate makes every cluster node match the configuration of the source client, one transaction per node.
+// Only the default instance is replicated; nil is returned for other instances or without replicas.
+// Nodes that fail are reported, and caught up by the next replication.
+func (s *HAProxyManagerServer) replicate(ctx context.Context, source DataplaneClient, transactionID string) []*pb.ReplicaStatus {
+	replicas := s.currentConfig().Cluster.Replicas
+	if source != s.client || len(replicas) == 0 {
+		return nil
+	}
+
+	// Concurrent replications would race for the version of each node
+	s.replicationMutex.Lock()
+	defer s.replicationMutex.Unlock()
+
+	desired, readErr := s.readState(ctx, source, "")
+	if readErr != nil {
+		readErr = fmt.Errorf("failed to read the configuration of the default instance: %w", readErr)
+	}
+
+	results := make([]*pb.ReplicaStatus, len(replicas))
+	var wg sync.WaitGroup
+	for i, name := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if readErr != nil {
+				results[i] = s.recordReplica(name, 0, 0, readErr)
+				return
+			}
+			version, changes, err := s.syncReplica(ctx, name, desired)
+			results[i] = s.recordReplica(name, version, changes, err)
+		}()
+	}
+	wg.Wait()
+
+	if partialFailure(results) {
+		s.webhooks.Notify(webhook.Event{
+			Type:          webhook.EventReplicationFailed,
+			TransactionID: transactionID,
+			Message:       "Replication to some cluster nodes failed; they are caught up by the next replication",
+		})
+	}
+	return results
+}
+
+// syncReplica makes a node match the desired state in one transaction. It returns the configuration version
+// of the node and the number of changes applied.
+func (s *HAProxyManagerServer) syncReplica(ctx context.Context, name string, desired *pb.State) (int32, int, error) {
+	client, ok := s.instances[name]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown HAProxy instance %q", name)
+	}
+
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the configuration version: %w", err)
+	}
+	current, err := s.readState(ctx, client, "")
+	if err != nil {
+		return derefInt(version), 0, fmt.Errorf("failed to read the configuration: %w", err)
+	}
+	changes := state.Diff(current, desired, true)
+	if len(changes) == 0 {
+		return derefInt(version), 0, nil
+	}
+
+	transaction, err := client.CreateTransaction(ctx, int(derefInt(version)))
+	if err != nil {
+		return derefInt(version), 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	transactionID := derefString(transaction.Id)
+
+	for _, change := range changes {
+		if err := applyReplicaChange(ctx, client, transactionID, change); err != nil {
+			if _, closeErr := client.CloseTransaction(ctx, transactionID); closeErr != nil {
+				logger.FromContext(ctx).Warn("Failed to close replication transaction",
+					zap.String("instance", name),
+					zap.String("transaction_id", transactionID),
+					zap.Error(closeErr))
+			}
+			return derefInt(version), 0, fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Resource, change.Name, err)
+		}
+	}
+
+	if _, err := client.CommitTransaction(ctx, transactionID); err != nil {
+		return derefInt(version), 0, fmt.Errorf("failed to commit transaction %s: %w", transactionID, err)
+	}
+	// A commit increments the version by one
+	return derefInt(version) + 1, len(changes), nil
+}
+
+// applyReplicaChange performs a planned change directly on a node. Unlike applyStateChange it bypasses the RPC
+// handlers, since replicas have neither a journal nor Netplan integration of their own.
+func applyReplicaChange(ctx context.Context, client DataplaneClient, transactionID string, change state.Change) error {
+	var err error
+	switch change.Resource + "/" + change.Action {
+	case state.ResourceBackend + "/" + state.ActionCreate:
+		_, err = client.AddBackend(ctx, *convertBackendFromProto(change.Object.(*pb.Backend)), transactionID)
+	case state.ResourceBackend + "/" + state.ActionUpdate:
+		_, err = client.ReplaceBackend(ctx, change.Name, *convertBackendFromProto(change.Object.(*pb.Backend)), transactionID)
+	case state.ResourceBackend + "/" + state.ActionDelete:
+		err = client.DeleteBackend(ctx, change.Name, transactionID)
+	case state.ResourceFrontend + "/" + state.ActionCreate:
+		_, err = client.AddFrontend(ctx, *convertFrontendFromProto(change.Object.(*pb.Frontend)), transactionID)
+	case state.ResourceFrontend + "/" + state.ActionUpdate:
+		_, err = client.ReplaceFrontend(ctx, change.Name, *convertFrontendFromProto(change.Object.(*pb.Frontend)), transactionID)
+	case state.ResourceFrontend + "/" + state.ActionDelete:
+		err = client.DeleteFrontend(ctx, change.Name, transactionID)
+	case state.ResourceBind + "/" + state.ActionCreate:
+		_, err = client.AddBind(ctx, change.Parent, transactionID, *convertBindFromProto(change.Object.(*pb.Bind)))
+	case state.ResourceBind + "/" + state.ActionUpdate:
+		_, err = client.ReplaceBind(ctx, change.Parent, transactionID, *convertBindFromProto(change.Object.(*pb.Bind)))
+	case state.ResourceBind + "/" + state.ActionDelete:
+		err = client.DeleteBind(ctx, change.Name, change.Parent, transactionID)
+	case state.ResourceServer + "/" + state.ActionCreate:
+		_, err = client.AddServer(ctx, change.Parent, transactionID, *convertServerFromProto(change.Object.(*pb.Server)))
+	case state.ResourceServer + "/" + state.ActionUpdate:
+		_, err = client.ReplaceServer(ctx, change.Parent, transactionID, *convertServerFromProto(change.Object.(*pb.Server)))
+	case state.ResourceServer + "/" + state.ActionDelete:
+		err = client.DeleteServer(ctx, change.Name, change.Parent, transactionID)
+	default:
+		return fmt.Errorf("unsupported change %s %s", change.Action, change.Resource)
+	}
+	return err
+}
+
+// recordReplica stores the outcome of a replication to a node and returns its status
+func (s *HAProxyManagerServer) recordReplica(name string, version int32, changes int, err error) *pb.ReplicaStatus {
+	now := timestamppb.New(time.Now())
+
+	s.replicasMutex.Lock()
+	defer s.replicasMutex.Unlock()
+
+	replica, ok := s.replicas[name]
+	if !ok {
+		replica = &pb.ReplicaStatus{Instance: name}
+		s.replicas[name] = replica
+	}
+	replica.LastAttemptTime = now
+	replica.Changes = int32(changes)
+	if version != 0 {
+		replica.Version = version
+	}
+
+	if err != nil {
+		replica.Synced = false
+		replica.Error = err.Error()
+		metrics.ClusterReplicaSynced.WithLabelValues(name).Set(0)
+		logger.GetLogger().Error("Failed to replicate configuration to cluster node",
+			zap.String("instance", name),
+			zap.Error(err))
+	} else {
+		replica.Synced = true
+		replica.Error = ""
+		replica.LastSyncTime = now
+		metrics.ClusterReplicaSynced.WithLabelValues(name).Set(1)
+		logger.GetLogger().Info("Replicated configuration to cluster node",
+			zap.String("instance", name),
+			zap.Int32("version", replica.Version),
+			zap.Int("changes", changes))
+	}
+	return proto.Clone(replica).(*pb.ReplicaStatus)
+}
+
+// partialFailure reports whether any node failed to sync
+func partialFailure(replicas []*pb.ReplicaStatus) bool {
+	for _, replica := range replicas {
+		if !replica.Synced {
+			return true
+		}
+	}
+	return false
+}
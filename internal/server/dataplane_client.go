@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/standalone"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// DataplaneClient is the Data Plane API of one HAProxy instance as used by the server. It is implemented by
+// dataplane.Client, by standalone.Client for instances without the Data Plane API and, for tests, by the
+// in-memory fakedataplane.Client.
+//
+// Errors follow dataplane.Client: API errors are the error types of haproxy-go, e.g. *v3.NotFoundError, and
+// unavailability is reported with dataplane.ErrCircuitOpen or an error for which dataplane.IsTransient holds.
+type DataplaneClient interface {
+	// Instance names the HAProxy instance
+	Instance() string
+	// ActiveURL is the URL of the Data Plane API requests are sent to
+	ActiveURL() string
+
+	GetVersion(ctx context.Context) (*int, error)
+	GetInfo(ctx context.Context) (*dataplane.Info, error)
+
+	CreateTransaction(ctx context.Context, version int) (*v3.Transaction, error)
+	GetTransaction(ctx context.Context, id string) (*v3.Transaction, error)
+	ListTransactions(ctx context.Context) ([]v3.Transaction, error)
+	CommitTransaction(ctx context.Context, id string) (*v3.Transaction, error)
+	CloseTransaction(ctx context.Context, id string) (*string, error)
+
+	GetGlobal(ctx context.Context, transactionId string) (*dataplane.Global, error)
+	ReplaceGlobal(ctx context.Context, global dataplane.Global, transactionId string) (*dataplane.Global, error)
+
+	AddBackend(ctx context.Context, backend dataplane.Backend, transactionId string) (*dataplane.Backend, error)
+	GetBackend(ctx context.Context, name string, transactionId string) (*dataplane.Backend, error)
+	ListBackends(ctx context.Context, transactionId string) ([]dataplane.Backend, error)
+	ReplaceBackend(ctx context.Context, name string, backend dataplane.Backend, transactionId string) (*dataplane.Backend, error)
+	DeleteBackend(ctx context.Context, name string, transactionId string) error
+	EachBackend(ctx context.Context, transactionId string, fn func(dataplane.Backend) error) error
+
+	AddFrontend(ctx context.Context, frontend dataplane.Frontend, transactionId string) (*dataplane.Frontend, error)
+	GetFrontend(ctx context.Context, name string, transactionId string) (*dataplane.Frontend, error)
+	ListFrontends(ctx context.Context, transactionId string) ([]dataplane.Frontend, error)
+	ReplaceFrontend(ctx context.Context, name string, frontend dataplane.Frontend, transactionId string) (*dataplane.Frontend, error)
+	DeleteFrontend(ctx context.Context, name string, transactionId string) error
+
+	AddBind(ctx context.Context, frontend string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error)
+	GetBind(ctx context.Context, name string, frontend string, transactionId string) (*dataplane.Bind, error)
+	ListBinds(ctx context.Context, frontend string, transactionId string) ([]dataplane.Bind, error)
+	ReplaceBind(ctx context.Context, frontend string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error)
+	DeleteBind(ctx context.Context, name string, frontend string, transactionId string) error
+
+	AddServer(ctx context.Context, backend string, transactionId string, server dataplane.Server) (*dataplane.Server, error)
+	GetServer(ctx context.Context, name string, backend string, transactionId string) (*dataplane.Server, error)
+	ListServers(ctx context.Context, backend string, transactionId string) ([]dataplane.Server, error)
+	ReplaceServer(ctx context.Context, backend string, transactionId string, server dataplane.Server) (*dataplane.Server, error)
+	DeleteServer(ctx context.Context, name string, backend string, transactionId string) error
+	EachServer(ctx context.Context, backend string, transactionId string, fn func(dataplane.Server) error) error
+
+	ListACLs(ctx context.Context, frontend string, transactionId string) ([]dataplane.ACL, error)
+	AddACL(ctx context.Context, frontend string, transactionId string, index int, acl dataplane.ACL) (*dataplane.ACL, error)
+	DeleteACL(ctx context.Context, frontend string, transactionId string, index int) error
+	ListBackendSwitchingRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.BackendSwitchingRule, error)
+	AddBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error)
+	ReplaceBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error)
+	DeleteBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int) error
+	ListHTTPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.HTTPRequestRule, error)
+	AddHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.HTTPRequestRule) (*dataplane.HTTPRequestRule, error)
+	DeleteHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error
+	ListTCPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.TCPRequestRule, error)
+	AddTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.TCPRequestRule) (*dataplane.TCPRequestRule, error)
+	DeleteTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error
+
+	AddRing(ctx context.Context, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error)
+	GetRing(ctx context.Context, name string, transactionId string) (*dataplane.Ring, error)
+	ListRings(ctx context.Context, transactionId string) ([]dataplane.Ring, error)
+	ReplaceRing(ctx context.Context, name string, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error)
+	DeleteRing(ctx context.Context, name string, transactionId string) error
+	// Log targets belong to a parent of type "frontend" or "backend"
+	ListLogTargets(ctx context.Context, parentType, parent string, transactionId string) ([]dataplane.LogTarget, error)
+	AddLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int, target dataplane.LogTarget) (*dataplane.LogTarget, error)
+	DeleteLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int) error
+	ListHTTPChecks(ctx context.Context, backend string, transactionId string) ([]dataplane.HTTPCheck, error)
+	AddHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error)
+	ReplaceHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error)
+	DeleteHTTPCheck(ctx context.Context, backend string, transactionId string, index int) error
+
+	AddProgram(ctx context.Context, program dataplane.Program, transactionId string) (*dataplane.Program, error)
+	GetProgram(ctx context.Context, name string, transactionId string) (*dataplane.Program, error)
+	ListPrograms(ctx context.Context, transactionId string) ([]dataplane.Program, error)
+	ReplaceProgram(ctx context.Context, name string, program dataplane.Program, transactionId string) (*dataplane.Program, error)
+	DeleteProgram(ctx context.Context, name string, transactionId string) error
+
+	AddCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error)
+	ReplaceCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error)
+	GetCertificate(ctx context.Context, name string) (*dataplane.Certificate, error)
+
+	AddStorageFile(ctx context.Context, name, content string) (*dataplane.StorageFile, error)
+	GetStorageFile(ctx context.Context, name string) (string, error)
+	ListStorageFiles(ctx context.Context) ([]dataplane.StorageFile, error)
+	DeleteStorageFile(ctx context.Context, name string) error
+
+	GetStats(ctx context.Context) ([]dataplane.ProxyStats, error)
+	SetServerAdminState(ctx context.Context, backend, name, state string) (*dataplane.RuntimeServer, error)
+	ListRuntimeServers(ctx context.Context, backend string) ([]dataplane.RuntimeServer, error)
+	// WatchRing reads the events of a ring of the running process, waiting for further ones with follow
+	WatchRing(ctx context.Context, name string, follow bool, fn func(event string) error) error
+}
+
+var (
+	_ DataplaneClient = (*dataplane.Client)(nil)
+	_ DataplaneClient = (*standalone.Client)(nil)
+)
+
+// liveClient returns the client as a dataplane.Client, whose endpoint, credentials and request policy can be
+// changed at runtime. Other implementations are left as they are on reload.
+func liveClient(client DataplaneClient) (*dataplane.Client, bool) {
+	live, ok := client.(*dataplane.Client)
+	return live, ok
+}
@@ -0,0 +1,363 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
+	"github.com/bear-san/haproxy-configurator/internal/webhook"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SwapBackends exchanges two backends in the traffic of a frontend, e.g. to release a new version deployed to the
+// idle one of a blue/green pair. The default_backend and every use_backend rule pointing to one of them are
+// changed to the other within one transaction, so the switch takes effect at once when it is committed.
+func (s *HAProxyManagerServer) SwapBackends(ctx context.Context, req *pb.SwapBackendsRequest) (*pb.SwapBackendsResponse, error) {
+	client := s.dataplane(ctx)
+
+	switch {
+	case req.FrontendName == "":
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	case req.Blue == "" || req.Green == "":
+		return nil, status.Errorf(codes.InvalidArgument, "blue and green backends are required")
+	case req.Blue == req.Green:
+		return nil, status.Errorf(codes.InvalidArgument, "blue and green must be different backends")
+	case req.ExpectedActive != "" && req.ExpectedActive != req.Blue && req.ExpectedActive != req.Green:
+		return nil, status.Errorf(codes.InvalidArgument, "expected_active must be the blue or green backend")
+	}
+	swap := func(name string) string {
+		switch name {
+		case req.Blue:
+			return req.Green
+		case req.Green:
+			return req.Blue
+		}
+		return name
+	}
+
+	response := &pb.SwapBackendsResponse{}
+	transaction, err := s.inTransaction(ctx, req.TransactionId, func(transactionID string) error {
+		for _, name := range []string{req.Blue, req.Green} {
+			if _, err := client.GetBackend(ctx, name, transactionID); err != nil {
+				return handleHAProxyError(err)
+			}
+		}
+		frontend, err := client.GetFrontend(ctx, req.FrontendName, transactionID)
+		current := convertFrontendToProto(frontend)
+		if err := checkVersion(resourceFrontend, req.ExpectedVersion, current, err); err != nil {
+			return err
+		}
+		rules, err := client.ListBackendSwitchingRules(ctx, req.FrontendName, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+
+		// The default backend decides which of the two is active; otherwise the first rule does
+		active := ""
+		if swap(current.DefaultBackend) != current.DefaultBackend {
+			active = current.DefaultBackend
+		}
+		for _, rule := range rules {
+			if active == "" && swap(rule.Name) != rule.Name {
+				active = rule.Name
+			}
+		}
+		if active == "" {
+			return status.Errorf(codes.FailedPrecondition, "frontend %s sends to neither %s nor %s", req.FrontendName, req.Blue, req.Green)
+		}
+		if req.ExpectedActive != "" && active != req.ExpectedActive {
+			return status.Errorf(codes.FailedPrecondition, "frontend %s sends to %s, expected %s", req.FrontendName, active, req.ExpectedActive)
+		}
+
+		// Only the default backend changes; the other settings of the frontend are kept as they are
+		response.Frontend = current
+		if swapped := swap(current.DefaultBackend); swapped != current.DefaultBackend {
+			changed := *frontend
+			changed.DefaultBackend = &swapped
+			updated, err := client.ReplaceFrontend(ctx, req.FrontendName, changed, transactionID)
+			if err != nil {
+				return stepError(err, "swap the default backend")
+			}
+			s.recordChange(resourceFrontend, actionUpdate, "", req.FrontendName, transactionID, frontend, updated)
+			response.Frontend = convertFrontendToProto(updated)
+		}
+		if err := attachMetadata(s, transactionID, []*pb.Frontend{response.Frontend}, func(frontend *pb.Frontend) metadata.Key {
+			return metadataKey(client, metadata.KindFrontend, "", frontend.Name)
+		}); err != nil {
+			return err
+		}
+		for i, rule := range rules {
+			if swapped := swap(rule.Name); swapped != rule.Name {
+				rule.Name = swapped
+				if _, err := client.ReplaceBackendSwitchingRule(ctx, req.FrontendName, transactionID, i, rule); err != nil {
+					return stepError(err, "swap use_backend rule "+rule.CondTest)
+				}
+				response.SwappedRules++
+			}
+		}
+		response.ActiveBackend = swap(active)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Transaction = transaction
+
+	logger.FromContext(ctx).Info("Swapped backends",
+		zap.String("frontend", req.FrontendName),
+		zap.String("active_backend", response.ActiveBackend),
+		zap.Int32("swapped_rules", response.SwappedRules))
+
+	return response, nil
+}
+
+// Canary traffic shifting
+const (
+	defaultStepInterval = time.Minute
+	maxServerWeight     = 256
+)
+
+// canaryTraffic are the counters of the canary servers of a backend
+type canaryTraffic struct {
+	requests, errors int64
+}
+
+// ShiftTraffic moves the load of a backend to its canary servers in steps, e.g. 5%, 25% and 100%. Each step
+// is committed in a transaction of its own and observed for the step interval; if the canary servers fail too
+// many requests, the weights the servers had before are restored. The call returns once the last step was
+// observed, so clients should allow for the steps times the interval. If the call is canceled, the weights of
+// the last applied step remain.
+func (s *HAProxyManagerServer) ShiftTraffic(ctx context.Context, req *pb.ShiftTrafficRequest) (*pb.ShiftTrafficResponse, error) {
+	client := s.dataplane(ctx)
+
+	if err := validateShiftTrafficRequest(req); err != nil {
+		return nil, err
+	}
+	interval := defaultStepInterval
+	if req.StepInterval != nil {
+		interval = req.StepInterval.AsDuration()
+	}
+
+	servers, err := client.ListServers(ctx, req.BackendName, "")
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	canaries := make(map[string]bool, len(req.CanaryServers))
+	for _, name := range req.CanaryServers {
+		canaries[name] = true
+	}
+	found := 0
+	for _, server := range servers {
+		if canaries[derefString(server.Name)] {
+			found++
+		}
+	}
+	if found != len(canaries) {
+		return nil, status.Errorf(codes.NotFound, "not all canary servers exist in backend %s", req.BackendName)
+	}
+	if len(servers) == found {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one server of backend %s must remain in the baseline", req.BackendName)
+	}
+	original := make(map[string]*int, len(servers))
+	for _, server := range servers {
+		original[derefString(server.Name)] = server.Weight
+	}
+
+	response := &pb.ShiftTrafficResponse{}
+	monitored := req.MaxErrorRate > 0
+	for i, percent := range req.Steps {
+		canaryWeight, baselineWeight := canaryWeights(int(percent), found, len(servers)-found)
+		err := s.setWeights(ctx, client, req.BackendName, servers, func(name string) *int {
+			if canaries[name] {
+				return &canaryWeight
+			}
+			return &baselineWeight
+		})
+		if err != nil {
+			return nil, err
+		}
+		step := &pb.ShiftTrafficStep{Percent: percent, CanaryWeight: int32(canaryWeight), BaselineWeight: int32(baselineWeight)}
+		response.Steps = append(response.Steps, step)
+		logger.FromContext(ctx).Info("Shifted traffic to canary servers",
+			zap.String("backend_name", req.BackendName),
+			zap.Int32("percent", percent))
+
+		if !monitored {
+			if i < len(req.Steps)-1 {
+				if err := sleep(ctx, interval); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		before, err := readCanaryTraffic(ctx, client, req.BackendName, canaries)
+		if err == nil {
+			if err := sleep(ctx, interval); err != nil {
+				return nil, err
+			}
+			var after canaryTraffic
+			after, err = readCanaryTraffic(ctx, client, req.BackendName, canaries)
+			step.Requests, step.Errors = after.requests-before.requests, after.errors-before.errors
+		}
+
+		reason := ""
+		switch {
+		case err != nil:
+			reason = fmt.Sprintf("statistics of the canary servers are unavailable: %v", err)
+		case step.Requests > 0 && step.Requests >= req.MinRequests && float64(step.Errors)/float64(step.Requests) > req.MaxErrorRate:
+			reason = fmt.Sprintf("canary servers failed %d of %d requests at %d%%, more than the maximum error rate of %g",
+				step.Errors, step.Requests, percent, req.MaxErrorRate)
+		}
+		if reason != "" {
+			logger.FromContext(ctx).Warn("Rolling back traffic shift",
+				zap.String("backend_name", req.BackendName),
+				zap.String("reason", reason))
+			if err := s.setWeights(ctx, client, req.BackendName, servers, func(name string) *int { return original[name] }); err != nil {
+				return nil, stepError(err, "roll back the weights of backend "+req.BackendName)
+			}
+			response.RolledBack = true
+			response.RollbackReason = reason
+			s.webhooks.Notify(webhook.Event{
+				Type:    webhook.EventRollback,
+				Message: fmt.Sprintf("Traffic shift of backend %s rolled back at %d%%", req.BackendName, percent),
+				Error:   reason,
+			})
+			return response, nil
+		}
+	}
+	return response, nil
+}
+
+// validateShiftTrafficRequest checks the backend, canary servers, steps and rollback settings
+func validateShiftTrafficRequest(req *pb.ShiftTrafficRequest) error {
+	switch {
+	case req.BackendName == "":
+		return status.Errorf(codes.InvalidArgument, "backend name is required")
+	case len(req.CanaryServers) == 0:
+		return status.Errorf(codes.InvalidArgument, "at least one canary server is required")
+	case len(req.Steps) == 0:
+		return status.Errorf(codes.InvalidArgument, "at least one step is required")
+	case req.StepInterval != nil && (req.StepInterval.CheckValid() != nil || req.StepInterval.AsDuration() < 0):
+		return status.Errorf(codes.InvalidArgument, "step interval must be a positive duration")
+	case req.MaxErrorRate < 0 || req.MaxErrorRate > 1:
+		return status.Errorf(codes.InvalidArgument, "max_error_rate must be between 0 and 1")
+	case req.MinRequests < 0:
+		return status.Errorf(codes.InvalidArgument, "min_requests must not be negative")
+	}
+	previous := int32(0)
+	for _, percent := range req.Steps {
+		if percent <= previous || percent > 100 {
+			return status.Errorf(codes.InvalidArgument, "steps must be ascending percentages between 1 and 100")
+		}
+		previous = percent
+	}
+	return nil
+}
+
+// canaryWeights returns the weight of each canary and baseline server giving the canary servers the given share
+// of the load. A share that rounds to zero still gets the smallest weight, so a small first step is not skipped.
+func canaryWeights(percent, canaries, baselines int) (canary, baseline int) {
+	canary = int(math.Round(float64(maxServerWeight*percent) / 100 / float64(canaries)))
+	baseline = int(math.Round(float64(maxServerWeight*(100-percent)) / 100 / float64(baselines)))
+	if canary == 0 && percent > 0 {
+		canary = 1
+	}
+	if baseline == 0 && percent < 100 {
+		baseline = 1
+	}
+	return canary, baseline
+}
+
+// setWeights sets the weights of the servers of a backend in a transaction of its own. A nil weight leaves the
+// weight unset. Only the weight changes; health checks and the other settings are kept as they are.
+func (s *HAProxyManagerServer) setWeights(ctx context.Context, client DataplaneClient, backend string, servers []dataplane.Server, weight func(name string) *int) error {
+	_, err := s.inTransaction(ctx, "", func(transactionID string) error {
+		for _, listed := range servers {
+			name := derefString(listed.Name)
+			previous, err := client.GetServer(ctx, name, backend, transactionID)
+			if err != nil {
+				return stepError(err, "read server "+name)
+			}
+			server := *previous
+			server.Weight = weight(name)
+			updated, err := client.ReplaceServer(ctx, backend, transactionID, server)
+			if err != nil {
+				return stepError(err, "set the weight of server "+name)
+			}
+			s.recordChange(resourceServer, actionUpdate, backend, name, transactionID, previous, updated)
+		}
+		return nil
+	})
+	return err
+}
+
+// readCanaryTraffic sums the requests and failures of the canary servers of a backend. Requests are HTTP
+// requests, or sessions on TCP backends; failures are 5xx responses, failed connections and failed responses.
+func readCanaryTraffic(ctx context.Context, client DataplaneClient, backend string, canaries map[string]bool) (canaryTraffic, error) {
+	stats, err := client.GetStats(ctx)
+	if err != nil {
+		return canaryTraffic{}, err
+	}
+	var traffic canaryTraffic
+	for _, entry := range stats {
+		if entry.Type != "server" || entry.BackendName != backend || !canaries[entry.Name] {
+			continue
+		}
+		requests := entry.Stats.ReqTot
+		if requests == 0 {
+			requests = entry.Stats.Stot
+		}
+		traffic.requests += requests
+		traffic.errors += entry.Stats.Hrsp5xx + entry.Stats.Econ + entry.Stats.Eresp
+	}
+	return traffic, nil
+}
+
+// sleep waits for the given duration unless the call is canceled first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// inTransaction runs fn within the given transaction. Without one, fn runs within a transaction of its own that
+// is committed afterwards, or closed if fn fails; the committed transaction is returned.
+func (s *HAProxyManagerServer) inTransaction(ctx context.Context, transactionID string, fn func(transactionID string) error) (*pb.Transaction, error) {
+	if transactionID != "" {
+		return nil, fn(transactionID)
+	}
+
+	created, err := s.CreateTransaction(ctx, &pb.CreateTransactionRequest{})
+	if err != nil {
+		return nil, err
+	}
+	transactionID = created.Transaction.Id
+
+	if err := fn(transactionID); err != nil {
+		if _, closeErr := s.CloseTransaction(ctx, &pb.CloseTransactionRequest{TransactionId: transactionID}); closeErr != nil {
+			logger.FromContext(ctx).Warn("Failed to close transaction after a failed change",
+				zap.String("transaction_id", transactionID),
+				zap.Error(closeErr))
+		}
+		return nil, err
+	}
+
+	committed, err := s.CommitTransactionWithNetplan(ctx, &pb.CommitTransactionRequest{TransactionId: transactionID})
+	if err != nil {
+		return nil, err
+	}
+	return committed.Transaction, nil
+}
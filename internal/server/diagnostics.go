@@ -0,0 +1,39 @@
+package server
+
+// DebugState returns a snapshot of the server state for the debug dump endpoint
+func (s *HAProxyManagerServer) DebugState() map[string]interface{} {
+	state := make(map[string]interface{})
+
+	circuitStates := make(map[string]string)
+	for name, client := range s.instances {
+		if live, ok := liveClient(client); ok {
+			circuitStates[name] = live.Breaker().State().String()
+		}
+	}
+	state["dataplane_circuit_state"] = circuitStates
+	state["journal_enabled"] = s.journal != nil
+	state["dry_run"] = s.currentConfig().DryRun
+	state["netplan"] = s.netplanSummary()
+	if election := s.leaderElection(); election != nil {
+		state["leader"] = election.IsLeader()
+		state["leader_identity"] = election.Leader()
+	}
+
+	s.mutex.RLock()
+	controller := s.bgp
+	s.mutex.RUnlock()
+	if controller != nil {
+		state["bgp_announced"] = controller.Announced()
+	}
+
+	if netplanMgr := s.netplan(); netplanMgr != nil {
+		transactions, err := netplanMgr.ListTransactions()
+		if err != nil {
+			state["netplan_transactions_error"] = err.Error()
+		} else {
+			state["netplan_transactions"] = transactions
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/discovery"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetDiscovery registers the discovery manager reported by GetDiscoveryStatus
+func (s *HAProxyManagerServer) SetDiscovery(manager *discovery.Manager) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.discovery = manager
+}
+
+// SyncServers makes the servers of a backend match the discovered ones in a transaction of its own, then puts
+// the unhealthy servers into maintenance in the running process and makes the healthy ones ready again. Like
+// ApplyState it is the entry point for in-process controllers, so standbys and change freezes are checked here.
+func (s *HAProxyManagerServer) SyncServers(ctx context.Context, instance, backend string, desired []*pb.Server, unhealthy []string) ([]*pb.StateChange, error) {
+	if err := s.requireLeader(); err != nil {
+		return nil, err
+	}
+	if err := s.requireUnfrozen(); err != nil {
+		return nil, err
+	}
+
+	client, ok := s.instances[instance]
+	if !ok {
+		client = s.client
+	}
+	ctx = context.WithValue(ctx, instanceContextKey{}, client)
+
+	servers, err := client.ListServers(ctx, backend, "")
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	current := make(map[string]*pb.Server, len(servers))
+	for i := range servers {
+		server := convertServerToProto(&servers[i])
+		current[server.Name] = server
+	}
+
+	var changes []state.Change
+	wanted := make(map[string]bool, len(desired))
+	for _, server := range desired {
+		wanted[server.Name] = true
+		existing, ok := current[server.Name]
+		switch {
+		case !ok:
+			changes = append(changes, state.Change{Resource: state.ResourceServer, Action: state.ActionCreate, Parent: backend, Name: server.Name, Object: server})
+		case !state.SameResource(existing, server):
+			changes = append(changes, state.Change{Resource: state.ResourceServer, Action: state.ActionUpdate, Parent: backend, Name: server.Name, Object: server})
+		}
+	}
+	for _, server := range servers {
+		if name := derefString(server.Name); !wanted[name] {
+			changes = append(changes, state.Change{Resource: state.ResourceServer, Action: state.ActionDelete, Parent: backend, Name: name})
+		}
+	}
+
+	if len(changes) > 0 {
+		_, err := s.inTransaction(ctx, "", func(transactionID string) error {
+			// Every change concerns another server, so they are applied concurrently
+			return applyConcurrently(s.applyConcurrency(ctx), changes, func(_ int, change state.Change) error {
+				var err error
+				switch change.Action {
+				case state.ActionCreate:
+					_, err = s.CreateServer(ctx, &pb.CreateServerRequest{TransactionId: transactionID, BackendName: backend, Server: change.Object.(*pb.Server)})
+				case state.ActionUpdate:
+					_, err = s.UpdateServer(ctx, &pb.UpdateServerRequest{TransactionId: transactionID, BackendName: backend, Name: change.Name, Server: change.Object.(*pb.Server)})
+				case state.ActionDelete:
+					_, err = s.DeleteServer(ctx, &pb.DeleteServerRequest{TransactionId: transactionID, BackendName: backend, Name: change.Name})
+				}
+				if err != nil {
+					return stepError(err, change.Action+" server "+change.Name)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Runtime states are lost on reloads, so they are checked on every sync
+	down := make(map[string]bool, len(unhealthy))
+	for _, name := range unhealthy {
+		down[name] = true
+	}
+	runtime, err := client.ListRuntimeServers(ctx, backend)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, server := range runtime {
+		if !wanted[server.Name] {
+			continue
+		}
+		// Healthy servers drained by an operator are left alone
+		target := dataplane.AdminStateMaint
+		if !down[server.Name] {
+			if server.AdminState != dataplane.AdminStateMaint {
+				continue
+			}
+			target = dataplane.AdminStateReady
+		}
+		if server.AdminState == target {
+			continue
+		}
+		if _, err := client.SetServerAdminState(ctx, backend, server.Name, target); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	}
+
+	result := make([]*pb.StateChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, change.Proto())
+	}
+	return result, nil
+}
+
+// GetDiscoveryStatus reports the backends synced from discovery sources and the outcome of their last sync
+func (s *HAProxyManagerServer) GetDiscoveryStatus(_ context.Context, _ *pb.GetDiscoveryStatusRequest) (*pb.GetDiscoveryStatusResponse, error) {
+	s.mutex.RLock()
+	manager := s.discovery
+	s.mutex.RUnlock()
+
+	if manager == nil {
+		return &pb.GetDiscoveryStatusResponse{Enabled: false}, nil
+	}
+
+	response := &pb.GetDiscoveryStatusResponse{Enabled: true}
+	for _, status := range manager.Status() {
+		backend := &pb.DiscoveredBackend{
+			BackendName:    status.Backend,
+			Source:         status.Source,
+			Servers:        int32(status.Servers),
+			HealthyServers: int32(status.HealthyServers),
+			LastError:      status.LastError,
+			LastChanges:    status.LastChanges,
+		}
+		if !status.LastSync.IsZero() {
+			backend.LastSyncTime = timestamppb.New(status.LastSync)
+		}
+		response.Backends = append(response.Backends, backend)
+	}
+	return response, nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/drift"
+	"github.com/bear-san/haproxy-configurator/internal/webhook"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetDrift registers the drift detector, which is held off while committing and reported by GetDriftStatus
+func (s *HAProxyManagerServer) SetDrift(detector *drift.Detector) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.drift = detector
+}
+
+// driftDetector returns the drift detector, or nil if drift detection is disabled
+func (s *HAProxyManagerServer) driftDetector() *drift.Detector {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.drift
+}
+
+// driftFor returns the drift detector if it compares the instance of the client
+func (s *HAProxyManagerServer) driftFor(client DataplaneClient) *drift.Detector {
+	detector := s.driftDetector()
+	if detector == nil || detector.Instance() != client.Instance() {
+		return nil
+	}
+	return detector
+}
+
+// LiveState reads the live configuration of an HAProxy instance for in-process controllers
+func (s *HAProxyManagerServer) LiveState(ctx context.Context, instance string) (*pb.State, error) {
+	client, ok := s.instances[instance]
+	if !ok {
+		client = s.client
+	}
+	return s.readState(ctx, client, "")
+}
+
+// NotifyDrift sends the drift_detected webhook event
+func (s *HAProxyManagerServer) NotifyDrift(instance string, changes []*pb.StateChange) {
+	s.webhooks.Notify(webhook.Event{
+		Type:    webhook.EventDriftDetected,
+		Message: fmt.Sprintf("HAProxy instance %s differs from the desired state in %d resources", instance, len(changes)),
+	})
+}
+
+// GetDriftStatus reports the outcome of the last comparison of the live configuration with the desired state
+func (s *HAProxyManagerServer) GetDriftStatus(_ context.Context, _ *pb.GetDriftStatusRequest) (*pb.GetDriftStatusResponse, error) {
+	detector := s.driftDetector()
+	if detector == nil {
+		return &pb.GetDriftStatusResponse{Enabled: false}, nil
+	}
+	return convertDriftStatusToProto(detector.Status()), nil
+}
+
+// CheckDrift compares the live configuration with the desired state now, remediating drift if configured
+func (s *HAProxyManagerServer) CheckDrift(ctx context.Context, _ *pb.CheckDriftRequest) (*pb.CheckDriftResponse, error) {
+	detector := s.driftDetector()
+	if detector == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "drift detection is not configured")
+	}
+	detector.Check(ctx)
+	return &pb.CheckDriftResponse{Status: convertDriftStatusToProto(detector.Status())}, nil
+}
+
+// convertDriftStatusToProto converts drift.Status to pb.GetDriftStatusResponse
+func convertDriftStatusToProto(status drift.Status) *pb.GetDriftStatusResponse {
+	response := &pb.GetDriftStatusResponse{
+		Enabled:      true,
+		Instance:     status.Instance,
+		DesiredState: status.DesiredState,
+		Record:       status.Record,
+		Remediate:    status.Remediate,
+		Drifted:      len(status.Changes) > 0,
+		Changes:      status.Changes,
+		LastError:    status.LastError,
+	}
+	if !status.LastCheck.IsZero() {
+		response.LastCheckTime = timestamppb.New(status.LastCheck)
+	}
+	if !status.Detected.IsZero() {
+		response.DetectedTime = timestamppb.New(status.Detected)
+	}
+	if !status.LastRemediation.IsZero() {
+		response.LastRemediationTime = timestamppb.New(status.LastRemediation)
+	}
+	return response
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// changeFreeze is a maintenance window during which changes are rejected
+type changeFreeze struct {
+	reason  string
+	since   time.Time
+	expires time.Time // Zero if the freeze lasts until UnfreezeChanges
+}
+
+// FreezeChanges starts a change freeze, or replaces the reason and expiry of the current one. The freeze is kept
+// in memory by this configurator only.
+func (s *HAProxyManagerServer) FreezeChanges(ctx context.Context, req *pb.FreezeChangesRequest) (*pb.FreezeChangesResponse, error) {
+	if req.Duration != nil && (!req.Duration.IsValid() || req.Duration.AsDuration() <= 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "duration must be positive")
+	}
+
+	s.freezeMutex.Lock()
+	now := time.Now()
+	freeze := &changeFreeze{reason: req.Reason, since: now}
+	if current := s.activeFreeze(now); current != nil {
+		freeze.since = current.since
+	}
+	if req.Duration != nil {
+		freeze.expires = now.Add(req.Duration.AsDuration())
+	}
+	s.freeze = freeze
+	s.freezeMutex.Unlock()
+
+	logger.FromContext(ctx).Info("Froze changes",
+		zap.String("reason", freeze.reason),
+		zap.Time("expires", freeze.expires))
+	return &pb.FreezeChangesResponse{Freeze: freeze.state()}, nil
+}
+
+// UnfreezeChanges lifts the change freeze
+func (s *HAProxyManagerServer) UnfreezeChanges(ctx context.Context, _ *pb.UnfreezeChangesRequest) (*pb.UnfreezeChangesResponse, error) {
+	s.freezeMutex.Lock()
+	wasFrozen := s.activeFreeze(time.Now()) != nil
+	s.freeze = nil
+	s.freezeMutex.Unlock()
+
+	if wasFrozen {
+		logger.FromContext(ctx).Info("Unfroze changes")
+	}
+	return &pb.UnfreezeChangesResponse{WasFrozen: wasFrozen}, nil
+}
+
+// freezeState returns the change freeze in effect for GetStatus
+func (s *HAProxyManagerServer) freezeState() *pb.FreezeState {
+	s.freezeMutex.Lock()
+	defer s.freezeMutex.Unlock()
+	if freeze := s.activeFreeze(time.Now()); freeze != nil {
+		return freeze.state()
+	}
+	return &pb.FreezeState{}
+}
+
+// requireUnfrozen fails with FAILED_PRECONDITION while changes are frozen
+func (s *HAProxyManagerServer) requireUnfrozen() error {
+	s.freezeMutex.Lock()
+	freeze := s.activeFreeze(time.Now())
+	s.freezeMutex.Unlock()
+	if freeze == nil {
+		return nil
+	}
+
+	message := "changes are frozen"
+	if !freeze.expires.IsZero() {
+		message += " until " + freeze.expires.UTC().Format(time.RFC3339)
+	}
+	if freeze.reason != "" {
+		message += ": " + freeze.reason
+	}
+	return status.Error(codes.FailedPrecondition, message)
+}
+
+// activeFreeze returns the change freeze in effect at now, dropping one that expired; the caller holds
+// freezeMutex
+func (s *HAProxyManagerServer) activeFreeze(now time.Time) *changeFreeze {
+	if s.freeze != nil && !s.freeze.expires.IsZero() && !now.Before(s.freeze.expires) {
+		logger.GetLogger().Info("Change freeze expired",
+			zap.String("reason", s.freeze.reason))
+		s.freeze = nil
+	}
+	return s.freeze
+}
+
+// state converts the freeze to its message
+func (f *changeFreeze) state() *pb.FreezeState {
+	state := &pb.FreezeState{Frozen: true, Reason: f.reason, Since: timestamppb.New(f.since)}
+	if !f.expires.IsZero() {
+		state.ExpireTime = timestamppb.New(f.expires)
+	}
+	return state
+}
+
+// UnaryFreezeInterceptor rejects the calls that change the configuration while changes are frozen
+func (s *HAProxyManagerServer) UnaryFreezeInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if changesConfiguration(info.FullMethod, req) {
+			if err := s.requireUnfrozen(); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamFreezeInterceptor rejects the streaming calls that change the configuration, such as DrainServer, while
+// changes are frozen
+func (s *HAProxyManagerServer) StreamFreezeInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !readOnlyMethod(info.FullMethod) {
+			if err := s.requireUnfrozen(); err != nil {
+				return err
+			}
+		}
+		return handler(srv, stream)
+	}
+}
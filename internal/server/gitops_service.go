@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/gitops"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetGitOps registers the GitOps controller whose progress GetGitOpsStatus reports
+func (s *HAProxyManagerServer) SetGitOps(controller *gitops.Controller) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.gitops = controller
+}
+
+// ApplyState makes the configuration of an HAProxy instance match the desired state, which is complete
+// or, with a prefix, complete for the frontends and backends named with it
+// It is the entry point for in-process controllers such as GitOps, which bypass the gRPC interceptors,
+// so standbys and change freezes are checked here
+func (s *HAProxyManagerServer) ApplyState(ctx context.Context, instance string, desired *pb.State, prefix string) ([]*pb.StateChange, error) {
+	if err := s.requireLeader(); err != nil {
+		return nil, err
+	}
+	if err := s.requireUnfrozen(); err != nil {
+		return nil, err
+	}
+
+	client, ok := s.instances[instance]
+	if !ok {
+		client = s.client
+	}
+	ctx = context.WithValue(ctx, instanceContextKey{}, client)
+
+	response, err := s.ApplyDesiredState(ctx, &pb.ApplyDesiredStateRequest{State: desired, NamePrefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	return response.Changes, nil
+}
+
+// GetGitOpsStatus reports the last revision applied from the GitOps source and the outcome of the last sync
+func (s *HAProxyManagerServer) GetGitOpsStatus(_ context.Context, _ *pb.GetGitOpsStatusRequest) (*pb.GetGitOpsStatusResponse, error) {
+	s.mutex.RLock()
+	controller := s.gitops
+	s.mutex.RUnlock()
+
+	if controller == nil {
+		return &pb.GetGitOpsStatusResponse{Enabled: false}, nil
+	}
+
+	status := controller.Status()
+	response := &pb.GetGitOpsStatusResponse{
+		Enabled:         true,
+		Source:          status.Source,
+		Revision:        status.Revision,
+		FetchedRevision: status.FetchedRevision,
+		LastError:       status.LastError,
+		LastChanges:     status.LastChanges,
+	}
+	if !status.LastSync.IsZero() {
+		response.LastSyncTime = timestamppb.New(status.LastSync)
+	}
+	if !status.LastApplied.IsZero() {
+		response.LastAppliedTime = timestamppb.New(status.LastApplied)
+	}
+	return response, nil
+}
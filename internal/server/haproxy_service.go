@@ -2,12 +2,26 @@ package server
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
+	"errors"
+	"sync"
+	"time"
 
+	"github.com/bear-san/haproxy-configurator/internal/audit"
+	"github.com/bear-san/haproxy-configurator/internal/bgp"
 	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/discovery"
+	"github.com/bear-san/haproxy-configurator/internal/drift"
+	"github.com/bear-san/haproxy-configurator/internal/events"
+	"github.com/bear-san/haproxy-configurator/internal/gitops"
+	"github.com/bear-san/haproxy-configurator/internal/idempotency"
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+	"github.com/bear-san/haproxy-configurator/internal/leader"
 	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
 	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"github.com/bear-san/haproxy-configurator/internal/peersync"
+	"github.com/bear-san/haproxy-configurator/internal/webhook"
 	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
 	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
 	"go.uber.org/zap"
@@ -18,27 +32,84 @@ import (
 // HAProxyManagerServer implements the HAProxyManagerServiceServer interface
 type HAProxyManagerServer struct {
 	pb.UnimplementedHAProxyManagerServiceServer
-	client     v3.Client
+	client    DataplaneClient            // Default instance
+	instances map[string]DataplaneClient // All instances by name, including the default
+	journal   *journal.Store
+	// Labels and annotations of resources, which HAProxy has no place for; nil without metadata.path
+	metadataStore *metadata.Store
+	changes       *events.Broadcaster[journal.Event]
+	webhooks      *webhook.Dispatcher
+	audit         *audit.Exporter // Forwards changes to syslog or journald
+
+	idempotency *idempotency.Cache // Responses of calls with an idempotency key
+
+	mutex      sync.RWMutex // Protects netplanMgr and config, which are swapped on reload, and the controllers set after startup
 	netplanMgr *netplan.Manager
 	config     *config.Config
+	gitops     *gitops.Controller
+	discovery  *discovery.Manager
+	bgp        *bgp.Controller
+	election   *leader.Election
+	peerSync   *peersync.Syncer
+	drift      *drift.Detector
+	reloader   func() ([]string, error) // Reloads the configuration file, see SetConfigReloader
+
+	started time.Time
+
+	transactionsMutex sync.Mutex
+	transactions      map[string]string // Transaction ID -> instance name
+	lastCommit        time.Time         // When a transaction was last committed, zero before the first
+
+	freezeMutex sync.Mutex
+	freeze      *changeFreeze // Nil unless changes are frozen, see FreezeChanges
+
+	replicationMutex sync.Mutex // Serializes replications to the cluster nodes
+	replicasMutex    sync.Mutex
+	replicas         map[string]*pb.ReplicaStatus // Outcome of the last replication by instance name
 }
 
-
 // NewHAProxyManagerServerWithConfig creates a new HAProxyManagerServer instance using a configuration file
 func NewHAProxyManagerServerWithConfig(cfg *config.Config) *HAProxyManagerServer {
-	logger.GetLogger().Info("Initializing HAProxy manager server with config",
-		zap.String("base_url", cfg.HAProxy.APIURL),
-		zap.String("username", cfg.HAProxy.Username))
+	return NewHAProxyManagerServerWithClients(cfg, nil)
+}
 
-	// Create base64 encoded credentials
-	credential := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cfg.HAProxy.Username, cfg.HAProxy.Password)))
+// NewHAProxyManagerServerWithClients creates a new HAProxyManagerServer instance that reaches the Data Plane API
+// through the given clients by instance name, e.g. in-memory fakes in tests. Clients for the default instance
+// and the configured instances that are not given are created from the configuration.
+func NewHAProxyManagerServerWithClients(cfg *config.Config, clients map[string]DataplaneClient) *HAProxyManagerServer {
+	logger.GetLogger().Info("Initializing HAProxy manager server with config",
+		zap.String("base_url", cfg.HAProxy.APIURL))
 
 	server := &HAProxyManagerServer{
-		client: v3.Client{
-			BaseUrl:    cfg.HAProxy.APIURL,
-			Credential: credential,
-		},
-		config: cfg,
+		client:       clients[config.DefaultInstance],
+		instances:    make(map[string]DataplaneClient),
+		transactions: make(map[string]string),
+		replicas:     make(map[string]*pb.ReplicaStatus),
+		changes:      events.NewBroadcaster[journal.Event](events.DefaultBufferSize),
+		idempotency:  idempotency.NewCache(time.Duration(cfg.Idempotency.TTLSeconds)*time.Second, cfg.Idempotency.MaxKeys),
+		config:       cfg,
+		started:      time.Now(),
+	}
+	if server.client == nil {
+		server.client = newDataplaneClient(config.DefaultInstance, cfg.HAProxy, cfg.DryRun, cfg.DataplaneRecording)
+	}
+	server.instances[config.DefaultInstance] = server.client
+
+	// Create clients for additional HAProxy instances
+	for _, instance := range cfg.Instances {
+		client, ok := clients[instance.Name]
+		if !ok {
+			client = newDataplaneClient(instance.Name, instance.HAProxySettings, cfg.DryRun, cfg.DataplaneRecording)
+		}
+		server.instances[instance.Name] = client
+
+		logger.GetLogger().Info("Registered HAProxy instance",
+			zap.String("instance", instance.Name),
+			zap.String("base_url", client.ActiveURL()))
+	}
+
+	if cfg.DryRun {
+		logger.GetLogger().Warn("Dry-run mode: requests are validated and logged, but nothing is written to the Data Plane API or Netplan")
 	}
 
 	// Initialize Netplan if configured
@@ -49,12 +120,83 @@ func NewHAProxyManagerServerWithConfig(cfg *config.Config) *HAProxyManagerServer
 			zap.String("config_path", cfg.Netplan.ConfigPath))
 	}
 
+	// Open the event journal if configured
+	if cfg.HasJournal() {
+		retention := time.Duration(cfg.Journal.RetentionDays) * 24 * time.Hour
+		store, err := journal.Open(cfg.Journal.Path, retention)
+		if err != nil {
+			logger.GetLogger().Error("Failed to open event journal, configuration changes will not be recorded",
+				zap.String("path", cfg.Journal.Path),
+				zap.Error(err))
+		} else {
+			server.journal = store
+
+			logger.GetLogger().Info("Event journal enabled",
+				zap.String("path", cfg.Journal.Path),
+				zap.Int("retention_days", cfg.Journal.RetentionDays))
+		}
+	}
+
+	// Open the metadata store if configured
+	if cfg.HasMetadata() {
+		store, err := metadata.Open(cfg.Metadata.Path)
+		if err != nil {
+			logger.GetLogger().Error("Failed to open metadata store, labels and annotations are unavailable",
+				zap.String("path", cfg.Metadata.Path),
+				zap.Error(err))
+		} else {
+			server.metadataStore = store
+
+			logger.GetLogger().Info("Metadata store enabled",
+				zap.String("path", cfg.Metadata.Path))
+		}
+	}
+
+	// Forward changes to syslog or journald if configured
+	if cfg.HasAudit() {
+		exporter, err := audit.NewExporter(cfg.Audit)
+		if err != nil {
+			logger.GetLogger().Error("Failed to initialize audit export, changes will not be forwarded",
+				zap.String("target", cfg.Audit.Target),
+				zap.Error(err))
+		} else {
+			server.audit = exporter
+
+			logger.GetLogger().Info("Audit export enabled",
+				zap.String("target", cfg.Audit.Target),
+				zap.String("address", cfg.Audit.Address))
+		}
+	}
+
+	// Set up webhook notifications if configured
+	if len(cfg.Webhooks) > 0 {
+		dispatcher, err := webhook.NewDispatcher(cfg.Webhooks)
+		if err != nil {
+			logger.GetLogger().Error("Failed to initialize webhooks, notifications disabled",
+				zap.Error(err))
+		} else {
+			server.webhooks = dispatcher
+
+			logger.GetLogger().Info("Webhook notifications enabled",
+				zap.Int("webhooks", len(cfg.Webhooks)))
+		}
+	}
+
 	return server
 }
 
+// SetDataplaneCredentials replaces the credentials used for the default HAProxy instance, e.g. after rotation in Vault
+func (s *HAProxyManagerServer) SetDataplaneCredentials(username, password string) {
+	if client, ok := liveClient(s.client); ok {
+		client.SetCredential(encodeCredential(username, password))
+	}
+}
+
 // GetVersion retrieves the current HAProxy configuration version from the HAProxy Data Plane API
-func (s *HAProxyManagerServer) GetVersion(_ context.Context, _ *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
-	version, err := s.client.GetVersion()
+func (s *HAProxyManagerServer) GetVersion(ctx context.Context, _ *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	client := s.dataplane(ctx)
+
+	version, err := client.GetVersion(ctx)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -64,25 +206,63 @@ func (s *HAProxyManagerServer) GetVersion(_ context.Context, _ *pb.GetVersionReq
 }
 
 // CreateTransaction creates a new configuration transaction in HAProxy
-// The transaction must be committed or closed after making configuration changes
-func (s *HAProxyManagerServer) CreateTransaction(_ context.Context, req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
-	transaction, err := s.client.CreateTransaction(int(req.Version))
+// The transaction must be committed or closed after making configuration changes.
+// Without a version, the transaction is based on the current version, see createTransactionAtCurrentVersion.
+func (s *HAProxyManagerServer) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
+	client := s.dataplane(ctx)
+
+	var transaction *v3.Transaction
+	var err error
+	if req.Version != 0 {
+		transaction, err = client.CreateTransaction(ctx, int(req.Version))
+	} else {
+		transaction, err = createTransactionAtCurrentVersion(ctx, client)
+	}
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	if transaction != nil && transaction.Id != nil {
+		s.trackTransaction(*transaction.Id, client.Instance())
+		logger.FromContext(ctx).Info("Created HAProxy transaction",
+			zap.String(logger.FieldHAProxyTxnID, *transaction.Id),
+			zap.String("instance", client.Instance()))
+	}
+
 	return &pb.CreateTransactionResponse{
 		Transaction: convertTransactionToProto(transaction),
 	}, nil
 }
 
+// createTransactionAtCurrentVersion reads the configuration version and creates a transaction based on it. If the
+// configuration changes between the two requests, the version is read again once.
+func createTransactionAtCurrentVersion(ctx context.Context, client DataplaneClient) (*v3.Transaction, error) {
+	for retry := 0; ; retry++ {
+		version, err := client.GetVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		transaction, err := client.CreateTransaction(ctx, int(derefInt(version)))
+		var conflict *v3.ConflictError
+		if retry == 0 && errors.As(err, &conflict) {
+			logger.FromContext(ctx).Debug("Configuration version changed while creating a transaction, retrying",
+				zap.String("instance", client.Instance()),
+				zap.Int32("version", derefInt(version)))
+			continue
+		}
+		return transaction, err
+	}
+}
+
 // GetTransaction retrieves the details of a specific transaction by its ID
-func (s *HAProxyManagerServer) GetTransaction(_ context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+func (s *HAProxyManagerServer) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
 
-	transaction, err := s.client.GetTransaction(req.TransactionId)
+	transaction, err := client.GetTransaction(ctx, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -92,26 +272,63 @@ func (s *HAProxyManagerServer) GetTransaction(_ context.Context, req *pb.GetTran
 	}, nil
 }
 
+// ListTransactions lists the transactions that have been neither committed nor closed
+func (s *HAProxyManagerServer) ListTransactions(ctx context.Context, _ *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	client := s.dataplane(ctx)
+
+	transactions, err := client.ListTransactions(ctx)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	response := &pb.ListTransactionsResponse{}
+	for i := range transactions {
+		response.Transactions = append(response.Transactions, convertTransactionToProto(&transactions[i]))
+	}
+	return response, nil
+}
+
 // CommitTransaction commits a transaction, applying all configuration changes to HAProxy
-func (s *HAProxyManagerServer) CommitTransaction(_ context.Context, req *pb.CommitTransactionRequest) (*pb.CommitTransactionResponse, error) {
+func (s *HAProxyManagerServer) CommitTransaction(ctx context.Context, req *pb.CommitTransactionRequest) (*pb.CommitTransactionResponse, error) {
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
 
 	// Use Netplan-aware transaction commit
-	return s.CommitTransactionWithNetplan(req)
+	return s.CommitTransactionWithNetplan(ctx, req)
 }
 
 // CloseTransaction closes a transaction without committing any changes
-func (s *HAProxyManagerServer) CloseTransaction(_ context.Context, req *pb.CloseTransactionRequest) (*pb.CloseTransactionResponse, error) {
+func (s *HAProxyManagerServer) CloseTransaction(ctx context.Context, req *pb.CloseTransactionRequest) (*pb.CloseTransactionResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
 
-	message, err := s.client.CloseTransaction(req.TransactionId)
+	message, err := client.CloseTransaction(ctx, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
+	s.untrackTransaction(req.TransactionId)
+	if netplanMgr := s.netplanFor(client); netplanMgr != nil {
+		netplanMgr.DiscardBinds(req.TransactionId)
+		if err := netplanMgr.DiscardTransaction(req.TransactionId); err != nil {
+			logger.FromContext(ctx).Warn("Failed to discard the Netplan transaction",
+				zap.String("transaction_id", req.TransactionId),
+				zap.Error(err))
+		}
+	}
+	if s.metadataStore != nil {
+		s.metadataStore.Discard(req.TransactionId)
+	}
+
+	s.recordChange(resourceTransaction, actionClose, "", req.TransactionId, req.TransactionId, nil, nil)
+	s.webhooks.Notify(webhook.Event{
+		Type:          webhook.EventRollback,
+		TransactionID: req.TransactionId,
+		Message:       "Transaction closed; its changes were discarded",
+	})
 
 	return &pb.CloseTransactionResponse{
 		Message: derefString(message),
@@ -120,44 +337,79 @@ func (s *HAProxyManagerServer) CloseTransaction(_ context.Context, req *pb.Close
 
 // CreateBackend creates a new backend configuration in HAProxy
 // A backend defines a set of servers to which the proxy will connect to forward incoming requests
-func (s *HAProxyManagerServer) CreateBackend(_ context.Context, req *pb.CreateBackendRequest) (*pb.CreateBackendResponse, error) {
+func (s *HAProxyManagerServer) CreateBackend(ctx context.Context, req *pb.CreateBackendRequest) (*pb.CreateBackendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Backend == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "backend is required")
 	}
 	if req.Backend.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
+	if err := validateConnectionLimits(req.Backend.Maxconn, req.Backend.Minconn, req.Backend.Maxqueue, req.Backend.Fullconn); err != nil {
+		return nil, err
+	}
+	if err := s.checkMetadata(req.Backend); err != nil {
+		return nil, err
+	}
 
 	backend := convertBackendFromProto(req.Backend)
-	created, err := s.client.AddBackend(*backend, req.TransactionId)
+	created, err := client.AddBackend(ctx, *backend, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceBackend, actionCreate, "", req.Backend.Name, req.TransactionId, nil, created)
+
+	result := convertBackendToProto(created)
+	key := metadataKey(client, metadata.KindBackend, "", req.Backend.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Backend, result, true); err != nil {
+		return nil, err
+	}
 	return &pb.CreateBackendResponse{
-		Backend: convertBackendToProto(created),
+		Backend: result,
 	}, nil
 }
 
 // GetBackend retrieves a specific backend configuration by name
-func (s *HAProxyManagerServer) GetBackend(_ context.Context, req *pb.GetBackendRequest) (*pb.GetBackendResponse, error) {
+func (s *HAProxyManagerServer) GetBackend(ctx context.Context, req *pb.GetBackendRequest) (*pb.GetBackendResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
 
-	backend, err := s.client.GetBackend(req.Name, req.TransactionId)
+	backend, err := client.GetBackend(ctx, req.Name, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	result := convertBackendToProto(backend)
+	if err := attachMetadata(s, req.TransactionId, []*pb.Backend{result}, func(backend *pb.Backend) metadata.Key {
+		return metadataKey(client, metadata.KindBackend, "", backend.Name)
+	}); err != nil {
+		return nil, err
+	}
 	return &pb.GetBackendResponse{
-		Backend: convertBackendToProto(backend),
+		Backend: result,
 	}, nil
 }
 
-// ListBackends retrieves all backend configurations from HAProxy
-func (s *HAProxyManagerServer) ListBackends(_ context.Context, req *pb.ListBackendsRequest) (*pb.ListBackendsResponse, error) {
-	backends, err := s.client.ListBackends(req.TransactionId)
+// ListBackends retrieves the backend configurations from HAProxy that match the filter, in the requested order
+func (s *HAProxyManagerServer) ListBackends(ctx context.Context, req *pb.ListBackendsRequest) (*pb.ListBackendsResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
+	query, err := newListQuery("backend", req.Filter, req.OrderBy, "name", "mode")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return nil, err
+	}
+
+	backends, err := client.ListBackends(ctx, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -167,48 +419,104 @@ func (s *HAProxyManagerServer) ListBackends(_ context.Context, req *pb.ListBacke
 		pbBackends = append(pbBackends, convertBackendToProto(&backend))
 	}
 
+	if err := attachMetadata(s, req.TransactionId, pbBackends, func(backend *pb.Backend) metadata.Key {
+		return metadataKey(client, metadata.KindBackend, "", backend.Name)
+	}); err != nil {
+		return nil, err
+	}
+
+	pbBackends = applyListQuery(query, pbBackends, func(backend *pb.Backend) listFields {
+		return listFields{name: backend.Name, mode: backend.Mode, labels: backend.Labels}
+	})
+
 	return &pb.ListBackendsResponse{
 		Backends: pbBackends,
 	}, nil
 }
 
 // UpdateBackend updates an existing backend configuration
-func (s *HAProxyManagerServer) UpdateBackend(_ context.Context, req *pb.UpdateBackendRequest) (*pb.UpdateBackendResponse, error) {
+func (s *HAProxyManagerServer) UpdateBackend(ctx context.Context, req *pb.UpdateBackendRequest) (*pb.UpdateBackendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
 	if req.Backend == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "backend is required")
 	}
+	if err := validateConnectionLimits(req.Backend.Maxconn, req.Backend.Minconn, req.Backend.Maxqueue, req.Backend.Fullconn); err != nil {
+		return nil, err
+	}
+	if err := s.checkMetadata(req.Backend); err != nil {
+		return nil, err
+	}
+
+	var previous *dataplane.Backend
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetBackend(ctx, req.Name, req.TransactionId)
+		if err := checkVersion(resourceBackend, req.ExpectedVersion, convertBackendToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
 
 	backend := convertBackendFromProto(req.Backend)
-	updated, err := s.client.ReplaceBackend(req.Name, *backend, req.TransactionId)
+	updated, err := client.ReplaceBackend(ctx, req.Name, *backend, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceBackend, actionUpdate, "", req.Name, req.TransactionId, previous, updated)
+
+	result := convertBackendToProto(updated)
+	key := metadataKey(client, metadata.KindBackend, "", req.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Backend, result, false); err != nil {
+		return nil, err
+	}
 	return &pb.UpdateBackendResponse{
-		Backend: convertBackendToProto(updated),
+		Backend: result,
 	}, nil
 }
 
-// DeleteBackend removes a backend configuration from HAProxy
-func (s *HAProxyManagerServer) DeleteBackend(_ context.Context, req *pb.DeleteBackendRequest) (*pb.DeleteBackendResponse, error) {
+// DeleteBackend removes a backend configuration from HAProxy, unless frontends still refer to it and force is not set
+func (s *HAProxyManagerServer) DeleteBackend(ctx context.Context, req *pb.DeleteBackendRequest) (*pb.DeleteBackendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
 
-	err := s.client.DeleteBackend(req.Name, req.TransactionId)
+	if !req.Force {
+		if err := checkBackendReferences(ctx, client, req.TransactionId, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	var previous *dataplane.Backend
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetBackend(ctx, req.Name, req.TransactionId)
+		if err := checkVersion(resourceBackend, req.ExpectedVersion, convertBackendToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
+
+	err := client.DeleteBackend(ctx, req.Name, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceBackend, actionDelete, "", req.Name, req.TransactionId, previous, nil)
+	s.deleteMetadata(ctx, req.TransactionId, metadataKey(client, metadata.KindBackend, "", req.Name))
+
 	return &pb.DeleteBackendResponse{}, nil
 }
 
 // CreateFrontend creates a new frontend configuration in HAProxy
 // A frontend defines how requests should be received and which backend to route them to
-func (s *HAProxyManagerServer) CreateFrontend(_ context.Context, req *pb.CreateFrontendRequest) (*pb.CreateFrontendResponse, error) {
+func (s *HAProxyManagerServer) CreateFrontend(ctx context.Context, req *pb.CreateFrontendRequest) (*pb.CreateFrontendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Frontend == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend is required")
 	}
@@ -216,36 +524,67 @@ func (s *HAProxyManagerServer) CreateFrontend(_ context.Context, req *pb.CreateF
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 
+	if err := s.checkMetadata(req.Frontend); err != nil {
+		return nil, err
+	}
+
 	frontend := convertFrontendFromProto(req.Frontend)
-	created, err := s.client.AddFrontend(*frontend, req.TransactionId)
+	created, err := client.AddFrontend(ctx, *frontend, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceFrontend, actionCreate, "", req.Frontend.Name, req.TransactionId, nil, created)
+
+	result := convertFrontendToProto(created)
+	key := metadataKey(client, metadata.KindFrontend, "", req.Frontend.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Frontend, result, true); err != nil {
+		return nil, err
+	}
 	return &pb.CreateFrontendResponse{
-		Frontend: convertFrontendToProto(created),
+		Frontend: result,
 	}, nil
 }
 
 // GetFrontend retrieves a specific frontend configuration by name
-func (s *HAProxyManagerServer) GetFrontend(_ context.Context, req *pb.GetFrontendRequest) (*pb.GetFrontendResponse, error) {
+func (s *HAProxyManagerServer) GetFrontend(ctx context.Context, req *pb.GetFrontendRequest) (*pb.GetFrontendResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 
-	frontend, err := s.client.GetFrontend(req.Name, req.TransactionId)
+	frontend, err := client.GetFrontend(ctx, req.Name, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	result := convertFrontendToProto(frontend)
+	if err := attachMetadata(s, req.TransactionId, []*pb.Frontend{result}, func(frontend *pb.Frontend) metadata.Key {
+		return metadataKey(client, metadata.KindFrontend, "", frontend.Name)
+	}); err != nil {
+		return nil, err
+	}
 	return &pb.GetFrontendResponse{
-		Frontend: convertFrontendToProto(frontend),
+		Frontend: result,
 	}, nil
 }
 
-// ListFrontends retrieves all frontend configurations from HAProxy
-func (s *HAProxyManagerServer) ListFrontends(_ context.Context, req *pb.ListFrontendsRequest) (*pb.ListFrontendsResponse, error) {
-	frontends, err := s.client.ListFrontends(req.TransactionId)
+// ListFrontends retrieves the frontend configurations from HAProxy that match the filter, in the requested order
+func (s *HAProxyManagerServer) ListFrontends(ctx context.Context, req *pb.ListFrontendsRequest) (*pb.ListFrontendsResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
+	query, err := newListQuery("frontend", req.Filter, req.OrderBy, "name", "mode")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return nil, err
+	}
+
+	frontends, err := client.ListFrontends(ctx, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -255,48 +594,93 @@ func (s *HAProxyManagerServer) ListFrontends(_ context.Context, req *pb.ListFron
 		pbFrontends = append(pbFrontends, convertFrontendToProto(&frontend))
 	}
 
+	if err := attachMetadata(s, req.TransactionId, pbFrontends, func(frontend *pb.Frontend) metadata.Key {
+		return metadataKey(client, metadata.KindFrontend, "", frontend.Name)
+	}); err != nil {
+		return nil, err
+	}
+
+	pbFrontends = applyListQuery(query, pbFrontends, func(frontend *pb.Frontend) listFields {
+		return listFields{name: frontend.Name, mode: frontend.Mode, labels: frontend.Labels}
+	})
+
 	return &pb.ListFrontendsResponse{
 		Frontends: pbFrontends,
 	}, nil
 }
 
 // UpdateFrontend updates an existing frontend configuration
-func (s *HAProxyManagerServer) UpdateFrontend(_ context.Context, req *pb.UpdateFrontendRequest) (*pb.UpdateFrontendResponse, error) {
+func (s *HAProxyManagerServer) UpdateFrontend(ctx context.Context, req *pb.UpdateFrontendRequest) (*pb.UpdateFrontendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 	if req.Frontend == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend is required")
 	}
+	if err := s.checkMetadata(req.Frontend); err != nil {
+		return nil, err
+	}
+
+	var previous *dataplane.Frontend
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetFrontend(ctx, req.Name, req.TransactionId)
+		if err := checkVersion(resourceFrontend, req.ExpectedVersion, convertFrontendToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
 
 	frontend := convertFrontendFromProto(req.Frontend)
-	updated, err := s.client.ReplaceFrontend(req.Name, *frontend, req.TransactionId)
+	updated, err := client.ReplaceFrontend(ctx, req.Name, *frontend, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceFrontend, actionUpdate, "", req.Name, req.TransactionId, previous, updated)
+
+	result := convertFrontendToProto(updated)
+	key := metadataKey(client, metadata.KindFrontend, "", req.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Frontend, result, false); err != nil {
+		return nil, err
+	}
 	return &pb.UpdateFrontendResponse{
-		Frontend: convertFrontendToProto(updated),
+		Frontend: result,
 	}, nil
 }
 
 // DeleteFrontend removes a frontend configuration from HAProxy
-func (s *HAProxyManagerServer) DeleteFrontend(_ context.Context, req *pb.DeleteFrontendRequest) (*pb.DeleteFrontendResponse, error) {
+func (s *HAProxyManagerServer) DeleteFrontend(ctx context.Context, req *pb.DeleteFrontendRequest) (*pb.DeleteFrontendResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 
-	err := s.client.DeleteFrontend(req.Name, req.TransactionId)
+	var previous *dataplane.Frontend
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetFrontend(ctx, req.Name, req.TransactionId)
+		if err := checkVersion(resourceFrontend, req.ExpectedVersion, convertFrontendToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
+
+	err := client.DeleteFrontend(ctx, req.Name, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceFrontend, actionDelete, "", req.Name, req.TransactionId, previous, nil)
+	s.deleteMetadata(ctx, req.TransactionId, metadataKey(client, metadata.KindFrontend, "", req.Name))
+
 	return &pb.DeleteFrontendResponse{}, nil
 }
 
-// CreateBind creates a new bind configuration for a frontend in HAProxy
+// CreateBind creates a new bind configuration for a frontend in HAProxy, or one per address of an address list
 // A bind defines the listening address and port for a frontend
-func (s *HAProxyManagerServer) CreateBind(_ context.Context, req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
+func (s *HAProxyManagerServer) CreateBind(ctx context.Context, req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
 	if req.FrontendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
@@ -306,13 +690,25 @@ func (s *HAProxyManagerServer) CreateBind(_ context.Context, req *pb.CreateBindR
 	if req.Bind.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "bind name is required")
 	}
+	if req.DualStack {
+		return s.createDualStackBinds(ctx, req)
+	}
+	if req.Hostname != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "hostname requires dual_stack")
+	}
+	if len(req.Addresses) > 0 {
+		return s.createBinds(ctx, req)
+	}
 
 	// Use Netplan-aware bind creation
-	return s.CreateBindWithNetplan(req)
+	return s.CreateBindWithNetplan(ctx, req)
 }
 
 // GetBind retrieves a specific bind configuration by name from a frontend
-func (s *HAProxyManagerServer) GetBind(_ context.Context, req *pb.GetBindRequest) (*pb.GetBindResponse, error) {
+func (s *HAProxyManagerServer) GetBind(ctx context.Context, req *pb.GetBindRequest) (*pb.GetBindResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.FrontendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
@@ -320,23 +716,40 @@ func (s *HAProxyManagerServer) GetBind(_ context.Context, req *pb.GetBindRequest
 		return nil, status.Errorf(codes.InvalidArgument, "bind name is required")
 	}
 
-	bind, err := s.client.GetBind(req.Name, req.FrontendName, req.TransactionId)
+	bind, err := client.GetBind(ctx, req.Name, req.FrontendName, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	result := convertBindToProto(bind)
+	if err := attachMetadata(s, req.TransactionId, []*pb.Bind{result}, func(bind *pb.Bind) metadata.Key {
+		return metadataKey(client, metadata.KindBind, req.FrontendName, bind.Name)
+	}); err != nil {
+		return nil, err
+	}
 	return &pb.GetBindResponse{
-		Bind: convertBindToProto(bind),
+		Bind: result,
 	}, nil
 }
 
-// ListBinds retrieves all bind configurations for a specific frontend
-func (s *HAProxyManagerServer) ListBinds(_ context.Context, req *pb.ListBindsRequest) (*pb.ListBindsResponse, error) {
+// ListBinds retrieves the bind configurations of a specific frontend that match the filter, in the requested order
+func (s *HAProxyManagerServer) ListBinds(ctx context.Context, req *pb.ListBindsRequest) (*pb.ListBindsResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.FrontendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 
-	binds, err := s.client.ListBinds(req.FrontendName, req.TransactionId)
+	query, err := newListQuery("bind", req.Filter, req.OrderBy, "name", "address", "port")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return nil, err
+	}
+
+	binds, err := client.ListBinds(ctx, req.FrontendName, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -346,33 +759,67 @@ func (s *HAProxyManagerServer) ListBinds(_ context.Context, req *pb.ListBindsReq
 		pbBinds = append(pbBinds, convertBindToProto(&bind))
 	}
 
+	if err := attachMetadata(s, req.TransactionId, pbBinds, func(bind *pb.Bind) metadata.Key {
+		return metadataKey(client, metadata.KindBind, req.FrontendName, bind.Name)
+	}); err != nil {
+		return nil, err
+	}
+
+	pbBinds = applyListQuery(query, pbBinds, func(bind *pb.Bind) listFields {
+		return listFields{name: bind.Name, address: bind.Address, port: bind.Port, labels: bind.Labels}
+	})
+
 	return &pb.ListBindsResponse{
 		Binds: pbBinds,
 	}, nil
 }
 
 // UpdateBind updates an existing bind configuration for a frontend
-func (s *HAProxyManagerServer) UpdateBind(_ context.Context, req *pb.UpdateBindRequest) (*pb.UpdateBindResponse, error) {
+func (s *HAProxyManagerServer) UpdateBind(ctx context.Context, req *pb.UpdateBindRequest) (*pb.UpdateBindResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.FrontendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
 	if req.Bind == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "bind is required")
 	}
+	if err := s.checkMetadata(req.Bind); err != nil {
+		return nil, err
+	}
+
+	var previous *dataplane.Bind
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetBind(ctx, req.Bind.Name, req.FrontendName, req.TransactionId)
+		if err := checkVersion(resourceBind, req.ExpectedVersion, convertBindToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
 
 	bind := convertBindFromProto(req.Bind)
-	updated, err := s.client.ReplaceBind(req.FrontendName, req.TransactionId, *bind)
+	updated, err := client.ReplaceBind(ctx, req.FrontendName, req.TransactionId, *bind)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceBind, actionUpdate, req.FrontendName, req.Bind.Name, req.TransactionId, previous, updated)
+	if netplanMgr := s.netplanFor(client); netplanMgr != nil {
+		netplanMgr.RecordBind(req.TransactionId, req.FrontendName, req.Bind.Name, req.Bind.Address, int(req.Bind.Port))
+	}
+
+	result := convertBindToProto(updated)
+	key := metadataKey(client, metadata.KindBind, req.FrontendName, req.Bind.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Bind, result, false); err != nil {
+		return nil, err
+	}
 	return &pb.UpdateBindResponse{
-		Bind: convertBindToProto(updated),
+		Bind: result,
 	}, nil
 }
 
 // DeleteBind removes a bind configuration from a frontend
-func (s *HAProxyManagerServer) DeleteBind(_ context.Context, req *pb.DeleteBindRequest) (*pb.DeleteBindResponse, error) {
+func (s *HAProxyManagerServer) DeleteBind(ctx context.Context, req *pb.DeleteBindRequest) (*pb.DeleteBindResponse, error) {
 	if req.FrontendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
 	}
@@ -381,12 +828,14 @@ func (s *HAProxyManagerServer) DeleteBind(_ context.Context, req *pb.DeleteBindR
 	}
 
 	// Use Netplan-aware bind deletion
-	return s.DeleteBindWithNetplan(req)
+	return s.DeleteBindWithNetplan(ctx, req)
 }
 
 // CreateServer creates a new server configuration in a backend
 // A server represents a backend server that will handle forwarded requests
-func (s *HAProxyManagerServer) CreateServer(_ context.Context, req *pb.CreateServerRequest) (*pb.CreateServerResponse, error) {
+func (s *HAProxyManagerServer) CreateServer(ctx context.Context, req *pb.CreateServerRequest) (*pb.CreateServerResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.BackendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
@@ -396,20 +845,36 @@ func (s *HAProxyManagerServer) CreateServer(_ context.Context, req *pb.CreateSer
 	if req.Server.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "server name is required")
 	}
+	if err := validateServerSettings(req.Server); err != nil {
+		return nil, err
+	}
+	if err := s.checkMetadata(req.Server); err != nil {
+		return nil, err
+	}
 
 	server := convertServerFromProto(req.Server)
-	created, err := s.client.AddServer(req.BackendName, req.TransactionId, *server)
+	created, err := client.AddServer(ctx, req.BackendName, req.TransactionId, *server)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceServer, actionCreate, req.BackendName, req.Server.Name, req.TransactionId, nil, created)
+
+	result := convertServerToProto(created)
+	key := metadataKey(client, metadata.KindServer, req.BackendName, req.Server.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Server, result, true); err != nil {
+		return nil, err
+	}
 	return &pb.CreateServerResponse{
-		Server: convertServerToProto(created),
+		Server: result,
 	}, nil
 }
 
 // GetServer retrieves a specific server configuration by name from a backend
-func (s *HAProxyManagerServer) GetServer(_ context.Context, req *pb.GetServerRequest) (*pb.GetServerResponse, error) {
+func (s *HAProxyManagerServer) GetServer(ctx context.Context, req *pb.GetServerRequest) (*pb.GetServerResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.BackendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
@@ -417,23 +882,40 @@ func (s *HAProxyManagerServer) GetServer(_ context.Context, req *pb.GetServerReq
 		return nil, status.Errorf(codes.InvalidArgument, "server name is required")
 	}
 
-	server, err := s.client.GetServer(req.Name, req.BackendName, req.TransactionId)
+	server, err := client.GetServer(ctx, req.Name, req.BackendName, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	result := convertServerToProto(server)
+	if err := attachMetadata(s, req.TransactionId, []*pb.Server{result}, func(server *pb.Server) metadata.Key {
+		return metadataKey(client, metadata.KindServer, req.BackendName, server.Name)
+	}); err != nil {
+		return nil, err
+	}
 	return &pb.GetServerResponse{
-		Server: convertServerToProto(server),
+		Server: result,
 	}, nil
 }
 
-// ListServers retrieves all server configurations for a specific backend
-func (s *HAProxyManagerServer) ListServers(_ context.Context, req *pb.ListServersRequest) (*pb.ListServersResponse, error) {
+// ListServers retrieves the server configurations of a specific backend that match the filter, in the requested order
+func (s *HAProxyManagerServer) ListServers(ctx context.Context, req *pb.ListServersRequest) (*pb.ListServersResponse, error) {
+	ctx = readCache(ctx, req.NoCache)
+	client := s.dataplane(ctx)
+
 	if req.BackendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
 
-	servers, err := s.client.ListServers(req.BackendName, req.TransactionId)
+	query, err := newListQuery("server", req.Filter, req.OrderBy, "name", "address", "port")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return nil, err
+	}
+
+	servers, err := client.ListServers(ctx, req.BackendName, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
@@ -443,13 +925,25 @@ func (s *HAProxyManagerServer) ListServers(_ context.Context, req *pb.ListServer
 		pbServers = append(pbServers, convertServerToProto(&server))
 	}
 
+	if err := attachMetadata(s, req.TransactionId, pbServers, func(server *pb.Server) metadata.Key {
+		return metadataKey(client, metadata.KindServer, req.BackendName, server.Name)
+	}); err != nil {
+		return nil, err
+	}
+
+	pbServers = applyListQuery(query, pbServers, func(server *pb.Server) listFields {
+		return listFields{name: server.Name, address: server.Address, port: server.Port, labels: server.Labels}
+	})
+
 	return &pb.ListServersResponse{
 		Servers: pbServers,
 	}, nil
 }
 
 // UpdateServer updates an existing server configuration in a backend
-func (s *HAProxyManagerServer) UpdateServer(_ context.Context, req *pb.UpdateServerRequest) (*pb.UpdateServerResponse, error) {
+func (s *HAProxyManagerServer) UpdateServer(ctx context.Context, req *pb.UpdateServerRequest) (*pb.UpdateServerResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.BackendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
@@ -459,20 +953,44 @@ func (s *HAProxyManagerServer) UpdateServer(_ context.Context, req *pb.UpdateSer
 	if req.Server == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "server is required")
 	}
+	if err := validateServerSettings(req.Server); err != nil {
+		return nil, err
+	}
+	if err := s.checkMetadata(req.Server); err != nil {
+		return nil, err
+	}
+
+	var previous *dataplane.Server
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetServer(ctx, req.Name, req.BackendName, req.TransactionId)
+		if err := checkVersion(resourceServer, req.ExpectedVersion, convertServerToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
 
 	server := convertServerFromProto(req.Server)
-	updated, err := s.client.ReplaceServer(req.BackendName, req.TransactionId, *server)
+	updated, err := client.ReplaceServer(ctx, req.BackendName, req.TransactionId, *server)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceServer, actionUpdate, req.BackendName, req.Name, req.TransactionId, previous, updated)
+
+	result := convertServerToProto(updated)
+	key := metadataKey(client, metadata.KindServer, req.BackendName, req.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Server, result, false); err != nil {
+		return nil, err
+	}
 	return &pb.UpdateServerResponse{
-		Server: convertServerToProto(updated),
+		Server: result,
 	}, nil
 }
 
 // DeleteServer removes a server configuration from a backend
-func (s *HAProxyManagerServer) DeleteServer(_ context.Context, req *pb.DeleteServerRequest) (*pb.DeleteServerResponse, error) {
+func (s *HAProxyManagerServer) DeleteServer(ctx context.Context, req *pb.DeleteServerRequest) (*pb.DeleteServerResponse, error) {
+	client := s.dataplane(ctx)
+
 	if req.BackendName == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
 	}
@@ -480,10 +998,22 @@ func (s *HAProxyManagerServer) DeleteServer(_ context.Context, req *pb.DeleteSer
 		return nil, status.Errorf(codes.InvalidArgument, "server name is required")
 	}
 
-	err := s.client.DeleteServer(req.Name, req.BackendName, req.TransactionId)
+	var previous *dataplane.Server
+	if s.journal != nil || req.ExpectedVersion != "" {
+		var err error
+		previous, err = client.GetServer(ctx, req.Name, req.BackendName, req.TransactionId)
+		if err := checkVersion(resourceServer, req.ExpectedVersion, convertServerToProto(previous), err); err != nil {
+			return nil, err
+		}
+	}
+
+	err := client.DeleteServer(ctx, req.Name, req.BackendName, req.TransactionId)
 	if err != nil {
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceServer, actionDelete, req.BackendName, req.Name, req.TransactionId, previous, nil)
+	s.deleteMetadata(ctx, req.TransactionId, metadataKey(client, metadata.KindServer, req.BackendName, req.Name))
+
 	return &pb.DeleteServerResponse{}, nil
 }
@@ -1,18 +1,50 @@
 package server
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"github.com/bear-san/haproxy-configurator/internal/standalone"
 	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
 	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// encodeCredential builds the base64 encoded basic auth credential for the Data Plane API
+func encodeCredential(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
 // Helper functions for error handling
 func handleHAProxyError(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	if errors.Is(err, dataplane.ErrCircuitOpen) {
+		return status.Errorf(codes.Unavailable, "HAProxy Data Plane API is unavailable: %v", err)
+	}
+	if errors.Is(err, dataplane.ErrTooManyTransactions) {
+		return status.Errorf(codes.ResourceExhausted, "%v; commit or close a transaction first", err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Errorf(codes.DeadlineExceeded, "HAProxy Data Plane API request timed out: %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Errorf(codes.Canceled, "HAProxy Data Plane API request canceled: %v", err)
+	}
+	if errors.Is(err, standalone.ErrRuntimeUnavailable) || errors.Is(err, dataplane.ErrRuntimeSocketUnavailable) {
+		return status.Errorf(codes.Unimplemented, "%v", err)
+	}
+	if dataplane.IsTransient(err) {
+		return status.Errorf(codes.Unavailable, "HAProxy Data Plane API is temporarily unavailable, e.g. reloading: %v", err)
+	}
+
 	switch e := err.(type) {
 	case *v3.NotFoundError:
 		return status.Errorf(codes.NotFound, "resource not found: %s", e.Message)
@@ -40,6 +72,14 @@ func intPtr(i int32) *int {
 	return &val
 }
 
+// optionalInt returns nil for zero, which leaves the setting out of the configuration
+func optionalInt(i int32) *int {
+	if i == 0 {
+		return nil
+	}
+	return intPtr(i)
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -123,16 +163,19 @@ func convertBalanceAlgorithmToProto(algo string) pb.BalanceAlgorithm {
 	}
 }
 
-// convertBackendToProto converts v3.Backend to pb.Backend
-func convertBackendToProto(backend *v3.Backend) *pb.Backend {
+// convertBackendToProto converts dataplane.Backend to pb.Backend
+func convertBackendToProto(backend *dataplane.Backend) *pb.Backend {
 	if backend == nil {
 		return nil
 	}
 
 	result := &pb.Backend{
-		Id:   derefInt(backend.Id),
-		Name: derefString(backend.Name),
-		Mode: convertProxyModeToProto(backend.Mode),
+		Id:       derefInt(backend.Id),
+		Name:     derefString(backend.Name),
+		Mode:     convertProxyModeToProto(backend.Mode),
+		Fullconn: derefInt(backend.Fullconn),
+		Disabled: derefBool(backend.Disabled),
+		Httpchk:  backend.AdvCheck == advCheckHTTP,
 	}
 
 	if backend.Balance != nil && backend.Balance.Algorithm != "" {
@@ -140,21 +183,36 @@ func convertBackendToProto(backend *v3.Backend) *pb.Backend {
 			Algorithm: convertBalanceAlgorithmToProto(backend.Balance.Algorithm),
 		}
 	}
+	if backend.DefaultServer != nil {
+		result.Maxconn = derefInt(backend.DefaultServer.Maxconn)
+		result.Minconn = derefInt(backend.DefaultServer.Minconn)
+		result.Maxqueue = derefInt(backend.DefaultServer.Maxqueue)
+	}
 
+	result.ResourceVersion = resourceVersion(result)
 	return result
 }
 
-// convertBackendFromProto converts pb.Backend to v3.Backend
-func convertBackendFromProto(backend *pb.Backend) *v3.Backend {
+// convertBackendFromProto converts pb.Backend to dataplane.Backend
+func convertBackendFromProto(backend *pb.Backend) *dataplane.Backend {
 	if backend == nil {
 		return nil
 	}
 
 	mode := convertProxyMode(backend.Mode)
-	result := &v3.Backend{
+	result := &dataplane.Backend{Backend: v3.Backend{
 		Id:   intPtr(backend.Id),
 		Name: stringPtr(backend.Name),
 		Mode: mode,
+	}, Fullconn: optionalInt(backend.Fullconn)}
+	if backend.Disabled {
+		result.Disabled = boolPtr(true)
+	}
+	if backend.Httpchk {
+		result.AdvCheck = advCheckHTTP
+	}
+	if limits := convertConnectionLimitsFromProto(backend.Maxconn, backend.Minconn, backend.Maxqueue); limits != (dataplane.ConnectionLimits{}) {
+		result.DefaultServer = &dataplane.DefaultServer{ConnectionLimits: limits}
 	}
 
 	if backend.Balance != nil {
@@ -167,13 +225,13 @@ func convertBackendFromProto(backend *pb.Backend) *v3.Backend {
 	return result
 }
 
-// convertFrontendToProto converts v3.Frontend to pb.Frontend
-func convertFrontendToProto(frontend *v3.Frontend) *pb.Frontend {
+// convertFrontendToProto converts dataplane.Frontend to pb.Frontend
+func convertFrontendToProto(frontend *dataplane.Frontend) *pb.Frontend {
 	if frontend == nil {
 		return nil
 	}
 
-	return &pb.Frontend{
+	result := &pb.Frontend{
 		Id:             derefInt(frontend.Id),
 		Name:           derefString(frontend.Name),
 		DefaultBackend: derefString(frontend.DefaultBackend),
@@ -182,16 +240,18 @@ func convertFrontendToProto(frontend *v3.Frontend) *pb.Frontend {
 		Enabled:        derefBool(frontend.Enabled),
 		Mode:           convertProxyModeToProto(derefString(frontend.Mode)),
 	}
+	result.ResourceVersion = resourceVersion(result)
+	return result
 }
 
-// convertFrontendFromProto converts pb.Frontend to v3.Frontend
-func convertFrontendFromProto(frontend *pb.Frontend) *v3.Frontend {
+// convertFrontendFromProto converts pb.Frontend to dataplane.Frontend
+func convertFrontendFromProto(frontend *pb.Frontend) *dataplane.Frontend {
 	if frontend == nil {
 		return nil
 	}
 
 	mode := convertProxyMode(frontend.Mode)
-	return &v3.Frontend{
+	return &dataplane.Frontend{Frontend: v3.Frontend{
 		Id:             intPtr(frontend.Id),
 		Name:           stringPtr(frontend.Name),
 		DefaultBackend: stringPtr(frontend.DefaultBackend),
@@ -199,66 +259,137 @@ func convertFrontendFromProto(frontend *pb.Frontend) *v3.Frontend {
 		Disabled:       boolPtr(frontend.Disabled),
 		Enabled:        boolPtr(frontend.Enabled),
 		Mode:           &mode,
-	}
+	}}
 }
 
-// convertServerToProto converts v3.Server to pb.Server
-func convertServerToProto(server *v3.Server) *pb.Server {
+// convertServerToProto converts dataplane.Server to pb.Server
+func convertServerToProto(server *dataplane.Server) *pb.Server {
 	if server == nil {
 		return nil
 	}
 
-	return &pb.Server{
-		Id:      derefString(server.Id),
-		Name:    derefString(server.Name),
-		Address: derefString(server.Address),
-		Port:    derefInt(server.Port),
+	result := &pb.Server{
+		Id:          derefString(server.Id),
+		Name:        derefString(server.Name),
+		Address:     derefString(server.Address),
+		Port:        derefInt(server.Port),
+		Maxconn:     derefInt(server.Maxconn),
+		Minconn:     derefInt(server.Minconn),
+		Maxqueue:    derefInt(server.Maxqueue),
+		Maintenance: server.Maintenance == "enabled",
+	}
+	if server.Weight != nil {
+		weight := int32(*server.Weight)
+		result.Weight = &weight
+	}
+	switch {
+	case server.SendProxyV2 == "enabled":
+		result.SendProxy = pb.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2
+	case server.SendProxy == "enabled":
+		result.SendProxy = pb.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V1
 	}
+	result.ResourceVersion = resourceVersion(result)
+	return result
 }
 
-// convertServerFromProto converts pb.Server to v3.Server
-func convertServerFromProto(server *pb.Server) *v3.Server {
+// convertServerFromProto converts pb.Server to dataplane.Server
+func convertServerFromProto(server *pb.Server) *dataplane.Server {
 	if server == nil {
 		return nil
 	}
 
-	return &v3.Server{
-		Id:      stringPtr(server.Id),
-		Name:    stringPtr(server.Name),
-		Address: stringPtr(server.Address),
-		Port:    intPtr(server.Port),
+	result := &dataplane.Server{
+		Server: v3.Server{
+			Id:      stringPtr(server.Id),
+			Name:    stringPtr(server.Name),
+			Address: stringPtr(server.Address),
+			Port:    intPtr(server.Port),
+		},
+		ConnectionLimits: convertConnectionLimitsFromProto(server.Maxconn, server.Minconn, server.Maxqueue),
+	}
+	if server.Weight != nil {
+		result.Weight = intPtr(*server.Weight)
+	}
+	if server.Maintenance {
+		result.Maintenance = "enabled"
+	}
+	switch server.SendProxy {
+	case pb.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V1:
+		result.SendProxy = "enabled"
+	case pb.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2:
+		result.SendProxyV2 = "enabled"
+	}
+	return result
+}
+
+// validateConnectionLimits checks that the connection limits of a server or backend are not negative and that
+// minconn does not exceed maxconn
+func validateConnectionLimits(maxconn, minconn, maxqueue, fullconn int32) error {
+	if maxconn < 0 || minconn < 0 || maxqueue < 0 || fullconn < 0 {
+		return status.Errorf(codes.InvalidArgument, "connection limits must not be negative")
+	}
+	if minconn > 0 && maxconn > 0 && minconn > maxconn {
+		return status.Errorf(codes.InvalidArgument, "minconn %d exceeds maxconn %d", minconn, maxconn)
+	}
+	return nil
+}
+
+// validateServerSettings checks the connection limits and weight of a server
+func validateServerSettings(server *pb.Server) error {
+	if server.Weight != nil && (*server.Weight < 0 || *server.Weight > 256) {
+		return status.Errorf(codes.InvalidArgument, "weight must be between 0 and 256")
+	}
+	return validateConnectionLimits(server.Maxconn, server.Minconn, server.Maxqueue, 0)
+}
+
+// convertConnectionLimitsFromProto converts the connection limits of a server or backend; zero leaves a limit unset
+func convertConnectionLimitsFromProto(maxconn, minconn, maxqueue int32) dataplane.ConnectionLimits {
+	return dataplane.ConnectionLimits{
+		Maxconn:  optionalInt(maxconn),
+		Minconn:  optionalInt(minconn),
+		Maxqueue: optionalInt(maxqueue),
 	}
 }
 
-// convertBindToProto converts v3.Bind to pb.Bind
-func convertBindToProto(bind *v3.Bind) *pb.Bind {
+// convertBindToProto converts dataplane.Bind to pb.Bind
+func convertBindToProto(bind *dataplane.Bind) *pb.Bind {
 	if bind == nil {
 		return nil
 	}
 
-	return &pb.Bind{
-		Id:      derefString(bind.Id),
-		Name:    derefString(bind.Name),
-		Address: derefString(bind.Address),
-		Port:    derefInt(bind.Port),
-		V4V6:    derefBool(bind.V4V6),
-		V6Only:  derefBool(bind.V6Only),
+	result := &pb.Bind{
+		Id:             derefString(bind.Id),
+		Name:           derefString(bind.Name),
+		Address:        derefString(bind.Address),
+		Port:           derefInt(bind.Port),
+		V4V6:           derefBool(bind.V4V6),
+		V6Only:         derefBool(bind.V6Only),
+		Ssl:            derefBool(bind.SSL),
+		SslCertificate: derefString(bind.SSLCertificate),
+		AcceptProxy:    derefBool(bind.AcceptProxy),
 	}
+	result.ResourceVersion = resourceVersion(result)
+	return result
 }
 
-// convertBindFromProto converts pb.Bind to v3.Bind
-func convertBindFromProto(bind *pb.Bind) *v3.Bind {
+// convertBindFromProto converts pb.Bind to dataplane.Bind
+func convertBindFromProto(bind *pb.Bind) *dataplane.Bind {
 	if bind == nil {
 		return nil
 	}
 
-	return &v3.Bind{
-		Id:      stringPtr(bind.Id),
-		Name:    stringPtr(bind.Name),
-		Address: stringPtr(bind.Address),
-		Port:    intPtr(bind.Port),
-		V4V6:    boolPtr(bind.V4V6),
-		V6Only:  boolPtr(bind.V6Only),
+	return &dataplane.Bind{
+		Bind: v3.Bind{
+			Id:      stringPtr(bind.Id),
+			Name:    stringPtr(bind.Name),
+			Address: stringPtr(bind.Address),
+			Port:    intPtr(bind.Port),
+			V4V6:    boolPtr(bind.V4V6),
+			V6Only:  boolPtr(bind.V6Only),
+		},
+		SSL:            boolPtr(bind.Ssl),
+		SSLCertificate: stringPtr(bind.SslCertificate),
+		AcceptProxy:    boolPtr(bind.AcceptProxy),
 	}
 }
 
@@ -273,3 +404,72 @@ func convertTransactionToProto(transaction *v3.Transaction) *pb.Transaction {
 		Status: derefString(transaction.Status),
 	}
 }
+
+// convertNetplanChangesToProto converts the changes of a Netplan transaction to pb.NetplanChange
+func convertNetplanChangesToProto(changes []netplan.TransactionChange) []*pb.NetplanChange {
+	var result []*pb.NetplanChange
+	for _, change := range changes {
+		result = append(result, &pb.NetplanChange{
+			Operation:  change.Operation,
+			IpAddress:  change.IPAddress,
+			Interface:  change.Interface,
+			SubnetMask: change.SubnetMask,
+			Port:       int32(change.Port),
+		})
+	}
+	return result
+}
+
+// convertNetplanTransactionToProto converts netplan.Transaction to pb.NetplanTransaction
+func convertNetplanTransactionToProto(transaction *netplan.Transaction) *pb.NetplanTransaction {
+	result := &pb.NetplanTransaction{
+		TransactionId: transaction.TransactionID,
+		Status:        transaction.Status,
+		CreatedAt:     timestamppb.New(transaction.CreatedAt),
+		Changes:       convertNetplanChangesToProto(transaction.Changes),
+		Error:         transaction.Error,
+	}
+	if transaction.FailedChange != nil {
+		index := int32(*transaction.FailedChange)
+		result.FailedChange = &index
+	}
+	if transaction.FailedAt != nil {
+		result.FailedAt = timestamppb.New(*transaction.FailedAt)
+	}
+	if transaction.CommittedAt != nil {
+		result.CommittedAt = timestamppb.New(*transaction.CommittedAt)
+	}
+	return result
+}
+
+// convertNetplanTransactionFromProto converts pb.NetplanTransaction to netplan.Transaction
+func convertNetplanTransactionFromProto(transaction *pb.NetplanTransaction) *netplan.Transaction {
+	result := &netplan.Transaction{
+		TransactionID: transaction.TransactionId,
+		Status:        transaction.Status,
+		CreatedAt:     transaction.CreatedAt.AsTime(),
+		Error:         transaction.Error,
+	}
+	if transaction.FailedChange != nil {
+		index := int(*transaction.FailedChange)
+		result.FailedChange = &index
+	}
+	if transaction.FailedAt != nil {
+		failedAt := transaction.FailedAt.AsTime()
+		result.FailedAt = &failedAt
+	}
+	if transaction.CommittedAt != nil {
+		committedAt := transaction.CommittedAt.AsTime()
+		result.CommittedAt = &committedAt
+	}
+	for _, change := range transaction.Changes {
+		result.Changes = append(result.Changes, netplan.TransactionChange{
+			Operation:  change.Operation,
+			IPAddress:  change.IpAddress,
+			Interface:  change.Interface,
+			SubnetMask: change.SubnetMask,
+			Port:       int(change.Port),
+		})
+	}
+	return result
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// http-check rules only run once option httpchk is set, so adding one sets it on the backend within the same
+// transaction. Rules are identified by their position, like the rules of frontends.
+
+// advCheckHTTP is the health check protocol of option httpchk
+const advCheckHTTP = "httpchk"
+
+var (
+	httpMethodPattern    = regexp.MustCompile(`^[A-Z]+$`)
+	httpVersionPattern   = regexp.MustCompile(`^HTTP/(1\.0|1\.1|2|2\.0)$`)
+	statusPatternPattern = regexp.MustCompile(`^[1-5][0-9]{2}(-[1-5][0-9]{2})?(,[1-5][0-9]{2}(-[1-5][0-9]{2})?)*$`)
+)
+
+// httpCheckMatches maps the matches of expect rules to their keywords
+var httpCheckMatches = map[pb.HTTPCheckMatch]string{
+	pb.HTTPCheckMatch_HTTP_CHECK_MATCH_UNSPECIFIED:  "status",
+	pb.HTTPCheckMatch_HTTP_CHECK_MATCH_STATUS:       "status",
+	pb.HTTPCheckMatch_HTTP_CHECK_MATCH_STATUS_REGEX: "rstatus",
+	pb.HTTPCheckMatch_HTTP_CHECK_MATCH_STRING:       "string",
+	pb.HTTPCheckMatch_HTTP_CHECK_MATCH_STRING_REGEX: "rstring",
+}
+
+// CreateHTTPCheck inserts an http-check rule into a backend within a transaction, enabling HTTP health checks
+// of the backend if needed
+func (s *HAProxyManagerServer) CreateHTTPCheck(ctx context.Context, req *pb.CreateHTTPCheckRequest) (*pb.CreateHTTPCheckResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	if err := validateHTTPCheck(req.Check); err != nil {
+		return nil, err
+	}
+
+	backend, err := client.GetBackend(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	checks, err := client.ListHTTPChecks(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	index := len(checks)
+	if req.Index != nil {
+		index = int(*req.Index)
+		if index < 0 || index > len(checks) {
+			return nil, status.Errorf(codes.InvalidArgument, "index must be between 0 and %d", len(checks))
+		}
+	}
+
+	check, err := client.AddHTTPCheck(ctx, req.BackendName, req.TransactionId, index, convertHTTPCheckFromProto(req.Check))
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	created := convertHTTPCheckToProto(check)
+	s.recordChange(resourceHTTPCheck, actionCreate, req.BackendName, strconv.Itoa(index), req.TransactionId, nil, created)
+
+	if backend.AdvCheck != advCheckHTTP {
+		updated := *backend
+		updated.AdvCheck = advCheckHTTP
+		replaced, err := client.ReplaceBackend(ctx, req.BackendName, updated, req.TransactionId)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		s.recordChange(resourceBackend, actionUpdate, "", req.BackendName, req.TransactionId, backend, replaced)
+	}
+
+	return &pb.CreateHTTPCheckResponse{Check: created, Index: int32(index)}, nil
+}
+
+// ListHTTPChecks retrieves the http-check rules of a backend in the order they run
+func (s *HAProxyManagerServer) ListHTTPChecks(ctx context.Context, req *pb.ListHTTPChecksRequest) (*pb.ListHTTPChecksResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+
+	backend, err := client.GetBackend(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	checks, err := client.ListHTTPChecks(ctx, req.BackendName, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	result := make([]*pb.HTTPCheck, 0, len(checks))
+	for i := range checks {
+		result = append(result, convertHTTPCheckToProto(&checks[i]))
+	}
+
+	return &pb.ListHTTPChecksResponse{Checks: result, Httpchk: backend.AdvCheck == advCheckHTTP}, nil
+}
+
+// UpdateHTTPCheck replaces the http-check rule of a backend at a position within a transaction
+func (s *HAProxyManagerServer) UpdateHTTPCheck(ctx context.Context, req *pb.UpdateHTTPCheckRequest) (*pb.UpdateHTTPCheckResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	if err := validateHTTPCheck(req.Check); err != nil {
+		return nil, err
+	}
+
+	previous, err := findHTTPCheck(ctx, client, req.BackendName, req.TransactionId, int(req.Index))
+	if err != nil {
+		return nil, err
+	}
+	check, err := client.ReplaceHTTPCheck(ctx, req.BackendName, req.TransactionId, int(req.Index), convertHTTPCheckFromProto(req.Check))
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	updated := convertHTTPCheckToProto(check)
+
+	s.recordChange(resourceHTTPCheck, actionUpdate, req.BackendName, strconv.Itoa(int(req.Index)), req.TransactionId, previous, updated)
+
+	return &pb.UpdateHTTPCheckResponse{Check: updated}, nil
+}
+
+// DeleteHTTPCheck removes the http-check rule of a backend at a position within a transaction. HTTP health
+// checks stay enabled when the last rule is removed, falling back to the request of option httpchk.
+func (s *HAProxyManagerServer) DeleteHTTPCheck(ctx context.Context, req *pb.DeleteHTTPCheckRequest) (*pb.DeleteHTTPCheckResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+
+	previous, err := findHTTPCheck(ctx, client, req.BackendName, req.TransactionId, int(req.Index))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.DeleteHTTPCheck(ctx, req.BackendName, req.TransactionId, int(req.Index)); err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	s.recordChange(resourceHTTPCheck, actionDelete, req.BackendName, strconv.Itoa(int(req.Index)), req.TransactionId, previous, nil)
+
+	return &pb.DeleteHTTPCheckResponse{}, nil
+}
+
+// findHTTPCheck returns the http-check rule of a backend at a position
+func findHTTPCheck(ctx context.Context, client DataplaneClient, backend, transactionID string, index int) (*pb.HTTPCheck, error) {
+	checks, err := client.ListHTTPChecks(ctx, backend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	if index < 0 || index >= len(checks) {
+		return nil, status.Errorf(codes.NotFound, "http-check rule %d not found in backend %s", index, backend)
+	}
+	return convertHTTPCheckToProto(&checks[index]), nil
+}
+
+// validateHTTPCheck checks that a rule sets only the fields of its type and that they fit on a line of
+// haproxy.cfg
+func validateHTTPCheck(check *pb.HTTPCheck) error {
+	if check == nil {
+		return status.Errorf(codes.InvalidArgument, "http-check rule is required")
+	}
+	for _, value := range []string{check.Uri, check.Body, check.Pattern} {
+		if strings.ContainsAny(value, "\r\n") {
+			return status.Errorf(codes.InvalidArgument, "http-check values must be single lines")
+		}
+	}
+
+	switch check.Type {
+	case pb.HTTPCheckType_HTTP_CHECK_TYPE_SEND:
+		switch {
+		case check.Match != pb.HTTPCheckMatch_HTTP_CHECK_MATCH_UNSPECIFIED || check.Pattern != "" || check.Negate:
+			return status.Errorf(codes.InvalidArgument, "match, pattern and negate apply to expect rules only")
+		case check.Method != "" && !httpMethodPattern.MatchString(check.Method):
+			return status.Errorf(codes.InvalidArgument, "invalid method %q", check.Method)
+		case strings.ContainsAny(check.Uri, " \t"):
+			return status.Errorf(codes.InvalidArgument, "URI must not contain spaces")
+		case check.Version != "" && !httpVersionPattern.MatchString(check.Version):
+			return status.Errorf(codes.InvalidArgument, "version must be HTTP/1.0, HTTP/1.1 or HTTP/2")
+		}
+		for _, header := range check.Headers {
+			if !headerNamePattern.MatchString(header.Name) {
+				return status.Errorf(codes.InvalidArgument, "invalid header name %q", header.Name)
+			}
+			if strings.ContainsAny(header.Value, "\r\n") {
+				return status.Errorf(codes.InvalidArgument, "value of header %s must be a single line", header.Name)
+			}
+		}
+	case pb.HTTPCheckType_HTTP_CHECK_TYPE_EXPECT:
+		match := check.Match
+		switch {
+		case check.Method != "" || check.Uri != "" || check.Version != "" || len(check.Headers) > 0 || check.Body != "":
+			return status.Errorf(codes.InvalidArgument, "method, URI, version, headers and body apply to send rules only")
+		case httpCheckMatches[match] == "":
+			return status.Errorf(codes.InvalidArgument, "unknown match %v", match)
+		case check.Pattern == "":
+			return status.Errorf(codes.InvalidArgument, "pattern is required for expect rules")
+		case httpCheckMatches[match] == "status" && !statusPatternPattern.MatchString(check.Pattern):
+			return status.Errorf(codes.InvalidArgument, "status pattern must be a list of codes or ranges, e.g. 200,300-399")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "http-check type must be send or expect")
+	}
+	return nil
+}
+
+// convertHTTPCheckToProto converts an http-check rule to its protobuf message
+func convertHTTPCheckToProto(check *dataplane.HTTPCheck) *pb.HTTPCheck {
+	result := &pb.HTTPCheck{
+		Method:  check.Method,
+		Uri:     check.URI,
+		Version: check.Version,
+		Body:    check.Body,
+		Pattern: check.Pattern,
+		Negate:  check.ExclamationMark,
+	}
+	switch check.Type {
+	case "send":
+		result.Type = pb.HTTPCheckType_HTTP_CHECK_TYPE_SEND
+	case "expect":
+		result.Type = pb.HTTPCheckType_HTTP_CHECK_TYPE_EXPECT
+		for match, keyword := range httpCheckMatches {
+			if keyword == check.Match && match != pb.HTTPCheckMatch_HTTP_CHECK_MATCH_UNSPECIFIED {
+				result.Match = match
+			}
+		}
+	}
+	for _, header := range check.Headers {
+		result.Headers = append(result.Headers, &pb.HTTPCheckHeader{Name: header.Name, Value: header.Fmt})
+	}
+	return result
+}
+
+// convertHTTPCheckFromProto converts an http-check message to an http-check rule
+func convertHTTPCheckFromProto(check *pb.HTTPCheck) dataplane.HTTPCheck {
+	if check.Type == pb.HTTPCheckType_HTTP_CHECK_TYPE_EXPECT {
+		return dataplane.HTTPCheck{
+			Type:            "expect",
+			Match:           httpCheckMatches[check.Match],
+			Pattern:         check.Pattern,
+			ExclamationMark: check.Negate,
+		}
+	}
+	result := dataplane.HTTPCheck{
+		Type:    "send",
+		Method:  check.Method,
+		URI:     check.Uri,
+		Version: check.Version,
+		Body:    check.Body,
+	}
+	for _, header := range check.Headers {
+		result.Headers = append(result.Headers, dataplane.HTTPCheckHeader{Name: header.Name, Fmt: header.Value})
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"encoding/pem"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ports of the frontends created by CreateHTTPSFrontend unless given
+const (
+	defaultHTTPSPort = 443
+	defaultHTTPPort  = 80
+)
+
+// CreateHTTPSFrontend sets up TLS termination for a backend: it stores the certificate, then creates an HTTP
+// mode frontend with an SSL bind and optionally a companion frontend redirecting HTTP to HTTPS, all within
+// the transaction. The certificate is stored immediately and remains stored if the transaction is closed.
+func (s *HAProxyManagerServer) CreateHTTPSFrontend(ctx context.Context, req *pb.CreateHTTPSFrontendRequest) (*pb.CreateHTTPSFrontendResponse, error) {
+	client := s.dataplane(ctx)
+
+	if err := validateHTTPSFrontendRequest(req); err != nil {
+		return nil, err
+	}
+	port := req.Port
+	if port == 0 {
+		port = defaultHTTPSPort
+	}
+	httpPort := req.HttpPort
+	if httpPort == 0 {
+		httpPort = defaultHTTPPort
+	}
+	httpName := req.HttpFrontendName
+	if httpName == "" {
+		httpName = req.Name + "-http"
+	}
+	if req.RedirectHttp && (httpPort == port || httpName == req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "the redirecting frontend needs a name and port of its own")
+	}
+
+	certificate, err := s.storeCertificate(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+	certificateFile := certificate.File
+	if certificateFile == "" {
+		certificateFile = certificate.StorageName
+	}
+	response := &pb.CreateHTTPSFrontendResponse{CertificateFile: certificateFile}
+
+	response.Frontend, response.Bind, err = s.createFrontendWithBind(ctx, req.TransactionId, &pb.Frontend{
+		Name:           req.Name,
+		DefaultBackend: req.DefaultBackend,
+		Mode:           pb.ProxyMode_PROXY_MODE_HTTP,
+	}, &pb.Bind{
+		Name:           req.Name,
+		Address:        req.Address,
+		Port:           port,
+		Ssl:            true,
+		SslCertificate: certificateFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !req.RedirectHttp {
+		return response, nil
+	}
+
+	response.HttpFrontend, response.HttpBind, err = s.createFrontendWithBind(ctx, req.TransactionId, &pb.Frontend{
+		Name:        httpName,
+		Description: "Redirects to HTTPS frontend " + req.Name,
+		Mode:        pb.ProxyMode_PROXY_MODE_HTTP,
+	}, &pb.Bind{
+		Name:    httpName,
+		Address: req.Address,
+		Port:    httpPort,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code := 301
+	redirect := dataplane.HTTPRequestRule{Type: "redirect", RedirType: "scheme", RedirValue: "https", RedirCode: &code}
+	if _, err := client.AddHTTPRequestRule(ctx, httpName, req.TransactionId, 0, redirect); err != nil {
+		return nil, stepError(err, "add the HTTPS redirect to frontend "+httpName)
+	}
+	return response, nil
+}
+
+// validateHTTPSFrontendRequest checks the required fields and that a given certificate is usable
+func validateHTTPSFrontendRequest(req *pb.CreateHTTPSFrontendRequest) error {
+	switch {
+	case req.TransactionId == "":
+		return status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	case req.Name == "":
+		return status.Errorf(codes.InvalidArgument, "frontend name is required")
+	case req.DefaultBackend == "":
+		return status.Errorf(codes.InvalidArgument, "default backend is required")
+	case req.Address == "":
+		return status.Errorf(codes.InvalidArgument, "address is required")
+	case req.CertificateName == "":
+		return status.Errorf(codes.InvalidArgument, "certificate name is required")
+	case strings.ContainsAny(req.CertificateName, "/\\"):
+		return status.Errorf(codes.InvalidArgument, "certificate name must not contain path separators")
+	case req.Port < 0 || req.Port > 65535 || req.HttpPort < 0 || req.HttpPort > 65535:
+		return status.Errorf(codes.InvalidArgument, "ports must be between 1 and 65535")
+	}
+	if req.CertificatePem == "" {
+		if req.OverwriteCertificate {
+			return status.Errorf(codes.InvalidArgument, "overwrite_certificate requires certificate_pem")
+		}
+		return nil
+	}
+	return validateCertificatePEM(req.CertificatePem)
+}
+
+// validateCertificatePEM checks that a PEM bundle holds a certificate and a private key, as HAProxy expects
+// both in the file referenced by a bind
+func validateCertificatePEM(bundle string) error {
+	var certificate, key bool
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			certificate = true
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			key = true
+		}
+	}
+	if !certificate || !key {
+		return status.Errorf(codes.InvalidArgument, "certificate_pem must contain a PEM certificate and its private key")
+	}
+	return nil
+}
+
+// storeCertificate uploads the certificate of the request, or looks up the stored one if none is given
+func (s *HAProxyManagerServer) storeCertificate(ctx context.Context, client DataplaneClient, req *pb.CreateHTTPSFrontendRequest) (*dataplane.Certificate, error) {
+	if req.CertificatePem == "" {
+		certificate, err := client.GetCertificate(ctx, req.CertificateName)
+		if v3.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "certificate %s is not stored; set certificate_pem to upload it", req.CertificateName)
+		}
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		return certificate, nil
+	}
+
+	certificate, err := client.AddCertificate(ctx, req.CertificateName, req.CertificatePem)
+	if v3.IsConflict(err) && req.OverwriteCertificate {
+		certificate, err = client.ReplaceCertificate(ctx, req.CertificateName, req.CertificatePem)
+	}
+	if v3.IsConflict(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "certificate %s is already stored; set overwrite_certificate to replace it", req.CertificateName)
+	}
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	return certificate, nil
+}
+
+// createFrontendWithBind creates a frontend and its bind within a transaction, assigning the VIP of the bind
+// with Netplan
+func (s *HAProxyManagerServer) createFrontendWithBind(ctx context.Context, transactionID string, frontend *pb.Frontend, bind *pb.Bind) (*pb.Frontend, *pb.Bind, error) {
+	createdFrontend, err := s.CreateFrontend(ctx, &pb.CreateFrontendRequest{TransactionId: transactionID, Frontend: frontend})
+	if err != nil {
+		return nil, nil, stepError(err, "create frontend "+frontend.Name)
+	}
+	createdBind, err := s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{TransactionId: transactionID, FrontendName: frontend.Name, Bind: bind})
+	if err != nil {
+		return nil, nil, stepError(err, "create bind "+bind.Name)
+	}
+	return createdFrontend.Frontend, createdBind.Bind, nil
+}
+
+// stepError reports a failed step of a composite RPC, keeping its status code. Errors from the Data Plane API
+// are converted first.
+func stepError(err error, step string) error {
+	converted, ok := status.FromError(err)
+	if !ok {
+		converted = status.Convert(handleHAProxyError(err))
+	}
+	return status.Errorf(converted.Code(), "failed to %s (earlier changes remain in the transaction): %s", step, converted.Message())
+}
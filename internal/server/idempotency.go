@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bear-san/haproxy-configurator/internal/idempotency"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyMetadataKey is the gRPC metadata key carrying the idempotency key of a call that changes the
+// configuration. A retried call with the same key returns the response of the first successful call.
+const IdempotencyKeyMetadataKey = "x-idempotency-key"
+
+// IdempotentReplayMetadataKey is set in the response header when the response is that of an earlier call
+const IdempotentReplayMetadataKey = "x-idempotent-replay"
+
+// UnaryIdempotencyInterceptor answers calls repeating the idempotency key of an earlier successful call with
+// its response instead of applying the change again. It must run after UnaryInstanceInterceptor, as keys
+// are scoped to the HAProxy instance.
+func (s *HAProxyManagerServer) UnaryIdempotencyInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := idempotencyKey(ctx)
+		request, ok := req.(proto.Message)
+		if key == "" || !ok || readOnlyMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		scoped := s.dataplane(ctx).Instance() + "\x00" + key
+		response, replayed, err := s.idempotency.Do(ctx, scoped, info.FullMethod, request, func() (proto.Message, error) {
+			response, err := handler(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return response.(proto.Message), nil
+		})
+		if errors.Is(err, idempotency.ErrKeyReused) {
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key %q was already used for a different request", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if replayed {
+			metrics.IdempotentReplays.WithLabelValues(info.FullMethod).Inc()
+			_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayMetadataKey, "true"))
+		}
+		return response, nil
+	}
+}
+
+// idempotencyKey returns the idempotency key of a call, or an empty string
+func idempotencyKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(IdempotencyKeyMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/buildinfo"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetServerInfo returns the build of the server, its enabled features and the Data Plane API version of the
+// selected instance. An unreachable Data Plane API is reported in the response rather than failing the call.
+func (s *HAProxyManagerServer) GetServerInfo(ctx context.Context, _ *pb.GetServerInfoRequest) (*pb.GetServerInfoResponse, error) {
+	build := buildinfo.Get()
+	client := s.dataplane(ctx)
+	response := &pb.GetServerInfoResponse{
+		Version:   build.Version,
+		Commit:    build.Commit,
+		BuildDate: build.Date,
+		GoVersion: build.GoVersion,
+		Features:  s.features(),
+		Instance:  client.Instance(),
+		Leader:    true,
+	}
+	if election := s.leaderElection(); election != nil {
+		response.Leader = election.IsLeader()
+		response.LeaderIdentity = election.Leader()
+	}
+
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		response.DataplaneApiError = handleHAProxyError(err).Error()
+	} else if info != nil {
+		response.DataplaneApiVersion = info.API.Version
+		response.DataplaneApiBuildDate = info.API.BuildDate
+	}
+	// Read after the call, which may have made the client fail over
+	response.DataplaneApiUrl = client.ActiveURL()
+	return response, nil
+}
+
+// GetStatus summarizes the state of the server: uptime, configuration file, Data Plane API connectivity of the
+// selected instance, open transactions and the Netplan integration. Like GetServerInfo, an unreachable Data Plane
+// API is reported in the response rather than failing the call.
+func (s *HAProxyManagerServer) GetStatus(ctx context.Context, _ *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	client := s.dataplane(ctx)
+	response := &pb.GetStatusResponse{
+		StartTime:  timestamppb.New(s.started),
+		Uptime:     durationpb.New(time.Since(s.started).Truncate(time.Second)),
+		ConfigFile: s.currentConfig().Path,
+		Instance:   client.Instance(),
+	}
+
+	start := time.Now()
+	_, err := client.GetInfo(ctx)
+	response.DataplaneApiLatency = durationpb.New(time.Since(start))
+	if err != nil {
+		response.DataplaneApiError = handleHAProxyError(err).Error()
+	} else {
+		response.DataplaneApiReachable = true
+	}
+
+	response.Freeze = s.freezeState()
+
+	s.transactionsMutex.Lock()
+	response.OpenTransactions = int32(len(s.transactions))
+	if !s.lastCommit.IsZero() {
+		response.LastCommitTime = timestamppb.New(s.lastCommit)
+	}
+	s.transactionsMutex.Unlock()
+
+	if netplanMgr := s.netplan(); netplanMgr != nil {
+		response.NetplanEnabled = true
+		response.TrackedVips = int32(len(netplanMgr.GetTrackedAddresses()))
+		if result := netplanMgr.LastApply(); result != nil {
+			response.LastNetplanApply = &pb.NetplanApplyResult{Time: timestamppb.New(result.Time), Success: result.Err == nil}
+			if result.Err != nil {
+				response.LastNetplanApply.Error = result.Err.Error()
+			}
+		}
+	}
+	return response, nil
+}
+
+// features lists the optional features enabled in the active configuration
+func (s *HAProxyManagerServer) features() []string {
+	cfg := s.currentConfig()
+	candidates := []struct {
+		name    string
+		enabled bool
+	}{
+		{"netplan", cfg.HasNetplanIntegration()},
+		{"grpc_tls", cfg.HasVault() && cfg.Vault.GRPCTLS.Path != ""},
+		{"dataplane_tls", strings.HasPrefix(cfg.HAProxy.APIURL, "https://")},
+		{"dataplane_mtls", cfg.HAProxy.TLS.ClientCert != ""},
+		{"dataplane_failover", len(cfg.HAProxy.FallbackAPIURLs) > 0},
+		{"vault", cfg.HasVault()},
+		{"journal", s.journal != nil},
+		{"metadata", s.metadataStore != nil},
+		{"webhooks", s.webhooks != nil},
+		{"gitops", cfg.HasGitOps()},
+		{"drift_detection", cfg.HasDriftDetection()},
+		{"kubernetes", cfg.HasKubernetesOperator() || cfg.HasKubernetesLoadBalancer()},
+		{"bgp", cfg.HasBGP()},
+		{"multi_instance", len(cfg.Instances) > 0},
+		{"cluster", cfg.HasCluster()},
+		{"leader_election", cfg.HasLeaderElection()},
+		{"peer_sync", cfg.HasPeerSync()},
+		{"dry_run", cfg.DryRun},
+		{"dataplane_record", cfg.DataplaneRecording.Mode == "record"},
+		{"dataplane_replay", cfg.DataplaneRecording.Mode == "replay"},
+		{"standalone", cfg.HasStandalone()},
+	}
+
+	var features []string
+	for _, candidate := range candidates {
+		if candidate.enabled {
+			features = append(features, candidate.name)
+		}
+	}
+	return features
+}
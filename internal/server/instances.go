@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"github.com/bear-san/haproxy-configurator/internal/standalone"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// InstanceMetadataKey is the gRPC metadata key selecting the HAProxy instance a call is routed to.
+// Calls without it go to the default instance, or to the instance that created their transaction.
+const InstanceMetadataKey = "x-haproxy-instance"
+
+// instanceContextKey stores the resolved Data Plane API client in the request context
+type instanceContextKey struct{}
+
+// transactionRequest is implemented by every request that carries a transaction ID
+type transactionRequest interface {
+	GetTransactionId() string
+}
+
+// newDataplaneClient creates the Data Plane API client for a named HAProxy instance, or the client writing its
+// haproxy.cfg in standalone mode; in dry-run mode it only logs the writes
+func newDataplaneClient(name string, settings config.HAProxySettings, dryRun bool, recording config.DataplaneRecordingSettings) DataplaneClient {
+	if settings.Standalone.Enabled {
+		logger.GetLogger().Info("Managing HAProxy instance in standalone mode",
+			zap.String("instance", name),
+			zap.String("config_file", settings.Standalone.ConfigFile),
+			zap.String("reload", settings.Standalone.Reload))
+		return standalone.New(name, settings.Standalone, dryRun, standalone.ExecRunner{})
+	}
+
+	var breaker *dataplane.CircuitBreaker
+	if !settings.CircuitBreaker.Disabled {
+		breaker = dataplane.NewCircuitBreaker(settings.CircuitBreaker.FailureThreshold,
+			time.Duration(settings.CircuitBreaker.OpenSeconds)*time.Second)
+	}
+
+	timeout, retry := dataplaneRequestPolicy(settings)
+	return dataplane.NewClient(name, dataplane.Endpoint{
+		BaseURL:    settings.APIURL,
+		Credential: encodeCredential(settings.Username, settings.Password),
+		HTTPClient: newDataplaneHTTPClient(name, settings),
+		Timeout:    timeout,
+		Retry:      retry,
+		Failover:   dataplaneFailoverPolicy(settings),
+		DryRun:     dryRun,
+		// Reads are only cached for the read RPCs, see readCache
+		ReadCacheTTL:        dataplaneReadCacheTTL(settings),
+		MaxOpenTransactions: settings.MaxOpenTransactions,
+		WrapTransport:       dataplaneRecording(name, recording),
+		RuntimeSocket:       settings.RuntimeSocket,
+	}, breaker)
+}
+
+// dataplaneRecording returns the transport wrapper recording the Data Plane API requests of an instance, or
+// answering them from its recording, as configured in dataplane_recording. An instance whose recording cannot be
+// loaded fails every request rather than reaching the Data Plane API.
+func dataplaneRecording(name string, recording config.DataplaneRecordingSettings) func(http.RoundTripper) http.RoundTripper {
+	path := dataplane.RecordingPath(recording.Dir, name)
+	switch recording.Mode {
+	case "record":
+		recorder, err := dataplane.NewRecorder(path)
+		if err != nil {
+			logger.GetLogger().Error("Failed to open Data Plane API recording, not recording",
+				zap.String("instance", name),
+				zap.Error(err))
+			return nil
+		}
+		logger.GetLogger().Warn("Recording Data Plane API requests",
+			zap.String("instance", name),
+			zap.String("path", path))
+		return recorder.Wrap
+	case "replay":
+		replayer, err := dataplane.NewReplayer(path)
+		if err != nil {
+			logger.GetLogger().Error("Failed to load Data Plane API recording, failing all requests",
+				zap.String("instance", name),
+				zap.Error(err))
+			replayer = &dataplane.Replayer{}
+		}
+		logger.GetLogger().Warn("Replaying Data Plane API requests from a recording",
+			zap.String("instance", name),
+			zap.String("path", path),
+			zap.Int("interactions", replayer.Remaining()))
+		return replayer.Wrap
+	default:
+		return nil
+	}
+}
+
+// readCache lets the reads of a read RPC be answered from the read cache of the Data Plane API client, unless
+// the request bypasses it. Handlers that write must not use it: they need the live configuration.
+func readCache(ctx context.Context, noCache bool) context.Context {
+	if noCache {
+		return ctx
+	}
+	return dataplane.WithReadCache(ctx)
+}
+
+// dataplaneRequestPolicy converts the configured per-request timeout and retry settings
+func dataplaneRequestPolicy(settings config.HAProxySettings) (time.Duration, dataplane.RetryPolicies) {
+	timeout := time.Duration(settings.RequestTimeoutSeconds) * time.Second
+	return timeout, dataplane.RetryPolicies{
+		Read:  dataplaneRetryPolicy(settings.Retry, settings.Retry.Reads),
+		Write: dataplaneRetryPolicy(settings.Retry, settings.Retry.Writes),
+	}
+}
+
+// dataplaneRetryPolicy converts the retry settings of a type of request
+func dataplaneRetryPolicy(retry config.RetrySettings, overrides config.OperationRetrySettings) dataplane.RetryPolicy {
+	settings := retry.For(overrides)
+	if settings.Disabled {
+		return dataplane.RetryPolicy{}
+	}
+	return dataplane.RetryPolicy{
+		MaxRetries:     settings.MaxRetries,
+		InitialBackoff: time.Duration(settings.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(settings.MaxBackoffMs) * time.Millisecond,
+		Jitter:         !retry.DisableJitter,
+	}
+}
+
+// dataplaneReadCacheTTL converts the configured read cache TTL
+func dataplaneReadCacheTTL(settings config.HAProxySettings) time.Duration {
+	return time.Duration(settings.ReadCacheTTLMs) * time.Millisecond
+}
+
+// dataplaneFailoverPolicy converts the configured fallback URLs and failover settings
+func dataplaneFailoverPolicy(settings config.HAProxySettings) dataplane.FailoverPolicy {
+	return dataplane.FailoverPolicy{
+		FallbackURLs:     settings.FallbackAPIURLs,
+		FailureThreshold: settings.Failover.FailureThreshold,
+		ProbeInterval:    time.Duration(settings.Failover.ProbeIntervalSeconds) * time.Second,
+	}
+}
+
+// newDataplaneHTTPClient creates the HTTP client for an instance. TLS settings are checked by
+// config validation; should they still fail to load, the default verifying TLS configuration is used.
+func newDataplaneHTTPClient(name string, settings config.HAProxySettings) *http.Client {
+	httpClient, err := dataplane.NewHTTPClient(settings)
+	if err != nil {
+		logger.GetLogger().Error("Failed to apply Data Plane API TLS settings, using defaults",
+			zap.String("instance", name),
+			zap.Error(err))
+		settings.TLS = config.DataplaneTLSSettings{}
+		httpClient, _ = dataplane.NewHTTPClient(settings)
+	}
+	return httpClient
+}
+
+// UnaryInstanceInterceptor resolves the HAProxy instance of each call and makes its client available to the handler
+func (s *HAProxyManagerServer) UnaryInstanceInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var transactionID string
+		if r, ok := req.(transactionRequest); ok {
+			transactionID = r.GetTransactionId()
+		}
+
+		client, err := s.resolveInstance(ctx, transactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, instanceContextKey{}, client), req)
+	}
+}
+
+// resolveInstance picks the client for a call from the instance metadata and the transaction's owner
+func (s *HAProxyManagerServer) resolveInstance(ctx context.Context, transactionID string) (DataplaneClient, error) {
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(InstanceMetadataKey); len(values) > 0 {
+			requested = values[0]
+		}
+	}
+
+	name := requested
+	if owner, ok := s.transactionInstance(transactionID); ok {
+		if requested != "" && requested != owner {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"transaction %s belongs to HAProxy instance %q, not %q", transactionID, owner, requested)
+		}
+		name = owner
+	}
+	if name == "" {
+		name = config.DefaultInstance
+	}
+
+	client, ok := s.instances[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown HAProxy instance %q", name)
+	}
+	return client, nil
+}
+
+// dataplane returns the Data Plane API client selected for the call, falling back to the default instance
+func (s *HAProxyManagerServer) dataplane(ctx context.Context) DataplaneClient {
+	if client, ok := ctx.Value(instanceContextKey{}).(DataplaneClient); ok {
+		return client
+	}
+	return s.client
+}
+
+// applyConcurrency returns how many changes of a batch may be sent at once to the instance of the call
+func (s *HAProxyManagerServer) applyConcurrency(ctx context.Context) int {
+	cfg := s.currentConfig()
+	name := s.dataplane(ctx).Instance()
+	for _, instance := range cfg.Instances {
+		if instance.Name == name {
+			return instance.ApplyConcurrency
+		}
+	}
+	return cfg.HAProxy.ApplyConcurrency
+}
+
+// netplanFor returns the Netplan manager if the client targets the local (default) instance.
+// Addresses of remote instances are not managed on this host.
+func (s *HAProxyManagerServer) netplanFor(client DataplaneClient) *netplan.Manager {
+	if client != s.client {
+		return nil
+	}
+	return s.netplan()
+}
+
+// trackTransaction remembers which instance a transaction was created on
+func (s *HAProxyManagerServer) trackTransaction(transactionID, instance string) {
+	s.transactionsMutex.Lock()
+	defer s.transactionsMutex.Unlock()
+	s.transactions[transactionID] = instance
+}
+
+// untrackTransaction forgets a committed or closed transaction
+func (s *HAProxyManagerServer) untrackTransaction(transactionID string) {
+	s.transactionsMutex.Lock()
+	defer s.transactionsMutex.Unlock()
+	delete(s.transactions, transactionID)
+}
+
+// commitTransaction forgets a committed transaction and remembers when it was committed
+func (s *HAProxyManagerServer) commitTransaction(transactionID string) {
+	s.transactionsMutex.Lock()
+	defer s.transactionsMutex.Unlock()
+	delete(s.transactions, transactionID)
+	s.lastCommit = time.Now()
+}
+
+// transactionInstance returns the instance a transaction was created on, if known
+func (s *HAProxyManagerServer) transactionInstance(transactionID string) (string, bool) {
+	if transactionID == "" {
+		return "", false
+	}
+
+	s.transactionsMutex.Lock()
+	defer s.transactionsMutex.Unlock()
+	instance, ok := s.transactions[transactionID]
+	return instance, ok
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Resource types recorded in the event journal
+const (
+	resourceBackend         = "backend"
+	resourceFrontend        = "frontend"
+	resourceBind            = "bind"
+	resourceServer          = "server"
+	resourceRoute           = "route"
+	resourceRateLimitPolicy = "rate_limit_policy"
+	resourceLuaScript       = "lua_script"
+	resourceLuaAction       = "lua_action"
+	resourceRing            = "ring"
+	resourceRingLogTarget   = "ring_log_target"
+	resourceProgram         = "program"
+	resourceHTTPCheck       = "http_check"
+	resourceTransaction     = "transaction"
+)
+
+// Actions recorded in the event journal
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+	actionCommit = "commit"
+	actionClose  = "close"
+)
+
+// ListEvents returns recorded configuration changes matching the request filters, newest first
+func (s *HAProxyManagerServer) ListEvents(_ context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	if s.journal == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "event journal is not configured")
+	}
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	filter := journal.Filter{
+		ResourceType:  req.ResourceType,
+		ResourceName:  req.ResourceName,
+		TransactionID: req.TransactionId,
+		Limit:         int(req.Limit),
+	}
+	if req.Since != nil {
+		filter.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		filter.Until = req.Until.AsTime()
+	}
+
+	events, err := s.journal.List(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query event journal: %v", err)
+	}
+
+	var pbEvents []*pb.Event
+	for i := range events {
+		pbEvents = append(pbEvents, convertEventToProto(&events[i]))
+	}
+
+	return &pb.ListEventsResponse{
+		Events: pbEvents,
+	}, nil
+}
+
+// recordChange appends a configuration change to the event journal, forwards it to the audit target and
+// publishes it to watchers.
+// Journal failures are logged and never fail the RPC that caused the change.
+func (s *HAProxyManagerServer) recordChange(resourceType, action, parentName, resourceName, transactionID string, oldValue, newValue interface{}) {
+	if s.journal == nil && s.audit == nil && s.changes.SubscriberCount() == 0 {
+		return
+	}
+
+	event := &journal.Event{
+		Timestamp:     time.Now(),
+		ResourceType:  resourceType,
+		ResourceName:  resourceName,
+		ParentName:    parentName,
+		Action:        action,
+		TransactionID: transactionID,
+		OldValue:      marshalEventValue(oldValue),
+		NewValue:      marshalEventValue(newValue),
+	}
+
+	if s.journal != nil {
+		if err := s.journal.Append(event); err != nil {
+			logger.GetLogger().Warn("Failed to record configuration change in event journal",
+				zap.String("resource_type", resourceType),
+				zap.String("resource_name", resourceName),
+				zap.String("action", action),
+				zap.String("transaction_id", transactionID),
+				zap.Error(err))
+		}
+	}
+
+	s.audit.Export(*event)
+	s.changes.Publish(*event)
+}
+
+// marshalEventValue encodes a resource for storage in the journal with credentials masked, returning nil for
+// absent values
+func marshalEventValue(value interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return logger.RedactJSON(data)
+}
+
+// convertEventToProto converts journal.Event to pb.Event
+func convertEventToProto(event *journal.Event) *pb.Event {
+	if event == nil {
+		return nil
+	}
+
+	return &pb.Event{
+		Id:            event.ID,
+		Timestamp:     timestamppb.New(event.Timestamp),
+		ResourceType:  event.ResourceType,
+		ResourceName:  event.ResourceName,
+		ParentName:    event.ParentName,
+		Action:        event.Action,
+		TransactionId: event.TransactionID,
+		OldValue:      string(event.OldValue),
+		NewValue:      string(event.NewValue),
+	}
+}
+
+// convertEventFromProto converts pb.Event to journal.Event
+func convertEventFromProto(event *pb.Event) journal.Event {
+	result := journal.Event{
+		ID:            event.Id,
+		Timestamp:     event.Timestamp.AsTime(),
+		ResourceType:  event.ResourceType,
+		ResourceName:  event.ResourceName,
+		ParentName:    event.ParentName,
+		Action:        event.Action,
+		TransactionID: event.TransactionId,
+	}
+	if event.OldValue != "" {
+		result.OldValue = json.RawMessage(event.OldValue)
+	}
+	if event.NewValue != "" {
+		result.NewValue = json.RawMessage(event.NewValue)
+	}
+	return result
+}
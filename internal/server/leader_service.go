@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/leader"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readOnlyPrefixes are the prefixes of the RPCs a standby still serves
+var readOnlyPrefixes = []string{"Get", "List", "Stream", "Export", "Diff", "Render", "Watch"}
+
+// SetLeaderElection makes the server reject changes unless the election is won
+func (s *HAProxyManagerServer) SetLeaderElection(election *leader.Election) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.election = election
+}
+
+// leaderElection returns the leader election, or nil if it is disabled
+func (s *HAProxyManagerServer) leaderElection() *leader.Election {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.election
+}
+
+// requireLeader fails with UNAVAILABLE while this instance is a standby, naming the leader to send changes to
+func (s *HAProxyManagerServer) requireLeader() error {
+	election := s.leaderElection()
+	if election == nil || election.IsLeader() {
+		return nil
+	}
+	if current := election.Leader(); current != "" {
+		return status.Errorf(codes.Unavailable, "this configurator is a standby, send changes to the leader %q", current)
+	}
+	return status.Errorf(codes.Unavailable, "this configurator is a standby and no leader is elected")
+}
+
+// localMethods are the RPCs that only change this configurator, which a standby serves as well
+var localMethods = []string{"ReloadConfig", "FreezeChanges", "UnfreezeChanges"}
+
+// changesConfiguration reports whether a unary call changes the configuration. Besides the read-only and local
+// RPCs, an orphan cleanup without remove only reports the orphans.
+func changesConfiguration(fullMethod string, req interface{}) bool {
+	if readOnlyMethod(fullMethod) || slices.Contains(localMethods, methodName(fullMethod)) {
+		return false
+	}
+	if r, ok := req.(*pb.CleanupOrphanedAddressesRequest); ok && !r.Remove {
+		return false
+	}
+	return true
+}
+
+// UnaryLeaderInterceptor rejects the calls that change the configuration while this instance is a standby
+func (s *HAProxyManagerServer) UnaryLeaderInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if changesConfiguration(info.FullMethod, req) {
+			if err := s.requireLeader(); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamLeaderInterceptor rejects the streaming calls that change the configuration, such as DrainServer, while
+// this instance is a standby
+func (s *HAProxyManagerServer) StreamLeaderInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !readOnlyMethod(info.FullMethod) {
+			if err := s.requireLeader(); err != nil {
+				return err
+			}
+		}
+		return handler(srv, stream)
+	}
+}
+
+// readOnlyMethod reports whether the RPC with the given full method name only reads
+func readOnlyMethod(fullMethod string) bool {
+	name := methodName(fullMethod)
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodName returns the name of the RPC of a full method name, e.g. "CreateBind"
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"cmp"
+	"net"
+	"slices"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// listFields are the attributes of a listed resource that can be filtered and ordered by
+type listFields struct {
+	name    string
+	mode    pb.ProxyMode
+	address string
+	port    int32
+	labels  map[string]string
+}
+
+// listQuery is a validated filter and ordering of a List RPC
+type listQuery struct {
+	filter     *pb.ListFilter
+	orderBy    string
+	descending bool
+	selector   labels.Selector // Nil unless the filter has a label selector
+}
+
+// newListQuery validates a filter and order_by against the fields the resource type has
+func newListQuery(resource string, filter *pb.ListFilter, orderBy string, fields ...string) (*listQuery, error) {
+	if filter == nil {
+		filter = &pb.ListFilter{}
+	}
+	if filter.Mode != pb.ProxyMode_PROXY_MODE_UNSPECIFIED && !slices.Contains(fields, "mode") {
+		return nil, status.Errorf(codes.InvalidArgument, "%ss cannot be filtered by mode", resource)
+	}
+	if (filter.Address != "" || filter.Port != 0) && !slices.Contains(fields, "address") {
+		return nil, status.Errorf(codes.InvalidArgument, "%ss cannot be filtered by address or port", resource)
+	}
+	if filter.Port < 0 || filter.Port > 65535 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid port filter: %d", filter.Port)
+	}
+
+	query := &listQuery{filter: filter}
+	if filter.LabelSelector != "" {
+		selector, err := labels.Parse(filter.LabelSelector)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label selector: %v", err)
+		}
+		query.selector = selector
+	}
+	query.orderBy, query.descending = strings.CutPrefix(orderBy, "-")
+	if query.orderBy != "" && !slices.Contains(fields, query.orderBy) {
+		return nil, status.Errorf(codes.InvalidArgument, "%ss cannot be ordered by %q, expected one of %s",
+			resource, query.orderBy, strings.Join(fields, ", "))
+	}
+	return query, nil
+}
+
+// matches reports whether a resource passes the filter
+func (q *listQuery) matches(fields listFields) bool {
+	filter := q.filter
+	if !strings.HasPrefix(fields.name, filter.NamePrefix) {
+		return false
+	}
+	if filter.Mode != pb.ProxyMode_PROXY_MODE_UNSPECIFIED && fields.mode != filter.Mode {
+		return false
+	}
+	if filter.Address != "" && !sameAddress(fields.address, filter.Address) {
+		return false
+	}
+	if q.selector != nil && !q.selector.Matches(labels.Set(fields.labels)) {
+		return false
+	}
+	return filter.Port == 0 || fields.port == filter.Port
+}
+
+// compare orders two resources by the requested field, falling back to the name
+func (q *listQuery) compare(a, b listFields) int {
+	var result int
+	switch q.orderBy {
+	case "mode":
+		result = cmp.Compare(a.mode, b.mode)
+	case "address":
+		result = compareAddresses(a.address, b.address)
+	case "port":
+		result = cmp.Compare(a.port, b.port)
+	}
+	if result == 0 {
+		result = strings.Compare(a.name, b.name)
+	}
+	if q.descending {
+		return -result
+	}
+	return result
+}
+
+// applyListQuery filters and orders resources. The resources keep their configuration order if no order is
+// requested.
+func applyListQuery[T any](query *listQuery, resources []T, fields func(T) listFields) []T {
+	var result []T
+	for _, resource := range resources {
+		if query.matches(fields(resource)) {
+			result = append(result, resource)
+		}
+	}
+	if query.orderBy != "" {
+		slices.SortStableFunc(result, func(a, b T) int {
+			return query.compare(fields(a), fields(b))
+		})
+	}
+	return result
+}
+
+// sameAddress compares two addresses, treating different notations of the same IP as equal
+func sameAddress(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA != nil && ipB != nil {
+		return ipA.Equal(ipB)
+	}
+	return a == b
+}
+
+// compareAddresses orders IP addresses numerically, with IPv4 before IPv6 and host names last
+func compareAddresses(a, b string) int {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	switch {
+	case ipA == nil && ipB == nil:
+		return strings.Compare(a, b)
+	case ipA == nil:
+		return 1
+	case ipB == nil:
+		return -1
+	}
+	if v4A, v4B := ipA.To4(), ipB.To4(); (v4A == nil) != (v4B == nil) {
+		if v4A != nil {
+			return -1
+		}
+		return 1
+	}
+	return slices.Compare(ipA.To16(), ipB.To16())
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the ID of a call. An ID sent by the client is kept,
+// otherwise one is generated; either way it is returned in the response header and logged with every entry
+// of the call.
+const RequestIDMetadataKey = "x-request-id"
+
+// forwardedForMetadataKey carries the address of REST clients, set by the gateway
+const forwardedForMetadataKey = "x-forwarded-for"
+
+// UnaryLoggingInterceptor attaches the request ID, client, method and HAProxy transaction ID of each call to
+// the log entries emitted while servicing it. It must run first, so that the other interceptors log them too.
+func (s *HAProxyManagerServer) UnaryLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestFields(ctx, info.FullMethod)
+		if r, ok := req.(transactionRequest); ok && r.GetTransactionId() != "" {
+			ctx = logger.WithFields(ctx, zap.String(logger.FieldHAProxyTxnID, r.GetTransactionId()))
+		}
+
+		start := time.Now()
+		response, err := handler(ctx, req)
+		logger.FromContext(ctx).Debug("Handled request",
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("duration", time.Since(start)))
+		return response, err
+	}
+}
+
+// StreamLoggingInterceptor attaches the request ID, client and method of each stream to the log entries
+// emitted while servicing it
+func (s *HAProxyManagerServer) StreamLoggingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &loggingStream{ServerStream: stream, ctx: withRequestFields(stream.Context(), info.FullMethod)})
+	}
+}
+
+// loggingStream is a server stream whose context carries the log fields of the call
+type loggingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream with the log fields attached
+func (s *loggingStream) Context() context.Context {
+	return s.ctx
+}
+
+// withRequestFields attaches the request ID, client and method of a call to its context and returns the
+// request ID in the response header
+func withRequestFields(ctx context.Context, method string) context.Context {
+	requestID := metadataValue(ctx, RequestIDMetadataKey)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+
+	return logger.WithFields(ctx,
+		zap.String(logger.FieldRequestID, requestID),
+		zap.String(logger.FieldClient, clientAddress(ctx)),
+		zap.String(logger.FieldMethod, method))
+}
+
+// clientAddress identifies the client of a call: the address a REST call was forwarded for, or the peer address
+func clientAddress(ctx context.Context) string {
+	if forwarded := metadataValue(ctx, forwardedForMetadataKey); forwarded != "" {
+		client, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(client)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// metadataValue returns the first value of an incoming metadata key, or an empty string
+func metadataValue(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// newRequestID generates a random request ID
+func newRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
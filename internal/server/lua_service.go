@@ -0,0 +1,594 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/luascript"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lua scripts are stored as general files, one per version, and loaded with lua-load in the global section.
+// Their actions run as http-request or tcp-request rules of type lua, identified by the name of the action.
+
+var luaActionPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// luaScripts is the state of the Lua scripts: their stored versions and the global section loading them
+type luaScripts struct {
+	versions map[string][]luaVersion // By script, oldest first
+	global   *dataplane.Global
+}
+
+// luaVersion is a stored version of a script
+type luaVersion struct {
+	version int
+	file    dataplane.StorageFile
+}
+
+// UploadLuaScript validates a script and stores it as a new version, loading it within a transaction if requested
+func (s *HAProxyManagerServer) UploadLuaScript(ctx context.Context, req *pb.UploadLuaScriptRequest) (*pb.UploadLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if err := luascript.ValidateName(req.Name); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := luascript.Validate(req.Content); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid Lua script: %v", err)
+	}
+	if req.Load && req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required to load the script")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	previous := scripts.script(req.Name)
+
+	version := 1
+	if existing := scripts.versions[req.Name]; len(existing) > 0 {
+		version = existing[len(existing)-1].version + 1
+	}
+	file, err := client.AddStorageFile(ctx, luascript.FileName(req.Name, version), req.Content)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	if file.Size == nil {
+		size := len(req.Content)
+		file.Size = &size
+	}
+	scripts.versions[req.Name] = append(scripts.versions[req.Name], luaVersion{version: version, file: *file})
+
+	if req.Load {
+		if err := scripts.setLoaded(ctx, client, req.TransactionId, req.Name, version); err != nil {
+			return nil, err
+		}
+	}
+
+	script := scripts.script(req.Name)
+	s.recordChange(resourceLuaScript, actionCreate, "", req.Name, req.TransactionId, previous, script)
+
+	return &pb.UploadLuaScriptResponse{Script: script, Version: convertLuaVersionToProto(scripts.versions[req.Name][len(scripts.versions[req.Name])-1])}, nil
+}
+
+// GetLuaScript retrieves a script with the content of one of its versions
+func (s *HAProxyManagerServer) GetLuaScript(ctx context.Context, req *pb.GetLuaScriptRequest) (*pb.GetLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "script name is required")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	versions := scripts.versions[req.Name]
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Lua script %s not found", req.Name)
+	}
+
+	number := int(req.Version)
+	if number == 0 {
+		if number, _ = scripts.loaded(req.Name); number == 0 {
+			number = versions[len(versions)-1].version
+		}
+	}
+	version, ok := scripts.find(req.Name, number)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "version %d of Lua script %s not found", number, req.Name)
+	}
+	content, err := client.GetStorageFile(ctx, version.file.StorageName)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	return &pb.GetLuaScriptResponse{Script: scripts.script(req.Name), Content: content, Actions: luascript.Actions(content)}, nil
+}
+
+// ListLuaScripts retrieves all scripts with their versions
+func (s *HAProxyManagerServer) ListLuaScripts(ctx context.Context, req *pb.ListLuaScriptsRequest) (*pb.ListLuaScriptsResponse, error) {
+	scripts, err := loadLuaScripts(ctx, s.dataplane(ctx), req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pb.LuaScript
+	for _, name := range slices.Sorted(maps.Keys(scripts.versions)) {
+		result = append(result, scripts.script(name))
+	}
+	return &pb.ListLuaScriptsResponse{Scripts: result}, nil
+}
+
+// LoadLuaScript loads a version of a script within a transaction, in place of the loaded one
+func (s *HAProxyManagerServer) LoadLuaScript(ctx context.Context, req *pb.LoadLuaScriptRequest) (*pb.LoadLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "script name is required")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	versions := scripts.versions[req.Name]
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Lua script %s not found", req.Name)
+	}
+	version := int(req.Version)
+	if version == 0 {
+		version = versions[len(versions)-1].version
+	}
+	if _, ok := scripts.find(req.Name, version); !ok {
+		return nil, status.Errorf(codes.NotFound, "version %d of Lua script %s not found", version, req.Name)
+	}
+
+	previous := scripts.script(req.Name)
+	if err := scripts.setLoaded(ctx, client, req.TransactionId, req.Name, version); err != nil {
+		return nil, err
+	}
+	script := scripts.script(req.Name)
+	s.recordChange(resourceLuaScript, actionUpdate, "", req.Name, req.TransactionId, previous, script)
+
+	return &pb.LoadLuaScriptResponse{Script: script}, nil
+}
+
+// RollbackLuaScript loads an earlier version of a loaded script within a transaction. If HAProxy rejects the
+// configuration, the commit fails and the version loaded before stays active.
+func (s *HAProxyManagerServer) RollbackLuaScript(ctx context.Context, req *pb.RollbackLuaScriptRequest) (*pb.RollbackLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "script name is required")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	loaded, _ := scripts.loaded(req.Name)
+	if loaded == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Lua script %s is not loaded", req.Name)
+	}
+
+	version := int(req.Version)
+	if version == 0 {
+		for _, v := range scripts.versions[req.Name] {
+			if v.version < loaded {
+				version = v.version
+			}
+		}
+		if version == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Lua script %s has no version before %d", req.Name, loaded)
+		}
+	}
+	if version >= loaded {
+		return nil, status.Errorf(codes.InvalidArgument, "version %d is not earlier than the loaded version %d", version, loaded)
+	}
+	if _, ok := scripts.find(req.Name, version); !ok {
+		return nil, status.Errorf(codes.NotFound, "version %d of Lua script %s not found", version, req.Name)
+	}
+
+	previous := scripts.script(req.Name)
+	if err := scripts.setLoaded(ctx, client, req.TransactionId, req.Name, version); err != nil {
+		return nil, err
+	}
+	script := scripts.script(req.Name)
+	s.recordChange(resourceLuaScript, actionUpdate, "", req.Name, req.TransactionId, previous, script)
+
+	return &pb.RollbackLuaScriptResponse{Script: script}, nil
+}
+
+// UnloadLuaScript removes the lua-load of a script within a transaction, keeping its versions
+func (s *HAProxyManagerServer) UnloadLuaScript(ctx context.Context, req *pb.UnloadLuaScriptRequest) (*pb.UnloadLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "script name is required")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if loaded, _ := scripts.loaded(req.Name); loaded == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Lua script %s is not loaded", req.Name)
+	}
+
+	previous := scripts.script(req.Name)
+	if err := scripts.setLoaded(ctx, client, req.TransactionId, req.Name, 0); err != nil {
+		return nil, err
+	}
+	script := scripts.script(req.Name)
+	s.recordChange(resourceLuaScript, actionUpdate, "", req.Name, req.TransactionId, previous, script)
+
+	return &pb.UnloadLuaScriptResponse{Script: script}, nil
+}
+
+// DeleteLuaScript deletes a version of a script, or all of them, from the storage. The version loaded by the
+// committed configuration cannot be deleted.
+func (s *HAProxyManagerServer) DeleteLuaScript(ctx context.Context, req *pb.DeleteLuaScriptRequest) (*pb.DeleteLuaScriptResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "script name is required")
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+	versions := scripts.versions[req.Name]
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Lua script %s not found", req.Name)
+	}
+	loaded, _ := scripts.loaded(req.Name)
+
+	previous := scripts.script(req.Name)
+	if req.Version != 0 {
+		version, ok := scripts.find(req.Name, int(req.Version))
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "version %d of Lua script %s not found", req.Version, req.Name)
+		}
+		versions = []luaVersion{version}
+	}
+	for _, version := range versions {
+		if version.version == loaded {
+			return nil, status.Errorf(codes.FailedPrecondition, "version %d of Lua script %s is loaded; unload it first", loaded, req.Name)
+		}
+	}
+	for _, version := range versions {
+		if err := client.DeleteStorageFile(ctx, version.file.StorageName); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	}
+
+	s.recordChange(resourceLuaScript, actionDelete, "", req.Name, "", previous, nil)
+
+	return &pb.DeleteLuaScriptResponse{}, nil
+}
+
+// CreateLuaAction runs an action registered by a loaded script at the end of the request rules of a frontend
+// within a transaction
+func (s *HAProxyManagerServer) CreateLuaAction(ctx context.Context, req *pb.CreateLuaActionRequest) (*pb.CreateLuaActionResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if err := validateLuaAction(req.Action); err != nil {
+		return nil, err
+	}
+
+	mode, err := frontendMode(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := listLuaActions(ctx, client, req.FrontendName, req.TransactionId, mode)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := actions[req.Action.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "Lua action %s already exists in frontend %s", req.Action.Name, req.FrontendName)
+	}
+
+	scripts, err := loadLuaScripts(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	registered, err := scripts.registeredActions(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(registered, req.Action.Name) {
+		return nil, status.Errorf(codes.FailedPrecondition, "no loaded Lua script registers action %s", req.Action.Name)
+	}
+
+	action := req.Action
+	if mode == "http" {
+		rules, err := client.ListHTTPRequestRules(ctx, req.FrontendName, req.TransactionId)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		rule := dataplane.HTTPRequestRule{Type: "lua", LuaAction: action.Name, LuaParams: action.Arguments, Cond: action.Cond, CondTest: action.CondTest}
+		if _, err := client.AddHTTPRequestRule(ctx, req.FrontendName, req.TransactionId, len(rules), rule); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	} else {
+		rules, err := client.ListTCPRequestRules(ctx, req.FrontendName, req.TransactionId)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		rule := dataplane.TCPRequestRule{Type: "content", Action: "lua", LuaAction: action.Name, LuaParams: action.Arguments, Cond: action.Cond, CondTest: action.CondTest}
+		if _, err := client.AddTCPRequestRule(ctx, req.FrontendName, req.TransactionId, len(rules), rule); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	}
+
+	s.recordChange(resourceLuaAction, actionCreate, req.FrontendName, action.Name, req.TransactionId, nil, action)
+
+	return &pb.CreateLuaActionResponse{Action: action}, nil
+}
+
+// ListLuaActions retrieves the Lua actions of a frontend in the order they run
+func (s *HAProxyManagerServer) ListLuaActions(ctx context.Context, req *pb.ListLuaActionsRequest) (*pb.ListLuaActionsResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+
+	mode, err := frontendMode(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	var result []*pb.LuaAction
+	if mode == "http" {
+		rules, err := client.ListHTTPRequestRules(ctx, req.FrontendName, req.TransactionId)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		for _, rule := range rules {
+			if rule.Type == "lua" {
+				result = append(result, &pb.LuaAction{Name: rule.LuaAction, Arguments: rule.LuaParams, Cond: rule.Cond, CondTest: rule.CondTest})
+			}
+		}
+	} else {
+		rules, err := client.ListTCPRequestRules(ctx, req.FrontendName, req.TransactionId)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		for _, rule := range rules {
+			if rule.Type == "content" && rule.Action == "lua" {
+				result = append(result, &pb.LuaAction{Name: rule.LuaAction, Arguments: rule.LuaParams, Cond: rule.Cond, CondTest: rule.CondTest})
+			}
+		}
+	}
+
+	return &pb.ListLuaActionsResponse{Actions: result}, nil
+}
+
+// DeleteLuaAction removes a Lua action from a frontend within a transaction
+func (s *HAProxyManagerServer) DeleteLuaAction(ctx context.Context, req *pb.DeleteLuaActionRequest) (*pb.DeleteLuaActionResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Lua action name is required")
+	}
+
+	mode, err := frontendMode(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := listLuaActions(ctx, client, req.FrontendName, req.TransactionId, mode)
+	if err != nil {
+		return nil, err
+	}
+	index, ok := actions[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Lua action %s not found in frontend %s", req.Name, req.FrontendName)
+	}
+	if mode == "http" {
+		err = client.DeleteHTTPRequestRule(ctx, req.FrontendName, req.TransactionId, index)
+	} else {
+		err = client.DeleteTCPRequestRule(ctx, req.FrontendName, req.TransactionId, index)
+	}
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	s.recordChange(resourceLuaAction, actionDelete, req.FrontendName, req.Name, req.TransactionId, nil, nil)
+
+	return &pb.DeleteLuaActionResponse{}, nil
+}
+
+// validateLuaAction checks the name, arguments and condition of a Lua action
+func validateLuaAction(action *pb.LuaAction) error {
+	switch {
+	case action == nil:
+		return status.Errorf(codes.InvalidArgument, "Lua action is required")
+	case !luaActionPattern.MatchString(action.Name):
+		return status.Errorf(codes.InvalidArgument, "Lua action name must start with a letter or \"_\" and consist of letters, digits, \"_\" and \".\"")
+	case strings.ContainsAny(action.Arguments+action.CondTest, "\r\n"):
+		return status.Errorf(codes.InvalidArgument, "Lua action arguments and condition must be on a single line")
+	case action.Cond != "" && action.Cond != "if" && action.Cond != "unless":
+		return status.Errorf(codes.InvalidArgument, "cond must be \"if\" or \"unless\"")
+	case (action.Cond == "") != (action.CondTest == ""):
+		return status.Errorf(codes.InvalidArgument, "cond and cond_test must be set together")
+	}
+	return nil
+}
+
+// frontendMode returns the mode of a frontend, "tcp" if unset like in HAProxy
+func frontendMode(ctx context.Context, client DataplaneClient, frontend, transactionID string) (string, error) {
+	current, err := client.GetFrontend(ctx, frontend, transactionID)
+	if err != nil {
+		return "", handleHAProxyError(err)
+	}
+	if mode := derefString(current.Mode); mode != "" {
+		return mode, nil
+	}
+	return "tcp", nil
+}
+
+// listLuaActions returns the positions of the Lua actions of a frontend among its request rules by name
+func listLuaActions(ctx context.Context, client DataplaneClient, frontend, transactionID, mode string) (map[string]int, error) {
+	actions := make(map[string]int)
+	if mode == "http" {
+		rules, err := client.ListHTTPRequestRules(ctx, frontend, transactionID)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		for i, rule := range rules {
+			if rule.Type == "lua" {
+				actions[rule.LuaAction] = i
+			}
+		}
+		return actions, nil
+	}
+
+	rules, err := client.ListTCPRequestRules(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for i, rule := range rules {
+		if rule.Type == "content" && rule.Action == "lua" {
+			actions[rule.LuaAction] = i
+		}
+	}
+	return actions, nil
+}
+
+// loadLuaScripts reads the stored versions of the scripts and the global section of a transaction
+func loadLuaScripts(ctx context.Context, client DataplaneClient, transactionID string) (*luaScripts, error) {
+	files, err := client.ListStorageFiles(ctx)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	global, err := client.GetGlobal(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	scripts := &luaScripts{versions: make(map[string][]luaVersion), global: global}
+	for _, file := range files {
+		if name, version, ok := luascript.ParseFileName(file.StorageName); ok {
+			scripts.versions[name] = append(scripts.versions[name], luaVersion{version: version, file: file})
+		}
+	}
+	for _, versions := range scripts.versions {
+		slices.SortFunc(versions, func(a, b luaVersion) int { return a.version - b.version })
+	}
+	return scripts, nil
+}
+
+// loaded returns the version of a script loaded by the global section and the position of its lua-load, or 0
+// and -1 if the script is not loaded
+func (l *luaScripts) loaded(name string) (int, int) {
+	for i, file := range l.global.LuaLoads {
+		if script, version, ok := luascript.ParseFileName(path.Base(file)); ok && script == name {
+			return version, i
+		}
+	}
+	return 0, -1
+}
+
+// find returns a stored version of a script
+func (l *luaScripts) find(name string, version int) (luaVersion, bool) {
+	for _, v := range l.versions[name] {
+		if v.version == version {
+			return v, true
+		}
+	}
+	return luaVersion{}, false
+}
+
+// setLoaded loads a version of a script in place of the loaded one, or unloads the script for version 0, and
+// replaces the global section of the transaction. Other lua-load entries keep their order.
+func (l *luaScripts) setLoaded(ctx context.Context, client DataplaneClient, transactionID, name string, version int) error {
+	_, index := l.loaded(name)
+	loads := slices.Clone(l.global.LuaLoads)
+	switch {
+	case version == 0:
+		loads = slices.Delete(loads, index, index+1)
+	case index >= 0:
+		v, _ := l.find(name, version)
+		loads[index] = v.file.File
+	default:
+		v, _ := l.find(name, version)
+		loads = append(loads, v.file.File)
+	}
+
+	global := *l.global
+	global.LuaLoads = loads
+	replaced, err := client.ReplaceGlobal(ctx, global, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	l.global = replaced
+	return nil
+}
+
+// registeredActions returns the actions registered by the loaded versions of the stored scripts
+func (l *luaScripts) registeredActions(ctx context.Context, client DataplaneClient) ([]string, error) {
+	var actions []string
+	for _, name := range slices.Sorted(maps.Keys(l.versions)) {
+		loaded, _ := l.loaded(name)
+		version, ok := l.find(name, loaded)
+		if !ok {
+			continue
+		}
+		content, err := client.GetStorageFile(ctx, version.file.StorageName)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		actions = append(actions, luascript.Actions(content)...)
+	}
+	return actions, nil
+}
+
+// script returns a script with its versions and loaded version, or nil if it has no versions
+func (l *luaScripts) script(name string) *pb.LuaScript {
+	versions := l.versions[name]
+	if len(versions) == 0 {
+		return nil
+	}
+	script := &pb.LuaScript{Name: name}
+	for _, version := range versions {
+		script.Versions = append(script.Versions, convertLuaVersionToProto(version))
+	}
+	loaded, _ := l.loaded(name)
+	script.LoadedVersion = int32(loaded)
+	return script
+}
+
+// convertLuaVersionToProto converts a stored version of a script
+func convertLuaVersionToProto(version luaVersion) *pb.LuaScriptVersion {
+	return &pb.LuaScriptVersion{Version: int32(version.version), File: version.file.File, Size: int64(derefInt(version.file.Size))}
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maintenanceEnabled is the value of the maintenance flag of a server in maintenance
+const maintenanceEnabled = "enabled"
+
+// EnterMaintenance puts a server, or every server of a backend, into maintenance in the running process at once.
+// With persist the server or backend is also disabled in the configuration, in the given transaction or in one
+// of its own, so maintenance survives reloads and restarts.
+func (s *HAProxyManagerServer) EnterMaintenance(ctx context.Context, req *pb.EnterMaintenanceRequest) (*pb.EnterMaintenanceResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	servers, err := maintenanceServers(ctx, client, req.BackendName, req.ServerName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, server := range servers {
+		if _, err := client.SetServerAdminState(ctx, req.BackendName, derefString(server.Name), dataplane.AdminStateMaint); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	}
+
+	response := &pb.EnterMaintenanceResponse{}
+	persisted := false
+	if req.Persist {
+		persisted, err = isMaintenancePersisted(ctx, client, req.BackendName, req.ServerName, req.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+		if !persisted {
+			response.Transaction, err = s.inTransaction(ctx, req.TransactionId, func(transactionID string) error {
+				return s.persistMaintenance(ctx, client, req.BackendName, req.ServerName, transactionID, true)
+			})
+			if err != nil {
+				return nil, err
+			}
+			persisted = true
+		}
+	}
+
+	logger.FromContext(ctx).Info("Entered maintenance",
+		zap.String("backend_name", req.BackendName),
+		zap.String("server_name", req.ServerName),
+		zap.Bool("persisted", persisted))
+
+	response.Entries = maintenanceResult(req.BackendName, req.ServerName, servers, true, persisted)
+	return response, nil
+}
+
+// ExitMaintenance makes a server, or every server of a backend, ready again in the running process and clears
+// its persisted flag. Exiting a backend leaves servers that were persisted in maintenance on their own there.
+func (s *HAProxyManagerServer) ExitMaintenance(ctx context.Context, req *pb.ExitMaintenanceRequest) (*pb.ExitMaintenanceResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.BackendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+	servers, err := maintenanceServers(ctx, client, req.BackendName, req.ServerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The flag is cleared first, so a reload in between does not put the servers back into maintenance
+	response := &pb.ExitMaintenanceResponse{}
+	persisted, err := isMaintenancePersisted(ctx, client, req.BackendName, req.ServerName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if persisted {
+		response.Transaction, err = s.inTransaction(ctx, req.TransactionId, func(transactionID string) error {
+			return s.persistMaintenance(ctx, client, req.BackendName, req.ServerName, transactionID, false)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ready []dataplane.Server
+	for _, server := range servers {
+		if req.ServerName == "" && server.Maintenance == maintenanceEnabled {
+			continue
+		}
+		if _, err := client.SetServerAdminState(ctx, req.BackendName, derefString(server.Name), dataplane.AdminStateReady); err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		ready = append(ready, server)
+	}
+
+	logger.FromContext(ctx).Info("Exited maintenance",
+		zap.String("backend_name", req.BackendName),
+		zap.String("server_name", req.ServerName))
+
+	response.Entries = maintenanceResult(req.BackendName, req.ServerName, ready, false, false)
+	return response, nil
+}
+
+// ListMaintenance returns the backends and servers in maintenance, in the running process or persisted
+func (s *HAProxyManagerServer) ListMaintenance(ctx context.Context, req *pb.ListMaintenanceRequest) (*pb.ListMaintenanceResponse, error) {
+	client := s.dataplane(ctx)
+
+	var backends []dataplane.Backend
+	if req.BackendName != "" {
+		backend, err := client.GetBackend(ctx, req.BackendName, "")
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		backends = append(backends, *backend)
+	} else {
+		var err error
+		backends, err = client.ListBackends(ctx, "")
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+	}
+
+	response := &pb.ListMaintenanceResponse{}
+	for _, backend := range backends {
+		name := derefString(backend.Name)
+		if isRateLimitBackend(name) {
+			continue
+		}
+		servers, err := client.ListServers(ctx, name, "")
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+
+		// A disabled backend is not running, so its servers have no runtime state
+		states := make(map[string]string)
+		if !derefBool(backend.Disabled) {
+			runtime, err := client.ListRuntimeServers(ctx, name)
+			if err != nil {
+				return nil, handleHAProxyError(err)
+			}
+			for _, server := range runtime {
+				states[server.Name] = server.AdminState
+			}
+		}
+
+		var entries []*pb.MaintenanceEntry
+		inRuntime := 0
+		for _, server := range servers {
+			entry := &pb.MaintenanceEntry{
+				BackendName: name,
+				ServerName:  derefString(server.Name),
+				Runtime:     states[derefString(server.Name)] == dataplane.AdminStateMaint,
+				Persisted:   server.Maintenance == maintenanceEnabled,
+			}
+			if entry.Runtime {
+				inRuntime++
+			}
+			if entry.Runtime || entry.Persisted {
+				entries = append(entries, entry)
+			}
+		}
+		backendEntry := &pb.MaintenanceEntry{
+			BackendName: name,
+			Runtime:     len(servers) > 0 && inRuntime == len(servers),
+			Persisted:   derefBool(backend.Disabled),
+		}
+		if backendEntry.Runtime || backendEntry.Persisted {
+			response.Entries = append(response.Entries, backendEntry)
+		}
+		response.Entries = append(response.Entries, entries...)
+	}
+	return response, nil
+}
+
+// maintenanceServers returns the committed server of a backend with the given name, or all of them
+func maintenanceServers(ctx context.Context, client DataplaneClient, backend, name string) ([]dataplane.Server, error) {
+	if name != "" {
+		server, err := client.GetServer(ctx, name, backend, "")
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		return []dataplane.Server{*server}, nil
+	}
+	servers, err := client.ListServers(ctx, backend, "")
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	return servers, nil
+}
+
+// isMaintenancePersisted reports whether a server has its maintenance flag set or, without a server name,
+// whether a backend is disabled
+func isMaintenancePersisted(ctx context.Context, client DataplaneClient, backend, name, transactionID string) (bool, error) {
+	if name != "" {
+		server, err := client.GetServer(ctx, name, backend, transactionID)
+		if err != nil {
+			return false, handleHAProxyError(err)
+		}
+		return server.Maintenance == maintenanceEnabled, nil
+	}
+	current, err := client.GetBackend(ctx, backend, transactionID)
+	if err != nil {
+		return false, handleHAProxyError(err)
+	}
+	return derefBool(current.Disabled), nil
+}
+
+// persistMaintenance sets or clears the maintenance flag of a server or, without a server name, disables or
+// enables a backend. Only the flag changes; the other settings are kept as they are.
+func (s *HAProxyManagerServer) persistMaintenance(ctx context.Context, client DataplaneClient, backend, name, transactionID string, enabled bool) error {
+	if name != "" {
+		previous, err := client.GetServer(ctx, name, backend, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		server := *previous
+		server.Maintenance = ""
+		if enabled {
+			server.Maintenance = maintenanceEnabled
+		}
+		updated, err := client.ReplaceServer(ctx, backend, transactionID, server)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		s.recordChange(resourceServer, actionUpdate, backend, name, transactionID, previous, updated)
+		return nil
+	}
+
+	previous, err := client.GetBackend(ctx, backend, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	updatedBackend := *previous
+	updatedBackend.Disabled = nil
+	if enabled {
+		updatedBackend.Disabled = boolPtr(true)
+	}
+	updated, err := client.ReplaceBackend(ctx, backend, updatedBackend, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	s.recordChange(resourceBackend, actionUpdate, "", backend, transactionID, previous, updated)
+	return nil
+}
+
+// maintenanceResult lists the backend or server an RPC put into or took out of maintenance and, for a backend,
+// the servers it changed
+func maintenanceResult(backend, name string, servers []dataplane.Server, runtime, persisted bool) []*pb.MaintenanceEntry {
+	if name != "" {
+		return []*pb.MaintenanceEntry{{BackendName: backend, ServerName: name, Runtime: runtime, Persisted: persisted}}
+	}
+	entries := []*pb.MaintenanceEntry{{BackendName: backend, Runtime: runtime, Persisted: persisted}}
+	for _, server := range servers {
+		entries = append(entries, &pb.MaintenanceEntry{
+			BackendName: backend,
+			ServerName:  derefString(server.Name),
+			Runtime:     runtime,
+			Persisted:   server.Maintenance == maintenanceEnabled,
+		})
+	}
+	return entries
+}
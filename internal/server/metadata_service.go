@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"maps"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// labeledResource is a backend, frontend, bind or server carrying labels and annotations
+type labeledResource interface {
+	GetLabels() map[string]string
+	GetAnnotations() map[string]string
+}
+
+// setResourceMetadata sets the labels and annotations of a resource message
+func setResourceMetadata(resource labeledResource, m metadata.Metadata) {
+	switch r := resource.(type) {
+	case *pb.Backend:
+		r.Labels, r.Annotations = m.Labels, m.Annotations
+	case *pb.Frontend:
+		r.Labels, r.Annotations = m.Labels, m.Annotations
+	case *pb.Bind:
+		r.Labels, r.Annotations = m.Labels, m.Annotations
+	case *pb.Server:
+		r.Labels, r.Annotations = m.Labels, m.Annotations
+	}
+}
+
+// metadataKey returns the key of a resource of the instance of client in the metadata store
+func metadataKey(client DataplaneClient, kind, parent, name string) metadata.Key {
+	return metadata.Key{Instance: client.Instance(), Kind: kind, Parent: parent, Name: name}
+}
+
+// checkMetadata validates the labels and annotations of a resource in a request, which require the metadata store
+func (s *HAProxyManagerServer) checkMetadata(resource labeledResource) error {
+	if len(resource.GetLabels()) == 0 && len(resource.GetAnnotations()) == 0 {
+		return nil
+	}
+	if s.metadataStore == nil {
+		return status.Errorf(codes.FailedPrecondition, "labels and annotations require metadata.path to be configured")
+	}
+	if err := metadata.Validate(resource.GetLabels(), resource.GetAnnotations()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
+
+// checkLabelSelector fails if a list is filtered by labels without the metadata store
+func (s *HAProxyManagerServer) checkLabelSelector(query *listQuery) error {
+	if query.selector != nil && s.metadataStore == nil {
+		return status.Errorf(codes.FailedPrecondition, "label selectors require metadata.path to be configured")
+	}
+	return nil
+}
+
+// storeMetadata records the labels and annotations of a created or updated resource and sets them on result, the
+// resource of the response. A created resource gets exactly the metadata of the request, dropping any left over
+// from an earlier resource of the same name; an update replaces the labels or annotations only if it has any.
+func (s *HAProxyManagerServer) storeMetadata(ctx context.Context, transactionID string, key metadata.Key, desired, result labeledResource, created bool) error {
+	if s.metadataStore == nil {
+		return nil
+	}
+
+	current, err := s.metadataStore.Get(transactionID, key)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s %s was changed, but reading its metadata failed: %v", key.Kind, key.Name, err)
+	}
+	updated := current[0]
+	if created || len(desired.GetLabels()) > 0 {
+		updated.Labels = desired.GetLabels()
+	}
+	if created || len(desired.GetAnnotations()) > 0 {
+		updated.Annotations = desired.GetAnnotations()
+	}
+
+	if !maps.Equal(updated.Labels, current[0].Labels) || !maps.Equal(updated.Annotations, current[0].Annotations) {
+		if err := s.metadataStore.Set(transactionID, key, updated); err != nil {
+			logger.FromContext(ctx).Error("Failed to store metadata",
+				zap.String("resource_type", key.Kind),
+				zap.String("resource_name", key.Name),
+				zap.String("transaction_id", transactionID),
+				zap.Error(err))
+			return status.Errorf(codes.Internal, "%s %s was changed, but storing its metadata failed: %v", key.Kind, key.Name, err)
+		}
+	}
+	setResourceMetadata(result, updated)
+	return nil
+}
+
+// deleteMetadata forgets the metadata of a deleted resource and of its binds or servers. A failure is logged
+// only, as the resource is deleted already; metadata left behind is dropped when a resource of the same name is
+// created.
+func (s *HAProxyManagerServer) deleteMetadata(ctx context.Context, transactionID string, key metadata.Key) {
+	if s.metadataStore == nil {
+		return
+	}
+	if err := s.metadataStore.Delete(transactionID, key); err != nil {
+		logger.FromContext(ctx).Warn("Failed to delete metadata of deleted resource",
+			zap.String("resource_type", key.Kind),
+			zap.String("resource_name", key.Name),
+			zap.String("transaction_id", transactionID),
+			zap.Error(err))
+	}
+}
+
+// commitMetadata writes the metadata changes of a committed transaction. A failure is logged only, as the
+// changes to HAProxy are committed already.
+func (s *HAProxyManagerServer) commitMetadata(ctx context.Context, transactionID string) {
+	if s.metadataStore == nil {
+		return
+	}
+	if err := s.metadataStore.Commit(transactionID); err != nil {
+		logger.FromContext(ctx).Error("Failed to store the metadata changes of committed transaction",
+			zap.String("transaction_id", transactionID),
+			zap.Error(err))
+	}
+}
+
+// inheritMetadata returns a copy of desired with the labels or annotations it leaves empty taken from current, as
+// an update keeps them; comparing it with current only finds the changes an update makes
+func inheritMetadata[T interface {
+	labeledResource
+	proto.Message
+}](current, desired T) T {
+	result := proto.Clone(desired).(T)
+	inherited := metadata.Metadata{Labels: desired.GetLabels(), Annotations: desired.GetAnnotations()}
+	if len(inherited.Labels) == 0 {
+		inherited.Labels = current.GetLabels()
+	}
+	if len(inherited.Annotations) == 0 {
+		inherited.Annotations = current.GetAnnotations()
+	}
+	setResourceMetadata(result, inherited)
+	return result
+}
+
+// attachMetadata sets the labels and annotations of resources read from HAProxy, as seen by the transaction
+func attachMetadata[T labeledResource](s *HAProxyManagerServer, transactionID string, resources []T, key func(T) metadata.Key) error {
+	if s.metadataStore == nil || len(resources) == 0 {
+		return nil
+	}
+
+	keys := make([]metadata.Key, len(resources))
+	for i, resource := range resources {
+		keys[i] = key(resource)
+	}
+	found, err := s.metadataStore.Get(transactionID, keys...)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read metadata: %v", err)
+	}
+	for i, resource := range resources {
+		setResourceMetadata(resource, found[i])
+	}
+	return nil
+}
+
+// SetMetadata replaces the labels and annotations of a backend, frontend, bind or server
+func (s *HAProxyManagerServer) SetMetadata(ctx context.Context, req *pb.SetMetadataRequest) (*pb.SetMetadataResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if s.metadataStore == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "labels and annotations require metadata.path to be configured")
+	}
+	if err := metadata.Validate(req.Labels, req.Annotations); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var err error
+	switch req.ResourceType {
+	case metadata.KindBackend:
+		_, err = client.GetBackend(ctx, req.Name, req.TransactionId)
+	case metadata.KindFrontend:
+		_, err = client.GetFrontend(ctx, req.Name, req.TransactionId)
+	case metadata.KindBind:
+		if req.ParentName == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "parent name (the frontend) is required")
+		}
+		_, err = client.GetBind(ctx, req.Name, req.ParentName, req.TransactionId)
+	case metadata.KindServer:
+		if req.ParentName == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "parent name (the backend) is required")
+		}
+		_, err = client.GetServer(ctx, req.Name, req.ParentName, req.TransactionId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "resource type must be backend, frontend, bind or server, got %q", req.ResourceType)
+	}
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	parent := req.ParentName
+	if req.ResourceType == metadata.KindBackend || req.ResourceType == metadata.KindFrontend {
+		parent = ""
+	}
+	key := metadataKey(client, req.ResourceType, parent, req.Name)
+	if err := s.metadataStore.Set(req.TransactionId, key, metadata.Metadata{Labels: req.Labels, Annotations: req.Annotations}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store metadata: %v", err)
+	}
+
+	logger.FromContext(ctx).Info("Set metadata",
+		zap.String("resource_type", req.ResourceType),
+		zap.String("parent_name", parent),
+		zap.String("resource_name", req.Name),
+		zap.String("transaction_id", req.TransactionId))
+	return &pb.SetMetadataResponse{Labels: req.Labels, Annotations: req.Annotations}, nil
+}
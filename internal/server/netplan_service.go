@@ -1,200 +1,381 @@
 package server
 
 import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
 	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"github.com/bear-san/haproxy-configurator/internal/webhook"
 	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-
 // CreateBindWithNetplan creates a bind configuration and manages IP address assignment
-func (s *HAProxyManagerServer) CreateBindWithNetplan(req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
+func (s *HAProxyManagerServer) CreateBindWithNetplan(ctx context.Context, req *pb.CreateBindRequest) (*pb.CreateBindResponse, error) {
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
 
-	logger.GetLogger().Info("Creating bind with Netplan integration",
+	logger.FromContext(ctx).Info("Creating bind with Netplan integration",
 		zap.String("frontend_name", req.FrontendName),
 		zap.String("address", req.Bind.Address),
 		zap.Int32("port", req.Bind.Port),
 		zap.String("transaction_id", req.TransactionId))
 
+	client := s.dataplane(ctx)
+	netplanMgr := s.netplanFor(client)
+	if netplanMgr != nil {
+		ctx = logger.WithFields(ctx, zap.String(logger.FieldNetplanTxnID, req.TransactionId))
+	}
+	if req.Bind != nil {
+		if err := s.checkMetadata(req.Bind); err != nil {
+			return nil, err
+		}
+		if err := s.checkBindConflicts(ctx, client, netplanMgr, req.TransactionId, req.FrontendName, req.Bind); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle Netplan IP address assignment via transaction
-	if s.netplanMgr != nil && req.Bind != nil && req.Bind.Address != "" {
-		port := int(req.Bind.Port)
-		logger.GetLogger().Debug("Adding IP address to Netplan transaction",
-			zap.String("ip_address", req.Bind.Address),
-			zap.String("transaction_id", req.TransactionId))
+	if netplanMgr != nil && req.Bind != nil && req.Bind.Address != "" {
+		if err := netplanMgr.CheckBindAddress(req.Bind.Address); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bind address: %v", err)
+		}
 
-		if err := s.netplanMgr.AddIPAddressToTransaction(req.TransactionId, req.Bind.Address, port); err != nil {
-			logger.GetLogger().Warn("Failed to add IP address to Netplan transaction, continuing without Netplan integration",
+		port := int(req.Bind.Port)
+		if !netplanMgr.ManagesAddress(req.Bind.Address) {
+			logger.FromContext(ctx).Warn("Bind address is in none of the mapped subnets, creating the HAProxy bind without a VIP",
+				zap.String("ip_address", req.Bind.Address),
+				zap.String("transaction_id", req.TransactionId))
+		} else if err := netplanMgr.AddIPAddressToTransaction(req.TransactionId, req.Bind.Address, port); err != nil {
+			if s.failOnNetplanError() {
+				logger.FromContext(ctx).Error("Failed to add IP address to Netplan transaction",
+					zap.String("ip_address", req.Bind.Address),
+					zap.String("transaction_id", req.TransactionId),
+					zap.Error(err))
+				return nil, status.Errorf(codes.Internal, "failed to add IP address %s to the Netplan transaction: %v", req.Bind.Address, err)
+			}
+			logger.FromContext(ctx).Warn("Failed to add IP address to Netplan transaction, continuing without Netplan integration",
 				zap.String("ip_address", req.Bind.Address),
 				zap.String("transaction_id", req.TransactionId),
 				zap.Error(err))
 			// Continue with bind creation even if Netplan transaction fails
 		} else {
-			logger.GetLogger().Debug("Successfully added IP address to Netplan transaction",
+			logger.FromContext(ctx).Debug("Successfully added IP address to Netplan transaction",
 				zap.String("ip_address", req.Bind.Address),
 				zap.String("transaction_id", req.TransactionId))
 		}
 	} else {
-		logger.GetLogger().Debug("Netplan integration disabled or no IP address specified, creating HAProxy bind only")
+		logger.FromContext(ctx).Debug("Netplan integration disabled or no IP address specified, creating HAProxy bind only")
 	}
 
 	// Create the bind in HAProxy
 	bind := convertBindFromProto(req.Bind)
-	created, err := s.client.AddBind(req.FrontendName, req.TransactionId, *bind)
+	created, err := client.AddBind(ctx, req.FrontendName, req.TransactionId, *bind)
 	if err != nil {
 		// HAProxy bind creation failed - no need to rollback since we're using transactions
 		// The transaction will not be committed if HAProxy fails
-		logger.GetLogger().Error("HAProxy bind creation failed",
+		logger.FromContext(ctx).Error("HAProxy bind creation failed",
 			zap.String("frontend_name", req.FrontendName),
 			zap.String("transaction_id", req.TransactionId),
 			zap.Error(err))
 		return nil, handleHAProxyError(err)
 	}
 
+	s.recordChange(resourceBind, actionCreate, req.FrontendName, req.Bind.Name, req.TransactionId, nil, created)
+	if netplanMgr != nil {
+		netplanMgr.RecordBind(req.TransactionId, req.FrontendName, req.Bind.Name, req.Bind.Address, int(req.Bind.Port))
+	}
+
+	result := convertBindToProto(created)
+	key := metadataKey(client, metadata.KindBind, req.FrontendName, req.Bind.Name)
+	if err := s.storeMetadata(ctx, req.TransactionId, key, req.Bind, result, true); err != nil {
+		return nil, err
+	}
 	return &pb.CreateBindResponse{
-		Bind: convertBindToProto(created),
+		Bind: result,
 	}, nil
 }
 
 // DeleteBindWithNetplan removes a bind configuration and cleans up IP address assignment
-func (s *HAProxyManagerServer) DeleteBindWithNetplan(req *pb.DeleteBindRequest) (*pb.DeleteBindResponse, error) {
+func (s *HAProxyManagerServer) DeleteBindWithNetplan(ctx context.Context, req *pb.DeleteBindRequest) (*pb.DeleteBindResponse, error) {
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
 
-	logger.GetLogger().Info("Deleting bind with Netplan integration",
+	logger.FromContext(ctx).Info("Deleting bind with Netplan integration",
 		zap.String("frontend_name", req.FrontendName),
 		zap.String("bind_name", req.Name),
 		zap.String("transaction_id", req.TransactionId))
 
-	// Get the bind configuration first to extract the IP address
+	// The address of the bind is taken from the bind index; the bind is only read if the index misses it or
+	// the journal and version check need it anyway
 	var bindAddress string
-	if s.netplanMgr != nil {
-		bind, err := s.client.GetBind(req.Name, req.FrontendName, req.TransactionId)
-		if err == nil && bind.Address != nil {
+	var previous *dataplane.Bind
+	client := s.dataplane(ctx)
+	netplanMgr := s.netplanFor(client)
+	indexed := false
+	if netplanMgr != nil {
+		ctx = logger.WithFields(ctx, zap.String(logger.FieldNetplanTxnID, req.TransactionId))
+		var entry netplan.BindEntry
+		if entry, indexed = netplanMgr.LookupBind(req.TransactionId, req.FrontendName, req.Name); indexed {
+			bindAddress = entry.Address
+		}
+	}
+	if (netplanMgr != nil && !indexed) || s.journal != nil || req.ExpectedVersion != "" {
+		bind, err := client.GetBind(ctx, req.Name, req.FrontendName, req.TransactionId)
+		if err := checkVersion(resourceBind, req.ExpectedVersion, convertBindToProto(bind), err); err != nil {
+			return nil, err
+		}
+		previous = bind
+		if err == nil && bind != nil && bind.Address != nil {
 			bindAddress = *bind.Address
-			logger.GetLogger().Debug("Found bind address for Netplan transaction removal",
+			logger.FromContext(ctx).Debug("Found bind address for Netplan transaction removal",
 				zap.String("bind_address", bindAddress))
 		} else {
-			logger.GetLogger().Warn("Could not retrieve bind address for Netplan transaction",
+			logger.FromContext(ctx).Warn("Could not retrieve bind address for Netplan transaction",
 				zap.String("bind_name", req.Name),
 				zap.Error(err))
 		}
 	}
 
 	// Delete the bind from HAProxy
-	logger.GetLogger().Debug("Deleting bind from HAProxy",
+	logger.FromContext(ctx).Debug("Deleting bind from HAProxy",
 		zap.String("bind_name", req.Name))
-	err := s.client.DeleteBind(req.Name, req.FrontendName, req.TransactionId)
+	err := client.DeleteBind(ctx, req.Name, req.FrontendName, req.TransactionId)
 	if err != nil {
-		logger.GetLogger().Error("Failed to delete bind from HAProxy",
+		logger.FromContext(ctx).Error("Failed to delete bind from HAProxy",
 			zap.String("bind_name", req.Name),
 			zap.Error(err))
 		return nil, handleHAProxyError(err)
 	}
-	logger.GetLogger().Debug("Successfully deleted bind from HAProxy",
+	logger.FromContext(ctx).Debug("Successfully deleted bind from HAProxy",
 		zap.String("bind_name", req.Name))
 
-	// Add IP address removal to Netplan transaction
-	if s.netplanMgr != nil && bindAddress != "" {
-		logger.GetLogger().Debug("Adding IP address removal to Netplan transaction",
+	s.recordChange(resourceBind, actionDelete, req.FrontendName, req.Name, req.TransactionId, previous, nil)
+	s.deleteMetadata(ctx, req.TransactionId, metadataKey(client, metadata.KindBind, req.FrontendName, req.Name))
+	if netplanMgr != nil {
+		netplanMgr.ForgetBind(req.TransactionId, req.FrontendName, req.Name)
+	}
+
+	// Add IP address removal to Netplan transaction; a VIP whose mapping was removed by a reload is released as well
+	if netplanMgr != nil && bindAddress != "" && (netplanMgr.ManagesAddress(bindAddress) || netplanMgr.TracksAddress(bindAddress)) {
+		logger.FromContext(ctx).Debug("Adding IP address removal to Netplan transaction",
 			zap.String("ip_address", bindAddress),
 			zap.String("transaction_id", req.TransactionId))
-		if err := s.netplanMgr.RemoveIPAddressFromTransaction(req.TransactionId, bindAddress); err != nil {
-			logger.GetLogger().Warn("Failed to add IP address removal to Netplan transaction",
+		if err := netplanMgr.RemoveIPAddressFromTransaction(req.TransactionId, bindAddress); err != nil {
+			if s.failOnNetplanError() {
+				logger.FromContext(ctx).Error("Failed to add IP address removal to Netplan transaction",
+					zap.String("ip_address", bindAddress),
+					zap.String("transaction_id", req.TransactionId),
+					zap.Error(err))
+				return nil, status.Errorf(codes.Internal, "bind is deleted in the transaction, but removing IP address %s from the Netplan transaction failed: %v; close the transaction to keep the bind", bindAddress, err)
+			}
+			logger.FromContext(ctx).Warn("Failed to add IP address removal to Netplan transaction",
 				zap.String("ip_address", bindAddress),
 				zap.String("transaction_id", req.TransactionId),
 				zap.Error(err))
 			// Don't fail the entire operation for Netplan transaction errors
 		} else {
-			logger.GetLogger().Debug("Successfully added IP address removal to Netplan transaction",
+			logger.FromContext(ctx).Debug("Successfully added IP address removal to Netplan transaction",
 				zap.String("ip_address", bindAddress),
 				zap.String("transaction_id", req.TransactionId))
 		}
 	} else {
-		logger.GetLogger().Debug("No IP address to remove from Netplan or Netplan integration disabled")
+		logger.FromContext(ctx).Debug("No IP address to remove from Netplan or Netplan integration disabled")
 	}
 
 	return &pb.DeleteBindResponse{}, nil
 }
 
 // CommitTransactionWithNetplan commits the transaction and applies Netplan changes
-func (s *HAProxyManagerServer) CommitTransactionWithNetplan(req *pb.CommitTransactionRequest) (*pb.CommitTransactionResponse, error) {
+func (s *HAProxyManagerServer) CommitTransactionWithNetplan(ctx context.Context, req *pb.CommitTransactionRequest) (*pb.CommitTransactionResponse, error) {
 	if req.TransactionId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
 	}
+	ctx = logger.WithFields(ctx, zap.String(logger.FieldHAProxyTxnID, req.TransactionId))
 
-	logger.GetLogger().Info("Committing transaction with Netplan integration",
+	logger.FromContext(ctx).Info("Committing transaction with Netplan integration",
 		zap.String("transaction_id", req.TransactionId))
 
 	// Commit HAProxy transaction first
-	logger.GetLogger().Debug("Committing HAProxy transaction",
+	logger.FromContext(ctx).Debug("Committing HAProxy transaction",
 		zap.String("transaction_id", req.TransactionId))
-	transaction, err := s.client.CommitTransaction(req.TransactionId)
+	client := s.dataplane(ctx)
+	// Drift is not checked while committing; in record mode the committed state becomes the desired state
+	detector := s.driftFor(client)
+	if detector != nil {
+		detector.BeginCommit()
+	}
+	transaction, err := client.CommitTransaction(ctx, req.TransactionId)
+	if detector != nil {
+		detector.EndCommit(ctx, err == nil)
+	}
 	if err != nil {
-		logger.GetLogger().Error("Failed to commit HAProxy transaction",
+		logger.FromContext(ctx).Error("Failed to commit HAProxy transaction",
 			zap.String("transaction_id", req.TransactionId),
 			zap.Error(err))
+		s.webhooks.Notify(webhook.Event{
+			Type:          webhook.EventTransactionFailed,
+			TransactionID: req.TransactionId,
+			Message:       "HAProxy transaction commit failed",
+			Error:         err.Error(),
+		})
 		return nil, handleHAProxyError(err)
 	}
-	logger.GetLogger().Info("Successfully committed HAProxy transaction",
+	logger.FromContext(ctx).Info("Successfully committed HAProxy transaction",
 		zap.String("transaction_id", req.TransactionId))
+	s.commitTransaction(req.TransactionId)
+	s.commitMetadata(ctx, req.TransactionId)
 
-	// Commit Netplan transaction and apply configuration after successful HAProxy commit
-	if s.netplanMgr != nil {
-		logger.GetLogger().Debug("Committing Netplan transaction",
-			zap.String("transaction_id", req.TransactionId))
-		if netplanErr := s.netplanMgr.CommitTransaction(req.TransactionId); netplanErr != nil {
-			logger.GetLogger().Warn("Failed to commit Netplan transaction, HAProxy changes are committed but Netplan changes may not be applied",
+	s.recordChange(resourceTransaction, actionCommit, "", req.TransactionId, req.TransactionId, nil, transaction)
+
+	// Commit Netplan transaction, which applies the configuration, after successful HAProxy commit. With
+	// failure_policy "fail" a Netplan error is returned once the committed configuration is announced and replicated.
+	var netplanFailure error
+	if netplanMgr := s.netplanFor(client); netplanMgr != nil {
+		ctx := logger.WithFields(ctx, zap.String(logger.FieldNetplanTxnID, req.TransactionId))
+		netplanMgr.CommitBinds(req.TransactionId)
+
+		// Transactions that change no VIP have no Netplan transaction and leave the host configuration alone
+		pending, netplanErr := netplanMgr.GetTransaction(req.TransactionId)
+		if netplanErr == nil && pending != nil {
+			logger.FromContext(ctx).Debug("Committing Netplan transaction",
+				zap.String("transaction_id", req.TransactionId))
+			netplanErr = netplanMgr.CommitTransaction(ctx, req.TransactionId)
+		}
+		if netplanErr == nil && pending == nil {
+			logger.FromContext(ctx).Debug("No Netplan changes in transaction",
+				zap.String("transaction_id", req.TransactionId))
+		} else if netplanErr != nil {
+			logger.FromContext(ctx).Warn("Failed to commit Netplan transaction, HAProxy changes are committed but Netplan changes may not be applied",
 				zap.String("transaction_id", req.TransactionId),
 				zap.Error(netplanErr))
-			// Log the error but don't fail the transaction commit
+			s.webhooks.Notify(webhook.Event{
+				Type:          webhook.EventNetplanFailed,
+				TransactionID: req.TransactionId,
+				Message:       "Netplan transaction commit failed; HAProxy changes are committed",
+				Error:         netplanErr.Error(),
+			})
 			// The HAProxy changes are already committed at this point
+			netplanFailure = status.Errorf(codes.Internal, "HAProxy transaction %s is committed, but committing the Netplan transaction failed: %v", req.TransactionId, netplanErr)
 		} else {
-			logger.GetLogger().Info("Successfully committed Netplan transaction",
+			// CommitTransaction applied the configuration, restoring the previous one if netplan apply failed
+			logger.FromContext(ctx).Info("Successfully committed and applied Netplan transaction",
 				zap.String("transaction_id", req.TransactionId))
-
-			// Apply Netplan configuration after successful transaction commit
-			logger.GetLogger().Debug("Applying Netplan configuration")
-			if applyErr := s.netplanMgr.ApplyNetplan(); applyErr != nil {
-				logger.GetLogger().Warn("Failed to apply Netplan configuration, files updated but network changes may not be active",
-					zap.Error(applyErr))
-			} else {
-				logger.GetLogger().Info("Successfully applied Netplan configuration")
-			}
 		}
 	} else {
-		logger.GetLogger().Debug("Netplan integration disabled, transaction commit complete")
+		logger.FromContext(ctx).Debug("Netplan integration disabled, transaction commit complete")
+	}
+
+	// Announce added VIPs and withdraw removed ones
+	s.triggerBGP(client)
+
+	// Mirror the committed configuration to the cluster nodes
+	replicas := s.replicate(ctx, client, req.TransactionId)
+
+	s.webhooks.Notify(webhook.Event{
+		Type:          webhook.EventTransactionCommitted,
+		TransactionID: req.TransactionId,
+		Message:       "Transaction committed",
+	})
+
+	if netplanFailure != nil && s.failOnNetplanError() {
+		return nil, netplanFailure
 	}
 
 	return &pb.CommitTransactionResponse{
-		Transaction: convertTransactionToProto(transaction),
+		Transaction:    convertTransactionToProto(transaction),
+		Replicas:       replicas,
+		PartialFailure: partialFailure(replicas),
 	}, nil
 }
 
-// GetNetplanStatus returns the current status of Netplan integration
-func (s *HAProxyManagerServer) GetNetplanStatus() map[string]interface{} {
+// netplanSummary returns the current status of Netplan integration for the debug dump
+func (s *HAProxyManagerServer) netplanSummary() map[string]interface{} {
 	status := make(map[string]interface{})
 
-	if s.config == nil || !s.config.HasNetplanIntegration() {
+	cfg := s.currentConfig()
+	if cfg == nil || !cfg.HasNetplanIntegration() {
 		status["enabled"] = false
 		status["message"] = "Netplan integration disabled"
 		return status
 	}
 
 	status["enabled"] = true
-	status["config_path"] = s.config.Netplan.ConfigPath
-	status["backup_enabled"] = s.config.Netplan.BackupEnabled
-	status["interface_mappings"] = len(s.config.Netplan.InterfaceMappings)
+	status["config_path"] = cfg.Netplan.ConfigPath
+	status["backup_enabled"] = cfg.Netplan.BackupEnabled
+	status["strict_address_validation"] = cfg.Netplan.StrictAddressValidation
+	status["fail_on_error"] = cfg.Netplan.FailOnError()
+	status["interface_mappings"] = len(cfg.Netplan.InterfaceMappings)
 
-	if s.netplanMgr != nil {
-		status["tracked_addresses"] = s.netplanMgr.GetTrackedAddresses()
+	if netplanMgr := s.netplan(); netplanMgr != nil {
+		status["tracked_addresses"] = netplanMgr.GetTrackedAddresses()
 	}
 
 	return status
 }
+
+// GetNetplanStatus reports the VIPs managed through Netplan, whether they are configured on the host,
+// and the Netplan transactions that have not been committed
+func (s *HAProxyManagerServer) GetNetplanStatus(ctx context.Context, _ *pb.GetNetplanStatusRequest) (*pb.GetNetplanStatusResponse, error) {
+	netplanMgr := s.netplanFor(s.dataplane(ctx))
+	if netplanMgr == nil {
+		return &pb.GetNetplanStatusResponse{Enabled: false}, nil
+	}
+
+	response := &pb.GetNetplanStatusResponse{Enabled: true}
+	if cfg := s.currentConfig(); cfg != nil {
+		response.ConfigPath = cfg.Netplan.ConfigPath
+	}
+
+	addresses, err := netplanMgr.AuditAddresses()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to audit addresses: %v", err)
+	}
+	for _, address := range addresses {
+		response.Addresses = append(response.Addresses, &pb.TrackedAddress{
+			IpAddress:     address.Address,
+			Interface:     address.Interface,
+			Configured:    address.HostInterface != "",
+			HostInterface: address.HostInterface,
+		})
+	}
+
+	transactions, err := netplanMgr.ListTransactions()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list Netplan transactions: %v", err)
+	}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, convertNetplanTransactionToProto(transaction))
+	}
+	return response, nil
+}
+
+// GetNetplanTransaction returns the Netplan side of a transaction, including why its commit failed
+func (s *HAProxyManagerServer) GetNetplanTransaction(ctx context.Context, req *pb.GetNetplanTransactionRequest) (*pb.GetNetplanTransactionResponse, error) {
+	netplanMgr := s.netplanFor(s.dataplane(ctx))
+	if netplanMgr == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Netplan integration is disabled or the instance is not on this host")
+	}
+
+	transaction, err := netplanMgr.FindTransaction(req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read Netplan transaction: %v", err)
+	}
+	if transaction == nil {
+		return nil, status.Errorf(codes.NotFound, "transaction %s has no Netplan changes", req.TransactionId)
+	}
+	return &pb.GetNetplanTransactionResponse{Transaction: convertNetplanTransactionToProto(transaction)}, nil
+}
+
+// failOnNetplanError reports whether Netplan errors of the bind and commit RPCs are returned to the caller, as
+// configured by the failure_policy of the Netplan settings
+func (s *HAProxyManagerServer) failOnNetplanError() bool {
+	cfg := s.currentConfig()
+	return cfg != nil && cfg.Netplan.FailOnError()
+}
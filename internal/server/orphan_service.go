@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/metrics"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CleanupOrphanedAddresses finds the VIPs in the Netplan configuration that no bind listens on and removes
+// them if requested
+func (s *HAProxyManagerServer) CleanupOrphanedAddresses(ctx context.Context, req *pb.CleanupOrphanedAddressesRequest) (*pb.CleanupOrphanedAddressesResponse, error) {
+	netplanMgr := s.netplanFor(s.dataplane(ctx))
+	if netplanMgr == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Netplan integration is disabled or the instance is not on this host")
+	}
+
+	orphans, err := s.findOrphans(ctx, netplanMgr)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.CleanupOrphanedAddressesResponse{}
+	for _, orphan := range orphans {
+		response.Orphans = append(response.Orphans, &pb.OrphanedAddress{IpAddress: orphan.Address, Interface: orphan.Interface})
+	}
+	if req.Remove && len(orphans) > 0 {
+		if err := s.removeOrphans(ctx, netplanMgr, orphans); err != nil {
+			return nil, err
+		}
+		response.Removed = true
+	}
+	return response, nil
+}
+
+// RunOrphanCleanup searches for orphaned VIPs every interval until ctx is cancelled, removing them if
+// orphan_cleanup.remove is set. Standbys skip the search, as binds are changed on the leader, and orphans are
+// only reported while changes are frozen.
+func (s *HAProxyManagerServer) RunOrphanCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		netplanMgr := s.netplan()
+		cfg := s.currentConfig()
+		if netplanMgr == nil || cfg == nil || !s.IsLeader() {
+			continue
+		}
+		orphans, err := s.findOrphans(ctx, netplanMgr)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Failed to search for orphaned VIPs",
+				zap.Error(err))
+			continue
+		}
+		if len(orphans) == 0 {
+			continue
+		}
+		if !cfg.Netplan.OrphanCleanup.Remove {
+			logger.FromContext(ctx).Warn("Found VIPs in the Netplan configuration that no bind listens on",
+				zap.Any("orphans", orphans))
+			continue
+		}
+		if err := s.requireUnfrozen(); err != nil {
+			logger.FromContext(ctx).Warn("Postponed removing orphaned VIPs while changes are frozen",
+				zap.Any("orphans", orphans),
+				zap.Error(err))
+			continue
+		}
+		if err := s.removeOrphans(ctx, netplanMgr, orphans); err != nil {
+			logger.FromContext(ctx).Error("Failed to remove orphaned VIPs",
+				zap.Any("orphans", orphans),
+				zap.Error(err))
+		}
+	}
+}
+
+// findOrphans compares the VIPs of the Netplan configuration with the live binds of the local instance
+func (s *HAProxyManagerServer) findOrphans(ctx context.Context, netplanMgr *netplan.Manager) ([]netplan.OrphanedAddress, error) {
+	var listErr error
+	orphans, err := netplanMgr.FindOrphans(func() ([]string, error) {
+		addresses, err := s.BindAddresses(ctx)
+		listErr = err
+		return addresses, err
+	})
+	if listErr != nil {
+		return nil, listErr // Already a gRPC status
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search for orphaned VIPs: %v", err)
+	}
+	metrics.NetplanOrphanedAddresses.Set(float64(len(orphans)))
+	return orphans, nil
+}
+
+// removeOrphans removes orphaned VIPs from the Netplan configuration
+func (s *HAProxyManagerServer) removeOrphans(ctx context.Context, netplanMgr *netplan.Manager, orphans []netplan.OrphanedAddress) error {
+	if err := netplanMgr.RemoveOrphans(ctx, orphans); err != nil {
+		return status.Errorf(codes.Internal, "failed to remove orphaned VIPs: %v", err)
+	}
+	metrics.NetplanOrphanedAddresses.Set(0)
+	s.triggerBGP(s.client)
+	return nil
+}
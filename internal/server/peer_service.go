@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/journal"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"github.com/bear-san/haproxy-configurator/internal/peersync"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// peerEventBatch is the maximum number of journal events returned by a single GetPeerState call
+const peerEventBatch = 500
+
+// SetPeerSync registers the syncer copying the state of the leader, for GetPeerSyncStatus
+func (s *HAProxyManagerServer) SetPeerSync(syncer *peersync.Syncer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.peerSync = syncer
+}
+
+// IsLeader reports whether this instance accepts changes, which is always the case without leader election
+func (s *HAProxyManagerServer) IsLeader() bool {
+	election := s.leaderElection()
+	return election == nil || election.IsLeader()
+}
+
+// GetPeerState returns the tracked VIPs, the Netplan transactions not yet committed and the journal events
+// after the given ID, for standbys to copy
+func (s *HAProxyManagerServer) GetPeerState(_ context.Context, req *pb.GetPeerStateRequest) (*pb.GetPeerStateResponse, error) {
+	response := &pb.GetPeerStateResponse{Leader: s.IsLeader()}
+	if election := s.leaderElection(); election != nil {
+		response.Identity = election.Identity()
+	}
+
+	if netplanMgr := s.netplan(); netplanMgr != nil {
+		response.NetplanEnabled = true
+		response.TrackedAddresses = netplanMgr.GetTrackedAddresses()
+
+		transactions, err := netplanMgr.ListTransactions()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list Netplan transactions: %v", err)
+		}
+		for _, transaction := range transactions {
+			response.NetplanTransactions = append(response.NetplanTransactions, convertNetplanTransactionToProto(transaction))
+		}
+	}
+
+	if s.journal != nil {
+		events, err := s.journal.After(req.AfterEventId, peerEventBatch+1)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read events: %v", err)
+		}
+		if len(events) > peerEventBatch {
+			events = events[:peerEventBatch]
+			response.MoreEvents = true
+		}
+		for i := range events {
+			response.Events = append(response.Events, convertEventToProto(&events[i]))
+		}
+	}
+	return response, nil
+}
+
+// GetPeerSyncStatus reports the outcome of the last sync with every peer
+func (s *HAProxyManagerServer) GetPeerSyncStatus(_ context.Context, _ *pb.GetPeerSyncStatusRequest) (*pb.GetPeerSyncStatusResponse, error) {
+	s.mutex.RLock()
+	syncer := s.peerSync
+	s.mutex.RUnlock()
+
+	if syncer == nil {
+		return &pb.GetPeerSyncStatusResponse{Enabled: false}, nil
+	}
+	return &pb.GetPeerSyncStatusResponse{Enabled: true, Peers: syncer.Status()}, nil
+}
+
+// PeerCursor returns the ID of the last journal event, from which copying the journal of the leader continues
+func (s *HAProxyManagerServer) PeerCursor() (uint64, error) {
+	if s.journal == nil {
+		return 0, nil
+	}
+	return s.journal.LastID()
+}
+
+// ImportPeerState replaces the tracked VIPs and uncommitted Netplan transactions with those of the leader and
+// appends its journal events. A state is only imported while this instance is a standby.
+func (s *HAProxyManagerServer) ImportPeerState(state *pb.GetPeerStateResponse) error {
+	if s.IsLeader() {
+		return nil
+	}
+
+	if netplanMgr := s.netplan(); netplanMgr != nil && state.NetplanEnabled {
+		netplanMgr.ReplaceTrackedAddresses(state.TrackedAddresses)
+		// The binds change on the leader, so the bind index of a standby would go stale; lookups fall back to
+		// reading the bind instead
+		netplanMgr.ReplaceBinds(nil)
+
+		transactions := make([]*netplan.Transaction, 0, len(state.NetplanTransactions))
+		for _, transaction := range state.NetplanTransactions {
+			transactions = append(transactions, convertNetplanTransactionFromProto(transaction))
+		}
+		if err := netplanMgr.ReplaceTransactions(transactions); err != nil {
+			return err
+		}
+	}
+
+	if s.journal != nil && len(state.Events) > 0 {
+		events := make([]journal.Event, 0, len(state.Events))
+		for _, event := range state.Events {
+			events = append(events, convertEventFromProto(event))
+		}
+		if err := s.journal.Import(events); err != nil {
+			return err
+		}
+	}
+
+	logger.GetLogger().Debug("Imported state of the leader",
+		zap.String("leader", state.Identity),
+		zap.Int("tracked_addresses", len(state.TrackedAddresses)),
+		zap.Int("netplan_transactions", len(state.NetplanTransactions)),
+		zap.Int("events", len(state.Events)))
+	return nil
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateProgram adds a program section within a transaction
+func (s *HAProxyManagerServer) CreateProgram(ctx context.Context, req *pb.CreateProgramRequest) (*pb.CreateProgramResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if err := validateProgram(req.Program); err != nil {
+		return nil, err
+	}
+
+	program, err := client.AddProgram(ctx, convertProgramFromProto(req.Program), req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	created := convertProgramToProto(program)
+
+	s.recordChange(resourceProgram, actionCreate, "", created.Name, req.TransactionId, nil, created)
+
+	return &pb.CreateProgramResponse{Program: created}, nil
+}
+
+// GetProgram retrieves a program section by name
+func (s *HAProxyManagerServer) GetProgram(ctx context.Context, req *pb.GetProgramRequest) (*pb.GetProgramResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "program name is required")
+	}
+
+	program, err := client.GetProgram(ctx, req.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	return &pb.GetProgramResponse{Program: convertProgramToProto(program)}, nil
+}
+
+// ListPrograms retrieves all program sections
+func (s *HAProxyManagerServer) ListPrograms(ctx context.Context, req *pb.ListProgramsRequest) (*pb.ListProgramsResponse, error) {
+	client := s.dataplane(ctx)
+
+	programs, err := client.ListPrograms(ctx, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	result := make([]*pb.Program, 0, len(programs))
+	for i := range programs {
+		result = append(result, convertProgramToProto(&programs[i]))
+	}
+
+	return &pb.ListProgramsResponse{Programs: result}, nil
+}
+
+// UpdateProgram replaces a program section within a transaction. The master restarts the program on the reload
+// applying the change, whatever its restart policy.
+func (s *HAProxyManagerServer) UpdateProgram(ctx context.Context, req *pb.UpdateProgramRequest) (*pb.UpdateProgramResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if err := validateProgram(req.Program); err != nil {
+		return nil, err
+	}
+
+	previous, err := client.GetProgram(ctx, req.Program.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	program, err := client.ReplaceProgram(ctx, req.Program.Name, convertProgramFromProto(req.Program), req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	updated := convertProgramToProto(program)
+
+	s.recordChange(resourceProgram, actionUpdate, "", updated.Name, req.TransactionId, convertProgramToProto(previous), updated)
+
+	return &pb.UpdateProgramResponse{Program: updated}, nil
+}
+
+// DeleteProgram removes a program section within a transaction; the master stops the program on reload
+func (s *HAProxyManagerServer) DeleteProgram(ctx context.Context, req *pb.DeleteProgramRequest) (*pb.DeleteProgramResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "program name is required")
+	}
+
+	previous, err := client.GetProgram(ctx, req.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	if err := client.DeleteProgram(ctx, req.Name, req.TransactionId); err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	s.recordChange(resourceProgram, actionDelete, "", req.Name, req.TransactionId, convertProgramToProto(previous), nil)
+
+	return &pb.DeleteProgramResponse{}, nil
+}
+
+// validateProgram checks the name, command and identity of a program. Commands are written to a single line of
+// haproxy.cfg, so line breaks are rejected.
+func validateProgram(program *pb.Program) error {
+	switch {
+	case program == nil:
+		return status.Errorf(codes.InvalidArgument, "program is required")
+	case program.Name == "":
+		return status.Errorf(codes.InvalidArgument, "program name is required")
+	case !sectionNamePattern.MatchString(program.Name):
+		return status.Errorf(codes.InvalidArgument, "program name must consist of letters, digits, \".\", \"-\" and \"_\"")
+	case strings.TrimSpace(program.Command) == "":
+		return status.Errorf(codes.InvalidArgument, "program command is required")
+	case strings.ContainsAny(program.Command, "\r\n"):
+		return status.Errorf(codes.InvalidArgument, "program command must be a single line")
+	case program.User != "" && !sectionNamePattern.MatchString(program.User):
+		return status.Errorf(codes.InvalidArgument, "invalid user %q", program.User)
+	case program.Group != "" && !sectionNamePattern.MatchString(program.Group):
+		return status.Errorf(codes.InvalidArgument, "invalid group %q", program.Group)
+	}
+	return nil
+}
+
+// convertProgramToProto converts a program section to its protobuf message
+func convertProgramToProto(program *dataplane.Program) *pb.Program {
+	result := &pb.Program{
+		Name:          derefString(program.Name),
+		Command:       program.Command,
+		User:          program.User,
+		Group:         program.Group,
+		RestartPolicy: pb.ProgramRestartPolicy_PROGRAM_RESTART_POLICY_ON_RELOAD,
+	}
+	if program.StartOnReload == "disabled" {
+		result.RestartPolicy = pb.ProgramRestartPolicy_PROGRAM_RESTART_POLICY_KEEP_RUNNING
+	}
+	return result
+}
+
+// convertProgramFromProto converts a program message to a program section
+func convertProgramFromProto(program *pb.Program) dataplane.Program {
+	result := dataplane.Program{
+		Name:          &program.Name,
+		Command:       strings.TrimSpace(program.Command),
+		User:          program.User,
+		Group:         program.Group,
+		StartOnReload: "enabled",
+	}
+	if program.RestartPolicy == pb.ProgramRestartPolicy_PROGRAM_RESTART_POLICY_KEEP_RUNNING {
+		result.StartOnReload = "disabled"
+	}
+	return result
+}
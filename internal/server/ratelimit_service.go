@@ -0,0 +1,488 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rate limit policies are stored as backends holding a stick table, named after the frontend and policy, and
+// track-sc and deny rules referencing the table
+const rateLimitBackendPrefix = "ratelimit."
+
+// Stick tables of rate limit policies
+const (
+	rateLimitTableSize    = 100000 // Clients tracked at once
+	rateLimitHeaderKeylen = 64     // Bytes of a header value kept as key
+	rateLimitDenyStatus   = 429
+	stickCounters         = 3 // Trackers per stream in a default HAProxy build
+)
+
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+// rateLimitTable is the rate limiting configuration of a frontend: its mode, the backends holding the stick
+// tables of its policies and its request rules
+type rateLimitTable struct {
+	frontend  string
+	mode      string
+	backends  []dataplane.Backend
+	httpRules []dataplane.HTTPRequestRule
+	tcpRules  []dataplane.TCPRequestRule
+}
+
+// CreateRateLimitPolicy adds a rate limit policy to a frontend within a transaction
+func (s *HAProxyManagerServer) CreateRateLimitPolicy(ctx context.Context, req *pb.CreateRateLimitPolicyRequest) (*pb.CreateRateLimitPolicyResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+
+	table, err := loadRateLimitTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := table.normalize(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+	if table.find(policy.Name) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "rate limit policy %s already exists in frontend %s", policy.Name, req.FrontendName)
+	}
+	if err := table.add(ctx, client, req.TransactionId, policy); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRateLimitPolicy, actionCreate, req.FrontendName, policy.Name, req.TransactionId, nil, policy)
+
+	return &pb.CreateRateLimitPolicyResponse{Policy: policy}, nil
+}
+
+// GetRateLimitPolicy retrieves a rate limit policy of a frontend by name
+func (s *HAProxyManagerServer) GetRateLimitPolicy(ctx context.Context, req *pb.GetRateLimitPolicyRequest) (*pb.GetRateLimitPolicyResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "rate limit policy name is required")
+	}
+
+	table, err := loadRateLimitTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	policy := table.find(req.Name)
+	if policy == nil {
+		return nil, status.Errorf(codes.NotFound, "rate limit policy %s not found in frontend %s", req.Name, req.FrontendName)
+	}
+
+	return &pb.GetRateLimitPolicyResponse{Policy: policy}, nil
+}
+
+// ListRateLimitPolicies retrieves the rate limit policies of a frontend
+func (s *HAProxyManagerServer) ListRateLimitPolicies(ctx context.Context, req *pb.ListRateLimitPoliciesRequest) (*pb.ListRateLimitPoliciesResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+
+	table, err := loadRateLimitTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRateLimitPoliciesResponse{Policies: table.policies()}, nil
+}
+
+// UpdateRateLimitPolicy replaces a rate limit policy of a frontend within a transaction. Its stick table and
+// rules are recreated, so the counts of clients start over once the transaction is committed.
+func (s *HAProxyManagerServer) UpdateRateLimitPolicy(ctx context.Context, req *pb.UpdateRateLimitPolicyRequest) (*pb.UpdateRateLimitPolicyResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+
+	table, err := loadRateLimitTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := table.normalize(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+	previous := table.find(policy.Name)
+	if previous == nil {
+		return nil, status.Errorf(codes.NotFound, "rate limit policy %s not found in frontend %s", policy.Name, req.FrontendName)
+	}
+	if err := checkVersion(resourceRateLimitPolicy, req.ExpectedVersion, previous, nil); err != nil {
+		return nil, err
+	}
+	if err := table.remove(ctx, client, req.TransactionId, policy.Name); err != nil {
+		return nil, err
+	}
+	if err := table.add(ctx, client, req.TransactionId, policy); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRateLimitPolicy, actionUpdate, req.FrontendName, policy.Name, req.TransactionId, previous, policy)
+
+	return &pb.UpdateRateLimitPolicyResponse{Policy: policy}, nil
+}
+
+// DeleteRateLimitPolicy removes a rate limit policy, its rules and its stick table from a frontend within a
+// transaction
+func (s *HAProxyManagerServer) DeleteRateLimitPolicy(ctx context.Context, req *pb.DeleteRateLimitPolicyRequest) (*pb.DeleteRateLimitPolicyResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "rate limit policy name is required")
+	}
+
+	table, err := loadRateLimitTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	previous := table.find(req.Name)
+	if previous == nil {
+		return nil, status.Errorf(codes.NotFound, "rate limit policy %s not found in frontend %s", req.Name, req.FrontendName)
+	}
+	if err := checkVersion(resourceRateLimitPolicy, req.ExpectedVersion, previous, nil); err != nil {
+		return nil, err
+	}
+	if err := table.remove(ctx, client, req.TransactionId, req.Name); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRateLimitPolicy, actionDelete, req.FrontendName, req.Name, req.TransactionId, previous, nil)
+
+	return &pb.DeleteRateLimitPolicyResponse{}, nil
+}
+
+// loadRateLimitTable reads the mode, rate limit backends and request rules of a frontend
+func loadRateLimitTable(ctx context.Context, client DataplaneClient, frontend, transactionID string) (*rateLimitTable, error) {
+	current, err := client.GetFrontend(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	backends, err := client.ListBackends(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	httpRules, err := client.ListHTTPRequestRules(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	tcpRules, err := client.ListTCPRequestRules(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	table := &rateLimitTable{frontend: frontend, mode: derefString(current.Mode), httpRules: httpRules, tcpRules: tcpRules}
+	if table.mode == "" {
+		table.mode = "tcp" // The default of HAProxy
+	}
+	for _, backend := range backends {
+		if table.policyName(derefString(backend.Name)) != "" && backend.StickTable != nil {
+			table.backends = append(table.backends, backend)
+		}
+	}
+	return table, nil
+}
+
+// normalize validates a policy for the frontend and returns a copy with the defaults filled in, as it is read
+// back from the configuration
+func (t *rateLimitTable) normalize(policy *pb.RateLimitPolicy) (*pb.RateLimitPolicy, error) {
+	if policy == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "rate limit policy is required")
+	}
+	if !routeNamePattern.MatchString(policy.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "rate limit policy name must consist of letters, digits, \"-\" and \"_\"")
+	}
+	if policy.Requests <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "requests must be positive")
+	}
+	if policy.PeriodSeconds <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "period_seconds must be positive")
+	}
+
+	normalized := &pb.RateLimitPolicy{
+		Name:          policy.Name,
+		Requests:      policy.Requests,
+		PeriodSeconds: policy.PeriodSeconds,
+		Key:           policy.Key,
+		Action:        policy.Action,
+		DenyStatus:    policy.DenyStatus,
+	}
+	if normalized.Key == pb.RateLimitKey_RATE_LIMIT_KEY_UNSPECIFIED {
+		normalized.Key = pb.RateLimitKey_RATE_LIMIT_KEY_SOURCE_IP
+	}
+	if normalized.Action == pb.RateLimitAction_RATE_LIMIT_ACTION_UNSPECIFIED {
+		normalized.Action = pb.RateLimitAction_RATE_LIMIT_ACTION_DENY
+	}
+
+	switch {
+	case normalized.Key == pb.RateLimitKey_RATE_LIMIT_KEY_HEADER && !headerNamePattern.MatchString(policy.Header):
+		return nil, status.Errorf(codes.InvalidArgument, "a valid header name is required to count requests by header")
+	case normalized.Key != pb.RateLimitKey_RATE_LIMIT_KEY_HEADER && policy.Header != "":
+		return nil, status.Errorf(codes.InvalidArgument, "header is only used to count requests by header")
+	case policy.DenyStatus != 0 && (policy.DenyStatus < 200 || policy.DenyStatus > 599):
+		return nil, status.Errorf(codes.InvalidArgument, "deny_status must be an HTTP status between 200 and 599")
+	}
+	if normalized.Key == pb.RateLimitKey_RATE_LIMIT_KEY_HEADER {
+		normalized.Header = strings.ToLower(policy.Header)
+	}
+
+	if t.mode != "http" {
+		switch {
+		case normalized.Key == pb.RateLimitKey_RATE_LIMIT_KEY_HEADER:
+			return nil, status.Errorf(codes.FailedPrecondition, "counting requests by header requires an HTTP mode frontend")
+		case normalized.Action == pb.RateLimitAction_RATE_LIMIT_ACTION_TARPIT:
+			return nil, status.Errorf(codes.FailedPrecondition, "tarpitting requires an HTTP mode frontend")
+		case normalized.DenyStatus != 0:
+			return nil, status.Errorf(codes.FailedPrecondition, "deny_status requires an HTTP mode frontend; TCP frontends reject connections")
+		}
+		return normalized, nil
+	}
+	if normalized.DenyStatus == 0 {
+		normalized.DenyStatus = rateLimitDenyStatus
+	}
+	return normalized, nil
+}
+
+// policies returns the policies of the frontend in the order of their backends
+func (t *rateLimitTable) policies() []*pb.RateLimitPolicy {
+	var policies []*pb.RateLimitPolicy
+	for _, backend := range t.backends {
+		policies = append(policies, t.policy(backend))
+	}
+	return policies
+}
+
+// policy reads a policy back from the stick table of its backend and the rules referencing it
+func (t *rateLimitTable) policy(backend dataplane.Backend) *pb.RateLimitPolicy {
+	name := derefString(backend.Name)
+	policy := &pb.RateLimitPolicy{
+		Name:          t.policyName(name),
+		PeriodSeconds: derefInt(backend.StickTable.Expire) / 1000,
+		Key:           pb.RateLimitKey_RATE_LIMIT_KEY_SOURCE_IP,
+		Action:        pb.RateLimitAction_RATE_LIMIT_ACTION_DENY,
+	}
+
+	if t.mode == "http" {
+		for _, rule := range t.httpRules {
+			switch {
+			case rule.Type == "track-sc" && rule.TrackScTable == name:
+				if header, ok := strings.CutPrefix(rule.TrackScKey, "req.hdr("); ok {
+					policy.Key = pb.RateLimitKey_RATE_LIMIT_KEY_HEADER
+					policy.Header = strings.TrimSuffix(header, ")")
+				}
+			case (rule.Type == "deny" || rule.Type == "tarpit") && rule.Cond == "if":
+				if limit, ok := rateLimitOfCondition(rule.CondTest, name); ok {
+					policy.Requests = limit
+					policy.DenyStatus = derefInt(rule.DenyStatus)
+					if rule.Type == "tarpit" {
+						policy.Action = pb.RateLimitAction_RATE_LIMIT_ACTION_TARPIT
+					}
+				}
+			}
+		}
+	} else {
+		for _, rule := range t.tcpRules {
+			if rule.Type == "connection" && rule.Action == "reject" && rule.Cond == "if" {
+				if limit, ok := rateLimitOfCondition(rule.CondTest, name); ok {
+					policy.Requests = limit
+				}
+			}
+		}
+	}
+
+	policy.ResourceVersion = resourceVersion(policy)
+	return policy
+}
+
+// find returns the policy with the given name or nil
+func (t *rateLimitTable) find(name string) *pb.RateLimitPolicy {
+	for _, backend := range t.backends {
+		if derefString(backend.Name) == t.backendName(name) {
+			return t.policy(backend)
+		}
+	}
+	return nil
+}
+
+// add creates the stick table backend of a normalized policy and inserts its rules at the start of the
+// frontend's rules, so that it applies before any other http-request or tcp-request connection rule
+func (t *rateLimitTable) add(ctx context.Context, client DataplaneClient, transactionID string, policy *pb.RateLimitPolicy) error {
+	counter, ok := t.freeStickCounter()
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition, "frontend %s has no free stick counter; it tracks at most %d tables", t.frontend, stickCounters)
+	}
+
+	name := t.backendName(policy.Name)
+	period := fmt.Sprintf("%ds", policy.PeriodSeconds)
+	size := rateLimitTableSize
+	expire := int(policy.PeriodSeconds) * 1000
+	stickTable := &dataplane.StickTable{Type: "ip", Size: &size, Expire: &expire, Store: "conn_rate(" + period + ")"}
+	if t.mode == "http" {
+		stickTable.Store = "http_req_rate(" + period + ")"
+	}
+	if policy.Key == pb.RateLimitKey_RATE_LIMIT_KEY_HEADER {
+		keylen := rateLimitHeaderKeylen
+		stickTable.Type = "string"
+		stickTable.Keylen = &keylen
+	}
+	backend := dataplane.Backend{Backend: v3.Backend{Name: &name}, StickTable: stickTable}
+	if _, err := client.AddBackend(ctx, backend, transactionID); err != nil {
+		return handleHAProxyError(err)
+	}
+	t.backends = append(t.backends, backend)
+
+	if t.mode != "http" {
+		track := dataplane.TCPRequestRule{Type: "connection", Action: "track-sc", TrackKey: "src", TrackTable: name, TrackStickCounter: &counter}
+		reject := dataplane.TCPRequestRule{Type: "connection", Action: "reject", Cond: "if", CondTest: rateLimitCondition("sc_conn_rate", counter, name, policy.Requests)}
+		for i, rule := range []dataplane.TCPRequestRule{track, reject} {
+			if _, err := client.AddTCPRequestRule(ctx, t.frontend, transactionID, i, rule); err != nil {
+				return handleHAProxyError(err)
+			}
+		}
+		t.tcpRules = append([]dataplane.TCPRequestRule{track, reject}, t.tcpRules...)
+	} else {
+		key := "src"
+		if policy.Key == pb.RateLimitKey_RATE_LIMIT_KEY_HEADER {
+			key = "req.hdr(" + policy.Header + ")"
+		}
+		action := "deny"
+		if policy.Action == pb.RateLimitAction_RATE_LIMIT_ACTION_TARPIT {
+			action = "tarpit"
+		}
+		denyStatus := int(policy.DenyStatus)
+		track := dataplane.HTTPRequestRule{Type: "track-sc", TrackScKey: key, TrackScTable: name, TrackScStickCounter: &counter}
+		deny := dataplane.HTTPRequestRule{Type: action, DenyStatus: &denyStatus, Cond: "if", CondTest: rateLimitCondition("sc_http_req_rate", counter, name, policy.Requests)}
+		for i, rule := range []dataplane.HTTPRequestRule{track, deny} {
+			if _, err := client.AddHTTPRequestRule(ctx, t.frontend, transactionID, i, rule); err != nil {
+				return handleHAProxyError(err)
+			}
+		}
+		t.httpRules = append([]dataplane.HTTPRequestRule{track, deny}, t.httpRules...)
+	}
+
+	policy.ResourceVersion = resourceVersion(policy)
+	return nil
+}
+
+// remove deletes the rules referencing the stick table of a policy, last first so that the positions of the
+// remaining ones stay valid, and then its backend
+func (t *rateLimitTable) remove(ctx context.Context, client DataplaneClient, transactionID, policyName string) error {
+	name := t.backendName(policyName)
+	for i := len(t.httpRules) - 1; i >= 0; i-- {
+		rule := t.httpRules[i]
+		if _, ok := rateLimitOfCondition(rule.CondTest, name); !ok && rule.TrackScTable != name {
+			continue
+		}
+		if err := client.DeleteHTTPRequestRule(ctx, t.frontend, transactionID, i); err != nil {
+			return handleHAProxyError(err)
+		}
+		t.httpRules = append(t.httpRules[:i], t.httpRules[i+1:]...)
+	}
+	for i := len(t.tcpRules) - 1; i >= 0; i-- {
+		rule := t.tcpRules[i]
+		if _, ok := rateLimitOfCondition(rule.CondTest, name); !ok && rule.TrackTable != name {
+			continue
+		}
+		if err := client.DeleteTCPRequestRule(ctx, t.frontend, transactionID, i); err != nil {
+			return handleHAProxyError(err)
+		}
+		t.tcpRules = append(t.tcpRules[:i], t.tcpRules[i+1:]...)
+	}
+
+	if err := client.DeleteBackend(ctx, name, transactionID); err != nil {
+		return handleHAProxyError(err)
+	}
+	for i, backend := range t.backends {
+		if derefString(backend.Name) == name {
+			t.backends = append(t.backends[:i], t.backends[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// freeStickCounter returns the lowest stick counter no track-sc rule of the frontend uses. HTTP and TCP rules
+// share the counters of a stream.
+func (t *rateLimitTable) freeStickCounter() (int, bool) {
+	used := make(map[int]bool)
+	for _, rule := range t.httpRules {
+		if rule.Type == "track-sc" && rule.TrackScStickCounter != nil {
+			used[*rule.TrackScStickCounter] = true
+		}
+	}
+	for _, rule := range t.tcpRules {
+		if rule.Action == "track-sc" && rule.TrackStickCounter != nil {
+			used[*rule.TrackStickCounter] = true
+		}
+	}
+	for counter := 0; counter < stickCounters; counter++ {
+		if !used[counter] {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// backendName returns the name of the backend holding the stick table of a policy
+func (t *rateLimitTable) backendName(policyName string) string {
+	return rateLimitBackendPrefix + t.frontend + "." + policyName
+}
+
+// policyName returns the policy a backend name belongs to, or an empty string if it holds none of the frontend
+func (t *rateLimitTable) policyName(backendName string) string {
+	name, ok := strings.CutPrefix(backendName, rateLimitBackendPrefix+t.frontend+".")
+	if !ok || !routeNamePattern.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// isRateLimitBackend reports whether a backend holds the stick table of a rate limit policy
+func isRateLimitBackend(name string) bool {
+	return strings.HasPrefix(name, rateLimitBackendPrefix)
+}
+
+// rateLimitCondition builds the condition of a deny rule comparing the rate tracked in a table with a limit
+func rateLimitCondition(fetch string, counter int, table string, limit int64) string {
+	return fmt.Sprintf("{ %s(%d,%s) gt %d }", fetch, counter, table, limit)
+}
+
+// rateLimitOfCondition returns the limit of a condition built by rateLimitCondition for the given table
+func rateLimitOfCondition(condition, table string) (int64, bool) {
+	fields := strings.Fields(condition)
+	if len(fields) != 5 || fields[0] != "{" || fields[2] != "gt" || fields[4] != "}" || !strings.HasSuffix(fields[1], ","+table+")") {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(fields[3], 10, 64)
+	return limit, err == nil
+}
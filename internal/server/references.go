@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkBackendReferences rejects the delete of a backend with FAILED_PRECONDITION while frontends, as seen in
+// the transaction, still refer to it: as their default_backend, in use_backend rules, or as the stick table of
+// track-sc rules
+func checkBackendReferences(ctx context.Context, client DataplaneClient, transactionID, backend string) error {
+	frontends, err := client.ListFrontends(ctx, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+
+	var references []string
+	for _, frontend := range frontends {
+		name := derefString(frontend.Name)
+		if derefString(frontend.DefaultBackend) == backend {
+			references = append(references, fmt.Sprintf("frontend %s (default_backend)", name))
+		}
+
+		switchingRules, err := client.ListBackendSwitchingRules(ctx, name, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		for i, rule := range switchingRules {
+			if rule.Name == backend {
+				references = append(references, fmt.Sprintf("frontend %s (use_backend rule %d)", name, i))
+			}
+		}
+
+		httpRules, err := client.ListHTTPRequestRules(ctx, name, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		for i, rule := range httpRules {
+			if rule.TrackScTable == backend {
+				references = append(references, fmt.Sprintf("frontend %s (http-request rule %d)", name, i))
+			}
+		}
+
+		tcpRules, err := client.ListTCPRequestRules(ctx, name, transactionID)
+		if err != nil {
+			return handleHAProxyError(err)
+		}
+		for i, rule := range tcpRules {
+			if rule.TrackTable == backend {
+				references = append(references, fmt.Sprintf("frontend %s (tcp-request rule %d)", name, i))
+			}
+		}
+	}
+
+	if len(references) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "backend %s is still referenced by %s; remove the references first or set force",
+			backend, strings.Join(references, ", "))
+	}
+	return nil
+}
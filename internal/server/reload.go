@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reload atomically swaps in a new, already validated configuration.
+// The Data Plane API endpoint, credentials, TLS, timeout, retry and failover settings and the Netplan settings take effect
+// immediately; settings that only apply at startup are reported, returned sorted and left unchanged.
+func (s *HAProxyManagerServer) Reload(cfg *config.Config) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old := s.config
+
+	// The Data Plane API clients are created at startup, so dry-run mode and recording cannot change on reload
+	if cfg.DryRun != old.DryRun {
+		logger.GetLogger().Warn("Configuration section changed but only takes effect after a restart",
+			zap.String("section", "dry_run"))
+		cfg.DryRun = old.DryRun
+	}
+	if cfg.DataplaneRecording != old.DataplaneRecording {
+		logger.GetLogger().Warn("Configuration section changed but only takes effect after a restart",
+			zap.String("section", "dataplane_recording"))
+		cfg.DataplaneRecording = old.DataplaneRecording
+	}
+
+	if client, ok := liveClient(s.client); ok {
+		reloadDataplaneClient(client, config.DefaultInstance, old.HAProxy, cfg.HAProxy)
+	}
+
+	oldInstances := make(map[string]config.HAProxyInstance)
+	for _, instance := range old.Instances {
+		oldInstances[instance.Name] = instance
+	}
+	for _, instance := range cfg.Instances {
+		previous, ok := oldInstances[instance.Name]
+		if !ok {
+			continue
+		}
+		if client, ok := liveClient(s.instances[instance.Name]); ok {
+			reloadDataplaneClient(client, instance.Name, previous.HAProxySettings, instance.HAProxySettings)
+		}
+		if previous.Standalone != instance.Standalone {
+			logger.GetLogger().Warn("Configuration section changed but only takes effect after a restart",
+				zap.String("section", "haproxy_instances."+instance.Name+".standalone"))
+		}
+	}
+
+	netplanChanged := !reflect.DeepEqual(old.Netplan, cfg.Netplan)
+	if netplanChanged && s.netplanMgr != nil && cfg.HasNetplanIntegration() {
+		// Updated in place, the manager keeps its tracked addresses, bind index and transactions in progress
+		if err := s.netplanMgr.UpdateSettings(cfg); err != nil {
+			logger.GetLogger().Info("Replacing the Netplan manager",
+				zap.String("reason", err.Error()))
+		} else {
+			netplanChanged = false
+			logger.GetLogger().Info("Reloaded Netplan settings",
+				zap.Bool("netplan_enabled", true),
+				zap.Int("interface_mappings", len(cfg.Netplan.InterfaceMappings)))
+		}
+	}
+	if netplanChanged {
+		var netplanMgr *netplan.Manager
+		if cfg.HasNetplanIntegration() {
+			netplanMgr = netplan.NewManagerWithConfig(cfg)
+			// Pending transactions live on disk; carry over the in-memory address tracking
+			if s.netplanMgr != nil {
+				netplanMgr.RestoreTrackedAddresses(s.netplanMgr.GetTrackedAddresses())
+				netplanMgr.RestoreLastApply(s.netplanMgr.LastApply())
+			}
+		}
+		if s.netplanMgr != nil {
+			s.netplanMgr.Close()
+		}
+		s.netplanMgr = netplanMgr
+
+		logger.GetLogger().Info("Reloaded Netplan settings",
+			zap.Bool("netplan_enabled", cfg.HasNetplanIntegration()),
+			zap.Int("interface_mappings", len(cfg.Netplan.InterfaceMappings)))
+	}
+
+	restartRequired := map[string]bool{
+		"haproxy.circuit_breaker": !reflect.DeepEqual(old.HAProxy.CircuitBreaker, cfg.HAProxy.CircuitBreaker),
+		"haproxy.standalone":      old.HAProxy.Standalone != cfg.HAProxy.Standalone,
+		"haproxy_instances":       !sameInstanceNames(old.Instances, cfg.Instances),
+		"logging":                 !reflect.DeepEqual(old.Logging, cfg.Logging),
+		"journal":                 !reflect.DeepEqual(old.Journal, cfg.Journal),
+		"metadata":                old.Metadata != cfg.Metadata,
+		"audit":                   old.Audit != cfg.Audit,
+		"idempotency":             old.Idempotency != cfg.Idempotency,
+		"webhooks":                !reflect.DeepEqual(old.Webhooks, cfg.Webhooks),
+		"vault":                   !reflect.DeepEqual(old.Vault, cfg.Vault),
+		"gitops":                  old.GitOps != cfg.GitOps,
+		"drift_detection":         old.DriftDetection != cfg.DriftDetection,
+		"kubernetes":              !reflect.DeepEqual(old.Kubernetes, cfg.Kubernetes),
+		"bgp":                     !reflect.DeepEqual(old.BGP, cfg.BGP),
+		"leader_election":         old.LeaderElection != cfg.LeaderElection,
+		"peer_sync":               !reflect.DeepEqual(old.PeerSync, cfg.PeerSync),
+		"netplan.orphan_cleanup.interval_seconds": old.Netplan.OrphanCleanup.IntervalSeconds != cfg.Netplan.OrphanCleanup.IntervalSeconds,
+	}
+	var sections []string
+	for section, changed := range restartRequired {
+		if changed {
+			logger.GetLogger().Warn("Configuration section changed but only takes effect after a restart",
+				zap.String("section", section))
+			sections = append(sections, section)
+		}
+	}
+	sort.Strings(sections)
+
+	s.config = cfg
+	return sections
+}
+
+// SetConfigReloader registers the function reloading the configuration file for ReloadConfig. It returns the
+// changed sections that only take effect after a restart, or why the file could not be loaded.
+func (s *HAProxyManagerServer) SetConfigReloader(reload func() ([]string, error)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reloader = reload
+}
+
+// ReloadConfig reloads the configuration file like SIGHUP. An invalid file fails with FAILED_PRECONDITION and
+// leaves the active configuration in place.
+func (s *HAProxyManagerServer) ReloadConfig(ctx context.Context, _ *pb.ReloadConfigRequest) (*pb.ReloadConfigResponse, error) {
+	s.mutex.RLock()
+	reload := s.reloader
+	s.mutex.RUnlock()
+	if reload == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the configuration cannot be reloaded without a configuration file")
+	}
+
+	sections, err := reload()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to reload configuration, keeping the active configuration: %v", err)
+	}
+	return &pb.ReloadConfigResponse{
+		ConfigFile:      s.currentConfig().Path,
+		RestartRequired: sections,
+	}, nil
+}
+
+// reloadDataplaneClient applies the changed Data Plane API settings of an instance to its client
+func reloadDataplaneClient(client *dataplane.Client, name string, previous, current config.HAProxySettings) {
+	if previous.APIURL != current.APIURL || previous.Username != current.Username || previous.Password != current.Password {
+		client.SetEndpoint(current.APIURL, encodeCredential(current.Username, current.Password))
+
+		logger.GetLogger().Info("Reloaded HAProxy Data Plane API settings",
+			zap.String("instance", name),
+			zap.String("base_url", current.APIURL))
+	}
+	if previous.TLS != current.TLS || previous.ConnectionPool != current.ConnectionPool || !slices.Equal(previous.FallbackAPIURLs, current.FallbackAPIURLs) {
+		client.SetHTTPClient(newDataplaneHTTPClient(name, current))
+	}
+	if previous.RequestTimeoutSeconds != current.RequestTimeoutSeconds || previous.Retry != current.Retry {
+		client.SetRequestPolicy(dataplaneRequestPolicy(current))
+	}
+	if !slices.Equal(previous.FallbackAPIURLs, current.FallbackAPIURLs) || previous.Failover != current.Failover {
+		client.SetFailover(dataplaneFailoverPolicy(current))
+	}
+	if previous.ReadCacheTTLMs != current.ReadCacheTTLMs {
+		client.SetReadCacheTTL(dataplaneReadCacheTTL(current))
+	}
+	if previous.MaxOpenTransactions != current.MaxOpenTransactions {
+		client.SetMaxOpenTransactions(current.MaxOpenTransactions)
+	}
+}
+
+// sameInstanceNames reports whether both configurations declare the same set of HAProxy instances
+func sameInstanceNames(a, b []config.HAProxyInstance) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	names := make(map[string]bool)
+	for _, instance := range a {
+		names[instance.Name] = true
+	}
+	for _, instance := range b {
+		if !names[instance.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// netplan returns the current Netplan manager, or nil if Netplan integration is disabled
+func (s *HAProxyManagerServer) netplan() *netplan.Manager {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.netplanMgr
+}
+
+// currentConfig returns the active configuration
+func (s *HAProxyManagerServer) currentConfig() *config.Config {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.config
+}
@@ -0,0 +1,422 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rings are ring sections; frontends and backends send logs to them with log lines addressed "ring@<name>",
+// which are the only log lines these RPCs touch
+
+const ringAddressPrefix = "ring@"
+
+var sectionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var (
+	ringFormats   = []string{"raw", "rfc3164", "rfc5424", "iso", "timed", "short", "priority"}
+	logLevels     = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+	logFacilities = []string{
+		"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "auth2", "ftp", "ntp",
+		"audit", "alert", "cron2", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+	}
+)
+
+// CreateRing adds a ring section within a transaction
+func (s *HAProxyManagerServer) CreateRing(ctx context.Context, req *pb.CreateRingRequest) (*pb.CreateRingResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if err := validateRing(req.Ring); err != nil {
+		return nil, err
+	}
+
+	ring, err := client.AddRing(ctx, *convertRingFromProto(req.Ring), req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	created := convertRingToProto(ring)
+
+	s.recordChange(resourceRing, actionCreate, "", created.Name, req.TransactionId, nil, created)
+
+	return &pb.CreateRingResponse{Ring: created}, nil
+}
+
+// GetRing retrieves a ring section by name
+func (s *HAProxyManagerServer) GetRing(ctx context.Context, req *pb.GetRingRequest) (*pb.GetRingResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ring name is required")
+	}
+
+	ring, err := client.GetRing(ctx, req.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	return &pb.GetRingResponse{Ring: convertRingToProto(ring)}, nil
+}
+
+// ListRings retrieves all ring sections
+func (s *HAProxyManagerServer) ListRings(ctx context.Context, req *pb.ListRingsRequest) (*pb.ListRingsResponse, error) {
+	client := s.dataplane(ctx)
+
+	rings, err := client.ListRings(ctx, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	result := make([]*pb.Ring, 0, len(rings))
+	for i := range rings {
+		result = append(result, convertRingToProto(&rings[i]))
+	}
+
+	return &pb.ListRingsResponse{Rings: result}, nil
+}
+
+// UpdateRing replaces a ring section within a transaction. HAProxy allocates a new buffer on reload, so the
+// events kept so far are lost.
+func (s *HAProxyManagerServer) UpdateRing(ctx context.Context, req *pb.UpdateRingRequest) (*pb.UpdateRingResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if err := validateRing(req.Ring); err != nil {
+		return nil, err
+	}
+
+	previous, err := client.GetRing(ctx, req.Ring.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	ring, err := client.ReplaceRing(ctx, req.Ring.Name, *convertRingFromProto(req.Ring), req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	updated := convertRingToProto(ring)
+
+	s.recordChange(resourceRing, actionUpdate, "", updated.Name, req.TransactionId, convertRingToProto(previous), updated)
+
+	return &pb.UpdateRingResponse{Ring: updated}, nil
+}
+
+// DeleteRing removes a ring section within a transaction. Rings still receiving logs from a frontend or backend
+// are kept, since HAProxy would reject the configuration.
+func (s *HAProxyManagerServer) DeleteRing(ctx context.Context, req *pb.DeleteRingRequest) (*pb.DeleteRingResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ring name is required")
+	}
+
+	previous, err := client.GetRing(ctx, req.Name, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	users, err := ringUsers(ctx, client, req.Name, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "ring %s receives logs from %s", req.Name, strings.Join(users, ", "))
+	}
+	if err := client.DeleteRing(ctx, req.Name, req.TransactionId); err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	s.recordChange(resourceRing, actionDelete, "", req.Name, req.TransactionId, convertRingToProto(previous), nil)
+
+	return &pb.DeleteRingResponse{}, nil
+}
+
+// AttachRingLogTarget sends the logs of a frontend or backend to a ring within a transaction
+func (s *HAProxyManagerServer) AttachRingLogTarget(ctx context.Context, req *pb.AttachRingLogTargetRequest) (*pb.AttachRingLogTargetResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	parentType, parent, err := logTargetParent(req.FrontendName, req.BackendName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRingLogTarget(req.Target); err != nil {
+		return nil, err
+	}
+
+	if _, err := client.GetRing(ctx, req.Target.Ring, req.TransactionId); err != nil {
+		if status.Code(handleHAProxyError(err)) == codes.NotFound {
+			return nil, status.Errorf(codes.FailedPrecondition, "ring %s does not exist", req.Target.Ring)
+		}
+		return nil, handleHAProxyError(err)
+	}
+	targets, err := client.ListLogTargets(ctx, parentType, parent, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	if findRingLogTarget(targets, req.Target.Ring) >= 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "%s %s already sends logs to ring %s", parentType, parent, req.Target.Ring)
+	}
+	target, err := client.AddLogTarget(ctx, parentType, parent, req.TransactionId, len(targets), convertRingLogTargetFromProto(req.Target))
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	attached := convertRingLogTargetToProto(target)
+
+	s.recordChange(resourceRingLogTarget, actionCreate, parent, attached.Ring, req.TransactionId, nil, attached)
+
+	return &pb.AttachRingLogTargetResponse{Target: attached}, nil
+}
+
+// ListRingLogTargets retrieves the rings a frontend or backend sends logs to
+func (s *HAProxyManagerServer) ListRingLogTargets(ctx context.Context, req *pb.ListRingLogTargetsRequest) (*pb.ListRingLogTargetsResponse, error) {
+	client := s.dataplane(ctx)
+
+	parentType, parent, err := logTargetParent(req.FrontendName, req.BackendName)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := client.ListLogTargets(ctx, parentType, parent, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	var result []*pb.RingLogTarget
+	for i := range targets {
+		if strings.HasPrefix(targets[i].Address, ringAddressPrefix) {
+			result = append(result, convertRingLogTargetToProto(&targets[i]))
+		}
+	}
+
+	return &pb.ListRingLogTargetsResponse{Targets: result}, nil
+}
+
+// DetachRingLogTarget stops sending the logs of a frontend or backend to a ring within a transaction
+func (s *HAProxyManagerServer) DetachRingLogTarget(ctx context.Context, req *pb.DetachRingLogTargetRequest) (*pb.DetachRingLogTargetResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	parentType, parent, err := logTargetParent(req.FrontendName, req.BackendName)
+	if err != nil {
+		return nil, err
+	}
+	if req.Ring == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ring name is required")
+	}
+
+	targets, err := client.ListLogTargets(ctx, parentType, parent, req.TransactionId)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	index := findRingLogTarget(targets, req.Ring)
+	if index < 0 {
+		return nil, status.Errorf(codes.NotFound, "%s %s does not send logs to ring %s", parentType, parent, req.Ring)
+	}
+	if err := client.DeleteLogTarget(ctx, parentType, parent, req.TransactionId, index); err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	s.recordChange(resourceRingLogTarget, actionDelete, parent, req.Ring, req.TransactionId, convertRingLogTargetToProto(&targets[index]), nil)
+
+	return &pb.DetachRingLogTargetResponse{}, nil
+}
+
+// StreamRing sends the events kept in a ring of the running process, followed by new ones if requested. The
+// Data Plane API does not serve ring contents, so they are read through the runtime socket.
+func (s *HAProxyManagerServer) StreamRing(req *pb.StreamRingRequest, stream pb.HAProxyManagerService_StreamRingServer) error {
+	if req.Name == "" {
+		return status.Errorf(codes.InvalidArgument, "ring name is required")
+	}
+
+	ctx := stream.Context()
+	client, err := s.resolveInstance(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = client.WatchRing(ctx, req.Name, req.Follow, func(event string) error {
+		sendErr = stream.Send(&pb.StreamRingResponse{Event: event})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil && ctx.Err() != nil {
+		// The client went away while following the ring
+		return nil
+	}
+	return handleHAProxyError(err)
+}
+
+// ringUsers returns the frontends and backends sending logs to a ring
+func ringUsers(ctx context.Context, client DataplaneClient, ring, transactionID string) ([]string, error) {
+	var users []string
+	frontends, err := client.ListFrontends(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, frontend := range frontends {
+		name := derefString(frontend.Name)
+		targets, err := client.ListLogTargets(ctx, "frontend", name, transactionID)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		if findRingLogTarget(targets, ring) >= 0 {
+			users = append(users, "frontend "+name)
+		}
+	}
+	backends, err := client.ListBackends(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, backend := range backends {
+		name := derefString(backend.Name)
+		targets, err := client.ListLogTargets(ctx, "backend", name, transactionID)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		if findRingLogTarget(targets, ring) >= 0 {
+			users = append(users, "backend "+name)
+		}
+	}
+	return users, nil
+}
+
+// logTargetParent returns the type and name of the frontend or backend log targets are requested for
+func logTargetParent(frontend, backend string) (string, string, error) {
+	switch {
+	case frontend != "" && backend != "":
+		return "", "", status.Errorf(codes.InvalidArgument, "only one of frontend name and backend name may be set")
+	case frontend != "":
+		return "frontend", frontend, nil
+	case backend != "":
+		return "backend", backend, nil
+	}
+	return "", "", status.Errorf(codes.InvalidArgument, "frontend name or backend name is required")
+}
+
+// findRingLogTarget returns the position of the log target sending to a ring, or -1
+func findRingLogTarget(targets []dataplane.LogTarget, ring string) int {
+	return slices.IndexFunc(targets, func(target dataplane.LogTarget) bool {
+		return target.Address == ringAddressPrefix+ring
+	})
+}
+
+// validateRing checks the name, format and sizes of a ring
+func validateRing(ring *pb.Ring) error {
+	switch {
+	case ring == nil:
+		return status.Errorf(codes.InvalidArgument, "ring is required")
+	case ring.Name == "":
+		return status.Errorf(codes.InvalidArgument, "ring name is required")
+	case !sectionNamePattern.MatchString(ring.Name):
+		return status.Errorf(codes.InvalidArgument, "ring name must consist of letters, digits, \".\", \"-\" and \"_\"")
+	case ring.Format != "" && !slices.Contains(ringFormats, ring.Format):
+		return status.Errorf(codes.InvalidArgument, "ring format must be one of %s", strings.Join(ringFormats, ", "))
+	case ring.MaxLength < 0 || ring.Size < 0:
+		return status.Errorf(codes.InvalidArgument, "ring max length and size must not be negative")
+	}
+	return nil
+}
+
+// validateRingLogTarget checks the ring, facility, levels and format of a log target
+func validateRingLogTarget(target *pb.RingLogTarget) error {
+	switch {
+	case target == nil:
+		return status.Errorf(codes.InvalidArgument, "log target is required")
+	case target.Ring == "":
+		return status.Errorf(codes.InvalidArgument, "ring name is required")
+	case !sectionNamePattern.MatchString(target.Ring):
+		return status.Errorf(codes.InvalidArgument, "ring name must consist of letters, digits, \".\", \"-\" and \"_\"")
+	case target.Facility != "" && !slices.Contains(logFacilities, target.Facility):
+		return status.Errorf(codes.InvalidArgument, "unknown syslog facility %q", target.Facility)
+	case target.Level != "" && !slices.Contains(logLevels, target.Level):
+		return status.Errorf(codes.InvalidArgument, "level must be one of %s", strings.Join(logLevels, ", "))
+	case target.MinLevel != "" && !slices.Contains(logLevels, target.MinLevel):
+		return status.Errorf(codes.InvalidArgument, "min level must be one of %s", strings.Join(logLevels, ", "))
+	case target.MinLevel != "" && target.Level == "":
+		return status.Errorf(codes.InvalidArgument, "min level requires a level")
+	case target.Format != "" && !slices.Contains(ringFormats, target.Format):
+		return status.Errorf(codes.InvalidArgument, "log format must be one of %s", strings.Join(ringFormats, ", "))
+	case target.Length < 0:
+		return status.Errorf(codes.InvalidArgument, "log length must not be negative")
+	}
+	return nil
+}
+
+// convertRingToProto converts a ring section to its protobuf message
+func convertRingToProto(ring *dataplane.Ring) *pb.Ring {
+	return &pb.Ring{
+		Name:        derefString(ring.Name),
+		Description: ring.Description,
+		Format:      ring.Format,
+		MaxLength:   derefInt(ring.Maxlen),
+		Size:        derefInt(ring.Size),
+	}
+}
+
+// convertRingFromProto converts a ring message to a ring section
+func convertRingFromProto(ring *pb.Ring) *dataplane.Ring {
+	result := &dataplane.Ring{
+		Name:        &ring.Name,
+		Description: ring.Description,
+		Format:      ring.Format,
+	}
+	if ring.MaxLength > 0 {
+		maxlen := int(ring.MaxLength)
+		result.Maxlen = &maxlen
+	}
+	if ring.Size > 0 {
+		size := int(ring.Size)
+		result.Size = &size
+	}
+	return result
+}
+
+// convertRingLogTargetToProto converts a log line sending to a ring to its protobuf message
+func convertRingLogTargetToProto(target *dataplane.LogTarget) *pb.RingLogTarget {
+	return &pb.RingLogTarget{
+		Ring:     strings.TrimPrefix(target.Address, ringAddressPrefix),
+		Facility: target.Facility,
+		Level:    target.Level,
+		MinLevel: target.Minlevel,
+		Format:   target.Format,
+		Length:   derefInt(target.Length),
+	}
+}
+
+// convertRingLogTargetFromProto converts a ring log target message to a log line, defaulting the facility
+func convertRingLogTargetFromProto(target *pb.RingLogTarget) dataplane.LogTarget {
+	result := dataplane.LogTarget{
+		Address:  ringAddressPrefix + target.Ring,
+		Facility: target.Facility,
+		Level:    target.Level,
+		Minlevel: target.MinLevel,
+		Format:   target.Format,
+	}
+	if result.Facility == "" {
+		result.Facility = "local0"
+	}
+	if target.Length > 0 {
+		length := int(target.Length)
+		result.Length = &length
+	}
+	return result
+}
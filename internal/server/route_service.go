@@ -0,0 +1,460 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Routes are stored as ACLs named after the route and use_backend rules referencing them
+const (
+	routeACLPrefix      = "route_"
+	routeWildcardSuffix = ".wildcard"
+)
+
+// Sample fetches compared with the hostnames of a route
+const (
+	criterionPassthroughSNI = "req.ssl_sni"              // TLS client hello inspected on TCP frontends
+	criterionTerminatedSNI  = "ssl_fc_sni"               // TLS terminated by an HTTP frontend
+	criterionHost           = "req.hdr(host),field(1,:)" // Host header without the port
+)
+
+// sniInspectDelay is how long TCP frontends wait for the TLS client hello to route by SNI, in milliseconds
+const sniInspectDelay = 5000
+
+var (
+	routeNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	hostnamePattern  = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// routeTable is the routing configuration of a frontend: its mode, ACLs and use_backend rules
+type routeTable struct {
+	mode  string
+	acls  []dataplane.ACL
+	rules []dataplane.BackendSwitchingRule
+}
+
+// CreateRoute adds a hostname based route to a frontend within a transaction
+func (s *HAProxyManagerServer) CreateRoute(ctx context.Context, req *pb.CreateRouteRequest) (*pb.CreateRouteResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	route, err := normalizeRoute(req.Route)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := loadRouteTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if table.find(route.Name) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "route %s already exists in frontend %s", route.Name, req.FrontendName)
+	}
+	if err := table.add(ctx, client, req.FrontendName, req.TransactionId, route); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRoute, actionCreate, req.FrontendName, route.Name, req.TransactionId, nil, route)
+
+	return &pb.CreateRouteResponse{Route: route}, nil
+}
+
+// GetRoute retrieves a route of a frontend by name
+func (s *HAProxyManagerServer) GetRoute(ctx context.Context, req *pb.GetRouteRequest) (*pb.GetRouteResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "route name is required")
+	}
+
+	table, err := loadRouteTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	route := table.find(req.Name)
+	if route == nil {
+		return nil, status.Errorf(codes.NotFound, "route %s not found in frontend %s", req.Name, req.FrontendName)
+	}
+
+	return &pb.GetRouteResponse{Route: route}, nil
+}
+
+// ListRoutes retrieves the routes of a frontend in the order of their ACLs
+func (s *HAProxyManagerServer) ListRoutes(ctx context.Context, req *pb.ListRoutesRequest) (*pb.ListRoutesResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+
+	table, err := loadRouteTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRoutesResponse{Routes: table.routes()}, nil
+}
+
+// UpdateRoute replaces a route of a frontend within a transaction. Its ACLs and rule are recreated, so a
+// route whose exact hostnames changed moves behind the other routes with exact hostnames.
+func (s *HAProxyManagerServer) UpdateRoute(ctx context.Context, req *pb.UpdateRouteRequest) (*pb.UpdateRouteResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	route, err := normalizeRoute(req.Route)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := loadRouteTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	previous := table.find(route.Name)
+	if previous == nil {
+		return nil, status.Errorf(codes.NotFound, "route %s not found in frontend %s", route.Name, req.FrontendName)
+	}
+	if err := checkVersion(resourceRoute, req.ExpectedVersion, previous, nil); err != nil {
+		return nil, err
+	}
+	if err := table.remove(ctx, client, req.FrontendName, req.TransactionId, route.Name); err != nil {
+		return nil, err
+	}
+	if err := table.add(ctx, client, req.FrontendName, req.TransactionId, route); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRoute, actionUpdate, req.FrontendName, route.Name, req.TransactionId, previous, route)
+
+	return &pb.UpdateRouteResponse{Route: route}, nil
+}
+
+// DeleteRoute removes a route and its ACLs from a frontend within a transaction
+func (s *HAProxyManagerServer) DeleteRoute(ctx context.Context, req *pb.DeleteRouteRequest) (*pb.DeleteRouteResponse, error) {
+	client := s.dataplane(ctx)
+
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	if req.FrontendName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "frontend name is required")
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "route name is required")
+	}
+
+	table, err := loadRouteTable(ctx, client, req.FrontendName, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	previous := table.find(req.Name)
+	if previous == nil {
+		return nil, status.Errorf(codes.NotFound, "route %s not found in frontend %s", req.Name, req.FrontendName)
+	}
+	if err := checkVersion(resourceRoute, req.ExpectedVersion, previous, nil); err != nil {
+		return nil, err
+	}
+	if err := table.remove(ctx, client, req.FrontendName, req.TransactionId, req.Name); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(resourceRoute, actionDelete, req.FrontendName, req.Name, req.TransactionId, previous, nil)
+
+	return &pb.DeleteRouteResponse{}, nil
+}
+
+// normalizeRoute validates a route and returns a copy with lowercase hostnames, exact ones first, as they are
+// read back from the ACLs
+func normalizeRoute(route *pb.Route) (*pb.Route, error) {
+	if route == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "route is required")
+	}
+	if !routeNamePattern.MatchString(route.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "route name must consist of letters, digits, \"-\" and \"_\"")
+	}
+	if route.Backend == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend is required")
+	}
+	if len(route.Hostnames) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one hostname is required")
+	}
+
+	normalized := &pb.Route{Name: route.Name, Backend: route.Backend, Match: route.Match}
+	var wildcards []string
+	seen := make(map[string]bool, len(route.Hostnames))
+	for _, hostname := range route.Hostnames {
+		hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+		if !hostnamePattern.MatchString(hostname) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid hostname %q", hostname)
+		}
+		if seen[hostname] {
+			return nil, status.Errorf(codes.InvalidArgument, "hostname %s is given more than once", hostname)
+		}
+		seen[hostname] = true
+		if strings.HasPrefix(hostname, "*.") {
+			wildcards = append(wildcards, hostname)
+		} else {
+			normalized.Hostnames = append(normalized.Hostnames, hostname)
+		}
+	}
+	normalized.Hostnames = append(normalized.Hostnames, wildcards...)
+	return normalized, nil
+}
+
+// loadRouteTable reads the mode, ACLs and use_backend rules of a frontend
+func loadRouteTable(ctx context.Context, client DataplaneClient, frontend, transactionID string) (*routeTable, error) {
+	current, err := client.GetFrontend(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	acls, err := client.ListACLs(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	rules, err := client.ListBackendSwitchingRules(ctx, frontend, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	mode := derefString(current.Mode)
+	if mode == "" {
+		mode = "tcp" // The default of HAProxy
+	}
+	return &routeTable{mode: mode, acls: acls, rules: rules}, nil
+}
+
+// routes returns the routes of the frontend in the order of their ACLs. ACLs and rules not created for routes
+// are ignored.
+func (t *routeTable) routes() []*pb.Route {
+	var routes []*pb.Route
+	byName := make(map[string]*pb.Route)
+	for _, acl := range t.acls {
+		name, wildcard, ok := routeACLName(acl.Name)
+		if !ok {
+			continue
+		}
+		route := byName[name]
+		if route == nil {
+			route = &pb.Route{Name: name, Match: matchOfCriterion(acl.Criterion)}
+			byName[name] = route
+			routes = append(routes, route)
+		}
+		values := strings.Fields(acl.Value)
+		for i := 0; i < len(values); i++ {
+			value := values[i]
+			switch {
+			case value == "-m":
+				i++ // The match method, "end" for wildcards
+			case strings.HasPrefix(value, "-"):
+			case wildcard:
+				route.Hostnames = append(route.Hostnames, "*"+value)
+			default:
+				route.Hostnames = append(route.Hostnames, value)
+			}
+		}
+	}
+	for _, rule := range t.rules {
+		if name, _, ok := routeACLName(rule.CondTest); ok && rule.Cond == "if" && byName[name] != nil {
+			byName[name].Backend = rule.Name
+		}
+	}
+
+	// Exact hostnames come first, whichever ACL was read first
+	for _, route := range routes {
+		var exact, wildcards []string
+		for _, hostname := range route.Hostnames {
+			if strings.HasPrefix(hostname, "*.") {
+				wildcards = append(wildcards, hostname)
+			} else {
+				exact = append(exact, hostname)
+			}
+		}
+		route.Hostnames = append(exact, wildcards...)
+		route.ResourceVersion = resourceVersion(route)
+	}
+	return routes
+}
+
+// find returns the route with the given name or nil
+func (t *routeTable) find(name string) *pb.Route {
+	for _, route := range t.routes() {
+		if route.Name == name {
+			return route
+		}
+	}
+	return nil
+}
+
+// add creates the ACLs and use_backend rules of a normalized route. The rule of exact hostnames is inserted
+// before the first rule of wildcards, so exact hostnames take precedence whichever route they belong to.
+func (t *routeTable) add(ctx context.Context, client DataplaneClient, frontend, transactionID string, route *pb.Route) error {
+	for _, other := range t.routes() {
+		for _, hostname := range other.Hostnames {
+			for _, requested := range route.Hostnames {
+				if hostname == requested && other.Name != route.Name {
+					return status.Errorf(codes.AlreadyExists, "hostname %s is already routed by route %s", hostname, other.Name)
+				}
+			}
+		}
+	}
+
+	criterion, err := t.criterion(route)
+	if err != nil {
+		return err
+	}
+	if criterion == criterionPassthroughSNI {
+		if err := ensureSNIInspection(ctx, client, frontend, transactionID); err != nil {
+			return err
+		}
+	}
+
+	var exact, wildcards []string
+	for _, hostname := range route.Hostnames {
+		if suffix, ok := strings.CutPrefix(hostname, "*"); ok {
+			wildcards = append(wildcards, suffix)
+		} else {
+			exact = append(exact, hostname)
+		}
+	}
+	if len(exact) > 0 {
+		acl := dataplane.ACL{Name: routeACLPrefix + route.Name, Criterion: criterion, Value: "-i " + strings.Join(exact, " ")}
+		if err := t.insert(ctx, client, frontend, transactionID, acl, route.Backend, t.firstWildcardRule()); err != nil {
+			return err
+		}
+	}
+	if len(wildcards) > 0 {
+		acl := dataplane.ACL{Name: routeACLPrefix + route.Name + routeWildcardSuffix, Criterion: criterion, Value: "-i -m end " + strings.Join(wildcards, " ")}
+		if err := t.insert(ctx, client, frontend, transactionID, acl, route.Backend, len(t.rules)); err != nil {
+			return err
+		}
+	}
+	route.Match = matchOfCriterion(criterion)
+	route.ResourceVersion = resourceVersion(route)
+	return nil
+}
+
+// insert appends an ACL and inserts the use_backend rule referencing it at the given position
+func (t *routeTable) insert(ctx context.Context, client DataplaneClient, frontend, transactionID string, acl dataplane.ACL, backend string, index int) error {
+	if _, err := client.AddACL(ctx, frontend, transactionID, len(t.acls), acl); err != nil {
+		return handleHAProxyError(err)
+	}
+	t.acls = append(t.acls, acl)
+
+	rule := dataplane.BackendSwitchingRule{Name: backend, Cond: "if", CondTest: acl.Name}
+	if _, err := client.AddBackendSwitchingRule(ctx, frontend, transactionID, index, rule); err != nil {
+		return handleHAProxyError(err)
+	}
+	t.rules = append(t.rules[:index], append([]dataplane.BackendSwitchingRule{rule}, t.rules[index:]...)...)
+	return nil
+}
+
+// remove deletes the ACLs and use_backend rules of a route, last first so that the positions of the remaining
+// ones stay valid
+func (t *routeTable) remove(ctx context.Context, client DataplaneClient, frontend, transactionID, name string) error {
+	for i := len(t.rules) - 1; i >= 0; i-- {
+		if routeName, _, ok := routeACLName(t.rules[i].CondTest); !ok || routeName != name {
+			continue
+		}
+		if err := client.DeleteBackendSwitchingRule(ctx, frontend, transactionID, i); err != nil {
+			return handleHAProxyError(err)
+		}
+		t.rules = append(t.rules[:i], t.rules[i+1:]...)
+	}
+	for i := len(t.acls) - 1; i >= 0; i-- {
+		if routeName, _, ok := routeACLName(t.acls[i].Name); !ok || routeName != name {
+			continue
+		}
+		if err := client.DeleteACL(ctx, frontend, transactionID, i); err != nil {
+			return handleHAProxyError(err)
+		}
+		t.acls = append(t.acls[:i], t.acls[i+1:]...)
+	}
+	return nil
+}
+
+// firstWildcardRule returns the position of the first use_backend rule of wildcard hostnames, or the number of
+// rules if there is none
+func (t *routeTable) firstWildcardRule() int {
+	for i, rule := range t.rules {
+		if _, wildcard, ok := routeACLName(rule.CondTest); ok && wildcard {
+			return i
+		}
+	}
+	return len(t.rules)
+}
+
+// criterion returns the sample fetch matching the hostnames of a route on the frontend
+func (t *routeTable) criterion(route *pb.Route) (string, error) {
+	switch {
+	case route.Match == pb.RouteMatch_ROUTE_MATCH_HOST && t.mode != "http":
+		return "", status.Errorf(codes.FailedPrecondition, "routing by Host header requires an HTTP mode frontend")
+	case route.Match == pb.RouteMatch_ROUTE_MATCH_HOST:
+		return criterionHost, nil
+	case route.Match == pb.RouteMatch_ROUTE_MATCH_SNI && t.mode == "http":
+		return criterionTerminatedSNI, nil
+	case t.mode == "http":
+		return criterionHost, nil
+	default:
+		return criterionPassthroughSNI, nil
+	}
+}
+
+// ensureSNIInspection makes a TCP frontend wait for the TLS client hello before choosing a backend, unless it
+// already has an inspect delay
+func ensureSNIInspection(ctx context.Context, client DataplaneClient, frontend, transactionID string) error {
+	rules, err := client.ListTCPRequestRules(ctx, frontend, transactionID)
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	for _, rule := range rules {
+		if rule.Type == "inspect-delay" {
+			return nil
+		}
+	}
+
+	delay := sniInspectDelay
+	if _, err := client.AddTCPRequestRule(ctx, frontend, transactionID, 0, dataplane.TCPRequestRule{Type: "inspect-delay", Timeout: &delay}); err != nil {
+		return handleHAProxyError(err)
+	}
+	accept := dataplane.TCPRequestRule{Type: "content", Action: "accept", Cond: "if", CondTest: "{ req.ssl_hello_type 1 }"}
+	if _, err := client.AddTCPRequestRule(ctx, frontend, transactionID, 1, accept); err != nil {
+		return handleHAProxyError(err)
+	}
+	return nil
+}
+
+// routeACLName returns the route an ACL name belongs to and whether it is the ACL of its wildcard hostnames
+func routeACLName(aclName string) (name string, wildcard bool, ok bool) {
+	name, ok = strings.CutPrefix(aclName, routeACLPrefix)
+	if !ok {
+		return "", false, false
+	}
+	name, wildcard = strings.CutSuffix(name, routeWildcardSuffix)
+	return name, wildcard, routeNamePattern.MatchString(name)
+}
+
+// matchOfCriterion returns what an ACL criterion compares hostnames with
+func matchOfCriterion(criterion string) pb.RouteMatch {
+	if criterion == criterionHost {
+		return pb.RouteMatch_ROUTE_MATCH_HOST
+	}
+	return pb.RouteMatch_ROUTE_MATCH_SNI
+}
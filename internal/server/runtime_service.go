@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// adminStates maps the administrative states of the API to the ones of the runtime API
+var adminStates = map[pb.ServerAdminState]string{
+	pb.ServerAdminState_SERVER_ADMIN_STATE_READY: dataplane.AdminStateReady,
+	pb.ServerAdminState_SERVER_ADMIN_STATE_DRAIN: dataplane.AdminStateDrain,
+	pb.ServerAdminState_SERVER_ADMIN_STATE_MAINT: dataplane.AdminStateMaint,
+}
+
+// Drain defaults
+const (
+	defaultDrainTimeout      = 5 * time.Minute
+	defaultDrainPollInterval = time.Second
+)
+
+// GetStats returns the live statistics of all frontends, backends and servers
+func (s *HAProxyManagerServer) GetStats(ctx context.Context, _ *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	stats, err := s.dataplane(ctx).GetStats(ctx)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	response := &pb.GetStatsResponse{}
+	for _, entry := range stats {
+		response.Stats = append(response.Stats, &pb.ProxyStats{
+			Type:            entry.Type,
+			Name:            entry.Name,
+			BackendName:     entry.BackendName,
+			Status:          entry.Stats.Status,
+			CurrentSessions: entry.Stats.Scur,
+			MaxSessions:     entry.Stats.Smax,
+			TotalSessions:   entry.Stats.Stot,
+			SessionRate:     entry.Stats.Rate,
+			BytesIn:         entry.Stats.Bin,
+			BytesOut:        entry.Stats.Bout,
+			CheckStatus:     entry.Stats.CheckStatus,
+		})
+	}
+	return response, nil
+}
+
+// SetServerState changes the administrative state of a server in the running process, e.g. to drain it
+// before maintenance. The change takes effect immediately, without a transaction.
+func (s *HAProxyManagerServer) SetServerState(ctx context.Context, req *pb.SetServerStateRequest) (*pb.SetServerStateResponse, error) {
+	if req.BackendName == "" || req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "backend name and server name are required")
+	}
+	state, ok := adminStates[req.AdminState]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "admin state is required")
+	}
+
+	server, err := s.dataplane(ctx).SetServerAdminState(ctx, req.BackendName, req.Name, state)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	logger.FromContext(ctx).Info("Changed server state",
+		zap.String("backend_name", req.BackendName),
+		zap.String("server_name", req.Name),
+		zap.String("admin_state", state))
+
+	response := &pb.SetServerStateResponse{AdminState: req.AdminState}
+	if server != nil {
+		response.OperationalState = server.OperationalState
+		for adminState, name := range adminStates {
+			if name == server.AdminState {
+				response.AdminState = adminState
+			}
+		}
+	}
+	return response, nil
+}
+
+// DrainServer sets a server to drain in the running process, then reports its current sessions every poll
+// interval until they reach zero. The stream fails with DEADLINE_EXCEEDED if sessions remain after the timeout;
+// the server stays in drain either way.
+func (s *HAProxyManagerServer) DrainServer(req *pb.DrainServerRequest, stream pb.HAProxyManagerService_DrainServerServer) error {
+	if req.BackendName == "" || req.Name == "" {
+		return status.Errorf(codes.InvalidArgument, "backend name and server name are required")
+	}
+	timeout, interval := defaultDrainTimeout, defaultDrainPollInterval
+	if req.Timeout != nil {
+		timeout = req.Timeout.AsDuration()
+	}
+	if req.PollInterval != nil {
+		interval = req.PollInterval.AsDuration()
+	}
+	if timeout <= 0 || interval <= 0 {
+		return status.Errorf(codes.InvalidArgument, "timeout and poll interval must be positive durations")
+	}
+
+	ctx := stream.Context()
+	client, err := s.resolveInstance(ctx, "")
+	if err != nil {
+		return err
+	}
+	if _, err := client.SetServerAdminState(ctx, req.BackendName, req.Name, dataplane.AdminStateDrain); err != nil {
+		return handleHAProxyError(err)
+	}
+	logger.FromContext(ctx).Info("Draining server",
+		zap.String("backend_name", req.BackendName),
+		zap.String("server_name", req.Name))
+
+	start := time.Now()
+	for {
+		sessions, err := serverSessions(ctx, client, req.BackendName, req.Name)
+		if err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+		response := &pb.DrainServerResponse{CurrentSessions: sessions, Elapsed: durationpb.New(elapsed), Drained: sessions == 0}
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+		if response.Drained {
+			logger.FromContext(ctx).Info("Server drained",
+				zap.String("backend_name", req.BackendName),
+				zap.String("server_name", req.Name),
+				zap.Duration("elapsed", elapsed))
+			return nil
+		}
+		if elapsed >= timeout {
+			return status.Errorf(codes.DeadlineExceeded, "server %s of backend %s still has %d sessions after %s",
+				req.Name, req.BackendName, sessions, timeout)
+		}
+		if err := sleep(ctx, min(interval, timeout-elapsed)); err != nil {
+			return err
+		}
+	}
+}
+
+// serverSessions returns the current sessions of a server in the running process
+func serverSessions(ctx context.Context, client DataplaneClient, backend, name string) (int64, error) {
+	stats, err := client.GetStats(ctx)
+	if err != nil {
+		return 0, handleHAProxyError(err)
+	}
+	for _, entry := range stats {
+		if entry.Type == "server" && entry.BackendName == backend && entry.Name == name {
+			return entry.Stats.Scur, nil
+		}
+	}
+	return 0, status.Errorf(codes.NotFound, "no statistics for server %s of backend %s", name, backend)
+}
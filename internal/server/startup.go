@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/netplan"
+	"go.uber.org/zap"
+)
+
+// VerifyStartup checks that the Data Plane API of every HAProxy instance answers and imports the state that is
+// lost on restart from the live configuration: the instances the open transactions belong to and, from the binds
+// of the local instance and the Netplan file, the tracked addresses and the bind index
+func (s *HAProxyManagerServer) VerifyStartup(ctx context.Context) error {
+	imported := 0
+	for name, client := range s.instances {
+		if _, err := client.GetVersion(ctx); err != nil {
+			return fmt.Errorf("HAProxy instance %q is not reachable: %w", name, err)
+		}
+		count, err := s.importTransactions(ctx, client)
+		if err != nil {
+			return fmt.Errorf("failed to list the transactions of HAProxy instance %q: %w", name, err)
+		}
+		imported += count
+	}
+	logger.GetLogger().Info("Imported open transactions", zap.Int("transactions", imported))
+
+	netplanMgr := s.netplan()
+	if netplanMgr == nil {
+		return nil
+	}
+	current, err := s.readState(ctx, s.client, "")
+	if err != nil {
+		return fmt.Errorf("failed to read binds: %w", err)
+	}
+	var addresses []string
+	binds := make(map[string]netplan.BindEntry)
+	for _, frontend := range current.Frontends {
+		for _, bind := range frontend.Binds {
+			addresses = append(addresses, bind.Address)
+			binds[netplan.BindKey(frontend.Frontend.Name, bind.Name)] = netplan.BindEntry{Address: bind.Address, Port: int(bind.Port)}
+		}
+	}
+	netplanMgr.ReplaceBinds(binds)
+
+	tracked, err := netplanMgr.ReconcileAddresses(addresses)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile Netplan addresses: %w", err)
+	}
+
+	logger.GetLogger().Info("Reconciled Netplan addresses with the binds",
+		zap.Int("frontends", len(current.Frontends)),
+		zap.Int("binds", len(addresses)),
+		zap.Int("tracked_addresses", tracked))
+	return nil
+}
+
+// importTransactions tracks the open transactions of an instance, so that calls carrying their IDs are routed to
+// it after a restart as before. It returns the number of transactions that were not tracked yet.
+func (s *HAProxyManagerServer) importTransactions(ctx context.Context, client DataplaneClient) (int, error) {
+	transactions, err := client.ListTransactions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.transactionsMutex.Lock()
+	defer s.transactionsMutex.Unlock()
+	imported := 0
+	for _, transaction := range transactions {
+		if transaction.Id == nil {
+			continue
+		}
+		if _, ok := s.transactions[*transaction.Id]; !ok {
+			s.transactions[*transaction.Id] = client.Instance()
+			imported++
+		}
+	}
+	return imported, nil
+}
+
+// Alive reports an error if the configuration lock cannot be taken before ctx is done, i.e. the server is stuck
+func (s *HAProxyManagerServer) Alive(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.currentConfig()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("configuration lock not acquired: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,445 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/standalone"
+	"github.com/bear-san/haproxy-configurator/internal/state"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportState returns all frontends, binds, backends and servers together with the tracked VIPs as one document
+func (s *HAProxyManagerServer) ExportState(ctx context.Context, req *pb.ExportStateRequest) (*pb.ExportStateResponse, error) {
+	client := s.dataplane(ctx)
+
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+
+	current, err := s.readState(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	// IDs are assigned by the Data Plane API and are not portable between environments
+	for _, frontend := range current.Frontends {
+		frontend.Frontend.Id = 0
+		for _, bind := range frontend.Binds {
+			bind.Id = ""
+		}
+	}
+	for _, backend := range current.Backends {
+		backend.Backend.Id = 0
+		for _, server := range backend.Servers {
+			server.Id = ""
+		}
+	}
+	if netplanMgr := s.netplanFor(client); netplanMgr != nil {
+		current.TrackedAddresses = netplanMgr.GetTrackedAddresses()
+	}
+
+	document, err := state.Encode(current, req.Format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.ExportStateResponse{
+		State:    current,
+		Document: document,
+		Version:  derefInt(version),
+	}, nil
+}
+
+// ImportState applies a state document inside a single transaction and commits it
+// Resources in the document are created or replaced; with prune, resources missing from it are deleted.
+// A dry run only returns the planned changes.
+func (s *HAProxyManagerServer) ImportState(ctx context.Context, req *pb.ImportStateRequest) (*pb.ImportStateResponse, error) {
+	desired, err := desiredState(req.State, req.Document, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, changes, err := s.reconcileState(ctx, desired, req.Prune, 0, "", req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ImportStateResponse{Transaction: transaction}
+	for _, change := range changes {
+		switch change.Action {
+		case state.ActionCreate:
+			response.Created++
+		case state.ActionUpdate:
+			response.Updated++
+		case state.ActionDelete:
+			response.Deleted++
+		}
+		response.Changes = append(response.Changes, change.Proto())
+	}
+	return response, nil
+}
+
+// ApplyDesiredState makes the live configuration match the complete desired state
+// Only the operations needed are performed, in one transaction including the Netplan changes;
+// if the configuration already matches or on a dry run, no transaction is created
+func (s *HAProxyManagerServer) ApplyDesiredState(ctx context.Context, req *pb.ApplyDesiredStateRequest) (*pb.ApplyDesiredStateResponse, error) {
+	desired, err := desiredState(req.State, req.Document, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.CheckScope(desired, req.NamePrefix); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state: %v", err)
+	}
+
+	transaction, changes, err := s.reconcileState(ctx, desired, true, req.Version, req.NamePrefix, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ApplyDesiredStateResponse{Transaction: transaction}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, change.Proto())
+	}
+	return response, nil
+}
+
+// DiffTransaction lists the operations committing a transaction performs, by comparing the configuration
+// inside it with the live one, together with the Netplan changes it will apply
+func (s *HAProxyManagerServer) DiffTransaction(ctx context.Context, req *pb.DiffTransactionRequest) (*pb.DiffTransactionResponse, error) {
+	if req.TransactionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction ID is required")
+	}
+	client := s.dataplane(ctx)
+
+	live, err := s.readState(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.readState(ctx, client, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.DiffTransactionResponse{}
+	for _, change := range state.Diff(live, pending, true) {
+		response.Changes = append(response.Changes, change.Proto())
+	}
+
+	if netplanMgr := s.netplanFor(client); netplanMgr != nil {
+		transaction, err := netplanMgr.GetTransaction(req.TransactionId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read Netplan transaction: %v", err)
+		}
+		if transaction != nil {
+			response.NetplanChanges = convertNetplanChangesToProto(transaction.Changes)
+		}
+	}
+	return response, nil
+}
+
+// RenderPreview renders the frontend and backend sections of haproxy.cfg as committing a transaction or
+// applying a state document leaves them, for change reviews. Nothing is written.
+func (s *HAProxyManagerServer) RenderPreview(ctx context.Context, req *pb.RenderPreviewRequest) (*pb.RenderPreviewResponse, error) {
+	var frontends []*standalone.Frontend
+	var backends []*standalone.Backend
+	if req.TransactionId != "" {
+		if req.Document != "" || req.State != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "only one of transaction_id, document and state may be set")
+		}
+		var err error
+		frontends, backends, err = readSections(ctx, s.dataplane(ctx), req.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if req.Document == "" && req.State == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transaction_id, document or state is required")
+		}
+		desired, err := desiredState(req.State, req.Document, req.Format)
+		if err != nil {
+			return nil, err
+		}
+		frontends, backends = stateSections(desired)
+	}
+
+	response := &pb.RenderPreviewResponse{}
+	sections := make([]string, 0, len(frontends)+len(backends))
+	for _, frontend := range frontends {
+		text := standalone.RenderFrontend(frontend)
+		response.Sections = append(response.Sections, &pb.RenderedSection{Type: "frontend", Name: derefString(frontend.Frontend.Name), Text: text})
+		sections = append(sections, text)
+	}
+	for _, backend := range backends {
+		text := standalone.RenderBackend(backend)
+		response.Sections = append(response.Sections, &pb.RenderedSection{Type: "backend", Name: derefString(backend.Backend.Name), Text: text})
+		sections = append(sections, text)
+	}
+	response.Configuration = strings.Join(sections, "\n")
+	return response, nil
+}
+
+// readSections reads the frontends with their binds and rules and the backends with their servers, including
+// the stick table backends of rate limit policies, as they are rendered into haproxy.cfg
+func readSections(ctx context.Context, client DataplaneClient, transactionID string) ([]*standalone.Frontend, []*standalone.Backend, error) {
+	frontends, err := client.ListFrontends(ctx, transactionID)
+	if err != nil {
+		return nil, nil, handleHAProxyError(err)
+	}
+	var frontendSections []*standalone.Frontend
+	for _, frontend := range frontends {
+		name := derefString(frontend.Name)
+		section := &standalone.Frontend{Frontend: frontend}
+		if section.Binds, err = client.ListBinds(ctx, name, transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		if section.ACLs, err = client.ListACLs(ctx, name, transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		if section.TCPRequestRules, err = client.ListTCPRequestRules(ctx, name, transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		if section.HTTPRequestRules, err = client.ListHTTPRequestRules(ctx, name, transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		if section.BackendSwitchingRules, err = client.ListBackendSwitchingRules(ctx, name, transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		frontendSections = append(frontendSections, section)
+	}
+
+	backends, err := client.ListBackends(ctx, transactionID)
+	if err != nil {
+		return nil, nil, handleHAProxyError(err)
+	}
+	var backendSections []*standalone.Backend
+	for _, backend := range backends {
+		section := &standalone.Backend{Backend: backend}
+		if section.Servers, err = client.ListServers(ctx, derefString(backend.Name), transactionID); err != nil {
+			return nil, nil, handleHAProxyError(err)
+		}
+		backendSections = append(backendSections, section)
+	}
+	return frontendSections, backendSections, nil
+}
+
+// stateSections converts the frontends, binds, backends and servers of a state document for rendering. State
+// documents carry no rules, so frontends are rendered without them.
+func stateSections(desired *pb.State) ([]*standalone.Frontend, []*standalone.Backend) {
+	var frontends []*standalone.Frontend
+	for _, entry := range desired.Frontends {
+		section := &standalone.Frontend{Frontend: *convertFrontendFromProto(entry.Frontend)}
+		for _, bind := range entry.Binds {
+			section.Binds = append(section.Binds, *convertBindFromProto(bind))
+		}
+		frontends = append(frontends, section)
+	}
+
+	var backends []*standalone.Backend
+	for _, entry := range desired.Backends {
+		section := &standalone.Backend{Backend: *convertBackendFromProto(entry.Backend)}
+		for _, server := range entry.Servers {
+			section.Servers = append(section.Servers, *convertServerFromProto(server))
+		}
+		backends = append(backends, section)
+	}
+	return frontends, backends
+}
+
+// desiredState takes the state from a request, decoding the document if one is given
+func desiredState(desired *pb.State, document string, format pb.StateFormat) (*pb.State, error) {
+	if document != "" {
+		if desired != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "only one of document and state may be set")
+		}
+		decoded, err := state.Decode(document, format)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		desired = decoded
+	}
+	if desired == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "document or state is required")
+	}
+	if err := state.Validate(desired); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state: %v", err)
+	}
+	state.Normalize(desired)
+	return desired, nil
+}
+
+// reconcileState diffs the live configuration against the desired state and applies the changes in one
+// transaction, applying independent changes concurrently, see state.Stages. The transaction is based on the version the live state was read at, so a concurrent change
+// makes the commit fail instead of being overwritten. If expectedVersion is set, it must match that version.
+// With a prefix, only frontends and backends named with it are compared and pruned.
+// A dry run returns the planned changes without creating a transaction.
+func (s *HAProxyManagerServer) reconcileState(ctx context.Context, desired *pb.State, prune bool, expectedVersion int32, prefix string, dryRun bool) (*pb.Transaction, []state.Change, error) {
+	client := s.dataplane(ctx)
+
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		return nil, nil, handleHAProxyError(err)
+	}
+	if expectedVersion != 0 && derefInt(version) != expectedVersion {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "configuration version is %d, expected %d", derefInt(version), expectedVersion)
+	}
+
+	current, err := s.readState(ctx, client, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if prefix != "" {
+		current = state.Scope(current, prefix)
+	}
+	changes := state.Diff(current, desired, prune)
+	if len(changes) == 0 {
+		logger.FromContext(ctx).Debug("Live configuration already matches the desired state")
+		return nil, nil, nil
+	}
+	if dryRun {
+		logger.FromContext(ctx).Info("Planned state changes (dry run)",
+			zap.Int("changes", len(changes)),
+			zap.Bool("prune", prune))
+		return nil, changes, nil
+	}
+
+	created, err := s.CreateTransaction(ctx, &pb.CreateTransactionRequest{Version: derefInt(version)})
+	if err != nil {
+		return nil, nil, err
+	}
+	transactionID := created.Transaction.Id
+
+	logger.FromContext(ctx).Info("Applying state changes",
+		zap.String("transaction_id", transactionID),
+		zap.Int("changes", len(changes)),
+		zap.Bool("prune", prune))
+
+	// Independent changes are applied concurrently, except binds: the Netplan integration is not safe for
+	// concurrent use
+	workers := s.applyConcurrency(ctx)
+	var bindMutex sync.Mutex
+	for _, stage := range state.Stages(changes) {
+		err := applyConcurrently(workers, stage, func(_ int, change state.Change) error {
+			if change.Resource == state.ResourceBind {
+				bindMutex.Lock()
+				defer bindMutex.Unlock()
+			}
+			err := s.applyStateChange(ctx, transactionID, change)
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to apply state change, closing transaction",
+					zap.String("transaction_id", transactionID),
+					zap.String("resource_type", change.Resource),
+					zap.String("action", change.Action),
+					zap.String("name", change.Name),
+					zap.Error(err))
+			}
+			return err
+		})
+		if err != nil {
+			if _, closeErr := s.CloseTransaction(ctx, &pb.CloseTransactionRequest{TransactionId: transactionID}); closeErr != nil {
+				logger.FromContext(ctx).Warn("Failed to close transaction after state change failure",
+					zap.String("transaction_id", transactionID),
+					zap.Error(closeErr))
+			}
+			return nil, nil, err
+		}
+	}
+
+	committed, err := s.CommitTransactionWithNetplan(ctx, &pb.CommitTransactionRequest{TransactionId: transactionID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return committed.Transaction, changes, nil
+}
+
+// applyStateChange performs a planned change through the regular RPC handlers, so that changes are journaled
+// and binds go through the Netplan integration
+func (s *HAProxyManagerServer) applyStateChange(ctx context.Context, transactionID string, change state.Change) error {
+	var err error
+	switch change.Resource + "/" + change.Action {
+	case state.ResourceBackend + "/" + state.ActionCreate:
+		_, err = s.CreateBackend(ctx, &pb.CreateBackendRequest{Backend: change.Object.(*pb.Backend), TransactionId: transactionID})
+	case state.ResourceBackend + "/" + state.ActionUpdate:
+		_, err = s.UpdateBackend(ctx, &pb.UpdateBackendRequest{Name: change.Name, Backend: change.Object.(*pb.Backend), TransactionId: transactionID})
+	case state.ResourceBackend + "/" + state.ActionDelete:
+		_, err = s.DeleteBackend(ctx, &pb.DeleteBackendRequest{Name: change.Name, TransactionId: transactionID})
+	case state.ResourceFrontend + "/" + state.ActionCreate:
+		_, err = s.CreateFrontend(ctx, &pb.CreateFrontendRequest{Frontend: change.Object.(*pb.Frontend), TransactionId: transactionID})
+	case state.ResourceFrontend + "/" + state.ActionUpdate:
+		_, err = s.UpdateFrontend(ctx, &pb.UpdateFrontendRequest{Name: change.Name, Frontend: change.Object.(*pb.Frontend), TransactionId: transactionID})
+	case state.ResourceFrontend + "/" + state.ActionDelete:
+		_, err = s.DeleteFrontend(ctx, &pb.DeleteFrontendRequest{Name: change.Name, TransactionId: transactionID})
+	case state.ResourceBind + "/" + state.ActionCreate:
+		_, err = s.CreateBindWithNetplan(ctx, &pb.CreateBindRequest{FrontendName: change.Parent, Bind: change.Object.(*pb.Bind), TransactionId: transactionID})
+	case state.ResourceBind + "/" + state.ActionUpdate:
+		_, err = s.UpdateBind(ctx, &pb.UpdateBindRequest{FrontendName: change.Parent, Bind: change.Object.(*pb.Bind), TransactionId: transactionID})
+	case state.ResourceBind + "/" + state.ActionDelete:
+		_, err = s.DeleteBindWithNetplan(ctx, &pb.DeleteBindRequest{FrontendName: change.Parent, Name: change.Name, TransactionId: transactionID})
+	case state.ResourceServer + "/" + state.ActionCreate:
+		_, err = s.CreateServer(ctx, &pb.CreateServerRequest{BackendName: change.Parent, Server: change.Object.(*pb.Server), TransactionId: transactionID})
+	case state.ResourceServer + "/" + state.ActionUpdate:
+		_, err = s.UpdateServer(ctx, &pb.UpdateServerRequest{BackendName: change.Parent, Name: change.Name, Server: change.Object.(*pb.Server), TransactionId: transactionID})
+	case state.ResourceServer + "/" + state.ActionDelete:
+		_, err = s.DeleteServer(ctx, &pb.DeleteServerRequest{BackendName: change.Parent, Name: change.Name, TransactionId: transactionID})
+	default:
+		return status.Errorf(codes.Internal, "unsupported change %s %s", change.Action, change.Resource)
+	}
+	return err
+}
+
+// readState lists all frontends with their binds and all backends with their servers, except the stick table
+// backends of rate limit policies. Resource versions are left out, as state documents compare resources by content.
+func (s *HAProxyManagerServer) readState(ctx context.Context, client DataplaneClient, transactionID string) (*pb.State, error) {
+	result := &pb.State{}
+
+	frontends, err := client.ListFrontends(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, frontend := range frontends {
+		name := derefString(frontend.Name)
+		binds, err := client.ListBinds(ctx, name, transactionID)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		entry := &pb.FrontendState{Frontend: convertFrontendToProto(&frontend)}
+		entry.Frontend.ResourceVersion = ""
+		for _, bind := range binds {
+			converted := convertBindToProto(&bind)
+			converted.ResourceVersion = ""
+			entry.Binds = append(entry.Binds, converted)
+		}
+		result.Frontends = append(result.Frontends, entry)
+	}
+
+	backends, err := client.ListBackends(ctx, transactionID)
+	if err != nil {
+		return nil, handleHAProxyError(err)
+	}
+	for _, backend := range backends {
+		name := derefString(backend.Name)
+		if isRateLimitBackend(name) {
+			continue // Managed through the rate limit policies of frontends
+		}
+		servers, err := client.ListServers(ctx, name, transactionID)
+		if err != nil {
+			return nil, handleHAProxyError(err)
+		}
+		entry := &pb.BackendState{Backend: convertBackendToProto(&backend)}
+		entry.Backend.ResourceVersion = ""
+		for _, server := range servers {
+			converted := convertServerToProto(&server)
+			converted.ResourceVersion = ""
+			entry.Servers = append(entry.Servers, converted)
+		}
+		result.Backends = append(result.Backends, entry)
+	}
+
+	state.Sort(result)
+	return result, nil
+}
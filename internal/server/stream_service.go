@@ -0,0 +1,86 @@
+package server
+
+import (
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/metadata"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamBackends sends the backends matching the filter one at a time, so that neither the server nor the
+// client holds the whole list in memory
+func (s *HAProxyManagerServer) StreamBackends(req *pb.StreamBackendsRequest, stream pb.HAProxyManagerService_StreamBackendsServer) error {
+	ctx := stream.Context()
+	// Streaming calls bypass the unary interceptors, so the instance is resolved here
+	client, err := s.resolveInstance(ctx, req.TransactionId)
+	if err != nil {
+		return err
+	}
+	query, err := newListQuery("backend", req.Filter, "", "name", "mode")
+	if err != nil {
+		return err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = client.EachBackend(ctx, req.TransactionId, func(backend dataplane.Backend) error {
+		converted := convertBackendToProto(&backend)
+		if err := attachMetadata(s, req.TransactionId, []*pb.Backend{converted}, func(backend *pb.Backend) metadata.Key {
+			return metadataKey(client, metadata.KindBackend, "", backend.Name)
+		}); err != nil {
+			return err
+		}
+		if !query.matches(listFields{name: converted.Name, mode: converted.Mode, labels: converted.Labels}) {
+			return nil
+		}
+		sendErr = stream.Send(&pb.StreamBackendsResponse{Backend: converted})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return handleHAProxyError(err)
+}
+
+// StreamServers sends the servers of a backend matching the filter one at a time, so that neither the server
+// nor the client holds the whole list in memory
+func (s *HAProxyManagerServer) StreamServers(req *pb.StreamServersRequest, stream pb.HAProxyManagerService_StreamServersServer) error {
+	if req.BackendName == "" {
+		return status.Errorf(codes.InvalidArgument, "backend name is required")
+	}
+
+	ctx := stream.Context()
+	client, err := s.resolveInstance(ctx, req.TransactionId)
+	if err != nil {
+		return err
+	}
+	query, err := newListQuery("server", req.Filter, "", "name", "address", "port")
+	if err != nil {
+		return err
+	}
+	if err := s.checkLabelSelector(query); err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = client.EachServer(ctx, req.BackendName, req.TransactionId, func(server dataplane.Server) error {
+		converted := convertServerToProto(&server)
+		if err := attachMetadata(s, req.TransactionId, []*pb.Server{converted}, func(server *pb.Server) metadata.Key {
+			return metadataKey(client, metadata.KindServer, req.BackendName, server.Name)
+		}); err != nil {
+			return err
+		}
+		if !query.matches(listFields{name: converted.Name, address: converted.Address, port: converted.Port, labels: converted.Labels}) {
+			return nil
+		}
+		sendErr = stream.Send(&pb.StreamServersResponse{Server: converted})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return handleHAProxyError(err)
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// versionedResource is a backend, frontend, bind, server or route carrying its resource version
+type versionedResource interface {
+	proto.Message
+	GetName() string
+	GetResourceVersion() string
+}
+
+// resourceVersion derives the version of a resource from its content. HAProxy keeps no versions of its own,
+// so a change made by any client of the Data Plane API changes the version as well.
+func resourceVersion(resource versionedResource) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(resource)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// checkVersion compares the version of the current resource, read with err, with the version expected by the
+// request. Nothing is compared if the request expects no version.
+func checkVersion(resourceType, expected string, current versionedResource, err error) error {
+	if expected == "" {
+		return nil
+	}
+	if err != nil {
+		return handleHAProxyError(err)
+	}
+	if actual := current.GetResourceVersion(); actual != expected {
+		return status.Errorf(codes.FailedPrecondition, "%s %s was modified: its version is %s, expected %s",
+			resourceType, current.GetName(), actual, expected)
+	}
+	return nil
+}
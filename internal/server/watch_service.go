@@ -0,0 +1,49 @@
+package server
+
+import (
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchChanges streams configuration change events as backends, frontends, binds and servers are
+// created, updated or deleted and as transactions are committed or closed.
+// Only changes made after the subscription starts are delivered; use ListEvents for history.
+func (s *HAProxyManagerServer) WatchChanges(req *pb.WatchChangesRequest, stream pb.HAProxyManagerService_WatchChangesServer) error {
+	resourceTypes := make(map[string]bool)
+	for _, resourceType := range req.ResourceTypes {
+		resourceTypes[resourceType] = true
+	}
+
+	subscription := s.changes.Subscribe()
+	defer subscription.Cancel()
+
+	logger.FromContext(stream.Context()).Debug("Change watcher connected",
+		zap.Strings("resource_types", req.ResourceTypes))
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			logger.FromContext(stream.Context()).Debug("Change watcher disconnected")
+			return nil
+
+		case event, ok := <-subscription.C:
+			if !ok {
+				if subscription.Overflowed() {
+					return status.Errorf(codes.ResourceExhausted, "watcher fell too far behind; reconnect and resync with List RPCs")
+				}
+				return status.Errorf(codes.Unavailable, "change stream closed")
+			}
+
+			if len(resourceTypes) > 0 && !resourceTypes[event.ResourceType] {
+				continue
+			}
+
+			if err := stream.Send(&pb.WatchChangesResponse{Event: convertEventToProto(&event)}); err != nil {
+				return err
+			}
+		}
+	}
+}
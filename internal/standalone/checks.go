@@ -0,0 +1,67 @@
+package standalone
+
+import (
+	"context"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+)
+
+// ListHTTPChecks lists the http-check rules of a backend in order
+func (c *Client) ListHTTPChecks(ctx context.Context, backend string, transactionId string) ([]dataplane.HTTPCheck, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.HTTPCheck, error) {
+		b, err := cfg.backend(backend)
+		if err != nil {
+			return nil, err
+		}
+		return copyList(b.HTTPChecks), nil
+	})
+}
+
+// AddHTTPCheck inserts an http-check rule into a backend at the given position
+func (c *Client) AddHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.HTTPCheck, error) {
+		b, err := cfg.backend(backend)
+		if err != nil {
+			return nil, err
+		}
+		list, err := insertAt(b.HTTPChecks, index, copyOf(check))
+		if err != nil {
+			return nil, err
+		}
+		b.HTTPChecks = list
+		result := copyOf(check)
+		return &result, nil
+	})
+}
+
+// ReplaceHTTPCheck replaces the http-check rule of a backend at the given position
+func (c *Client) ReplaceHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.HTTPCheck, error) {
+		b, err := cfg.backend(backend)
+		if err != nil {
+			return nil, err
+		}
+		if err := replaceAt(b.HTTPChecks, index, copyOf(check)); err != nil {
+			return nil, err
+		}
+		result := copyOf(check)
+		return &result, nil
+	})
+}
+
+// DeleteHTTPCheck deletes the http-check rule of a backend at the given position
+func (c *Client) DeleteHTTPCheck(ctx context.Context, backend string, transactionId string, index int) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		b, err := cfg.backend(backend)
+		if err != nil {
+			return struct{}{}, err
+		}
+		list, err := deleteAt(b.HTTPChecks, index)
+		if err != nil {
+			return struct{}{}, err
+		}
+		b.HTTPChecks = list
+		return struct{}{}, nil
+	})
+	return err
+}
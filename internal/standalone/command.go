@@ -0,0 +1,20 @@
+package standalone
+
+import (
+	"context"
+	"os/exec"
+)
+
+// CommandRunner runs the haproxy and systemctl commands of the standalone mode
+type CommandRunner interface {
+	// Run runs a command until it exits or ctx is done, returning its combined output
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// ExecRunner runs commands on the host
+type ExecRunner struct{}
+
+// Run executes the command, killing it if ctx is done first
+func (ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
@@ -0,0 +1,222 @@
+package standalone
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// ErrRuntimeUnavailable is returned for statistics and runtime changes if no master socket is configured
+var ErrRuntimeUnavailable = errors.New("runtime API unavailable in standalone mode without master_socket")
+
+// masterTimeout bounds a command on the master socket without a deadline of its own
+const masterTimeout = 10 * time.Second
+
+// masterCommand sends a command to the master CLI of HAProxy and returns its output. Commands for the worker
+// are prefixed with @1.
+func (c *Client) masterCommand(ctx context.Context, command string) (string, error) {
+	if c.settings.MasterSocket == "" {
+		return "", ErrRuntimeUnavailable
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.settings.MasterSocket)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to master socket: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(masterTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if _, err := io.WriteString(conn, command+"\n"); err != nil {
+		return "", fmt.Errorf("failed to send %q to master socket: %w", command, err)
+	}
+	output, err := io.ReadAll(conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the answer to %q from master socket: %w", command, err)
+	}
+	return string(output), nil
+}
+
+// masterReload reloads HAProxy with the reload command of the master CLI. HAProxy 2.7 and later answer with
+// the outcome; a failed reload keeps the previous workers running.
+func (c *Client) masterReload(ctx context.Context) error {
+	output, err := c.masterCommand(ctx, "reload")
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(output, "Success=0") {
+		return fmt.Errorf("reload failed: %s", strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// GetStats returns the statistics of the frontends, backends and servers of the running worker
+func (c *Client) GetStats(ctx context.Context) ([]dataplane.ProxyStats, error) {
+	rows, err := c.showStat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []dataplane.ProxyStats
+	for _, row := range rows {
+		entry := dataplane.ProxyStats{Name: row["pxname"]}
+		switch row["type"] {
+		case "0":
+			entry.Type = "frontend"
+		case "1":
+			entry.Type = "backend"
+		case "2":
+			entry.Type = "server"
+			entry.Name = row["svname"]
+			entry.BackendName = row["pxname"]
+		default:
+			continue // Listeners
+		}
+		entry.Stats = dataplane.StatsValues{
+			Status:      row["status"],
+			Scur:        counter(row["scur"]),
+			Smax:        counter(row["smax"]),
+			Stot:        counter(row["stot"]),
+			Rate:        counter(row["rate"]),
+			Bin:         counter(row["bin"]),
+			Bout:        counter(row["bout"]),
+			ReqTot:      counter(row["req_tot"]),
+			Hrsp5xx:     counter(row["hrsp_5xx"]),
+			Econ:        counter(row["econ"]),
+			Eresp:       counter(row["eresp"]),
+			CheckStatus: row["check_status"],
+		}
+		stats = append(stats, entry)
+	}
+	return stats, nil
+}
+
+// SetServerAdminState changes the administrative state of a server in the running worker
+func (c *Client) SetServerAdminState(ctx context.Context, backend, name, state string) (*dataplane.RuntimeServer, error) {
+	switch state {
+	case dataplane.AdminStateReady, dataplane.AdminStateDrain, dataplane.AdminStateMaint:
+	default:
+		return nil, &v3.BadRequestError{Message: fmt.Sprintf("invalid admin state %q", state)}
+	}
+
+	output, err := c.masterCommand(ctx, fmt.Sprintf("@1 set server %s/%s state %s", backend, name, state))
+	if err != nil {
+		return nil, err
+	}
+	if message := strings.TrimSpace(output); message != "" {
+		if strings.Contains(message, "No such") {
+			return nil, &v3.NotFoundError{Message: message}
+		}
+		return nil, &v3.BadRequestError{Message: message}
+	}
+
+	servers, err := c.ListRuntimeServers(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		if server.Name == name {
+			return &server, nil
+		}
+	}
+	return nil, &v3.NotFoundError{Message: fmt.Sprintf("server %s not found", name)}
+}
+
+// ListRuntimeServers lists the state of the servers of a backend in the running worker
+func (c *Client) ListRuntimeServers(ctx context.Context, backend string) ([]dataplane.RuntimeServer, error) {
+	rows, err := c.showStat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	servers := []dataplane.RuntimeServer{}
+	for _, row := range rows {
+		if row["pxname"] != backend {
+			continue
+		}
+		found = true
+		if row["type"] != "2" {
+			continue
+		}
+
+		server := dataplane.RuntimeServer{Name: row["svname"]}
+		if host, port, err := net.SplitHostPort(row["addr"]); err == nil {
+			server.Address = host
+			if p, err := strconv.Atoi(port); err == nil {
+				server.Port = &p
+			}
+		}
+		server.AdminState, server.OperationalState = serverStates(row["status"])
+		servers = append(servers, server)
+	}
+	if !found {
+		return nil, &v3.NotFoundError{Message: fmt.Sprintf("backend %s not found", backend)}
+	}
+	return servers, nil
+}
+
+// showStat reads the statistics of the running worker, one row per proxy, server and listener by column name
+func (c *Client) showStat(ctx context.Context) ([]map[string]string, error) {
+	output, err := c.masterCommand(ctx, "@1 show stat")
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(output, "# ")))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, &v3.InvalidResponseError{Message: fmt.Sprintf("unexpected show stat output: %q", output)}
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// serverStates derives the administrative and operational state of a server from its status, e.g. "UP",
+// "DOWN", "NOLB", "DRAIN" or "MAINT (via b/s)"
+func serverStates(status string) (admin, operational string) {
+	switch {
+	case strings.HasPrefix(status, "MAINT"):
+		return dataplane.AdminStateMaint, "down"
+	case strings.HasPrefix(status, "DRAIN"):
+		return dataplane.AdminStateDrain, "up"
+	case strings.HasPrefix(status, "NOLB"):
+		return dataplane.AdminStateReady, "stopping"
+	case strings.HasPrefix(status, "DOWN"):
+		return dataplane.AdminStateReady, "down"
+	default:
+		return dataplane.AdminStateReady, "up"
+	}
+}
+
+// counter parses a counter of the statistics; empty fields are zero
+func counter(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
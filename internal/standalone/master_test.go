@@ -0,0 +1,172 @@
+package standalone
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+const showStat = `# pxname,svname,qcur,qmax,scur,smax,slim,stot,bin,bout,dreq,dresp,ereq,econ,eresp,wretr,wredis,status,weight,act,bck,chkfail,chkdown,lastchg,downtime,qlimit,pid,iid,sid,throttle,lbtot,tracked,type,rate,rate_lim,rate_max,check_status,check_code,check_duration,hrsp_1xx,hrsp_2xx,hrsp_3xx,hrsp_4xx,hrsp_5xx,hrsp_other,hanafail,req_rate,req_rate_max,req_tot,cli_abrt,srv_abrt,addr
+web,FRONTEND,,,3,10,4096,120,1000,2000,0,0,0,,,,,OPEN,,,,,,,,,1,2,0,,,,0,5,0,20,,,,0,100,0,0,2,0,,4,15,102,,,
+app,app1,0,0,2,5,,60,500,1000,,0,,1,0,0,0,UP,10,1,0,0,0,100,0,,1,3,1,,60,,2,2,,10,L4OK,,1,0,50,0,0,1,0,,,,,0,0,10.0.0.1:8080
+app,app2,0,0,0,0,,0,0,0,,0,,0,0,0,0,MAINT,1,1,0,0,0,100,0,,1,3,2,,0,,2,0,,0,,,,0,0,0,0,0,0,,,,,0,0,10.0.0.2:8080
+app,BACKEND,0,0,2,5,410,60,500,1000,0,0,,1,0,0,0,UP,11,1,0,,0,100,0,,1,3,0,,60,,1,2,,10,,,,0,50,0,0,1,0,,,,52,0,0,
+`
+
+// startMaster serves a fake master CLI on a Unix socket, answering each command with answer
+func startMaster(t *testing.T, answer func(command string) string) (string, func() []string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "master.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("Unix sockets unavailable: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mutex sync.Mutex
+	var commands []string
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			command, _ := bufio.NewReader(conn).ReadString('\n')
+			command = strings.TrimSpace(command)
+			mutex.Lock()
+			commands = append(commands, command)
+			mutex.Unlock()
+			_, _ = conn.Write([]byte(answer(command)))
+			conn.Close()
+		}
+	}()
+	return path, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string(nil), commands...)
+	}
+}
+
+func TestMasterSocketRuntime(t *testing.T) {
+	ctx := context.Background()
+	socket, commands := startMaster(t, func(command string) string {
+		switch {
+		case command == "@1 show stat":
+			return showStat
+		case strings.HasPrefix(command, "@1 set server app/missing"):
+			return "No such server.\n"
+		default:
+			return ""
+		}
+	})
+	client, _, _ := newTestClient(t)
+	client.settings.MasterSocket = socket
+
+	stats, err := client.GetStats(ctx)
+	if err != nil || len(stats) != 4 {
+		t.Fatalf("Expected 4 statistics, got %v: %v", stats, err)
+	}
+	if stats[1].Type != "server" || stats[1].Name != "app1" || stats[1].BackendName != "app" || stats[1].Stats.Scur != 2 || stats[1].Stats.CheckStatus != "L4OK" {
+		t.Errorf("Unexpected server statistics %+v", stats[1])
+	}
+	if stats[0].Type != "frontend" || stats[0].Stats.ReqTot != 102 || stats[0].Stats.Hrsp5xx != 2 {
+		t.Errorf("Unexpected frontend statistics %+v", stats[0])
+	}
+
+	servers, err := client.ListRuntimeServers(ctx, "app")
+	if err != nil || len(servers) != 2 {
+		t.Fatalf("Expected 2 runtime servers, got %v: %v", servers, err)
+	}
+	if servers[0].Address != "10.0.0.1" || *servers[0].Port != 8080 || servers[0].AdminState != dataplane.AdminStateReady || servers[0].OperationalState != "up" {
+		t.Errorf("Unexpected runtime server %+v", servers[0])
+	}
+	if servers[1].AdminState != dataplane.AdminStateMaint {
+		t.Errorf("Expected app2 in maintenance, got %+v", servers[1])
+	}
+	if _, err := client.ListRuntimeServers(ctx, "missing"); !v3.IsNotFound(err) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	if _, err := client.SetServerAdminState(ctx, "app", "app1", dataplane.AdminStateDrain); err != nil {
+		t.Errorf("SetServerAdminState failed: %v", err)
+	}
+	if _, err := client.SetServerAdminState(ctx, "app", "missing", dataplane.AdminStateDrain); !v3.IsNotFound(err) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if got := commands(); !slices.Contains(got, "@1 set server app/app1 state drain") {
+		t.Errorf("Expected the state change to be sent, got %v", got)
+	}
+}
+
+func TestMasterSocketReload(t *testing.T) {
+	ctx := context.Background()
+	answer := "Success=1\n--\n"
+	socket, commands := startMaster(t, func(string) string { return answer })
+	client, settings, runner := newTestClient(t)
+	client.settings.Reload = "master_socket"
+	client.settings.MasterSocket = socket
+
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "app")); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	if got := commands(); len(got) != 1 || got[0] != "reload" {
+		t.Errorf("Expected a reload through the master socket, got %v", got)
+	}
+	if len(runner.commands) != 1 {
+		t.Errorf("Expected only the check to run, got %v", runner.commands)
+	}
+
+	answer = "Success=0\n--\n[ALERT] config: parsing error\n"
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "other")); !v3.IsCommitFailed(err) {
+		t.Errorf("Expected the failed reload to fail the commit, got %v", err)
+	}
+	if data, _ := os.ReadFile(settings.ConfigFile); strings.Contains(string(data), "backend other") {
+		t.Errorf("Expected the previous configuration to be restored, got:\n%s", data)
+	}
+}
+
+func TestMasterSocketRing(t *testing.T) {
+	ctx := context.Background()
+	socket, commands := startMaster(t, func(command string) string {
+		if command == "@1 show events debug" {
+			return "<134>Oct 17 10:00:00 haproxy[1]: first\n<134>Oct 17 10:00:01 haproxy[1]: second\n"
+		}
+		return "No such event sink. Possible values:\n  buf0\n"
+	})
+	client, _, _ := newTestClient(t)
+	client.settings.MasterSocket = socket
+
+	var events []string
+	if err := client.WatchRing(ctx, "debug", false, func(event string) error {
+		events = append(events, event)
+		return nil
+	}); err != nil {
+		t.Fatalf("WatchRing failed: %v", err)
+	}
+	if len(events) != 2 || !strings.HasSuffix(events[1], "second") {
+		t.Errorf("Expected the events of the ring, got %v", events)
+	}
+	if err := client.WatchRing(ctx, "missing", false, func(string) error { return nil }); !v3.IsNotFound(err) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if got := commands(); len(got) != 2 || got[0] != "@1 show events debug" {
+		t.Errorf("Expected show events through the master socket, got %v", got)
+	}
+}
+
+func TestRuntimeWithoutMasterSocket(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	if _, err := client.GetStats(context.Background()); !errors.Is(err, ErrRuntimeUnavailable) {
+		t.Errorf("Expected ErrRuntimeUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package standalone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// program returns the program section with the given name
+func (c *configuration) program(name string) (*dataplane.Program, error) {
+	for i := range c.Programs {
+		if nameOf(c.Programs[i].Name) == name {
+			return &c.Programs[i], nil
+		}
+	}
+	return nil, &v3.NotFoundError{Message: fmt.Sprintf("program %s not found", name)}
+}
+
+// AddProgram creates a program section
+func (c *Client) AddProgram(ctx context.Context, program dataplane.Program, transactionId string) (*dataplane.Program, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Program, error) {
+		if err := requireName("program", program.Name); err != nil {
+			return nil, err
+		}
+		if _, err := cfg.program(*program.Name); err == nil {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("program %s already exists", *program.Name)}
+		}
+		cfg.Programs = append(cfg.Programs, copyOf(program))
+		result := copyOf(program)
+		return &result, nil
+	})
+}
+
+// GetProgram retrieves a program section by name
+func (c *Client) GetProgram(ctx context.Context, name string, transactionId string) (*dataplane.Program, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Program, error) {
+		existing, err := cfg.program(name)
+		if err != nil {
+			return nil, err
+		}
+		result := copyOf(*existing)
+		return &result, nil
+	})
+}
+
+// ListPrograms lists the program sections
+func (c *Client) ListPrograms(ctx context.Context, transactionId string) ([]dataplane.Program, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Program, error) {
+		return copyList(cfg.Programs), nil
+	})
+}
+
+// ReplaceProgram replaces a program section
+func (c *Client) ReplaceProgram(ctx context.Context, name string, program dataplane.Program, transactionId string) (*dataplane.Program, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Program, error) {
+		existing, err := cfg.program(name)
+		if err != nil {
+			return nil, err
+		}
+		program.Name = &name
+		*existing = copyOf(program)
+		result := copyOf(program)
+		return &result, nil
+	})
+}
+
+// DeleteProgram deletes a program section
+func (c *Client) DeleteProgram(ctx context.Context, name string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		for i := range cfg.Programs {
+			if nameOf(cfg.Programs[i].Name) == name {
+				cfg.Programs = append(cfg.Programs[:i], cfg.Programs[i+1:]...)
+				return struct{}{}, nil
+			}
+		}
+		return struct{}{}, &v3.NotFoundError{Message: fmt.Sprintf("program %s not found", name)}
+	})
+	return err
+}
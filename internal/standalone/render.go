@@ -0,0 +1,399 @@
+package standalone
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+)
+
+// defaultBase is written in place of the global and defaults sections if base_file is not set
+const defaultBase = `global
+    log /dev/log local0
+    maxconn 4096
+
+defaults
+    log global
+    timeout connect 5s
+    timeout client 50s
+    timeout server 50s
+`
+
+// render returns the haproxy.cfg of a configuration: the base file followed by the managed sections
+func (c *Client) render(cfg *configuration) ([]byte, error) {
+	base := defaultBase
+	if c.settings.BaseFile != "" {
+		data, err := os.ReadFile(c.settings.BaseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read base file: %w", err)
+		}
+		base = string(data)
+	}
+	return []byte(renderConfiguration(base, cfg)), nil
+}
+
+// renderConfiguration renders the managed sections after the global and defaults sections in base. Lua scripts
+// are loaded by a global section of their own, which HAProxy merges with the one in base.
+func renderConfiguration(base string, cfg *configuration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by haproxy-configurator (version %d); changes are overwritten on the next commit\n\n", cfg.Version)
+	b.WriteString(strings.TrimRight(base, "\n"))
+	b.WriteString("\n")
+
+	if len(cfg.LuaLoads) > 0 {
+		b.WriteString("\nglobal\n")
+		for _, file := range cfg.LuaLoads {
+			line(&b, "lua-load", file)
+		}
+	}
+	for _, ring := range cfg.Rings {
+		b.WriteString("\n")
+		b.WriteString(RenderRing(ring))
+	}
+	for _, program := range cfg.Programs {
+		b.WriteString("\n")
+		b.WriteString(RenderProgram(program))
+	}
+
+	for _, f := range cfg.Frontends {
+		b.WriteString("\n")
+		b.WriteString(RenderFrontend(f))
+	}
+	for _, be := range cfg.Backends {
+		b.WriteString("\n")
+		b.WriteString(RenderBackend(be))
+	}
+	return b.String()
+}
+
+// RenderRing returns a ring section in haproxy.cfg syntax
+func RenderRing(ring dataplane.Ring) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ring %s\n", nameOf(ring.Name))
+	if ring.Description != "" {
+		line(&b, "description", ring.Description)
+	}
+	if ring.Format != "" {
+		line(&b, "format", ring.Format)
+	}
+	if ring.Maxlen != nil {
+		line(&b, "maxlen", strconv.Itoa(*ring.Maxlen))
+	}
+	if ring.Size != nil {
+		line(&b, "size", strconv.Itoa(*ring.Size))
+	}
+	return b.String()
+}
+
+// RenderProgram returns a program section in haproxy.cfg syntax
+func RenderProgram(program dataplane.Program) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "program %s\n", nameOf(program.Name))
+	line(&b, "command", program.Command)
+	if program.User != "" {
+		line(&b, "user", program.User)
+	}
+	if program.Group != "" {
+		line(&b, "group", program.Group)
+	}
+	if program.StartOnReload == "disabled" {
+		line(&b, "no", "option", "start-on-reload")
+	}
+	return b.String()
+}
+
+// RenderFrontend returns a frontend section in haproxy.cfg syntax. Rules are written in the order HAProxy
+// evaluates them, so that it does not warn about them.
+func RenderFrontend(f *Frontend) string {
+	var b strings.Builder
+	renderFrontend(&b, f)
+	return b.String()
+}
+
+// RenderBackend returns a backend section in haproxy.cfg syntax
+func RenderBackend(be *Backend) string {
+	var b strings.Builder
+	renderBackend(&b, be)
+	return b.String()
+}
+
+// renderFrontend writes a frontend section
+func renderFrontend(b *strings.Builder, f *Frontend) {
+	fmt.Fprintf(b, "frontend %s\n", nameOf(f.Frontend.Name))
+	if f.Frontend.Mode != nil && *f.Frontend.Mode != "" {
+		line(b, "mode", *f.Frontend.Mode)
+	}
+	if f.Frontend.Description != nil && *f.Frontend.Description != "" {
+		line(b, "description", *f.Frontend.Description)
+	}
+	if isTrue(f.Frontend.Disabled) {
+		line(b, "disabled")
+	}
+	for _, target := range f.LogTargets {
+		line(b, logLine(target)...)
+	}
+	for _, bind := range f.Binds {
+		line(b, bindLine(bind)...)
+	}
+	for _, acl := range f.ACLs {
+		line(b, "acl", acl.Name, acl.Criterion, acl.Value)
+	}
+	for _, rule := range f.TCPRequestRules {
+		line(b, tcpRequestLine(rule)...)
+	}
+	for _, rule := range f.HTTPRequestRules {
+		line(b, httpRequestLine(rule)...)
+	}
+	for _, rule := range f.BackendSwitchingRules {
+		line(b, "use_backend", rule.Name, rule.Cond, rule.CondTest)
+	}
+	if f.Frontend.DefaultBackend != nil && *f.Frontend.DefaultBackend != "" {
+		line(b, "default_backend", *f.Frontend.DefaultBackend)
+	}
+}
+
+// renderBackend writes a backend section
+func renderBackend(b *strings.Builder, be *Backend) {
+	fmt.Fprintf(b, "backend %s\n", nameOf(be.Backend.Name))
+	if be.Backend.Mode != "" {
+		line(b, "mode", be.Backend.Mode)
+	}
+	if be.Backend.Balance != nil && be.Backend.Balance.Algorithm != "" {
+		line(b, "balance", be.Backend.Balance.Algorithm)
+	}
+	if be.Backend.Fullconn != nil {
+		line(b, "fullconn", strconv.Itoa(*be.Backend.Fullconn))
+	}
+	if be.Backend.DefaultServer != nil {
+		if limits := connectionLimits(be.Backend.DefaultServer.ConnectionLimits); len(limits) > 0 {
+			line(b, append([]string{"default-server"}, limits...)...)
+		}
+	}
+	if table := be.Backend.StickTable; table != nil {
+		words := []string{"stick-table", "type", table.Type}
+		if table.Keylen != nil {
+			words = append(words, "len", strconv.Itoa(*table.Keylen))
+		}
+		if table.Size != nil {
+			words = append(words, "size", strconv.Itoa(*table.Size))
+		}
+		if table.Expire != nil {
+			words = append(words, "expire", strconv.Itoa(*table.Expire)+"ms")
+		}
+		if table.Store != "" {
+			words = append(words, "store", table.Store)
+		}
+		line(b, words...)
+	}
+	if isTrue(be.Backend.Disabled) {
+		line(b, "disabled")
+	}
+	for _, target := range be.LogTargets {
+		line(b, logLine(target)...)
+	}
+	if be.Backend.AdvCheck != "" {
+		line(b, "option", be.Backend.AdvCheck)
+	}
+	for _, check := range be.HTTPChecks {
+		line(b, httpCheckLine(check)...)
+	}
+	for _, server := range be.Servers {
+		line(b, serverLine(server)...)
+	}
+}
+
+// bindLine returns the words of a bind line
+func bindLine(bind dataplane.Bind) []string {
+	address := nameOf(bind.Address)
+	if bind.Port != nil {
+		address += ":" + strconv.Itoa(*bind.Port)
+	}
+	words := []string{"bind", address, "name", nameOf(bind.Name)}
+	if isTrue(bind.V4V6) {
+		words = append(words, "v4v6")
+	}
+	if isTrue(bind.V6Only) {
+		words = append(words, "v6only")
+	}
+	if isTrue(bind.AcceptProxy) {
+		words = append(words, "accept-proxy")
+	}
+	if isTrue(bind.SSL) {
+		words = append(words, "ssl")
+		if bind.SSLCertificate != nil && *bind.SSLCertificate != "" {
+			words = append(words, "crt", *bind.SSLCertificate)
+		}
+	}
+	return words
+}
+
+// logLine returns the words of a log line: its target, options, facility and levels
+func logLine(target dataplane.LogTarget) []string {
+	words := []string{"log", target.Address}
+	if target.Length != nil {
+		words = append(words, "len", strconv.Itoa(*target.Length))
+	}
+	if target.Format != "" {
+		words = append(words, "format", target.Format)
+	}
+	return append(words, target.Facility, target.Level, target.Minlevel)
+}
+
+// httpCheckLine returns the words of an http-check rule, quoting the values that may contain spaces
+func httpCheckLine(check dataplane.HTTPCheck) []string {
+	words := []string{"http-check", check.Type}
+	if check.Type == "expect" {
+		if check.ExclamationMark {
+			words = append(words, "!")
+		}
+		return append(words, check.Match, quoteWord(check.Pattern))
+	}
+	if check.Method != "" {
+		words = append(words, "meth", check.Method)
+	}
+	if check.URI != "" {
+		words = append(words, "uri", quoteWord(check.URI))
+	}
+	if check.Version != "" {
+		words = append(words, "ver", check.Version)
+	}
+	for _, header := range check.Headers {
+		words = append(words, "hdr", header.Name, quoteWord(header.Fmt))
+	}
+	if check.Body != "" {
+		words = append(words, "body", quoteWord(check.Body))
+	}
+	return words
+}
+
+// quoteWord puts a word in double quotes if haproxy.cfg would otherwise split or unescape it
+func quoteWord(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t\"'\\#$") {
+		return word
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(word) + `"`
+}
+
+// serverLine returns the words of a server line
+func serverLine(server dataplane.Server) []string {
+	address := nameOf(server.Address)
+	if server.Port != nil {
+		address += ":" + strconv.Itoa(*server.Port)
+	}
+	words := []string{"server", nameOf(server.Name), address}
+	if server.Weight != nil {
+		words = append(words, "weight", strconv.Itoa(*server.Weight))
+	}
+	words = append(words, connectionLimits(server.ConnectionLimits)...)
+	if server.SendProxy == "enabled" {
+		words = append(words, "send-proxy")
+	}
+	if server.SendProxyV2 == "enabled" {
+		words = append(words, "send-proxy-v2")
+	}
+	if server.Maintenance == "enabled" {
+		words = append(words, "disabled")
+	}
+	return words
+}
+
+// connectionLimits returns the words of the connection limits of a server or default-server line
+func connectionLimits(limits dataplane.ConnectionLimits) []string {
+	var words []string
+	for _, limit := range []struct {
+		keyword string
+		value   *int
+	}{
+		{"maxconn", limits.Maxconn},
+		{"minconn", limits.Minconn},
+		{"maxqueue", limits.Maxqueue},
+	} {
+		if limit.value != nil {
+			words = append(words, limit.keyword, strconv.Itoa(*limit.value))
+		}
+	}
+	return words
+}
+
+// tcpRequestLine returns the words of a tcp-request rule
+func tcpRequestLine(rule dataplane.TCPRequestRule) []string {
+	if rule.Type == "inspect-delay" {
+		timeout := 0
+		if rule.Timeout != nil {
+			timeout = *rule.Timeout
+		}
+		return []string{"tcp-request", "inspect-delay", strconv.Itoa(timeout) + "ms"}
+	}
+
+	words := []string{"tcp-request", rule.Type}
+	switch rule.Action {
+	case "track-sc":
+		words = append(words, trackSC(rule.TrackStickCounter, rule.TrackKey, rule.TrackTable)...)
+	case "lua":
+		words = append(words, "lua."+rule.LuaAction, rule.LuaParams)
+	default:
+		words = append(words, rule.Action)
+	}
+	return append(words, rule.Cond, rule.CondTest)
+}
+
+// httpRequestLine returns the words of an http-request rule
+func httpRequestLine(rule dataplane.HTTPRequestRule) []string {
+	words := []string{"http-request"}
+	switch rule.Type {
+	case "redirect":
+		words = append(words, "redirect", rule.RedirType, rule.RedirValue)
+		if rule.RedirCode != nil {
+			words = append(words, "code", strconv.Itoa(*rule.RedirCode))
+		}
+	case "track-sc":
+		words = append(words, trackSC(rule.TrackScStickCounter, rule.TrackScKey, rule.TrackScTable)...)
+	case "lua":
+		words = append(words, "lua."+rule.LuaAction, rule.LuaParams)
+	case "deny", "tarpit":
+		words = append(words, rule.Type)
+		if rule.DenyStatus != nil {
+			words = append(words, "deny_status", strconv.Itoa(*rule.DenyStatus))
+		}
+	default:
+		words = append(words, rule.Type)
+	}
+	return append(words, rule.Cond, rule.CondTest)
+}
+
+// trackSC returns the words of a track-sc action
+func trackSC(counter *int, key, table string) []string {
+	sc := 0
+	if counter != nil {
+		sc = *counter
+	}
+	words := []string{"track-sc" + strconv.Itoa(sc), key}
+	if table != "" {
+		words = append(words, "table", table)
+	}
+	return words
+}
+
+// line writes an indented line of the non-empty words
+func line(b *strings.Builder, words ...string) {
+	b.WriteString("    ")
+	first := true
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if !first {
+			b.WriteString(" ")
+		}
+		b.WriteString(word)
+		first = false
+	}
+	b.WriteString("\n")
+}
+
+// isTrue dereferences an optional flag
+func isTrue(flag *bool) bool {
+	return flag != nil && *flag
+}
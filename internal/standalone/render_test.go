@@ -0,0 +1,103 @@
+package standalone
+
+import (
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+func TestRenderConfiguration(t *testing.T) {
+	name := func(s string) *string { return &s }
+	number := func(i int) *int { return &i }
+	yes := true
+
+	cfg := &configuration{
+		Version:  3,
+		LuaLoads: []string{"/etc/haproxy/general/auth.v2.lua"},
+		Rings:    []dataplane.Ring{{Name: name("debug"), Format: "timed", Maxlen: number(1200), Size: number(32768)}},
+		Programs: []dataplane.Program{{Name: name("exporter"), Command: "/usr/bin/exporter --port 9101", User: "nobody", StartOnReload: "disabled"}},
+		Frontends: []*Frontend{{
+			Frontend: dataplane.Frontend{Frontend: v3.Frontend{Name: name("web"), Mode: name("http"), DefaultBackend: name("app")}},
+			Binds: []dataplane.Bind{
+				{Bind: v3.Bind{Name: name("http"), Address: name("192.168.1.10"), Port: number(80)}},
+				{Bind: v3.Bind{Name: name("https"), Address: name("::"), Port: number(443), V4V6: &yes}, SSL: &yes, SSLCertificate: name("/etc/haproxy/ssl/web.pem")},
+			},
+			ACLs: []dataplane.ACL{{Name: "api", Criterion: "path_beg", Value: "/api"}},
+			TCPRequestRules: []dataplane.TCPRequestRule{
+				{Type: "connection", Action: "track-sc", TrackKey: "src", TrackTable: "limits", TrackStickCounter: number(1)},
+				{Type: "content", Action: "lua", LuaAction: "classify"},
+			},
+			HTTPRequestRules: []dataplane.HTTPRequestRule{
+				{Type: "redirect", RedirType: "scheme", RedirValue: "https", RedirCode: number(301), Cond: "unless", CondTest: "{ ssl_fc }"},
+				{Type: "deny", DenyStatus: number(429), Cond: "if", CondTest: "{ sc_http_req_rate(0) gt 10 }"},
+				{Type: "lua", LuaAction: "auth", LuaParams: "admin", Cond: "if", CondTest: "api"},
+			},
+			BackendSwitchingRules: []dataplane.BackendSwitchingRule{{Name: "api", Cond: "if", CondTest: "api"}},
+			LogTargets:            []dataplane.LogTarget{{Address: "ring@debug", Format: "raw", Facility: "local0", Level: "info"}},
+		}},
+		Backends: []*Backend{{
+			Backend: dataplane.Backend{
+				Backend:       v3.Backend{Name: name("app"), Mode: "http", Balance: &v3.BackendBalance{Algorithm: "roundrobin"}},
+				DefaultServer: &dataplane.DefaultServer{ConnectionLimits: dataplane.ConnectionLimits{Maxconn: number(100)}},
+				StickTable:    &dataplane.StickTable{Type: "ip", Size: number(100000), Expire: number(30000), Store: "http_req_rate(10s)"},
+				AdvCheck:      "httpchk",
+			},
+			HTTPChecks: []dataplane.HTTPCheck{
+				{Type: "send", Method: "GET", URI: "/health", Version: "HTTP/1.1", Headers: []dataplane.HTTPCheckHeader{{Name: "User-Agent", Fmt: "haproxy check"}}},
+				{Type: "expect", Match: "string", Pattern: "ok", ExclamationMark: true},
+			},
+			Servers: []dataplane.Server{
+				{Server: v3.Server{Name: name("app1"), Address: name("10.0.0.1"), Port: number(8080)}, Weight: number(10)},
+				{Server: v3.Server{Name: name("app2"), Address: name("10.0.0.2"), Port: number(8080)}, SendProxyV2: "enabled", Maintenance: "enabled"},
+			},
+		}},
+	}
+
+	expected := `# Generated by haproxy-configurator (version 3); changes are overwritten on the next commit
+
+global
+    daemon
+
+global
+    lua-load /etc/haproxy/general/auth.v2.lua
+
+ring debug
+    format timed
+    maxlen 1200
+    size 32768
+
+program exporter
+    command /usr/bin/exporter --port 9101
+    user nobody
+    no option start-on-reload
+
+frontend web
+    mode http
+    log ring@debug format raw local0 info
+    bind 192.168.1.10:80 name http
+    bind :::443 name https v4v6 ssl crt /etc/haproxy/ssl/web.pem
+    acl api path_beg /api
+    tcp-request connection track-sc1 src table limits
+    tcp-request content lua.classify
+    http-request redirect scheme https code 301 unless { ssl_fc }
+    http-request deny deny_status 429 if { sc_http_req_rate(0) gt 10 }
+    http-request lua.auth admin if api
+    use_backend api if api
+    default_backend app
+
+backend app
+    mode http
+    balance roundrobin
+    default-server maxconn 100
+    stick-table type ip size 100000 expire 30000ms store http_req_rate(10s)
+    option httpchk
+    http-check send meth GET uri /health ver HTTP/1.1 hdr User-Agent "haproxy check"
+    http-check expect ! string ok
+    server app1 10.0.0.1:8080 weight 10
+    server app2 10.0.0.2:8080 send-proxy-v2 disabled
+`
+	if rendered := renderConfiguration("global\n    daemon\n\n", cfg); rendered != expected {
+		t.Errorf("Unexpected configuration:\n%s\nExpected:\n%s", rendered, expected)
+	}
+}
@@ -0,0 +1,720 @@
+package standalone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+	"go.uber.org/zap"
+)
+
+// view runs fn on the configuration seen by a read: that of the transaction, or the committed one without
+func view[T any](c *Client, transactionID string, fn func(*configuration) (T, error)) (T, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cfg := c.config
+	if transactionID != "" {
+		t, ok := c.transactions[transactionID]
+		if !ok {
+			var zero T
+			return zero, &v3.NotFoundError{Message: "transaction " + transactionID + " not found"}
+		}
+		cfg = t.config
+	}
+	return fn(cfg)
+}
+
+// change runs fn on the configuration of an open transaction. Unlike the Data Plane API, the standalone mode
+// only changes the configuration through transactions.
+func change[T any](c *Client, transactionID string, fn func(*configuration) (T, error)) (T, error) {
+	if transactionID == "" {
+		var zero T
+		return zero, &v3.BadRequestError{Message: "changes require a transaction in standalone mode"}
+	}
+	return view(c, transactionID, fn)
+}
+
+// requireName fails for resources without a name
+func requireName(kind string, name *string) error {
+	if nameOf(name) == "" {
+		return &v3.BadRequestError{Message: kind + " name is required"}
+	}
+	return nil
+}
+
+// AddBackend creates a backend
+func (c *Client) AddBackend(ctx context.Context, b dataplane.Backend, transactionId string) (*dataplane.Backend, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Backend, error) {
+		if err := requireName("backend", b.Name); err != nil {
+			return nil, err
+		}
+		if _, err := cfg.backend(*b.Name); err == nil {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("backend %s already exists", *b.Name)}
+		}
+		cfg.Backends = append(cfg.Backends, &Backend{Backend: copyOf(b)})
+		result := copyOf(b)
+		return &result, nil
+	})
+}
+
+// GetBackend retrieves a backend by name
+func (c *Client) GetBackend(ctx context.Context, name string, transactionId string) (*dataplane.Backend, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Backend, error) {
+		existing, err := cfg.backend(name)
+		if err != nil {
+			return nil, err
+		}
+		result := copyOf(existing.Backend)
+		return &result, nil
+	})
+}
+
+// ListBackends lists all backends
+func (c *Client) ListBackends(ctx context.Context, transactionId string) ([]dataplane.Backend, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Backend, error) {
+		list := make([]dataplane.Backend, 0, len(cfg.Backends))
+		for _, b := range cfg.Backends {
+			list = append(list, copyOf(b.Backend))
+		}
+		return list, nil
+	})
+}
+
+// ReplaceBackend replaces the settings of a backend, keeping its servers
+func (c *Client) ReplaceBackend(ctx context.Context, name string, b dataplane.Backend, transactionId string) (*dataplane.Backend, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Backend, error) {
+		existing, err := cfg.backend(name)
+		if err != nil {
+			return nil, err
+		}
+		existing.Backend = copyOf(b)
+		result := copyOf(b)
+		return &result, nil
+	})
+}
+
+// DeleteBackend deletes a backend with its servers
+func (c *Client) DeleteBackend(ctx context.Context, name string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		existing, err := cfg.backend(name)
+		if err != nil {
+			return struct{}{}, err
+		}
+		for i, b := range cfg.Backends {
+			if b == existing {
+				cfg.Backends = append(cfg.Backends[:i], cfg.Backends[i+1:]...)
+				break
+			}
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// EachBackend calls fn for every backend, stopping at the first error
+func (c *Client) EachBackend(ctx context.Context, transactionId string, fn func(dataplane.Backend) error) error {
+	backends, err := c.ListBackends(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+	for _, b := range backends {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddFrontend creates a frontend
+func (c *Client) AddFrontend(ctx context.Context, f dataplane.Frontend, transactionId string) (*dataplane.Frontend, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Frontend, error) {
+		if err := requireName("frontend", f.Name); err != nil {
+			return nil, err
+		}
+		if _, err := cfg.frontend(*f.Name); err == nil {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("frontend %s already exists", *f.Name)}
+		}
+		cfg.Frontends = append(cfg.Frontends, &Frontend{Frontend: copyOf(f)})
+		result := copyOf(f)
+		return &result, nil
+	})
+}
+
+// GetFrontend retrieves a frontend by name
+func (c *Client) GetFrontend(ctx context.Context, name string, transactionId string) (*dataplane.Frontend, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Frontend, error) {
+		existing, err := cfg.frontend(name)
+		if err != nil {
+			return nil, err
+		}
+		result := copyOf(existing.Frontend)
+		return &result, nil
+	})
+}
+
+// ListFrontends lists all frontends
+func (c *Client) ListFrontends(ctx context.Context, transactionId string) ([]dataplane.Frontend, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Frontend, error) {
+		list := make([]dataplane.Frontend, 0, len(cfg.Frontends))
+		for _, f := range cfg.Frontends {
+			list = append(list, copyOf(f.Frontend))
+		}
+		return list, nil
+	})
+}
+
+// ReplaceFrontend replaces the settings of a frontend, keeping its binds and rules
+func (c *Client) ReplaceFrontend(ctx context.Context, name string, f dataplane.Frontend, transactionId string) (*dataplane.Frontend, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Frontend, error) {
+		existing, err := cfg.frontend(name)
+		if err != nil {
+			return nil, err
+		}
+		existing.Frontend = copyOf(f)
+		result := copyOf(f)
+		return &result, nil
+	})
+}
+
+// DeleteFrontend deletes a frontend with its binds and rules
+func (c *Client) DeleteFrontend(ctx context.Context, name string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		existing, err := cfg.frontend(name)
+		if err != nil {
+			return struct{}{}, err
+		}
+		for i, f := range cfg.Frontends {
+			if f == existing {
+				cfg.Frontends = append(cfg.Frontends[:i], cfg.Frontends[i+1:]...)
+				break
+			}
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// AddBind creates a bind in a frontend
+func (c *Client) AddBind(ctx context.Context, frontendName string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Bind, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireName("bind", bind.Name); err != nil {
+			return nil, err
+		}
+		if bindIndex(f.Binds, *bind.Name) >= 0 {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("bind %s already exists", *bind.Name)}
+		}
+		f.Binds = append(f.Binds, copyOf(bind))
+		result := copyOf(bind)
+		return &result, nil
+	})
+}
+
+// GetBind retrieves a bind of a frontend by name
+func (c *Client) GetBind(ctx context.Context, name string, frontendName string, transactionId string) (*dataplane.Bind, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Bind, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		index := bindIndex(f.Binds, name)
+		if index < 0 {
+			return nil, &v3.NotFoundError{Message: fmt.Sprintf("bind %s not found", name)}
+		}
+		result := copyOf(f.Binds[index])
+		return &result, nil
+	})
+}
+
+// ListBinds lists the binds of a frontend
+func (c *Client) ListBinds(ctx context.Context, frontendName string, transactionId string) ([]dataplane.Bind, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Bind, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		return copyList(f.Binds), nil
+	})
+}
+
+// ReplaceBind replaces the bind of a frontend with the same name
+func (c *Client) ReplaceBind(ctx context.Context, frontendName string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Bind, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		index := bindIndex(f.Binds, nameOf(bind.Name))
+		if index < 0 {
+			return nil, &v3.NotFoundError{Message: fmt.Sprintf("bind %s not found", nameOf(bind.Name))}
+		}
+		f.Binds[index] = copyOf(bind)
+		result := copyOf(bind)
+		return &result, nil
+	})
+}
+
+// DeleteBind deletes a bind of a frontend
+func (c *Client) DeleteBind(ctx context.Context, name string, frontendName string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return struct{}{}, err
+		}
+		index := bindIndex(f.Binds, name)
+		if index < 0 {
+			return struct{}{}, &v3.NotFoundError{Message: fmt.Sprintf("bind %s not found", name)}
+		}
+		f.Binds = append(f.Binds[:index], f.Binds[index+1:]...)
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// AddServer creates a server in a backend
+func (c *Client) AddServer(ctx context.Context, backendName string, transactionId string, server dataplane.Server) (*dataplane.Server, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Server, error) {
+		b, err := cfg.backend(backendName)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireName("server", server.Name); err != nil {
+			return nil, err
+		}
+		if serverIndex(b.Servers, *server.Name) >= 0 {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("server %s already exists", *server.Name)}
+		}
+		b.Servers = append(b.Servers, copyOf(server))
+		result := copyOf(server)
+		return &result, nil
+	})
+}
+
+// GetServer retrieves a server of a backend by name
+func (c *Client) GetServer(ctx context.Context, name string, backendName string, transactionId string) (*dataplane.Server, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Server, error) {
+		b, err := cfg.backend(backendName)
+		if err != nil {
+			return nil, err
+		}
+		index := serverIndex(b.Servers, name)
+		if index < 0 {
+			return nil, &v3.NotFoundError{Message: fmt.Sprintf("server %s not found", name)}
+		}
+		result := copyOf(b.Servers[index])
+		return &result, nil
+	})
+}
+
+// ListServers lists the servers of a backend
+func (c *Client) ListServers(ctx context.Context, backendName string, transactionId string) ([]dataplane.Server, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Server, error) {
+		b, err := cfg.backend(backendName)
+		if err != nil {
+			return nil, err
+		}
+		return copyList(b.Servers), nil
+	})
+}
+
+// ReplaceServer replaces the server of a backend with the same name
+func (c *Client) ReplaceServer(ctx context.Context, backendName string, transactionId string, server dataplane.Server) (*dataplane.Server, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Server, error) {
+		b, err := cfg.backend(backendName)
+		if err != nil {
+			return nil, err
+		}
+		index := serverIndex(b.Servers, nameOf(server.Name))
+		if index < 0 {
+			return nil, &v3.NotFoundError{Message: fmt.Sprintf("server %s not found", nameOf(server.Name))}
+		}
+		b.Servers[index] = copyOf(server)
+		result := copyOf(server)
+		return &result, nil
+	})
+}
+
+// DeleteServer deletes a server of a backend
+func (c *Client) DeleteServer(ctx context.Context, name string, backendName string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		b, err := cfg.backend(backendName)
+		if err != nil {
+			return struct{}{}, err
+		}
+		index := serverIndex(b.Servers, name)
+		if index < 0 {
+			return struct{}{}, &v3.NotFoundError{Message: fmt.Sprintf("server %s not found", name)}
+		}
+		b.Servers = append(b.Servers[:index], b.Servers[index+1:]...)
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// EachServer calls fn for every server of a backend, stopping at the first error
+func (c *Client) EachServer(ctx context.Context, backendName string, transactionId string, fn func(dataplane.Server) error) error {
+	servers, err := c.ListServers(ctx, backendName, transactionId)
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if err := fn(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindIndex returns the position of a bind by name, or -1
+func bindIndex(binds []dataplane.Bind, name string) int {
+	for i, bind := range binds {
+		if nameOf(bind.Name) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// serverIndex returns the position of a server by name, or -1
+func serverIndex(servers []dataplane.Server, name string) int {
+	for i, server := range servers {
+		if nameOf(server.Name) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Rules are lists within a frontend addressed by position. Inserting or deleting one shifts the positions of
+// those after it.
+
+// listRules lists a collection of rules of a frontend in order
+func listRules[T any](c *Client, frontendName, transactionID string, rules func(*Frontend) *[]T) ([]T, error) {
+	return view(c, transactionID, func(cfg *configuration) ([]T, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		return copyList(*rules(f)), nil
+	})
+}
+
+// addRule inserts a rule into a collection of a frontend at the given position
+func addRule[T any](c *Client, frontendName, transactionID string, index int, rule T, rules func(*Frontend) *[]T) (*T, error) {
+	return change(c, transactionID, func(cfg *configuration) (*T, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		list, err := insertAt(*rules(f), index, copyOf(rule))
+		if err != nil {
+			return nil, err
+		}
+		*rules(f) = list
+		result := copyOf(rule)
+		return &result, nil
+	})
+}
+
+// replaceRule replaces the rule of a collection of a frontend at the given position
+func replaceRule[T any](c *Client, frontendName, transactionID string, index int, rule T, rules func(*Frontend) *[]T) (*T, error) {
+	return change(c, transactionID, func(cfg *configuration) (*T, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return nil, err
+		}
+		if err := replaceAt(*rules(f), index, copyOf(rule)); err != nil {
+			return nil, err
+		}
+		result := copyOf(rule)
+		return &result, nil
+	})
+}
+
+// deleteRule deletes the rule of a collection of a frontend at the given position
+func deleteRule[T any](c *Client, frontendName, transactionID string, index int, rules func(*Frontend) *[]T) error {
+	_, err := change(c, transactionID, func(cfg *configuration) (struct{}, error) {
+		f, err := cfg.frontend(frontendName)
+		if err != nil {
+			return struct{}{}, err
+		}
+		list, err := deleteAt(*rules(f), index)
+		if err != nil {
+			return struct{}{}, err
+		}
+		*rules(f) = list
+		return struct{}{}, nil
+	})
+	return err
+}
+
+func acls(f *Frontend) *[]dataplane.ACL { return &f.ACLs }
+func switchingRules(f *Frontend) *[]dataplane.BackendSwitchingRule {
+	return &f.BackendSwitchingRules
+}
+func httpRequestRules(f *Frontend) *[]dataplane.HTTPRequestRule { return &f.HTTPRequestRules }
+func tcpRequestRules(f *Frontend) *[]dataplane.TCPRequestRule   { return &f.TCPRequestRules }
+
+// ListACLs lists the ACLs of a frontend in order
+func (c *Client) ListACLs(ctx context.Context, frontend string, transactionId string) ([]dataplane.ACL, error) {
+	return listRules(c, frontend, transactionId, acls)
+}
+
+// AddACL inserts an ACL into a frontend at the given position
+func (c *Client) AddACL(ctx context.Context, frontend string, transactionId string, index int, acl dataplane.ACL) (*dataplane.ACL, error) {
+	return addRule(c, frontend, transactionId, index, acl, acls)
+}
+
+// DeleteACL deletes the ACL of a frontend at the given position
+func (c *Client) DeleteACL(ctx context.Context, frontend string, transactionId string, index int) error {
+	return deleteRule(c, frontend, transactionId, index, acls)
+}
+
+// ListBackendSwitchingRules lists the use_backend rules of a frontend in order
+func (c *Client) ListBackendSwitchingRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.BackendSwitchingRule, error) {
+	return listRules(c, frontend, transactionId, switchingRules)
+}
+
+// AddBackendSwitchingRule inserts a use_backend rule into a frontend at the given position
+func (c *Client) AddBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error) {
+	return addRule(c, frontend, transactionId, index, rule, switchingRules)
+}
+
+// ReplaceBackendSwitchingRule replaces the use_backend rule of a frontend at the given position
+func (c *Client) ReplaceBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error) {
+	return replaceRule(c, frontend, transactionId, index, rule, switchingRules)
+}
+
+// DeleteBackendSwitchingRule deletes the use_backend rule of a frontend at the given position
+func (c *Client) DeleteBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return deleteRule(c, frontend, transactionId, index, switchingRules)
+}
+
+// ListHTTPRequestRules lists the http-request rules of a frontend in order
+func (c *Client) ListHTTPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.HTTPRequestRule, error) {
+	return listRules(c, frontend, transactionId, httpRequestRules)
+}
+
+// AddHTTPRequestRule inserts an http-request rule into a frontend at the given position
+func (c *Client) AddHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.HTTPRequestRule) (*dataplane.HTTPRequestRule, error) {
+	return addRule(c, frontend, transactionId, index, rule, httpRequestRules)
+}
+
+// DeleteHTTPRequestRule deletes the http-request rule of a frontend at the given position
+func (c *Client) DeleteHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return deleteRule(c, frontend, transactionId, index, httpRequestRules)
+}
+
+// ListTCPRequestRules lists the tcp-request rules of a frontend in order
+func (c *Client) ListTCPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.TCPRequestRule, error) {
+	return listRules(c, frontend, transactionId, tcpRequestRules)
+}
+
+// AddTCPRequestRule inserts a tcp-request rule into a frontend at the given position
+func (c *Client) AddTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.TCPRequestRule) (*dataplane.TCPRequestRule, error) {
+	return addRule(c, frontend, transactionId, index, rule, tcpRequestRules)
+}
+
+// DeleteTCPRequestRule deletes the tcp-request rule of a frontend at the given position
+func (c *Client) DeleteTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	return deleteRule(c, frontend, transactionId, index, tcpRequestRules)
+}
+
+// Certificates are written to the certificate directory immediately, like the certificate storage of the
+// Data Plane API; they are not part of transactions.
+
+// AddCertificate writes a certificate, failing with a conflict if one with the same name exists
+func (c *Client) AddCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error) {
+	path, err := c.certificatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, &v3.ConflictError{Message: fmt.Sprintf("certificate %s already exists", name)}
+	}
+	if err := c.writeCertificate(path, pem); err != nil {
+		return nil, err
+	}
+	return &dataplane.Certificate{StorageName: name, File: path}, nil
+}
+
+// ReplaceCertificate replaces the content of a certificate and reloads HAProxy, which only reads certificates
+// when it loads its configuration
+func (c *Client) ReplaceCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error) {
+	path, err := c.certificatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, &v3.NotFoundError{Message: fmt.Sprintf("certificate %s not found", name)}
+	}
+	if err := c.writeCertificate(path, pem); err != nil {
+		return nil, err
+	}
+	if !c.dryRun {
+		c.commitMutex.Lock()
+		defer c.commitMutex.Unlock()
+		if err := c.reload(ctx); err != nil {
+			return nil, &v3.InternalError{Message: fmt.Sprintf("certificate %s written, but HAProxy failed to reload: %v", name, err)}
+		}
+	}
+	return &dataplane.Certificate{StorageName: name, File: path}, nil
+}
+
+// GetCertificate retrieves a certificate by name
+func (c *Client) GetCertificate(ctx context.Context, name string) (*dataplane.Certificate, error) {
+	path, err := c.certificatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, &v3.NotFoundError{Message: fmt.Sprintf("certificate %s not found", name)}
+	}
+	return &dataplane.Certificate{StorageName: name, File: path}, nil
+}
+
+// certificatePath returns the file of a certificate, rejecting names that leave the certificate directory
+func (c *Client) certificatePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", &v3.BadRequestError{Message: fmt.Sprintf("invalid certificate name %q", name)}
+	}
+	return filepath.Join(c.settings.CertificateDir, name), nil
+}
+
+// writeCertificate writes the PEM of a certificate with its private key, readable only by its owner
+func (c *Client) writeCertificate(path, pem string) error {
+	if c.dryRun {
+		logger.GetLogger().Info("Dry run: skipped writing certificate",
+			zap.String("instance", c.instance),
+			zap.String("path", path))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return &v3.InternalError{Message: fmt.Sprintf("failed to create certificate directory: %v", err)}
+	}
+	if err := os.WriteFile(path, []byte(pem), 0600); err != nil {
+		return &v3.InternalError{Message: fmt.Sprintf("failed to write certificate: %v", err)}
+	}
+	return nil
+}
+
+// Stored files, such as Lua scripts, are written to the storage directory immediately, like the general
+// storage of the Data Plane API; they are not part of transactions.
+
+// AddStorageFile writes a stored file, failing with a conflict if one with the same name exists
+func (c *Client) AddStorageFile(ctx context.Context, name, content string) (*dataplane.StorageFile, error) {
+	path, err := c.storagePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, &v3.ConflictError{Message: fmt.Sprintf("file %s already exists", name)}
+	}
+	if c.dryRun {
+		logger.GetLogger().Info("Dry run: skipped writing file",
+			zap.String("instance", c.instance),
+			zap.String("path", path))
+	} else {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, &v3.InternalError{Message: fmt.Sprintf("failed to create storage directory: %v", err)}
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return nil, &v3.InternalError{Message: fmt.Sprintf("failed to write file: %v", err)}
+		}
+	}
+	size := len(content)
+	return &dataplane.StorageFile{StorageName: name, File: path, Size: &size}, nil
+}
+
+// GetStorageFile returns the content of a stored file
+func (c *Client) GetStorageFile(ctx context.Context, name string) (string, error) {
+	path, err := c.storagePath(name)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", &v3.NotFoundError{Message: fmt.Sprintf("file %s not found", name)}
+	}
+	return string(data), nil
+}
+
+// ListStorageFiles lists the stored files
+func (c *Client) ListStorageFiles(ctx context.Context) ([]dataplane.StorageFile, error) {
+	entries, err := os.ReadDir(c.settings.StorageDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []dataplane.StorageFile{}, nil
+	}
+	if err != nil {
+		return nil, &v3.InternalError{Message: fmt.Sprintf("failed to read storage directory: %v", err)}
+	}
+
+	files := []dataplane.StorageFile{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		file := dataplane.StorageFile{StorageName: entry.Name(), File: filepath.Join(c.settings.StorageDir, entry.Name())}
+		if info, err := entry.Info(); err == nil {
+			size := int(info.Size())
+			file.Size = &size
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// DeleteStorageFile deletes a stored file
+func (c *Client) DeleteStorageFile(ctx context.Context, name string) error {
+	path, err := c.storagePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return &v3.NotFoundError{Message: fmt.Sprintf("file %s not found", name)}
+	}
+	if c.dryRun {
+		logger.GetLogger().Info("Dry run: skipped deleting file",
+			zap.String("instance", c.instance),
+			zap.String("path", path))
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return &v3.InternalError{Message: fmt.Sprintf("failed to delete file: %v", err)}
+	}
+	return nil
+}
+
+// storagePath returns the path of a stored file, rejecting names that leave the storage directory
+func (c *Client) storagePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", &v3.BadRequestError{Message: fmt.Sprintf("invalid file name %q", name)}
+	}
+	return filepath.Join(c.settings.StorageDir, name), nil
+}
+
+// GetGlobal retrieves the managed settings of the global section: the Lua scripts it loads
+func (c *Client) GetGlobal(ctx context.Context, transactionId string) (*dataplane.Global, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Global, error) {
+		return &dataplane.Global{LuaLoads: slices.Clone(cfg.LuaLoads)}, nil
+	})
+}
+
+// ReplaceGlobal replaces the managed settings of the global section. Other global settings belong to the base
+// file.
+func (c *Client) ReplaceGlobal(ctx context.Context, global dataplane.Global, transactionId string) (*dataplane.Global, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Global, error) {
+		cfg.LuaLoads = slices.Clone(global.LuaLoads)
+		return &dataplane.Global{LuaLoads: slices.Clone(cfg.LuaLoads)}, nil
+	})
+}
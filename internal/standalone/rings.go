@@ -0,0 +1,154 @@
+package standalone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// ring returns the ring section with the given name
+func (c *configuration) ring(name string) (*dataplane.Ring, error) {
+	for i := range c.Rings {
+		if nameOf(c.Rings[i].Name) == name {
+			return &c.Rings[i], nil
+		}
+	}
+	return nil, &v3.NotFoundError{Message: fmt.Sprintf("ring %s not found", name)}
+}
+
+// logTargets returns the log targets of a frontend or backend
+func (c *configuration) logTargets(parentType, parent string) (*[]dataplane.LogTarget, error) {
+	switch parentType {
+	case "frontend":
+		f, err := c.frontend(parent)
+		if err != nil {
+			return nil, err
+		}
+		return &f.LogTargets, nil
+	case "backend":
+		b, err := c.backend(parent)
+		if err != nil {
+			return nil, err
+		}
+		return &b.LogTargets, nil
+	default:
+		return nil, &v3.BadRequestError{Message: fmt.Sprintf("log targets of %ss are not managed", parentType)}
+	}
+}
+
+// AddRing creates a ring section
+func (c *Client) AddRing(ctx context.Context, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Ring, error) {
+		if err := requireName("ring", ring.Name); err != nil {
+			return nil, err
+		}
+		if _, err := cfg.ring(*ring.Name); err == nil {
+			return nil, &v3.ConflictError{Message: fmt.Sprintf("ring %s already exists", *ring.Name)}
+		}
+		cfg.Rings = append(cfg.Rings, copyOf(ring))
+		result := copyOf(ring)
+		return &result, nil
+	})
+}
+
+// GetRing retrieves a ring section by name
+func (c *Client) GetRing(ctx context.Context, name string, transactionId string) (*dataplane.Ring, error) {
+	return view(c, transactionId, func(cfg *configuration) (*dataplane.Ring, error) {
+		existing, err := cfg.ring(name)
+		if err != nil {
+			return nil, err
+		}
+		result := copyOf(*existing)
+		return &result, nil
+	})
+}
+
+// ListRings lists the ring sections
+func (c *Client) ListRings(ctx context.Context, transactionId string) ([]dataplane.Ring, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.Ring, error) {
+		return copyList(cfg.Rings), nil
+	})
+}
+
+// ReplaceRing replaces a ring section
+func (c *Client) ReplaceRing(ctx context.Context, name string, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.Ring, error) {
+		existing, err := cfg.ring(name)
+		if err != nil {
+			return nil, err
+		}
+		ring.Name = &name
+		*existing = copyOf(ring)
+		result := copyOf(ring)
+		return &result, nil
+	})
+}
+
+// DeleteRing deletes a ring section
+func (c *Client) DeleteRing(ctx context.Context, name string, transactionId string) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		for i := range cfg.Rings {
+			if nameOf(cfg.Rings[i].Name) == name {
+				cfg.Rings = append(cfg.Rings[:i], cfg.Rings[i+1:]...)
+				return struct{}{}, nil
+			}
+		}
+		return struct{}{}, &v3.NotFoundError{Message: fmt.Sprintf("ring %s not found", name)}
+	})
+	return err
+}
+
+// ListLogTargets lists the log targets of a frontend or backend in order
+func (c *Client) ListLogTargets(ctx context.Context, parentType, parent string, transactionId string) ([]dataplane.LogTarget, error) {
+	return view(c, transactionId, func(cfg *configuration) ([]dataplane.LogTarget, error) {
+		targets, err := cfg.logTargets(parentType, parent)
+		if err != nil {
+			return nil, err
+		}
+		return copyList(*targets), nil
+	})
+}
+
+// AddLogTarget inserts a log target into a frontend or backend at the given position
+func (c *Client) AddLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int, target dataplane.LogTarget) (*dataplane.LogTarget, error) {
+	return change(c, transactionId, func(cfg *configuration) (*dataplane.LogTarget, error) {
+		targets, err := cfg.logTargets(parentType, parent)
+		if err != nil {
+			return nil, err
+		}
+		list, err := insertAt(*targets, index, copyOf(target))
+		if err != nil {
+			return nil, err
+		}
+		*targets = list
+		result := copyOf(target)
+		return &result, nil
+	})
+}
+
+// DeleteLogTarget deletes the log target of a frontend or backend at the given position
+func (c *Client) DeleteLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int) error {
+	_, err := change(c, transactionId, func(cfg *configuration) (struct{}, error) {
+		targets, err := cfg.logTargets(parentType, parent)
+		if err != nil {
+			return struct{}{}, err
+		}
+		list, err := deleteAt(*targets, index)
+		if err != nil {
+			return struct{}{}, err
+		}
+		*targets = list
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// WatchRing calls fn for every event in a ring of the running worker, read through the master socket
+func (c *Client) WatchRing(ctx context.Context, name string, follow bool, fn func(event string) error) error {
+	if c.settings.MasterSocket == "" {
+		return ErrRuntimeUnavailable
+	}
+	return dataplane.ReadRing(ctx, c.settings.MasterSocket, "@1 ", name, follow, fn)
+}
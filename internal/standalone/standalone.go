@@ -0,0 +1,362 @@
+// Package standalone manages HAProxy without the Data Plane API, e.g. on small edge boxes. Client keeps the
+// frontends, backends, binds, servers and rules in memory with the transaction semantics of the Data Plane API;
+// committing a transaction renders haproxy.cfg, checks it with haproxy -c and reloads HAProxy through systemd
+// or its master socket:
+//
+//	client := standalone.New("default", cfg.HAProxy.Standalone, false, standalone.ExecRunner{})
+//
+// The managed resources are kept in a state file, from which the configuration is rendered again after a
+// restart. Errors are those of the Data Plane API client, e.g. *v3.NotFoundError for unknown objects.
+//
+// RenderFrontend and RenderBackend render single sections, e.g. to preview a change made through the Data Plane
+// API.
+package standalone
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+	"go.uber.org/zap"
+)
+
+// DefaultStateDir holds the state files of the instances that do not set state_file
+const DefaultStateDir = "/var/lib/haproxy-configurator/standalone"
+
+// commandTimeout bounds the configuration check and the reload
+const commandTimeout = 30 * time.Second
+
+// Client manages the haproxy.cfg of one HAProxy instance. It implements the client interface of the gRPC
+// service in place of the Data Plane API client.
+type Client struct {
+	instance  string
+	settings  config.StandaloneSettings
+	stateFile string
+	dryRun    bool
+	runner    CommandRunner
+	stateErr  error // Set if the state file could not be loaded; transactions are refused
+
+	commitMutex sync.Mutex // Serializes commits, which check, write and reload outside the state mutex
+
+	mutex        sync.Mutex
+	config       *configuration
+	transactions map[string]*transaction
+}
+
+// transaction is an open transaction working on its own copy of the configuration
+type transaction struct {
+	id      string
+	version int
+	config  *configuration
+}
+
+// New creates the standalone client of an instance and restores the resources managed before a restart from
+// its state file. If the state file cannot be read, the client refuses transactions rather than replacing the
+// configuration with an empty one. In dry-run mode commits are rendered and checked, but nothing is written.
+func New(instance string, settings config.StandaloneSettings, dryRun bool, runner CommandRunner) *Client {
+	stateFile := settings.StateFile
+	if stateFile == "" {
+		stateFile = filepath.Join(DefaultStateDir, instance+".json")
+	}
+
+	client := &Client{
+		instance:     instance,
+		settings:     settings,
+		stateFile:    stateFile,
+		dryRun:       dryRun,
+		runner:       runner,
+		config:       &configuration{Version: 1},
+		transactions: make(map[string]*transaction),
+	}
+
+	data, err := os.ReadFile(stateFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		client.stateErr = fmt.Errorf("failed to read state file %s: %w", stateFile, err)
+	default:
+		var restored configuration
+		if err := json.Unmarshal(data, &restored); err != nil {
+			client.stateErr = fmt.Errorf("invalid state file %s: %w", stateFile, err)
+		} else {
+			client.config = &restored
+		}
+	}
+	if client.stateErr != nil {
+		logger.GetLogger().Error("Failed to restore standalone HAProxy configuration, refusing changes",
+			zap.String("instance", instance),
+			zap.Error(client.stateErr))
+	}
+	return client
+}
+
+// Instance names the HAProxy instance of the client
+func (c *Client) Instance() string {
+	return c.instance
+}
+
+// ActiveURL identifies the configuration file in logs
+func (c *Client) ActiveURL() string {
+	return "file://" + c.settings.ConfigFile
+}
+
+// GetVersion returns the committed configuration version
+func (c *Client) GetVersion(ctx context.Context) (*int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	version := c.config.Version
+	return &version, nil
+}
+
+// GetInfo reports the standalone mode in place of the Data Plane API version
+func (c *Client) GetInfo(ctx context.Context) (*dataplane.Info, error) {
+	var info dataplane.Info
+	info.API.Version = "standalone"
+	return &info, nil
+}
+
+// CreateTransaction creates a transaction based on the given version
+func (c *Client) CreateTransaction(ctx context.Context, version int) (*v3.Transaction, error) {
+	if c.stateErr != nil {
+		return nil, &v3.InternalError{Message: c.stateErr.Error()}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if version != c.config.Version {
+		return nil, &v3.ConflictError{Message: fmt.Sprintf("version mismatch: expected %d, got %d", c.config.Version, version)}
+	}
+
+	t := &transaction{id: newTransactionID(), version: version, config: c.config.clone()}
+	c.transactions[t.id] = t
+	return t.object("in_progress"), nil
+}
+
+// GetTransaction retrieves an open transaction
+func (c *Client) GetTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t, ok := c.transactions[id]
+	if !ok {
+		return nil, &v3.NotFoundError{Message: "transaction " + id + " not found"}
+	}
+	return t.object("in_progress"), nil
+}
+
+// ListTransactions lists the open transactions
+func (c *Client) ListTransactions(ctx context.Context) ([]v3.Transaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	list := make([]v3.Transaction, 0, len(c.transactions))
+	for _, id := range sortedKeys(c.transactions) {
+		list = append(list, *c.transactions[id].object("in_progress"))
+	}
+	return list, nil
+}
+
+// CommitTransaction renders the configuration of a transaction, checks it and, once written, reloads HAProxy.
+// If the check or the reload fails, the previous configuration is restored and the transaction is discarded.
+func (c *Client) CommitTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	c.commitMutex.Lock()
+	defer c.commitMutex.Unlock()
+
+	c.mutex.Lock()
+	t, ok := c.transactions[id]
+	delete(c.transactions, id)
+	previous := c.config
+	c.mutex.Unlock()
+
+	if !ok {
+		return nil, &v3.NotFoundError{Message: "transaction " + id + " not found"}
+	}
+	if t.version != previous.Version {
+		return nil, &v3.ConflictError{Message: fmt.Sprintf("version mismatch: transaction is based on %d, configuration is at %d", t.version, previous.Version)}
+	}
+
+	next := t.config
+	next.Version = previous.Version + 1
+	if err := c.apply(ctx, previous, next); err != nil {
+		return nil, &v3.CommitFailedError{TransactionID: id, Message: err.Error()}
+	}
+	if !c.dryRun {
+		c.mutex.Lock()
+		c.config = next
+		c.mutex.Unlock()
+	}
+	return t.object("success"), nil
+}
+
+// CloseTransaction discards a transaction
+func (c *Client) CloseTransaction(ctx context.Context, id string) (*string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.transactions[id]; !ok {
+		return nil, &v3.NotFoundError{Message: "transaction " + id + " not found"}
+	}
+	delete(c.transactions, id)
+	message := ""
+	return &message, nil
+}
+
+// apply writes the configuration file and state of the next version and reloads HAProxy, restoring the
+// previous ones if HAProxy rejects the configuration
+func (c *Client) apply(ctx context.Context, previous, next *configuration) error {
+	rendered, err := c.render(next)
+	if err != nil {
+		return err
+	}
+
+	// The candidate is written next to the configuration file, so that it can be renamed into place atomically
+	dir := filepath.Dir(c.settings.ConfigFile)
+	if c.dryRun {
+		dir = ""
+	}
+	candidate, err := writeTemporary(dir, rendered)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(candidate)
+	if err := c.check(ctx, candidate); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		logger.GetLogger().Info("Dry run: skipped writing HAProxy configuration",
+			zap.String("instance", c.instance),
+			zap.String("path", c.settings.ConfigFile),
+			zap.Int("version", next.Version),
+			logger.Payload("configuration", rendered))
+		return nil
+	}
+
+	current, err := os.ReadFile(c.settings.ConfigFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", c.settings.ConfigFile, err)
+	}
+	if err := os.Rename(candidate, c.settings.ConfigFile); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", c.settings.ConfigFile, err)
+	}
+	if err := c.saveState(next); err != nil {
+		c.restore(previous, current)
+		return err
+	}
+	if err := c.reload(ctx); err != nil {
+		c.restore(previous, current)
+		return fmt.Errorf("failed to reload HAProxy: %w", err)
+	}
+
+	logger.GetLogger().Info("Wrote and reloaded standalone HAProxy configuration",
+		zap.String("instance", c.instance),
+		zap.String("path", c.settings.ConfigFile),
+		zap.Int("version", next.Version))
+	return nil
+}
+
+// restore puts back the configuration file and state of the previous version after a failed commit. HAProxy
+// is not reloaded again: a failed reload keeps the running configuration.
+func (c *Client) restore(previous *configuration, file []byte) {
+	var err error
+	if file != nil {
+		err = os.WriteFile(c.settings.ConfigFile, file, 0644)
+	} else {
+		err = os.Remove(c.settings.ConfigFile)
+	}
+	if err == nil {
+		err = c.saveState(previous)
+	}
+	if err != nil {
+		logger.GetLogger().Error("Failed to restore the previous standalone HAProxy configuration",
+			zap.String("instance", c.instance),
+			zap.String("path", c.settings.ConfigFile),
+			zap.Error(err))
+	}
+}
+
+// check runs haproxy -c on a configuration file
+func (c *Client) check(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	output, err := c.runner.Run(ctx, c.settings.HAProxyBinary, "-c", "-f", path)
+	if err != nil {
+		return fmt.Errorf("HAProxy rejected the configuration: %w: %s", err, output)
+	}
+	return nil
+}
+
+// reload makes HAProxy load the written configuration
+func (c *Client) reload(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	if c.settings.Reload == "master_socket" {
+		return c.masterReload(ctx)
+	}
+	if output, err := c.runner.Run(ctx, "systemctl", "reload", c.settings.SystemdUnit); err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
+
+// saveState replaces the state file with a configuration
+func (c *Client) saveState(cfg *configuration) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.stateFile), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	temporary := c.stateFile + ".tmp"
+	if err := os.WriteFile(temporary, data, 0600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(temporary, c.stateFile); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+	return nil
+}
+
+// writeTemporary writes a configuration to a new file in dir, or in the temporary directory if dir is empty
+func writeTemporary(dir string, data []byte) (string, error) {
+	file, err := os.CreateTemp(dir, ".haproxy-*.cfg")
+	if err != nil {
+		return "", fmt.Errorf("failed to create configuration file: %w", err)
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0644)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// object returns the transaction as reported by the Data Plane API
+func (t *transaction) object(status string) *v3.Transaction {
+	id := t.id
+	return &v3.Transaction{Id: &id, Status: &status}
+}
+
+// newTransactionID generates a random transaction ID, unique across restarts
+func newTransactionID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,311 @@
+package standalone
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// recordingRunner records commands and fails those starting with a configured prefix
+type recordingRunner struct {
+	mutex    sync.Mutex
+	commands []string
+	fail     string
+}
+
+func (r *recordingRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	command := strings.Join(append([]string{name}, args...), " ")
+	r.commands = append(r.commands, command)
+	if r.fail != "" && strings.HasPrefix(command, r.fail) {
+		return []byte("[ALERT] parsing failed"), errors.New("exit status 1")
+	}
+	return nil, nil
+}
+
+func newTestClient(t *testing.T) (*Client, config.StandaloneSettings, *recordingRunner) {
+	t.Helper()
+	dir := t.TempDir()
+	settings := config.StandaloneSettings{
+		Enabled:        true,
+		ConfigFile:     filepath.Join(dir, "haproxy.cfg"),
+		StateFile:      filepath.Join(dir, "state", "default.json"),
+		CertificateDir: filepath.Join(dir, "ssl"),
+		StorageDir:     filepath.Join(dir, "general"),
+		HAProxyBinary:  "haproxy",
+		Reload:         "systemd",
+		SystemdUnit:    "haproxy.service",
+	}
+	runner := &recordingRunner{}
+	return New("default", settings, false, runner), settings, runner
+}
+
+// addBackend creates a backend with a server in a new transaction and returns the transaction
+func addBackend(t *testing.T, client *Client, name string) string {
+	t.Helper()
+	ctx := context.Background()
+	version, _ := client.GetVersion(ctx)
+	transaction, err := client.CreateTransaction(ctx, *version)
+	if err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+	address, port := "10.0.0.1", 8080
+	if _, err := client.AddBackend(ctx, dataplane.Backend{Backend: v3.Backend{Name: &name, Mode: "http"}}, *transaction.Id); err != nil {
+		t.Fatalf("AddBackend failed: %v", err)
+	}
+	server := dataplane.Server{Server: v3.Server{Name: &name, Address: &address, Port: &port}}
+	if _, err := client.AddServer(ctx, name, *transaction.Id, server); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	return *transaction.Id
+}
+
+func TestCommitWritesAndReloads(t *testing.T) {
+	ctx := context.Background()
+	client, settings, runner := newTestClient(t)
+
+	id := addBackend(t, client, "app")
+	if _, err := client.GetBackend(ctx, "app", ""); !v3.IsNotFound(err) {
+		t.Errorf("Expected the backend to be invisible outside the transaction, got %v", err)
+	}
+	if _, err := client.CommitTransaction(ctx, id); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+
+	data, err := os.ReadFile(settings.ConfigFile)
+	if err != nil {
+		t.Fatalf("Failed to read the configuration: %v", err)
+	}
+	if !strings.Contains(string(data), "backend app\n") || !strings.Contains(string(data), "server app 10.0.0.1:8080") {
+		t.Errorf("Expected the backend in the configuration, got:\n%s", data)
+	}
+	if len(runner.commands) != 2 || !strings.HasPrefix(runner.commands[0], "haproxy -c -f ") || runner.commands[1] != "systemctl reload haproxy.service" {
+		t.Errorf("Expected a check and a reload, got %v", runner.commands)
+	}
+	if version, _ := client.GetVersion(ctx); *version != 2 {
+		t.Errorf("Expected version 2, got %d", *version)
+	}
+	if transactions, _ := client.ListTransactions(ctx); len(transactions) != 0 {
+		t.Errorf("Expected no open transactions, got %v", transactions)
+	}
+
+	// A restart restores the managed resources from the state file
+	restarted := New("default", settings, false, runner)
+	servers, err := restarted.ListServers(ctx, "app", "")
+	if err != nil || len(servers) != 1 || *servers[0].Name != "app" {
+		t.Errorf("Expected the server to be restored, got %v: %v", servers, err)
+	}
+	if version, _ := restarted.GetVersion(ctx); *version != 2 {
+		t.Errorf("Expected the restored version 2, got %d", *version)
+	}
+}
+
+func TestCommitRejectedConfiguration(t *testing.T) {
+	ctx := context.Background()
+	client, settings, runner := newTestClient(t)
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "app")); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	committed, _ := os.ReadFile(settings.ConfigFile)
+
+	runner.fail = "haproxy -c"
+	_, err := client.CommitTransaction(ctx, addBackend(t, client, "other"))
+	if !v3.IsCommitFailed(err) || !strings.Contains(err.Error(), "parsing failed") {
+		t.Fatalf("Expected the commit to fail with the output of the check, got %v", err)
+	}
+	if data, _ := os.ReadFile(settings.ConfigFile); string(data) != string(committed) {
+		t.Errorf("Expected the configuration to be left unchanged, got:\n%s", data)
+	}
+	if _, err := client.GetBackend(ctx, "other", ""); !v3.IsNotFound(err) {
+		t.Errorf("Expected the rejected backend to be discarded, got %v", err)
+	}
+	if version, _ := client.GetVersion(ctx); *version != 2 {
+		t.Errorf("Expected version 2, got %d", *version)
+	}
+}
+
+func TestCommitReloadFailureRestores(t *testing.T) {
+	ctx := context.Background()
+	client, settings, runner := newTestClient(t)
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "app")); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	committed, _ := os.ReadFile(settings.ConfigFile)
+	state, _ := os.ReadFile(settings.StateFile)
+
+	runner.fail = "systemctl"
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "other")); !v3.IsCommitFailed(err) {
+		t.Fatalf("Expected the commit to fail, got %v", err)
+	}
+	if data, _ := os.ReadFile(settings.ConfigFile); string(data) != string(committed) {
+		t.Errorf("Expected the previous configuration to be restored, got:\n%s", data)
+	}
+	if data, _ := os.ReadFile(settings.StateFile); string(data) != string(state) {
+		t.Errorf("Expected the previous state to be restored, got:\n%s", data)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+	client, _, _ := newTestClient(t)
+
+	name := "app"
+	if _, err := client.AddBackend(ctx, dataplane.Backend{Backend: v3.Backend{Name: &name}}, ""); !v3.IsBadRequest(err) {
+		t.Errorf("Expected changes outside transactions to be rejected, got %v", err)
+	}
+	if _, err := client.CreateTransaction(ctx, 7); !v3.IsConflict(err) {
+		t.Errorf("Expected a version mismatch, got %v", err)
+	}
+
+	first := addBackend(t, client, "app")
+	second := addBackend(t, client, "other")
+	if _, err := client.AddBackend(ctx, dataplane.Backend{Backend: v3.Backend{Name: &name}}, first); !v3.IsConflict(err) {
+		t.Errorf("Expected a duplicate backend to conflict, got %v", err)
+	}
+	if _, err := client.CommitTransaction(ctx, first); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	if _, err := client.CommitTransaction(ctx, second); !v3.IsConflict(err) {
+		t.Errorf("Expected the outdated transaction to conflict, got %v", err)
+	}
+
+	third := addBackend(t, client, "third")
+	if _, err := client.CloseTransaction(ctx, third); err != nil {
+		t.Errorf("CloseTransaction failed: %v", err)
+	}
+	if _, err := client.GetTransaction(ctx, third); !v3.IsNotFound(err) {
+		t.Errorf("Expected the closed transaction to be gone, got %v", err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	ctx := context.Background()
+	client, _, _ := newTestClient(t)
+	transaction, _ := client.CreateTransaction(ctx, 1)
+	id := *transaction.Id
+
+	name := "web"
+	if _, err := client.AddFrontend(ctx, dataplane.Frontend{Frontend: v3.Frontend{Name: &name}}, id); err != nil {
+		t.Fatalf("AddFrontend failed: %v", err)
+	}
+	for i, acl := range []string{"b", "a", "c"} {
+		index := []int{0, 0, 2}[i]
+		if _, err := client.AddACL(ctx, name, id, index, dataplane.ACL{Name: acl, Criterion: "path"}); err != nil {
+			t.Fatalf("AddACL failed: %v", err)
+		}
+	}
+	if err := client.DeleteACL(ctx, name, id, 1); err != nil {
+		t.Fatalf("DeleteACL failed: %v", err)
+	}
+	if err := client.DeleteACL(ctx, name, id, 5); !v3.IsNotFound(err) {
+		t.Errorf("Expected an unknown position to be rejected, got %v", err)
+	}
+
+	acls, err := client.ListACLs(ctx, name, id)
+	if err != nil || len(acls) != 2 || acls[0].Name != "a" || acls[1].Name != "c" {
+		t.Errorf("Expected ACLs a and c, got %v: %v", acls, err)
+	}
+}
+
+func TestInvalidStateFile(t *testing.T) {
+	_, settings, runner := newTestClient(t)
+	if err := os.MkdirAll(filepath.Dir(settings.StateFile), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settings.StateFile, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := New("default", settings, false, runner)
+	if _, err := client.CreateTransaction(context.Background(), 1); err == nil {
+		t.Error("Expected transactions to be refused with an invalid state file")
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	ctx := context.Background()
+	client, settings, runner := newTestClient(t)
+
+	certificate, err := client.AddCertificate(ctx, "web.pem", "PEM")
+	if err != nil || certificate.File != filepath.Join(settings.CertificateDir, "web.pem") {
+		t.Fatalf("Unexpected certificate %v: %v", certificate, err)
+	}
+	if _, err := client.AddCertificate(ctx, "web.pem", "PEM"); !v3.IsConflict(err) {
+		t.Errorf("Expected a duplicate certificate to conflict, got %v", err)
+	}
+	if _, err := client.AddCertificate(ctx, "../web.pem", "PEM"); !v3.IsBadRequest(err) {
+		t.Errorf("Expected a name outside the certificate directory to be rejected, got %v", err)
+	}
+	if _, err := client.ReplaceCertificate(ctx, "web.pem", "NEW"); err != nil {
+		t.Fatalf("ReplaceCertificate failed: %v", err)
+	}
+	if data, _ := os.ReadFile(certificate.File); string(data) != "NEW" {
+		t.Errorf("Expected the certificate to be replaced, got %q", data)
+	}
+	if len(runner.commands) != 1 || runner.commands[0] != "systemctl reload haproxy.service" {
+		t.Errorf("Expected the replacement to reload HAProxy, got %v", runner.commands)
+	}
+	if _, err := client.GetCertificate(ctx, "missing.pem"); !v3.IsNotFound(err) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestStorageFiles(t *testing.T) {
+	ctx := context.Background()
+	client, settings, _ := newTestClient(t)
+
+	if files, err := client.ListStorageFiles(ctx); err != nil || len(files) != 0 {
+		t.Fatalf("Expected no files before the first upload, got %v: %v", files, err)
+	}
+	file, err := client.AddStorageFile(ctx, "auth.v1.lua", "core.register_action('auth', { 'http-req' }, f)")
+	if err != nil || file.File != filepath.Join(settings.StorageDir, "auth.v1.lua") {
+		t.Fatalf("Unexpected file %v: %v", file, err)
+	}
+	if _, err := client.AddStorageFile(ctx, "auth.v1.lua", "-- again"); !v3.IsConflict(err) {
+		t.Errorf("Expected a duplicate file to conflict, got %v", err)
+	}
+	if _, err := client.AddStorageFile(ctx, "../auth.lua", "-- outside"); !v3.IsBadRequest(err) {
+		t.Errorf("Expected a name outside the storage directory to be rejected, got %v", err)
+	}
+	if content, err := client.GetStorageFile(ctx, "auth.v1.lua"); err != nil || !strings.Contains(content, "register_action") {
+		t.Errorf("Unexpected content %q: %v", content, err)
+	}
+	if files, err := client.ListStorageFiles(ctx); err != nil || len(files) != 1 || files[0].StorageName != "auth.v1.lua" {
+		t.Errorf("Unexpected files %v: %v", files, err)
+	}
+	if err := client.DeleteStorageFile(ctx, "auth.v1.lua"); err != nil {
+		t.Fatalf("DeleteStorageFile failed: %v", err)
+	}
+	if _, err := client.GetStorageFile(ctx, "auth.v1.lua"); !v3.IsNotFound(err) {
+		t.Errorf("Expected NotFound after deletion, got %v", err)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	_, settings, runner := newTestClient(t)
+	client := New("default", settings, true, runner)
+
+	if _, err := client.CommitTransaction(ctx, addBackend(t, client, "app")); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	if _, err := os.Stat(settings.ConfigFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no configuration to be written, got %v", err)
+	}
+	if len(runner.commands) != 1 || !strings.HasPrefix(runner.commands[0], "haproxy -c") {
+		t.Errorf("Expected only the check, got %v", runner.commands)
+	}
+	if version, _ := client.GetVersion(ctx); *version != 1 {
+		t.Errorf("Expected the version to be unchanged, got %d", *version)
+	}
+}
@@ -0,0 +1,132 @@
+package standalone
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// configuration is the managed part of haproxy.cfg, as kept in the state file
+type configuration struct {
+	Version   int                 `json:"version"`
+	LuaLoads  []string            `json:"lua_loads,omitempty"` // Scripts loaded in the global section
+	Rings     []dataplane.Ring    `json:"rings,omitempty"`
+	Programs  []dataplane.Program `json:"programs,omitempty"`
+	Frontends []*Frontend         `json:"frontends,omitempty"`
+	Backends  []*Backend          `json:"backends,omitempty"`
+}
+
+// Frontend is a frontend section with its binds, rules and log targets
+type Frontend struct {
+	Frontend              dataplane.Frontend               `json:"frontend"`
+	Binds                 []dataplane.Bind                 `json:"binds,omitempty"`
+	ACLs                  []dataplane.ACL                  `json:"acls,omitempty"`
+	TCPRequestRules       []dataplane.TCPRequestRule       `json:"tcp_request_rules,omitempty"`
+	HTTPRequestRules      []dataplane.HTTPRequestRule      `json:"http_request_rules,omitempty"`
+	BackendSwitchingRules []dataplane.BackendSwitchingRule `json:"backend_switching_rules,omitempty"`
+	LogTargets            []dataplane.LogTarget            `json:"log_targets,omitempty"`
+}
+
+// Backend is a backend section with its servers, http-check rules and log targets
+type Backend struct {
+	Backend    dataplane.Backend     `json:"backend"`
+	Servers    []dataplane.Server    `json:"servers,omitempty"`
+	HTTPChecks []dataplane.HTTPCheck `json:"http_checks,omitempty"`
+	LogTargets []dataplane.LogTarget `json:"log_targets,omitempty"`
+}
+
+// clone returns a deep copy of the configuration for a transaction
+func (c *configuration) clone() *configuration {
+	return copyOf(c)
+}
+
+// frontend returns the frontend with the given name
+func (c *configuration) frontend(name string) (*Frontend, error) {
+	for _, f := range c.Frontends {
+		if nameOf(f.Frontend.Name) == name {
+			return f, nil
+		}
+	}
+	return nil, &v3.NotFoundError{Message: fmt.Sprintf("frontend %s not found", name)}
+}
+
+// backend returns the backend with the given name
+func (c *configuration) backend(name string) (*Backend, error) {
+	for _, b := range c.Backends {
+		if nameOf(b.Backend.Name) == name {
+			return b, nil
+		}
+	}
+	return nil, &v3.NotFoundError{Message: fmt.Sprintf("backend %s not found", name)}
+}
+
+// copyOf returns a deep copy of a resource, so that callers and the store never share pointers
+func copyOf[T any](value T) T {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("standalone: failed to copy %T: %v", value, err))
+	}
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		panic(fmt.Sprintf("standalone: failed to copy %T: %v", value, err))
+	}
+	return result
+}
+
+// copyList returns deep copies of resources, never nil
+func copyList[T any](list []T) []T {
+	result := make([]T, 0, len(list))
+	for _, item := range list {
+		result = append(result, copyOf(item))
+	}
+	return result
+}
+
+// insertAt inserts an item into a list at the given position, which may be the end of the list
+func insertAt[T any](list []T, index int, item T) ([]T, error) {
+	if index < 0 || index > len(list) {
+		return nil, &v3.NotFoundError{Message: fmt.Sprintf("rule %d not found", index)}
+	}
+	list = append(list, item)
+	copy(list[index+1:], list[index:])
+	list[index] = item
+	return list, nil
+}
+
+// replaceAt replaces the item of a list at the given position
+func replaceAt[T any](list []T, index int, item T) error {
+	if index < 0 || index >= len(list) {
+		return &v3.NotFoundError{Message: fmt.Sprintf("rule %d not found", index)}
+	}
+	list[index] = item
+	return nil
+}
+
+// deleteAt removes the item of a list at the given position
+func deleteAt[T any](list []T, index int) ([]T, error) {
+	if index < 0 || index >= len(list) {
+		return nil, &v3.NotFoundError{Message: fmt.Sprintf("rule %d not found", index)}
+	}
+	return append(list[:index], list[index+1:]...), nil
+}
+
+// nameOf dereferences the name of a resource
+func nameOf(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,274 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Resource types of planned changes, matching the event journal
+const (
+	ResourceBackend  = "backend"
+	ResourceFrontend = "frontend"
+	ResourceBind     = "bind"
+	ResourceServer   = "server"
+)
+
+// Actions of planned changes, matching the event journal
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a single operation needed to move the live configuration towards the desired state
+type Change struct {
+	Resource string
+	Action   string
+	Parent   string        // Frontend of a bind or backend of a server
+	Name     string        // Name of the resource
+	Object   proto.Message // Desired resource for create and update, nil for delete
+}
+
+// Proto converts the change to its API representation
+func (c Change) Proto() *pb.StateChange {
+	return &pb.StateChange{
+		ResourceType: c.Resource,
+		Action:       c.Action,
+		ParentName:   c.Parent,
+		Name:         c.Name,
+	}
+}
+
+// Diff computes the changes that turn current into desired, in an order the Data Plane API accepts:
+// backends and servers first, then frontends and binds, then deletion of frontends and finally backends
+// so that nothing references a deleted backend. Unless prune is set, resources missing from desired are kept.
+// A bind whose address changes is recreated so that its VIP moves in Netplan as well.
+func Diff(current, desired *pb.State, prune bool) []Change {
+	var changes []Change
+
+	currentBackends := make(map[string]*pb.BackendState)
+	for _, backend := range current.Backends {
+		currentBackends[backend.Backend.Name] = backend
+	}
+	currentFrontends := make(map[string]*pb.FrontendState)
+	for _, frontend := range current.Frontends {
+		currentFrontends[frontend.Frontend.Name] = frontend
+	}
+
+	desiredBackends := make(map[string]bool)
+	for _, backend := range desired.Backends {
+		name := backend.Backend.Name
+		desiredBackends[name] = true
+
+		existing, ok := currentBackends[name]
+		if !ok {
+			changes = append(changes, Change{Resource: ResourceBackend, Action: ActionCreate, Name: name, Object: backend.Backend})
+			existing = &pb.BackendState{}
+		} else if !SameResource(existing.Backend, backend.Backend) {
+			changes = append(changes, Change{Resource: ResourceBackend, Action: ActionUpdate, Name: name, Object: backend.Backend})
+		}
+
+		servers := make(map[string]*pb.Server)
+		for _, server := range existing.Servers {
+			servers[server.Name] = server
+		}
+		for _, server := range backend.Servers {
+			previous, ok := servers[server.Name]
+			delete(servers, server.Name)
+			if !ok {
+				changes = append(changes, Change{Resource: ResourceServer, Action: ActionCreate, Parent: name, Name: server.Name, Object: server})
+			} else if !SameResource(previous, server) {
+				changes = append(changes, Change{Resource: ResourceServer, Action: ActionUpdate, Parent: name, Name: server.Name, Object: server})
+			}
+		}
+		if prune {
+			for _, server := range existing.Servers {
+				if _, ok := servers[server.Name]; ok {
+					changes = append(changes, Change{Resource: ResourceServer, Action: ActionDelete, Parent: name, Name: server.Name})
+				}
+			}
+		}
+	}
+
+	desiredFrontends := make(map[string]bool)
+	for _, frontend := range desired.Frontends {
+		name := frontend.Frontend.Name
+		desiredFrontends[name] = true
+
+		existing, ok := currentFrontends[name]
+		if !ok {
+			changes = append(changes, Change{Resource: ResourceFrontend, Action: ActionCreate, Name: name, Object: frontend.Frontend})
+			existing = &pb.FrontendState{}
+		} else if !SameResource(existing.Frontend, frontend.Frontend) {
+			changes = append(changes, Change{Resource: ResourceFrontend, Action: ActionUpdate, Name: name, Object: frontend.Frontend})
+		}
+
+		binds := make(map[string]*pb.Bind)
+		for _, bind := range existing.Binds {
+			binds[bind.Name] = bind
+		}
+		for _, bind := range frontend.Binds {
+			previous, ok := binds[bind.Name]
+			delete(binds, bind.Name)
+			switch {
+			case !ok:
+				changes = append(changes, Change{Resource: ResourceBind, Action: ActionCreate, Parent: name, Name: bind.Name, Object: bind})
+			case SameResource(previous, bind):
+			case previous.Address == bind.Address:
+				changes = append(changes, Change{Resource: ResourceBind, Action: ActionUpdate, Parent: name, Name: bind.Name, Object: bind})
+			default:
+				changes = append(changes,
+					Change{Resource: ResourceBind, Action: ActionDelete, Parent: name, Name: bind.Name},
+					Change{Resource: ResourceBind, Action: ActionCreate, Parent: name, Name: bind.Name, Object: bind})
+			}
+		}
+		if prune {
+			for _, bind := range existing.Binds {
+				if _, ok := binds[bind.Name]; ok {
+					changes = append(changes, Change{Resource: ResourceBind, Action: ActionDelete, Parent: name, Name: bind.Name})
+				}
+			}
+		}
+	}
+
+	if !prune {
+		return changes
+	}
+
+	// Binds of deleted frontends are deleted one by one so that their VIPs are released
+	for _, frontend := range current.Frontends {
+		name := frontend.Frontend.Name
+		if desiredFrontends[name] {
+			continue
+		}
+		for _, bind := range frontend.Binds {
+			changes = append(changes, Change{Resource: ResourceBind, Action: ActionDelete, Parent: name, Name: bind.Name})
+		}
+		changes = append(changes, Change{Resource: ResourceFrontend, Action: ActionDelete, Name: name})
+	}
+	for _, backend := range current.Backends {
+		if !desiredBackends[backend.Backend.Name] {
+			changes = append(changes, Change{Resource: ResourceBackend, Action: ActionDelete, Name: backend.Backend.Name})
+		}
+	}
+
+	return changes
+}
+
+// Stages splits changes in the order of Diff into stages to be applied one after the other. The changes of a
+// stage may be applied concurrently: each resource appears at most once per stage, binds and servers follow the
+// changes of their frontend or backend, frontends and backends follow their binds and servers, and the changes
+// of frontends start only after those of backends and vice versa, as frontends reference backends.
+func Stages(changes []Change) [][]Change {
+	var stages [][]Change
+	last := make(map[string]int)     // Stage of the last change of a resource
+	children := make(map[string]int) // Stage of the last change of a bind or server of a frontend or backend
+	floor, scope := 0, ""
+	for _, change := range changes {
+		if s := change.scope(); s != scope {
+			floor, scope = len(stages), s
+		}
+
+		key := change.Resource + "/" + change.Parent + "/" + change.Name
+		parent := change.parentKey()
+		stage := floor
+		if previous, ok := last[key]; ok {
+			stage = max(stage, previous+1)
+		}
+		if parent != "" {
+			if previous, ok := last[parent]; ok {
+				stage = max(stage, previous+1)
+			}
+		} else if previous, ok := children[key]; ok {
+			stage = max(stage, previous+1)
+		}
+
+		for len(stages) <= stage {
+			stages = append(stages, nil)
+		}
+		stages[stage] = append(stages[stage], change)
+		last[key] = stage
+		if parent != "" {
+			children[parent] = max(children[parent], stage)
+		}
+	}
+	return stages
+}
+
+// scope returns the top-level resource type a change belongs to
+func (c Change) scope() string {
+	if c.Resource == ResourceBind || c.Resource == ResourceFrontend {
+		return ResourceFrontend
+	}
+	return ResourceBackend
+}
+
+// parentKey returns the key of the frontend of a bind or the backend of a server, or "" for other changes
+func (c Change) parentKey() string {
+	switch c.Resource {
+	case ResourceBind:
+		return ResourceFrontend + "//" + c.Parent
+	case ResourceServer:
+		return ResourceBackend + "//" + c.Parent
+	}
+	return ""
+}
+
+// Scope returns the part of a state owned by a controller that names its frontends and backends with prefix.
+// Binds and servers belong to their frontend or backend.
+func Scope(state *pb.State, prefix string) *pb.State {
+	scoped := &pb.State{}
+	for _, frontend := range state.Frontends {
+		if strings.HasPrefix(frontend.GetFrontend().GetName(), prefix) {
+			scoped.Frontends = append(scoped.Frontends, frontend)
+		}
+	}
+	for _, backend := range state.Backends {
+		if strings.HasPrefix(backend.GetBackend().GetName(), prefix) {
+			scoped.Backends = append(scoped.Backends, backend)
+		}
+	}
+	return scoped
+}
+
+// CheckScope verifies that every frontend and backend of a desired state is named with prefix
+func CheckScope(state *pb.State, prefix string) error {
+	for _, frontend := range state.Frontends {
+		if !strings.HasPrefix(frontend.GetFrontend().GetName(), prefix) {
+			return fmt.Errorf("frontend %s does not start with %q", frontend.GetFrontend().GetName(), prefix)
+		}
+	}
+	for _, backend := range state.Backends {
+		if !strings.HasPrefix(backend.GetBackend().GetName(), prefix) {
+			return fmt.Errorf("backend %s does not start with %q", backend.GetBackend().GetName(), prefix)
+		}
+	}
+	return nil
+}
+
+// SameResource compares a live resource with a desired one, ignoring the ID assigned by the Data Plane API
+// and the resource version derived from it
+func SameResource(current, desired proto.Message) bool {
+	a := proto.Clone(current)
+	b := proto.Clone(desired)
+	for _, message := range []proto.Message{a, b} {
+		switch m := message.(type) {
+		case *pb.Backend:
+			m.Id = 0
+			m.ResourceVersion = ""
+		case *pb.Frontend:
+			m.Id = 0
+			m.ResourceVersion = ""
+		case *pb.Bind:
+			m.Id = ""
+			m.ResourceVersion = ""
+		case *pb.Server:
+			m.Id = ""
+			m.ResourceVersion = ""
+		}
+	}
+	return proto.Equal(a, b)
+}
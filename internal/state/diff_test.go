@@ -0,0 +1,147 @@
+package state
+
+import (
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// changeList renders changes as "action resource parent/name" for comparison
+func changeList(changes []Change) []string {
+	var result []string
+	for _, change := range changes {
+		result = append(result, change.Action+" "+change.Resource+" "+change.Parent+"/"+change.Name)
+	}
+	return result
+}
+
+func assertChanges(t *testing.T, changes []Change, expected ...string) {
+	t.Helper()
+	got := changeList(changes)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected changes %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected changes %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	live := testState()
+	// IDs assigned by the Data Plane API do not count as differences
+	live.Backends[0].Backend.Id = 3
+	live.Frontends[0].Binds[0].Id = "vip"
+
+	assertChanges(t, Diff(live, testState(), true))
+}
+
+func TestDiffCreatesEverything(t *testing.T) {
+	assertChanges(t, Diff(&pb.State{}, testState(), true),
+		"create backend /app",
+		"create server app/app1",
+		"create frontend /web",
+		"create bind web/vip")
+}
+
+func TestDiffUpdatesAndPrunes(t *testing.T) {
+	live := testState()
+	live.Backends = append(live.Backends, &pb.BackendState{Backend: &pb.Backend{Name: "old"}})
+	live.Backends[0].Servers = append(live.Backends[0].Servers, &pb.Server{Name: "app2", Address: "10.0.0.2", Port: 8080})
+	live.Frontends = append(live.Frontends, &pb.FrontendState{
+		Frontend: &pb.Frontend{Name: "legacy"},
+		Binds:    []*pb.Bind{{Name: "legacy-vip", Address: "192.168.1.50", Port: 80}},
+	})
+
+	desired := testState()
+	desired.Backends[0].Servers[0].Port = 9090
+	desired.Frontends[0].Binds[0].Address = "192.168.1.101"
+
+	assertChanges(t, Diff(live, desired, true),
+		"update server app/app1",
+		"delete server app/app2",
+		"delete bind web/vip",
+		"create bind web/vip",
+		"delete bind legacy/legacy-vip",
+		"delete frontend /legacy",
+		"delete backend /old")
+
+	// Without prune, only the differences in desired resources are applied
+	assertChanges(t, Diff(live, desired, false),
+		"update server app/app1",
+		"delete bind web/vip",
+		"create bind web/vip")
+}
+
+func TestStages(t *testing.T) {
+	live := testState()
+	live.Backends = append(live.Backends, &pb.BackendState{Backend: &pb.Backend{Name: "old"}})
+	live.Backends[0].Servers = append(live.Backends[0].Servers, &pb.Server{Name: "app2", Address: "10.0.0.2", Port: 8080})
+	live.Frontends = append(live.Frontends, &pb.FrontendState{
+		Frontend: &pb.Frontend{Name: "legacy"},
+		Binds:    []*pb.Bind{{Name: "legacy-vip", Address: "192.168.1.50", Port: 80}},
+	})
+
+	desired := testState()
+	desired.Backends[0].Servers[0].Port = 9090
+	desired.Backends = append(desired.Backends, &pb.BackendState{
+		Backend: &pb.Backend{Name: "api"},
+		Servers: []*pb.Server{{Name: "api1", Address: "10.0.1.1", Port: 8080}, {Name: "api2", Address: "10.0.1.2", Port: 8080}},
+	})
+	desired.Frontends[0].Binds[0].Address = "192.168.1.101"
+
+	stages := Stages(Diff(live, desired, true))
+	expected := [][]string{
+		{"update server app/app1", "delete server app/app2", "create backend /api"},
+		{"create server api/api1", "create server api/api2"},
+		{"delete bind web/vip", "delete bind legacy/legacy-vip"},
+		{"create bind web/vip", "delete frontend /legacy"},
+		{"delete backend /old"},
+	}
+	if len(stages) != len(expected) {
+		t.Fatalf("Expected %d stages, got %v", len(expected), stages)
+	}
+	for i := range expected {
+		assertChanges(t, stages[i], expected[i]...)
+	}
+}
+
+func TestDiffUpdatesBindInPlace(t *testing.T) {
+	desired := testState()
+	desired.Frontends[0].Binds[0].Port = 8443
+
+	changes := Diff(testState(), desired, true)
+	assertChanges(t, changes, "update bind web/vip")
+	if !proto.Equal(changes[0].Object, desired.Frontends[0].Binds[0]) {
+		t.Errorf("Expected the desired bind as change object, got %v", changes[0].Object)
+	}
+}
+
+func TestDiffNormalizedModes(t *testing.T) {
+	live := testState()
+	live.Backends[0].Backend.Mode = pb.ProxyMode_PROXY_MODE_TCP
+
+	desired := testState()
+	Normalize(desired)
+	assertChanges(t, Diff(live, desired, true))
+}
+
+func TestDiffScoped(t *testing.T) {
+	live := testState()
+	live.Backends = append(live.Backends, &pb.BackendState{Backend: &pb.Backend{Name: "k8s-old"}})
+
+	desired := &pb.State{Backends: []*pb.BackendState{{Backend: &pb.Backend{Name: "k8s-app"}}}}
+	if err := CheckScope(desired, "k8s-"); err != nil {
+		t.Fatalf("CheckScope failed: %v", err)
+	}
+	if err := CheckScope(testState(), "k8s-"); err == nil {
+		t.Error("Expected CheckScope to reject names without the prefix")
+	}
+
+	// Resources without the prefix are neither compared nor pruned
+	assertChanges(t, Diff(Scope(live, "k8s-"), desired, true),
+		"create backend /k8s-app",
+		"delete backend /k8s-old")
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+)
+
+// ManifestFiles lists the YAML and JSON files below path in a stable order, skipping hidden directories
+// such as .git. A path naming a file is returned as is, whatever its extension.
+func ManifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if file != path && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests in %s: %w", path, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// DecodeManifest parses one manifest, taking the format from the extension of its name
+func DecodeManifest(name string, data []byte) (*pb.State, error) {
+	format := pb.StateFormat_STATE_FORMAT_YAML
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		format = pb.StateFormat_STATE_FORMAT_JSON
+	}
+	document, err := Decode(string(data), format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", name, err)
+	}
+	return document, nil
+}
+
+// Merge combines manifests into one state. Resources may be split across manifests freely,
+// but each may only be declared once.
+func Merge(documents ...*pb.State) (*pb.State, error) {
+	merged := &pb.State{}
+	for _, document := range documents {
+		merged.Frontends = append(merged.Frontends, document.Frontends...)
+		merged.Backends = append(merged.Backends, document.Backends...)
+	}
+
+	if err := Validate(merged); err != nil {
+		return nil, fmt.Errorf("invalid manifests: %w", err)
+	}
+	return merged, nil
+}
+
+// LoadManifests reads the manifest files and directories and merges them into one state
+func LoadManifests(paths ...string) (*pb.State, error) {
+	var documents []*pb.State
+	for _, path := range paths {
+		files, err := ManifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifest %s: %w", file, err)
+			}
+			document, err := DecodeManifest(file, data)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	}
+	return Merge(documents...)
+}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const backendManifest = `backends:
+  - backend:
+      name: app
+    servers:
+      - name: app1
+        address: 10.0.0.1
+        port: 8080
+`
+
+const frontendManifest = `{"frontends": [{"frontend": {"name": "web", "default_backend": "app"}, "binds": [{"name": "vip", "address": "192.168.1.100", "port": 443}]}]}`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadManifestsMergesFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "backends", "app.yaml"), backendManifest)
+	writeFile(t, filepath.Join(dir, "web.json"), frontendManifest)
+	writeFile(t, filepath.Join(dir, "empty.yml"), "")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a manifest")
+	writeFile(t, filepath.Join(dir, ".git", "config.yaml"), "invalid: [")
+
+	desired, err := LoadManifests(dir)
+	if err != nil {
+		t.Fatalf("LoadManifests failed: %v", err)
+	}
+	if len(desired.Backends) != 1 || len(desired.Frontends) != 1 || len(desired.Frontends[0].Binds) != 1 {
+		t.Errorf("Unexpected merged state: %v", desired)
+	}
+
+	// A resource declared in two files is rejected
+	writeFile(t, filepath.Join(dir, "copy.yaml"), backendManifest)
+	if _, err := LoadManifests(dir); err == nil || !strings.Contains(err.Error(), "duplicate backend app") {
+		t.Errorf("Expected duplicate backend error, got %v", err)
+	}
+}
+
+func TestLoadManifestsFiles(t *testing.T) {
+	dir := t.TempDir()
+	backend := filepath.Join(dir, "app.manifest")
+	frontend := filepath.Join(dir, "web.json")
+	writeFile(t, backend, backendManifest)
+	writeFile(t, frontend, frontendManifest)
+
+	desired, err := LoadManifests(backend, frontend)
+	if err != nil {
+		t.Fatalf("LoadManifests failed: %v", err)
+	}
+	if len(desired.Backends) != 1 || len(desired.Frontends) != 1 {
+		t.Errorf("Unexpected merged state: %v", desired)
+	}
+
+	if _, err := LoadManifests(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing manifest")
+	}
+}
@@ -0,0 +1,190 @@
+// Package state encodes and decodes full-state documents used by ExportState and ImportState
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// Encode renders a state as a YAML or JSON document, YAML being the default
+func Encode(state *pb.State, format pb.StateFormat) (string, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, Indent: "  "}.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode state: %w", err)
+	}
+	if format == pb.StateFormat_STATE_FORMAT_JSON {
+		return string(data) + "\n", nil
+	}
+
+	document, err := JSONToYAML(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert state to YAML: %w", err)
+	}
+	return string(document), nil
+}
+
+// JSONToYAML converts a JSON document to block YAML, keeping the field order
+func JSONToYAML(data []byte) ([]byte, error) {
+	// Go through yaml.Node, as maps would sort the fields
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearStyle(&node)
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Decode parses a YAML or JSON document into a state. As JSON is valid YAML, an unspecified
+// format accepts both.
+func Decode(document string, format pb.StateFormat) (*pb.State, error) {
+	data := []byte(document)
+	if format != pb.StateFormat_STATE_FORMAT_JSON {
+		var value interface{}
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse state document: %w", err)
+		}
+		if value == nil {
+			// Empty document
+			return &pb.State{}, nil
+		}
+		converted, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state document: %w", err)
+		}
+		data = converted
+	}
+
+	state := &pb.State{}
+	if err := protojson.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state document: %w", err)
+	}
+	return state, nil
+}
+
+// Validate checks that every resource in the state is named and that names are unique
+func Validate(state *pb.State) error {
+	frontends := make(map[string]bool)
+	for _, frontend := range state.Frontends {
+		if frontend.Frontend == nil || frontend.Frontend.Name == "" {
+			return fmt.Errorf("frontend name is required")
+		}
+		name := frontend.Frontend.Name
+		if frontends[name] {
+			return fmt.Errorf("duplicate frontend %s", name)
+		}
+		frontends[name] = true
+
+		binds := make(map[string]bool)
+		for _, bind := range frontend.Binds {
+			if bind.Name == "" {
+				return fmt.Errorf("bind name is required in frontend %s", name)
+			}
+			if binds[bind.Name] {
+				return fmt.Errorf("duplicate bind %s in frontend %s", bind.Name, name)
+			}
+			binds[bind.Name] = true
+		}
+	}
+
+	backends := make(map[string]bool)
+	for _, backend := range state.Backends {
+		if backend.Backend == nil || backend.Backend.Name == "" {
+			return fmt.Errorf("backend name is required")
+		}
+		name := backend.Backend.Name
+		if backends[name] {
+			return fmt.Errorf("duplicate backend %s", name)
+		}
+		backends[name] = true
+
+		servers := make(map[string]bool)
+		for _, server := range backend.Servers {
+			if server.Name == "" {
+				return fmt.Errorf("server name is required in backend %s", name)
+			}
+			if servers[server.Name] {
+				return fmt.Errorf("duplicate server %s in backend %s", server.Name, name)
+			}
+			servers[server.Name] = true
+		}
+	}
+	return nil
+}
+
+// Normalize fills in the defaults the Data Plane API applies on write, so that a desired state compares
+// equal to the live configuration it produces. Frontends and backends without a mode are created in TCP mode.
+// Labels and annotations are dropped, as they are kept by the metadata store rather than in the state.
+func Normalize(state *pb.State) {
+	for _, frontend := range state.Frontends {
+		NormalizeFrontend(frontend.Frontend)
+		if frontend.Frontend != nil {
+			frontend.Frontend.Labels, frontend.Frontend.Annotations = nil, nil
+		}
+		for _, bind := range frontend.Binds {
+			bind.Labels, bind.Annotations = nil, nil
+		}
+	}
+	for _, backend := range state.Backends {
+		NormalizeBackend(backend.Backend)
+		if backend.Backend != nil {
+			backend.Backend.Labels, backend.Backend.Annotations = nil, nil
+		}
+		for _, server := range backend.Servers {
+			server.Labels, server.Annotations = nil, nil
+		}
+	}
+}
+
+// NormalizeFrontend fills in the defaults the Data Plane API applies to a frontend on write
+func NormalizeFrontend(frontend *pb.Frontend) {
+	if frontend != nil && frontend.Mode == pb.ProxyMode_PROXY_MODE_UNSPECIFIED {
+		frontend.Mode = pb.ProxyMode_PROXY_MODE_TCP
+	}
+}
+
+// NormalizeBackend fills in the defaults the Data Plane API applies to a backend on write
+func NormalizeBackend(backend *pb.Backend) {
+	if backend != nil && backend.Mode == pb.ProxyMode_PROXY_MODE_UNSPECIFIED {
+		backend.Mode = pb.ProxyMode_PROXY_MODE_TCP
+	}
+}
+
+// Sort orders all resources by name so that exported documents are stable
+func Sort(state *pb.State) {
+	sort.Slice(state.Frontends, func(i, j int) bool {
+		return state.Frontends[i].GetFrontend().GetName() < state.Frontends[j].GetFrontend().GetName()
+	})
+	for _, frontend := range state.Frontends {
+		sort.Slice(frontend.Binds, func(i, j int) bool {
+			return frontend.Binds[i].Name < frontend.Binds[j].Name
+		})
+	}
+	sort.Slice(state.Backends, func(i, j int) bool {
+		return state.Backends[i].GetBackend().GetName() < state.Backends[j].GetBackend().GetName()
+	})
+	for _, backend := range state.Backends {
+		sort.Slice(backend.Servers, func(i, j int) bool {
+			return backend.Servers[i].Name < backend.Servers[j].Name
+		})
+	}
+}
+
+// clearStyle drops the flow style inherited from JSON so the output is block YAML
+func clearStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
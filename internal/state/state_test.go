@@ -0,0 +1,75 @@
+package state
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func testState() *pb.State {
+	return &pb.State{
+		Frontends: []*pb.FrontendState{{
+			Frontend: &pb.Frontend{Name: "web", DefaultBackend: "app", Mode: pb.ProxyMode_PROXY_MODE_HTTP},
+			Binds:    []*pb.Bind{{Name: "vip", Address: "192.168.1.100", Port: 443}},
+		}},
+		Backends: []*pb.BackendState{{
+			Backend: &pb.Backend{Name: "app", Balance: &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN}},
+			Servers: []*pb.Server{{Name: "app1", Address: "10.0.0.1", Port: 8080}},
+		}},
+		TrackedAddresses: map[string]string{"192.168.1.100": "eth0"},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []pb.StateFormat{pb.StateFormat_STATE_FORMAT_YAML, pb.StateFormat_STATE_FORMAT_JSON} {
+		document, err := Encode(testState(), format)
+		if err != nil {
+			t.Fatalf("Encode(%v) failed: %v", format, err)
+		}
+
+		// Unspecified format accepts both encodings
+		decoded, err := Decode(document, pb.StateFormat_STATE_FORMAT_UNSPECIFIED)
+		if err != nil {
+			t.Fatalf("Decode(%v) failed: %v\n%s", format, err, document)
+		}
+		if !proto.Equal(decoded, testState()) {
+			t.Errorf("Round trip through %v changed the state:\n%s", format, document)
+		}
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	document, err := Encode(testState(), pb.StateFormat_STATE_FORMAT_UNSPECIFIED)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if strings.Contains(document, "{") || !strings.Contains(document, "default_backend: app") {
+		t.Errorf("Expected block YAML with proto field names, got:\n%s", document)
+	}
+}
+
+func TestDecodeRejectsUnknownFields(t *testing.T) {
+	if _, err := Decode("frontends:\n  - frontend:\n      nmae: web\n", pb.StateFormat_STATE_FORMAT_YAML); err == nil {
+		t.Error("Expected an error for a misspelled field")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(testState()); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	state := testState()
+	state.Backends = append(state.Backends, &pb.BackendState{Backend: &pb.Backend{Name: "app"}})
+	if err := Validate(state); err == nil || !strings.Contains(err.Error(), "duplicate backend app") {
+		t.Errorf("Expected duplicate backend error, got %v", err)
+	}
+
+	state = testState()
+	state.Frontends[0].Binds = append(state.Frontends[0].Binds, &pb.Bind{Port: 80})
+	if err := Validate(state); err == nil {
+		t.Error("Expected an error for an unnamed bind")
+	}
+}
@@ -0,0 +1,99 @@
+// Package systemd implements the sd_notify protocol, so that systemd can track the readiness and liveness
+// of the server when it runs as a Type=notify service
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Notification states understood by systemd
+const (
+	Ready    = "READY=1"
+	Stopping = "STOPPING=1"
+	Watchdog = "WATCHDOG=1"
+)
+
+// Status returns the notification setting the status line shown by systemctl status
+func Status(text string) string {
+	return "STATUS=" + text
+}
+
+// Notify sends states, separated by newlines, to the service manager. It returns false without an error when
+// the process was not started by systemd, i.e. NOTIFY_SOCKET is unset.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+	// A leading @ denotes a socket in the abstract namespace
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to notify socket: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("failed to send notification: %w", err)
+	}
+	return true, nil
+}
+
+// WatchdogTimeout returns the time systemd expects watchdog pings within, or 0 if the watchdog is disabled
+// for this process (WatchdogSec unset or WATCHDOG_PID naming another process)
+func WatchdogTimeout() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid WATCHDOG_USEC %q", usec)
+	}
+	return time.Duration(value) * time.Microsecond, nil
+}
+
+// RunWatchdog pings the watchdog at half its timeout until ctx is done. A ping is only sent while check
+// succeeds, so that systemd restarts the service once it has been unhealthy for the whole timeout.
+func RunWatchdog(ctx context.Context, timeout time.Duration, check func(context.Context) error) {
+	interval := timeout / 2
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := check(checkCtx)
+		cancel()
+
+		if err != nil {
+			logger.GetLogger().Warn("Health check failed, skipping watchdog ping",
+				zap.Duration("watchdog_timeout", timeout),
+				zap.Error(err))
+		} else if _, err := Notify(Watchdog); err != nil {
+			logger.GetLogger().Warn("Failed to ping systemd watchdog",
+				zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// listen creates a notify socket and points NOTIFY_SOCKET at it
+func listen(t *testing.T) *net.UnixConn {
+	t.Helper()
+	// Socket paths are limited to about 100 bytes, t.TempDir() can be longer
+	dir, err := os.MkdirTemp("", "sd")
+	if err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", path)
+	return conn
+}
+
+// receive reads the next notification
+func receive(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 256)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to receive notification: %v", err)
+	}
+	return string(buf[:n])
+}
+
+func TestNotify(t *testing.T) {
+	conn := listen(t)
+
+	sent, err := Notify(Ready + "\n" + Status("Serving"))
+	if err != nil || !sent {
+		t.Fatalf("Expected the notification to be sent, got %v, %v", sent, err)
+	}
+	if got := receive(t, conn); got != "READY=1\nSTATUS=Serving" {
+		t.Errorf("Unexpected notification %q", got)
+	}
+}
+
+func TestNotifyWithoutSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	if sent, err := Notify(Ready); sent || err != nil {
+		t.Errorf("Expected nothing to be sent, got %v, %v", sent, err)
+	}
+}
+
+func TestWatchdogTimeout(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	if timeout, err := WatchdogTimeout(); err != nil || timeout != 30*time.Second {
+		t.Errorf("Expected 30s, got %v, %v", timeout, err)
+	}
+
+	t.Setenv("WATCHDOG_PID", "1")
+	if timeout, err := WatchdogTimeout(); err != nil || timeout != 0 {
+		t.Errorf("Expected the watchdog of another process to be ignored, got %v, %v", timeout, err)
+	}
+
+	t.Setenv("WATCHDOG_PID", "")
+	t.Setenv("WATCHDOG_USEC", "soon")
+	if _, err := WatchdogTimeout(); err == nil {
+		t.Error("Expected an error for an invalid WATCHDOG_USEC")
+	}
+}
+
+func TestRunWatchdogSkipsPingsWhileUnhealthy(t *testing.T) {
+	conn := listen(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	healthy := make(chan bool, 1)
+	healthy <- false
+	go RunWatchdog(ctx, 20*time.Millisecond, func(context.Context) error {
+		select {
+		case ok := <-healthy:
+			if !ok {
+				return errors.New("stuck")
+			}
+		default:
+		}
+		return nil
+	})
+
+	// The first check fails; the ping arrives with the next one
+	start := time.Now()
+	if got := receive(t, conn); got != Watchdog {
+		t.Errorf("Unexpected notification %q", got)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Expected the failed check to skip the first ping, got a ping after %v", elapsed)
+	}
+}
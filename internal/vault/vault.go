@@ -0,0 +1,320 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// defaultServiceAccountTokenFile is where Kubernetes mounts the pod's service account token
+const defaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// requestTimeout bounds every request made to Vault
+const requestTimeout = 30 * time.Second
+
+// Secret is the result of reading or writing a Vault path
+type Secret struct {
+	Data          map[string]interface{}
+	LeaseDuration time.Duration
+}
+
+// String returns the value of key as a string, or an error if it is missing or not a string
+func (s *Secret) String(key string) (string, error) {
+	value, ok := s.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret", key)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q in secret is not a string", key)
+	}
+	return str, nil
+}
+
+// response is the common envelope of Vault API responses
+type response struct {
+	Data          map[string]interface{} `json:"data"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// Client is a minimal Vault HTTP API client supporting token, AppRole and Kubernetes authentication
+type Client struct {
+	settings   config.VaultSettings
+	httpClient *http.Client
+
+	mutex       sync.RWMutex
+	token       string
+	tokenExpiry time.Time // Zero if the token does not expire
+	renewable   bool
+}
+
+// NewClient creates a Vault client for the given settings. Call Login before reading secrets.
+func NewClient(settings config.VaultSettings) (*Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: settings.TLSSkipVerify,
+	}
+	if settings.CACert != "" {
+		pem, err := os.ReadFile(settings.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in vault CA certificate %s", settings.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &Client{
+		settings: settings,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+		},
+	}, nil
+}
+
+// Login authenticates with the configured auth method and stores the resulting token
+func (c *Client) Login(ctx context.Context) error {
+	auth := c.settings.Auth
+
+	switch auth.Method {
+	case "token":
+		token := auth.Token
+		if auth.TokenFile != "" {
+			data, err := os.ReadFile(auth.TokenFile)
+			if err != nil {
+				return fmt.Errorf("failed to read vault token file: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		if token == "" {
+			token = os.Getenv("VAULT_TOKEN")
+		}
+		c.setToken(token, 0, true)
+		return c.lookupSelf(ctx)
+	case "approle":
+		secretID := auth.SecretID
+		if auth.SecretIDFile != "" {
+			data, err := os.ReadFile(auth.SecretIDFile)
+			if err != nil {
+				return fmt.Errorf("failed to read vault secret_id file: %w", err)
+			}
+			secretID = strings.TrimSpace(string(data))
+		}
+		return c.login(ctx, mountPath(auth.MountPath, "approle"), map[string]interface{}{
+			"role_id":   auth.RoleID,
+			"secret_id": secretID,
+		})
+	case "kubernetes":
+		tokenFile := auth.ServiceAccountTokenFile
+		if tokenFile == "" {
+			tokenFile = defaultServiceAccountTokenFile
+		}
+		jwt, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read service account token: %w", err)
+		}
+		return c.login(ctx, mountPath(auth.MountPath, "kubernetes"), map[string]interface{}{
+			"role": auth.Role,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		})
+	default:
+		return fmt.Errorf("unsupported vault auth method %q", auth.Method)
+	}
+}
+
+// RenewToken extends the current token's lease if it is renewable
+func (c *Client) RenewToken(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodPost, "auth/token/renew-self", map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to renew vault token: %w", err)
+	}
+	if resp.Auth == nil {
+		return fmt.Errorf("failed to renew vault token: response contains no auth data")
+	}
+
+	c.setToken(resp.Auth.ClientToken, resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+// TokenExpiry returns when the current token expires and whether it can be renewed.
+// A zero time means the token does not expire.
+func (c *Client) TokenExpiry() (time.Time, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.tokenExpiry, c.renewable
+}
+
+// hasToken reports whether a token has been acquired
+func (c *Client) hasToken() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.token != ""
+}
+
+// Read reads a secret. KV version 2 responses are unwrapped so that Data holds the secret's fields.
+func (c *Client) Read(ctx context.Context, path string) (*Secret, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+	return newSecret(resp), nil
+}
+
+// Write writes data to a path, e.g. to issue a certificate from a PKI secrets engine
+func (c *Client) Write(ctx context.Context, path string, data map[string]interface{}) (*Secret, error) {
+	resp, err := c.do(ctx, http.MethodPost, path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write vault path %s: %w", path, err)
+	}
+	return newSecret(resp), nil
+}
+
+// login exchanges credentials for a token at auth/<mount>/login
+func (c *Client) login(ctx context.Context, mount string, body map[string]interface{}) error {
+	resp, err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", body)
+	if err != nil {
+		return fmt.Errorf("failed to log in to vault: %w", err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return fmt.Errorf("failed to log in to vault: response contains no token")
+	}
+
+	c.setToken(resp.Auth.ClientToken, resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+// lookupSelf validates a static token and records its TTL
+func (c *Client) lookupSelf(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodGet, "auth/token/lookup-self", nil)
+	if err != nil {
+		return fmt.Errorf("failed to validate vault token: %w", err)
+	}
+
+	ttl, _ := resp.Data["ttl"].(float64)
+	renewable, _ := resp.Data["renewable"].(bool)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.renewable = renewable
+	c.tokenExpiry = time.Time{}
+	if ttl > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(ttl) * time.Second)
+	}
+	return nil
+}
+
+// setToken stores a token and its lease
+func (c *Client) setToken(token string, leaseSeconds int, renewable bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.token = token
+	c.renewable = renewable
+	c.tokenExpiry = time.Time{}
+	if leaseSeconds > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(leaseSeconds) * time.Second)
+	}
+}
+
+// do sends a request to the Vault HTTP API and decodes the response envelope
+func (c *Client) do(ctx context.Context, method, path string, body interface{}) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	url := strings.TrimRight(c.settings.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.settings.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.settings.Namespace)
+	}
+
+	c.mutex.RLock()
+	token := c.token
+	c.mutex.RUnlock()
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var resp response
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode response (status %d): %w", res.StatusCode, err)
+		}
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("vault returned status %d: %s", res.StatusCode, strings.Join(resp.Errors, "; "))
+		}
+		return nil, fmt.Errorf("vault returned status %d", res.StatusCode)
+	}
+
+	return &resp, nil
+}
+
+// newSecret converts a response into a Secret, unwrapping KV version 2 data
+func newSecret(resp *response) *Secret {
+	data := resp.Data
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = inner
+		}
+	}
+
+	return &Secret{
+		Data:          data,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+	}
+}
+
+// mountPath returns the configured auth mount path or the method's default
+func mountPath(configured, defaultPath string) string {
+	if configured != "" {
+		return strings.Trim(configured, "/")
+	}
+	return defaultPath
+}
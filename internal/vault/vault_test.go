@@ -0,0 +1,135 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// fakeVault serves the subset of the Vault API used by the client
+type fakeVault struct {
+	mutex    sync.Mutex
+	password string
+	logins   int
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		f.logins++
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"s.test","lease_duration":3600,"renewable":true}}`))
+	case "/v1/secret/data/haproxy":
+		if r.Header.Get("X-Vault-Token") != "s.test" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     map[string]interface{}{"username": "dataplane", "password": f.password},
+				"metadata": map[string]interface{}{"version": 1},
+			},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+	}
+}
+
+func newTestSettings(address string) config.VaultSettings {
+	return config.VaultSettings{
+		Address: address,
+		Auth: config.VaultAuthSettings{
+			Method:   "approle",
+			RoleID:   "role",
+			SecretID: "secret",
+		},
+		RefreshIntervalSeconds: 300,
+		HAProxyCredentials: config.VaultSecretSettings{
+			Path:        "secret/data/haproxy",
+			UsernameKey: "username",
+			PasswordKey: "password",
+		},
+	}
+}
+
+func TestWatcherRefreshCredentials(t *testing.T) {
+	fake := &fakeVault{password: "first"}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	settings := newTestSettings(srv.URL)
+	client, err := NewClient(settings)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	watcher := NewWatcher(client, settings)
+	var notified []Credentials
+	watcher.OnCredentialsChange(func(c Credentials) {
+		notified = append(notified, c)
+	})
+
+	ctx := context.Background()
+	if err := watcher.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if got := watcher.Credentials(); got.Username != "dataplane" || got.Password != "first" {
+		t.Errorf("Unexpected credentials: %+v", got)
+	}
+
+	// Unchanged secrets must not trigger the callback again
+	if err := watcher.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if len(notified) != 1 {
+		t.Errorf("Expected 1 notification, got %d", len(notified))
+	}
+
+	fake.mutex.Lock()
+	fake.password = "rotated"
+	fake.mutex.Unlock()
+
+	if err := watcher.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if len(notified) != 2 || notified[1].Password != "rotated" {
+		t.Errorf("Expected rotated credentials to be notified, got %+v", notified)
+	}
+
+	// The token is valid for an hour, so no further logins are needed
+	if fake.logins != 1 {
+		t.Errorf("Expected 1 login, got %d", fake.logins)
+	}
+}
+
+func TestClientLoginFailure(t *testing.T) {
+	srv := httptest.NewServer(&fakeVault{})
+	defer srv.Close()
+
+	settings := newTestSettings(srv.URL)
+	settings.Auth.SecretID = "wrong"
+
+	client, err := NewClient(settings)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if err := client.Login(context.Background()); err == nil {
+		t.Error("Expected login with invalid secret ID to fail")
+	}
+}
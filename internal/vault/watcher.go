@@ -0,0 +1,258 @@
+package vault
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// minRefreshDelay keeps a failing Vault from being polled in a tight loop
+const minRefreshDelay = 10 * time.Second
+
+// Credentials are the Data Plane API credentials read from Vault
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Watcher fetches secrets from Vault and keeps them fresh, renewing or
+// re-acquiring its own token and re-issuing certificates before they expire
+type Watcher struct {
+	client   *Client
+	settings config.VaultSettings
+
+	mutex         sync.RWMutex
+	credentials   Credentials
+	certificate   *tls.Certificate
+	certRenewAt   time.Time
+	onCredentials func(Credentials)
+}
+
+// NewWatcher creates a Watcher for the secrets configured in settings
+func NewWatcher(client *Client, settings config.VaultSettings) *Watcher {
+	return &Watcher{
+		client:   client,
+		settings: settings,
+	}
+}
+
+// OnCredentialsChange registers a callback invoked whenever refreshed credentials differ from the previous ones
+func (w *Watcher) OnCredentialsChange(fn func(Credentials)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.onCredentials = fn
+}
+
+// Credentials returns the most recently fetched Data Plane API credentials
+func (w *Watcher) Credentials() Credentials {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.credentials
+}
+
+// GetCertificate returns the current gRPC server certificate; it is suitable for tls.Config.GetCertificate
+func (w *Watcher) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	if w.certificate == nil {
+		return nil, fmt.Errorf("no certificate has been loaded from vault")
+	}
+	return w.certificate, nil
+}
+
+// Refresh logs in if necessary and fetches every configured secret once
+func (w *Watcher) Refresh(ctx context.Context) error {
+	if err := w.ensureToken(ctx); err != nil {
+		return err
+	}
+
+	if w.settings.HAProxyCredentials.Path != "" {
+		if err := w.refreshCredentials(ctx); err != nil {
+			return err
+		}
+	}
+
+	if w.settings.GRPCTLS.Path != "" {
+		w.mutex.RLock()
+		due := w.certificate == nil || w.settings.GRPCTLS.CommonName == "" || !time.Now().Before(w.certRenewAt)
+		w.mutex.RUnlock()
+
+		if due {
+			if err := w.refreshCertificate(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run refreshes secrets periodically until ctx is cancelled
+func (w *Watcher) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(w.nextDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := w.Refresh(ctx); err != nil {
+			logger.GetLogger().Error("Failed to refresh secrets from vault, keeping previous values",
+				zap.String("address", w.settings.Address),
+				zap.Error(err))
+		}
+	}
+}
+
+// ensureToken logs in when there is no usable token and renews tokens approaching expiry
+func (w *Watcher) ensureToken(ctx context.Context) error {
+	expiry, renewable := w.client.TokenExpiry()
+	hasToken := w.client.hasToken()
+
+	if hasToken && (expiry.IsZero() || time.Until(expiry) > 2*w.refreshInterval()) {
+		return nil
+	}
+
+	if hasToken && renewable && time.Now().Before(expiry) {
+		err := w.client.RenewToken(ctx)
+		if err == nil {
+			return nil
+		}
+		logger.GetLogger().Warn("Failed to renew vault token, logging in again",
+			zap.Error(err))
+	}
+
+	return w.client.Login(ctx)
+}
+
+// refreshCredentials reads the Data Plane API credentials and notifies the callback on change
+func (w *Watcher) refreshCredentials(ctx context.Context) error {
+	settings := w.settings.HAProxyCredentials
+
+	secret, err := w.client.Read(ctx, settings.Path)
+	if err != nil {
+		return err
+	}
+
+	username, err := secret.String(settings.UsernameKey)
+	if err != nil {
+		return fmt.Errorf("invalid HAProxy credentials at %s: %w", settings.Path, err)
+	}
+	password, err := secret.String(settings.PasswordKey)
+	if err != nil {
+		return fmt.Errorf("invalid HAProxy credentials at %s: %w", settings.Path, err)
+	}
+
+	credentials := Credentials{Username: username, Password: password}
+
+	w.mutex.Lock()
+	changed := w.credentials != credentials
+	w.credentials = credentials
+	callback := w.onCredentials
+	w.mutex.Unlock()
+
+	if changed && callback != nil {
+		logger.GetLogger().Info("HAProxy Data Plane API credentials updated from vault",
+			zap.String("path", settings.Path))
+		callback(credentials)
+	}
+
+	return nil
+}
+
+// refreshCertificate reads or issues the gRPC server certificate
+func (w *Watcher) refreshCertificate(ctx context.Context) error {
+	settings := w.settings.GRPCTLS
+
+	var secret *Secret
+	var err error
+	if settings.CommonName != "" {
+		request := map[string]interface{}{
+			"common_name": settings.CommonName,
+		}
+		if settings.TTL != "" {
+			request["ttl"] = settings.TTL
+		}
+		secret, err = w.client.Write(ctx, settings.Path, request)
+	} else {
+		secret, err = w.client.Read(ctx, settings.Path)
+	}
+	if err != nil {
+		return err
+	}
+
+	certPEM, err := secret.String(settings.CertificateKey)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC TLS certificate at %s: %w", settings.Path, err)
+	}
+	keyPEM, err := secret.String(settings.PrivateKeyKey)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC TLS certificate at %s: %w", settings.Path, err)
+	}
+
+	certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return fmt.Errorf("failed to parse gRPC TLS certificate at %s: %w", settings.Path, err)
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse gRPC TLS certificate at %s: %w", settings.Path, err)
+	}
+	certificate.Leaf = leaf
+
+	// Re-issue once two thirds of the certificate's lifetime has passed
+	lifetime := leaf.NotAfter.Sub(leaf.NotBefore)
+
+	w.mutex.Lock()
+	w.certificate = &certificate
+	w.certRenewAt = leaf.NotBefore.Add(lifetime * 2 / 3)
+	w.mutex.Unlock()
+
+	logger.GetLogger().Info("gRPC TLS certificate loaded from vault",
+		zap.String("path", settings.Path),
+		zap.String("subject", leaf.Subject.String()),
+		zap.Time("not_after", leaf.NotAfter))
+
+	return nil
+}
+
+// nextDelay returns how long to wait before the next refresh
+func (w *Watcher) nextDelay() time.Duration {
+	delay := w.refreshInterval()
+
+	if expiry, _ := w.client.TokenExpiry(); !expiry.IsZero() {
+		if untilRenewal := time.Until(expiry) / 2; untilRenewal < delay {
+			delay = untilRenewal
+		}
+	}
+
+	w.mutex.RLock()
+	if w.certificate != nil && w.settings.GRPCTLS.CommonName != "" {
+		if untilRenewal := time.Until(w.certRenewAt); untilRenewal < delay {
+			delay = untilRenewal
+		}
+	}
+	w.mutex.RUnlock()
+
+	if delay < minRefreshDelay {
+		delay = minRefreshDelay
+	}
+	return delay
+}
+
+// refreshInterval returns the configured secret refresh interval
+func (w *Watcher) refreshInterval() time.Duration {
+	if w.settings.RefreshIntervalSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(w.settings.RefreshIntervalSeconds) * time.Second
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"text/template"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// signatureHeader carries the HMAC-SHA256 of the body, keyed with the secret of the webhook
+const signatureHeader = "X-Webhook-Signature"
+
+// httpNotifier POSTs events to an HTTP endpoint, as JSON or rendered from a template
+type httpNotifier struct {
+	url      string
+	headers  map[string]string
+	secret   string
+	template *template.Template
+	client   *http.Client
+}
+
+// newHTTPNotifier creates a generic webhook notifier
+func newHTTPNotifier(settings config.WebhookSettings, client *http.Client) (Notifier, error) {
+	notifier := &httpNotifier{url: settings.URL, headers: settings.Headers, secret: settings.Secret, client: client}
+	if settings.Template != "" {
+		tmpl, err := template.New("webhook").Parse(settings.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template for webhook %s: %w", settings.URL, err)
+		}
+		notifier.template = tmpl
+	}
+	return notifier, nil
+}
+
+// Notify renders the event body and POSTs it
+func (n *httpNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := n.renderBody(event)
+	if err != nil {
+		return err
+	}
+	headers := n.headers
+	if n.secret != "" {
+		headers = make(map[string]string, len(n.headers)+1)
+		for key, value := range n.headers {
+			headers[key] = value
+		}
+		headers[signatureHeader] = Sign(n.secret, body)
+	}
+	return post(ctx, n.client, n.url, headers, body)
+}
+
+// Sign returns the signature of a webhook body sent in the X-Webhook-Signature header, "sha256=" followed by the
+// hex encoded HMAC-SHA256 of the body keyed with secret. Receivers compute it over the raw body and compare it
+// with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// renderBody produces the request body from the template, or the JSON encoded event by default
+func (n *httpNotifier) renderBody(event Event) ([]byte, error) {
+	if n.template == nil {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event: %w", err)
+		}
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends a JSON body to url and fails on non-2xx responses
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		// The URL is left out, as the URLs of Slack webhooks are secrets
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode/100 != 2 {
+		return &statusError{code: res.StatusCode}
+	}
+	return nil
+}
+
+// statusError is the error of a delivery the endpoint answered with a non-2xx status
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.code)
+}
+
+// retryable reports whether a failed delivery may succeed when retried. Client errors other than timeouts and
+// rate limits are rejections of the request itself.
+func retryable(err error) bool {
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.code/100 != 4 || statusErr.code == http.StatusRequestTimeout || statusErr.code == http.StatusTooManyRequests
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+)
+
+// slackNotifier posts events to a Slack incoming webhook
+type slackNotifier struct {
+	url      string
+	channel  string
+	username string
+	template *template.Template
+	client   *http.Client
+}
+
+// slackMessage is the payload of a Slack incoming webhook
+type slackMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// newSlackNotifier creates a notifier for a Slack incoming webhook
+func newSlackNotifier(settings config.WebhookSettings, client *http.Client) (Notifier, error) {
+	notifier := &slackNotifier{url: settings.URL, channel: settings.Channel, username: settings.Username, client: client}
+	if settings.Template != "" {
+		tmpl, err := template.New("slack").Parse(settings.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template for Slack webhook: %w", err)
+		}
+		notifier.template = tmpl
+	}
+	return notifier, nil
+}
+
+// Notify posts the event as a message, its text rendered from the template or summarizing the event
+func (n *slackNotifier) Notify(ctx context.Context, event Event) error {
+	message := slackMessage{Text: slackText(event), Channel: n.channel, Username: n.username}
+	if n.template != nil {
+		var buf bytes.Buffer
+		if err := n.template.Execute(&buf, event); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		message.Text = buf.String()
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+	return post(ctx, n.client, n.url, nil, body)
+}
+
+// slackText summarizes an event in Slack markup, e.g. "*transaction_failed*: HAProxy transaction commit failed"
+func slackText(event Event) string {
+	text := fmt.Sprintf("*%s*: %s", event.Type, event.Message)
+	if event.TransactionID != "" {
+		text += fmt.Sprintf(" (transaction `%s`)", event.TransactionID)
+	}
+	if event.Error != "" {
+		text += fmt.Sprintf("\n> %s", event.Error)
+	}
+	return text
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Event types that can trigger webhooks
+const (
+	EventTransactionCommitted = "transaction_committed"
+	EventTransactionFailed    = "transaction_failed"
+	EventRollback             = "rollback"
+	EventNetplanFailed        = "netplan_failed"
+	EventReplicationFailed    = "replication_failed"
+	EventDriftDetected        = "drift_detected"
+)
+
+// defaultTimeout is used for webhook requests when no timeout is configured
+const defaultTimeout = 10 * time.Second
+
+// retryBackoff is the wait before the first retry of a failed delivery, doubled for every further retry
+var retryBackoff = time.Second
+
+// Event is the payload delivered to webhooks
+type Event struct {
+	Type          string    `json:"type"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Message       string    `json:"message"`
+	Error         string    `json:"error,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Notifier delivers events to a single destination, e.g. a Slack channel
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// notifierTypes creates the notifier for each webhook type; the empty type is a generic HTTP POST
+var notifierTypes = map[string]func(settings config.WebhookSettings, client *http.Client) (Notifier, error){
+	"":      newHTTPNotifier,
+	"http":  newHTTPNotifier,
+	"slack": newSlackNotifier,
+}
+
+// subscription is a configured notifier with the event types it receives
+type subscription struct {
+	notifier Notifier
+	name     string
+	events   map[string]bool
+	timeout  time.Duration
+	retries  int
+}
+
+// Dispatcher delivers events to all notifiers subscribed to them
+type Dispatcher struct {
+	subscriptions []subscription
+}
+
+// NewDispatcher creates a dispatcher for the configured webhooks
+func NewDispatcher(settings []config.WebhookSettings) (*Dispatcher, error) {
+	client := &http.Client{}
+	dispatcher := &Dispatcher{}
+
+	for i, s := range settings {
+		newNotifier, ok := notifierTypes[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q for webhook %d", s.Type, i)
+		}
+		notifier, err := newNotifier(s, client)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.Add(notifier, displayURL(s), s.Events, time.Duration(s.TimeoutSeconds)*time.Second, s.MaxRetries)
+	}
+
+	return dispatcher, nil
+}
+
+// displayURL identifies a webhook in logs. The path of Slack webhooks and the query of any webhook are left
+// out, as they carry tokens.
+func displayURL(settings config.WebhookSettings) string {
+	u, err := url.Parse(settings.URL)
+	if err != nil {
+		return settings.Type + " webhook"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	if settings.Type == "slack" {
+		u.Path = ""
+	}
+	return u.String()
+}
+
+// Add subscribes a notifier to the given event types, or to every event type if none are given. Each delivery
+// attempt is cancelled after timeout, or the default timeout of 10 seconds, and a failed delivery is attempted
+// up to retries more times. The name identifies the notifier in logs.
+func (d *Dispatcher) Add(notifier Notifier, name string, eventTypes []string, timeout time.Duration, retries int) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	s := subscription{
+		notifier: notifier,
+		name:     name,
+		events:   make(map[string]bool),
+		timeout:  timeout,
+		retries:  retries,
+	}
+	for _, eventType := range eventTypes {
+		s.events[eventType] = true
+	}
+	d.subscriptions = append(d.subscriptions, s)
+}
+
+// Notify delivers the event asynchronously to every notifier subscribed to its type.
+// Delivery failures are logged and never block the caller.
+func (d *Dispatcher) Notify(event Event) {
+	if d == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	event.Message = logger.Redact(event.Message)
+	event.Error = logger.Redact(event.Error)
+
+	for _, s := range d.subscriptions {
+		if len(s.events) > 0 && !s.events[event.Type] {
+			continue
+		}
+
+		go func(s subscription) {
+			if err := s.deliver(event); err != nil {
+				logger.GetLogger().Warn("Failed to deliver webhook",
+					zap.String("webhook", s.name),
+					zap.String("event_type", event.Type),
+					zap.String("transaction_id", event.TransactionID),
+					zap.Error(err))
+				return
+			}
+			logger.GetLogger().Debug("Delivered webhook",
+				zap.String("webhook", s.name),
+				zap.String("event_type", event.Type))
+		}(s)
+	}
+}
+
+// deliver sends the event to the notifier, retrying failures that may be transient with exponential backoff
+func (s subscription) deliver(event Event) error {
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		err := s.notifier.Notify(ctx, event)
+		cancel()
+		if err == nil || attempt >= s.retries || !retryable(err) {
+			return err
+		}
+
+		logger.GetLogger().Debug("Retrying webhook",
+			zap.String("webhook", s.name),
+			zap.String("event_type", event.Type),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,627 @@
+package fakedataplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bear-san/haproxy-configurator/internal/dataplane"
+	v3 "github.com/bear-san/haproxy-go/dataplane/v3"
+)
+
+// Client is an in-memory Data Plane API client working on the state of a fake without HTTP. It implements the
+// client interface of the gRPC service, so that the service can be tested without a listening API:
+//
+//	fake := fakedataplane.New()
+//	service := server.NewHAProxyManagerServerWithClients(cfg, map[string]server.DataplaneClient{
+//		config.DefaultInstance: fake.Client(config.DefaultInstance),
+//	})
+//
+// Errors are those of the Data Plane API client, e.g. *v3.NotFoundError for unknown objects.
+type Client struct {
+	fake     *Server
+	instance string
+}
+
+// Client returns an in-memory client for the named HAProxy instance. Its requests are not authenticated, even
+// if SetCredentials was called.
+func (s *Server) Client(instance string) *Client {
+	return &Client{fake: s, instance: instance}
+}
+
+// Instance names the HAProxy instance of the client
+func (c *Client) Instance() string {
+	return c.instance
+}
+
+// ActiveURL identifies the fake in logs; there is no URL behind it
+func (c *Client) ActiveURL() string {
+	return "memory://" + c.instance
+}
+
+// GetVersion returns the committed configuration version
+func (c *Client) GetVersion(ctx context.Context) (*int, error) {
+	data, err := c.do(ctx, http.MethodGet, configurationPath+"/version", "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &version, nil
+}
+
+// GetInfo returns the version of the fake
+func (c *Client) GetInfo(ctx context.Context) (*dataplane.Info, error) {
+	return requestObject[dataplane.Info](ctx, c, http.MethodGet, "/v3/info", "", nil)
+}
+
+// CreateTransaction creates a transaction based on the given version
+func (c *Client) CreateTransaction(ctx context.Context, version int) (*v3.Transaction, error) {
+	return requestObject[v3.Transaction](ctx, c, http.MethodPost, transactionsPath+"?version="+strconv.Itoa(version), "", nil)
+}
+
+// GetTransaction retrieves an open transaction
+func (c *Client) GetTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	return requestObject[v3.Transaction](ctx, c, http.MethodGet, transactionsPath+"/"+url.PathEscape(id), "", nil)
+}
+
+// ListTransactions lists the open transactions
+func (c *Client) ListTransactions(ctx context.Context) ([]v3.Transaction, error) {
+	return requestList[v3.Transaction](ctx, c, transactionsPath, "")
+}
+
+// CommitTransaction commits a transaction
+func (c *Client) CommitTransaction(ctx context.Context, id string) (*v3.Transaction, error) {
+	return requestObject[v3.Transaction](ctx, c, http.MethodPut, transactionsPath+"/"+url.PathEscape(id), "", nil)
+}
+
+// CloseTransaction discards a transaction
+func (c *Client) CloseTransaction(ctx context.Context, id string) (*string, error) {
+	data, err := c.do(ctx, http.MethodDelete, transactionsPath+"/"+url.PathEscape(id), "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	message := string(data)
+	return &message, nil
+}
+
+// GetGlobal retrieves the global section
+func (c *Client) GetGlobal(ctx context.Context, transactionId string) (*dataplane.Global, error) {
+	return requestObject[dataplane.Global](ctx, c, http.MethodGet, configPath("global"), transactionId, nil)
+}
+
+// ReplaceGlobal replaces the global section
+func (c *Client) ReplaceGlobal(ctx context.Context, global dataplane.Global, transactionId string) (*dataplane.Global, error) {
+	return requestObject[dataplane.Global](ctx, c, http.MethodPut, configPath("global"), transactionId, global)
+}
+
+// AddBackend creates a backend
+func (c *Client) AddBackend(ctx context.Context, backend dataplane.Backend, transactionId string) (*dataplane.Backend, error) {
+	return requestObject[dataplane.Backend](ctx, c, http.MethodPost, configPath("backends"), transactionId, backend)
+}
+
+// GetBackend retrieves a backend by name
+func (c *Client) GetBackend(ctx context.Context, name string, transactionId string) (*dataplane.Backend, error) {
+	return requestObject[dataplane.Backend](ctx, c, http.MethodGet, configPath("backends", name), transactionId, nil)
+}
+
+// ListBackends lists all backends
+func (c *Client) ListBackends(ctx context.Context, transactionId string) ([]dataplane.Backend, error) {
+	return requestList[dataplane.Backend](ctx, c, configPath("backends"), transactionId)
+}
+
+// ReplaceBackend replaces a backend
+func (c *Client) ReplaceBackend(ctx context.Context, name string, backend dataplane.Backend, transactionId string) (*dataplane.Backend, error) {
+	return requestObject[dataplane.Backend](ctx, c, http.MethodPut, configPath("backends", name), transactionId, backend)
+}
+
+// DeleteBackend deletes a backend
+func (c *Client) DeleteBackend(ctx context.Context, name string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("backends", name), transactionId, "", nil)
+	return err
+}
+
+// EachBackend calls fn for every backend in order, stopping at the first error
+func (c *Client) EachBackend(ctx context.Context, transactionId string, fn func(dataplane.Backend) error) error {
+	backends, err := c.ListBackends(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+	for _, backend := range backends {
+		if err := fn(backend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddFrontend creates a frontend
+func (c *Client) AddFrontend(ctx context.Context, frontend dataplane.Frontend, transactionId string) (*dataplane.Frontend, error) {
+	return requestObject[dataplane.Frontend](ctx, c, http.MethodPost, configPath("frontends"), transactionId, frontend)
+}
+
+// GetFrontend retrieves a frontend by name
+func (c *Client) GetFrontend(ctx context.Context, name string, transactionId string) (*dataplane.Frontend, error) {
+	return requestObject[dataplane.Frontend](ctx, c, http.MethodGet, configPath("frontends", name), transactionId, nil)
+}
+
+// ListFrontends lists all frontends
+func (c *Client) ListFrontends(ctx context.Context, transactionId string) ([]dataplane.Frontend, error) {
+	return requestList[dataplane.Frontend](ctx, c, configPath("frontends"), transactionId)
+}
+
+// ReplaceFrontend replaces a frontend
+func (c *Client) ReplaceFrontend(ctx context.Context, name string, frontend dataplane.Frontend, transactionId string) (*dataplane.Frontend, error) {
+	return requestObject[dataplane.Frontend](ctx, c, http.MethodPut, configPath("frontends", name), transactionId, frontend)
+}
+
+// DeleteFrontend deletes a frontend
+func (c *Client) DeleteFrontend(ctx context.Context, name string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("frontends", name), transactionId, "", nil)
+	return err
+}
+
+// AddBind creates a bind on a frontend
+func (c *Client) AddBind(ctx context.Context, frontend string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error) {
+	return requestObject[dataplane.Bind](ctx, c, http.MethodPost, configPath("frontends", frontend, "binds"), transactionId, bind)
+}
+
+// GetBind retrieves a bind of a frontend by name
+func (c *Client) GetBind(ctx context.Context, name string, frontend string, transactionId string) (*dataplane.Bind, error) {
+	return requestObject[dataplane.Bind](ctx, c, http.MethodGet, configPath("frontends", frontend, "binds", name), transactionId, nil)
+}
+
+// ListBinds lists the binds of a frontend
+func (c *Client) ListBinds(ctx context.Context, frontend string, transactionId string) ([]dataplane.Bind, error) {
+	return requestList[dataplane.Bind](ctx, c, configPath("frontends", frontend, "binds"), transactionId)
+}
+
+// ReplaceBind replaces the bind of a frontend with the same name
+func (c *Client) ReplaceBind(ctx context.Context, frontend string, transactionId string, bind dataplane.Bind) (*dataplane.Bind, error) {
+	return requestObject[dataplane.Bind](ctx, c, http.MethodPut, configPath("frontends", frontend, "binds", deref(bind.Name)), transactionId, bind)
+}
+
+// DeleteBind deletes a bind from a frontend
+func (c *Client) DeleteBind(ctx context.Context, name string, frontend string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("frontends", frontend, "binds", name), transactionId, "", nil)
+	return err
+}
+
+// AddServer creates a server in a backend
+func (c *Client) AddServer(ctx context.Context, backend string, transactionId string, server dataplane.Server) (*dataplane.Server, error) {
+	return requestObject[dataplane.Server](ctx, c, http.MethodPost, configPath("backends", backend, "servers"), transactionId, server)
+}
+
+// GetServer retrieves a server of a backend by name
+func (c *Client) GetServer(ctx context.Context, name string, backend string, transactionId string) (*dataplane.Server, error) {
+	return requestObject[dataplane.Server](ctx, c, http.MethodGet, configPath("backends", backend, "servers", name), transactionId, nil)
+}
+
+// ListServers lists the servers of a backend
+func (c *Client) ListServers(ctx context.Context, backend string, transactionId string) ([]dataplane.Server, error) {
+	return requestList[dataplane.Server](ctx, c, configPath("backends", backend, "servers"), transactionId)
+}
+
+// ReplaceServer replaces the server of a backend with the same name
+func (c *Client) ReplaceServer(ctx context.Context, backend string, transactionId string, server dataplane.Server) (*dataplane.Server, error) {
+	return requestObject[dataplane.Server](ctx, c, http.MethodPut, configPath("backends", backend, "servers", deref(server.Name)), transactionId, server)
+}
+
+// DeleteServer deletes a server from a backend
+func (c *Client) DeleteServer(ctx context.Context, name string, backend string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("backends", backend, "servers", name), transactionId, "", nil)
+	return err
+}
+
+// EachServer calls fn for every server of a backend in order, stopping at the first error
+func (c *Client) EachServer(ctx context.Context, backend string, transactionId string, fn func(dataplane.Server) error) error {
+	servers, err := c.ListServers(ctx, backend, transactionId)
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if err := fn(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListACLs lists the ACLs of a frontend in order
+func (c *Client) ListACLs(ctx context.Context, frontend string, transactionId string) ([]dataplane.ACL, error) {
+	return requestList[dataplane.ACL](ctx, c, configPath("frontends", frontend, "acls"), transactionId)
+}
+
+// AddACL inserts an ACL into a frontend at the given position
+func (c *Client) AddACL(ctx context.Context, frontend string, transactionId string, index int, acl dataplane.ACL) (*dataplane.ACL, error) {
+	return requestObject[dataplane.ACL](ctx, c, http.MethodPost, rulePath(frontend, "acls", index), transactionId, acl)
+}
+
+// DeleteACL deletes the ACL of a frontend at the given position
+func (c *Client) DeleteACL(ctx context.Context, frontend string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, rulePath(frontend, "acls", index), transactionId, "", nil)
+	return err
+}
+
+// ListBackendSwitchingRules lists the use_backend rules of a frontend in order
+func (c *Client) ListBackendSwitchingRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.BackendSwitchingRule, error) {
+	return requestList[dataplane.BackendSwitchingRule](ctx, c, configPath("frontends", frontend, "backend_switching_rules"), transactionId)
+}
+
+// AddBackendSwitchingRule inserts a use_backend rule into a frontend at the given position
+func (c *Client) AddBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error) {
+	return requestObject[dataplane.BackendSwitchingRule](ctx, c, http.MethodPost, rulePath(frontend, "backend_switching_rules", index), transactionId, rule)
+}
+
+// ReplaceBackendSwitchingRule replaces the use_backend rule of a frontend at the given position
+func (c *Client) ReplaceBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.BackendSwitchingRule) (*dataplane.BackendSwitchingRule, error) {
+	return requestObject[dataplane.BackendSwitchingRule](ctx, c, http.MethodPut, rulePath(frontend, "backend_switching_rules", index), transactionId, rule)
+}
+
+// DeleteBackendSwitchingRule deletes the use_backend rule of a frontend at the given position
+func (c *Client) DeleteBackendSwitchingRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, rulePath(frontend, "backend_switching_rules", index), transactionId, "", nil)
+	return err
+}
+
+// ListHTTPRequestRules lists the http-request rules of a frontend in order
+func (c *Client) ListHTTPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.HTTPRequestRule, error) {
+	return requestList[dataplane.HTTPRequestRule](ctx, c, configPath("frontends", frontend, "http_request_rules"), transactionId)
+}
+
+// AddHTTPRequestRule inserts an http-request rule into a frontend at the given position
+func (c *Client) AddHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.HTTPRequestRule) (*dataplane.HTTPRequestRule, error) {
+	return requestObject[dataplane.HTTPRequestRule](ctx, c, http.MethodPost, rulePath(frontend, "http_request_rules", index), transactionId, rule)
+}
+
+// DeleteHTTPRequestRule deletes the http-request rule of a frontend at the given position
+func (c *Client) DeleteHTTPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, rulePath(frontend, "http_request_rules", index), transactionId, "", nil)
+	return err
+}
+
+// ListTCPRequestRules lists the tcp-request rules of a frontend in order
+func (c *Client) ListTCPRequestRules(ctx context.Context, frontend string, transactionId string) ([]dataplane.TCPRequestRule, error) {
+	return requestList[dataplane.TCPRequestRule](ctx, c, configPath("frontends", frontend, "tcp_request_rules"), transactionId)
+}
+
+// AddTCPRequestRule inserts a tcp-request rule into a frontend at the given position
+func (c *Client) AddTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int, rule dataplane.TCPRequestRule) (*dataplane.TCPRequestRule, error) {
+	return requestObject[dataplane.TCPRequestRule](ctx, c, http.MethodPost, rulePath(frontend, "tcp_request_rules", index), transactionId, rule)
+}
+
+// DeleteTCPRequestRule deletes the tcp-request rule of a frontend at the given position
+func (c *Client) DeleteTCPRequestRule(ctx context.Context, frontend string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, rulePath(frontend, "tcp_request_rules", index), transactionId, "", nil)
+	return err
+}
+
+// AddRing creates a ring section
+func (c *Client) AddRing(ctx context.Context, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error) {
+	return requestObject[dataplane.Ring](ctx, c, http.MethodPost, configPath("rings"), transactionId, ring)
+}
+
+// GetRing retrieves a ring section by name
+func (c *Client) GetRing(ctx context.Context, name string, transactionId string) (*dataplane.Ring, error) {
+	return requestObject[dataplane.Ring](ctx, c, http.MethodGet, configPath("rings", name), transactionId, nil)
+}
+
+// ListRings lists the ring sections
+func (c *Client) ListRings(ctx context.Context, transactionId string) ([]dataplane.Ring, error) {
+	return requestList[dataplane.Ring](ctx, c, configPath("rings"), transactionId)
+}
+
+// ReplaceRing replaces a ring section
+func (c *Client) ReplaceRing(ctx context.Context, name string, ring dataplane.Ring, transactionId string) (*dataplane.Ring, error) {
+	return requestObject[dataplane.Ring](ctx, c, http.MethodPut, configPath("rings", name), transactionId, ring)
+}
+
+// DeleteRing deletes a ring section
+func (c *Client) DeleteRing(ctx context.Context, name string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("rings", name), transactionId, "", nil)
+	return err
+}
+
+// ListLogTargets lists the log targets of a frontend or backend in order
+func (c *Client) ListLogTargets(ctx context.Context, parentType, parent string, transactionId string) ([]dataplane.LogTarget, error) {
+	return requestList[dataplane.LogTarget](ctx, c, configPath(parentType+"s", parent, "log_targets"), transactionId)
+}
+
+// AddLogTarget inserts a log target into a frontend or backend at the given position
+func (c *Client) AddLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int, target dataplane.LogTarget) (*dataplane.LogTarget, error) {
+	return requestObject[dataplane.LogTarget](ctx, c, http.MethodPost, configPath(parentType+"s", parent, "log_targets", strconv.Itoa(index)), transactionId, target)
+}
+
+// DeleteLogTarget deletes the log target of a frontend or backend at the given position
+func (c *Client) DeleteLogTarget(ctx context.Context, parentType, parent string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath(parentType+"s", parent, "log_targets", strconv.Itoa(index)), transactionId, "", nil)
+	return err
+}
+
+// ListHTTPChecks lists the http-check rules of a backend in order
+func (c *Client) ListHTTPChecks(ctx context.Context, backend string, transactionId string) ([]dataplane.HTTPCheck, error) {
+	return requestList[dataplane.HTTPCheck](ctx, c, configPath("backends", backend, "http_checks"), transactionId)
+}
+
+// AddHTTPCheck inserts an http-check rule into a backend at the given position
+func (c *Client) AddHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error) {
+	return requestObject[dataplane.HTTPCheck](ctx, c, http.MethodPost, configPath("backends", backend, "http_checks", strconv.Itoa(index)), transactionId, check)
+}
+
+// ReplaceHTTPCheck replaces the http-check rule of a backend at the given position
+func (c *Client) ReplaceHTTPCheck(ctx context.Context, backend string, transactionId string, index int, check dataplane.HTTPCheck) (*dataplane.HTTPCheck, error) {
+	return requestObject[dataplane.HTTPCheck](ctx, c, http.MethodPut, configPath("backends", backend, "http_checks", strconv.Itoa(index)), transactionId, check)
+}
+
+// DeleteHTTPCheck deletes the http-check rule of a backend at the given position
+func (c *Client) DeleteHTTPCheck(ctx context.Context, backend string, transactionId string, index int) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("backends", backend, "http_checks", strconv.Itoa(index)), transactionId, "", nil)
+	return err
+}
+
+// AddProgram creates a program section
+func (c *Client) AddProgram(ctx context.Context, program dataplane.Program, transactionId string) (*dataplane.Program, error) {
+	return requestObject[dataplane.Program](ctx, c, http.MethodPost, configPath("programs"), transactionId, program)
+}
+
+// GetProgram retrieves a program section by name
+func (c *Client) GetProgram(ctx context.Context, name string, transactionId string) (*dataplane.Program, error) {
+	return requestObject[dataplane.Program](ctx, c, http.MethodGet, configPath("programs", name), transactionId, nil)
+}
+
+// ListPrograms lists the program sections
+func (c *Client) ListPrograms(ctx context.Context, transactionId string) ([]dataplane.Program, error) {
+	return requestList[dataplane.Program](ctx, c, configPath("programs"), transactionId)
+}
+
+// ReplaceProgram replaces a program section
+func (c *Client) ReplaceProgram(ctx context.Context, name string, program dataplane.Program, transactionId string) (*dataplane.Program, error) {
+	return requestObject[dataplane.Program](ctx, c, http.MethodPut, configPath("programs", name), transactionId, program)
+}
+
+// DeleteProgram deletes a program section
+func (c *Client) DeleteProgram(ctx context.Context, name string, transactionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, configPath("programs", name), transactionId, "", nil)
+	return err
+}
+
+// AddCertificate stores a certificate, failing with a conflict if one with the same name exists
+func (c *Client) AddCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file_upload", name)
+	if err == nil {
+		_, err = file.Write([]byte(pem))
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		return nil, &v3.InternalError{Message: err.Error()}
+	}
+
+	data, err := c.do(ctx, http.MethodPost, certificatesPath, "", form.FormDataContentType(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return decode[dataplane.Certificate](data)
+}
+
+// ReplaceCertificate replaces the content of a stored certificate
+func (c *Client) ReplaceCertificate(ctx context.Context, name, pem string) (*dataplane.Certificate, error) {
+	data, err := c.do(ctx, http.MethodPut, certificatesPath+"/"+url.PathEscape(name), "", "text/plain", []byte(pem))
+	if err != nil {
+		return nil, err
+	}
+	return decode[dataplane.Certificate](data)
+}
+
+// GetCertificate retrieves a stored certificate by name
+func (c *Client) GetCertificate(ctx context.Context, name string) (*dataplane.Certificate, error) {
+	return requestObject[dataplane.Certificate](ctx, c, http.MethodGet, certificatesPath+"/"+url.PathEscape(name), "", nil)
+}
+
+// AddStorageFile stores a general file, failing with a conflict if one with the same name exists
+func (c *Client) AddStorageFile(ctx context.Context, name, content string) (*dataplane.StorageFile, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file_upload", name)
+	if err == nil {
+		_, err = file.Write([]byte(content))
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		return nil, &v3.InternalError{Message: err.Error()}
+	}
+
+	data, err := c.do(ctx, http.MethodPost, storagePath, "", form.FormDataContentType(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return decode[dataplane.StorageFile](data)
+}
+
+// GetStorageFile returns the content of a general file
+func (c *Client) GetStorageFile(ctx context.Context, name string) (string, error) {
+	data, err := c.do(ctx, http.MethodGet, storagePath+"/"+url.PathEscape(name), "", "", nil)
+	return string(data), err
+}
+
+// ListStorageFiles lists the general files
+func (c *Client) ListStorageFiles(ctx context.Context) ([]dataplane.StorageFile, error) {
+	return requestList[dataplane.StorageFile](ctx, c, storagePath, "")
+}
+
+// DeleteStorageFile deletes a general file
+func (c *Client) DeleteStorageFile(ctx context.Context, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, storagePath+"/"+url.PathEscape(name), "", "", nil)
+	return err
+}
+
+// GetStats returns the statistics of the servers with simulated traffic, see SetTraffic
+func (c *Client) GetStats(ctx context.Context) ([]dataplane.ProxyStats, error) {
+	processes, err := requestList[struct {
+		Stats []dataplane.ProxyStats `json:"stats"`
+	}](ctx, c, statsPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []dataplane.ProxyStats
+	for _, process := range processes {
+		stats = append(stats, process.Stats...)
+	}
+	return stats, nil
+}
+
+// SetServerAdminState changes the runtime administrative state of a committed server
+func (c *Client) SetServerAdminState(ctx context.Context, backend, name, state string) (*dataplane.RuntimeServer, error) {
+	path := runtimePath + "/backends/" + url.PathEscape(backend) + "/servers/" + url.PathEscape(name)
+	return requestObject[dataplane.RuntimeServer](ctx, c, http.MethodPut, path, "", dataplane.RuntimeServer{AdminState: state})
+}
+
+// ListRuntimeServers lists the runtime state of the committed servers of a backend
+func (c *Client) ListRuntimeServers(ctx context.Context, backend string) ([]dataplane.RuntimeServer, error) {
+	return requestList[dataplane.RuntimeServer](ctx, c, runtimePath+"/backends/"+url.PathEscape(backend)+"/servers", "")
+}
+
+// WatchRing calls fn for the events added to a committed ring with AddRingEvents. With follow it waits for
+// further events until ctx is done.
+func (c *Client) WatchRing(ctx context.Context, name string, follow bool, fn func(event string) error) error {
+	sent := 0
+	for {
+		c.fake.mutex.Lock()
+		if find(c.fake.config.Rings, name) == nil {
+			c.fake.mutex.Unlock()
+			return &v3.NotFoundError{Message: "ring " + name + " not found"}
+		}
+		events := append([]string(nil), c.fake.ringEvents[name][sent:]...)
+		updated := c.fake.ringUpdated
+		c.fake.mutex.Unlock()
+
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+			sent++
+		}
+		if !follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// do handles a request in-process and returns the response body. Error responses are converted to the errors
+// the Data Plane API client returns for them.
+func (c *Client) do(ctx context.Context, method, path, transactionID, contentType string, body []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if transactionID != "" {
+		path += "?transaction_id=" + url.QueryEscape(transactionID)
+	}
+
+	r := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	c.fake.mutex.Lock()
+	c.fake.route(w, r)
+	c.fake.mutex.Unlock()
+
+	data := w.Body.Bytes()
+	switch w.Code {
+	case http.StatusUnauthorized:
+		return nil, &v3.UnauthorizedError{Message: string(data)}
+	case http.StatusBadRequest:
+		return nil, &v3.BadRequestError{Message: string(data)}
+	case http.StatusNotFound:
+		return nil, &v3.NotFoundError{Message: string(data)}
+	case http.StatusConflict:
+		return nil, &v3.ConflictError{Message: string(data)}
+	}
+	if w.Code/100 != 2 {
+		return nil, &v3.UnknownError{Message: string(data), StatusCode: w.Code}
+	}
+	return data, nil
+}
+
+// requestObject sends a request with an optional JSON body and decodes a single object response
+func requestObject[T any](ctx context.Context, c *Client, method, path, transactionID string, body interface{}) (*T, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, &v3.InternalError{Message: err.Error()}
+		}
+	}
+
+	data, err := c.do(ctx, method, path, transactionID, "application/json", data)
+	if err != nil {
+		return nil, err
+	}
+	return decode[T](data)
+}
+
+// requestList reads a collection
+func requestList[T any](ctx context.Context, c *Client, path, transactionID string) ([]T, error) {
+	data, err := c.do(ctx, http.MethodGet, path, transactionID, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []T
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return list, nil
+}
+
+// decode decodes an object response; an empty body yields nil
+func decode[T any](data []byte) (*T, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, &v3.InvalidResponseError{Message: err.Error()}
+	}
+	return &value, nil
+}
+
+// configPath returns the path of a configuration object from its segments, e.g. "backends", "app"
+func configPath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return configurationPath + "/" + strings.Join(escaped, "/")
+}
+
+// rulePath returns the path of the entry of an indexed list of a frontend
+func rulePath(frontend, collection string, index int) string {
+	return configPath("frontends", frontend, collection, strconv.Itoa(index))
+}
+
+// deref returns the value of an optional name, or an empty string if unset
+func deref(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
@@ -0,0 +1,917 @@
+// Package fakedataplane is an in-memory HAProxy Data Plane API v3 for end-to-end tests. It implements
+// configuration versions, transactions, the global section, rings, programs, backends, frontends, binds,
+// servers, the ACLs and rules of frontends, the http-check rules of backends, the log targets of frontends and
+// backends and the storage of SSL certificates and general files closely enough to run the gRPC service without
+// HAProxy:
+//
+//	fake := fakedataplane.New()
+//	srv := httptest.NewServer(fake)
+//	defer srv.Close()
+//
+// Objects are stored as sent, so every field the client writes is returned on reads. Server statistics report
+// the traffic simulated with SetTraffic and the sessions simulated with SetSessions; the runtime API keeps the
+// administrative state of committed servers. Rings hold the events added with AddRingEvents.
+//
+// Client works on the same state without HTTP and can be passed to the gRPC service in place of the Data Plane
+// API client.
+package fakedataplane
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	configurationPath = "/v3/services/haproxy/configuration"
+	transactionsPath  = "/v3/services/haproxy/transactions"
+	certificatesPath  = "/v3/services/haproxy/storage/ssl_certificates"
+	storagePath       = "/v3/services/haproxy/storage/general"
+	statsPath         = "/v3/services/haproxy/stats/native"
+	runtimePath       = "/v3/services/haproxy/runtime"
+)
+
+// Directories stored certificates and general files are reported to be in
+const (
+	certificateDirectory = "/etc/haproxy/ssl/"
+	storageDirectory     = "/etc/haproxy/general/"
+)
+
+// Object is a configuration object as sent to and returned by the API
+type Object = map[string]interface{}
+
+// section is a frontend or backend together with its binds or servers
+type section struct {
+	Object   Object
+	Children []Object
+	Rules    map[string][]Object // Indexed lists of a frontend by collection, e.g. "acls"
+}
+
+// ruleCollections are the indexed lists of frontends, with the field each entry requires
+var ruleCollections = map[string]string{
+	"acls":                    "acl_name",
+	"backend_switching_rules": "name",
+	"http_request_rules":      "type",
+	"tcp_request_rules":       "type",
+	"log_targets":             "address", // Of backends as well
+	"http_checks":             "type",    // Of backends
+}
+
+// traffic is the simulated load of a server: its counters and how much each stats request advances them
+type traffic struct {
+	backend, server  string
+	requests, errors int64
+	requestsPerRead  int64
+	errorsPerRead    int64
+	sessions         []int64 // Current sessions reported by the following stats requests, the last one repeating
+}
+
+// configuration is the global section and a complete set of frontends and backends
+type configuration struct {
+	Global    Object
+	Rings     []*section
+	Programs  []*section
+	Frontends []*section
+	Backends  []*section
+}
+
+// transaction is an open transaction working on its own copy of the configuration
+type transaction struct {
+	id      string
+	version int
+	config  *configuration
+}
+
+// Server is a fake Data Plane API; it implements http.Handler
+type Server struct {
+	mutex        sync.Mutex
+	version      int
+	config       *configuration
+	transactions map[string]*transaction
+	nextID       int
+	certificates map[string]string // Content by storage name
+	files        map[string]string // Content of general files by storage name
+	traffic      []*traffic
+	adminStates  map[string]string // Runtime state by "backend/server"
+	ringEvents   map[string][]string
+	ringUpdated  chan struct{} // Closed and replaced whenever events are added to a ring
+	username     string
+	password     string
+}
+
+// New creates a fake with an empty configuration at version 1
+func New() *Server {
+	return &Server{
+		version:      1,
+		config:       &configuration{},
+		transactions: make(map[string]*transaction),
+		certificates: make(map[string]string),
+		files:        make(map[string]string),
+		adminStates:  make(map[string]string),
+		ringEvents:   make(map[string][]string),
+		ringUpdated:  make(chan struct{}),
+	}
+}
+
+// SetCredentials makes the fake require basic authentication with the given credentials
+func (s *Server) SetCredentials(username, password string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.username, s.password = username, password
+}
+
+// Version returns the committed configuration version
+func (s *Server) Version() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.version
+}
+
+// OpenTransactions returns the IDs of the transactions that are neither committed nor closed
+func (s *Server) OpenTransactions() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedKeys(s.transactions)
+}
+
+// Get returns a committed object by its path below the configuration, e.g. "backends", "app", "servers", "app1"
+func (s *Server) Get(path ...string) (Object, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sections, name, child, err := resolve(s.config, path)
+	if err != nil || name == "" {
+		return nil, false
+	}
+	parent := find(*sections, name)
+	if parent == nil {
+		return nil, false
+	}
+	if child == nil {
+		return clone(parent.Object), true
+	}
+	if *child == "" {
+		return nil, false
+	}
+	for _, object := range parent.Children {
+		if object["name"] == *child {
+			return clone(object), true
+		}
+	}
+	return nil, false
+}
+
+// Certificate returns the content of a stored certificate
+func (s *Server) Certificate(name string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	content, ok := s.certificates[name]
+	return content, ok
+}
+
+// Global returns the committed global section
+func (s *Server) Global() Object {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return clone(s.config.Global)
+}
+
+// File returns the content of a stored general file
+func (s *Server) File(name string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	content, ok := s.files[name]
+	return content, ok
+}
+
+// Rules returns the committed entries of an indexed list of a frontend in order, e.g. "http_request_rules"
+func (s *Server) Rules(frontend, collection string) []Object {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	parent := find(s.config.Frontends, frontend)
+	if parent == nil {
+		return nil
+	}
+	var rules []Object
+	for _, rule := range parent.Rules[collection] {
+		rules = append(rules, clone(rule))
+	}
+	return rules
+}
+
+// BackendRules returns the committed entries of an indexed list of a backend in order, e.g. "http_checks"
+func (s *Server) BackendRules(backend, collection string) []Object {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	parent := find(s.config.Backends, backend)
+	if parent == nil {
+		return nil
+	}
+	var rules []Object
+	for _, rule := range parent.Rules[collection] {
+		rules = append(rules, clone(rule))
+	}
+	return rules
+}
+
+// AddRingEvents appends events to a ring, as HAProxy does with the log lines and traces sent to it
+func (s *Server) AddRingEvents(ring string, events ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ringEvents[ring] = append(s.ringEvents[ring], events...)
+	close(s.ringUpdated)
+	s.ringUpdated = make(chan struct{})
+}
+
+// SetTraffic simulates load on a server: each stats request counts the given number of further HTTP requests,
+// of which errors failed with a 5xx status
+func (s *Server) SetTraffic(backend, server string, requests, errors int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t := s.serverTraffic(backend, server)
+	t.requestsPerRead, t.errorsPerRead = requests, errors
+}
+
+// SetSessions simulates the current sessions of a server: each stats request reports the next of the given
+// values, and the last one from then on
+func (s *Server) SetSessions(backend, server string, sessions ...int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.serverTraffic(backend, server).sessions = sessions
+}
+
+// AdminState returns the runtime administrative state of a server, "ready" unless it was changed
+func (s *Server) AdminState(backend, server string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.runtimeServer(backend, server)["admin_state"].(string)
+}
+
+// serverTraffic returns the simulated load of a server, adding it if there is none yet
+func (s *Server) serverTraffic(backend, server string) *traffic {
+	for _, t := range s.traffic {
+		if t.backend == backend && t.server == server {
+			return t
+		}
+	}
+	t := &traffic{backend: backend, server: server}
+	s.traffic = append(s.traffic, t)
+	return t
+}
+
+// ServeHTTP handles a Data Plane API request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.username != "" {
+		if username, password, ok := r.BasicAuth(); !ok || username != s.username || password != s.password {
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+	}
+	s.route(w, r)
+}
+
+// route handles an authenticated request; the caller holds the mutex
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/v3/info":
+		writeJSON(w, http.StatusOK, Object{"api": Object{"version": "fake"}})
+	case r.URL.Path == configurationPath+"/version":
+		_, _ = fmt.Fprintln(w, s.version)
+	case r.URL.Path == transactionsPath:
+		s.handleTransactions(w, r)
+	case strings.HasPrefix(r.URL.Path, transactionsPath+"/"):
+		s.handleTransaction(w, r, strings.TrimPrefix(r.URL.Path, transactionsPath+"/"))
+	case strings.HasPrefix(r.URL.Path, configurationPath+"/"):
+		s.handleConfiguration(w, r)
+	case r.URL.Path == statsPath && r.Method == http.MethodGet:
+		s.handleStats(w)
+	case strings.HasPrefix(r.URL.Path, runtimePath+"/"):
+		s.handleRuntime(w, r, strings.Split(strings.TrimPrefix(r.URL.Path, runtimePath+"/"), "/"))
+	case r.URL.Path == certificatesPath || strings.HasPrefix(r.URL.Path, certificatesPath+"/"):
+		s.handleCertificates(w, r, strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, certificatesPath), "/"))
+	case r.URL.Path == storagePath || strings.HasPrefix(r.URL.Path, storagePath+"/"):
+		s.handleFiles(w, r, strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, storagePath), "/"))
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+	}
+}
+
+// handleTransactions creates and lists transactions
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		list := make([]Object, 0, len(s.transactions))
+		for _, id := range sortedKeys(s.transactions) {
+			list = append(list, s.transactions[id].object("in_progress"))
+		}
+		writeJSON(w, http.StatusOK, list)
+	case http.MethodPost:
+		version, err := strconv.Atoi(r.URL.Query().Get("version"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "version is required")
+			return
+		}
+		if version != s.version {
+			writeError(w, http.StatusConflict, fmt.Sprintf("version mismatch: expected %d, got %d", s.version, version))
+			return
+		}
+		s.nextID++
+		t := &transaction{id: fmt.Sprintf("txn-%d", s.nextID), version: version, config: cloneConfiguration(s.config)}
+		s.transactions[t.id] = t
+		writeJSON(w, http.StatusCreated, t.object("in_progress"))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// handleTransaction gets, commits and closes a transaction
+func (s *Server) handleTransaction(w http.ResponseWriter, r *http.Request, id string) {
+	t, ok := s.transactions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "transaction "+id+" not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, t.object("in_progress"))
+	case http.MethodPut:
+		if t.version != s.version {
+			delete(s.transactions, id)
+			writeError(w, http.StatusConflict, fmt.Sprintf("version mismatch: transaction is based on %d, configuration is at %d", t.version, s.version))
+			return
+		}
+		s.config = t.config
+		s.version++
+		delete(s.transactions, id)
+		writeJSON(w, http.StatusOK, t.object("success"))
+	case http.MethodDelete:
+		delete(s.transactions, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// handleConfiguration reads and writes rings, programs, frontends, backends, binds and servers, within a transaction if
+// transaction_id is set and directly otherwise
+func (s *Server) handleConfiguration(w http.ResponseWriter, r *http.Request) {
+	config := s.config
+	direct := true
+	if id := r.URL.Query().Get("transaction_id"); id != "" {
+		t, ok := s.transactions[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "transaction "+id+" not found")
+			return
+		}
+		config, direct = t.config, false
+	}
+
+	var path []string
+	for _, segment := range strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), configurationPath+"/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		path = append(path, unescaped)
+	}
+	if len(path) >= 3 && (path[0] == "frontends" || path[0] == "backends") && ruleCollections[path[2]] != "" {
+		s.handleRules(w, r, config, direct, path)
+		return
+	}
+	if len(path) == 1 && path[0] == "global" {
+		s.handleGlobal(w, r, config, direct)
+		return
+	}
+	sections, name, child, err := resolve(config, path)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var body Object
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		data, err := io.ReadAll(r.Body)
+		if err == nil {
+			err = json.Unmarshal(data, &body)
+		}
+		if err != nil || body == nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		if value, _ := body["name"].(string); value == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+	}
+
+	status, response := handleObject(r.Method, sections, name, child, body)
+	if status/100 == 2 && r.Method != http.MethodGet && direct {
+		s.version++
+	}
+	if message, ok := response.(string); ok {
+		writeError(w, status, message)
+		return
+	}
+	writeJSON(w, status, response)
+}
+
+// handleRules lists an indexed list of a frontend or backend, such as its ACLs, http-request rules or log
+// targets, and inserts, reads, replaces or deletes its entries by index
+func (s *Server) handleRules(w http.ResponseWriter, r *http.Request, config *configuration, direct bool, path []string) {
+	sections := config.Frontends
+	if path[0] == "backends" {
+		sections = config.Backends
+	}
+	parent := find(sections, path[1])
+	if parent == nil {
+		writeError(w, http.StatusNotFound, path[1]+" not found")
+		return
+	}
+	if len(path) == 3 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+			return
+		}
+		rules := parent.Rules[path[2]]
+		if rules == nil {
+			rules = []Object{}
+		}
+		writeJSON(w, http.StatusOK, rules)
+		return
+	}
+	index, err := strconv.Atoi(path[3])
+	if err != nil || len(path) > 4 || index < 0 {
+		writeError(w, http.StatusBadRequest, "invalid rule index "+strings.Join(path[3:], "/"))
+		return
+	}
+
+	var body Object
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		if value, _ := body[ruleCollections[path[2]]].(string); value == "" {
+			writeError(w, http.StatusBadRequest, ruleCollections[path[2]]+" is required")
+			return
+		}
+	}
+
+	if parent.Rules == nil {
+		parent.Rules = make(map[string][]Object)
+	}
+	rules := parent.Rules[path[2]]
+	switch {
+	case r.Method == http.MethodPost && index <= len(rules):
+		parent.Rules[path[2]] = append(rules[:index], append([]Object{body}, rules[index:]...)...)
+		writeJSON(w, http.StatusCreated, body)
+	case index >= len(rules):
+		writeError(w, http.StatusNotFound, fmt.Sprintf("rule %d not found", index))
+		return
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, rules[index])
+		return
+	case r.Method == http.MethodPut:
+		rules[index] = body
+		writeJSON(w, http.StatusOK, body)
+	case r.Method == http.MethodDelete:
+		parent.Rules[path[2]] = append(rules[:index], rules[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		return
+	}
+	if direct {
+		s.version++
+	}
+}
+
+// handleGlobal reads and replaces the global section, which is empty until it is first written
+func (s *Server) handleGlobal(w http.ResponseWriter, r *http.Request, config *configuration, direct bool) {
+	switch r.Method {
+	case http.MethodGet:
+		global := config.Global
+		if global == nil {
+			global = Object{}
+		}
+		writeJSON(w, http.StatusOK, global)
+	case http.MethodPut:
+		var body Object
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		config.Global = body
+		if direct {
+			s.version++
+		}
+		writeJSON(w, http.StatusOK, body)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// handleStats reports the counters of the servers with simulated traffic, advancing them first
+func (s *Server) handleStats(w http.ResponseWriter) {
+	stats := []Object{}
+	for _, t := range s.traffic {
+		t.requests += t.requestsPerRead
+		t.errors += t.errorsPerRead
+		var sessions int64
+		if len(t.sessions) > 0 {
+			sessions = t.sessions[0]
+			if len(t.sessions) > 1 {
+				t.sessions = t.sessions[1:]
+			}
+		}
+		status := "UP"
+		if state, ok := s.adminStates[t.backend+"/"+t.server]; ok && state != "ready" {
+			status = strings.ToUpper(state)
+		}
+		stats = append(stats, Object{
+			"type":         "server",
+			"name":         t.server,
+			"backend_name": t.backend,
+			"stats":        Object{"status": status, "scur": sessions, "stot": t.requests, "req_tot": t.requests, "hrsp_5xx": t.errors},
+		})
+	}
+	writeJSON(w, http.StatusOK, []Object{{"runtimeAPI": "fake", "stats": stats}})
+}
+
+// handleRuntime lists the administrative states of the committed servers of a backend at
+// backends/{backend}/servers, and reads and changes the state of one at backends/{backend}/servers/{server}
+func (s *Server) handleRuntime(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) < 3 || len(path) > 4 || path[0] != "backends" || path[2] != "servers" {
+		writeError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+		return
+	}
+	backend := path[1]
+	parent := find(s.config.Backends, backend)
+	if len(path) == 3 {
+		if parent == nil || r.Method != http.MethodGet {
+			writeError(w, http.StatusNotFound, "backend "+backend+" not found")
+			return
+		}
+		list := []Object{}
+		for _, object := range parent.Children {
+			name, _ := object["name"].(string)
+			list = append(list, s.runtimeServer(backend, name))
+		}
+		writeJSON(w, http.StatusOK, list)
+		return
+	}
+
+	name := path[3]
+	found := false
+	if parent != nil {
+		for _, object := range parent.Children {
+			found = found || object["name"] == name
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("server %s not found in backend %s", name, backend))
+		return
+	}
+
+	key := backend + "/" + name
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var body Object
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		state, _ := body["admin_state"].(string)
+		if state != "ready" && state != "drain" && state != "maint" {
+			writeError(w, http.StatusBadRequest, "invalid admin_state "+state)
+			return
+		}
+		s.adminStates[key] = state
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.runtimeServer(backend, name))
+}
+
+// runtimeServer returns the runtime state of a server, which is ready unless it was changed
+func (s *Server) runtimeServer(backend, name string) Object {
+	state, ok := s.adminStates[backend+"/"+name]
+	if !ok {
+		state = "ready"
+	}
+	return Object{"name": name, "admin_state": state, "operational_state": "up"}
+}
+
+// handleCertificates stores, lists, reads, replaces and deletes SSL certificates. Uploads are multipart forms
+// with the certificate in file_upload and replacements send the certificate as the body, as with the Data
+// Plane API. Storage is not part of transactions or the configuration version.
+func (s *Server) handleCertificates(w http.ResponseWriter, r *http.Request, name string) {
+	_, exists := s.certificates[name]
+	switch {
+	case name == "" && r.Method == http.MethodGet:
+		list := make([]Object, 0, len(s.certificates))
+		for _, name := range sortedKeys(s.certificates) {
+			list = append(list, certificateObject(name))
+		}
+		writeJSON(w, http.StatusOK, list)
+	case name == "" && r.Method == http.MethodPost:
+		file, header, err := r.FormFile("file_upload")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "file_upload is required")
+			return
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := s.certificates[header.Filename]; ok {
+			writeError(w, http.StatusConflict, header.Filename+" already exists")
+			return
+		}
+		s.certificates[header.Filename] = string(content)
+		writeJSON(w, http.StatusCreated, certificateObject(header.Filename))
+	case name == "":
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	case !exists:
+		writeError(w, http.StatusNotFound, name+" not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, certificateObject(name))
+	case r.Method == http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil || len(content) == 0 {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		s.certificates[name] = string(content)
+		writeJSON(w, http.StatusOK, certificateObject(name))
+	case r.Method == http.MethodDelete:
+		delete(s.certificates, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// handleFiles stores, lists, reads and deletes general files. Uploads are multipart forms with the file in
+// file_upload, and reading a file returns its content, as with the Data Plane API. Storage is not part of
+// transactions or the configuration version.
+func (s *Server) handleFiles(w http.ResponseWriter, r *http.Request, name string) {
+	content, exists := s.files[name]
+	switch {
+	case name == "" && r.Method == http.MethodGet:
+		list := make([]Object, 0, len(s.files))
+		for _, name := range sortedKeys(s.files) {
+			list = append(list, fileObject(name, s.files[name]))
+		}
+		writeJSON(w, http.StatusOK, list)
+	case name == "" && r.Method == http.MethodPost:
+		file, header, err := r.FormFile("file_upload")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "file_upload is required")
+			return
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := s.files[header.Filename]; ok {
+			writeError(w, http.StatusConflict, header.Filename+" already exists")
+			return
+		}
+		s.files[header.Filename] = string(content)
+		writeJSON(w, http.StatusCreated, fileObject(header.Filename, string(content)))
+	case name == "":
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	case !exists:
+		writeError(w, http.StatusNotFound, name+" not found")
+	case r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = io.WriteString(w, content)
+	case r.Method == http.MethodDelete:
+		delete(s.files, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// fileObject returns the API representation of a stored general file
+func fileObject(name, content string) Object {
+	return Object{"storage_name": name, "file": storageDirectory + name, "size": len(content)}
+}
+
+// certificateObject returns the API representation of a stored certificate
+func certificateObject(name string) Object {
+	return Object{"storage_name": name, "file": certificateDirectory + name, "description": "managed SSL file"}
+}
+
+// handleObject applies a request to a collection of sections or to the children of one; a string response
+// is an error message
+func handleObject(method string, sections *[]*section, name string, child *string, body Object) (int, interface{}) {
+	if child == nil {
+		return handleSection(method, sections, name, body)
+	}
+
+	parent := find(*sections, name)
+	if parent == nil {
+		return http.StatusNotFound, fmt.Sprintf("%s not found", name)
+	}
+	return handleChild(method, parent, *child, body)
+}
+
+// handleSection lists, creates, reads, replaces or deletes rings, programs, frontends or backends
+func handleSection(method string, sections *[]*section, name string, body Object) (int, interface{}) {
+	existing := find(*sections, name)
+	switch {
+	case name == "" && method == http.MethodGet:
+		list := make([]Object, 0, len(*sections))
+		for _, s := range *sections {
+			list = append(list, s.Object)
+		}
+		return http.StatusOK, list
+	case name == "" && method == http.MethodPost:
+		if find(*sections, body["name"].(string)) != nil {
+			return http.StatusConflict, fmt.Sprintf("%s already exists", body["name"])
+		}
+		*sections = append(*sections, &section{Object: body})
+		return http.StatusCreated, body
+	case name == "":
+		return http.StatusMethodNotAllowed, method + " not allowed"
+	case existing == nil:
+		return http.StatusNotFound, fmt.Sprintf("%s not found", name)
+	case method == http.MethodGet:
+		return http.StatusOK, existing.Object
+	case method == http.MethodPut:
+		existing.Object = body
+		return http.StatusOK, body
+	case method == http.MethodDelete:
+		for i, s := range *sections {
+			if s == existing {
+				*sections = append((*sections)[:i], (*sections)[i+1:]...)
+				break
+			}
+		}
+		return http.StatusNoContent, nil
+	default:
+		return http.StatusMethodNotAllowed, method + " not allowed"
+	}
+}
+
+// handleChild lists, creates, reads, replaces or deletes the binds or servers of a section
+func handleChild(method string, parent *section, name string, body Object) (int, interface{}) {
+	index := -1
+	for i, object := range parent.Children {
+		if name != "" && object["name"] == name {
+			index = i
+		}
+	}
+
+	switch {
+	case name == "" && method == http.MethodGet:
+		list := parent.Children
+		if list == nil {
+			list = []Object{}
+		}
+		return http.StatusOK, list
+	case name == "" && method == http.MethodPost:
+		for _, object := range parent.Children {
+			if object["name"] == body["name"] {
+				return http.StatusConflict, fmt.Sprintf("%s already exists", body["name"])
+			}
+		}
+		parent.Children = append(parent.Children, body)
+		return http.StatusCreated, body
+	case name == "":
+		return http.StatusMethodNotAllowed, method + " not allowed"
+	case index < 0:
+		return http.StatusNotFound, fmt.Sprintf("%s not found", name)
+	case method == http.MethodGet:
+		return http.StatusOK, parent.Children[index]
+	case method == http.MethodPut:
+		parent.Children[index] = body
+		return http.StatusOK, body
+	case method == http.MethodDelete:
+		parent.Children = append(parent.Children[:index], parent.Children[index+1:]...)
+		return http.StatusNoContent, nil
+	default:
+		return http.StatusMethodNotAllowed, method + " not allowed"
+	}
+}
+
+// resolve maps a configuration path to its sections, the section name and, below a section, the child
+// name; names are empty for collections and child is nil for paths that end at a section
+func resolve(config *configuration, path []string) (sections *[]*section, name string, child *string, err error) {
+	if len(path) == 0 || len(path) > 4 {
+		return nil, "", nil, fmt.Errorf("unknown endpoint %s", strings.Join(path, "/"))
+	}
+
+	var childKind string
+	switch path[0] {
+	case "frontends":
+		sections, childKind = &config.Frontends, "binds"
+	case "backends":
+		sections, childKind = &config.Backends, "servers"
+	case "rings":
+		sections = &config.Rings
+	case "programs":
+		sections = &config.Programs
+	default:
+		return nil, "", nil, fmt.Errorf("unknown endpoint %s", strings.Join(path, "/"))
+	}
+
+	if len(path) > 1 {
+		name = path[1]
+	}
+	if len(path) > 2 {
+		if path[2] != childKind {
+			return nil, "", nil, fmt.Errorf("unknown endpoint %s", strings.Join(path, "/"))
+		}
+		childName := ""
+		if len(path) > 3 {
+			childName = path[3]
+		}
+		child = &childName
+	}
+	return sections, name, child, nil
+}
+
+// find returns the section with the given name or nil
+func find(sections []*section, name string) *section {
+	for _, s := range sections {
+		if s.Object["name"] == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// object returns the API representation of a transaction
+func (t *transaction) object(status string) Object {
+	return Object{"id": t.id, "status": status, "_version": t.version}
+}
+
+// cloneConfiguration deep copies a configuration, so that transactions do not share objects
+func cloneConfiguration(config *configuration) *configuration {
+	var copied configuration
+	data, _ := json.Marshal(config)
+	_ = json.Unmarshal(data, &copied)
+	return &copied
+}
+
+// clone deep copies an object
+func clone(object Object) Object {
+	var copied Object
+	data, _ := json.Marshal(object)
+	_ = json.Unmarshal(data, &copied)
+	return copied
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeJSON writes a JSON response; a nil value writes no body
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	if value == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error in the format of the Data Plane API
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Object{"code": status, "message": message})
+}
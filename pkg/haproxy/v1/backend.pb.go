@@ -124,11 +124,22 @@ func (x *BackendBalance) GetAlgorithm() BalanceAlgorithm {
 
 // Backend represents a HAProxy backend configuration
 type Backend struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Balance       *BackendBalance        `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the backend
-	Mode          ProxyMode              `protobuf:"varint,4,opt,name=mode,proto3,enum=haproxy.v1.ProxyMode" json:"mode,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Balance         *BackendBalance        `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the backend
+	Mode            ProxyMode              `protobuf:"varint,4,opt,name=mode,proto3,enum=haproxy.v1.ProxyMode" json:"mode,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,5,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Changes whenever the resource changes; set in responses only
+	Fullconn        int32                  `protobuf:"varint,6,opt,name=fullconn,proto3" json:"fullconn,omitempty"`                                     // Load at which servers with a minconn accept up to their maxconn; 0 leaves it to HAProxy
+	// Connection limits of servers that do not set their own (default-server); 0 leaves a limit unset
+	Maxconn  int32 `protobuf:"varint,7,opt,name=maxconn,proto3" json:"maxconn,omitempty"`
+	Minconn  int32 `protobuf:"varint,8,opt,name=minconn,proto3" json:"minconn,omitempty"`
+	Maxqueue int32 `protobuf:"varint,9,opt,name=maxqueue,proto3" json:"maxqueue,omitempty"`
+	Disabled bool  `protobuf:"varint,10,opt,name=disabled,proto3" json:"disabled,omitempty"` // Stopped whenever HAProxy loads the configuration, e.g. for persistent maintenance
+	Httpchk  bool  `protobuf:"varint,11,opt,name=httpchk,proto3" json:"httpchk,omitempty"`   // Health checks are HTTP requests (option httpchk), made by the http-check rules if any
+	// Kept in the metadata store of the configurator, as HAProxy has no place for them; see metadata.path
+	Labels        map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string `protobuf:"bytes,13,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -191,6 +202,69 @@ func (x *Backend) GetMode() ProxyMode {
 	return ProxyMode_PROXY_MODE_UNSPECIFIED
 }
 
+func (x *Backend) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *Backend) GetFullconn() int32 {
+	if x != nil {
+		return x.Fullconn
+	}
+	return 0
+}
+
+func (x *Backend) GetMaxconn() int32 {
+	if x != nil {
+		return x.Maxconn
+	}
+	return 0
+}
+
+func (x *Backend) GetMinconn() int32 {
+	if x != nil {
+		return x.Minconn
+	}
+	return 0
+}
+
+func (x *Backend) GetMaxqueue() int32 {
+	if x != nil {
+		return x.Maxqueue
+	}
+	return 0
+}
+
+func (x *Backend) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Backend) GetHttpchk() bool {
+	if x != nil {
+		return x.Httpchk
+	}
+	return false
+}
+
+func (x *Backend) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Backend) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 type CreateBackendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -291,6 +365,7 @@ type GetBackendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NoCache       bool                   `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -339,6 +414,13 @@ func (x *GetBackendRequest) GetName() string {
 	return ""
 }
 
+func (x *GetBackendRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type GetBackendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Backend       *Backend               `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
@@ -386,6 +468,9 @@ func (x *GetBackendResponse) GetBackend() *Backend {
 type ListBackendsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`  // "name" or "mode", prefixed with "-" for descending order; configuration order if empty
+	NoCache       bool                   `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -427,6 +512,27 @@ func (x *ListBackendsRequest) GetTransactionId() string {
 	return ""
 }
 
+func (x *ListBackendsRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListBackendsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListBackendsRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type ListBackendsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Backends      []*Backend             `protobuf:"bytes,1,rep,name=backends,proto3" json:"backends,omitempty"`
@@ -472,12 +578,13 @@ func (x *ListBackendsResponse) GetBackends() []*Backend {
 }
 
 type UpdateBackendRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Backend       *Backend               `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Backend         *Backend               `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateBackendRequest) Reset() {
@@ -531,6 +638,13 @@ func (x *UpdateBackendRequest) GetBackend() *Backend {
 	return nil
 }
 
+func (x *UpdateBackendRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type UpdateBackendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Backend       *Backend               `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
@@ -576,9 +690,13 @@ func (x *UpdateBackendResponse) GetBackend() *Backend {
 }
 
 type DeleteBackendRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	// Delete the backend even if frontends still refer to it through default_backend, use_backend or track-sc
+	// rules; without it such deletes fail with FAILED_PRECONDITION
+	Force         bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -627,6 +745,20 @@ func (x *DeleteBackendRequest) GetName() string {
 	return ""
 }
 
+func (x *DeleteBackendRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *DeleteBackendRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
 type DeleteBackendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -663,6 +795,216 @@ func (*DeleteBackendResponse) Descriptor() ([]byte, []int) {
 	return file_backend_proto_rawDescGZIP(), []int{11}
 }
 
+// ApplyBackend creates the backend if it does not exist and replaces it if it differs
+type ApplyBackendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Backend       *Backend               `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBackendRequest) Reset() {
+	*x = ApplyBackendRequest{}
+	mi := &file_backend_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBackendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBackendRequest) ProtoMessage() {}
+
+func (x *ApplyBackendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBackendRequest.ProtoReflect.Descriptor instead.
+func (*ApplyBackendRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApplyBackendRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ApplyBackendRequest) GetBackend() *Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+type ApplyBackendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backend       *Backend               `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // False if the backend already matched
+	Created       bool                   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"` // True if the backend did not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBackendResponse) Reset() {
+	*x = ApplyBackendResponse{}
+	mi := &file_backend_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBackendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBackendResponse) ProtoMessage() {}
+
+func (x *ApplyBackendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBackendResponse.ProtoReflect.Descriptor instead.
+func (*ApplyBackendResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ApplyBackendResponse) GetBackend() *Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+func (x *ApplyBackendResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ApplyBackendResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// StreamBackends sends the backends one at a time, in configuration order
+type StreamBackendsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBackendsRequest) Reset() {
+	*x = StreamBackendsRequest{}
+	mi := &file_backend_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBackendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBackendsRequest) ProtoMessage() {}
+
+func (x *StreamBackendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBackendsRequest.ProtoReflect.Descriptor instead.
+func (*StreamBackendsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamBackendsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *StreamBackendsRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type StreamBackendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backend       *Backend               `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBackendsResponse) Reset() {
+	*x = StreamBackendsResponse{}
+	mi := &file_backend_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBackendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBackendsResponse) ProtoMessage() {}
+
+func (x *StreamBackendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBackendsResponse.ProtoReflect.Descriptor instead.
+func (*StreamBackendsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StreamBackendsResponse) GetBackend() *Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
 var File_backend_proto protoreflect.FileDescriptor
 
 const file_backend_proto_rawDesc = "" +
@@ -670,36 +1012,71 @@ const file_backend_proto_rawDesc = "" +
 	"\rbackend.proto\x12\n" +
 	"haproxy.v1\x1a\fcommon.proto\"L\n" +
 	"\x0eBackendBalance\x12:\n" +
-	"\talgorithm\x18\x01 \x01(\x0e2\x1c.haproxy.v1.BalanceAlgorithmR\talgorithm\"\x8e\x01\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2\x1c.haproxy.v1.BalanceAlgorithmR\talgorithm\"\xd7\x04\n" +
 	"\aBackend\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x124\n" +
 	"\abalance\x18\x02 \x01(\v2\x1a.haproxy.v1.BackendBalanceR\abalance\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
-	"\x04mode\x18\x04 \x01(\x0e2\x15.haproxy.v1.ProxyModeR\x04mode\"l\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x15.haproxy.v1.ProxyModeR\x04mode\x12)\n" +
+	"\x10resource_version\x18\x05 \x01(\tR\x0fresourceVersion\x12\x1a\n" +
+	"\bfullconn\x18\x06 \x01(\x05R\bfullconn\x12\x18\n" +
+	"\amaxconn\x18\a \x01(\x05R\amaxconn\x12\x18\n" +
+	"\aminconn\x18\b \x01(\x05R\aminconn\x12\x1a\n" +
+	"\bmaxqueue\x18\t \x01(\x05R\bmaxqueue\x12\x1a\n" +
+	"\bdisabled\x18\n" +
+	" \x01(\bR\bdisabled\x12\x18\n" +
+	"\ahttpchk\x18\v \x01(\bR\ahttpchk\x127\n" +
+	"\x06labels\x18\f \x03(\v2\x1f.haproxy.v1.Backend.LabelsEntryR\x06labels\x12F\n" +
+	"\vannotations\x18\r \x03(\v2$.haproxy.v1.Backend.AnnotationsEntryR\vannotations\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
 	"\x14CreateBackendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12-\n" +
 	"\abackend\x18\x02 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"F\n" +
 	"\x15CreateBackendResponse\x12-\n" +
-	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"N\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"i\n" +
 	"\x11GetBackendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"C\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bno_cache\x18\x03 \x01(\bR\anoCache\"C\n" +
 	"\x12GetBackendResponse\x12-\n" +
-	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"<\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"\xa2\x01\n" +
 	"\x13ListBackendsRequest\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"G\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x19\n" +
+	"\bno_cache\x18\x04 \x01(\bR\anoCache\"G\n" +
 	"\x14ListBackendsResponse\x12/\n" +
-	"\bbackends\x18\x01 \x03(\v2\x13.haproxy.v1.BackendR\bbackends\"\x80\x01\n" +
+	"\bbackends\x18\x01 \x03(\v2\x13.haproxy.v1.BackendR\bbackends\"\xab\x01\n" +
 	"\x14UpdateBackendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
-	"\abackend\x18\x03 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"F\n" +
+	"\abackend\x18\x03 \x01(\v2\x13.haproxy.v1.BackendR\abackend\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"F\n" +
 	"\x15UpdateBackendResponse\x12-\n" +
-	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"Q\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"\x92\x01\n" +
 	"\x14DeleteBackendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\x17\n" +
-	"\x15DeleteBackendResponse*\xae\x01\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\tR\x0fexpectedVersion\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\"\x17\n" +
+	"\x15DeleteBackendResponse\"k\n" +
+	"\x13ApplyBackendRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12-\n" +
+	"\abackend\x18\x02 \x01(\v2\x13.haproxy.v1.BackendR\abackend\"y\n" +
+	"\x14ApplyBackendResponse\x12-\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreated\"n\n" +
+	"\x15StreamBackendsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\"G\n" +
+	"\x16StreamBackendsResponse\x12-\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend*\xae\x01\n" +
 	"\x10BalanceAlgorithm\x12!\n" +
 	"\x1dBALANCE_ALGORITHM_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17BALANCE_ALGORITHM_FIRST\x10\x01\x12\x1a\n" +
@@ -720,38 +1097,52 @@ func file_backend_proto_rawDescGZIP() []byte {
 }
 
 var file_backend_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_backend_proto_goTypes = []any{
-	(BalanceAlgorithm)(0),         // 0: haproxy.v1.BalanceAlgorithm
-	(*BackendBalance)(nil),        // 1: haproxy.v1.BackendBalance
-	(*Backend)(nil),               // 2: haproxy.v1.Backend
-	(*CreateBackendRequest)(nil),  // 3: haproxy.v1.CreateBackendRequest
-	(*CreateBackendResponse)(nil), // 4: haproxy.v1.CreateBackendResponse
-	(*GetBackendRequest)(nil),     // 5: haproxy.v1.GetBackendRequest
-	(*GetBackendResponse)(nil),    // 6: haproxy.v1.GetBackendResponse
-	(*ListBackendsRequest)(nil),   // 7: haproxy.v1.ListBackendsRequest
-	(*ListBackendsResponse)(nil),  // 8: haproxy.v1.ListBackendsResponse
-	(*UpdateBackendRequest)(nil),  // 9: haproxy.v1.UpdateBackendRequest
-	(*UpdateBackendResponse)(nil), // 10: haproxy.v1.UpdateBackendResponse
-	(*DeleteBackendRequest)(nil),  // 11: haproxy.v1.DeleteBackendRequest
-	(*DeleteBackendResponse)(nil), // 12: haproxy.v1.DeleteBackendResponse
-	(ProxyMode)(0),                // 13: haproxy.v1.ProxyMode
+	(BalanceAlgorithm)(0),          // 0: haproxy.v1.BalanceAlgorithm
+	(*BackendBalance)(nil),         // 1: haproxy.v1.BackendBalance
+	(*Backend)(nil),                // 2: haproxy.v1.Backend
+	(*CreateBackendRequest)(nil),   // 3: haproxy.v1.CreateBackendRequest
+	(*CreateBackendResponse)(nil),  // 4: haproxy.v1.CreateBackendResponse
+	(*GetBackendRequest)(nil),      // 5: haproxy.v1.GetBackendRequest
+	(*GetBackendResponse)(nil),     // 6: haproxy.v1.GetBackendResponse
+	(*ListBackendsRequest)(nil),    // 7: haproxy.v1.ListBackendsRequest
+	(*ListBackendsResponse)(nil),   // 8: haproxy.v1.ListBackendsResponse
+	(*UpdateBackendRequest)(nil),   // 9: haproxy.v1.UpdateBackendRequest
+	(*UpdateBackendResponse)(nil),  // 10: haproxy.v1.UpdateBackendResponse
+	(*DeleteBackendRequest)(nil),   // 11: haproxy.v1.DeleteBackendRequest
+	(*DeleteBackendResponse)(nil),  // 12: haproxy.v1.DeleteBackendResponse
+	(*ApplyBackendRequest)(nil),    // 13: haproxy.v1.ApplyBackendRequest
+	(*ApplyBackendResponse)(nil),   // 14: haproxy.v1.ApplyBackendResponse
+	(*StreamBackendsRequest)(nil),  // 15: haproxy.v1.StreamBackendsRequest
+	(*StreamBackendsResponse)(nil), // 16: haproxy.v1.StreamBackendsResponse
+	nil,                            // 17: haproxy.v1.Backend.LabelsEntry
+	nil,                            // 18: haproxy.v1.Backend.AnnotationsEntry
+	(ProxyMode)(0),                 // 19: haproxy.v1.ProxyMode
+	(*ListFilter)(nil),             // 20: haproxy.v1.ListFilter
 }
 var file_backend_proto_depIdxs = []int32{
 	0,  // 0: haproxy.v1.BackendBalance.algorithm:type_name -> haproxy.v1.BalanceAlgorithm
 	1,  // 1: haproxy.v1.Backend.balance:type_name -> haproxy.v1.BackendBalance
-	13, // 2: haproxy.v1.Backend.mode:type_name -> haproxy.v1.ProxyMode
-	2,  // 3: haproxy.v1.CreateBackendRequest.backend:type_name -> haproxy.v1.Backend
-	2,  // 4: haproxy.v1.CreateBackendResponse.backend:type_name -> haproxy.v1.Backend
-	2,  // 5: haproxy.v1.GetBackendResponse.backend:type_name -> haproxy.v1.Backend
-	2,  // 6: haproxy.v1.ListBackendsResponse.backends:type_name -> haproxy.v1.Backend
-	2,  // 7: haproxy.v1.UpdateBackendRequest.backend:type_name -> haproxy.v1.Backend
-	2,  // 8: haproxy.v1.UpdateBackendResponse.backend:type_name -> haproxy.v1.Backend
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	19, // 2: haproxy.v1.Backend.mode:type_name -> haproxy.v1.ProxyMode
+	17, // 3: haproxy.v1.Backend.labels:type_name -> haproxy.v1.Backend.LabelsEntry
+	18, // 4: haproxy.v1.Backend.annotations:type_name -> haproxy.v1.Backend.AnnotationsEntry
+	2,  // 5: haproxy.v1.CreateBackendRequest.backend:type_name -> haproxy.v1.Backend
+	2,  // 6: haproxy.v1.CreateBackendResponse.backend:type_name -> haproxy.v1.Backend
+	2,  // 7: haproxy.v1.GetBackendResponse.backend:type_name -> haproxy.v1.Backend
+	20, // 8: haproxy.v1.ListBackendsRequest.filter:type_name -> haproxy.v1.ListFilter
+	2,  // 9: haproxy.v1.ListBackendsResponse.backends:type_name -> haproxy.v1.Backend
+	2,  // 10: haproxy.v1.UpdateBackendRequest.backend:type_name -> haproxy.v1.Backend
+	2,  // 11: haproxy.v1.UpdateBackendResponse.backend:type_name -> haproxy.v1.Backend
+	2,  // 12: haproxy.v1.ApplyBackendRequest.backend:type_name -> haproxy.v1.Backend
+	2,  // 13: haproxy.v1.ApplyBackendResponse.backend:type_name -> haproxy.v1.Backend
+	20, // 14: haproxy.v1.StreamBackendsRequest.filter:type_name -> haproxy.v1.ListFilter
+	2,  // 15: haproxy.v1.StreamBackendsResponse.backend:type_name -> haproxy.v1.Backend
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_backend_proto_init() }
@@ -766,7 +1157,7 @@ func file_backend_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backend_proto_rawDesc), len(file_backend_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -23,13 +23,20 @@ const (
 
 // Bind represents a HAProxy bind configuration
 type Bind struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the bind
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	V4V6          bool                   `protobuf:"varint,5,opt,name=v4v6,proto3" json:"v4v6,omitempty"`
-	V6Only        bool                   `protobuf:"varint,6,opt,name=v6only,proto3" json:"v6only,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the bind
+	Address         string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Port            int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	V4V6            bool                   `protobuf:"varint,5,opt,name=v4v6,proto3" json:"v4v6,omitempty"`
+	V6Only          bool                   `protobuf:"varint,6,opt,name=v6only,proto3" json:"v6only,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,7,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Changes whenever the resource changes; set in responses only
+	Ssl             bool                   `protobuf:"varint,8,opt,name=ssl,proto3" json:"ssl,omitempty"`                                               // Terminates TLS with ssl_certificate
+	SslCertificate  string                 `protobuf:"bytes,9,opt,name=ssl_certificate,json=sslCertificate,proto3" json:"ssl_certificate,omitempty"`    // Certificate file on the HAProxy host, e.g. from CreateHTTPSFrontendResponse
+	AcceptProxy     bool                   `protobuf:"varint,10,opt,name=accept_proxy,json=acceptProxy,proto3" json:"accept_proxy,omitempty"`           // Expects a PROXY protocol header (v1 or v2) from a load balancer in front; clients without one are refused
+	// Kept in the metadata store of the configurator, as HAProxy has no place for them; see metadata.path
+	Labels        map[string]string `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string `protobuf:"bytes,12,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,11 +113,62 @@ func (x *Bind) GetV6Only() bool {
 	return false
 }
 
+func (x *Bind) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *Bind) GetSsl() bool {
+	if x != nil {
+		return x.Ssl
+	}
+	return false
+}
+
+func (x *Bind) GetSslCertificate() string {
+	if x != nil {
+		return x.SslCertificate
+	}
+	return ""
+}
+
+func (x *Bind) GetAcceptProxy() bool {
+	if x != nil {
+		return x.AcceptProxy
+	}
+	return false
+}
+
+func (x *Bind) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Bind) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 type CreateBindRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
 	Bind          *Bind                  `protobuf:"bytes,3,opt,name=bind,proto3" json:"bind,omitempty"`
+	// Creates one bind per address instead of the single bind.address: each entry is an address, a CIDR such as
+	// "192.168.1.16/28" or a range such as "192.168.1.10-192.168.1.20". The binds are named "<bind.name>-<address>"
+	// and otherwise copy bind; at most 256 addresses
+	Addresses []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// Creates an IPv4 and an IPv6 bind named "<bind.name>-ipv4" and "<bind.name>-ipv6" instead of the single bind:
+	// addresses holds one IPv4 and one IPv6 address, or hostname resolves to one of each. Either both binds are
+	// created or none
+	DualStack     bool   `protobuf:"varint,5,opt,name=dual_stack,json=dualStack,proto3" json:"dual_stack,omitempty"`
+	Hostname      string `protobuf:"bytes,6,opt,name=hostname,proto3" json:"hostname,omitempty"` // Name resolved to the addresses of a dual-stack bind
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -166,9 +224,31 @@ func (x *CreateBindRequest) GetBind() *Bind {
 	return nil
 }
 
+func (x *CreateBindRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *CreateBindRequest) GetDualStack() bool {
+	if x != nil {
+		return x.DualStack
+	}
+	return false
+}
+
+func (x *CreateBindRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 type CreateBindResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bind          *Bind                  `protobuf:"bytes,1,opt,name=bind,proto3" json:"bind,omitempty"`
+	Bind          *Bind                  `protobuf:"bytes,1,opt,name=bind,proto3" json:"bind,omitempty"`   // The first bind created
+	Binds         []*Bind                `protobuf:"bytes,2,rep,name=binds,proto3" json:"binds,omitempty"` // All binds created from addresses
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -210,11 +290,19 @@ func (x *CreateBindResponse) GetBind() *Bind {
 	return nil
 }
 
+func (x *CreateBindResponse) GetBinds() []*Bind {
+	if x != nil {
+		return x.Binds
+	}
+	return nil
+}
+
 type GetBindRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
 	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	NoCache       bool                   `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -270,6 +358,13 @@ func (x *GetBindRequest) GetName() string {
 	return ""
 }
 
+func (x *GetBindRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type GetBindResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bind          *Bind                  `protobuf:"bytes,1,opt,name=bind,proto3" json:"bind,omitempty"`
@@ -318,6 +413,9 @@ type ListBindsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`  // "name", "address" or "port", prefixed with "-" for descending order; configuration order if empty
+	NoCache       bool                   `protobuf:"varint,5,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -366,6 +464,27 @@ func (x *ListBindsRequest) GetFrontendName() string {
 	return ""
 }
 
+func (x *ListBindsRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListBindsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListBindsRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type ListBindsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Binds         []*Bind                `protobuf:"bytes,1,rep,name=binds,proto3" json:"binds,omitempty"`
@@ -411,12 +530,13 @@ func (x *ListBindsResponse) GetBinds() []*Bind {
 }
 
 type UpdateBindRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
-	Bind          *Bind                  `protobuf:"bytes,3,opt,name=bind,proto3" json:"bind,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName    string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Bind            *Bind                  `protobuf:"bytes,3,opt,name=bind,proto3" json:"bind,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateBindRequest) Reset() {
@@ -470,6 +590,13 @@ func (x *UpdateBindRequest) GetBind() *Bind {
 	return nil
 }
 
+func (x *UpdateBindRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type UpdateBindResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bind          *Bind                  `protobuf:"bytes,1,opt,name=bind,proto3" json:"bind,omitempty"`
@@ -515,12 +642,13 @@ func (x *UpdateBindResponse) GetBind() *Bind {
 }
 
 type DeleteBindRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName    string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteBindRequest) Reset() {
@@ -574,6 +702,13 @@ func (x *DeleteBindRequest) GetName() string {
 	return ""
 }
 
+func (x *DeleteBindRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type DeleteBindResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -610,48 +745,201 @@ func (*DeleteBindResponse) Descriptor() ([]byte, []int) {
 	return file_bind_proto_rawDescGZIP(), []int{10}
 }
 
+// ApplyBind creates the bind if it does not exist and replaces it if it differs
+type ApplyBindRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Bind          *Bind                  `protobuf:"bytes,3,opt,name=bind,proto3" json:"bind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBindRequest) Reset() {
+	*x = ApplyBindRequest{}
+	mi := &file_bind_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBindRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBindRequest) ProtoMessage() {}
+
+func (x *ApplyBindRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bind_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBindRequest.ProtoReflect.Descriptor instead.
+func (*ApplyBindRequest) Descriptor() ([]byte, []int) {
+	return file_bind_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApplyBindRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ApplyBindRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *ApplyBindRequest) GetBind() *Bind {
+	if x != nil {
+		return x.Bind
+	}
+	return nil
+}
+
+type ApplyBindResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bind          *Bind                  `protobuf:"bytes,1,opt,name=bind,proto3" json:"bind,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // False if the bind already matched
+	Created       bool                   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"` // True if the bind did not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBindResponse) Reset() {
+	*x = ApplyBindResponse{}
+	mi := &file_bind_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBindResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBindResponse) ProtoMessage() {}
+
+func (x *ApplyBindResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bind_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBindResponse.ProtoReflect.Descriptor instead.
+func (*ApplyBindResponse) Descriptor() ([]byte, []int) {
+	return file_bind_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApplyBindResponse) GetBind() *Bind {
+	if x != nil {
+		return x.Bind
+	}
+	return nil
+}
+
+func (x *ApplyBindResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ApplyBindResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
 var File_bind_proto protoreflect.FileDescriptor
 
 const file_bind_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
 	"bind.proto\x12\n" +
-	"haproxy.v1\"\x84\x01\n" +
+	"haproxy.v1\x1a\fcommon.proto\"\x83\x04\n" +
 	"\x04Bind\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x12\n" +
 	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x12\n" +
 	"\x04v4v6\x18\x05 \x01(\bR\x04v4v6\x12\x16\n" +
-	"\x06v6only\x18\x06 \x01(\bR\x06v6only\"\x85\x01\n" +
+	"\x06v6only\x18\x06 \x01(\bR\x06v6only\x12)\n" +
+	"\x10resource_version\x18\a \x01(\tR\x0fresourceVersion\x12\x10\n" +
+	"\x03ssl\x18\b \x01(\bR\x03ssl\x12'\n" +
+	"\x0fssl_certificate\x18\t \x01(\tR\x0esslCertificate\x12!\n" +
+	"\faccept_proxy\x18\n" +
+	" \x01(\bR\vacceptProxy\x124\n" +
+	"\x06labels\x18\v \x03(\v2\x1c.haproxy.v1.Bind.LabelsEntryR\x06labels\x12C\n" +
+	"\vannotations\x18\f \x03(\v2!.haproxy.v1.Bind.AnnotationsEntryR\vannotations\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xde\x01\n" +
 	"\x11CreateBindRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
 	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12$\n" +
-	"\x04bind\x18\x03 \x01(\v2\x10.haproxy.v1.BindR\x04bind\":\n" +
+	"\x04bind\x18\x03 \x01(\v2\x10.haproxy.v1.BindR\x04bind\x12\x1c\n" +
+	"\taddresses\x18\x04 \x03(\tR\taddresses\x12\x1d\n" +
+	"\n" +
+	"dual_stack\x18\x05 \x01(\bR\tdualStack\x12\x1a\n" +
+	"\bhostname\x18\x06 \x01(\tR\bhostname\"b\n" +
 	"\x12CreateBindResponse\x12$\n" +
-	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"p\n" +
+	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\x12&\n" +
+	"\x05binds\x18\x02 \x03(\v2\x10.haproxy.v1.BindR\x05binds\"\x8b\x01\n" +
 	"\x0eGetBindRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
 	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"7\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x19\n" +
+	"\bno_cache\x18\x04 \x01(\bR\anoCache\"7\n" +
 	"\x0fGetBindResponse\x12$\n" +
-	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"^\n" +
+	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"\xc4\x01\n" +
 	"\x10ListBindsRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
-	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\";\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12.\n" +
+	"\x06filter\x18\x03 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x19\n" +
+	"\bno_cache\x18\x05 \x01(\bR\anoCache\";\n" +
 	"\x11ListBindsResponse\x12&\n" +
-	"\x05binds\x18\x01 \x03(\v2\x10.haproxy.v1.BindR\x05binds\"\x85\x01\n" +
+	"\x05binds\x18\x01 \x03(\v2\x10.haproxy.v1.BindR\x05binds\"\xb0\x01\n" +
 	"\x11UpdateBindRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
 	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12$\n" +
-	"\x04bind\x18\x03 \x01(\v2\x10.haproxy.v1.BindR\x04bind\":\n" +
+	"\x04bind\x18\x03 \x01(\v2\x10.haproxy.v1.BindR\x04bind\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\":\n" +
 	"\x12UpdateBindResponse\x12$\n" +
-	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"s\n" +
+	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"\x9e\x01\n" +
 	"\x11DeleteBindRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
 	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"\x14\n" +
-	"\x12DeleteBindResponseB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"\x14\n" +
+	"\x12DeleteBindResponse\"\x84\x01\n" +
+	"\x10ApplyBindRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12$\n" +
+	"\x04bind\x18\x03 \x01(\v2\x10.haproxy.v1.BindR\x04bind\"m\n" +
+	"\x11ApplyBindResponse\x12$\n" +
+	"\x04bind\x18\x01 \x01(\v2\x10.haproxy.v1.BindR\x04bind\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreatedB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
 
 var (
 	file_bind_proto_rawDescOnce sync.Once
@@ -665,7 +953,7 @@ func file_bind_proto_rawDescGZIP() []byte {
 	return file_bind_proto_rawDescData
 }
 
-var file_bind_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_bind_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_bind_proto_goTypes = []any{
 	(*Bind)(nil),               // 0: haproxy.v1.Bind
 	(*CreateBindRequest)(nil),  // 1: haproxy.v1.CreateBindRequest
@@ -678,19 +966,30 @@ var file_bind_proto_goTypes = []any{
 	(*UpdateBindResponse)(nil), // 8: haproxy.v1.UpdateBindResponse
 	(*DeleteBindRequest)(nil),  // 9: haproxy.v1.DeleteBindRequest
 	(*DeleteBindResponse)(nil), // 10: haproxy.v1.DeleteBindResponse
+	(*ApplyBindRequest)(nil),   // 11: haproxy.v1.ApplyBindRequest
+	(*ApplyBindResponse)(nil),  // 12: haproxy.v1.ApplyBindResponse
+	nil,                        // 13: haproxy.v1.Bind.LabelsEntry
+	nil,                        // 14: haproxy.v1.Bind.AnnotationsEntry
+	(*ListFilter)(nil),         // 15: haproxy.v1.ListFilter
 }
 var file_bind_proto_depIdxs = []int32{
-	0, // 0: haproxy.v1.CreateBindRequest.bind:type_name -> haproxy.v1.Bind
-	0, // 1: haproxy.v1.CreateBindResponse.bind:type_name -> haproxy.v1.Bind
-	0, // 2: haproxy.v1.GetBindResponse.bind:type_name -> haproxy.v1.Bind
-	0, // 3: haproxy.v1.ListBindsResponse.binds:type_name -> haproxy.v1.Bind
-	0, // 4: haproxy.v1.UpdateBindRequest.bind:type_name -> haproxy.v1.Bind
-	0, // 5: haproxy.v1.UpdateBindResponse.bind:type_name -> haproxy.v1.Bind
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	13, // 0: haproxy.v1.Bind.labels:type_name -> haproxy.v1.Bind.LabelsEntry
+	14, // 1: haproxy.v1.Bind.annotations:type_name -> haproxy.v1.Bind.AnnotationsEntry
+	0,  // 2: haproxy.v1.CreateBindRequest.bind:type_name -> haproxy.v1.Bind
+	0,  // 3: haproxy.v1.CreateBindResponse.bind:type_name -> haproxy.v1.Bind
+	0,  // 4: haproxy.v1.CreateBindResponse.binds:type_name -> haproxy.v1.Bind
+	0,  // 5: haproxy.v1.GetBindResponse.bind:type_name -> haproxy.v1.Bind
+	15, // 6: haproxy.v1.ListBindsRequest.filter:type_name -> haproxy.v1.ListFilter
+	0,  // 7: haproxy.v1.ListBindsResponse.binds:type_name -> haproxy.v1.Bind
+	0,  // 8: haproxy.v1.UpdateBindRequest.bind:type_name -> haproxy.v1.Bind
+	0,  // 9: haproxy.v1.UpdateBindResponse.bind:type_name -> haproxy.v1.Bind
+	0,  // 10: haproxy.v1.ApplyBindRequest.bind:type_name -> haproxy.v1.Bind
+	0,  // 11: haproxy.v1.ApplyBindResponse.bind:type_name -> haproxy.v1.Bind
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_bind_proto_init() }
@@ -698,13 +997,14 @@ func file_bind_proto_init() {
 	if File_bind_proto != nil {
 		return
 	}
+	file_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bind_proto_rawDesc), len(file_bind_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
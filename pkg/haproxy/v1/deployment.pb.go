@@ -0,0 +1,498 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: deployment.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SwapBackendsRequest exchanges two backends in the traffic of a frontend: its default_backend and use_backend
+// rules pointing to blue then point to green and vice versa
+type SwapBackendsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Optional: without one, the swap is made and committed in a transaction of its own
+	FrontendName    string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Blue            string                 `protobuf:"bytes,3,opt,name=blue,proto3" json:"blue,omitempty"`                                              // Required: backend name
+	Green           string                 `protobuf:"bytes,4,opt,name=green,proto3" json:"green,omitempty"`                                            // Required: backend name
+	ExpectedActive  string                 `protobuf:"bytes,5,opt,name=expected_active,json=expectedActive,proto3" json:"expected_active,omitempty"`    // Fails with FAILED_PRECONDITION unless the frontend currently sends to this backend (optional)
+	ExpectedVersion string                 `protobuf:"bytes,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the frontend has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SwapBackendsRequest) Reset() {
+	*x = SwapBackendsRequest{}
+	mi := &file_deployment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapBackendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapBackendsRequest) ProtoMessage() {}
+
+func (x *SwapBackendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapBackendsRequest.ProtoReflect.Descriptor instead.
+func (*SwapBackendsRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SwapBackendsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *SwapBackendsRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *SwapBackendsRequest) GetBlue() string {
+	if x != nil {
+		return x.Blue
+	}
+	return ""
+}
+
+func (x *SwapBackendsRequest) GetGreen() string {
+	if x != nil {
+		return x.Green
+	}
+	return ""
+}
+
+func (x *SwapBackendsRequest) GetExpectedActive() string {
+	if x != nil {
+		return x.ExpectedActive
+	}
+	return ""
+}
+
+func (x *SwapBackendsRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+type SwapBackendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frontend      *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	ActiveBackend string                 `protobuf:"bytes,2,opt,name=active_backend,json=activeBackend,proto3" json:"active_backend,omitempty"` // Backend the frontend sends to after the swap
+	SwappedRules  int32                  `protobuf:"varint,3,opt,name=swapped_rules,json=swappedRules,proto3" json:"swapped_rules,omitempty"`   // Number of use_backend rules changed
+	Transaction   *Transaction           `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`                          // The committed transaction, unset if transaction_id was given
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwapBackendsResponse) Reset() {
+	*x = SwapBackendsResponse{}
+	mi := &file_deployment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapBackendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapBackendsResponse) ProtoMessage() {}
+
+func (x *SwapBackendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapBackendsResponse.ProtoReflect.Descriptor instead.
+func (*SwapBackendsResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SwapBackendsResponse) GetFrontend() *Frontend {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+func (x *SwapBackendsResponse) GetActiveBackend() string {
+	if x != nil {
+		return x.ActiveBackend
+	}
+	return ""
+}
+
+func (x *SwapBackendsResponse) GetSwappedRules() int32 {
+	if x != nil {
+		return x.SwappedRules
+	}
+	return 0
+}
+
+func (x *SwapBackendsResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// ShiftTrafficRequest moves the load of a backend to its canary servers in steps. Each step sets the weights of
+// all servers of the backend in a transaction of its own and is then observed for step_interval; if the canary
+// servers fail more than max_error_rate of their requests, the original weights are restored.
+type ShiftTrafficRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	CanaryServers []string               `protobuf:"bytes,2,rep,name=canary_servers,json=canaryServers,proto3" json:"canary_servers,omitempty"`  // Required: servers receiving the shifted load; the others are the baseline
+	Steps         []int32                `protobuf:"varint,3,rep,packed,name=steps,proto3" json:"steps,omitempty"`                               // Required: ascending share of the canary servers in percent, e.g. [5, 25, 100]
+	StepInterval  *durationpb.Duration   `protobuf:"bytes,4,opt,name=step_interval,json=stepInterval,proto3" json:"step_interval,omitempty"`     // Observation time after each step; 60 seconds if unset
+	MaxErrorRate  float64                `protobuf:"fixed64,5,opt,name=max_error_rate,json=maxErrorRate,proto3" json:"max_error_rate,omitempty"` // Fraction of failed canary requests triggering the rollback, e.g. 0.05; 0 disables it
+	MinRequests   int64                  `protobuf:"varint,6,opt,name=min_requests,json=minRequests,proto3" json:"min_requests,omitempty"`       // Canary requests a step needs before its error rate is judged
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShiftTrafficRequest) Reset() {
+	*x = ShiftTrafficRequest{}
+	mi := &file_deployment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShiftTrafficRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftTrafficRequest) ProtoMessage() {}
+
+func (x *ShiftTrafficRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftTrafficRequest.ProtoReflect.Descriptor instead.
+func (*ShiftTrafficRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ShiftTrafficRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *ShiftTrafficRequest) GetCanaryServers() []string {
+	if x != nil {
+		return x.CanaryServers
+	}
+	return nil
+}
+
+func (x *ShiftTrafficRequest) GetSteps() []int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *ShiftTrafficRequest) GetStepInterval() *durationpb.Duration {
+	if x != nil {
+		return x.StepInterval
+	}
+	return nil
+}
+
+func (x *ShiftTrafficRequest) GetMaxErrorRate() float64 {
+	if x != nil {
+		return x.MaxErrorRate
+	}
+	return 0
+}
+
+func (x *ShiftTrafficRequest) GetMinRequests() int64 {
+	if x != nil {
+		return x.MinRequests
+	}
+	return 0
+}
+
+type ShiftTrafficStep struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Percent        int32                  `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	CanaryWeight   int32                  `protobuf:"varint,2,opt,name=canary_weight,json=canaryWeight,proto3" json:"canary_weight,omitempty"`       // Weight of each canary server
+	BaselineWeight int32                  `protobuf:"varint,3,opt,name=baseline_weight,json=baselineWeight,proto3" json:"baseline_weight,omitempty"` // Weight of each baseline server
+	Requests       int64                  `protobuf:"varint,4,opt,name=requests,proto3" json:"requests,omitempty"`                                   // Requests of the canary servers while the step was observed
+	Errors         int64                  `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"`                                       // Failed requests among them
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShiftTrafficStep) Reset() {
+	*x = ShiftTrafficStep{}
+	mi := &file_deployment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShiftTrafficStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftTrafficStep) ProtoMessage() {}
+
+func (x *ShiftTrafficStep) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftTrafficStep.ProtoReflect.Descriptor instead.
+func (*ShiftTrafficStep) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShiftTrafficStep) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ShiftTrafficStep) GetCanaryWeight() int32 {
+	if x != nil {
+		return x.CanaryWeight
+	}
+	return 0
+}
+
+func (x *ShiftTrafficStep) GetBaselineWeight() int32 {
+	if x != nil {
+		return x.BaselineWeight
+	}
+	return 0
+}
+
+func (x *ShiftTrafficStep) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *ShiftTrafficStep) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+type ShiftTrafficResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Steps          []*ShiftTrafficStep    `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"` // Steps applied, including the one that failed
+	RolledBack     bool                   `protobuf:"varint,2,opt,name=rolled_back,json=rolledBack,proto3" json:"rolled_back,omitempty"`
+	RollbackReason string                 `protobuf:"bytes,3,opt,name=rollback_reason,json=rollbackReason,proto3" json:"rollback_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShiftTrafficResponse) Reset() {
+	*x = ShiftTrafficResponse{}
+	mi := &file_deployment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShiftTrafficResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftTrafficResponse) ProtoMessage() {}
+
+func (x *ShiftTrafficResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftTrafficResponse.ProtoReflect.Descriptor instead.
+func (*ShiftTrafficResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ShiftTrafficResponse) GetSteps() []*ShiftTrafficStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *ShiftTrafficResponse) GetRolledBack() bool {
+	if x != nil {
+		return x.RolledBack
+	}
+	return false
+}
+
+func (x *ShiftTrafficResponse) GetRollbackReason() string {
+	if x != nil {
+		return x.RollbackReason
+	}
+	return ""
+}
+
+var File_deployment_proto protoreflect.FileDescriptor
+
+const file_deployment_proto_rawDesc = "" +
+	"\n" +
+	"\x10deployment.proto\x12\n" +
+	"haproxy.v1\x1a\x0efrontend.proto\x1a\x11transaction.proto\x1a\x1egoogle/protobuf/duration.proto\"\xdf\x01\n" +
+	"\x13SwapBackendsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
+	"\x04blue\x18\x03 \x01(\tR\x04blue\x12\x14\n" +
+	"\x05green\x18\x04 \x01(\tR\x05green\x12'\n" +
+	"\x0fexpected_active\x18\x05 \x01(\tR\x0eexpectedActive\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\tR\x0fexpectedVersion\"\xcf\x01\n" +
+	"\x14SwapBackendsResponse\x120\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\x12%\n" +
+	"\x0eactive_backend\x18\x02 \x01(\tR\ractiveBackend\x12#\n" +
+	"\rswapped_rules\x18\x03 \x01(\x05R\fswappedRules\x129\n" +
+	"\vtransaction\x18\x04 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\"\xfe\x01\n" +
+	"\x13ShiftTrafficRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12%\n" +
+	"\x0ecanary_servers\x18\x02 \x03(\tR\rcanaryServers\x12\x14\n" +
+	"\x05steps\x18\x03 \x03(\x05R\x05steps\x12>\n" +
+	"\rstep_interval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fstepInterval\x12$\n" +
+	"\x0emax_error_rate\x18\x05 \x01(\x01R\fmaxErrorRate\x12!\n" +
+	"\fmin_requests\x18\x06 \x01(\x03R\vminRequests\"\xae\x01\n" +
+	"\x10ShiftTrafficStep\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x05R\apercent\x12#\n" +
+	"\rcanary_weight\x18\x02 \x01(\x05R\fcanaryWeight\x12'\n" +
+	"\x0fbaseline_weight\x18\x03 \x01(\x05R\x0ebaselineWeight\x12\x1a\n" +
+	"\brequests\x18\x04 \x01(\x03R\brequests\x12\x16\n" +
+	"\x06errors\x18\x05 \x01(\x03R\x06errors\"\x94\x01\n" +
+	"\x14ShiftTrafficResponse\x122\n" +
+	"\x05steps\x18\x01 \x03(\v2\x1c.haproxy.v1.ShiftTrafficStepR\x05steps\x12\x1f\n" +
+	"\vrolled_back\x18\x02 \x01(\bR\n" +
+	"rolledBack\x12'\n" +
+	"\x0frollback_reason\x18\x03 \x01(\tR\x0erollbackReasonB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_deployment_proto_rawDescOnce sync.Once
+	file_deployment_proto_rawDescData []byte
+)
+
+func file_deployment_proto_rawDescGZIP() []byte {
+	file_deployment_proto_rawDescOnce.Do(func() {
+		file_deployment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_deployment_proto_rawDesc), len(file_deployment_proto_rawDesc)))
+	})
+	return file_deployment_proto_rawDescData
+}
+
+var file_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_deployment_proto_goTypes = []any{
+	(*SwapBackendsRequest)(nil),  // 0: haproxy.v1.SwapBackendsRequest
+	(*SwapBackendsResponse)(nil), // 1: haproxy.v1.SwapBackendsResponse
+	(*ShiftTrafficRequest)(nil),  // 2: haproxy.v1.ShiftTrafficRequest
+	(*ShiftTrafficStep)(nil),     // 3: haproxy.v1.ShiftTrafficStep
+	(*ShiftTrafficResponse)(nil), // 4: haproxy.v1.ShiftTrafficResponse
+	(*Frontend)(nil),             // 5: haproxy.v1.Frontend
+	(*Transaction)(nil),          // 6: haproxy.v1.Transaction
+	(*durationpb.Duration)(nil),  // 7: google.protobuf.Duration
+}
+var file_deployment_proto_depIdxs = []int32{
+	5, // 0: haproxy.v1.SwapBackendsResponse.frontend:type_name -> haproxy.v1.Frontend
+	6, // 1: haproxy.v1.SwapBackendsResponse.transaction:type_name -> haproxy.v1.Transaction
+	7, // 2: haproxy.v1.ShiftTrafficRequest.step_interval:type_name -> google.protobuf.Duration
+	3, // 3: haproxy.v1.ShiftTrafficResponse.steps:type_name -> haproxy.v1.ShiftTrafficStep
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_deployment_proto_init() }
+func file_deployment_proto_init() {
+	if File_deployment_proto != nil {
+		return
+	}
+	file_frontend_proto_init()
+	file_transaction_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_deployment_proto_rawDesc), len(file_deployment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_deployment_proto_goTypes,
+		DependencyIndexes: file_deployment_proto_depIdxs,
+		MessageInfos:      file_deployment_proto_msgTypes,
+	}.Build()
+	File_deployment_proto = out.File
+	file_deployment_proto_goTypes = nil
+	file_deployment_proto_depIdxs = nil
+}
@@ -0,0 +1,281 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: discovery.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DiscoveredBackend is the sync state of a backend whose servers come from a discovery source
+type DiscoveredBackend struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BackendName    string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Source         string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                                        // e.g. "consul:web"
+	Servers        int32                  `protobuf:"varint,3,opt,name=servers,proto3" json:"servers,omitempty"`                                     // Discovered servers
+	HealthyServers int32                  `protobuf:"varint,4,opt,name=healthy_servers,json=healthyServers,proto3" json:"healthy_servers,omitempty"` // The others are kept in maintenance
+	LastSyncTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+	LastError      string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`       // Error of the last sync, empty if it succeeded
+	LastChanges    []*StateChange         `protobuf:"bytes,7,rep,name=last_changes,json=lastChanges,proto3" json:"last_changes,omitempty"` // Operations of the last sync that changed anything
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscoveredBackend) Reset() {
+	*x = DiscoveredBackend{}
+	mi := &file_discovery_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveredBackend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveredBackend) ProtoMessage() {}
+
+func (x *DiscoveredBackend) ProtoReflect() protoreflect.Message {
+	mi := &file_discovery_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveredBackend.ProtoReflect.Descriptor instead.
+func (*DiscoveredBackend) Descriptor() ([]byte, []int) {
+	return file_discovery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DiscoveredBackend) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *DiscoveredBackend) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *DiscoveredBackend) GetServers() int32 {
+	if x != nil {
+		return x.Servers
+	}
+	return 0
+}
+
+func (x *DiscoveredBackend) GetHealthyServers() int32 {
+	if x != nil {
+		return x.HealthyServers
+	}
+	return 0
+}
+
+func (x *DiscoveredBackend) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+func (x *DiscoveredBackend) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DiscoveredBackend) GetLastChanges() []*StateChange {
+	if x != nil {
+		return x.LastChanges
+	}
+	return nil
+}
+
+type GetDiscoveryStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscoveryStatusRequest) Reset() {
+	*x = GetDiscoveryStatusRequest{}
+	mi := &file_discovery_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscoveryStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscoveryStatusRequest) ProtoMessage() {}
+
+func (x *GetDiscoveryStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discovery_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscoveryStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDiscoveryStatusRequest) Descriptor() ([]byte, []int) {
+	return file_discovery_proto_rawDescGZIP(), []int{1}
+}
+
+// GetDiscoveryStatusResponse reports the backends synced from discovery sources
+type GetDiscoveryStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Backends      []*DiscoveredBackend   `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscoveryStatusResponse) Reset() {
+	*x = GetDiscoveryStatusResponse{}
+	mi := &file_discovery_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscoveryStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscoveryStatusResponse) ProtoMessage() {}
+
+func (x *GetDiscoveryStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discovery_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscoveryStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDiscoveryStatusResponse) Descriptor() ([]byte, []int) {
+	return file_discovery_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDiscoveryStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetDiscoveryStatusResponse) GetBackends() []*DiscoveredBackend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+var File_discovery_proto protoreflect.FileDescriptor
+
+const file_discovery_proto_rawDesc = "" +
+	"\n" +
+	"\x0fdiscovery.proto\x12\n" +
+	"haproxy.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\vstate.proto\"\xae\x02\n" +
+	"\x11DiscoveredBackend\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\aservers\x18\x03 \x01(\x05R\aservers\x12'\n" +
+	"\x0fhealthy_servers\x18\x04 \x01(\x05R\x0ehealthyServers\x12@\n" +
+	"\x0elast_sync_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12:\n" +
+	"\flast_changes\x18\a \x03(\v2\x17.haproxy.v1.StateChangeR\vlastChanges\"\x1b\n" +
+	"\x19GetDiscoveryStatusRequest\"q\n" +
+	"\x1aGetDiscoveryStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x129\n" +
+	"\bbackends\x18\x02 \x03(\v2\x1d.haproxy.v1.DiscoveredBackendR\bbackendsB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_discovery_proto_rawDescOnce sync.Once
+	file_discovery_proto_rawDescData []byte
+)
+
+func file_discovery_proto_rawDescGZIP() []byte {
+	file_discovery_proto_rawDescOnce.Do(func() {
+		file_discovery_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_discovery_proto_rawDesc), len(file_discovery_proto_rawDesc)))
+	})
+	return file_discovery_proto_rawDescData
+}
+
+var file_discovery_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_discovery_proto_goTypes = []any{
+	(*DiscoveredBackend)(nil),          // 0: haproxy.v1.DiscoveredBackend
+	(*GetDiscoveryStatusRequest)(nil),  // 1: haproxy.v1.GetDiscoveryStatusRequest
+	(*GetDiscoveryStatusResponse)(nil), // 2: haproxy.v1.GetDiscoveryStatusResponse
+	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+	(*StateChange)(nil),                // 4: haproxy.v1.StateChange
+}
+var file_discovery_proto_depIdxs = []int32{
+	3, // 0: haproxy.v1.DiscoveredBackend.last_sync_time:type_name -> google.protobuf.Timestamp
+	4, // 1: haproxy.v1.DiscoveredBackend.last_changes:type_name -> haproxy.v1.StateChange
+	0, // 2: haproxy.v1.GetDiscoveryStatusResponse.backends:type_name -> haproxy.v1.DiscoveredBackend
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_discovery_proto_init() }
+func file_discovery_proto_init() {
+	if File_discovery_proto != nil {
+		return
+	}
+	file_state_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_discovery_proto_rawDesc), len(file_discovery_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_discovery_proto_goTypes,
+		DependencyIndexes: file_discovery_proto_depIdxs,
+		MessageInfos:      file_discovery_proto_msgTypes,
+	}.Build()
+	File_discovery_proto = out.File
+	file_discovery_proto_goTypes = nil
+	file_discovery_proto_depIdxs = nil
+}
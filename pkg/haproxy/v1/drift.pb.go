@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: drift.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetDriftStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriftStatusRequest) Reset() {
+	*x = GetDriftStatusRequest{}
+	mi := &file_drift_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriftStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriftStatusRequest) ProtoMessage() {}
+
+func (x *GetDriftStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drift_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriftStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDriftStatusRequest) Descriptor() ([]byte, []int) {
+	return file_drift_proto_rawDescGZIP(), []int{0}
+}
+
+// GetDriftStatusResponse reports whether the live configuration differs from the desired state
+type GetDriftStatusResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Enabled             bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Instance            string                 `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`                             // HAProxy instance that is compared
+	DesiredState        string                 `protobuf:"bytes,3,opt,name=desired_state,json=desiredState,proto3" json:"desired_state,omitempty"` // Path of the desired state document or manifests
+	Record              bool                   `protobuf:"varint,4,opt,name=record,proto3" json:"record,omitempty"`                                // Whether the desired state is recorded after every commit through the configurator
+	Remediate           bool                   `protobuf:"varint,5,opt,name=remediate,proto3" json:"remediate,omitempty"`                          // Whether drift is reverted automatically
+	Drifted             bool                   `protobuf:"varint,6,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	Changes             []*StateChange         `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"` // Operations that would make the live configuration match the desired state
+	LastCheckTime       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_check_time,json=lastCheckTime,proto3" json:"last_check_time,omitempty"`
+	DetectedTime        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=detected_time,json=detectedTime,proto3" json:"detected_time,omitempty"` // When the current drift was first detected
+	LastRemediationTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_remediation_time,json=lastRemediationTime,proto3" json:"last_remediation_time,omitempty"`
+	LastError           string                 `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Error of the last comparison or remediation, empty if it succeeded
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetDriftStatusResponse) Reset() {
+	*x = GetDriftStatusResponse{}
+	mi := &file_drift_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriftStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriftStatusResponse) ProtoMessage() {}
+
+func (x *GetDriftStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drift_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriftStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDriftStatusResponse) Descriptor() ([]byte, []int) {
+	return file_drift_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDriftStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetDriftStatusResponse) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetDriftStatusResponse) GetDesiredState() string {
+	if x != nil {
+		return x.DesiredState
+	}
+	return ""
+}
+
+func (x *GetDriftStatusResponse) GetRecord() bool {
+	if x != nil {
+		return x.Record
+	}
+	return false
+}
+
+func (x *GetDriftStatusResponse) GetRemediate() bool {
+	if x != nil {
+		return x.Remediate
+	}
+	return false
+}
+
+func (x *GetDriftStatusResponse) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+func (x *GetDriftStatusResponse) GetChanges() []*StateChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetDriftStatusResponse) GetLastCheckTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheckTime
+	}
+	return nil
+}
+
+func (x *GetDriftStatusResponse) GetDetectedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedTime
+	}
+	return nil
+}
+
+func (x *GetDriftStatusResponse) GetLastRemediationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRemediationTime
+	}
+	return nil
+}
+
+func (x *GetDriftStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type CheckDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDriftRequest) Reset() {
+	*x = CheckDriftRequest{}
+	mi := &file_drift_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDriftRequest) ProtoMessage() {}
+
+func (x *CheckDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drift_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDriftRequest.ProtoReflect.Descriptor instead.
+func (*CheckDriftRequest) Descriptor() ([]byte, []int) {
+	return file_drift_proto_rawDescGZIP(), []int{2}
+}
+
+// CheckDriftResponse contains the outcome of a comparison run on request
+type CheckDriftResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Status        *GetDriftStatusResponse `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDriftResponse) Reset() {
+	*x = CheckDriftResponse{}
+	mi := &file_drift_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDriftResponse) ProtoMessage() {}
+
+func (x *CheckDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drift_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDriftResponse.ProtoReflect.Descriptor instead.
+func (*CheckDriftResponse) Descriptor() ([]byte, []int) {
+	return file_drift_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckDriftResponse) GetStatus() *GetDriftStatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_drift_proto protoreflect.FileDescriptor
+
+const file_drift_proto_rawDesc = "" +
+	"\n" +
+	"\vdrift.proto\x12\n" +
+	"haproxy.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\vstate.proto\"\x17\n" +
+	"\x15GetDriftStatusRequest\"\xea\x03\n" +
+	"\x16GetDriftStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
+	"\binstance\x18\x02 \x01(\tR\binstance\x12#\n" +
+	"\rdesired_state\x18\x03 \x01(\tR\fdesiredState\x12\x16\n" +
+	"\x06record\x18\x04 \x01(\bR\x06record\x12\x1c\n" +
+	"\tremediate\x18\x05 \x01(\bR\tremediate\x12\x18\n" +
+	"\adrifted\x18\x06 \x01(\bR\adrifted\x121\n" +
+	"\achanges\x18\a \x03(\v2\x17.haproxy.v1.StateChangeR\achanges\x12B\n" +
+	"\x0flast_check_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rlastCheckTime\x12?\n" +
+	"\rdetected_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fdetectedTime\x12N\n" +
+	"\x15last_remediation_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x13lastRemediationTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\"\x13\n" +
+	"\x11CheckDriftRequest\"P\n" +
+	"\x12CheckDriftResponse\x12:\n" +
+	"\x06status\x18\x01 \x01(\v2\".haproxy.v1.GetDriftStatusResponseR\x06statusB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_drift_proto_rawDescOnce sync.Once
+	file_drift_proto_rawDescData []byte
+)
+
+func file_drift_proto_rawDescGZIP() []byte {
+	file_drift_proto_rawDescOnce.Do(func() {
+		file_drift_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_drift_proto_rawDesc), len(file_drift_proto_rawDesc)))
+	})
+	return file_drift_proto_rawDescData
+}
+
+var file_drift_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_drift_proto_goTypes = []any{
+	(*GetDriftStatusRequest)(nil),  // 0: haproxy.v1.GetDriftStatusRequest
+	(*GetDriftStatusResponse)(nil), // 1: haproxy.v1.GetDriftStatusResponse
+	(*CheckDriftRequest)(nil),      // 2: haproxy.v1.CheckDriftRequest
+	(*CheckDriftResponse)(nil),     // 3: haproxy.v1.CheckDriftResponse
+	(*StateChange)(nil),            // 4: haproxy.v1.StateChange
+	(*timestamppb.Timestamp)(nil),  // 5: google.protobuf.Timestamp
+}
+var file_drift_proto_depIdxs = []int32{
+	4, // 0: haproxy.v1.GetDriftStatusResponse.changes:type_name -> haproxy.v1.StateChange
+	5, // 1: haproxy.v1.GetDriftStatusResponse.last_check_time:type_name -> google.protobuf.Timestamp
+	5, // 2: haproxy.v1.GetDriftStatusResponse.detected_time:type_name -> google.protobuf.Timestamp
+	5, // 3: haproxy.v1.GetDriftStatusResponse.last_remediation_time:type_name -> google.protobuf.Timestamp
+	1, // 4: haproxy.v1.CheckDriftResponse.status:type_name -> haproxy.v1.GetDriftStatusResponse
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_drift_proto_init() }
+func file_drift_proto_init() {
+	if File_drift_proto != nil {
+		return
+	}
+	file_state_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_drift_proto_rawDesc), len(file_drift_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_drift_proto_goTypes,
+		DependencyIndexes: file_drift_proto_depIdxs,
+		MessageInfos:      file_drift_proto_msgTypes,
+	}.Build()
+	File_drift_proto = out.File
+	file_drift_proto_goTypes = nil
+	file_drift_proto_depIdxs = nil
+}
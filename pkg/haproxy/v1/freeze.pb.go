@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: freeze.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FreezeState describes a change freeze, during which the RPCs that change the configuration are rejected
+type FreezeState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frozen        bool                   `protobuf:"varint,1,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"` // Unset if the freeze lasts until UnfreezeChanges
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreezeState) Reset() {
+	*x = FreezeState{}
+	mi := &file_freeze_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreezeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeState) ProtoMessage() {}
+
+func (x *FreezeState) ProtoReflect() protoreflect.Message {
+	mi := &file_freeze_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeState.ProtoReflect.Descriptor instead.
+func (*FreezeState) Descriptor() ([]byte, []int) {
+	return file_freeze_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FreezeState) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
+func (x *FreezeState) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FreezeState) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *FreezeState) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+// FreezeChangesRequest starts a change freeze, or replaces the reason and expiry of the current one
+type FreezeChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"` // Lifts the freeze automatically after; unset until UnfreezeChanges
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreezeChangesRequest) Reset() {
+	*x = FreezeChangesRequest{}
+	mi := &file_freeze_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreezeChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeChangesRequest) ProtoMessage() {}
+
+func (x *FreezeChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_freeze_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeChangesRequest.ProtoReflect.Descriptor instead.
+func (*FreezeChangesRequest) Descriptor() ([]byte, []int) {
+	return file_freeze_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FreezeChangesRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FreezeChangesRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type FreezeChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Freeze        *FreezeState           `protobuf:"bytes,1,opt,name=freeze,proto3" json:"freeze,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreezeChangesResponse) Reset() {
+	*x = FreezeChangesResponse{}
+	mi := &file_freeze_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreezeChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeChangesResponse) ProtoMessage() {}
+
+func (x *FreezeChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_freeze_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeChangesResponse.ProtoReflect.Descriptor instead.
+func (*FreezeChangesResponse) Descriptor() ([]byte, []int) {
+	return file_freeze_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FreezeChangesResponse) GetFreeze() *FreezeState {
+	if x != nil {
+		return x.Freeze
+	}
+	return nil
+}
+
+type UnfreezeChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfreezeChangesRequest) Reset() {
+	*x = UnfreezeChangesRequest{}
+	mi := &file_freeze_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfreezeChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfreezeChangesRequest) ProtoMessage() {}
+
+func (x *UnfreezeChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_freeze_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfreezeChangesRequest.ProtoReflect.Descriptor instead.
+func (*UnfreezeChangesRequest) Descriptor() ([]byte, []int) {
+	return file_freeze_proto_rawDescGZIP(), []int{3}
+}
+
+type UnfreezeChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WasFrozen     bool                   `protobuf:"varint,1,opt,name=was_frozen,json=wasFrozen,proto3" json:"was_frozen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfreezeChangesResponse) Reset() {
+	*x = UnfreezeChangesResponse{}
+	mi := &file_freeze_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfreezeChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfreezeChangesResponse) ProtoMessage() {}
+
+func (x *UnfreezeChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_freeze_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfreezeChangesResponse.ProtoReflect.Descriptor instead.
+func (*UnfreezeChangesResponse) Descriptor() ([]byte, []int) {
+	return file_freeze_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UnfreezeChangesResponse) GetWasFrozen() bool {
+	if x != nil {
+		return x.WasFrozen
+	}
+	return false
+}
+
+var File_freeze_proto protoreflect.FileDescriptor
+
+const file_freeze_proto_rawDesc = "" +
+	"\n" +
+	"\ffreeze.proto\x12\n" +
+	"haproxy.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x01\n" +
+	"\vFreezeState\x12\x16\n" +
+	"\x06frozen\x18\x01 \x01(\bR\x06frozen\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12;\n" +
+	"\vexpire_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"e\n" +
+	"\x14FreezeChangesRequest\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\"H\n" +
+	"\x15FreezeChangesResponse\x12/\n" +
+	"\x06freeze\x18\x01 \x01(\v2\x17.haproxy.v1.FreezeStateR\x06freeze\"\x18\n" +
+	"\x16UnfreezeChangesRequest\"8\n" +
+	"\x17UnfreezeChangesResponse\x12\x1d\n" +
+	"\n" +
+	"was_frozen\x18\x01 \x01(\bR\twasFrozenB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_freeze_proto_rawDescOnce sync.Once
+	file_freeze_proto_rawDescData []byte
+)
+
+func file_freeze_proto_rawDescGZIP() []byte {
+	file_freeze_proto_rawDescOnce.Do(func() {
+		file_freeze_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_freeze_proto_rawDesc), len(file_freeze_proto_rawDesc)))
+	})
+	return file_freeze_proto_rawDescData
+}
+
+var file_freeze_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_freeze_proto_goTypes = []any{
+	(*FreezeState)(nil),             // 0: haproxy.v1.FreezeState
+	(*FreezeChangesRequest)(nil),    // 1: haproxy.v1.FreezeChangesRequest
+	(*FreezeChangesResponse)(nil),   // 2: haproxy.v1.FreezeChangesResponse
+	(*UnfreezeChangesRequest)(nil),  // 3: haproxy.v1.UnfreezeChangesRequest
+	(*UnfreezeChangesResponse)(nil), // 4: haproxy.v1.UnfreezeChangesResponse
+	(*timestamppb.Timestamp)(nil),   // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 6: google.protobuf.Duration
+}
+var file_freeze_proto_depIdxs = []int32{
+	5, // 0: haproxy.v1.FreezeState.since:type_name -> google.protobuf.Timestamp
+	5, // 1: haproxy.v1.FreezeState.expire_time:type_name -> google.protobuf.Timestamp
+	6, // 2: haproxy.v1.FreezeChangesRequest.duration:type_name -> google.protobuf.Duration
+	0, // 3: haproxy.v1.FreezeChangesResponse.freeze:type_name -> haproxy.v1.FreezeState
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_freeze_proto_init() }
+func file_freeze_proto_init() {
+	if File_freeze_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_freeze_proto_rawDesc), len(file_freeze_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_freeze_proto_goTypes,
+		DependencyIndexes: file_freeze_proto_depIdxs,
+		MessageInfos:      file_freeze_proto_msgTypes,
+	}.Build()
+	File_freeze_proto = out.File
+	file_freeze_proto_goTypes = nil
+	file_freeze_proto_depIdxs = nil
+}
@@ -23,16 +23,20 @@ const (
 
 // Frontend represents a HAProxy frontend configuration
 type Frontend struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	DefaultBackend string                 `protobuf:"bytes,1,opt,name=default_backend,json=defaultBackend,proto3" json:"default_backend,omitempty"`
-	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Disabled       bool                   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
-	Enabled        bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Id             int32                  `protobuf:"varint,5,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the frontend
-	Mode           ProxyMode              `protobuf:"varint,7,opt,name=mode,proto3,enum=haproxy.v1.ProxyMode" json:"mode,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DefaultBackend  string                 `protobuf:"bytes,1,opt,name=default_backend,json=defaultBackend,proto3" json:"default_backend,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Disabled        bool                   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Enabled         bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Id              int32                  `protobuf:"varint,5,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the frontend
+	Mode            ProxyMode              `protobuf:"varint,7,opt,name=mode,proto3,enum=haproxy.v1.ProxyMode" json:"mode,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Changes whenever the resource changes; set in responses only
+	// Kept in the metadata store of the configurator, as HAProxy has no place for them; see metadata.path
+	Labels        map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string `protobuf:"bytes,10,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Frontend) Reset() {
@@ -114,6 +118,27 @@ func (x *Frontend) GetMode() ProxyMode {
 	return ProxyMode_PROXY_MODE_UNSPECIFIED
 }
 
+func (x *Frontend) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *Frontend) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Frontend) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 type CreateFrontendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -214,6 +239,7 @@ type GetFrontendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NoCache       bool                   `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -262,6 +288,13 @@ func (x *GetFrontendRequest) GetName() string {
 	return ""
 }
 
+func (x *GetFrontendRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type GetFrontendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Frontend      *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
@@ -309,6 +342,9 @@ func (x *GetFrontendResponse) GetFrontend() *Frontend {
 type ListFrontendsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`  // "name" or "mode", prefixed with "-" for descending order; configuration order if empty
+	NoCache       bool                   `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -350,6 +386,27 @@ func (x *ListFrontendsRequest) GetTransactionId() string {
 	return ""
 }
 
+func (x *ListFrontendsRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListFrontendsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListFrontendsRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type ListFrontendsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Frontends     []*Frontend            `protobuf:"bytes,1,rep,name=frontends,proto3" json:"frontends,omitempty"`
@@ -395,12 +452,13 @@ func (x *ListFrontendsResponse) GetFrontends() []*Frontend {
 }
 
 type UpdateFrontendRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Frontend      *Frontend              `protobuf:"bytes,3,opt,name=frontend,proto3" json:"frontend,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Frontend        *Frontend              `protobuf:"bytes,3,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateFrontendRequest) Reset() {
@@ -454,6 +512,13 @@ func (x *UpdateFrontendRequest) GetFrontend() *Frontend {
 	return nil
 }
 
+func (x *UpdateFrontendRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type UpdateFrontendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Frontend      *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
@@ -499,11 +564,12 @@ func (x *UpdateFrontendResponse) GetFrontend() *Frontend {
 }
 
 type DeleteFrontendRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteFrontendRequest) Reset() {
@@ -550,6 +616,13 @@ func (x *DeleteFrontendRequest) GetName() string {
 	return ""
 }
 
+func (x *DeleteFrontendRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type DeleteFrontendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -586,12 +659,329 @@ func (*DeleteFrontendResponse) Descriptor() ([]byte, []int) {
 	return file_frontend_proto_rawDescGZIP(), []int{10}
 }
 
+// ApplyFrontend creates the frontend if it does not exist and replaces it if it differs
+type ApplyFrontendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Frontend      *Frontend              `protobuf:"bytes,2,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyFrontendRequest) Reset() {
+	*x = ApplyFrontendRequest{}
+	mi := &file_frontend_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyFrontendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyFrontendRequest) ProtoMessage() {}
+
+func (x *ApplyFrontendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyFrontendRequest.ProtoReflect.Descriptor instead.
+func (*ApplyFrontendRequest) Descriptor() ([]byte, []int) {
+	return file_frontend_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApplyFrontendRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ApplyFrontendRequest) GetFrontend() *Frontend {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+type ApplyFrontendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frontend      *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // False if the frontend already matched
+	Created       bool                   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"` // True if the frontend did not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyFrontendResponse) Reset() {
+	*x = ApplyFrontendResponse{}
+	mi := &file_frontend_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyFrontendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyFrontendResponse) ProtoMessage() {}
+
+func (x *ApplyFrontendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyFrontendResponse.ProtoReflect.Descriptor instead.
+func (*ApplyFrontendResponse) Descriptor() ([]byte, []int) {
+	return file_frontend_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApplyFrontendResponse) GetFrontend() *Frontend {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+func (x *ApplyFrontendResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ApplyFrontendResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// CreateHTTPSFrontend terminates TLS for a backend in one transaction: it stores the certificate, creates an
+// HTTP mode frontend with an SSL bind and, with redirect_http, a companion frontend redirecting plain HTTP to
+// HTTPS. The certificate is stored immediately, as certificate storage is not part of transactions.
+type CreateHTTPSFrontendRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId        string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`                       // Required
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                              // Required: name of the HTTPS frontend
+	DefaultBackend       string                 `protobuf:"bytes,3,opt,name=default_backend,json=defaultBackend,proto3" json:"default_backend,omitempty"`                    // Required
+	Address              string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`                                                        // Required: address of the SSL bind
+	Port                 int32                  `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`                                                             // 443 if unset
+	CertificateName      string                 `protobuf:"bytes,6,opt,name=certificate_name,json=certificateName,proto3" json:"certificate_name,omitempty"`                 // Required: storage name of the certificate, e.g. "example.com.pem"
+	CertificatePem       string                 `protobuf:"bytes,7,opt,name=certificate_pem,json=certificatePem,proto3" json:"certificate_pem,omitempty"`                    // Certificate chain and private key; the stored certificate is used if empty
+	OverwriteCertificate bool                   `protobuf:"varint,8,opt,name=overwrite_certificate,json=overwriteCertificate,proto3" json:"overwrite_certificate,omitempty"` // Replaces a stored certificate of the same name instead of failing
+	RedirectHttp         bool                   `protobuf:"varint,9,opt,name=redirect_http,json=redirectHttp,proto3" json:"redirect_http,omitempty"`                         // Also creates a frontend redirecting HTTP to HTTPS on the same address
+	HttpFrontendName     string                 `protobuf:"bytes,10,opt,name=http_frontend_name,json=httpFrontendName,proto3" json:"http_frontend_name,omitempty"`           // Name of the redirecting frontend; the name suffixed with "-http" if empty
+	HttpPort             int32                  `protobuf:"varint,11,opt,name=http_port,json=httpPort,proto3" json:"http_port,omitempty"`                                    // 80 if unset
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateHTTPSFrontendRequest) Reset() {
+	*x = CreateHTTPSFrontendRequest{}
+	mi := &file_frontend_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHTTPSFrontendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHTTPSFrontendRequest) ProtoMessage() {}
+
+func (x *CreateHTTPSFrontendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHTTPSFrontendRequest.ProtoReflect.Descriptor instead.
+func (*CreateHTTPSFrontendRequest) Descriptor() ([]byte, []int) {
+	return file_frontend_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateHTTPSFrontendRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetDefaultBackend() string {
+	if x != nil {
+		return x.DefaultBackend
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CreateHTTPSFrontendRequest) GetCertificateName() string {
+	if x != nil {
+		return x.CertificateName
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetCertificatePem() string {
+	if x != nil {
+		return x.CertificatePem
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetOverwriteCertificate() bool {
+	if x != nil {
+		return x.OverwriteCertificate
+	}
+	return false
+}
+
+func (x *CreateHTTPSFrontendRequest) GetRedirectHttp() bool {
+	if x != nil {
+		return x.RedirectHttp
+	}
+	return false
+}
+
+func (x *CreateHTTPSFrontendRequest) GetHttpFrontendName() string {
+	if x != nil {
+		return x.HttpFrontendName
+	}
+	return ""
+}
+
+func (x *CreateHTTPSFrontendRequest) GetHttpPort() int32 {
+	if x != nil {
+		return x.HttpPort
+	}
+	return 0
+}
+
+type CreateHTTPSFrontendResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Frontend        *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	Bind            *Bind                  `protobuf:"bytes,2,opt,name=bind,proto3" json:"bind,omitempty"`
+	HttpFrontend    *Frontend              `protobuf:"bytes,3,opt,name=http_frontend,json=httpFrontend,proto3" json:"http_frontend,omitempty"`          // Set with redirect_http
+	HttpBind        *Bind                  `protobuf:"bytes,4,opt,name=http_bind,json=httpBind,proto3" json:"http_bind,omitempty"`                      // Set with redirect_http
+	CertificateFile string                 `protobuf:"bytes,5,opt,name=certificate_file,json=certificateFile,proto3" json:"certificate_file,omitempty"` // Path of the certificate on the HAProxy host
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateHTTPSFrontendResponse) Reset() {
+	*x = CreateHTTPSFrontendResponse{}
+	mi := &file_frontend_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHTTPSFrontendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHTTPSFrontendResponse) ProtoMessage() {}
+
+func (x *CreateHTTPSFrontendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHTTPSFrontendResponse.ProtoReflect.Descriptor instead.
+func (*CreateHTTPSFrontendResponse) Descriptor() ([]byte, []int) {
+	return file_frontend_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateHTTPSFrontendResponse) GetFrontend() *Frontend {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+func (x *CreateHTTPSFrontendResponse) GetBind() *Bind {
+	if x != nil {
+		return x.Bind
+	}
+	return nil
+}
+
+func (x *CreateHTTPSFrontendResponse) GetHttpFrontend() *Frontend {
+	if x != nil {
+		return x.HttpFrontend
+	}
+	return nil
+}
+
+func (x *CreateHTTPSFrontendResponse) GetHttpBind() *Bind {
+	if x != nil {
+		return x.HttpBind
+	}
+	return nil
+}
+
+func (x *CreateHTTPSFrontendResponse) GetCertificateFile() string {
+	if x != nil {
+		return x.CertificateFile
+	}
+	return ""
+}
+
 var File_frontend_proto protoreflect.FileDescriptor
 
 const file_frontend_proto_rawDesc = "" +
 	"\n" +
 	"\x0efrontend.proto\x12\n" +
-	"haproxy.v1\x1a\fcommon.proto\"\xda\x01\n" +
+	"haproxy.v1\x1a\n" +
+	"bind.proto\x1a\fcommon.proto\"\x83\x04\n" +
 	"\bFrontend\x12'\n" +
 	"\x0fdefault_backend\x18\x01 \x01(\tR\x0edefaultBackend\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
@@ -599,31 +989,73 @@ const file_frontend_proto_rawDesc = "" +
 	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x0e\n" +
 	"\x02id\x18\x05 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x06 \x01(\tR\x04name\x12)\n" +
-	"\x04mode\x18\a \x01(\x0e2\x15.haproxy.v1.ProxyModeR\x04mode\"p\n" +
+	"\x04mode\x18\a \x01(\x0e2\x15.haproxy.v1.ProxyModeR\x04mode\x12)\n" +
+	"\x10resource_version\x18\b \x01(\tR\x0fresourceVersion\x128\n" +
+	"\x06labels\x18\t \x03(\v2 .haproxy.v1.Frontend.LabelsEntryR\x06labels\x12G\n" +
+	"\vannotations\x18\n" +
+	" \x03(\v2%.haproxy.v1.Frontend.AnnotationsEntryR\vannotations\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"p\n" +
 	"\x15CreateFrontendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x120\n" +
 	"\bfrontend\x18\x02 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"J\n" +
 	"\x16CreateFrontendResponse\x120\n" +
-	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"O\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"j\n" +
 	"\x12GetFrontendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"G\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bno_cache\x18\x03 \x01(\bR\anoCache\"G\n" +
 	"\x13GetFrontendResponse\x120\n" +
-	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"=\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"\xa3\x01\n" +
 	"\x14ListFrontendsRequest\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"K\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x19\n" +
+	"\bno_cache\x18\x04 \x01(\bR\anoCache\"K\n" +
 	"\x15ListFrontendsResponse\x122\n" +
-	"\tfrontends\x18\x01 \x03(\v2\x14.haproxy.v1.FrontendR\tfrontends\"\x84\x01\n" +
+	"\tfrontends\x18\x01 \x03(\v2\x14.haproxy.v1.FrontendR\tfrontends\"\xaf\x01\n" +
 	"\x15UpdateFrontendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
-	"\bfrontend\x18\x03 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"J\n" +
+	"\bfrontend\x18\x03 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"J\n" +
 	"\x16UpdateFrontendResponse\x120\n" +
-	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"R\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"}\n" +
 	"\x15DeleteFrontendRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\x18\n" +
-	"\x16DeleteFrontendResponseB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\tR\x0fexpectedVersion\"\x18\n" +
+	"\x16DeleteFrontendResponse\"o\n" +
+	"\x14ApplyFrontendRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x120\n" +
+	"\bfrontend\x18\x02 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\"}\n" +
+	"\x15ApplyFrontendResponse\x120\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreated\"\xa7\x03\n" +
+	"\x1aCreateHTTPSFrontendRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x0fdefault_backend\x18\x03 \x01(\tR\x0edefaultBackend\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\x05 \x01(\x05R\x04port\x12)\n" +
+	"\x10certificate_name\x18\x06 \x01(\tR\x0fcertificateName\x12'\n" +
+	"\x0fcertificate_pem\x18\a \x01(\tR\x0ecertificatePem\x123\n" +
+	"\x15overwrite_certificate\x18\b \x01(\bR\x14overwriteCertificate\x12#\n" +
+	"\rredirect_http\x18\t \x01(\bR\fredirectHttp\x12,\n" +
+	"\x12http_frontend_name\x18\n" +
+	" \x01(\tR\x10httpFrontendName\x12\x1b\n" +
+	"\thttp_port\x18\v \x01(\x05R\bhttpPort\"\x8a\x02\n" +
+	"\x1bCreateHTTPSFrontendResponse\x120\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\x12$\n" +
+	"\x04bind\x18\x02 \x01(\v2\x10.haproxy.v1.BindR\x04bind\x129\n" +
+	"\rhttp_frontend\x18\x03 \x01(\v2\x14.haproxy.v1.FrontendR\fhttpFrontend\x12-\n" +
+	"\thttp_bind\x18\x04 \x01(\v2\x10.haproxy.v1.BindR\bhttpBind\x12)\n" +
+	"\x10certificate_file\x18\x05 \x01(\tR\x0fcertificateFileB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
 
 var (
 	file_frontend_proto_rawDescOnce sync.Once
@@ -637,34 +1069,51 @@ func file_frontend_proto_rawDescGZIP() []byte {
 	return file_frontend_proto_rawDescData
 }
 
-var file_frontend_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_frontend_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_frontend_proto_goTypes = []any{
-	(*Frontend)(nil),               // 0: haproxy.v1.Frontend
-	(*CreateFrontendRequest)(nil),  // 1: haproxy.v1.CreateFrontendRequest
-	(*CreateFrontendResponse)(nil), // 2: haproxy.v1.CreateFrontendResponse
-	(*GetFrontendRequest)(nil),     // 3: haproxy.v1.GetFrontendRequest
-	(*GetFrontendResponse)(nil),    // 4: haproxy.v1.GetFrontendResponse
-	(*ListFrontendsRequest)(nil),   // 5: haproxy.v1.ListFrontendsRequest
-	(*ListFrontendsResponse)(nil),  // 6: haproxy.v1.ListFrontendsResponse
-	(*UpdateFrontendRequest)(nil),  // 7: haproxy.v1.UpdateFrontendRequest
-	(*UpdateFrontendResponse)(nil), // 8: haproxy.v1.UpdateFrontendResponse
-	(*DeleteFrontendRequest)(nil),  // 9: haproxy.v1.DeleteFrontendRequest
-	(*DeleteFrontendResponse)(nil), // 10: haproxy.v1.DeleteFrontendResponse
-	(ProxyMode)(0),                 // 11: haproxy.v1.ProxyMode
+	(*Frontend)(nil),                    // 0: haproxy.v1.Frontend
+	(*CreateFrontendRequest)(nil),       // 1: haproxy.v1.CreateFrontendRequest
+	(*CreateFrontendResponse)(nil),      // 2: haproxy.v1.CreateFrontendResponse
+	(*GetFrontendRequest)(nil),          // 3: haproxy.v1.GetFrontendRequest
+	(*GetFrontendResponse)(nil),         // 4: haproxy.v1.GetFrontendResponse
+	(*ListFrontendsRequest)(nil),        // 5: haproxy.v1.ListFrontendsRequest
+	(*ListFrontendsResponse)(nil),       // 6: haproxy.v1.ListFrontendsResponse
+	(*UpdateFrontendRequest)(nil),       // 7: haproxy.v1.UpdateFrontendRequest
+	(*UpdateFrontendResponse)(nil),      // 8: haproxy.v1.UpdateFrontendResponse
+	(*DeleteFrontendRequest)(nil),       // 9: haproxy.v1.DeleteFrontendRequest
+	(*DeleteFrontendResponse)(nil),      // 10: haproxy.v1.DeleteFrontendResponse
+	(*ApplyFrontendRequest)(nil),        // 11: haproxy.v1.ApplyFrontendRequest
+	(*ApplyFrontendResponse)(nil),       // 12: haproxy.v1.ApplyFrontendResponse
+	(*CreateHTTPSFrontendRequest)(nil),  // 13: haproxy.v1.CreateHTTPSFrontendRequest
+	(*CreateHTTPSFrontendResponse)(nil), // 14: haproxy.v1.CreateHTTPSFrontendResponse
+	nil,                                 // 15: haproxy.v1.Frontend.LabelsEntry
+	nil,                                 // 16: haproxy.v1.Frontend.AnnotationsEntry
+	(ProxyMode)(0),                      // 17: haproxy.v1.ProxyMode
+	(*ListFilter)(nil),                  // 18: haproxy.v1.ListFilter
+	(*Bind)(nil),                        // 19: haproxy.v1.Bind
 }
 var file_frontend_proto_depIdxs = []int32{
-	11, // 0: haproxy.v1.Frontend.mode:type_name -> haproxy.v1.ProxyMode
-	0,  // 1: haproxy.v1.CreateFrontendRequest.frontend:type_name -> haproxy.v1.Frontend
-	0,  // 2: haproxy.v1.CreateFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
-	0,  // 3: haproxy.v1.GetFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
-	0,  // 4: haproxy.v1.ListFrontendsResponse.frontends:type_name -> haproxy.v1.Frontend
-	0,  // 5: haproxy.v1.UpdateFrontendRequest.frontend:type_name -> haproxy.v1.Frontend
-	0,  // 6: haproxy.v1.UpdateFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
-	7,  // [7:7] is the sub-list for method output_type
-	7,  // [7:7] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	17, // 0: haproxy.v1.Frontend.mode:type_name -> haproxy.v1.ProxyMode
+	15, // 1: haproxy.v1.Frontend.labels:type_name -> haproxy.v1.Frontend.LabelsEntry
+	16, // 2: haproxy.v1.Frontend.annotations:type_name -> haproxy.v1.Frontend.AnnotationsEntry
+	0,  // 3: haproxy.v1.CreateFrontendRequest.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 4: haproxy.v1.CreateFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 5: haproxy.v1.GetFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
+	18, // 6: haproxy.v1.ListFrontendsRequest.filter:type_name -> haproxy.v1.ListFilter
+	0,  // 7: haproxy.v1.ListFrontendsResponse.frontends:type_name -> haproxy.v1.Frontend
+	0,  // 8: haproxy.v1.UpdateFrontendRequest.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 9: haproxy.v1.UpdateFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 10: haproxy.v1.ApplyFrontendRequest.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 11: haproxy.v1.ApplyFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
+	0,  // 12: haproxy.v1.CreateHTTPSFrontendResponse.frontend:type_name -> haproxy.v1.Frontend
+	19, // 13: haproxy.v1.CreateHTTPSFrontendResponse.bind:type_name -> haproxy.v1.Bind
+	0,  // 14: haproxy.v1.CreateHTTPSFrontendResponse.http_frontend:type_name -> haproxy.v1.Frontend
+	19, // 15: haproxy.v1.CreateHTTPSFrontendResponse.http_bind:type_name -> haproxy.v1.Bind
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_frontend_proto_init() }
@@ -672,6 +1121,7 @@ func file_frontend_proto_init() {
 	if File_frontend_proto != nil {
 		return
 	}
+	file_bind_proto_init()
 	file_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -679,7 +1129,7 @@ func file_frontend_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontend_proto_rawDesc), len(file_frontend_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,233 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: gitops.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetGitOpsStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGitOpsStatusRequest) Reset() {
+	*x = GetGitOpsStatusRequest{}
+	mi := &file_gitops_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGitOpsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGitOpsStatusRequest) ProtoMessage() {}
+
+func (x *GetGitOpsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGitOpsStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetGitOpsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_gitops_proto_rawDescGZIP(), []int{0}
+}
+
+// GetGitOpsStatusResponse reports the progress of GitOps reconciliation
+type GetGitOpsStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Enabled         bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Source          string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                                          // Manifest directory, or repository@branch
+	Revision        string                 `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`                                      // Last revision applied successfully
+	FetchedRevision string                 `protobuf:"bytes,4,opt,name=fetched_revision,json=fetchedRevision,proto3" json:"fetched_revision,omitempty"` // Last revision read from the source; differs from revision while it fails to apply
+	LastSyncTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+	LastAppliedTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_applied_time,json=lastAppliedTime,proto3" json:"last_applied_time,omitempty"` // Last time a new revision or a correction was applied
+	LastError       string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                     // Error of the last reconciliation, empty if it succeeded
+	LastChanges     []*StateChange         `protobuf:"bytes,8,rep,name=last_changes,json=lastChanges,proto3" json:"last_changes,omitempty"`               // Operations of the last apply that changed anything
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetGitOpsStatusResponse) Reset() {
+	*x = GetGitOpsStatusResponse{}
+	mi := &file_gitops_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGitOpsStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGitOpsStatusResponse) ProtoMessage() {}
+
+func (x *GetGitOpsStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGitOpsStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetGitOpsStatusResponse) Descriptor() ([]byte, []int) {
+	return file_gitops_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetGitOpsStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetGitOpsStatusResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetGitOpsStatusResponse) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *GetGitOpsStatusResponse) GetFetchedRevision() string {
+	if x != nil {
+		return x.FetchedRevision
+	}
+	return ""
+}
+
+func (x *GetGitOpsStatusResponse) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+func (x *GetGitOpsStatusResponse) GetLastAppliedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAppliedTime
+	}
+	return nil
+}
+
+func (x *GetGitOpsStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *GetGitOpsStatusResponse) GetLastChanges() []*StateChange {
+	if x != nil {
+		return x.LastChanges
+	}
+	return nil
+}
+
+var File_gitops_proto protoreflect.FileDescriptor
+
+const file_gitops_proto_rawDesc = "" +
+	"\n" +
+	"\fgitops.proto\x12\n" +
+	"haproxy.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\vstate.proto\"\x18\n" +
+	"\x16GetGitOpsStatusRequest\"\xf7\x02\n" +
+	"\x17GetGitOpsStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\tR\brevision\x12)\n" +
+	"\x10fetched_revision\x18\x04 \x01(\tR\x0ffetchedRevision\x12@\n" +
+	"\x0elast_sync_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\x12F\n" +
+	"\x11last_applied_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastAppliedTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12:\n" +
+	"\flast_changes\x18\b \x03(\v2\x17.haproxy.v1.StateChangeR\vlastChangesB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_gitops_proto_rawDescOnce sync.Once
+	file_gitops_proto_rawDescData []byte
+)
+
+func file_gitops_proto_rawDescGZIP() []byte {
+	file_gitops_proto_rawDescOnce.Do(func() {
+		file_gitops_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gitops_proto_rawDesc), len(file_gitops_proto_rawDesc)))
+	})
+	return file_gitops_proto_rawDescData
+}
+
+var file_gitops_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gitops_proto_goTypes = []any{
+	(*GetGitOpsStatusRequest)(nil),  // 0: haproxy.v1.GetGitOpsStatusRequest
+	(*GetGitOpsStatusResponse)(nil), // 1: haproxy.v1.GetGitOpsStatusResponse
+	(*timestamppb.Timestamp)(nil),   // 2: google.protobuf.Timestamp
+	(*StateChange)(nil),             // 3: haproxy.v1.StateChange
+}
+var file_gitops_proto_depIdxs = []int32{
+	2, // 0: haproxy.v1.GetGitOpsStatusResponse.last_sync_time:type_name -> google.protobuf.Timestamp
+	2, // 1: haproxy.v1.GetGitOpsStatusResponse.last_applied_time:type_name -> google.protobuf.Timestamp
+	3, // 2: haproxy.v1.GetGitOpsStatusResponse.last_changes:type_name -> haproxy.v1.StateChange
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_gitops_proto_init() }
+func file_gitops_proto_init() {
+	if File_gitops_proto != nil {
+		return
+	}
+	file_state_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gitops_proto_rawDesc), len(file_gitops_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gitops_proto_goTypes,
+		DependencyIndexes: file_gitops_proto_depIdxs,
+		MessageInfos:      file_gitops_proto_msgTypes,
+	}.Build()
+	File_gitops_proto = out.File
+	file_gitops_proto_goTypes = nil
+	file_gitops_proto_depIdxs = nil
+}
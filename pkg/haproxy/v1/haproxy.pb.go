@@ -7,6 +7,7 @@
 package v1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -25,148 +26,562 @@ var File_haproxy_proto protoreflect.FileDescriptor
 const file_haproxy_proto_rawDesc = "" +
 	"\n" +
 	"\rhaproxy.proto\x12\n" +
-	"haproxy.v1\x1a\x11transaction.proto\x1a\rbackend.proto\x1a\x0efrontend.proto\x1a\n" +
-	"bind.proto\x1a\fserver.proto2\xbb\x10\n" +
-	"\x15HAProxyManagerService\x12K\n" +
+	"haproxy.v1\x1a\x11transaction.proto\x1a\rbackend.proto\x1a\rcluster.proto\x1a\x10deployment.proto\x1a\x0fdiscovery.proto\x1a\vdrift.proto\x1a\ffreeze.proto\x1a\x0efrontend.proto\x1a\n" +
+	"bind.proto\x1a\fserver.proto\x1a\vevent.proto\x1a\fgitops.proto\x1a\n" +
+	"info.proto\x1a\tlua.proto\x1a\x11maintenance.proto\x1a\x0emetadata.proto\x1a\rnetplan.proto\x1a\n" +
+	"peer.proto\x1a\x0fratelimit.proto\x1a\x0fhttpcheck.proto\x1a\rprogram.proto\x1a\n" +
+	"ring.proto\x1a\vroute.proto\x1a\rruntime.proto\x1a\vstate.proto\x1a\x1cgoogle/api/annotations.proto2\x81i\n" +
+	"\x15HAProxyManagerService\x12f\n" +
+	"\rGetServerInfo\x12 .haproxy.v1.GetServerInfoRequest\x1a!.haproxy.v1.GetServerInfoResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/info\x12\\\n" +
+	"\tGetStatus\x12\x1c.haproxy.v1.GetStatusRequest\x1a\x1d.haproxy.v1.GetStatusResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/status\x12o\n" +
+	"\fReloadConfig\x12\x1f.haproxy.v1.ReloadConfigRequest\x1a .haproxy.v1.ReloadConfigResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/config:reload\x12s\n" +
+	"\rFreezeChanges\x12 .haproxy.v1.FreezeChangesRequest\x1a!.haproxy.v1.FreezeChangesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/changes:freeze\x12{\n" +
+	"\x0fUnfreezeChanges\x12\".haproxy.v1.UnfreezeChangesRequest\x1a#.haproxy.v1.UnfreezeChangesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/changes:unfreeze\x12`\n" +
 	"\n" +
-	"GetVersion\x12\x1d.haproxy.v1.GetVersionRequest\x1a\x1e.haproxy.v1.GetVersionResponse\x12`\n" +
-	"\x11CreateTransaction\x12$.haproxy.v1.CreateTransactionRequest\x1a%.haproxy.v1.CreateTransactionResponse\x12W\n" +
-	"\x0eGetTransaction\x12!.haproxy.v1.GetTransactionRequest\x1a\".haproxy.v1.GetTransactionResponse\x12`\n" +
-	"\x11CommitTransaction\x12$.haproxy.v1.CommitTransactionRequest\x1a%.haproxy.v1.CommitTransactionResponse\x12]\n" +
-	"\x10CloseTransaction\x12#.haproxy.v1.CloseTransactionRequest\x1a$.haproxy.v1.CloseTransactionResponse\x12T\n" +
-	"\rCreateBackend\x12 .haproxy.v1.CreateBackendRequest\x1a!.haproxy.v1.CreateBackendResponse\x12K\n" +
+	"GetVersion\x12\x1d.haproxy.v1.GetVersionRequest\x1a\x1e.haproxy.v1.GetVersionResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/version\x12}\n" +
+	"\x11CreateTransaction\x12$.haproxy.v1.CreateTransactionRequest\x1a%.haproxy.v1.CreateTransactionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/transactions\x12\x82\x01\n" +
+	"\x0eGetTransaction\x12!.haproxy.v1.GetTransactionRequest\x1a\".haproxy.v1.GetTransactionResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/transactions/{transaction_id}\x12w\n" +
+	"\x10ListTransactions\x12#.haproxy.v1.ListTransactionsRequest\x1a$.haproxy.v1.ListTransactionsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/transactions\x12\x8a\x01\n" +
+	"\x0fDiffTransaction\x12\".haproxy.v1.DiffTransactionRequest\x1a#.haproxy.v1.DiffTransactionResponse\".\x82\xd3\xe4\x93\x02(\x12&/v1/transactions/{transaction_id}/diff\x12\x92\x01\n" +
+	"\x11CommitTransaction\x12$.haproxy.v1.CommitTransactionRequest\x1a%.haproxy.v1.CommitTransactionResponse\"0\x82\xd3\xe4\x93\x02*\"(/v1/transactions/{transaction_id}/commit\x12\x88\x01\n" +
+	"\x10CloseTransaction\x12#.haproxy.v1.CloseTransactionRequest\x1a$.haproxy.v1.CloseTransactionResponse\")\x82\xd3\xe4\x93\x02#*!/v1/transactions/{transaction_id}\x12s\n" +
+	"\rCreateBackend\x12 .haproxy.v1.CreateBackendRequest\x1a!.haproxy.v1.CreateBackendResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\abackend\"\f/v1/backends\x12h\n" +
 	"\n" +
-	"GetBackend\x12\x1d.haproxy.v1.GetBackendRequest\x1a\x1e.haproxy.v1.GetBackendResponse\x12Q\n" +
-	"\fListBackends\x12\x1f.haproxy.v1.ListBackendsRequest\x1a .haproxy.v1.ListBackendsResponse\x12T\n" +
-	"\rUpdateBackend\x12 .haproxy.v1.UpdateBackendRequest\x1a!.haproxy.v1.UpdateBackendResponse\x12T\n" +
-	"\rDeleteBackend\x12 .haproxy.v1.DeleteBackendRequest\x1a!.haproxy.v1.DeleteBackendResponse\x12W\n" +
-	"\x0eCreateFrontend\x12!.haproxy.v1.CreateFrontendRequest\x1a\".haproxy.v1.CreateFrontendResponse\x12N\n" +
-	"\vGetFrontend\x12\x1e.haproxy.v1.GetFrontendRequest\x1a\x1f.haproxy.v1.GetFrontendResponse\x12T\n" +
-	"\rListFrontends\x12 .haproxy.v1.ListFrontendsRequest\x1a!.haproxy.v1.ListFrontendsResponse\x12W\n" +
-	"\x0eUpdateFrontend\x12!.haproxy.v1.UpdateFrontendRequest\x1a\".haproxy.v1.UpdateFrontendResponse\x12W\n" +
-	"\x0eDeleteFrontend\x12!.haproxy.v1.DeleteFrontendRequest\x1a\".haproxy.v1.DeleteFrontendResponse\x12K\n" +
+	"GetBackend\x12\x1d.haproxy.v1.GetBackendRequest\x1a\x1e.haproxy.v1.GetBackendResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/backends/{name}\x12g\n" +
+	"\fListBackends\x12\x1f.haproxy.v1.ListBackendsRequest\x1a .haproxy.v1.ListBackendsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/backends\x12v\n" +
+	"\x0eStreamBackends\x12!.haproxy.v1.StreamBackendsRequest\x1a\".haproxy.v1.StreamBackendsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/backends:stream0\x01\x12z\n" +
+	"\rUpdateBackend\x12 .haproxy.v1.UpdateBackendRequest\x1a!.haproxy.v1.UpdateBackendResponse\"$\x82\xd3\xe4\x93\x02\x1e:\abackend\x1a\x13/v1/backends/{name}\x12q\n" +
+	"\rDeleteBackend\x12 .haproxy.v1.DeleteBackendRequest\x1a!.haproxy.v1.DeleteBackendResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/backends/{name}\x12\x85\x01\n" +
+	"\fApplyBackend\x12\x1f.haproxy.v1.ApplyBackendRequest\x1a .haproxy.v1.ApplyBackendResponse\"2\x82\xd3\xe4\x93\x02,:\abackend\x1a!/v1/backends/{backend.name}:apply\x12x\n" +
+	"\x0eCreateFrontend\x12!.haproxy.v1.CreateFrontendRequest\x1a\".haproxy.v1.CreateFrontendResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\bfrontend\"\r/v1/frontends\x12l\n" +
+	"\vGetFrontend\x12\x1e.haproxy.v1.GetFrontendRequest\x1a\x1f.haproxy.v1.GetFrontendResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/frontends/{name}\x12k\n" +
+	"\rListFrontends\x12 .haproxy.v1.ListFrontendsRequest\x1a!.haproxy.v1.ListFrontendsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/frontends\x12\x7f\n" +
+	"\x0eUpdateFrontend\x12!.haproxy.v1.UpdateFrontendRequest\x1a\".haproxy.v1.UpdateFrontendResponse\"&\x82\xd3\xe4\x93\x02 :\bfrontend\x1a\x14/v1/frontends/{name}\x12u\n" +
+	"\x0eDeleteFrontend\x12!.haproxy.v1.DeleteFrontendRequest\x1a\".haproxy.v1.DeleteFrontendResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/frontends/{name}\x12\x8b\x01\n" +
+	"\rApplyFrontend\x12 .haproxy.v1.ApplyFrontendRequest\x1a!.haproxy.v1.ApplyFrontendResponse\"5\x82\xd3\xe4\x93\x02/:\bfrontend\x1a#/v1/frontends/{frontend.name}:apply\x12\x8c\x01\n" +
+	"\x13CreateHTTPSFrontend\x12&.haproxy.v1.CreateHTTPSFrontendRequest\x1a'.haproxy.v1.CreateHTTPSFrontendResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/frontends:createHttps\x12\x88\x01\n" +
+	"\fSwapBackends\x12\x1f.haproxy.v1.SwapBackendsRequest\x1a .haproxy.v1.SwapBackendsResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/frontends/{frontend_name}:swapBackends\x12\x86\x01\n" +
+	"\fShiftTraffic\x12\x1f.haproxy.v1.ShiftTrafficRequest\x1a .haproxy.v1.ShiftTrafficResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/v1/backends/{backend_name}:shiftTraffic\x12~\n" +
 	"\n" +
-	"CreateBind\x12\x1d.haproxy.v1.CreateBindRequest\x1a\x1e.haproxy.v1.CreateBindResponse\x12B\n" +
-	"\aGetBind\x12\x1a.haproxy.v1.GetBindRequest\x1a\x1b.haproxy.v1.GetBindResponse\x12H\n" +
-	"\tListBinds\x12\x1c.haproxy.v1.ListBindsRequest\x1a\x1d.haproxy.v1.ListBindsResponse\x12K\n" +
+	"CreateBind\x12\x1d.haproxy.v1.CreateBindRequest\x1a\x1e.haproxy.v1.CreateBindResponse\"1\x82\xd3\xe4\x93\x02+:\x04bind\"#/v1/frontends/{frontend_name}/binds\x12v\n" +
+	"\aGetBind\x12\x1a.haproxy.v1.GetBindRequest\x1a\x1b.haproxy.v1.GetBindResponse\"2\x82\xd3\xe4\x93\x02,\x12*/v1/frontends/{frontend_name}/binds/{name}\x12u\n" +
+	"\tListBinds\x12\x1c.haproxy.v1.ListBindsRequest\x1a\x1d.haproxy.v1.ListBindsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/frontends/{frontend_name}/binds\x12\x8a\x01\n" +
 	"\n" +
-	"UpdateBind\x12\x1d.haproxy.v1.UpdateBindRequest\x1a\x1e.haproxy.v1.UpdateBindResponse\x12K\n" +
+	"UpdateBind\x12\x1d.haproxy.v1.UpdateBindRequest\x1a\x1e.haproxy.v1.UpdateBindResponse\"=\x82\xd3\xe4\x93\x027:\x04bind\x1a//v1/frontends/{frontend_name}/binds/{bind.name}\x12\x7f\n" +
 	"\n" +
-	"DeleteBind\x12\x1d.haproxy.v1.DeleteBindRequest\x1a\x1e.haproxy.v1.DeleteBindResponse\x12Q\n" +
-	"\fCreateServer\x12\x1f.haproxy.v1.CreateServerRequest\x1a .haproxy.v1.CreateServerResponse\x12H\n" +
-	"\tGetServer\x12\x1c.haproxy.v1.GetServerRequest\x1a\x1d.haproxy.v1.GetServerResponse\x12N\n" +
-	"\vListServers\x12\x1e.haproxy.v1.ListServersRequest\x1a\x1f.haproxy.v1.ListServersResponse\x12Q\n" +
-	"\fUpdateServer\x12\x1f.haproxy.v1.UpdateServerRequest\x1a .haproxy.v1.UpdateServerResponse\x12Q\n" +
-	"\fDeleteServer\x12\x1f.haproxy.v1.DeleteServerRequest\x1a .haproxy.v1.DeleteServerResponseB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+	"DeleteBind\x12\x1d.haproxy.v1.DeleteBindRequest\x1a\x1e.haproxy.v1.DeleteBindResponse\"2\x82\xd3\xe4\x93\x02,**/v1/frontends/{frontend_name}/binds/{name}\x12\x8d\x01\n" +
+	"\tApplyBind\x12\x1c.haproxy.v1.ApplyBindRequest\x1a\x1d.haproxy.v1.ApplyBindResponse\"C\x82\xd3\xe4\x93\x02=:\x04bind\x1a5/v1/frontends/{frontend_name}/binds/{bind.name}:apply\x12\x83\x01\n" +
+	"\vCreateRoute\x12\x1e.haproxy.v1.CreateRouteRequest\x1a\x1f.haproxy.v1.CreateRouteResponse\"3\x82\xd3\xe4\x93\x02-:\x05route\"$/v1/frontends/{frontend_name}/routes\x12z\n" +
+	"\bGetRoute\x12\x1b.haproxy.v1.GetRouteRequest\x1a\x1c.haproxy.v1.GetRouteResponse\"3\x82\xd3\xe4\x93\x02-\x12+/v1/frontends/{frontend_name}/routes/{name}\x12y\n" +
+	"\n" +
+	"ListRoutes\x12\x1d.haproxy.v1.ListRoutesRequest\x1a\x1e.haproxy.v1.ListRoutesResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/frontends/{frontend_name}/routes\x12\x90\x01\n" +
+	"\vUpdateRoute\x12\x1e.haproxy.v1.UpdateRouteRequest\x1a\x1f.haproxy.v1.UpdateRouteResponse\"@\x82\xd3\xe4\x93\x02::\x05route\x1a1/v1/frontends/{frontend_name}/routes/{route.name}\x12\x83\x01\n" +
+	"\vDeleteRoute\x12\x1e.haproxy.v1.DeleteRouteRequest\x1a\x1f.haproxy.v1.DeleteRouteResponse\"3\x82\xd3\xe4\x93\x02-*+/v1/frontends/{frontend_name}/routes/{name}\x12\xa7\x01\n" +
+	"\x15CreateRateLimitPolicy\x12(.haproxy.v1.CreateRateLimitPolicyRequest\x1a).haproxy.v1.CreateRateLimitPolicyResponse\"9\x82\xd3\xe4\x93\x023:\x06policy\")/v1/frontends/{frontend_name}/rate-limits\x12\x9d\x01\n" +
+	"\x12GetRateLimitPolicy\x12%.haproxy.v1.GetRateLimitPolicyRequest\x1a&.haproxy.v1.GetRateLimitPolicyResponse\"8\x82\xd3\xe4\x93\x022\x120/v1/frontends/{frontend_name}/rate-limits/{name}\x12\x9f\x01\n" +
+	"\x15ListRateLimitPolicies\x12(.haproxy.v1.ListRateLimitPoliciesRequest\x1a).haproxy.v1.ListRateLimitPoliciesResponse\"1\x82\xd3\xe4\x93\x02+\x12)/v1/frontends/{frontend_name}/rate-limits\x12\xb5\x01\n" +
+	"\x15UpdateRateLimitPolicy\x12(.haproxy.v1.UpdateRateLimitPolicyRequest\x1a).haproxy.v1.UpdateRateLimitPolicyResponse\"G\x82\xd3\xe4\x93\x02A:\x06policy\x1a7/v1/frontends/{frontend_name}/rate-limits/{policy.name}\x12\xa6\x01\n" +
+	"\x15DeleteRateLimitPolicy\x12(.haproxy.v1.DeleteRateLimitPolicyRequest\x1a).haproxy.v1.DeleteRateLimitPolicyResponse\"8\x82\xd3\xe4\x93\x022*0/v1/frontends/{frontend_name}/rate-limits/{name}\x12v\n" +
+	"\x0fUploadLuaScript\x12\".haproxy.v1.UploadLuaScriptRequest\x1a#.haproxy.v1.UploadLuaScriptResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/lua-scripts\x12q\n" +
+	"\fGetLuaScript\x12\x1f.haproxy.v1.GetLuaScriptRequest\x1a .haproxy.v1.GetLuaScriptResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/lua-scripts/{name}\x12p\n" +
+	"\x0eListLuaScripts\x12!.haproxy.v1.ListLuaScriptsRequest\x1a\".haproxy.v1.ListLuaScriptsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/lua-scripts\x12|\n" +
+	"\rLoadLuaScript\x12 .haproxy.v1.LoadLuaScriptRequest\x1a!.haproxy.v1.LoadLuaScriptResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/lua-scripts/{name}:load\x12\x8c\x01\n" +
+	"\x11RollbackLuaScript\x12$.haproxy.v1.RollbackLuaScriptRequest\x1a%.haproxy.v1.RollbackLuaScriptResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/lua-scripts/{name}:rollback\x12\x84\x01\n" +
+	"\x0fUnloadLuaScript\x12\".haproxy.v1.UnloadLuaScriptRequest\x1a#.haproxy.v1.UnloadLuaScriptResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/lua-scripts/{name}:unload\x12z\n" +
+	"\x0fDeleteLuaScript\x12\".haproxy.v1.DeleteLuaScriptRequest\x1a#.haproxy.v1.DeleteLuaScriptResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/lua-scripts/{name}\x12\x95\x01\n" +
+	"\x0fCreateLuaAction\x12\".haproxy.v1.CreateLuaActionRequest\x1a#.haproxy.v1.CreateLuaActionResponse\"9\x82\xd3\xe4\x93\x023:\x06action\")/v1/frontends/{frontend_name}/lua-actions\x12\x8a\x01\n" +
+	"\x0eListLuaActions\x12!.haproxy.v1.ListLuaActionsRequest\x1a\".haproxy.v1.ListLuaActionsResponse\"1\x82\xd3\xe4\x93\x02+\x12)/v1/frontends/{frontend_name}/lua-actions\x12\x94\x01\n" +
+	"\x0fDeleteLuaAction\x12\".haproxy.v1.DeleteLuaActionRequest\x1a#.haproxy.v1.DeleteLuaActionResponse\"8\x82\xd3\xe4\x93\x022*0/v1/frontends/{frontend_name}/lua-actions/{name}\x12d\n" +
+	"\n" +
+	"CreateRing\x12\x1d.haproxy.v1.CreateRingRequest\x1a\x1e.haproxy.v1.CreateRingResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x04ring\"\t/v1/rings\x12\\\n" +
+	"\aGetRing\x12\x1a.haproxy.v1.GetRingRequest\x1a\x1b.haproxy.v1.GetRingResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/rings/{name}\x12[\n" +
+	"\tListRings\x12\x1c.haproxy.v1.ListRingsRequest\x1a\x1d.haproxy.v1.ListRingsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/rings\x12p\n" +
+	"\n" +
+	"UpdateRing\x12\x1d.haproxy.v1.UpdateRingRequest\x1a\x1e.haproxy.v1.UpdateRingResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x04ring\x1a\x15/v1/rings/{ring.name}\x12e\n" +
+	"\n" +
+	"DeleteRing\x12\x1d.haproxy.v1.DeleteRingRequest\x1a\x1e.haproxy.v1.DeleteRingResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/rings/{name}\x12\x87\x01\n" +
+	"\x13AttachRingLogTarget\x12&.haproxy.v1.AttachRingLogTargetRequest\x1a'.haproxy.v1.AttachRingLogTargetResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/ring-log-targets\x12\x81\x01\n" +
+	"\x12ListRingLogTargets\x12%.haproxy.v1.ListRingLogTargetsRequest\x1a&.haproxy.v1.ListRingLogTargetsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/ring-log-targets\x12\x8b\x01\n" +
+	"\x13DetachRingLogTarget\x12&.haproxy.v1.DetachRingLogTargetRequest\x1a'.haproxy.v1.DetachRingLogTargetResponse\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/ring-log-targets/{ring}\x12n\n" +
+	"\n" +
+	"StreamRing\x12\x1d.haproxy.v1.StreamRingRequest\x1a\x1e.haproxy.v1.StreamRingResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/rings/{name}:stream0\x01\x12s\n" +
+	"\rCreateProgram\x12 .haproxy.v1.CreateProgramRequest\x1a!.haproxy.v1.CreateProgramResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\aprogram\"\f/v1/programs\x12h\n" +
+	"\n" +
+	"GetProgram\x12\x1d.haproxy.v1.GetProgramRequest\x1a\x1e.haproxy.v1.GetProgramResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/programs/{name}\x12g\n" +
+	"\fListPrograms\x12\x1f.haproxy.v1.ListProgramsRequest\x1a .haproxy.v1.ListProgramsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/programs\x12\x82\x01\n" +
+	"\rUpdateProgram\x12 .haproxy.v1.UpdateProgramRequest\x1a!.haproxy.v1.UpdateProgramResponse\",\x82\xd3\xe4\x93\x02&:\aprogram\x1a\x1b/v1/programs/{program.name}\x12q\n" +
+	"\rDeleteProgram\x12 .haproxy.v1.DeleteProgramRequest\x1a!.haproxy.v1.DeleteProgramResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/programs/{name}\x12\x8e\x01\n" +
+	"\x0fCreateHTTPCheck\x12\".haproxy.v1.CreateHTTPCheckRequest\x1a#.haproxy.v1.CreateHTTPCheckResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/backends/{backend_name}/http-checks\x12\x88\x01\n" +
+	"\x0eListHTTPChecks\x12!.haproxy.v1.ListHTTPChecksRequest\x1a\".haproxy.v1.ListHTTPChecksResponse\"/\x82\xd3\xe4\x93\x02)\x12'/v1/backends/{backend_name}/http-checks\x12\x96\x01\n" +
+	"\x0fUpdateHTTPCheck\x12\".haproxy.v1.UpdateHTTPCheckRequest\x1a#.haproxy.v1.UpdateHTTPCheckResponse\":\x82\xd3\xe4\x93\x024:\x01*\x1a//v1/backends/{backend_name}/http-checks/{index}\x12\x93\x01\n" +
+	"\x0fDeleteHTTPCheck\x12\".haproxy.v1.DeleteHTTPCheckRequest\x1a#.haproxy.v1.DeleteHTTPCheckResponse\"7\x82\xd3\xe4\x93\x021*//v1/backends/{backend_name}/http-checks/{index}\x12\x86\x01\n" +
+	"\fCreateServer\x12\x1f.haproxy.v1.CreateServerRequest\x1a .haproxy.v1.CreateServerResponse\"3\x82\xd3\xe4\x93\x02-:\x06server\"#/v1/backends/{backend_name}/servers\x12|\n" +
+	"\tGetServer\x12\x1c.haproxy.v1.GetServerRequest\x1a\x1d.haproxy.v1.GetServerResponse\"2\x82\xd3\xe4\x93\x02,\x12*/v1/backends/{backend_name}/servers/{name}\x12{\n" +
+	"\vListServers\x12\x1e.haproxy.v1.ListServersRequest\x1a\x1f.haproxy.v1.ListServersResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/backends/{backend_name}/servers\x12\x8a\x01\n" +
+	"\rStreamServers\x12 .haproxy.v1.StreamServersRequest\x1a!.haproxy.v1.StreamServersResponse\"2\x82\xd3\xe4\x93\x02,\x12*/v1/backends/{backend_name}/servers:stream0\x01\x12\x8d\x01\n" +
+	"\fUpdateServer\x12\x1f.haproxy.v1.UpdateServerRequest\x1a .haproxy.v1.UpdateServerResponse\":\x82\xd3\xe4\x93\x024:\x06server\x1a*/v1/backends/{backend_name}/servers/{name}\x12\x85\x01\n" +
+	"\fDeleteServer\x12\x1f.haproxy.v1.DeleteServerRequest\x1a .haproxy.v1.DeleteServerResponse\"2\x82\xd3\xe4\x93\x02,**/v1/backends/{backend_name}/servers/{name}\x12\x97\x01\n" +
+	"\vApplyServer\x12\x1e.haproxy.v1.ApplyServerRequest\x1a\x1f.haproxy.v1.ApplyServerResponse\"G\x82\xd3\xe4\x93\x02A:\x06server\x1a7/v1/backends/{backend_name}/servers/{server.name}:apply\x12\x90\x01\n" +
+	"\rCreateServers\x12 .haproxy.v1.CreateServersRequest\x1a!.haproxy.v1.CreateServersResponse\":\x82\xd3\xe4\x93\x024:\x01*\"//v1/backends/{backend_name}/servers:batchCreate\x12\x90\x01\n" +
+	"\rDeleteServers\x12 .haproxy.v1.DeleteServersRequest\x1a!.haproxy.v1.DeleteServersResponse\":\x82\xd3\xe4\x93\x024:\x01*\"//v1/backends/{backend_name}/servers:batchDelete\x12g\n" +
+	"\vSetMetadata\x12\x1e.haproxy.v1.SetMetadataRequest\x1a\x1f.haproxy.v1.SetMetadataResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/metadata\x12a\n" +
+	"\vExportState\x12\x1e.haproxy.v1.ExportStateRequest\x1a\x1f.haproxy.v1.ExportStateResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/state\x12d\n" +
+	"\vImportState\x12\x1e.haproxy.v1.ImportStateRequest\x1a\x1f.haproxy.v1.ImportStateResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/state\x12v\n" +
+	"\x11ApplyDesiredState\x12$.haproxy.v1.ApplyDesiredStateRequest\x1a%.haproxy.v1.ApplyDesiredStateResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\x1a\t/v1/state\x12r\n" +
+	"\rRenderPreview\x12 .haproxy.v1.RenderPreviewRequest\x1a!.haproxy.v1.RenderPreviewResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/state:preview\x12X\n" +
+	"\bGetStats\x12\x1b.haproxy.v1.GetStatsRequest\x1a\x1c.haproxy.v1.GetStatsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/stats\x12\x94\x01\n" +
+	"\x0eSetServerState\x12!.haproxy.v1.SetServerStateRequest\x1a\".haproxy.v1.SetServerStateResponse\";\x82\xd3\xe4\x93\x025:\x01*\x1a0/v1/backends/{backend_name}/servers/{name}/state\x12\x8d\x01\n" +
+	"\vDrainServer\x12\x1e.haproxy.v1.DrainServerRequest\x1a\x1f.haproxy.v1.DrainServerResponse\";\x82\xd3\xe4\x93\x025:\x01*\"0/v1/backends/{backend_name}/servers/{name}:drain0\x01\x12\x96\x01\n" +
+	"\x10EnterMaintenance\x12#.haproxy.v1.EnterMaintenanceRequest\x1a$.haproxy.v1.EnterMaintenanceResponse\"7\x82\xd3\xe4\x93\x021:\x01*\",/v1/backends/{backend_name}:enterMaintenance\x12\x92\x01\n" +
+	"\x0fExitMaintenance\x12\".haproxy.v1.ExitMaintenanceRequest\x1a#.haproxy.v1.ExitMaintenanceResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/v1/backends/{backend_name}:exitMaintenance\x12s\n" +
+	"\x0fListMaintenance\x12\".haproxy.v1.ListMaintenanceRequest\x1a#.haproxy.v1.ListMaintenanceResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/maintenance\x12y\n" +
+	"\x10GetNetplanStatus\x12#.haproxy.v1.GetNetplanStatusRequest\x1a$.haproxy.v1.GetNetplanStatusResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/netplan/status\x12\x9f\x01\n" +
+	"\x15GetNetplanTransaction\x12(.haproxy.v1.GetNetplanTransactionRequest\x1a).haproxy.v1.GetNetplanTransactionResponse\"1\x82\xd3\xe4\x93\x02+\x12)/v1/netplan/transactions/{transaction_id}\x12\x9d\x01\n" +
+	"\x18CleanupOrphanedAddresses\x12+.haproxy.v1.CleanupOrphanedAddressesRequest\x1a,.haproxy.v1.CleanupOrphanedAddressesResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/netplan/orphans:cleanup\x12y\n" +
+	"\x10GetClusterStatus\x12#.haproxy.v1.GetClusterStatusRequest\x1a$.haproxy.v1.GetClusterStatusResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/cluster/status\x12k\n" +
+	"\vSyncCluster\x12\x1e.haproxy.v1.SyncClusterRequest\x1a\x1f.haproxy.v1.SyncClusterResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/cluster/sync\x12i\n" +
+	"\fGetPeerState\x12\x1f.haproxy.v1.GetPeerStateRequest\x1a .haproxy.v1.GetPeerStateResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/peer/state\x12y\n" +
+	"\x11GetPeerSyncStatus\x12$.haproxy.v1.GetPeerSyncStatusRequest\x1a%.haproxy.v1.GetPeerSyncStatusResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/peer/status\x12u\n" +
+	"\x0fGetGitOpsStatus\x12\".haproxy.v1.GetGitOpsStatusRequest\x1a#.haproxy.v1.GetGitOpsStatusResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/gitops/status\x12\x81\x01\n" +
+	"\x12GetDiscoveryStatus\x12%.haproxy.v1.GetDiscoveryStatusRequest\x1a&.haproxy.v1.GetDiscoveryStatusResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/discovery/status\x12j\n" +
+	"\x0eGetDriftStatus\x12!.haproxy.v1.GetDriftStatusRequest\x1a\".haproxy.v1.GetDriftStatusResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/drift\x12g\n" +
+	"\n" +
+	"CheckDrift\x12\x1d.haproxy.v1.CheckDriftRequest\x1a\x1e.haproxy.v1.CheckDriftResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/drift/check\x12_\n" +
+	"\n" +
+	"ListEvents\x12\x1d.haproxy.v1.ListEventsRequest\x1a\x1e.haproxy.v1.ListEventsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/events\x12m\n" +
+	"\fWatchChanges\x12\x1f.haproxy.v1.WatchChangesRequest\x1a .haproxy.v1.WatchChangesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/events/watch0\x01B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
 
 var file_haproxy_proto_goTypes = []any{
-	(*GetVersionRequest)(nil),         // 0: haproxy.v1.GetVersionRequest
-	(*CreateTransactionRequest)(nil),  // 1: haproxy.v1.CreateTransactionRequest
-	(*GetTransactionRequest)(nil),     // 2: haproxy.v1.GetTransactionRequest
-	(*CommitTransactionRequest)(nil),  // 3: haproxy.v1.CommitTransactionRequest
-	(*CloseTransactionRequest)(nil),   // 4: haproxy.v1.CloseTransactionRequest
-	(*CreateBackendRequest)(nil),      // 5: haproxy.v1.CreateBackendRequest
-	(*GetBackendRequest)(nil),         // 6: haproxy.v1.GetBackendRequest
-	(*ListBackendsRequest)(nil),       // 7: haproxy.v1.ListBackendsRequest
-	(*UpdateBackendRequest)(nil),      // 8: haproxy.v1.UpdateBackendRequest
-	(*DeleteBackendRequest)(nil),      // 9: haproxy.v1.DeleteBackendRequest
-	(*CreateFrontendRequest)(nil),     // 10: haproxy.v1.CreateFrontendRequest
-	(*GetFrontendRequest)(nil),        // 11: haproxy.v1.GetFrontendRequest
-	(*ListFrontendsRequest)(nil),      // 12: haproxy.v1.ListFrontendsRequest
-	(*UpdateFrontendRequest)(nil),     // 13: haproxy.v1.UpdateFrontendRequest
-	(*DeleteFrontendRequest)(nil),     // 14: haproxy.v1.DeleteFrontendRequest
-	(*CreateBindRequest)(nil),         // 15: haproxy.v1.CreateBindRequest
-	(*GetBindRequest)(nil),            // 16: haproxy.v1.GetBindRequest
-	(*ListBindsRequest)(nil),          // 17: haproxy.v1.ListBindsRequest
-	(*UpdateBindRequest)(nil),         // 18: haproxy.v1.UpdateBindRequest
-	(*DeleteBindRequest)(nil),         // 19: haproxy.v1.DeleteBindRequest
-	(*CreateServerRequest)(nil),       // 20: haproxy.v1.CreateServerRequest
-	(*GetServerRequest)(nil),          // 21: haproxy.v1.GetServerRequest
-	(*ListServersRequest)(nil),        // 22: haproxy.v1.ListServersRequest
-	(*UpdateServerRequest)(nil),       // 23: haproxy.v1.UpdateServerRequest
-	(*DeleteServerRequest)(nil),       // 24: haproxy.v1.DeleteServerRequest
-	(*GetVersionResponse)(nil),        // 25: haproxy.v1.GetVersionResponse
-	(*CreateTransactionResponse)(nil), // 26: haproxy.v1.CreateTransactionResponse
-	(*GetTransactionResponse)(nil),    // 27: haproxy.v1.GetTransactionResponse
-	(*CommitTransactionResponse)(nil), // 28: haproxy.v1.CommitTransactionResponse
-	(*CloseTransactionResponse)(nil),  // 29: haproxy.v1.CloseTransactionResponse
-	(*CreateBackendResponse)(nil),     // 30: haproxy.v1.CreateBackendResponse
-	(*GetBackendResponse)(nil),        // 31: haproxy.v1.GetBackendResponse
-	(*ListBackendsResponse)(nil),      // 32: haproxy.v1.ListBackendsResponse
-	(*UpdateBackendResponse)(nil),     // 33: haproxy.v1.UpdateBackendResponse
-	(*DeleteBackendResponse)(nil),     // 34: haproxy.v1.DeleteBackendResponse
-	(*CreateFrontendResponse)(nil),    // 35: haproxy.v1.CreateFrontendResponse
-	(*GetFrontendResponse)(nil),       // 36: haproxy.v1.GetFrontendResponse
-	(*ListFrontendsResponse)(nil),     // 37: haproxy.v1.ListFrontendsResponse
-	(*UpdateFrontendResponse)(nil),    // 38: haproxy.v1.UpdateFrontendResponse
-	(*DeleteFrontendResponse)(nil),    // 39: haproxy.v1.DeleteFrontendResponse
-	(*CreateBindResponse)(nil),        // 40: haproxy.v1.CreateBindResponse
-	(*GetBindResponse)(nil),           // 41: haproxy.v1.GetBindResponse
-	(*ListBindsResponse)(nil),         // 42: haproxy.v1.ListBindsResponse
-	(*UpdateBindResponse)(nil),        // 43: haproxy.v1.UpdateBindResponse
-	(*DeleteBindResponse)(nil),        // 44: haproxy.v1.DeleteBindResponse
-	(*CreateServerResponse)(nil),      // 45: haproxy.v1.CreateServerResponse
-	(*GetServerResponse)(nil),         // 46: haproxy.v1.GetServerResponse
-	(*ListServersResponse)(nil),       // 47: haproxy.v1.ListServersResponse
-	(*UpdateServerResponse)(nil),      // 48: haproxy.v1.UpdateServerResponse
-	(*DeleteServerResponse)(nil),      // 49: haproxy.v1.DeleteServerResponse
+	(*GetServerInfoRequest)(nil),             // 0: haproxy.v1.GetServerInfoRequest
+	(*GetStatusRequest)(nil),                 // 1: haproxy.v1.GetStatusRequest
+	(*ReloadConfigRequest)(nil),              // 2: haproxy.v1.ReloadConfigRequest
+	(*FreezeChangesRequest)(nil),             // 3: haproxy.v1.FreezeChangesRequest
+	(*UnfreezeChangesRequest)(nil),           // 4: haproxy.v1.UnfreezeChangesRequest
+	(*GetVersionRequest)(nil),                // 5: haproxy.v1.GetVersionRequest
+	(*CreateTransactionRequest)(nil),         // 6: haproxy.v1.CreateTransactionRequest
+	(*GetTransactionRequest)(nil),            // 7: haproxy.v1.GetTransactionRequest
+	(*ListTransactionsRequest)(nil),          // 8: haproxy.v1.ListTransactionsRequest
+	(*DiffTransactionRequest)(nil),           // 9: haproxy.v1.DiffTransactionRequest
+	(*CommitTransactionRequest)(nil),         // 10: haproxy.v1.CommitTransactionRequest
+	(*CloseTransactionRequest)(nil),          // 11: haproxy.v1.CloseTransactionRequest
+	(*CreateBackendRequest)(nil),             // 12: haproxy.v1.CreateBackendRequest
+	(*GetBackendRequest)(nil),                // 13: haproxy.v1.GetBackendRequest
+	(*ListBackendsRequest)(nil),              // 14: haproxy.v1.ListBackendsRequest
+	(*StreamBackendsRequest)(nil),            // 15: haproxy.v1.StreamBackendsRequest
+	(*UpdateBackendRequest)(nil),             // 16: haproxy.v1.UpdateBackendRequest
+	(*DeleteBackendRequest)(nil),             // 17: haproxy.v1.DeleteBackendRequest
+	(*ApplyBackendRequest)(nil),              // 18: haproxy.v1.ApplyBackendRequest
+	(*CreateFrontendRequest)(nil),            // 19: haproxy.v1.CreateFrontendRequest
+	(*GetFrontendRequest)(nil),               // 20: haproxy.v1.GetFrontendRequest
+	(*ListFrontendsRequest)(nil),             // 21: haproxy.v1.ListFrontendsRequest
+	(*UpdateFrontendRequest)(nil),            // 22: haproxy.v1.UpdateFrontendRequest
+	(*DeleteFrontendRequest)(nil),            // 23: haproxy.v1.DeleteFrontendRequest
+	(*ApplyFrontendRequest)(nil),             // 24: haproxy.v1.ApplyFrontendRequest
+	(*CreateHTTPSFrontendRequest)(nil),       // 25: haproxy.v1.CreateHTTPSFrontendRequest
+	(*SwapBackendsRequest)(nil),              // 26: haproxy.v1.SwapBackendsRequest
+	(*ShiftTrafficRequest)(nil),              // 27: haproxy.v1.ShiftTrafficRequest
+	(*CreateBindRequest)(nil),                // 28: haproxy.v1.CreateBindRequest
+	(*GetBindRequest)(nil),                   // 29: haproxy.v1.GetBindRequest
+	(*ListBindsRequest)(nil),                 // 30: haproxy.v1.ListBindsRequest
+	(*UpdateBindRequest)(nil),                // 31: haproxy.v1.UpdateBindRequest
+	(*DeleteBindRequest)(nil),                // 32: haproxy.v1.DeleteBindRequest
+	(*ApplyBindRequest)(nil),                 // 33: haproxy.v1.ApplyBindRequest
+	(*CreateRouteRequest)(nil),               // 34: haproxy.v1.CreateRouteRequest
+	(*GetRouteRequest)(nil),                  // 35: haproxy.v1.GetRouteRequest
+	(*ListRoutesRequest)(nil),                // 36: haproxy.v1.ListRoutesRequest
+	(*UpdateRouteRequest)(nil),               // 37: haproxy.v1.UpdateRouteRequest
+	(*DeleteRouteRequest)(nil),               // 38: haproxy.v1.DeleteRouteRequest
+	(*CreateRateLimitPolicyRequest)(nil),     // 39: haproxy.v1.CreateRateLimitPolicyRequest
+	(*GetRateLimitPolicyRequest)(nil),        // 40: haproxy.v1.GetRateLimitPolicyRequest
+	(*ListRateLimitPoliciesRequest)(nil),     // 41: haproxy.v1.ListRateLimitPoliciesRequest
+	(*UpdateRateLimitPolicyRequest)(nil),     // 42: haproxy.v1.UpdateRateLimitPolicyRequest
+	(*DeleteRateLimitPolicyRequest)(nil),     // 43: haproxy.v1.DeleteRateLimitPolicyRequest
+	(*UploadLuaScriptRequest)(nil),           // 44: haproxy.v1.UploadLuaScriptRequest
+	(*GetLuaScriptRequest)(nil),              // 45: haproxy.v1.GetLuaScriptRequest
+	(*ListLuaScriptsRequest)(nil),            // 46: haproxy.v1.ListLuaScriptsRequest
+	(*LoadLuaScriptRequest)(nil),             // 47: haproxy.v1.LoadLuaScriptRequest
+	(*RollbackLuaScriptRequest)(nil),         // 48: haproxy.v1.RollbackLuaScriptRequest
+	(*UnloadLuaScriptRequest)(nil),           // 49: haproxy.v1.UnloadLuaScriptRequest
+	(*DeleteLuaScriptRequest)(nil),           // 50: haproxy.v1.DeleteLuaScriptRequest
+	(*CreateLuaActionRequest)(nil),           // 51: haproxy.v1.CreateLuaActionRequest
+	(*ListLuaActionsRequest)(nil),            // 52: haproxy.v1.ListLuaActionsRequest
+	(*DeleteLuaActionRequest)(nil),           // 53: haproxy.v1.DeleteLuaActionRequest
+	(*CreateRingRequest)(nil),                // 54: haproxy.v1.CreateRingRequest
+	(*GetRingRequest)(nil),                   // 55: haproxy.v1.GetRingRequest
+	(*ListRingsRequest)(nil),                 // 56: haproxy.v1.ListRingsRequest
+	(*UpdateRingRequest)(nil),                // 57: haproxy.v1.UpdateRingRequest
+	(*DeleteRingRequest)(nil),                // 58: haproxy.v1.DeleteRingRequest
+	(*AttachRingLogTargetRequest)(nil),       // 59: haproxy.v1.AttachRingLogTargetRequest
+	(*ListRingLogTargetsRequest)(nil),        // 60: haproxy.v1.ListRingLogTargetsRequest
+	(*DetachRingLogTargetRequest)(nil),       // 61: haproxy.v1.DetachRingLogTargetRequest
+	(*StreamRingRequest)(nil),                // 62: haproxy.v1.StreamRingRequest
+	(*CreateProgramRequest)(nil),             // 63: haproxy.v1.CreateProgramRequest
+	(*GetProgramRequest)(nil),                // 64: haproxy.v1.GetProgramRequest
+	(*ListProgramsRequest)(nil),              // 65: haproxy.v1.ListProgramsRequest
+	(*UpdateProgramRequest)(nil),             // 66: haproxy.v1.UpdateProgramRequest
+	(*DeleteProgramRequest)(nil),             // 67: haproxy.v1.DeleteProgramRequest
+	(*CreateHTTPCheckRequest)(nil),           // 68: haproxy.v1.CreateHTTPCheckRequest
+	(*ListHTTPChecksRequest)(nil),            // 69: haproxy.v1.ListHTTPChecksRequest
+	(*UpdateHTTPCheckRequest)(nil),           // 70: haproxy.v1.UpdateHTTPCheckRequest
+	(*DeleteHTTPCheckRequest)(nil),           // 71: haproxy.v1.DeleteHTTPCheckRequest
+	(*CreateServerRequest)(nil),              // 72: haproxy.v1.CreateServerRequest
+	(*GetServerRequest)(nil),                 // 73: haproxy.v1.GetServerRequest
+	(*ListServersRequest)(nil),               // 74: haproxy.v1.ListServersRequest
+	(*StreamServersRequest)(nil),             // 75: haproxy.v1.StreamServersRequest
+	(*UpdateServerRequest)(nil),              // 76: haproxy.v1.UpdateServerRequest
+	(*DeleteServerRequest)(nil),              // 77: haproxy.v1.DeleteServerRequest
+	(*ApplyServerRequest)(nil),               // 78: haproxy.v1.ApplyServerRequest
+	(*CreateServersRequest)(nil),             // 79: haproxy.v1.CreateServersRequest
+	(*DeleteServersRequest)(nil),             // 80: haproxy.v1.DeleteServersRequest
+	(*SetMetadataRequest)(nil),               // 81: haproxy.v1.SetMetadataRequest
+	(*ExportStateRequest)(nil),               // 82: haproxy.v1.ExportStateRequest
+	(*ImportStateRequest)(nil),               // 83: haproxy.v1.ImportStateRequest
+	(*ApplyDesiredStateRequest)(nil),         // 84: haproxy.v1.ApplyDesiredStateRequest
+	(*RenderPreviewRequest)(nil),             // 85: haproxy.v1.RenderPreviewRequest
+	(*GetStatsRequest)(nil),                  // 86: haproxy.v1.GetStatsRequest
+	(*SetServerStateRequest)(nil),            // 87: haproxy.v1.SetServerStateRequest
+	(*DrainServerRequest)(nil),               // 88: haproxy.v1.DrainServerRequest
+	(*EnterMaintenanceRequest)(nil),          // 89: haproxy.v1.EnterMaintenanceRequest
+	(*ExitMaintenanceRequest)(nil),           // 90: haproxy.v1.ExitMaintenanceRequest
+	(*ListMaintenanceRequest)(nil),           // 91: haproxy.v1.ListMaintenanceRequest
+	(*GetNetplanStatusRequest)(nil),          // 92: haproxy.v1.GetNetplanStatusRequest
+	(*GetNetplanTransactionRequest)(nil),     // 93: haproxy.v1.GetNetplanTransactionRequest
+	(*CleanupOrphanedAddressesRequest)(nil),  // 94: haproxy.v1.CleanupOrphanedAddressesRequest
+	(*GetClusterStatusRequest)(nil),          // 95: haproxy.v1.GetClusterStatusRequest
+	(*SyncClusterRequest)(nil),               // 96: haproxy.v1.SyncClusterRequest
+	(*GetPeerStateRequest)(nil),              // 97: haproxy.v1.GetPeerStateRequest
+	(*GetPeerSyncStatusRequest)(nil),         // 98: haproxy.v1.GetPeerSyncStatusRequest
+	(*GetGitOpsStatusRequest)(nil),           // 99: haproxy.v1.GetGitOpsStatusRequest
+	(*GetDiscoveryStatusRequest)(nil),        // 100: haproxy.v1.GetDiscoveryStatusRequest
+	(*GetDriftStatusRequest)(nil),            // 101: haproxy.v1.GetDriftStatusRequest
+	(*CheckDriftRequest)(nil),                // 102: haproxy.v1.CheckDriftRequest
+	(*ListEventsRequest)(nil),                // 103: haproxy.v1.ListEventsRequest
+	(*WatchChangesRequest)(nil),              // 104: haproxy.v1.WatchChangesRequest
+	(*GetServerInfoResponse)(nil),            // 105: haproxy.v1.GetServerInfoResponse
+	(*GetStatusResponse)(nil),                // 106: haproxy.v1.GetStatusResponse
+	(*ReloadConfigResponse)(nil),             // 107: haproxy.v1.ReloadConfigResponse
+	(*FreezeChangesResponse)(nil),            // 108: haproxy.v1.FreezeChangesResponse
+	(*UnfreezeChangesResponse)(nil),          // 109: haproxy.v1.UnfreezeChangesResponse
+	(*GetVersionResponse)(nil),               // 110: haproxy.v1.GetVersionResponse
+	(*CreateTransactionResponse)(nil),        // 111: haproxy.v1.CreateTransactionResponse
+	(*GetTransactionResponse)(nil),           // 112: haproxy.v1.GetTransactionResponse
+	(*ListTransactionsResponse)(nil),         // 113: haproxy.v1.ListTransactionsResponse
+	(*DiffTransactionResponse)(nil),          // 114: haproxy.v1.DiffTransactionResponse
+	(*CommitTransactionResponse)(nil),        // 115: haproxy.v1.CommitTransactionResponse
+	(*CloseTransactionResponse)(nil),         // 116: haproxy.v1.CloseTransactionResponse
+	(*CreateBackendResponse)(nil),            // 117: haproxy.v1.CreateBackendResponse
+	(*GetBackendResponse)(nil),               // 118: haproxy.v1.GetBackendResponse
+	(*ListBackendsResponse)(nil),             // 119: haproxy.v1.ListBackendsResponse
+	(*StreamBackendsResponse)(nil),           // 120: haproxy.v1.StreamBackendsResponse
+	(*UpdateBackendResponse)(nil),            // 121: haproxy.v1.UpdateBackendResponse
+	(*DeleteBackendResponse)(nil),            // 122: haproxy.v1.DeleteBackendResponse
+	(*ApplyBackendResponse)(nil),             // 123: haproxy.v1.ApplyBackendResponse
+	(*CreateFrontendResponse)(nil),           // 124: haproxy.v1.CreateFrontendResponse
+	(*GetFrontendResponse)(nil),              // 125: haproxy.v1.GetFrontendResponse
+	(*ListFrontendsResponse)(nil),            // 126: haproxy.v1.ListFrontendsResponse
+	(*UpdateFrontendResponse)(nil),           // 127: haproxy.v1.UpdateFrontendResponse
+	(*DeleteFrontendResponse)(nil),           // 128: haproxy.v1.DeleteFrontendResponse
+	(*ApplyFrontendResponse)(nil),            // 129: haproxy.v1.ApplyFrontendResponse
+	(*CreateHTTPSFrontendResponse)(nil),      // 130: haproxy.v1.CreateHTTPSFrontendResponse
+	(*SwapBackendsResponse)(nil),             // 131: haproxy.v1.SwapBackendsResponse
+	(*ShiftTrafficResponse)(nil),             // 132: haproxy.v1.ShiftTrafficResponse
+	(*CreateBindResponse)(nil),               // 133: haproxy.v1.CreateBindResponse
+	(*GetBindResponse)(nil),                  // 134: haproxy.v1.GetBindResponse
+	(*ListBindsResponse)(nil),                // 135: haproxy.v1.ListBindsResponse
+	(*UpdateBindResponse)(nil),               // 136: haproxy.v1.UpdateBindResponse
+	(*DeleteBindResponse)(nil),               // 137: haproxy.v1.DeleteBindResponse
+	(*ApplyBindResponse)(nil),                // 138: haproxy.v1.ApplyBindResponse
+	(*CreateRouteResponse)(nil),              // 139: haproxy.v1.CreateRouteResponse
+	(*GetRouteResponse)(nil),                 // 140: haproxy.v1.GetRouteResponse
+	(*ListRoutesResponse)(nil),               // 141: haproxy.v1.ListRoutesResponse
+	(*UpdateRouteResponse)(nil),              // 142: haproxy.v1.UpdateRouteResponse
+	(*DeleteRouteResponse)(nil),              // 143: haproxy.v1.DeleteRouteResponse
+	(*CreateRateLimitPolicyResponse)(nil),    // 144: haproxy.v1.CreateRateLimitPolicyResponse
+	(*GetRateLimitPolicyResponse)(nil),       // 145: haproxy.v1.GetRateLimitPolicyResponse
+	(*ListRateLimitPoliciesResponse)(nil),    // 146: haproxy.v1.ListRateLimitPoliciesResponse
+	(*UpdateRateLimitPolicyResponse)(nil),    // 147: haproxy.v1.UpdateRateLimitPolicyResponse
+	(*DeleteRateLimitPolicyResponse)(nil),    // 148: haproxy.v1.DeleteRateLimitPolicyResponse
+	(*UploadLuaScriptResponse)(nil),          // 149: haproxy.v1.UploadLuaScriptResponse
+	(*GetLuaScriptResponse)(nil),             // 150: haproxy.v1.GetLuaScriptResponse
+	(*ListLuaScriptsResponse)(nil),           // 151: haproxy.v1.ListLuaScriptsResponse
+	(*LoadLuaScriptResponse)(nil),            // 152: haproxy.v1.LoadLuaScriptResponse
+	(*RollbackLuaScriptResponse)(nil),        // 153: haproxy.v1.RollbackLuaScriptResponse
+	(*UnloadLuaScriptResponse)(nil),          // 154: haproxy.v1.UnloadLuaScriptResponse
+	(*DeleteLuaScriptResponse)(nil),          // 155: haproxy.v1.DeleteLuaScriptResponse
+	(*CreateLuaActionResponse)(nil),          // 156: haproxy.v1.CreateLuaActionResponse
+	(*ListLuaActionsResponse)(nil),           // 157: haproxy.v1.ListLuaActionsResponse
+	(*DeleteLuaActionResponse)(nil),          // 158: haproxy.v1.DeleteLuaActionResponse
+	(*CreateRingResponse)(nil),               // 159: haproxy.v1.CreateRingResponse
+	(*GetRingResponse)(nil),                  // 160: haproxy.v1.GetRingResponse
+	(*ListRingsResponse)(nil),                // 161: haproxy.v1.ListRingsResponse
+	(*UpdateRingResponse)(nil),               // 162: haproxy.v1.UpdateRingResponse
+	(*DeleteRingResponse)(nil),               // 163: haproxy.v1.DeleteRingResponse
+	(*AttachRingLogTargetResponse)(nil),      // 164: haproxy.v1.AttachRingLogTargetResponse
+	(*ListRingLogTargetsResponse)(nil),       // 165: haproxy.v1.ListRingLogTargetsResponse
+	(*DetachRingLogTargetResponse)(nil),      // 166: haproxy.v1.DetachRingLogTargetResponse
+	(*StreamRingResponse)(nil),               // 167: haproxy.v1.StreamRingResponse
+	(*CreateProgramResponse)(nil),            // 168: haproxy.v1.CreateProgramResponse
+	(*GetProgramResponse)(nil),               // 169: haproxy.v1.GetProgramResponse
+	(*ListProgramsResponse)(nil),             // 170: haproxy.v1.ListProgramsResponse
+	(*UpdateProgramResponse)(nil),            // 171: haproxy.v1.UpdateProgramResponse
+	(*DeleteProgramResponse)(nil),            // 172: haproxy.v1.DeleteProgramResponse
+	(*CreateHTTPCheckResponse)(nil),          // 173: haproxy.v1.CreateHTTPCheckResponse
+	(*ListHTTPChecksResponse)(nil),           // 174: haproxy.v1.ListHTTPChecksResponse
+	(*UpdateHTTPCheckResponse)(nil),          // 175: haproxy.v1.UpdateHTTPCheckResponse
+	(*DeleteHTTPCheckResponse)(nil),          // 176: haproxy.v1.DeleteHTTPCheckResponse
+	(*CreateServerResponse)(nil),             // 177: haproxy.v1.CreateServerResponse
+	(*GetServerResponse)(nil),                // 178: haproxy.v1.GetServerResponse
+	(*ListServersResponse)(nil),              // 179: haproxy.v1.ListServersResponse
+	(*StreamServersResponse)(nil),            // 180: haproxy.v1.StreamServersResponse
+	(*UpdateServerResponse)(nil),             // 181: haproxy.v1.UpdateServerResponse
+	(*DeleteServerResponse)(nil),             // 182: haproxy.v1.DeleteServerResponse
+	(*ApplyServerResponse)(nil),              // 183: haproxy.v1.ApplyServerResponse
+	(*CreateServersResponse)(nil),            // 184: haproxy.v1.CreateServersResponse
+	(*DeleteServersResponse)(nil),            // 185: haproxy.v1.DeleteServersResponse
+	(*SetMetadataResponse)(nil),              // 186: haproxy.v1.SetMetadataResponse
+	(*ExportStateResponse)(nil),              // 187: haproxy.v1.ExportStateResponse
+	(*ImportStateResponse)(nil),              // 188: haproxy.v1.ImportStateResponse
+	(*ApplyDesiredStateResponse)(nil),        // 189: haproxy.v1.ApplyDesiredStateResponse
+	(*RenderPreviewResponse)(nil),            // 190: haproxy.v1.RenderPreviewResponse
+	(*GetStatsResponse)(nil),                 // 191: haproxy.v1.GetStatsResponse
+	(*SetServerStateResponse)(nil),           // 192: haproxy.v1.SetServerStateResponse
+	(*DrainServerResponse)(nil),              // 193: haproxy.v1.DrainServerResponse
+	(*EnterMaintenanceResponse)(nil),         // 194: haproxy.v1.EnterMaintenanceResponse
+	(*ExitMaintenanceResponse)(nil),          // 195: haproxy.v1.ExitMaintenanceResponse
+	(*ListMaintenanceResponse)(nil),          // 196: haproxy.v1.ListMaintenanceResponse
+	(*GetNetplanStatusResponse)(nil),         // 197: haproxy.v1.GetNetplanStatusResponse
+	(*GetNetplanTransactionResponse)(nil),    // 198: haproxy.v1.GetNetplanTransactionResponse
+	(*CleanupOrphanedAddressesResponse)(nil), // 199: haproxy.v1.CleanupOrphanedAddressesResponse
+	(*GetClusterStatusResponse)(nil),         // 200: haproxy.v1.GetClusterStatusResponse
+	(*SyncClusterResponse)(nil),              // 201: haproxy.v1.SyncClusterResponse
+	(*GetPeerStateResponse)(nil),             // 202: haproxy.v1.GetPeerStateResponse
+	(*GetPeerSyncStatusResponse)(nil),        // 203: haproxy.v1.GetPeerSyncStatusResponse
+	(*GetGitOpsStatusResponse)(nil),          // 204: haproxy.v1.GetGitOpsStatusResponse
+	(*GetDiscoveryStatusResponse)(nil),       // 205: haproxy.v1.GetDiscoveryStatusResponse
+	(*GetDriftStatusResponse)(nil),           // 206: haproxy.v1.GetDriftStatusResponse
+	(*CheckDriftResponse)(nil),               // 207: haproxy.v1.CheckDriftResponse
+	(*ListEventsResponse)(nil),               // 208: haproxy.v1.ListEventsResponse
+	(*WatchChangesResponse)(nil),             // 209: haproxy.v1.WatchChangesResponse
 }
 var file_haproxy_proto_depIdxs = []int32{
-	0,  // 0: haproxy.v1.HAProxyManagerService.GetVersion:input_type -> haproxy.v1.GetVersionRequest
-	1,  // 1: haproxy.v1.HAProxyManagerService.CreateTransaction:input_type -> haproxy.v1.CreateTransactionRequest
-	2,  // 2: haproxy.v1.HAProxyManagerService.GetTransaction:input_type -> haproxy.v1.GetTransactionRequest
-	3,  // 3: haproxy.v1.HAProxyManagerService.CommitTransaction:input_type -> haproxy.v1.CommitTransactionRequest
-	4,  // 4: haproxy.v1.HAProxyManagerService.CloseTransaction:input_type -> haproxy.v1.CloseTransactionRequest
-	5,  // 5: haproxy.v1.HAProxyManagerService.CreateBackend:input_type -> haproxy.v1.CreateBackendRequest
-	6,  // 6: haproxy.v1.HAProxyManagerService.GetBackend:input_type -> haproxy.v1.GetBackendRequest
-	7,  // 7: haproxy.v1.HAProxyManagerService.ListBackends:input_type -> haproxy.v1.ListBackendsRequest
-	8,  // 8: haproxy.v1.HAProxyManagerService.UpdateBackend:input_type -> haproxy.v1.UpdateBackendRequest
-	9,  // 9: haproxy.v1.HAProxyManagerService.DeleteBackend:input_type -> haproxy.v1.DeleteBackendRequest
-	10, // 10: haproxy.v1.HAProxyManagerService.CreateFrontend:input_type -> haproxy.v1.CreateFrontendRequest
-	11, // 11: haproxy.v1.HAProxyManagerService.GetFrontend:input_type -> haproxy.v1.GetFrontendRequest
-	12, // 12: haproxy.v1.HAProxyManagerService.ListFrontends:input_type -> haproxy.v1.ListFrontendsRequest
-	13, // 13: haproxy.v1.HAProxyManagerService.UpdateFrontend:input_type -> haproxy.v1.UpdateFrontendRequest
-	14, // 14: haproxy.v1.HAProxyManagerService.DeleteFrontend:input_type -> haproxy.v1.DeleteFrontendRequest
-	15, // 15: haproxy.v1.HAProxyManagerService.CreateBind:input_type -> haproxy.v1.CreateBindRequest
-	16, // 16: haproxy.v1.HAProxyManagerService.GetBind:input_type -> haproxy.v1.GetBindRequest
-	17, // 17: haproxy.v1.HAProxyManagerService.ListBinds:input_type -> haproxy.v1.ListBindsRequest
-	18, // 18: haproxy.v1.HAProxyManagerService.UpdateBind:input_type -> haproxy.v1.UpdateBindRequest
-	19, // 19: haproxy.v1.HAProxyManagerService.DeleteBind:input_type -> haproxy.v1.DeleteBindRequest
-	20, // 20: haproxy.v1.HAProxyManagerService.CreateServer:input_type -> haproxy.v1.CreateServerRequest
-	21, // 21: haproxy.v1.HAProxyManagerService.GetServer:input_type -> haproxy.v1.GetServerRequest
-	22, // 22: haproxy.v1.HAProxyManagerService.ListServers:input_type -> haproxy.v1.ListServersRequest
-	23, // 23: haproxy.v1.HAProxyManagerService.UpdateServer:input_type -> haproxy.v1.UpdateServerRequest
-	24, // 24: haproxy.v1.HAProxyManagerService.DeleteServer:input_type -> haproxy.v1.DeleteServerRequest
-	25, // 25: haproxy.v1.HAProxyManagerService.GetVersion:output_type -> haproxy.v1.GetVersionResponse
-	26, // 26: haproxy.v1.HAProxyManagerService.CreateTransaction:output_type -> haproxy.v1.CreateTransactionResponse
-	27, // 27: haproxy.v1.HAProxyManagerService.GetTransaction:output_type -> haproxy.v1.GetTransactionResponse
-	28, // 28: haproxy.v1.HAProxyManagerService.CommitTransaction:output_type -> haproxy.v1.CommitTransactionResponse
-	29, // 29: haproxy.v1.HAProxyManagerService.CloseTransaction:output_type -> haproxy.v1.CloseTransactionResponse
-	30, // 30: haproxy.v1.HAProxyManagerService.CreateBackend:output_type -> haproxy.v1.CreateBackendResponse
-	31, // 31: haproxy.v1.HAProxyManagerService.GetBackend:output_type -> haproxy.v1.GetBackendResponse
-	32, // 32: haproxy.v1.HAProxyManagerService.ListBackends:output_type -> haproxy.v1.ListBackendsResponse
-	33, // 33: haproxy.v1.HAProxyManagerService.UpdateBackend:output_type -> haproxy.v1.UpdateBackendResponse
-	34, // 34: haproxy.v1.HAProxyManagerService.DeleteBackend:output_type -> haproxy.v1.DeleteBackendResponse
-	35, // 35: haproxy.v1.HAProxyManagerService.CreateFrontend:output_type -> haproxy.v1.CreateFrontendResponse
-	36, // 36: haproxy.v1.HAProxyManagerService.GetFrontend:output_type -> haproxy.v1.GetFrontendResponse
-	37, // 37: haproxy.v1.HAProxyManagerService.ListFrontends:output_type -> haproxy.v1.ListFrontendsResponse
-	38, // 38: haproxy.v1.HAProxyManagerService.UpdateFrontend:output_type -> haproxy.v1.UpdateFrontendResponse
-	39, // 39: haproxy.v1.HAProxyManagerService.DeleteFrontend:output_type -> haproxy.v1.DeleteFrontendResponse
-	40, // 40: haproxy.v1.HAProxyManagerService.CreateBind:output_type -> haproxy.v1.CreateBindResponse
-	41, // 41: haproxy.v1.HAProxyManagerService.GetBind:output_type -> haproxy.v1.GetBindResponse
-	42, // 42: haproxy.v1.HAProxyManagerService.ListBinds:output_type -> haproxy.v1.ListBindsResponse
-	43, // 43: haproxy.v1.HAProxyManagerService.UpdateBind:output_type -> haproxy.v1.UpdateBindResponse
-	44, // 44: haproxy.v1.HAProxyManagerService.DeleteBind:output_type -> haproxy.v1.DeleteBindResponse
-	45, // 45: haproxy.v1.HAProxyManagerService.CreateServer:output_type -> haproxy.v1.CreateServerResponse
-	46, // 46: haproxy.v1.HAProxyManagerService.GetServer:output_type -> haproxy.v1.GetServerResponse
-	47, // 47: haproxy.v1.HAProxyManagerService.ListServers:output_type -> haproxy.v1.ListServersResponse
-	48, // 48: haproxy.v1.HAProxyManagerService.UpdateServer:output_type -> haproxy.v1.UpdateServerResponse
-	49, // 49: haproxy.v1.HAProxyManagerService.DeleteServer:output_type -> haproxy.v1.DeleteServerResponse
-	25, // [25:50] is the sub-list for method output_type
-	0,  // [0:25] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	0,   // 0: haproxy.v1.HAProxyManagerService.GetServerInfo:input_type -> haproxy.v1.GetServerInfoRequest
+	1,   // 1: haproxy.v1.HAProxyManagerService.GetStatus:input_type -> haproxy.v1.GetStatusRequest
+	2,   // 2: haproxy.v1.HAProxyManagerService.ReloadConfig:input_type -> haproxy.v1.ReloadConfigRequest
+	3,   // 3: haproxy.v1.HAProxyManagerService.FreezeChanges:input_type -> haproxy.v1.FreezeChangesRequest
+	4,   // 4: haproxy.v1.HAProxyManagerService.UnfreezeChanges:input_type -> haproxy.v1.UnfreezeChangesRequest
+	5,   // 5: haproxy.v1.HAProxyManagerService.GetVersion:input_type -> haproxy.v1.GetVersionRequest
+	6,   // 6: haproxy.v1.HAProxyManagerService.CreateTransaction:input_type -> haproxy.v1.CreateTransactionRequest
+	7,   // 7: haproxy.v1.HAProxyManagerService.GetTransaction:input_type -> haproxy.v1.GetTransactionRequest
+	8,   // 8: haproxy.v1.HAProxyManagerService.ListTransactions:input_type -> haproxy.v1.ListTransactionsRequest
+	9,   // 9: haproxy.v1.HAProxyManagerService.DiffTransaction:input_type -> haproxy.v1.DiffTransactionRequest
+	10,  // 10: haproxy.v1.HAProxyManagerService.CommitTransaction:input_type -> haproxy.v1.CommitTransactionRequest
+	11,  // 11: haproxy.v1.HAProxyManagerService.CloseTransaction:input_type -> haproxy.v1.CloseTransactionRequest
+	12,  // 12: haproxy.v1.HAProxyManagerService.CreateBackend:input_type -> haproxy.v1.CreateBackendRequest
+	13,  // 13: haproxy.v1.HAProxyManagerService.GetBackend:input_type -> haproxy.v1.GetBackendRequest
+	14,  // 14: haproxy.v1.HAProxyManagerService.ListBackends:input_type -> haproxy.v1.ListBackendsRequest
+	15,  // 15: haproxy.v1.HAProxyManagerService.StreamBackends:input_type -> haproxy.v1.StreamBackendsRequest
+	16,  // 16: haproxy.v1.HAProxyManagerService.UpdateBackend:input_type -> haproxy.v1.UpdateBackendRequest
+	17,  // 17: haproxy.v1.HAProxyManagerService.DeleteBackend:input_type -> haproxy.v1.DeleteBackendRequest
+	18,  // 18: haproxy.v1.HAProxyManagerService.ApplyBackend:input_type -> haproxy.v1.ApplyBackendRequest
+	19,  // 19: haproxy.v1.HAProxyManagerService.CreateFrontend:input_type -> haproxy.v1.CreateFrontendRequest
+	20,  // 20: haproxy.v1.HAProxyManagerService.GetFrontend:input_type -> haproxy.v1.GetFrontendRequest
+	21,  // 21: haproxy.v1.HAProxyManagerService.ListFrontends:input_type -> haproxy.v1.ListFrontendsRequest
+	22,  // 22: haproxy.v1.HAProxyManagerService.UpdateFrontend:input_type -> haproxy.v1.UpdateFrontendRequest
+	23,  // 23: haproxy.v1.HAProxyManagerService.DeleteFrontend:input_type -> haproxy.v1.DeleteFrontendRequest
+	24,  // 24: haproxy.v1.HAProxyManagerService.ApplyFrontend:input_type -> haproxy.v1.ApplyFrontendRequest
+	25,  // 25: haproxy.v1.HAProxyManagerService.CreateHTTPSFrontend:input_type -> haproxy.v1.CreateHTTPSFrontendRequest
+	26,  // 26: haproxy.v1.HAProxyManagerService.SwapBackends:input_type -> haproxy.v1.SwapBackendsRequest
+	27,  // 27: haproxy.v1.HAProxyManagerService.ShiftTraffic:input_type -> haproxy.v1.ShiftTrafficRequest
+	28,  // 28: haproxy.v1.HAProxyManagerService.CreateBind:input_type -> haproxy.v1.CreateBindRequest
+	29,  // 29: haproxy.v1.HAProxyManagerService.GetBind:input_type -> haproxy.v1.GetBindRequest
+	30,  // 30: haproxy.v1.HAProxyManagerService.ListBinds:input_type -> haproxy.v1.ListBindsRequest
+	31,  // 31: haproxy.v1.HAProxyManagerService.UpdateBind:input_type -> haproxy.v1.UpdateBindRequest
+	32,  // 32: haproxy.v1.HAProxyManagerService.DeleteBind:input_type -> haproxy.v1.DeleteBindRequest
+	33,  // 33: haproxy.v1.HAProxyManagerService.ApplyBind:input_type -> haproxy.v1.ApplyBindRequest
+	34,  // 34: haproxy.v1.HAProxyManagerService.CreateRoute:input_type -> haproxy.v1.CreateRouteRequest
+	35,  // 35: haproxy.v1.HAProxyManagerService.GetRoute:input_type -> haproxy.v1.GetRouteRequest
+	36,  // 36: haproxy.v1.HAProxyManagerService.ListRoutes:input_type -> haproxy.v1.ListRoutesRequest
+	37,  // 37: haproxy.v1.HAProxyManagerService.UpdateRoute:input_type -> haproxy.v1.UpdateRouteRequest
+	38,  // 38: haproxy.v1.HAProxyManagerService.DeleteRoute:input_type -> haproxy.v1.DeleteRouteRequest
+	39,  // 39: haproxy.v1.HAProxyManagerService.CreateRateLimitPolicy:input_type -> haproxy.v1.CreateRateLimitPolicyRequest
+	40,  // 40: haproxy.v1.HAProxyManagerService.GetRateLimitPolicy:input_type -> haproxy.v1.GetRateLimitPolicyRequest
+	41,  // 41: haproxy.v1.HAProxyManagerService.ListRateLimitPolicies:input_type -> haproxy.v1.ListRateLimitPoliciesRequest
+	42,  // 42: haproxy.v1.HAProxyManagerService.UpdateRateLimitPolicy:input_type -> haproxy.v1.UpdateRateLimitPolicyRequest
+	43,  // 43: haproxy.v1.HAProxyManagerService.DeleteRateLimitPolicy:input_type -> haproxy.v1.DeleteRateLimitPolicyRequest
+	44,  // 44: haproxy.v1.HAProxyManagerService.UploadLuaScript:input_type -> haproxy.v1.UploadLuaScriptRequest
+	45,  // 45: haproxy.v1.HAProxyManagerService.GetLuaScript:input_type -> haproxy.v1.GetLuaScriptRequest
+	46,  // 46: haproxy.v1.HAProxyManagerService.ListLuaScripts:input_type -> haproxy.v1.ListLuaScriptsRequest
+	47,  // 47: haproxy.v1.HAProxyManagerService.LoadLuaScript:input_type -> haproxy.v1.LoadLuaScriptRequest
+	48,  // 48: haproxy.v1.HAProxyManagerService.RollbackLuaScript:input_type -> haproxy.v1.RollbackLuaScriptRequest
+	49,  // 49: haproxy.v1.HAProxyManagerService.UnloadLuaScript:input_type -> haproxy.v1.UnloadLuaScriptRequest
+	50,  // 50: haproxy.v1.HAProxyManagerService.DeleteLuaScript:input_type -> haproxy.v1.DeleteLuaScriptRequest
+	51,  // 51: haproxy.v1.HAProxyManagerService.CreateLuaAction:input_type -> haproxy.v1.CreateLuaActionRequest
+	52,  // 52: haproxy.v1.HAProxyManagerService.ListLuaActions:input_type -> haproxy.v1.ListLuaActionsRequest
+	53,  // 53: haproxy.v1.HAProxyManagerService.DeleteLuaAction:input_type -> haproxy.v1.DeleteLuaActionRequest
+	54,  // 54: haproxy.v1.HAProxyManagerService.CreateRing:input_type -> haproxy.v1.CreateRingRequest
+	55,  // 55: haproxy.v1.HAProxyManagerService.GetRing:input_type -> haproxy.v1.GetRingRequest
+	56,  // 56: haproxy.v1.HAProxyManagerService.ListRings:input_type -> haproxy.v1.ListRingsRequest
+	57,  // 57: haproxy.v1.HAProxyManagerService.UpdateRing:input_type -> haproxy.v1.UpdateRingRequest
+	58,  // 58: haproxy.v1.HAProxyManagerService.DeleteRing:input_type -> haproxy.v1.DeleteRingRequest
+	59,  // 59: haproxy.v1.HAProxyManagerService.AttachRingLogTarget:input_type -> haproxy.v1.AttachRingLogTargetRequest
+	60,  // 60: haproxy.v1.HAProxyManagerService.ListRingLogTargets:input_type -> haproxy.v1.ListRingLogTargetsRequest
+	61,  // 61: haproxy.v1.HAProxyManagerService.DetachRingLogTarget:input_type -> haproxy.v1.DetachRingLogTargetRequest
+	62,  // 62: haproxy.v1.HAProxyManagerService.StreamRing:input_type -> haproxy.v1.StreamRingRequest
+	63,  // 63: haproxy.v1.HAProxyManagerService.CreateProgram:input_type -> haproxy.v1.CreateProgramRequest
+	64,  // 64: haproxy.v1.HAProxyManagerService.GetProgram:input_type -> haproxy.v1.GetProgramRequest
+	65,  // 65: haproxy.v1.HAProxyManagerService.ListPrograms:input_type -> haproxy.v1.ListProgramsRequest
+	66,  // 66: haproxy.v1.HAProxyManagerService.UpdateProgram:input_type -> haproxy.v1.UpdateProgramRequest
+	67,  // 67: haproxy.v1.HAProxyManagerService.DeleteProgram:input_type -> haproxy.v1.DeleteProgramRequest
+	68,  // 68: haproxy.v1.HAProxyManagerService.CreateHTTPCheck:input_type -> haproxy.v1.CreateHTTPCheckRequest
+	69,  // 69: haproxy.v1.HAProxyManagerService.ListHTTPChecks:input_type -> haproxy.v1.ListHTTPChecksRequest
+	70,  // 70: haproxy.v1.HAProxyManagerService.UpdateHTTPCheck:input_type -> haproxy.v1.UpdateHTTPCheckRequest
+	71,  // 71: haproxy.v1.HAProxyManagerService.DeleteHTTPCheck:input_type -> haproxy.v1.DeleteHTTPCheckRequest
+	72,  // 72: haproxy.v1.HAProxyManagerService.CreateServer:input_type -> haproxy.v1.CreateServerRequest
+	73,  // 73: haproxy.v1.HAProxyManagerService.GetServer:input_type -> haproxy.v1.GetServerRequest
+	74,  // 74: haproxy.v1.HAProxyManagerService.ListServers:input_type -> haproxy.v1.ListServersRequest
+	75,  // 75: haproxy.v1.HAProxyManagerService.StreamServers:input_type -> haproxy.v1.StreamServersRequest
+	76,  // 76: haproxy.v1.HAProxyManagerService.UpdateServer:input_type -> haproxy.v1.UpdateServerRequest
+	77,  // 77: haproxy.v1.HAProxyManagerService.DeleteServer:input_type -> haproxy.v1.DeleteServerRequest
+	78,  // 78: haproxy.v1.HAProxyManagerService.ApplyServer:input_type -> haproxy.v1.ApplyServerRequest
+	79,  // 79: haproxy.v1.HAProxyManagerService.CreateServers:input_type -> haproxy.v1.CreateServersRequest
+	80,  // 80: haproxy.v1.HAProxyManagerService.DeleteServers:input_type -> haproxy.v1.DeleteServersRequest
+	81,  // 81: haproxy.v1.HAProxyManagerService.SetMetadata:input_type -> haproxy.v1.SetMetadataRequest
+	82,  // 82: haproxy.v1.HAProxyManagerService.ExportState:input_type -> haproxy.v1.ExportStateRequest
+	83,  // 83: haproxy.v1.HAProxyManagerService.ImportState:input_type -> haproxy.v1.ImportStateRequest
+	84,  // 84: haproxy.v1.HAProxyManagerService.ApplyDesiredState:input_type -> haproxy.v1.ApplyDesiredStateRequest
+	85,  // 85: haproxy.v1.HAProxyManagerService.RenderPreview:input_type -> haproxy.v1.RenderPreviewRequest
+	86,  // 86: haproxy.v1.HAProxyManagerService.GetStats:input_type -> haproxy.v1.GetStatsRequest
+	87,  // 87: haproxy.v1.HAProxyManagerService.SetServerState:input_type -> haproxy.v1.SetServerStateRequest
+	88,  // 88: haproxy.v1.HAProxyManagerService.DrainServer:input_type -> haproxy.v1.DrainServerRequest
+	89,  // 89: haproxy.v1.HAProxyManagerService.EnterMaintenance:input_type -> haproxy.v1.EnterMaintenanceRequest
+	90,  // 90: haproxy.v1.HAProxyManagerService.ExitMaintenance:input_type -> haproxy.v1.ExitMaintenanceRequest
+	91,  // 91: haproxy.v1.HAProxyManagerService.ListMaintenance:input_type -> haproxy.v1.ListMaintenanceRequest
+	92,  // 92: haproxy.v1.HAProxyManagerService.GetNetplanStatus:input_type -> haproxy.v1.GetNetplanStatusRequest
+	93,  // 93: haproxy.v1.HAProxyManagerService.GetNetplanTransaction:input_type -> haproxy.v1.GetNetplanTransactionRequest
+	94,  // 94: haproxy.v1.HAProxyManagerService.CleanupOrphanedAddresses:input_type -> haproxy.v1.CleanupOrphanedAddressesRequest
+	95,  // 95: haproxy.v1.HAProxyManagerService.GetClusterStatus:input_type -> haproxy.v1.GetClusterStatusRequest
+	96,  // 96: haproxy.v1.HAProxyManagerService.SyncCluster:input_type -> haproxy.v1.SyncClusterRequest
+	97,  // 97: haproxy.v1.HAProxyManagerService.GetPeerState:input_type -> haproxy.v1.GetPeerStateRequest
+	98,  // 98: haproxy.v1.HAProxyManagerService.GetPeerSyncStatus:input_type -> haproxy.v1.GetPeerSyncStatusRequest
+	99,  // 99: haproxy.v1.HAProxyManagerService.GetGitOpsStatus:input_type -> haproxy.v1.GetGitOpsStatusRequest
+	100, // 100: haproxy.v1.HAProxyManagerService.GetDiscoveryStatus:input_type -> haproxy.v1.GetDiscoveryStatusRequest
+	101, // 101: haproxy.v1.HAProxyManagerService.GetDriftStatus:input_type -> haproxy.v1.GetDriftStatusRequest
+	102, // 102: haproxy.v1.HAProxyManagerService.CheckDrift:input_type -> haproxy.v1.CheckDriftRequest
+	103, // 103: haproxy.v1.HAProxyManagerService.ListEvents:input_type -> haproxy.v1.ListEventsRequest
+	104, // 104: haproxy.v1.HAProxyManagerService.WatchChanges:input_type -> haproxy.v1.WatchChangesRequest
+	105, // 105: haproxy.v1.HAProxyManagerService.GetServerInfo:output_type -> haproxy.v1.GetServerInfoResponse
+	106, // 106: haproxy.v1.HAProxyManagerService.GetStatus:output_type -> haproxy.v1.GetStatusResponse
+	107, // 107: haproxy.v1.HAProxyManagerService.ReloadConfig:output_type -> haproxy.v1.ReloadConfigResponse
+	108, // 108: haproxy.v1.HAProxyManagerService.FreezeChanges:output_type -> haproxy.v1.FreezeChangesResponse
+	109, // 109: haproxy.v1.HAProxyManagerService.UnfreezeChanges:output_type -> haproxy.v1.UnfreezeChangesResponse
+	110, // 110: haproxy.v1.HAProxyManagerService.GetVersion:output_type -> haproxy.v1.GetVersionResponse
+	111, // 111: haproxy.v1.HAProxyManagerService.CreateTransaction:output_type -> haproxy.v1.CreateTransactionResponse
+	112, // 112: haproxy.v1.HAProxyManagerService.GetTransaction:output_type -> haproxy.v1.GetTransactionResponse
+	113, // 113: haproxy.v1.HAProxyManagerService.ListTransactions:output_type -> haproxy.v1.ListTransactionsResponse
+	114, // 114: haproxy.v1.HAProxyManagerService.DiffTransaction:output_type -> haproxy.v1.DiffTransactionResponse
+	115, // 115: haproxy.v1.HAProxyManagerService.CommitTransaction:output_type -> haproxy.v1.CommitTransactionResponse
+	116, // 116: haproxy.v1.HAProxyManagerService.CloseTransaction:output_type -> haproxy.v1.CloseTransactionResponse
+	117, // 117: haproxy.v1.HAProxyManagerService.CreateBackend:output_type -> haproxy.v1.CreateBackendResponse
+	118, // 118: haproxy.v1.HAProxyManagerService.GetBackend:output_type -> haproxy.v1.GetBackendResponse
+	119, // 119: haproxy.v1.HAProxyManagerService.ListBackends:output_type -> haproxy.v1.ListBackendsResponse
+	120, // 120: haproxy.v1.HAProxyManagerService.StreamBackends:output_type -> haproxy.v1.StreamBackendsResponse
+	121, // 121: haproxy.v1.HAProxyManagerService.UpdateBackend:output_type -> haproxy.v1.UpdateBackendResponse
+	122, // 122: haproxy.v1.HAProxyManagerService.DeleteBackend:output_type -> haproxy.v1.DeleteBackendResponse
+	123, // 123: haproxy.v1.HAProxyManagerService.ApplyBackend:output_type -> haproxy.v1.ApplyBackendResponse
+	124, // 124: haproxy.v1.HAProxyManagerService.CreateFrontend:output_type -> haproxy.v1.CreateFrontendResponse
+	125, // 125: haproxy.v1.HAProxyManagerService.GetFrontend:output_type -> haproxy.v1.GetFrontendResponse
+	126, // 126: haproxy.v1.HAProxyManagerService.ListFrontends:output_type -> haproxy.v1.ListFrontendsResponse
+	127, // 127: haproxy.v1.HAProxyManagerService.UpdateFrontend:output_type -> haproxy.v1.UpdateFrontendResponse
+	128, // 128: haproxy.v1.HAProxyManagerService.DeleteFrontend:output_type -> haproxy.v1.DeleteFrontendResponse
+	129, // 129: haproxy.v1.HAProxyManagerService.ApplyFrontend:output_type -> haproxy.v1.ApplyFrontendResponse
+	130, // 130: haproxy.v1.HAProxyManagerService.CreateHTTPSFrontend:output_type -> haproxy.v1.CreateHTTPSFrontendResponse
+	131, // 131: haproxy.v1.HAProxyManagerService.SwapBackends:output_type -> haproxy.v1.SwapBackendsResponse
+	132, // 132: haproxy.v1.HAProxyManagerService.ShiftTraffic:output_type -> haproxy.v1.ShiftTrafficResponse
+	133, // 133: haproxy.v1.HAProxyManagerService.CreateBind:output_type -> haproxy.v1.CreateBindResponse
+	134, // 134: haproxy.v1.HAProxyManagerService.GetBind:output_type -> haproxy.v1.GetBindResponse
+	135, // 135: haproxy.v1.HAProxyManagerService.ListBinds:output_type -> haproxy.v1.ListBindsResponse
+	136, // 136: haproxy.v1.HAProxyManagerService.UpdateBind:output_type -> haproxy.v1.UpdateBindResponse
+	137, // 137: haproxy.v1.HAProxyManagerService.DeleteBind:output_type -> haproxy.v1.DeleteBindResponse
+	138, // 138: haproxy.v1.HAProxyManagerService.ApplyBind:output_type -> haproxy.v1.ApplyBindResponse
+	139, // 139: haproxy.v1.HAProxyManagerService.CreateRoute:output_type -> haproxy.v1.CreateRouteResponse
+	140, // 140: haproxy.v1.HAProxyManagerService.GetRoute:output_type -> haproxy.v1.GetRouteResponse
+	141, // 141: haproxy.v1.HAProxyManagerService.ListRoutes:output_type -> haproxy.v1.ListRoutesResponse
+	142, // 142: haproxy.v1.HAProxyManagerService.UpdateRoute:output_type -> haproxy.v1.UpdateRouteResponse
+	143, // 143: haproxy.v1.HAProxyManagerService.DeleteRoute:output_type -> haproxy.v1.DeleteRouteResponse
+	144, // 144: haproxy.v1.HAProxyManagerService.CreateRateLimitPolicy:output_type -> haproxy.v1.CreateRateLimitPolicyResponse
+	145, // 145: haproxy.v1.HAProxyManagerService.GetRateLimitPolicy:output_type -> haproxy.v1.GetRateLimitPolicyResponse
+	146, // 146: haproxy.v1.HAProxyManagerService.ListRateLimitPolicies:output_type -> haproxy.v1.ListRateLimitPoliciesResponse
+	147, // 147: haproxy.v1.HAProxyManagerService.UpdateRateLimitPolicy:output_type -> haproxy.v1.UpdateRateLimitPolicyResponse
+	148, // 148: haproxy.v1.HAProxyManagerService.DeleteRateLimitPolicy:output_type -> haproxy.v1.DeleteRateLimitPolicyResponse
+	149, // 149: haproxy.v1.HAProxyManagerService.UploadLuaScript:output_type -> haproxy.v1.UploadLuaScriptResponse
+	150, // 150: haproxy.v1.HAProxyManagerService.GetLuaScript:output_type -> haproxy.v1.GetLuaScriptResponse
+	151, // 151: haproxy.v1.HAProxyManagerService.ListLuaScripts:output_type -> haproxy.v1.ListLuaScriptsResponse
+	152, // 152: haproxy.v1.HAProxyManagerService.LoadLuaScript:output_type -> haproxy.v1.LoadLuaScriptResponse
+	153, // 153: haproxy.v1.HAProxyManagerService.RollbackLuaScript:output_type -> haproxy.v1.RollbackLuaScriptResponse
+	154, // 154: haproxy.v1.HAProxyManagerService.UnloadLuaScript:output_type -> haproxy.v1.UnloadLuaScriptResponse
+	155, // 155: haproxy.v1.HAProxyManagerService.DeleteLuaScript:output_type -> haproxy.v1.DeleteLuaScriptResponse
+	156, // 156: haproxy.v1.HAProxyManagerService.CreateLuaAction:output_type -> haproxy.v1.CreateLuaActionResponse
+	157, // 157: haproxy.v1.HAProxyManagerService.ListLuaActions:output_type -> haproxy.v1.ListLuaActionsResponse
+	158, // 158: haproxy.v1.HAProxyManagerService.DeleteLuaAction:output_type -> haproxy.v1.DeleteLuaActionResponse
+	159, // 159: haproxy.v1.HAProxyManagerService.CreateRing:output_type -> haproxy.v1.CreateRingResponse
+	160, // 160: haproxy.v1.HAProxyManagerService.GetRing:output_type -> haproxy.v1.GetRingResponse
+	161, // 161: haproxy.v1.HAProxyManagerService.ListRings:output_type -> haproxy.v1.ListRingsResponse
+	162, // 162: haproxy.v1.HAProxyManagerService.UpdateRing:output_type -> haproxy.v1.UpdateRingResponse
+	163, // 163: haproxy.v1.HAProxyManagerService.DeleteRing:output_type -> haproxy.v1.DeleteRingResponse
+	164, // 164: haproxy.v1.HAProxyManagerService.AttachRingLogTarget:output_type -> haproxy.v1.AttachRingLogTargetResponse
+	165, // 165: haproxy.v1.HAProxyManagerService.ListRingLogTargets:output_type -> haproxy.v1.ListRingLogTargetsResponse
+	166, // 166: haproxy.v1.HAProxyManagerService.DetachRingLogTarget:output_type -> haproxy.v1.DetachRingLogTargetResponse
+	167, // 167: haproxy.v1.HAProxyManagerService.StreamRing:output_type -> haproxy.v1.StreamRingResponse
+	168, // 168: haproxy.v1.HAProxyManagerService.CreateProgram:output_type -> haproxy.v1.CreateProgramResponse
+	169, // 169: haproxy.v1.HAProxyManagerService.GetProgram:output_type -> haproxy.v1.GetProgramResponse
+	170, // 170: haproxy.v1.HAProxyManagerService.ListPrograms:output_type -> haproxy.v1.ListProgramsResponse
+	171, // 171: haproxy.v1.HAProxyManagerService.UpdateProgram:output_type -> haproxy.v1.UpdateProgramResponse
+	172, // 172: haproxy.v1.HAProxyManagerService.DeleteProgram:output_type -> haproxy.v1.DeleteProgramResponse
+	173, // 173: haproxy.v1.HAProxyManagerService.CreateHTTPCheck:output_type -> haproxy.v1.CreateHTTPCheckResponse
+	174, // 174: haproxy.v1.HAProxyManagerService.ListHTTPChecks:output_type -> haproxy.v1.ListHTTPChecksResponse
+	175, // 175: haproxy.v1.HAProxyManagerService.UpdateHTTPCheck:output_type -> haproxy.v1.UpdateHTTPCheckResponse
+	176, // 176: haproxy.v1.HAProxyManagerService.DeleteHTTPCheck:output_type -> haproxy.v1.DeleteHTTPCheckResponse
+	177, // 177: haproxy.v1.HAProxyManagerService.CreateServer:output_type -> haproxy.v1.CreateServerResponse
+	178, // 178: haproxy.v1.HAProxyManagerService.GetServer:output_type -> haproxy.v1.GetServerResponse
+	179, // 179: haproxy.v1.HAProxyManagerService.ListServers:output_type -> haproxy.v1.ListServersResponse
+	180, // 180: haproxy.v1.HAProxyManagerService.StreamServers:output_type -> haproxy.v1.StreamServersResponse
+	181, // 181: haproxy.v1.HAProxyManagerService.UpdateServer:output_type -> haproxy.v1.UpdateServerResponse
+	182, // 182: haproxy.v1.HAProxyManagerService.DeleteServer:output_type -> haproxy.v1.DeleteServerResponse
+	183, // 183: haproxy.v1.HAProxyManagerService.ApplyServer:output_type -> haproxy.v1.ApplyServerResponse
+	184, // 184: haproxy.v1.HAProxyManagerService.CreateServers:output_type -> haproxy.v1.CreateServersResponse
+	185, // 185: haproxy.v1.HAProxyManagerService.DeleteServers:output_type -> haproxy.v1.DeleteServersResponse
+	186, // 186: haproxy.v1.HAProxyManagerService.SetMetadata:output_type -> haproxy.v1.SetMetadataResponse
+	187, // 187: haproxy.v1.HAProxyManagerService.ExportState:output_type -> haproxy.v1.ExportStateResponse
+	188, // 188: haproxy.v1.HAProxyManagerService.ImportState:output_type -> haproxy.v1.ImportStateResponse
+	189, // 189: haproxy.v1.HAProxyManagerService.ApplyDesiredState:output_type -> haproxy.v1.ApplyDesiredStateResponse
+	190, // 190: haproxy.v1.HAProxyManagerService.RenderPreview:output_type -> haproxy.v1.RenderPreviewResponse
+	191, // 191: haproxy.v1.HAProxyManagerService.GetStats:output_type -> haproxy.v1.GetStatsResponse
+	192, // 192: haproxy.v1.HAProxyManagerService.SetServerState:output_type -> haproxy.v1.SetServerStateResponse
+	193, // 193: haproxy.v1.HAProxyManagerService.DrainServer:output_type -> haproxy.v1.DrainServerResponse
+	194, // 194: haproxy.v1.HAProxyManagerService.EnterMaintenance:output_type -> haproxy.v1.EnterMaintenanceResponse
+	195, // 195: haproxy.v1.HAProxyManagerService.ExitMaintenance:output_type -> haproxy.v1.ExitMaintenanceResponse
+	196, // 196: haproxy.v1.HAProxyManagerService.ListMaintenance:output_type -> haproxy.v1.ListMaintenanceResponse
+	197, // 197: haproxy.v1.HAProxyManagerService.GetNetplanStatus:output_type -> haproxy.v1.GetNetplanStatusResponse
+	198, // 198: haproxy.v1.HAProxyManagerService.GetNetplanTransaction:output_type -> haproxy.v1.GetNetplanTransactionResponse
+	199, // 199: haproxy.v1.HAProxyManagerService.CleanupOrphanedAddresses:output_type -> haproxy.v1.CleanupOrphanedAddressesResponse
+	200, // 200: haproxy.v1.HAProxyManagerService.GetClusterStatus:output_type -> haproxy.v1.GetClusterStatusResponse
+	201, // 201: haproxy.v1.HAProxyManagerService.SyncCluster:output_type -> haproxy.v1.SyncClusterResponse
+	202, // 202: haproxy.v1.HAProxyManagerService.GetPeerState:output_type -> haproxy.v1.GetPeerStateResponse
+	203, // 203: haproxy.v1.HAProxyManagerService.GetPeerSyncStatus:output_type -> haproxy.v1.GetPeerSyncStatusResponse
+	204, // 204: haproxy.v1.HAProxyManagerService.GetGitOpsStatus:output_type -> haproxy.v1.GetGitOpsStatusResponse
+	205, // 205: haproxy.v1.HAProxyManagerService.GetDiscoveryStatus:output_type -> haproxy.v1.GetDiscoveryStatusResponse
+	206, // 206: haproxy.v1.HAProxyManagerService.GetDriftStatus:output_type -> haproxy.v1.GetDriftStatusResponse
+	207, // 207: haproxy.v1.HAProxyManagerService.CheckDrift:output_type -> haproxy.v1.CheckDriftResponse
+	208, // 208: haproxy.v1.HAProxyManagerService.ListEvents:output_type -> haproxy.v1.ListEventsResponse
+	209, // 209: haproxy.v1.HAProxyManagerService.WatchChanges:output_type -> haproxy.v1.WatchChangesResponse
+	105, // [105:210] is the sub-list for method output_type
+	0,   // [0:105] is the sub-list for method input_type
+	0,   // [0:0] is the sub-list for extension type_name
+	0,   // [0:0] is the sub-list for extension extendee
+	0,   // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_haproxy_proto_init() }
@@ -176,9 +591,29 @@ func file_haproxy_proto_init() {
 	}
 	file_transaction_proto_init()
 	file_backend_proto_init()
+	file_cluster_proto_init()
+	file_deployment_proto_init()
+	file_discovery_proto_init()
+	file_drift_proto_init()
+	file_freeze_proto_init()
 	file_frontend_proto_init()
 	file_bind_proto_init()
 	file_server_proto_init()
+	file_event_proto_init()
+	file_gitops_proto_init()
+	file_info_proto_init()
+	file_lua_proto_init()
+	file_maintenance_proto_init()
+	file_metadata_proto_init()
+	file_netplan_proto_init()
+	file_peer_proto_init()
+	file_ratelimit_proto_init()
+	file_httpcheck_proto_init()
+	file_program_proto_init()
+	file_ring_proto_init()
+	file_route_proto_init()
+	file_runtime_proto_init()
+	file_state_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
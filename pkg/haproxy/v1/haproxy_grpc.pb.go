@@ -19,31 +19,111 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	HAProxyManagerService_GetVersion_FullMethodName        = "/haproxy.v1.HAProxyManagerService/GetVersion"
-	HAProxyManagerService_CreateTransaction_FullMethodName = "/haproxy.v1.HAProxyManagerService/CreateTransaction"
-	HAProxyManagerService_GetTransaction_FullMethodName    = "/haproxy.v1.HAProxyManagerService/GetTransaction"
-	HAProxyManagerService_CommitTransaction_FullMethodName = "/haproxy.v1.HAProxyManagerService/CommitTransaction"
-	HAProxyManagerService_CloseTransaction_FullMethodName  = "/haproxy.v1.HAProxyManagerService/CloseTransaction"
-	HAProxyManagerService_CreateBackend_FullMethodName     = "/haproxy.v1.HAProxyManagerService/CreateBackend"
-	HAProxyManagerService_GetBackend_FullMethodName        = "/haproxy.v1.HAProxyManagerService/GetBackend"
-	HAProxyManagerService_ListBackends_FullMethodName      = "/haproxy.v1.HAProxyManagerService/ListBackends"
-	HAProxyManagerService_UpdateBackend_FullMethodName     = "/haproxy.v1.HAProxyManagerService/UpdateBackend"
-	HAProxyManagerService_DeleteBackend_FullMethodName     = "/haproxy.v1.HAProxyManagerService/DeleteBackend"
-	HAProxyManagerService_CreateFrontend_FullMethodName    = "/haproxy.v1.HAProxyManagerService/CreateFrontend"
-	HAProxyManagerService_GetFrontend_FullMethodName       = "/haproxy.v1.HAProxyManagerService/GetFrontend"
-	HAProxyManagerService_ListFrontends_FullMethodName     = "/haproxy.v1.HAProxyManagerService/ListFrontends"
-	HAProxyManagerService_UpdateFrontend_FullMethodName    = "/haproxy.v1.HAProxyManagerService/UpdateFrontend"
-	HAProxyManagerService_DeleteFrontend_FullMethodName    = "/haproxy.v1.HAProxyManagerService/DeleteFrontend"
-	HAProxyManagerService_CreateBind_FullMethodName        = "/haproxy.v1.HAProxyManagerService/CreateBind"
-	HAProxyManagerService_GetBind_FullMethodName           = "/haproxy.v1.HAProxyManagerService/GetBind"
-	HAProxyManagerService_ListBinds_FullMethodName         = "/haproxy.v1.HAProxyManagerService/ListBinds"
-	HAProxyManagerService_UpdateBind_FullMethodName        = "/haproxy.v1.HAProxyManagerService/UpdateBind"
-	HAProxyManagerService_DeleteBind_FullMethodName        = "/haproxy.v1.HAProxyManagerService/DeleteBind"
-	HAProxyManagerService_CreateServer_FullMethodName      = "/haproxy.v1.HAProxyManagerService/CreateServer"
-	HAProxyManagerService_GetServer_FullMethodName         = "/haproxy.v1.HAProxyManagerService/GetServer"
-	HAProxyManagerService_ListServers_FullMethodName       = "/haproxy.v1.HAProxyManagerService/ListServers"
-	HAProxyManagerService_UpdateServer_FullMethodName      = "/haproxy.v1.HAProxyManagerService/UpdateServer"
-	HAProxyManagerService_DeleteServer_FullMethodName      = "/haproxy.v1.HAProxyManagerService/DeleteServer"
+	HAProxyManagerService_GetServerInfo_FullMethodName            = "/haproxy.v1.HAProxyManagerService/GetServerInfo"
+	HAProxyManagerService_GetStatus_FullMethodName                = "/haproxy.v1.HAProxyManagerService/GetStatus"
+	HAProxyManagerService_ReloadConfig_FullMethodName             = "/haproxy.v1.HAProxyManagerService/ReloadConfig"
+	HAProxyManagerService_FreezeChanges_FullMethodName            = "/haproxy.v1.HAProxyManagerService/FreezeChanges"
+	HAProxyManagerService_UnfreezeChanges_FullMethodName          = "/haproxy.v1.HAProxyManagerService/UnfreezeChanges"
+	HAProxyManagerService_GetVersion_FullMethodName               = "/haproxy.v1.HAProxyManagerService/GetVersion"
+	HAProxyManagerService_CreateTransaction_FullMethodName        = "/haproxy.v1.HAProxyManagerService/CreateTransaction"
+	HAProxyManagerService_GetTransaction_FullMethodName           = "/haproxy.v1.HAProxyManagerService/GetTransaction"
+	HAProxyManagerService_ListTransactions_FullMethodName         = "/haproxy.v1.HAProxyManagerService/ListTransactions"
+	HAProxyManagerService_DiffTransaction_FullMethodName          = "/haproxy.v1.HAProxyManagerService/DiffTransaction"
+	HAProxyManagerService_CommitTransaction_FullMethodName        = "/haproxy.v1.HAProxyManagerService/CommitTransaction"
+	HAProxyManagerService_CloseTransaction_FullMethodName         = "/haproxy.v1.HAProxyManagerService/CloseTransaction"
+	HAProxyManagerService_CreateBackend_FullMethodName            = "/haproxy.v1.HAProxyManagerService/CreateBackend"
+	HAProxyManagerService_GetBackend_FullMethodName               = "/haproxy.v1.HAProxyManagerService/GetBackend"
+	HAProxyManagerService_ListBackends_FullMethodName             = "/haproxy.v1.HAProxyManagerService/ListBackends"
+	HAProxyManagerService_StreamBackends_FullMethodName           = "/haproxy.v1.HAProxyManagerService/StreamBackends"
+	HAProxyManagerService_UpdateBackend_FullMethodName            = "/haproxy.v1.HAProxyManagerService/UpdateBackend"
+	HAProxyManagerService_DeleteBackend_FullMethodName            = "/haproxy.v1.HAProxyManagerService/DeleteBackend"
+	HAProxyManagerService_ApplyBackend_FullMethodName             = "/haproxy.v1.HAProxyManagerService/ApplyBackend"
+	HAProxyManagerService_CreateFrontend_FullMethodName           = "/haproxy.v1.HAProxyManagerService/CreateFrontend"
+	HAProxyManagerService_GetFrontend_FullMethodName              = "/haproxy.v1.HAProxyManagerService/GetFrontend"
+	HAProxyManagerService_ListFrontends_FullMethodName            = "/haproxy.v1.HAProxyManagerService/ListFrontends"
+	HAProxyManagerService_UpdateFrontend_FullMethodName           = "/haproxy.v1.HAProxyManagerService/UpdateFrontend"
+	HAProxyManagerService_DeleteFrontend_FullMethodName           = "/haproxy.v1.HAProxyManagerService/DeleteFrontend"
+	HAProxyManagerService_ApplyFrontend_FullMethodName            = "/haproxy.v1.HAProxyManagerService/ApplyFrontend"
+	HAProxyManagerService_CreateHTTPSFrontend_FullMethodName      = "/haproxy.v1.HAProxyManagerService/CreateHTTPSFrontend"
+	HAProxyManagerService_SwapBackends_FullMethodName             = "/haproxy.v1.HAProxyManagerService/SwapBackends"
+	HAProxyManagerService_ShiftTraffic_FullMethodName             = "/haproxy.v1.HAProxyManagerService/ShiftTraffic"
+	HAProxyManagerService_CreateBind_FullMethodName               = "/haproxy.v1.HAProxyManagerService/CreateBind"
+	HAProxyManagerService_GetBind_FullMethodName                  = "/haproxy.v1.HAProxyManagerService/GetBind"
+	HAProxyManagerService_ListBinds_FullMethodName                = "/haproxy.v1.HAProxyManagerService/ListBinds"
+	HAProxyManagerService_UpdateBind_FullMethodName               = "/haproxy.v1.HAProxyManagerService/UpdateBind"
+	HAProxyManagerService_DeleteBind_FullMethodName               = "/haproxy.v1.HAProxyManagerService/DeleteBind"
+	HAProxyManagerService_ApplyBind_FullMethodName                = "/haproxy.v1.HAProxyManagerService/ApplyBind"
+	HAProxyManagerService_CreateRoute_FullMethodName              = "/haproxy.v1.HAProxyManagerService/CreateRoute"
+	HAProxyManagerService_GetRoute_FullMethodName                 = "/haproxy.v1.HAProxyManagerService/GetRoute"
+	HAProxyManagerService_ListRoutes_FullMethodName               = "/haproxy.v1.HAProxyManagerService/ListRoutes"
+	HAProxyManagerService_UpdateRoute_FullMethodName              = "/haproxy.v1.HAProxyManagerService/UpdateRoute"
+	HAProxyManagerService_DeleteRoute_FullMethodName              = "/haproxy.v1.HAProxyManagerService/DeleteRoute"
+	HAProxyManagerService_CreateRateLimitPolicy_FullMethodName    = "/haproxy.v1.HAProxyManagerService/CreateRateLimitPolicy"
+	HAProxyManagerService_GetRateLimitPolicy_FullMethodName       = "/haproxy.v1.HAProxyManagerService/GetRateLimitPolicy"
+	HAProxyManagerService_ListRateLimitPolicies_FullMethodName    = "/haproxy.v1.HAProxyManagerService/ListRateLimitPolicies"
+	HAProxyManagerService_UpdateRateLimitPolicy_FullMethodName    = "/haproxy.v1.HAProxyManagerService/UpdateRateLimitPolicy"
+	HAProxyManagerService_DeleteRateLimitPolicy_FullMethodName    = "/haproxy.v1.HAProxyManagerService/DeleteRateLimitPolicy"
+	HAProxyManagerService_UploadLuaScript_FullMethodName          = "/haproxy.v1.HAProxyManagerService/UploadLuaScript"
+	HAProxyManagerService_GetLuaScript_FullMethodName             = "/haproxy.v1.HAProxyManagerService/GetLuaScript"
+	HAProxyManagerService_ListLuaScripts_FullMethodName           = "/haproxy.v1.HAProxyManagerService/ListLuaScripts"
+	HAProxyManagerService_LoadLuaScript_FullMethodName            = "/haproxy.v1.HAProxyManagerService/LoadLuaScript"
+	HAProxyManagerService_RollbackLuaScript_FullMethodName        = "/haproxy.v1.HAProxyManagerService/RollbackLuaScript"
+	HAProxyManagerService_UnloadLuaScript_FullMethodName          = "/haproxy.v1.HAProxyManagerService/UnloadLuaScript"
+	HAProxyManagerService_DeleteLuaScript_FullMethodName          = "/haproxy.v1.HAProxyManagerService/DeleteLuaScript"
+	HAProxyManagerService_CreateLuaAction_FullMethodName          = "/haproxy.v1.HAProxyManagerService/CreateLuaAction"
+	HAProxyManagerService_ListLuaActions_FullMethodName           = "/haproxy.v1.HAProxyManagerService/ListLuaActions"
+	HAProxyManagerService_DeleteLuaAction_FullMethodName          = "/haproxy.v1.HAProxyManagerService/DeleteLuaAction"
+	HAProxyManagerService_CreateRing_FullMethodName               = "/haproxy.v1.HAProxyManagerService/CreateRing"
+	HAProxyManagerService_GetRing_FullMethodName                  = "/haproxy.v1.HAProxyManagerService/GetRing"
+	HAProxyManagerService_ListRings_FullMethodName                = "/haproxy.v1.HAProxyManagerService/ListRings"
+	HAProxyManagerService_UpdateRing_FullMethodName               = "/haproxy.v1.HAProxyManagerService/UpdateRing"
+	HAProxyManagerService_DeleteRing_FullMethodName               = "/haproxy.v1.HAProxyManagerService/DeleteRing"
+	HAProxyManagerService_AttachRingLogTarget_FullMethodName      = "/haproxy.v1.HAProxyManagerService/AttachRingLogTarget"
+	HAProxyManagerService_ListRingLogTargets_FullMethodName       = "/haproxy.v1.HAProxyManagerService/ListRingLogTargets"
+	HAProxyManagerService_DetachRingLogTarget_FullMethodName      = "/haproxy.v1.HAProxyManagerService/DetachRingLogTarget"
+	HAProxyManagerService_StreamRing_FullMethodName               = "/haproxy.v1.HAProxyManagerService/StreamRing"
+	HAProxyManagerService_CreateProgram_FullMethodName            = "/haproxy.v1.HAProxyManagerService/CreateProgram"
+	HAProxyManagerService_GetProgram_FullMethodName               = "/haproxy.v1.HAProxyManagerService/GetProgram"
+	HAProxyManagerService_ListPrograms_FullMethodName             = "/haproxy.v1.HAProxyManagerService/ListPrograms"
+	HAProxyManagerService_UpdateProgram_FullMethodName            = "/haproxy.v1.HAProxyManagerService/UpdateProgram"
+	HAProxyManagerService_DeleteProgram_FullMethodName            = "/haproxy.v1.HAProxyManagerService/DeleteProgram"
+	HAProxyManagerService_CreateHTTPCheck_FullMethodName          = "/haproxy.v1.HAProxyManagerService/CreateHTTPCheck"
+	HAProxyManagerService_ListHTTPChecks_FullMethodName           = "/haproxy.v1.HAProxyManagerService/ListHTTPChecks"
+	HAProxyManagerService_UpdateHTTPCheck_FullMethodName          = "/haproxy.v1.HAProxyManagerService/UpdateHTTPCheck"
+	HAProxyManagerService_DeleteHTTPCheck_FullMethodName          = "/haproxy.v1.HAProxyManagerService/DeleteHTTPCheck"
+	HAProxyManagerService_CreateServer_FullMethodName             = "/haproxy.v1.HAProxyManagerService/CreateServer"
+	HAProxyManagerService_GetServer_FullMethodName                = "/haproxy.v1.HAProxyManagerService/GetServer"
+	HAProxyManagerService_ListServers_FullMethodName              = "/haproxy.v1.HAProxyManagerService/ListServers"
+	HAProxyManagerService_StreamServers_FullMethodName            = "/haproxy.v1.HAProxyManagerService/StreamServers"
+	HAProxyManagerService_UpdateServer_FullMethodName             = "/haproxy.v1.HAProxyManagerService/UpdateServer"
+	HAProxyManagerService_DeleteServer_FullMethodName             = "/haproxy.v1.HAProxyManagerService/DeleteServer"
+	HAProxyManagerService_ApplyServer_FullMethodName              = "/haproxy.v1.HAProxyManagerService/ApplyServer"
+	HAProxyManagerService_CreateServers_FullMethodName            = "/haproxy.v1.HAProxyManagerService/CreateServers"
+	HAProxyManagerService_DeleteServers_FullMethodName            = "/haproxy.v1.HAProxyManagerService/DeleteServers"
+	HAProxyManagerService_SetMetadata_FullMethodName              = "/haproxy.v1.HAProxyManagerService/SetMetadata"
+	HAProxyManagerService_ExportState_FullMethodName              = "/haproxy.v1.HAProxyManagerService/ExportState"
+	HAProxyManagerService_ImportState_FullMethodName              = "/haproxy.v1.HAProxyManagerService/ImportState"
+	HAProxyManagerService_ApplyDesiredState_FullMethodName        = "/haproxy.v1.HAProxyManagerService/ApplyDesiredState"
+	HAProxyManagerService_RenderPreview_FullMethodName            = "/haproxy.v1.HAProxyManagerService/RenderPreview"
+	HAProxyManagerService_GetStats_FullMethodName                 = "/haproxy.v1.HAProxyManagerService/GetStats"
+	HAProxyManagerService_SetServerState_FullMethodName           = "/haproxy.v1.HAProxyManagerService/SetServerState"
+	HAProxyManagerService_DrainServer_FullMethodName              = "/haproxy.v1.HAProxyManagerService/DrainServer"
+	HAProxyManagerService_EnterMaintenance_FullMethodName         = "/haproxy.v1.HAProxyManagerService/EnterMaintenance"
+	HAProxyManagerService_ExitMaintenance_FullMethodName          = "/haproxy.v1.HAProxyManagerService/ExitMaintenance"
+	HAProxyManagerService_ListMaintenance_FullMethodName          = "/haproxy.v1.HAProxyManagerService/ListMaintenance"
+	HAProxyManagerService_GetNetplanStatus_FullMethodName         = "/haproxy.v1.HAProxyManagerService/GetNetplanStatus"
+	HAProxyManagerService_GetNetplanTransaction_FullMethodName    = "/haproxy.v1.HAProxyManagerService/GetNetplanTransaction"
+	HAProxyManagerService_CleanupOrphanedAddresses_FullMethodName = "/haproxy.v1.HAProxyManagerService/CleanupOrphanedAddresses"
+	HAProxyManagerService_GetClusterStatus_FullMethodName         = "/haproxy.v1.HAProxyManagerService/GetClusterStatus"
+	HAProxyManagerService_SyncCluster_FullMethodName              = "/haproxy.v1.HAProxyManagerService/SyncCluster"
+	HAProxyManagerService_GetPeerState_FullMethodName             = "/haproxy.v1.HAProxyManagerService/GetPeerState"
+	HAProxyManagerService_GetPeerSyncStatus_FullMethodName        = "/haproxy.v1.HAProxyManagerService/GetPeerSyncStatus"
+	HAProxyManagerService_GetGitOpsStatus_FullMethodName          = "/haproxy.v1.HAProxyManagerService/GetGitOpsStatus"
+	HAProxyManagerService_GetDiscoveryStatus_FullMethodName       = "/haproxy.v1.HAProxyManagerService/GetDiscoveryStatus"
+	HAProxyManagerService_GetDriftStatus_FullMethodName           = "/haproxy.v1.HAProxyManagerService/GetDriftStatus"
+	HAProxyManagerService_CheckDrift_FullMethodName               = "/haproxy.v1.HAProxyManagerService/CheckDrift"
+	HAProxyManagerService_ListEvents_FullMethodName               = "/haproxy.v1.HAProxyManagerService/ListEvents"
+	HAProxyManagerService_WatchChanges_FullMethodName             = "/haproxy.v1.HAProxyManagerService/WatchChanges"
 )
 
 // HAProxyManagerServiceClient is the client API for HAProxyManagerService service.
@@ -53,36 +133,141 @@ const (
 // HAProxyManagerService provides a unified interface for managing HAProxy configuration
 // This service aggregates all HAProxy configuration operations into a single service
 type HAProxyManagerServiceClient interface {
+	// Build and feature information
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	// Uptime, Data Plane API connectivity, open transactions and Netplan state in one call
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// Reload the configuration file, like SIGHUP
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// Change freeze for maintenance windows: while frozen, the RPCs that change the configuration fail with
+	// FAILED_PRECONDITION
+	FreezeChanges(ctx context.Context, in *FreezeChangesRequest, opts ...grpc.CallOption) (*FreezeChangesResponse, error)
+	UnfreezeChanges(ctx context.Context, in *UnfreezeChangesRequest, opts ...grpc.CallOption) (*UnfreezeChangesResponse, error)
 	// Transaction operations
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
 	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error)
 	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	DiffTransaction(ctx context.Context, in *DiffTransactionRequest, opts ...grpc.CallOption) (*DiffTransactionResponse, error)
 	CommitTransaction(ctx context.Context, in *CommitTransactionRequest, opts ...grpc.CallOption) (*CommitTransactionResponse, error)
 	CloseTransaction(ctx context.Context, in *CloseTransactionRequest, opts ...grpc.CallOption) (*CloseTransactionResponse, error)
 	// Backend operations
 	CreateBackend(ctx context.Context, in *CreateBackendRequest, opts ...grpc.CallOption) (*CreateBackendResponse, error)
 	GetBackend(ctx context.Context, in *GetBackendRequest, opts ...grpc.CallOption) (*GetBackendResponse, error)
 	ListBackends(ctx context.Context, in *ListBackendsRequest, opts ...grpc.CallOption) (*ListBackendsResponse, error)
+	StreamBackends(ctx context.Context, in *StreamBackendsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBackendsResponse], error)
 	UpdateBackend(ctx context.Context, in *UpdateBackendRequest, opts ...grpc.CallOption) (*UpdateBackendResponse, error)
 	DeleteBackend(ctx context.Context, in *DeleteBackendRequest, opts ...grpc.CallOption) (*DeleteBackendResponse, error)
+	ApplyBackend(ctx context.Context, in *ApplyBackendRequest, opts ...grpc.CallOption) (*ApplyBackendResponse, error)
 	// Frontend operations
 	CreateFrontend(ctx context.Context, in *CreateFrontendRequest, opts ...grpc.CallOption) (*CreateFrontendResponse, error)
 	GetFrontend(ctx context.Context, in *GetFrontendRequest, opts ...grpc.CallOption) (*GetFrontendResponse, error)
 	ListFrontends(ctx context.Context, in *ListFrontendsRequest, opts ...grpc.CallOption) (*ListFrontendsResponse, error)
 	UpdateFrontend(ctx context.Context, in *UpdateFrontendRequest, opts ...grpc.CallOption) (*UpdateFrontendResponse, error)
 	DeleteFrontend(ctx context.Context, in *DeleteFrontendRequest, opts ...grpc.CallOption) (*DeleteFrontendResponse, error)
+	ApplyFrontend(ctx context.Context, in *ApplyFrontendRequest, opts ...grpc.CallOption) (*ApplyFrontendResponse, error)
+	CreateHTTPSFrontend(ctx context.Context, in *CreateHTTPSFrontendRequest, opts ...grpc.CallOption) (*CreateHTTPSFrontendResponse, error)
+	// Release operations (blue/green and canary deployments)
+	SwapBackends(ctx context.Context, in *SwapBackendsRequest, opts ...grpc.CallOption) (*SwapBackendsResponse, error)
+	ShiftTraffic(ctx context.Context, in *ShiftTrafficRequest, opts ...grpc.CallOption) (*ShiftTrafficResponse, error)
 	// Bind operations (binds are associated with frontends)
 	CreateBind(ctx context.Context, in *CreateBindRequest, opts ...grpc.CallOption) (*CreateBindResponse, error)
 	GetBind(ctx context.Context, in *GetBindRequest, opts ...grpc.CallOption) (*GetBindResponse, error)
 	ListBinds(ctx context.Context, in *ListBindsRequest, opts ...grpc.CallOption) (*ListBindsResponse, error)
 	UpdateBind(ctx context.Context, in *UpdateBindRequest, opts ...grpc.CallOption) (*UpdateBindResponse, error)
 	DeleteBind(ctx context.Context, in *DeleteBindRequest, opts ...grpc.CallOption) (*DeleteBindResponse, error)
+	ApplyBind(ctx context.Context, in *ApplyBindRequest, opts ...grpc.CallOption) (*ApplyBindResponse, error)
+	// Route operations (hostname based routing rules of frontends)
+	CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*CreateRouteResponse, error)
+	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error)
+	ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error)
+	UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error)
+	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*DeleteRouteResponse, error)
+	// Rate limit operations (per-client request limits of frontends)
+	CreateRateLimitPolicy(ctx context.Context, in *CreateRateLimitPolicyRequest, opts ...grpc.CallOption) (*CreateRateLimitPolicyResponse, error)
+	GetRateLimitPolicy(ctx context.Context, in *GetRateLimitPolicyRequest, opts ...grpc.CallOption) (*GetRateLimitPolicyResponse, error)
+	ListRateLimitPolicies(ctx context.Context, in *ListRateLimitPoliciesRequest, opts ...grpc.CallOption) (*ListRateLimitPoliciesResponse, error)
+	UpdateRateLimitPolicy(ctx context.Context, in *UpdateRateLimitPolicyRequest, opts ...grpc.CallOption) (*UpdateRateLimitPolicyResponse, error)
+	DeleteRateLimitPolicy(ctx context.Context, in *DeleteRateLimitPolicyRequest, opts ...grpc.CallOption) (*DeleteRateLimitPolicyResponse, error)
+	// Lua script operations (scripts are stored immediately; loading them and their actions are transactional)
+	UploadLuaScript(ctx context.Context, in *UploadLuaScriptRequest, opts ...grpc.CallOption) (*UploadLuaScriptResponse, error)
+	GetLuaScript(ctx context.Context, in *GetLuaScriptRequest, opts ...grpc.CallOption) (*GetLuaScriptResponse, error)
+	ListLuaScripts(ctx context.Context, in *ListLuaScriptsRequest, opts ...grpc.CallOption) (*ListLuaScriptsResponse, error)
+	LoadLuaScript(ctx context.Context, in *LoadLuaScriptRequest, opts ...grpc.CallOption) (*LoadLuaScriptResponse, error)
+	RollbackLuaScript(ctx context.Context, in *RollbackLuaScriptRequest, opts ...grpc.CallOption) (*RollbackLuaScriptResponse, error)
+	UnloadLuaScript(ctx context.Context, in *UnloadLuaScriptRequest, opts ...grpc.CallOption) (*UnloadLuaScriptResponse, error)
+	DeleteLuaScript(ctx context.Context, in *DeleteLuaScriptRequest, opts ...grpc.CallOption) (*DeleteLuaScriptResponse, error)
+	CreateLuaAction(ctx context.Context, in *CreateLuaActionRequest, opts ...grpc.CallOption) (*CreateLuaActionResponse, error)
+	ListLuaActions(ctx context.Context, in *ListLuaActionsRequest, opts ...grpc.CallOption) (*ListLuaActionsResponse, error)
+	DeleteLuaAction(ctx context.Context, in *DeleteLuaActionRequest, opts ...grpc.CallOption) (*DeleteLuaActionResponse, error)
+	// Ring operations (in-memory buffers of log lines and traces for debugging on the box)
+	CreateRing(ctx context.Context, in *CreateRingRequest, opts ...grpc.CallOption) (*CreateRingResponse, error)
+	GetRing(ctx context.Context, in *GetRingRequest, opts ...grpc.CallOption) (*GetRingResponse, error)
+	ListRings(ctx context.Context, in *ListRingsRequest, opts ...grpc.CallOption) (*ListRingsResponse, error)
+	UpdateRing(ctx context.Context, in *UpdateRingRequest, opts ...grpc.CallOption) (*UpdateRingResponse, error)
+	DeleteRing(ctx context.Context, in *DeleteRingRequest, opts ...grpc.CallOption) (*DeleteRingResponse, error)
+	AttachRingLogTarget(ctx context.Context, in *AttachRingLogTargetRequest, opts ...grpc.CallOption) (*AttachRingLogTargetResponse, error)
+	ListRingLogTargets(ctx context.Context, in *ListRingLogTargetsRequest, opts ...grpc.CallOption) (*ListRingLogTargetsResponse, error)
+	DetachRingLogTarget(ctx context.Context, in *DetachRingLogTargetRequest, opts ...grpc.CallOption) (*DetachRingLogTargetResponse, error)
+	StreamRing(ctx context.Context, in *StreamRingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRingResponse], error)
+	// Program operations (external processes run by the HAProxy master)
+	CreateProgram(ctx context.Context, in *CreateProgramRequest, opts ...grpc.CallOption) (*CreateProgramResponse, error)
+	GetProgram(ctx context.Context, in *GetProgramRequest, opts ...grpc.CallOption) (*GetProgramResponse, error)
+	ListPrograms(ctx context.Context, in *ListProgramsRequest, opts ...grpc.CallOption) (*ListProgramsResponse, error)
+	UpdateProgram(ctx context.Context, in *UpdateProgramRequest, opts ...grpc.CallOption) (*UpdateProgramResponse, error)
+	DeleteProgram(ctx context.Context, in *DeleteProgramRequest, opts ...grpc.CallOption) (*DeleteProgramResponse, error)
+	// Health check operations (http-check rules of backends)
+	CreateHTTPCheck(ctx context.Context, in *CreateHTTPCheckRequest, opts ...grpc.CallOption) (*CreateHTTPCheckResponse, error)
+	ListHTTPChecks(ctx context.Context, in *ListHTTPChecksRequest, opts ...grpc.CallOption) (*ListHTTPChecksResponse, error)
+	UpdateHTTPCheck(ctx context.Context, in *UpdateHTTPCheckRequest, opts ...grpc.CallOption) (*UpdateHTTPCheckResponse, error)
+	DeleteHTTPCheck(ctx context.Context, in *DeleteHTTPCheckRequest, opts ...grpc.CallOption) (*DeleteHTTPCheckResponse, error)
 	// Server operations (servers are associated with backends)
 	CreateServer(ctx context.Context, in *CreateServerRequest, opts ...grpc.CallOption) (*CreateServerResponse, error)
 	GetServer(ctx context.Context, in *GetServerRequest, opts ...grpc.CallOption) (*GetServerResponse, error)
 	ListServers(ctx context.Context, in *ListServersRequest, opts ...grpc.CallOption) (*ListServersResponse, error)
+	StreamServers(ctx context.Context, in *StreamServersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamServersResponse], error)
 	UpdateServer(ctx context.Context, in *UpdateServerRequest, opts ...grpc.CallOption) (*UpdateServerResponse, error)
 	DeleteServer(ctx context.Context, in *DeleteServerRequest, opts ...grpc.CallOption) (*DeleteServerResponse, error)
+	ApplyServer(ctx context.Context, in *ApplyServerRequest, opts ...grpc.CallOption) (*ApplyServerResponse, error)
+	CreateServers(ctx context.Context, in *CreateServersRequest, opts ...grpc.CallOption) (*CreateServersResponse, error)
+	DeleteServers(ctx context.Context, in *DeleteServersRequest, opts ...grpc.CallOption) (*DeleteServersResponse, error)
+	// Labels and annotations of backends, frontends, binds and servers
+	SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*SetMetadataResponse, error)
+	// State export and import
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error)
+	ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (*ImportStateResponse, error)
+	ApplyDesiredState(ctx context.Context, in *ApplyDesiredStateRequest, opts ...grpc.CallOption) (*ApplyDesiredStateResponse, error)
+	RenderPreview(ctx context.Context, in *RenderPreviewRequest, opts ...grpc.CallOption) (*RenderPreviewResponse, error)
+	// Runtime statistics and server states
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	SetServerState(ctx context.Context, in *SetServerStateRequest, opts ...grpc.CallOption) (*SetServerStateResponse, error)
+	DrainServer(ctx context.Context, in *DrainServerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DrainServerResponse], error)
+	// Maintenance of backends and servers
+	EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EnterMaintenanceResponse, error)
+	ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*ExitMaintenanceResponse, error)
+	ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error)
+	// Netplan address management status
+	GetNetplanStatus(ctx context.Context, in *GetNetplanStatusRequest, opts ...grpc.CallOption) (*GetNetplanStatusResponse, error)
+	// Netplan side of a transaction, including committed and failed ones
+	GetNetplanTransaction(ctx context.Context, in *GetNetplanTransactionRequest, opts ...grpc.CallOption) (*GetNetplanTransactionResponse, error)
+	// Find VIPs in the Netplan configuration that no bind listens on, and optionally remove them
+	CleanupOrphanedAddresses(ctx context.Context, in *CleanupOrphanedAddressesRequest, opts ...grpc.CallOption) (*CleanupOrphanedAddressesResponse, error)
+	// Replication of the default instance to the cluster nodes
+	GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*GetClusterStatusResponse, error)
+	SyncCluster(ctx context.Context, in *SyncClusterRequest, opts ...grpc.CallOption) (*SyncClusterResponse, error)
+	// State copied by standby configurators from the leader
+	GetPeerState(ctx context.Context, in *GetPeerStateRequest, opts ...grpc.CallOption) (*GetPeerStateResponse, error)
+	GetPeerSyncStatus(ctx context.Context, in *GetPeerSyncStatusRequest, opts ...grpc.CallOption) (*GetPeerSyncStatusResponse, error)
+	// GitOps reconciliation status
+	GetGitOpsStatus(ctx context.Context, in *GetGitOpsStatusRequest, opts ...grpc.CallOption) (*GetGitOpsStatusResponse, error)
+	// Service discovery status
+	GetDiscoveryStatus(ctx context.Context, in *GetDiscoveryStatusRequest, opts ...grpc.CallOption) (*GetDiscoveryStatusResponse, error)
+	// Drift between the live configuration and the desired state
+	GetDriftStatus(ctx context.Context, in *GetDriftStatusRequest, opts ...grpc.CallOption) (*GetDriftStatusResponse, error)
+	CheckDrift(ctx context.Context, in *CheckDriftRequest, opts ...grpc.CallOption) (*CheckDriftResponse, error)
+	// Event journal operations
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchChangesResponse], error)
 }
 
 type hAProxyManagerServiceClient struct {
@@ -93,6 +278,56 @@ func NewHAProxyManagerServiceClient(cc grpc.ClientConnInterface) HAProxyManagerS
 	return &hAProxyManagerServiceClient{cc}
 }
 
+func (c *hAProxyManagerServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) FreezeChanges(ctx context.Context, in *FreezeChangesRequest, opts ...grpc.CallOption) (*FreezeChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreezeChangesResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_FreezeChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) UnfreezeChanges(ctx context.Context, in *UnfreezeChangesRequest, opts ...grpc.CallOption) (*UnfreezeChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnfreezeChangesResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UnfreezeChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hAProxyManagerServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetVersionResponse)
@@ -123,6 +358,26 @@ func (c *hAProxyManagerServiceClient) GetTransaction(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *hAProxyManagerServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DiffTransaction(ctx context.Context, in *DiffTransactionRequest, opts ...grpc.CallOption) (*DiffTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffTransactionResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DiffTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hAProxyManagerServiceClient) CommitTransaction(ctx context.Context, in *CommitTransactionRequest, opts ...grpc.CallOption) (*CommitTransactionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommitTransactionResponse)
@@ -173,6 +428,25 @@ func (c *hAProxyManagerServiceClient) ListBackends(ctx context.Context, in *List
 	return out, nil
 }
 
+func (c *hAProxyManagerServiceClient) StreamBackends(ctx context.Context, in *StreamBackendsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBackendsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HAProxyManagerService_ServiceDesc.Streams[0], HAProxyManagerService_StreamBackends_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamBackendsRequest, StreamBackendsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamBackendsClient = grpc.ServerStreamingClient[StreamBackendsResponse]
+
 func (c *hAProxyManagerServiceClient) UpdateBackend(ctx context.Context, in *UpdateBackendRequest, opts ...grpc.CallOption) (*UpdateBackendResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateBackendResponse)
@@ -193,6 +467,16 @@ func (c *hAProxyManagerServiceClient) DeleteBackend(ctx context.Context, in *Del
 	return out, nil
 }
 
+func (c *hAProxyManagerServiceClient) ApplyBackend(ctx context.Context, in *ApplyBackendRequest, opts ...grpc.CallOption) (*ApplyBackendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyBackendResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ApplyBackend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hAProxyManagerServiceClient) CreateFrontend(ctx context.Context, in *CreateFrontendRequest, opts ...grpc.CallOption) (*CreateFrontendResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateFrontendResponse)
@@ -243,6 +527,46 @@ func (c *hAProxyManagerServiceClient) DeleteFrontend(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *hAProxyManagerServiceClient) ApplyFrontend(ctx context.Context, in *ApplyFrontendRequest, opts ...grpc.CallOption) (*ApplyFrontendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyFrontendResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ApplyFrontend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CreateHTTPSFrontend(ctx context.Context, in *CreateHTTPSFrontendRequest, opts ...grpc.CallOption) (*CreateHTTPSFrontendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHTTPSFrontendResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateHTTPSFrontend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) SwapBackends(ctx context.Context, in *SwapBackendsRequest, opts ...grpc.CallOption) (*SwapBackendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SwapBackendsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_SwapBackends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ShiftTraffic(ctx context.Context, in *ShiftTrafficRequest, opts ...grpc.CallOption) (*ShiftTrafficResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShiftTrafficResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ShiftTraffic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hAProxyManagerServiceClient) CreateBind(ctx context.Context, in *CreateBindRequest, opts ...grpc.CallOption) (*CreateBindResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateBindResponse)
@@ -293,649 +617,3105 @@ func (c *hAProxyManagerServiceClient) DeleteBind(ctx context.Context, in *Delete
 	return out, nil
 }
 
-func (c *hAProxyManagerServiceClient) CreateServer(ctx context.Context, in *CreateServerRequest, opts ...grpc.CallOption) (*CreateServerResponse, error) {
+func (c *hAProxyManagerServiceClient) ApplyBind(ctx context.Context, in *ApplyBindRequest, opts ...grpc.CallOption) (*ApplyBindResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateServerResponse)
-	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateServer_FullMethodName, in, out, cOpts...)
+	out := new(ApplyBindResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ApplyBind_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hAProxyManagerServiceClient) GetServer(ctx context.Context, in *GetServerRequest, opts ...grpc.CallOption) (*GetServerResponse, error) {
+func (c *hAProxyManagerServiceClient) CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*CreateRouteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetServerResponse)
-	err := c.cc.Invoke(ctx, HAProxyManagerService_GetServer_FullMethodName, in, out, cOpts...)
+	out := new(CreateRouteResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateRoute_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hAProxyManagerServiceClient) ListServers(ctx context.Context, in *ListServersRequest, opts ...grpc.CallOption) (*ListServersResponse, error) {
+func (c *hAProxyManagerServiceClient) GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListServersResponse)
-	err := c.cc.Invoke(ctx, HAProxyManagerService_ListServers_FullMethodName, in, out, cOpts...)
+	out := new(GetRouteResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetRoute_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hAProxyManagerServiceClient) UpdateServer(ctx context.Context, in *UpdateServerRequest, opts ...grpc.CallOption) (*UpdateServerResponse, error) {
+func (c *hAProxyManagerServiceClient) ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateServerResponse)
-	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateServer_FullMethodName, in, out, cOpts...)
+	out := new(ListRoutesResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListRoutes_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hAProxyManagerServiceClient) DeleteServer(ctx context.Context, in *DeleteServerRequest, opts ...grpc.CallOption) (*DeleteServerResponse, error) {
+func (c *hAProxyManagerServiceClient) UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteServerResponse)
-	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteServer_FullMethodName, in, out, cOpts...)
+	out := new(UpdateRouteResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateRoute_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// HAProxyManagerServiceServer is the server API for HAProxyManagerService service.
-// All implementations must embed UnimplementedHAProxyManagerServiceServer
-// for forward compatibility.
-//
-// HAProxyManagerService provides a unified interface for managing HAProxy configuration
-// This service aggregates all HAProxy configuration operations into a single service
-type HAProxyManagerServiceServer interface {
-	// Transaction operations
-	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
-	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
-	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
-	CommitTransaction(context.Context, *CommitTransactionRequest) (*CommitTransactionResponse, error)
-	CloseTransaction(context.Context, *CloseTransactionRequest) (*CloseTransactionResponse, error)
-	// Backend operations
-	CreateBackend(context.Context, *CreateBackendRequest) (*CreateBackendResponse, error)
-	GetBackend(context.Context, *GetBackendRequest) (*GetBackendResponse, error)
-	ListBackends(context.Context, *ListBackendsRequest) (*ListBackendsResponse, error)
-	UpdateBackend(context.Context, *UpdateBackendRequest) (*UpdateBackendResponse, error)
-	DeleteBackend(context.Context, *DeleteBackendRequest) (*DeleteBackendResponse, error)
-	// Frontend operations
-	CreateFrontend(context.Context, *CreateFrontendRequest) (*CreateFrontendResponse, error)
-	GetFrontend(context.Context, *GetFrontendRequest) (*GetFrontendResponse, error)
-	ListFrontends(context.Context, *ListFrontendsRequest) (*ListFrontendsResponse, error)
-	UpdateFrontend(context.Context, *UpdateFrontendRequest) (*UpdateFrontendResponse, error)
-	DeleteFrontend(context.Context, *DeleteFrontendRequest) (*DeleteFrontendResponse, error)
-	// Bind operations (binds are associated with frontends)
-	CreateBind(context.Context, *CreateBindRequest) (*CreateBindResponse, error)
-	GetBind(context.Context, *GetBindRequest) (*GetBindResponse, error)
-	ListBinds(context.Context, *ListBindsRequest) (*ListBindsResponse, error)
-	UpdateBind(context.Context, *UpdateBindRequest) (*UpdateBindResponse, error)
-	DeleteBind(context.Context, *DeleteBindRequest) (*DeleteBindResponse, error)
-	// Server operations (servers are associated with backends)
-	CreateServer(context.Context, *CreateServerRequest) (*CreateServerResponse, error)
-	GetServer(context.Context, *GetServerRequest) (*GetServerResponse, error)
-	ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error)
-	UpdateServer(context.Context, *UpdateServerRequest) (*UpdateServerResponse, error)
-	DeleteServer(context.Context, *DeleteServerRequest) (*DeleteServerResponse, error)
-	mustEmbedUnimplementedHAProxyManagerServiceServer()
+func (c *hAProxyManagerServiceClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*DeleteRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRouteResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedHAProxyManagerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedHAProxyManagerServiceServer struct{}
-
-func (UnimplementedHAProxyManagerServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+func (c *hAProxyManagerServiceClient) CreateRateLimitPolicy(ctx context.Context, in *CreateRateLimitPolicyRequest, opts ...grpc.CallOption) (*CreateRateLimitPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRateLimitPolicyResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateRateLimitPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+
+func (c *hAProxyManagerServiceClient) GetRateLimitPolicy(ctx context.Context, in *GetRateLimitPolicyRequest, opts ...grpc.CallOption) (*GetRateLimitPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRateLimitPolicyResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetRateLimitPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+
+func (c *hAProxyManagerServiceClient) ListRateLimitPolicies(ctx context.Context, in *ListRateLimitPoliciesRequest, opts ...grpc.CallOption) (*ListRateLimitPoliciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRateLimitPoliciesResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListRateLimitPolicies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) CommitTransaction(context.Context, *CommitTransactionRequest) (*CommitTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CommitTransaction not implemented")
+
+func (c *hAProxyManagerServiceClient) UpdateRateLimitPolicy(ctx context.Context, in *UpdateRateLimitPolicyRequest, opts ...grpc.CallOption) (*UpdateRateLimitPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRateLimitPolicyResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateRateLimitPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) CloseTransaction(context.Context, *CloseTransactionRequest) (*CloseTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CloseTransaction not implemented")
+
+func (c *hAProxyManagerServiceClient) DeleteRateLimitPolicy(ctx context.Context, in *DeleteRateLimitPolicyRequest, opts ...grpc.CallOption) (*DeleteRateLimitPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRateLimitPolicyResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteRateLimitPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) CreateBackend(context.Context, *CreateBackendRequest) (*CreateBackendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateBackend not implemented")
+
+func (c *hAProxyManagerServiceClient) UploadLuaScript(ctx context.Context, in *UploadLuaScriptRequest, opts ...grpc.CallOption) (*UploadLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UploadLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) GetBackend(context.Context, *GetBackendRequest) (*GetBackendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBackend not implemented")
+
+func (c *hAProxyManagerServiceClient) GetLuaScript(ctx context.Context, in *GetLuaScriptRequest, opts ...grpc.CallOption) (*GetLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) ListBackends(context.Context, *ListBackendsRequest) (*ListBackendsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListBackends not implemented")
+
+func (c *hAProxyManagerServiceClient) ListLuaScripts(ctx context.Context, in *ListLuaScriptsRequest, opts ...grpc.CallOption) (*ListLuaScriptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLuaScriptsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListLuaScripts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) UpdateBackend(context.Context, *UpdateBackendRequest) (*UpdateBackendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateBackend not implemented")
+
+func (c *hAProxyManagerServiceClient) LoadLuaScript(ctx context.Context, in *LoadLuaScriptRequest, opts ...grpc.CallOption) (*LoadLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_LoadLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) DeleteBackend(context.Context, *DeleteBackendRequest) (*DeleteBackendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackend not implemented")
+
+func (c *hAProxyManagerServiceClient) RollbackLuaScript(ctx context.Context, in *RollbackLuaScriptRequest, opts ...grpc.CallOption) (*RollbackLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_RollbackLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) CreateFrontend(context.Context, *CreateFrontendRequest) (*CreateFrontendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateFrontend not implemented")
+
+func (c *hAProxyManagerServiceClient) UnloadLuaScript(ctx context.Context, in *UnloadLuaScriptRequest, opts ...grpc.CallOption) (*UnloadLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnloadLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UnloadLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) GetFrontend(context.Context, *GetFrontendRequest) (*GetFrontendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFrontend not implemented")
+
+func (c *hAProxyManagerServiceClient) DeleteLuaScript(ctx context.Context, in *DeleteLuaScriptRequest, opts ...grpc.CallOption) (*DeleteLuaScriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLuaScriptResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteLuaScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedHAProxyManagerServiceServer) ListFrontends(context.Context, *ListFrontendsRequest) (*ListFrontendsResponse, error) {
+
+func (c *hAProxyManagerServiceClient) CreateLuaAction(ctx context.Context, in *CreateLuaActionRequest, opts ...grpc.CallOption) (*CreateLuaActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLuaActionResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateLuaAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListLuaActions(ctx context.Context, in *ListLuaActionsRequest, opts ...grpc.CallOption) (*ListLuaActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLuaActionsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListLuaActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteLuaAction(ctx context.Context, in *DeleteLuaActionRequest, opts ...grpc.CallOption) (*DeleteLuaActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLuaActionResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteLuaAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CreateRing(ctx context.Context, in *CreateRingRequest, opts ...grpc.CallOption) (*CreateRingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRingResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateRing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetRing(ctx context.Context, in *GetRingRequest, opts ...grpc.CallOption) (*GetRingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRingResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetRing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListRings(ctx context.Context, in *ListRingsRequest, opts ...grpc.CallOption) (*ListRingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRingsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListRings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) UpdateRing(ctx context.Context, in *UpdateRingRequest, opts ...grpc.CallOption) (*UpdateRingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRingResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateRing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteRing(ctx context.Context, in *DeleteRingRequest, opts ...grpc.CallOption) (*DeleteRingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRingResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteRing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) AttachRingLogTarget(ctx context.Context, in *AttachRingLogTargetRequest, opts ...grpc.CallOption) (*AttachRingLogTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachRingLogTargetResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_AttachRingLogTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListRingLogTargets(ctx context.Context, in *ListRingLogTargetsRequest, opts ...grpc.CallOption) (*ListRingLogTargetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRingLogTargetsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListRingLogTargets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DetachRingLogTarget(ctx context.Context, in *DetachRingLogTargetRequest, opts ...grpc.CallOption) (*DetachRingLogTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetachRingLogTargetResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DetachRingLogTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) StreamRing(ctx context.Context, in *StreamRingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRingResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HAProxyManagerService_ServiceDesc.Streams[1], HAProxyManagerService_StreamRing_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRingRequest, StreamRingResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamRingClient = grpc.ServerStreamingClient[StreamRingResponse]
+
+func (c *hAProxyManagerServiceClient) CreateProgram(ctx context.Context, in *CreateProgramRequest, opts ...grpc.CallOption) (*CreateProgramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateProgramResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateProgram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetProgram(ctx context.Context, in *GetProgramRequest, opts ...grpc.CallOption) (*GetProgramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProgramResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetProgram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListPrograms(ctx context.Context, in *ListProgramsRequest, opts ...grpc.CallOption) (*ListProgramsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProgramsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListPrograms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) UpdateProgram(ctx context.Context, in *UpdateProgramRequest, opts ...grpc.CallOption) (*UpdateProgramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProgramResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateProgram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteProgram(ctx context.Context, in *DeleteProgramRequest, opts ...grpc.CallOption) (*DeleteProgramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProgramResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteProgram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CreateHTTPCheck(ctx context.Context, in *CreateHTTPCheckRequest, opts ...grpc.CallOption) (*CreateHTTPCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHTTPCheckResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateHTTPCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListHTTPChecks(ctx context.Context, in *ListHTTPChecksRequest, opts ...grpc.CallOption) (*ListHTTPChecksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHTTPChecksResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListHTTPChecks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) UpdateHTTPCheck(ctx context.Context, in *UpdateHTTPCheckRequest, opts ...grpc.CallOption) (*UpdateHTTPCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateHTTPCheckResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateHTTPCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteHTTPCheck(ctx context.Context, in *DeleteHTTPCheckRequest, opts ...grpc.CallOption) (*DeleteHTTPCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteHTTPCheckResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteHTTPCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CreateServer(ctx context.Context, in *CreateServerRequest, opts ...grpc.CallOption) (*CreateServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateServerResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetServer(ctx context.Context, in *GetServerRequest, opts ...grpc.CallOption) (*GetServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListServers(ctx context.Context, in *ListServersRequest, opts ...grpc.CallOption) (*ListServersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServersResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) StreamServers(ctx context.Context, in *StreamServersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamServersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HAProxyManagerService_ServiceDesc.Streams[2], HAProxyManagerService_StreamServers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamServersRequest, StreamServersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamServersClient = grpc.ServerStreamingClient[StreamServersResponse]
+
+func (c *hAProxyManagerServiceClient) UpdateServer(ctx context.Context, in *UpdateServerRequest, opts ...grpc.CallOption) (*UpdateServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateServerResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_UpdateServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteServer(ctx context.Context, in *DeleteServerRequest, opts ...grpc.CallOption) (*DeleteServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteServerResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ApplyServer(ctx context.Context, in *ApplyServerRequest, opts ...grpc.CallOption) (*ApplyServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyServerResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ApplyServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CreateServers(ctx context.Context, in *CreateServersRequest, opts ...grpc.CallOption) (*CreateServersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateServersResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CreateServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DeleteServers(ctx context.Context, in *DeleteServersRequest, opts ...grpc.CallOption) (*DeleteServersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteServersResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_DeleteServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*SetMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMetadataResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_SetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportStateResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ExportState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (*ImportStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportStateResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ImportState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ApplyDesiredState(ctx context.Context, in *ApplyDesiredStateRequest, opts ...grpc.CallOption) (*ApplyDesiredStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyDesiredStateResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ApplyDesiredState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) RenderPreview(ctx context.Context, in *RenderPreviewRequest, opts ...grpc.CallOption) (*RenderPreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderPreviewResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_RenderPreview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) SetServerState(ctx context.Context, in *SetServerStateRequest, opts ...grpc.CallOption) (*SetServerStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetServerStateResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_SetServerState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) DrainServer(ctx context.Context, in *DrainServerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DrainServerResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HAProxyManagerService_ServiceDesc.Streams[3], HAProxyManagerService_DrainServer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DrainServerRequest, DrainServerResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_DrainServerClient = grpc.ServerStreamingClient[DrainServerResponse]
+
+func (c *hAProxyManagerServiceClient) EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EnterMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnterMaintenanceResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_EnterMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*ExitMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExitMaintenanceResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ExitMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMaintenanceResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetNetplanStatus(ctx context.Context, in *GetNetplanStatusRequest, opts ...grpc.CallOption) (*GetNetplanStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetplanStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetNetplanStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetNetplanTransaction(ctx context.Context, in *GetNetplanTransactionRequest, opts ...grpc.CallOption) (*GetNetplanTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetplanTransactionResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetNetplanTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CleanupOrphanedAddresses(ctx context.Context, in *CleanupOrphanedAddressesRequest, opts ...grpc.CallOption) (*CleanupOrphanedAddressesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupOrphanedAddressesResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CleanupOrphanedAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*GetClusterStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetClusterStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetClusterStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) SyncCluster(ctx context.Context, in *SyncClusterRequest, opts ...grpc.CallOption) (*SyncClusterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncClusterResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_SyncCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetPeerState(ctx context.Context, in *GetPeerStateRequest, opts ...grpc.CallOption) (*GetPeerStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPeerStateResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetPeerState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetPeerSyncStatus(ctx context.Context, in *GetPeerSyncStatusRequest, opts ...grpc.CallOption) (*GetPeerSyncStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPeerSyncStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetPeerSyncStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetGitOpsStatus(ctx context.Context, in *GetGitOpsStatusRequest, opts ...grpc.CallOption) (*GetGitOpsStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGitOpsStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetGitOpsStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetDiscoveryStatus(ctx context.Context, in *GetDiscoveryStatusRequest, opts ...grpc.CallOption) (*GetDiscoveryStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDiscoveryStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetDiscoveryStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) GetDriftStatus(ctx context.Context, in *GetDriftStatusRequest, opts ...grpc.CallOption) (*GetDriftStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriftStatusResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_GetDriftStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) CheckDrift(ctx context.Context, in *CheckDriftRequest, opts ...grpc.CallOption) (*CheckDriftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDriftResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_CheckDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, HAProxyManagerService_ListEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hAProxyManagerServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchChangesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HAProxyManagerService_ServiceDesc.Streams[4], HAProxyManagerService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchChangesRequest, WatchChangesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_WatchChangesClient = grpc.ServerStreamingClient[WatchChangesResponse]
+
+// HAProxyManagerServiceServer is the server API for HAProxyManagerService service.
+// All implementations must embed UnimplementedHAProxyManagerServiceServer
+// for forward compatibility.
+//
+// HAProxyManagerService provides a unified interface for managing HAProxy configuration
+// This service aggregates all HAProxy configuration operations into a single service
+type HAProxyManagerServiceServer interface {
+	// Build and feature information
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	// Uptime, Data Plane API connectivity, open transactions and Netplan state in one call
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// Reload the configuration file, like SIGHUP
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// Change freeze for maintenance windows: while frozen, the RPCs that change the configuration fail with
+	// FAILED_PRECONDITION
+	FreezeChanges(context.Context, *FreezeChangesRequest) (*FreezeChangesResponse, error)
+	UnfreezeChanges(context.Context, *UnfreezeChangesRequest) (*UnfreezeChangesResponse, error)
+	// Transaction operations
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	DiffTransaction(context.Context, *DiffTransactionRequest) (*DiffTransactionResponse, error)
+	CommitTransaction(context.Context, *CommitTransactionRequest) (*CommitTransactionResponse, error)
+	CloseTransaction(context.Context, *CloseTransactionRequest) (*CloseTransactionResponse, error)
+	// Backend operations
+	CreateBackend(context.Context, *CreateBackendRequest) (*CreateBackendResponse, error)
+	GetBackend(context.Context, *GetBackendRequest) (*GetBackendResponse, error)
+	ListBackends(context.Context, *ListBackendsRequest) (*ListBackendsResponse, error)
+	StreamBackends(*StreamBackendsRequest, grpc.ServerStreamingServer[StreamBackendsResponse]) error
+	UpdateBackend(context.Context, *UpdateBackendRequest) (*UpdateBackendResponse, error)
+	DeleteBackend(context.Context, *DeleteBackendRequest) (*DeleteBackendResponse, error)
+	ApplyBackend(context.Context, *ApplyBackendRequest) (*ApplyBackendResponse, error)
+	// Frontend operations
+	CreateFrontend(context.Context, *CreateFrontendRequest) (*CreateFrontendResponse, error)
+	GetFrontend(context.Context, *GetFrontendRequest) (*GetFrontendResponse, error)
+	ListFrontends(context.Context, *ListFrontendsRequest) (*ListFrontendsResponse, error)
+	UpdateFrontend(context.Context, *UpdateFrontendRequest) (*UpdateFrontendResponse, error)
+	DeleteFrontend(context.Context, *DeleteFrontendRequest) (*DeleteFrontendResponse, error)
+	ApplyFrontend(context.Context, *ApplyFrontendRequest) (*ApplyFrontendResponse, error)
+	CreateHTTPSFrontend(context.Context, *CreateHTTPSFrontendRequest) (*CreateHTTPSFrontendResponse, error)
+	// Release operations (blue/green and canary deployments)
+	SwapBackends(context.Context, *SwapBackendsRequest) (*SwapBackendsResponse, error)
+	ShiftTraffic(context.Context, *ShiftTrafficRequest) (*ShiftTrafficResponse, error)
+	// Bind operations (binds are associated with frontends)
+	CreateBind(context.Context, *CreateBindRequest) (*CreateBindResponse, error)
+	GetBind(context.Context, *GetBindRequest) (*GetBindResponse, error)
+	ListBinds(context.Context, *ListBindsRequest) (*ListBindsResponse, error)
+	UpdateBind(context.Context, *UpdateBindRequest) (*UpdateBindResponse, error)
+	DeleteBind(context.Context, *DeleteBindRequest) (*DeleteBindResponse, error)
+	ApplyBind(context.Context, *ApplyBindRequest) (*ApplyBindResponse, error)
+	// Route operations (hostname based routing rules of frontends)
+	CreateRoute(context.Context, *CreateRouteRequest) (*CreateRouteResponse, error)
+	GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error)
+	ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error)
+	UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error)
+	DeleteRoute(context.Context, *DeleteRouteRequest) (*DeleteRouteResponse, error)
+	// Rate limit operations (per-client request limits of frontends)
+	CreateRateLimitPolicy(context.Context, *CreateRateLimitPolicyRequest) (*CreateRateLimitPolicyResponse, error)
+	GetRateLimitPolicy(context.Context, *GetRateLimitPolicyRequest) (*GetRateLimitPolicyResponse, error)
+	ListRateLimitPolicies(context.Context, *ListRateLimitPoliciesRequest) (*ListRateLimitPoliciesResponse, error)
+	UpdateRateLimitPolicy(context.Context, *UpdateRateLimitPolicyRequest) (*UpdateRateLimitPolicyResponse, error)
+	DeleteRateLimitPolicy(context.Context, *DeleteRateLimitPolicyRequest) (*DeleteRateLimitPolicyResponse, error)
+	// Lua script operations (scripts are stored immediately; loading them and their actions are transactional)
+	UploadLuaScript(context.Context, *UploadLuaScriptRequest) (*UploadLuaScriptResponse, error)
+	GetLuaScript(context.Context, *GetLuaScriptRequest) (*GetLuaScriptResponse, error)
+	ListLuaScripts(context.Context, *ListLuaScriptsRequest) (*ListLuaScriptsResponse, error)
+	LoadLuaScript(context.Context, *LoadLuaScriptRequest) (*LoadLuaScriptResponse, error)
+	RollbackLuaScript(context.Context, *RollbackLuaScriptRequest) (*RollbackLuaScriptResponse, error)
+	UnloadLuaScript(context.Context, *UnloadLuaScriptRequest) (*UnloadLuaScriptResponse, error)
+	DeleteLuaScript(context.Context, *DeleteLuaScriptRequest) (*DeleteLuaScriptResponse, error)
+	CreateLuaAction(context.Context, *CreateLuaActionRequest) (*CreateLuaActionResponse, error)
+	ListLuaActions(context.Context, *ListLuaActionsRequest) (*ListLuaActionsResponse, error)
+	DeleteLuaAction(context.Context, *DeleteLuaActionRequest) (*DeleteLuaActionResponse, error)
+	// Ring operations (in-memory buffers of log lines and traces for debugging on the box)
+	CreateRing(context.Context, *CreateRingRequest) (*CreateRingResponse, error)
+	GetRing(context.Context, *GetRingRequest) (*GetRingResponse, error)
+	ListRings(context.Context, *ListRingsRequest) (*ListRingsResponse, error)
+	UpdateRing(context.Context, *UpdateRingRequest) (*UpdateRingResponse, error)
+	DeleteRing(context.Context, *DeleteRingRequest) (*DeleteRingResponse, error)
+	AttachRingLogTarget(context.Context, *AttachRingLogTargetRequest) (*AttachRingLogTargetResponse, error)
+	ListRingLogTargets(context.Context, *ListRingLogTargetsRequest) (*ListRingLogTargetsResponse, error)
+	DetachRingLogTarget(context.Context, *DetachRingLogTargetRequest) (*DetachRingLogTargetResponse, error)
+	StreamRing(*StreamRingRequest, grpc.ServerStreamingServer[StreamRingResponse]) error
+	// Program operations (external processes run by the HAProxy master)
+	CreateProgram(context.Context, *CreateProgramRequest) (*CreateProgramResponse, error)
+	GetProgram(context.Context, *GetProgramRequest) (*GetProgramResponse, error)
+	ListPrograms(context.Context, *ListProgramsRequest) (*ListProgramsResponse, error)
+	UpdateProgram(context.Context, *UpdateProgramRequest) (*UpdateProgramResponse, error)
+	DeleteProgram(context.Context, *DeleteProgramRequest) (*DeleteProgramResponse, error)
+	// Health check operations (http-check rules of backends)
+	CreateHTTPCheck(context.Context, *CreateHTTPCheckRequest) (*CreateHTTPCheckResponse, error)
+	ListHTTPChecks(context.Context, *ListHTTPChecksRequest) (*ListHTTPChecksResponse, error)
+	UpdateHTTPCheck(context.Context, *UpdateHTTPCheckRequest) (*UpdateHTTPCheckResponse, error)
+	DeleteHTTPCheck(context.Context, *DeleteHTTPCheckRequest) (*DeleteHTTPCheckResponse, error)
+	// Server operations (servers are associated with backends)
+	CreateServer(context.Context, *CreateServerRequest) (*CreateServerResponse, error)
+	GetServer(context.Context, *GetServerRequest) (*GetServerResponse, error)
+	ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error)
+	StreamServers(*StreamServersRequest, grpc.ServerStreamingServer[StreamServersResponse]) error
+	UpdateServer(context.Context, *UpdateServerRequest) (*UpdateServerResponse, error)
+	DeleteServer(context.Context, *DeleteServerRequest) (*DeleteServerResponse, error)
+	ApplyServer(context.Context, *ApplyServerRequest) (*ApplyServerResponse, error)
+	CreateServers(context.Context, *CreateServersRequest) (*CreateServersResponse, error)
+	DeleteServers(context.Context, *DeleteServersRequest) (*DeleteServersResponse, error)
+	// Labels and annotations of backends, frontends, binds and servers
+	SetMetadata(context.Context, *SetMetadataRequest) (*SetMetadataResponse, error)
+	// State export and import
+	ExportState(context.Context, *ExportStateRequest) (*ExportStateResponse, error)
+	ImportState(context.Context, *ImportStateRequest) (*ImportStateResponse, error)
+	ApplyDesiredState(context.Context, *ApplyDesiredStateRequest) (*ApplyDesiredStateResponse, error)
+	RenderPreview(context.Context, *RenderPreviewRequest) (*RenderPreviewResponse, error)
+	// Runtime statistics and server states
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	SetServerState(context.Context, *SetServerStateRequest) (*SetServerStateResponse, error)
+	DrainServer(*DrainServerRequest, grpc.ServerStreamingServer[DrainServerResponse]) error
+	// Maintenance of backends and servers
+	EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EnterMaintenanceResponse, error)
+	ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*ExitMaintenanceResponse, error)
+	ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error)
+	// Netplan address management status
+	GetNetplanStatus(context.Context, *GetNetplanStatusRequest) (*GetNetplanStatusResponse, error)
+	// Netplan side of a transaction, including committed and failed ones
+	GetNetplanTransaction(context.Context, *GetNetplanTransactionRequest) (*GetNetplanTransactionResponse, error)
+	// Find VIPs in the Netplan configuration that no bind listens on, and optionally remove them
+	CleanupOrphanedAddresses(context.Context, *CleanupOrphanedAddressesRequest) (*CleanupOrphanedAddressesResponse, error)
+	// Replication of the default instance to the cluster nodes
+	GetClusterStatus(context.Context, *GetClusterStatusRequest) (*GetClusterStatusResponse, error)
+	SyncCluster(context.Context, *SyncClusterRequest) (*SyncClusterResponse, error)
+	// State copied by standby configurators from the leader
+	GetPeerState(context.Context, *GetPeerStateRequest) (*GetPeerStateResponse, error)
+	GetPeerSyncStatus(context.Context, *GetPeerSyncStatusRequest) (*GetPeerSyncStatusResponse, error)
+	// GitOps reconciliation status
+	GetGitOpsStatus(context.Context, *GetGitOpsStatusRequest) (*GetGitOpsStatusResponse, error)
+	// Service discovery status
+	GetDiscoveryStatus(context.Context, *GetDiscoveryStatusRequest) (*GetDiscoveryStatusResponse, error)
+	// Drift between the live configuration and the desired state
+	GetDriftStatus(context.Context, *GetDriftStatusRequest) (*GetDriftStatusResponse, error)
+	CheckDrift(context.Context, *CheckDriftRequest) (*CheckDriftResponse, error)
+	// Event journal operations
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[WatchChangesResponse]) error
+	mustEmbedUnimplementedHAProxyManagerServiceServer()
+}
+
+// UnimplementedHAProxyManagerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHAProxyManagerServiceServer struct{}
+
+func (UnimplementedHAProxyManagerServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) FreezeChanges(context.Context, *FreezeChangesRequest) (*FreezeChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeChanges not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UnfreezeChanges(context.Context, *UnfreezeChangesRequest) (*UnfreezeChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeChanges not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DiffTransaction(context.Context, *DiffTransactionRequest) (*DiffTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CommitTransaction(context.Context, *CommitTransactionRequest) (*CommitTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CloseTransaction(context.Context, *CloseTransactionRequest) (*CloseTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateBackend(context.Context, *CreateBackendRequest) (*CreateBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBackend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetBackend(context.Context, *GetBackendRequest) (*GetBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListBackends(context.Context, *ListBackendsRequest) (*ListBackendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackends not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) StreamBackends(*StreamBackendsRequest, grpc.ServerStreamingServer[StreamBackendsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBackends not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateBackend(context.Context, *UpdateBackendRequest) (*UpdateBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBackend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteBackend(context.Context, *DeleteBackendRequest) (*DeleteBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ApplyBackend(context.Context, *ApplyBackendRequest) (*ApplyBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyBackend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateFrontend(context.Context, *CreateFrontendRequest) (*CreateFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetFrontend(context.Context, *GetFrontendRequest) (*GetFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListFrontends(context.Context, *ListFrontendsRequest) (*ListFrontendsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFrontends not implemented")
 }
-func (UnimplementedHAProxyManagerServiceServer) UpdateFrontend(context.Context, *UpdateFrontendRequest) (*UpdateFrontendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateFrontend not implemented")
+func (UnimplementedHAProxyManagerServiceServer) UpdateFrontend(context.Context, *UpdateFrontendRequest) (*UpdateFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteFrontend(context.Context, *DeleteFrontendRequest) (*DeleteFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ApplyFrontend(context.Context, *ApplyFrontendRequest) (*ApplyFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateHTTPSFrontend(context.Context, *CreateHTTPSFrontendRequest) (*CreateHTTPSFrontendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHTTPSFrontend not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) SwapBackends(context.Context, *SwapBackendsRequest) (*SwapBackendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapBackends not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ShiftTraffic(context.Context, *ShiftTrafficRequest) (*ShiftTrafficResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShiftTraffic not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateBind(context.Context, *CreateBindRequest) (*CreateBindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBind not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetBind(context.Context, *GetBindRequest) (*GetBindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBind not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListBinds(context.Context, *ListBindsRequest) (*ListBindsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBinds not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateBind(context.Context, *UpdateBindRequest) (*UpdateBindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBind not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteBind(context.Context, *DeleteBindRequest) (*DeleteBindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBind not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ApplyBind(context.Context, *ApplyBindRequest) (*ApplyBindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyBind not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateRoute(context.Context, *CreateRouteRequest) (*CreateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoute not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoute not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoutes not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoute not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteRoute(context.Context, *DeleteRouteRequest) (*DeleteRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoute not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateRateLimitPolicy(context.Context, *CreateRateLimitPolicyRequest) (*CreateRateLimitPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRateLimitPolicy not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetRateLimitPolicy(context.Context, *GetRateLimitPolicyRequest) (*GetRateLimitPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitPolicy not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListRateLimitPolicies(context.Context, *ListRateLimitPoliciesRequest) (*ListRateLimitPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRateLimitPolicies not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateRateLimitPolicy(context.Context, *UpdateRateLimitPolicyRequest) (*UpdateRateLimitPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRateLimitPolicy not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteRateLimitPolicy(context.Context, *DeleteRateLimitPolicyRequest) (*DeleteRateLimitPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRateLimitPolicy not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UploadLuaScript(context.Context, *UploadLuaScriptRequest) (*UploadLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetLuaScript(context.Context, *GetLuaScriptRequest) (*GetLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListLuaScripts(context.Context, *ListLuaScriptsRequest) (*ListLuaScriptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLuaScripts not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) LoadLuaScript(context.Context, *LoadLuaScriptRequest) (*LoadLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) RollbackLuaScript(context.Context, *RollbackLuaScriptRequest) (*RollbackLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UnloadLuaScript(context.Context, *UnloadLuaScriptRequest) (*UnloadLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnloadLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteLuaScript(context.Context, *DeleteLuaScriptRequest) (*DeleteLuaScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLuaScript not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateLuaAction(context.Context, *CreateLuaActionRequest) (*CreateLuaActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLuaAction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListLuaActions(context.Context, *ListLuaActionsRequest) (*ListLuaActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLuaActions not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteLuaAction(context.Context, *DeleteLuaActionRequest) (*DeleteLuaActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLuaAction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateRing(context.Context, *CreateRingRequest) (*CreateRingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRing not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetRing(context.Context, *GetRingRequest) (*GetRingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRing not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListRings(context.Context, *ListRingsRequest) (*ListRingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRings not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateRing(context.Context, *UpdateRingRequest) (*UpdateRingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRing not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteRing(context.Context, *DeleteRingRequest) (*DeleteRingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRing not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) AttachRingLogTarget(context.Context, *AttachRingLogTargetRequest) (*AttachRingLogTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachRingLogTarget not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListRingLogTargets(context.Context, *ListRingLogTargetsRequest) (*ListRingLogTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRingLogTargets not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DetachRingLogTarget(context.Context, *DetachRingLogTargetRequest) (*DetachRingLogTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachRingLogTarget not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) StreamRing(*StreamRingRequest, grpc.ServerStreamingServer[StreamRingResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRing not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateProgram(context.Context, *CreateProgramRequest) (*CreateProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProgram not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetProgram(context.Context, *GetProgramRequest) (*GetProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProgram not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListPrograms(context.Context, *ListProgramsRequest) (*ListProgramsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrograms not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateProgram(context.Context, *UpdateProgramRequest) (*UpdateProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProgram not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteProgram(context.Context, *DeleteProgramRequest) (*DeleteProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProgram not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateHTTPCheck(context.Context, *CreateHTTPCheckRequest) (*CreateHTTPCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHTTPCheck not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListHTTPChecks(context.Context, *ListHTTPChecksRequest) (*ListHTTPChecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHTTPChecks not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateHTTPCheck(context.Context, *UpdateHTTPCheckRequest) (*UpdateHTTPCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHTTPCheck not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteHTTPCheck(context.Context, *DeleteHTTPCheckRequest) (*DeleteHTTPCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHTTPCheck not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateServer(context.Context, *CreateServerRequest) (*CreateServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetServer(context.Context, *GetServerRequest) (*GetServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) StreamServers(*StreamServersRequest, grpc.ServerStreamingServer[StreamServersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamServers not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) UpdateServer(context.Context, *UpdateServerRequest) (*UpdateServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteServer(context.Context, *DeleteServerRequest) (*DeleteServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ApplyServer(context.Context, *ApplyServerRequest) (*ApplyServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CreateServers(context.Context, *CreateServersRequest) (*CreateServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServers not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DeleteServers(context.Context, *DeleteServersRequest) (*DeleteServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServers not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) SetMetadata(context.Context, *SetMetadataRequest) (*SetMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMetadata not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ExportState(context.Context, *ExportStateRequest) (*ExportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ImportState(context.Context, *ImportStateRequest) (*ImportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ApplyDesiredState(context.Context, *ApplyDesiredStateRequest) (*ApplyDesiredStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyDesiredState not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) RenderPreview(context.Context, *RenderPreviewRequest) (*RenderPreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderPreview not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) SetServerState(context.Context, *SetServerStateRequest) (*SetServerStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServerState not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) DrainServer(*DrainServerRequest, grpc.ServerStreamingServer[DrainServerResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DrainServer not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EnterMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterMaintenance not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*ExitMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitMaintenance not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenance not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetNetplanStatus(context.Context, *GetNetplanStatusRequest) (*GetNetplanStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetplanStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetNetplanTransaction(context.Context, *GetNetplanTransactionRequest) (*GetNetplanTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetplanTransaction not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CleanupOrphanedAddresses(context.Context, *CleanupOrphanedAddressesRequest) (*CleanupOrphanedAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupOrphanedAddresses not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetClusterStatus(context.Context, *GetClusterStatusRequest) (*GetClusterStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) SyncCluster(context.Context, *SyncClusterRequest) (*SyncClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncCluster not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetPeerState(context.Context, *GetPeerStateRequest) (*GetPeerStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerState not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetPeerSyncStatus(context.Context, *GetPeerSyncStatusRequest) (*GetPeerSyncStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerSyncStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetGitOpsStatus(context.Context, *GetGitOpsStatusRequest) (*GetGitOpsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGitOpsStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetDiscoveryStatus(context.Context, *GetDiscoveryStatusRequest) (*GetDiscoveryStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiscoveryStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) GetDriftStatus(context.Context, *GetDriftStatusRequest) (*GetDriftStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriftStatus not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) CheckDrift(context.Context, *CheckDriftRequest) (*CheckDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDrift not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[WatchChangesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedHAProxyManagerServiceServer) mustEmbedUnimplementedHAProxyManagerServiceServer() {}
+func (UnimplementedHAProxyManagerServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeHAProxyManagerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HAProxyManagerServiceServer will
+// result in compilation errors.
+type UnsafeHAProxyManagerServiceServer interface {
+	mustEmbedUnimplementedHAProxyManagerServiceServer()
+}
+
+func RegisterHAProxyManagerServiceServer(s grpc.ServiceRegistrar, srv HAProxyManagerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHAProxyManagerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HAProxyManagerService_ServiceDesc, srv)
+}
+
+func _HAProxyManagerService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_FreezeChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).FreezeChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_FreezeChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).FreezeChanges(ctx, req.(*FreezeChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UnfreezeChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UnfreezeChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UnfreezeChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UnfreezeChanges(ctx, req.(*UnfreezeChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateTransaction(ctx, req.(*CreateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DiffTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DiffTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DiffTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DiffTransaction(ctx, req.(*DiffTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CommitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CommitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CommitTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CommitTransaction(ctx, req.(*CommitTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CloseTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CloseTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CloseTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CloseTransaction(ctx, req.(*CloseTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateBackend(ctx, req.(*CreateBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetBackend(ctx, req.(*GetBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListBackends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListBackends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListBackends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListBackends(ctx, req.(*ListBackendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_StreamBackends_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBackendsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HAProxyManagerServiceServer).StreamBackends(m, &grpc.GenericServerStream[StreamBackendsRequest, StreamBackendsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamBackendsServer = grpc.ServerStreamingServer[StreamBackendsResponse]
+
+func _HAProxyManagerService_UpdateBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateBackend(ctx, req.(*UpdateBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteBackend(ctx, req.(*DeleteBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ApplyBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ApplyBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ApplyBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ApplyBackend(ctx, req.(*ApplyBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateFrontend(ctx, req.(*CreateFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetFrontend(ctx, req.(*GetFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListFrontends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFrontendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListFrontends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListFrontends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListFrontends(ctx, req.(*ListFrontendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UpdateFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateFrontend(ctx, req.(*UpdateFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteFrontend(ctx, req.(*DeleteFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ApplyFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ApplyFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ApplyFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ApplyFrontend(ctx, req.(*ApplyFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateHTTPSFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHTTPSFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateHTTPSFrontend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateHTTPSFrontend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateHTTPSFrontend(ctx, req.(*CreateHTTPSFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_SwapBackends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapBackendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).SwapBackends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_SwapBackends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).SwapBackends(ctx, req.(*SwapBackendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ShiftTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShiftTrafficRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ShiftTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ShiftTraffic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ShiftTraffic(ctx, req.(*ShiftTrafficRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateBind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateBind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateBind(ctx, req.(*CreateBindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetBind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetBind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetBind(ctx, req.(*GetBindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListBinds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBindsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListBinds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListBinds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListBinds(ctx, req.(*ListBindsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UpdateBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateBind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateBind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateBind(ctx, req.(*UpdateBindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteBind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteBind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteBind(ctx, req.(*DeleteBindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ApplyBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyBindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ApplyBind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ApplyBind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ApplyBind(ctx, req.(*ApplyBindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateRoute(ctx, req.(*CreateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetRoute(ctx, req.(*GetRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListRoutes(ctx, req.(*ListRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UpdateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateRoute(ctx, req.(*UpdateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteRoute(ctx, req.(*DeleteRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateRateLimitPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRateLimitPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateRateLimitPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateRateLimitPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateRateLimitPolicy(ctx, req.(*CreateRateLimitPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetRateLimitPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateLimitPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetRateLimitPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetRateLimitPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetRateLimitPolicy(ctx, req.(*GetRateLimitPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListRateLimitPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRateLimitPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListRateLimitPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListRateLimitPolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListRateLimitPolicies(ctx, req.(*ListRateLimitPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UpdateRateLimitPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRateLimitPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateRateLimitPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateRateLimitPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateRateLimitPolicy(ctx, req.(*UpdateRateLimitPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteRateLimitPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRateLimitPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteRateLimitPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteRateLimitPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteRateLimitPolicy(ctx, req.(*DeleteRateLimitPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UploadLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UploadLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UploadLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UploadLuaScript(ctx, req.(*UploadLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetLuaScript(ctx, req.(*GetLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListLuaScripts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLuaScriptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListLuaScripts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListLuaScripts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListLuaScripts(ctx, req.(*ListLuaScriptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_LoadLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).LoadLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_LoadLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).LoadLuaScript(ctx, req.(*LoadLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_RollbackLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).RollbackLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_RollbackLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).RollbackLuaScript(ctx, req.(*RollbackLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UnloadLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnloadLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UnloadLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UnloadLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UnloadLuaScript(ctx, req.(*UnloadLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteLuaScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLuaScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteLuaScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteLuaScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteLuaScript(ctx, req.(*DeleteLuaScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateLuaAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLuaActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateLuaAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateLuaAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateLuaAction(ctx, req.(*CreateLuaActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListLuaActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLuaActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListLuaActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListLuaActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListLuaActions(ctx, req.(*ListLuaActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteLuaAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLuaActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteLuaAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteLuaAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteLuaAction(ctx, req.(*DeleteLuaActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_CreateRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateRing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateRing(ctx, req.(*CreateRingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetRing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetRing(ctx, req.(*GetRingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListRings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListRings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListRings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListRings(ctx, req.(*ListRingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_UpdateRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateRing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateRing(ctx, req.(*UpdateRingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DeleteRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteRing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteRing(ctx, req.(*DeleteRingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_AttachRingLogTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachRingLogTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).AttachRingLogTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_AttachRingLogTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).AttachRingLogTarget(ctx, req.(*AttachRingLogTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_ListRingLogTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRingLogTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListRingLogTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListRingLogTargets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListRingLogTargets(ctx, req.(*ListRingLogTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_DetachRingLogTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachRingLogTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DetachRingLogTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DetachRingLogTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DetachRingLogTarget(ctx, req.(*DetachRingLogTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_StreamRing_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HAProxyManagerServiceServer).StreamRing(m, &grpc.GenericServerStream[StreamRingRequest, StreamRingResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamRingServer = grpc.ServerStreamingServer[StreamRingResponse]
+
+func _HAProxyManagerService_CreateProgram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProgramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateProgram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateProgram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateProgram(ctx, req.(*CreateProgramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HAProxyManagerService_GetProgram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProgramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetProgram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetProgram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetProgram(ctx, req.(*GetProgramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) DeleteFrontend(context.Context, *DeleteFrontendRequest) (*DeleteFrontendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFrontend not implemented")
+
+func _HAProxyManagerService_ListPrograms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProgramsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListPrograms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListPrograms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListPrograms(ctx, req.(*ListProgramsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) CreateBind(context.Context, *CreateBindRequest) (*CreateBindResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateBind not implemented")
+
+func _HAProxyManagerService_UpdateProgram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProgramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateProgram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateProgram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateProgram(ctx, req.(*UpdateProgramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) GetBind(context.Context, *GetBindRequest) (*GetBindResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBind not implemented")
+
+func _HAProxyManagerService_DeleteProgram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProgramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteProgram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteProgram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteProgram(ctx, req.(*DeleteProgramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) ListBinds(context.Context, *ListBindsRequest) (*ListBindsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListBinds not implemented")
+
+func _HAProxyManagerService_CreateHTTPCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHTTPCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateHTTPCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateHTTPCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateHTTPCheck(ctx, req.(*CreateHTTPCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) UpdateBind(context.Context, *UpdateBindRequest) (*UpdateBindResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateBind not implemented")
+
+func _HAProxyManagerService_ListHTTPChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHTTPChecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListHTTPChecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListHTTPChecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListHTTPChecks(ctx, req.(*ListHTTPChecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) DeleteBind(context.Context, *DeleteBindRequest) (*DeleteBindResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteBind not implemented")
+
+func _HAProxyManagerService_UpdateHTTPCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHTTPCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateHTTPCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateHTTPCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateHTTPCheck(ctx, req.(*UpdateHTTPCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) CreateServer(context.Context, *CreateServerRequest) (*CreateServerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateServer not implemented")
+
+func _HAProxyManagerService_DeleteHTTPCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHTTPCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteHTTPCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteHTTPCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteHTTPCheck(ctx, req.(*DeleteHTTPCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) GetServer(context.Context, *GetServerRequest) (*GetServerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+
+func _HAProxyManagerService_CreateServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).CreateServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_CreateServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).CreateServer(ctx, req.(*CreateServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+
+func _HAProxyManagerService_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).GetServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_GetServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).GetServer(ctx, req.(*GetServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) UpdateServer(context.Context, *UpdateServerRequest) (*UpdateServerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateServer not implemented")
+
+func _HAProxyManagerService_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).ListServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_ListServers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).ListServers(ctx, req.(*ListServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHAProxyManagerServiceServer) DeleteServer(context.Context, *DeleteServerRequest) (*DeleteServerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteServer not implemented")
+
+func _HAProxyManagerService_StreamServers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HAProxyManagerServiceServer).StreamServers(m, &grpc.GenericServerStream[StreamServersRequest, StreamServersResponse]{ServerStream: stream})
 }
-func (UnimplementedHAProxyManagerServiceServer) mustEmbedUnimplementedHAProxyManagerServiceServer() {}
-func (UnimplementedHAProxyManagerServiceServer) testEmbeddedByValue()                               {}
 
-// UnsafeHAProxyManagerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to HAProxyManagerServiceServer will
-// result in compilation errors.
-type UnsafeHAProxyManagerServiceServer interface {
-	mustEmbedUnimplementedHAProxyManagerServiceServer()
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_StreamServersServer = grpc.ServerStreamingServer[StreamServersResponse]
+
+func _HAProxyManagerService_UpdateServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).UpdateServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_UpdateServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).UpdateServer(ctx, req.(*UpdateServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterHAProxyManagerServiceServer(s grpc.ServiceRegistrar, srv HAProxyManagerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedHAProxyManagerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _HAProxyManagerService_DeleteServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&HAProxyManagerService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(HAProxyManagerServiceServer).DeleteServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HAProxyManagerService_DeleteServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HAProxyManagerServiceServer).DeleteServer(ctx, req.(*DeleteServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVersionRequest)
+func _HAProxyManagerService_ApplyServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyServerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetVersion(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ApplyServer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetVersion_FullMethodName,
+		FullMethod: HAProxyManagerService_ApplyServer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+		return srv.(HAProxyManagerServiceServer).ApplyServer(ctx, req.(*ApplyServerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTransactionRequest)
+func _HAProxyManagerService_CreateServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CreateTransaction(ctx, in)
+		return srv.(HAProxyManagerServiceServer).CreateServers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CreateTransaction_FullMethodName,
+		FullMethod: HAProxyManagerService_CreateServers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CreateTransaction(ctx, req.(*CreateTransactionRequest))
+		return srv.(HAProxyManagerServiceServer).CreateServers(ctx, req.(*CreateServersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionRequest)
+func _HAProxyManagerService_DeleteServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetTransaction(ctx, in)
+		return srv.(HAProxyManagerServiceServer).DeleteServers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetTransaction_FullMethodName,
+		FullMethod: HAProxyManagerService_DeleteServers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+		return srv.(HAProxyManagerServiceServer).DeleteServers(ctx, req.(*DeleteServersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CommitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CommitTransactionRequest)
+func _HAProxyManagerService_SetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMetadataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CommitTransaction(ctx, in)
+		return srv.(HAProxyManagerServiceServer).SetMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CommitTransaction_FullMethodName,
+		FullMethod: HAProxyManagerService_SetMetadata_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CommitTransaction(ctx, req.(*CommitTransactionRequest))
+		return srv.(HAProxyManagerServiceServer).SetMetadata(ctx, req.(*SetMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CloseTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CloseTransactionRequest)
+func _HAProxyManagerService_ExportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CloseTransaction(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ExportState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CloseTransaction_FullMethodName,
+		FullMethod: HAProxyManagerService_ExportState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CloseTransaction(ctx, req.(*CloseTransactionRequest))
+		return srv.(HAProxyManagerServiceServer).ExportState(ctx, req.(*ExportStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CreateBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBackendRequest)
+func _HAProxyManagerService_ImportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CreateBackend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ImportState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CreateBackend_FullMethodName,
+		FullMethod: HAProxyManagerService_ImportState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CreateBackend(ctx, req.(*CreateBackendRequest))
+		return srv.(HAProxyManagerServiceServer).ImportState(ctx, req.(*ImportStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBackendRequest)
+func _HAProxyManagerService_ApplyDesiredState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyDesiredStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetBackend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ApplyDesiredState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetBackend_FullMethodName,
+		FullMethod: HAProxyManagerService_ApplyDesiredState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetBackend(ctx, req.(*GetBackendRequest))
+		return srv.(HAProxyManagerServiceServer).ApplyDesiredState(ctx, req.(*ApplyDesiredStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_ListBackends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBackendsRequest)
+func _HAProxyManagerService_RenderPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderPreviewRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).ListBackends(ctx, in)
+		return srv.(HAProxyManagerServiceServer).RenderPreview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_ListBackends_FullMethodName,
+		FullMethod: HAProxyManagerService_RenderPreview_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).ListBackends(ctx, req.(*ListBackendsRequest))
+		return srv.(HAProxyManagerServiceServer).RenderPreview(ctx, req.(*RenderPreviewRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_UpdateBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBackendRequest)
+func _HAProxyManagerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).UpdateBackend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_UpdateBackend_FullMethodName,
+		FullMethod: HAProxyManagerService_GetStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).UpdateBackend(ctx, req.(*UpdateBackendRequest))
+		return srv.(HAProxyManagerServiceServer).GetStats(ctx, req.(*GetStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_DeleteBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBackendRequest)
+func _HAProxyManagerService_SetServerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetServerStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).DeleteBackend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).SetServerState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_DeleteBackend_FullMethodName,
+		FullMethod: HAProxyManagerService_SetServerState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).DeleteBackend(ctx, req.(*DeleteBackendRequest))
+		return srv.(HAProxyManagerServiceServer).SetServerState(ctx, req.(*SetServerStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CreateFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateFrontendRequest)
+func _HAProxyManagerService_DrainServer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DrainServerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HAProxyManagerServiceServer).DrainServer(m, &grpc.GenericServerStream[DrainServerRequest, DrainServerResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_DrainServerServer = grpc.ServerStreamingServer[DrainServerResponse]
+
+func _HAProxyManagerService_EnterMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterMaintenanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CreateFrontend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).EnterMaintenance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CreateFrontend_FullMethodName,
+		FullMethod: HAProxyManagerService_EnterMaintenance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CreateFrontend(ctx, req.(*CreateFrontendRequest))
+		return srv.(HAProxyManagerServiceServer).EnterMaintenance(ctx, req.(*EnterMaintenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFrontendRequest)
+func _HAProxyManagerService_ExitMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitMaintenanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetFrontend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ExitMaintenance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetFrontend_FullMethodName,
+		FullMethod: HAProxyManagerService_ExitMaintenance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetFrontend(ctx, req.(*GetFrontendRequest))
+		return srv.(HAProxyManagerServiceServer).ExitMaintenance(ctx, req.(*ExitMaintenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_ListFrontends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFrontendsRequest)
+func _HAProxyManagerService_ListMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).ListFrontends(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ListMaintenance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_ListFrontends_FullMethodName,
+		FullMethod: HAProxyManagerService_ListMaintenance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).ListFrontends(ctx, req.(*ListFrontendsRequest))
+		return srv.(HAProxyManagerServiceServer).ListMaintenance(ctx, req.(*ListMaintenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_UpdateFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFrontendRequest)
+func _HAProxyManagerService_GetNetplanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetplanStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).UpdateFrontend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetNetplanStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_UpdateFrontend_FullMethodName,
+		FullMethod: HAProxyManagerService_GetNetplanStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).UpdateFrontend(ctx, req.(*UpdateFrontendRequest))
+		return srv.(HAProxyManagerServiceServer).GetNetplanStatus(ctx, req.(*GetNetplanStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_DeleteFrontend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFrontendRequest)
+func _HAProxyManagerService_GetNetplanTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetplanTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).DeleteFrontend(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetNetplanTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_DeleteFrontend_FullMethodName,
+		FullMethod: HAProxyManagerService_GetNetplanTransaction_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).DeleteFrontend(ctx, req.(*DeleteFrontendRequest))
+		return srv.(HAProxyManagerServiceServer).GetNetplanTransaction(ctx, req.(*GetNetplanTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CreateBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBindRequest)
+func _HAProxyManagerService_CleanupOrphanedAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupOrphanedAddressesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CreateBind(ctx, in)
+		return srv.(HAProxyManagerServiceServer).CleanupOrphanedAddresses(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CreateBind_FullMethodName,
+		FullMethod: HAProxyManagerService_CleanupOrphanedAddresses_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CreateBind(ctx, req.(*CreateBindRequest))
+		return srv.(HAProxyManagerServiceServer).CleanupOrphanedAddresses(ctx, req.(*CleanupOrphanedAddressesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBindRequest)
+func _HAProxyManagerService_GetClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetBind(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetClusterStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetBind_FullMethodName,
+		FullMethod: HAProxyManagerService_GetClusterStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetBind(ctx, req.(*GetBindRequest))
+		return srv.(HAProxyManagerServiceServer).GetClusterStatus(ctx, req.(*GetClusterStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_ListBinds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBindsRequest)
+func _HAProxyManagerService_SyncCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncClusterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).ListBinds(ctx, in)
+		return srv.(HAProxyManagerServiceServer).SyncCluster(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_ListBinds_FullMethodName,
+		FullMethod: HAProxyManagerService_SyncCluster_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).ListBinds(ctx, req.(*ListBindsRequest))
+		return srv.(HAProxyManagerServiceServer).SyncCluster(ctx, req.(*SyncClusterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_UpdateBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBindRequest)
+func _HAProxyManagerService_GetPeerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).UpdateBind(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetPeerState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_UpdateBind_FullMethodName,
+		FullMethod: HAProxyManagerService_GetPeerState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).UpdateBind(ctx, req.(*UpdateBindRequest))
+		return srv.(HAProxyManagerServiceServer).GetPeerState(ctx, req.(*GetPeerStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_DeleteBind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBindRequest)
+func _HAProxyManagerService_GetPeerSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerSyncStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).DeleteBind(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetPeerSyncStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_DeleteBind_FullMethodName,
+		FullMethod: HAProxyManagerService_GetPeerSyncStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).DeleteBind(ctx, req.(*DeleteBindRequest))
+		return srv.(HAProxyManagerServiceServer).GetPeerSyncStatus(ctx, req.(*GetPeerSyncStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_CreateServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateServerRequest)
+func _HAProxyManagerService_GetGitOpsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGitOpsStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).CreateServer(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetGitOpsStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_CreateServer_FullMethodName,
+		FullMethod: HAProxyManagerService_GetGitOpsStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).CreateServer(ctx, req.(*CreateServerRequest))
+		return srv.(HAProxyManagerServiceServer).GetGitOpsStatus(ctx, req.(*GetGitOpsStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServerRequest)
+func _HAProxyManagerService_GetDiscoveryStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiscoveryStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).GetServer(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetDiscoveryStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_GetServer_FullMethodName,
+		FullMethod: HAProxyManagerService_GetDiscoveryStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).GetServer(ctx, req.(*GetServerRequest))
+		return srv.(HAProxyManagerServiceServer).GetDiscoveryStatus(ctx, req.(*GetDiscoveryStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListServersRequest)
+func _HAProxyManagerService_GetDriftStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriftStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).ListServers(ctx, in)
+		return srv.(HAProxyManagerServiceServer).GetDriftStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_ListServers_FullMethodName,
+		FullMethod: HAProxyManagerService_GetDriftStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).ListServers(ctx, req.(*ListServersRequest))
+		return srv.(HAProxyManagerServiceServer).GetDriftStatus(ctx, req.(*GetDriftStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_UpdateServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServerRequest)
+func _HAProxyManagerService_CheckDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDriftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).UpdateServer(ctx, in)
+		return srv.(HAProxyManagerServiceServer).CheckDrift(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_UpdateServer_FullMethodName,
+		FullMethod: HAProxyManagerService_CheckDrift_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).UpdateServer(ctx, req.(*UpdateServerRequest))
+		return srv.(HAProxyManagerServiceServer).CheckDrift(ctx, req.(*CheckDriftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HAProxyManagerService_DeleteServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServerRequest)
+func _HAProxyManagerService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HAProxyManagerServiceServer).DeleteServer(ctx, in)
+		return srv.(HAProxyManagerServiceServer).ListEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HAProxyManagerService_DeleteServer_FullMethodName,
+		FullMethod: HAProxyManagerService_ListEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HAProxyManagerServiceServer).DeleteServer(ctx, req.(*DeleteServerRequest))
+		return srv.(HAProxyManagerServiceServer).ListEvents(ctx, req.(*ListEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HAProxyManagerService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HAProxyManagerServiceServer).WatchChanges(m, &grpc.GenericServerStream[WatchChangesRequest, WatchChangesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HAProxyManagerService_WatchChangesServer = grpc.ServerStreamingServer[WatchChangesResponse]
+
 // HAProxyManagerService_ServiceDesc is the grpc.ServiceDesc for HAProxyManagerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -943,6 +3723,26 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "haproxy.v1.HAProxyManagerService",
 	HandlerType: (*HAProxyManagerServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _HAProxyManagerService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _HAProxyManagerService_GetStatus_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _HAProxyManagerService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "FreezeChanges",
+			Handler:    _HAProxyManagerService_FreezeChanges_Handler,
+		},
+		{
+			MethodName: "UnfreezeChanges",
+			Handler:    _HAProxyManagerService_UnfreezeChanges_Handler,
+		},
 		{
 			MethodName: "GetVersion",
 			Handler:    _HAProxyManagerService_GetVersion_Handler,
@@ -955,6 +3755,14 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransaction",
 			Handler:    _HAProxyManagerService_GetTransaction_Handler,
 		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _HAProxyManagerService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "DiffTransaction",
+			Handler:    _HAProxyManagerService_DiffTransaction_Handler,
+		},
 		{
 			MethodName: "CommitTransaction",
 			Handler:    _HAProxyManagerService_CommitTransaction_Handler,
@@ -983,6 +3791,10 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBackend",
 			Handler:    _HAProxyManagerService_DeleteBackend_Handler,
 		},
+		{
+			MethodName: "ApplyBackend",
+			Handler:    _HAProxyManagerService_ApplyBackend_Handler,
+		},
 		{
 			MethodName: "CreateFrontend",
 			Handler:    _HAProxyManagerService_CreateFrontend_Handler,
@@ -1003,6 +3815,22 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFrontend",
 			Handler:    _HAProxyManagerService_DeleteFrontend_Handler,
 		},
+		{
+			MethodName: "ApplyFrontend",
+			Handler:    _HAProxyManagerService_ApplyFrontend_Handler,
+		},
+		{
+			MethodName: "CreateHTTPSFrontend",
+			Handler:    _HAProxyManagerService_CreateHTTPSFrontend_Handler,
+		},
+		{
+			MethodName: "SwapBackends",
+			Handler:    _HAProxyManagerService_SwapBackends_Handler,
+		},
+		{
+			MethodName: "ShiftTraffic",
+			Handler:    _HAProxyManagerService_ShiftTraffic_Handler,
+		},
 		{
 			MethodName: "CreateBind",
 			Handler:    _HAProxyManagerService_CreateBind_Handler,
@@ -1023,6 +3851,158 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBind",
 			Handler:    _HAProxyManagerService_DeleteBind_Handler,
 		},
+		{
+			MethodName: "ApplyBind",
+			Handler:    _HAProxyManagerService_ApplyBind_Handler,
+		},
+		{
+			MethodName: "CreateRoute",
+			Handler:    _HAProxyManagerService_CreateRoute_Handler,
+		},
+		{
+			MethodName: "GetRoute",
+			Handler:    _HAProxyManagerService_GetRoute_Handler,
+		},
+		{
+			MethodName: "ListRoutes",
+			Handler:    _HAProxyManagerService_ListRoutes_Handler,
+		},
+		{
+			MethodName: "UpdateRoute",
+			Handler:    _HAProxyManagerService_UpdateRoute_Handler,
+		},
+		{
+			MethodName: "DeleteRoute",
+			Handler:    _HAProxyManagerService_DeleteRoute_Handler,
+		},
+		{
+			MethodName: "CreateRateLimitPolicy",
+			Handler:    _HAProxyManagerService_CreateRateLimitPolicy_Handler,
+		},
+		{
+			MethodName: "GetRateLimitPolicy",
+			Handler:    _HAProxyManagerService_GetRateLimitPolicy_Handler,
+		},
+		{
+			MethodName: "ListRateLimitPolicies",
+			Handler:    _HAProxyManagerService_ListRateLimitPolicies_Handler,
+		},
+		{
+			MethodName: "UpdateRateLimitPolicy",
+			Handler:    _HAProxyManagerService_UpdateRateLimitPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteRateLimitPolicy",
+			Handler:    _HAProxyManagerService_DeleteRateLimitPolicy_Handler,
+		},
+		{
+			MethodName: "UploadLuaScript",
+			Handler:    _HAProxyManagerService_UploadLuaScript_Handler,
+		},
+		{
+			MethodName: "GetLuaScript",
+			Handler:    _HAProxyManagerService_GetLuaScript_Handler,
+		},
+		{
+			MethodName: "ListLuaScripts",
+			Handler:    _HAProxyManagerService_ListLuaScripts_Handler,
+		},
+		{
+			MethodName: "LoadLuaScript",
+			Handler:    _HAProxyManagerService_LoadLuaScript_Handler,
+		},
+		{
+			MethodName: "RollbackLuaScript",
+			Handler:    _HAProxyManagerService_RollbackLuaScript_Handler,
+		},
+		{
+			MethodName: "UnloadLuaScript",
+			Handler:    _HAProxyManagerService_UnloadLuaScript_Handler,
+		},
+		{
+			MethodName: "DeleteLuaScript",
+			Handler:    _HAProxyManagerService_DeleteLuaScript_Handler,
+		},
+		{
+			MethodName: "CreateLuaAction",
+			Handler:    _HAProxyManagerService_CreateLuaAction_Handler,
+		},
+		{
+			MethodName: "ListLuaActions",
+			Handler:    _HAProxyManagerService_ListLuaActions_Handler,
+		},
+		{
+			MethodName: "DeleteLuaAction",
+			Handler:    _HAProxyManagerService_DeleteLuaAction_Handler,
+		},
+		{
+			MethodName: "CreateRing",
+			Handler:    _HAProxyManagerService_CreateRing_Handler,
+		},
+		{
+			MethodName: "GetRing",
+			Handler:    _HAProxyManagerService_GetRing_Handler,
+		},
+		{
+			MethodName: "ListRings",
+			Handler:    _HAProxyManagerService_ListRings_Handler,
+		},
+		{
+			MethodName: "UpdateRing",
+			Handler:    _HAProxyManagerService_UpdateRing_Handler,
+		},
+		{
+			MethodName: "DeleteRing",
+			Handler:    _HAProxyManagerService_DeleteRing_Handler,
+		},
+		{
+			MethodName: "AttachRingLogTarget",
+			Handler:    _HAProxyManagerService_AttachRingLogTarget_Handler,
+		},
+		{
+			MethodName: "ListRingLogTargets",
+			Handler:    _HAProxyManagerService_ListRingLogTargets_Handler,
+		},
+		{
+			MethodName: "DetachRingLogTarget",
+			Handler:    _HAProxyManagerService_DetachRingLogTarget_Handler,
+		},
+		{
+			MethodName: "CreateProgram",
+			Handler:    _HAProxyManagerService_CreateProgram_Handler,
+		},
+		{
+			MethodName: "GetProgram",
+			Handler:    _HAProxyManagerService_GetProgram_Handler,
+		},
+		{
+			MethodName: "ListPrograms",
+			Handler:    _HAProxyManagerService_ListPrograms_Handler,
+		},
+		{
+			MethodName: "UpdateProgram",
+			Handler:    _HAProxyManagerService_UpdateProgram_Handler,
+		},
+		{
+			MethodName: "DeleteProgram",
+			Handler:    _HAProxyManagerService_DeleteProgram_Handler,
+		},
+		{
+			MethodName: "CreateHTTPCheck",
+			Handler:    _HAProxyManagerService_CreateHTTPCheck_Handler,
+		},
+		{
+			MethodName: "ListHTTPChecks",
+			Handler:    _HAProxyManagerService_ListHTTPChecks_Handler,
+		},
+		{
+			MethodName: "UpdateHTTPCheck",
+			Handler:    _HAProxyManagerService_UpdateHTTPCheck_Handler,
+		},
+		{
+			MethodName: "DeleteHTTPCheck",
+			Handler:    _HAProxyManagerService_DeleteHTTPCheck_Handler,
+		},
 		{
 			MethodName: "CreateServer",
 			Handler:    _HAProxyManagerService_CreateServer_Handler,
@@ -1043,7 +4023,133 @@ var HAProxyManagerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteServer",
 			Handler:    _HAProxyManagerService_DeleteServer_Handler,
 		},
+		{
+			MethodName: "ApplyServer",
+			Handler:    _HAProxyManagerService_ApplyServer_Handler,
+		},
+		{
+			MethodName: "CreateServers",
+			Handler:    _HAProxyManagerService_CreateServers_Handler,
+		},
+		{
+			MethodName: "DeleteServers",
+			Handler:    _HAProxyManagerService_DeleteServers_Handler,
+		},
+		{
+			MethodName: "SetMetadata",
+			Handler:    _HAProxyManagerService_SetMetadata_Handler,
+		},
+		{
+			MethodName: "ExportState",
+			Handler:    _HAProxyManagerService_ExportState_Handler,
+		},
+		{
+			MethodName: "ImportState",
+			Handler:    _HAProxyManagerService_ImportState_Handler,
+		},
+		{
+			MethodName: "ApplyDesiredState",
+			Handler:    _HAProxyManagerService_ApplyDesiredState_Handler,
+		},
+		{
+			MethodName: "RenderPreview",
+			Handler:    _HAProxyManagerService_RenderPreview_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _HAProxyManagerService_GetStats_Handler,
+		},
+		{
+			MethodName: "SetServerState",
+			Handler:    _HAProxyManagerService_SetServerState_Handler,
+		},
+		{
+			MethodName: "EnterMaintenance",
+			Handler:    _HAProxyManagerService_EnterMaintenance_Handler,
+		},
+		{
+			MethodName: "ExitMaintenance",
+			Handler:    _HAProxyManagerService_ExitMaintenance_Handler,
+		},
+		{
+			MethodName: "ListMaintenance",
+			Handler:    _HAProxyManagerService_ListMaintenance_Handler,
+		},
+		{
+			MethodName: "GetNetplanStatus",
+			Handler:    _HAProxyManagerService_GetNetplanStatus_Handler,
+		},
+		{
+			MethodName: "GetNetplanTransaction",
+			Handler:    _HAProxyManagerService_GetNetplanTransaction_Handler,
+		},
+		{
+			MethodName: "CleanupOrphanedAddresses",
+			Handler:    _HAProxyManagerService_CleanupOrphanedAddresses_Handler,
+		},
+		{
+			MethodName: "GetClusterStatus",
+			Handler:    _HAProxyManagerService_GetClusterStatus_Handler,
+		},
+		{
+			MethodName: "SyncCluster",
+			Handler:    _HAProxyManagerService_SyncCluster_Handler,
+		},
+		{
+			MethodName: "GetPeerState",
+			Handler:    _HAProxyManagerService_GetPeerState_Handler,
+		},
+		{
+			MethodName: "GetPeerSyncStatus",
+			Handler:    _HAProxyManagerService_GetPeerSyncStatus_Handler,
+		},
+		{
+			MethodName: "GetGitOpsStatus",
+			Handler:    _HAProxyManagerService_GetGitOpsStatus_Handler,
+		},
+		{
+			MethodName: "GetDiscoveryStatus",
+			Handler:    _HAProxyManagerService_GetDiscoveryStatus_Handler,
+		},
+		{
+			MethodName: "GetDriftStatus",
+			Handler:    _HAProxyManagerService_GetDriftStatus_Handler,
+		},
+		{
+			MethodName: "CheckDrift",
+			Handler:    _HAProxyManagerService_CheckDrift_Handler,
+		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _HAProxyManagerService_ListEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBackends",
+			Handler:       _HAProxyManagerService_StreamBackends_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRing",
+			Handler:       _HAProxyManagerService_StreamRing_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamServers",
+			Handler:       _HAProxyManagerService_StreamServers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DrainServer",
+			Handler:       _HAProxyManagerService_DrainServer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _HAProxyManagerService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "haproxy.proto",
 }
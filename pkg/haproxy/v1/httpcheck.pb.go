@@ -0,0 +1,852 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: httpcheck.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HTTPCheckType is the kind of an http-check rule
+type HTTPCheckType int32
+
+const (
+	HTTPCheckType_HTTP_CHECK_TYPE_UNSPECIFIED HTTPCheckType = 0
+	HTTPCheckType_HTTP_CHECK_TYPE_SEND        HTTPCheckType = 1 // Sends the health check request
+	HTTPCheckType_HTTP_CHECK_TYPE_EXPECT      HTTPCheckType = 2 // Tests the response; the check fails unless it matches
+)
+
+// Enum value maps for HTTPCheckType.
+var (
+	HTTPCheckType_name = map[int32]string{
+		0: "HTTP_CHECK_TYPE_UNSPECIFIED",
+		1: "HTTP_CHECK_TYPE_SEND",
+		2: "HTTP_CHECK_TYPE_EXPECT",
+	}
+	HTTPCheckType_value = map[string]int32{
+		"HTTP_CHECK_TYPE_UNSPECIFIED": 0,
+		"HTTP_CHECK_TYPE_SEND":        1,
+		"HTTP_CHECK_TYPE_EXPECT":      2,
+	}
+)
+
+func (x HTTPCheckType) Enum() *HTTPCheckType {
+	p := new(HTTPCheckType)
+	*p = x
+	return p
+}
+
+func (x HTTPCheckType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HTTPCheckType) Descriptor() protoreflect.EnumDescriptor {
+	return file_httpcheck_proto_enumTypes[0].Descriptor()
+}
+
+func (HTTPCheckType) Type() protoreflect.EnumType {
+	return &file_httpcheck_proto_enumTypes[0]
+}
+
+func (x HTTPCheckType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HTTPCheckType.Descriptor instead.
+func (HTTPCheckType) EnumDescriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{0}
+}
+
+// HTTPCheckMatch is what an expect rule tests
+type HTTPCheckMatch int32
+
+const (
+	HTTPCheckMatch_HTTP_CHECK_MATCH_UNSPECIFIED  HTTPCheckMatch = 0 // Same as HTTP_CHECK_MATCH_STATUS
+	HTTPCheckMatch_HTTP_CHECK_MATCH_STATUS       HTTPCheckMatch = 1 // Status code in a list or range, e.g. "200" or "200-399"
+	HTTPCheckMatch_HTTP_CHECK_MATCH_STATUS_REGEX HTTPCheckMatch = 2 // Status code matching a regular expression
+	HTTPCheckMatch_HTTP_CHECK_MATCH_STRING       HTTPCheckMatch = 3 // Body containing a string
+	HTTPCheckMatch_HTTP_CHECK_MATCH_STRING_REGEX HTTPCheckMatch = 4 // Body matching a regular expression
+)
+
+// Enum value maps for HTTPCheckMatch.
+var (
+	HTTPCheckMatch_name = map[int32]string{
+		0: "HTTP_CHECK_MATCH_UNSPECIFIED",
+		1: "HTTP_CHECK_MATCH_STATUS",
+		2: "HTTP_CHECK_MATCH_STATUS_REGEX",
+		3: "HTTP_CHECK_MATCH_STRING",
+		4: "HTTP_CHECK_MATCH_STRING_REGEX",
+	}
+	HTTPCheckMatch_value = map[string]int32{
+		"HTTP_CHECK_MATCH_UNSPECIFIED":  0,
+		"HTTP_CHECK_MATCH_STATUS":       1,
+		"HTTP_CHECK_MATCH_STATUS_REGEX": 2,
+		"HTTP_CHECK_MATCH_STRING":       3,
+		"HTTP_CHECK_MATCH_STRING_REGEX": 4,
+	}
+)
+
+func (x HTTPCheckMatch) Enum() *HTTPCheckMatch {
+	p := new(HTTPCheckMatch)
+	*p = x
+	return p
+}
+
+func (x HTTPCheckMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HTTPCheckMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_httpcheck_proto_enumTypes[1].Descriptor()
+}
+
+func (HTTPCheckMatch) Type() protoreflect.EnumType {
+	return &file_httpcheck_proto_enumTypes[1]
+}
+
+func (x HTTPCheckMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HTTPCheckMatch.Descriptor instead.
+func (HTTPCheckMatch) EnumDescriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{1}
+}
+
+// HTTPCheckHeader is a header of the health check request
+type HTTPCheckHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // May use log format variables, e.g. "%[srv_name]"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPCheckHeader) Reset() {
+	*x = HTTPCheckHeader{}
+	mi := &file_httpcheck_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPCheckHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPCheckHeader) ProtoMessage() {}
+
+func (x *HTTPCheckHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPCheckHeader.ProtoReflect.Descriptor instead.
+func (*HTTPCheckHeader) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HTTPCheckHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HTTPCheckHeader) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// HTTPCheck is an http-check rule of a backend. Health checks run the rules of a backend in order: send rules
+// write the request and expect rules test the response.
+type HTTPCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  HTTPCheckType          `protobuf:"varint,1,opt,name=type,proto3,enum=haproxy.v1.HTTPCheckType" json:"type,omitempty"` // Required
+	// Send rules; unset fields keep the defaults of HAProxy (OPTIONS / HTTP/1.0)
+	Method  string             `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`   // e.g. "GET" or "HEAD"
+	Uri     string             `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`         // e.g. "/health"
+	Version string             `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"` // e.g. "HTTP/1.1"
+	Headers []*HTTPCheckHeader `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body    string             `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	// Expect rules
+	Match         HTTPCheckMatch `protobuf:"varint,7,opt,name=match,proto3,enum=haproxy.v1.HTTPCheckMatch" json:"match,omitempty"`
+	Pattern       string         `protobuf:"bytes,8,opt,name=pattern,proto3" json:"pattern,omitempty"` // Required for expect rules
+	Negate        bool           `protobuf:"varint,9,opt,name=negate,proto3" json:"negate,omitempty"`  // The check fails if the response matches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPCheck) Reset() {
+	*x = HTTPCheck{}
+	mi := &file_httpcheck_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPCheck) ProtoMessage() {}
+
+func (x *HTTPCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPCheck.ProtoReflect.Descriptor instead.
+func (*HTTPCheck) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HTTPCheck) GetType() HTTPCheckType {
+	if x != nil {
+		return x.Type
+	}
+	return HTTPCheckType_HTTP_CHECK_TYPE_UNSPECIFIED
+}
+
+func (x *HTTPCheck) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HTTPCheck) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *HTTPCheck) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HTTPCheck) GetHeaders() []*HTTPCheckHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTPCheck) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *HTTPCheck) GetMatch() HTTPCheckMatch {
+	if x != nil {
+		return x.Match
+	}
+	return HTTPCheckMatch_HTTP_CHECK_MATCH_UNSPECIFIED
+}
+
+func (x *HTTPCheck) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *HTTPCheck) GetNegate() bool {
+	if x != nil {
+		return x.Negate
+	}
+	return false
+}
+
+type CreateHTTPCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Check         *HTTPCheck             `protobuf:"bytes,3,opt,name=check,proto3" json:"check,omitempty"`
+	Index         *int32                 `protobuf:"varint,4,opt,name=index,proto3,oneof" json:"index,omitempty"` // Position to insert the rule at, counting from 0; appended if unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHTTPCheckRequest) Reset() {
+	*x = CreateHTTPCheckRequest{}
+	mi := &file_httpcheck_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHTTPCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHTTPCheckRequest) ProtoMessage() {}
+
+func (x *CreateHTTPCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHTTPCheckRequest.ProtoReflect.Descriptor instead.
+func (*CreateHTTPCheckRequest) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateHTTPCheckRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateHTTPCheckRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *CreateHTTPCheckRequest) GetCheck() *HTTPCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *CreateHTTPCheckRequest) GetIndex() int32 {
+	if x != nil && x.Index != nil {
+		return *x.Index
+	}
+	return 0
+}
+
+type CreateHTTPCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         *HTTPCheck             `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHTTPCheckResponse) Reset() {
+	*x = CreateHTTPCheckResponse{}
+	mi := &file_httpcheck_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHTTPCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHTTPCheckResponse) ProtoMessage() {}
+
+func (x *CreateHTTPCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHTTPCheckResponse.ProtoReflect.Descriptor instead.
+func (*CreateHTTPCheckResponse) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateHTTPCheckResponse) GetCheck() *HTTPCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *CreateHTTPCheckResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type ListHTTPChecksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHTTPChecksRequest) Reset() {
+	*x = ListHTTPChecksRequest{}
+	mi := &file_httpcheck_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHTTPChecksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHTTPChecksRequest) ProtoMessage() {}
+
+func (x *ListHTTPChecksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHTTPChecksRequest.ProtoReflect.Descriptor instead.
+func (*ListHTTPChecksRequest) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListHTTPChecksRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ListHTTPChecksRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+type ListHTTPChecksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checks        []*HTTPCheck           `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`    // In the order they run; their index is their position in the list
+	Httpchk       bool                   `protobuf:"varint,2,opt,name=httpchk,proto3" json:"httpchk,omitempty"` // Whether the backend runs HTTP health checks; the rules have no effect otherwise
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHTTPChecksResponse) Reset() {
+	*x = ListHTTPChecksResponse{}
+	mi := &file_httpcheck_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHTTPChecksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHTTPChecksResponse) ProtoMessage() {}
+
+func (x *ListHTTPChecksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHTTPChecksResponse.ProtoReflect.Descriptor instead.
+func (*ListHTTPChecksResponse) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListHTTPChecksResponse) GetChecks() []*HTTPCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *ListHTTPChecksResponse) GetHttpchk() bool {
+	if x != nil {
+		return x.Httpchk
+	}
+	return false
+}
+
+type UpdateHTTPCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Index         int32                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Check         *HTTPCheck             `protobuf:"bytes,4,opt,name=check,proto3" json:"check,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateHTTPCheckRequest) Reset() {
+	*x = UpdateHTTPCheckRequest{}
+	mi := &file_httpcheck_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateHTTPCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateHTTPCheckRequest) ProtoMessage() {}
+
+func (x *UpdateHTTPCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateHTTPCheckRequest.ProtoReflect.Descriptor instead.
+func (*UpdateHTTPCheckRequest) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateHTTPCheckRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *UpdateHTTPCheckRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *UpdateHTTPCheckRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *UpdateHTTPCheckRequest) GetCheck() *HTTPCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+type UpdateHTTPCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         *HTTPCheck             `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateHTTPCheckResponse) Reset() {
+	*x = UpdateHTTPCheckResponse{}
+	mi := &file_httpcheck_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateHTTPCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateHTTPCheckResponse) ProtoMessage() {}
+
+func (x *UpdateHTTPCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateHTTPCheckResponse.ProtoReflect.Descriptor instead.
+func (*UpdateHTTPCheckResponse) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateHTTPCheckResponse) GetCheck() *HTTPCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+type DeleteHTTPCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Index         int32                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteHTTPCheckRequest) Reset() {
+	*x = DeleteHTTPCheckRequest{}
+	mi := &file_httpcheck_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteHTTPCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHTTPCheckRequest) ProtoMessage() {}
+
+func (x *DeleteHTTPCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHTTPCheckRequest.ProtoReflect.Descriptor instead.
+func (*DeleteHTTPCheckRequest) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteHTTPCheckRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteHTTPCheckRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *DeleteHTTPCheckRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type DeleteHTTPCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteHTTPCheckResponse) Reset() {
+	*x = DeleteHTTPCheckResponse{}
+	mi := &file_httpcheck_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteHTTPCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHTTPCheckResponse) ProtoMessage() {}
+
+func (x *DeleteHTTPCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_httpcheck_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHTTPCheckResponse.ProtoReflect.Descriptor instead.
+func (*DeleteHTTPCheckResponse) Descriptor() ([]byte, []int) {
+	return file_httpcheck_proto_rawDescGZIP(), []int{9}
+}
+
+var File_httpcheck_proto protoreflect.FileDescriptor
+
+const file_httpcheck_proto_rawDesc = "" +
+	"\n" +
+	"\x0fhttpcheck.proto\x12\n" +
+	"haproxy.v1\";\n" +
+	"\x0fHTTPCheckHeader\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xad\x02\n" +
+	"\tHTTPCheck\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.haproxy.v1.HTTPCheckTypeR\x04type\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x10\n" +
+	"\x03uri\x18\x03 \x01(\tR\x03uri\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x125\n" +
+	"\aheaders\x18\x05 \x03(\v2\x1b.haproxy.v1.HTTPCheckHeaderR\aheaders\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x120\n" +
+	"\x05match\x18\a \x01(\x0e2\x1a.haproxy.v1.HTTPCheckMatchR\x05match\x12\x18\n" +
+	"\apattern\x18\b \x01(\tR\apattern\x12\x16\n" +
+	"\x06negate\x18\t \x01(\bR\x06negate\"\xb4\x01\n" +
+	"\x16CreateHTTPCheckRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12+\n" +
+	"\x05check\x18\x03 \x01(\v2\x15.haproxy.v1.HTTPCheckR\x05check\x12\x19\n" +
+	"\x05index\x18\x04 \x01(\x05H\x00R\x05index\x88\x01\x01B\b\n" +
+	"\x06_index\"\\\n" +
+	"\x17CreateHTTPCheckResponse\x12+\n" +
+	"\x05check\x18\x01 \x01(\v2\x15.haproxy.v1.HTTPCheckR\x05check\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"a\n" +
+	"\x15ListHTTPChecksRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\"a\n" +
+	"\x16ListHTTPChecksResponse\x12-\n" +
+	"\x06checks\x18\x01 \x03(\v2\x15.haproxy.v1.HTTPCheckR\x06checks\x12\x18\n" +
+	"\ahttpchk\x18\x02 \x01(\bR\ahttpchk\"\xa5\x01\n" +
+	"\x16UpdateHTTPCheckRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x05R\x05index\x12+\n" +
+	"\x05check\x18\x04 \x01(\v2\x15.haproxy.v1.HTTPCheckR\x05check\"F\n" +
+	"\x17UpdateHTTPCheckResponse\x12+\n" +
+	"\x05check\x18\x01 \x01(\v2\x15.haproxy.v1.HTTPCheckR\x05check\"x\n" +
+	"\x16DeleteHTTPCheckRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x05R\x05index\"\x19\n" +
+	"\x17DeleteHTTPCheckResponse*f\n" +
+	"\rHTTPCheckType\x12\x1f\n" +
+	"\x1bHTTP_CHECK_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14HTTP_CHECK_TYPE_SEND\x10\x01\x12\x1a\n" +
+	"\x16HTTP_CHECK_TYPE_EXPECT\x10\x02*\xb2\x01\n" +
+	"\x0eHTTPCheckMatch\x12 \n" +
+	"\x1cHTTP_CHECK_MATCH_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17HTTP_CHECK_MATCH_STATUS\x10\x01\x12!\n" +
+	"\x1dHTTP_CHECK_MATCH_STATUS_REGEX\x10\x02\x12\x1b\n" +
+	"\x17HTTP_CHECK_MATCH_STRING\x10\x03\x12!\n" +
+	"\x1dHTTP_CHECK_MATCH_STRING_REGEX\x10\x04B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_httpcheck_proto_rawDescOnce sync.Once
+	file_httpcheck_proto_rawDescData []byte
+)
+
+func file_httpcheck_proto_rawDescGZIP() []byte {
+	file_httpcheck_proto_rawDescOnce.Do(func() {
+		file_httpcheck_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_httpcheck_proto_rawDesc), len(file_httpcheck_proto_rawDesc)))
+	})
+	return file_httpcheck_proto_rawDescData
+}
+
+var file_httpcheck_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_httpcheck_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_httpcheck_proto_goTypes = []any{
+	(HTTPCheckType)(0),              // 0: haproxy.v1.HTTPCheckType
+	(HTTPCheckMatch)(0),             // 1: haproxy.v1.HTTPCheckMatch
+	(*HTTPCheckHeader)(nil),         // 2: haproxy.v1.HTTPCheckHeader
+	(*HTTPCheck)(nil),               // 3: haproxy.v1.HTTPCheck
+	(*CreateHTTPCheckRequest)(nil),  // 4: haproxy.v1.CreateHTTPCheckRequest
+	(*CreateHTTPCheckResponse)(nil), // 5: haproxy.v1.CreateHTTPCheckResponse
+	(*ListHTTPChecksRequest)(nil),   // 6: haproxy.v1.ListHTTPChecksRequest
+	(*ListHTTPChecksResponse)(nil),  // 7: haproxy.v1.ListHTTPChecksResponse
+	(*UpdateHTTPCheckRequest)(nil),  // 8: haproxy.v1.UpdateHTTPCheckRequest
+	(*UpdateHTTPCheckResponse)(nil), // 9: haproxy.v1.UpdateHTTPCheckResponse
+	(*DeleteHTTPCheckRequest)(nil),  // 10: haproxy.v1.DeleteHTTPCheckRequest
+	(*DeleteHTTPCheckResponse)(nil), // 11: haproxy.v1.DeleteHTTPCheckResponse
+}
+var file_httpcheck_proto_depIdxs = []int32{
+	0, // 0: haproxy.v1.HTTPCheck.type:type_name -> haproxy.v1.HTTPCheckType
+	2, // 1: haproxy.v1.HTTPCheck.headers:type_name -> haproxy.v1.HTTPCheckHeader
+	1, // 2: haproxy.v1.HTTPCheck.match:type_name -> haproxy.v1.HTTPCheckMatch
+	3, // 3: haproxy.v1.CreateHTTPCheckRequest.check:type_name -> haproxy.v1.HTTPCheck
+	3, // 4: haproxy.v1.CreateHTTPCheckResponse.check:type_name -> haproxy.v1.HTTPCheck
+	3, // 5: haproxy.v1.ListHTTPChecksResponse.checks:type_name -> haproxy.v1.HTTPCheck
+	3, // 6: haproxy.v1.UpdateHTTPCheckRequest.check:type_name -> haproxy.v1.HTTPCheck
+	3, // 7: haproxy.v1.UpdateHTTPCheckResponse.check:type_name -> haproxy.v1.HTTPCheck
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_httpcheck_proto_init() }
+func file_httpcheck_proto_init() {
+	if File_httpcheck_proto != nil {
+		return
+	}
+	file_httpcheck_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_httpcheck_proto_rawDesc), len(file_httpcheck_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_httpcheck_proto_goTypes,
+		DependencyIndexes: file_httpcheck_proto_depIdxs,
+		EnumInfos:         file_httpcheck_proto_enumTypes,
+		MessageInfos:      file_httpcheck_proto_msgTypes,
+	}.Build()
+	File_httpcheck_proto = out.File
+	file_httpcheck_proto_goTypes = nil
+	file_httpcheck_proto_depIdxs = nil
+}
@@ -0,0 +1,637 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: info.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_info_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{0}
+}
+
+// GetServerInfoResponse describes the running server and the Data Plane API of the selected HAProxy instance
+type GetServerInfoResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Version               string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"` // Release version, "dev" for local builds
+	Commit                string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	BuildDate             string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	GoVersion             string                 `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Features              []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                                    // Enabled optional features, e.g. netplan, grpc_tls, dry_run
+	Instance              string                 `protobuf:"bytes,6,opt,name=instance,proto3" json:"instance,omitempty"`                                                    // HAProxy instance the Data Plane API fields refer to
+	DataplaneApiVersion   string                 `protobuf:"bytes,7,opt,name=dataplane_api_version,json=dataplaneApiVersion,proto3" json:"dataplane_api_version,omitempty"` // Empty if the Data Plane API could not be reached
+	DataplaneApiBuildDate string                 `protobuf:"bytes,8,opt,name=dataplane_api_build_date,json=dataplaneApiBuildDate,proto3" json:"dataplane_api_build_date,omitempty"`
+	DataplaneApiError     string                 `protobuf:"bytes,9,opt,name=dataplane_api_error,json=dataplaneApiError,proto3" json:"dataplane_api_error,omitempty"` // Why the Data Plane API version is missing
+	Leader                bool                   `protobuf:"varint,10,opt,name=leader,proto3" json:"leader,omitempty"`                                                // Whether this instance accepts changes; always true without leader election
+	LeaderIdentity        string                 `protobuf:"bytes,11,opt,name=leader_identity,json=leaderIdentity,proto3" json:"leader_identity,omitempty"`           // Identity of the elected leader, empty if unknown or without leader election
+	DataplaneApiUrl       string                 `protobuf:"bytes,12,opt,name=dataplane_api_url,json=dataplaneApiUrl,proto3" json:"dataplane_api_url,omitempty"`      // Data Plane API URL in use, which differs from api_url after a failover
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_info_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetDataplaneApiVersion() string {
+	if x != nil {
+		return x.DataplaneApiVersion
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetDataplaneApiBuildDate() string {
+	if x != nil {
+		return x.DataplaneApiBuildDate
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetDataplaneApiError() string {
+	if x != nil {
+		return x.DataplaneApiError
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetLeader() bool {
+	if x != nil {
+		return x.Leader
+	}
+	return false
+}
+
+func (x *GetServerInfoResponse) GetLeaderIdentity() string {
+	if x != nil {
+		return x.LeaderIdentity
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetDataplaneApiUrl() string {
+	if x != nil {
+		return x.DataplaneApiUrl
+	}
+	return ""
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_info_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{2}
+}
+
+// GetStatusResponse summarizes the state of the server and its subsystems for dashboards and runbooks
+type GetStatusResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	StartTime             *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Uptime                *durationpb.Duration   `protobuf:"bytes,2,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	ConfigFile            string                 `protobuf:"bytes,3,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"` // Configuration file the server was started with
+	Instance              string                 `protobuf:"bytes,4,opt,name=instance,proto3" json:"instance,omitempty"`                       // HAProxy instance the Data Plane API fields refer to
+	DataplaneApiReachable bool                   `protobuf:"varint,5,opt,name=dataplane_api_reachable,json=dataplaneApiReachable,proto3" json:"dataplane_api_reachable,omitempty"`
+	DataplaneApiLatency   *durationpb.Duration   `protobuf:"bytes,6,opt,name=dataplane_api_latency,json=dataplaneApiLatency,proto3" json:"dataplane_api_latency,omitempty"` // Round trip of an info request, also set when it failed
+	DataplaneApiError     string                 `protobuf:"bytes,7,opt,name=dataplane_api_error,json=dataplaneApiError,proto3" json:"dataplane_api_error,omitempty"`       // Why the Data Plane API could not be reached
+	OpenTransactions      int32                  `protobuf:"varint,8,opt,name=open_transactions,json=openTransactions,proto3" json:"open_transactions,omitempty"`           // Transactions created through the server and neither committed nor closed yet
+	NetplanEnabled        bool                   `protobuf:"varint,9,opt,name=netplan_enabled,json=netplanEnabled,proto3" json:"netplan_enabled,omitempty"`
+	TrackedVips           int32                  `protobuf:"varint,10,opt,name=tracked_vips,json=trackedVips,proto3" json:"tracked_vips,omitempty"`                 // VIPs assigned by the Netplan integration
+	LastCommitTime        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_commit_time,json=lastCommitTime,proto3" json:"last_commit_time,omitempty"`       // Unset if no transaction was committed since startup
+	LastNetplanApply      *NetplanApplyResult    `protobuf:"bytes,12,opt,name=last_netplan_apply,json=lastNetplanApply,proto3" json:"last_netplan_apply,omitempty"` // Unset if Netplan was not applied since startup
+	Freeze                *FreezeState           `protobuf:"bytes,13,opt,name=freeze,proto3" json:"freeze,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_info_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetStatusResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetConfigFile() string {
+	if x != nil {
+		return x.ConfigFile
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetDataplaneApiReachable() bool {
+	if x != nil {
+		return x.DataplaneApiReachable
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetDataplaneApiLatency() *durationpb.Duration {
+	if x != nil {
+		return x.DataplaneApiLatency
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetDataplaneApiError() string {
+	if x != nil {
+		return x.DataplaneApiError
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetOpenTransactions() int32 {
+	if x != nil {
+		return x.OpenTransactions
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetNetplanEnabled() bool {
+	if x != nil {
+		return x.NetplanEnabled
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetTrackedVips() int32 {
+	if x != nil {
+		return x.TrackedVips
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetLastCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCommitTime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetLastNetplanApply() *NetplanApplyResult {
+	if x != nil {
+		return x.LastNetplanApply
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetFreeze() *FreezeState {
+	if x != nil {
+		return x.Freeze
+	}
+	return nil
+}
+
+// NetplanApplyResult is the outcome of applying the Netplan configuration to the host
+type NetplanApplyResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetplanApplyResult) Reset() {
+	*x = NetplanApplyResult{}
+	mi := &file_info_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetplanApplyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetplanApplyResult) ProtoMessage() {}
+
+func (x *NetplanApplyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetplanApplyResult.ProtoReflect.Descriptor instead.
+func (*NetplanApplyResult) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NetplanApplyResult) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *NetplanApplyResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *NetplanApplyResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_info_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{5}
+}
+
+// ReloadConfigResponse reports the outcome of reloading the configuration file
+type ReloadConfigResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConfigFile      string                 `protobuf:"bytes,1,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"`
+	RestartRequired []string               `protobuf:"bytes,2,rep,name=restart_required,json=restartRequired,proto3" json:"restart_required,omitempty"` // Changed sections that only take effect after a restart
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	mi := &file_info_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReloadConfigResponse) GetConfigFile() string {
+	if x != nil {
+		return x.ConfigFile
+	}
+	return ""
+}
+
+func (x *ReloadConfigResponse) GetRestartRequired() []string {
+	if x != nil {
+		return x.RestartRequired
+	}
+	return nil
+}
+
+var File_info_proto protoreflect.FileDescriptor
+
+const file_info_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"info.proto\x12\n" +
+	"haproxy.v1\x1a\ffreeze.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xc9\x03\n" +
+	"\x15GetServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\tR\tbuildDate\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\x12\x1a\n" +
+	"\binstance\x18\x06 \x01(\tR\binstance\x122\n" +
+	"\x15dataplane_api_version\x18\a \x01(\tR\x13dataplaneApiVersion\x127\n" +
+	"\x18dataplane_api_build_date\x18\b \x01(\tR\x15dataplaneApiBuildDate\x12.\n" +
+	"\x13dataplane_api_error\x18\t \x01(\tR\x11dataplaneApiError\x12\x16\n" +
+	"\x06leader\x18\n" +
+	" \x01(\bR\x06leader\x12'\n" +
+	"\x0fleader_identity\x18\v \x01(\tR\x0eleaderIdentity\x12*\n" +
+	"\x11dataplane_api_url\x18\f \x01(\tR\x0fdataplaneApiUrl\"\x12\n" +
+	"\x10GetStatusRequest\"\xb3\x05\n" +
+	"\x11GetStatusResponse\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
+	"\x06uptime\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12\x1f\n" +
+	"\vconfig_file\x18\x03 \x01(\tR\n" +
+	"configFile\x12\x1a\n" +
+	"\binstance\x18\x04 \x01(\tR\binstance\x126\n" +
+	"\x17dataplane_api_reachable\x18\x05 \x01(\bR\x15dataplaneApiReachable\x12M\n" +
+	"\x15dataplane_api_latency\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x13dataplaneApiLatency\x12.\n" +
+	"\x13dataplane_api_error\x18\a \x01(\tR\x11dataplaneApiError\x12+\n" +
+	"\x11open_transactions\x18\b \x01(\x05R\x10openTransactions\x12'\n" +
+	"\x0fnetplan_enabled\x18\t \x01(\bR\x0enetplanEnabled\x12!\n" +
+	"\ftracked_vips\x18\n" +
+	" \x01(\x05R\vtrackedVips\x12D\n" +
+	"\x10last_commit_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0elastCommitTime\x12L\n" +
+	"\x12last_netplan_apply\x18\f \x01(\v2\x1e.haproxy.v1.NetplanApplyResultR\x10lastNetplanApply\x12/\n" +
+	"\x06freeze\x18\r \x01(\v2\x17.haproxy.v1.FreezeStateR\x06freeze\"t\n" +
+	"\x12NetplanApplyResult\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x15\n" +
+	"\x13ReloadConfigRequest\"b\n" +
+	"\x14ReloadConfigResponse\x12\x1f\n" +
+	"\vconfig_file\x18\x01 \x01(\tR\n" +
+	"configFile\x12)\n" +
+	"\x10restart_required\x18\x02 \x03(\tR\x0frestartRequiredB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_info_proto_rawDescOnce sync.Once
+	file_info_proto_rawDescData []byte
+)
+
+func file_info_proto_rawDescGZIP() []byte {
+	file_info_proto_rawDescOnce.Do(func() {
+		file_info_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_info_proto_rawDesc), len(file_info_proto_rawDesc)))
+	})
+	return file_info_proto_rawDescData
+}
+
+var file_info_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_info_proto_goTypes = []any{
+	(*GetServerInfoRequest)(nil),  // 0: haproxy.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil), // 1: haproxy.v1.GetServerInfoResponse
+	(*GetStatusRequest)(nil),      // 2: haproxy.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),     // 3: haproxy.v1.GetStatusResponse
+	(*NetplanApplyResult)(nil),    // 4: haproxy.v1.NetplanApplyResult
+	(*ReloadConfigRequest)(nil),   // 5: haproxy.v1.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),  // 6: haproxy.v1.ReloadConfigResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 8: google.protobuf.Duration
+	(*FreezeState)(nil),           // 9: haproxy.v1.FreezeState
+}
+var file_info_proto_depIdxs = []int32{
+	7, // 0: haproxy.v1.GetStatusResponse.start_time:type_name -> google.protobuf.Timestamp
+	8, // 1: haproxy.v1.GetStatusResponse.uptime:type_name -> google.protobuf.Duration
+	8, // 2: haproxy.v1.GetStatusResponse.dataplane_api_latency:type_name -> google.protobuf.Duration
+	7, // 3: haproxy.v1.GetStatusResponse.last_commit_time:type_name -> google.protobuf.Timestamp
+	4, // 4: haproxy.v1.GetStatusResponse.last_netplan_apply:type_name -> haproxy.v1.NetplanApplyResult
+	9, // 5: haproxy.v1.GetStatusResponse.freeze:type_name -> haproxy.v1.FreezeState
+	7, // 6: haproxy.v1.NetplanApplyResult.time:type_name -> google.protobuf.Timestamp
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_info_proto_init() }
+func file_info_proto_init() {
+	if File_info_proto != nil {
+		return
+	}
+	file_freeze_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_info_proto_rawDesc), len(file_info_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_info_proto_goTypes,
+		DependencyIndexes: file_info_proto_depIdxs,
+		MessageInfos:      file_info_proto_msgTypes,
+	}.Build()
+	File_info_proto = out.File
+	file_info_proto_goTypes = nil
+	file_info_proto_depIdxs = nil
+}
@@ -0,0 +1,1390 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: lua.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LuaScript is a Lua script in the storage of HAProxy. Every upload is stored as a new version
+// "<name>.v<version>.lua", so that earlier versions stay available for rollbacks. At most one version of a script
+// is loaded with lua-load in the global section.
+type LuaScript struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                         // Letters, digits, "-" and "_"
+	Versions      []*LuaScriptVersion    `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`                                 // Oldest first
+	LoadedVersion int32                  `protobuf:"varint,3,opt,name=loaded_version,json=loadedVersion,proto3" json:"loaded_version,omitempty"` // Version loaded by lua-load; 0 if the script is not loaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LuaScript) Reset() {
+	*x = LuaScript{}
+	mi := &file_lua_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LuaScript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LuaScript) ProtoMessage() {}
+
+func (x *LuaScript) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LuaScript.ProtoReflect.Descriptor instead.
+func (*LuaScript) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LuaScript) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LuaScript) GetVersions() []*LuaScriptVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *LuaScript) GetLoadedVersion() int32 {
+	if x != nil {
+		return x.LoadedVersion
+	}
+	return 0
+}
+
+// LuaScriptVersion is an uploaded version of a Lua script
+type LuaScriptVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`  // Path on the HAProxy host, as referenced by lua-load
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"` // Bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LuaScriptVersion) Reset() {
+	*x = LuaScriptVersion{}
+	mi := &file_lua_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LuaScriptVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LuaScriptVersion) ProtoMessage() {}
+
+func (x *LuaScriptVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LuaScriptVersion.ProtoReflect.Descriptor instead.
+func (*LuaScriptVersion) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LuaScriptVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LuaScriptVersion) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *LuaScriptVersion) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// LuaAction runs an action registered by a loaded script with core.register_action. HTTP frontends run it as an
+// http-request rule, TCP frontends as a tcp-request content rule, after the existing rules.
+type LuaAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                         // Required: the name passed to core.register_action
+	Arguments     string                 `protobuf:"bytes,2,opt,name=arguments,proto3" json:"arguments,omitempty"`               // Passed to the action, separated by spaces
+	Cond          string                 `protobuf:"bytes,3,opt,name=cond,proto3" json:"cond,omitempty"`                         // "if" or "unless"
+	CondTest      string                 `protobuf:"bytes,4,opt,name=cond_test,json=condTest,proto3" json:"cond_test,omitempty"` // Required with cond
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LuaAction) Reset() {
+	*x = LuaAction{}
+	mi := &file_lua_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LuaAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LuaAction) ProtoMessage() {}
+
+func (x *LuaAction) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LuaAction.ProtoReflect.Descriptor instead.
+func (*LuaAction) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LuaAction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LuaAction) GetArguments() string {
+	if x != nil {
+		return x.Arguments
+	}
+	return ""
+}
+
+func (x *LuaAction) GetCond() string {
+	if x != nil {
+		return x.Cond
+	}
+	return ""
+}
+
+func (x *LuaAction) GetCondTest() string {
+	if x != nil {
+		return x.CondTest
+	}
+	return ""
+}
+
+type UploadLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // Lua source, at most 1 MiB
+	Load          bool                   `protobuf:"varint,3,opt,name=load,proto3" json:"load,omitempty"`      // Load the new version in place of the loaded one; requires transaction_id
+	TransactionId string                 `protobuf:"bytes,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadLuaScriptRequest) Reset() {
+	*x = UploadLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadLuaScriptRequest) ProtoMessage() {}
+
+func (x *UploadLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*UploadLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadLuaScriptRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UploadLuaScriptRequest) GetLoad() bool {
+	if x != nil {
+		return x.Load
+	}
+	return false
+}
+
+func (x *UploadLuaScriptRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type UploadLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        *LuaScript             `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Version       *LuaScriptVersion      `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // The uploaded version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadLuaScriptResponse) Reset() {
+	*x = UploadLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadLuaScriptResponse) ProtoMessage() {}
+
+func (x *UploadLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*UploadLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadLuaScriptResponse) GetScript() *LuaScript {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+func (x *UploadLuaScriptResponse) GetVersion() *LuaScriptVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type GetLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                                 // Version whose content is returned; 0 for the loaded version, or the latest if none is loaded
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Reports the version loaded within the transaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLuaScriptRequest) Reset() {
+	*x = GetLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLuaScriptRequest) ProtoMessage() {}
+
+func (x *GetLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*GetLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetLuaScriptRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetLuaScriptRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type GetLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        *LuaScript             `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Actions       []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"` // Actions the returned version registers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLuaScriptResponse) Reset() {
+	*x = GetLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLuaScriptResponse) ProtoMessage() {}
+
+func (x *GetLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*GetLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLuaScriptResponse) GetScript() *LuaScript {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+func (x *GetLuaScriptResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *GetLuaScriptResponse) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type ListLuaScriptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLuaScriptsRequest) Reset() {
+	*x = ListLuaScriptsRequest{}
+	mi := &file_lua_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLuaScriptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLuaScriptsRequest) ProtoMessage() {}
+
+func (x *ListLuaScriptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLuaScriptsRequest.ProtoReflect.Descriptor instead.
+func (*ListLuaScriptsRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListLuaScriptsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ListLuaScriptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scripts       []*LuaScript           `protobuf:"bytes,1,rep,name=scripts,proto3" json:"scripts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLuaScriptsResponse) Reset() {
+	*x = ListLuaScriptsResponse{}
+	mi := &file_lua_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLuaScriptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLuaScriptsResponse) ProtoMessage() {}
+
+func (x *ListLuaScriptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLuaScriptsResponse.ProtoReflect.Descriptor instead.
+func (*ListLuaScriptsResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListLuaScriptsResponse) GetScripts() []*LuaScript {
+	if x != nil {
+		return x.Scripts
+	}
+	return nil
+}
+
+type LoadLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // 0 for the latest version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadLuaScriptRequest) Reset() {
+	*x = LoadLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadLuaScriptRequest) ProtoMessage() {}
+
+func (x *LoadLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*LoadLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LoadLuaScriptRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LoadLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoadLuaScriptRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type LoadLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        *LuaScript             `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadLuaScriptResponse) Reset() {
+	*x = LoadLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadLuaScriptResponse) ProtoMessage() {}
+
+func (x *LoadLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*LoadLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LoadLuaScriptResponse) GetScript() *LuaScript {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+type RollbackLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // 0 for the version before the loaded one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackLuaScriptRequest) Reset() {
+	*x = RollbackLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackLuaScriptRequest) ProtoMessage() {}
+
+func (x *RollbackLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*RollbackLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RollbackLuaScriptRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RollbackLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RollbackLuaScriptRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RollbackLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        *LuaScript             `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackLuaScriptResponse) Reset() {
+	*x = RollbackLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackLuaScriptResponse) ProtoMessage() {}
+
+func (x *RollbackLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*RollbackLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RollbackLuaScriptResponse) GetScript() *LuaScript {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+type UnloadLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadLuaScriptRequest) Reset() {
+	*x = UnloadLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadLuaScriptRequest) ProtoMessage() {}
+
+func (x *UnloadLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*UnloadLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnloadLuaScriptRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *UnloadLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnloadLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        *LuaScript             `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadLuaScriptResponse) Reset() {
+	*x = UnloadLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadLuaScriptResponse) ProtoMessage() {}
+
+func (x *UnloadLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*UnloadLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnloadLuaScriptResponse) GetScript() *LuaScript {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+type DeleteLuaScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // 0 for all versions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLuaScriptRequest) Reset() {
+	*x = DeleteLuaScriptRequest{}
+	mi := &file_lua_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLuaScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLuaScriptRequest) ProtoMessage() {}
+
+func (x *DeleteLuaScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLuaScriptRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLuaScriptRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteLuaScriptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteLuaScriptRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteLuaScriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLuaScriptResponse) Reset() {
+	*x = DeleteLuaScriptResponse{}
+	mi := &file_lua_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLuaScriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLuaScriptResponse) ProtoMessage() {}
+
+func (x *DeleteLuaScriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLuaScriptResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLuaScriptResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{16}
+}
+
+type CreateLuaActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Action        *LuaAction             `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLuaActionRequest) Reset() {
+	*x = CreateLuaActionRequest{}
+	mi := &file_lua_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLuaActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLuaActionRequest) ProtoMessage() {}
+
+func (x *CreateLuaActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLuaActionRequest.ProtoReflect.Descriptor instead.
+func (*CreateLuaActionRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateLuaActionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateLuaActionRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *CreateLuaActionRequest) GetAction() *LuaAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type CreateLuaActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        *LuaAction             `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLuaActionResponse) Reset() {
+	*x = CreateLuaActionResponse{}
+	mi := &file_lua_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLuaActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLuaActionResponse) ProtoMessage() {}
+
+func (x *CreateLuaActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLuaActionResponse.ProtoReflect.Descriptor instead.
+func (*CreateLuaActionResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateLuaActionResponse) GetAction() *LuaAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type ListLuaActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLuaActionsRequest) Reset() {
+	*x = ListLuaActionsRequest{}
+	mi := &file_lua_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLuaActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLuaActionsRequest) ProtoMessage() {}
+
+func (x *ListLuaActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLuaActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListLuaActionsRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListLuaActionsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ListLuaActionsRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+type ListLuaActionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*LuaAction           `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLuaActionsResponse) Reset() {
+	*x = ListLuaActionsResponse{}
+	mi := &file_lua_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLuaActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLuaActionsResponse) ProtoMessage() {}
+
+func (x *ListLuaActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLuaActionsResponse.ProtoReflect.Descriptor instead.
+func (*ListLuaActionsResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListLuaActionsResponse) GetActions() []*LuaAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type DeleteLuaActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLuaActionRequest) Reset() {
+	*x = DeleteLuaActionRequest{}
+	mi := &file_lua_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLuaActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLuaActionRequest) ProtoMessage() {}
+
+func (x *DeleteLuaActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLuaActionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLuaActionRequest) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteLuaActionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteLuaActionRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *DeleteLuaActionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteLuaActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLuaActionResponse) Reset() {
+	*x = DeleteLuaActionResponse{}
+	mi := &file_lua_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLuaActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLuaActionResponse) ProtoMessage() {}
+
+func (x *DeleteLuaActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lua_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLuaActionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLuaActionResponse) Descriptor() ([]byte, []int) {
+	return file_lua_proto_rawDescGZIP(), []int{22}
+}
+
+var File_lua_proto protoreflect.FileDescriptor
+
+const file_lua_proto_rawDesc = "" +
+	"\n" +
+	"\tlua.proto\x12\n" +
+	"haproxy.v1\"\x80\x01\n" +
+	"\tLuaScript\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\bversions\x18\x02 \x03(\v2\x1c.haproxy.v1.LuaScriptVersionR\bversions\x12%\n" +
+	"\x0eloaded_version\x18\x03 \x01(\x05R\rloadedVersion\"T\n" +
+	"\x10LuaScriptVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"n\n" +
+	"\tLuaAction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\targuments\x18\x02 \x01(\tR\targuments\x12\x12\n" +
+	"\x04cond\x18\x03 \x01(\tR\x04cond\x12\x1b\n" +
+	"\tcond_test\x18\x04 \x01(\tR\bcondTest\"\x81\x01\n" +
+	"\x16UploadLuaScriptRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
+	"\x04load\x18\x03 \x01(\bR\x04load\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\tR\rtransactionId\"\x80\x01\n" +
+	"\x17UploadLuaScriptResponse\x12-\n" +
+	"\x06script\x18\x01 \x01(\v2\x15.haproxy.v1.LuaScriptR\x06script\x126\n" +
+	"\aversion\x18\x02 \x01(\v2\x1c.haproxy.v1.LuaScriptVersionR\aversion\"j\n" +
+	"\x13GetLuaScriptRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"y\n" +
+	"\x14GetLuaScriptResponse\x12-\n" +
+	"\x06script\x18\x01 \x01(\v2\x15.haproxy.v1.LuaScriptR\x06script\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x18\n" +
+	"\aactions\x18\x03 \x03(\tR\aactions\">\n" +
+	"\x15ListLuaScriptsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"I\n" +
+	"\x16ListLuaScriptsResponse\x12/\n" +
+	"\ascripts\x18\x01 \x03(\v2\x15.haproxy.v1.LuaScriptR\ascripts\"k\n" +
+	"\x14LoadLuaScriptRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"F\n" +
+	"\x15LoadLuaScriptResponse\x12-\n" +
+	"\x06script\x18\x01 \x01(\v2\x15.haproxy.v1.LuaScriptR\x06script\"o\n" +
+	"\x18RollbackLuaScriptRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"J\n" +
+	"\x19RollbackLuaScriptResponse\x12-\n" +
+	"\x06script\x18\x01 \x01(\v2\x15.haproxy.v1.LuaScriptR\x06script\"S\n" +
+	"\x16UnloadLuaScriptRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"H\n" +
+	"\x17UnloadLuaScriptResponse\x12-\n" +
+	"\x06script\x18\x01 \x01(\v2\x15.haproxy.v1.LuaScriptR\x06script\"F\n" +
+	"\x16DeleteLuaScriptRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x19\n" +
+	"\x17DeleteLuaScriptResponse\"\x93\x01\n" +
+	"\x16CreateLuaActionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12-\n" +
+	"\x06action\x18\x03 \x01(\v2\x15.haproxy.v1.LuaActionR\x06action\"H\n" +
+	"\x17CreateLuaActionResponse\x12-\n" +
+	"\x06action\x18\x01 \x01(\v2\x15.haproxy.v1.LuaActionR\x06action\"c\n" +
+	"\x15ListLuaActionsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\"I\n" +
+	"\x16ListLuaActionsResponse\x12/\n" +
+	"\aactions\x18\x01 \x03(\v2\x15.haproxy.v1.LuaActionR\aactions\"x\n" +
+	"\x16DeleteLuaActionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x19\n" +
+	"\x17DeleteLuaActionResponseB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_lua_proto_rawDescOnce sync.Once
+	file_lua_proto_rawDescData []byte
+)
+
+func file_lua_proto_rawDescGZIP() []byte {
+	file_lua_proto_rawDescOnce.Do(func() {
+		file_lua_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lua_proto_rawDesc), len(file_lua_proto_rawDesc)))
+	})
+	return file_lua_proto_rawDescData
+}
+
+var file_lua_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_lua_proto_goTypes = []any{
+	(*LuaScript)(nil),                 // 0: haproxy.v1.LuaScript
+	(*LuaScriptVersion)(nil),          // 1: haproxy.v1.LuaScriptVersion
+	(*LuaAction)(nil),                 // 2: haproxy.v1.LuaAction
+	(*UploadLuaScriptRequest)(nil),    // 3: haproxy.v1.UploadLuaScriptRequest
+	(*UploadLuaScriptResponse)(nil),   // 4: haproxy.v1.UploadLuaScriptResponse
+	(*GetLuaScriptRequest)(nil),       // 5: haproxy.v1.GetLuaScriptRequest
+	(*GetLuaScriptResponse)(nil),      // 6: haproxy.v1.GetLuaScriptResponse
+	(*ListLuaScriptsRequest)(nil),     // 7: haproxy.v1.ListLuaScriptsRequest
+	(*ListLuaScriptsResponse)(nil),    // 8: haproxy.v1.ListLuaScriptsResponse
+	(*LoadLuaScriptRequest)(nil),      // 9: haproxy.v1.LoadLuaScriptRequest
+	(*LoadLuaScriptResponse)(nil),     // 10: haproxy.v1.LoadLuaScriptResponse
+	(*RollbackLuaScriptRequest)(nil),  // 11: haproxy.v1.RollbackLuaScriptRequest
+	(*RollbackLuaScriptResponse)(nil), // 12: haproxy.v1.RollbackLuaScriptResponse
+	(*UnloadLuaScriptRequest)(nil),    // 13: haproxy.v1.UnloadLuaScriptRequest
+	(*UnloadLuaScriptResponse)(nil),   // 14: haproxy.v1.UnloadLuaScriptResponse
+	(*DeleteLuaScriptRequest)(nil),    // 15: haproxy.v1.DeleteLuaScriptRequest
+	(*DeleteLuaScriptResponse)(nil),   // 16: haproxy.v1.DeleteLuaScriptResponse
+	(*CreateLuaActionRequest)(nil),    // 17: haproxy.v1.CreateLuaActionRequest
+	(*CreateLuaActionResponse)(nil),   // 18: haproxy.v1.CreateLuaActionResponse
+	(*ListLuaActionsRequest)(nil),     // 19: haproxy.v1.ListLuaActionsRequest
+	(*ListLuaActionsResponse)(nil),    // 20: haproxy.v1.ListLuaActionsResponse
+	(*DeleteLuaActionRequest)(nil),    // 21: haproxy.v1.DeleteLuaActionRequest
+	(*DeleteLuaActionResponse)(nil),   // 22: haproxy.v1.DeleteLuaActionResponse
+}
+var file_lua_proto_depIdxs = []int32{
+	1,  // 0: haproxy.v1.LuaScript.versions:type_name -> haproxy.v1.LuaScriptVersion
+	0,  // 1: haproxy.v1.UploadLuaScriptResponse.script:type_name -> haproxy.v1.LuaScript
+	1,  // 2: haproxy.v1.UploadLuaScriptResponse.version:type_name -> haproxy.v1.LuaScriptVersion
+	0,  // 3: haproxy.v1.GetLuaScriptResponse.script:type_name -> haproxy.v1.LuaScript
+	0,  // 4: haproxy.v1.ListLuaScriptsResponse.scripts:type_name -> haproxy.v1.LuaScript
+	0,  // 5: haproxy.v1.LoadLuaScriptResponse.script:type_name -> haproxy.v1.LuaScript
+	0,  // 6: haproxy.v1.RollbackLuaScriptResponse.script:type_name -> haproxy.v1.LuaScript
+	0,  // 7: haproxy.v1.UnloadLuaScriptResponse.script:type_name -> haproxy.v1.LuaScript
+	2,  // 8: haproxy.v1.CreateLuaActionRequest.action:type_name -> haproxy.v1.LuaAction
+	2,  // 9: haproxy.v1.CreateLuaActionResponse.action:type_name -> haproxy.v1.LuaAction
+	2,  // 10: haproxy.v1.ListLuaActionsResponse.actions:type_name -> haproxy.v1.LuaAction
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_lua_proto_init() }
+func file_lua_proto_init() {
+	if File_lua_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lua_proto_rawDesc), len(file_lua_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_lua_proto_goTypes,
+		DependencyIndexes: file_lua_proto_depIdxs,
+		MessageInfos:      file_lua_proto_msgTypes,
+	}.Build()
+	File_lua_proto = out.File
+	file_lua_proto_goTypes = nil
+	file_lua_proto_depIdxs = nil
+}
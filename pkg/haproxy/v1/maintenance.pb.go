@@ -0,0 +1,510 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: maintenance.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MaintenanceEntry is a backend or server in maintenance
+type MaintenanceEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	ServerName    string                 `protobuf:"bytes,2,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"` // Empty for a whole backend
+	Runtime       bool                   `protobuf:"varint,3,opt,name=runtime,proto3" json:"runtime,omitempty"`                        // In maintenance in the running process; for a backend, all of its servers are
+	Persisted     bool                   `protobuf:"varint,4,opt,name=persisted,proto3" json:"persisted,omitempty"`                    // Disabled in the configuration, so maintenance survives reloads and restarts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceEntry) Reset() {
+	*x = MaintenanceEntry{}
+	mi := &file_maintenance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceEntry) ProtoMessage() {}
+
+func (x *MaintenanceEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceEntry.ProtoReflect.Descriptor instead.
+func (*MaintenanceEntry) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MaintenanceEntry) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *MaintenanceEntry) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *MaintenanceEntry) GetRuntime() bool {
+	if x != nil {
+		return x.Runtime
+	}
+	return false
+}
+
+func (x *MaintenanceEntry) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+// EnterMaintenanceRequest puts a server, or every server of a backend, into maintenance in the running process
+// at once. With persist the server or backend is also disabled in the configuration.
+type EnterMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	ServerName    string                 `protobuf:"bytes,2,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"` // Empty for the whole backend
+	Persist       bool                   `protobuf:"varint,3,opt,name=persist,proto3" json:"persist,omitempty"`
+	TransactionId string                 `protobuf:"bytes,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Optional: transaction for the persisted flag; without one it is committed on its own
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterMaintenanceRequest) Reset() {
+	*x = EnterMaintenanceRequest{}
+	mi := &file_maintenance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterMaintenanceRequest) ProtoMessage() {}
+
+func (x *EnterMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*EnterMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EnterMaintenanceRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *EnterMaintenanceRequest) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *EnterMaintenanceRequest) GetPersist() bool {
+	if x != nil {
+		return x.Persist
+	}
+	return false
+}
+
+func (x *EnterMaintenanceRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type EnterMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*MaintenanceEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`         // The backend or server and, for a backend, its servers
+	Transaction   *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"` // The committed transaction, when persisted without a transaction ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterMaintenanceResponse) Reset() {
+	*x = EnterMaintenanceResponse{}
+	mi := &file_maintenance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterMaintenanceResponse) ProtoMessage() {}
+
+func (x *EnterMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*EnterMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EnterMaintenanceResponse) GetEntries() []*MaintenanceEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *EnterMaintenanceResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// ExitMaintenanceRequest makes a server, or every server of a backend, ready again and clears a persisted flag
+type ExitMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	ServerName    string                 `protobuf:"bytes,2,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`          // Empty for the whole backend
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Optional: transaction for clearing the persisted flag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitMaintenanceRequest) Reset() {
+	*x = ExitMaintenanceRequest{}
+	mi := &file_maintenance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitMaintenanceRequest) ProtoMessage() {}
+
+func (x *ExitMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*ExitMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExitMaintenanceRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *ExitMaintenanceRequest) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *ExitMaintenanceRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ExitMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*MaintenanceEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`         // The backend or server and, for a backend, its servers
+	Transaction   *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"` // The committed transaction, when a persisted flag was cleared without a transaction ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitMaintenanceResponse) Reset() {
+	*x = ExitMaintenanceResponse{}
+	mi := &file_maintenance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitMaintenanceResponse) ProtoMessage() {}
+
+func (x *ExitMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*ExitMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExitMaintenanceResponse) GetEntries() []*MaintenanceEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ExitMaintenanceResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type ListMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"` // Optional: only this backend and its servers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceRequest) Reset() {
+	*x = ListMaintenanceRequest{}
+	mi := &file_maintenance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMaintenanceRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+// ListMaintenanceResponse contains the backends and servers currently in maintenance
+type ListMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*MaintenanceEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceResponse) Reset() {
+	*x = ListMaintenanceResponse{}
+	mi := &file_maintenance_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListMaintenanceResponse) GetEntries() []*MaintenanceEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_maintenance_proto protoreflect.FileDescriptor
+
+const file_maintenance_proto_rawDesc = "" +
+	"\n" +
+	"\x11maintenance.proto\x12\n" +
+	"haproxy.v1\x1a\x11transaction.proto\"\x8e\x01\n" +
+	"\x10MaintenanceEntry\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x1f\n" +
+	"\vserver_name\x18\x02 \x01(\tR\n" +
+	"serverName\x12\x18\n" +
+	"\aruntime\x18\x03 \x01(\bR\aruntime\x12\x1c\n" +
+	"\tpersisted\x18\x04 \x01(\bR\tpersisted\"\x9e\x01\n" +
+	"\x17EnterMaintenanceRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x1f\n" +
+	"\vserver_name\x18\x02 \x01(\tR\n" +
+	"serverName\x12\x18\n" +
+	"\apersist\x18\x03 \x01(\bR\apersist\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\tR\rtransactionId\"\x8d\x01\n" +
+	"\x18EnterMaintenanceResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.haproxy.v1.MaintenanceEntryR\aentries\x129\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\"\x83\x01\n" +
+	"\x16ExitMaintenanceRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x1f\n" +
+	"\vserver_name\x18\x02 \x01(\tR\n" +
+	"serverName\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"\x8c\x01\n" +
+	"\x17ExitMaintenanceResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.haproxy.v1.MaintenanceEntryR\aentries\x129\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\";\n" +
+	"\x16ListMaintenanceRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\"Q\n" +
+	"\x17ListMaintenanceResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.haproxy.v1.MaintenanceEntryR\aentriesB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_maintenance_proto_rawDescOnce sync.Once
+	file_maintenance_proto_rawDescData []byte
+)
+
+func file_maintenance_proto_rawDescGZIP() []byte {
+	file_maintenance_proto_rawDescOnce.Do(func() {
+		file_maintenance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_maintenance_proto_rawDesc), len(file_maintenance_proto_rawDesc)))
+	})
+	return file_maintenance_proto_rawDescData
+}
+
+var file_maintenance_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_maintenance_proto_goTypes = []any{
+	(*MaintenanceEntry)(nil),         // 0: haproxy.v1.MaintenanceEntry
+	(*EnterMaintenanceRequest)(nil),  // 1: haproxy.v1.EnterMaintenanceRequest
+	(*EnterMaintenanceResponse)(nil), // 2: haproxy.v1.EnterMaintenanceResponse
+	(*ExitMaintenanceRequest)(nil),   // 3: haproxy.v1.ExitMaintenanceRequest
+	(*ExitMaintenanceResponse)(nil),  // 4: haproxy.v1.ExitMaintenanceResponse
+	(*ListMaintenanceRequest)(nil),   // 5: haproxy.v1.ListMaintenanceRequest
+	(*ListMaintenanceResponse)(nil),  // 6: haproxy.v1.ListMaintenanceResponse
+	(*Transaction)(nil),              // 7: haproxy.v1.Transaction
+}
+var file_maintenance_proto_depIdxs = []int32{
+	0, // 0: haproxy.v1.EnterMaintenanceResponse.entries:type_name -> haproxy.v1.MaintenanceEntry
+	7, // 1: haproxy.v1.EnterMaintenanceResponse.transaction:type_name -> haproxy.v1.Transaction
+	0, // 2: haproxy.v1.ExitMaintenanceResponse.entries:type_name -> haproxy.v1.MaintenanceEntry
+	7, // 3: haproxy.v1.ExitMaintenanceResponse.transaction:type_name -> haproxy.v1.Transaction
+	0, // 4: haproxy.v1.ListMaintenanceResponse.entries:type_name -> haproxy.v1.MaintenanceEntry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_maintenance_proto_init() }
+func file_maintenance_proto_init() {
+	if File_maintenance_proto != nil {
+		return
+	}
+	file_transaction_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_maintenance_proto_rawDesc), len(file_maintenance_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_maintenance_proto_goTypes,
+		DependencyIndexes: file_maintenance_proto_depIdxs,
+		MessageInfos:      file_maintenance_proto_msgTypes,
+	}.Build()
+	File_maintenance_proto = out.File
+	file_maintenance_proto_goTypes = nil
+	file_maintenance_proto_depIdxs = nil
+}
@@ -0,0 +1,733 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: netplan.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NetplanChange is a VIP assignment or removal applied to Netplan when its transaction is committed
+type NetplanChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"` // "add" or "remove"
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Interface     string                 `protobuf:"bytes,3,opt,name=interface,proto3" json:"interface,omitempty"`
+	SubnetMask    string                 `protobuf:"bytes,4,opt,name=subnet_mask,json=subnetMask,proto3" json:"subnet_mask,omitempty"`
+	Port          int32                  `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"` // Port of the bind the VIP belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetplanChange) Reset() {
+	*x = NetplanChange{}
+	mi := &file_netplan_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetplanChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetplanChange) ProtoMessage() {}
+
+func (x *NetplanChange) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetplanChange.ProtoReflect.Descriptor instead.
+func (*NetplanChange) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NetplanChange) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *NetplanChange) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *NetplanChange) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *NetplanChange) GetSubnetMask() string {
+	if x != nil {
+		return x.SubnetMask
+	}
+	return ""
+}
+
+func (x *NetplanChange) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+// NetplanTransaction is the Netplan side of an HAProxy transaction
+type NetplanTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pending", "failed" or "committed"
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Changes       []*NetplanChange       `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                          // Why the commit failed
+	FailedChange  *int32                 `protobuf:"varint,6,opt,name=failed_change,json=failedChange,proto3,oneof" json:"failed_change,omitempty"` // Index of the change that could not be applied, unset if the commit failed as a whole
+	FailedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	CommittedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=committed_at,json=committedAt,proto3" json:"committed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetplanTransaction) Reset() {
+	*x = NetplanTransaction{}
+	mi := &file_netplan_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetplanTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetplanTransaction) ProtoMessage() {}
+
+func (x *NetplanTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetplanTransaction.ProtoReflect.Descriptor instead.
+func (*NetplanTransaction) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NetplanTransaction) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *NetplanTransaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NetplanTransaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NetplanTransaction) GetChanges() []*NetplanChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *NetplanTransaction) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NetplanTransaction) GetFailedChange() int32 {
+	if x != nil && x.FailedChange != nil {
+		return *x.FailedChange
+	}
+	return 0
+}
+
+func (x *NetplanTransaction) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *NetplanTransaction) GetCommittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommittedAt
+	}
+	return nil
+}
+
+// TrackedAddress is a VIP assigned through Netplan
+type TrackedAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IpAddress     string                 `protobuf:"bytes,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Interface     string                 `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`                              // Interface the VIP was assigned to
+	Configured    bool                   `protobuf:"varint,3,opt,name=configured,proto3" json:"configured,omitempty"`                           // Whether the VIP is configured on the host
+	HostInterface string                 `protobuf:"bytes,4,opt,name=host_interface,json=hostInterface,proto3" json:"host_interface,omitempty"` // Interface the VIP is configured on, empty if it is missing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackedAddress) Reset() {
+	*x = TrackedAddress{}
+	mi := &file_netplan_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackedAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackedAddress) ProtoMessage() {}
+
+func (x *TrackedAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackedAddress.ProtoReflect.Descriptor instead.
+func (*TrackedAddress) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrackedAddress) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *TrackedAddress) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *TrackedAddress) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+func (x *TrackedAddress) GetHostInterface() string {
+	if x != nil {
+		return x.HostInterface
+	}
+	return ""
+}
+
+type GetNetplanStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetplanStatusRequest) Reset() {
+	*x = GetNetplanStatusRequest{}
+	mi := &file_netplan_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetplanStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetplanStatusRequest) ProtoMessage() {}
+
+func (x *GetNetplanStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetplanStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetNetplanStatusRequest) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{3}
+}
+
+// GetNetplanStatusResponse reports the VIPs managed through Netplan and the transactions not yet committed
+type GetNetplanStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // False if Netplan integration is disabled or the instance is not on this host
+	ConfigPath    string                 `protobuf:"bytes,2,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	Addresses     []*TrackedAddress      `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Transactions  []*NetplanTransaction  `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetplanStatusResponse) Reset() {
+	*x = GetNetplanStatusResponse{}
+	mi := &file_netplan_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetplanStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetplanStatusResponse) ProtoMessage() {}
+
+func (x *GetNetplanStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetplanStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetNetplanStatusResponse) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetNetplanStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetNetplanStatusResponse) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+func (x *GetNetplanStatusResponse) GetAddresses() []*TrackedAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GetNetplanStatusResponse) GetTransactions() []*NetplanTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type GetNetplanTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetplanTransactionRequest) Reset() {
+	*x = GetNetplanTransactionRequest{}
+	mi := &file_netplan_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetplanTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetplanTransactionRequest) ProtoMessage() {}
+
+func (x *GetNetplanTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetplanTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetNetplanTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetNetplanTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type GetNetplanTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *NetplanTransaction    `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetplanTransactionResponse) Reset() {
+	*x = GetNetplanTransactionResponse{}
+	mi := &file_netplan_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetplanTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetplanTransactionResponse) ProtoMessage() {}
+
+func (x *GetNetplanTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetplanTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetNetplanTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetNetplanTransactionResponse) GetTransaction() *NetplanTransaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// OrphanedAddress is a VIP in the Netplan configuration that no bind listens on anymore
+type OrphanedAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IpAddress     string                 `protobuf:"bytes,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Interface     string                 `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"` // Interface mapping the VIP is assigned to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrphanedAddress) Reset() {
+	*x = OrphanedAddress{}
+	mi := &file_netplan_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrphanedAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanedAddress) ProtoMessage() {}
+
+func (x *OrphanedAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrphanedAddress.ProtoReflect.Descriptor instead.
+func (*OrphanedAddress) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrphanedAddress) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *OrphanedAddress) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+type CleanupOrphanedAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Remove        bool                   `protobuf:"varint,1,opt,name=remove,proto3" json:"remove,omitempty"` // Remove the orphans from the Netplan configuration; otherwise they are only reported
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupOrphanedAddressesRequest) Reset() {
+	*x = CleanupOrphanedAddressesRequest{}
+	mi := &file_netplan_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupOrphanedAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupOrphanedAddressesRequest) ProtoMessage() {}
+
+func (x *CleanupOrphanedAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupOrphanedAddressesRequest.ProtoReflect.Descriptor instead.
+func (*CleanupOrphanedAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CleanupOrphanedAddressesRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type CleanupOrphanedAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orphans       []*OrphanedAddress     `protobuf:"bytes,1,rep,name=orphans,proto3" json:"orphans,omitempty"`
+	Removed       bool                   `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"` // Whether the orphans were removed and Netplan applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupOrphanedAddressesResponse) Reset() {
+	*x = CleanupOrphanedAddressesResponse{}
+	mi := &file_netplan_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupOrphanedAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupOrphanedAddressesResponse) ProtoMessage() {}
+
+func (x *CleanupOrphanedAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_netplan_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupOrphanedAddressesResponse.ProtoReflect.Descriptor instead.
+func (*CleanupOrphanedAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_netplan_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CleanupOrphanedAddressesResponse) GetOrphans() []*OrphanedAddress {
+	if x != nil {
+		return x.Orphans
+	}
+	return nil
+}
+
+func (x *CleanupOrphanedAddressesResponse) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+var File_netplan_proto protoreflect.FileDescriptor
+
+const file_netplan_proto_rawDesc = "" +
+	"\n" +
+	"\rnetplan.proto\x12\n" +
+	"haproxy.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x01\n" +
+	"\rNetplanChange\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12\x1c\n" +
+	"\tinterface\x18\x03 \x01(\tR\tinterface\x12\x1f\n" +
+	"\vsubnet_mask\x18\x04 \x01(\tR\n" +
+	"subnetMask\x12\x12\n" +
+	"\x04port\x18\x05 \x01(\x05R\x04port\"\x8d\x03\n" +
+	"\x12NetplanTransaction\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\achanges\x18\x04 \x03(\v2\x19.haproxy.v1.NetplanChangeR\achanges\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12(\n" +
+	"\rfailed_change\x18\x06 \x01(\x05H\x00R\ffailedChange\x88\x01\x01\x127\n" +
+	"\tfailed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12=\n" +
+	"\fcommitted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcommittedAtB\x10\n" +
+	"\x0e_failed_change\"\x94\x01\n" +
+	"\x0eTrackedAddress\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x01 \x01(\tR\tipAddress\x12\x1c\n" +
+	"\tinterface\x18\x02 \x01(\tR\tinterface\x12\x1e\n" +
+	"\n" +
+	"configured\x18\x03 \x01(\bR\n" +
+	"configured\x12%\n" +
+	"\x0ehost_interface\x18\x04 \x01(\tR\rhostInterface\"\x19\n" +
+	"\x17GetNetplanStatusRequest\"\xd3\x01\n" +
+	"\x18GetNetplanStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1f\n" +
+	"\vconfig_path\x18\x02 \x01(\tR\n" +
+	"configPath\x128\n" +
+	"\taddresses\x18\x03 \x03(\v2\x1a.haproxy.v1.TrackedAddressR\taddresses\x12B\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x1e.haproxy.v1.NetplanTransactionR\ftransactions\"E\n" +
+	"\x1cGetNetplanTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"a\n" +
+	"\x1dGetNetplanTransactionResponse\x12@\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1e.haproxy.v1.NetplanTransactionR\vtransaction\"N\n" +
+	"\x0fOrphanedAddress\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x01 \x01(\tR\tipAddress\x12\x1c\n" +
+	"\tinterface\x18\x02 \x01(\tR\tinterface\"9\n" +
+	"\x1fCleanupOrphanedAddressesRequest\x12\x16\n" +
+	"\x06remove\x18\x01 \x01(\bR\x06remove\"s\n" +
+	" CleanupOrphanedAddressesResponse\x125\n" +
+	"\aorphans\x18\x01 \x03(\v2\x1b.haproxy.v1.OrphanedAddressR\aorphans\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\bR\aremovedB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_netplan_proto_rawDescOnce sync.Once
+	file_netplan_proto_rawDescData []byte
+)
+
+func file_netplan_proto_rawDescGZIP() []byte {
+	file_netplan_proto_rawDescOnce.Do(func() {
+		file_netplan_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_netplan_proto_rawDesc), len(file_netplan_proto_rawDesc)))
+	})
+	return file_netplan_proto_rawDescData
+}
+
+var file_netplan_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_netplan_proto_goTypes = []any{
+	(*NetplanChange)(nil),                    // 0: haproxy.v1.NetplanChange
+	(*NetplanTransaction)(nil),               // 1: haproxy.v1.NetplanTransaction
+	(*TrackedAddress)(nil),                   // 2: haproxy.v1.TrackedAddress
+	(*GetNetplanStatusRequest)(nil),          // 3: haproxy.v1.GetNetplanStatusRequest
+	(*GetNetplanStatusResponse)(nil),         // 4: haproxy.v1.GetNetplanStatusResponse
+	(*GetNetplanTransactionRequest)(nil),     // 5: haproxy.v1.GetNetplanTransactionRequest
+	(*GetNetplanTransactionResponse)(nil),    // 6: haproxy.v1.GetNetplanTransactionResponse
+	(*OrphanedAddress)(nil),                  // 7: haproxy.v1.OrphanedAddress
+	(*CleanupOrphanedAddressesRequest)(nil),  // 8: haproxy.v1.CleanupOrphanedAddressesRequest
+	(*CleanupOrphanedAddressesResponse)(nil), // 9: haproxy.v1.CleanupOrphanedAddressesResponse
+	(*timestamppb.Timestamp)(nil),            // 10: google.protobuf.Timestamp
+}
+var file_netplan_proto_depIdxs = []int32{
+	10, // 0: haproxy.v1.NetplanTransaction.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: haproxy.v1.NetplanTransaction.changes:type_name -> haproxy.v1.NetplanChange
+	10, // 2: haproxy.v1.NetplanTransaction.failed_at:type_name -> google.protobuf.Timestamp
+	10, // 3: haproxy.v1.NetplanTransaction.committed_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: haproxy.v1.GetNetplanStatusResponse.addresses:type_name -> haproxy.v1.TrackedAddress
+	1,  // 5: haproxy.v1.GetNetplanStatusResponse.transactions:type_name -> haproxy.v1.NetplanTransaction
+	1,  // 6: haproxy.v1.GetNetplanTransactionResponse.transaction:type_name -> haproxy.v1.NetplanTransaction
+	7,  // 7: haproxy.v1.CleanupOrphanedAddressesResponse.orphans:type_name -> haproxy.v1.OrphanedAddress
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_netplan_proto_init() }
+func file_netplan_proto_init() {
+	if File_netplan_proto != nil {
+		return
+	}
+	file_netplan_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_netplan_proto_rawDesc), len(file_netplan_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_netplan_proto_goTypes,
+		DependencyIndexes: file_netplan_proto_depIdxs,
+		MessageInfos:      file_netplan_proto_msgTypes,
+	}.Build()
+	File_netplan_proto = out.File
+	file_netplan_proto_goTypes = nil
+	file_netplan_proto_depIdxs = nil
+}
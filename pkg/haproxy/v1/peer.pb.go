@@ -0,0 +1,433 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: peer.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPeerStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterEventId  uint64                 `protobuf:"varint,1,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"` // Only return journal events with a larger ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerStateRequest) Reset() {
+	*x = GetPeerStateRequest{}
+	mi := &file_peer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerStateRequest) ProtoMessage() {}
+
+func (x *GetPeerStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerStateRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerStateRequest) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPeerStateRequest) GetAfterEventId() uint64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+// GetPeerStateResponse is the state a standby copies from the leader, so that it knows which VIPs it owns
+// after a failover
+type GetPeerStateResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Identity            string                 `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"` // Leader election identity of the peer
+	Leader              bool                   `protobuf:"varint,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	NetplanEnabled      bool                   `protobuf:"varint,3,opt,name=netplan_enabled,json=netplanEnabled,proto3" json:"netplan_enabled,omitempty"`
+	TrackedAddresses    map[string]string      `protobuf:"bytes,4,rep,name=tracked_addresses,json=trackedAddresses,proto3" json:"tracked_addresses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Netplan-managed VIP -> interface
+	NetplanTransactions []*NetplanTransaction  `protobuf:"bytes,5,rep,name=netplan_transactions,json=netplanTransactions,proto3" json:"netplan_transactions,omitempty"`                                                                  // Transactions not yet committed
+	Events              []*Event               `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`                                                                                                                       // Journal events after after_event_id, oldest first
+	MoreEvents          bool                   `protobuf:"varint,7,opt,name=more_events,json=moreEvents,proto3" json:"more_events,omitempty"`                                                                                            // Whether further events are left for the next request
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetPeerStateResponse) Reset() {
+	*x = GetPeerStateResponse{}
+	mi := &file_peer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerStateResponse) ProtoMessage() {}
+
+func (x *GetPeerStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerStateResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerStateResponse) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPeerStateResponse) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *GetPeerStateResponse) GetLeader() bool {
+	if x != nil {
+		return x.Leader
+	}
+	return false
+}
+
+func (x *GetPeerStateResponse) GetNetplanEnabled() bool {
+	if x != nil {
+		return x.NetplanEnabled
+	}
+	return false
+}
+
+func (x *GetPeerStateResponse) GetTrackedAddresses() map[string]string {
+	if x != nil {
+		return x.TrackedAddresses
+	}
+	return nil
+}
+
+func (x *GetPeerStateResponse) GetNetplanTransactions() []*NetplanTransaction {
+	if x != nil {
+		return x.NetplanTransactions
+	}
+	return nil
+}
+
+func (x *GetPeerStateResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetPeerStateResponse) GetMoreEvents() bool {
+	if x != nil {
+		return x.MoreEvents
+	}
+	return false
+}
+
+// PeerStatus is the outcome of the last synchronization with a peer
+type PeerStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Identity      string                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Leader        bool                   `protobuf:"varint,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	LastSyncTime  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"` // Last successful synchronization
+	LastError     string                 `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastEventId   uint64                 `protobuf:"varint,6,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"` // Last journal event imported from the peer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStatus) Reset() {
+	*x = PeerStatus{}
+	mi := &file_peer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatus) ProtoMessage() {}
+
+func (x *PeerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatus.ProtoReflect.Descriptor instead.
+func (*PeerStatus) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PeerStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetLeader() bool {
+	if x != nil {
+		return x.Leader
+	}
+	return false
+}
+
+func (x *PeerStatus) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+func (x *PeerStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type GetPeerSyncStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerSyncStatusRequest) Reset() {
+	*x = GetPeerSyncStatusRequest{}
+	mi := &file_peer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerSyncStatusRequest) ProtoMessage() {}
+
+func (x *GetPeerSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{3}
+}
+
+// GetPeerSyncStatusResponse reports the synchronization with every peer
+type GetPeerSyncStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Peers         []*PeerStatus          `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerSyncStatusResponse) Reset() {
+	*x = GetPeerSyncStatusResponse{}
+	mi := &file_peer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerSyncStatusResponse) ProtoMessage() {}
+
+func (x *GetPeerSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_peer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPeerSyncStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetPeerSyncStatusResponse) GetPeers() []*PeerStatus {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+var File_peer_proto protoreflect.FileDescriptor
+
+const file_peer_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"peer.proto\x12\n" +
+	"haproxy.v1\x1a\vevent.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\rnetplan.proto\";\n" +
+	"\x13GetPeerStateRequest\x12$\n" +
+	"\x0eafter_event_id\x18\x01 \x01(\x04R\fafterEventId\"\xbc\x03\n" +
+	"\x14GetPeerStateResponse\x12\x1a\n" +
+	"\bidentity\x18\x01 \x01(\tR\bidentity\x12\x16\n" +
+	"\x06leader\x18\x02 \x01(\bR\x06leader\x12'\n" +
+	"\x0fnetplan_enabled\x18\x03 \x01(\bR\x0enetplanEnabled\x12c\n" +
+	"\x11tracked_addresses\x18\x04 \x03(\v26.haproxy.v1.GetPeerStateResponse.TrackedAddressesEntryR\x10trackedAddresses\x12Q\n" +
+	"\x14netplan_transactions\x18\x05 \x03(\v2\x1e.haproxy.v1.NetplanTransactionR\x13netplanTransactions\x12)\n" +
+	"\x06events\x18\x06 \x03(\v2\x11.haproxy.v1.EventR\x06events\x12\x1f\n" +
+	"\vmore_events\x18\a \x01(\bR\n" +
+	"moreEvents\x1aC\n" +
+	"\x15TrackedAddressesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdf\x01\n" +
+	"\n" +
+	"PeerStatus\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\x12\x16\n" +
+	"\x06leader\x18\x03 \x01(\bR\x06leader\x12@\n" +
+	"\x0elast_sync_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\x12\"\n" +
+	"\rlast_event_id\x18\x06 \x01(\x04R\vlastEventId\"\x1a\n" +
+	"\x18GetPeerSyncStatusRequest\"c\n" +
+	"\x19GetPeerSyncStatusResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12,\n" +
+	"\x05peers\x18\x02 \x03(\v2\x16.haproxy.v1.PeerStatusR\x05peersB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_peer_proto_rawDescOnce sync.Once
+	file_peer_proto_rawDescData []byte
+)
+
+func file_peer_proto_rawDescGZIP() []byte {
+	file_peer_proto_rawDescOnce.Do(func() {
+		file_peer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_peer_proto_rawDesc), len(file_peer_proto_rawDesc)))
+	})
+	return file_peer_proto_rawDescData
+}
+
+var file_peer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_peer_proto_goTypes = []any{
+	(*GetPeerStateRequest)(nil),       // 0: haproxy.v1.GetPeerStateRequest
+	(*GetPeerStateResponse)(nil),      // 1: haproxy.v1.GetPeerStateResponse
+	(*PeerStatus)(nil),                // 2: haproxy.v1.PeerStatus
+	(*GetPeerSyncStatusRequest)(nil),  // 3: haproxy.v1.GetPeerSyncStatusRequest
+	(*GetPeerSyncStatusResponse)(nil), // 4: haproxy.v1.GetPeerSyncStatusResponse
+	nil,                               // 5: haproxy.v1.GetPeerStateResponse.TrackedAddressesEntry
+	(*NetplanTransaction)(nil),        // 6: haproxy.v1.NetplanTransaction
+	(*Event)(nil),                     // 7: haproxy.v1.Event
+	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
+}
+var file_peer_proto_depIdxs = []int32{
+	5, // 0: haproxy.v1.GetPeerStateResponse.tracked_addresses:type_name -> haproxy.v1.GetPeerStateResponse.TrackedAddressesEntry
+	6, // 1: haproxy.v1.GetPeerStateResponse.netplan_transactions:type_name -> haproxy.v1.NetplanTransaction
+	7, // 2: haproxy.v1.GetPeerStateResponse.events:type_name -> haproxy.v1.Event
+	8, // 3: haproxy.v1.PeerStatus.last_sync_time:type_name -> google.protobuf.Timestamp
+	2, // 4: haproxy.v1.GetPeerSyncStatusResponse.peers:type_name -> haproxy.v1.PeerStatus
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_peer_proto_init() }
+func file_peer_proto_init() {
+	if File_peer_proto != nil {
+		return
+	}
+	file_event_proto_init()
+	file_netplan_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_peer_proto_rawDesc), len(file_peer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_peer_proto_goTypes,
+		DependencyIndexes: file_peer_proto_depIdxs,
+		MessageInfos:      file_peer_proto_msgTypes,
+	}.Build()
+	File_peer_proto = out.File
+	file_peer_proto_goTypes = nil
+	file_peer_proto_depIdxs = nil
+}
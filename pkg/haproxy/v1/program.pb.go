@@ -0,0 +1,722 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: program.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProgramRestartPolicy defines what happens to a program when HAProxy reloads
+type ProgramRestartPolicy int32
+
+const (
+	ProgramRestartPolicy_PROGRAM_RESTART_POLICY_UNSPECIFIED  ProgramRestartPolicy = 0 // Same as PROGRAM_RESTART_POLICY_ON_RELOAD
+	ProgramRestartPolicy_PROGRAM_RESTART_POLICY_ON_RELOAD    ProgramRestartPolicy = 1 // Stop the program and start a new instance on every reload
+	ProgramRestartPolicy_PROGRAM_RESTART_POLICY_KEEP_RUNNING ProgramRestartPolicy = 2 // Keep the running instance across reloads
+)
+
+// Enum value maps for ProgramRestartPolicy.
+var (
+	ProgramRestartPolicy_name = map[int32]string{
+		0: "PROGRAM_RESTART_POLICY_UNSPECIFIED",
+		1: "PROGRAM_RESTART_POLICY_ON_RELOAD",
+		2: "PROGRAM_RESTART_POLICY_KEEP_RUNNING",
+	}
+	ProgramRestartPolicy_value = map[string]int32{
+		"PROGRAM_RESTART_POLICY_UNSPECIFIED":  0,
+		"PROGRAM_RESTART_POLICY_ON_RELOAD":    1,
+		"PROGRAM_RESTART_POLICY_KEEP_RUNNING": 2,
+	}
+)
+
+func (x ProgramRestartPolicy) Enum() *ProgramRestartPolicy {
+	p := new(ProgramRestartPolicy)
+	*p = x
+	return p
+}
+
+func (x ProgramRestartPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProgramRestartPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_program_proto_enumTypes[0].Descriptor()
+}
+
+func (ProgramRestartPolicy) Type() protoreflect.EnumType {
+	return &file_program_proto_enumTypes[0]
+}
+
+func (x ProgramRestartPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProgramRestartPolicy.Descriptor instead.
+func (ProgramRestartPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{0}
+}
+
+// Program is a program section: an external process, such as an agent or exporter, started and supervised by
+// the HAProxy master. HAProxy runs programs only in master-worker mode.
+type Program struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Required: letters, digits, ".", "-" and "_"
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"` // Required: the executable and its arguments, as written after "command" in haproxy.cfg
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`       // User the program runs as; that of the master if unset
+	Group         string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`     // Group the program runs as; that of the master if unset
+	RestartPolicy ProgramRestartPolicy   `protobuf:"varint,5,opt,name=restart_policy,json=restartPolicy,proto3,enum=haproxy.v1.ProgramRestartPolicy" json:"restart_policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	mi := &file_program_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Program) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Program) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Program) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Program) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Program) GetRestartPolicy() ProgramRestartPolicy {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return ProgramRestartPolicy_PROGRAM_RESTART_POLICY_UNSPECIFIED
+}
+
+type CreateProgramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Program       *Program               `protobuf:"bytes,2,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProgramRequest) Reset() {
+	*x = CreateProgramRequest{}
+	mi := &file_program_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProgramRequest) ProtoMessage() {}
+
+func (x *CreateProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProgramRequest.ProtoReflect.Descriptor instead.
+func (*CreateProgramRequest) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateProgramRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateProgramRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type CreateProgramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProgramResponse) Reset() {
+	*x = CreateProgramResponse{}
+	mi := &file_program_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProgramResponse) ProtoMessage() {}
+
+func (x *CreateProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProgramResponse.ProtoReflect.Descriptor instead.
+func (*CreateProgramResponse) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateProgramResponse) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type GetProgramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProgramRequest) Reset() {
+	*x = GetProgramRequest{}
+	mi := &file_program_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProgramRequest) ProtoMessage() {}
+
+func (x *GetProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProgramRequest.ProtoReflect.Descriptor instead.
+func (*GetProgramRequest) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProgramRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetProgramRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetProgramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProgramResponse) Reset() {
+	*x = GetProgramResponse{}
+	mi := &file_program_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProgramResponse) ProtoMessage() {}
+
+func (x *GetProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProgramResponse.ProtoReflect.Descriptor instead.
+func (*GetProgramResponse) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProgramResponse) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type ListProgramsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProgramsRequest) Reset() {
+	*x = ListProgramsRequest{}
+	mi := &file_program_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProgramsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProgramsRequest) ProtoMessage() {}
+
+func (x *ListProgramsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProgramsRequest.ProtoReflect.Descriptor instead.
+func (*ListProgramsRequest) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProgramsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ListProgramsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Programs      []*Program             `protobuf:"bytes,1,rep,name=programs,proto3" json:"programs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProgramsResponse) Reset() {
+	*x = ListProgramsResponse{}
+	mi := &file_program_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProgramsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProgramsResponse) ProtoMessage() {}
+
+func (x *ListProgramsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProgramsResponse.ProtoReflect.Descriptor instead.
+func (*ListProgramsResponse) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProgramsResponse) GetPrograms() []*Program {
+	if x != nil {
+		return x.Programs
+	}
+	return nil
+}
+
+type UpdateProgramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Program       *Program               `protobuf:"bytes,2,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProgramRequest) Reset() {
+	*x = UpdateProgramRequest{}
+	mi := &file_program_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProgramRequest) ProtoMessage() {}
+
+func (x *UpdateProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProgramRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProgramRequest) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateProgramRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *UpdateProgramRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type UpdateProgramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProgramResponse) Reset() {
+	*x = UpdateProgramResponse{}
+	mi := &file_program_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProgramResponse) ProtoMessage() {}
+
+func (x *UpdateProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProgramResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProgramResponse) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateProgramResponse) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type DeleteProgramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProgramRequest) Reset() {
+	*x = DeleteProgramRequest{}
+	mi := &file_program_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProgramRequest) ProtoMessage() {}
+
+func (x *DeleteProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProgramRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProgramRequest) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteProgramRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteProgramRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteProgramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProgramResponse) Reset() {
+	*x = DeleteProgramResponse{}
+	mi := &file_program_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProgramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProgramResponse) ProtoMessage() {}
+
+func (x *DeleteProgramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_program_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProgramResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProgramResponse) Descriptor() ([]byte, []int) {
+	return file_program_proto_rawDescGZIP(), []int{10}
+}
+
+var File_program_proto protoreflect.FileDescriptor
+
+const file_program_proto_rawDesc = "" +
+	"\n" +
+	"\rprogram.proto\x12\n" +
+	"haproxy.v1\"\xaa\x01\n" +
+	"\aProgram\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\x12G\n" +
+	"\x0erestart_policy\x18\x05 \x01(\x0e2 .haproxy.v1.ProgramRestartPolicyR\rrestartPolicy\"l\n" +
+	"\x14CreateProgramRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12-\n" +
+	"\aprogram\x18\x02 \x01(\v2\x13.haproxy.v1.ProgramR\aprogram\"F\n" +
+	"\x15CreateProgramResponse\x12-\n" +
+	"\aprogram\x18\x01 \x01(\v2\x13.haproxy.v1.ProgramR\aprogram\"N\n" +
+	"\x11GetProgramRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"C\n" +
+	"\x12GetProgramResponse\x12-\n" +
+	"\aprogram\x18\x01 \x01(\v2\x13.haproxy.v1.ProgramR\aprogram\"<\n" +
+	"\x13ListProgramsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"G\n" +
+	"\x14ListProgramsResponse\x12/\n" +
+	"\bprograms\x18\x01 \x03(\v2\x13.haproxy.v1.ProgramR\bprograms\"l\n" +
+	"\x14UpdateProgramRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12-\n" +
+	"\aprogram\x18\x02 \x01(\v2\x13.haproxy.v1.ProgramR\aprogram\"F\n" +
+	"\x15UpdateProgramResponse\x12-\n" +
+	"\aprogram\x18\x01 \x01(\v2\x13.haproxy.v1.ProgramR\aprogram\"Q\n" +
+	"\x14DeleteProgramRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x17\n" +
+	"\x15DeleteProgramResponse*\x8d\x01\n" +
+	"\x14ProgramRestartPolicy\x12&\n" +
+	"\"PROGRAM_RESTART_POLICY_UNSPECIFIED\x10\x00\x12$\n" +
+	" PROGRAM_RESTART_POLICY_ON_RELOAD\x10\x01\x12'\n" +
+	"#PROGRAM_RESTART_POLICY_KEEP_RUNNING\x10\x02B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_program_proto_rawDescOnce sync.Once
+	file_program_proto_rawDescData []byte
+)
+
+func file_program_proto_rawDescGZIP() []byte {
+	file_program_proto_rawDescOnce.Do(func() {
+		file_program_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_program_proto_rawDesc), len(file_program_proto_rawDesc)))
+	})
+	return file_program_proto_rawDescData
+}
+
+var file_program_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_program_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_program_proto_goTypes = []any{
+	(ProgramRestartPolicy)(0),     // 0: haproxy.v1.ProgramRestartPolicy
+	(*Program)(nil),               // 1: haproxy.v1.Program
+	(*CreateProgramRequest)(nil),  // 2: haproxy.v1.CreateProgramRequest
+	(*CreateProgramResponse)(nil), // 3: haproxy.v1.CreateProgramResponse
+	(*GetProgramRequest)(nil),     // 4: haproxy.v1.GetProgramRequest
+	(*GetProgramResponse)(nil),    // 5: haproxy.v1.GetProgramResponse
+	(*ListProgramsRequest)(nil),   // 6: haproxy.v1.ListProgramsRequest
+	(*ListProgramsResponse)(nil),  // 7: haproxy.v1.ListProgramsResponse
+	(*UpdateProgramRequest)(nil),  // 8: haproxy.v1.UpdateProgramRequest
+	(*UpdateProgramResponse)(nil), // 9: haproxy.v1.UpdateProgramResponse
+	(*DeleteProgramRequest)(nil),  // 10: haproxy.v1.DeleteProgramRequest
+	(*DeleteProgramResponse)(nil), // 11: haproxy.v1.DeleteProgramResponse
+}
+var file_program_proto_depIdxs = []int32{
+	0, // 0: haproxy.v1.Program.restart_policy:type_name -> haproxy.v1.ProgramRestartPolicy
+	1, // 1: haproxy.v1.CreateProgramRequest.program:type_name -> haproxy.v1.Program
+	1, // 2: haproxy.v1.CreateProgramResponse.program:type_name -> haproxy.v1.Program
+	1, // 3: haproxy.v1.GetProgramResponse.program:type_name -> haproxy.v1.Program
+	1, // 4: haproxy.v1.ListProgramsResponse.programs:type_name -> haproxy.v1.Program
+	1, // 5: haproxy.v1.UpdateProgramRequest.program:type_name -> haproxy.v1.Program
+	1, // 6: haproxy.v1.UpdateProgramResponse.program:type_name -> haproxy.v1.Program
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_program_proto_init() }
+func file_program_proto_init() {
+	if File_program_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_program_proto_rawDesc), len(file_program_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_program_proto_goTypes,
+		DependencyIndexes: file_program_proto_depIdxs,
+		EnumInfos:         file_program_proto_enumTypes,
+		MessageInfos:      file_program_proto_msgTypes,
+	}.Build()
+	File_program_proto = out.File
+	file_program_proto_goTypes = nil
+	file_program_proto_depIdxs = nil
+}
@@ -0,0 +1,871 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: ratelimit.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RateLimitKey defines what requests are counted by
+type RateLimitKey int32
+
+const (
+	RateLimitKey_RATE_LIMIT_KEY_UNSPECIFIED RateLimitKey = 0 // Same as RATE_LIMIT_KEY_SOURCE_IP
+	RateLimitKey_RATE_LIMIT_KEY_SOURCE_IP   RateLimitKey = 1 // Client address
+	RateLimitKey_RATE_LIMIT_KEY_HEADER      RateLimitKey = 2 // Value of a request header; HTTP frontends only
+)
+
+// Enum value maps for RateLimitKey.
+var (
+	RateLimitKey_name = map[int32]string{
+		0: "RATE_LIMIT_KEY_UNSPECIFIED",
+		1: "RATE_LIMIT_KEY_SOURCE_IP",
+		2: "RATE_LIMIT_KEY_HEADER",
+	}
+	RateLimitKey_value = map[string]int32{
+		"RATE_LIMIT_KEY_UNSPECIFIED": 0,
+		"RATE_LIMIT_KEY_SOURCE_IP":   1,
+		"RATE_LIMIT_KEY_HEADER":      2,
+	}
+)
+
+func (x RateLimitKey) Enum() *RateLimitKey {
+	p := new(RateLimitKey)
+	*p = x
+	return p
+}
+
+func (x RateLimitKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RateLimitKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_ratelimit_proto_enumTypes[0].Descriptor()
+}
+
+func (RateLimitKey) Type() protoreflect.EnumType {
+	return &file_ratelimit_proto_enumTypes[0]
+}
+
+func (x RateLimitKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RateLimitKey.Descriptor instead.
+func (RateLimitKey) EnumDescriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{0}
+}
+
+// RateLimitAction defines what happens to requests beyond the limit
+type RateLimitAction int32
+
+const (
+	RateLimitAction_RATE_LIMIT_ACTION_UNSPECIFIED RateLimitAction = 0 // Same as RATE_LIMIT_ACTION_DENY
+	RateLimitAction_RATE_LIMIT_ACTION_DENY        RateLimitAction = 1 // Respond with deny_status; TCP frontends reject the connection
+	RateLimitAction_RATE_LIMIT_ACTION_TARPIT      RateLimitAction = 2 // Hold the request for the tarpit timeout before denying it; HTTP frontends only
+)
+
+// Enum value maps for RateLimitAction.
+var (
+	RateLimitAction_name = map[int32]string{
+		0: "RATE_LIMIT_ACTION_UNSPECIFIED",
+		1: "RATE_LIMIT_ACTION_DENY",
+		2: "RATE_LIMIT_ACTION_TARPIT",
+	}
+	RateLimitAction_value = map[string]int32{
+		"RATE_LIMIT_ACTION_UNSPECIFIED": 0,
+		"RATE_LIMIT_ACTION_DENY":        1,
+		"RATE_LIMIT_ACTION_TARPIT":      2,
+	}
+)
+
+func (x RateLimitAction) Enum() *RateLimitAction {
+	p := new(RateLimitAction)
+	*p = x
+	return p
+}
+
+func (x RateLimitAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RateLimitAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_ratelimit_proto_enumTypes[1].Descriptor()
+}
+
+func (RateLimitAction) Type() protoreflect.EnumType {
+	return &file_ratelimit_proto_enumTypes[1]
+}
+
+func (x RateLimitAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RateLimitAction.Descriptor instead.
+func (RateLimitAction) EnumDescriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{1}
+}
+
+// RateLimitPolicy limits the requests of a frontend per client. HTTP frontends count requests, TCP frontends
+// count connections. It is stored as a backend "ratelimit.<frontend>.<name>" holding the stick table and a
+// track-sc and deny rule at the start of the frontend's http-request (or tcp-request connection) rules. A
+// frontend has room for three policies, one per stick counter.
+type RateLimitPolicy struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                         // Required: letters, digits, "-" and "_"
+	Requests        int64                  `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`                                // Required: requests allowed per period
+	PeriodSeconds   int32                  `protobuf:"varint,3,opt,name=period_seconds,json=periodSeconds,proto3" json:"period_seconds,omitempty"` // Required
+	Key             RateLimitKey           `protobuf:"varint,4,opt,name=key,proto3,enum=haproxy.v1.RateLimitKey" json:"key,omitempty"`
+	Header          string                 `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"` // Required with RATE_LIMIT_KEY_HEADER
+	Action          RateLimitAction        `protobuf:"varint,6,opt,name=action,proto3,enum=haproxy.v1.RateLimitAction" json:"action,omitempty"`
+	DenyStatus      int32                  `protobuf:"varint,7,opt,name=deny_status,json=denyStatus,proto3" json:"deny_status,omitempty"`               // HTTP status of denied requests; 429 if unset
+	ResourceVersion string                 `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Changes whenever the resource changes; set in responses only
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RateLimitPolicy) Reset() {
+	*x = RateLimitPolicy{}
+	mi := &file_ratelimit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitPolicy) ProtoMessage() {}
+
+func (x *RateLimitPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitPolicy.ProtoReflect.Descriptor instead.
+func (*RateLimitPolicy) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RateLimitPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RateLimitPolicy) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *RateLimitPolicy) GetPeriodSeconds() int32 {
+	if x != nil {
+		return x.PeriodSeconds
+	}
+	return 0
+}
+
+func (x *RateLimitPolicy) GetKey() RateLimitKey {
+	if x != nil {
+		return x.Key
+	}
+	return RateLimitKey_RATE_LIMIT_KEY_UNSPECIFIED
+}
+
+func (x *RateLimitPolicy) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *RateLimitPolicy) GetAction() RateLimitAction {
+	if x != nil {
+		return x.Action
+	}
+	return RateLimitAction_RATE_LIMIT_ACTION_UNSPECIFIED
+}
+
+func (x *RateLimitPolicy) GetDenyStatus() int32 {
+	if x != nil {
+		return x.DenyStatus
+	}
+	return 0
+}
+
+func (x *RateLimitPolicy) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+type CreateRateLimitPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Policy        *RateLimitPolicy       `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRateLimitPolicyRequest) Reset() {
+	*x = CreateRateLimitPolicyRequest{}
+	mi := &file_ratelimit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRateLimitPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRateLimitPolicyRequest) ProtoMessage() {}
+
+func (x *CreateRateLimitPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRateLimitPolicyRequest.ProtoReflect.Descriptor instead.
+func (*CreateRateLimitPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRateLimitPolicyRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateRateLimitPolicyRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *CreateRateLimitPolicyRequest) GetPolicy() *RateLimitPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type CreateRateLimitPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *RateLimitPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRateLimitPolicyResponse) Reset() {
+	*x = CreateRateLimitPolicyResponse{}
+	mi := &file_ratelimit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRateLimitPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRateLimitPolicyResponse) ProtoMessage() {}
+
+func (x *CreateRateLimitPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRateLimitPolicyResponse.ProtoReflect.Descriptor instead.
+func (*CreateRateLimitPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRateLimitPolicyResponse) GetPolicy() *RateLimitPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type GetRateLimitPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateLimitPolicyRequest) Reset() {
+	*x = GetRateLimitPolicyRequest{}
+	mi := &file_ratelimit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateLimitPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateLimitPolicyRequest) ProtoMessage() {}
+
+func (x *GetRateLimitPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateLimitPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetRateLimitPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRateLimitPolicyRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetRateLimitPolicyRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *GetRateLimitPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetRateLimitPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *RateLimitPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateLimitPolicyResponse) Reset() {
+	*x = GetRateLimitPolicyResponse{}
+	mi := &file_ratelimit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateLimitPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateLimitPolicyResponse) ProtoMessage() {}
+
+func (x *GetRateLimitPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateLimitPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetRateLimitPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRateLimitPolicyResponse) GetPolicy() *RateLimitPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ListRateLimitPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRateLimitPoliciesRequest) Reset() {
+	*x = ListRateLimitPoliciesRequest{}
+	mi := &file_ratelimit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRateLimitPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateLimitPoliciesRequest) ProtoMessage() {}
+
+func (x *ListRateLimitPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRateLimitPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListRateLimitPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRateLimitPoliciesRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ListRateLimitPoliciesRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+type ListRateLimitPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*RateLimitPolicy     `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRateLimitPoliciesResponse) Reset() {
+	*x = ListRateLimitPoliciesResponse{}
+	mi := &file_ratelimit_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRateLimitPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateLimitPoliciesResponse) ProtoMessage() {}
+
+func (x *ListRateLimitPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRateLimitPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListRateLimitPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRateLimitPoliciesResponse) GetPolicies() []*RateLimitPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type UpdateRateLimitPolicyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName    string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Policy          *RateLimitPolicy       `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRateLimitPolicyRequest) Reset() {
+	*x = UpdateRateLimitPolicyRequest{}
+	mi := &file_ratelimit_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRateLimitPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRateLimitPolicyRequest) ProtoMessage() {}
+
+func (x *UpdateRateLimitPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRateLimitPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRateLimitPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateRateLimitPolicyRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *UpdateRateLimitPolicyRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *UpdateRateLimitPolicyRequest) GetPolicy() *RateLimitPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *UpdateRateLimitPolicyRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+type UpdateRateLimitPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *RateLimitPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRateLimitPolicyResponse) Reset() {
+	*x = UpdateRateLimitPolicyResponse{}
+	mi := &file_ratelimit_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRateLimitPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRateLimitPolicyResponse) ProtoMessage() {}
+
+func (x *UpdateRateLimitPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRateLimitPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRateLimitPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRateLimitPolicyResponse) GetPolicy() *RateLimitPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type DeleteRateLimitPolicyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName    string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteRateLimitPolicyRequest) Reset() {
+	*x = DeleteRateLimitPolicyRequest{}
+	mi := &file_ratelimit_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateLimitPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateLimitPolicyRequest) ProtoMessage() {}
+
+func (x *DeleteRateLimitPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateLimitPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRateLimitPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRateLimitPolicyRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteRateLimitPolicyRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *DeleteRateLimitPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteRateLimitPolicyRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+type DeleteRateLimitPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRateLimitPolicyResponse) Reset() {
+	*x = DeleteRateLimitPolicyResponse{}
+	mi := &file_ratelimit_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateLimitPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateLimitPolicyResponse) ProtoMessage() {}
+
+func (x *DeleteRateLimitPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ratelimit_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateLimitPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRateLimitPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_ratelimit_proto_rawDescGZIP(), []int{10}
+}
+
+var File_ratelimit_proto protoreflect.FileDescriptor
+
+const file_ratelimit_proto_rawDesc = "" +
+	"\n" +
+	"\x0fratelimit.proto\x12\n" +
+	"haproxy.v1\"\xad\x02\n" +
+	"\x0fRateLimitPolicy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12%\n" +
+	"\x0eperiod_seconds\x18\x03 \x01(\x05R\rperiodSeconds\x12*\n" +
+	"\x03key\x18\x04 \x01(\x0e2\x18.haproxy.v1.RateLimitKeyR\x03key\x12\x16\n" +
+	"\x06header\x18\x05 \x01(\tR\x06header\x123\n" +
+	"\x06action\x18\x06 \x01(\x0e2\x1b.haproxy.v1.RateLimitActionR\x06action\x12\x1f\n" +
+	"\vdeny_status\x18\a \x01(\x05R\n" +
+	"denyStatus\x12)\n" +
+	"\x10resource_version\x18\b \x01(\tR\x0fresourceVersion\"\x9f\x01\n" +
+	"\x1cCreateRateLimitPolicyRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x123\n" +
+	"\x06policy\x18\x03 \x01(\v2\x1b.haproxy.v1.RateLimitPolicyR\x06policy\"T\n" +
+	"\x1dCreateRateLimitPolicyResponse\x123\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1b.haproxy.v1.RateLimitPolicyR\x06policy\"{\n" +
+	"\x19GetRateLimitPolicyRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"Q\n" +
+	"\x1aGetRateLimitPolicyResponse\x123\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1b.haproxy.v1.RateLimitPolicyR\x06policy\"j\n" +
+	"\x1cListRateLimitPoliciesRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\"X\n" +
+	"\x1dListRateLimitPoliciesResponse\x127\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x1b.haproxy.v1.RateLimitPolicyR\bpolicies\"\xca\x01\n" +
+	"\x1cUpdateRateLimitPolicyRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x123\n" +
+	"\x06policy\x18\x03 \x01(\v2\x1b.haproxy.v1.RateLimitPolicyR\x06policy\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"T\n" +
+	"\x1dUpdateRateLimitPolicyResponse\x123\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1b.haproxy.v1.RateLimitPolicyR\x06policy\"\xa9\x01\n" +
+	"\x1cDeleteRateLimitPolicyRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"\x1f\n" +
+	"\x1dDeleteRateLimitPolicyResponse*g\n" +
+	"\fRateLimitKey\x12\x1e\n" +
+	"\x1aRATE_LIMIT_KEY_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18RATE_LIMIT_KEY_SOURCE_IP\x10\x01\x12\x19\n" +
+	"\x15RATE_LIMIT_KEY_HEADER\x10\x02*n\n" +
+	"\x0fRateLimitAction\x12!\n" +
+	"\x1dRATE_LIMIT_ACTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16RATE_LIMIT_ACTION_DENY\x10\x01\x12\x1c\n" +
+	"\x18RATE_LIMIT_ACTION_TARPIT\x10\x02B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_ratelimit_proto_rawDescOnce sync.Once
+	file_ratelimit_proto_rawDescData []byte
+)
+
+func file_ratelimit_proto_rawDescGZIP() []byte {
+	file_ratelimit_proto_rawDescOnce.Do(func() {
+		file_ratelimit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ratelimit_proto_rawDesc), len(file_ratelimit_proto_rawDesc)))
+	})
+	return file_ratelimit_proto_rawDescData
+}
+
+var file_ratelimit_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ratelimit_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_ratelimit_proto_goTypes = []any{
+	(RateLimitKey)(0),                     // 0: haproxy.v1.RateLimitKey
+	(RateLimitAction)(0),                  // 1: haproxy.v1.RateLimitAction
+	(*RateLimitPolicy)(nil),               // 2: haproxy.v1.RateLimitPolicy
+	(*CreateRateLimitPolicyRequest)(nil),  // 3: haproxy.v1.CreateRateLimitPolicyRequest
+	(*CreateRateLimitPolicyResponse)(nil), // 4: haproxy.v1.CreateRateLimitPolicyResponse
+	(*GetRateLimitPolicyRequest)(nil),     // 5: haproxy.v1.GetRateLimitPolicyRequest
+	(*GetRateLimitPolicyResponse)(nil),    // 6: haproxy.v1.GetRateLimitPolicyResponse
+	(*ListRateLimitPoliciesRequest)(nil),  // 7: haproxy.v1.ListRateLimitPoliciesRequest
+	(*ListRateLimitPoliciesResponse)(nil), // 8: haproxy.v1.ListRateLimitPoliciesResponse
+	(*UpdateRateLimitPolicyRequest)(nil),  // 9: haproxy.v1.UpdateRateLimitPolicyRequest
+	(*UpdateRateLimitPolicyResponse)(nil), // 10: haproxy.v1.UpdateRateLimitPolicyResponse
+	(*DeleteRateLimitPolicyRequest)(nil),  // 11: haproxy.v1.DeleteRateLimitPolicyRequest
+	(*DeleteRateLimitPolicyResponse)(nil), // 12: haproxy.v1.DeleteRateLimitPolicyResponse
+}
+var file_ratelimit_proto_depIdxs = []int32{
+	0, // 0: haproxy.v1.RateLimitPolicy.key:type_name -> haproxy.v1.RateLimitKey
+	1, // 1: haproxy.v1.RateLimitPolicy.action:type_name -> haproxy.v1.RateLimitAction
+	2, // 2: haproxy.v1.CreateRateLimitPolicyRequest.policy:type_name -> haproxy.v1.RateLimitPolicy
+	2, // 3: haproxy.v1.CreateRateLimitPolicyResponse.policy:type_name -> haproxy.v1.RateLimitPolicy
+	2, // 4: haproxy.v1.GetRateLimitPolicyResponse.policy:type_name -> haproxy.v1.RateLimitPolicy
+	2, // 5: haproxy.v1.ListRateLimitPoliciesResponse.policies:type_name -> haproxy.v1.RateLimitPolicy
+	2, // 6: haproxy.v1.UpdateRateLimitPolicyRequest.policy:type_name -> haproxy.v1.RateLimitPolicy
+	2, // 7: haproxy.v1.UpdateRateLimitPolicyResponse.policy:type_name -> haproxy.v1.RateLimitPolicy
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_ratelimit_proto_init() }
+func file_ratelimit_proto_init() {
+	if File_ratelimit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ratelimit_proto_rawDesc), len(file_ratelimit_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ratelimit_proto_goTypes,
+		DependencyIndexes: file_ratelimit_proto_depIdxs,
+		EnumInfos:         file_ratelimit_proto_enumTypes,
+		MessageInfos:      file_ratelimit_proto_msgTypes,
+	}.Build()
+	File_ratelimit_proto = out.File
+	file_ratelimit_proto_goTypes = nil
+	file_ratelimit_proto_depIdxs = nil
+}
@@ -0,0 +1,1213 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: ring.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Ring is a ring section: a buffer in the memory of HAProxy keeping the latest log lines or traces sent to it,
+// which StreamRing reads
+type Ring struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Required: letters, digits, ".", "-" and "_"
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                         // "raw", "rfc3164", "rfc5424", "iso", "timed", "short" or "priority"; rfc3164 if unset
+	MaxLength     int32                  `protobuf:"varint,4,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"` // Longest event kept, in bytes
+	Size          int32                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                            // Size of the buffer, in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ring) Reset() {
+	*x = Ring{}
+	mi := &file_ring_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ring) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ring) ProtoMessage() {}
+
+func (x *Ring) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ring.ProtoReflect.Descriptor instead.
+func (*Ring) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Ring) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ring) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Ring) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Ring) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *Ring) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// RingLogTarget sends the logs of a frontend or backend to a ring. It is stored as a log line with the address
+// "ring@<ring>"; a frontend or backend sends to each ring at most once.
+type RingLogTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ring          string                 `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`                         // Required
+	Facility      string                 `protobuf:"bytes,2,opt,name=facility,proto3" json:"facility,omitempty"`                 // Syslog facility; "local0" if unset
+	Level         string                 `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`                       // Most verbose level sent, e.g. "info"; all levels if unset
+	MinLevel      string                 `protobuf:"bytes,4,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"` // Least verbose level sent
+	Format        string                 `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`                     // Log format, e.g. "raw"; that of the ring if unset
+	Length        int32                  `protobuf:"varint,6,opt,name=length,proto3" json:"length,omitempty"`                    // Longest line, in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RingLogTarget) Reset() {
+	*x = RingLogTarget{}
+	mi := &file_ring_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RingLogTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RingLogTarget) ProtoMessage() {}
+
+func (x *RingLogTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RingLogTarget.ProtoReflect.Descriptor instead.
+func (*RingLogTarget) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RingLogTarget) GetRing() string {
+	if x != nil {
+		return x.Ring
+	}
+	return ""
+}
+
+func (x *RingLogTarget) GetFacility() string {
+	if x != nil {
+		return x.Facility
+	}
+	return ""
+}
+
+func (x *RingLogTarget) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *RingLogTarget) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *RingLogTarget) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RingLogTarget) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type CreateRingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Ring          *Ring                  `protobuf:"bytes,2,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRingRequest) Reset() {
+	*x = CreateRingRequest{}
+	mi := &file_ring_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRingRequest) ProtoMessage() {}
+
+func (x *CreateRingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRingRequest.ProtoReflect.Descriptor instead.
+func (*CreateRingRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRingRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateRingRequest) GetRing() *Ring {
+	if x != nil {
+		return x.Ring
+	}
+	return nil
+}
+
+type CreateRingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ring          *Ring                  `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRingResponse) Reset() {
+	*x = CreateRingResponse{}
+	mi := &file_ring_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRingResponse) ProtoMessage() {}
+
+func (x *CreateRingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRingResponse.ProtoReflect.Descriptor instead.
+func (*CreateRingResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRingResponse) GetRing() *Ring {
+	if x != nil {
+		return x.Ring
+	}
+	return nil
+}
+
+type GetRingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRingRequest) Reset() {
+	*x = GetRingRequest{}
+	mi := &file_ring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRingRequest) ProtoMessage() {}
+
+func (x *GetRingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRingRequest.ProtoReflect.Descriptor instead.
+func (*GetRingRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRingRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetRingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetRingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ring          *Ring                  `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRingResponse) Reset() {
+	*x = GetRingResponse{}
+	mi := &file_ring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRingResponse) ProtoMessage() {}
+
+func (x *GetRingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRingResponse.ProtoReflect.Descriptor instead.
+func (*GetRingResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRingResponse) GetRing() *Ring {
+	if x != nil {
+		return x.Ring
+	}
+	return nil
+}
+
+type ListRingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRingsRequest) Reset() {
+	*x = ListRingsRequest{}
+	mi := &file_ring_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRingsRequest) ProtoMessage() {}
+
+func (x *ListRingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRingsRequest.ProtoReflect.Descriptor instead.
+func (*ListRingsRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRingsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ListRingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rings         []*Ring                `protobuf:"bytes,1,rep,name=rings,proto3" json:"rings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRingsResponse) Reset() {
+	*x = ListRingsResponse{}
+	mi := &file_ring_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRingsResponse) ProtoMessage() {}
+
+func (x *ListRingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRingsResponse.ProtoReflect.Descriptor instead.
+func (*ListRingsResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRingsResponse) GetRings() []*Ring {
+	if x != nil {
+		return x.Rings
+	}
+	return nil
+}
+
+type UpdateRingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Ring          *Ring                  `protobuf:"bytes,2,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRingRequest) Reset() {
+	*x = UpdateRingRequest{}
+	mi := &file_ring_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRingRequest) ProtoMessage() {}
+
+func (x *UpdateRingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRingRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRingRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *UpdateRingRequest) GetRing() *Ring {
+	if x != nil {
+		return x.Ring
+	}
+	return nil
+}
+
+type UpdateRingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ring          *Ring                  `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRingResponse) Reset() {
+	*x = UpdateRingResponse{}
+	mi := &file_ring_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRingResponse) ProtoMessage() {}
+
+func (x *UpdateRingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRingResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateRingResponse) GetRing() *Ring {
+	if x != nil {
+		return x.Ring
+	}
+	return nil
+}
+
+type DeleteRingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRingRequest) Reset() {
+	*x = DeleteRingRequest{}
+	mi := &file_ring_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRingRequest) ProtoMessage() {}
+
+func (x *DeleteRingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRingRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRingRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteRingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteRingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRingResponse) Reset() {
+	*x = DeleteRingResponse{}
+	mi := &file_ring_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRingResponse) ProtoMessage() {}
+
+func (x *DeleteRingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRingResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{11}
+}
+
+// The log targets of a frontend or a backend: exactly one of frontend_name and backend_name is set
+type AttachRingLogTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	BackendName   string                 `protobuf:"bytes,3,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Target        *RingLogTarget         `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachRingLogTargetRequest) Reset() {
+	*x = AttachRingLogTargetRequest{}
+	mi := &file_ring_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachRingLogTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachRingLogTargetRequest) ProtoMessage() {}
+
+func (x *AttachRingLogTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachRingLogTargetRequest.ProtoReflect.Descriptor instead.
+func (*AttachRingLogTargetRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AttachRingLogTargetRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AttachRingLogTargetRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *AttachRingLogTargetRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *AttachRingLogTargetRequest) GetTarget() *RingLogTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type AttachRingLogTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *RingLogTarget         `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachRingLogTargetResponse) Reset() {
+	*x = AttachRingLogTargetResponse{}
+	mi := &file_ring_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachRingLogTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachRingLogTargetResponse) ProtoMessage() {}
+
+func (x *AttachRingLogTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachRingLogTargetResponse.ProtoReflect.Descriptor instead.
+func (*AttachRingLogTargetResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AttachRingLogTargetResponse) GetTarget() *RingLogTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type ListRingLogTargetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	BackendName   string                 `protobuf:"bytes,3,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRingLogTargetsRequest) Reset() {
+	*x = ListRingLogTargetsRequest{}
+	mi := &file_ring_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRingLogTargetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRingLogTargetsRequest) ProtoMessage() {}
+
+func (x *ListRingLogTargetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRingLogTargetsRequest.ProtoReflect.Descriptor instead.
+func (*ListRingLogTargetsRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListRingLogTargetsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ListRingLogTargetsRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *ListRingLogTargetsRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+type ListRingLogTargetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []*RingLogTarget       `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRingLogTargetsResponse) Reset() {
+	*x = ListRingLogTargetsResponse{}
+	mi := &file_ring_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRingLogTargetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRingLogTargetsResponse) ProtoMessage() {}
+
+func (x *ListRingLogTargetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRingLogTargetsResponse.ProtoReflect.Descriptor instead.
+func (*ListRingLogTargetsResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRingLogTargetsResponse) GetTargets() []*RingLogTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type DetachRingLogTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FrontendName  string                 `protobuf:"bytes,2,opt,name=frontend_name,json=frontendName,proto3" json:"frontend_name,omitempty"`
+	BackendName   string                 `protobuf:"bytes,3,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Ring          string                 `protobuf:"bytes,4,opt,name=ring,proto3" json:"ring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetachRingLogTargetRequest) Reset() {
+	*x = DetachRingLogTargetRequest{}
+	mi := &file_ring_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachRingLogTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachRingLogTargetRequest) ProtoMessage() {}
+
+func (x *DetachRingLogTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachRingLogTargetRequest.ProtoReflect.Descriptor instead.
+func (*DetachRingLogTargetRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DetachRingLogTargetRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DetachRingLogTargetRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *DetachRingLogTargetRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *DetachRingLogTargetRequest) GetRing() string {
+	if x != nil {
+		return x.Ring
+	}
+	return ""
+}
+
+type DetachRingLogTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetachRingLogTargetResponse) Reset() {
+	*x = DetachRingLogTargetResponse{}
+	mi := &file_ring_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachRingLogTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachRingLogTargetResponse) ProtoMessage() {}
+
+func (x *DetachRingLogTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachRingLogTargetResponse.ProtoReflect.Descriptor instead.
+func (*DetachRingLogTargetResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{17}
+}
+
+type StreamRingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"` // Keep the stream open and send new events as they arrive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRingRequest) Reset() {
+	*x = StreamRingRequest{}
+	mi := &file_ring_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRingRequest) ProtoMessage() {}
+
+func (x *StreamRingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRingRequest.ProtoReflect.Descriptor instead.
+func (*StreamRingRequest) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamRingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreamRingRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+// StreamRingResponse is an event of the ring, such as a log line or trace, as formatted by the ring
+type StreamRingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRingResponse) Reset() {
+	*x = StreamRingResponse{}
+	mi := &file_ring_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRingResponse) ProtoMessage() {}
+
+func (x *StreamRingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ring_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRingResponse.ProtoReflect.Descriptor instead.
+func (*StreamRingResponse) Descriptor() ([]byte, []int) {
+	return file_ring_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StreamRingResponse) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+var File_ring_proto protoreflect.FileDescriptor
+
+const file_ring_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"ring.proto\x12\n" +
+	"haproxy.v1\"\x87\x01\n" +
+	"\x04Ring\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x04 \x01(\x05R\tmaxLength\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x05R\x04size\"\xa2\x01\n" +
+	"\rRingLogTarget\x12\x12\n" +
+	"\x04ring\x18\x01 \x01(\tR\x04ring\x12\x1a\n" +
+	"\bfacility\x18\x02 \x01(\tR\bfacility\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\tR\x05level\x12\x1b\n" +
+	"\tmin_level\x18\x04 \x01(\tR\bminLevel\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\x12\x16\n" +
+	"\x06length\x18\x06 \x01(\x05R\x06length\"`\n" +
+	"\x11CreateRingRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12$\n" +
+	"\x04ring\x18\x02 \x01(\v2\x10.haproxy.v1.RingR\x04ring\":\n" +
+	"\x12CreateRingResponse\x12$\n" +
+	"\x04ring\x18\x01 \x01(\v2\x10.haproxy.v1.RingR\x04ring\"K\n" +
+	"\x0eGetRingRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"7\n" +
+	"\x0fGetRingResponse\x12$\n" +
+	"\x04ring\x18\x01 \x01(\v2\x10.haproxy.v1.RingR\x04ring\"9\n" +
+	"\x10ListRingsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\";\n" +
+	"\x11ListRingsResponse\x12&\n" +
+	"\x05rings\x18\x01 \x03(\v2\x10.haproxy.v1.RingR\x05rings\"`\n" +
+	"\x11UpdateRingRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12$\n" +
+	"\x04ring\x18\x02 \x01(\v2\x10.haproxy.v1.RingR\x04ring\":\n" +
+	"\x12UpdateRingResponse\x12$\n" +
+	"\x04ring\x18\x01 \x01(\v2\x10.haproxy.v1.RingR\x04ring\"N\n" +
+	"\x11DeleteRingRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x14\n" +
+	"\x12DeleteRingResponse\"\xbe\x01\n" +
+	"\x1aAttachRingLogTargetRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12!\n" +
+	"\fbackend_name\x18\x03 \x01(\tR\vbackendName\x121\n" +
+	"\x06target\x18\x04 \x01(\v2\x19.haproxy.v1.RingLogTargetR\x06target\"P\n" +
+	"\x1bAttachRingLogTargetResponse\x121\n" +
+	"\x06target\x18\x01 \x01(\v2\x19.haproxy.v1.RingLogTargetR\x06target\"\x8a\x01\n" +
+	"\x19ListRingLogTargetsRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12!\n" +
+	"\fbackend_name\x18\x03 \x01(\tR\vbackendName\"Q\n" +
+	"\x1aListRingLogTargetsResponse\x123\n" +
+	"\atargets\x18\x01 \x03(\v2\x19.haproxy.v1.RingLogTargetR\atargets\"\x9f\x01\n" +
+	"\x1aDetachRingLogTargetRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12#\n" +
+	"\rfrontend_name\x18\x02 \x01(\tR\ffrontendName\x12!\n" +
+	"\fbackend_name\x18\x03 \x01(\tR\vbackendName\x12\x12\n" +
+	"\x04ring\x18\x04 \x01(\tR\x04ring\"\x1d\n" +
+	"\x1bDetachRingLogTargetResponse\"?\n" +
+	"\x11StreamRingRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\"*\n" +
+	"\x12StreamRingResponse\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05eventB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_ring_proto_rawDescOnce sync.Once
+	file_ring_proto_rawDescData []byte
+)
+
+func file_ring_proto_rawDescGZIP() []byte {
+	file_ring_proto_rawDescOnce.Do(func() {
+		file_ring_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ring_proto_rawDesc), len(file_ring_proto_rawDesc)))
+	})
+	return file_ring_proto_rawDescData
+}
+
+var file_ring_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_ring_proto_goTypes = []any{
+	(*Ring)(nil),                        // 0: haproxy.v1.Ring
+	(*RingLogTarget)(nil),               // 1: haproxy.v1.RingLogTarget
+	(*CreateRingRequest)(nil),           // 2: haproxy.v1.CreateRingRequest
+	(*CreateRingResponse)(nil),          // 3: haproxy.v1.CreateRingResponse
+	(*GetRingRequest)(nil),              // 4: haproxy.v1.GetRingRequest
+	(*GetRingResponse)(nil),             // 5: haproxy.v1.GetRingResponse
+	(*ListRingsRequest)(nil),            // 6: haproxy.v1.ListRingsRequest
+	(*ListRingsResponse)(nil),           // 7: haproxy.v1.ListRingsResponse
+	(*UpdateRingRequest)(nil),           // 8: haproxy.v1.UpdateRingRequest
+	(*UpdateRingResponse)(nil),          // 9: haproxy.v1.UpdateRingResponse
+	(*DeleteRingRequest)(nil),           // 10: haproxy.v1.DeleteRingRequest
+	(*DeleteRingResponse)(nil),          // 11: haproxy.v1.DeleteRingResponse
+	(*AttachRingLogTargetRequest)(nil),  // 12: haproxy.v1.AttachRingLogTargetRequest
+	(*AttachRingLogTargetResponse)(nil), // 13: haproxy.v1.AttachRingLogTargetResponse
+	(*ListRingLogTargetsRequest)(nil),   // 14: haproxy.v1.ListRingLogTargetsRequest
+	(*ListRingLogTargetsResponse)(nil),  // 15: haproxy.v1.ListRingLogTargetsResponse
+	(*DetachRingLogTargetRequest)(nil),  // 16: haproxy.v1.DetachRingLogTargetRequest
+	(*DetachRingLogTargetResponse)(nil), // 17: haproxy.v1.DetachRingLogTargetResponse
+	(*StreamRingRequest)(nil),           // 18: haproxy.v1.StreamRingRequest
+	(*StreamRingResponse)(nil),          // 19: haproxy.v1.StreamRingResponse
+}
+var file_ring_proto_depIdxs = []int32{
+	0, // 0: haproxy.v1.CreateRingRequest.ring:type_name -> haproxy.v1.Ring
+	0, // 1: haproxy.v1.CreateRingResponse.ring:type_name -> haproxy.v1.Ring
+	0, // 2: haproxy.v1.GetRingResponse.ring:type_name -> haproxy.v1.Ring
+	0, // 3: haproxy.v1.ListRingsResponse.rings:type_name -> haproxy.v1.Ring
+	0, // 4: haproxy.v1.UpdateRingRequest.ring:type_name -> haproxy.v1.Ring
+	0, // 5: haproxy.v1.UpdateRingResponse.ring:type_name -> haproxy.v1.Ring
+	1, // 6: haproxy.v1.AttachRingLogTargetRequest.target:type_name -> haproxy.v1.RingLogTarget
+	1, // 7: haproxy.v1.AttachRingLogTargetResponse.target:type_name -> haproxy.v1.RingLogTarget
+	1, // 8: haproxy.v1.ListRingLogTargetsResponse.targets:type_name -> haproxy.v1.RingLogTarget
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_ring_proto_init() }
+func file_ring_proto_init() {
+	if File_ring_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ring_proto_rawDesc), len(file_ring_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ring_proto_goTypes,
+		DependencyIndexes: file_ring_proto_depIdxs,
+		MessageInfos:      file_ring_proto_msgTypes,
+	}.Build()
+	File_ring_proto = out.File
+	file_ring_proto_goTypes = nil
+	file_ring_proto_depIdxs = nil
+}
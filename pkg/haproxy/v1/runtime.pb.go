@@ -0,0 +1,639 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: runtime.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ServerAdminState is the administrative state of a server in the running HAProxy process
+type ServerAdminState int32
+
+const (
+	ServerAdminState_SERVER_ADMIN_STATE_UNSPECIFIED ServerAdminState = 0
+	ServerAdminState_SERVER_ADMIN_STATE_READY       ServerAdminState = 1 // Receives traffic
+	ServerAdminState_SERVER_ADMIN_STATE_DRAIN       ServerAdminState = 2 // Keeps its sessions but receives no new ones
+	ServerAdminState_SERVER_ADMIN_STATE_MAINT       ServerAdminState = 3 // Receives no traffic
+)
+
+// Enum value maps for ServerAdminState.
+var (
+	ServerAdminState_name = map[int32]string{
+		0: "SERVER_ADMIN_STATE_UNSPECIFIED",
+		1: "SERVER_ADMIN_STATE_READY",
+		2: "SERVER_ADMIN_STATE_DRAIN",
+		3: "SERVER_ADMIN_STATE_MAINT",
+	}
+	ServerAdminState_value = map[string]int32{
+		"SERVER_ADMIN_STATE_UNSPECIFIED": 0,
+		"SERVER_ADMIN_STATE_READY":       1,
+		"SERVER_ADMIN_STATE_DRAIN":       2,
+		"SERVER_ADMIN_STATE_MAINT":       3,
+	}
+)
+
+func (x ServerAdminState) Enum() *ServerAdminState {
+	p := new(ServerAdminState)
+	*p = x
+	return p
+}
+
+func (x ServerAdminState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerAdminState) Descriptor() protoreflect.EnumDescriptor {
+	return file_runtime_proto_enumTypes[0].Descriptor()
+}
+
+func (ServerAdminState) Type() protoreflect.EnumType {
+	return &file_runtime_proto_enumTypes[0]
+}
+
+func (x ServerAdminState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerAdminState.Descriptor instead.
+func (ServerAdminState) EnumDescriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{0}
+}
+
+// ProxyStats are the live statistics of a frontend, backend or server
+type ProxyStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "frontend", "backend" or "server"
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BackendName     string                 `protobuf:"bytes,3,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"` // Backend of a server
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                              // e.g. "OPEN", "UP", "DOWN", "DRAIN" or "MAINT"
+	CurrentSessions int64                  `protobuf:"varint,5,opt,name=current_sessions,json=currentSessions,proto3" json:"current_sessions,omitempty"`
+	MaxSessions     int64                  `protobuf:"varint,6,opt,name=max_sessions,json=maxSessions,proto3" json:"max_sessions,omitempty"`
+	TotalSessions   int64                  `protobuf:"varint,7,opt,name=total_sessions,json=totalSessions,proto3" json:"total_sessions,omitempty"`
+	SessionRate     int64                  `protobuf:"varint,8,opt,name=session_rate,json=sessionRate,proto3" json:"session_rate,omitempty"` // Sessions per second over the last second
+	BytesIn         int64                  `protobuf:"varint,9,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`
+	BytesOut        int64                  `protobuf:"varint,10,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`
+	CheckStatus     string                 `protobuf:"bytes,11,opt,name=check_status,json=checkStatus,proto3" json:"check_status,omitempty"` // Result of the last health check of a server
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProxyStats) Reset() {
+	*x = ProxyStats{}
+	mi := &file_runtime_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyStats) ProtoMessage() {}
+
+func (x *ProxyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyStats.ProtoReflect.Descriptor instead.
+func (*ProxyStats) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProxyStats) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProxyStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProxyStats) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *ProxyStats) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProxyStats) GetCurrentSessions() int64 {
+	if x != nil {
+		return x.CurrentSessions
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetMaxSessions() int64 {
+	if x != nil {
+		return x.MaxSessions
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetTotalSessions() int64 {
+	if x != nil {
+		return x.TotalSessions
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetSessionRate() int64 {
+	if x != nil {
+		return x.SessionRate
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetBytesIn() int64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetBytesOut() int64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+func (x *ProxyStats) GetCheckStatus() string {
+	if x != nil {
+		return x.CheckStatus
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_runtime_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{1}
+}
+
+// GetStatsResponse contains the statistics of all frontends, backends and servers
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*ProxyStats          `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_runtime_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStatsResponse) GetStats() []*ProxyStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// SetServerStateRequest changes the administrative state of a server in the running process,
+// without a transaction or a reload; the change is lost when HAProxy restarts
+type SetServerStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AdminState    ServerAdminState       `protobuf:"varint,3,opt,name=admin_state,json=adminState,proto3,enum=haproxy.v1.ServerAdminState" json:"admin_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServerStateRequest) Reset() {
+	*x = SetServerStateRequest{}
+	mi := &file_runtime_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerStateRequest) ProtoMessage() {}
+
+func (x *SetServerStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerStateRequest.ProtoReflect.Descriptor instead.
+func (*SetServerStateRequest) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetServerStateRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *SetServerStateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetServerStateRequest) GetAdminState() ServerAdminState {
+	if x != nil {
+		return x.AdminState
+	}
+	return ServerAdminState_SERVER_ADMIN_STATE_UNSPECIFIED
+}
+
+type SetServerStateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AdminState       ServerAdminState       `protobuf:"varint,1,opt,name=admin_state,json=adminState,proto3,enum=haproxy.v1.ServerAdminState" json:"admin_state,omitempty"`
+	OperationalState string                 `protobuf:"bytes,2,opt,name=operational_state,json=operationalState,proto3" json:"operational_state,omitempty"` // "up", "down" or "stopping"
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetServerStateResponse) Reset() {
+	*x = SetServerStateResponse{}
+	mi := &file_runtime_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerStateResponse) ProtoMessage() {}
+
+func (x *SetServerStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerStateResponse.ProtoReflect.Descriptor instead.
+func (*SetServerStateResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetServerStateResponse) GetAdminState() ServerAdminState {
+	if x != nil {
+		return x.AdminState
+	}
+	return ServerAdminState_SERVER_ADMIN_STATE_UNSPECIFIED
+}
+
+func (x *SetServerStateResponse) GetOperationalState() string {
+	if x != nil {
+		return x.OperationalState
+	}
+	return ""
+}
+
+// DrainServerRequest drains a server in the running process and reports its sessions until they end,
+// so deploy tooling knows when the server can be restarted
+type DrainServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackendName   string                 `protobuf:"bytes,1,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                               // How long to wait for the sessions to end, 5m when unset
+	PollInterval  *durationpb.Duration   `protobuf:"bytes,4,opt,name=poll_interval,json=pollInterval,proto3" json:"poll_interval,omitempty"` // How often the sessions are reported, 1s when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainServerRequest) Reset() {
+	*x = DrainServerRequest{}
+	mi := &file_runtime_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainServerRequest) ProtoMessage() {}
+
+func (x *DrainServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainServerRequest.ProtoReflect.Descriptor instead.
+func (*DrainServerRequest) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DrainServerRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *DrainServerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DrainServerRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *DrainServerRequest) GetPollInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PollInterval
+	}
+	return nil
+}
+
+// DrainServerResponse reports the sessions of a draining server; the stream ends with drained set, or with
+// DEADLINE_EXCEEDED if sessions remain after the timeout
+type DrainServerResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentSessions int64                  `protobuf:"varint,1,opt,name=current_sessions,json=currentSessions,proto3" json:"current_sessions,omitempty"`
+	Elapsed         *durationpb.Duration   `protobuf:"bytes,2,opt,name=elapsed,proto3" json:"elapsed,omitempty"` // Since the server was set to drain
+	Drained         bool                   `protobuf:"varint,3,opt,name=drained,proto3" json:"drained,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DrainServerResponse) Reset() {
+	*x = DrainServerResponse{}
+	mi := &file_runtime_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainServerResponse) ProtoMessage() {}
+
+func (x *DrainServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainServerResponse.ProtoReflect.Descriptor instead.
+func (*DrainServerResponse) Descriptor() ([]byte, []int) {
+	return file_runtime_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DrainServerResponse) GetCurrentSessions() int64 {
+	if x != nil {
+		return x.CurrentSessions
+	}
+	return 0
+}
+
+func (x *DrainServerResponse) GetElapsed() *durationpb.Duration {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+func (x *DrainServerResponse) GetDrained() bool {
+	if x != nil {
+		return x.Drained
+	}
+	return false
+}
+
+var File_runtime_proto protoreflect.FileDescriptor
+
+const file_runtime_proto_rawDesc = "" +
+	"\n" +
+	"\rruntime.proto\x12\n" +
+	"haproxy.v1\x1a\x1egoogle/protobuf/duration.proto\"\xe2\x02\n" +
+	"\n" +
+	"ProxyStats\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fbackend_name\x18\x03 \x01(\tR\vbackendName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12)\n" +
+	"\x10current_sessions\x18\x05 \x01(\x03R\x0fcurrentSessions\x12!\n" +
+	"\fmax_sessions\x18\x06 \x01(\x03R\vmaxSessions\x12%\n" +
+	"\x0etotal_sessions\x18\a \x01(\x03R\rtotalSessions\x12!\n" +
+	"\fsession_rate\x18\b \x01(\x03R\vsessionRate\x12\x19\n" +
+	"\bbytes_in\x18\t \x01(\x03R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\n" +
+	" \x01(\x03R\bbytesOut\x12!\n" +
+	"\fcheck_status\x18\v \x01(\tR\vcheckStatus\"\x11\n" +
+	"\x0fGetStatsRequest\"@\n" +
+	"\x10GetStatsResponse\x12,\n" +
+	"\x05stats\x18\x01 \x03(\v2\x16.haproxy.v1.ProxyStatsR\x05stats\"\x8d\x01\n" +
+	"\x15SetServerStateRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12=\n" +
+	"\vadmin_state\x18\x03 \x01(\x0e2\x1c.haproxy.v1.ServerAdminStateR\n" +
+	"adminState\"\x84\x01\n" +
+	"\x16SetServerStateResponse\x12=\n" +
+	"\vadmin_state\x18\x01 \x01(\x0e2\x1c.haproxy.v1.ServerAdminStateR\n" +
+	"adminState\x12+\n" +
+	"\x11operational_state\x18\x02 \x01(\tR\x10operationalState\"\xc0\x01\n" +
+	"\x12DrainServerRequest\x12!\n" +
+	"\fbackend_name\x18\x01 \x01(\tR\vbackendName\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12>\n" +
+	"\rpoll_interval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fpollInterval\"\x8f\x01\n" +
+	"\x13DrainServerResponse\x12)\n" +
+	"\x10current_sessions\x18\x01 \x01(\x03R\x0fcurrentSessions\x123\n" +
+	"\aelapsed\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\aelapsed\x12\x18\n" +
+	"\adrained\x18\x03 \x01(\bR\adrained*\x90\x01\n" +
+	"\x10ServerAdminState\x12\"\n" +
+	"\x1eSERVER_ADMIN_STATE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18SERVER_ADMIN_STATE_READY\x10\x01\x12\x1c\n" +
+	"\x18SERVER_ADMIN_STATE_DRAIN\x10\x02\x12\x1c\n" +
+	"\x18SERVER_ADMIN_STATE_MAINT\x10\x03B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_runtime_proto_rawDescOnce sync.Once
+	file_runtime_proto_rawDescData []byte
+)
+
+func file_runtime_proto_rawDescGZIP() []byte {
+	file_runtime_proto_rawDescOnce.Do(func() {
+		file_runtime_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_runtime_proto_rawDesc), len(file_runtime_proto_rawDesc)))
+	})
+	return file_runtime_proto_rawDescData
+}
+
+var file_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_runtime_proto_goTypes = []any{
+	(ServerAdminState)(0),          // 0: haproxy.v1.ServerAdminState
+	(*ProxyStats)(nil),             // 1: haproxy.v1.ProxyStats
+	(*GetStatsRequest)(nil),        // 2: haproxy.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),       // 3: haproxy.v1.GetStatsResponse
+	(*SetServerStateRequest)(nil),  // 4: haproxy.v1.SetServerStateRequest
+	(*SetServerStateResponse)(nil), // 5: haproxy.v1.SetServerStateResponse
+	(*DrainServerRequest)(nil),     // 6: haproxy.v1.DrainServerRequest
+	(*DrainServerResponse)(nil),    // 7: haproxy.v1.DrainServerResponse
+	(*durationpb.Duration)(nil),    // 8: google.protobuf.Duration
+}
+var file_runtime_proto_depIdxs = []int32{
+	1, // 0: haproxy.v1.GetStatsResponse.stats:type_name -> haproxy.v1.ProxyStats
+	0, // 1: haproxy.v1.SetServerStateRequest.admin_state:type_name -> haproxy.v1.ServerAdminState
+	0, // 2: haproxy.v1.SetServerStateResponse.admin_state:type_name -> haproxy.v1.ServerAdminState
+	8, // 3: haproxy.v1.DrainServerRequest.timeout:type_name -> google.protobuf.Duration
+	8, // 4: haproxy.v1.DrainServerRequest.poll_interval:type_name -> google.protobuf.Duration
+	8, // 5: haproxy.v1.DrainServerResponse.elapsed:type_name -> google.protobuf.Duration
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_runtime_proto_init() }
+func file_runtime_proto_init() {
+	if File_runtime_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_runtime_proto_rawDesc), len(file_runtime_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_runtime_proto_goTypes,
+		DependencyIndexes: file_runtime_proto_depIdxs,
+		EnumInfos:         file_runtime_proto_enumTypes,
+		MessageInfos:      file_runtime_proto_msgTypes,
+	}.Build()
+	File_runtime_proto = out.File
+	file_runtime_proto_goTypes = nil
+	file_runtime_proto_depIdxs = nil
+}
@@ -21,13 +21,73 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ProxyProtocolVersion defines which PROXY protocol header a server is sent to pass on the client address
+type ProxyProtocolVersion int32
+
+const (
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED ProxyProtocolVersion = 0 // No header is sent
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V1          ProxyProtocolVersion = 1 // Human readable header (send-proxy)
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2          ProxyProtocolVersion = 2 // Binary header (send-proxy-v2)
+)
+
+// Enum value maps for ProxyProtocolVersion.
+var (
+	ProxyProtocolVersion_name = map[int32]string{
+		0: "PROXY_PROTOCOL_VERSION_UNSPECIFIED",
+		1: "PROXY_PROTOCOL_VERSION_V1",
+		2: "PROXY_PROTOCOL_VERSION_V2",
+	}
+	ProxyProtocolVersion_value = map[string]int32{
+		"PROXY_PROTOCOL_VERSION_UNSPECIFIED": 0,
+		"PROXY_PROTOCOL_VERSION_V1":          1,
+		"PROXY_PROTOCOL_VERSION_V2":          2,
+	}
+)
+
+func (x ProxyProtocolVersion) Enum() *ProxyProtocolVersion {
+	p := new(ProxyProtocolVersion)
+	*p = x
+	return p
+}
+
+func (x ProxyProtocolVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProxyProtocolVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_proto_enumTypes[0].Descriptor()
+}
+
+func (ProxyProtocolVersion) Type() protoreflect.EnumType {
+	return &file_server_proto_enumTypes[0]
+}
+
+func (x ProxyProtocolVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProxyProtocolVersion.Descriptor instead.
+func (ProxyProtocolVersion) EnumDescriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{0}
+}
+
 // Server represents a HAProxy server configuration
 type Server struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the server
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Required: Unique identifier for the server
+	Address         string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Port            int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,5,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`                     // Changes whenever the resource changes; set in responses only
+	Maxconn         int32                  `protobuf:"varint,6,opt,name=maxconn,proto3" json:"maxconn,omitempty"`                                                           // Concurrent connections; further ones wait in the queue. 0 leaves it unset
+	Minconn         int32                  `protobuf:"varint,7,opt,name=minconn,proto3" json:"minconn,omitempty"`                                                           // Concurrent connections while the backend is below fullconn; 0 leaves it unset
+	Maxqueue        int32                  `protobuf:"varint,8,opt,name=maxqueue,proto3" json:"maxqueue,omitempty"`                                                         // Queued connections; further ones go to other servers. 0 leaves it unset
+	SendProxy       ProxyProtocolVersion   `protobuf:"varint,9,opt,name=send_proxy,json=sendProxy,proto3,enum=haproxy.v1.ProxyProtocolVersion" json:"send_proxy,omitempty"` // The server must expect the header, e.g. with accept-proxy
+	Weight          *int32                 `protobuf:"varint,10,opt,name=weight,proto3,oneof" json:"weight,omitempty"`                                                      // Share of the load relative to the other servers, 0 to 256; 1 if unset
+	Maintenance     bool                   `protobuf:"varint,11,opt,name=maintenance,proto3" json:"maintenance,omitempty"`                                                  // Starts in maintenance whenever HAProxy loads the configuration
+	// Kept in the metadata store of the configurator, as HAProxy has no place for them; see metadata.path
+	Labels        map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string `protobuf:"bytes,13,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,6 +150,69 @@ func (x *Server) GetPort() int32 {
 	return 0
 }
 
+func (x *Server) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *Server) GetMaxconn() int32 {
+	if x != nil {
+		return x.Maxconn
+	}
+	return 0
+}
+
+func (x *Server) GetMinconn() int32 {
+	if x != nil {
+		return x.Minconn
+	}
+	return 0
+}
+
+func (x *Server) GetMaxqueue() int32 {
+	if x != nil {
+		return x.Maxqueue
+	}
+	return 0
+}
+
+func (x *Server) GetSendProxy() ProxyProtocolVersion {
+	if x != nil {
+		return x.SendProxy
+	}
+	return ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED
+}
+
+func (x *Server) GetWeight() int32 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *Server) GetMaintenance() bool {
+	if x != nil {
+		return x.Maintenance
+	}
+	return false
+}
+
+func (x *Server) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Server) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 type CreateServerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -199,6 +322,7 @@ type GetServerRequest struct {
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
 	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	NoCache       bool                   `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -254,6 +378,13 @@ func (x *GetServerRequest) GetName() string {
 	return ""
 }
 
+func (x *GetServerRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type GetServerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
@@ -302,6 +433,9 @@ type ListServersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`  // "name", "address" or "port", prefixed with "-" for descending order; configuration order if empty
+	NoCache       bool                   `protobuf:"varint,5,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"` // Read from the Data Plane API even if the read cache holds the answer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -350,6 +484,27 @@ func (x *ListServersRequest) GetBackendName() string {
 	return ""
 }
 
+func (x *ListServersRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListServersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListServersRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type ListServersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Servers       []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
@@ -395,13 +550,14 @@ func (x *ListServersResponse) GetServers() []*Server {
 }
 
 type UpdateServerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Server        *Server                `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName     string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Server          *Server                `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateServerRequest) Reset() {
@@ -462,6 +618,13 @@ func (x *UpdateServerRequest) GetServer() *Server {
 	return nil
 }
 
+func (x *UpdateServerRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type UpdateServerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
@@ -507,12 +670,13 @@ func (x *UpdateServerResponse) GetServer() *Server {
 }
 
 type DeleteServerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName     string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion string                 `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fails with FAILED_PRECONDITION unless the resource has this resource_version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteServerRequest) Reset() {
@@ -566,6 +730,13 @@ func (x *DeleteServerRequest) GetName() string {
 	return ""
 }
 
+func (x *DeleteServerRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
 type DeleteServerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -602,46 +773,527 @@ func (*DeleteServerResponse) Descriptor() ([]byte, []int) {
 	return file_server_proto_rawDescGZIP(), []int{10}
 }
 
+// ApplyServer creates the server if it does not exist and replaces it if it differs
+type ApplyServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Server        *Server                `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyServerRequest) Reset() {
+	*x = ApplyServerRequest{}
+	mi := &file_server_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyServerRequest) ProtoMessage() {}
+
+func (x *ApplyServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyServerRequest.ProtoReflect.Descriptor instead.
+func (*ApplyServerRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApplyServerRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ApplyServerRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *ApplyServerRequest) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+type ApplyServerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // False if the server already matched
+	Created       bool                   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"` // True if the server did not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyServerResponse) Reset() {
+	*x = ApplyServerResponse{}
+	mi := &file_server_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyServerResponse) ProtoMessage() {}
+
+func (x *ApplyServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyServerResponse.ProtoReflect.Descriptor instead.
+func (*ApplyServerResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApplyServerResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *ApplyServerResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ApplyServerResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// CreateServers adds several servers to a backend within one transaction
+type CreateServersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Required
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Servers       []*Server              `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServersRequest) Reset() {
+	*x = CreateServersRequest{}
+	mi := &file_server_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServersRequest) ProtoMessage() {}
+
+func (x *CreateServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServersRequest.ProtoReflect.Descriptor instead.
+func (*CreateServersRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateServersRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateServersRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *CreateServersRequest) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type CreateServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Servers       []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServersResponse) Reset() {
+	*x = CreateServersResponse{}
+	mi := &file_server_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServersResponse) ProtoMessage() {}
+
+func (x *CreateServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServersResponse.ProtoReflect.Descriptor instead.
+func (*CreateServersResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateServersResponse) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+// DeleteServers removes several servers from a backend within one transaction
+type DeleteServersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Required
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Names         []string               `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteServersRequest) Reset() {
+	*x = DeleteServersRequest{}
+	mi := &file_server_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteServersRequest) ProtoMessage() {}
+
+func (x *DeleteServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteServersRequest.ProtoReflect.Descriptor instead.
+func (*DeleteServersRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteServersRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DeleteServersRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *DeleteServersRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type DeleteServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteServersResponse) Reset() {
+	*x = DeleteServersResponse{}
+	mi := &file_server_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteServersResponse) ProtoMessage() {}
+
+func (x *DeleteServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteServersResponse.ProtoReflect.Descriptor instead.
+func (*DeleteServersResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{16}
+}
+
+// StreamServers sends the servers of a backend one at a time, in configuration order
+type StreamServersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BackendName   string                 `protobuf:"bytes,2,opt,name=backend_name,json=backendName,proto3" json:"backend_name,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamServersRequest) Reset() {
+	*x = StreamServersRequest{}
+	mi := &file_server_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamServersRequest) ProtoMessage() {}
+
+func (x *StreamServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamServersRequest.ProtoReflect.Descriptor instead.
+func (*StreamServersRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StreamServersRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *StreamServersRequest) GetBackendName() string {
+	if x != nil {
+		return x.BackendName
+	}
+	return ""
+}
+
+func (x *StreamServersRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type StreamServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamServersResponse) Reset() {
+	*x = StreamServersResponse{}
+	mi := &file_server_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamServersResponse) ProtoMessage() {}
+
+func (x *StreamServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamServersResponse.ProtoReflect.Descriptor instead.
+func (*StreamServersResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamServersResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
 var File_server_proto protoreflect.FileDescriptor
 
 const file_server_proto_rawDesc = "" +
 	"\n" +
 	"\fserver.proto\x12\n" +
-	"haproxy.v1\"Z\n" +
+	"haproxy.v1\x1a\fcommon.proto\"\xda\x04\n" +
 	"\x06Server\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x12\n" +
-	"\x04port\x18\x04 \x01(\x05R\x04port\"\x8b\x01\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12)\n" +
+	"\x10resource_version\x18\x05 \x01(\tR\x0fresourceVersion\x12\x18\n" +
+	"\amaxconn\x18\x06 \x01(\x05R\amaxconn\x12\x18\n" +
+	"\aminconn\x18\a \x01(\x05R\aminconn\x12\x1a\n" +
+	"\bmaxqueue\x18\b \x01(\x05R\bmaxqueue\x12?\n" +
+	"\n" +
+	"send_proxy\x18\t \x01(\x0e2 .haproxy.v1.ProxyProtocolVersionR\tsendProxy\x12\x1b\n" +
+	"\x06weight\x18\n" +
+	" \x01(\x05H\x00R\x06weight\x88\x01\x01\x12 \n" +
+	"\vmaintenance\x18\v \x01(\bR\vmaintenance\x126\n" +
+	"\x06labels\x18\f \x03(\v2\x1e.haproxy.v1.Server.LabelsEntryR\x06labels\x12E\n" +
+	"\vannotations\x18\r \x03(\v2#.haproxy.v1.Server.AnnotationsEntryR\vannotations\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_weight\"\x8b\x01\n" +
 	"\x13CreateServerRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
 	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12*\n" +
 	"\x06server\x18\x03 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"B\n" +
 	"\x14CreateServerResponse\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"p\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"\x8b\x01\n" +
 	"\x10GetServerRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
 	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"?\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x19\n" +
+	"\bno_cache\x18\x04 \x01(\bR\anoCache\"?\n" +
 	"\x11GetServerResponse\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"^\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"\xc4\x01\n" +
 	"\x12ListServersRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
-	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\"C\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12.\n" +
+	"\x06filter\x18\x03 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x19\n" +
+	"\bno_cache\x18\x05 \x01(\bR\anoCache\"C\n" +
 	"\x13ListServersResponse\x12,\n" +
-	"\aservers\x18\x01 \x03(\v2\x12.haproxy.v1.ServerR\aservers\"\x9f\x01\n" +
+	"\aservers\x18\x01 \x03(\v2\x12.haproxy.v1.ServerR\aservers\"\xca\x01\n" +
 	"\x13UpdateServerRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
 	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12*\n" +
-	"\x06server\x18\x04 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"B\n" +
+	"\x06server\x18\x04 \x01(\v2\x12.haproxy.v1.ServerR\x06server\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\tR\x0fexpectedVersion\"B\n" +
 	"\x14UpdateServerResponse\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"s\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"\x9e\x01\n" +
 	"\x13DeleteServerRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
 	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"\x16\n" +
-	"\x14DeleteServerResponseB9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\tR\x0fexpectedVersion\"\x16\n" +
+	"\x14DeleteServerResponse\"\x8a\x01\n" +
+	"\x12ApplyServerRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12*\n" +
+	"\x06server\x18\x03 \x01(\v2\x12.haproxy.v1.ServerR\x06server\"u\n" +
+	"\x13ApplyServerResponse\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreated\"\x8e\x01\n" +
+	"\x14CreateServersRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12,\n" +
+	"\aservers\x18\x03 \x03(\v2\x12.haproxy.v1.ServerR\aservers\"E\n" +
+	"\x15CreateServersResponse\x12,\n" +
+	"\aservers\x18\x01 \x03(\v2\x12.haproxy.v1.ServerR\aservers\"v\n" +
+	"\x14DeleteServersRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12\x14\n" +
+	"\x05names\x18\x03 \x03(\tR\x05names\"\x17\n" +
+	"\x15DeleteServersResponse\"\x90\x01\n" +
+	"\x14StreamServersRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fbackend_name\x18\x02 \x01(\tR\vbackendName\x12.\n" +
+	"\x06filter\x18\x03 \x01(\v2\x16.haproxy.v1.ListFilterR\x06filter\"C\n" +
+	"\x15StreamServersResponse\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.haproxy.v1.ServerR\x06server*|\n" +
+	"\x14ProxyProtocolVersion\x12&\n" +
+	"\"PROXY_PROTOCOL_VERSION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PROXY_PROTOCOL_VERSION_V1\x10\x01\x12\x1d\n" +
+	"\x19PROXY_PROTOCOL_VERSION_V2\x10\x02B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
 
 var (
 	file_server_proto_rawDescOnce sync.Once
@@ -655,32 +1307,55 @@ func file_server_proto_rawDescGZIP() []byte {
 	return file_server_proto_rawDescData
 }
 
-var file_server_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_server_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_server_proto_goTypes = []any{
-	(*Server)(nil),               // 0: haproxy.v1.Server
-	(*CreateServerRequest)(nil),  // 1: haproxy.v1.CreateServerRequest
-	(*CreateServerResponse)(nil), // 2: haproxy.v1.CreateServerResponse
-	(*GetServerRequest)(nil),     // 3: haproxy.v1.GetServerRequest
-	(*GetServerResponse)(nil),    // 4: haproxy.v1.GetServerResponse
-	(*ListServersRequest)(nil),   // 5: haproxy.v1.ListServersRequest
-	(*ListServersResponse)(nil),  // 6: haproxy.v1.ListServersResponse
-	(*UpdateServerRequest)(nil),  // 7: haproxy.v1.UpdateServerRequest
-	(*UpdateServerResponse)(nil), // 8: haproxy.v1.UpdateServerResponse
-	(*DeleteServerRequest)(nil),  // 9: haproxy.v1.DeleteServerRequest
-	(*DeleteServerResponse)(nil), // 10: haproxy.v1.DeleteServerResponse
+	(ProxyProtocolVersion)(0),     // 0: haproxy.v1.ProxyProtocolVersion
+	(*Server)(nil),                // 1: haproxy.v1.Server
+	(*CreateServerRequest)(nil),   // 2: haproxy.v1.CreateServerRequest
+	(*CreateServerResponse)(nil),  // 3: haproxy.v1.CreateServerResponse
+	(*GetServerRequest)(nil),      // 4: haproxy.v1.GetServerRequest
+	(*GetServerResponse)(nil),     // 5: haproxy.v1.GetServerResponse
+	(*ListServersRequest)(nil),    // 6: haproxy.v1.ListServersRequest
+	(*ListServersResponse)(nil),   // 7: haproxy.v1.ListServersResponse
+	(*UpdateServerRequest)(nil),   // 8: haproxy.v1.UpdateServerRequest
+	(*UpdateServerResponse)(nil),  // 9: haproxy.v1.UpdateServerResponse
+	(*DeleteServerRequest)(nil),   // 10: haproxy.v1.DeleteServerRequest
+	(*DeleteServerResponse)(nil),  // 11: haproxy.v1.DeleteServerResponse
+	(*ApplyServerRequest)(nil),    // 12: haproxy.v1.ApplyServerRequest
+	(*ApplyServerResponse)(nil),   // 13: haproxy.v1.ApplyServerResponse
+	(*CreateServersRequest)(nil),  // 14: haproxy.v1.CreateServersRequest
+	(*CreateServersResponse)(nil), // 15: haproxy.v1.CreateServersResponse
+	(*DeleteServersRequest)(nil),  // 16: haproxy.v1.DeleteServersRequest
+	(*DeleteServersResponse)(nil), // 17: haproxy.v1.DeleteServersResponse
+	(*StreamServersRequest)(nil),  // 18: haproxy.v1.StreamServersRequest
+	(*StreamServersResponse)(nil), // 19: haproxy.v1.StreamServersResponse
+	nil,                           // 20: haproxy.v1.Server.LabelsEntry
+	nil,                           // 21: haproxy.v1.Server.AnnotationsEntry
+	(*ListFilter)(nil),            // 22: haproxy.v1.ListFilter
 }
 var file_server_proto_depIdxs = []int32{
-	0, // 0: haproxy.v1.CreateServerRequest.server:type_name -> haproxy.v1.Server
-	0, // 1: haproxy.v1.CreateServerResponse.server:type_name -> haproxy.v1.Server
-	0, // 2: haproxy.v1.GetServerResponse.server:type_name -> haproxy.v1.Server
-	0, // 3: haproxy.v1.ListServersResponse.servers:type_name -> haproxy.v1.Server
-	0, // 4: haproxy.v1.UpdateServerRequest.server:type_name -> haproxy.v1.Server
-	0, // 5: haproxy.v1.UpdateServerResponse.server:type_name -> haproxy.v1.Server
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	0,  // 0: haproxy.v1.Server.send_proxy:type_name -> haproxy.v1.ProxyProtocolVersion
+	20, // 1: haproxy.v1.Server.labels:type_name -> haproxy.v1.Server.LabelsEntry
+	21, // 2: haproxy.v1.Server.annotations:type_name -> haproxy.v1.Server.AnnotationsEntry
+	1,  // 3: haproxy.v1.CreateServerRequest.server:type_name -> haproxy.v1.Server
+	1,  // 4: haproxy.v1.CreateServerResponse.server:type_name -> haproxy.v1.Server
+	1,  // 5: haproxy.v1.GetServerResponse.server:type_name -> haproxy.v1.Server
+	22, // 6: haproxy.v1.ListServersRequest.filter:type_name -> haproxy.v1.ListFilter
+	1,  // 7: haproxy.v1.ListServersResponse.servers:type_name -> haproxy.v1.Server
+	1,  // 8: haproxy.v1.UpdateServerRequest.server:type_name -> haproxy.v1.Server
+	1,  // 9: haproxy.v1.UpdateServerResponse.server:type_name -> haproxy.v1.Server
+	1,  // 10: haproxy.v1.ApplyServerRequest.server:type_name -> haproxy.v1.Server
+	1,  // 11: haproxy.v1.ApplyServerResponse.server:type_name -> haproxy.v1.Server
+	1,  // 12: haproxy.v1.CreateServersRequest.servers:type_name -> haproxy.v1.Server
+	1,  // 13: haproxy.v1.CreateServersResponse.servers:type_name -> haproxy.v1.Server
+	22, // 14: haproxy.v1.StreamServersRequest.filter:type_name -> haproxy.v1.ListFilter
+	1,  // 15: haproxy.v1.StreamServersResponse.server:type_name -> haproxy.v1.Server
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_server_proto_init() }
@@ -688,18 +1363,21 @@ func file_server_proto_init() {
 	if File_server_proto != nil {
 		return
 	}
+	file_common_proto_init()
+	file_server_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_server_proto_rawDesc), len(file_server_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_server_proto_goTypes,
 		DependencyIndexes: file_server_proto_depIdxs,
+		EnumInfos:         file_server_proto_enumTypes,
 		MessageInfos:      file_server_proto_msgTypes,
 	}.Build()
 	File_server_proto = out.File
@@ -0,0 +1,1173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: state.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StateFormat selects the encoding of a state document
+type StateFormat int32
+
+const (
+	StateFormat_STATE_FORMAT_UNSPECIFIED StateFormat = 0 // YAML on export; detected on import
+	StateFormat_STATE_FORMAT_YAML        StateFormat = 1
+	StateFormat_STATE_FORMAT_JSON        StateFormat = 2
+)
+
+// Enum value maps for StateFormat.
+var (
+	StateFormat_name = map[int32]string{
+		0: "STATE_FORMAT_UNSPECIFIED",
+		1: "STATE_FORMAT_YAML",
+		2: "STATE_FORMAT_JSON",
+	}
+	StateFormat_value = map[string]int32{
+		"STATE_FORMAT_UNSPECIFIED": 0,
+		"STATE_FORMAT_YAML":        1,
+		"STATE_FORMAT_JSON":        2,
+	}
+)
+
+func (x StateFormat) Enum() *StateFormat {
+	p := new(StateFormat)
+	*p = x
+	return p
+}
+
+func (x StateFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StateFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_state_proto_enumTypes[0].Descriptor()
+}
+
+func (StateFormat) Type() protoreflect.EnumType {
+	return &file_state_proto_enumTypes[0]
+}
+
+func (x StateFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StateFormat.Descriptor instead.
+func (StateFormat) EnumDescriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{0}
+}
+
+// State is a snapshot of all managed HAProxy configuration
+type State struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Frontends        []*FrontendState       `protobuf:"bytes,1,rep,name=frontends,proto3" json:"frontends,omitempty"`
+	Backends         []*BackendState        `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	TrackedAddresses map[string]string      `protobuf:"bytes,3,rep,name=tracked_addresses,json=trackedAddresses,proto3" json:"tracked_addresses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Netplan-managed VIP -> interface (informational, derived from binds on import)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_state_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *State) GetFrontends() []*FrontendState {
+	if x != nil {
+		return x.Frontends
+	}
+	return nil
+}
+
+func (x *State) GetBackends() []*BackendState {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+func (x *State) GetTrackedAddresses() map[string]string {
+	if x != nil {
+		return x.TrackedAddresses
+	}
+	return nil
+}
+
+// FrontendState is a frontend together with its binds
+type FrontendState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frontend      *Frontend              `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	Binds         []*Bind                `protobuf:"bytes,2,rep,name=binds,proto3" json:"binds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrontendState) Reset() {
+	*x = FrontendState{}
+	mi := &file_state_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrontendState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrontendState) ProtoMessage() {}
+
+func (x *FrontendState) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrontendState.ProtoReflect.Descriptor instead.
+func (*FrontendState) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FrontendState) GetFrontend() *Frontend {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+func (x *FrontendState) GetBinds() []*Bind {
+	if x != nil {
+		return x.Binds
+	}
+	return nil
+}
+
+// BackendState is a backend together with its servers
+type BackendState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backend       *Backend               `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	Servers       []*Server              `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackendState) Reset() {
+	*x = BackendState{}
+	mi := &file_state_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendState) ProtoMessage() {}
+
+func (x *BackendState) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendState.ProtoReflect.Descriptor instead.
+func (*BackendState) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BackendState) GetBackend() *Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+func (x *BackendState) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+// ExportStateRequest exports the live (or in-transaction) configuration
+type ExportStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Export the state as seen inside this transaction (optional)
+	Format        StateFormat            `protobuf:"varint,2,opt,name=format,proto3,enum=haproxy.v1.StateFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStateRequest) Reset() {
+	*x = ExportStateRequest{}
+	mi := &file_state_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateRequest) ProtoMessage() {}
+
+func (x *ExportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateRequest.ProtoReflect.Descriptor instead.
+func (*ExportStateRequest) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportStateRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ExportStateRequest) GetFormat() StateFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StateFormat_STATE_FORMAT_UNSPECIFIED
+}
+
+type ExportStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *State                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"` // The state encoded in the requested format
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`  // Configuration version the state was read at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStateResponse) Reset() {
+	*x = ExportStateResponse{}
+	mi := &file_state_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateResponse) ProtoMessage() {}
+
+func (x *ExportStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateResponse.ProtoReflect.Descriptor instead.
+func (*ExportStateResponse) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportStateResponse) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ExportStateResponse) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ExportStateResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// ImportStateRequest applies a state document inside a single transaction and commits it
+// Either document or state must be set
+type ImportStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      string                 `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Format        StateFormat            `protobuf:"varint,2,opt,name=format,proto3,enum=haproxy.v1.StateFormat" json:"format,omitempty"` // Format of document
+	State         *State                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Prune         bool                   `protobuf:"varint,4,opt,name=prune,proto3" json:"prune,omitempty"`                 // Delete frontends, binds, backends and servers that are not in the document
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Only plan the changes; no transaction is created and nothing is written
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStateRequest) Reset() {
+	*x = ImportStateRequest{}
+	mi := &file_state_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateRequest) ProtoMessage() {}
+
+func (x *ImportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateRequest.ProtoReflect.Descriptor instead.
+func (*ImportStateRequest) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportStateRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ImportStateRequest) GetFormat() StateFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StateFormat_STATE_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportStateRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ImportStateRequest) GetPrune() bool {
+	if x != nil {
+		return x.Prune
+	}
+	return false
+}
+
+func (x *ImportStateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"` // The committed transaction, unset if nothing had to change or on a dry run
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Deleted       int32                  `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Changes       []*StateChange         `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"` // Operations performed, or planned on a dry run, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStateResponse) Reset() {
+	*x = ImportStateResponse{}
+	mi := &file_state_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateResponse) ProtoMessage() {}
+
+func (x *ImportStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateResponse.ProtoReflect.Descriptor instead.
+func (*ImportStateResponse) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportStateResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *ImportStateResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetChanges() []*StateChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// StateChange is a single add/update/delete operation performed to reach a desired state
+type StateChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResourceType  string                 `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"` // "backend", "frontend", "bind" or "server"
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                                 // "create", "update" or "delete"
+	ParentName    string                 `protobuf:"bytes,3,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`       // Frontend of a bind or backend of a server
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateChange) Reset() {
+	*x = StateChange{}
+	mi := &file_state_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateChange) ProtoMessage() {}
+
+func (x *StateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateChange.ProtoReflect.Descriptor instead.
+func (*StateChange) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StateChange) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *StateChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *StateChange) GetParentName() string {
+	if x != nil {
+		return x.ParentName
+	}
+	return ""
+}
+
+func (x *StateChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ApplyDesiredStateRequest carries the complete desired configuration
+// Everything not in it is deleted; applying the same state twice is a no-op
+// Either document or state must be set
+type ApplyDesiredStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *State                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	Format        StateFormat            `protobuf:"varint,3,opt,name=format,proto3,enum=haproxy.v1.StateFormat" json:"format,omitempty"` // Format of document
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                           // Expected configuration version; the apply fails if the configuration changed since (optional)
+	NamePrefix    string                 `protobuf:"bytes,5,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`    // Only manage frontends and backends whose names start with this prefix; others are left alone (optional)
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`               // Only plan the changes; no transaction is created and nothing is written
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyDesiredStateRequest) Reset() {
+	*x = ApplyDesiredStateRequest{}
+	mi := &file_state_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyDesiredStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyDesiredStateRequest) ProtoMessage() {}
+
+func (x *ApplyDesiredStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyDesiredStateRequest.ProtoReflect.Descriptor instead.
+func (*ApplyDesiredStateRequest) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApplyDesiredStateRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ApplyDesiredStateRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ApplyDesiredStateRequest) GetFormat() StateFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StateFormat_STATE_FORMAT_UNSPECIFIED
+}
+
+func (x *ApplyDesiredStateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ApplyDesiredStateRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ApplyDesiredStateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ApplyDesiredStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"` // The committed transaction, unset if the live configuration already matched or on a dry run
+	Changes       []*StateChange         `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`         // Operations performed, or planned on a dry run, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyDesiredStateResponse) Reset() {
+	*x = ApplyDesiredStateResponse{}
+	mi := &file_state_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyDesiredStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyDesiredStateResponse) ProtoMessage() {}
+
+func (x *ApplyDesiredStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyDesiredStateResponse.ProtoReflect.Descriptor instead.
+func (*ApplyDesiredStateResponse) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApplyDesiredStateResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *ApplyDesiredStateResponse) GetChanges() []*StateChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// RenderPreviewRequest renders the frontend and backend sections of haproxy.cfg as they will be after committing
+// a transaction or applying a state document, without changing anything
+// Exactly one of transaction_id, document and state must be set
+type RenderPreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Render the configuration as seen inside this transaction
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	Format        StateFormat            `protobuf:"varint,3,opt,name=format,proto3,enum=haproxy.v1.StateFormat" json:"format,omitempty"` // Format of document
+	State         *State                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderPreviewRequest) Reset() {
+	*x = RenderPreviewRequest{}
+	mi := &file_state_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPreviewRequest) ProtoMessage() {}
+
+func (x *RenderPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPreviewRequest.ProtoReflect.Descriptor instead.
+func (*RenderPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RenderPreviewRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RenderPreviewRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *RenderPreviewRequest) GetFormat() StateFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StateFormat_STATE_FORMAT_UNSPECIFIED
+}
+
+func (x *RenderPreviewRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type RenderPreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Configuration string                 `protobuf:"bytes,1,opt,name=configuration,proto3" json:"configuration,omitempty"` // All sections, frontends first
+	Sections      []*RenderedSection     `protobuf:"bytes,2,rep,name=sections,proto3" json:"sections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderPreviewResponse) Reset() {
+	*x = RenderPreviewResponse{}
+	mi := &file_state_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPreviewResponse) ProtoMessage() {}
+
+func (x *RenderPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPreviewResponse.ProtoReflect.Descriptor instead.
+func (*RenderPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RenderPreviewResponse) GetConfiguration() string {
+	if x != nil {
+		return x.Configuration
+	}
+	return ""
+}
+
+func (x *RenderPreviewResponse) GetSections() []*RenderedSection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+// RenderedSection is one frontend or backend section in haproxy.cfg syntax
+type RenderedSection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "frontend" or "backend"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderedSection) Reset() {
+	*x = RenderedSection{}
+	mi := &file_state_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderedSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderedSection) ProtoMessage() {}
+
+func (x *RenderedSection) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderedSection.ProtoReflect.Descriptor instead.
+func (*RenderedSection) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RenderedSection) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RenderedSection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenderedSection) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// DiffTransactionRequest compares the configuration inside a transaction with the live configuration
+type DiffTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffTransactionRequest) Reset() {
+	*x = DiffTransactionRequest{}
+	mi := &file_state_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffTransactionRequest) ProtoMessage() {}
+
+func (x *DiffTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffTransactionRequest.ProtoReflect.Descriptor instead.
+func (*DiffTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DiffTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type DiffTransactionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Changes        []*StateChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`                                     // Operations committing the transaction performs
+	NetplanChanges []*NetplanChange       `protobuf:"bytes,2,rep,name=netplan_changes,json=netplanChanges,proto3" json:"netplan_changes,omitempty"` // Pending Netplan changes of the transaction, in order
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiffTransactionResponse) Reset() {
+	*x = DiffTransactionResponse{}
+	mi := &file_state_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffTransactionResponse) ProtoMessage() {}
+
+func (x *DiffTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffTransactionResponse.ProtoReflect.Descriptor instead.
+func (*DiffTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_state_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DiffTransactionResponse) GetChanges() []*StateChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *DiffTransactionResponse) GetNetplanChanges() []*NetplanChange {
+	if x != nil {
+		return x.NetplanChanges
+	}
+	return nil
+}
+
+var File_state_proto protoreflect.FileDescriptor
+
+const file_state_proto_rawDesc = "" +
+	"\n" +
+	"\vstate.proto\x12\n" +
+	"haproxy.v1\x1a\rbackend.proto\x1a\n" +
+	"bind.proto\x1a\x0efrontend.proto\x1a\rnetplan.proto\x1a\fserver.proto\x1a\x11transaction.proto\"\x91\x02\n" +
+	"\x05State\x127\n" +
+	"\tfrontends\x18\x01 \x03(\v2\x19.haproxy.v1.FrontendStateR\tfrontends\x124\n" +
+	"\bbackends\x18\x02 \x03(\v2\x18.haproxy.v1.BackendStateR\bbackends\x12T\n" +
+	"\x11tracked_addresses\x18\x03 \x03(\v2'.haproxy.v1.State.TrackedAddressesEntryR\x10trackedAddresses\x1aC\n" +
+	"\x15TrackedAddressesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
+	"\rFrontendState\x120\n" +
+	"\bfrontend\x18\x01 \x01(\v2\x14.haproxy.v1.FrontendR\bfrontend\x12&\n" +
+	"\x05binds\x18\x02 \x03(\v2\x10.haproxy.v1.BindR\x05binds\"k\n" +
+	"\fBackendState\x12-\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.haproxy.v1.BackendR\abackend\x12,\n" +
+	"\aservers\x18\x02 \x03(\v2\x12.haproxy.v1.ServerR\aservers\"l\n" +
+	"\x12ExportStateRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12/\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x17.haproxy.v1.StateFormatR\x06format\"t\n" +
+	"\x13ExportStateResponse\x12'\n" +
+	"\x05state\x18\x01 \x01(\v2\x11.haproxy.v1.StateR\x05state\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"\xb9\x01\n" +
+	"\x12ImportStateRequest\x12\x1a\n" +
+	"\bdocument\x18\x01 \x01(\tR\bdocument\x12/\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x17.haproxy.v1.StateFormatR\x06format\x12'\n" +
+	"\x05state\x18\x03 \x01(\v2\x11.haproxy.v1.StateR\x05state\x12\x14\n" +
+	"\x05prune\x18\x04 \x01(\bR\x05prune\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"\xd1\x01\n" +
+	"\x13ImportStateResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\x05R\adeleted\x121\n" +
+	"\achanges\x18\x05 \x03(\v2\x17.haproxy.v1.StateChangeR\achanges\"\x7f\n" +
+	"\vStateChange\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1f\n" +
+	"\vparent_name\x18\x03 \x01(\tR\n" +
+	"parentName\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\"\xe4\x01\n" +
+	"\x18ApplyDesiredStateRequest\x12'\n" +
+	"\x05state\x18\x01 \x01(\v2\x11.haproxy.v1.StateR\x05state\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12/\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x17.haproxy.v1.StateFormatR\x06format\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x1f\n" +
+	"\vname_prefix\x18\x05 \x01(\tR\n" +
+	"namePrefix\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\x89\x01\n" +
+	"\x19ApplyDesiredStateResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\x121\n" +
+	"\achanges\x18\x02 \x03(\v2\x17.haproxy.v1.StateChangeR\achanges\"\xb3\x01\n" +
+	"\x14RenderPreviewRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12/\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x17.haproxy.v1.StateFormatR\x06format\x12'\n" +
+	"\x05state\x18\x04 \x01(\v2\x11.haproxy.v1.StateR\x05state\"v\n" +
+	"\x15RenderPreviewResponse\x12$\n" +
+	"\rconfiguration\x18\x01 \x01(\tR\rconfiguration\x127\n" +
+	"\bsections\x18\x02 \x03(\v2\x1b.haproxy.v1.RenderedSectionR\bsections\"M\n" +
+	"\x0fRenderedSection\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"?\n" +
+	"\x16DiffTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\x90\x01\n" +
+	"\x17DiffTransactionResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.haproxy.v1.StateChangeR\achanges\x12B\n" +
+	"\x0fnetplan_changes\x18\x02 \x03(\v2\x19.haproxy.v1.NetplanChangeR\x0enetplanChanges*Y\n" +
+	"\vStateFormat\x12\x1c\n" +
+	"\x18STATE_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11STATE_FORMAT_YAML\x10\x01\x12\x15\n" +
+	"\x11STATE_FORMAT_JSON\x10\x02B9Z7github.com/bear-san/haproxy-configurator/pkg/haproxy/v1b\x06proto3"
+
+var (
+	file_state_proto_rawDescOnce sync.Once
+	file_state_proto_rawDescData []byte
+)
+
+func file_state_proto_rawDescGZIP() []byte {
+	file_state_proto_rawDescOnce.Do(func() {
+		file_state_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_state_proto_rawDesc), len(file_state_proto_rawDesc)))
+	})
+	return file_state_proto_rawDescData
+}
+
+var file_state_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_state_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_state_proto_goTypes = []any{
+	(StateFormat)(0),                  // 0: haproxy.v1.StateFormat
+	(*State)(nil),                     // 1: haproxy.v1.State
+	(*FrontendState)(nil),             // 2: haproxy.v1.FrontendState
+	(*BackendState)(nil),              // 3: haproxy.v1.BackendState
+	(*ExportStateRequest)(nil),        // 4: haproxy.v1.ExportStateRequest
+	(*ExportStateResponse)(nil),       // 5: haproxy.v1.ExportStateResponse
+	(*ImportStateRequest)(nil),        // 6: haproxy.v1.ImportStateRequest
+	(*ImportStateResponse)(nil),       // 7: haproxy.v1.ImportStateResponse
+	(*StateChange)(nil),               // 8: haproxy.v1.StateChange
+	(*ApplyDesiredStateRequest)(nil),  // 9: haproxy.v1.ApplyDesiredStateRequest
+	(*ApplyDesiredStateResponse)(nil), // 10: haproxy.v1.ApplyDesiredStateResponse
+	(*RenderPreviewRequest)(nil),      // 11: haproxy.v1.RenderPreviewRequest
+	(*RenderPreviewResponse)(nil),     // 12: haproxy.v1.RenderPreviewResponse
+	(*RenderedSection)(nil),           // 13: haproxy.v1.RenderedSection
+	(*DiffTransactionRequest)(nil),    // 14: haproxy.v1.DiffTransactionRequest
+	(*DiffTransactionResponse)(nil),   // 15: haproxy.v1.DiffTransactionResponse
+	nil,                               // 16: haproxy.v1.State.TrackedAddressesEntry
+	(*Frontend)(nil),                  // 17: haproxy.v1.Frontend
+	(*Bind)(nil),                      // 18: haproxy.v1.Bind
+	(*Backend)(nil),                   // 19: haproxy.v1.Backend
+	(*Server)(nil),                    // 20: haproxy.v1.Server
+	(*Transaction)(nil),               // 21: haproxy.v1.Transaction
+	(*NetplanChange)(nil),             // 22: haproxy.v1.NetplanChange
+}
+var file_state_proto_depIdxs = []int32{
+	2,  // 0: haproxy.v1.State.frontends:type_name -> haproxy.v1.FrontendState
+	3,  // 1: haproxy.v1.State.backends:type_name -> haproxy.v1.BackendState
+	16, // 2: haproxy.v1.State.tracked_addresses:type_name -> haproxy.v1.State.TrackedAddressesEntry
+	17, // 3: haproxy.v1.FrontendState.frontend:type_name -> haproxy.v1.Frontend
+	18, // 4: haproxy.v1.FrontendState.binds:type_name -> haproxy.v1.Bind
+	19, // 5: haproxy.v1.BackendState.backend:type_name -> haproxy.v1.Backend
+	20, // 6: haproxy.v1.BackendState.servers:type_name -> haproxy.v1.Server
+	0,  // 7: haproxy.v1.ExportStateRequest.format:type_name -> haproxy.v1.StateFormat
+	1,  // 8: haproxy.v1.ExportStateResponse.state:type_name -> haproxy.v1.State
+	0,  // 9: haproxy.v1.ImportStateRequest.format:type_name -> haproxy.v1.StateFormat
+	1,  // 10: haproxy.v1.ImportStateRequest.state:type_name -> haproxy.v1.State
+	21, // 11: haproxy.v1.ImportStateResponse.transaction:type_name -> haproxy.v1.Transaction
+	8,  // 12: haproxy.v1.ImportStateResponse.changes:type_name -> haproxy.v1.StateChange
+	1,  // 13: haproxy.v1.ApplyDesiredStateRequest.state:type_name -> haproxy.v1.State
+	0,  // 14: haproxy.v1.ApplyDesiredStateRequest.format:type_name -> haproxy.v1.StateFormat
+	21, // 15: haproxy.v1.ApplyDesiredStateResponse.transaction:type_name -> haproxy.v1.Transaction
+	8,  // 16: haproxy.v1.ApplyDesiredStateResponse.changes:type_name -> haproxy.v1.StateChange
+	0,  // 17: haproxy.v1.RenderPreviewRequest.format:type_name -> haproxy.v1.StateFormat
+	1,  // 18: haproxy.v1.RenderPreviewRequest.state:type_name -> haproxy.v1.State
+	13, // 19: haproxy.v1.RenderPreviewResponse.sections:type_name -> haproxy.v1.RenderedSection
+	8,  // 20: haproxy.v1.DiffTransactionResponse.changes:type_name -> haproxy.v1.StateChange
+	22, // 21: haproxy.v1.DiffTransactionResponse.netplan_changes:type_name -> haproxy.v1.NetplanChange
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_state_proto_init() }
+func file_state_proto_init() {
+	if File_state_proto != nil {
+		return
+	}
+	file_backend_proto_init()
+	file_bind_proto_init()
+	file_frontend_proto_init()
+	file_netplan_proto_init()
+	file_server_proto_init()
+	file_transaction_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_state_proto_rawDesc), len(file_state_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_state_proto_goTypes,
+		DependencyIndexes: file_state_proto_depIdxs,
+		EnumInfos:         file_state_proto_enumTypes,
+		MessageInfos:      file_state_proto_msgTypes,
+	}.Build()
+	File_state_proto = out.File
+	file_state_proto_goTypes = nil
+	file_state_proto_depIdxs = nil
+}
@@ -159,7 +159,7 @@ func (x *GetVersionResponse) GetVersion() int32 {
 // CreateTransactionRequest creates a new transaction
 type CreateTransactionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // Configuration version to base the transaction on; 0 uses the current version
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -336,6 +336,88 @@ func (x *GetTransactionResponse) GetTransaction() *Transaction {
 	return nil
 }
 
+// ListTransactionsRequest lists the transactions that have been neither committed nor closed
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_transaction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_proto_rawDescGZIP(), []int{7}
+}
+
+// ListTransactionsResponse contains the open transactions
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_transaction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
 // CommitTransactionRequest commits a transaction
 type CommitTransactionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -346,7 +428,7 @@ type CommitTransactionRequest struct {
 
 func (x *CommitTransactionRequest) Reset() {
 	*x = CommitTransactionRequest{}
-	mi := &file_transaction_proto_msgTypes[7]
+	mi := &file_transaction_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +440,7 @@ func (x *CommitTransactionRequest) String() string {
 func (*CommitTransactionRequest) ProtoMessage() {}
 
 func (x *CommitTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[7]
+	mi := &file_transaction_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +453,7 @@ func (x *CommitTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitTransactionRequest.ProtoReflect.Descriptor instead.
 func (*CommitTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{7}
+	return file_transaction_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CommitTransactionRequest) GetTransactionId() string {
@@ -383,15 +465,17 @@ func (x *CommitTransactionRequest) GetTransactionId() string {
 
 // CommitTransactionResponse contains the result of the commit operation
 type CommitTransactionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Transaction    *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Replicas       []*ReplicaStatus       `protobuf:"bytes,2,rep,name=replicas,proto3" json:"replicas,omitempty"`                                    // Replication to the cluster nodes, if the default instance is replicated
+	PartialFailure bool                   `protobuf:"varint,3,opt,name=partial_failure,json=partialFailure,proto3" json:"partial_failure,omitempty"` // Whether the transaction is committed but some nodes failed to sync
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CommitTransactionResponse) Reset() {
 	*x = CommitTransactionResponse{}
-	mi := &file_transaction_proto_msgTypes[8]
+	mi := &file_transaction_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +487,7 @@ func (x *CommitTransactionResponse) String() string {
 func (*CommitTransactionResponse) ProtoMessage() {}
 
 func (x *CommitTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[8]
+	mi := &file_transaction_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +500,7 @@ func (x *CommitTransactionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitTransactionResponse.ProtoReflect.Descriptor instead.
 func (*CommitTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{8}
+	return file_transaction_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CommitTransactionResponse) GetTransaction() *Transaction {
@@ -426,6 +510,20 @@ func (x *CommitTransactionResponse) GetTransaction() *Transaction {
 	return nil
 }
 
+func (x *CommitTransactionResponse) GetReplicas() []*ReplicaStatus {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *CommitTransactionResponse) GetPartialFailure() bool {
+	if x != nil {
+		return x.PartialFailure
+	}
+	return false
+}
+
 // CloseTransactionRequest closes/deletes a transaction
 type CloseTransactionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -436,7 +534,7 @@ type CloseTransactionRequest struct {
 
 func (x *CloseTransactionRequest) Reset() {
 	*x = CloseTransactionRequest{}
-	mi := &file_transaction_proto_msgTypes[9]
+	mi := &file_transaction_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -448,7 +546,7 @@ func (x *CloseTransactionRequest) String() string {
 func (*CloseTransactionRequest) ProtoMessage() {}
 
 func (x *CloseTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[9]
+	mi := &file_transaction_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +559,7 @@ func (x *CloseTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseTransactionRequest.ProtoReflect.Descriptor instead.
 func (*CloseTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{9}
+	return file_transaction_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CloseTransactionRequest) GetTransactionId() string {
@@ -481,7 +579,7 @@ type CloseTransactionResponse struct {
 
 func (x *CloseTransactionResponse) Reset() {
 	*x = CloseTransactionResponse{}
-	mi := &file_transaction_proto_msgTypes[10]
+	mi := &file_transaction_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -493,7 +591,7 @@ func (x *CloseTransactionResponse) String() string {
 func (*CloseTransactionResponse) ProtoMessage() {}
 
 func (x *CloseTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[10]
+	mi := &file_transaction_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +604,7 @@ func (x *CloseTransactionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseTransactionResponse.ProtoReflect.Descriptor instead.
 func (*CloseTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{10}
+	return file_transaction_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CloseTransactionResponse) GetMessage() string {
@@ -521,7 +619,7 @@ var File_transaction_proto protoreflect.FileDescriptor
 const file_transaction_proto_rawDesc = "" +
 	"\n" +
 	"\x11transaction.proto\x12\n" +
-	"haproxy.v1\"5\n" +
+	"haproxy.v1\x1a\rcluster.proto\"5\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\"\x13\n" +
@@ -535,11 +633,16 @@ const file_transaction_proto_rawDesc = "" +
 	"\x15GetTransactionRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"S\n" +
 	"\x16GetTransactionResponse\x129\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\"A\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\"\x19\n" +
+	"\x17ListTransactionsRequest\"W\n" +
+	"\x18ListTransactionsResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.haproxy.v1.TransactionR\ftransactions\"A\n" +
 	"\x18CommitTransactionRequest\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"V\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xb6\x01\n" +
 	"\x19CommitTransactionResponse\x129\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\"@\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.haproxy.v1.TransactionR\vtransaction\x125\n" +
+	"\breplicas\x18\x02 \x03(\v2\x19.haproxy.v1.ReplicaStatusR\breplicas\x12'\n" +
+	"\x0fpartial_failure\x18\x03 \x01(\bR\x0epartialFailure\"@\n" +
 	"\x17CloseTransactionRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"4\n" +
 	"\x18CloseTransactionResponse\x12\x18\n" +
@@ -557,7 +660,7 @@ func file_transaction_proto_rawDescGZIP() []byte {
 	return file_transaction_proto_rawDescData
 }
 
-var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_transaction_proto_goTypes = []any{
 	(*Transaction)(nil),               // 0: haproxy.v1.Transaction
 	(*GetVersionRequest)(nil),         // 1: haproxy.v1.GetVersionRequest
@@ -566,20 +669,25 @@ var file_transaction_proto_goTypes = []any{
 	(*CreateTransactionResponse)(nil), // 4: haproxy.v1.CreateTransactionResponse
 	(*GetTransactionRequest)(nil),     // 5: haproxy.v1.GetTransactionRequest
 	(*GetTransactionResponse)(nil),    // 6: haproxy.v1.GetTransactionResponse
-	(*CommitTransactionRequest)(nil),  // 7: haproxy.v1.CommitTransactionRequest
-	(*CommitTransactionResponse)(nil), // 8: haproxy.v1.CommitTransactionResponse
-	(*CloseTransactionRequest)(nil),   // 9: haproxy.v1.CloseTransactionRequest
-	(*CloseTransactionResponse)(nil),  // 10: haproxy.v1.CloseTransactionResponse
+	(*ListTransactionsRequest)(nil),   // 7: haproxy.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),  // 8: haproxy.v1.ListTransactionsResponse
+	(*CommitTransactionRequest)(nil),  // 9: haproxy.v1.CommitTransactionRequest
+	(*CommitTransactionResponse)(nil), // 10: haproxy.v1.CommitTransactionResponse
+	(*CloseTransactionRequest)(nil),   // 11: haproxy.v1.CloseTransactionRequest
+	(*CloseTransactionResponse)(nil),  // 12: haproxy.v1.CloseTransactionResponse
+	(*ReplicaStatus)(nil),             // 13: haproxy.v1.ReplicaStatus
 }
 var file_transaction_proto_depIdxs = []int32{
-	0, // 0: haproxy.v1.CreateTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
-	0, // 1: haproxy.v1.GetTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
-	0, // 2: haproxy.v1.CommitTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: haproxy.v1.CreateTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
+	0,  // 1: haproxy.v1.GetTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
+	0,  // 2: haproxy.v1.ListTransactionsResponse.transactions:type_name -> haproxy.v1.Transaction
+	0,  // 3: haproxy.v1.CommitTransactionResponse.transaction:type_name -> haproxy.v1.Transaction
+	13, // 4: haproxy.v1.CommitTransactionResponse.replicas:type_name -> haproxy.v1.ReplicaStatus
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_transaction_proto_init() }
@@ -587,13 +695,14 @@ func file_transaction_proto_init() {
 	if File_transaction_proto != nil {
 		return
 	}
+	file_cluster_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transaction_proto_rawDesc), len(file_transaction_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
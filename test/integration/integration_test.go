@@ -0,0 +1,429 @@
+//go:build integration
+
+// Package integration runs the gRPC service against HAProxy and the Data Plane API in Docker. The tests are only
+// built with the integration tag and are skipped when Docker is not available:
+//
+//	go test -tags integration ./test/integration/...
+//
+// HAPROXY_IMAGE selects another HAProxy image with the Data Plane API, e.g. haproxytech/haproxy-alpine:3.0.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bear-san/haproxy-configurator/internal/config"
+	"github.com/bear-san/haproxy-configurator/internal/logger"
+	"github.com/bear-san/haproxy-configurator/internal/server"
+	pb "github.com/bear-san/haproxy-configurator/pkg/haproxy/v1"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// defaultImage is the HAProxy image the tests run unless HAPROXY_IMAGE is set
+const defaultImage = "haproxytech/haproxy-debian:3.0"
+
+// haproxy is the container shared by the tests of the package; each test works on resources of its own
+var haproxy struct {
+	once      sync.Once
+	container testcontainers.Container
+	settings  config.HAProxySettings
+	err       error
+}
+
+func TestMain(m *testing.M) {
+	_ = logger.InitLogger(false)
+	code := m.Run()
+	if haproxy.container != nil {
+		_ = haproxy.container.Terminate(context.Background())
+	}
+	os.Exit(code)
+}
+
+// startHAProxy starts the shared HAProxy container on first use and returns the settings to reach its
+// Data Plane API
+func startHAProxy(t *testing.T) config.HAProxySettings {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	haproxy.once.Do(func() {
+		image := os.Getenv("HAPROXY_IMAGE")
+		if image == "" {
+			image = defaultImage
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        image,
+				ExposedPorts: []string{"5555/tcp"},
+				Files: []testcontainers.ContainerFile{
+					{HostFilePath: "testdata/haproxy.cfg", ContainerFilePath: "/usr/local/etc/haproxy/haproxy.cfg", FileMode: 0o644},
+					{HostFilePath: "testdata/dataplaneapi.yml", ContainerFilePath: "/usr/local/etc/haproxy/dataplaneapi.yml", FileMode: 0o644},
+				},
+				WaitingFor: wait.ForHTTP("/v3/info").WithPort("5555/tcp").WithBasicAuth("admin", "secret").
+					WithStartupTimeout(2 * time.Minute),
+			},
+			Started: true,
+		})
+		if container != nil {
+			haproxy.container = container
+		}
+		if err != nil {
+			haproxy.err = fmt.Errorf("failed to start %s: %w", image, err)
+			return
+		}
+
+		endpoint, err := container.PortEndpoint(ctx, "5555/tcp", "http")
+		if err != nil {
+			haproxy.err = fmt.Errorf("failed to get the Data Plane API endpoint: %w", err)
+			return
+		}
+		haproxy.settings = config.HAProxySettings{APIURL: endpoint, Username: "admin", Password: "secret"}
+	})
+	if haproxy.err != nil {
+		t.Fatal(haproxy.err)
+	}
+	return haproxy.settings
+}
+
+// startService runs the gRPC service against the HAProxy container and returns a function connecting
+// clients to it; each call opens a connection of its own
+func startService(t *testing.T) func() pb.HAProxyManagerServiceClient {
+	t.Helper()
+
+	cfg := &config.Config{HAProxy: startHAProxy(t)}
+	if err := cfg.ValidateConfig(); err != nil {
+		t.Fatalf("Invalid configuration: %v", err)
+	}
+	service := server.NewHAProxyManagerServerWithConfig(cfg)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(service.UnaryLoggingInterceptor(), service.UnaryLeaderInterceptor(),
+		service.UnaryFreezeInterceptor(), service.UnaryInstanceInterceptor(), service.UnaryIdempotencyInterceptor()),
+		grpc.ChainStreamInterceptor(service.StreamLoggingInterceptor(), service.StreamLeaderInterceptor(), service.StreamFreezeInterceptor()))
+	pb.RegisterHAProxyManagerServiceServer(grpcServer, service)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	return func() pb.HAProxyManagerServiceClient {
+		t.Helper()
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		return pb.NewHAProxyManagerServiceClient(conn)
+	}
+}
+
+// beginTransaction starts a transaction on the current version
+func beginTransaction(t *testing.T, client pb.HAProxyManagerServiceClient) string {
+	t.Helper()
+	transaction, err := client.CreateTransaction(context.Background(), &pb.CreateTransactionRequest{})
+	if err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+	return transaction.Transaction.Id
+}
+
+// commit commits a transaction
+func commit(t *testing.T, client pb.HAProxyManagerServiceClient, transactionID string) {
+	t.Helper()
+	if _, err := client.CommitTransaction(context.Background(), &pb.CommitTransactionRequest{TransactionId: transactionID}); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+}
+
+// waitFor polls condition until it holds or the test times out; HAProxy applies commits with a reload
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(30 * time.Second); !condition(); time.Sleep(200 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the condition")
+		}
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	client := startService(t)()
+	ctx := context.Background()
+
+	info, err := client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetServerInfo failed: %v", err)
+	}
+	if info.DataplaneApiVersion == "" || info.DataplaneApiError != "" {
+		t.Errorf("Expected the Data Plane API version, got %v", info)
+	}
+	if version, err := client.GetVersion(ctx, &pb.GetVersionRequest{}); err != nil || version.Version < 1 {
+		t.Errorf("Unexpected configuration version %v: %v", version, err)
+	}
+}
+
+func TestTransactionLifecycle(t *testing.T) {
+	client := startService(t)()
+	ctx := context.Background()
+
+	txn := beginTransaction(t, client)
+	if _, err := client.CreateBackend(ctx, &pb.CreateBackendRequest{TransactionId: txn, Backend: &pb.Backend{
+		Name: "lifecycle_app", Mode: pb.ProxyMode_PROXY_MODE_HTTP,
+		Balance: &pb.BackendBalance{Algorithm: pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN},
+	}}); err != nil {
+		t.Fatalf("CreateBackend failed: %v", err)
+	}
+	if _, err := client.CreateServers(ctx, &pb.CreateServersRequest{TransactionId: txn, BackendName: "lifecycle_app", Servers: []*pb.Server{
+		{Name: "app1", Address: "127.0.0.1", Port: 9001},
+		{Name: "app2", Address: "127.0.0.1", Port: 9002},
+	}}); err != nil {
+		t.Fatalf("CreateServers failed: %v", err)
+	}
+	if _, err := client.CreateFrontend(ctx, &pb.CreateFrontendRequest{TransactionId: txn,
+		Frontend: &pb.Frontend{Name: "lifecycle_www", Mode: pb.ProxyMode_PROXY_MODE_HTTP, DefaultBackend: "lifecycle_app"}}); err != nil {
+		t.Fatalf("CreateFrontend failed: %v", err)
+	}
+	if _, err := client.CreateBind(ctx, &pb.CreateBindRequest{TransactionId: txn, FrontendName: "lifecycle_www",
+		Bind: &pb.Bind{Name: "http", Address: "0.0.0.0", Port: 8081}}); err != nil {
+		t.Fatalf("CreateBind failed: %v", err)
+	}
+
+	if transaction, err := client.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: txn}); err != nil || transaction.Transaction.Status != "in_progress" {
+		t.Errorf("Expected the transaction to be in progress, got %v: %v", transaction, err)
+	}
+	listed, err := client.ListTransactions(ctx, &pb.ListTransactionsRequest{})
+	if err != nil {
+		t.Fatalf("ListTransactions failed: %v", err)
+	}
+	found := false
+	for _, transaction := range listed.Transactions {
+		found = found || transaction.Id == txn
+	}
+	if !found {
+		t.Errorf("Expected transaction %s to be listed, got %v", txn, listed.Transactions)
+	}
+	if diff, err := client.DiffTransaction(ctx, &pb.DiffTransactionRequest{TransactionId: txn}); err != nil || len(diff.Changes) != 5 {
+		t.Errorf("Expected 5 changes in the transaction, got %v: %v", diff, err)
+	}
+
+	// Nothing is visible before the commit
+	if _, err := client.GetBackend(ctx, &pb.GetBackendRequest{Name: "lifecycle_app"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for the uncommitted backend, got %v", err)
+	}
+	commit(t, client, txn)
+
+	backend, err := client.GetBackend(ctx, &pb.GetBackendRequest{Name: "lifecycle_app"})
+	if err != nil || backend.Backend.Balance.GetAlgorithm() != pb.BalanceAlgorithm_BALANCE_ALGORITHM_ROUNDROBIN {
+		t.Errorf("Unexpected committed backend %v: %v", backend, err)
+	}
+	if servers, err := client.ListServers(ctx, &pb.ListServersRequest{BackendName: "lifecycle_app"}); err != nil || len(servers.Servers) != 2 {
+		t.Errorf("Expected 2 servers, got %v: %v", servers, err)
+	}
+	if bind, err := client.GetBind(ctx, &pb.GetBindRequest{FrontendName: "lifecycle_www", Name: "http"}); err != nil || bind.Bind.Port != 8081 {
+		t.Errorf("Unexpected committed bind %v: %v", bind, err)
+	}
+	exported, err := client.ExportState(ctx, &pb.ExportStateRequest{})
+	if err != nil {
+		t.Fatalf("ExportState failed: %v", err)
+	}
+	exportedFrontend := false
+	for _, frontend := range exported.State.Frontends {
+		exportedFrontend = exportedFrontend || (frontend.Frontend.Name == "lifecycle_www" && len(frontend.Binds) == 1)
+	}
+	if !exportedFrontend {
+		t.Errorf("Expected lifecycle_www with its bind in the exported state, got %v", exported.State.Frontends)
+	}
+
+	// The running process picks up the committed configuration
+	waitFor(t, func() bool {
+		stats, err := client.GetStats(ctx, &pb.GetStatsRequest{})
+		if err != nil {
+			return false
+		}
+		for _, entry := range stats.Stats {
+			if entry.Type == "server" && entry.BackendName == "lifecycle_app" && entry.Name == "app1" {
+				return true
+			}
+		}
+		return false
+	})
+	state, err := client.SetServerState(ctx, &pb.SetServerStateRequest{BackendName: "lifecycle_app", Name: "app1",
+		AdminState: pb.ServerAdminState_SERVER_ADMIN_STATE_MAINT})
+	if err != nil || state.AdminState != pb.ServerAdminState_SERVER_ADMIN_STATE_MAINT {
+		t.Errorf("Expected app1 in maintenance, got %v: %v", state, err)
+	}
+
+	// Updates and deletes in a second transaction
+	txn = beginTransaction(t, client)
+	if _, err := client.UpdateServer(ctx, &pb.UpdateServerRequest{TransactionId: txn, BackendName: "lifecycle_app", Name: "app2",
+		Server: &pb.Server{Name: "app2", Address: "127.0.0.1", Port: 9003}}); err != nil {
+		t.Fatalf("UpdateServer failed: %v", err)
+	}
+	if _, err := client.DeleteServer(ctx, &pb.DeleteServerRequest{TransactionId: txn, BackendName: "lifecycle_app", Name: "app1"}); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	if _, err := client.UpdateBind(ctx, &pb.UpdateBindRequest{TransactionId: txn, FrontendName: "lifecycle_www",
+		Bind: &pb.Bind{Name: "http", Address: "0.0.0.0", Port: 8082}}); err != nil {
+		t.Fatalf("UpdateBind failed: %v", err)
+	}
+	commit(t, client, txn)
+
+	if server, err := client.GetServer(ctx, &pb.GetServerRequest{BackendName: "lifecycle_app", Name: "app2"}); err != nil || server.Server.Port != 9003 {
+		t.Errorf("Expected app2 on port 9003, got %v: %v", server, err)
+	}
+	if _, err := client.GetServer(ctx, &pb.GetServerRequest{BackendName: "lifecycle_app", Name: "app1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected app1 to be deleted, got %v", err)
+	}
+
+	// Closed transactions are discarded
+	txn = beginTransaction(t, client)
+	if _, err := client.DeleteFrontend(ctx, &pb.DeleteFrontendRequest{TransactionId: txn, Name: "lifecycle_www"}); err != nil {
+		t.Fatalf("DeleteFrontend failed: %v", err)
+	}
+	if _, err := client.CloseTransaction(ctx, &pb.CloseTransactionRequest{TransactionId: txn}); err != nil {
+		t.Fatalf("CloseTransaction failed: %v", err)
+	}
+	if _, err := client.GetFrontend(ctx, &pb.GetFrontendRequest{Name: "lifecycle_www"}); err != nil {
+		t.Errorf("Expected the frontend to remain after closing the transaction, got %v", err)
+	}
+
+	txn = beginTransaction(t, client)
+	if _, err := client.DeleteFrontend(ctx, &pb.DeleteFrontendRequest{TransactionId: txn, Name: "lifecycle_www"}); err != nil {
+		t.Fatalf("DeleteFrontend failed: %v", err)
+	}
+	if _, err := client.DeleteBackend(ctx, &pb.DeleteBackendRequest{TransactionId: txn, Name: "lifecycle_app"}); err != nil {
+		t.Fatalf("DeleteBackend failed: %v", err)
+	}
+	commit(t, client, txn)
+	if _, err := client.GetBackend(ctx, &pb.GetBackendRequest{Name: "lifecycle_app"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the backend to be deleted, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	client := startService(t)()
+	ctx := context.Background()
+
+	backend := &pb.Backend{Name: "apply_app", Mode: pb.ProxyMode_PROXY_MODE_TCP}
+	txn := beginTransaction(t, client)
+	if applied, err := client.ApplyBackend(ctx, &pb.ApplyBackendRequest{TransactionId: txn, Backend: backend}); err != nil || !applied.Created {
+		t.Fatalf("Expected ApplyBackend to create the backend, got %v: %v", applied, err)
+	}
+	if applied, err := client.ApplyServer(ctx, &pb.ApplyServerRequest{TransactionId: txn, BackendName: "apply_app",
+		Server: &pb.Server{Name: "app1", Address: "127.0.0.1", Port: 9001}}); err != nil || !applied.Created {
+		t.Fatalf("Expected ApplyServer to create the server, got %v: %v", applied, err)
+	}
+	commit(t, client, txn)
+
+	// Applying the same configuration again changes nothing
+	txn = beginTransaction(t, client)
+	if applied, err := client.ApplyBackend(ctx, &pb.ApplyBackendRequest{TransactionId: txn, Backend: backend}); err != nil || applied.Changed {
+		t.Errorf("Expected ApplyBackend to leave the backend unchanged, got %v: %v", applied, err)
+	}
+	if _, err := client.CloseTransaction(ctx, &pb.CloseTransactionRequest{TransactionId: txn}); err != nil {
+		t.Fatalf("CloseTransaction failed: %v", err)
+	}
+
+	desired := &pb.State{
+		Backends:  []*pb.BackendState{{Backend: &pb.Backend{Name: "desired_app"}, Servers: []*pb.Server{{Name: "app1", Address: "127.0.0.1", Port: 9001}}}},
+		Frontends: []*pb.FrontendState{{Frontend: &pb.Frontend{Name: "desired_www", DefaultBackend: "desired_app"}}},
+	}
+	applied, err := client.ApplyDesiredState(ctx, &pb.ApplyDesiredStateRequest{State: desired, NamePrefix: "desired_"})
+	if err != nil || len(applied.Changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %v: %v", applied, err)
+	}
+	if applied, err = client.ApplyDesiredState(ctx, &pb.ApplyDesiredStateRequest{State: desired, NamePrefix: "desired_"}); err != nil || len(applied.Changes) != 0 {
+		t.Errorf("Expected applying the same state again to be a no-op, got %v: %v", applied, err)
+	}
+}
+
+func TestConcurrentClients(t *testing.T) {
+	connect := startService(t)
+	ctx := context.Background()
+
+	const clients = 8
+	setup := connect()
+	txn := beginTransaction(t, setup)
+	if _, err := setup.CreateBackend(ctx, &pb.CreateBackendRequest{TransactionId: txn, Backend: &pb.Backend{Name: "concurrent_app"}}); err != nil {
+		t.Fatalf("CreateBackend failed: %v", err)
+	}
+	commit(t, setup, txn)
+
+	// Each client adds its server in a transaction of its own; commits based on an outdated version are
+	// retried on a new transaction
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+	for i := 1; i <= clients; i++ {
+		client := connect()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for attempt := 0; ; attempt++ {
+				transaction, err := client.CreateTransaction(ctx, &pb.CreateTransactionRequest{})
+				if err != nil {
+					errs <- err
+					return
+				}
+				id := transaction.Transaction.Id
+				if _, err := client.CreateServer(ctx, &pb.CreateServerRequest{TransactionId: id, BackendName: "concurrent_app",
+					Server: &pb.Server{Name: fmt.Sprintf("app%d", i), Address: "127.0.0.1", Port: int32(9000 + i)}}); err != nil {
+					errs <- err
+					return
+				}
+				_, err = client.CommitTransaction(ctx, &pb.CommitTransactionRequest{TransactionId: id})
+				if err == nil {
+					return
+				}
+				_, _ = client.CloseTransaction(ctx, &pb.CloseTransactionRequest{TransactionId: id})
+				if status.Code(err) != codes.AlreadyExists || attempt == 2*clients {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Concurrent client failed: %v", err)
+	}
+
+	listed, err := setup.ListServers(ctx, &pb.ListServersRequest{BackendName: "concurrent_app"})
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+	if len(listed.Servers) != clients {
+		t.Errorf("Expected %d servers, got %d", clients, len(listed.Servers))
+	}
+
+	// Clients sharing one transaction see each other's changes
+	txn = beginTransaction(t, setup)
+	for i := 1; i <= clients; i++ {
+		client := connect()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.DeleteServer(ctx, &pb.DeleteServerRequest{TransactionId: txn, BackendName: "concurrent_app",
+				Name: fmt.Sprintf("app%d", i)}); err != nil {
+				t.Errorf("DeleteServer failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	commit(t, setup, txn)
+	if listed, err := setup.ListServers(ctx, &pb.ListServersRequest{BackendName: "concurrent_app"}); err != nil || len(listed.Servers) != 0 {
+		t.Errorf("Expected all servers to be deleted, got %v: %v", listed, err)
+	}
+}